@@ -53,14 +53,33 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	restoreControlPlaneLoadBalancerStatus(&restored.Status.Network.SecondaryAPIServerELB, &dst.Status.Network.SecondaryAPIServerELB)
 
 	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	dst.Spec.AssociateOIDCProvider = restored.Spec.AssociateOIDCProvider
+	dst.Spec.SSMParameterPrefix = restored.Spec.SSMParameterPrefix
+	dst.Spec.ManagedComponents = restored.Spec.ManagedComponents
+	dst.Spec.ServiceEndpoints = restored.Spec.ServiceEndpoints
+	dst.Spec.InstanceProfiles = restored.Spec.InstanceProfiles
+	dst.Status.InstanceProfiles = restored.Status.InstanceProfiles
+	dst.Status.OIDCProvider = restored.Status.OIDCProvider
 	if restored.Status.Bastion != nil {
 		dst.Status.Bastion.InstanceMetadataOptions = restored.Status.Bastion.InstanceMetadataOptions
 		dst.Status.Bastion.PlacementGroupName = restored.Status.Bastion.PlacementGroupName
 		dst.Status.Bastion.PlacementGroupPartition = restored.Status.Bastion.PlacementGroupPartition
 		dst.Status.Bastion.PrivateDNSName = restored.Status.Bastion.PrivateDNSName
 		dst.Status.Bastion.PublicIPOnLaunch = restored.Status.Bastion.PublicIPOnLaunch
+		dst.Status.Bastion.IPv6Only = restored.Status.Bastion.IPv6Only
 	}
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.RequiredTags = restored.Spec.RequiredTags
+	dst.Spec.InstanceAccessMode = restored.Spec.InstanceAccessMode
+	dst.Spec.DefaultEBSEncryptionKeyARN = restored.Spec.DefaultEBSEncryptionKeyARN
+	dst.Spec.EBSEncryptionByDefault = restored.Spec.EBSEncryptionByDefault
+	dst.Spec.APIServerHealthCheck = restored.Spec.APIServerHealthCheck
+	dst.Status.APIServerHealthCheck = restored.Status.APIServerHealthCheck
+	dst.Spec.StandbyRegion = restored.Spec.StandbyRegion
+	dst.Spec.FailureDomainSelection = restored.Spec.FailureDomainSelection
+	dst.Status.StandbyNetwork = restored.Status.StandbyNetwork
+	dst.Status.LastReconciliation = restored.Status.LastReconciliation
+	dst.Status.Region = restored.Status.Region
 
 	for role, sg := range restored.Status.Network.SecurityGroups {
 		dst.Status.Network.SecurityGroups[role] = sg
@@ -104,8 +123,9 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.VPC.EmptyRoutesDefaultVPCSecurityGroup = restored.Spec.NetworkSpec.VPC.EmptyRoutesDefaultVPCSecurityGroup
 	dst.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch = restored.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch
 	dst.Spec.NetworkSpec.VPC.CarrierGatewayID = restored.Spec.NetworkSpec.VPC.CarrierGatewayID
+	dst.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = restored.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 
-	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, and SubnetSpec.ZoneType fields, if any.
+	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, SubnetSpec.ZoneType, SubnetSpec.Replaces, SubnetSpec.IPv6Native and SubnetSpec.EnableDNS64 fields, if any.
 	for _, subnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.ID == subnet.ID {
@@ -118,6 +138,9 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 				if subnet.ZoneType != nil {
 					dstSubnet.ZoneType = subnet.ZoneType
 				}
+				dstSubnet.Replaces = subnet.Replaces
+				dstSubnet.IPv6Native = subnet.IPv6Native
+				dstSubnet.EnableDNS64 = subnet.EnableDNS64
 				dstSubnet.DeepCopyInto(&dst.Spec.NetworkSpec.Subnets[i])
 			}
 		}
@@ -143,6 +166,7 @@ func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav2.LoadBalancer)
 	dst.Tags = restored.Tags
 	dst.ClassicELBListeners = restored.ClassicELBListeners
 	dst.AvailabilityZones = restored.AvailabilityZones
+	dst.IPAddressType = restored.IPAddressType
 }
 
 // restoreIPAMPool manually restores the ipam pool data.
@@ -157,17 +181,25 @@ func restoreIPAMPool(restored, dst *infrav2.IPAMPool) {
 // Assumes restored and dst are non-nil.
 func restoreControlPlaneLoadBalancer(restored, dst *infrav2.AWSLoadBalancerSpec) {
 	dst.Name = restored.Name
+	dst.ARN = restored.ARN
 	dst.HealthCheckProtocol = restored.HealthCheckProtocol
 	dst.HealthCheck = restored.HealthCheck
 	dst.LoadBalancerType = restored.LoadBalancerType
 	dst.DisableHostsRewrite = restored.DisableHostsRewrite
 	dst.PreserveClientIP = restored.PreserveClientIP
+	dst.TargetType = restored.TargetType
+	dst.DNSClientRoutingPolicy = restored.DNSClientRoutingPolicy
+	dst.AdditionalTargetGroupAttributes = restored.AdditionalTargetGroupAttributes
+	dst.ConnectionDrainingTimeout = restored.ConnectionDrainingTimeout
+	dst.AccessLogs = restored.AccessLogs
 	dst.IngressRules = restored.IngressRules
 	dst.AdditionalListeners = restored.AdditionalListeners
 	dst.AdditionalSecurityGroups = restored.AdditionalSecurityGroups
 	dst.Scheme = restored.Scheme
 	dst.CrossZoneLoadBalancing = restored.CrossZoneLoadBalancing
+	dst.DeletionProtection = restored.DeletionProtection
 	dst.Subnets = restored.Subnets
+	dst.IPAddressType = restored.IPAddressType
 }
 
 // ConvertFrom converts the v1beta1 AWSCluster receiver to a v1beta1 AWSCluster.
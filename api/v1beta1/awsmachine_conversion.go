@@ -41,6 +41,15 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.PlacementGroupPartition = restored.Spec.PlacementGroupPartition
 	dst.Spec.PrivateDNSName = restored.Spec.PrivateDNSName
 	dst.Spec.SecurityGroupOverrides = restored.Spec.SecurityGroupOverrides
+	dst.Spec.ImageLookupSSMParameter = restored.Spec.ImageLookupSSMParameter
+	dst.Spec.OSFamily = restored.Spec.OSFamily
+	dst.Spec.LoadBalancerAttachments = restored.Spec.LoadBalancerAttachments
+	dst.Spec.SubnetSelector = restored.Spec.SubnetSelector
+	dst.Spec.IPv6Only = restored.Spec.IPv6Only
+	dst.Status.ImageID = restored.Status.ImageID
+	dst.Status.CostEstimate = restored.Status.CostEstimate
+	dst.Status.LoadBalancerAttachments = restored.Status.LoadBalancerAttachments
+	dst.Status.LastReconciliation = restored.Status.LastReconciliation
 
 	return nil
 }
@@ -91,6 +100,11 @@ func (r *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.PlacementGroupPartition = restored.Spec.Template.Spec.PlacementGroupPartition
 	dst.Spec.Template.Spec.PrivateDNSName = restored.Spec.Template.Spec.PrivateDNSName
 	dst.Spec.Template.Spec.SecurityGroupOverrides = restored.Spec.Template.Spec.SecurityGroupOverrides
+	dst.Spec.Template.Spec.ImageLookupSSMParameter = restored.Spec.Template.Spec.ImageLookupSSMParameter
+	dst.Spec.Template.Spec.OSFamily = restored.Spec.Template.Spec.OSFamily
+	dst.Spec.Template.Spec.LoadBalancerAttachments = restored.Spec.Template.Spec.LoadBalancerAttachments
+	dst.Spec.Template.Spec.SubnetSelector = restored.Spec.Template.Spec.SubnetSelector
+	dst.Spec.Template.Spec.IPv6Only = restored.Spec.Template.Spec.IPv6Only
 
 	return nil
 }
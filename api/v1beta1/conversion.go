@@ -27,6 +27,18 @@ func Convert_v1beta2_AWSClusterSpec_To_v1beta1_AWSClusterSpec(in *v1beta2.AWSClu
 	return autoConvert_v1beta2_AWSClusterSpec_To_v1beta1_AWSClusterSpec(in, out, s)
 }
 
+func Convert_v1beta2_AWSClusterIdentitySpec_To_v1beta1_AWSClusterIdentitySpec(in *v1beta2.AWSClusterIdentitySpec, out *AWSClusterIdentitySpec, s conversion.Scope) error {
+	return autoConvert_v1beta2_AWSClusterIdentitySpec_To_v1beta1_AWSClusterIdentitySpec(in, out, s)
+}
+
+func Convert_v1beta2_AWSClusterRoleIdentitySpec_To_v1beta1_AWSClusterRoleIdentitySpec(in *v1beta2.AWSClusterRoleIdentitySpec, out *AWSClusterRoleIdentitySpec, s conversion.Scope) error {
+	return autoConvert_v1beta2_AWSClusterRoleIdentitySpec_To_v1beta1_AWSClusterRoleIdentitySpec(in, out, s)
+}
+
+func Convert_v1beta2_AWSClusterStatus_To_v1beta1_AWSClusterStatus(in *v1beta2.AWSClusterStatus, out *AWSClusterStatus, s conversion.Scope) error {
+	return autoConvert_v1beta2_AWSClusterStatus_To_v1beta1_AWSClusterStatus(in, out, s)
+}
+
 func Convert_v1beta1_AWSResourceReference_To_v1beta2_AWSResourceReference(in *AWSResourceReference, out *v1beta2.AWSResourceReference, s conversion.Scope) error {
 	return autoConvert_v1beta1_AWSResourceReference_To_v1beta2_AWSResourceReference(in, out, s)
 }
@@ -47,6 +59,10 @@ func Convert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AWSMac
 	return autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in, out, s)
 }
 
+func Convert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in *v1beta2.AWSMachineStatus, out *AWSMachineStatus, s conversion.Scope) error {
+	return autoConvert_v1beta2_AWSMachineStatus_To_v1beta1_AWSMachineStatus(in, out, s)
+}
+
 func Convert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out *Instance, s conversion.Scope) error {
 	return autoConvert_v1beta2_Instance_To_v1beta1_Instance(in, out, s)
 }
@@ -57,7 +73,9 @@ func Convert_v1beta1_ClassicELB_To_v1beta2_LoadBalancer(in *ClassicELB, out *v1b
 	out.Scheme = v1beta2.ELBScheme(in.Scheme)
 	out.HealthCheck = (*v1beta2.ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.ClassicElbAttributes = (v1beta2.ClassicELBAttributes)(in.Attributes)
+	if err := Convert_v1beta1_ClassicELBAttributes_To_v1beta2_ClassicELBAttributes(&in.Attributes, &out.ClassicElbAttributes, s); err != nil {
+		return err
+	}
 	out.ClassicELBListeners = *(*[]v1beta2.ClassicELBListener)(unsafe.Pointer(&in.Listeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -71,7 +89,9 @@ func Convert_v1beta2_LoadBalancer_To_v1beta1_ClassicELB(in *v1beta2.LoadBalancer
 	out.Scheme = ClassicELBScheme(in.Scheme)
 	out.HealthCheck = (*ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.Attributes = (ClassicELBAttributes)(in.ClassicElbAttributes)
+	if err := Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(&in.ClassicElbAttributes, &out.Attributes, s); err != nil {
+		return err
+	}
 	out.Listeners = *(*[]ClassicELBListener)(unsafe.Pointer(&in.ClassicELBListeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -79,6 +99,10 @@ func Convert_v1beta2_LoadBalancer_To_v1beta1_ClassicELB(in *v1beta2.LoadBalancer
 	return nil
 }
 
+func Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in *v1beta2.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
+	return autoConvert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in, out, s)
+}
+
 func Convert_v1beta2_IngressRule_To_v1beta1_IngressRule(in *v1beta2.IngressRule, out *IngressRule, s conversion.Scope) error {
 	return autoConvert_v1beta2_IngressRule_To_v1beta1_IngressRule(in, out, s)
 }
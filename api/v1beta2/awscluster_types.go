@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta2
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -39,7 +41,9 @@ type AWSClusterSpec struct {
 	// The AWS Region the cluster lives in.
 	Region string `json:"region,omitempty"`
 
-	// Partition is the AWS security partition being used. Defaults to "aws"
+	// Partition is the AWS security partition being used, e.g. "aws-us-gov" or "aws-cn",
+	// used to build the ARNs of the AWS resources. Defaults to the partition of the region,
+	// and should be set for the regions of partitions which aren't known yet.
 	// +optional
 	Partition string `json:"partition,omitempty"`
 
@@ -47,6 +51,15 @@ type AWSClusterSpec struct {
 	// +optional
 	SSHKeyName *string `json:"sshKeyName,omitempty"`
 
+	// InstanceAccessMode is how the instances of the cluster are accessed. With SSHKey, the default, the SSH key
+	// of sshKeyName, or the one of the machines, is attached to the instances. With SSM, no SSH key is attached to
+	// the instances, the bastion or the launch templates of the machine pools, whatever their sshKeyName, and the
+	// SSMConnectivity condition of the machines reports whether their instance registered with Systems Manager,
+	// which requires their instance profile to allow it.
+	// +kubebuilder:validation:Enum=SSHKey;SSM
+	// +optional
+	InstanceAccessMode InstanceAccessMode `json:"instanceAccessMode,omitempty"`
+
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
@@ -56,6 +69,13 @@ type AWSClusterSpec struct {
 	// +optional
 	AdditionalTags Tags `json:"additionalTags,omitempty"`
 
+	// RequiredTags are the keys of the tags, e.g. cost allocation tags, which all the AWS resources managed for the
+	// cluster and its machines must carry. When the additional tags of the AWSCluster, or merged with the ones of an
+	// AWSMachine, don't set all of them, the reconciliation fails before any resource is created, and the
+	// RequiredTagsReady condition is set to false.
+	// +optional
+	RequiredTags []string `json:"requiredTags,omitempty"`
+
 	// ControlPlaneLoadBalancer is optional configuration for customizing control plane behavior.
 	// +optional
 	ControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
@@ -68,6 +88,21 @@ type AWSClusterSpec struct {
 	// +optional
 	SecondaryControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"secondaryControlPlaneLoadBalancer,omitempty"`
 
+	// APIServerHealthCheck, when set, creates a Route 53 health check of the control plane endpoint, whose ID
+	// is reported in the status so that externally managed DNS failover records, e.g. of clusters spanning
+	// several regions, can reference it. The health check is deleted when this field is unset or when the
+	// cluster is deleted. The control plane endpoint must be reachable from the internet.
+	// +optional
+	APIServerHealthCheck *Route53HealthCheck `json:"apiServerHealthCheck,omitempty"`
+
+	// StandbyRegion, when set, pre-reconciles the network skeleton of the cluster, that is its VPC, its subnets
+	// and its security groups, in a second region, so that the cluster can be recreated there faster in case of
+	// a regional outage. The VPC and the subnets use the same CIDR blocks and tags as in the region of the cluster.
+	// Neither gateways nor route tables are created in the standby region. The network of the standby region is
+	// deleted when this field is unset or when the cluster is deleted. It requires the VPC to be managed.
+	// +optional
+	StandbyRegion *StandbyRegion `json:"standbyRegion,omitempty"`
+
 	// ImageLookupFormat is the AMI naming format to look up machine images when
 	// a machine does not specify an AMI. When set, this will be used for all
 	// cluster machines unless a machine specifies a different ImageLookupOrg.
@@ -95,6 +130,21 @@ type AWSClusterSpec struct {
 	// different ImageLookupBaseOS.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// DefaultEBSEncryptionKeyARN is the ARN of the customer managed KMS key used to encrypt the volumes of the
+	// cluster machines and of the launch templates of its machine pools which don't specify an encryption key,
+	// unless they explicitly disable encryption. The key policy must allow the Auto Scaling service-linked role to
+	// use the key, which is reported by the EBSEncryptionKeyReady condition.
+	// +optional
+	DefaultEBSEncryptionKeyARN string `json:"defaultEBSEncryptionKeyARN,omitempty"`
+
+	// EBSEncryptionByDefault is whether the EBS encryption by default setting of the account, in the region of the
+	// cluster, is checked before the AWS resources of the cluster are created. With Check, the resources aren't
+	// created until the setting is enabled. With Enable, the setting is enabled, which applies to all the volumes
+	// created afterwards in the region of the account. The result is reported by the EBSEncryptionByDefault condition.
+	// +kubebuilder:validation:Enum=Check;Enable
+	// +optional
+	EBSEncryptionByDefault EBSEncryptionByDefaultMode `json:"ebsEncryptionByDefault,omitempty"`
+
 	// Bastion contains options to configure the bastion host.
 	// +optional
 	Bastion Bastion `json:"bastion"`
@@ -111,8 +161,72 @@ type AWSClusterSpec struct {
 	// BootstrapFormatIgnition feature flag to be enabled).
 	// +optional
 	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
+
+	// SSMParameterPrefix, when set, stores the bootstrap data of the machines of this cluster using
+	// cloud-init as SecureString parameters of AWS Systems Manager Parameter Store named after this
+	// prefix, whatever their secure secrets backend is. The parameters are deleted once the instances
+	// have fetched them, so it can be used instead of S3Bucket where S3 buckets are prohibited.
+	// Instances must be allowed to get and delete the parameters under this prefix.
+	// +kubebuilder:validation:MaxLength:=512
+	// +kubebuilder:validation:Pattern=`^/?[a-zA-Z0-9_.\-]+(/[a-zA-Z0-9_.\-]+)*/?$`
+	// +optional
+	SSMParameterPrefix string `json:"ssmParameterPrefix,omitempty"`
+
+	// AssociateOIDCProvider can be enabled to publish the OIDC discovery document and the
+	// signing keys of the service account issuer in the S3 bucket of the cluster, and to
+	// create an IAM OIDC identity provider for it, so that service accounts can assume IAM
+	// roles (IRSA). It requires S3Bucket to be set. The issuer URL, reported in
+	// status.oidcProvider.issuerURL, must also be set in the service-account-issuer flag of
+	// kube-apiserver through the configuration of the control plane provider, as the
+	// controller doesn't configure kube-apiserver.
+	// +optional
+	AssociateOIDCProvider bool `json:"associateOIDCProvider,omitempty"`
+
+	// ManagedComponents lists the infrastructure components still reconciled by the controller
+	// when the AWSCluster is externally managed, that is annotated with cluster.x-k8s.io/managed-by.
+	// The other components, as well as the readiness and the failure domains of the AWSCluster,
+	// are left to the external manager. It is ignored when the AWSCluster is not externally managed.
+	// +listType=set
+	// +optional
+	ManagedComponents []AWSClusterComponent `json:"managedComponents,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints of the AWS services used to reconcile the cluster,
+	// e.g. with the URLs of VPC endpoints in air-gapped environments. It takes precedence over the
+	// service endpoints of the identity of the cluster and of the controllers.
+	// +optional
+	ServiceEndpoints ServiceEndpoints `json:"serviceEndpoints,omitempty"`
+
+	// InstanceProfiles configures the IAM instance profiles of the control plane and worker nodes
+	// created and managed by the controller for the cluster, instead of using pre-created ones.
+	// The AWSMachines without iamInstanceProfile use the managed instance profile of their role.
+	// +optional
+	InstanceProfiles *ManagedInstanceProfiles `json:"instanceProfiles,omitempty"`
+
+	// FailureDomainSelection restricts the availability zones reported as failure domains, across which
+	// Cluster API spreads the machines of the cluster. Only the availability zones with private subnets are
+	// reported in any case.
+	// +optional
+	FailureDomainSelection *FailureDomainSelection `json:"failureDomainSelection,omitempty"`
 }
 
+// AWSClusterComponent is an infrastructure component of an AWSCluster.
+// +kubebuilder:validation:Enum=Network;SecurityGroups;Bastion;LoadBalancer
+type AWSClusterComponent string
+
+const (
+	// AWSClusterComponentNetwork is the VPC of the cluster, with its subnets, gateways and route tables.
+	AWSClusterComponentNetwork = AWSClusterComponent("Network")
+
+	// AWSClusterComponentSecurityGroups is the security groups of the cluster.
+	AWSClusterComponentSecurityGroups = AWSClusterComponent("SecurityGroups")
+
+	// AWSClusterComponentBastion is the bastion host of the cluster.
+	AWSClusterComponentBastion = AWSClusterComponent("Bastion")
+
+	// AWSClusterComponentLoadBalancer is the load balancers of the control plane of the cluster.
+	AWSClusterComponentLoadBalancer = AWSClusterComponent("LoadBalancer")
+)
+
 // AWSIdentityKind defines allowed AWS identity types.
 type AWSIdentityKind string
 
@@ -125,6 +239,9 @@ var (
 
 	// ClusterStaticIdentityKind defines identity reference kind as AWSClusterStaticIdentity.
 	ClusterStaticIdentityKind = AWSIdentityKind("AWSClusterStaticIdentity")
+
+	// ClusterWebIdentityKind defines identity reference kind as AWSClusterWebIdentity.
+	ClusterWebIdentityKind = AWSIdentityKind("AWSClusterWebIdentity")
 )
 
 // AWSIdentityReference specifies a identity.
@@ -134,10 +251,30 @@ type AWSIdentityReference struct {
 	Name string `json:"name"`
 
 	// Kind of the identity.
-	// +kubebuilder:validation:Enum=AWSClusterControllerIdentity;AWSClusterRoleIdentity;AWSClusterStaticIdentity
+	// +kubebuilder:validation:Enum=AWSClusterControllerIdentity;AWSClusterRoleIdentity;AWSClusterStaticIdentity;AWSClusterWebIdentity
 	Kind AWSIdentityKind `json:"kind"`
 }
 
+// FailureDomainSelection restricts the availability zones an AWSCluster reports as failure domains.
+type FailureDomainSelection struct {
+	// AllowedAvailabilityZones lists the availability zones which can be reported as failure domains.
+	// All the availability zones can be when empty.
+	// +listType=set
+	// +optional
+	AllowedAvailabilityZones []string `json:"allowedAvailabilityZones,omitempty"`
+
+	// DeniedAvailabilityZones lists the availability zones which are never reported as failure domains.
+	// +listType=set
+	// +optional
+	DeniedAvailabilityZones []string `json:"deniedAvailabilityZones,omitempty"`
+
+	// InstanceTypes lists the instance types an availability zone must offer to be reported as a failure
+	// domain, e.g. the instance types of the control plane and worker machines of the cluster.
+	// +listType=set
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+}
+
 // Bastion defines a bastion host.
 type Bastion struct {
 	// Enabled allows this provider to create a bastion host instance
@@ -166,6 +303,26 @@ type Bastion struct {
 	AMI string `json:"ami,omitempty"`
 }
 
+// EBSEncryptionByDefaultMode defines how the EBS encryption by default setting of the account is reconciled.
+type EBSEncryptionByDefaultMode string
+
+const (
+	// EBSEncryptionByDefaultModeCheck waits for the EBS encryption by default to be enabled.
+	EBSEncryptionByDefaultModeCheck = EBSEncryptionByDefaultMode("Check")
+	// EBSEncryptionByDefaultModeEnable enables the EBS encryption by default.
+	EBSEncryptionByDefaultModeEnable = EBSEncryptionByDefaultMode("Enable")
+)
+
+// InstanceAccessMode defines how the instances of a cluster are accessed.
+type InstanceAccessMode string
+
+const (
+	// InstanceAccessModeSSHKey attaches SSH keys to the instances.
+	InstanceAccessModeSSHKey = InstanceAccessMode("SSHKey")
+	// InstanceAccessModeSSM doesn't attach any SSH key to the instances, which are accessed with Systems Manager.
+	InstanceAccessModeSSM = InstanceAccessMode("SSM")
+)
+
 // LoadBalancerType defines the type of load balancer to use.
 type LoadBalancerType string
 
@@ -182,6 +339,16 @@ var (
 	LoadBalancerTypeDisabled = LoadBalancerType("disabled")
 )
 
+// LoadBalancerIPAddressType defines the IP address type of a load balancer.
+type LoadBalancerIPAddressType string
+
+const (
+	// LoadBalancerIPAddressTypeIPv4 serves the clients over IPv4 only.
+	LoadBalancerIPAddressTypeIPv4 = LoadBalancerIPAddressType("ipv4")
+	// LoadBalancerIPAddressTypeDualStack serves the clients over both IPv4 and IPv6.
+	LoadBalancerIPAddressTypeDualStack = LoadBalancerIPAddressType("dualstack")
+)
+
 // AWSLoadBalancerSpec defines the desired state of an AWS load balancer.
 type AWSLoadBalancerSpec struct {
 	// Name sets the name of the classic ELB load balancer. As per AWS, the name must be unique
@@ -193,13 +360,22 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	Name *string `json:"name,omitempty"`
 
+	// ARN is the ARN of an existing network or application load balancer to use as the control plane
+	// load balancer, instead of creating one. CAPA registers the control plane instances with it and
+	// creates the listeners and the target groups it needs, but never modifies nor deletes the load
+	// balancer itself. The ports of the listeners must not already be used by the load balancer.
+	// Only supported for the primary control plane load balancer. Once set, the value cannot be changed.
+	// +optional
+	ARN *string `json:"arn,omitempty"`
+
 	// Scheme sets the scheme of the load balancer (defaults to internet-facing)
 	// +kubebuilder:default=internet-facing
 	// +kubebuilder:validation:Enum=internet-facing;internal
 	// +optional
 	Scheme *ELBScheme `json:"scheme,omitempty"`
 
-	// CrossZoneLoadBalancing enables the classic ELB cross availability zone balancing.
+	// CrossZoneLoadBalancing enables the cross availability zone balancing of the classic ELB
+	// or of the network load balancer.
 	//
 	// With cross-zone load balancing, each load balancer node for your Classic Load Balancer
 	// distributes requests evenly across the registered instances in all enabled Availability Zones.
@@ -210,6 +386,14 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing"`
 
+	// DeletionProtection prevents the load balancer from being deleted while it is in use.
+	// The deletion protection is removed by CAPA only when the cluster itself is deleted.
+	// Only supported for network, application and gateway load balancers created by CAPA.
+	//
+	// Defaults to false.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
 	// Subnets sets the subnets that should be applied to the control plane load balancer (defaults to discovered subnets for managed VPCs or an empty set for unmanaged VPCs)
 	// +optional
 	Subnets []string `json:"subnets,omitempty"`
@@ -252,6 +436,89 @@ type AWSLoadBalancerSpec struct {
 	// PreserveClientIP lets the user control if preservation of client ips must be retained or not.
 	// If this is enabled 6443 will be opened to 0.0.0.0/0.
 	PreserveClientIP bool `json:"preserveClientIP,omitempty"`
+
+	// TargetType sets how the control plane instances are registered with the target groups of the
+	// load balancer: by instance ID, or by the private IP address of their primary network interface.
+	// Only supported for network and application load balancers, and not supported with IPv6. The
+	// default is instance. Once set, the value cannot be changed.
+	// +kubebuilder:validation:Enum=instance;ip
+	// +optional
+	TargetType TargetType `json:"targetType,omitempty"`
+
+	// DNSClientRoutingPolicy sets how the clients resolving the DNS name of a network load balancer are
+	// routed to its availability zones, to keep the connections within the availability zone of the client.
+	// Not supported for existing load balancers. The routing policy is left untouched when not set.
+	// +kubebuilder:validation:Enum=any_availability_zone;availability_zone_affinity;partial_availability_zone_affinity
+	// +optional
+	DNSClientRoutingPolicy DNSClientRoutingPolicy `json:"dnsClientRoutingPolicy,omitempty"`
+
+	// AdditionalTargetGroupAttributes sets attributes of the target groups created for the listeners of
+	// the load balancer, for instance deregistration_delay.timeout_seconds or preserve_client_ip.enabled.
+	// These attributes take precedence over the ones set by CAPA, and are reconciled on the existing target groups.
+	// Only supported for network and application load balancers.
+	// +optional
+	AdditionalTargetGroupAttributes map[string]string `json:"additionalTargetGroupAttributes,omitempty"`
+
+	// ConnectionDrainingTimeout is the maximum time CAPA waits, after de-registering a deleted control plane
+	// instance from the target groups of the load balancer, for its connections to be drained before
+	// terminating it. The connections are drained during the deregistration delay of the target groups.
+	// The instances are terminated right after their deregistration when not set.
+	// Only supported for network and application load balancers.
+	// +optional
+	ConnectionDrainingTimeout *metav1.Duration `json:"connectionDrainingTimeout,omitempty"`
+
+	// AccessLogs configures the access logs of the load balancer, stored in an S3 bucket.
+	// The access logs are left untouched when not set.
+	// Not supported for gateway load balancers nor for existing load balancers.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
+
+	// IPAddressType sets the IP address type of the load balancer: ipv4, or dualstack to serve the API
+	// server over both IPv4 and IPv6. A dual-stack load balancer is only attached to subnets with an IPv6
+	// CIDR block, so dualstack requires IPv6 to be enabled on the VPC or subnets with an IPv6 CIDR block.
+	// Only supported for network and application load balancers, and not for existing load balancers.
+	// Defaults to dualstack for VPCs with IPv6 enabled, and to ipv4 otherwise. The IP address type of
+	// the load balancer is left untouched when not set.
+	// +kubebuilder:validation:Enum=ipv4;dualstack
+	// +optional
+	IPAddressType LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
+}
+
+// PreservesClientIP returns true if the target groups of the load balancer preserve the IP address of the
+// clients, either through PreserveClientIP or through the preserve_client_ip.enabled target group attribute.
+func (s *AWSLoadBalancerSpec) PreservesClientIP() bool {
+	if v, ok := s.AdditionalTargetGroupAttributes[TargetGroupAttributeEnablePreserveClientIP]; ok {
+		return strings.EqualFold(v, "true")
+	}
+	return s.PreserveClientIP
+}
+
+// IsIPTargetType returns true if the instances are registered with the target groups by IP address.
+func (s *AWSLoadBalancerSpec) IsIPTargetType() bool {
+	return s != nil && s.TargetType == TargetTypeIP
+}
+
+// IsDualStack returns true if the load balancer serves its clients over both IPv4 and IPv6.
+func (s *AWSLoadBalancerSpec) IsDualStack() bool {
+	return s != nil && s.IPAddressType == LoadBalancerIPAddressTypeDualStack
+}
+
+// LoadBalancerAccessLogs defines the access logs configuration of a load balancer.
+type LoadBalancerAccessLogs struct {
+	// Enabled enables the access logs of the load balancer.
+	Enabled bool `json:"enabled"`
+
+	// Bucket is the name of the S3 bucket the access logs are stored in. It must be in the region of
+	// the cluster, and its bucket policy must allow the load balancer to write the access logs.
+	// Required when the access logs are enabled.
+	// +kubebuilder:validation:MaxLength:=63
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+
+	// Prefix is the prefix of the access logs in the S3 bucket. It can't start or end with a slash,
+	// nor contain "AWSLogs". The access logs are stored at the root of the bucket when not set.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // AdditionalListenerSpec defines the desired state of an
@@ -281,6 +548,189 @@ type AWSClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Bastion        *Instance                `json:"bastion,omitempty"`
 	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
+
+	// OIDCProvider holds the status of the IAM OIDC identity provider of the cluster
+	// when AssociateOIDCProvider is enabled.
+	// +optional
+	OIDCProvider OIDCProviderStatus `json:"oidcProvider,omitempty"`
+
+	// InstanceProfiles holds the names of the IAM instance profiles managed for the cluster.
+	// +optional
+	InstanceProfiles *ManagedInstanceProfilesStatus `json:"instanceProfiles,omitempty"`
+
+	// APIServerHealthCheck holds the status of the Route 53 health check of the control plane endpoint
+	// when APIServerHealthCheck is set.
+	// +optional
+	APIServerHealthCheck *Route53HealthCheckStatus `json:"apiServerHealthCheck,omitempty"`
+
+	// StandbyNetwork holds the network resources reconciled in the standby region of the cluster.
+	// +optional
+	StandbyNetwork *StandbyNetworkStatus `json:"standbyNetwork,omitempty"`
+
+	// Region is the effective AWS Region of the cluster: the region of its spec or, when it is omitted, the
+	// default region of its identity, the default region of the controllers or the region of the instance they
+	// run on, in this order. It doesn't change once it is resolved.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// LastReconciliation records the last successful reconciliation of the AWS resources of the cluster, when the
+	// controller skips the reconciliations of ready clusters whose desired state is unchanged until its resync
+	// period elapses.
+	// +optional
+	LastReconciliation *LastReconciliation `json:"lastReconciliation,omitempty"`
+}
+
+// OIDCProviderStatus holds the status of the IAM OIDC identity provider of a cluster.
+type OIDCProviderStatus struct {
+	// ARN holds the ARN of the IAM OIDC identity provider.
+	ARN string `json:"arn,omitempty"`
+
+	// IssuerURL is the URL of the service account issuer. It has to be set as the
+	// service-account-issuer and, followed by /openid/v1/jwks, as the service-account-jwks-uri
+	// arguments of kube-apiserver.
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// TrustPolicy contains the boilerplate IAM trust policy to use for IRSA.
+	TrustPolicy string `json:"trustPolicy,omitempty"`
+}
+
+// ManagedInstanceProfiles defines the IAM instance profiles managed for the nodes of a cluster.
+type ManagedInstanceProfiles struct {
+	// ControlPlane is the template of the instance profile of the control plane nodes.
+	// +optional
+	ControlPlane *InstanceProfileTemplate `json:"controlPlane,omitempty"`
+
+	// Nodes is the template of the instance profile of the worker nodes.
+	// +optional
+	Nodes *InstanceProfileTemplate `json:"nodes,omitempty"`
+}
+
+// InstanceProfileTemplate is the template of an IAM instance profile managed for a cluster and of its role.
+type InstanceProfileTemplate struct {
+	// DisableDefaultPolicy disables the inline policy granting the permissions required by the AWS
+	// cloud provider and by the bootstrap of the nodes, as the policies generated by clusterawsadm do.
+	// +optional
+	DisableDefaultPolicy bool `json:"disableDefaultPolicy,omitempty"`
+
+	// ManagedPolicyARNs are the ARNs of the managed policies to attach to the role.
+	// +optional
+	ManagedPolicyARNs []string `json:"managedPolicyARNs,omitempty"`
+
+	// InlinePolicies are additional inline policies to embed in the role.
+	// +optional
+	InlinePolicies []InlinePolicy `json:"inlinePolicies,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the role and the instance profile, in
+	// addition to the ones added by default.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+}
+
+// InlinePolicy is a policy embedded in an IAM role.
+type InlinePolicy struct {
+	// Name is the name of the policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// PolicyDocument is the JSON policy document.
+	// +kubebuilder:validation:MinLength=1
+	PolicyDocument string `json:"policyDocument"`
+}
+
+// ManagedInstanceProfilesStatus holds the names of the IAM instance profiles managed for a cluster.
+type ManagedInstanceProfilesStatus struct {
+	// ControlPlane is the name of the instance profile of the control plane nodes.
+	// +optional
+	ControlPlane string `json:"controlPlane,omitempty"`
+
+	// Nodes is the name of the instance profile of the worker nodes.
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+}
+
+// Route53HealthCheck defines the Route 53 health check of the control plane endpoint of a cluster.
+type Route53HealthCheck struct {
+	// Protocol is the protocol used by the health checkers. With HTTPS, the /readyz path of the API server
+	// must answer with a 2xx or 3xx status code, without its certificate being validated, while with TCP, a
+	// connection must be established. It defaults to HTTPS and cannot be changed once set.
+	// +kubebuilder:validation:Enum=HTTPS;TCP
+	// +kubebuilder:default=HTTPS
+	// +optional
+	Protocol Route53HealthCheckProtocol `json:"protocol,omitempty"`
+
+	// RequestInterval is the number of seconds between two checks of each health checker, either 10 or 30.
+	// It defaults to 30 and cannot be changed once set.
+	// +kubebuilder:validation:Enum=10;30
+	// +optional
+	RequestInterval *int64 `json:"requestInterval,omitempty"`
+
+	// FailureThreshold is the number of consecutive checks which must fail or succeed for a health checker to
+	// change the status of the endpoint. It defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	FailureThreshold *int64 `json:"failureThreshold,omitempty"`
+
+	// Regions are the regions from which the health checkers check the endpoint, at least 3 of them.
+	// The health checkers of all the regions supported by Route 53 are used when unset.
+	// +listType=set
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+}
+
+// Route53HealthCheckProtocol is the protocol of a Route 53 health check.
+type Route53HealthCheckProtocol string
+
+const (
+	// Route53HealthCheckProtocolHTTPS checks the readiness endpoint of the API server over HTTPS.
+	Route53HealthCheckProtocolHTTPS = Route53HealthCheckProtocol("HTTPS")
+
+	// Route53HealthCheckProtocolTCP checks that a TCP connection can be established with the API server.
+	Route53HealthCheckProtocolTCP = Route53HealthCheckProtocol("TCP")
+)
+
+// Route53HealthCheckStatus holds the status of the Route 53 health check of the control plane endpoint.
+type Route53HealthCheckStatus struct {
+	// ID is the ID of the health check.
+	ID string `json:"id"`
+
+	// Healthy is whether more than 18% of the health checkers, the threshold used by Route 53, last reported
+	// the endpoint as healthy.
+	// +optional
+	Healthy bool `json:"healthy"`
+}
+
+// StandbyRegion defines the region in which the network skeleton of a cluster is pre-reconciled.
+type StandbyRegion struct {
+	// Name is the name of the standby region. It cannot be changed once set.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AvailabilityZones maps the availability zones of the subnets of the cluster to the ones of their copies
+	// in the standby region. An availability zone which isn't mapped is replaced by the one with the same
+	// letter in the standby region, e.g. us-east-1a by us-west-2a. The subnets of local and wavelength zones
+	// aren't copied.
+	// +optional
+	AvailabilityZones map[string]string `json:"availabilityZones,omitempty"`
+}
+
+// StandbyNetworkStatus holds the network resources reconciled in the standby region of a cluster.
+type StandbyNetworkStatus struct {
+	// Region is the standby region.
+	Region string `json:"region"`
+
+	// VPC is the VPC of the standby region.
+	// +optional
+	VPC VPCSpec `json:"vpc,omitempty"`
+
+	// Subnets are the subnets of the standby region.
+	// +optional
+	Subnets Subnets `json:"subnets,omitempty"`
+
+	// SecurityGroups are the security groups of the standby region, by role.
+	// +optional
+	SecurityGroups map[SecurityGroupRole]SecurityGroup `json:"securityGroups,omitempty"`
 }
 
 // S3Bucket defines a supporting S3 bucket for the cluster, currently can be optionally used for Ignition.
@@ -313,6 +763,20 @@ type S3Bucket struct {
 	// BestEffortDeleteObjects defines whether access/permission errors during object deletion should be ignored.
 	// +optional
 	BestEffortDeleteObjects *bool `json:"bestEffortDeleteObjects,omitempty"`
+
+	// KMSKeyARN is the ARN of a customer managed AWS KMS key used to encrypt the bootstrap data stored
+	// in the S3 Bucket, which is otherwise encrypted with the AWS managed key for S3.
+	//
+	// The controller must be allowed to generate data keys with it, and the IAM instance profiles
+	// reading bootstrap data to decrypt with it.
+	// +optional
+	KMSKeyARN string `json:"kmsKeyARN,omitempty"`
+
+	// BootstrapDataExpirationDays, when set, adds lifecycle rules to the S3 Bucket expiring the bootstrap
+	// data objects after this number of days, so that objects left behind by deleted machines are removed.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	BootstrapDataExpirationDays *int32 `json:"bootstrapDataExpirationDays,omitempty"`
 }
 
 // +kubebuilder:object:root=true
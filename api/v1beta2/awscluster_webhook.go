@@ -17,9 +17,11 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -55,12 +57,30 @@ func (r *AWSCluster) ValidateCreate() (admission.Warnings, error) {
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
+	allErrs = append(allErrs, r.validateInstanceAccessMode()...)
+	allErrs = append(allErrs, r.validateDefaultEBSEncryptionKeyARN()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, ValidateRequiredTags(r.Spec.RequiredTags, field.NewPath("spec", "requiredTags"))...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.validateOIDCProvider()...)
+	allErrs = append(allErrs, r.validateSSMParameterPrefix()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetReplacements(field.NewPath("spec", "network"))...)
 	allErrs = append(allErrs, r.validateControlPlaneLBs()...)
+	allErrs = append(allErrs, r.Spec.ServiceEndpoints.Validate()...)
+	allErrs = append(allErrs, r.Spec.InstanceProfiles.Validate()...)
+	allErrs = append(allErrs, r.Spec.FailureDomainSelection.Validate()...)
+	allErrs = append(allErrs, r.validateAPIServerHealthCheck()...)
+	allErrs = append(allErrs, r.validateStandbyRegion()...)
+
+	var warnings admission.Warnings
+	if regionValidator != nil && len(allErrs) == 0 {
+		regionWarnings, regionErrs := regionValidator.ValidateAWSCluster(context.Background(), r)
+		warnings = append(warnings, regionWarnings...)
+		allErrs = append(allErrs, regionErrs...)
+	}
 
-	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+	return warnings, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -86,18 +106,13 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	}
 
 	// Validate the control plane load balancers.
-	lbs := map[*AWSLoadBalancerSpec]*AWSLoadBalancerSpec{
-		oldC.Spec.ControlPlaneLoadBalancer:          r.Spec.ControlPlaneLoadBalancer,
-		oldC.Spec.SecondaryControlPlaneLoadBalancer: r.Spec.SecondaryControlPlaneLoadBalancer,
-	}
-
-	for oldLB, newLB := range lbs {
-		if oldLB == nil && newLB == nil {
-			continue
-		}
-
-		allErrs = append(allErrs, r.validateControlPlaneLoadBalancerUpdate(oldLB, newLB)...)
+	if oldC.Spec.ControlPlaneLoadBalancer != nil || r.Spec.ControlPlaneLoadBalancer != nil {
+		allErrs = append(allErrs, r.validateControlPlaneLoadBalancerUpdate(field.NewPath("spec", "controlPlaneLoadBalancer"),
+			oldC.Spec.ControlPlaneLoadBalancer, r.Spec.ControlPlaneLoadBalancer)...)
 	}
+	allErrs = append(allErrs, r.validateSecondaryControlPlaneLoadBalancerUpdate(oldC.Spec.SecondaryControlPlaneLoadBalancer,
+		r.Spec.SecondaryControlPlaneLoadBalancer)...)
+	allErrs = append(allErrs, r.validateControlPlaneLBs()...)
 
 	if !cmp.Equal(oldC.Spec.ControlPlaneEndpoint, clusterv1.APIEndpoint{}) &&
 		!cmp.Equal(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) {
@@ -135,20 +150,40 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	}
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.validateInstanceAccessMode()...)
+	allErrs = append(allErrs, r.validateDefaultEBSEncryptionKeyARN()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, ValidateRequiredTags(r.Spec.RequiredTags, field.NewPath("spec", "requiredTags"))...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.validateOIDCProvider()...)
+	allErrs = append(allErrs, r.validateSSMParameterPrefix()...)
+	allErrs = append(allErrs, r.Spec.ServiceEndpoints.Validate()...)
+	allErrs = append(allErrs, r.Spec.InstanceProfiles.Validate()...)
+	allErrs = append(allErrs, r.Spec.FailureDomainSelection.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetReplacements(field.NewPath("spec", "network"))...)
+	allErrs = append(allErrs, r.Spec.InstanceProfiles.ValidateUpdate(oldC.Spec.InstanceProfiles)...)
+	allErrs = append(allErrs, r.validateAPIServerHealthCheck()...)
+	allErrs = append(allErrs, r.Spec.APIServerHealthCheck.ValidateUpdate(oldC.Spec.APIServerHealthCheck)...)
+	allErrs = append(allErrs, r.validateStandbyRegion()...)
+
+	// The network of the standby region has to be deleted before another region can be used.
+	if oldC.Spec.StandbyRegion != nil && r.Spec.StandbyRegion != nil && oldC.Spec.StandbyRegion.Name != r.Spec.StandbyRegion.Name {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "standbyRegion", "name"), r.Spec.StandbyRegion.Name, "field is immutable"),
+		)
+	}
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
-func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoadBalancerSpec) field.ErrorList {
+func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(fldPath *field.Path, oldlb, newlb *AWSLoadBalancerSpec) field.ErrorList {
 	var allErrs field.ErrorList
 
 	if oldlb == nil {
 		// If old scheme was nil, the only value accepted here is the default value: internet-facing
 		if newlb.Scheme != nil && newlb.Scheme.String() != ELBSchemeInternetFacing.String() {
 			allErrs = append(allErrs,
-				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"),
+				field.Invalid(fldPath.Child("scheme"),
 					newlb.Scheme, "field is immutable, default value was set to internet-facing"),
 			)
 		}
@@ -159,14 +194,14 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 		if (oldlb.LoadBalancerType == LoadBalancerTypeDisabled && newlb.LoadBalancerType != LoadBalancerTypeDisabled) ||
 			(newlb.LoadBalancerType == LoadBalancerTypeDisabled && oldlb.LoadBalancerType != LoadBalancerTypeDisabled) {
 			allErrs = append(allErrs,
-				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "type"),
+				field.Invalid(fldPath.Child("type"),
 					newlb.Scheme, "field is immutable when created of disabled type"),
 			)
 		}
 		// If old scheme was not nil, the new scheme should be the same.
 		if !cmp.Equal(oldlb.Scheme, newlb.Scheme) {
 			allErrs = append(allErrs,
-				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"),
+				field.Invalid(fldPath.Child("scheme"),
 					newlb.Scheme, "field is immutable"),
 			)
 		}
@@ -175,18 +210,35 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 		// so the name remains nil. In either case, the name cannot be changed.
 		if !cmp.Equal(oldlb.Name, newlb.Name) {
 			allErrs = append(allErrs,
-				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"),
+				field.Invalid(fldPath.Child("name"),
 					newlb.Name, "field is immutable"),
 			)
 		}
+		if !cmp.Equal(oldlb.ARN, newlb.ARN) {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("arn"),
+					newlb.ARN, "field is immutable"),
+			)
+		}
+		// The targets of the existing target groups can't be registered differently.
+		if oldlb.IsIPTargetType() != newlb.IsIPTargetType() {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("targetType"),
+					newlb.TargetType, "field is immutable"),
+			)
+		}
 	}
 
 	// Block the update for Protocol :
 	// - if it was not set in old spec but added in new spec
 	// - if it was set in old spec but changed in new spec
-	if !cmp.Equal(newlb.HealthCheckProtocol, oldlb.HealthCheckProtocol) {
+	var oldHealthCheckProtocol *ELBProtocol
+	if oldlb != nil {
+		oldHealthCheckProtocol = oldlb.HealthCheckProtocol
+	}
+	if !cmp.Equal(newlb.HealthCheckProtocol, oldHealthCheckProtocol) {
 		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheckProtocol"),
+			field.Invalid(fldPath.Child("healthCheckProtocol"),
 				newlb.HealthCheckProtocol, "field is immutable once set"),
 		)
 	}
@@ -194,9 +246,32 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 	return allErrs
 }
 
+// validateSecondaryControlPlaneLoadBalancerUpdate validates the update of the secondary control plane load balancer.
+// It can be added to an existing cluster, in which case it is validated like on creation, but it can't be removed
+// as its load balancer would be left behind.
+func (r *AWSCluster) validateSecondaryControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoadBalancerSpec) field.ErrorList {
+	fldPath := field.NewPath("spec", "secondaryControlPlaneLoadBalancer")
+
+	switch {
+	case oldlb == nil:
+		return nil
+	case newlb == nil:
+		return field.ErrorList{field.Forbidden(fldPath, "secondary control plane load balancer cannot be removed")}
+	}
+
+	return r.validateControlPlaneLoadBalancerUpdate(fldPath, oldlb, newlb)
+}
+
 // Default satisfies the defaulting webhook interface.
 func (r *AWSCluster) Default() {
 	SetObjectDefaults_AWSCluster(r)
+	// The creation timestamp of the objects is only set once they are admitted.
+	if r.CreationTimestamp.IsZero() {
+		if config := getProviderConfig(context.Background()); config != nil {
+			config.defaultAWSCluster(r)
+		}
+	}
+	r.Spec.AdditionalTags = r.Spec.AdditionalTags.WithDefaults()
 }
 
 func (r *AWSCluster) validateGCTasksAnnotation() field.ErrorList {
@@ -210,7 +285,7 @@ func (r *AWSCluster) validateGCTasksAnnotation() field.ErrorList {
 	if gcTasksAnnotationValue := annotations[ExternalResourceGCTasksAnnotation]; gcTasksAnnotationValue != "" {
 		gcTasks := strings.Split(gcTasksAnnotationValue, ",")
 
-		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup}
+		supportedGCTasks := []GCTask{GCTaskLoadBalancer, GCTaskTargetGroup, GCTaskSecurityGroup, GCTaskNetworkInterface, GCTaskVolume, GCTaskLaunchTemplate}
 
 		for _, gcTask := range gcTasks {
 			found := false
@@ -239,13 +314,124 @@ func (r *AWSCluster) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
 
+// validateInstanceAccessMode rejects an SSH key for the bastion of the clusters whose instances are accessed with
+// Systems Manager, as it would never be attached.
+func (r *AWSCluster) validateInstanceAccessMode() field.ErrorList {
+	if r.Spec.InstanceAccessMode == InstanceAccessModeSSM && r.Spec.SSHKeyName != nil && *r.Spec.SSHKeyName != "" {
+		return field.ErrorList{
+			field.Invalid(field.NewPath("spec", "sshKeyName"), *r.Spec.SSHKeyName, "must be empty when instanceAccessMode is SSM"),
+		}
+	}
+	return nil
+}
+
+// validateDefaultEBSEncryptionKeyARN rejects default EBS encryption keys which aren't the ARN of a KMS key, as the
+// key policy of the key is checked, which aliases and key IDs don't allow.
+func (r *AWSCluster) validateDefaultEBSEncryptionKeyARN() field.ErrorList {
+	if r.Spec.DefaultEBSEncryptionKeyARN == "" {
+		return nil
+	}
+
+	if parsed, err := arn.Parse(r.Spec.DefaultEBSEncryptionKeyARN); err != nil || parsed.Service != "kms" || !strings.HasPrefix(parsed.Resource, "key/") {
+		return field.ErrorList{
+			field.Invalid(field.NewPath("spec", "defaultEBSEncryptionKeyARN"), r.Spec.DefaultEBSEncryptionKeyARN, "must be the ARN of a KMS key"),
+		}
+	}
+	return nil
+}
+
+func (r *AWSCluster) validateOIDCProvider() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !r.Spec.AssociateOIDCProvider {
+		return allErrs
+	}
+
+	if r.Spec.S3Bucket == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "s3Bucket"), "s3Bucket is required when associateOIDCProvider is enabled"))
+		return allErrs
+	}
+
+	// The issuer URL uses the virtual-hosted-style URL of the bucket, whose TLS certificate
+	// doesn't cover bucket names containing dots.
+	if strings.Contains(r.Spec.S3Bucket.Name, ".") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "s3Bucket", "name"), r.Spec.S3Bucket.Name, "bucket name can't contain dots when associateOIDCProvider is enabled"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSCluster) validateSSMParameterPrefix() field.ErrorList {
+	var allErrs field.ErrorList
+
+	// SSM reserves the parameter names beginning with "aws" or "ssm".
+	name := strings.ToLower(strings.TrimPrefix(r.Spec.SSMParameterPrefix, "/"))
+	if strings.HasPrefix(name, "aws") || strings.HasPrefix(name, "ssm") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "ssmParameterPrefix"), r.Spec.SSMParameterPrefix, "can't begin with \"aws\" or \"ssm\""))
+	}
+
+	return allErrs
+}
+
+// validateAPIServerHealthCheck rejects health checks of the endpoint of internal load balancers, which the Route 53
+// health checkers can't reach.
+func (r *AWSCluster) validateAPIServerHealthCheck() field.ErrorList {
+	if r.Spec.APIServerHealthCheck == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	if lb := r.Spec.ControlPlaneLoadBalancer; lb != nil && lb.LoadBalancerType != LoadBalancerTypeDisabled && lb.Scheme != nil && *lb.Scheme == ELBSchemeInternal {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "apiServerHealthCheck"), "the endpoint of an internal load balancer can't be checked by Route 53"))
+	}
+	allErrs = append(allErrs, r.Spec.APIServerHealthCheck.Validate()...)
+
+	return allErrs
+}
+
+// validateStandbyRegion validates that the network of the cluster can be copied to its standby region: the VPC must
+// be managed, and neither IPAM pools nor security group overrides, which are regional, can be used.
+func (r *AWSCluster) validateStandbyRegion() field.ErrorList {
+	standby := r.Spec.StandbyRegion
+	if standby == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "standbyRegion")
+	if standby.Name == r.Spec.Region {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), standby.Name, "must differ from the region of the cluster"))
+	}
+	for az, standbyAZ := range standby.AvailabilityZones {
+		if !strings.HasPrefix(standbyAZ, standby.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("availabilityZones").Key(az), standbyAZ, "must be an availability zone of the standby region"))
+		}
+	}
+
+	// The managed VPCs have their ID set once created, along with the tags identifying them as owned by the cluster.
+	vpc := r.Spec.NetworkSpec.VPC
+	if vpc.IsUnmanaged(r.Labels[clusterv1.ClusterNameLabel]) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can't be used with an unmanaged VPC"))
+	}
+	if vpc.IPAMPool != nil || vpc.IsIPv6Enabled() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can't be used with IPAM pools or IPv6"))
+	}
+	if len(r.Spec.NetworkSpec.SecurityGroupOverrides) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can't be used with security group overrides"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("ipv6"), r.Spec.NetworkSpec.VPC.IPv6, "IPv6 cannot be used with unmanaged clusters at this time."))
 	}
 	for _, subnet := range r.Spec.NetworkSpec.Subnets {
-		if subnet.IsIPv6 || subnet.IPv6CidrBlock != "" {
+		if subnet.IsIPv6 || subnet.IPv6CidrBlock != "" || subnet.IPv6Native || subnet.EnableDNS64 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("subnets"), r.Spec.NetworkSpec.Subnets, "IPv6 cannot be used with unmanaged clusters at this time."))
 		}
 		if subnet.ZoneType != nil && subnet.IsEdge() {
@@ -272,6 +458,81 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+// validateAccessLogs validates the access logs configuration of a control plane load balancer.
+func validateAccessLogs(fldPath *field.Path, lb *AWSLoadBalancerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	accessLogs := lb.AccessLogs
+	if accessLogs == nil {
+		return nil
+	}
+	fldPath = fldPath.Child("accessLogs")
+
+	switch {
+	case lb.LoadBalancerType == LoadBalancerTypeELB || lb.LoadBalancerType == LoadBalancerTypeDisabled:
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("access logs are not supported for load balancers of type %q", lb.LoadBalancerType)))
+	case lb.ARN != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "access logs cannot be configured when using an existing load balancer"))
+	}
+
+	if accessLogs.Enabled && accessLogs.Bucket == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("bucket"), "is required when the access logs are enabled"))
+	}
+	if strings.HasPrefix(accessLogs.Prefix, "/") || strings.HasSuffix(accessLogs.Prefix, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), accessLogs.Prefix, "cannot start or end with a slash"))
+	}
+	if strings.Contains(accessLogs.Prefix, "AWSLogs") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), accessLogs.Prefix, "cannot contain AWSLogs"))
+	}
+
+	return allErrs
+}
+
+// validateTargetGroups validates the target type, the DNS client routing policy, the additional target group
+// attributes and the connection draining timeout of a control plane load balancer.
+func validateTargetGroups(fldPath *field.Path, lb *AWSLoadBalancerSpec, ipv6 bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	isV2 := lb.LoadBalancerType == LoadBalancerTypeNLB || lb.LoadBalancerType == LoadBalancerTypeALB
+	if lb.TargetType != "" && lb.TargetType != TargetTypeInstance {
+		switch {
+		case !isV2:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("targetType"), fmt.Sprintf("target type is not supported for load balancers of type %q", lb.LoadBalancerType)))
+		case ipv6:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("targetType"), "ip target type is not supported with IPv6"))
+		}
+	}
+
+	if lb.DNSClientRoutingPolicy != "" {
+		switch {
+		case lb.LoadBalancerType != LoadBalancerTypeNLB:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsClientRoutingPolicy"), "DNS client routing policy is only supported for network load balancers"))
+		case lb.ARN != nil:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsClientRoutingPolicy"), "DNS client routing policy cannot be configured when using an existing load balancer"))
+		}
+	}
+
+	if len(lb.AdditionalTargetGroupAttributes) > 0 && !isV2 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalTargetGroupAttributes"), fmt.Sprintf("target group attributes are not supported for load balancers of type %q", lb.LoadBalancerType)))
+	}
+	for k, v := range lb.AdditionalTargetGroupAttributes {
+		if k == "" || v == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalTargetGroupAttributes").Key(k), v, "key and value cannot be empty"))
+		}
+	}
+
+	if lb.ConnectionDrainingTimeout != nil {
+		switch {
+		case !isV2:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("connectionDrainingTimeout"), fmt.Sprintf("connection draining is not supported for load balancers of type %q", lb.LoadBalancerType)))
+		case lb.ConnectionDrainingTimeout.Duration < 0:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("connectionDrainingTimeout"), lb.ConnectionDrainingTimeout.Duration.String(), "cannot be negative"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -282,7 +543,7 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "name"), r.Spec.SecondaryControlPlaneLoadBalancer.Name, "secondary controlPlaneLoadBalancer.name cannot be empty"))
 		}
 
-		if r.Spec.SecondaryControlPlaneLoadBalancer.Name == r.Spec.ControlPlaneLoadBalancer.Name {
+		if cmp.Equal(r.Spec.SecondaryControlPlaneLoadBalancer.Name, r.Spec.ControlPlaneLoadBalancer.Name) {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "name"), r.Spec.SecondaryControlPlaneLoadBalancer.Name, "field must be different from controlPlaneLoadBalancer.name"))
 		}
 
@@ -293,26 +554,75 @@ func (r *AWSCluster) validateControlPlaneLBs() field.ErrorList {
 		if r.Spec.SecondaryControlPlaneLoadBalancer.LoadBalancerType != LoadBalancerTypeNLB {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "loadBalancerType"), r.Spec.SecondaryControlPlaneLoadBalancer.LoadBalancerType, "secondary control plane load balancer must be a Network Load Balancer"))
 		}
+
+		if r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeDisabled {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "secondaryControlPlaneLoadBalancer"), "cannot be used when the control plane load balancer is disabled"))
+		}
+
+		if r.Spec.SecondaryControlPlaneLoadBalancer.ARN != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "arn"), "an existing load balancer can only be used as the primary control plane load balancer"))
+		}
 	}
 
 	// Additional listeners are only supported for NLBs.
 	// Validate the control plane load balancers.
-	loadBalancers := []*AWSLoadBalancerSpec{
-		r.Spec.ControlPlaneLoadBalancer,
-		r.Spec.SecondaryControlPlaneLoadBalancer,
+	loadBalancers := []struct {
+		fldPath *field.Path
+		spec    *AWSLoadBalancerSpec
+	}{
+		{field.NewPath("spec", "controlPlaneLoadBalancer"), r.Spec.ControlPlaneLoadBalancer},
+		{field.NewPath("spec", "secondaryControlPlaneLoadBalancer"), r.Spec.SecondaryControlPlaneLoadBalancer},
 	}
 	for _, cp := range loadBalancers {
-		if cp == nil {
+		if cp.spec == nil {
 			continue
 		}
 
-		for _, rule := range cp.IngressRules {
+		for _, rule := range cp.spec.IngressRules {
 			if (rule.CidrBlocks != nil || rule.IPv6CidrBlocks != nil) && (rule.SourceSecurityGroupIDs != nil || rule.SourceSecurityGroupRoles != nil) {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "ingressRules"), r.Spec.ControlPlaneLoadBalancer.IngressRules, "CIDR blocks and security group IDs or security group roles cannot be used together"))
+				allErrs = append(allErrs, field.Invalid(cp.fldPath.Child("ingressRules"), cp.spec.IngressRules, "CIDR blocks and security group IDs or security group roles cannot be used together"))
+			}
+		}
+
+		allErrs = append(allErrs, validateAccessLogs(cp.fldPath, cp.spec)...)
+		allErrs = append(allErrs, validateTargetGroups(cp.fldPath, cp.spec, r.Spec.NetworkSpec.VPC.IsIPv6Enabled())...)
+
+		if cp.spec.IPAddressType != "" {
+			switch {
+			case cp.spec.LoadBalancerType != LoadBalancerTypeNLB && cp.spec.LoadBalancerType != LoadBalancerTypeALB:
+				allErrs = append(allErrs, field.Forbidden(cp.fldPath.Child("ipAddressType"), fmt.Sprintf("IP address type is not supported for load balancers of type %q", cp.spec.LoadBalancerType)))
+			case cp.spec.ARN != nil:
+				allErrs = append(allErrs, field.Forbidden(cp.fldPath.Child("ipAddressType"), "IP address type cannot be configured when using an existing load balancer"))
+			case cp.spec.IsDualStack() && !r.Spec.NetworkSpec.VPC.IsIPv6Enabled() && !r.Spec.NetworkSpec.Subnets.HasIPv6CidrBlock():
+				allErrs = append(allErrs, field.Forbidden(cp.fldPath.Child("ipAddressType"), "dual-stack load balancers require IPv6 to be enabled on the VPC or subnets with an IPv6 CIDR block"))
+			}
+		}
+
+		if cp.spec.DeletionProtection {
+			switch {
+			case cp.spec.LoadBalancerType == LoadBalancerTypeClassic || cp.spec.LoadBalancerType == LoadBalancerTypeDisabled:
+				allErrs = append(allErrs, field.Forbidden(cp.fldPath.Child("deletionProtection"), fmt.Sprintf("deletion protection is not supported for load balancers of type %q", cp.spec.LoadBalancerType)))
+			case cp.spec.ARN != nil:
+				allErrs = append(allErrs, field.Forbidden(cp.fldPath.Child("deletionProtection"), "deletion protection cannot be configured when using an existing load balancer"))
 			}
 		}
 	}
 
+	// An existing load balancer is identified by its ARN, and must be a network or application load balancer.
+	if r.Spec.ControlPlaneLoadBalancer.ARN != nil {
+		if r.Spec.ControlPlaneLoadBalancer.Name != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneLoadBalancer", "name"), "cannot configure a name when using an existing load balancer"))
+		}
+
+		if _, err := arn.Parse(*r.Spec.ControlPlaneLoadBalancer.ARN); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "arn"), *r.Spec.ControlPlaneLoadBalancer.ARN, "must be a valid ARN"))
+		}
+
+		if lbType := r.Spec.ControlPlaneLoadBalancer.LoadBalancerType; lbType != LoadBalancerTypeNLB && lbType != LoadBalancerTypeALB {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "loadBalancerType"), lbType, "an existing load balancer must be a Network or an Application Load Balancer"))
+		}
+	}
+
 	if r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeDisabled {
 		if r.Spec.ControlPlaneLoadBalancer.Name != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"), r.Spec.ControlPlaneLoadBalancer.Name, "cannot configure a name if the LoadBalancer reconciliation is disabled"))
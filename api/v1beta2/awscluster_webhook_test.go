@@ -207,6 +207,91 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "rejects associateOIDCProvider without bucket",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					AssociateOIDCProvider: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects associateOIDCProvider with bucket name containing dots",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					AssociateOIDCProvider: true,
+					S3Bucket: &S3Bucket{
+						Name: "cluster.example.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts associateOIDCProvider with bucket",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					AssociateOIDCProvider: true,
+					S3Bucket: &S3Bucket{
+						Name: "cluster-oidc",
+					},
+				},
+			},
+		},
+		{
+			name: "accepts bucket with KMS key ARN",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{
+						Name:                           "bootstrap",
+						ControlPlaneIAMInstanceProfile: "control-plane.cluster-api-provider-aws.sigs.k8s.io",
+						NodesIAMInstanceProfiles:       []string{"nodes.cluster-api-provider-aws.sigs.k8s.io"},
+						KMSKeyARN:                      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+					},
+				},
+			},
+		},
+		{
+			name: "rejects bucket with invalid KMS key ARN",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{
+						Name:                           "bootstrap",
+						ControlPlaneIAMInstanceProfile: "control-plane.cluster-api-provider-aws.sigs.k8s.io",
+						NodesIAMInstanceProfiles:       []string{"nodes.cluster-api-provider-aws.sigs.k8s.io"},
+						KMSKeyARN:                      "arn:aws:s3:::bootstrap",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts ssmParameterPrefix",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSMParameterPrefix: "/cluster-api-provider-aws/bootstrap",
+				},
+			},
+		},
+		{
+			name: "rejects ssmParameterPrefix beginning with aws",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSMParameterPrefix: "/AWS/bootstrap",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects ssmParameterPrefix beginning with ssm",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					SSMParameterPrefix: "ssm-bootstrap",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "rejects empty bucket name",
 			cluster: &AWSCluster{
@@ -568,6 +653,338 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "accepts an existing network load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/shared/50dc6c495c0c9188"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "accepts access logs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Enabled: true,
+							Bucket:  "logs",
+							Prefix:  "apiserver",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "accepts deletion protection on network load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:   LoadBalancerTypeNLB,
+						DeletionProtection: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects deletion protection on classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:   LoadBalancerTypeClassic,
+						DeletionProtection: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects dual-stack load balancers without IPv6",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    LoadBalancerIPAddressTypeDualStack,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts IPv4 network load balancers without IPv6",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    LoadBalancerIPAddressTypeIPv4,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects the IP address type of classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						IPAddressType:    LoadBalancerIPAddressTypeDualStack,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects the IP address type of existing load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/existing/1234567890abcdef"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    LoadBalancerIPAddressTypeDualStack,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects enabled access logs without bucket",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects an access logs prefix containing AWSLogs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Enabled: true,
+							Bucket:  "logs",
+							Prefix:  "apiserver/AWSLogs",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts the ip target type with additional target group attributes for an NLB",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:       LoadBalancerTypeNLB,
+						TargetType:             TargetTypeIP,
+						DNSClientRoutingPolicy: DNSClientRoutingPolicyAvailabilityZoneAffinity,
+						AdditionalTargetGroupAttributes: map[string]string{
+							"deregistration_delay.timeout_seconds": "30",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects the ip target type for a classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						TargetType:       TargetTypeIP,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects a DNS client routing policy for an ALB",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:       LoadBalancerTypeALB,
+						DNSClientRoutingPolicy: DNSClientRoutingPolicyAvailabilityZoneAffinity,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects additional target group attributes for a classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						AdditionalTargetGroupAttributes: map[string]string{
+							"deregistration_delay.timeout_seconds": "30",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects a connection draining timeout for a classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:          LoadBalancerTypeClassic,
+						ConnectionDrainingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects an existing classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/shared"),
+						LoadBalancerType: LoadBalancerTypeClassic,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects a name with an existing load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/shared/50dc6c495c0c9188"),
+						Name:             ptr.To("shared"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects an invalid load balancer ARN",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("shared"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "SSH key name is rejected when instances are accessed with SSM",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					InstanceAccessMode: InstanceAccessModeSSM,
+					SSHKeyName:         ptr.To("my-key"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty SSH key name is accepted when instances are accessed with SSM",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					InstanceAccessMode: InstanceAccessModeSSM,
+					SSHKeyName:         ptr.To(""),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "default EBS encryption key ARN is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DefaultEBSEncryptionKeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "default EBS encryption key alias is rejected",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					DefaultEBSEncryptionKeyARN: "arn:aws:kms:us-east-1:123456789012:alias/volumes",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standby region is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region:        "us-east-1",
+					StandbyRegion: &StandbyRegion{Name: "us-west-2", AvailabilityZones: map[string]string{"us-east-1e": "us-west-2d"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "standby region must differ from the region of the cluster",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region:        "us-east-1",
+					StandbyRegion: &StandbyRegion{Name: "us-east-1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standby availability zones must belong to the standby region",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region:        "us-east-1",
+					StandbyRegion: &StandbyRegion{Name: "us-west-2", AvailabilityZones: map[string]string{"us-east-1a": "eu-west-1a"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standby region is rejected with an unmanaged VPC",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Region:        "us-east-1",
+					NetworkSpec:   NetworkSpec{VPC: VPCSpec{ID: "vpc-123"}},
+					StandbyRegion: &StandbyRegion{Name: "us-west-2"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "API server health check of an internet-facing load balancer is accepted",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					APIServerHealthCheck: &Route53HealthCheck{Regions: []string{"us-east-1", "eu-west-1", "ap-southeast-1"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "API server health check of an internal load balancer is rejected",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Scheme: &ELBSchemeInternal},
+					APIServerHealthCheck:     &Route53HealthCheck{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -952,6 +1369,115 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "existing control plane load balancer is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/shared/50dc6c495c0c9188"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/other/60dc6c495c0c9188"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary control plane load balancer can be added",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             ptr.To("internal-apiserver"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secondary control plane load balancer cannot be removed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             ptr.To("internal-apiserver"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary control plane load balancer name is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             ptr.To("internal-apiserver"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             ptr.To("other-apiserver"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "control plane load balancer target type is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						TargetType:       TargetTypeIP,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "incorrect GC tasks annotation",
 			oldCluster: &AWSCluster{
@@ -966,6 +1492,81 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "dual-stack can be enabled once the subnets have an IPv6 CIDR block",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IPAddressType:    LoadBalancerIPAddressTypeDualStack,
+					},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								ID:            "subnet-1",
+								CidrBlock:     "10.0.0.0/24",
+								IPv6CidrBlock: "2001:db8:1234:1a01::/64",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dual-stack can't be enabled without subnets with an IPv6 CIDR block",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeALB,
+						IPAddressType:    LoadBalancerIPAddressTypeDualStack,
+					},
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								ID:        "subnet-1",
+								CidrBlock: "10.0.0.0/24",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standby region can be removed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{Region: "us-east-1", StandbyRegion: &StandbyRegion{Name: "us-west-2"}},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{Region: "us-east-1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "standby region is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{Region: "us-east-1", StandbyRegion: &StandbyRegion{Name: "us-west-2"}},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{Region: "us-east-1", StandbyRegion: &StandbyRegion{Name: "eu-west-1"}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
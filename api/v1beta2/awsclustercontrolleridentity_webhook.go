@@ -63,6 +63,10 @@ func (r *AWSClusterControllerIdentity) ValidateCreate() (admission.Warnings, err
 		}
 	}
 
+	if errs := append(r.Spec.ServiceEndpoints.Validate(), r.Spec.Proxy.Validate()...); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	return nil, nil
 }
 
@@ -60,6 +60,12 @@ func (r *AWSClusterRoleIdentity) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
+	errs := append(r.Spec.ServiceEndpoints.Validate(), r.Spec.Proxy.Validate()...)
+	errs = append(errs, r.Spec.validateSessionTags()...)
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	return nil, nil
 }
 
@@ -89,6 +95,12 @@ func (r *AWSClusterRoleIdentity) ValidateUpdate(old runtime.Object) (admission.W
 		}
 	}
 
+	errs := append(r.Spec.ServiceEndpoints.Validate(), r.Spec.Proxy.Validate()...)
+	errs = append(errs, r.Spec.validateSessionTags()...)
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	return nil, nil
 }
 
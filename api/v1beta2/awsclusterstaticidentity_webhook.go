@@ -55,6 +55,10 @@ func (r *AWSClusterStaticIdentity) ValidateCreate() (admission.Warnings, error)
 		}
 	}
 
+	if errs := append(r.Spec.ServiceEndpoints.Validate(), r.Spec.Proxy.Validate()...); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	return nil, nil
 }
 
@@ -83,6 +87,10 @@ func (r *AWSClusterStaticIdentity) ValidateUpdate(old runtime.Object) (admission
 		}
 	}
 
+	if errs := append(r.Spec.ServiceEndpoints.Validate(), r.Spec.Proxy.Validate()...); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	return nil, nil
 }
 
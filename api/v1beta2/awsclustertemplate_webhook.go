@@ -49,6 +49,8 @@ func (r *AWSClusterTemplate) ValidateCreate() (admission.Warnings, error) {
 
 	allErrs = append(allErrs, r.Spec.Template.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, validateSSHKeyName(r.Spec.Template.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, r.Spec.Template.Spec.AdditionalTags.ValidateWithPath(field.NewPath("spec", "template", "spec", "additionalTags"))...)
+	allErrs = append(allErrs, ValidateRequiredTags(r.Spec.Template.Spec.RequiredTags, field.NewPath("spec", "template", "spec", "requiredTags"))...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var _ = ctrl.Log.WithName("awsclusterwebidentity-resource")
+
+func (r *AWSClusterWebIdentity) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsclusterwebidentity,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusterwebidentities,versions=v1beta2,name=validation.awsclusterwebidentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsclusterwebidentity,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsclusterwebidentities,versions=v1beta2,name=default.awsclusterwebidentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var (
+	_ webhook.Validator = &AWSClusterWebIdentity{}
+	_ webhook.Defaulter = &AWSClusterWebIdentity{}
+)
+
+// ValidateCreate will do any extra validation when creating an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateCreate() (admission.Warnings, error) {
+	return nil, r.validateSpec()
+}
+
+// ValidateDelete allows you to add any extra validation when deleting an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate will do any extra validation when updating an AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if _, ok := old.(*AWSClusterWebIdentity); !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSClusterWebIdentity but got a %T", old))
+	}
+
+	return nil, r.validateSpec()
+}
+
+// Default will set default values for the AWSClusterWebIdentity.
+func (r *AWSClusterWebIdentity) Default() {
+	SetDefaults_Labels(&r.ObjectMeta)
+}
+
+func (r *AWSClusterWebIdentity) validateSpec() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if r.Spec.RoleArn == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("roleARN"), "roleARN is required"))
+	}
+
+	switch {
+	case r.Spec.TokenFile == "" && r.Spec.RolesAnywhere == nil:
+		allErrs = append(allErrs, field.Required(specPath, "one of tokenFile or rolesAnywhere must be set"))
+	case r.Spec.TokenFile != "" && r.Spec.RolesAnywhere != nil:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("rolesAnywhere"), "cannot be set together with tokenFile"))
+	case r.Spec.TokenFile != "":
+		allErrs = append(allErrs, validateWebIdentityFile(specPath.Child("tokenFile"), r.Spec.TokenFile)...)
+	case r.Spec.RolesAnywhere != nil:
+		raPath := specPath.Child("rolesAnywhere")
+		if r.Spec.RolesAnywhere.TrustAnchorARN == "" {
+			allErrs = append(allErrs, field.Required(raPath.Child("trustAnchorARN"), "trustAnchorARN is required"))
+		}
+		if r.Spec.RolesAnywhere.ProfileARN == "" {
+			allErrs = append(allErrs, field.Required(raPath.Child("profileARN"), "profileARN is required"))
+		}
+		if r.Spec.RolesAnywhere.CertificateFile == "" {
+			allErrs = append(allErrs, field.Required(raPath.Child("certificateFile"), "certificateFile is required"))
+		} else {
+			allErrs = append(allErrs, validateWebIdentityFile(raPath.Child("certificateFile"), r.Spec.RolesAnywhere.CertificateFile)...)
+		}
+		if r.Spec.RolesAnywhere.PrivateKeyFile == "" {
+			allErrs = append(allErrs, field.Required(raPath.Child("privateKeyFile"), "privateKeyFile is required"))
+		} else {
+			allErrs = append(allErrs, validateWebIdentityFile(raPath.Child("privateKeyFile"), r.Spec.RolesAnywhere.PrivateKeyFile)...)
+		}
+	}
+
+	// Validate selector parses as Selector
+	if r.Spec.AllowedNamespaces != nil {
+		_, err := metav1.LabelSelectorAsSelector(&r.Spec.AllowedNamespaces.Selector)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("allowedNamespaces", "selector"), r.Spec.AllowedNamespaces.Selector, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, r.Spec.ServiceEndpoints.Validate()...)
+	allErrs = append(allErrs, r.Spec.Proxy.Validate()...)
+
+	return allErrs.ToAggregate()
+}
+
+// validateWebIdentityFile rejects the relative paths and the paths with ".." elements. The controller additionally
+// checks that the files are in its web identity files directory.
+func validateWebIdentityFile(fldPath *field.Path, name string) field.ErrorList {
+	if !strings.HasPrefix(name, "/") || slices.Contains(strings.Split(name, "/"), "..") {
+		return field.ErrorList{field.Invalid(fldPath, name, "must be an absolute path without \"..\" elements")}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAWSClusterWebIdentityValidateCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      AWSClusterWebIdentitySpec
+		wantError bool
+	}{
+		{
+			name: "successfully create with a token file",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				TokenFile:   "/var/run/secrets/capa/token",
+			},
+			wantError: false,
+		},
+		{
+			name: "successfully create with IAM Roles Anywhere",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				RolesAnywhere: &RolesAnywhereSpec{
+					TrustAnchorARN:  "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+					ProfileARN:      "arn:aws:rolesanywhere:us-east-1:123456789012:profile/p",
+					CertificateFile: "/var/run/secrets/capa/tls.crt",
+					PrivateKeyFile:  "/var/run/secrets/capa/tls.key",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "do not allow a missing role ARN",
+			spec: AWSClusterWebIdentitySpec{
+				TokenFile: "/var/run/secrets/capa/token",
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow neither tokenFile nor rolesAnywhere",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow both tokenFile and rolesAnywhere",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				TokenFile:   "/var/run/secrets/capa/token",
+				RolesAnywhere: &RolesAnywhereSpec{
+					TrustAnchorARN:  "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+					ProfileARN:      "arn:aws:rolesanywhere:us-east-1:123456789012:profile/p",
+					CertificateFile: "/var/run/secrets/capa/tls.crt",
+					PrivateKeyFile:  "/var/run/secrets/capa/tls.key",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow a relative token file",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				TokenFile:   "capa/token",
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow files escaping their directory",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				RolesAnywhere: &RolesAnywhereSpec{
+					TrustAnchorARN:  "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+					ProfileARN:      "arn:aws:rolesanywhere:us-east-1:123456789012:profile/p",
+					CertificateFile: "/var/run/secrets/capa/tls.crt",
+					PrivateKeyFile:  "/var/run/secrets/capa/../kubernetes.io/serviceaccount/token",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "do not allow an incomplete rolesAnywhere configuration",
+			spec: AWSClusterWebIdentitySpec{
+				AWSRoleSpec: AWSRoleSpec{RoleArn: "arn:aws:iam::123456789012:role/capa"},
+				RolesAnywhere: &RolesAnywhereSpec{
+					TrustAnchorARN: "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+				},
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := &AWSClusterWebIdentity{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: GroupVersion.String(),
+					Kind:       "AWSClusterWebIdentity",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "web",
+				},
+				Spec: tt.spec,
+			}
+			ctx := context.TODO()
+			if err := testEnv.Create(ctx, identity); (err != nil) != tt.wantError {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantError)
+			}
+			testEnv.Delete(ctx, identity)
+		})
+	}
+}
@@ -90,6 +90,19 @@ type AWSMachineSpec struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// OSFamily is the operating system family of the instance, linux if not set. It defines the
+	// format of the user data, and the default base OS used for image lookup.
+	// +kubebuilder:validation:Enum:=linux;windows
+	// +optional
+	OSFamily OSFamily `json:"osFamily,omitempty"`
+
+	// ImageLookupSSMParameter is the name of an AWS Systems Manager parameter whose value is the ID
+	// of the AMI to use, for example the public parameters of the EKS optimized or Bottlerocket AMIs:
+	// /aws/service/bottlerocket/aws-k8s-1.28/x86_64/latest/image_id. It is ignored if an explicit
+	// AMI is set, and takes precedence over the other image lookup fields.
+	// +optional
+	ImageLookupSSMParameter string `json:"imageLookupSSMParameter,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength:=2
@@ -125,6 +138,12 @@ type AWSMachineSpec struct {
 	// +optional
 	Subnet *AWSResourceReference `json:"subnet,omitempty"`
 
+	// SubnetSelector selects the subnet to use for this instance by its tags and availability zone in the VPC
+	// of the cluster, when the subnet is not referenced with Subnet. The subnet is resolved when the instance
+	// is created.
+	// +optional
+	SubnetSelector *SubnetSelector `json:"subnetSelector,omitempty"`
+
 	// SecurityGroupOverrides is an optional set of security groups to use for the node.
 	// This is optional - if not provided security groups from the cluster will be used.
 	// +optional
@@ -188,6 +207,19 @@ type AWSMachineSpec struct {
 	// PrivateDNSName is the options for the instance hostname.
 	// +optional
 	PrivateDNSName *PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// IPv6Only launches the instance with IPv6 addressing only, in an IPv6-native subnet of the cluster.
+	// The instance is assigned an IPv6 address and, unless set otherwise in PrivateDNSName, a resource-name
+	// hostname with DNS AAAA records. The instance type must be built on the Nitro System, and the instance
+	// can't have a public IPv4 address.
+	// +optional
+	IPv6Only bool `json:"ipv6Only,omitempty"`
+
+	// LoadBalancerAttachments lists the load balancers the instance is registered with once running, and
+	// deregistered from when deleted. Control plane instances are registered with the control plane load
+	// balancers regardless of this field.
+	// +optional
+	LoadBalancerAttachments *LoadBalancerAttachments `json:"loadBalancerAttachments,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -241,6 +273,8 @@ type Ignition struct {
 	// and users with ec2:DescribeInstances permission or users running pods
 	// that can access the ec2 metadata service have access to this sensitive information.
 	// So this is only to be used at ones own risk, and only when other more secure options are not viable.
+	// With Ignition versions 3.x, when Proxy or TLS are set or the config exceeds the 16KB user data limit,
+	// the config is compressed and embedded in a data URL merged by a config carrying these settings.
 	//
 	// +optional
 	// +kubebuilder:default="ClusterObjectStore"
@@ -326,6 +360,21 @@ type AWSMachineStatus struct {
 	// +optional
 	InstanceState *InstanceState `json:"instanceState,omitempty"`
 
+	// ImageID is the ID of the AMI the instance was created from, as resolved from the AMI and
+	// image lookup fields of the spec.
+	// +optional
+	ImageID *string `json:"imageID,omitempty"`
+
+	// CostEstimate is the estimated hourly cost of the instance, which is set when the CostEstimation
+	// feature gate is enabled.
+	// +optional
+	CostEstimate *CostEstimate `json:"costEstimate,omitempty"`
+
+	// LoadBalancerAttachments lists the load balancers the instance is registered with, so that the instance is
+	// de-registered from the ones removed from the load balancer attachments of the spec.
+	// +optional
+	LoadBalancerAttachments *LoadBalancerAttachments `json:"loadBalancerAttachments,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -367,6 +416,11 @@ type AWSMachineStatus struct {
 	// Conditions defines current service state of the AWSMachine.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LastReconciliation records the last successful reconciliation of the instance, when the controller skips the
+	// reconciliations of running instances whose desired state is unchanged until its resync period elapses.
+	// +optional
+	LastReconciliation *LastReconciliation `json:"lastReconciliation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
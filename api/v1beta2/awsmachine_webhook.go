@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net"
@@ -63,9 +64,21 @@ func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, r.validateSSHKeyName()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.validateSubnetSelector()...)
+	allErrs = append(allErrs, validateIPv6Only(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, r.validateImageLookupSSMParameter()...)
+	allErrs = append(allErrs, r.validateOSFamily()...)
+	allErrs = append(allErrs, r.Spec.LoadBalancerAttachments.Validate(field.NewPath("spec", "loadBalancerAttachments"))...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
-	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
+	var warnings admission.Warnings
+	if regionValidator != nil && len(allErrs) == 0 {
+		regionWarnings, regionErrs := regionValidator.ValidateAWSMachine(context.Background(), r)
+		warnings = append(warnings, regionWarnings...)
+		allErrs = append(allErrs, regionErrs...)
+	}
+
+	return warnings, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -87,6 +100,7 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+	allErrs = append(allErrs, r.Spec.LoadBalancerAttachments.Validate(field.NewPath("spec", "loadBalancerAttachments"))...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	newAWSMachineSpec := newAWSMachine["spec"].(map[string]interface{})
@@ -108,6 +122,10 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	delete(oldAWSMachineSpec, "additionalSecurityGroups")
 	delete(newAWSMachineSpec, "additionalSecurityGroups")
 
+	// allow changes to loadBalancerAttachments
+	delete(oldAWSMachineSpec, "loadBalancerAttachments")
+	delete(newAWSMachineSpec, "loadBalancerAttachments")
+
 	// allow changes to secretPrefix, secretCount, and secureSecretsBackend
 	if cloudInit, ok := oldAWSMachineSpec["cloudInit"].(map[string]interface{}); ok {
 		delete(cloudInit, "secretPrefix")
@@ -331,13 +349,17 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, ValidateRootVolumeInstanceStore(r.Spec.RootVolume, field.NewPath("spec", "rootVolume"))...)
+
 	return allErrs
 }
 
 func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 	var allErrs field.ErrorList
 
-	for _, volume := range r.Spec.NonRootVolumes {
+	for i, volume := range r.Spec.NonRootVolumes {
+		allErrs = append(allErrs, ValidateNonRootVolume(volume, field.NewPath("spec", "nonRootVolumes").Index(i))...)
+
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -366,7 +388,15 @@ func (r *AWSMachine) ValidateDelete() (admission.Warnings, error) {
 
 // Default implements webhook.Defaulter such that an empty CloudInit will be defined with a default
 // SecureSecretsBackend as SecretBackendSecretsManager iff InsecureSkipSecretsManager is unset.
+// The defaults of the AWSProviderConfig are applied to the new AWSMachines.
 func (r *AWSMachine) Default() {
+	// The creation timestamp of the objects is only set once they are admitted.
+	if r.CreationTimestamp.IsZero() {
+		if config := getProviderConfig(context.Background()); config != nil {
+			config.defaultAWSMachine(r)
+		}
+	}
+
 	if !r.Spec.CloudInit.InsecureSkipSecretsManager && r.Spec.CloudInit.SecureSecretsBackend == "" && !r.ignitionEnabled() {
 		r.Spec.CloudInit.SecureSecretsBackend = SecretBackendSecretsManager
 	}
@@ -378,6 +408,8 @@ func (r *AWSMachine) Default() {
 
 		r.Spec.Ignition.Version = DefaultIgnitionVersion
 	}
+
+	r.Spec.AdditionalTags = r.Spec.AdditionalTags.WithDefaults()
 }
 
 func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
@@ -391,6 +423,64 @@ func (r *AWSMachine) validateAdditionalSecurityGroups() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSMachine) validateSubnetSelector() field.ErrorList {
+	return validateSubnetSelector(r.Spec.Subnet, r.Spec.SubnetSelector, field.NewPath("spec"))
+}
+
+// validateSubnetSelector validates the subnet selector of an AWSMachineSpec, which can't be combined with a subnet.
+func validateSubnetSelector(subnet *AWSResourceReference, selector *SubnetSelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if selector == nil {
+		return allErrs
+	}
+	if subnet != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnetSelector"), "only one of subnet or subnetSelector may be specified"))
+	}
+	return append(allErrs, selector.Validate(fldPath.Child("subnetSelector"))...)
+}
+
+// validateIPv6Only validates that an IPv6-only AWSMachineSpec doesn't request IPv4 addressing, and uses an instance
+// type supporting IPv6-only addressing.
+func validateIPv6Only(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !spec.IPv6Only {
+		return allErrs
+	}
+	if !InstanceTypeSupportsIPv6Only(spec.InstanceType) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("instanceType"), spec.InstanceType, "must be built on the Nitro System when spec.ipv6Only is set"))
+	}
+	if spec.PublicIP != nil && *spec.PublicIP {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIP"), "cannot be set if spec.ipv6Only is set"))
+	}
+	if spec.PrivateDNSName != nil && spec.PrivateDNSName.HostnameType != nil && *spec.PrivateDNSName.HostnameType == "ip-name" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("privateDnsName", "hostnameType"), *spec.PrivateDNSName.HostnameType, "must be resource-name if spec.ipv6Only is set"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachine) validateImageLookupSSMParameter() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.ImageLookupSSMParameter != "" && r.Spec.AMI.ID != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.imageLookupSSMParameter"), "cannot be set together with spec.ami.id"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachine) validateOSFamily() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.OSFamily == OSFamilyWindows && r.Spec.Ignition != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition"), "cannot be set if spec.osFamily is windows"))
+	}
+	// Windows instances can't retrieve their user data from AWS Secrets Manager or AWS Systems Manager Parameter Store,
+	// as it relies on a cloud-init boothook, so the user data must be explicitly stored in plain text.
+	if r.Spec.OSFamily == OSFamilyWindows && !r.Spec.CloudInit.InsecureSkipSecretsManager {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "cloudInit", "insecureSkipSecretsManager"), r.Spec.CloudInit.InsecureSkipSecretsManager, "must be true if spec.osFamily is windows"))
+	}
+	return allErrs
+}
+
 func (r *AWSMachine) validateSSHKeyName() field.ErrorList {
 	return validateSSHKeyName(r.Spec.SSHKeyName)
 }
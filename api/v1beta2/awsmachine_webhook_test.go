@@ -95,6 +95,61 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "instance store root volume is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type: VolumeTypeInstanceStore,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance store root volume can't have a size",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type: VolumeTypeInstanceStore,
+						Size: 8,
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance store non root volume is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "name",
+							Type:       VolumeTypeInstanceStore,
+							Size:       8,
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure non root volume have sizes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NonRootVolumes: []Volume{
+						{
+							DeviceName: "name",
+						},
+					},
+					InstanceType: "test",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure non root volume have device names",
 			machine: &AWSMachine{
@@ -368,6 +423,84 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "SSM parameter image lookup is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:            "test",
+					ImageLookupSSMParameter: "/aws/service/bottlerocket/aws-k8s-1.28/x86_64/latest/image_id",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Windows machines can't use ignition",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					OSFamily:     OSFamilyWindows,
+					Ignition:     &Ignition{Version: "3.1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Windows machines must skip AWS Secrets Manager",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					OSFamily:     OSFamilyWindows,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Windows machines storing their user data in plain text are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					OSFamily:     OSFamilyWindows,
+					CloudInit:    CloudInit{InsecureSkipSecretsManager: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "SSM parameter image lookup can't be set with an AMI ID",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:            "test",
+					AMI:                     AMIReference{ID: ptr.To[string]("ami-0123456789abcdef0")},
+					ImageLookupSSMParameter: "/aws/service/bottlerocket/aws-k8s-1.28/x86_64/latest/image_id",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "load balancer attachments with target groups and classic load balancers are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					LoadBalancerAttachments: &LoadBalancerAttachments{
+						TargetGroupARNs:          []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"},
+						ClassicLoadBalancerNames: []string{"ingress"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "load balancer attachments with an invalid target group ARN are rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					LoadBalancerAttachments: &LoadBalancerAttachments{
+						TargetGroupARNs: []string{"arn:aws:s3:::ingress"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -455,6 +588,27 @@ func TestAWSMachineUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "change in load balancer attachments",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					LoadBalancerAttachments: &LoadBalancerAttachments{
+						TargetGroupARNs:          []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"},
+						ClassicLoadBalancerNames: []string{"ingress"},
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "test",
+					LoadBalancerAttachments: &LoadBalancerAttachments{
+						TargetGroupARNs: []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "change in tags adding invalid ones",
 			oldMachine: &AWSMachine{
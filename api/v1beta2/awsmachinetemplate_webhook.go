@@ -73,6 +73,8 @@ func (r *AWSMachineTemplate) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, ValidateRootVolumeInstanceStore(spec.RootVolume, field.NewPath("spec", "template", "spec", "rootVolume"))...)
+
 	return allErrs
 }
 
@@ -81,7 +83,9 @@ func (r *AWSMachineTemplate) validateNonRootVolumes() field.ErrorList {
 
 	spec := r.Spec.Template.Spec
 
-	for _, volume := range spec.NonRootVolumes {
+	for i, volume := range spec.NonRootVolumes {
+		allErrs = append(allErrs, ValidateNonRootVolume(volume, field.NewPath("spec", "template", "spec", "nonRootVolumes").Index(i))...)
+
 		if VolumeTypesProvisioned.Has(string(volume.Type)) && volume.IOPS == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.template.spec.nonRootVolumes.iops"), "iops required if type is 'io1' or 'io2'"))
 		}
@@ -116,6 +120,31 @@ func (r *AWSMachineTemplate) validateAdditionalSecurityGroups() field.ErrorList
 	return allErrs
 }
 
+func (r *AWSMachineTemplate) validateImageLookupSSMParameter() field.ErrorList {
+	var allErrs field.ErrorList
+
+	spec := r.Spec.Template.Spec
+
+	if spec.ImageLookupSSMParameter != "" && spec.AMI.ID != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "imageLookupSSMParameter"), "cannot be set together with spec.template.spec.ami.id"))
+	}
+	return allErrs
+}
+
+func (r *AWSMachineTemplate) validateOSFamily() field.ErrorList {
+	var allErrs field.ErrorList
+
+	spec := r.Spec.Template.Spec
+
+	if spec.OSFamily == OSFamilyWindows && spec.Ignition != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "ignition"), "cannot be set if spec.template.spec.osFamily is windows"))
+	}
+	if spec.OSFamily == OSFamilyWindows && !spec.CloudInit.InsecureSkipSecretsManager {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "template", "spec", "cloudInit", "insecureSkipSecretsManager"), spec.CloudInit.InsecureSkipSecretsManager, "must be true if spec.template.spec.osFamily is windows"))
+	}
+	return allErrs
+}
+
 func (r *AWSMachineTemplate) validateCloudInitSecret() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -203,7 +232,12 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 	allErrs = append(allErrs, obj.validateNonRootVolumes()...)
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
-	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, validateSubnetSelector(spec.Subnet, spec.SubnetSelector, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateIPv6Only(&spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, obj.validateImageLookupSSMParameter()...)
+	allErrs = append(allErrs, obj.validateOSFamily()...)
+	allErrs = append(allErrs, spec.LoadBalancerAttachments.Validate(field.NewPath("spec", "template", "spec", "loadBalancerAttachments"))...)
+	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.ValidateWithPath(field.NewPath("spec", "template", "spec", "additionalTags"))...)
 
 	return nil, aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
 }
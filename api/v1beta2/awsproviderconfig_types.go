@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AWSProviderConfigName is the name of the AWSProviderConfig singleton.
+	AWSProviderConfigName = "default"
+)
+
+// AWSProviderConfigSpec defines the defaults applied by the webhooks to the new AWSClusters and AWSMachines.
+// The values set by the objects take precedence over these defaults.
+type AWSProviderConfigSpec struct {
+	// InstanceMetadataOptions are the default metadata options of the instances of the AWSMachines which don't
+	// set them.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// RequiredTags are added to the additional tags of the AWSClusters and AWSMachines which don't already set them.
+	// +optional
+	RequiredTags Tags `json:"requiredTags,omitempty"`
+
+	// InstanceTypes are the default instance types of the AWSMachines and of the bastion hosts which don't set one.
+	// +optional
+	InstanceTypes *DefaultInstanceTypes `json:"instanceTypes,omitempty"`
+
+	// KMSKeys are the default KMS keys used to encrypt the AWS resources which don't set one.
+	// +optional
+	KMSKeys *DefaultKMSKeys `json:"kmsKeys,omitempty"`
+}
+
+// DefaultInstanceTypes defines the default instance types.
+type DefaultInstanceTypes struct {
+	// ControlPlane is the default instance type of the AWSMachines of the control planes.
+	// +kubebuilder:validation:MinLength:=2
+	// +optional
+	ControlPlane string `json:"controlPlane,omitempty"`
+
+	// Worker is the default instance type of the AWSMachines which are not part of a control plane.
+	// +kubebuilder:validation:MinLength:=2
+	// +optional
+	Worker string `json:"worker,omitempty"`
+
+	// Bastion is the default instance type of the bastion hosts of the AWSClusters.
+	// +kubebuilder:validation:MinLength:=2
+	// +optional
+	Bastion string `json:"bastion,omitempty"`
+}
+
+// DefaultKMSKeys defines the default KMS keys.
+type DefaultKMSKeys struct {
+	// VolumeEncryptionKey is the default KMS key, either a key ID or ARN, used to encrypt the encrypted root and non
+	// root volumes of the AWSMachines which don't set one.
+	// +optional
+	VolumeEncryptionKey string `json:"volumeEncryptionKey,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsproviderconfigs,scope=Cluster,categories=cluster-api,shortName=awspc
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of AWSProviderConfig"
+
+// AWSProviderConfig is the Schema for the awsproviderconfigs API.
+// It is a singleton, named default, holding the organization-wide defaults of the AWSClusters and AWSMachines.
+type AWSProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AWSProviderConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AWSProviderConfigList contains a list of AWSProviderConfig.
+type AWSProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSProviderConfig{}, &AWSProviderConfigList{})
+}
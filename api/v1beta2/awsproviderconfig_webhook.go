@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// providerConfigLog is for logging in this package.
+var providerConfigLog = ctrl.Log.WithName("awsproviderconfig-resource")
+
+// providerConfigReader is used by the webhooks to get the AWSProviderConfig when set.
+var providerConfigReader client.Reader
+
+// SetProviderConfigReader sets the reader used by the webhooks to get the AWSProviderConfig whose defaults are
+// applied to the new AWSClusters and AWSMachines. No defaults are applied by default.
+// It should be called before the webhooks are started.
+func SetProviderConfigReader(reader client.Reader) {
+	providerConfigReader = reader
+}
+
+// getProviderConfig returns the spec of the AWSProviderConfig, or nil if there is none. Failing to get it doesn't
+// fail the admission of the objects, which are then not defaulted.
+func getProviderConfig(ctx context.Context) *AWSProviderConfigSpec {
+	if providerConfigReader == nil {
+		return nil
+	}
+
+	config := &AWSProviderConfig{}
+	if err := providerConfigReader.Get(ctx, client.ObjectKey{Name: AWSProviderConfigName}, config); err != nil {
+		if !apierrors.IsNotFound(err) {
+			providerConfigLog.Error(err, "failed to get AWSProviderConfig, the defaults are not applied")
+		}
+		return nil
+	}
+	return &config.Spec
+}
+
+func (r *AWSProviderConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsproviderconfig,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsproviderconfigs,versions=v1beta2,name=validation.awsproviderconfig.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.Validator = &AWSProviderConfig{}
+
+// ValidateCreate will do any extra validation when creating an AWSProviderConfig.
+func (r *AWSProviderConfig) ValidateCreate() (admission.Warnings, error) {
+	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, r.validate())
+}
+
+// ValidateUpdate will do any extra validation when updating an AWSProviderConfig.
+func (r *AWSProviderConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if _, ok := old.(*AWSProviderConfig); !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an AWSProviderConfig but got a %T", old))
+	}
+
+	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, r.validate())
+}
+
+// ValidateDelete allows you to add any extra validation when deleting an AWSProviderConfig.
+func (r *AWSProviderConfig) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *AWSProviderConfig) validate() field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Ensures AWSProviderConfig being singleton by only allowing "default" as name
+	if r.Name != AWSProviderConfigName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"),
+			r.Name, "AWSProviderConfig is a singleton and only acceptable name is default"))
+	}
+
+	allErrs = append(allErrs, r.Spec.RequiredTags.ValidateWithPath(field.NewPath("spec", "requiredTags"))...)
+
+	return allErrs
+}
+
+// defaultAWSCluster applies the defaults to a new AWSCluster.
+func (c *AWSProviderConfigSpec) defaultAWSCluster(cluster *AWSCluster) {
+	cluster.Spec.AdditionalTags = withRequiredTags(cluster.Spec.AdditionalTags, c.RequiredTags)
+
+	if c.InstanceTypes != nil && cluster.Spec.Bastion.Enabled && cluster.Spec.Bastion.InstanceType == "" {
+		cluster.Spec.Bastion.InstanceType = c.InstanceTypes.Bastion
+	}
+}
+
+// defaultAWSMachine applies the defaults to a new AWSMachine.
+func (c *AWSProviderConfigSpec) defaultAWSMachine(machine *AWSMachine) {
+	machine.Spec.AdditionalTags = withRequiredTags(machine.Spec.AdditionalTags, c.RequiredTags)
+
+	if machine.Spec.InstanceMetadataOptions == nil && c.InstanceMetadataOptions != nil {
+		machine.Spec.InstanceMetadataOptions = c.InstanceMetadataOptions.DeepCopy()
+	}
+
+	if c.InstanceTypes != nil && machine.Spec.InstanceType == "" {
+		if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			machine.Spec.InstanceType = c.InstanceTypes.ControlPlane
+		} else {
+			machine.Spec.InstanceType = c.InstanceTypes.Worker
+		}
+	}
+
+	if c.KMSKeys != nil && c.KMSKeys.VolumeEncryptionKey != "" {
+		defaultVolumeEncryptionKey(machine.Spec.RootVolume, c.KMSKeys.VolumeEncryptionKey)
+		for i := range machine.Spec.NonRootVolumes {
+			defaultVolumeEncryptionKey(&machine.Spec.NonRootVolumes[i], c.KMSKeys.VolumeEncryptionKey)
+		}
+	}
+}
+
+// withRequiredTags returns the tags with the required tags they don't already set added.
+func withRequiredTags(tags, required Tags) Tags {
+	if len(required) == 0 {
+		return tags
+	}
+
+	merged := required.DeepCopy()
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// defaultVolumeEncryptionKey sets the KMS key of an encrypted volume which doesn't set one.
+func defaultVolumeEncryptionKey(volume *Volume, key string) {
+	if volume == nil || volume.Encrypted == nil || !*volume.Encrypted || volume.EncryptionKey != "" {
+		return
+	}
+	volume.EncryptionKey = key
+}
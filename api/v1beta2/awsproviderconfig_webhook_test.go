@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAWSProviderConfigValidateCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *AWSProviderConfig
+		wantError bool
+	}{
+		{
+			name: "default config",
+			config: &AWSProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: AWSProviderConfigName},
+				Spec:       AWSProviderConfigSpec{RequiredTags: Tags{"cost-center": "platform"}},
+			},
+		},
+		{
+			name:      "config with another name",
+			config:    &AWSProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			wantError: true,
+		},
+		{
+			name: "invalid required tags",
+			config: &AWSProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: AWSProviderConfigName},
+				Spec:       AWSProviderConfigSpec{RequiredTags: Tags{"aws:owner": "platform"}},
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := tt.config.ValidateCreate()
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAWSProviderConfigDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	SetProviderConfigReader(fake.NewClientBuilder().WithScheme(scheme).WithObjects(&AWSProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: AWSProviderConfigName},
+		Spec: AWSProviderConfigSpec{
+			InstanceMetadataOptions: &InstanceMetadataOptions{HTTPTokens: HTTPTokensStateRequired},
+			RequiredTags:            Tags{"cost-center": "platform", "owner": "infra"},
+			InstanceTypes:           &DefaultInstanceTypes{ControlPlane: "m5.xlarge", Worker: "m5.large", Bastion: "t3.micro"},
+			KMSKeys:                 &DefaultKMSKeys{VolumeEncryptionKey: "alias/volumes"},
+		},
+	}).Build())
+	defer SetProviderConfigReader(nil)
+
+	t.Run("new AWSMachine of a control plane is defaulted", func(t *testing.T) {
+		g := NewWithT(t)
+		machine := &AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{clusterv1.MachineControlPlaneLabel: ""}},
+			Spec: AWSMachineSpec{
+				AdditionalTags: Tags{"owner": "team"},
+				RootVolume:     &Volume{Size: 8, Encrypted: ptr.To(true)},
+				NonRootVolumes: []Volume{
+					{DeviceName: "/dev/sdb", Size: 8, Encrypted: ptr.To(true), EncryptionKey: "alias/data"},
+					{DeviceName: "/dev/sdc", Size: 8},
+				},
+			},
+		}
+		machine.Default()
+
+		g.Expect(machine.Spec.InstanceType).To(Equal("m5.xlarge"))
+		g.Expect(machine.Spec.InstanceMetadataOptions).To(Equal(&InstanceMetadataOptions{HTTPTokens: HTTPTokensStateRequired}))
+		g.Expect(machine.Spec.AdditionalTags).To(Equal(Tags{"cost-center": "platform", "owner": "team"}))
+		g.Expect(machine.Spec.RootVolume.EncryptionKey).To(Equal("alias/volumes"))
+		g.Expect(machine.Spec.NonRootVolumes[0].EncryptionKey).To(Equal("alias/data"))
+		g.Expect(machine.Spec.NonRootVolumes[1].EncryptionKey).To(BeEmpty())
+	})
+
+	t.Run("values set by a new AWSMachine take precedence", func(t *testing.T) {
+		g := NewWithT(t)
+		machine := &AWSMachine{
+			Spec: AWSMachineSpec{
+				InstanceType:            "c5.large",
+				InstanceMetadataOptions: &InstanceMetadataOptions{HTTPTokens: HTTPTokensStateOptional},
+			},
+		}
+		machine.Default()
+
+		g.Expect(machine.Spec.InstanceType).To(Equal("c5.large"))
+		g.Expect(machine.Spec.InstanceMetadataOptions.HTTPTokens).To(Equal(HTTPTokensStateOptional))
+	})
+
+	t.Run("existing AWSMachine isn't defaulted", func(t *testing.T) {
+		g := NewWithT(t)
+		machine := &AWSMachine{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()}}
+		machine.Default()
+
+		g.Expect(machine.Spec.InstanceType).To(BeEmpty())
+		g.Expect(machine.Spec.InstanceMetadataOptions).To(BeNil())
+		g.Expect(machine.Spec.AdditionalTags).To(BeNil())
+	})
+
+	t.Run("new AWSCluster is defaulted", func(t *testing.T) {
+		g := NewWithT(t)
+		cluster := &AWSCluster{Spec: AWSClusterSpec{Bastion: Bastion{Enabled: true}}}
+		cluster.Default()
+
+		g.Expect(cluster.Spec.Bastion.InstanceType).To(Equal("t3.micro"))
+		g.Expect(cluster.Spec.AdditionalTags).To(Equal(Tags{"cost-center": "platform", "owner": "infra"}))
+	})
+}
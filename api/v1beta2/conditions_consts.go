@@ -173,16 +173,61 @@ const (
 )
 
 const (
-	// ELBAttachedCondition will report true when a control plane is successfully registered with an ELB.
+	// EIPAssociatedCondition reports whether a public IPv4 address is associated with the instance of a machine.
+	// Only applicable to machines requesting a public IP address with publicIP.
+	EIPAssociatedCondition clusterv1.ConditionType = "EIPAssociated"
+
+	// WaitingForPublicIPReason is used while the instance of a machine has no public IPv4 address associated.
+	WaitingForPublicIPReason = "WaitingForPublicIP"
+)
+
+const (
+	// VolumesAttachedCondition reports whether the root volume and the non-root volumes of a machine are attached
+	// to its instance.
+	VolumesAttachedCondition clusterv1.ConditionType = "VolumesAttached"
+
+	// VolumesNotAttachedReason is used when some of the volumes of a machine are not attached to its instance.
+	VolumesNotAttachedReason = "VolumesNotAttached"
+)
+
+const (
+	// NodeJoinedCondition reports whether the node of a machine joined the cluster. It is only set when the node
+	// doesn't join the cluster within the node join timeout of the controller, so that the diagnostics collected
+	// from the instance are reported in its message, and is set to true once the node joins.
+	NodeJoinedCondition clusterv1.ConditionType = "NodeJoined"
+
+	// NodeJoinTimeoutReason is used when the node of a machine didn't join the cluster within the node join timeout
+	// after its instance started running.
+	NodeJoinTimeoutReason = "NodeJoinTimeout"
+)
+
+const (
+	// SSMConnectivityCondition reports whether the instance of a machine is registered with Systems Manager and its
+	// SSM agent is online. Only applicable to the machines of clusters using the SSM instance access mode.
+	SSMConnectivityCondition clusterv1.ConditionType = "SSMConnectivity"
+
+	// SSMAgentNotRegisteredReason is used while the SSM agent of the instance of a machine hasn't registered it with
+	// Systems Manager.
+	SSMAgentNotRegisteredReason = "SSMAgentNotRegistered"
+	// SSMAgentOfflineReason is used when the SSM agent of the instance of a machine registered it with Systems
+	// Manager, but isn't online.
+	SSMAgentOfflineReason = "SSMAgentOffline"
+)
+
+const (
+	// ELBAttachedCondition will report true when a control plane is successfully registered with an ELB,
+	// or when a machine is successfully registered with the load balancers of its load balancer attachments.
 	// When set to false, severity can be an Error if the subnet is not found or unavailable in the instance's AZ.
-	// Note this is only applicable to control plane machines.
-	// Only applicable to control plane machines.
+	// Only applicable to control plane machines and to machines with load balancer attachments.
 	ELBAttachedCondition clusterv1.ConditionType = "ELBAttached"
 
 	// ELBAttachFailedReason used when a control plane node fails to attach to the ELB.
 	ELBAttachFailedReason = "ELBAttachFailed"
 	// ELBDetachFailedReason used when a control plane node fails to detach from an ELB.
 	ELBDetachFailedReason = "ELBDetachFailed"
+	// ELBConnectionDrainingReason used when the connections of a deleted control plane node de-registered from
+	// the target groups of a load balancer are being drained.
+	ELBConnectionDrainingReason = "ELBConnectionDraining"
 )
 
 const (
@@ -192,3 +237,103 @@ const (
 	// S3BucketFailedReason is used when any errors occur during reconciliation of an S3 bucket.
 	S3BucketFailedReason = "S3BucketCreationFailed"
 )
+
+const (
+	// OIDCProviderReadyCondition indicates the OIDC discovery documents of the service account issuer
+	// have been published and the IAM OIDC identity provider of the cluster has been created.
+	OIDCProviderReadyCondition clusterv1.ConditionType = "OIDCProviderReady"
+
+	// WaitingForServiceAccountKeyReason is used when the service account signing key of the cluster
+	// hasn't been generated yet.
+	WaitingForServiceAccountKeyReason = "WaitingForServiceAccountKey"
+
+	// OIDCProviderFailedReason is used when any errors occur during reconciliation of the OIDC provider.
+	OIDCProviderFailedReason = "OIDCProviderFailed"
+)
+
+const (
+	// InstanceProfilesReadyCondition indicates the IAM instance profiles managed for the nodes of the
+	// cluster, and their roles, have been created and are up to date.
+	InstanceProfilesReadyCondition clusterv1.ConditionType = "InstanceProfilesReady"
+
+	// InstanceProfilesReconciliationFailedReason is used when any errors occur during reconciliation of the instance profiles.
+	InstanceProfilesReconciliationFailedReason = "InstanceProfilesReconciliationFailed"
+)
+
+const (
+	// IAMPermissionsReadyCondition reports whether the identity used by the controllers is allowed to perform the
+	// key actions needed to create the AWS resources of the cluster, as simulated by the IAM policy simulator.
+	IAMPermissionsReadyCondition clusterv1.ConditionType = "IAMPermissionsReady"
+
+	// IAMPermissionsMissingReason is used when the simulation denies some of the actions needed by the controllers.
+	IAMPermissionsMissingReason = "IAMPermissionsMissing"
+	// IAMPermissionsCheckFailedReason is used when the permissions of the identity couldn't be simulated, e.g.
+	// because the identity isn't allowed to call iam:SimulatePrincipalPolicy.
+	IAMPermissionsCheckFailedReason = "IAMPermissionsCheckFailed"
+)
+
+const (
+	// RequiredTagsReadyCondition reports whether the additional tags of the AWS resources of an AWSCluster or
+	// AWSMachine set all the required tags of the cluster.
+	RequiredTagsReadyCondition clusterv1.ConditionType = "RequiredTagsReady"
+
+	// RequiredTagsMissingReason is used when the additional tags don't set some of the required tags.
+	RequiredTagsMissingReason = "RequiredTagsMissing"
+)
+
+const (
+	// EBSEncryptionKeyReadyCondition reports whether the key policy or the grants of the default EBS encryption key
+	// of an AWSCluster allow the Auto Scaling service-linked role to use it to encrypt the volumes of the instances
+	// of the machine pools.
+	EBSEncryptionKeyReadyCondition clusterv1.ConditionType = "EBSEncryptionKeyReady"
+
+	// EBSEncryptionKeyNotUsableReason is used when the service-linked role isn't allowed to perform some of the
+	// actions needed to encrypt the volumes with the key.
+	EBSEncryptionKeyNotUsableReason = "EBSEncryptionKeyNotUsable"
+	// EBSEncryptionKeyCheckFailedReason is used when the key policy or the grants of the key couldn't be read, e.g.
+	// because the identity used by the controllers isn't allowed to call kms:GetKeyPolicy.
+	EBSEncryptionKeyCheckFailedReason = "EBSEncryptionKeyCheckFailed"
+)
+
+const (
+	// EBSEncryptionByDefaultCondition reports whether the EBS encryption by default setting of the account is enabled
+	// in the region of an AWSCluster.
+	EBSEncryptionByDefaultCondition clusterv1.ConditionType = "EBSEncryptionByDefault"
+
+	// EBSEncryptionByDefaultDisabledReason is used when the EBS encryption by default is disabled.
+	EBSEncryptionByDefaultDisabledReason = "EBSEncryptionByDefaultDisabled"
+	// EBSEncryptionByDefaultCheckFailedReason is used when the EBS encryption by default couldn't be read or enabled,
+	// e.g. because the identity used by the controllers isn't allowed to call ec2:GetEbsEncryptionByDefault.
+	EBSEncryptionByDefaultCheckFailedReason = "EBSEncryptionByDefaultCheckFailed"
+)
+
+const (
+	// APIServerHealthCheckReadyCondition reports whether the Route 53 health check of the control plane endpoint of
+	// an AWSCluster exists and reports the endpoint as healthy.
+	APIServerHealthCheckReadyCondition clusterv1.ConditionType = "APIServerHealthCheckReady"
+
+	// APIServerHealthCheckUnhealthyReason is used when the health checkers don't report the endpoint as healthy.
+	APIServerHealthCheckUnhealthyReason = "APIServerHealthCheckUnhealthy"
+	// APIServerHealthCheckFailedReason is used when the health check couldn't be created, updated or read, e.g.
+	// because the identity used by the controllers isn't allowed to call route53:CreateHealthCheck.
+	APIServerHealthCheckFailedReason = "APIServerHealthCheckFailed"
+)
+
+const (
+	// StandbyNetworkReadyCondition reports whether the VPC, the subnets and the security groups of an AWSCluster are
+	// reconciled in its standby region.
+	StandbyNetworkReadyCondition clusterv1.ConditionType = "StandbyNetworkReady"
+
+	// StandbyNetworkReconciliationFailedReason is used when the network of the standby region couldn't be reconciled.
+	StandbyNetworkReconciliationFailedReason = "StandbyNetworkReconciliationFailed"
+	// StandbyNetworkDeletionFailedReason is used when the network of the standby region couldn't be deleted.
+	StandbyNetworkDeletionFailedReason = "StandbyNetworkDeletionFailed"
+)
+
+const (
+	// DeletingFailedReason is used when the resources reported by a condition couldn't be deleted.
+	DeletingFailedReason = "DeletingFailed"
+	// DeletingBlockedReason is used when the resources reported by a condition aren't deleted yet because the
+	// resources they depend on couldn't be deleted.
+	DeletingBlockedReason = "DeletingBlocked"
+)
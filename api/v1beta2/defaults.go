@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta2
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -35,24 +37,44 @@ func SetDefaults_NetworkSpec(obj *NetworkSpec) { //nolint:golint,stylecheck
 	// Default to Calico ingress rules if no rules have been set
 	if obj.CNI == nil {
 		obj.CNI = &CNISpec{
-			CNIIngressRules: CNIIngressRules{
-				{
-					Description: "bgp (calico)",
-					Protocol:    SecurityGroupProtocolTCP,
-					FromPort:    179,
-					ToPort:      179,
-				},
-				{
-					Description: "IP-in-IP (calico)",
-					Protocol:    SecurityGroupProtocolIPinIP,
-					FromPort:    -1,
-					ToPort:      65535,
-				},
-			},
+			CNIIngressRules: defaultCNIIngressRules(),
 		}
 	}
 }
 
+// defaultCNIIngressRules returns the ingress rules of Calico in IP-in-IP mode.
+func defaultCNIIngressRules() CNIIngressRules {
+	return CNIIngressRules{
+		{
+			Description: "bgp (calico)",
+			Protocol:    SecurityGroupProtocolTCP,
+			FromPort:    179,
+			ToPort:      179,
+		},
+		{
+			Description: "IP-in-IP (calico)",
+			Protocol:    SecurityGroupProtocolIPinIP,
+			FromPort:    -1,
+			ToPort:      65535,
+		},
+	}
+}
+
+// WindowsCNIIngressRules returns the CNI ingress rules of a cluster with Windows machines. Windows doesn't support
+// IP-in-IP, so Calico uses VXLAN for their pods, and its VXLAN traffic is allowed in addition to the default rules.
+// Rules set by the user are returned as is.
+func WindowsCNIIngressRules(rules CNIIngressRules) CNIIngressRules {
+	if !slices.Equal(rules, defaultCNIIngressRules()) {
+		return rules
+	}
+	return append(slices.Clone(rules), CNIIngressRule{
+		Description: "vxlan (calico)",
+		Protocol:    SecurityGroupProtocolUDP,
+		FromPort:    4789,
+		ToPort:      4789,
+	})
+}
+
 // SetDefaults_AWSClusterSpec is used by defaulter-gen.
 func SetDefaults_AWSClusterSpec(s *AWSClusterSpec) { //nolint:golint,stylecheck
 	if s.IdentityRef == nil {
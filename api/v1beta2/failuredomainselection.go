@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates FailureDomainSelection fields.
+func (s *FailureDomainSelection) Validate() field.ErrorList {
+	if s == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fldPath := field.NewPath("spec", "failureDomainSelection")
+
+	for i, zone := range s.AllowedAvailabilityZones {
+		if zone == "" {
+			errs = append(errs, field.Required(fldPath.Child("allowedAvailabilityZones").Index(i), "can't be empty"))
+		}
+	}
+	for i, zone := range s.DeniedAvailabilityZones {
+		if zone == "" {
+			errs = append(errs, field.Required(fldPath.Child("deniedAvailabilityZones").Index(i), "can't be empty"))
+			continue
+		}
+		if slices.Contains(s.AllowedAvailabilityZones, zone) {
+			errs = append(errs, field.Invalid(fldPath.Child("deniedAvailabilityZones").Index(i), zone, "availability zone is also allowed"))
+		}
+	}
+	for i, instanceType := range s.InstanceTypes {
+		if instanceType == "" {
+			errs = append(errs, field.Required(fldPath.Child("instanceTypes").Index(i), "can't be empty"))
+		}
+	}
+
+	return errs
+}
+
+// AllowsAvailabilityZone returns whether an availability zone can be reported as a failure domain.
+func (s *FailureDomainSelection) AllowsAvailabilityZone(zone string) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.AllowedAvailabilityZones) > 0 && !slices.Contains(s.AllowedAvailabilityZones, zone) {
+		return false
+	}
+	return !slices.Contains(s.DeniedAvailabilityZones, zone)
+}
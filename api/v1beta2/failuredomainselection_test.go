@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFailureDomainSelectionValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		selection  *FailureDomainSelection
+		expectErrs int
+	}{
+		{
+			name: "nil selection",
+		},
+		{
+			name: "valid selection",
+			selection: &FailureDomainSelection{
+				AllowedAvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+				DeniedAvailabilityZones:  []string{"us-east-1c"},
+				InstanceTypes:            []string{"m5.large"},
+			},
+		},
+		{
+			name:       "empty availability zones",
+			selection:  &FailureDomainSelection{AllowedAvailabilityZones: []string{""}, DeniedAvailabilityZones: []string{""}},
+			expectErrs: 2,
+		},
+		{
+			name:       "empty instance type",
+			selection:  &FailureDomainSelection{InstanceTypes: []string{""}},
+			expectErrs: 1,
+		},
+		{
+			name: "availability zone both allowed and denied",
+			selection: &FailureDomainSelection{
+				AllowedAvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+				DeniedAvailabilityZones:  []string{"us-east-1b"},
+			},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.selection.Validate()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
+
+func TestFailureDomainSelectionAllowsAvailabilityZone(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection *FailureDomainSelection
+		zone      string
+		expected  bool
+	}{
+		{
+			name:     "nil selection",
+			zone:     "us-east-1a",
+			expected: true,
+		},
+		{
+			name:      "allowed availability zone",
+			selection: &FailureDomainSelection{AllowedAvailabilityZones: []string{"us-east-1a"}},
+			zone:      "us-east-1a",
+			expected:  true,
+		},
+		{
+			name:      "availability zone not allowed",
+			selection: &FailureDomainSelection{AllowedAvailabilityZones: []string{"us-east-1a"}},
+			zone:      "us-east-1b",
+		},
+		{
+			name:      "denied availability zone",
+			selection: &FailureDomainSelection{DeniedAvailabilityZones: []string{"us-east-1a"}},
+			zone:      "us-east-1a",
+		},
+		{
+			name:      "availability zone not denied",
+			selection: &FailureDomainSelection{DeniedAvailabilityZones: []string{"us-east-1a"}},
+			zone:      "us-east-1b",
+			expected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.selection.AllowsAvailabilityZone(tt.zone)).To(Equal(tt.expected))
+		})
+	}
+}
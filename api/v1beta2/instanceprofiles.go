@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// ManagedInstanceProfileNamePrefix prefixes the names of the IAM instance profiles managed for the
+	// clusters and of their roles, so that the permissions of the controllers can be restricted to them.
+	ManagedInstanceProfileNamePrefix = "capa-"
+
+	// DefaultInstanceProfilePolicyName is the name of the inline policy of the roles of the managed
+	// instance profiles granting the permissions required by the AWS cloud provider and by the bootstrap
+	// of the nodes.
+	DefaultInstanceProfilePolicyName = "cluster-api-provider-aws"
+)
+
+// Validate validates the managed policy ARNs and the inline policies of the instance profile templates.
+func (p *ManagedInstanceProfiles) Validate() field.ErrorList {
+	if p == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "instanceProfiles")
+	allErrs = append(allErrs, p.ControlPlane.validate(fldPath.Child("controlPlane"))...)
+	allErrs = append(allErrs, p.Nodes.validate(fldPath.Child("nodes"))...)
+
+	return allErrs
+}
+
+// ValidateUpdate validates that the instance profile templates are not removed, as the instances
+// using the instance profiles would lose their permissions.
+func (p *ManagedInstanceProfiles) ValidateUpdate(old *ManagedInstanceProfiles) field.ErrorList {
+	if old == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "instanceProfiles")
+	if old.ControlPlane != nil && (p == nil || p.ControlPlane == nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlane"), "cannot be removed once set"))
+	}
+	if old.Nodes != nil && (p == nil || p.Nodes == nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodes"), "cannot be removed once set"))
+	}
+
+	return allErrs
+}
+
+func (t *InstanceProfileTemplate) validate(fldPath *field.Path) field.ErrorList {
+	if t == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	for i, policyARN := range t.ManagedPolicyARNs {
+		if parsed, err := arn.Parse(policyARN); err != nil || parsed.Service != "iam" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("managedPolicyARNs").Index(i), policyARN, "must be the ARN of an IAM managed policy"))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, policy := range t.InlinePolicies {
+		policyPath := fldPath.Child("inlinePolicies").Index(i)
+		if policy.Name == DefaultInstanceProfilePolicyName {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("name"), policy.Name, "is reserved for the default policy"))
+		}
+		if names[policy.Name] {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names[policy.Name] = true
+
+		if !json.Valid([]byte(policy.PolicyDocument)) {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("policyDocument"), policy.PolicyDocument, "must be a valid JSON document"))
+		}
+	}
+
+	allErrs = append(allErrs, t.AdditionalTags.Validate()...)
+
+	return allErrs
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestManagedInstanceProfilesValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		profiles   *ManagedInstanceProfiles
+		expectErrs int
+	}{
+		{
+			name: "nil profiles",
+		},
+		{
+			name: "valid templates",
+			profiles: &ManagedInstanceProfiles{
+				ControlPlane: &InstanceProfileTemplate{},
+				Nodes: &InstanceProfileTemplate{
+					ManagedPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly"},
+					InlinePolicies:    []InlinePolicy{{Name: "s3", PolicyDocument: `{"Version":"2012-10-17","Statement":[]}`}},
+				},
+			},
+		},
+		{
+			name: "invalid managed policy ARN",
+			profiles: &ManagedInstanceProfiles{
+				Nodes: &InstanceProfileTemplate{ManagedPolicyARNs: []string{"AmazonEC2ContainerRegistryReadOnly"}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "reserved and duplicate inline policy names",
+			profiles: &ManagedInstanceProfiles{
+				Nodes: &InstanceProfileTemplate{InlinePolicies: []InlinePolicy{
+					{Name: DefaultInstanceProfilePolicyName, PolicyDocument: "{}"},
+					{Name: "s3", PolicyDocument: "{}"},
+					{Name: "s3", PolicyDocument: "{}"},
+				}},
+			},
+			expectErrs: 2,
+		},
+		{
+			name: "invalid policy document",
+			profiles: &ManagedInstanceProfiles{
+				ControlPlane: &InstanceProfileTemplate{InlinePolicies: []InlinePolicy{{Name: "s3", PolicyDocument: "{"}}},
+			},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.profiles.Validate()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
+
+func TestManagedInstanceProfilesValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	old := &ManagedInstanceProfiles{ControlPlane: &InstanceProfileTemplate{}, Nodes: &InstanceProfileTemplate{}}
+	g.Expect((&ManagedInstanceProfiles{ControlPlane: &InstanceProfileTemplate{}, Nodes: &InstanceProfileTemplate{}}).ValidateUpdate(old)).To(BeEmpty())
+	g.Expect((&ManagedInstanceProfiles{ControlPlane: &InstanceProfileTemplate{}}).ValidateUpdate(old)).To(HaveLen(1))
+	g.Expect((*ManagedInstanceProfiles)(nil).ValidateUpdate(old)).To(HaveLen(2))
+	g.Expect((*ManagedInstanceProfiles)(nil).ValidateUpdate(nil)).To(BeEmpty())
+}
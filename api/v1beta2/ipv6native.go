@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// xenInstanceFamilies are the instance families which aren't built on the Nitro System, and whose instances can't be
+// launched with IPv6 addressing only.
+var xenInstanceFamilies = sets.New(
+	"c1", "c3", "c4", "cc2", "cr1", "d2", "f1", "g2", "g3", "g3s", "h1", "hs1", "i2", "i3",
+	"m1", "m2", "m3", "m4", "p2", "p3", "r3", "r4", "t1", "t2", "x1", "x1e",
+)
+
+// InstanceTypeSupportsIPv6Only returns true if the instances of an instance type, e.g. m5.large, can be launched with
+// IPv6 addressing only.
+func InstanceTypeSupportsIPv6Only(instanceType string) bool {
+	family, _, ok := strings.Cut(instanceType, ".")
+	return ok && !xenInstanceFamilies.Has(family)
+}
+
+// ValidateIPv6NativeSubnets validates the IPv6-native subnets and the subnets with DNS64 enabled.
+func (n *NetworkSpec) ValidateIPv6NativeSubnets(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, subnet := range n.Subnets {
+		subnetPath := fldPath.Child("subnets").Index(i)
+
+		if subnet.IPv6Native {
+			if !n.VPC.IsIPv6Enabled() {
+				errs = append(errs, field.Forbidden(subnetPath.Child("ipv6Native"), "requires a VPC with IPv6 enabled"))
+			}
+			if subnet.CidrBlock != "" {
+				errs = append(errs, field.Forbidden(subnetPath.Child("cidrBlock"), "can't be set for an IPv6-native subnet"))
+			}
+			if subnet.IsEdge() {
+				errs = append(errs, field.Forbidden(subnetPath.Child("ipv6Native"), "IPv6 is not supported in Local Zones and Wavelength Zones"))
+			}
+		}
+
+		if subnet.EnableDNS64 {
+			if !n.VPC.IsIPv6Enabled() {
+				errs = append(errs, field.Forbidden(subnetPath.Child("enableDns64"), "requires a VPC with IPv6 enabled"))
+			}
+			if subnet.IsEdge() {
+				errs = append(errs, field.Forbidden(subnetPath.Child("enableDns64"), "IPv6 is not supported in Local Zones and Wavelength Zones"))
+			}
+		}
+	}
+
+	return errs
+}
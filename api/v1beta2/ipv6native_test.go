@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkSpecValidateIPv6NativeSubnets(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    NetworkSpec
+		expectErrs int
+	}{
+		{
+			name: "dual-stack subnets",
+			network: NetworkSpec{
+				Subnets: Subnets{{ID: "private-1a", CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a"}},
+			},
+		},
+		{
+			name: "IPv6-native subnet with DNS64",
+			network: NetworkSpec{
+				VPC: VPCSpec{IPv6: &IPv6{}},
+				Subnets: Subnets{
+					{ID: "private-1a", CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db8:1234:1a00::/64", AvailabilityZone: "us-east-1a"},
+					{ID: "private-1a-ipv6", IPv6CidrBlock: "2001:db8:1234:1a01::/64", AvailabilityZone: "us-east-1a", IPv6Native: true, EnableDNS64: true},
+				},
+			},
+		},
+		{
+			name: "IPv6-native subnet and DNS64 without IPv6",
+			network: NetworkSpec{
+				Subnets: Subnets{{ID: "private-1a", AvailabilityZone: "us-east-1a", IPv6Native: true, EnableDNS64: true}},
+			},
+			expectErrs: 2,
+		},
+		{
+			name: "IPv6-native subnet with an IPv4 CIDR block",
+			network: NetworkSpec{
+				VPC:     VPCSpec{IPv6: &IPv6{}},
+				Subnets: Subnets{{ID: "private-1a", CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IPv6Native: true}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "IPv6-native subnet in a Local Zone",
+			network: NetworkSpec{
+				VPC: VPCSpec{IPv6: &IPv6{}},
+				Subnets: Subnets{{
+					ID:               "private-nyc-1a",
+					AvailabilityZone: "us-east-1-nyc-1a",
+					ZoneType:         ptr.To(ZoneTypeLocalZone),
+					IPv6Native:       true,
+					EnableDNS64:      true,
+				}},
+			},
+			expectErrs: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.network.ValidateIPv6NativeSubnets(field.NewPath("spec", "network"))).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
+
+func TestInstanceTypeSupportsIPv6Only(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		want         bool
+	}{
+		{instanceType: "m5.large", want: true},
+		{instanceType: "t3.micro", want: true},
+		{instanceType: "i3en.xlarge", want: true},
+		{instanceType: "p3dn.24xlarge", want: true},
+		{instanceType: "t2.micro"},
+		{instanceType: "m4.large"},
+		{instanceType: "i3.large"},
+		{instanceType: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instanceType, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(InstanceTypeSupportsIPv6Only(tt.instanceType)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateIPv6Only(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       AWSMachineSpec
+		expectErrs int
+	}{
+		{
+			name: "dual-stack machine",
+			spec: AWSMachineSpec{InstanceType: "t2.micro", PublicIP: ptr.To(true)},
+		},
+		{
+			name: "IPv6-only machine",
+			spec: AWSMachineSpec{InstanceType: "m5.large", IPv6Only: true, PrivateDNSName: &PrivateDNSName{HostnameType: ptr.To("resource-name")}},
+		},
+		{
+			name:       "IPv6-only machine with a Xen instance type",
+			spec:       AWSMachineSpec{InstanceType: "t2.micro", IPv6Only: true},
+			expectErrs: 1,
+		},
+		{
+			name:       "IPv6-only machine with IPv4 addressing",
+			spec:       AWSMachineSpec{InstanceType: "m5.large", IPv6Only: true, PublicIP: ptr.To(true), PrivateDNSName: &PrivateDNSName{HostnameType: ptr.To("ip-name")}},
+			expectErrs: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(validateIPv6Only(&tt.spec, field.NewPath("spec"))).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
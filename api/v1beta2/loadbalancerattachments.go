@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates LoadBalancerAttachments fields.
+func (a *LoadBalancerAttachments) Validate(fldPath *field.Path) []*field.Error {
+	if a == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	errs = append(errs, ValidateTargetGroupARNs(fldPath.Child("targetGroupARNs"), a.TargetGroupARNs)...)
+
+	for i, name := range a.ClassicLoadBalancerNames {
+		if name == "" {
+			errs = append(errs, field.Required(fldPath.Child("classicLoadBalancerNames").Index(i), "can't be empty"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateTargetGroupARNs validates a list of target group ARNs.
+func ValidateTargetGroupARNs(fldPath *field.Path, targetGroupARNs []string) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, targetGroupARN := range targetGroupARNs {
+		if parsed, err := arn.Parse(targetGroupARN); err != nil || parsed.Service != "elasticloadbalancing" {
+			errs = append(errs, field.Invalid(fldPath.Index(i), targetGroupARN, "must be the ARN of a target group"))
+		}
+	}
+
+	return errs
+}
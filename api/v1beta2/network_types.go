@@ -209,16 +209,49 @@ var (
 	TargetGroupAttributeEnablePreserveClientIP = "preserve_client_ip.enabled"
 )
 
+// TargetType defines how the targets are registered with a target group.
+type TargetType string
+
+const (
+	// TargetTypeInstance registers the targets by instance ID.
+	TargetTypeInstance = TargetType("instance")
+	// TargetTypeIP registers the targets by IP address.
+	TargetTypeIP = TargetType("ip")
+)
+
+// DNSClientRoutingPolicy defines how the clients of a network load balancer are routed to its availability zones.
+type DNSClientRoutingPolicy string
+
+const (
+	// DNSClientRoutingPolicyAnyAvailabilityZone routes the clients to any availability zone.
+	DNSClientRoutingPolicyAnyAvailabilityZone = DNSClientRoutingPolicy("any_availability_zone")
+	// DNSClientRoutingPolicyAvailabilityZoneAffinity routes the clients to their own availability zone,
+	// as long as it has healthy targets.
+	DNSClientRoutingPolicyAvailabilityZoneAffinity = DNSClientRoutingPolicy("availability_zone_affinity")
+	// DNSClientRoutingPolicyPartialAvailabilityZoneAffinity routes 85% of the clients to their own availability zone.
+	DNSClientRoutingPolicyPartialAvailabilityZoneAffinity = DNSClientRoutingPolicy("partial_availability_zone_affinity")
+)
+
 // LoadBalancerAttribute defines a set of attributes for a V2 load balancer.
 type LoadBalancerAttribute string
 
 var (
 	// LoadBalancerAttributeEnableLoadBalancingCrossZone defines the attribute key for enabling load balancing cross zone.
 	LoadBalancerAttributeEnableLoadBalancingCrossZone = "load_balancing.cross_zone.enabled"
+	// LoadBalancerAttributeDeletionProtection defines the attribute key for enabling deletion protection.
+	LoadBalancerAttributeDeletionProtection = "deletion_protection.enabled"
 	// LoadBalancerAttributeIdleTimeTimeoutSeconds defines the attribute key for idle timeout.
 	LoadBalancerAttributeIdleTimeTimeoutSeconds = "idle_timeout.timeout_seconds"
 	// LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds defines the default idle timeout in seconds.
 	LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds = "60"
+	// LoadBalancerAttributeAccessLogsEnabled defines the attribute key for enabling access logs.
+	LoadBalancerAttributeAccessLogsEnabled = "access_logs.s3.enabled"
+	// LoadBalancerAttributeAccessLogsBucket defines the attribute key for the S3 bucket of the access logs.
+	LoadBalancerAttributeAccessLogsBucket = "access_logs.s3.bucket"
+	// LoadBalancerAttributeAccessLogsPrefix defines the attribute key for the S3 prefix of the access logs.
+	LoadBalancerAttributeAccessLogsPrefix = "access_logs.s3.prefix"
+	// LoadBalancerAttributeDNSClientRoutingPolicy defines the attribute key for the DNS client routing policy.
+	LoadBalancerAttributeDNSClientRoutingPolicy = "dns_record.client_routing_policy"
 )
 
 // TargetGroupSpec specifies target group settings for a given listener.
@@ -289,6 +322,10 @@ type LoadBalancer struct {
 	// LoadBalancerType sets the type for a load balancer. The default type is classic.
 	// +kubebuilder:validation:Enum:=classic;elb;alb;nlb
 	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// IPAddressType is the IP address type of a v2 load balancer.
+	// +optional
+	IPAddressType LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
 }
 
 // IsUnmanaged returns true if the Classic ELB is unmanaged.
@@ -310,6 +347,10 @@ type ClassicELBAttributes struct {
 	// CrossZoneLoadBalancing enables the classic load balancer load balancing.
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// AccessLogs is the access logs configuration of the classic load balancer.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -455,6 +496,23 @@ type VPCSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=ip-name;resource-name
 	PrivateDNSHostnameTypeOnLaunch *string `json:"privateDnsHostnameTypeOnLaunch,omitempty"`
+
+	// SecondaryCidrBlocks are additional IPv4 CIDR blocks associated with the VPC, e.g. to grow its
+	// subnets with replacement subnets declared in them.
+	//
+	// NOTE: This only applies when the VPC is managed by the Cluster API AWS controller.
+	//
+	// +listType=map
+	// +listMapKey=ipv4CidrBlock
+	// +optional
+	SecondaryCidrBlocks []VpcCidrBlock `json:"secondaryCidrBlocks,omitempty"`
+}
+
+// VpcCidrBlock defines a CIDR block associated with a VPC.
+type VpcCidrBlock struct {
+	// IPv4CidrBlock is the IPv4 CIDR block to associate with the VPC.
+	// +kubebuilder:validation:MinLength=1
+	IPv4CidrBlock string `json:"ipv4CidrBlock"`
 }
 
 // String returns a string representation of the VPC.
@@ -515,6 +573,21 @@ type SubnetSpec struct {
 	// +optional
 	IsIPv6 bool `json:"isIpv6,omitempty"`
 
+	// IPv6Native defines the subnet as an IPv6-only subnet, which has an IPv6 CIDR block but no IPv4 CIDR block.
+	// Only the machines which are IPv6-only are placed in IPv6-native subnets, and no NAT gateway is created in
+	// the public ones. The CIDR block must not be set, and the VPC must have IPv6 enabled.
+	// IPv6 is only supported in managed clusters, this field cannot be set on AWSCluster object.
+	// +optional
+	IPv6Native bool `json:"ipv6Native,omitempty"`
+
+	// EnableDNS64 enables DNS64 on the subnet, so that the DNS queries of IPv6-only workloads for IPv4-only
+	// destinations return synthesized IPv6 addresses. When the VPC is managed, the route table of the subnet routes
+	// these addresses (64:ff9b::/96) through the NAT gateway of the availability zone, which translates them (NAT64).
+	// DNS64 and the NAT64 route are configured when the subnet is created. The VPC must have IPv6 enabled.
+	// IPv6 is only supported in managed clusters, this field cannot be set on AWSCluster object.
+	// +optional
+	EnableDNS64 bool `json:"enableDns64,omitempty"`
+
 	// RouteTableID is the routing table id associated with the subnet.
 	// +optional
 	RouteTableID *string `json:"routeTableId,omitempty"`
@@ -562,6 +635,15 @@ type SubnetSpec struct {
 	//
 	// +optional
 	ParentZoneName *string `json:"parentZoneName,omitempty"`
+
+	// Replaces is the ID or the resource ID of the subnet of the same availability zone this subnet
+	// replaces, e.g. to grow the subnets of the zone into a secondary CIDR block of the VPC.
+	// Once this subnet is created, the new machines and load balancers are placed in it instead of
+	// the replaced subnet and, when the VPC is managed, the private subnets of the zone route their
+	// traffic through the NAT gateway of the replacement public subnet. The replaced subnet is tagged
+	// for manual draining; it is not deleted until it is removed from the subnets and from AWS.
+	// +optional
+	Replaces string `json:"replaces,omitempty"`
 }
 
 // GetResourceID returns the identifier for this subnet,
@@ -693,7 +775,7 @@ func (s Subnets) FindEqual(spec *SubnetSpec) *SubnetSpec {
 	for i := range s {
 		x := &(s[i]) // pointer to original structure
 		if (spec.GetResourceID() != "" && x.GetResourceID() == spec.GetResourceID()) ||
-			(spec.CidrBlock == x.CidrBlock) ||
+			(spec.CidrBlock != "" && spec.CidrBlock == x.CidrBlock) ||
 			(spec.IPv6CidrBlock != "" && spec.IPv6CidrBlock == x.IPv6CidrBlock) {
 			return x
 		}
@@ -739,6 +821,77 @@ func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	return
 }
 
+// ReplacementOf returns the subnet replacing the subnet passed in, if any.
+//
+// The returned pointer can be used to write back into the original slice.
+func (s Subnets) ReplacementOf(subnet *SubnetSpec) *SubnetSpec {
+	for i := range s {
+		x := &(s[i]) // pointer to original structure
+		if x.Replaces != "" && (x.Replaces == subnet.ID || x.Replaces == subnet.GetResourceID()) {
+			return x
+		}
+	}
+	return nil
+}
+
+// IsReplaced returns true if the subnet passed in is replaced by a subnet of the slice which has been created.
+func (s Subnets) IsReplaced(subnet *SubnetSpec) bool {
+	replacement := s.ReplacementOf(subnet)
+	return replacement != nil && replacement.ResourceID != ""
+}
+
+// Replaced returns a slice containing all subnets replaced by a subnet of the slice which has been created.
+func (s Subnets) Replaced() (res Subnets) {
+	for i := range s {
+		if s.IsReplaced(&s[i]) {
+			res = append(res, s[i])
+		}
+	}
+	return
+}
+
+// FilterIPv6Native returns a slice containing all subnets which are IPv6-native.
+func (s Subnets) FilterIPv6Native() (res Subnets) {
+	for _, x := range s {
+		if x.IPv6Native {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
+// WithoutIPv6Native returns a slice containing all subnets which have an IPv4 CIDR block, i.e. which aren't
+// IPv6-native.
+func (s Subnets) WithoutIPv6Native() (res Subnets) {
+	for _, x := range s {
+		if !x.IPv6Native {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
+// HasIPv6CidrBlock returns true if any of the subnets has an IPv6 CIDR block.
+func (s Subnets) HasIPv6CidrBlock() bool {
+	for _, x := range s {
+		if x.IsIPv6 || x.IPv6CidrBlock != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutReplaced returns a slice containing all subnets that aren't replaced by a subnet of the slice which has
+// been created.
+func (s Subnets) WithoutReplaced() (res Subnets) {
+	for i := range s {
+		if !s.IsReplaced(&s[i]) {
+			res = append(res, s[i])
+		}
+	}
+	return
+}
+
 // GetUniqueZones returns a slice containing the unique zones of the subnets.
 func (s Subnets) GetUniqueZones() []string {
 	keys := make(map[string]bool)
@@ -799,7 +952,7 @@ type RouteTable struct {
 }
 
 // SecurityGroupRole defines the unique role of a security group.
-// +kubebuilder:validation:Enum=bastion;node;controlplane;apiserver-lb;lb;node-eks-additional
+// +kubebuilder:validation:Enum=bastion;node;controlplane;apiserver-lb;apiserver-lb-secondary;lb;node-eks-additional
 type SecurityGroupRole string
 
 var (
@@ -818,6 +971,9 @@ var (
 	// SecurityGroupAPIServerLB defines a Kubernetes API Server Load Balancer role.
 	SecurityGroupAPIServerLB = SecurityGroupRole("apiserver-lb")
 
+	// SecurityGroupSecondaryAPIServerLB defines a secondary Kubernetes API Server Load Balancer role.
+	SecurityGroupSecondaryAPIServerLB = SecurityGroupRole("apiserver-lb-secondary")
+
 	// SecurityGroupLB defines a container for the cloud provider to inject its load balancer ingress rules.
 	SecurityGroupLB = SecurityGroupRole("lb")
 )
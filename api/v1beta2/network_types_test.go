@@ -848,3 +848,55 @@ func TestSubnets_HasPublicSubnetWavelength(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnets_Replacements(t *testing.T) {
+	tests := []struct {
+		name            string
+		subnets         Subnets
+		wantReplaced    []string
+		wantNotReplaced []string
+	}{
+		{
+			name: "no replacement",
+			subnets: Subnets{
+				{ID: "private-1a", ResourceID: "subnet-1"},
+				{ID: "public-1a", ResourceID: "subnet-2", IsPublic: true},
+			},
+			wantNotReplaced: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name: "replacement not created yet",
+			subnets: Subnets{
+				{ID: "private-1a", ResourceID: "subnet-1"},
+				{ID: "private-1a-grown", Replaces: "private-1a"},
+			},
+			wantNotReplaced: []string{"subnet-1", "private-1a-grown"},
+		},
+		{
+			name: "replacement referencing the ID of the subnet",
+			subnets: Subnets{
+				{ID: "private-1a", ResourceID: "subnet-1"},
+				{ID: "public-1a", ResourceID: "subnet-2", IsPublic: true},
+				{ID: "private-1a-grown", ResourceID: "subnet-3", Replaces: "private-1a"},
+			},
+			wantReplaced:    []string{"subnet-1"},
+			wantNotReplaced: []string{"subnet-2", "subnet-3"},
+		},
+		{
+			name: "replacement referencing the resource ID of the subnet",
+			subnets: Subnets{
+				{ID: "subnet-1"},
+				{ID: "subnet-3", ResourceID: "subnet-3", Replaces: "subnet-1"},
+			},
+			wantReplaced:    []string{"subnet-1"},
+			wantNotReplaced: []string{"subnet-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.subnets.Replaced().IDsWithEdge()).To(ConsistOf(tt.wantReplaced))
+			g.Expect(tt.subnets.WithoutReplaced().IDsWithEdge()).To(ConsistOf(tt.wantNotReplaced))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates that the proxy is an HTTP(S) URL and that the CA bundle contains PEM encoded certificates.
+func (p *ProxyConfig) Validate() field.ErrorList {
+	if p == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec", "proxy")
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(fldPath.Child("url"), p.URL, "must be an http or https URL"))
+		}
+	}
+	if p.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(p.CABundle)) {
+		errs = append(errs, field.Invalid(fldPath.Child("caBundle"), field.OmitValueType{}, "must contain PEM encoded certificates"))
+	}
+
+	return errs
+}
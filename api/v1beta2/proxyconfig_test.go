@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// testCABundle is a self-signed certificate of proxy.example.com.
+const testCABundle = `-----BEGIN CERTIFICATE-----
+MIIBkDCCATWgAwIBAgIUKzbaLKEWF5PsGHOkN9L4MT80cYYwCgYIKoZIzj0EAwIw
+HDEaMBgGA1UEAwwRcHJveHkuZXhhbXBsZS5jb20wIBcNMjYxMDE2MDgwMTIxWhgP
+MjEyNjA5MjIwODAxMjFaMBwxGjAYBgNVBAMMEXByb3h5LmV4YW1wbGUuY29tMFkw
+EwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEam7W4nIthcBBhHC/hNUBsNTpHIbSM8sa
+0jPbFccHG9FkZwO4i4cTXTKJLvgp3E4egsVOLGQiTbRnerd0daPwGKNTMFEwHQYD
+VR0OBBYEFDBdHnYzny0Sd3/jbX95A+bwETIbMB8GA1UdIwQYMBaAFDBdHnYzny0S
+d3/jbX95A+bwETIbMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSQAwRgIh
+AOLqqRopXaeh78DiVs+vUQ7YZWV73ekgEYvcSvH8R16ZAiEAyUYCUermkOoKj8wQ
+dOvBi5oq3J5tOnt5LVlGfR8DXoI=
+-----END CERTIFICATE-----
+`
+
+func TestProxyConfigValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxy      *ProxyConfig
+		expectErrs int
+	}{
+		{
+			name: "nil proxy",
+		},
+		{
+			name:  "valid proxy",
+			proxy: &ProxyConfig{URL: "http://proxy.example.com:3128", CABundle: testCABundle},
+		},
+		{
+			name:  "CA bundle without proxy",
+			proxy: &ProxyConfig{CABundle: testCABundle},
+		},
+		{
+			name:       "invalid URL",
+			proxy:      &ProxyConfig{URL: "socks5://proxy.example.com:1080"},
+			expectErrs: 1,
+		},
+		{
+			name:       "invalid CA bundle",
+			proxy:      &ProxyConfig{URL: "https://proxy.example.com", CABundle: "not a certificate"},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.proxy.Validate()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta2
+
+import (
+	"slices"
+
+	"github.com/aws/aws-sdk-go/service/route53"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+// minRoute53HealthCheckRegions is the minimum number of regions of the health checkers accepted by Route 53.
+const minRoute53HealthCheckRegions = 3
+
+// Validate validates the regions of the health checkers.
+func (h *Route53HealthCheck) Validate() field.ErrorList {
+	if h == nil || len(h.Regions) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "apiServerHealthCheck", "regions")
+	if len(h.Regions) < minRoute53HealthCheckRegions {
+		allErrs = append(allErrs, field.Invalid(fldPath, h.Regions, "at least 3 regions must be set"))
+	}
+
+	supported := route53.HealthCheckRegion_Values()
+	for i, region := range h.Regions {
+		if !slices.Contains(supported, region) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), region, supported))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateUpdate validates that the protocol and the request interval of an existing health check, which
+// Route 53 can't update, aren't changed.
+func (h *Route53HealthCheck) ValidateUpdate(old *Route53HealthCheck) field.ErrorList {
+	if h == nil || old == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "apiServerHealthCheck")
+	if h.GetProtocol() != old.GetProtocol() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("protocol"), h.Protocol, "field is immutable"))
+	}
+	if h.GetRequestInterval() != old.GetRequestInterval() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requestInterval"), h.RequestInterval, "field is immutable"))
+	}
+
+	return allErrs
+}
+
+// GetProtocol returns the protocol of the health check, HTTPS when unset.
+func (h *Route53HealthCheck) GetProtocol() Route53HealthCheckProtocol {
+	if h.Protocol == "" {
+		return Route53HealthCheckProtocolHTTPS
+	}
+	return h.Protocol
+}
+
+// GetRequestInterval returns the number of seconds between two checks of each health checker, 30 when unset.
+func (h *Route53HealthCheck) GetRequestInterval() int64 {
+	return ptr.Deref(h.RequestInterval, 30)
+}
+
+// GetFailureThreshold returns the number of consecutive checks changing the status of the endpoint, 3 when unset.
+func (h *Route53HealthCheck) GetFailureThreshold() int64 {
+	return ptr.Deref(h.FailureThreshold, 3)
+}
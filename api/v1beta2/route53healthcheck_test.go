@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestRoute53HealthCheckValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthCheck *Route53HealthCheck
+		expectErrs  int
+	}{
+		{
+			name: "nil health check",
+		},
+		{
+			name:        "default regions",
+			healthCheck: &Route53HealthCheck{},
+		},
+		{
+			name:        "supported regions",
+			healthCheck: &Route53HealthCheck{Regions: []string{"us-east-1", "eu-west-1", "ap-southeast-2"}},
+		},
+		{
+			name:        "too few regions",
+			healthCheck: &Route53HealthCheck{Regions: []string{"us-east-1", "eu-west-1"}},
+			expectErrs:  1,
+		},
+		{
+			name:        "unsupported region",
+			healthCheck: &Route53HealthCheck{Regions: []string{"us-east-1", "eu-west-1", "eu-central-1"}},
+			expectErrs:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.healthCheck.Validate()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
+
+func TestRoute53HealthCheckValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         *Route53HealthCheck
+		healthCheck *Route53HealthCheck
+		expectErrs  int
+	}{
+		{
+			name:        "health check added",
+			healthCheck: &Route53HealthCheck{Protocol: Route53HealthCheckProtocolTCP},
+		},
+		{
+			name: "health check removed",
+			old:  &Route53HealthCheck{Protocol: Route53HealthCheckProtocolTCP},
+		},
+		{
+			name:        "defaults set explicitly",
+			old:         &Route53HealthCheck{},
+			healthCheck: &Route53HealthCheck{Protocol: Route53HealthCheckProtocolHTTPS, RequestInterval: ptr.To[int64](30), FailureThreshold: ptr.To[int64](5)},
+		},
+		{
+			name:        "protocol and request interval changed",
+			old:         &Route53HealthCheck{Protocol: Route53HealthCheckProtocolHTTPS, RequestInterval: ptr.To[int64](30)},
+			healthCheck: &Route53HealthCheck{Protocol: Route53HealthCheckProtocolTCP, RequestInterval: ptr.To[int64](10)},
+			expectErrs:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.healthCheck.ValidateUpdate(tt.old)).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
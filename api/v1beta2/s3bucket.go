@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
@@ -66,6 +67,13 @@ func (b *S3Bucket) Validate() []*field.Error {
 		errs = append(errs, validateS3BucketName(b.Name)...)
 	}
 
+	if b.KMSKeyARN != "" {
+		if keyARN, err := arn.Parse(b.KMSKeyARN); err != nil || keyARN.Service != "kms" {
+			errs = append(errs,
+				field.Invalid(field.NewPath("spec", "s3Bucket", "kmsKeyARN"), b.KMSKeyARN, "must be the ARN of an AWS KMS key"))
+		}
+	}
+
 	return errs
 }
 
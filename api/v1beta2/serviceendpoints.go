@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net/url"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates that the services are known by the AWS SDK and that their endpoints are URLs.
+func (e ServiceEndpoints) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec", "serviceEndpoints")
+	for _, serviceID := range e.serviceIDs() {
+		if !isKnownServiceID(serviceID) {
+			errs = append(errs, field.Invalid(fldPath.Key(serviceID), serviceID, "must be the endpoint ID of an AWS service, e.g. ec2"))
+			continue
+		}
+		u, err := url.ParseRequestURI(e[serviceID])
+		if err != nil || u.Host == "" {
+			errs = append(errs, field.Invalid(fldPath.Key(serviceID), e[serviceID], "must be an absolute URL"))
+		}
+	}
+
+	return errs
+}
+
+// serviceIDs returns the sorted service IDs, so that the validation errors are stable.
+func (e ServiceEndpoints) serviceIDs() []string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func isKnownServiceID(serviceID string) bool {
+	for _, p := range endpoints.DefaultPartitions() {
+		if _, ok := p.Services()[serviceID]; ok {
+			return true
+		}
+	}
+	return false
+}
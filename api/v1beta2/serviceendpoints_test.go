@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestServiceEndpointsValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoints  ServiceEndpoints
+		expectErrs int
+	}{
+		{
+			name: "nil endpoints",
+		},
+		{
+			name: "valid endpoints",
+			endpoints: ServiceEndpoints{
+				"ec2":                  "https://vpce-0123.ec2.us-east-1.vpce.amazonaws.com",
+				"elasticloadbalancing": "https://elasticloadbalancing.proxy.example.com:8443",
+				"sts":                  "https://sts.us-east-1.amazonaws.com",
+				"eks":                  "https://eks.proxy.example.com",
+			},
+		},
+		{
+			name:       "unknown service",
+			endpoints:  ServiceEndpoints{"ec3": "https://ec3.example.com"},
+			expectErrs: 1,
+		},
+		{
+			name: "invalid URLs",
+			endpoints: ServiceEndpoints{
+				"ec2": "ec2.example.com",
+				"sts": "",
+			},
+			expectErrs: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.endpoints.Validate()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateSessionTags validates that the session tag keys are unique, as STS compares them case-insensitively,
+// that each tag has either a value or a value source, and that the transitive tag keys are session tag keys.
+func (r *AWSClusterRoleIdentitySpec) validateSessionTags() field.ErrorList {
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec", "sessionTags")
+	keys := map[string]bool{}
+	for i, tag := range r.SessionTags {
+		key := strings.ToLower(tag.Key)
+		if strings.HasPrefix(key, "aws:") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("key"), tag.Key, "cannot start with aws:"))
+		}
+		if keys[key] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("key"), tag.Key))
+		}
+		keys[key] = true
+
+		if tag.Value != "" && tag.ValueFrom != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "value and valueFrom are mutually exclusive"))
+		}
+	}
+
+	for i, key := range r.TransitiveTagKeys {
+		if !keys[strings.ToLower(key)] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "transitiveTagKeys").Index(i), key, "must be the key of a session tag"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateSessionTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       AWSClusterRoleIdentitySpec
+		expectErrs int
+	}{
+		{
+			name: "no session tags",
+		},
+		{
+			name: "valid session tags",
+			spec: AWSClusterRoleIdentitySpec{
+				SessionTags: []SessionTag{
+					{Key: "team", Value: "platform"},
+					{Key: "cluster", ValueFrom: SessionTagValueClusterName},
+				},
+				TransitiveTagKeys: []string{"Cluster"},
+			},
+		},
+		{
+			name: "reserved key prefix",
+			spec: AWSClusterRoleIdentitySpec{
+				SessionTags: []SessionTag{{Key: "AWS:team", Value: "platform"}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "keys differing only by case",
+			spec: AWSClusterRoleIdentitySpec{
+				SessionTags: []SessionTag{
+					{Key: "team", Value: "platform"},
+					{Key: "Team", Value: "security"},
+				},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "value and value source",
+			spec: AWSClusterRoleIdentitySpec{
+				SessionTags: []SessionTag{{Key: "cluster", Value: "cluster-a", ValueFrom: SessionTagValueClusterName}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "transitive key without session tag",
+			spec: AWSClusterRoleIdentitySpec{
+				SessionTags:       []SessionTag{{Key: "team", Value: "platform"}},
+				TransitiveTagKeys: []string{"cluster"},
+			},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.spec.validateSessionTags()).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateSubnetReplacements validates the secondary CIDR blocks of the VPC and the subnets replacing other subnets.
+func (n *NetworkSpec) ValidateSubnetReplacements(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	cidrBlocks := []*net.IPNet{}
+	if _, primary, err := net.ParseCIDR(n.VPC.CidrBlock); err == nil {
+		cidrBlocks = append(cidrBlocks, primary)
+	}
+	for i, block := range n.VPC.SecondaryCidrBlocks {
+		blockPath := fldPath.Child("vpc", "secondaryCidrBlocks").Index(i).Child("ipv4CidrBlock")
+		ip, secondary, err := net.ParseCIDR(block.IPv4CidrBlock)
+		switch {
+		case err != nil || ip.To4() == nil:
+			errs = append(errs, field.Invalid(blockPath, block.IPv4CidrBlock, "must be a valid IPv4 CIDR block"))
+		case block.IPv4CidrBlock == n.VPC.CidrBlock:
+			errs = append(errs, field.Invalid(blockPath, block.IPv4CidrBlock, "is the CIDR block of the VPC"))
+		default:
+			cidrBlocks = append(cidrBlocks, secondary)
+		}
+	}
+
+	replaced := sets.New[string]()
+	for i, subnet := range n.Subnets {
+		if subnet.Replaces == "" {
+			continue
+		}
+		replacesPath := fldPath.Child("subnets").Index(i).Child("replaces")
+
+		if subnet.Replaces == subnet.ID || subnet.Replaces == subnet.ResourceID {
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "a subnet can't replace itself"))
+			continue
+		}
+
+		var target *SubnetSpec
+		for j := range n.Subnets {
+			if j != i && (n.Subnets[j].ID == subnet.Replaces || n.Subnets[j].GetResourceID() == subnet.Replaces) {
+				target = &n.Subnets[j]
+				break
+			}
+		}
+		if target == nil {
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "must be the ID or the resource ID of another subnet"))
+			continue
+		}
+
+		switch {
+		case replaced.Has(target.ID):
+			errs = append(errs, field.Duplicate(replacesPath, subnet.Replaces))
+		case target.Replaces != "":
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "the replaced subnet can't replace another subnet"))
+		case target.IsPublic != subnet.IsPublic:
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "the replaced subnet must be public if and only if the subnet is"))
+		case target.IPv6Native != subnet.IPv6Native:
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "the replaced subnet must be IPv6-native if and only if the subnet is"))
+		case target.AvailabilityZone != "" && subnet.AvailabilityZone != "" && target.AvailabilityZone != subnet.AvailabilityZone:
+			errs = append(errs, field.Invalid(replacesPath, subnet.Replaces, "the replaced subnet must be in the same availability zone"))
+		}
+		replaced.Insert(target.ID)
+
+		// The CIDR blocks of unmanaged VPCs aren't known.
+		if n.VPC.CidrBlock == "" || subnet.CidrBlock == "" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(subnet.CidrBlock)
+		if err != nil {
+			continue
+		}
+		within := false
+		for _, block := range cidrBlocks {
+			if block.Contains(ip) {
+				within = true
+				break
+			}
+		}
+		if !within {
+			errs = append(errs, field.Invalid(fldPath.Child("subnets").Index(i).Child("cidrBlock"), subnet.CidrBlock,
+				"must be within the CIDR block or a secondary CIDR block of the VPC"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestNetworkSpecValidateSubnetReplacements(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    NetworkSpec
+		expectErrs int
+	}{
+		{
+			name: "no replacement",
+			network: NetworkSpec{
+				VPC:     VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{{ID: "private-1a", CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a"}},
+			},
+		},
+		{
+			name: "valid replacement in a secondary CIDR block",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []VpcCidrBlock{{IPv4CidrBlock: "10.1.0.0/16"}}},
+				Subnets: Subnets{
+					{ID: "private-1a", CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a"},
+					{ID: "private-1a-grown", CidrBlock: "10.1.0.0/20", AvailabilityZone: "us-east-1a", Replaces: "private-1a"},
+				},
+			},
+		},
+		{
+			name: "replacement referencing the resource ID of an unmanaged subnet",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-1"},
+				Subnets: Subnets{
+					{ID: "subnet-1", AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-2", AvailabilityZone: "us-east-1a", Replaces: "subnet-1"},
+				},
+			},
+		},
+		{
+			name: "invalid secondary CIDR blocks",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []VpcCidrBlock{{IPv4CidrBlock: "10.0.0.0/16"}, {IPv4CidrBlock: "not-a-cidr"}, {IPv4CidrBlock: "2001:db8::/56"}}},
+			},
+			expectErrs: 3,
+		},
+		{
+			name: "subnet replacing itself",
+			network: NetworkSpec{
+				Subnets: Subnets{{ID: "private-1a", Replaces: "private-1a"}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "replaced subnet not found",
+			network: NetworkSpec{
+				Subnets: Subnets{{ID: "private-1a-grown", Replaces: "private-1a"}},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "subnet replaced twice",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{ID: "private-1a"},
+					{ID: "private-1a-grown", Replaces: "private-1a"},
+					{ID: "private-1a-grown-again", Replaces: "private-1a"},
+				},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "chained replacements",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{ID: "private-1a"},
+					{ID: "private-1a-grown", Replaces: "private-1a"},
+					{ID: "private-1a-grown-again", Replaces: "private-1a-grown"},
+				},
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "replacement of a subnet of another kind and availability zone",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{ID: "public-1a", IsPublic: true, AvailabilityZone: "us-east-1a"},
+					{ID: "private-1a", AvailabilityZone: "us-east-1a"},
+					{ID: "private-1a-grown", Replaces: "public-1a", AvailabilityZone: "us-east-1a"},
+					{ID: "private-1b-grown", Replaces: "private-1a", AvailabilityZone: "us-east-1b"},
+				},
+			},
+			expectErrs: 2,
+		},
+		{
+			name: "replacement outside of the CIDR blocks of the VPC",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{ID: "private-1a", CidrBlock: "10.0.0.0/24"},
+					{ID: "private-1a-grown", CidrBlock: "10.1.0.0/20", Replaces: "private-1a"},
+				},
+			},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.network.ValidateSubnetReplacements(field.NewPath("spec", "network"))).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate validates SubnetSelector fields.
+func (s *SubnetSelector) Validate(fldPath *field.Path) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if len(s.Tags) == 0 && len(s.AvailabilityZones) == 0 {
+		errs = append(errs, field.Required(fldPath, "at least one tag or availability zone must be set"))
+	}
+
+	keys := sets.New[string]()
+	for i, tag := range s.Tags {
+		if tag.Key == "" {
+			errs = append(errs, field.Required(fldPath.Child("tags").Index(i).Child("key"), "can't be empty"))
+			continue
+		}
+		if keys.Has(tag.Key) {
+			errs = append(errs, field.Duplicate(fldPath.Child("tags").Index(i).Child("key"), tag.Key))
+		}
+		keys.Insert(tag.Key)
+	}
+
+	for i, zone := range s.AvailabilityZones {
+		if zone == "" {
+			errs = append(errs, field.Required(fldPath.Child("availabilityZones").Index(i), "can't be empty"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSubnetSelectorValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   *SubnetSelector
+		expectErrs int
+	}{
+		{
+			name: "nil selector",
+		},
+		{
+			name: "valid selector",
+			selector: &SubnetSelector{
+				Tags:              []SubnetTagSelector{{Key: "subnet-role", Values: []string{"workers"}}, {Key: "kubernetes.io/role/internal-elb"}},
+				AvailabilityZones: []string{"us-east-1a"},
+			},
+		},
+		{
+			name:       "empty selector",
+			selector:   &SubnetSelector{},
+			expectErrs: 1,
+		},
+		{
+			name:       "empty tag key",
+			selector:   &SubnetSelector{Tags: []SubnetTagSelector{{Values: []string{"workers"}}}},
+			expectErrs: 1,
+		},
+		{
+			name:       "duplicate tag key",
+			selector:   &SubnetSelector{Tags: []SubnetTagSelector{{Key: "subnet-role"}, {Key: "subnet-role", Values: []string{"workers"}}}},
+			expectErrs: 1,
+		},
+		{
+			name:       "empty availability zone",
+			selector:   &SubnetSelector{AvailabilityZones: []string{""}},
+			expectErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.selector.Validate(field.NewPath("spec", "subnetSelector"))).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
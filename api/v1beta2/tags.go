@@ -75,6 +75,12 @@ func (t Tags) Merge(other Tags) {
 // Tag's key cannot have prefix "aws:".
 // Max count of User tags for a specific resource can be 50.
 func (t Tags) Validate() []*field.Error {
+	return t.ValidateWithPath(field.NewPath("spec", "additionalTags"))
+}
+
+// ValidateWithPath checks if tags are valid for the AWS API/Resources like Validate, reporting the errors at the
+// specified path, e.g. the one of the additional tags of a template.
+func (t Tags) ValidateWithPath(fldPath *field.Path) []*field.Error {
 	// Defines the maximum number of user tags which can be created for a specific resource
 	const maxUserTagsAllowed = 50
 	var errs field.ErrorList
@@ -84,47 +90,93 @@ func (t Tags) Validate() []*field.Error {
 	for k, v := range t {
 		if len(k) < 1 {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), k, "key cannot be empty"),
+				field.Invalid(fldPath, k, "key cannot be empty"),
 			)
 		}
 		if len(k) > 128 {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), k, "key cannot be longer than 128 characters"),
+				field.Invalid(fldPath, k, "key cannot be longer than 128 characters"),
 			)
 		}
 		if len(v) > 256 {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), v, "value cannot be longer than 256 characters"),
+				field.Invalid(fldPath, v, "value cannot be longer than 256 characters"),
 			)
 		}
 		if wrongUserTagNomenclature(k) {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), k, "user created tag's key cannot have prefix aws:"),
+				field.Invalid(fldPath, k, "user created tag's key cannot have prefix aws:"),
 			)
 		}
 		val := re.MatchString(k)
 		if !val {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), k, "key cannot have characters other than alphabets, numbers, spaces and _ . : / = + - @ ."),
+				field.Invalid(fldPath, k, "key cannot have characters other than alphabets, numbers, spaces and _ . : / = + - @ ."),
 			)
 		}
 		val = re.MatchString(v)
 		if !val {
 			errs = append(errs,
-				field.Invalid(field.NewPath("spec", "additionalTags"), v, "value cannot have characters other than alphabets, numbers, spaces and _ . : / = + - @ ."),
+				field.Invalid(fldPath, v, "value cannot have characters other than alphabets, numbers, spaces and _ . : / = + - @ ."),
 			)
 		}
 	}
 
 	if userTagCount > maxUserTagsAllowed {
 		errs = append(errs,
-			field.Invalid(field.NewPath("spec", "additionalTags"), t, "user created tags cannot be more than 50"),
+			field.Invalid(fldPath, t, "user created tags cannot be more than 50"),
 		)
 	}
 
 	return errs
 }
 
+// defaultTags are added by the defaulting webhooks to the additional tags of the objects which don't already set them.
+var defaultTags Tags
+
+// SetDefaultTags sets the tags added by the defaulting webhooks to the additional tags of the AWSClusters,
+// AWSMachines, AWSMachinePools and AWSManagedMachinePools which don't already set them, e.g. the tags an organization
+// requires on all its AWS resources.
+func SetDefaultTags(tags Tags) {
+	defaultTags = tags.DeepCopy()
+}
+
+// WithDefaults returns a copy of the tags with the default tags they don't already set added.
+func (t Tags) WithDefaults() Tags {
+	if len(defaultTags) == 0 {
+		return t
+	}
+
+	tags := make(Tags, len(t)+len(defaultTags))
+	for k, v := range defaultTags {
+		tags[k] = v
+	}
+	for k, v := range t {
+		tags[k] = v
+	}
+	return tags
+}
+
+// MissingKeys returns the keys, among the specified ones, which the tags don't set.
+func (t Tags) MissingKeys(keys []string) []string {
+	var missing []string
+	for _, k := range keys {
+		if _, ok := t[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// ValidateRequiredTags checks that the keys of the required tags of a cluster are valid keys of user tags.
+func ValidateRequiredTags(keys []string, fldPath *field.Path) []*field.Error {
+	tags := make(Tags, len(keys))
+	for _, k := range keys {
+		tags[k] = ""
+	}
+	return tags.ValidateWithPath(fldPath)
+}
+
 // Checks whether the tag created is user tag or not.
 func wrongUserTagNomenclature(k string) bool {
 	return len(k) > 3 && k[0:4] == "aws:"
@@ -170,6 +222,11 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSSubnetAssociation = NameAWSProviderPrefix + "association"
 
+	// NameAWSSubnetReplacedBy is the tag name we use to mark the subnets which are replaced
+	// by another subnet of the same availability zone, and are waiting to be drained.
+	// The tag value is the ID of the replacement subnet.
+	NameAWSSubnetReplacedBy = NameAWSProviderPrefix + "replaced-by"
+
 	// SecondarySubnetTagValue is the secondary subnet tag constant value.
 	SecondarySubnetTagValue = "secondary"
 
@@ -354,6 +354,107 @@ func TestTagsValidate(t *testing.T) {
 	}
 }
 
+func TestTagsValidateWithPath(t *testing.T) {
+	tags := Tags{"aws:key": "value"}
+	out := tags.ValidateWithPath(field.NewPath("spec", "template", "spec", "additionalTags"))
+	expected := []*field.Error{
+		{
+			Type:     field.ErrorTypeInvalid,
+			Detail:   "user created tag's key cannot have prefix aws:",
+			Field:    "spec.template.spec.additionalTags",
+			BadValue: "aws:key",
+		},
+	}
+	if !cmp.Equal(out, expected) {
+		t.Errorf("expected %+v, got %+v", expected, out)
+	}
+}
+
+func TestTagsWithDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults Tags
+		self     Tags
+		expected Tags
+	}{
+		{
+			name:     "no default tags",
+			self:     Tags{"a": "b"},
+			expected: Tags{"a": "b"},
+		},
+		{
+			name:     "default tags added to nil tags",
+			defaults: Tags{"cost-center": "platform"},
+			expected: Tags{"cost-center": "platform"},
+		},
+		{
+			name:     "tags already set are kept",
+			defaults: Tags{"cost-center": "platform", "owner": "infra"},
+			self:     Tags{"owner": "team", "a": "b"},
+			expected: Tags{"cost-center": "platform", "owner": "team", "a": "b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaultTags(tc.defaults)
+			defer SetDefaultTags(nil)
+
+			out := tc.self.WithDefaults()
+			if !cmp.Equal(out, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestTagsMissingKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		self     Tags
+		keys     []string
+		expected []string
+	}{
+		{
+			name: "no required keys",
+			self: Tags{"a": "b"},
+		},
+		{
+			name: "all keys set",
+			self: Tags{"cost-center": "platform", "owner": ""},
+			keys: []string{"cost-center", "owner"},
+		},
+		{
+			name:     "missing keys in order",
+			self:     Tags{"owner": "infra"},
+			keys:     []string{"project", "owner", "cost-center"},
+			expected: []string{"project", "cost-center"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := tc.self.MissingKeys(tc.keys)
+			if !cmp.Equal(out, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestValidateRequiredTags(t *testing.T) {
+	out := ValidateRequiredTags([]string{"cost-center", "aws:createdBy"}, field.NewPath("spec", "requiredTags"))
+	expected := []*field.Error{
+		{
+			Type:     field.ErrorTypeInvalid,
+			Detail:   "user created tag's key cannot have prefix aws:",
+			Field:    "spec.requiredTags",
+			BadValue: "aws:createdBy",
+		},
+	}
+	if !cmp.Equal(out, expected) {
+		t.Errorf("expected %+v, got %+v", expected, out)
+	}
+}
+
 func getSortFieldErrorsFunc(errs []*field.Error) func(i, j int) bool {
 	return func(i, j int) bool {
 		if errs[i].Detail != errs[j].Detail {
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -37,6 +38,30 @@ type AWSResourceReference struct {
 	Filters []Filter `json:"filters,omitempty"`
 }
 
+// SubnetSelector selects subnets of the VPC of the cluster by their tags and availability zones, so that the same
+// templates can be used by clusters in different VPCs.
+type SubnetSelector struct {
+	// Tags selects the subnets having all of these tags.
+	// +optional
+	Tags []SubnetTagSelector `json:"tags,omitempty"`
+
+	// AvailabilityZones restricts the selected subnets to these availability zones.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+}
+
+// SubnetTagSelector selects subnets by the value of a tag.
+type SubnetTagSelector struct {
+	// Key is the key of the tag.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Values are the values of the tag selecting a subnet. When it is empty, the subnets having the tag are
+	// selected whatever its value.
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
 // AMIReference is a reference to a specific AWS resource by ID, ARN, or filters.
 // Only one of ID, ARN or Filters may be specified. Specifying more than one will result in
 // a validation error.
@@ -51,6 +76,17 @@ type AMIReference struct {
 	EKSOptimizedLookupType *EKSAMILookupType `json:"eksLookupType,omitempty"`
 }
 
+// OSFamily is the operating system family of an instance.
+type OSFamily string
+
+const (
+	// OSFamilyLinux is the Linux operating system family.
+	OSFamilyLinux = OSFamily("linux")
+
+	// OSFamilyWindows is the Windows operating system family.
+	OSFamilyWindows = OSFamily("windows")
+)
+
 // Filter is a filter used to identify an AWS resource.
 type Filter struct {
 	// Name of the filter. Filter names are case-sensitive.
@@ -78,6 +114,16 @@ const (
 	// ExternalResourceGCTasksAnnotation is the name of an annotation that indicates what
 	// external resources tasks should be executed by garbage collector for the cluster.
 	ExternalResourceGCTasksAnnotation = "aws.cluster.x-k8s.io/external-resource-tasks-gc"
+
+	// ForceDeleteAnnotation is the name of an annotation that, when set to "true", lets a deleted AWSCluster be
+	// removed even though its AWS resources can't be deleted because its credentials are invalid or were revoked.
+	// The AWS resources which can't be deleted are orphaned, and reported in events of the AWSCluster.
+	ForceDeleteAnnotation = "aws.cluster.x-k8s.io/force-delete"
+
+	// InvalidateCredentialsAnnotation is the name of an annotation that, whenever its value changes, e.g. to the
+	// current time, drops the cached AWS session of an AWSCluster or AWSManagedControlPlane and the cached
+	// credentials of its identity, so that rotated roles or credentials are used by the next reconcile.
+	InvalidateCredentialsAnnotation = "aws.cluster.x-k8s.io/invalidate-credentials"
 )
 
 // GCTask defines a task to be executed by the garbage collector.
@@ -92,6 +138,15 @@ var (
 
 	// GCTaskSecurityGroup defines a task to cleaning up resources for AWS security groups.
 	GCTaskSecurityGroup = GCTask("security-group")
+
+	// GCTaskNetworkInterface defines a task to cleaning up AWS network interfaces no longer attached to an instance.
+	GCTaskNetworkInterface = GCTask("network-interface")
+
+	// GCTaskVolume defines a task to cleaning up AWS EBS volumes no longer attached to an instance.
+	GCTaskVolume = GCTask("volume")
+
+	// GCTaskLaunchTemplate defines a task to cleaning up AWS launch templates left behind by machine pools.
+	GCTaskLaunchTemplate = GCTask("launch-template")
 )
 
 // AZSelectionScheme defines the scheme of selecting AZs.
@@ -249,6 +304,10 @@ type Instance struct {
 	// PublicIPOnLaunch is the option to associate a public IP on instance launch
 	// +optional
 	PublicIPOnLaunch *bool `json:"publicIPOnLaunch,omitempty"`
+
+	// IPv6Only indicates whether the instance is launched with IPv6 addressing only.
+	// +optional
+	IPv6Only bool `json:"ipv6Only,omitempty"`
 }
 
 // InstanceMetadataState describes the state of InstanceMetadataOptions.HttpEndpoint and InstanceMetadataOptions.InstanceMetadataTags
@@ -350,10 +409,15 @@ type Volume struct {
 
 	// Size specifies size (in Gi) of the storage device.
 	// Must be greater than the image snapshot size or 8 (whichever is greater).
+	// Defaults to the image snapshot size for root volumes, and is required for non root volumes.
+	// Must not be set for instance store root volumes.
 	// +kubebuilder:validation:Minimum=8
-	Size int64 `json:"size"`
+	// +optional
+	Size int64 `json:"size,omitempty"`
 
-	// Type is the type of the volume (e.g. gp2, io1, etc...).
+	// Type is the type of the volume (e.g. gp2, io1, etc...). The instance-store type is only valid for the root
+	// volume, whose root device is then the instance store volume of an instance store-backed AMI, for stateless
+	// instances whose root device doesn't outlive them.
 	// +optional
 	Type VolumeType `json:"type,omitempty"`
 
@@ -393,6 +457,10 @@ var (
 	// VolumeTypeGP3 is the string representing a general purpose ssd gp3 volume.
 	VolumeTypeGP3 = VolumeType("gp3")
 
+	// VolumeTypeInstanceStore is the string representing the instance store root volume of an instance store-backed
+	// AMI, which isn't an EBS volume.
+	VolumeTypeInstanceStore = VolumeType("instance-store")
+
 	// VolumeTypesGP are volume types provisioned for general purpose io.
 	VolumeTypesGP = sets.NewString(
 		string(VolumeTypeIO1),
@@ -426,6 +494,18 @@ const (
 	AmazonLinuxGPU EKSAMILookupType = "AmazonLinuxGPU"
 )
 
+// LoadBalancerAttachments lists the load balancers instances are registered with.
+type LoadBalancerAttachments struct {
+	// TargetGroupARNs are the ARNs of the target groups of network, application or gateway load balancers
+	// the instances are registered with. The target groups must have the instance target type.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// ClassicLoadBalancerNames are the names of the classic load balancers the instances are registered with.
+	// +optional
+	ClassicLoadBalancerNames []string `json:"classicLoadBalancerNames,omitempty"`
+}
+
 // PrivateDNSName is the options for the instance hostname.
 type PrivateDNSName struct {
 	// EnableResourceNameDNSAAAARecord indicates whether to respond to DNS queries for instance hostnames with DNS AAAA records.
@@ -439,3 +519,67 @@ type PrivateDNSName struct {
 	// +kubebuilder:validation:Enum:=ip-name;resource-name
 	HostnameType *string `json:"hostnameType,omitempty"`
 }
+
+// ProxyConfig configures how the AWS APIs are reached in egress controlled environments.
+type ProxyConfig struct {
+	// URL of the HTTP(S) proxy through which the AWS APIs are reached, e.g. http://proxy.example.com:3128.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// CABundle is a PEM encoded bundle of CA certificates trusted in addition to the system ones when
+	// reaching the AWS APIs, e.g. the certificate of a TLS intercepting proxy.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// ServiceEndpoints maps the endpoint IDs of AWS services, e.g. ec2, elasticloadbalancing, sts or eks,
+// to the URLs of the endpoints to use for them instead of their default endpoints in the region.
+type ServiceEndpoints map[string]string
+
+// CostEstimate is the estimated hourly cost of the instances of an AWSMachine or an AWSMachinePool, based on
+// the on-demand prices of the AWS Pricing API and on the current spot prices of the instance types.
+type CostEstimate struct {
+	// Currency is the currency of the prices, e.g. USD.
+	Currency string `json:"currency"`
+
+	// HourlyCost is the estimated hourly cost of the instances, priced at the spot price of their
+	// availability zone for spot instances and at the on-demand price otherwise.
+	HourlyCost string `json:"hourlyCost"`
+
+	// Prices are the hourly prices of the instance types of the instances in their availability zones.
+	// +optional
+	Prices []InstanceTypePrice `json:"prices,omitempty"`
+
+	// LastUpdated is the time the prices were last fetched.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// InstanceTypePrice is the hourly price of an instance type in an availability zone.
+type InstanceTypePrice struct {
+	// InstanceType is the instance type, e.g. m5.large.
+	InstanceType string `json:"instanceType"`
+
+	// AvailabilityZone is the availability zone the spot price applies to.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// OnDemandHourlyPrice is the hourly price of a Linux on-demand instance with shared tenancy of the
+	// instance type in the region.
+	// +optional
+	OnDemandHourlyPrice string `json:"onDemandHourlyPrice,omitempty"`
+
+	// SpotHourlyPrice is the current hourly spot price of a Linux instance of the instance type in the
+	// availability zone.
+	// +optional
+	SpotHourlyPrice string `json:"spotHourlyPrice,omitempty"`
+}
+
+// LastReconciliation records the last successful reconciliation of the AWS resources of an object.
+type LastReconciliation struct {
+	// Hash is the hash of the desired state the AWS resources were reconciled from.
+	Hash string `json:"hash"`
+
+	// Time is the time of the reconciliation.
+	Time metav1.Time `json:"time"`
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// IsInstanceStore returns whether the volume is the instance store root volume of an instance store-backed AMI.
+func (v *Volume) IsInstanceStore() bool {
+	return v != nil && v.Type == VolumeTypeInstanceStore
+}
+
+// ValidateRootVolumeInstanceStore rejects the EBS options of an instance store root volume, whose size is the one of
+// the instance store of the instance type, and which is encrypted by the instance store hardware.
+func ValidateRootVolumeInstanceStore(volume *Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !volume.IsInstanceStore() {
+		return allErrs
+	}
+
+	if volume.Size != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("size"), "size can't be set if type is 'instance-store'"))
+	}
+	if volume.IOPS != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("iops"), "iops can't be set if type is 'instance-store'"))
+	}
+	if volume.Encrypted != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("encrypted"), "encrypted can't be set if type is 'instance-store'"))
+	}
+	if volume.EncryptionKey != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("encryptionKey"), "encryptionKey can't be set if type is 'instance-store'"))
+	}
+
+	return allErrs
+}
+
+// ValidateNonRootVolume rejects the non root volumes which aren't EBS volumes with a size.
+func ValidateNonRootVolume(volume Volume, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if volume.IsInstanceStore() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), volume.Type, "only the root volume can be of type 'instance-store'"))
+	}
+	if volume.Size == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("size"), "non root volume should have size"))
+	}
+
+	return allErrs
+}
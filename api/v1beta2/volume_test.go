@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateRootVolumeInstanceStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		volume     *Volume
+		wantFields []string
+	}{
+		{
+			name: "no root volume",
+		},
+		{
+			name:   "EBS root volume",
+			volume: &Volume{Type: VolumeTypeGP3, Size: 16, Encrypted: ptr.To(true)},
+		},
+		{
+			name:   "instance store root volume",
+			volume: &Volume{Type: VolumeTypeInstanceStore},
+		},
+		{
+			name:       "instance store root volume with EBS options",
+			volume:     &Volume{Type: VolumeTypeInstanceStore, Size: 16, IOPS: 3000, Encrypted: ptr.To(true), EncryptionKey: "alias/volumes"},
+			wantFields: []string{"spec.rootVolume.size", "spec.rootVolume.iops", "spec.rootVolume.encrypted", "spec.rootVolume.encryptionKey"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := ValidateRootVolumeInstanceStore(tt.volume, field.NewPath("spec", "rootVolume"))
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.wantFields))
+		})
+	}
+}
+
+func TestValidateNonRootVolume(t *testing.T) {
+	tests := []struct {
+		name       string
+		volume     Volume
+		wantFields []string
+	}{
+		{
+			name:   "EBS volume",
+			volume: Volume{DeviceName: "/dev/sdb", Size: 16},
+		},
+		{
+			name:       "volume without size",
+			volume:     Volume{DeviceName: "/dev/sdb"},
+			wantFields: []string{"spec.nonRootVolumes[0].size"},
+		},
+		{
+			name:       "instance store volume",
+			volume:     Volume{DeviceName: "/dev/sdb", Type: VolumeTypeInstanceStore, Size: 16},
+			wantFields: []string{"spec.nonRootVolumes[0].type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := ValidateNonRootVolume(tt.volume, field.NewPath("spec", "nonRootVolumes").Index(0))
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.wantFields))
+		})
+	}
+}
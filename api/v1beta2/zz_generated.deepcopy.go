@@ -162,6 +162,18 @@ func (in *AWSClusterIdentitySpec) DeepCopyInto(out *AWSClusterIdentitySpec) {
 		*out = new(AllowedNamespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make(ServiceEndpoints, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterIdentitySpec.
@@ -274,6 +286,16 @@ func (in *AWSClusterRoleIdentitySpec) DeepCopyInto(out *AWSClusterRoleIdentitySp
 		*out = new(AWSIdentityReference)
 		**out = **in
 	}
+	if in.SessionTags != nil {
+		in, out := &in.SessionTags, &out.SessionTags
+		*out = make([]SessionTag, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitiveTagKeys != nil {
+		in, out := &in.TransitiveTagKeys, &out.TransitiveTagKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterRoleIdentitySpec.
@@ -303,6 +325,11 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RequiredTags != nil {
+		in, out := &in.RequiredTags, &out.RequiredTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ControlPlaneLoadBalancer != nil {
 		in, out := &in.ControlPlaneLoadBalancer, &out.ControlPlaneLoadBalancer
 		*out = new(AWSLoadBalancerSpec)
@@ -313,6 +340,16 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		*out = new(AWSLoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.APIServerHealthCheck != nil {
+		in, out := &in.APIServerHealthCheck, &out.APIServerHealthCheck
+		*out = new(Route53HealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StandbyRegion != nil {
+		in, out := &in.StandbyRegion, &out.StandbyRegion
+		*out = new(StandbyRegion)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Bastion.DeepCopyInto(&out.Bastion)
 	if in.IdentityRef != nil {
 		in, out := &in.IdentityRef, &out.IdentityRef
@@ -324,6 +361,28 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		*out = new(S3Bucket)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagedComponents != nil {
+		in, out := &in.ManagedComponents, &out.ManagedComponents
+		*out = make([]AWSClusterComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make(ServiceEndpoints, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceProfiles != nil {
+		in, out := &in.InstanceProfiles, &out.InstanceProfiles
+		*out = new(ManagedInstanceProfiles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureDomainSelection != nil {
+		in, out := &in.FailureDomainSelection, &out.FailureDomainSelection
+		*out = new(FailureDomainSelection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterSpec.
@@ -433,6 +492,27 @@ func (in *AWSClusterStatus) DeepCopyInto(out *AWSClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.OIDCProvider = in.OIDCProvider
+	if in.InstanceProfiles != nil {
+		in, out := &in.InstanceProfiles, &out.InstanceProfiles
+		*out = new(ManagedInstanceProfilesStatus)
+		**out = **in
+	}
+	if in.APIServerHealthCheck != nil {
+		in, out := &in.APIServerHealthCheck, &out.APIServerHealthCheck
+		*out = new(Route53HealthCheckStatus)
+		**out = **in
+	}
+	if in.StandbyNetwork != nil {
+		in, out := &in.StandbyNetwork, &out.StandbyNetwork
+		*out = new(StandbyNetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconciliation != nil {
+		in, out := &in.LastReconciliation, &out.LastReconciliation
+		*out = new(LastReconciliation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterStatus.
@@ -536,6 +616,86 @@ func (in *AWSClusterTemplateSpec) DeepCopy() *AWSClusterTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSClusterWebIdentity) DeepCopyInto(out *AWSClusterWebIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterWebIdentity.
+func (in *AWSClusterWebIdentity) DeepCopy() *AWSClusterWebIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSClusterWebIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSClusterWebIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSClusterWebIdentityList) DeepCopyInto(out *AWSClusterWebIdentityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSClusterWebIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterWebIdentityList.
+func (in *AWSClusterWebIdentityList) DeepCopy() *AWSClusterWebIdentityList {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSClusterWebIdentityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSClusterWebIdentityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSClusterWebIdentitySpec) DeepCopyInto(out *AWSClusterWebIdentitySpec) {
+	*out = *in
+	in.AWSClusterIdentitySpec.DeepCopyInto(&out.AWSClusterIdentitySpec)
+	in.AWSRoleSpec.DeepCopyInto(&out.AWSRoleSpec)
+	if in.RolesAnywhere != nil {
+		in, out := &in.RolesAnywhere, &out.RolesAnywhere
+		*out = new(RolesAnywhereSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClusterWebIdentitySpec.
+func (in *AWSClusterWebIdentitySpec) DeepCopy() *AWSClusterWebIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSClusterWebIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSIdentityReference) DeepCopyInto(out *AWSIdentityReference) {
 	*out = *in
@@ -559,6 +719,11 @@ func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ARN != nil {
+		in, out := &in.ARN, &out.ARN
+		*out = new(string)
+		**out = **in
+	}
 	if in.Scheme != nil {
 		in, out := &in.Scheme, &out.Scheme
 		*out = new(ELBScheme)
@@ -598,6 +763,23 @@ func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AdditionalTargetGroupAttributes != nil {
+		in, out := &in.AdditionalTargetGroupAttributes, &out.AdditionalTargetGroupAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConnectionDrainingTimeout != nil {
+		in, out := &in.ConnectionDrainingTimeout, &out.ConnectionDrainingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(LoadBalancerAccessLogs)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerSpec.
@@ -712,6 +894,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(AWSResourceReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SubnetSelector != nil {
+		in, out := &in.SubnetSelector, &out.SubnetSelector
+		*out = new(SubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityGroupOverrides != nil {
 		in, out := &in.SecurityGroupOverrides, &out.SecurityGroupOverrides
 		*out = make(map[SecurityGroupRole]string, len(*in))
@@ -762,6 +949,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(PrivateDNSName)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoadBalancerAttachments != nil {
+		in, out := &in.LoadBalancerAttachments, &out.LoadBalancerAttachments
+		*out = new(LoadBalancerAttachments)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineSpec.
@@ -787,6 +979,21 @@ func (in *AWSMachineStatus) DeepCopyInto(out *AWSMachineStatus) {
 		*out = new(InstanceState)
 		**out = **in
 	}
+	if in.ImageID != nil {
+		in, out := &in.ImageID, &out.ImageID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancerAttachments != nil {
+		in, out := &in.LoadBalancerAttachments, &out.LoadBalancerAttachments
+		*out = new(LoadBalancerAttachments)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -804,6 +1011,11 @@ func (in *AWSMachineStatus) DeepCopyInto(out *AWSMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastReconciliation != nil {
+		in, out := &in.LastReconciliation, &out.LastReconciliation
+		*out = new(LastReconciliation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachineStatus.
@@ -1027,6 +1239,101 @@ func (in *AWSManagedClusterStatus) DeepCopy() *AWSManagedClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSProviderConfig) DeepCopyInto(out *AWSProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSProviderConfig.
+func (in *AWSProviderConfig) DeepCopy() *AWSProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSProviderConfigList) DeepCopyInto(out *AWSProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSProviderConfigList.
+func (in *AWSProviderConfigList) DeepCopy() *AWSProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSProviderConfigSpec) DeepCopyInto(out *AWSProviderConfigSpec) {
+	*out = *in
+	if in.InstanceMetadataOptions != nil {
+		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
+		*out = new(InstanceMetadataOptions)
+		**out = **in
+	}
+	if in.RequiredTags != nil {
+		in, out := &in.RequiredTags, &out.RequiredTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = new(DefaultInstanceTypes)
+		**out = **in
+	}
+	if in.KMSKeys != nil {
+		in, out := &in.KMSKeys, &out.KMSKeys
+		*out = new(DefaultKMSKeys)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSProviderConfigSpec.
+func (in *AWSProviderConfigSpec) DeepCopy() *AWSProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSResourceReference) DeepCopyInto(out *AWSResourceReference) {
 	*out = *in
@@ -1224,6 +1531,11 @@ func (in *CNISpec) DeepCopy() *CNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELBAttributes) DeepCopyInto(out *ClassicELBAttributes) {
 	*out = *in
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(LoadBalancerAccessLogs)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClassicELBAttributes.
@@ -1281,6 +1593,90 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
+	*out = *in
+	if in.Prices != nil {
+		in, out := &in.Prices, &out.Prices
+		*out = make([]InstanceTypePrice, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(CostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultInstanceTypes) DeepCopyInto(out *DefaultInstanceTypes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultInstanceTypes.
+func (in *DefaultInstanceTypes) DeepCopy() *DefaultInstanceTypes {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultInstanceTypes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultKMSKeys) DeepCopyInto(out *DefaultKMSKeys) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultKMSKeys.
+func (in *DefaultKMSKeys) DeepCopy() *DefaultKMSKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultKMSKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainSelection) DeepCopyInto(out *FailureDomainSelection) {
+	*out = *in
+	if in.AllowedAvailabilityZones != nil {
+		in, out := &in.AllowedAvailabilityZones, &out.AllowedAvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedAvailabilityZones != nil {
+		in, out := &in.DeniedAvailabilityZones, &out.DeniedAvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainSelection.
+func (in *FailureDomainSelection) DeepCopy() *FailureDomainSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -1472,6 +1868,21 @@ func (in IngressRules) DeepCopy() IngressRules {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlinePolicy) DeepCopyInto(out *InlinePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlinePolicy.
+func (in *InlinePolicy) DeepCopy() *InlinePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InlinePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -1591,6 +2002,69 @@ func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceProfileTemplate) DeepCopyInto(out *InstanceProfileTemplate) {
+	*out = *in
+	if in.ManagedPolicyARNs != nil {
+		in, out := &in.ManagedPolicyARNs, &out.ManagedPolicyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InlinePolicies != nil {
+		in, out := &in.InlinePolicies, &out.InlinePolicies
+		*out = make([]InlinePolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceProfileTemplate.
+func (in *InstanceProfileTemplate) DeepCopy() *InstanceProfileTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceProfileTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceTypePrice) DeepCopyInto(out *InstanceTypePrice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceTypePrice.
+func (in *InstanceTypePrice) DeepCopy() *InstanceTypePrice {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceTypePrice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastReconciliation) DeepCopyInto(out *LastReconciliation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastReconciliation.
+func (in *LastReconciliation) DeepCopy() *LastReconciliation {
+	if in == nil {
+		return nil
+	}
+	out := new(LastReconciliation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Listener) DeepCopyInto(out *Listener) {
 	*out = *in
@@ -1635,7 +2109,7 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(ClassicELBHealthCheck)
 		**out = **in
 	}
-	out.ClassicElbAttributes = in.ClassicElbAttributes
+	in.ClassicElbAttributes.DeepCopyInto(&out.ClassicElbAttributes)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -1678,6 +2152,86 @@ func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerAccessLogs) DeepCopyInto(out *LoadBalancerAccessLogs) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerAccessLogs.
+func (in *LoadBalancerAccessLogs) DeepCopy() *LoadBalancerAccessLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerAccessLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerAttachments) DeepCopyInto(out *LoadBalancerAttachments) {
+	*out = *in
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClassicLoadBalancerNames != nil {
+		in, out := &in.ClassicLoadBalancerNames, &out.ClassicLoadBalancerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerAttachments.
+func (in *LoadBalancerAttachments) DeepCopy() *LoadBalancerAttachments {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerAttachments)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedInstanceProfiles) DeepCopyInto(out *ManagedInstanceProfiles) {
+	*out = *in
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(InstanceProfileTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(InstanceProfileTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedInstanceProfiles.
+func (in *ManagedInstanceProfiles) DeepCopy() *ManagedInstanceProfiles {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedInstanceProfiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedInstanceProfilesStatus) DeepCopyInto(out *ManagedInstanceProfilesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedInstanceProfilesStatus.
+func (in *ManagedInstanceProfilesStatus) DeepCopy() *ManagedInstanceProfilesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedInstanceProfilesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -1749,6 +2303,21 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCProviderStatus) DeepCopyInto(out *OIDCProviderStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProviderStatus.
+func (in *OIDCProviderStatus) DeepCopy() *OIDCProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateDNSName) DeepCopyInto(out *PrivateDNSName) {
 	*out = *in
@@ -1779,6 +2348,81 @@ func (in *PrivateDNSName) DeepCopy() *PrivateDNSName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolesAnywhereSpec) DeepCopyInto(out *RolesAnywhereSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolesAnywhereSpec.
+func (in *RolesAnywhereSpec) DeepCopy() *RolesAnywhereSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolesAnywhereSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route53HealthCheck) DeepCopyInto(out *Route53HealthCheck) {
+	*out = *in
+	if in.RequestInterval != nil {
+		in, out := &in.RequestInterval, &out.RequestInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53HealthCheck.
+func (in *Route53HealthCheck) DeepCopy() *Route53HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(Route53HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route53HealthCheckStatus) DeepCopyInto(out *Route53HealthCheckStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53HealthCheckStatus.
+func (in *Route53HealthCheckStatus) DeepCopy() *Route53HealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(Route53HealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -1812,6 +2456,11 @@ func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BootstrapDataExpirationDays != nil {
+		in, out := &in.BootstrapDataExpirationDays, &out.BootstrapDataExpirationDays
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Bucket.
@@ -1853,6 +2502,42 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ServiceEndpoints) DeepCopyInto(out *ServiceEndpoints) {
+	{
+		in := &in
+		*out = make(ServiceEndpoints, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpoints.
+func (in ServiceEndpoints) DeepCopy() ServiceEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpoints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionTag) DeepCopyInto(out *SessionTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionTag.
+func (in *SessionTag) DeepCopy() *SessionTag {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotMarketOptions) DeepCopyInto(out *SpotMarketOptions) {
 	*out = *in
@@ -1873,6 +2558,85 @@ func (in *SpotMarketOptions) DeepCopy() *SpotMarketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StandbyNetworkStatus) DeepCopyInto(out *StandbyNetworkStatus) {
+	*out = *in
+	in.VPC.DeepCopyInto(&out.VPC)
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(Subnets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make(map[SecurityGroupRole]SecurityGroup, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyNetworkStatus.
+func (in *StandbyNetworkStatus) DeepCopy() *StandbyNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StandbyNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StandbyRegion) DeepCopyInto(out *StandbyRegion) {
+	*out = *in
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyRegion.
+func (in *StandbyRegion) DeepCopy() *StandbyRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(StandbyRegion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSelector) DeepCopyInto(out *SubnetSelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]SubnetTagSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSelector.
+func (in *SubnetSelector) DeepCopy() *SubnetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -1915,6 +2679,26 @@ func (in *SubnetSpec) DeepCopy() *SubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetTagSelector) DeepCopyInto(out *SubnetTagSelector) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetTagSelector.
+func (in *SubnetTagSelector) DeepCopy() *SubnetTagSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetTagSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Subnets) DeepCopyInto(out *Subnets) {
 	{
@@ -2157,6 +2941,11 @@ func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SecondaryCidrBlocks != nil {
+		in, out := &in.SecondaryCidrBlocks, &out.SecondaryCidrBlocks
+		*out = make([]VpcCidrBlock, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSpec.
@@ -2193,3 +2982,18 @@ func (in *Volume) DeepCopy() *Volume {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcCidrBlock) DeepCopyInto(out *VpcCidrBlock) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcCidrBlock.
+func (in *VpcCidrBlock) DeepCopy() *VpcCidrBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcCidrBlock)
+	in.DeepCopyInto(out)
+	return out
+}
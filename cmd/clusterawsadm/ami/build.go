@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/blang/semver"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
+	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+)
+
+const (
+	// OSTagKey is the tag set on built AMIs with their operating system.
+	OSTagKey = infrav1.NameAWSProviderPrefix + "os"
+	// KubernetesVersionTagKey is the tag set on built AMIs with their Kubernetes version.
+	KubernetesVersionTagKey = infrav1.NameAWSProviderPrefix + "kubernetes-version"
+)
+
+// BuildInput defines the input of an AMI build with image-builder.
+type BuildInput struct {
+	// ImageBuilderDir is the images/capi directory of an image-builder checkout.
+	ImageBuilderDir   string
+	OperatingSystem   string
+	KubernetesVersion string
+	Regions           []string
+	// VarFiles are additional packer variable files passed to image-builder.
+	VarFiles []string
+	DryRun   bool
+	Stdout   io.Writer
+	Log      logr.Logger
+}
+
+// Build builds an AMI with image-builder, copies it to the regions of the input, and tags the
+// copies so they can be found by the default AMI lookup of CAPA.
+func Build(ctx context.Context, input BuildInput) (*amiv1.AWSAMIList, error) {
+	if len(input.Regions) == 0 {
+		return nil, errors.New("at least one region is required")
+	}
+
+	variables, err := packerVariables(input.KubernetesVersion, input.Regions)
+	if err != nil {
+		return nil, err
+	}
+
+	varFile, err := writePackerVarFile(variables)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(varFile)
+
+	target := buildTarget(input.OperatingSystem)
+	makeCmd := exec.Command("make", "-C", input.ImageBuilderDir, target) //nolint:gosec
+	makeCmd.Env = append(os.Environ(), "PACKER_VAR_FILES="+strings.Join(append([]string{varFile}, input.VarFiles...), " "))
+	makeCmd.Stdout = input.Stdout
+	makeCmd.Stderr = input.Stdout
+
+	if input.DryRun {
+		input.Log.Info("Skipping the build, dry-run enabled", "command", makeCmd.String(), "variables", variables)
+		return &amiv1.AWSAMIList{}, nil
+	}
+
+	input.Log.Info("Building AMI with image-builder, this may take a while...", "target", target)
+	if err := makeCmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to run image-builder target %s", target)
+	}
+
+	return tagBuiltImages(ctx, input)
+}
+
+// tagBuiltImages looks up the built AMI in each region the same way CAPA does, and tags it with
+// its operating system and Kubernetes version.
+func tagBuiltImages(ctx context.Context, input BuildInput) (*amiv1.AWSAMIList, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            aws.Config{Region: aws.String(input.Regions[0])},
+	})
+	if err != nil {
+		return nil, err
+	}
+	identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the AWS account ID")
+	}
+	ownerID := aws.StringValue(identity.Account)
+
+	list := &amiv1.AWSAMIList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       amiv1.AWSAMIListKind,
+			APIVersion: amiv1.SchemeGroupVersion.String(),
+		},
+	}
+	errs := []error{}
+	for _, region := range input.Regions {
+		ec2Client := ec2.New(sess, aws.NewConfig().WithRegion(region))
+
+		image, err := ec2service.DefaultAMILookup(ctx, ec2Client, ownerID, input.OperatingSystem, input.KubernetesVersion, ec2service.Amd64ArchitectureTag, "")
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "built AMI not found in region %s with the default AMI lookup", region))
+			continue
+		}
+
+		_, err = ec2Client.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{image.ImageId},
+			Tags: []*ec2.Tag{
+				{Key: aws.String(OSTagKey), Value: aws.String(input.OperatingSystem)},
+				{Key: aws.String(KubernetesVersionTagKey), Value: aws.String(input.KubernetesVersion)},
+			},
+		})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to tag AMI %s in region %s", aws.StringValue(image.ImageId), region))
+			continue
+		}
+		input.Log.Info("Tagged built AMI", "region", region, "imageID", aws.StringValue(image.ImageId))
+
+		list.Items = append(list.Items, amiv1.AWSAMI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              aws.StringValue(image.Name),
+				CreationTimestamp: metav1.NewTime(time.Now()),
+			},
+			TypeMeta: metav1.TypeMeta{
+				Kind:       amiv1.AWSAMIKind,
+				APIVersion: amiv1.SchemeGroupVersion.String(),
+			},
+			Spec: amiv1.AWSAMISpec{
+				OS:                input.OperatingSystem,
+				Region:            region,
+				ImageID:           aws.StringValue(image.ImageId),
+				KubernetesVersion: input.KubernetesVersion,
+			},
+		})
+	}
+
+	return list, kerrors.NewAggregate(errs)
+}
+
+// buildTarget returns the image-builder make target of an operating system, e.g. build-ami-ubuntu-2004
+// for ubuntu-20.04.
+func buildTarget(operatingSystem string) string {
+	name := strings.ReplaceAll(operatingSystem, ".", "")
+	name = strings.TrimSuffix(name, "-stable")
+	return "build-ami-" + name
+}
+
+// packerVariables returns the image-builder packer variables for a Kubernetes version and a set of regions.
+func packerVariables(kubernetesVersion string, regions []string) (map[string]string, error) {
+	v, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", kubernetesVersion)
+	}
+
+	return map[string]string{
+		"kubernetes_semver":      fmt.Sprintf("v%s", v),
+		"kubernetes_series":      fmt.Sprintf("v%d.%d", v.Major, v.Minor),
+		"kubernetes_rpm_version": v.String(),
+		"kubernetes_deb_version": fmt.Sprintf("%s-1.1", v),
+		"ami_regions":            strings.Join(regions, ","),
+	}, nil
+}
+
+func writePackerVarFile(variables map[string]string) (string, error) {
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "clusterawsadm-ami-*.json")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create packer variable file")
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return "", errors.Wrap(err, "failed to write packer variable file")
+	}
+	return filepath.Clean(f.Name()), nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ami
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildTarget(t *testing.T) {
+	tests := map[string]string{
+		"ubuntu-22.04":   "build-ami-ubuntu-2204",
+		"centos-7":       "build-ami-centos-7",
+		"amazon-2":       "build-ami-amazon-2",
+		"flatcar-stable": "build-ami-flatcar",
+	}
+	for os, want := range tests {
+		if got := buildTarget(os); got != want {
+			t.Errorf("buildTarget(%q) got = %v, want %v", os, got, want)
+		}
+	}
+}
+
+func TestPackerVariables(t *testing.T) {
+	got, err := packerVariables("1.28.3", []string{"us-west-2", "eu-west-1"})
+	if err != nil {
+		t.Fatalf("error while generating packer variables %+v", err)
+	}
+	want := map[string]string{
+		"kubernetes_semver":      "v1.28.3",
+		"kubernetes_series":      "v1.28",
+		"kubernetes_rpm_version": "1.28.3",
+		"kubernetes_deb_version": "1.28.3-1.1",
+		"ami_regions":            "us-west-2,eu-west-1",
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("packerVariables() got = %v, want %v", got, want)
+	}
+
+	if _, err := packerVariables("latest", nil); err == nil {
+		t.Errorf("packerVariables() expected an error for an invalid version")
+	}
+}
@@ -17,22 +17,32 @@ limitations under the License.
 package ami
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
 	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api/util"
 )
 
+// sourceImageIDTagKey is the tag recording the ID of the image an AMI was copied from, so that the regions already
+// having a copy of the image are skipped.
+const sourceImageIDTagKey = "sigs.k8s.io/cluster-api-provider-aws/source-image-id"
+
 // CopyInput defines input that can be copied to create an AWSAMI.
 type CopyInput struct {
 	SourceRegion      string
@@ -44,50 +54,98 @@ type CopyInput struct {
 	DryRun            bool
 	Encrypted         bool
 	Log               logr.Logger
+	// NewEC2Client returns the EC2 client of a region. Clients using the shared AWS configuration are used if not set.
+	NewEC2Client func(region string) (ec2iface.EC2API, error)
+	// NewKMSClient returns the KMS client of a region. Clients using the shared AWS configuration are used if not set.
+	NewKMSClient func(region string) (kmsiface.KMSAPI, error)
 }
 
-// Copy will create an AWSAMI from a CopyInput.
-func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
-	sourceSession, err := session.NewSessionWithOptions(session.Options{
+// newSession returns a session of the region using the shared AWS configuration.
+func newSession(region string) (*session.Session, error) {
+	return session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{Region: aws.String(input.SourceRegion)},
+		Config:            aws.Config{Region: aws.String(region)},
 	})
+}
+
+// newEC2Client returns an EC2 client of the region using the shared AWS configuration.
+func newEC2Client(region string) (ec2iface.EC2API, error) {
+	sess, err := newSession(region)
 	if err != nil {
 		return nil, err
 	}
-	ec2Client := ec2.New(sourceSession)
+	return ec2.New(sess), nil
+}
 
-	image, err := ec2service.DefaultAMILookup(ec2Client, input.OwnerID, input.OperatingSystem, input.KubernetesVersion, ec2service.Amd64ArchitectureTag, "")
+// newKMSClient returns a KMS client of the region using the shared AWS configuration.
+func newKMSClient(region string) (kmsiface.KMSAPI, error) {
+	sess, err := newSession(region)
 	if err != nil {
 		return nil, err
 	}
+	return kms.New(sess), nil
+}
 
-	var newImageID, newImageName string
+// Copy will create an AWSAMI from a CopyInput. The AMI isn't copied again if the destination region already has
+// a copy of it, with the same encryption and KMS key.
+func Copy(ctx context.Context, input CopyInput) (*amiv1.AWSAMI, error) {
+	if input.NewEC2Client == nil {
+		input.NewEC2Client = newEC2Client
+	}
+	if input.NewKMSClient == nil {
+		input.NewKMSClient = newKMSClient
+	}
+	ec2Client, err := input.NewEC2Client(input.SourceRegion)
+	if err != nil {
+		return nil, err
+	}
 
-	destSession, err := session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{Region: aws.String(input.DestinationRegion)},
-	})
+	image, err := ec2service.DefaultAMILookup(ctx, ec2Client, input.OwnerID, input.OperatingSystem, input.KubernetesVersion, ec2service.Amd64ArchitectureTag, "")
 	if err != nil {
 		return nil, err
 	}
 
+	destClient, err := input.NewEC2Client(input.DestinationRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	var kmsKeyARN string
 	if input.Encrypted {
+		kmsClient, err := input.NewKMSClient(input.DestinationRegion)
+		if err != nil {
+			return nil, err
+		}
+		kmsKeyARN, err = encryptionKeyARN(ctx, destClient, kmsClient, input.KmsKeyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newImageName, newImageID, err := findCopy(ctx, destClient, image, input.Encrypted, kmsKeyARN)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case newImageID != "":
+		input.Log.Info("The AMI was already copied to the region, skipping", "imageName", newImageName, "imageID", newImageID)
+	case input.Encrypted:
 		newImageName, newImageID, err = copyWithSnapshot(copyWithSnapshotInput{
 			sourceRegion:      input.SourceRegion,
 			image:             image,
 			destinationRegion: input.DestinationRegion,
 			encrypted:         input.Encrypted,
 			kmsKeyID:          input.KmsKeyID,
-			sess:              destSession,
+			ec2Client:         destClient,
 			log:               input.Log,
 		})
-	} else {
+	default:
 		newImageName, newImageID, err = copyWithoutSnapshot(copyWithoutSnapshotInput{
 			sourceRegion: input.SourceRegion,
 			image:        image,
 			dryRun:       input.DryRun,
-			sess:         destSession,
+			ec2Client:    destClient,
 			log:          input.Log,
 		})
 	}
@@ -120,23 +178,151 @@ func Copy(input CopyInput) (*amiv1.AWSAMI, error) {
 	return &ami, err
 }
 
+// CopyToRegions copies the AMI of the CopyInput to each of the given regions concurrently, ignoring
+// the DestinationRegion of the input, and returns the copies in the order of the regions.
+func CopyToRegions(ctx context.Context, input CopyInput, regions []string) (*amiv1.AWSAMIList, error) {
+	amis := make([]*amiv1.AWSAMI, len(regions))
+	errs := make([]error, len(regions))
+
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+
+			regionInput := input
+			regionInput.DestinationRegion = region
+			regionInput.Log = input.Log.WithValues("region", region)
+			amis[i], errs[i] = Copy(ctx, regionInput)
+			if errs[i] != nil {
+				errs[i] = errors.Wrapf(errs[i], "failed to copy AMI to region %s", region)
+			}
+		}(i, region)
+	}
+	wg.Wait()
+
+	list := &amiv1.AWSAMIList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       amiv1.AWSAMIListKind,
+			APIVersion: amiv1.SchemeGroupVersion.String(),
+		},
+	}
+	for _, ami := range amis {
+		if ami != nil {
+			list.Items = append(list.Items, *ami)
+		}
+	}
+
+	return list, kerrors.NewAggregate(errs)
+}
+
+// encryptionKeyARN returns the ARN of the KMS key encrypting the snapshots of the copies, which is the given key,
+// or the default EBS encryption key of the region if not set. Keys can be given by ID, ARN, alias name or alias ARN.
+func encryptionKeyARN(ctx context.Context, ec2Client ec2iface.EC2API, kmsClient kmsiface.KMSAPI, kmsKeyID string) (string, error) {
+	if kmsKeyID == "" {
+		out, err := ec2Client.GetEbsDefaultKmsKeyIdWithContext(ctx, &ec2.GetEbsDefaultKmsKeyIdInput{})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get the default EBS encryption key")
+		}
+		kmsKeyID = aws.StringValue(out.KmsKeyId)
+	}
+
+	out, err := kmsClient.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String(kmsKeyID)})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe the KMS key %s", kmsKeyID)
+	}
+	return aws.StringValue(out.KeyMetadata.Arn), nil
+}
+
+// findCopy returns the name and ID of the copy of the image owned by the account in the region of the client, if any.
+// The snapshots of an encrypted copy must be encrypted with the KMS key of the given ARN.
+func findCopy(ctx context.Context, ec2Client ec2iface.EC2API, image *ec2.Image, encrypted bool, kmsKeyARN string) (string, string, error) {
+	out, err := ec2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{"self"}),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + sourceImageIDTagKey),
+				Values: []*string{image.ImageId},
+			},
+			{
+				Name:   aws.String("block-device-mapping.encrypted"),
+				Values: aws.StringSlice([]string{strconv.FormatBool(encrypted)}),
+			},
+		},
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to describe the copies of the image")
+	}
+	for _, copied := range out.Images {
+		if aws.StringValue(copied.State) == ec2.ImageStateFailed {
+			continue
+		}
+		if encrypted {
+			sameKey, err := encryptedWithKey(ctx, ec2Client, copied, kmsKeyARN)
+			if err != nil {
+				return "", "", err
+			}
+			if !sameKey {
+				continue
+			}
+		}
+		return aws.StringValue(copied.Name), aws.StringValue(copied.ImageId), nil
+	}
+	return "", "", nil
+}
+
+// encryptedWithKey returns true if all the snapshots of the image are encrypted with the KMS key of the given ARN.
+func encryptedWithKey(ctx context.Context, ec2Client ec2iface.EC2API, image *ec2.Image, kmsKeyARN string) (bool, error) {
+	var snapshotIDs []*string
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+			snapshotIDs = append(snapshotIDs, mapping.Ebs.SnapshotId)
+		}
+	}
+	if len(snapshotIDs) == 0 {
+		return false, nil
+	}
+
+	out, err := ec2Client.DescribeSnapshotsWithContext(ctx, &ec2.DescribeSnapshotsInput{SnapshotIds: snapshotIDs})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe the snapshots of the image %s", aws.StringValue(image.ImageId))
+	}
+	for _, snapshot := range out.Snapshots {
+		if aws.StringValue(snapshot.KmsKeyId) != kmsKeyARN {
+			return false, nil
+		}
+	}
+	return len(out.Snapshots) == len(snapshotIDs), nil
+}
+
+// sourceImageIDTags returns the tags recording the image a copy was made from.
+func sourceImageIDTags(image *ec2.Image) []*ec2.Tag {
+	return []*ec2.Tag{{Key: aws.String(sourceImageIDTagKey), Value: image.ImageId}}
+}
+
 type copyWithoutSnapshotInput struct {
 	sourceRegion string
 	dryRun       bool
 	log          logr.Logger
-	sess         *session.Session
+	ec2Client    ec2iface.EC2API
 	image        *ec2.Image
 }
 
 func copyWithoutSnapshot(input copyWithoutSnapshotInput) (string, string, error) {
 	imgName := aws.StringValue(input.image.Name)
-	ec2Client := ec2.New(input.sess)
+	ec2Client := input.ec2Client
 	in2 := &ec2.CopyImageInput{
 		Description:   input.image.Description,
 		DryRun:        aws.Bool(input.dryRun),
 		Name:          input.image.Name,
 		SourceImageId: input.image.ImageId,
 		SourceRegion:  aws.String(input.sourceRegion),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeImage),
+				Tags:         sourceImageIDTags(input.image),
+			},
+		},
 	}
 	log := input.log.WithValues("imageName", imgName)
 	log.Info("Copying the retrieved image", "imageID", aws.StringValue(input.image.ImageId), "ownerID", aws.StringValue(input.image.OwnerId))
@@ -157,11 +343,11 @@ type copyWithSnapshotInput struct {
 	encrypted         bool
 	log               logr.Logger
 	image             *ec2.Image
-	sess              *session.Session
+	ec2Client         ec2iface.EC2API
 }
 
 func copyWithSnapshot(input copyWithSnapshotInput) (string, string, error) {
-	ec2Client := ec2.New(input.sess)
+	ec2Client := input.ec2Client
 	imgName := *input.image.Name + util.RandomString(3) + strconv.Itoa(int(time.Now().Unix()))
 	log := input.log.WithValues("imageName", imgName)
 
@@ -230,6 +416,12 @@ func copyWithSnapshot(input copyWithSnapshotInput) (string, string, error) {
 		RootDeviceName:      input.image.RootDeviceName,
 		SriovNetSupport:     input.image.SriovNetSupport,
 		VirtualizationType:  input.image.VirtualizationType,
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeImage),
+				Tags:         sourceImageIDTags(input.image),
+			},
+		},
 	})
 
 	if err != nil {
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ami
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ebsencryption/mock_kmsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+const sourceImageID = "ami-source"
+
+var sourceImage = &ec2.Image{
+	ImageId:      aws.String(sourceImageID),
+	Name:         aws.String("capa-ami-ubuntu-20.04-v1.28.3-1700000000"),
+	CreationDate: aws.String("2023-11-14T22:13:20.000Z"),
+	BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+		{
+			DeviceName: aws.String("/dev/sda1"),
+			Ebs:        &ec2.EbsBlockDevice{SnapshotId: aws.String("snap-source")},
+		},
+	},
+}
+
+// expectCopyLookup expects the lookup of an existing copy of the source image, returning the given images.
+func expectCopyLookup(m *mocks.MockEC2APIMockRecorder, encrypted string, images ...*ec2.Image) {
+	m.DescribeImagesWithContext(gomock.Any(), &ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{"self"}),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("tag:" + sourceImageIDTagKey), Values: aws.StringSlice([]string{sourceImageID})},
+			{Name: aws.String("block-device-mapping.encrypted"), Values: aws.StringSlice([]string{encrypted})},
+		},
+	}).Return(&ec2.DescribeImagesOutput{Images: images}, nil)
+}
+
+// expectCopyImage expects the copy of the source image, tagged with the ID of the source image, returning the ID of
+// the copy or the error.
+func expectCopyImage(m *mocks.MockEC2APIMockRecorder, imageID string, err error) {
+	call := m.CopyImage(&ec2.CopyImageInput{
+		DryRun:        aws.Bool(false),
+		Name:          sourceImage.Name,
+		SourceImageId: aws.String(sourceImageID),
+		SourceRegion:  aws.String("us-east-1"),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeImage),
+				Tags:         []*ec2.Tag{{Key: aws.String(sourceImageIDTagKey), Value: aws.String(sourceImageID)}},
+			},
+		},
+	})
+	if err != nil {
+		call.Return(nil, err)
+		return
+	}
+	call.Return(&ec2.CopyImageOutput{ImageId: aws.String(imageID)}, nil)
+}
+
+// expectCopySnapshotEncrypted expects the copy of the snapshot of the source image, encrypted with the given KMS key,
+// and the registration of the image of the snapshot, tagged with the ID of the source image.
+func expectCopySnapshotEncrypted(m *mocks.MockEC2APIMockRecorder, kmsKeyID, snapshotID, imageID string) {
+	m.CopySnapshotRequest(gomock.Any()).DoAndReturn(presignableCopySnapshotRequest)
+	m.CopySnapshot(gomock.Any()).DoAndReturn(func(input *ec2.CopySnapshotInput) (*ec2.CopySnapshotOutput, error) {
+		if !aws.BoolValue(input.Encrypted) || aws.StringValue(input.KmsKeyId) != kmsKeyID || aws.StringValue(input.PresignedUrl) == "" {
+			return nil, errors.New("unexpected unencrypted or unsigned snapshot copy")
+		}
+		return &ec2.CopySnapshotOutput{SnapshotId: aws.String(snapshotID)}, nil
+	})
+	m.WaitUntilSnapshotCompleted(gomock.Any()).Return(nil)
+	m.RegisterImage(gomock.Any()).DoAndReturn(func(input *ec2.RegisterImageInput) (*ec2.RegisterImageOutput, error) {
+		if aws.StringValue(input.BlockDeviceMappings[0].Ebs.SnapshotId) != snapshotID ||
+			aws.StringValue(input.TagSpecifications[0].Tags[0].Value) != sourceImageID {
+			return nil, errors.New("unexpected image registration")
+		}
+		return &ec2.RegisterImageOutput{ImageId: aws.String(imageID)}, nil
+	})
+}
+
+// encryptedCopy returns an available copy of the source image, whose snapshot is encrypted with the given KMS key.
+func encryptedCopy(m *mocks.MockEC2APIMockRecorder, imageID, kmsKeyARN string) *ec2.Image {
+	snapshotID := "snap-" + imageID
+	m.DescribeSnapshotsWithContext(gomock.Any(), &ec2.DescribeSnapshotsInput{SnapshotIds: aws.StringSlice([]string{snapshotID})}).
+		Return(&ec2.DescribeSnapshotsOutput{Snapshots: []*ec2.Snapshot{{SnapshotId: aws.String(snapshotID), KmsKeyId: aws.String(kmsKeyARN)}}}, nil)
+	return &ec2.Image{
+		ImageId: aws.String(imageID),
+		State:   aws.String(ec2.ImageStateAvailable),
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsBlockDevice{SnapshotId: aws.String(snapshotID), Encrypted: aws.Bool(true)}},
+		},
+	}
+}
+
+const (
+	defaultKeyARN = "arn:aws:kms:us-east-2:123456789012:key/default"
+	customKeyARN  = "arn:aws:kms:us-east-2:123456789012:key/custom"
+)
+
+// expectKeyLookup expects the resolution of the KMS key alias/capa to the custom key, or of the default EBS
+// encryption key if the KMS key isn't set.
+func expectKeyLookup(ec2Mock *mocks.MockEC2APIMockRecorder, kmsMock *mock_kmsiface.MockKMSAPIMockRecorder, kmsKeyID string) {
+	keyARN := customKeyARN
+	if kmsKeyID == "" {
+		kmsKeyID = "alias/aws/ebs"
+		keyARN = defaultKeyARN
+		ec2Mock.GetEbsDefaultKmsKeyIdWithContext(gomock.Any(), gomock.Any()).Return(&ec2.GetEbsDefaultKmsKeyIdOutput{KmsKeyId: aws.String(kmsKeyID)}, nil)
+	}
+	kmsMock.DescribeKeyWithContext(gomock.Any(), &kms.DescribeKeyInput{KeyId: aws.String(kmsKeyID)}).
+		Return(&kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{Arn: aws.String(keyARN)}}, nil)
+}
+
+func TestCopyToRegions(t *testing.T) {
+	tests := []struct {
+		name      string
+		encrypted bool
+		kmsKeyID  string
+		expect    map[string]func(m *mocks.MockEC2APIMockRecorder)
+		wantIDs   []string
+		wantErr   string
+	}{
+		{
+			name: "copies the AMI to each region",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-us-east-2", nil)
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-eu-west-1", nil)
+				},
+			},
+			wantIDs: []string{"ami-us-east-2", "ami-eu-west-1"},
+		},
+		{
+			name: "skips the regions already having a copy of the AMI",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-us-east-2", nil)
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false",
+						&ec2.Image{ImageId: aws.String("ami-failed"), State: aws.String(ec2.ImageStateFailed)},
+						&ec2.Image{ImageId: aws.String("ami-existing"), Name: sourceImage.Name, State: aws.String(ec2.ImageStateAvailable)},
+					)
+				},
+			},
+			wantIDs: []string{"ami-us-east-2", "ami-existing"},
+		},
+		{
+			name: "returns the copies of the other regions when a region fails",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "", errors.New("UnauthorizedOperation"))
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-eu-west-1", nil)
+				},
+			},
+			wantIDs: []string{"ami-eu-west-1"},
+			wantErr: "failed to copy AMI to region us-east-2: UnauthorizedOperation",
+		},
+		{
+			name: "fails the regions where the copy can't be tagged",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "", errors.New("UnauthorizedOperation: You are not authorized to perform: ec2:CreateTags"))
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-eu-west-1", nil)
+				},
+			},
+			wantIDs: []string{"ami-eu-west-1"},
+			wantErr: "failed to copy AMI to region us-east-2: UnauthorizedOperation: You are not authorized to perform: ec2:CreateTags",
+		},
+		{
+			name: "fails the regions where the existing copies can't be looked up",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					m.DescribeImagesWithContext(gomock.Any(), gomock.Any()).Return(nil, errors.New("RequestLimitExceeded"))
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "false")
+					expectCopyImage(m, "ami-eu-west-1", nil)
+				},
+			},
+			wantIDs: []string{"ami-eu-west-1"},
+			wantErr: "failed to copy AMI to region us-east-2: failed to describe the copies of the image: RequestLimitExceeded",
+		},
+		{
+			name:      "copies the snapshot of the AMI to encrypt it, skipping the regions having an encrypted copy",
+			encrypted: true,
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "true")
+					expectCopySnapshotEncrypted(m, "", "snap-us-east-2", "ami-us-east-2")
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "true", encryptedCopy(m, "ami-existing", defaultKeyARN))
+				},
+			},
+			wantIDs: []string{"ami-us-east-2", "ami-existing"},
+		},
+		{
+			name:      "copies the AMI again to the regions having a copy encrypted with another KMS key",
+			encrypted: true,
+			kmsKeyID:  "alias/capa",
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "true", encryptedCopy(m, "ami-default-key", defaultKeyARN))
+					expectCopySnapshotEncrypted(m, "alias/capa", "snap-us-east-2", "ami-us-east-2")
+				},
+				"eu-west-1": func(m *mocks.MockEC2APIMockRecorder) {
+					expectCopyLookup(m, "true", encryptedCopy(m, "ami-default-key", defaultKeyARN), encryptedCopy(m, "ami-custom-key", customKeyARN))
+				},
+			},
+			wantIDs: []string{"ami-us-east-2", "ami-custom-key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			regions := []string{"us-east-2", "eu-west-1"}
+			clients := map[string]ec2iface.EC2API{}
+			kmsClients := map[string]kmsiface.KMSAPI{}
+			source := mocks.NewMockEC2API(mockCtrl)
+			source.EXPECT().DescribeImagesWithContext(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{sourceImage}}, nil).Times(len(regions))
+			clients["us-east-1"] = source
+			for _, region := range regions {
+				m := mocks.NewMockEC2API(mockCtrl)
+				kmsMock := mock_kmsiface.NewMockKMSAPI(mockCtrl)
+				if tt.encrypted {
+					expectKeyLookup(m.EXPECT(), kmsMock.EXPECT(), tt.kmsKeyID)
+				}
+				tt.expect[region](m.EXPECT())
+				clients[region] = m
+				kmsClients[region] = kmsMock
+			}
+
+			amis, err := CopyToRegions(context.TODO(), CopyInput{
+				SourceRegion:      "us-east-1",
+				OperatingSystem:   "ubuntu-20.04",
+				KubernetesVersion: "v1.28.3",
+				Encrypted:         tt.encrypted,
+				KmsKeyID:          tt.kmsKeyID,
+				Log:               logr.Discard(),
+				NewEC2Client: func(region string) (ec2iface.EC2API, error) {
+					return clients[region], nil
+				},
+				NewKMSClient: func(region string) (kmsiface.KMSAPI, error) {
+					return kmsClients[region], nil
+				},
+			}, regions)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			var ids []string
+			for _, ami := range amis.Items {
+				ids = append(ids, ami.Spec.ImageID)
+			}
+			g.Expect(ids).To(Equal(tt.wantIDs))
+		})
+	}
+}
+
+// presignableCopySnapshotRequest returns a CopySnapshot request of a client with static credentials, which can be
+// presigned without calling AWS.
+func presignableCopySnapshotRequest(input *ec2.CopySnapshotInput) (*request.Request, *ec2.CopySnapshotOutput) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-2"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+	return ec2.New(sess).CopySnapshotRequest(input)
+}
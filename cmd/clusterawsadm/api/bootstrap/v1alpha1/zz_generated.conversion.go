@@ -187,6 +187,8 @@ func Convert_v1alpha1_AWSIAMConfigurationSpec_To_v1beta1_AWSIAMConfigurationSpec
 func autoConvert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfigurationSpec(in *v1beta1.AWSIAMConfigurationSpec, out *AWSIAMConfigurationSpec, s conversion.Scope) error {
 	out.NamePrefix = in.NamePrefix
 	out.NameSuffix = (*string)(unsafe.Pointer(in.NameSuffix))
+	// WARNING: in.Path requires manual conversion: does not exist in peer-type
+	// WARNING: in.PermissionsBoundary requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta1_ControlPlane_To_v1alpha1_ControlPlane(&in.ControlPlane, &out.ControlPlane, s); err != nil {
 		return err
 	}
@@ -207,6 +209,7 @@ func autoConvert_v1beta1_AWSIAMConfigurationSpec_To_v1alpha1_AWSIAMConfiguration
 	out.Partition = in.Partition
 	out.SecureSecretsBackends = *(*[]v1beta2.SecretBackend)(unsafe.Pointer(&in.SecureSecretsBackends))
 	// WARNING: in.S3Buckets requires manual conversion: does not exist in peer-type
+	// WARNING: in.SSMParameters requires manual conversion: does not exist in peer-type
 	// WARNING: in.AllowAssumeRole requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -39,6 +39,8 @@ const (
 	DefaultKMSAliasPattern = "cluster-api-provider-aws-*"
 	// DefaultS3BucketPrefix is the default S3 bucket prefix.
 	DefaultS3BucketPrefix = "cluster-api-provider-aws-"
+	// DefaultSSMParameterPrefix is the default SSM parameter prefix.
+	DefaultSSMParameterPrefix = "cluster-api-provider-aws/"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -106,6 +108,10 @@ func SetDefaults_AWSIAMConfigurationSpec(obj *AWSIAMConfigurationSpec) { //nolin
 	if obj.S3Buckets.NamePrefix == "" {
 		obj.S3Buckets.NamePrefix = DefaultS3BucketPrefix
 	}
+
+	if obj.SSMParameters.NamePrefix == "" {
+		obj.SSMParameters.NamePrefix = DefaultSSMParameterPrefix
+	}
 }
 
 // SetDefaults_AWSIAMConfiguration is used by defaulter-gen.
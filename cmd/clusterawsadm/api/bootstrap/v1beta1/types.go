@@ -170,6 +170,17 @@ type S3Buckets struct {
 	NamePrefix string `json:"namePrefix"`
 }
 
+// SSMParameters controls the configuration of the AWS IAM roles for AWS Systems Manager Parameter Store
+// parameters which can be used instead of S3 buckets to store bootstrap data for nodes.
+type SSMParameters struct {
+	// Enable controls whether permissions are granted to manage SSM parameters.
+	Enable bool `json:"enable"`
+
+	// NamePrefix will be prepended to every SSM parameter name allowed. Defaults to "cluster-api-provider-aws/".
+	// AWSCluster SSM parameter prefix must be prefixed with the same prefix.
+	NamePrefix string `json:"namePrefix"`
+}
+
 // AWSIAMConfigurationSpec defines the specification of the AWSIAMConfiguration.
 type AWSIAMConfigurationSpec struct {
 	// NamePrefix will be prepended to every AWS IAM role, user and policy created by clusterawsadm. Defaults to "".
@@ -179,6 +190,16 @@ type AWSIAMConfigurationSpec struct {
 	// ".cluster-api-provider-aws.sigs.k8s.io".
 	NameSuffix *string `json:"nameSuffix,omitempty"`
 
+	// Path is the AWS IAM path of every AWS IAM role, user, group, instance profile and policy created by
+	// clusterawsadm. It must begin and end with "/". Defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PermissionsBoundary is the ARN of the managed policy set as permissions boundary of every AWS IAM role
+	// and user created by clusterawsadm.
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+
 	// ControlPlane controls the configuration of the AWS IAM role for a Kubernetes cluster's control plane nodes.
 	ControlPlane ControlPlane `json:"controlPlane,omitempty"`
 
@@ -225,8 +246,50 @@ type AWSIAMConfigurationSpec struct {
 	// +optional
 	S3Buckets S3Buckets `json:"s3Buckets,omitempty"`
 
+	// SSMParameters, when enabled, will add controller permissions to create and delete the SSM
+	// parameters storing bootstrap data for workload clusters, and nodes permissions to get and
+	// delete them.
+	// +optional
+	SSMParameters SSMParameters `json:"ssmParameters,omitempty"`
+
 	// AllowAssumeRole enables the sts:AssumeRole permission within the CAPA policies
 	AllowAssumeRole bool `json:"allowAssumeRole,omitempty"`
+
+	// AllowInstanceProfileManagement, when enabled, will add controller permissions to manage the
+	// IAM instance profiles of the AWSClusters configuring instance profile templates, and their roles.
+	// +optional
+	AllowInstanceProfileManagement bool `json:"allowInstanceProfileManagement,omitempty"`
+
+	// AllowCostEstimation, when enabled, will add controller permissions to read the on-demand prices
+	// of the AWS Pricing API, the spot price history and the spot placement scores, which the CostEstimation
+	// feature gate needs.
+	// +optional
+	AllowCostEstimation bool `json:"allowCostEstimation,omitempty"`
+
+	// AllowEBSEncryptionKeyCheck, when enabled, will add controller permissions to read the key policy
+	// and the grants of the KMS keys, to check that the default EBS encryption key of the AWSClusters
+	// can be used by the Auto Scaling service-linked role.
+	// +optional
+	AllowEBSEncryptionKeyCheck bool `json:"allowEBSEncryptionKeyCheck,omitempty"`
+
+	// AllowEBSEncryptionByDefault, when enabled, will add controller permissions to read and enable the
+	// EBS encryption by default setting of the account, which the ebsEncryptionByDefault of the AWSClusters
+	// needs.
+	// +optional
+	AllowEBSEncryptionByDefault bool `json:"allowEBSEncryptionByDefault,omitempty"`
+
+	// AllowRoute53HealthChecks, when enabled, will add controller permissions to manage the Route 53
+	// health checks of the control plane endpoints, which the apiServerHealthCheck of the AWSClusters
+	// needs.
+	// +optional
+	AllowRoute53HealthChecks bool `json:"allowRoute53HealthChecks,omitempty"`
+
+	// ImageLookupSSMParameters lists the names of the AWS Systems Manager parameters, other than the
+	// public parameters under /aws/service/, which the controllers are allowed to read the AMI IDs of the
+	// imageLookupSSMParameter of the AWSMachines from. A name ending with "*" matches all the parameters
+	// with the same prefix, e.g. /my-team/amis/*.
+	// +optional
+	ImageLookupSSMParameters []string `json:"imageLookupSSMParameters,omitempty"`
 }
 
 // GetObjectKind returns the AAWSIAMConfiguration's TypeMeta.
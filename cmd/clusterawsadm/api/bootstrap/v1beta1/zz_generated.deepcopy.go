@@ -86,6 +86,12 @@ func (in *AWSIAMConfigurationSpec) DeepCopyInto(out *AWSIAMConfigurationSpec) {
 		copy(*out, *in)
 	}
 	out.S3Buckets = in.S3Buckets
+	out.SSMParameters = in.SSMParameters
+	if in.ImageLookupSSMParameters != nil {
+		in, out := &in.ImageLookupSSMParameters, &out.ImageLookupSSMParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSIAMConfigurationSpec.
@@ -286,3 +292,18 @@ func (in *S3Buckets) DeepCopy() *S3Buckets {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSMParameters) DeepCopyInto(out *SSMParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSMParameters.
+func (in *SSMParameters) DeepCopy() *SSMParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SSMParameters)
+	in.DeepCopyInto(out)
+	return out
+}
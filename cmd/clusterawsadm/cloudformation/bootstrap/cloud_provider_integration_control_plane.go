@@ -20,6 +20,7 @@ import (
 	"github.com/awslabs/goformation/v4/cloudformation"
 
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 )
 
 func (t Template) cloudProviderControlPlaneAwsRoles() []string {
@@ -32,71 +33,5 @@ func (t Template) cloudProviderControlPlaneAwsRoles() []string {
 
 // From https://github.com/kubernetes/cloud-provider-aws
 func (t Template) cloudProviderControlPlaneAwsPolicy() *iamv1.PolicyDocument {
-	return &iamv1.PolicyDocument{
-		Version: iamv1.CurrentVersion,
-		Statement: []iamv1.StatementEntry{
-			{
-				Effect:   iamv1.EffectAllow,
-				Resource: iamv1.Resources{iamv1.Any},
-				Action: iamv1.Actions{
-					"autoscaling:DescribeAutoScalingGroups",
-					"autoscaling:DescribeLaunchConfigurations",
-					"autoscaling:DescribeTags",
-					"ec2:AssignIpv6Addresses",
-					"ec2:DescribeInstances",
-					"ec2:DescribeImages",
-					"ec2:DescribeRegions",
-					"ec2:DescribeRouteTables",
-					"ec2:DescribeSecurityGroups",
-					"ec2:DescribeSubnets",
-					"ec2:DescribeVolumes",
-					"ec2:CreateSecurityGroup",
-					"ec2:CreateTags",
-					"ec2:CreateVolume",
-					"ec2:ModifyInstanceAttribute",
-					"ec2:ModifyVolume",
-					"ec2:AttachVolume",
-					"ec2:AuthorizeSecurityGroupIngress",
-					"ec2:CreateRoute",
-					"ec2:DeleteRoute",
-					"ec2:DeleteSecurityGroup",
-					"ec2:DeleteVolume",
-					"ec2:DetachVolume",
-					"ec2:RevokeSecurityGroupIngress",
-					"ec2:DescribeVpcs",
-					"elasticloadbalancing:AddTags",
-					"elasticloadbalancing:AttachLoadBalancerToSubnets",
-					"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
-					"elasticloadbalancing:CreateLoadBalancer",
-					"elasticloadbalancing:CreateLoadBalancerPolicy",
-					"elasticloadbalancing:CreateLoadBalancerListeners",
-					"elasticloadbalancing:ConfigureHealthCheck",
-					"elasticloadbalancing:DeleteLoadBalancer",
-					"elasticloadbalancing:DeleteLoadBalancerListeners",
-					"elasticloadbalancing:DescribeLoadBalancers",
-					"elasticloadbalancing:DescribeLoadBalancerAttributes",
-					"elasticloadbalancing:DetachLoadBalancerFromSubnets",
-					"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
-					"elasticloadbalancing:ModifyLoadBalancerAttributes",
-					"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
-					"elasticloadbalancing:SetLoadBalancerPoliciesForBackendServer",
-					"elasticloadbalancing:CreateListener",
-					"elasticloadbalancing:CreateTargetGroup",
-					"elasticloadbalancing:DeleteListener",
-					"elasticloadbalancing:DeleteTargetGroup",
-					"elasticloadbalancing:DeregisterTargets",
-					"elasticloadbalancing:DescribeListeners",
-					"elasticloadbalancing:DescribeLoadBalancerPolicies",
-					"elasticloadbalancing:DescribeTargetGroups",
-					"elasticloadbalancing:DescribeTargetHealth",
-					"elasticloadbalancing:ModifyListener",
-					"elasticloadbalancing:ModifyTargetGroup",
-					"elasticloadbalancing:RegisterTargets",
-					"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
-					"iam:CreateServiceLinkedRole",
-					"kms:DescribeKey",
-				},
-			},
-		},
-	}
+	return eksiam.CloudProviderControlPlanePolicy()
 }
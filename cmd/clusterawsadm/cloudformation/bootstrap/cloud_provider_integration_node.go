@@ -20,6 +20,7 @@ import (
 	"github.com/awslabs/goformation/v4/cloudformation"
 
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 )
 
 func (t Template) cloudProviderNodeAwsRoles() []string {
@@ -36,29 +37,5 @@ func (t Template) cloudProviderNodeAwsRoles() []string {
 
 // From https://github.com/kubernetes/cloud-provider-aws
 func (t Template) cloudProviderNodeAwsPolicy() *iamv1.PolicyDocument {
-	return &iamv1.PolicyDocument{
-		Version: iamv1.CurrentVersion,
-		Statement: []iamv1.StatementEntry{
-			{
-				Effect:   iamv1.EffectAllow,
-				Resource: iamv1.Resources{iamv1.Any},
-				Action: iamv1.Actions{
-					"ec2:AssignIpv6Addresses",
-					"ec2:DescribeInstances",
-					"ec2:DescribeRegions",
-					"ec2:CreateTags",
-					"ec2:DescribeTags",
-					"ec2:DescribeNetworkInterfaces",
-					"ec2:DescribeInstanceTypes",
-					"ecr:GetAuthorizationToken",
-					"ecr:BatchCheckLayerAvailability",
-					"ecr:GetDownloadUrlForLayer",
-					"ecr:GetRepositoryPolicy",
-					"ecr:DescribeRepositories",
-					"ecr:ListImages",
-					"ecr:BatchGetImage",
-				},
-			},
-		},
-	}
+	return eksiam.CloudProviderNodePolicy()
 }
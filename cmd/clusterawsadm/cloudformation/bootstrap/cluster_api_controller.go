@@ -18,6 +18,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/awslabs/goformation/v4/cloudformation"
 	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
@@ -27,7 +28,8 @@ import (
 )
 
 const (
-	eksClusterPolicyName = "AmazonEKSClusterPolicy"
+	eksClusterPolicyName             = "AmazonEKSClusterPolicy"
+	eksLocalOutpostClusterPolicyName = "AmazonEKSLocalOutpostClusterPolicy"
 )
 
 func (t Template) controllersPolicyGroups() []string {
@@ -110,6 +112,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteInternetGateway",
 				"ec2:DeleteEgressOnlyInternetGateway",
 				"ec2:DeleteNatGateway",
+				"ec2:DeleteNetworkInterface",
 				"ec2:DeleteRouteTable",
 				"ec2:ReplaceRoute",
 				"ec2:DeleteSecurityGroup",
@@ -117,6 +120,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteTags",
 				"ec2:DeleteVpc",
 				"ec2:DeleteVpcEndpoints",
+				"ec2:DeleteVolume",
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
@@ -126,6 +130,7 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeEgressOnlyInternetGateways",
 				"ec2:DescribeInstanceTypes",
+				"ec2:DescribeInstanceTypeOfferings",
 				"ec2:DescribeImages",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
@@ -165,12 +170,15 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
 				"elasticloadbalancing:RemoveTags",
 				"elasticloadbalancing:SetSubnets",
+				"elasticloadbalancing:SetIpAddressType",
 				"elasticloadbalancing:ModifyTargetGroupAttributes",
+				"elasticloadbalancing:DescribeTargetGroupAttributes",
 				"elasticloadbalancing:CreateTargetGroup",
 				"elasticloadbalancing:DescribeListeners",
 				"elasticloadbalancing:CreateListener",
 				"elasticloadbalancing:DescribeTargetHealth",
 				"elasticloadbalancing:RegisterTargets",
+				"elasticloadbalancing:DeregisterTargets",
 				"elasticloadbalancing:DeleteListener",
 				"autoscaling:DescribeAutoScalingGroups",
 				"autoscaling:DescribeInstanceRefreshes",
@@ -182,6 +190,8 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DeleteLaunchTemplateVersions",
 				"ec2:DescribeKeyPairs",
 				"ec2:ModifyInstanceMetadataOptions",
+				"ec2:GetConsoleOutput",
+				"ssm:DescribeInstanceInformation",
 			},
 		},
 		{
@@ -196,6 +206,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:StartInstanceRefresh",
 				"autoscaling:DeleteAutoScalingGroup",
 				"autoscaling:DeleteTags",
+				"autoscaling:AttachLoadBalancerTargetGroups",
+				"autoscaling:DetachLoadBalancerTargetGroups",
+				"autoscaling:AttachLoadBalancers",
+				"autoscaling:DetachLoadBalancers",
 			},
 		},
 		{
@@ -241,6 +255,13 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect:   iamv1.EffectAllow,
+			Resource: t.imageLookupSSMParametersARNs(),
+			Action: iamv1.Actions{
+				"ssm:GetParameter",
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -279,6 +300,54 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 			},
 		})
 	}
+	if t.Spec.AllowInstanceProfileManagement {
+		statement = append(statement, t.instanceProfileManagementStatements()...)
+	}
+	if t.Spec.AllowCostEstimation {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"ec2:DescribeSpotPriceHistory",
+				"ec2:GetSpotPlacementScores",
+				"pricing:GetProducts",
+			},
+		})
+	}
+	if t.Spec.AllowEBSEncryptionKeyCheck {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{"arn:*:kms:*:*:key/*"},
+			Action: iamv1.Actions{
+				"kms:GetKeyPolicy",
+				"kms:ListGrants",
+			},
+		})
+	}
+	if t.Spec.AllowEBSEncryptionByDefault {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"ec2:EnableEbsEncryptionByDefault",
+				"ec2:GetEbsEncryptionByDefault",
+			},
+		})
+	}
+	if t.Spec.AllowRoute53HealthChecks {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"route53:ChangeTagsForResource",
+				"route53:CreateHealthCheck",
+				"route53:DeleteHealthCheck",
+				"route53:GetHealthCheck",
+				"route53:GetHealthCheckStatus",
+				"route53:UpdateHealthCheck",
+			},
+		})
+	}
 	if t.Spec.S3Buckets.Enable {
 		statement = append(statement, iamv1.StatementEntry{
 			Effect: iamv1.EffectAllow,
@@ -293,6 +362,38 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"s3:DeleteObject",
 				"s3:PutBucketPolicy",
 				"s3:PutBucketTagging",
+				"s3:PutBucketPublicAccessBlock",
+				"s3:PutEncryptionConfiguration",
+				"s3:PutLifecycleConfiguration",
+				"s3:ListBucket",
+			},
+		})
+		// Needed to associate the service account issuer of self-managed clusters, published in
+		// their S3 bucket, with an IAM OIDC identity provider.
+		statement = append(statement, iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"*",
+			},
+			Action: iamv1.Actions{
+				"iam:ListOpenIDConnectProviders",
+				"iam:GetOpenIDConnectProvider",
+				"iam:CreateOpenIDConnectProvider",
+				"iam:DeleteOpenIDConnectProvider",
+				"iam:TagOpenIDConnectProvider",
+			},
+		})
+	}
+	if t.Spec.SSMParameters.Enable {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				t.ssmParametersARN(),
+			},
+			Action: iamv1.Actions{
+				"ssm:PutParameter",
+				"ssm:DeleteParameter",
+				"ssm:AddTagsToResource",
 			},
 		})
 	}
@@ -419,6 +520,7 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 			},
 			Resource: iamv1.Resources{
 				t.generateAWSManagedPolicyARN(eksClusterPolicyName),
+				t.generateAWSManagedPolicyARN(eksLocalOutpostClusterPolicyName),
 			},
 			Effect: iamv1.EffectAllow,
 		}, {
@@ -433,6 +535,7 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:UntagResource",
 				"eks:UpdateNodegroupVersion",
 				"eks:DescribeNodegroup",
+				"eks:ListNodegroups",
 				"eks:DeleteNodegroup",
 				"eks:UpdateNodegroupConfig",
 				"eks:CreateNodegroup",
@@ -461,11 +564,39 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:DescribeFargateProfile",
 				"eks:CreateFargateProfile",
 				"eks:DeleteFargateProfile",
+				"eks:ListAccessEntries",
+				"eks:DescribeAccessEntry",
+				"eks:CreateAccessEntry",
+				"eks:UpdateAccessEntry",
+				"eks:DeleteAccessEntry",
+				"eks:ListAssociatedAccessPolicies",
+				"eks:AssociateAccessPolicy",
+				"eks:DisassociateAccessPolicy",
+				"eks:ListPodIdentityAssociations",
+				"eks:DescribePodIdentityAssociation",
+				"eks:CreatePodIdentityAssociation",
+				"eks:UpdatePodIdentityAssociation",
+				"eks:DeletePodIdentityAssociation",
 			},
 			Resource: iamv1.Resources{
 				"*",
 			},
 			Effect: iamv1.EffectAllow,
+		}, {
+			Action: iamv1.Actions{
+				"logs:DescribeLogGroups",
+				"logs:CreateLogGroup",
+				"logs:PutRetentionPolicy",
+				"logs:DeleteRetentionPolicy",
+				"logs:AssociateKmsKey",
+				"logs:DisassociateKmsKey",
+				"logs:ListTagsForResource",
+				"logs:TagResource",
+			},
+			Resource: iamv1.Resources{
+				"arn:*:logs:*:*:log-group:*",
+			},
+			Effect: iamv1.EffectAllow,
 		}, {
 			Action: iamv1.Actions{
 				"iam:PassRole",
@@ -479,6 +610,19 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				},
 			},
 			Effect: iamv1.EffectAllow,
+		}, {
+			Action: iamv1.Actions{
+				"iam:PassRole",
+			},
+			Resource: iamv1.Resources{
+				"*",
+			},
+			Condition: iamv1.Conditions{
+				"StringEquals": map[string]string{
+					"iam:PassedToService": "pods.eks.amazonaws.com",
+				},
+			},
+			Effect: iamv1.EffectAllow,
 		},
 		{
 			Action: iamv1.Actions{
@@ -503,6 +647,58 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 	}
 }
 
+// instanceProfileManagementStatements returns the statements allowing the controllers to manage the
+// instance profiles of the AWSClusters and their roles, whose names are prefixed to limit the scope
+// of these permissions.
+func (t Template) instanceProfileManagementStatements() []iamv1.StatementEntry {
+	return []iamv1.StatementEntry{
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				fmt.Sprintf("arn:*:iam::*:role/%s*", infrav1.ManagedInstanceProfileNamePrefix),
+			},
+			Action: iamv1.Actions{
+				"iam:AttachRolePolicy",
+				"iam:CreateRole",
+				"iam:DeleteRole",
+				"iam:DeleteRolePolicy",
+				"iam:DetachRolePolicy",
+				"iam:GetRole",
+				"iam:GetRolePolicy",
+				"iam:ListAttachedRolePolicies",
+				"iam:ListRolePolicies",
+				"iam:PassRole",
+				"iam:PutRolePolicy",
+				"iam:TagRole",
+				"iam:UntagRole",
+				"iam:UpdateAssumeRolePolicy",
+			},
+		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				fmt.Sprintf("arn:*:iam::*:instance-profile/%s*", infrav1.ManagedInstanceProfileNamePrefix),
+			},
+			Action: iamv1.Actions{
+				"iam:AddRoleToInstanceProfile",
+				"iam:CreateInstanceProfile",
+				"iam:DeleteInstanceProfile",
+				"iam:GetInstanceProfile",
+				"iam:RemoveRoleFromInstanceProfile",
+				"iam:TagInstanceProfile",
+				"iam:UntagInstanceProfile",
+			},
+		},
+		{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"iam:GetPolicy",
+			},
+		},
+	}
+}
+
 func (t Template) allowedEC2InstanceProfiles() iamv1.Resources {
 	if t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles == nil {
 		t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles = []string{
@@ -512,8 +708,18 @@ func (t Template) allowedEC2InstanceProfiles() iamv1.Resources {
 	instanceProfiles := make(iamv1.Resources, len(t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles))
 
 	for i, p := range t.Spec.ClusterAPIControllers.AllowedEC2InstanceProfiles {
-		instanceProfiles[i] = fmt.Sprintf("arn:*:iam::*:role/%s", p)
+		instanceProfiles[i] = fmt.Sprintf("arn:*:iam::*:role%s%s", t.iamPath(), p)
 	}
 
 	return instanceProfiles
 }
+
+// imageLookupSSMParametersARNs returns the ARNs matching the SSM parameters which AMI IDs can be looked up from:
+// the public parameters of AWS and the ones allowed in the configuration.
+func (t Template) imageLookupSSMParametersARNs() iamv1.Resources {
+	arns := iamv1.Resources{"arn:*:ssm:*:*:parameter/aws/service/*"}
+	for _, name := range t.Spec.ImageLookupSSMParameters {
+		arns = append(arns, fmt.Sprintf("arn:*:ssm:*:*:parameter/%s", strings.TrimPrefix(name, "/")))
+	}
+	return arns
+}
@@ -17,53 +17,40 @@ limitations under the License.
 package bootstrap
 
 import (
+	"fmt"
+	"strings"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 )
 
 func (t Template) secretPolicy(secureSecretsBackend infrav1.SecretBackend) iamv1.StatementEntry {
-	switch secureSecretsBackend {
-	case infrav1.SecretBackendSecretsManager:
-		return iamv1.StatementEntry{
-			Effect: iamv1.EffectAllow,
-			Resource: iamv1.Resources{
-				"arn:*:secretsmanager:*:*:secret:aws.cluster.x-k8s.io/*",
-			},
-			Action: iamv1.Actions{
-				"secretsmanager:DeleteSecret",
-				"secretsmanager:GetSecretValue",
-			},
-		}
-	case infrav1.SecretBackendSSMParameterStore:
-		return iamv1.StatementEntry{
-			Effect: iamv1.EffectAllow,
-			Resource: iamv1.Resources{
-				"arn:*:ssm:*:*:parameter/cluster.x-k8s.io/*",
-			},
-			Action: iamv1.Actions{
-				"ssm:DeleteParameter",
-				"ssm:GetParameter",
-			},
-		}
-	}
-	return iamv1.StatementEntry{}
+	return eksiam.SecretBackendPolicyStatement(secureSecretsBackend)
 }
 
-func (t Template) sessionManagerPolicy() iamv1.StatementEntry {
+// ssmParametersARN returns the ARN matching the SSM parameters storing bootstrap data.
+func (t Template) ssmParametersARN() string {
+	return fmt.Sprintf("arn:*:ssm:*:*:parameter/%s*", strings.TrimPrefix(t.Spec.SSMParameters.NamePrefix, "/"))
+}
+
+func (t Template) ssmParametersPolicy() iamv1.StatementEntry {
 	return iamv1.StatementEntry{
-		Effect:   iamv1.EffectAllow,
-		Resource: iamv1.Resources{iamv1.Any},
+		Effect: iamv1.EffectAllow,
+		Resource: iamv1.Resources{
+			t.ssmParametersARN(),
+		},
 		Action: iamv1.Actions{
-			"ssm:UpdateInstanceInformation",
-			"ssmmessages:CreateControlChannel",
-			"ssmmessages:CreateDataChannel",
-			"ssmmessages:OpenControlChannel",
-			"ssmmessages:OpenDataChannel",
-			"s3:GetEncryptionConfiguration",
+			"ssm:DeleteParameter",
+			"ssm:GetParameter",
 		},
 	}
 }
 
+func (t Template) sessionManagerPolicy() iamv1.StatementEntry {
+	return eksiam.SessionManagerPolicyStatement()
+}
+
 func (t Template) nodeManagedPolicies() []string {
 	policies := t.Spec.Nodes.ExtraPolicyAttachments
 
@@ -89,6 +76,9 @@ func (t Template) nodePolicy() *iamv1.PolicyDocument {
 			t.secretPolicy(secureSecretsBackend),
 		)
 	}
+	if t.Spec.SSMParameters.Enable {
+		policyDocument.Statement = append(policyDocument.Statement, t.ssmParametersPolicy())
+	}
 	policyDocument.Statement = append(
 		policyDocument.Statement,
 		t.sessionManagerPolicy(),
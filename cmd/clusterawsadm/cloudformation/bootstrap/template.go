@@ -20,9 +20,13 @@ package bootstrap
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/awslabs/goformation/v4/cloudformation"
 	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
+	"github.com/pkg/errors"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
@@ -53,6 +57,10 @@ const (
 	EKSConsolePolicy                  PolicyName = "AWSIAMManagedPolicyEKSConsole"
 )
 
+// iamPathPattern is the pattern of the AWS IAM paths, see
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateRole.html.
+var iamPathPattern = regexp.MustCompile(`^/([\x21-\x7E]{0,510}/)?$`)
+
 // Template is an AWS CloudFormation template to bootstrap
 // IAM policies, users and roles for use by Cluster API Provider AWS.
 type Template struct {
@@ -73,26 +81,52 @@ func (t Template) NewManagedName(name string) string {
 	return fmt.Sprintf("%s%s%s", t.Spec.NamePrefix, name, *t.Spec.NameSuffix)
 }
 
+// Validate checks the IAM path and permissions boundary of the Template.
+func (t Template) Validate() error {
+	if t.Spec.Path != "" && !iamPathPattern.MatchString(t.Spec.Path) {
+		return errors.Errorf("invalid IAM path %q: must begin and end with \"/\" and contain only printable ASCII characters", t.Spec.Path)
+	}
+	if t.Spec.PermissionsBoundary != "" {
+		boundary, err := arn.Parse(t.Spec.PermissionsBoundary)
+		if err != nil || boundary.Service != "iam" || !strings.HasPrefix(boundary.Resource, "policy/") {
+			return errors.Errorf("invalid permissions boundary %q: must be the ARN of an IAM managed policy", t.Spec.PermissionsBoundary)
+		}
+	}
+	return nil
+}
+
+// iamPath returns the IAM path of the resources, defaulting to "/".
+func (t Template) iamPath() string {
+	if t.Spec.Path == "" {
+		return "/"
+	}
+	return t.Spec.Path
+}
+
 // RenderCloudFormation will render and return a cloudformation Template.
 func (t Template) RenderCloudFormation() *cloudformation.Template {
 	template := cloudformation.NewTemplate()
 
 	if t.Spec.BootstrapUser.Enable {
 		template.Resources[AWSIAMUserBootstrapper] = &cfn_iam.User{
-			UserName:          t.Spec.BootstrapUser.UserName,
-			Groups:            t.bootstrapUserGroups(),
-			ManagedPolicyArns: t.Spec.ControlPlane.ExtraPolicyAttachments,
-			Policies:          t.bootstrapUserPolicy(),
-			Tags:              converters.MapToCloudFormationTags(t.Spec.BootstrapUser.Tags),
+			UserName:            t.Spec.BootstrapUser.UserName,
+			Path:                t.Spec.Path,
+			PermissionsBoundary: t.Spec.PermissionsBoundary,
+			Groups:              t.bootstrapUserGroups(),
+			ManagedPolicyArns:   t.Spec.ControlPlane.ExtraPolicyAttachments,
+			Policies:            t.bootstrapUserPolicy(),
+			Tags:                converters.MapToCloudFormationTags(t.Spec.BootstrapUser.Tags),
 		}
 
 		template.Resources[AWSIAMGroupBootstrapper] = &cfn_iam.Group{
 			GroupName: t.Spec.BootstrapUser.GroupName,
+			Path:      t.Spec.Path,
 		}
 	}
 
 	template.Resources[string(ControllersPolicy)] = &cfn_iam.ManagedPolicy{
 		ManagedPolicyName: t.NewManagedName("controllers"),
+		Path:              t.Spec.Path,
 		Description:       `For the Kubernetes Cluster API Provider AWS Controllers`,
 		PolicyDocument:    t.ControllersPolicy(),
 		Groups:            t.controllersPolicyGroups(),
@@ -102,6 +136,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.Disable {
 		template.Resources[string(ControllersPolicyEKS)] = &cfn_iam.ManagedPolicy{
 			ManagedPolicyName: t.NewManagedName("controllers-eks"),
+			Path:              t.Spec.Path,
 			Description:       `For the Kubernetes Cluster API Provider AWS Controllers`,
 			PolicyDocument:    t.ControllersPolicyEKS(),
 			Groups:            t.controllersPolicyGroups(),
@@ -112,6 +147,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.ControlPlane.DisableCloudProviderPolicy {
 		template.Resources[string(ControlPlanePolicy)] = &cfn_iam.ManagedPolicy{
 			ManagedPolicyName: t.NewManagedName("control-plane"),
+			Path:              t.Spec.Path,
 			Description:       `For the Kubernetes Cloud Provider AWS Control Plane`,
 			PolicyDocument:    t.cloudProviderControlPlaneAwsPolicy(),
 			Roles:             t.cloudProviderControlPlaneAwsRoles(),
@@ -121,6 +157,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.Nodes.DisableCloudProviderPolicy {
 		template.Resources[string(NodePolicy)] = &cfn_iam.ManagedPolicy{
 			ManagedPolicyName: t.NewManagedName("nodes"),
+			Path:              t.Spec.Path,
 			Description:       `For the Kubernetes Cloud Provider AWS nodes`,
 			PolicyDocument:    t.nodePolicy(),
 			Roles:             t.cloudProviderNodeAwsRoles(),
@@ -130,6 +167,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if t.Spec.ControlPlane.EnableCSIPolicy {
 		template.Resources[string(CSIPolicy)] = &cfn_iam.ManagedPolicy{
 			ManagedPolicyName: t.NewManagedName("csi"),
+			Path:              t.Spec.Path,
 			Description:       `For the AWS EBS CSI Driver for Kubernetes`,
 			PolicyDocument:    t.csiControllerPolicy(),
 			Roles:             t.csiControlPlaneAwsRoles(),
@@ -138,6 +176,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMRoleControlPlane] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("control-plane"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.controlPlaneTrustPolicy(),
 		ManagedPolicyArns:        t.Spec.ControlPlane.ExtraPolicyAttachments,
 		Policies:                 t.controlPlanePolicies(),
@@ -146,6 +186,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMRoleControllers] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("controllers"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.controllersTrustPolicy(),
 		Policies:                 t.controllersRolePolicy(),
 		Tags:                     converters.MapToCloudFormationTags(t.Spec.ClusterAPIControllers.Tags),
@@ -153,6 +195,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMRoleNodes] = &cfn_iam.Role{
 		RoleName:                 t.NewManagedName("nodes"),
+		Path:                     t.Spec.Path,
+		PermissionsBoundary:      t.Spec.PermissionsBoundary,
 		AssumeRolePolicyDocument: t.nodeTrustPolicy(),
 		ManagedPolicyArns:        t.nodeManagedPolicies(),
 		Policies:                 t.nodePolicies(),
@@ -161,6 +205,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileControlPlane] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("control-plane"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleControlPlane),
 		},
@@ -168,6 +213,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileControllers] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("controllers"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleControllers),
 		},
@@ -175,6 +221,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 
 	template.Resources[AWSIAMInstanceProfileNodes] = &cfn_iam.InstanceProfile{
 		InstanceProfileName: t.NewManagedName("nodes"),
+		Path:                t.Spec.Path,
 		Roles: []string{
 			cloudformation.Ref(AWSIAMRoleNodes),
 		},
@@ -183,6 +230,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.DefaultControlPlaneRole.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSControlPlane] = &cfn_iam.Role{
 			RoleName:                 ekscontrolplanev1.DefaultEKSControlPlaneRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{"eks.amazonaws.com"}),
 			ManagedPolicyArns:        t.eksControlPlanePolicies(),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.DefaultControlPlaneRole.Tags),
@@ -192,6 +241,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.ManagedMachinePool.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSNodegroup] = &cfn_iam.Role{
 			RoleName:                 expinfrav1.DefaultEKSNodegroupRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{"ec2.amazonaws.com", "eks.amazonaws.com"}),
 			ManagedPolicyArns:        t.eksMachinePoolPolicies(),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.ManagedMachinePool.Tags),
@@ -201,6 +252,8 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if !t.Spec.EKS.Fargate.Disable && !t.Spec.EKS.Disable {
 		template.Resources[AWSIAMRoleEKSFargate] = &cfn_iam.Role{
 			RoleName:                 expinfrav1.DefaultEKSFargateRole,
+			Path:                     t.Spec.Path,
+			PermissionsBoundary:      t.Spec.PermissionsBoundary,
 			AssumeRolePolicyDocument: AssumeRolePolicy(iamv1.PrincipalService, []string{eksiam.EKSFargateService}),
 			ManagedPolicyArns:        t.fargateProfilePolicies(t.Spec.EKS.Fargate),
 			Tags:                     converters.MapToCloudFormationTags(t.Spec.EKS.Fargate.Tags),
@@ -210,6 +263,7 @@ func (t Template) RenderCloudFormation() *cloudformation.Template {
 	if t.Spec.EKS.EnableUserEKSConsolePolicy && !t.Spec.EKS.Disable {
 		template.Resources[string(EKSConsolePolicy)] = &cfn_iam.ManagedPolicy{
 			ManagedPolicyName: t.NewManagedName("eks-console"),
+			Path:              t.Spec.Path,
 			Description:       `For users/groups to view EKS nodes and workloads`,
 			PolicyDocument:    t.eksConsolePolicies(),
 		}
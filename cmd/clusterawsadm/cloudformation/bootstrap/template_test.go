@@ -70,6 +70,22 @@ func TestRenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_ssm_parameters",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.SSMParameters.Enable = true
+				return t
+			},
+		},
+		{
+			fixture: "with_image_lookup_ssm_parameters",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.ImageLookupSSMParameters = []string{"/my-team/amis/*", "ubuntu-ami-id"}
+				return t
+			},
+		},
 		{
 			fixture: "customsuffix",
 			template: func() Template {
@@ -183,6 +199,57 @@ func TestRenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_instance_profile_management",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowInstanceProfileManagement = true
+				return t
+			},
+		},
+		{
+			fixture: "with_cost_estimation",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowCostEstimation = true
+				return t
+			},
+		},
+		{
+			fixture: "with_ebs_encryption_key_check",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowEBSEncryptionKeyCheck = true
+				return t
+			},
+		},
+		{
+			fixture: "with_ebs_encryption_by_default",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowEBSEncryptionByDefault = true
+				return t
+			},
+		},
+		{
+			fixture: "with_route53_health_checks",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.AllowRoute53HealthChecks = true
+				return t
+			},
+		},
+		{
+			fixture: "with_path_and_permissions_boundary",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.NamePrefix = "team-"
+				t.Spec.Path = "/capa/"
+				t.Spec.PermissionsBoundary = "arn:aws:iam::123456789012:policy/boundary"
+				t.Spec.BootstrapUser.Enable = true
+				return t
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -211,3 +278,57 @@ func TestRenderCloudformation(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateValidate(t *testing.T) {
+	cases := []struct {
+		name                string
+		path                string
+		permissionsBoundary string
+		wantErr             bool
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:                "path and permissions boundary",
+			path:                "/capa/team/",
+			permissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+		},
+		{
+			name:    "path without trailing slash",
+			path:    "/capa",
+			wantErr: true,
+		},
+		{
+			name:    "path with spaces",
+			path:    "/capa team/",
+			wantErr: true,
+		},
+		{
+			name:                "permissions boundary isn't an ARN",
+			permissionsBoundary: "boundary",
+			wantErr:             true,
+		},
+		{
+			name:                "permissions boundary isn't a policy",
+			permissionsBoundary: "arn:aws:iam::123456789012:role/boundary",
+			wantErr:             true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := NewTemplate()
+			tmpl.Spec.Path = c.path
+			tmpl.Spec.PermissionsBoundary = c.permissionsBoundary
+
+			err := tmpl.Validate()
+			if c.wantErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
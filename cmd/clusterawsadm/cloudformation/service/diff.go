@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/iam"
+	go_cfn "github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const managedPolicyType = "AWS::IAM::ManagedPolicy"
+
+// StackDiff is the difference between a deployed bootstrap stack and a template.
+type StackDiff struct {
+	// MissingResources are the resources of the template that aren't in the stack.
+	MissingResources []string
+	// ExtraResources are the resources of the stack that aren't in the template anymore.
+	ExtraResources []string
+	// ChangedResources are the resources whose properties differ between the stack and the template.
+	ChangedResources []string
+	// Policies are the differences of the actions allowed by the policies of the resources.
+	Policies []PolicyDiff
+}
+
+// PolicyDiff is the difference between the actions allowed by the deployed policies of a resource
+// and the ones of the template.
+type PolicyDiff struct {
+	// Resource is the logical ID of the resource in the template.
+	Resource string
+	// Live is true if the deployed policy was read from AWS IAM, and may thus include changes
+	// made outside of AWS CloudFormation.
+	Live bool
+	// MissingActions are the actions of the template that the deployed policies don't allow.
+	MissingActions []string
+	// ExtraActions are the actions allowed by the deployed policies that aren't in the template anymore.
+	ExtraActions []string
+}
+
+// IsEmpty returns true if the stack matches the template.
+func (d *StackDiff) IsEmpty() bool {
+	return len(d.MissingResources) == 0 && len(d.ExtraResources) == 0 && len(d.ChangedResources) == 0 && len(d.Policies) == 0
+}
+
+// DiffBootstrapStack compares the deployed bootstrap CloudFormation stack with the given template.
+// If the IAM client of the service is set, the documents of the managed policies are read from
+// AWS IAM to also report changes made outside of AWS CloudFormation.
+func (s *Service) DiffBootstrapStack(stackName string, t go_cfn.Template) (*StackDiff, error) {
+	out, err := s.CFN.GetTemplate(&cfn.GetTemplateInput{
+		StackName:     aws.String(stackName),
+		TemplateStage: aws.String(cfn.TemplateStageOriginal),
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == "ValidationError" {
+			return nil, errors.Errorf("AWS CloudFormation stack %q doesn't exist", stackName)
+		}
+		return nil, errors.Wrap(err, "failed to get AWS CloudFormation stack template")
+	}
+
+	deployed, err := templateResources([]byte(aws.StringValue(out.TemplateBody)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse deployed AWS CloudFormation template")
+	}
+
+	desiredJSON, err := t.JSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate AWS CloudFormation JSON")
+	}
+	desired, err := templateResources(desiredJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	livePolicies := map[string][]string{}
+	if s.IAM != nil {
+		livePolicies, err = s.liveManagedPolicyActions(stackName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diffResources(deployed, desired, livePolicies), nil
+}
+
+// liveManagedPolicyActions returns the actions allowed by the default version of the managed
+// policies of the stack, by logical ID.
+func (s *Service) liveManagedPolicyActions(stackName string) (map[string][]string, error) {
+	out, err := s.CFN.DescribeStackResources(&cfn.DescribeStackResourcesInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to describe stack resources")
+	}
+
+	actions := map[string][]string{}
+	for _, r := range out.StackResources {
+		if aws.StringValue(r.ResourceType) != managedPolicyType || r.PhysicalResourceId == nil {
+			continue
+		}
+
+		policy, err := s.IAM.GetPolicy(&iam.GetPolicyInput{PolicyArn: r.PhysicalResourceId})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get policy %s", aws.StringValue(r.PhysicalResourceId))
+		}
+		version, err := s.IAM.GetPolicyVersion(&iam.GetPolicyVersionInput{
+			PolicyArn: r.PhysicalResourceId,
+			VersionId: policy.Policy.DefaultVersionId,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get default version of policy %s", aws.StringValue(r.PhysicalResourceId))
+		}
+
+		document, err := url.QueryUnescape(aws.StringValue(version.PolicyVersion.Document))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode document of policy %s", aws.StringValue(r.PhysicalResourceId))
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse document of policy %s", aws.StringValue(r.PhysicalResourceId))
+		}
+		actions[aws.StringValue(r.LogicalResourceId)] = allowedActions(parsed)
+	}
+
+	return actions, nil
+}
+
+// PrintStackDiff prints the difference between a deployed stack and a template.
+func PrintStackDiff(w io.Writer, d *StackDiff) {
+	if d.IsEmpty() {
+		fmt.Fprintln(w, "The AWS CloudFormation stack is up to date.")
+		return
+	}
+
+	printList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(w, "  %s\n", item)
+		}
+		fmt.Fprintln(w)
+	}
+
+	printList("Resources missing from the stack", d.MissingResources)
+	printList("Resources no longer in the template", d.ExtraResources)
+	printList("Resources with changed properties", d.ChangedResources)
+
+	for _, p := range d.Policies {
+		source := "template of the stack"
+		if p.Live {
+			source = "live policy"
+		}
+		fmt.Fprintf(w, "Policies of %s (compared with the %s):\n", p.Resource, source)
+		for _, action := range p.MissingActions {
+			fmt.Fprintf(w, "  + %s\n", action)
+		}
+		for _, action := range p.ExtraActions {
+			fmt.Fprintf(w, "  - %s\n", action)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// templateResources returns the resources of a YAML or JSON AWS CloudFormation template.
+func templateResources(body []byte) (map[string]map[string]interface{}, error) {
+	template := struct {
+		Resources map[string]map[string]interface{} `json:"Resources"`
+	}{}
+	if err := yaml.Unmarshal(body, &template); err != nil {
+		return nil, err
+	}
+	return template.Resources, nil
+}
+
+func diffResources(deployed, desired map[string]map[string]interface{}, livePolicies map[string][]string) *StackDiff {
+	d := &StackDiff{}
+
+	for name, resource := range desired {
+		current, ok := deployed[name]
+		if !ok {
+			d.MissingResources = append(d.MissingResources, fmt.Sprintf("%s (%s)", name, resource["Type"]))
+			continue
+		}
+		if !reflect.DeepEqual(current, resource) {
+			d.ChangedResources = append(d.ChangedResources, fmt.Sprintf("%s (%s)", name, resource["Type"]))
+		}
+
+		currentActions, live := livePolicies[name]
+		if !live {
+			currentActions = resourceActions(current)
+		}
+		desiredActions := resourceActions(resource)
+		policyDiff := PolicyDiff{
+			Resource:       name,
+			Live:           live,
+			MissingActions: actionsNotAllowed(desiredActions, currentActions),
+			ExtraActions:   actionsNotAllowed(currentActions, desiredActions),
+		}
+		if len(policyDiff.MissingActions) > 0 || len(policyDiff.ExtraActions) > 0 {
+			d.Policies = append(d.Policies, policyDiff)
+		}
+	}
+
+	for name, resource := range deployed {
+		if _, ok := desired[name]; !ok {
+			d.ExtraResources = append(d.ExtraResources, fmt.Sprintf("%s (%s)", name, resource["Type"]))
+		}
+	}
+
+	sort.Strings(d.MissingResources)
+	sort.Strings(d.ExtraResources)
+	sort.Strings(d.ChangedResources)
+	sort.Slice(d.Policies, func(i, j int) bool { return d.Policies[i].Resource < d.Policies[j].Resource })
+
+	return d
+}
+
+// resourceActions returns the actions allowed by the policy document of a managed policy, or
+// by the inline policies of a role or a user.
+func resourceActions(resource map[string]interface{}) []string {
+	properties, _ := resource["Properties"].(map[string]interface{})
+	if document, ok := properties["PolicyDocument"]; ok {
+		return allowedActions(document)
+	}
+
+	policies, _ := properties["Policies"].([]interface{})
+	actions := []string{}
+	for _, policy := range policies {
+		if p, ok := policy.(map[string]interface{}); ok {
+			actions = append(actions, allowedActions(p["PolicyDocument"])...)
+		}
+	}
+	return actions
+}
+
+// allowedActions returns the actions of the Allow statements of a policy document.
+func allowedActions(document interface{}) []string {
+	doc, _ := document.(map[string]interface{})
+	statements, ok := doc["Statement"].([]interface{})
+	if !ok {
+		statements = []interface{}{doc["Statement"]}
+	}
+
+	actions := []string{}
+	for _, statement := range statements {
+		s, _ := statement.(map[string]interface{})
+		if s["Effect"] != "Allow" {
+			continue
+		}
+		switch action := s["Action"].(type) {
+		case string:
+			actions = append(actions, action)
+		case []interface{}:
+			for _, a := range action {
+				if str, ok := a.(string); ok {
+					actions = append(actions, str)
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// actionsNotAllowed returns the actions that don't match any of the allowed action patterns.
+func actionsNotAllowed(actions, allowed []string) []string {
+	notAllowed := map[string]bool{}
+	for _, action := range actions {
+		found := false
+		for _, pattern := range allowed {
+			if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action)); matched {
+				found = true
+				break
+			}
+		}
+		if !found {
+			notAllowed[action] = true
+		}
+	}
+
+	result := make([]string, 0, len(notAllowed))
+	for action := range notAllowed {
+		result = append(result, action)
+	}
+	sort.Strings(result)
+	return result
+}
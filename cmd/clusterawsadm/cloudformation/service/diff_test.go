@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudformation
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cloudformation/bootstrap"
+)
+
+type fakeCFN struct {
+	cloudformationiface.CloudFormationAPI
+	template  string
+	resources []*cfn.StackResource
+}
+
+func (f *fakeCFN) GetTemplate(*cfn.GetTemplateInput) (*cfn.GetTemplateOutput, error) {
+	return &cfn.GetTemplateOutput{TemplateBody: aws.String(f.template)}, nil
+}
+
+func (f *fakeCFN) DescribeStackResources(*cfn.DescribeStackResourcesInput) (*cfn.DescribeStackResourcesOutput, error) {
+	return &cfn.DescribeStackResourcesOutput{StackResources: f.resources}, nil
+}
+
+type fakeIAM struct {
+	iamiface.IAMAPI
+	documents map[string]string
+}
+
+func (f *fakeIAM) GetPolicy(input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return &iam.GetPolicyOutput{Policy: &iam.Policy{Arn: input.PolicyArn, DefaultVersionId: aws.String("v2")}}, nil
+}
+
+func (f *fakeIAM) GetPolicyVersion(input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	return &iam.GetPolicyVersionOutput{PolicyVersion: &iam.PolicyVersion{
+		Document: aws.String(url.QueryEscape(f.documents[aws.StringValue(input.PolicyArn)])),
+	}}, nil
+}
+
+func TestDiffBootstrapStack(t *testing.T) {
+	deployedTemplate := func(configure func(t bootstrap.Template)) string {
+		tmpl := bootstrap.NewTemplate()
+		configure(tmpl)
+		data, err := tmpl.RenderCloudFormation().YAML()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("up to date", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := NewService(&fakeCFN{template: deployedTemplate(func(bootstrap.Template) {})})
+		diff, err := s.DiffBootstrapStack("stack", *bootstrap.NewTemplate().RenderCloudFormation())
+		g.Expect(err).To(BeNil())
+		g.Expect(diff.IsEmpty()).To(BeTrue())
+	})
+
+	t.Run("missing resources and permissions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		s := NewService(&fakeCFN{template: deployedTemplate(func(t bootstrap.Template) {
+			t.Spec.EKS.Disable = true
+		})})
+		desired := bootstrap.NewTemplate()
+		desired.Spec.S3Buckets.Enable = true
+
+		diff, err := s.DiffBootstrapStack("stack", *desired.RenderCloudFormation())
+		g.Expect(err).To(BeNil())
+		g.Expect(diff.MissingResources).To(ConsistOf(
+			"AWSIAMManagedPolicyControllersEKS (AWS::IAM::ManagedPolicy)",
+			"AWSIAMRoleEKSControlPlane (AWS::IAM::Role)",
+		))
+		g.Expect(diff.ExtraResources).To(BeEmpty())
+		g.Expect(diff.ChangedResources).To(ContainElement("AWSIAMManagedPolicyControllers (AWS::IAM::ManagedPolicy)"))
+		g.Expect(diff.Policies).To(ContainElement(And(
+			HaveField("Resource", string(bootstrap.ControllersPolicy)),
+			HaveField("MissingActions", ContainElements("s3:CreateBucket", "s3:PutBucketPolicy")),
+		)))
+	})
+
+	t.Run("live policy changed outside of the stack", func(t *testing.T) {
+		g := NewWithT(t)
+
+		policyARN := "arn:aws:iam::123456789012:policy/nodes.cluster-api-provider-aws.sigs.k8s.io"
+		s := NewService(&fakeCFN{
+			template: deployedTemplate(func(bootstrap.Template) {}),
+			resources: []*cfn.StackResource{{
+				LogicalResourceId:  aws.String(string(bootstrap.NodePolicy)),
+				PhysicalResourceId: aws.String(policyARN),
+				ResourceType:       aws.String(managedPolicyType),
+			}},
+		})
+		s.IAM = &fakeIAM{documents: map[string]string{
+			policyARN: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["ec2:Describe*","ecr:*"],"Resource":"*"}]}`,
+		}}
+
+		diff, err := s.DiffBootstrapStack("stack", *bootstrap.NewTemplate().RenderCloudFormation())
+		g.Expect(err).To(BeNil())
+		g.Expect(diff.MissingResources).To(BeEmpty())
+		g.Expect(diff.ChangedResources).To(BeEmpty())
+		g.Expect(diff.Policies).To(HaveLen(1))
+		g.Expect(diff.Policies[0].Resource).To(Equal(string(bootstrap.NodePolicy)))
+		g.Expect(diff.Policies[0].Live).To(BeTrue())
+		g.Expect(diff.Policies[0].MissingActions).NotTo(ContainElement("ec2:DescribeInstances"))
+		g.Expect(diff.Policies[0].MissingActions).To(ContainElement("ssm:UpdateInstanceInformation"))
+		g.Expect(diff.Policies[0].ExtraActions).To(ConsistOf("ec2:Describe*", "ecr:*"))
+	})
+}
+
+func TestActionsNotAllowed(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(actionsNotAllowed(
+		[]string{"ec2:DescribeInstances", "ec2:RunInstances", "iam:PassRole", "s3:GetObject"},
+		[]string{"EC2:Describe*", "ec2:RunInstances", "iam:*"},
+	)).To(ConsistOf("s3:GetObject"))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	go_cfn "github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
@@ -39,6 +40,8 @@ import (
 // One alternative is to have a large list of functions from the ec2 client.
 type Service struct {
 	CFN cloudformationiface.CloudFormationAPI
+	// IAM is optional, it's used to read the deployed AWS IAM policies.
+	IAM iamiface.IAMAPI
 }
 
 // NewService returns a new service given the CloudFormation api client.
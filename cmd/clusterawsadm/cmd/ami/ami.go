@@ -35,6 +35,7 @@ func RootCmd() *cobra.Command {
 			All AMI related actions such as:
 			# Copy AMIs based on Kubernetes version, OS etc from an AWS account where AMIs are stored
             to the current AWS account (use case: air-gapped deployments)
+			# Build AMIs with image-builder in the current AWS account
 			# (to be implemented) List available AMIs
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,6 +45,7 @@ func RootCmd() *cobra.Command {
 
 	newCmd.AddCommand(cm.CopyAMICmd())
 	newCmd.AddCommand(cm.EncryptedCopyAMICmd())
+	newCmd.AddCommand(cm.BuildAMICmd())
 	newCmd.AddCommand(ls.ListAMICmd())
 
 	return newCmd
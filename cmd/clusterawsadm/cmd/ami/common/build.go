@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/ami"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+var (
+	imageBuilderDir string
+	buildRegions    []string
+	packerVarFiles  []string
+)
+
+// BuildAMICmd will build an AMI with image-builder in the AWS account which credentials are provided.
+func BuildAMICmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build an AMI with image-builder in the AWS account which credentials are provided",
+		Long: cmd.LongDesc(`
+			Build an AMI for a Kubernetes version and OS using the image-builder project, copy it to a set
+			of regions and tag the copies. The AMIs are named so that they are found by the default AMI lookup
+			when the owner ID of the AWSMachine is set to the current AWS account.
+
+			Requires a checkout of https://github.com/kubernetes-sigs/image-builder and its dependencies
+			(make, packer and ansible, see "make deps-ami").
+		`),
+		Example: cmd.Examples(`
+		# Build an Ubuntu 22.04 AMI for Kubernetes v1.28.3 in us-west-2.
+		clusterawsadm ami build --image-builder-dir ~/image-builder/images/capi --os ubuntu-22.04 --kubernetes-version v1.28.3 --region us-west-2
+
+		# Build in several regions with additional packer variables, and write the AMIs to a file.
+		clusterawsadm ami build --image-builder-dir ~/image-builder/images/capi --os ubuntu-22.04 --kubernetes-version v1.28.3 \
+		  --regions us-west-2,eu-west-1 --var-file vars.json --output-file amis.yaml
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := cmdout.New("yaml", os.Stdout)
+			if err != nil {
+				return fmt.Errorf("failed creating output printer: %w", err)
+			}
+
+			regions := buildRegions
+			if len(regions) == 0 {
+				region, err := flags.GetRegionWithError(cmd)
+				if err != nil {
+					return err
+				}
+				regions = []string{region}
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				fmt.Printf("Failed to parse dry-run value: %v. Defaulting to --dry-run=false\n", err)
+			}
+
+			amis, err := ami.Build(cmd.Context(), ami.BuildInput{
+				ImageBuilderDir:   imageBuilderDir,
+				OperatingSystem:   opSystem,
+				KubernetesVersion: kubernetesVersion,
+				Regions:           regions,
+				VarFiles:          packerVarFiles,
+				DryRun:            dryRun,
+				Stdout:            os.Stderr,
+				Log:               logf.Log,
+			})
+			if amis != nil && outputFile != "" && len(amis.Items) > 0 {
+				if writeErr := writeAMIList(outputFile, amis); writeErr != nil {
+					return writeErr
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				return nil
+			}
+			return printer.Print(amis)
+		},
+	}
+
+	flags.AddRegionFlag(newCmd)
+	addOsFlag(newCmd)
+	addKubernetesVersionFlag(newCmd)
+	addDryRunFlag(newCmd)
+	newCmd.Flags().StringVar(&imageBuilderDir, "image-builder-dir", "", "The images/capi directory of an image-builder checkout")
+	if err := newCmd.MarkFlagRequired("image-builder-dir"); err != nil {
+		panic(errors.Wrap(err, "error marking required --image-builder-dir flag"))
+	}
+	newCmd.Flags().StringSliceVar(&buildRegions, "regions", nil, "The AWS regions to build the AMI in, instead of --region")
+	newCmd.Flags().StringSliceVar(&packerVarFiles, "var-file", nil, "Additional packer variable files passed to image-builder")
+	newCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the built AMIs to this file, as a YAML AWSAMIList")
+	return newCmd
+}
@@ -19,16 +19,29 @@ package common
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/ami"
+	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
 	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 )
 
+var (
+	toRegions  []string
+	encrypted  bool
+	copyKMSKey string
+	outputFile string
+
+	// newEC2Client returns the EC2 clients used to copy the AMIs, the clients of the AWS configuration if nil.
+	newEC2Client func(region string) (ec2iface.EC2API, error)
+)
+
 // CopyAMICmd will copy AMIs from an AWS account to the AWS account which credentials are provided.
 func CopyAMICmd() *cobra.Command {
 	newCmd := &cobra.Command{
@@ -48,6 +61,11 @@ func CopyAMICmd() *cobra.Command {
 
 		# copy from us-east-1 to us-east-2
 		clusterawsadm ami copy --os centos-7 --kubernetes-version=v1.19.4 --region us-east-2 --source-region us-east-1
+
+		# copy to several regions, encrypting the copies with a KMS key available in each of them under the same alias,
+		# and write the IDs of the copies to a file
+		clusterawsadm ami copy --os ubuntu-20.04 --kubernetes-version=v1.28.3 --source-region us-east-1 \
+		  --to-regions us-east-2,eu-west-1 --kms-key alias/capa-amis --output-file amis.yaml
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -55,9 +73,14 @@ func CopyAMICmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed creating output printer: %w", err)
 			}
-			region, err := flags.GetRegionWithError(cmd)
-			if err != nil {
-				return err
+
+			regions := toRegions
+			if len(regions) == 0 {
+				region, err := flags.GetRegionWithError(cmd)
+				if err != nil {
+					return err
+				}
+				regions = []string{region}
 			}
 			sourceRegion, err := GetSourceRegion(cmd)
 			if err != nil {
@@ -71,23 +94,34 @@ func CopyAMICmd() *cobra.Command {
 
 			log := logf.Log
 
-			ami, err := ami.Copy(ami.CopyInput{
-				DestinationRegion: region,
+			amis, err := ami.CopyToRegions(cmd.Context(), ami.CopyInput{
 				DryRun:            dryRun,
+				Encrypted:         encrypted || copyKMSKey != "",
+				KmsKeyID:          copyKMSKey,
 				KubernetesVersion: kubernetesVersion,
 				Log:               log,
 				OperatingSystem:   opSystem,
 				OwnerID:           ownerID,
 				SourceRegion:      sourceRegion,
-			},
-			)
+				NewEC2Client:      newEC2Client,
+			}, regions)
+
+			if outputFile != "" && len(amis.Items) > 0 {
+				if writeErr := writeAMIList(outputFile, amis); writeErr != nil {
+					return writeErr
+				}
+			}
 
 			if err != nil {
 				fmt.Print(err)
 				return err
 			}
 
-			printer.Print(ami)
+			if len(toRegions) == 0 {
+				printer.Print(&amis.Items[0])
+			} else {
+				printer.Print(amis)
+			}
 
 			return nil
 		},
@@ -99,5 +133,24 @@ func CopyAMICmd() *cobra.Command {
 	addDryRunFlag(newCmd)
 	addOwnerIDFlag(newCmd)
 	addSourceRegion(newCmd)
+	newCmd.Flags().StringSliceVar(&toRegions, "to-regions", nil, "The AWS regions to copy the AMI to, instead of --region")
+	newCmd.Flags().BoolVar(&encrypted, "encrypted", false, "Encrypt the EBS snapshots of the copies with the default KMS key")
+	newCmd.Flags().StringVar(&copyKMSKey, "kms-key", "", "The ID, ARN or alias of the KMS key used to encrypt the EBS snapshots of the copies. The key must exist in each destination region")
+	newCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the copied AMIs to this file, as a YAML AWSAMIList")
 	return newCmd
 }
+
+// writeAMIList writes the AMIs to a file as YAML, so that the IDs of the copies can be consumed by other tools.
+func writeAMIList(path string, amis *amiv1.AWSAMIList) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("failed creating output file: %w", err)
+	}
+	defer f.Close()
+
+	printer, err := cmdout.New("yaml", f)
+	if err != nil {
+		return fmt.Errorf("failed creating output printer: %w", err)
+	}
+	return printer.Print(amis)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	amiv1 "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/ami/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestCopyAMICmd(t *testing.T) {
+	sourceImage := &ec2.Image{
+		ImageId:      aws.String("ami-source"),
+		Name:         aws.String("capa-ami-ubuntu-20.04-v1.28.3-1700000000"),
+		CreationDate: aws.String("2023-11-14T22:13:20.000Z"),
+	}
+	// copied expects the copy of the source image to a region, returning the ID of the copy or the error.
+	copied := func(imageID string, err error) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeImagesWithContext(gomock.Any(), gomock.Any()).Return(&ec2.DescribeImagesOutput{}, nil)
+			if err != nil {
+				m.CopyImage(gomock.Any()).Return(nil, err)
+				return
+			}
+			m.CopyImage(gomock.Any()).Return(&ec2.CopyImageOutput{ImageId: aws.String(imageID)}, nil)
+		}
+	}
+	// alreadyCopied expects the lookup of the copy of the source image in a region, returning the existing copy.
+	alreadyCopied := func(imageID string) func(m *mocks.MockEC2APIMockRecorder) {
+		return func(m *mocks.MockEC2APIMockRecorder) {
+			m.DescribeImagesWithContext(gomock.Any(), gomock.Any()).Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{{ImageId: aws.String(imageID), Name: sourceImage.Name, State: aws.String(ec2.ImageStateAvailable)}},
+			}, nil)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expect         map[string]func(m *mocks.MockEC2APIMockRecorder)
+		wantErr        bool
+		wantOutputFile bool
+		wantIDs        []string
+	}{
+		{
+			name:   "copies the AMI to the region",
+			args:   []string{"--region", "us-east-2"},
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){"us-east-2": copied("ami-us-east-2", nil)},
+		},
+		{
+			name: "writes the copies of all the regions to the output file",
+			args: []string{"--to-regions", "us-east-2,eu-west-1", "--output-file", "amis.yaml"},
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": copied("ami-us-east-2", nil),
+				"eu-west-1": alreadyCopied("ami-eu-west-1"),
+			},
+			wantOutputFile: true,
+			wantIDs:        []string{"ami-us-east-2", "ami-eu-west-1"},
+		},
+		{
+			name: "writes the copies of the other regions to the output file when a region fails",
+			args: []string{"--to-regions", "us-east-2,eu-west-1", "--output-file", "amis.yaml"},
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": copied("", errors.New("UnauthorizedOperation")),
+				"eu-west-1": copied("ami-eu-west-1", nil),
+			},
+			wantErr:        true,
+			wantOutputFile: true,
+			wantIDs:        []string{"ami-eu-west-1"},
+		},
+		{
+			name: "doesn't write the output file when all the regions fail",
+			args: []string{"--to-regions", "us-east-2,eu-west-1", "--output-file", "amis.yaml"},
+			expect: map[string]func(m *mocks.MockEC2APIMockRecorder){
+				"us-east-2": copied("", errors.New("UnauthorizedOperation")),
+				"eu-west-1": copied("", errors.New("UnauthorizedOperation")),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			clients := map[string]ec2iface.EC2API{}
+			source := mocks.NewMockEC2API(mockCtrl)
+			source.EXPECT().DescribeImagesWithContext(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{sourceImage}}, nil).Times(len(tt.expect))
+			clients["us-east-1"] = source
+			for region, expect := range tt.expect {
+				m := mocks.NewMockEC2API(mockCtrl)
+				expect(m.EXPECT())
+				clients[region] = m
+			}
+			newEC2Client = func(region string) (ec2iface.EC2API, error) {
+				return clients[region], nil
+			}
+			defer func() { newEC2Client = nil }()
+
+			dir := t.TempDir()
+			args := []string{"--os", "ubuntu-20.04", "--kubernetes-version", "v1.28.3", "--source-region", "us-east-1"}
+			for _, arg := range tt.args {
+				if arg == "amis.yaml" {
+					arg = filepath.Join(dir, arg)
+				}
+				args = append(args, arg)
+			}
+			cmd := CopyAMICmd()
+			cmd.SetArgs(args)
+			cmd.SilenceUsage = true
+
+			err := cmd.ExecuteContext(context.TODO())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, "amis.yaml"))
+			if !tt.wantOutputFile {
+				g.Expect(os.IsNotExist(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			amis := &amiv1.AWSAMIList{}
+			g.Expect(yaml.Unmarshal(data, amis)).To(Succeed())
+			var ids []string
+			for _, ami := range amis.Items {
+				ids = append(ids, ami.Spec.ImageID)
+			}
+			g.Expect(ids).To(Equal(tt.wantIDs))
+		})
+	}
+}
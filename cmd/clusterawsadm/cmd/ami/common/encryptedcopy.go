@@ -88,7 +88,7 @@ func EncryptedCopyAMICmd() *cobra.Command {
 
 			log := ctrl.Log
 
-			ami, err := ami.Copy(ami.CopyInput{
+			ami, err := ami.Copy(cmd.Context(), ami.CopyInput{
 				DestinationRegion: region,
 				DryRun:            dryRun,
 				Encrypted:         true,
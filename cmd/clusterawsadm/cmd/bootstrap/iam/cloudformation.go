@@ -18,10 +18,12 @@ package iam
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cloudformation/bootstrap"
@@ -46,6 +48,9 @@ func printCloudFormationTemplateCmd() *cobra.Command {
 
 		# Print out a CloudFormation template using a custom configuration.
 		clusterawsadm bootstrap iam print-cloudformation-template --config bootstrap_config.yaml
+
+		# Print out a CloudFormation template setting a path and a permissions boundary on the AWS IAM resources.
+		clusterawsadm bootstrap iam print-cloudformation-template --path /capa/ --permissions-boundary arn:aws:iam::123456789012:policy/boundary
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,6 +58,9 @@ func printCloudFormationTemplateCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := applyIAMResourceFlags(t, cmd); err != nil {
+				return err
+			}
 
 			cfnTemplate := t.RenderCloudFormation()
 			yml, err := cfnTemplate.YAML()
@@ -65,6 +73,7 @@ func printCloudFormationTemplateCmd() *cobra.Command {
 		},
 	}
 	addConfigFlag(newCmd)
+	addIAMResourceFlags(newCmd)
 
 	return newCmd
 }
@@ -86,6 +95,65 @@ func createCloudFormationStackCmd() *cobra.Command {
 
 		# Create or update IAM roles and policies for Kubernetes using a AWS CloudFormation stack with a custom configuration.
 		clusterawsadm bootstrap iam create-cloudformation-stack --config bootstrap_config.yaml
+
+		# Create or update IAM roles and policies with a name prefix, a path and a permissions boundary to
+		# satisfy the IAM guardrails of the account.
+		clusterawsadm bootstrap iam create-cloudformation-stack --name-prefix team- --path /capa/ --permissions-boundary arn:aws:iam::123456789012:policy/boundary
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := getBootstrapTemplate(cmd)
+			if err != nil {
+				return err
+			}
+			if err := applyIAMResourceFlags(t, cmd); err != nil {
+				return err
+			}
+
+			if err := resolveTemplateRegion(t, cmd); err != nil {
+				fmt.Println("AWS_REGION env not set and --region flag not provided, default configuration will be used")
+			}
+
+			return reconcileCloudFormationStack(t)
+		},
+	}
+	addConfigFlag(newCmd)
+	addIAMResourceFlags(newCmd)
+	flags.AddRegionFlag(newCmd)
+	return newCmd
+}
+
+// reconcileCloudFormationStack creates or updates the AWS CloudFormation stack of the template.
+func reconcileCloudFormationStack(t *bootstrap.Template) error {
+	fmt.Printf("Attempting to create AWS CloudFormation stack %s\n", t.Spec.StackName)
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            aws.Config{Region: aws.String(t.Spec.Region)},
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return err
+	}
+
+	cfnSvc := cloudformation.NewService(cfn.New(sess))
+
+	err = cfnSvc.ReconcileBootstrapStack(t.Spec.StackName, *t.RenderCloudFormation(), t.Spec.StackTags)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return err
+	}
+
+	return cfnSvc.ShowStackResources(t.Spec.StackName)
+}
+
+func deleteCloudFormationStackCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "delete-cloudformation-stack",
+		Short: "Delete an AWS CloudFormation stack",
+		Args:  cobra.NoArgs,
+		Long: cmd.LongDesc(`
+			Delete the AWS CloudFormation stack that created AWS Identity and Access
+			Management (IAM) resources for use with Kubernetes Cluster API Provider
+			AWS.
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			t, err := getBootstrapTemplate(cmd)
@@ -97,7 +165,7 @@ func createCloudFormationStackCmd() *cobra.Command {
 				fmt.Println("AWS_REGION env not set and --region flag not provided, default configuration will be used")
 			}
 
-			fmt.Printf("Attempting to create AWS CloudFormation stack %s\n", t.Spec.StackName)
+			fmt.Printf("Attempting to delete AWS CloudFormation stack %s\n", t.Spec.StackName)
 			sess, err := session.NewSessionWithOptions(session.Options{
 				SharedConfigState: session.SharedConfigEnable,
 				Config:            aws.Config{Region: aws.String(t.Spec.Region)},
@@ -109,13 +177,13 @@ func createCloudFormationStackCmd() *cobra.Command {
 
 			cfnSvc := cloudformation.NewService(cfn.New(sess))
 
-			err = cfnSvc.ReconcileBootstrapStack(t.Spec.StackName, *t.RenderCloudFormation(), t.Spec.StackTags)
+			err = cfnSvc.DeleteStack(t.Spec.StackName, nil)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				return err
 			}
 
-			return cfnSvc.ShowStackResources(t.Spec.StackName)
+			return nil
 		},
 	}
 	addConfigFlag(newCmd)
@@ -123,27 +191,40 @@ func createCloudFormationStackCmd() *cobra.Command {
 	return newCmd
 }
 
-func deleteCloudFormationStackCmd() *cobra.Command {
+func diffCloudFormationStackCmd() *cobra.Command {
 	newCmd := &cobra.Command{
-		Use:   "delete-cloudformation-stack",
-		Short: "Delete an AWS CloudFormation stack",
+		Use:   "diff",
+		Short: "Compare the AWS CloudFormation stack with the current template",
 		Args:  cobra.NoArgs,
 		Long: cmd.LongDesc(`
-			Delete the AWS CloudFormation stack that created AWS Identity and Access
-			Management (IAM) resources for use with Kubernetes Cluster API Provider
-			AWS.
+	Compare the deployed AWS CloudFormation stack used for bootstrapping Kubernetes Cluster
+	API and Kubernetes AWS Identity and Access Management (IAM) permissions with the template
+	of this version of clusterawsadm. Resources and permissions missing from the stack are
+	reported, so that the stack can be updated before upgrading the controllers. The
+	documents of the deployed managed policies are read from AWS IAM, to also report changes
+	made outside of AWS CloudFormation. To use this command, there must be AWS credentials
+	loaded in this environment.
+		` + credentials.CredentialHelp),
+		Example: cmd.Examples(`
+		# Compare the AWS CloudFormation stack with the default template.
+		clusterawsadm bootstrap iam diff
+
+		# Compare the AWS CloudFormation stack with the template of a custom configuration.
+		clusterawsadm bootstrap iam diff --config bootstrap_config.yaml
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			t, err := getBootstrapTemplate(cmd)
 			if err != nil {
 				return err
 			}
+			if err := applyIAMResourceFlags(t, cmd); err != nil {
+				return err
+			}
 
 			if err := resolveTemplateRegion(t, cmd); err != nil {
 				fmt.Println("AWS_REGION env not set and --region flag not provided, default configuration will be used")
 			}
 
-			fmt.Printf("Attempting to delete AWS CloudFormation stack %s\n", t.Spec.StackName)
 			sess, err := session.NewSessionWithOptions(session.Options{
 				SharedConfigState: session.SharedConfigEnable,
 				Config:            aws.Config{Region: aws.String(t.Spec.Region)},
@@ -154,17 +235,20 @@ func deleteCloudFormationStackCmd() *cobra.Command {
 			}
 
 			cfnSvc := cloudformation.NewService(cfn.New(sess))
+			cfnSvc.IAM = iam.New(sess)
 
-			err = cfnSvc.DeleteStack(t.Spec.StackName, nil)
+			diff, err := cfnSvc.DiffBootstrapStack(t.Spec.StackName, *t.RenderCloudFormation())
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				return err
 			}
 
+			cloudformation.PrintStackDiff(os.Stdout, diff)
 			return nil
 		},
 	}
 	addConfigFlag(newCmd)
+	addIAMResourceFlags(newCmd)
 	flags.AddRegionFlag(newCmd)
 	return newCmd
 }
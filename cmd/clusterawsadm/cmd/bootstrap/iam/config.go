@@ -97,3 +97,24 @@ func addConfigFlag(c *cobra.Command) {
 		To see the default configuration, run 'clusterawsadm bootstrap iam print-config'.
 	`))
 }
+
+func addIAMResourceFlags(c *cobra.Command) {
+	c.Flags().String("name-prefix", "", "Prefix of the names of the AWS IAM roles, instance profiles and policies. Overrides the namePrefix of the configuration.")
+	c.Flags().String("path", "", "AWS IAM path of the created resources. Overrides the path of the configuration.")
+	c.Flags().String("permissions-boundary", "", "ARN of the permissions boundary of the created AWS IAM roles and users. Overrides the permissionsBoundary of the configuration.")
+}
+
+// applyIAMResourceFlags overrides the configuration of the template with the flags explicitly set
+// on the command line, and validates the result.
+func applyIAMResourceFlags(t *bootstrap.Template, cmd *cobra.Command) error {
+	if flag := cmd.Flags().Lookup("name-prefix"); flag != nil && flag.Changed {
+		t.Spec.NamePrefix = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup("path"); flag != nil && flag.Changed {
+		t.Spec.Path = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup("permissions-boundary"); flag != nil && flag.Changed {
+		t.Spec.PermissionsBoundary = flag.Value.String()
+	}
+	return t.Validate()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package iam
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/bootstrap/credentials"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/iam/native"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+func createCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:     "create",
+		Aliases: []string{"update"},
+		Short:   "Create or update the AWS IAM roles and policies",
+		Args:    cobra.NoArgs,
+		Long: cmd.LongDesc(`
+	Create or update the AWS Identity and Access Management (IAM) roles, instance profiles,
+	policies, users and groups for bootstrapping Kubernetes Cluster API and Kubernetes AWS
+	IAM permissions. By default, an AWS CloudFormation stack is created or updated, like
+	with create-cloudformation-stack.
+
+	With --native, the resources of the AWS CloudFormation template are instead created and
+	updated directly with the AWS IAM API, for accounts where AWS CloudFormation is not
+	permitted. Running the command again only updates the resources which drifted from the
+	template, and reports the drift. Changes which can't be made in place, e.g. of the path
+	of a resource, and the policies, group memberships and roles not in the template, are
+	only reported. With --dry-run, the drift is reported without changing the resources.
+	To use this command, there must be AWS credentials loaded in this environment.
+		` + credentials.CredentialHelp),
+		Example: cmd.Examples(`
+		# Create or update IAM roles and policies for Kubernetes using a AWS CloudFormation stack.
+		clusterawsadm bootstrap iam create
+
+		# Create or update IAM roles and policies for Kubernetes with the AWS IAM API.
+		clusterawsadm bootstrap iam create --native
+
+		# Report the drift of the IAM roles and policies of a custom configuration without changing them.
+		clusterawsadm bootstrap iam create --native --dry-run --config bootstrap_config.yaml
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nativeFlag, err := cmd.Flags().GetBool("native")
+			if err != nil {
+				return err
+			}
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+			if dryRun && !nativeFlag {
+				return errors.New("--dry-run requires --native")
+			}
+
+			t, err := getBootstrapTemplate(cmd)
+			if err != nil {
+				return err
+			}
+			if err := applyIAMResourceFlags(t, cmd); err != nil {
+				return err
+			}
+
+			if err := resolveTemplateRegion(t, cmd); err != nil {
+				fmt.Println("AWS_REGION env not set and --region flag not provided, default configuration will be used")
+			}
+
+			if !nativeFlag {
+				return reconcileCloudFormationStack(t)
+			}
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+				Config:            aws.Config{Region: aws.String(t.Spec.Region)},
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			svc := native.NewService(iam.New(sess), sts.New(sess))
+			svc.DryRun = dryRun
+
+			if dryRun {
+				fmt.Print("Comparing the AWS IAM resources with the template\n\n")
+			} else {
+				fmt.Print("Attempting to create or update the AWS IAM resources\n\n")
+			}
+			report, err := svc.ReconcileTemplate(*t.RenderCloudFormation(), t.Spec.StackTags)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return err
+			}
+
+			native.PrintReport(os.Stdout, report)
+			return nil
+		},
+	}
+	newCmd.Flags().Bool("native", false, "Create or update the AWS IAM resources with the AWS IAM API instead of an AWS CloudFormation stack.")
+	newCmd.Flags().Bool("dry-run", false, "Only report the drift of the AWS IAM resources from the template, without creating or updating them. Requires --native.")
+	addConfigFlag(newCmd)
+	addIAMResourceFlags(newCmd)
+	flags.AddRegionFlag(newCmd)
+	return newCmd
+}
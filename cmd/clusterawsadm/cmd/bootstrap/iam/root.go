@@ -27,11 +27,12 @@ import (
 func RootCmd() *cobra.Command {
 	newCmd := &cobra.Command{
 		Use:   "iam [command]",
-		Short: "View required AWS IAM policies and create/update IAM roles using AWS CloudFormation",
+		Short: "View required AWS IAM policies and create/update IAM roles using AWS CloudFormation or the AWS IAM API",
 		Long: cmd.LongDesc(`
 			View/output AWS Identity and Access Management (IAM) policy documents required for
 			configuring Kubernetes Cluster API Provider AWS as well as create/update AWS IAM
-			resources using AWS CloudFormation.
+			resources using AWS CloudFormation, or directly with the AWS IAM API in accounts
+			where AWS CloudFormation is not permitted.
 		`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -43,6 +44,8 @@ func RootCmd() *cobra.Command {
 	newCmd.AddCommand(printConfigCmd())
 	newCmd.AddCommand(printCloudFormationTemplateCmd())
 	newCmd.AddCommand(createCloudFormationStackCmd())
+	newCmd.AddCommand(createCmd())
 	newCmd.AddCommand(deleteCloudFormationStackCmd())
+	newCmd.AddCommand(diffCloudFormationStackCmd())
 	return newCmd
 }
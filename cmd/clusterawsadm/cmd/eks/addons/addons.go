@@ -30,6 +30,8 @@ func RootCmd() *cobra.Command {
 	}
 	newCmd.AddCommand(listAvailableCmd())
 	newCmd.AddCommand(listInstalledCmd())
+	newCmd.AddCommand(compatibilityCmd())
+	newCmd.AddCommand(checkCmd())
 
 	return newCmd
 }
@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package addons
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+// addonCheck is the result of the check of the version of an addon pinned by an AWSManagedControlPlane.
+type addonCheck struct {
+	ControlPlane      string `json:"controlPlane"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	Addon             string `json:"addon"`
+	Version           string `json:"version"`
+	Compatible        bool   `json:"compatible"`
+	// DefaultVersion is the default version of the addon for the Kubernetes version of the control plane.
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+}
+
+type addonChecks []addonCheck
+
+func checkCmd() *cobra.Command {
+	region := ""
+	outputPrinter := ""
+	filename := ""
+
+	newCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the EKS addon versions of AWSManagedControlPlanes",
+		Long: "Checks that the versions of the EKS addons pinned by the AWSManagedControlPlanes of a manifest are " +
+			"compatible with their Kubernetes version, and fails if any isn't",
+		Example: `  # Check the addons of the AWSManagedControlPlanes of a manifest
+  clusterawsadm eks addons check -f cluster.yaml
+
+  # Check the addons of a manifest generated by clusterctl
+  clusterctl generate cluster my-cluster --flavor eks | clusterawsadm eks addons check -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkAddons(region, filename, outputPrinter)
+		},
+	}
+
+	newCmd.Flags().StringVarP(&region, "region", "r", "", "The AWS region to get the EKS addons of")
+	newCmd.Flags().StringVarP(&filename, "filename", "f", "", "The manifest containing the AWSManagedControlPlanes, - for the standard input")
+	newCmd.Flags().StringVarP(&outputPrinter, "output", "o", "table", "The output format of the results. Possible values: table,json,yaml")
+	newCmd.MarkFlagRequired("filename") //nolint: errcheck
+
+	return newCmd
+}
+
+func checkAddons(region, filename, printerType string) error {
+	outputPrinter, err := cmdout.New(printerType, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed creating output printer: %w", err)
+	}
+
+	var reader io.Reader = os.Stdin
+	if filename != "-" {
+		file, err := os.Open(filename) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("opening manifest: %w", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	controlPlanes, err := readControlPlanes(reader)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	names := []string{}
+	for _, controlPlane := range controlPlanes {
+		if controlPlane.Spec.Addons == nil {
+			continue
+		}
+		for _, addon := range *controlPlane.Spec.Addons {
+			if !contains(names, addon.Name) {
+				names = append(names, addon.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println("No pinned EKS addons found")
+		return nil
+	}
+
+	eksClient, err := newEKSClient(region)
+	if err != nil {
+		return err
+	}
+	addons, err := describeAddons(eksClient, names)
+	if err != nil {
+		return fmt.Errorf("describing addon versions: %w", err)
+	}
+
+	checks, err := checkControlPlaneAddons(controlPlanes, newCompatibilityMatrix(addons, nil))
+	if err != nil {
+		return err
+	}
+
+	if printerType == string(cmdout.PrinterTypeTable) {
+		err = outputPrinter.Print(checks.ToTable())
+	} else {
+		err = outputPrinter.Print(checks)
+	}
+	if err != nil {
+		return err
+	}
+
+	if incompatible := checks.incompatible(); incompatible > 0 {
+		return fmt.Errorf("%d pinned addon versions are not compatible with the Kubernetes version of their control plane", incompatible)
+	}
+	return nil
+}
+
+// readControlPlanes returns the AWSManagedControlPlanes of a YAML or JSON manifest, which may contain
+// several documents and other kinds.
+func readControlPlanes(reader io.Reader) ([]*ekscontrolplanev1.AWSManagedControlPlane, error) {
+	controlPlanes := []*ekscontrolplanev1.AWSManagedControlPlane{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return controlPlanes, nil
+			}
+			return nil, err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		typeMeta := metav1.TypeMeta{}
+		if err := json.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, err
+		}
+		gv, err := schema.ParseGroupVersion(typeMeta.APIVersion)
+		if err != nil || gv.Group != ekscontrolplanev1.GroupVersion.Group || typeMeta.Kind != "AWSManagedControlPlane" {
+			continue
+		}
+
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		if err := json.Unmarshal(raw, controlPlane); err != nil {
+			return nil, fmt.Errorf("decoding AWSManagedControlPlane: %w", err)
+		}
+		controlPlanes = append(controlPlanes, controlPlane)
+	}
+}
+
+// checkControlPlaneAddons checks the versions of the addons pinned by the control planes against the
+// compatibility matrix of the addons.
+func checkControlPlaneAddons(controlPlanes []*ekscontrolplanev1.AWSManagedControlPlane, matrix *compatibilityMatrix) (addonChecks, error) {
+	checks := addonChecks{}
+	for _, controlPlane := range controlPlanes {
+		if controlPlane.Spec.Addons == nil || len(*controlPlane.Spec.Addons) == 0 {
+			continue
+		}
+
+		name := controlPlane.Name
+		if controlPlane.Namespace != "" {
+			name = controlPlane.Namespace + "/" + name
+		}
+		if controlPlane.Spec.Version == nil {
+			return nil, fmt.Errorf("AWSManagedControlPlane %s pins addon versions without a Kubernetes version", name)
+		}
+		v, err := version.ParseGeneric(*controlPlane.Spec.Version)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the Kubernetes version of AWSManagedControlPlane %s: %w", name, err)
+		}
+		kubernetesVersion := fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+
+		for _, addon := range *controlPlane.Spec.Addons {
+			checks = append(checks, addonCheck{
+				ControlPlane:      name,
+				KubernetesVersion: kubernetesVersion,
+				Addon:             addon.Name,
+				Version:           addon.Version,
+				Compatible:        matrix.compatible(addon.Name, addon.Version, kubernetesVersion),
+				DefaultVersion:    matrix.defaultVersion(addon.Name, kubernetesVersion),
+			})
+		}
+	}
+	return checks, nil
+}
+
+func (c addonChecks) incompatible() int {
+	incompatible := 0
+	for _, check := range c {
+		if !check.Compatible {
+			incompatible++
+		}
+	}
+	return incompatible
+}
+
+func (c addonChecks) ToTable() *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{
+				Name: "Control Plane",
+				Type: "string",
+			},
+			{
+				Name: "Kubernetes Version",
+				Type: "string",
+			},
+			{
+				Name: "Addon",
+				Type: "string",
+			},
+			{
+				Name: "Version",
+				Type: "string",
+			},
+			{
+				Name: "Compatible",
+				Type: "boolean",
+			},
+			{
+				Name: "Default Version",
+				Type: "string",
+			},
+		},
+	}
+
+	for _, check := range c {
+		row := metav1.TableRow{
+			Cells: []interface{}{check.ControlPlane, check.KubernetesVersion, check.Addon, check.Version, check.Compatible, check.DefaultVersion},
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package addons
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/spf13/cobra"
+
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+)
+
+func compatibilityCmd() *cobra.Command {
+	region := ""
+	outputPrinter := ""
+	addonNames := []string{}
+	kubernetesVersions := []string{}
+
+	newCmd := &cobra.Command{
+		Use:   "compatibility",
+		Short: "Show the compatibility matrix of the EKS addons",
+		Long: "Shows which versions of the EKS addons are compatible with which Kubernetes versions, and which version " +
+			"is the default one for each Kubernetes version",
+		Example: `  # Show the compatibility of all the versions of the vpc-cni and coredns addons
+  clusterawsadm eks addons compatibility --addon vpc-cni --addon coredns
+
+  # Show the compatibility of the addon versions with Kubernetes 1.29 and 1.30 as JSON
+  clusterawsadm eks addons compatibility --kubernetes-version 1.29 --kubernetes-version 1.30 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showCompatibility(region, addonNames, kubernetesVersions, outputPrinter)
+		},
+	}
+
+	newCmd.Flags().StringVarP(&region, "region", "r", "", "The AWS region to get the EKS addons of")
+	newCmd.Flags().StringSliceVar(&addonNames, "addon", nil, "The names of the addons to show, all the addons by default")
+	newCmd.Flags().StringSliceVar(&kubernetesVersions, "kubernetes-version", nil, "The Kubernetes versions to show, e.g. 1.29, all the versions by default")
+	newCmd.Flags().StringVarP(&outputPrinter, "output", "o", "table", "The output format of the results. Possible values: table,json,yaml")
+
+	return newCmd
+}
+
+func showCompatibility(region string, addonNames, kubernetesVersions []string, printerType string) error {
+	outputPrinter, err := cmdout.New(printerType, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed creating output printer: %w", err)
+	}
+
+	eksClient, err := newEKSClient(region)
+	if err != nil {
+		return err
+	}
+
+	addons, err := describeAddons(eksClient, addonNames)
+	if err != nil {
+		return fmt.Errorf("describing addon versions: %w", err)
+	}
+	if len(addons) == 0 {
+		fmt.Println("No EKS addons found")
+		return nil
+	}
+
+	matrix := newCompatibilityMatrix(addons, kubernetesVersions)
+	if printerType == string(cmdout.PrinterTypeTable) {
+		return outputPrinter.Print(matrix.ToTable())
+	}
+	return outputPrinter.Print(matrix)
+}
+
+func newEKSClient(region string) (*eks.EKS, error) {
+	cfg := aws.Config{}
+	if region != "" {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            cfg,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil, err
+	}
+
+	return eks.New(sess), nil
+}
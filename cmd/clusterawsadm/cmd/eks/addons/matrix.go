@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package addons
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	compatibleCell   = "yes"
+	defaultCell      = "default"
+	incompatibleCell = "-"
+)
+
+// addonVersionCompatibility is a row of a compatibility matrix: the Kubernetes versions an addon version is
+// compatible with.
+type addonVersionCompatibility struct {
+	Name               string   `json:"name"`
+	Version            string   `json:"version"`
+	KubernetesVersions []string `json:"kubernetesVersions"`
+	// DefaultFor are the Kubernetes versions the addon version is the default version of.
+	DefaultFor []string `json:"defaultFor,omitempty"`
+}
+
+// compatibilityMatrix is the compatibility of addon versions with Kubernetes versions.
+type compatibilityMatrix struct {
+	KubernetesVersions []string                    `json:"kubernetesVersions"`
+	Addons             []addonVersionCompatibility `json:"addons"`
+}
+
+// describeAddons returns the versions of the addons with the specified names, or of all the addons.
+func describeAddons(eksClient eksiface.EKSAPI, names []string) ([]*eks.AddonInfo, error) {
+	addons := []*eks.AddonInfo{}
+	describe := func(input *eks.DescribeAddonVersionsInput) error {
+		return eksClient.DescribeAddonVersionsPages(input, func(page *eks.DescribeAddonVersionsOutput, _ bool) bool {
+			addons = append(addons, page.Addons...)
+			return true
+		})
+	}
+
+	if len(names) == 0 {
+		return addons, describe(&eks.DescribeAddonVersionsInput{})
+	}
+	for _, name := range names {
+		if err := describe(&eks.DescribeAddonVersionsInput{AddonName: aws.String(name)}); err != nil {
+			return nil, err
+		}
+	}
+	return addons, nil
+}
+
+// newCompatibilityMatrix returns the compatibility matrix of the versions of the addons, restricted to the
+// specified Kubernetes versions if any. The addons are sorted by name and keep the order of their versions
+// returned by EKS, the latest first.
+func newCompatibilityMatrix(addons []*eks.AddonInfo, kubernetesVersions []string) *compatibilityMatrix {
+	matrix := &compatibilityMatrix{
+		KubernetesVersions: kubernetesVersions,
+		Addons:             []addonVersionCompatibility{},
+	}
+	filter := len(kubernetesVersions) > 0
+	seen := map[string]bool{}
+	for _, v := range kubernetesVersions {
+		seen[v] = true
+	}
+
+	sorted := append([]*eks.AddonInfo{}, addons...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return aws.StringValue(sorted[i].AddonName) < aws.StringValue(sorted[j].AddonName)
+	})
+
+	for _, addon := range sorted {
+		for _, addonVersion := range addon.AddonVersions {
+			row := addonVersionCompatibility{
+				Name:               aws.StringValue(addon.AddonName),
+				Version:            aws.StringValue(addonVersion.AddonVersion),
+				KubernetesVersions: []string{},
+			}
+			for _, compat := range addonVersion.Compatibilities {
+				clusterVersion := aws.StringValue(compat.ClusterVersion)
+				if filter && !seen[clusterVersion] {
+					continue
+				}
+				if !seen[clusterVersion] {
+					seen[clusterVersion] = true
+					matrix.KubernetesVersions = append(matrix.KubernetesVersions, clusterVersion)
+				}
+				row.KubernetesVersions = append(row.KubernetesVersions, clusterVersion)
+				if aws.BoolValue(compat.DefaultVersion) {
+					row.DefaultFor = append(row.DefaultFor, clusterVersion)
+				}
+			}
+			matrix.Addons = append(matrix.Addons, row)
+		}
+	}
+
+	sortKubernetesVersions(matrix.KubernetesVersions)
+	return matrix
+}
+
+// compatible returns whether the version of an addon is compatible with a Kubernetes version.
+func (m *compatibilityMatrix) compatible(name, addonVersion, kubernetesVersion string) bool {
+	for _, row := range m.Addons {
+		if row.Name == name && row.Version == addonVersion {
+			return contains(row.KubernetesVersions, kubernetesVersion)
+		}
+	}
+	return false
+}
+
+// defaultVersion returns the default version of an addon for a Kubernetes version, if any.
+func (m *compatibilityMatrix) defaultVersion(name, kubernetesVersion string) string {
+	for _, row := range m.Addons {
+		if row.Name == name && contains(row.DefaultFor, kubernetesVersion) {
+			return row.Version
+		}
+	}
+	return ""
+}
+
+func (m *compatibilityMatrix) ToTable() *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{
+				Name: "Name",
+				Type: "string",
+			},
+			{
+				Name: "Version",
+				Type: "string",
+			},
+		},
+	}
+	for _, v := range m.KubernetesVersions {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: v, Type: "string"})
+	}
+
+	for _, addon := range m.Addons {
+		cells := []interface{}{addon.Name, addon.Version}
+		for _, v := range m.KubernetesVersions {
+			switch {
+			case contains(addon.DefaultFor, v):
+				cells = append(cells, defaultCell)
+			case contains(addon.KubernetesVersions, v):
+				cells = append(cells, compatibleCell)
+			default:
+				cells = append(cells, incompatibleCell)
+			}
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return table
+}
+
+// sortKubernetesVersions sorts Kubernetes versions, the latest first.
+func sortKubernetesVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, erri := version.ParseGeneric(versions[i])
+		vj, errj := version.ParseGeneric(versions[j])
+		if erri != nil || errj != nil {
+			return versions[i] > versions[j]
+		}
+		return vj.LessThan(vi)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
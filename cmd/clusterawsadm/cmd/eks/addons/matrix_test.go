@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package addons
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+func testAddons() []*eks.AddonInfo {
+	compatibility := func(clusterVersion string, isDefault bool) *eks.Compatibility {
+		return &eks.Compatibility{ClusterVersion: aws.String(clusterVersion), DefaultVersion: aws.Bool(isDefault)}
+	}
+	return []*eks.AddonInfo{
+		{
+			AddonName: aws.String("vpc-cni"),
+			AddonVersions: []*eks.AddonVersionInfo{
+				{
+					AddonVersion:    aws.String("v1.16.0-eksbuild.1"),
+					Compatibilities: []*eks.Compatibility{compatibility("1.29", true), compatibility("1.28", false)},
+				},
+				{
+					AddonVersion:    aws.String("v1.15.1-eksbuild.1"),
+					Compatibilities: []*eks.Compatibility{compatibility("1.28", true), compatibility("1.9", true)},
+				},
+			},
+		},
+		{
+			AddonName: aws.String("coredns"),
+			AddonVersions: []*eks.AddonVersionInfo{
+				{
+					AddonVersion:    aws.String("v1.11.1-eksbuild.4"),
+					Compatibilities: []*eks.Compatibility{compatibility("1.29", true)},
+				},
+			},
+		},
+	}
+}
+
+func TestNewCompatibilityMatrix(t *testing.T) {
+	tests := []struct {
+		name                       string
+		kubernetesVersions         []string
+		expectedKubernetesVersions []string
+		expectedRows               []string
+	}{
+		{
+			name:                       "all Kubernetes versions",
+			expectedKubernetesVersions: []string{"1.29", "1.28", "1.9"},
+			expectedRows: []string{
+				"coredns v1.11.1-eksbuild.4 default - -",
+				"vpc-cni v1.16.0-eksbuild.1 default yes -",
+				"vpc-cni v1.15.1-eksbuild.1 - default default",
+			},
+		},
+		{
+			name:                       "filtered Kubernetes versions",
+			kubernetesVersions:         []string{"1.28"},
+			expectedKubernetesVersions: []string{"1.28"},
+			expectedRows: []string{
+				"coredns v1.11.1-eksbuild.4 -",
+				"vpc-cni v1.16.0-eksbuild.1 yes",
+				"vpc-cni v1.15.1-eksbuild.1 default",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			matrix := newCompatibilityMatrix(testAddons(), tc.kubernetesVersions)
+			g.Expect(matrix.KubernetesVersions).To(Equal(tc.expectedKubernetesVersions))
+
+			table := matrix.ToTable()
+			g.Expect(table.ColumnDefinitions).To(HaveLen(2 + len(tc.expectedKubernetesVersions)))
+			g.Expect(tableRows(table)).To(Equal(tc.expectedRows))
+		})
+	}
+}
+
+func TestCompatibilityMatrixLookups(t *testing.T) {
+	g := NewWithT(t)
+
+	matrix := newCompatibilityMatrix(testAddons(), nil)
+	g.Expect(matrix.compatible("vpc-cni", "v1.16.0-eksbuild.1", "1.28")).To(BeTrue())
+	g.Expect(matrix.compatible("vpc-cni", "v1.16.0-eksbuild.1", "1.9")).To(BeFalse())
+	g.Expect(matrix.compatible("vpc-cni", "v1.0.0", "1.29")).To(BeFalse())
+	g.Expect(matrix.compatible("kube-proxy", "v1.29.0-eksbuild.1", "1.29")).To(BeFalse())
+	g.Expect(matrix.defaultVersion("vpc-cni", "1.28")).To(Equal("v1.15.1-eksbuild.1"))
+	g.Expect(matrix.defaultVersion("coredns", "1.28")).To(BeEmpty())
+}
+
+func TestReadControlPlanes(t *testing.T) {
+	g := NewWithT(t)
+
+	manifest := `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: test
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+kind: AWSManagedControlPlane
+metadata:
+  name: test-control-plane
+  namespace: default
+spec:
+  version: v1.29.1
+  addons:
+  - name: vpc-cni
+    version: v1.16.0-eksbuild.1
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+kind: KubeadmControlPlane
+metadata:
+  name: other
+`
+
+	controlPlanes, err := readControlPlanes(strings.NewReader(manifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(controlPlanes).To(HaveLen(1))
+	g.Expect(controlPlanes[0].Name).To(Equal("test-control-plane"))
+	g.Expect(*controlPlanes[0].Spec.Version).To(Equal("v1.29.1"))
+	g.Expect(*controlPlanes[0].Spec.Addons).To(HaveLen(1))
+}
+
+func TestCheckControlPlaneAddons(t *testing.T) {
+	controlPlane := func(kubernetesVersion *string, addons ...ekscontrolplanev1.Addon) *ekscontrolplanev1.AWSManagedControlPlane {
+		return &ekscontrolplanev1.AWSManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				Version: kubernetesVersion,
+				Addons:  &addons,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		controlPlane         *ekscontrolplanev1.AWSManagedControlPlane
+		expectedChecks       addonChecks
+		expectedIncompatible int
+		expectError          bool
+	}{
+		{
+			name:         "compatible version",
+			controlPlane: controlPlane(aws.String("v1.28.5"), ekscontrolplanev1.Addon{Name: "vpc-cni", Version: "v1.16.0-eksbuild.1"}),
+			expectedChecks: addonChecks{
+				{
+					ControlPlane:      "default/test",
+					KubernetesVersion: "1.28",
+					Addon:             "vpc-cni",
+					Version:           "v1.16.0-eksbuild.1",
+					Compatible:        true,
+					DefaultVersion:    "v1.15.1-eksbuild.1",
+				},
+			},
+		},
+		{
+			name:         "incompatible version",
+			controlPlane: controlPlane(aws.String("v1.28"), ekscontrolplanev1.Addon{Name: "coredns", Version: "v1.11.1-eksbuild.4"}),
+			expectedChecks: addonChecks{
+				{
+					ControlPlane:      "default/test",
+					KubernetesVersion: "1.28",
+					Addon:             "coredns",
+					Version:           "v1.11.1-eksbuild.4",
+					Compatible:        false,
+				},
+			},
+			expectedIncompatible: 1,
+		},
+		{
+			name:           "no pinned addons",
+			controlPlane:   controlPlane(nil),
+			expectedChecks: addonChecks{},
+		},
+		{
+			name:         "pinned addons without a Kubernetes version",
+			controlPlane: controlPlane(nil, ekscontrolplanev1.Addon{Name: "coredns", Version: "v1.11.1-eksbuild.4"}),
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			checks, err := checkControlPlaneAddons([]*ekscontrolplanev1.AWSManagedControlPlane{tc.controlPlane}, newCompatibilityMatrix(testAddons(), nil))
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(checks).To(Equal(tc.expectedChecks))
+			g.Expect(checks.incompatible()).To(Equal(tc.expectedIncompatible))
+		})
+	}
+}
+
+func tableRows(table *metav1.Table) []string {
+	rows := []string{}
+	for _, row := range table.Rows {
+		cells := []string{}
+		for _, cell := range row.Cells {
+			cells = append(cells, cell.(string))
+		}
+		rows = append(rows, strings.Join(cells, " "))
+	}
+	return rows
+}
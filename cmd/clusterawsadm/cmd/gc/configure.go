@@ -46,7 +46,8 @@ func newConfigureCmd() *cobra.Command {
 		Long: cmd.LongDesc(`
 			This command will set what cleanup tasks to execute on the given cluster
 			during garbage collection (i.e. deleting) when the cluster is
-			requested to be deleted. Supported values: load-balancer, security-group, target-group.
+			requested to be deleted. Supported values: load-balancer, security-group, target-group,
+			network-interface, volume, launch-template.
 		`),
 		Example: cmd.Examples(`
 			# Configure GC for a cluster to delete only load balancers and security groups using existing k8s context
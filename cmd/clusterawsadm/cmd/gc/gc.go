@@ -35,6 +35,7 @@ func RootCmd() *cobra.Command {
 	newCmd.AddCommand(newEnableCmd())
 	newCmd.AddCommand(newDisableCmd())
 	newCmd.AddCommand(newConfigureCmd())
+	newCmd.AddCommand(newRunCmd())
 
 	return newCmd
 }
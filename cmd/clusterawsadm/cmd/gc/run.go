@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/homedir"
+
+	gcproc "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/gc"
+	cmdout "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/printers"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+func newRunCmd() *cobra.Command {
+	var (
+		clusterName           string
+		namespace             string
+		kubeConfig            string
+		kubeConfigDefault     string
+		dryRun                bool
+		alternativeGCStrategy bool
+		outputPrinterType     string
+	)
+
+	if home := homedir.HomeDir(); home != "" {
+		kubeConfigDefault = filepath.Join(home, ".kube", "config")
+	}
+
+	newCmd := &cobra.Command{
+		Use:   "run",
+		Short: "Garbage collect the external AWS resources of a cluster",
+		Long: cmd.LongDesc(`
+			This command will delete the AWS resources created by the workload cluster, such as the
+			load balancers of Services of type LoadBalancer, as the garbage collection would when the
+			cluster is deleted. The cleanup tasks configured for the cluster are honoured.
+
+			With --dry-run, the resources that would be deleted are listed instead, even if the cluster
+			opted-out of garbage collection. This can be used to check the scope of the garbage
+			collection before enabling it.
+
+			The AWS credentials are the ones the controllers would use for the cluster, so when the
+			cluster doesn't reference an identity they are taken from the environment.
+		`),
+		Example: cmd.Examples(`
+			# List the AWS resources the garbage collection of a cluster would delete using existing k8s context
+			clusterawsadm gc run --cluster-name=test-cluster --dry-run
+
+			# List the AWS resources the garbage collection of a cluster would delete as JSON
+			clusterawsadm gc run --cluster-name=test-cluster --dry-run -o json
+
+			# Delete the AWS resources of a cluster using kubeconfig
+			clusterawsadm gc run --cluster-name=test-cluster --kubeconfig=test.kubeconfig
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proc, err := gcproc.New(gcproc.GCInput{
+				ClusterName:    clusterName,
+				Namespace:      namespace,
+				KubeconfigPath: kubeConfig,
+			})
+			if err != nil {
+				return fmt.Errorf("creating command processor: %w", err)
+			}
+
+			if !dryRun {
+				if err := proc.Run(cmd.Context(), alternativeGCStrategy); err != nil {
+					return fmt.Errorf("running garbage collection: %w", err)
+				}
+				fmt.Printf("Ran garbage collection for cluster %s/%s\n", namespace, clusterName)
+
+				return nil
+			}
+
+			outputPrinter, err := cmdout.New(outputPrinterType, os.Stdout)
+			if err != nil {
+				return fmt.Errorf("creating output printer: %w", err)
+			}
+
+			resourceList, err := proc.ListResourcesToDelete(cmd.Context(), alternativeGCStrategy)
+			if err != nil {
+				return fmt.Errorf("listing resources to garbage collect: %w", err)
+			}
+
+			if outputPrinterType == string(cmdout.PrinterTypeTable) {
+				return outputPrinter.Print(resourceList.ToTable())
+			}
+
+			return outputPrinter.Print(resourceList)
+		},
+	}
+
+	newCmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the CAPA cluster")
+	newCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace for the cluster definition")
+	newCmd.Flags().StringVar(&kubeConfig, "kubeconfig", kubeConfigDefault, "Path to the kubeconfig file to use")
+	newCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the AWS resources that would be deleted without deleting them")
+	newCmd.Flags().BoolVar(&alternativeGCStrategy, "alternative-gc-strategy", false, "Collect the resources without the resource group tagging API, as the controllers do with the AlternativeGCStrategy feature gate")
+	newCmd.Flags().StringVarP(&outputPrinterType, "output", "o", "table", "The output format of the dry-run results. Possible values: table, json, yaml")
+
+	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
+
+	return newCmd
+}
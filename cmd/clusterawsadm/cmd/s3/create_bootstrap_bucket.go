@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cmd/flags"
+	s3proc "sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/s3"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd"
+)
+
+func newCreateBootstrapBucketCmd() *cobra.Command {
+	var (
+		clusterName                    string
+		bucketName                     string
+		controlPlaneIAMInstanceProfile string
+		nodesIAMInstanceProfiles       []string
+		presignedURLDuration           time.Duration
+		kmsKeyARN                      string
+		bootstrapDataExpirationDays    int32
+		oidcDiscovery                  bool
+		additionalTags                 map[string]string
+		validate                       bool
+	)
+
+	newCmd := &cobra.Command{
+		Use:   "create-bootstrap-bucket",
+		Short: "Create the S3 bucket storing the bootstrap data of a cluster",
+		Long: cmd.LongDesc(`
+			This command creates the S3 bucket storing the bootstrap data of a cluster,
+			for accounts where the controller isn't allowed to create buckets. The public
+			access block, tags, default encryption, lifecycle rules and policy of the bucket
+			are set the way the controller expects for the S3 bucket of an AWSCluster with
+			the same configuration. Running the command on an existing bucket of the caller
+			updates its configuration.
+
+			With --validate, the configuration of an existing bucket is checked instead, and
+			the command fails if the bucket doesn't match it.
+		`),
+		Example: cmd.Examples(`
+			# Create the bootstrap data bucket of a cluster
+			clusterawsadm s3 create-bootstrap-bucket --cluster-name=test-cluster --bucket-name=test-cluster-bootstrap
+
+			# Create a bucket encrypted with a customer managed KMS key expiring bootstrap data after a day
+			clusterawsadm s3 create-bootstrap-bucket --cluster-name=test-cluster --bucket-name=test-cluster-bootstrap --kms-key-arn=arn:aws:kms:us-west-2:123456789012:key/abcd --bootstrap-data-expiration-days=1
+
+			# Validate a pre-existing bucket
+			clusterawsadm s3 create-bootstrap-bucket --cluster-name=test-cluster --bucket-name=shared-bootstrap --validate
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			region, err := flags.GetRegionWithError(cmd)
+			if err != nil {
+				return err
+			}
+
+			input := &s3proc.BootstrapBucketInput{
+				ClusterName: clusterName,
+				Region:      region,
+				Bucket: infrav1.S3Bucket{
+					Name:                           bucketName,
+					ControlPlaneIAMInstanceProfile: controlPlaneIAMInstanceProfile,
+					NodesIAMInstanceProfiles:       nodesIAMInstanceProfiles,
+					KMSKeyARN:                      kmsKeyARN,
+				},
+				OIDCDiscovery:  oidcDiscovery,
+				AdditionalTags: additionalTags,
+			}
+			if presignedURLDuration > 0 {
+				input.Bucket.PresignedURLDuration = &metav1.Duration{Duration: presignedURLDuration}
+			}
+			if bootstrapDataExpirationDays > 0 {
+				input.Bucket.BootstrapDataExpirationDays = aws.Int32(bootstrapDataExpirationDays)
+			}
+
+			sess, err := session.NewSessionWithOptions(session.Options{
+				SharedConfigState: session.SharedConfigEnable,
+				Config:            aws.Config{Region: aws.String(region)},
+			})
+			if err != nil {
+				return flags.ResolveAWSError(err)
+			}
+			svc := s3proc.NewService(s3.New(sess), sts.New(sess))
+
+			if !validate {
+				if err := svc.CreateBootstrapBucket(input); err != nil {
+					return fmt.Errorf("creating bootstrap bucket: %w", flags.ResolveAWSError(err))
+				}
+				fmt.Printf("Created bootstrap bucket %s for cluster %s\n", bucketName, clusterName)
+				return nil
+			}
+
+			problems, err := svc.ValidateBootstrapBucket(input)
+			if err != nil {
+				return fmt.Errorf("validating bootstrap bucket: %w", flags.ResolveAWSError(err))
+			}
+			if len(problems) > 0 {
+				for _, problem := range problems {
+					fmt.Printf("- %s\n", problem)
+				}
+				return errors.New("bootstrap bucket doesn't match the configuration")
+			}
+			fmt.Printf("Bootstrap bucket %s matches the configuration of cluster %s\n", bucketName, clusterName)
+			return nil
+		},
+	}
+
+	newCmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the CAPA cluster")
+	newCmd.Flags().StringVar(&bucketName, "bucket-name", "", "The name of the S3 bucket")
+	newCmd.Flags().StringVar(&controlPlaneIAMInstanceProfile, "control-plane-iam-instance-profile", "control-plane"+iamv1.DefaultNameSuffix,
+		"The IAM instance profile allowed to read the bootstrap data of control plane nodes")
+	newCmd.Flags().StringSliceVar(&nodesIAMInstanceProfiles, "nodes-iam-instance-profiles", []string{"nodes" + iamv1.DefaultNameSuffix},
+		"The IAM instance profiles allowed to read the bootstrap data of worker nodes")
+	newCmd.Flags().DurationVar(&presignedURLDuration, "presigned-url-duration", 0,
+		"The duration of the presigned URLs of the bootstrap data, when used instead of the IAM instance profiles")
+	newCmd.Flags().StringVar(&kmsKeyARN, "kms-key-arn", "", "The ARN of the customer managed KMS key encrypting the bootstrap data")
+	newCmd.Flags().Int32Var(&bootstrapDataExpirationDays, "bootstrap-data-expiration-days", 0,
+		"The number of days after which bootstrap data objects expire, never by default")
+	newCmd.Flags().BoolVar(&oidcDiscovery, "oidc-discovery", false, "Allow public read access to the OIDC discovery documents of the cluster stored in the bucket")
+	newCmd.Flags().StringToStringVar(&additionalTags, "additional-tags", nil, "The additional tags of the AWSCluster, e.g. key1=value1,key2=value2")
+	newCmd.Flags().BoolVar(&validate, "validate", false, "Only validate the configuration of an existing bucket")
+	flags.AddRegionFlag(newCmd)
+
+	newCmd.MarkFlagRequired("cluster-name") //nolint: errcheck
+	newCmd.MarkFlagRequired("bucket-name")  //nolint: errcheck
+
+	return newCmd
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package s3 provides commands related to the S3 bucket storing the bootstrap data of clusters.
+package s3
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RootCmd is the root of the `s3 command`.
+func RootCmd() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "s3 [command]",
+		Short: "Commands related to the S3 bucket storing the bootstrap data of clusters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	newCmd.AddCommand(newCreateBootstrapBucketCmd())
+
+	return newCmd
+}
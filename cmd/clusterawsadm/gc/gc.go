@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/exec" // import all auth plugins
@@ -30,8 +31,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/resource"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -42,6 +47,7 @@ var (
 )
 
 func init() {
+	_ = corev1.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
 	_ = infrav1.AddToScheme(scheme)
 	_ = ekscontrolplanev1.AddToScheme(scheme)
@@ -124,7 +130,7 @@ func (c *CmdProcessor) Disable(ctx context.Context) error {
 
 // Configure is used to configure external resource garbage collection for a cluster.
 func (c *CmdProcessor) Configure(ctx context.Context, gcTasks []string) error {
-	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup}
+	supportedGCTasks := []infrav1.GCTask{infrav1.GCTaskLoadBalancer, infrav1.GCTaskTargetGroup, infrav1.GCTaskSecurityGroup, infrav1.GCTaskNetworkInterface, infrav1.GCTaskVolume, infrav1.GCTaskLaunchTemplate}
 
 	for _, gcTask := range gcTasks {
 		found := false
@@ -150,6 +156,106 @@ func (c *CmdProcessor) Configure(ctx context.Context, gcTasks []string) error {
 	return nil
 }
 
+// ListResourcesToDelete is used to list the AWS resources that the garbage collection of a cluster would delete.
+func (c *CmdProcessor) ListResourcesToDelete(ctx context.Context, alternativeGCStrategy bool) (*resource.AWSResourceList, error) {
+	svc, err := c.newGCService(ctx, alternativeGCStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := svc.ListResourcesToDelete(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing resources to delete: %w", err)
+	}
+
+	list := &resource.AWSResourceList{
+		ClusterName:  c.clusterName,
+		AWSResources: []resource.AWSResource{},
+	}
+	for _, r := range resources {
+		list.AWSResources = append(list.AWSResources, resource.AWSResource{
+			Partition: r.ARN.Partition,
+			Service:   r.ARN.Service,
+			Region:    r.ARN.Region,
+			AccountID: r.ARN.AccountID,
+			Resource:  r.ARN.Resource,
+			ARN:       r.ARN.String(),
+		})
+	}
+
+	return list, nil
+}
+
+// Run is used to garbage collect the AWS resources of a cluster, unless it opted-out of garbage collection.
+func (c *CmdProcessor) Run(ctx context.Context, alternativeGCStrategy bool) error {
+	svc, err := c.newGCService(ctx, alternativeGCStrategy)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.ReconcileDelete(ctx); err != nil {
+		return fmt.Errorf("deleting resources: %w", err)
+	}
+
+	return nil
+}
+
+// newGCService creates the garbage collection service of the cluster, using the same
+// AWS credentials as the controllers would.
+func (c *CmdProcessor) newGCService(ctx context.Context, alternativeGCStrategy bool) (*gc.Service, error) {
+	cluster, err := c.getCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := cluster.Spec.InfrastructureRef
+	if cpRef := cluster.Spec.ControlPlaneRef; cpRef != nil && cpRef.Kind == "AWSManagedControlPlane" {
+		// The resources of EKS clusters are garbage collected by the AWSManagedControlPlane controller.
+		ref = cpRef
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("capi cluster %s/%s has no infrastructure reference", cluster.Namespace, cluster.Name)
+	}
+	key := client.ObjectKey{Name: ref.Name, Namespace: cluster.Namespace}
+
+	var clusterScope cloud.ClusterScoper
+	switch ref.Kind {
+	case "AWSCluster":
+		awsCluster := &infrav1.AWSCluster{}
+		if err := c.client.Get(ctx, key, awsCluster); err != nil {
+			return nil, fmt.Errorf("getting infra cluster %s/%s: %w", key.Namespace, key.Name, err)
+		}
+
+		clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
+			Context:        ctx,
+			Client:         c.client,
+			Cluster:        cluster,
+			AWSCluster:     awsCluster,
+			ControllerName: "awscluster",
+		})
+	case "AWSManagedControlPlane":
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		if err := c.client.Get(ctx, key, controlPlane); err != nil {
+			return nil, fmt.Errorf("getting infra cluster %s/%s: %w", key.Namespace, key.Name, err)
+		}
+
+		clusterScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+			Context:        ctx,
+			Client:         c.client,
+			Cluster:        cluster,
+			ControlPlane:   controlPlane,
+			ControllerName: "awsmanagedcontrolplane",
+		})
+	default:
+		return nil, fmt.Errorf("unsupported infra cluster kind %s", ref.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating cluster scope: %w", err)
+	}
+
+	return gc.NewService(clusterScope, gc.WithGCStrategy(alternativeGCStrategy)), nil
+}
+
 func (c *CmdProcessor) setAnnotationAndPatch(ctx context.Context, annotationName, annotationValue string) error {
 	infraObj, err := c.getInfraCluster(ctx)
 	if err != nil {
@@ -174,7 +280,7 @@ func (c *CmdProcessor) setAnnotationAndPatch(ctx context.Context, annotationName
 	return nil
 }
 
-func (c *CmdProcessor) getInfraCluster(ctx context.Context) (*unstructured.Unstructured, error) {
+func (c *CmdProcessor) getCluster(ctx context.Context) (*clusterv1.Cluster, error) {
 	cluster := &clusterv1.Cluster{}
 
 	key := client.ObjectKey{
@@ -186,6 +292,15 @@ func (c *CmdProcessor) getInfraCluster(ctx context.Context) (*unstructured.Unstr
 		return nil, fmt.Errorf("getting capi cluster %s/%s: %w", c.namespace, c.clusterName, err)
 	}
 
+	return cluster, nil
+}
+
+func (c *CmdProcessor) getInfraCluster(ctx context.Context) (*unstructured.Unstructured, error) {
+	cluster, err := c.getCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ref := cluster.Spec.InfrastructureRef
 	obj, err := external.Get(ctx, c.client, ref, cluster.Namespace)
 	if err != nil {
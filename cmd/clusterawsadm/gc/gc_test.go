@@ -288,6 +288,49 @@ func TestConfigureGC(t *testing.T) {
 	}
 }
 
+func TestListResourcesToDelete(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clusterName  string
+		existingObjs []client.Object
+	}{
+		{
+			name:         "no capi cluster",
+			clusterName:  testClusterName,
+			existingObjs: []client.Object{},
+		},
+		{
+			name:         "no managed control plane",
+			clusterName:  testClusterName,
+			existingObjs: newManagedCluster(testClusterName, true),
+		},
+		{
+			name:         "no awscluster",
+			clusterName:  testClusterName,
+			existingObjs: newUnManagedCluster(testClusterName, true),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			input := GCInput{
+				ClusterName: tc.clusterName,
+				Namespace:   "default",
+			}
+
+			fake := newFakeClient(scheme, tc.existingObjs...)
+
+			proc, err := New(input, WithClient(fake))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			_, err = proc.ListResourcesToDelete(context.TODO(), false)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
 func newFakeClient(scheme *runtime.Scheme, objs ...client.Object) client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
 }
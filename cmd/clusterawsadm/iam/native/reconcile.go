@@ -0,0 +1,882 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package native
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	go_cfn "github.com/awslabs/goformation/v4/cloudformation"
+	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
+	cfn_tags "github.com/awslabs/goformation/v4/cloudformation/tags"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	roleType            = "AWS::IAM::Role"
+	userType            = "AWS::IAM::User"
+	groupType           = "AWS::IAM::Group"
+	instanceProfileType = "AWS::IAM::InstanceProfile"
+	managedPolicyType   = "AWS::IAM::ManagedPolicy"
+
+	// maxPolicyVersions is the maximum number of versions AWS IAM keeps for a managed policy.
+	maxPolicyVersions = 5
+)
+
+// reconciler reconciles the AWS IAM resources of a template.
+type reconciler struct {
+	*Service
+
+	template  go_cfn.Template
+	partition string
+	account   string
+	tags      map[string]string
+	// attachments are the ARNs of the managed policies of the template attached to a role, a user or a
+	// group, by principal key.
+	attachments map[string][]string
+}
+
+// inlinePolicy is an inline policy of a role, a user or a group.
+type inlinePolicy struct {
+	name     string
+	document interface{}
+}
+
+// principal abstracts the AWS IAM API calls managing the policies of a role, a user or a group.
+type principal struct {
+	key          string
+	getInline    func(policyName string) (string, error)
+	putInline    func(policyName, document string) error
+	listInline   func() ([]string, error)
+	listAttached func() ([]string, error)
+	attach       func(policyARN string) error
+}
+
+// ReconcileTemplate creates the AWS IAM resources of the template which don't exist and updates the ones which
+// drifted from it, the same way the AWS CloudFormation stack of the template would. The tags are added to the
+// roles and users, like the tags of a stack. Changes which can't be made in place, e.g. of the path of a
+// resource, and the policies, group memberships and roles not in the template are only reported.
+func (s *Service) ReconcileTemplate(t go_cfn.Template, tags map[string]string) (*Report, error) {
+	identity, err := s.STS.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the caller identity")
+	}
+	callerARN, err := arn.Parse(aws.StringValue(identity.Arn))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the caller identity %q", aws.StringValue(identity.Arn))
+	}
+
+	r := &reconciler{
+		Service:     s,
+		template:    t,
+		partition:   callerARN.Partition,
+		account:     aws.StringValue(identity.Account),
+		tags:        tags,
+		attachments: map[string][]string{},
+	}
+
+	policies := r.template.GetAllIAMManagedPolicyResources()
+	for _, id := range sortedKeys(policies) {
+		policy := policies[id]
+		policyARN := r.policyARN(policy)
+		for _, role := range policy.Roles {
+			r.attach("role/"+r.resolve(role), policyARN)
+		}
+		for _, user := range policy.Users {
+			r.attach("user/"+r.resolve(user), policyARN)
+		}
+		for _, group := range policy.Groups {
+			r.attach("group/"+r.resolve(group), policyARN)
+		}
+	}
+
+	report := &Report{}
+	add := func(result ResourceResult, err error) error {
+		if err != nil {
+			return err
+		}
+		report.Resources = append(report.Resources, result)
+		return nil
+	}
+
+	// the resources are reconciled in the order of their dependencies.
+	for _, id := range sortedKeys(policies) {
+		if err := add(r.reconcileManagedPolicy(policies[id])); err != nil {
+			return nil, err
+		}
+	}
+	groups := r.template.GetAllIAMGroupResources()
+	for _, id := range sortedKeys(groups) {
+		if err := add(r.reconcileGroup(groups[id])); err != nil {
+			return nil, err
+		}
+	}
+	roles := r.template.GetAllIAMRoleResources()
+	for _, id := range sortedKeys(roles) {
+		if err := add(r.reconcileRole(roles[id])); err != nil {
+			return nil, err
+		}
+	}
+	profiles := r.template.GetAllIAMInstanceProfileResources()
+	for _, id := range sortedKeys(profiles) {
+		if err := add(r.reconcileInstanceProfile(profiles[id])); err != nil {
+			return nil, err
+		}
+	}
+	users := r.template.GetAllIAMUserResources()
+	for _, id := range sortedKeys(users) {
+		if err := add(r.reconcileUser(users[id])); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func (r *reconciler) reconcileManagedPolicy(policy *cfn_iam.ManagedPolicy) (ResourceResult, error) {
+	result := ResourceResult{Type: managedPolicyType, Name: policy.ManagedPolicyName}
+	policyARN := r.policyARN(policy)
+	document, err := policyDocumentJSON(policy.PolicyDocument)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to convert the document of policy %s", policy.ManagedPolicyName)
+	}
+
+	out, err := r.IAM.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(policyARN)})
+	if err != nil {
+		if !isNoSuchEntity(err) {
+			return result, errors.Wrapf(err, "failed to get policy %s", policyARN)
+		}
+		if r.DryRun {
+			result.Status = ResourceMissing
+			return result, nil
+		}
+		input := &iam.CreatePolicyInput{
+			PolicyName:     aws.String(policy.ManagedPolicyName),
+			Path:           aws.String(iamPath(policy.Path)),
+			PolicyDocument: aws.String(document),
+		}
+		if policy.Description != "" {
+			input.Description = aws.String(policy.Description)
+		}
+		if _, err := r.IAM.CreatePolicy(input); err != nil {
+			return result, errors.Wrapf(err, "failed to create policy %s", policy.ManagedPolicyName)
+		}
+		result.Status = ResourceCreated
+		return result, nil
+	}
+
+	version, err := r.IAM.GetPolicyVersion(&iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(policyARN),
+		VersionId: out.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to get the default version of policy %s", policyARN)
+	}
+	equal, err := policyDocumentEqual(aws.StringValue(version.PolicyVersion.Document), document)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to compare the document of policy %s", policyARN)
+	}
+	if !equal {
+		result.Drift = append(result.Drift, fmt.Sprintf("document of the default version %s differs from the template", aws.StringValue(out.Policy.DefaultVersionId)))
+		if !r.DryRun {
+			if err := r.createPolicyVersion(policyARN, document); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	r.setStatus(&result)
+	return result, nil
+}
+
+// createPolicyVersion creates a default version of a managed policy, deleting its oldest version beforehand
+// if it already has as many versions as AWS IAM keeps.
+func (r *reconciler) createPolicyVersion(policyARN, document string) error {
+	versions, err := r.IAM.ListPolicyVersions(&iam.ListPolicyVersionsInput{PolicyArn: aws.String(policyARN)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the versions of policy %s", policyARN)
+	}
+
+	if len(versions.Versions) >= maxPolicyVersions {
+		var oldest *iam.PolicyVersion
+		for _, version := range versions.Versions {
+			if aws.BoolValue(version.IsDefaultVersion) {
+				continue
+			}
+			if oldest == nil || aws.TimeValue(version.CreateDate).Before(aws.TimeValue(oldest.CreateDate)) {
+				oldest = version
+			}
+		}
+		if oldest != nil {
+			if _, err := r.IAM.DeletePolicyVersion(&iam.DeletePolicyVersionInput{PolicyArn: aws.String(policyARN), VersionId: oldest.VersionId}); err != nil {
+				return errors.Wrapf(err, "failed to delete version %s of policy %s", aws.StringValue(oldest.VersionId), policyARN)
+			}
+		}
+	}
+
+	if _, err := r.IAM.CreatePolicyVersion(&iam.CreatePolicyVersionInput{
+		PolicyArn:      aws.String(policyARN),
+		PolicyDocument: aws.String(document),
+		SetAsDefault:   aws.Bool(true),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create a version of policy %s", policyARN)
+	}
+	return nil
+}
+
+func (r *reconciler) reconcileGroup(group *cfn_iam.Group) (ResourceResult, error) {
+	result := ResourceResult{Type: groupType, Name: group.GroupName}
+
+	out, err := r.IAM.GetGroup(&iam.GetGroupInput{GroupName: aws.String(group.GroupName)})
+	switch {
+	case err != nil && !isNoSuchEntity(err):
+		return result, errors.Wrapf(err, "failed to get group %s", group.GroupName)
+	case err != nil && r.DryRun:
+		result.Status = ResourceMissing
+		return result, nil
+	case err != nil:
+		if _, err := r.IAM.CreateGroup(&iam.CreateGroupInput{
+			GroupName: aws.String(group.GroupName),
+			Path:      aws.String(iamPath(group.Path)),
+		}); err != nil {
+			return result, errors.Wrapf(err, "failed to create group %s", group.GroupName)
+		}
+		result.Status = ResourceCreated
+	default:
+		if path := aws.StringValue(out.Group.Path); path != iamPath(group.Path) {
+			result.Drift = append(result.Drift, fmt.Sprintf("path is %s instead of %s, which requires recreating the group", path, iamPath(group.Path)))
+		}
+	}
+
+	inline := make([]inlinePolicy, 0, len(group.Policies))
+	for _, policy := range group.Policies {
+		inline = append(inline, inlinePolicy{name: policy.PolicyName, document: policy.PolicyDocument})
+	}
+	drift, err := r.ensurePolicies(r.groupPrincipal(group.GroupName), inline, group.ManagedPolicyArns)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to reconcile the policies of group %s", group.GroupName)
+	}
+	result.Drift = append(result.Drift, drift...)
+
+	r.setStatus(&result)
+	return result, nil
+}
+
+func (r *reconciler) reconcileRole(role *cfn_iam.Role) (ResourceResult, error) {
+	result := ResourceResult{Type: roleType, Name: role.RoleName}
+	trustPolicy, err := policyDocumentJSON(role.AssumeRolePolicyDocument)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to convert the trust policy of role %s", role.RoleName)
+	}
+	tags := r.resourceTags(role.Tags)
+
+	out, err := r.IAM.GetRole(&iam.GetRoleInput{RoleName: aws.String(role.RoleName)})
+	switch {
+	case err != nil && !isNoSuchEntity(err):
+		return result, errors.Wrapf(err, "failed to get role %s", role.RoleName)
+	case err != nil && r.DryRun:
+		result.Status = ResourceMissing
+		return result, nil
+	case err != nil:
+		input := &iam.CreateRoleInput{
+			RoleName:                 aws.String(role.RoleName),
+			Path:                     aws.String(iamPath(role.Path)),
+			AssumeRolePolicyDocument: aws.String(trustPolicy),
+			Tags:                     iamTags(tags),
+		}
+		if role.PermissionsBoundary != "" {
+			input.PermissionsBoundary = aws.String(role.PermissionsBoundary)
+		}
+		if _, err := r.IAM.CreateRole(input); err != nil {
+			return result, errors.Wrapf(err, "failed to create role %s", role.RoleName)
+		}
+		result.Status = ResourceCreated
+	default:
+		current := out.Role
+		if path := aws.StringValue(current.Path); path != iamPath(role.Path) {
+			result.Drift = append(result.Drift, fmt.Sprintf("path is %s instead of %s, which requires recreating the role", path, iamPath(role.Path)))
+		}
+
+		equal, err := policyDocumentEqual(aws.StringValue(current.AssumeRolePolicyDocument), trustPolicy)
+		if err != nil {
+			return result, errors.Wrapf(err, "failed to compare the trust policy of role %s", role.RoleName)
+		}
+		if !equal {
+			result.Drift = append(result.Drift, "trust policy differs from the template")
+			if !r.DryRun {
+				if _, err := r.IAM.UpdateAssumeRolePolicy(&iam.UpdateAssumeRolePolicyInput{
+					RoleName:       aws.String(role.RoleName),
+					PolicyDocument: aws.String(trustPolicy),
+				}); err != nil {
+					return result, errors.Wrapf(err, "failed to update the trust policy of role %s", role.RoleName)
+				}
+			}
+		}
+
+		currentBoundary := ""
+		if current.PermissionsBoundary != nil {
+			currentBoundary = aws.StringValue(current.PermissionsBoundary.PermissionsBoundaryArn)
+		}
+		if currentBoundary != role.PermissionsBoundary {
+			result.Drift = append(result.Drift, fmt.Sprintf("permissions boundary is %q instead of %q", currentBoundary, role.PermissionsBoundary))
+			if !r.DryRun {
+				if role.PermissionsBoundary == "" {
+					_, err = r.IAM.DeleteRolePermissionsBoundary(&iam.DeleteRolePermissionsBoundaryInput{RoleName: aws.String(role.RoleName)})
+				} else {
+					_, err = r.IAM.PutRolePermissionsBoundary(&iam.PutRolePermissionsBoundaryInput{RoleName: aws.String(role.RoleName), PermissionsBoundary: aws.String(role.PermissionsBoundary)})
+				}
+				if err != nil {
+					return result, errors.Wrapf(err, "failed to update the permissions boundary of role %s", role.RoleName)
+				}
+			}
+		}
+
+		if missing, drift := missingTags(current.Tags, tags); len(missing) > 0 {
+			result.Drift = append(result.Drift, drift...)
+			if !r.DryRun {
+				if _, err := r.IAM.TagRole(&iam.TagRoleInput{RoleName: aws.String(role.RoleName), Tags: missing}); err != nil {
+					return result, errors.Wrapf(err, "failed to tag role %s", role.RoleName)
+				}
+			}
+		}
+	}
+
+	inline := make([]inlinePolicy, 0, len(role.Policies))
+	for _, policy := range role.Policies {
+		inline = append(inline, inlinePolicy{name: policy.PolicyName, document: policy.PolicyDocument})
+	}
+	drift, err := r.ensurePolicies(r.rolePrincipal(role.RoleName), inline, role.ManagedPolicyArns)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to reconcile the policies of role %s", role.RoleName)
+	}
+	result.Drift = append(result.Drift, drift...)
+
+	r.setStatus(&result)
+	return result, nil
+}
+
+func (r *reconciler) reconcileInstanceProfile(profile *cfn_iam.InstanceProfile) (ResourceResult, error) {
+	result := ResourceResult{Type: instanceProfileType, Name: profile.InstanceProfileName}
+	roles := make([]string, 0, len(profile.Roles))
+	for _, role := range profile.Roles {
+		roles = append(roles, r.resolve(role))
+	}
+
+	current := []string{}
+	out, err := r.IAM.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(profile.InstanceProfileName)})
+	switch {
+	case err != nil && !isNoSuchEntity(err):
+		return result, errors.Wrapf(err, "failed to get instance profile %s", profile.InstanceProfileName)
+	case err != nil && r.DryRun:
+		result.Status = ResourceMissing
+		return result, nil
+	case err != nil:
+		if _, err := r.IAM.CreateInstanceProfile(&iam.CreateInstanceProfileInput{
+			InstanceProfileName: aws.String(profile.InstanceProfileName),
+			Path:                aws.String(iamPath(profile.Path)),
+		}); err != nil {
+			return result, errors.Wrapf(err, "failed to create instance profile %s", profile.InstanceProfileName)
+		}
+		result.Status = ResourceCreated
+	default:
+		if path := aws.StringValue(out.InstanceProfile.Path); path != iamPath(profile.Path) {
+			result.Drift = append(result.Drift, fmt.Sprintf("path is %s instead of %s, which requires recreating the instance profile", path, iamPath(profile.Path)))
+		}
+		for _, role := range out.InstanceProfile.Roles {
+			current = append(current, aws.StringValue(role.RoleName))
+		}
+	}
+
+	// an instance profile has at most one role, so the roles not in the template are removed first.
+	for _, role := range current {
+		if contains(roles, role) {
+			continue
+		}
+		result.Drift = append(result.Drift, fmt.Sprintf("role %s isn't in the template", role))
+		if !r.DryRun {
+			if _, err := r.IAM.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+				InstanceProfileName: aws.String(profile.InstanceProfileName),
+				RoleName:            aws.String(role),
+			}); err != nil {
+				return result, errors.Wrapf(err, "failed to remove role %s from instance profile %s", role, profile.InstanceProfileName)
+			}
+		}
+	}
+	for _, role := range roles {
+		if contains(current, role) {
+			continue
+		}
+		result.Drift = append(result.Drift, fmt.Sprintf("role %s isn't in the instance profile", role))
+		if !r.DryRun {
+			if _, err := r.IAM.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+				InstanceProfileName: aws.String(profile.InstanceProfileName),
+				RoleName:            aws.String(role),
+			}); err != nil {
+				return result, errors.Wrapf(err, "failed to add role %s to instance profile %s", role, profile.InstanceProfileName)
+			}
+		}
+	}
+
+	r.setStatus(&result)
+	return result, nil
+}
+
+func (r *reconciler) reconcileUser(user *cfn_iam.User) (ResourceResult, error) {
+	result := ResourceResult{Type: userType, Name: user.UserName}
+	tags := r.resourceTags(user.Tags)
+
+	out, err := r.IAM.GetUser(&iam.GetUserInput{UserName: aws.String(user.UserName)})
+	switch {
+	case err != nil && !isNoSuchEntity(err):
+		return result, errors.Wrapf(err, "failed to get user %s", user.UserName)
+	case err != nil && r.DryRun:
+		result.Status = ResourceMissing
+		return result, nil
+	case err != nil:
+		input := &iam.CreateUserInput{
+			UserName: aws.String(user.UserName),
+			Path:     aws.String(iamPath(user.Path)),
+			Tags:     iamTags(tags),
+		}
+		if user.PermissionsBoundary != "" {
+			input.PermissionsBoundary = aws.String(user.PermissionsBoundary)
+		}
+		if _, err := r.IAM.CreateUser(input); err != nil {
+			return result, errors.Wrapf(err, "failed to create user %s", user.UserName)
+		}
+		result.Status = ResourceCreated
+	default:
+		current := out.User
+		if path := aws.StringValue(current.Path); path != iamPath(user.Path) {
+			result.Drift = append(result.Drift, fmt.Sprintf("path is %s instead of %s, which requires recreating the user", path, iamPath(user.Path)))
+		}
+
+		currentBoundary := ""
+		if current.PermissionsBoundary != nil {
+			currentBoundary = aws.StringValue(current.PermissionsBoundary.PermissionsBoundaryArn)
+		}
+		if currentBoundary != user.PermissionsBoundary {
+			result.Drift = append(result.Drift, fmt.Sprintf("permissions boundary is %q instead of %q", currentBoundary, user.PermissionsBoundary))
+			if !r.DryRun {
+				if user.PermissionsBoundary == "" {
+					_, err = r.IAM.DeleteUserPermissionsBoundary(&iam.DeleteUserPermissionsBoundaryInput{UserName: aws.String(user.UserName)})
+				} else {
+					_, err = r.IAM.PutUserPermissionsBoundary(&iam.PutUserPermissionsBoundaryInput{UserName: aws.String(user.UserName), PermissionsBoundary: aws.String(user.PermissionsBoundary)})
+				}
+				if err != nil {
+					return result, errors.Wrapf(err, "failed to update the permissions boundary of user %s", user.UserName)
+				}
+			}
+		}
+
+		if missing, drift := missingTags(current.Tags, tags); len(missing) > 0 {
+			result.Drift = append(result.Drift, drift...)
+			if !r.DryRun {
+				if _, err := r.IAM.TagUser(&iam.TagUserInput{UserName: aws.String(user.UserName), Tags: missing}); err != nil {
+					return result, errors.Wrapf(err, "failed to tag user %s", user.UserName)
+				}
+			}
+		}
+	}
+
+	groups := []string{}
+	if err := r.IAM.ListGroupsForUserPages(&iam.ListGroupsForUserInput{UserName: aws.String(user.UserName)}, func(page *iam.ListGroupsForUserOutput, _ bool) bool {
+		for _, group := range page.Groups {
+			groups = append(groups, aws.StringValue(group.GroupName))
+		}
+		return true
+	}); err != nil {
+		return result, errors.Wrapf(err, "failed to list the groups of user %s", user.UserName)
+	}
+	for _, group := range user.Groups {
+		group = r.resolve(group)
+		if contains(groups, group) {
+			continue
+		}
+		result.Drift = append(result.Drift, fmt.Sprintf("user isn't a member of group %s", group))
+		if !r.DryRun {
+			if _, err := r.IAM.AddUserToGroup(&iam.AddUserToGroupInput{UserName: aws.String(user.UserName), GroupName: aws.String(group)}); err != nil {
+				return result, errors.Wrapf(err, "failed to add user %s to group %s", user.UserName, group)
+			}
+		}
+	}
+
+	inline := make([]inlinePolicy, 0, len(user.Policies))
+	for _, policy := range user.Policies {
+		inline = append(inline, inlinePolicy{name: policy.PolicyName, document: policy.PolicyDocument})
+	}
+	drift, err := r.ensurePolicies(r.userPrincipal(user.UserName), inline, user.ManagedPolicyArns)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to reconcile the policies of user %s", user.UserName)
+	}
+	result.Drift = append(result.Drift, drift...)
+
+	r.setStatus(&result)
+	return result, nil
+}
+
+// ensurePolicies puts the inline policies of a principal and attaches its managed policies, and the managed
+// policies of the template attached to it, returning the drift found.
+func (r *reconciler) ensurePolicies(p principal, inline []inlinePolicy, policyARNs []string) ([]string, error) {
+	drift := []string{}
+
+	for _, policy := range inline {
+		document, err := policyDocumentJSON(policy.document)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert inline policy %s", policy.name)
+		}
+		current, err := p.getInline(policy.name)
+		switch {
+		case err != nil && !isNoSuchEntity(err):
+			return nil, errors.Wrapf(err, "failed to get inline policy %s", policy.name)
+		case err != nil:
+			drift = append(drift, fmt.Sprintf("inline policy %s is missing", policy.name))
+		default:
+			equal, err := policyDocumentEqual(current, document)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to compare inline policy %s", policy.name)
+			}
+			if equal {
+				continue
+			}
+			drift = append(drift, fmt.Sprintf("inline policy %s differs from the template", policy.name))
+		}
+		if !r.DryRun {
+			if err := p.putInline(policy.name, document); err != nil {
+				return nil, errors.Wrapf(err, "failed to put inline policy %s", policy.name)
+			}
+		}
+	}
+
+	names, err := p.listInline()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the inline policies")
+	}
+	for _, name := range names {
+		found := false
+		for _, policy := range inline {
+			found = found || policy.name == name
+		}
+		if !found {
+			drift = append(drift, fmt.Sprintf("inline policy %s isn't in the template", name))
+		}
+	}
+
+	desired := append(append([]string{}, policyARNs...), r.attachments[p.key]...)
+	attached, err := p.listAttached()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the attached managed policies")
+	}
+	for _, policyARN := range desired {
+		if contains(attached, policyARN) {
+			continue
+		}
+		drift = append(drift, fmt.Sprintf("managed policy %s isn't attached", policyARN))
+		if !r.DryRun {
+			if err := p.attach(policyARN); err != nil {
+				return nil, errors.Wrapf(err, "failed to attach managed policy %s", policyARN)
+			}
+		}
+	}
+	for _, policyARN := range attached {
+		if !contains(desired, policyARN) {
+			drift = append(drift, fmt.Sprintf("managed policy %s is attached but isn't in the template", policyARN))
+		}
+	}
+
+	return drift, nil
+}
+
+func (r *reconciler) rolePrincipal(name string) principal {
+	return principal{
+		key: "role/" + name,
+		getInline: func(policyName string) (string, error) {
+			out, err := r.IAM.GetRolePolicy(&iam.GetRolePolicyInput{RoleName: aws.String(name), PolicyName: aws.String(policyName)})
+			if err != nil {
+				return "", err
+			}
+			return aws.StringValue(out.PolicyDocument), nil
+		},
+		putInline: func(policyName, document string) error {
+			_, err := r.IAM.PutRolePolicy(&iam.PutRolePolicyInput{RoleName: aws.String(name), PolicyName: aws.String(policyName), PolicyDocument: aws.String(document)})
+			return err
+		},
+		listInline: func() ([]string, error) {
+			names := []string{}
+			err := r.IAM.ListRolePoliciesPages(&iam.ListRolePoliciesInput{RoleName: aws.String(name)}, func(page *iam.ListRolePoliciesOutput, _ bool) bool {
+				names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+				return true
+			})
+			return names, err
+		},
+		listAttached: func() ([]string, error) {
+			policyARNs := []string{}
+			err := r.IAM.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(name)}, func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+				for _, policy := range page.AttachedPolicies {
+					policyARNs = append(policyARNs, aws.StringValue(policy.PolicyArn))
+				}
+				return true
+			})
+			return policyARNs, err
+		},
+		attach: func(policyARN string) error {
+			_, err := r.IAM.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(name), PolicyArn: aws.String(policyARN)})
+			return err
+		},
+	}
+}
+
+func (r *reconciler) userPrincipal(name string) principal {
+	return principal{
+		key: "user/" + name,
+		getInline: func(policyName string) (string, error) {
+			out, err := r.IAM.GetUserPolicy(&iam.GetUserPolicyInput{UserName: aws.String(name), PolicyName: aws.String(policyName)})
+			if err != nil {
+				return "", err
+			}
+			return aws.StringValue(out.PolicyDocument), nil
+		},
+		putInline: func(policyName, document string) error {
+			_, err := r.IAM.PutUserPolicy(&iam.PutUserPolicyInput{UserName: aws.String(name), PolicyName: aws.String(policyName), PolicyDocument: aws.String(document)})
+			return err
+		},
+		listInline: func() ([]string, error) {
+			names := []string{}
+			err := r.IAM.ListUserPoliciesPages(&iam.ListUserPoliciesInput{UserName: aws.String(name)}, func(page *iam.ListUserPoliciesOutput, _ bool) bool {
+				names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+				return true
+			})
+			return names, err
+		},
+		listAttached: func() ([]string, error) {
+			policyARNs := []string{}
+			err := r.IAM.ListAttachedUserPoliciesPages(&iam.ListAttachedUserPoliciesInput{UserName: aws.String(name)}, func(page *iam.ListAttachedUserPoliciesOutput, _ bool) bool {
+				for _, policy := range page.AttachedPolicies {
+					policyARNs = append(policyARNs, aws.StringValue(policy.PolicyArn))
+				}
+				return true
+			})
+			return policyARNs, err
+		},
+		attach: func(policyARN string) error {
+			_, err := r.IAM.AttachUserPolicy(&iam.AttachUserPolicyInput{UserName: aws.String(name), PolicyArn: aws.String(policyARN)})
+			return err
+		},
+	}
+}
+
+func (r *reconciler) groupPrincipal(name string) principal {
+	return principal{
+		key: "group/" + name,
+		getInline: func(policyName string) (string, error) {
+			out, err := r.IAM.GetGroupPolicy(&iam.GetGroupPolicyInput{GroupName: aws.String(name), PolicyName: aws.String(policyName)})
+			if err != nil {
+				return "", err
+			}
+			return aws.StringValue(out.PolicyDocument), nil
+		},
+		putInline: func(policyName, document string) error {
+			_, err := r.IAM.PutGroupPolicy(&iam.PutGroupPolicyInput{GroupName: aws.String(name), PolicyName: aws.String(policyName), PolicyDocument: aws.String(document)})
+			return err
+		},
+		listInline: func() ([]string, error) {
+			names := []string{}
+			err := r.IAM.ListGroupPoliciesPages(&iam.ListGroupPoliciesInput{GroupName: aws.String(name)}, func(page *iam.ListGroupPoliciesOutput, _ bool) bool {
+				names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+				return true
+			})
+			return names, err
+		},
+		listAttached: func() ([]string, error) {
+			policyARNs := []string{}
+			err := r.IAM.ListAttachedGroupPoliciesPages(&iam.ListAttachedGroupPoliciesInput{GroupName: aws.String(name)}, func(page *iam.ListAttachedGroupPoliciesOutput, _ bool) bool {
+				for _, policy := range page.AttachedPolicies {
+					policyARNs = append(policyARNs, aws.StringValue(policy.PolicyArn))
+				}
+				return true
+			})
+			return policyARNs, err
+		},
+		attach: func(policyARN string) error {
+			_, err := r.IAM.AttachGroupPolicy(&iam.AttachGroupPolicyInput{GroupName: aws.String(name), PolicyArn: aws.String(policyARN)})
+			return err
+		},
+	}
+}
+
+// setStatus sets the status of a resource which already existed from its drift.
+func (r *reconciler) setStatus(result *ResourceResult) {
+	switch {
+	case result.Status != "":
+	case len(result.Drift) == 0:
+		result.Status = ResourceUpToDate
+	case r.DryRun:
+		result.Status = ResourceDrifted
+	default:
+		result.Status = ResourceUpdated
+	}
+}
+
+func (r *reconciler) attach(key, policyARN string) {
+	if !contains(r.attachments[key], policyARN) {
+		r.attachments[key] = append(r.attachments[key], policyARN)
+	}
+}
+
+// policyARN returns the ARN of a managed policy of the template.
+func (r *reconciler) policyARN(policy *cfn_iam.ManagedPolicy) string {
+	return fmt.Sprintf("arn:%s:iam::%s:policy%s%s", r.partition, r.account, iamPath(policy.Path), policy.ManagedPolicyName)
+}
+
+// resolve returns the name of the resource of the template referenced by a value, or the value if it isn't
+// a reference to a resource of the template.
+func (r *reconciler) resolve(value string) string {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	ref := struct {
+		Ref string `json:"Ref"`
+	}{}
+	if err := json.Unmarshal(decoded, &ref); err != nil || ref.Ref == "" {
+		return value
+	}
+
+	switch resource := r.template.Resources[ref.Ref].(type) {
+	case *cfn_iam.Role:
+		return resource.RoleName
+	case *cfn_iam.Group:
+		return resource.GroupName
+	case *cfn_iam.User:
+		return resource.UserName
+	case *cfn_iam.ManagedPolicy:
+		return r.policyARN(resource)
+	}
+	return value
+}
+
+// resourceTags returns the tags of a resource of the template, added to the tags of all the resources.
+func (r *reconciler) resourceTags(resourceTags []cfn_tags.Tag) map[string]string {
+	tags := map[string]string{}
+	for key, value := range r.tags {
+		tags[key] = value
+	}
+	for _, tag := range resourceTags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
+
+// missingTags returns the tags a resource doesn't have or has with another value, and the corresponding drift.
+func missingTags(current []*iam.Tag, desired map[string]string) ([]*iam.Tag, []string) {
+	currentTags := map[string]string{}
+	for _, tag := range current {
+		currentTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	missing := []*iam.Tag{}
+	drift := []string{}
+	for _, key := range sortedKeys(desired) {
+		value, ok := currentTags[key]
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("tag %s is missing", key))
+		case value != desired[key]:
+			drift = append(drift, fmt.Sprintf("tag %s is %q instead of %q", key, value, desired[key]))
+		default:
+			continue
+		}
+		missing = append(missing, &iam.Tag{Key: aws.String(key), Value: aws.String(desired[key])})
+	}
+	return missing, drift
+}
+
+func iamTags(tags map[string]string) []*iam.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+	missing, _ := missingTags(nil, tags)
+	return missing
+}
+
+// iamPath returns the path of a resource, defaulting to "/" like AWS IAM.
+func iamPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// policyDocumentJSON returns the JSON of a policy document of the template.
+func policyDocumentJSON(document interface{}) (string, error) {
+	b, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// policyDocumentEqual returns whether the URL encoded policy document returned by AWS IAM is semantically
+// the same as the JSON one of the template.
+func policyDocumentEqual(current, desired string) (bool, error) {
+	decoded, err := url.QueryUnescape(current)
+	if err != nil {
+		return false, errors.Wrap(err, "couldn't decode policy document")
+	}
+
+	var currentDocument, desiredDocument iamv1.PolicyDocument
+	if err := json.Unmarshal([]byte(decoded), &currentDocument); err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal policy document")
+	}
+	if err := json.Unmarshal([]byte(desired), &desiredDocument); err != nil {
+		return false, errors.Wrap(err, "couldn't unmarshal policy document")
+	}
+	return cmp.Equal(currentDocument, desiredDocument), nil
+}
+
+func isNoSuchEntity(err error) bool {
+	code, _ := awserrors.Code(err)
+	return code == iam.ErrCodeNoSuchEntityException
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
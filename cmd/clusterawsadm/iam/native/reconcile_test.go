@@ -0,0 +1,506 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package native
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/cloudformation/bootstrap"
+)
+
+const testAccount = "123456789012"
+
+func TestReconcileTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	template := bootstrap.NewTemplate()
+	template.Spec.BootstrapUser.Enable = true
+	cfnTemplate := *template.RenderCloudFormation()
+
+	fake := newFakeIAM()
+	s := NewService(fake, &fakeSTS{})
+
+	s.DryRun = true
+	report, err := s.ReconcileTemplate(cfnTemplate, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Resources).To(HaveLen(len(cfnTemplate.Resources)))
+	g.Expect(report.Resources).To(HaveEach(HaveField("Status", ResourceMissing)))
+	g.Expect(fake.writes).To(BeEmpty())
+
+	s.DryRun = false
+	report, err = s.ReconcileTemplate(cfnTemplate, map[string]string{"owner": "platform"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Resources).To(HaveEach(HaveField("Status", ResourceCreated)))
+
+	controllersPolicy := fmt.Sprintf("arn:aws:iam::%s:policy/controllers.cluster-api-provider-aws.sigs.k8s.io", testAccount)
+	g.Expect(fake.roles["control-plane.cluster-api-provider-aws.sigs.k8s.io"].attached).To(ContainElement(controllersPolicy))
+	g.Expect(fake.roles["nodes.cluster-api-provider-aws.sigs.k8s.io"].role.Tags).To(ContainElement(&iam.Tag{Key: aws.String("owner"), Value: aws.String("platform")}))
+	g.Expect(fake.groups["bootstrapper.cluster-api-provider-aws.sigs.k8s.io"].attached).To(ContainElement(controllersPolicy))
+	g.Expect(fake.users["bootstrapper.cluster-api-provider-aws.sigs.k8s.io"].groups).To(ConsistOf("bootstrapper.cluster-api-provider-aws.sigs.k8s.io"))
+	g.Expect(fake.profiles["nodes.cluster-api-provider-aws.sigs.k8s.io"].Roles).To(HaveLen(1))
+
+	fake.writes = nil
+	report, err = s.ReconcileTemplate(cfnTemplate, map[string]string{"owner": "platform"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Resources).To(HaveEach(HaveField("Status", ResourceUpToDate)))
+	g.Expect(fake.writes).To(BeEmpty())
+}
+
+func TestReconcileTemplateDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	cfnTemplate := *bootstrap.NewTemplate().RenderCloudFormation()
+	fake := newFakeIAM()
+	s := NewService(fake, &fakeSTS{})
+	_, err := s.ReconcileTemplate(cfnTemplate, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	controlPlane := "control-plane.cluster-api-provider-aws.sigs.k8s.io"
+	controllersPolicy := fmt.Sprintf("arn:aws:iam::%s:policy/controllers.cluster-api-provider-aws.sigs.k8s.io", testAccount)
+	fake.policies[controllersPolicy].setDocument(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["ec2:DescribeInstances"],"Resource":["*"]}]}`)
+	fake.roles[controlPlane].attached = []string{"arn:aws:iam::aws:policy/AdministratorAccess"}
+	fake.roles[controlPlane].role.AssumeRolePolicyDocument = aws.String(url.QueryEscape(`{"Version":"2012-10-17","Statement":[]}`))
+	fake.roles[controlPlane].inline["extra"] = url.QueryEscape(`{"Version":"2012-10-17","Statement":[]}`)
+	fake.profiles[controlPlane].Roles = []*iam.Role{{RoleName: aws.String("other")}}
+	fake.writes = nil
+
+	s.DryRun = true
+	report, err := s.ReconcileTemplate(cfnTemplate, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fake.writes).To(BeEmpty())
+	g.Expect(result(report, managedPolicyType, "controllers.cluster-api-provider-aws.sigs.k8s.io")).To(And(
+		HaveField("Status", ResourceDrifted),
+		HaveField("Drift", ConsistOf("document of the default version v2 differs from the template")),
+	))
+	g.Expect(result(report, roleType, controlPlane)).To(And(
+		HaveField("Status", ResourceDrifted),
+		HaveField("Drift", ContainElements(
+			"trust policy differs from the template",
+			"inline policy extra isn't in the template",
+			fmt.Sprintf("managed policy %s isn't attached", controllersPolicy),
+			"managed policy arn:aws:iam::aws:policy/AdministratorAccess is attached but isn't in the template",
+		)),
+	))
+	g.Expect(result(report, instanceProfileType, controlPlane)).To(HaveField("Drift", ConsistOf(
+		"role other isn't in the template",
+		fmt.Sprintf("role %s isn't in the instance profile", controlPlane),
+	)))
+
+	s.DryRun = false
+	report, err = s.ReconcileTemplate(cfnTemplate, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result(report, managedPolicyType, "controllers.cluster-api-provider-aws.sigs.k8s.io")).To(HaveField("Status", ResourceUpdated))
+	g.Expect(result(report, roleType, controlPlane)).To(HaveField("Status", ResourceUpdated))
+
+	// the attachments and inline policies not in the template are only reported.
+	report, err = s.ReconcileTemplate(cfnTemplate, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result(report, managedPolicyType, "controllers.cluster-api-provider-aws.sigs.k8s.io")).To(HaveField("Status", ResourceUpToDate))
+	g.Expect(result(report, instanceProfileType, controlPlane)).To(HaveField("Status", ResourceUpToDate))
+	g.Expect(result(report, roleType, controlPlane)).To(HaveField("Drift", ConsistOf(
+		"inline policy extra isn't in the template",
+		"managed policy arn:aws:iam::aws:policy/AdministratorAccess is attached but isn't in the template",
+	)))
+}
+
+func TestCreatePolicyVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	fake := newFakeIAM()
+	policyARN := fmt.Sprintf("arn:aws:iam::%s:policy/test", testAccount)
+	policy := &fakePolicy{policy: &iam.Policy{Arn: aws.String(policyARN)}}
+	for i := 0; i < maxPolicyVersions; i++ {
+		policy.setDocument("{}")
+	}
+	fake.policies[policyARN] = policy
+
+	r := &reconciler{Service: NewService(fake, &fakeSTS{})}
+	g.Expect(r.createPolicyVersion(policyARN, "{}")).To(Succeed())
+
+	versions := []string{}
+	for _, version := range policy.versions {
+		versions = append(versions, aws.StringValue(version.VersionId))
+	}
+	g.Expect(versions).To(Equal([]string{"v2", "v3", "v4", "v5", "v6"}))
+	g.Expect(policy.policy.DefaultVersionId).To(Equal(aws.String("v6")))
+}
+
+func result(report *Report, resourceType, name string) ResourceResult {
+	for _, r := range report.Resources {
+		if r.Type == resourceType && r.Name == name {
+			return r
+		}
+	}
+	return ResourceResult{}
+}
+
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (f *fakeSTS) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(testAccount),
+		Arn:     aws.String(fmt.Sprintf("arn:aws:iam::%s:user/admin", testAccount)),
+	}, nil
+}
+
+type fakePrincipal struct {
+	inline   map[string]string
+	attached []string
+}
+
+type fakeRole struct {
+	fakePrincipal
+	role *iam.Role
+}
+
+type fakeUser struct {
+	fakePrincipal
+	user   *iam.User
+	groups []string
+}
+
+type fakeGroup struct {
+	fakePrincipal
+	group *iam.Group
+}
+
+type fakePolicy struct {
+	policy   *iam.Policy
+	versions []*iam.PolicyVersion
+	created  int
+}
+
+func (p *fakePolicy) setDocument(document string) {
+	p.created++
+	id := fmt.Sprintf("v%d", p.created)
+	for _, version := range p.versions {
+		version.IsDefaultVersion = aws.Bool(false)
+	}
+	p.versions = append(p.versions, &iam.PolicyVersion{
+		VersionId:        aws.String(id),
+		Document:         aws.String(url.QueryEscape(document)),
+		IsDefaultVersion: aws.Bool(true),
+		CreateDate:       aws.Time(time.Unix(int64(p.created), 0)),
+	})
+	p.policy.DefaultVersionId = aws.String(id)
+}
+
+// fakeIAM is an in-memory AWS IAM, recording the calls changing the resources.
+type fakeIAM struct {
+	iamiface.IAMAPI
+
+	roles    map[string]*fakeRole
+	users    map[string]*fakeUser
+	groups   map[string]*fakeGroup
+	profiles map[string]*iam.InstanceProfile
+	policies map[string]*fakePolicy
+	writes   []string
+}
+
+func newFakeIAM() *fakeIAM {
+	return &fakeIAM{
+		roles:    map[string]*fakeRole{},
+		users:    map[string]*fakeUser{},
+		groups:   map[string]*fakeGroup{},
+		profiles: map[string]*iam.InstanceProfile{},
+		policies: map[string]*fakePolicy{},
+	}
+}
+
+var errNoSuchEntity = awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+
+func (f *fakeIAM) write(format string, args ...interface{}) {
+	f.writes = append(f.writes, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeIAM) GetPolicy(input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	p, ok := f.policies[aws.StringValue(input.PolicyArn)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetPolicyOutput{Policy: p.policy}, nil
+}
+
+func (f *fakeIAM) CreatePolicy(input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+	f.write("CreatePolicy %s", aws.StringValue(input.PolicyName))
+	policyARN := fmt.Sprintf("arn:aws:iam::%s:policy%s%s", testAccount, aws.StringValue(input.Path), aws.StringValue(input.PolicyName))
+	p := &fakePolicy{policy: &iam.Policy{Arn: aws.String(policyARN)}}
+	p.setDocument(aws.StringValue(input.PolicyDocument))
+	f.policies[policyARN] = p
+	return &iam.CreatePolicyOutput{Policy: p.policy}, nil
+}
+
+func (f *fakeIAM) GetPolicyVersion(input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	for _, version := range f.policies[aws.StringValue(input.PolicyArn)].versions {
+		if aws.StringValue(version.VersionId) == aws.StringValue(input.VersionId) {
+			return &iam.GetPolicyVersionOutput{PolicyVersion: version}, nil
+		}
+	}
+	return nil, errNoSuchEntity
+}
+
+func (f *fakeIAM) ListPolicyVersions(input *iam.ListPolicyVersionsInput) (*iam.ListPolicyVersionsOutput, error) {
+	return &iam.ListPolicyVersionsOutput{Versions: f.policies[aws.StringValue(input.PolicyArn)].versions}, nil
+}
+
+func (f *fakeIAM) DeletePolicyVersion(input *iam.DeletePolicyVersionInput) (*iam.DeletePolicyVersionOutput, error) {
+	f.write("DeletePolicyVersion %s", aws.StringValue(input.VersionId))
+	p := f.policies[aws.StringValue(input.PolicyArn)]
+	versions := []*iam.PolicyVersion{}
+	for _, version := range p.versions {
+		if aws.StringValue(version.VersionId) != aws.StringValue(input.VersionId) {
+			versions = append(versions, version)
+		}
+	}
+	p.versions = versions
+	return &iam.DeletePolicyVersionOutput{}, nil
+}
+
+func (f *fakeIAM) CreatePolicyVersion(input *iam.CreatePolicyVersionInput) (*iam.CreatePolicyVersionOutput, error) {
+	f.write("CreatePolicyVersion %s", aws.StringValue(input.PolicyArn))
+	p := f.policies[aws.StringValue(input.PolicyArn)]
+	if len(p.versions) >= maxPolicyVersions {
+		return nil, awserr.New(iam.ErrCodeLimitExceededException, "too many versions", nil)
+	}
+	p.setDocument(aws.StringValue(input.PolicyDocument))
+	return &iam.CreatePolicyVersionOutput{}, nil
+}
+
+func (f *fakeIAM) GetGroup(input *iam.GetGroupInput) (*iam.GetGroupOutput, error) {
+	group, ok := f.groups[aws.StringValue(input.GroupName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetGroupOutput{Group: group.group}, nil
+}
+
+func (f *fakeIAM) CreateGroup(input *iam.CreateGroupInput) (*iam.CreateGroupOutput, error) {
+	f.write("CreateGroup %s", aws.StringValue(input.GroupName))
+	f.groups[aws.StringValue(input.GroupName)] = &fakeGroup{
+		fakePrincipal: fakePrincipal{inline: map[string]string{}},
+		group:         &iam.Group{GroupName: input.GroupName, Path: input.Path},
+	}
+	return &iam.CreateGroupOutput{}, nil
+}
+
+func (f *fakeIAM) GetRole(input *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	role, ok := f.roles[aws.StringValue(input.RoleName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetRoleOutput{Role: role.role}, nil
+}
+
+func (f *fakeIAM) CreateRole(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+	f.write("CreateRole %s", aws.StringValue(input.RoleName))
+	role := &iam.Role{
+		RoleName:                 input.RoleName,
+		Path:                     input.Path,
+		AssumeRolePolicyDocument: aws.String(url.QueryEscape(aws.StringValue(input.AssumeRolePolicyDocument))),
+		Tags:                     input.Tags,
+	}
+	if input.PermissionsBoundary != nil {
+		role.PermissionsBoundary = &iam.AttachedPermissionsBoundary{PermissionsBoundaryArn: input.PermissionsBoundary}
+	}
+	f.roles[aws.StringValue(input.RoleName)] = &fakeRole{fakePrincipal: fakePrincipal{inline: map[string]string{}}, role: role}
+	return &iam.CreateRoleOutput{Role: role}, nil
+}
+
+func (f *fakeIAM) UpdateAssumeRolePolicy(input *iam.UpdateAssumeRolePolicyInput) (*iam.UpdateAssumeRolePolicyOutput, error) {
+	f.write("UpdateAssumeRolePolicy %s", aws.StringValue(input.RoleName))
+	f.roles[aws.StringValue(input.RoleName)].role.AssumeRolePolicyDocument = aws.String(url.QueryEscape(aws.StringValue(input.PolicyDocument)))
+	return &iam.UpdateAssumeRolePolicyOutput{}, nil
+}
+
+func (f *fakeIAM) TagRole(input *iam.TagRoleInput) (*iam.TagRoleOutput, error) {
+	f.write("TagRole %s", aws.StringValue(input.RoleName))
+	role := f.roles[aws.StringValue(input.RoleName)].role
+	role.Tags = append(role.Tags, input.Tags...)
+	return &iam.TagRoleOutput{}, nil
+}
+
+func (f *fakeIAM) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	document, ok := f.roles[aws.StringValue(input.RoleName)].inline[aws.StringValue(input.PolicyName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetRolePolicyOutput{PolicyDocument: aws.String(document)}, nil
+}
+
+func (f *fakeIAM) PutRolePolicy(input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+	f.write("PutRolePolicy %s %s", aws.StringValue(input.RoleName), aws.StringValue(input.PolicyName))
+	f.roles[aws.StringValue(input.RoleName)].inline[aws.StringValue(input.PolicyName)] = url.QueryEscape(aws.StringValue(input.PolicyDocument))
+	return &iam.PutRolePolicyOutput{}, nil
+}
+
+func (f *fakeIAM) ListRolePoliciesPages(input *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+	fn(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice(inlineNames(f.roles[aws.StringValue(input.RoleName)].inline))}, true)
+	return nil
+}
+
+func (f *fakeIAM) ListAttachedRolePoliciesPages(input *iam.ListAttachedRolePoliciesInput, fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool) error {
+	fn(&iam.ListAttachedRolePoliciesOutput{AttachedPolicies: attachedPolicies(f.roles[aws.StringValue(input.RoleName)].attached)}, true)
+	return nil
+}
+
+func (f *fakeIAM) AttachRolePolicy(input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
+	f.write("AttachRolePolicy %s %s", aws.StringValue(input.RoleName), aws.StringValue(input.PolicyArn))
+	role := f.roles[aws.StringValue(input.RoleName)]
+	role.attached = append(role.attached, aws.StringValue(input.PolicyArn))
+	return &iam.AttachRolePolicyOutput{}, nil
+}
+
+func (f *fakeIAM) GetInstanceProfile(input *iam.GetInstanceProfileInput) (*iam.GetInstanceProfileOutput, error) {
+	profile, ok := f.profiles[aws.StringValue(input.InstanceProfileName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetInstanceProfileOutput{InstanceProfile: profile}, nil
+}
+
+func (f *fakeIAM) CreateInstanceProfile(input *iam.CreateInstanceProfileInput) (*iam.CreateInstanceProfileOutput, error) {
+	f.write("CreateInstanceProfile %s", aws.StringValue(input.InstanceProfileName))
+	f.profiles[aws.StringValue(input.InstanceProfileName)] = &iam.InstanceProfile{InstanceProfileName: input.InstanceProfileName, Path: input.Path}
+	return &iam.CreateInstanceProfileOutput{}, nil
+}
+
+func (f *fakeIAM) AddRoleToInstanceProfile(input *iam.AddRoleToInstanceProfileInput) (*iam.AddRoleToInstanceProfileOutput, error) {
+	f.write("AddRoleToInstanceProfile %s %s", aws.StringValue(input.InstanceProfileName), aws.StringValue(input.RoleName))
+	profile := f.profiles[aws.StringValue(input.InstanceProfileName)]
+	if len(profile.Roles) > 0 {
+		return nil, awserr.New(iam.ErrCodeLimitExceededException, "an instance profile has at most one role", nil)
+	}
+	profile.Roles = append(profile.Roles, &iam.Role{RoleName: input.RoleName})
+	return &iam.AddRoleToInstanceProfileOutput{}, nil
+}
+
+func (f *fakeIAM) RemoveRoleFromInstanceProfile(input *iam.RemoveRoleFromInstanceProfileInput) (*iam.RemoveRoleFromInstanceProfileOutput, error) {
+	f.write("RemoveRoleFromInstanceProfile %s %s", aws.StringValue(input.InstanceProfileName), aws.StringValue(input.RoleName))
+	profile := f.profiles[aws.StringValue(input.InstanceProfileName)]
+	roles := []*iam.Role{}
+	for _, role := range profile.Roles {
+		if aws.StringValue(role.RoleName) != aws.StringValue(input.RoleName) {
+			roles = append(roles, role)
+		}
+	}
+	profile.Roles = roles
+	return &iam.RemoveRoleFromInstanceProfileOutput{}, nil
+}
+
+func (f *fakeIAM) GetUser(input *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	user, ok := f.users[aws.StringValue(input.UserName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetUserOutput{User: user.user}, nil
+}
+
+func (f *fakeIAM) CreateUser(input *iam.CreateUserInput) (*iam.CreateUserOutput, error) {
+	f.write("CreateUser %s", aws.StringValue(input.UserName))
+	user := &iam.User{UserName: input.UserName, Path: input.Path, Tags: input.Tags}
+	f.users[aws.StringValue(input.UserName)] = &fakeUser{fakePrincipal: fakePrincipal{inline: map[string]string{}}, user: user}
+	return &iam.CreateUserOutput{User: user}, nil
+}
+
+func (f *fakeIAM) ListGroupsForUserPages(input *iam.ListGroupsForUserInput, fn func(*iam.ListGroupsForUserOutput, bool) bool) error {
+	groups := []*iam.Group{}
+	for _, group := range f.users[aws.StringValue(input.UserName)].groups {
+		groups = append(groups, &iam.Group{GroupName: aws.String(group)})
+	}
+	fn(&iam.ListGroupsForUserOutput{Groups: groups}, true)
+	return nil
+}
+
+func (f *fakeIAM) AddUserToGroup(input *iam.AddUserToGroupInput) (*iam.AddUserToGroupOutput, error) {
+	f.write("AddUserToGroup %s %s", aws.StringValue(input.UserName), aws.StringValue(input.GroupName))
+	user := f.users[aws.StringValue(input.UserName)]
+	user.groups = append(user.groups, aws.StringValue(input.GroupName))
+	return &iam.AddUserToGroupOutput{}, nil
+}
+
+func (f *fakeIAM) ListUserPoliciesPages(input *iam.ListUserPoliciesInput, fn func(*iam.ListUserPoliciesOutput, bool) bool) error {
+	fn(&iam.ListUserPoliciesOutput{PolicyNames: aws.StringSlice(inlineNames(f.users[aws.StringValue(input.UserName)].inline))}, true)
+	return nil
+}
+
+func (f *fakeIAM) ListAttachedUserPoliciesPages(input *iam.ListAttachedUserPoliciesInput, fn func(*iam.ListAttachedUserPoliciesOutput, bool) bool) error {
+	fn(&iam.ListAttachedUserPoliciesOutput{AttachedPolicies: attachedPolicies(f.users[aws.StringValue(input.UserName)].attached)}, true)
+	return nil
+}
+
+func (f *fakeIAM) ListGroupPoliciesPages(input *iam.ListGroupPoliciesInput, fn func(*iam.ListGroupPoliciesOutput, bool) bool) error {
+	fn(&iam.ListGroupPoliciesOutput{PolicyNames: aws.StringSlice(inlineNames(f.groups[aws.StringValue(input.GroupName)].inline))}, true)
+	return nil
+}
+
+func (f *fakeIAM) ListAttachedGroupPoliciesPages(input *iam.ListAttachedGroupPoliciesInput, fn func(*iam.ListAttachedGroupPoliciesOutput, bool) bool) error {
+	fn(&iam.ListAttachedGroupPoliciesOutput{AttachedPolicies: attachedPolicies(f.groups[aws.StringValue(input.GroupName)].attached)}, true)
+	return nil
+}
+
+func (f *fakeIAM) AttachGroupPolicy(input *iam.AttachGroupPolicyInput) (*iam.AttachGroupPolicyOutput, error) {
+	f.write("AttachGroupPolicy %s %s", aws.StringValue(input.GroupName), aws.StringValue(input.PolicyArn))
+	group := f.groups[aws.StringValue(input.GroupName)]
+	group.attached = append(group.attached, aws.StringValue(input.PolicyArn))
+	return &iam.AttachGroupPolicyOutput{}, nil
+}
+
+func (f *fakeIAM) AttachUserPolicy(input *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error) {
+	f.write("AttachUserPolicy %s %s", aws.StringValue(input.UserName), aws.StringValue(input.PolicyArn))
+	user := f.users[aws.StringValue(input.UserName)]
+	user.attached = append(user.attached, aws.StringValue(input.PolicyArn))
+	return &iam.AttachUserPolicyOutput{}, nil
+}
+
+func (f *fakeIAM) PutUserPolicy(input *iam.PutUserPolicyInput) (*iam.PutUserPolicyOutput, error) {
+	f.write("PutUserPolicy %s %s", aws.StringValue(input.UserName), aws.StringValue(input.PolicyName))
+	f.users[aws.StringValue(input.UserName)].inline[aws.StringValue(input.PolicyName)] = url.QueryEscape(aws.StringValue(input.PolicyDocument))
+	return &iam.PutUserPolicyOutput{}, nil
+}
+
+func (f *fakeIAM) GetUserPolicy(input *iam.GetUserPolicyInput) (*iam.GetUserPolicyOutput, error) {
+	document, ok := f.users[aws.StringValue(input.UserName)].inline[aws.StringValue(input.PolicyName)]
+	if !ok {
+		return nil, errNoSuchEntity
+	}
+	return &iam.GetUserPolicyOutput{PolicyDocument: aws.String(document)}, nil
+}
+
+func inlineNames(inline map[string]string) []string {
+	return sortedKeys(inline)
+}
+
+func attachedPolicies(policyARNs []string) []*iam.AttachedPolicy {
+	policies := []*iam.AttachedPolicy{}
+	for _, policyARN := range policyARNs {
+		policies = append(policies, &iam.AttachedPolicy{PolicyArn: aws.String(policyARN), PolicyName: aws.String(policyARN[strings.LastIndex(policyARN, "/")+1:])})
+	}
+	return policies
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package native provides a way to create and update the AWS IAM resources of a bootstrap
+// AWS CloudFormation template with the AWS IAM API, without AWS CloudFormation.
+package native
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+// ResourceStatus is the outcome of the reconciliation of an AWS IAM resource.
+type ResourceStatus string
+
+const (
+	// ResourceCreated is the status of the resources which didn't exist and were created.
+	ResourceCreated = ResourceStatus("created")
+	// ResourceUpdated is the status of the resources which drifted from the template and were updated.
+	ResourceUpdated = ResourceStatus("updated")
+	// ResourceUpToDate is the status of the resources matching the template.
+	ResourceUpToDate = ResourceStatus("up to date")
+	// ResourceMissing is the status of the resources which don't exist, in a dry run.
+	ResourceMissing = ResourceStatus("missing")
+	// ResourceDrifted is the status of the resources which drifted from the template, in a dry run.
+	ResourceDrifted = ResourceStatus("drifted")
+)
+
+// ResourceResult is the outcome of the reconciliation of an AWS IAM resource.
+type ResourceResult struct {
+	// Type is the AWS CloudFormation type of the resource, e.g. AWS::IAM::Role.
+	Type string
+	// Name is the name of the resource.
+	Name   string
+	Status ResourceStatus
+	// Drift are the differences found between the resource and the template.
+	Drift []string
+}
+
+// Report is the outcome of the reconciliation of the AWS IAM resources of a template.
+type Report struct {
+	Resources []ResourceResult
+}
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	IAM iamiface.IAMAPI
+	STS stsiface.STSAPI
+	// DryRun only reports the drift of the resources, without creating or updating them.
+	DryRun bool
+}
+
+// NewService returns a new service given the AWS IAM and AWS STS api clients.
+func NewService(iamClient iamiface.IAMAPI, stsClient stsiface.STSAPI) *Service {
+	return &Service{
+		IAM: iamClient,
+		STS: stsClient,
+	}
+}
+
+// PrintReport prints out in tabular format the status of the resources, and their drift.
+func PrintReport(w io.Writer, r *Report) {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.Debug)
+
+	fmt.Fprintln(tw, "Resource\tName\tStatus")
+	for _, resource := range r.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", resource.Type, resource.Name, resource.Status)
+	}
+	tw.Flush()
+
+	for _, resource := range r.Resources {
+		if len(resource.Drift) == 0 || resource.Status == ResourceCreated || resource.Status == ResourceMissing {
+			continue
+		}
+		fmt.Fprintf(w, "\nDrift of %s %s:\n", resource.Type, resource.Name)
+		for _, drift := range resource.Drift {
+			fmt.Fprintf(w, "  %s\n", drift)
+		}
+	}
+}
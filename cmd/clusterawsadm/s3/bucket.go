@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package s3 provides a way to create and validate the S3 bucket storing the bootstrap data of a cluster
+// before the cluster is created, with the configuration the controller expects.
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	s3service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
+)
+
+const (
+	errCodeNoSuchPublicAccessBlock = "NoSuchPublicAccessBlockConfiguration"
+	errCodeNoSuchBucketPolicy      = "NoSuchBucketPolicy"
+	errCodeNoSuchEncryption        = "ServerSideEncryptionConfigurationNotFoundError"
+	errCodeNoSuchLifecycle         = "NoSuchLifecycleConfiguration"
+	errCodeNotFound                = "NotFound"
+)
+
+// BootstrapBucketInput is the configuration of the bootstrap data bucket of a cluster.
+type BootstrapBucketInput struct {
+	// ClusterName is the name of the cluster the bucket is tagged with.
+	ClusterName string
+	// Region is the region of the bucket, which must be the region of the cluster.
+	Region string
+	// Bucket is the S3 bucket of the AWSCluster.
+	Bucket infrav1.S3Bucket
+	// OIDCDiscovery allows public read access to the OIDC discovery documents of the cluster.
+	OIDCDiscovery bool
+	// AdditionalTags are the additional tags of the AWSCluster.
+	AdditionalTags infrav1.Tags
+}
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	S3  s3iface.S3API
+	STS stsiface.STSAPI
+}
+
+// NewService returns a new service given the AWS S3 and AWS STS api clients.
+func NewService(s3Client s3iface.S3API, stsClient stsiface.STSAPI) *Service {
+	return &Service{
+		S3:  s3Client,
+		STS: stsClient,
+	}
+}
+
+// CreateBootstrapBucket creates the bootstrap data bucket if it doesn't exist, and configures its public
+// access block, tags, encryption, lifecycle and policy the way the controller does.
+func (s *Service) CreateBootstrapBucket(input *BootstrapBucketInput) error {
+	bucketName := input.Bucket.Name
+
+	createInput := &s3.CreateBucketInput{Bucket: aws.String(bucketName)}
+	if input.Region != s3service.AWSDefaultRegion {
+		createInput.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(input.Region),
+		}
+	}
+	if _, err := s.S3.CreateBucket(createInput); err != nil {
+		if code, _ := awserrors.Code(err); code != s3.ErrCodeBucketAlreadyOwnedByYou {
+			return errors.Wrap(err, "creating S3 bucket")
+		}
+	}
+
+	if _, err := s.S3.PutPublicAccessBlock(&s3.PutPublicAccessBlockInput{
+		Bucket:                         aws.String(bucketName),
+		PublicAccessBlockConfiguration: s3service.PublicAccessBlock(input.OIDCDiscovery),
+	}); err != nil {
+		return errors.Wrap(err, "putting public access block")
+	}
+
+	if _, err := s.S3.PutBucketTagging(&s3.PutBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+		Tagging: &s3.Tagging{
+			TagSet: s3service.BucketTags(input.ClusterName, input.AdditionalTags),
+		},
+	}); err != nil {
+		return errors.Wrap(err, "tagging bucket")
+	}
+
+	if input.Bucket.KMSKeyARN != "" {
+		if _, err := s.S3.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+			Bucket:                            aws.String(bucketName),
+			ServerSideEncryptionConfiguration: s3service.BucketEncryption(input.Bucket.KMSKeyARN),
+		}); err != nil {
+			return errors.Wrap(err, "putting bucket encryption")
+		}
+	}
+
+	if input.Bucket.BootstrapDataExpirationDays != nil {
+		if _, err := s.S3.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+			Bucket:                 aws.String(bucketName),
+			LifecycleConfiguration: s3service.BucketLifecycle(*input.Bucket.BootstrapDataExpirationDays),
+		}); err != nil {
+			return errors.Wrap(err, "putting bucket lifecycle configuration")
+		}
+	}
+
+	policy, err := s.bucketPolicy(input)
+	if err != nil {
+		return err
+	}
+	if _, err := s.S3.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(policy),
+	}); err != nil {
+		return errors.Wrap(err, "creating S3 bucket policy")
+	}
+
+	return nil
+}
+
+// ValidateBootstrapBucket checks that an existing bucket is configured the way the controller expects, and
+// returns the problems found.
+func (s *Service) ValidateBootstrapBucket(input *BootstrapBucketInput) ([]string, error) {
+	bucketName := input.Bucket.Name
+
+	if _, err := s.S3.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucketName)}); err != nil {
+		if code, _ := awserrors.Code(err); code == errCodeNotFound || code == s3.ErrCodeNoSuchBucket {
+			return []string{fmt.Sprintf("bucket %s doesn't exist", bucketName)}, nil
+		}
+		return nil, errors.Wrap(err, "getting S3 bucket")
+	}
+
+	problems := []string{}
+	validations := []func(*BootstrapBucketInput) ([]string, error){
+		s.validateLocation,
+		s.validatePublicAccessBlock,
+		s.validateEncryption,
+		s.validateLifecycle,
+		s.validatePolicy,
+	}
+	for _, validate := range validations {
+		found, err := validate(input)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, found...)
+	}
+
+	return problems, nil
+}
+
+func (s *Service) validateLocation(input *BootstrapBucketInput) ([]string, error) {
+	out, err := s.S3.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: aws.String(input.Bucket.Name)})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting bucket location")
+	}
+
+	if region := s3.NormalizeBucketLocation(aws.StringValue(out.LocationConstraint)); region != input.Region {
+		return []string{fmt.Sprintf("bucket is in region %s instead of %s", region, input.Region)}, nil
+	}
+	return nil, nil
+}
+
+func (s *Service) validatePublicAccessBlock(input *BootstrapBucketInput) ([]string, error) {
+	out, err := s.S3.GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{Bucket: aws.String(input.Bucket.Name)})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == errCodeNoSuchPublicAccessBlock {
+			return []string{"bucket has no public access block"}, nil
+		}
+		return nil, errors.Wrap(err, "getting public access block")
+	}
+
+	expected := s3service.PublicAccessBlock(input.OIDCDiscovery)
+	actual := out.PublicAccessBlockConfiguration
+	problems := []string{}
+	settings := []struct {
+		name             string
+		expected, actual *bool
+	}{
+		{"BlockPublicAcls", expected.BlockPublicAcls, actual.BlockPublicAcls},
+		{"IgnorePublicAcls", expected.IgnorePublicAcls, actual.IgnorePublicAcls},
+		{"BlockPublicPolicy", expected.BlockPublicPolicy, actual.BlockPublicPolicy},
+		{"RestrictPublicBuckets", expected.RestrictPublicBuckets, actual.RestrictPublicBuckets},
+	}
+	for _, setting := range settings {
+		if aws.BoolValue(setting.actual) != aws.BoolValue(setting.expected) {
+			problems = append(problems, fmt.Sprintf("public access block has %s %t instead of %t", setting.name, aws.BoolValue(setting.actual), aws.BoolValue(setting.expected)))
+		}
+	}
+	return problems, nil
+}
+
+func (s *Service) validateEncryption(input *BootstrapBucketInput) ([]string, error) {
+	if input.Bucket.KMSKeyARN == "" {
+		return nil, nil
+	}
+
+	out, err := s.S3.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: aws.String(input.Bucket.Name)})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == errCodeNoSuchEncryption {
+			return []string{"bucket has no default encryption"}, nil
+		}
+		return nil, errors.Wrap(err, "getting bucket encryption")
+	}
+
+	for _, rule := range out.ServerSideEncryptionConfiguration.Rules {
+		sse := rule.ApplyServerSideEncryptionByDefault
+		if sse != nil && aws.StringValue(sse.SSEAlgorithm) == s3.ServerSideEncryptionAwsKms && aws.StringValue(sse.KMSMasterKeyID) == input.Bucket.KMSKeyARN {
+			return nil, nil
+		}
+	}
+	return []string{fmt.Sprintf("bucket isn't encrypted by default with KMS key %s", input.Bucket.KMSKeyARN)}, nil
+}
+
+func (s *Service) validateLifecycle(input *BootstrapBucketInput) ([]string, error) {
+	if input.Bucket.BootstrapDataExpirationDays == nil {
+		return nil, nil
+	}
+
+	existing := map[string]*s3.LifecycleRule{}
+	out, err := s.S3.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(input.Bucket.Name)})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code != errCodeNoSuchLifecycle {
+			return nil, errors.Wrap(err, "getting bucket lifecycle configuration")
+		}
+	} else {
+		for _, rule := range out.Rules {
+			existing[aws.StringValue(rule.ID)] = rule
+		}
+	}
+
+	problems := []string{}
+	for _, rule := range s3service.BucketLifecycle(*input.Bucket.BootstrapDataExpirationDays).Rules {
+		id := aws.StringValue(rule.ID)
+		actual, ok := existing[id]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("bucket has no lifecycle rule %s", id))
+		case aws.StringValue(actual.Status) != s3.ExpirationStatusEnabled || actual.Expiration == nil ||
+			aws.Int64Value(actual.Expiration.Days) != aws.Int64Value(rule.Expiration.Days):
+			problems = append(problems, fmt.Sprintf("lifecycle rule %s doesn't expire objects after %d days", id, aws.Int64Value(rule.Expiration.Days)))
+		}
+	}
+	return problems, nil
+}
+
+// validatePolicy checks that the bucket policy has the statements of the controller. The statements are
+// only compared by their Sid, as S3 normalizes the policies it stores.
+func (s *Service) validatePolicy(input *BootstrapBucketInput) ([]string, error) {
+	expectedPolicy, err := s.bucketPolicy(input)
+	if err != nil {
+		return nil, err
+	}
+	expected := iamv1.PolicyDocument{}
+	if err := json.Unmarshal([]byte(expectedPolicy), &expected); err != nil {
+		return nil, errors.Wrap(err, "parsing bucket policy")
+	}
+
+	existing := map[string]bool{}
+	out, err := s.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: aws.String(input.Bucket.Name)})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code != errCodeNoSuchBucketPolicy {
+			return nil, errors.Wrap(err, "getting bucket policy")
+		}
+	} else {
+		// Only the statement IDs are read, as the principals of the normalized policy may not be lists.
+		actual := struct {
+			Statement []struct {
+				Sid string
+			}
+		}{}
+		if err := json.Unmarshal([]byte(aws.StringValue(out.Policy)), &actual); err != nil {
+			return nil, errors.Wrap(err, "parsing bucket policy")
+		}
+		for _, statement := range actual.Statement {
+			existing[statement.Sid] = true
+		}
+	}
+
+	problems := []string{}
+	for _, statement := range expected.Statement {
+		if !existing[statement.Sid] {
+			problems = append(problems, fmt.Sprintf("bucket policy has no statement %s", statement.Sid))
+		}
+	}
+	return problems, nil
+}
+
+func (s *Service) bucketPolicy(input *BootstrapBucketInput) (string, error) {
+	identity, err := s.STS.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "getting account ID")
+	}
+
+	policy, err := s3service.BucketPolicy(&input.Bucket, system.GetPartitionFromRegion(input.Region), aws.StringValue(identity.Account), input.OIDCDiscovery)
+	if err != nil {
+		return "", errors.Wrap(err, "generating bucket policy")
+	}
+	return policy, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package s3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3/mock_s3iface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3/mock_stsiface"
+)
+
+func testInput() *BootstrapBucketInput {
+	return &BootstrapBucketInput{
+		ClusterName: "test-cluster",
+		Region:      "us-west-2",
+		Bucket: infrav1.S3Bucket{
+			Name:                           "test-bucket",
+			ControlPlaneIAMInstanceProfile: "control-plane.cluster-api-provider-aws.sigs.k8s.io",
+			NodesIAMInstanceProfiles:       []string{"nodes.cluster-api-provider-aws.sigs.k8s.io"},
+			KMSKeyARN:                      "arn:aws:kms:us-west-2:123456789012:key/test",
+			BootstrapDataExpirationDays:    aws.Int32(1),
+		},
+	}
+}
+
+func TestCreateBootstrapBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		createError error
+		expectError bool
+	}{
+		{
+			name: "creates and configures the bucket",
+		},
+		{
+			name:        "configures a bucket already owned by the caller",
+			createError: awserr.New(s3.ErrCodeBucketAlreadyOwnedByYou, "", nil),
+		},
+		{
+			name:        "fails when the bucket is owned by another account",
+			createError: awserr.New(s3.ErrCodeBucketAlreadyExists, "", nil),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			s3Mock.EXPECT().CreateBucket(&s3.CreateBucketInput{
+				Bucket: aws.String("test-bucket"),
+				CreateBucketConfiguration: &s3.CreateBucketConfiguration{
+					LocationConstraint: aws.String("us-west-2"),
+				},
+			}).Return(nil, tc.createError)
+
+			if !tc.expectError {
+				s3Mock.EXPECT().PutPublicAccessBlock(gomock.Any()).DoAndReturn(func(input *s3.PutPublicAccessBlockInput) (*s3.PutPublicAccessBlockOutput, error) {
+					g.Expect(aws.BoolValue(input.PublicAccessBlockConfiguration.BlockPublicPolicy)).To(BeTrue())
+					return &s3.PutPublicAccessBlockOutput{}, nil
+				})
+				s3Mock.EXPECT().PutBucketTagging(gomock.Any()).DoAndReturn(func(input *s3.PutBucketTaggingInput) (*s3.PutBucketTaggingOutput, error) {
+					g.Expect(input.Tagging.TagSet).To(ContainElement(&s3.Tag{
+						Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+						Value: aws.String("owned"),
+					}))
+					return &s3.PutBucketTaggingOutput{}, nil
+				})
+				s3Mock.EXPECT().PutBucketEncryption(gomock.Any()).Return(&s3.PutBucketEncryptionOutput{}, nil)
+				s3Mock.EXPECT().PutBucketLifecycleConfiguration(gomock.Any()).Return(&s3.PutBucketLifecycleConfigurationOutput{}, nil)
+				stsMock.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+				s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).DoAndReturn(func(input *s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error) {
+					g.Expect(aws.StringValue(input.Policy)).To(ContainSubstring("arn:aws:iam::123456789012:role/control-plane.cluster-api-provider-aws.sigs.k8s.io"))
+					return &s3.PutBucketPolicyOutput{}, nil
+				})
+			}
+
+			err := NewService(s3Mock, stsMock).CreateBootstrapBucket(testInput())
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestValidateBootstrapBucket(t *testing.T) {
+	expectedPolicy := func(g *WithT, sids ...string) *string {
+		statements := []iamv1.StatementEntry{}
+		for _, sid := range sids {
+			statements = append(statements, iamv1.StatementEntry{Sid: sid, Effect: iamv1.EffectAllow})
+		}
+		policy, err := json.Marshal(iamv1.PolicyDocument{Version: "2012-10-17", Statement: statements})
+		g.Expect(err).NotTo(HaveOccurred())
+		return aws.String(string(policy))
+	}
+	allSids := []string{"ForceSSLOnlyAccess", "DenyUnencryptedBootstrapData", "DenyOtherKMSKeys", "control-plane", "nodes.cluster-api-provider-aws.sigs.k8s.io"}
+
+	tests := []struct {
+		name             string
+		headError        error
+		expect           func(g *WithT, s3Mock *mock_s3iface.MockS3APIMockRecorder)
+		expectedProblems []string
+	}{
+		{
+			name:             "missing bucket",
+			headError:        awserr.New("NotFound", "", nil),
+			expectedProblems: []string{"bucket test-bucket doesn't exist"},
+		},
+		{
+			name: "bucket matching the configuration",
+			expect: func(g *WithT, m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketLocation(gomock.Any()).Return(&s3.GetBucketLocationOutput{LocationConstraint: aws.String("us-west-2")}, nil)
+				m.GetPublicAccessBlock(gomock.Any()).Return(&s3.GetPublicAccessBlockOutput{
+					PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+						BlockPublicAcls:       aws.Bool(true),
+						IgnorePublicAcls:      aws.Bool(true),
+						BlockPublicPolicy:     aws.Bool(true),
+						RestrictPublicBuckets: aws.Bool(true),
+					},
+				}, nil)
+				m.GetBucketEncryption(gomock.Any()).Return(&s3.GetBucketEncryptionOutput{
+					ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+						Rules: []*s3.ServerSideEncryptionRule{
+							{
+								ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+									SSEAlgorithm:   aws.String(s3.ServerSideEncryptionAwsKms),
+									KMSMasterKeyID: aws.String("arn:aws:kms:us-west-2:123456789012:key/test"),
+								},
+							},
+						},
+					},
+				}, nil)
+				m.GetBucketLifecycleConfiguration(gomock.Any()).Return(&s3.GetBucketLifecycleConfigurationOutput{
+					Rules: []*s3.LifecycleRule{
+						{ID: aws.String("expire-control-plane-bootstrap-data"), Status: aws.String(s3.ExpirationStatusEnabled), Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)}},
+						{ID: aws.String("expire-node-bootstrap-data"), Status: aws.String(s3.ExpirationStatusEnabled), Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)}},
+					},
+				}, nil)
+				m.GetBucketPolicy(gomock.Any()).Return(&s3.GetBucketPolicyOutput{Policy: expectedPolicy(g, allSids...)}, nil)
+			},
+			expectedProblems: []string{},
+		},
+		{
+			name: "bucket not matching the configuration",
+			expect: func(g *WithT, m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketLocation(gomock.Any()).Return(&s3.GetBucketLocationOutput{}, nil)
+				m.GetPublicAccessBlock(gomock.Any()).Return(&s3.GetPublicAccessBlockOutput{
+					PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+						BlockPublicAcls:       aws.Bool(true),
+						IgnorePublicAcls:      aws.Bool(true),
+						BlockPublicPolicy:     aws.Bool(false),
+						RestrictPublicBuckets: aws.Bool(true),
+					},
+				}, nil)
+				m.GetBucketEncryption(gomock.Any()).Return(nil, awserr.New("ServerSideEncryptionConfigurationNotFoundError", "", nil))
+				m.GetBucketLifecycleConfiguration(gomock.Any()).Return(&s3.GetBucketLifecycleConfigurationOutput{
+					Rules: []*s3.LifecycleRule{
+						{ID: aws.String("expire-control-plane-bootstrap-data"), Status: aws.String(s3.ExpirationStatusEnabled), Expiration: &s3.LifecycleExpiration{Days: aws.Int64(7)}},
+					},
+				}, nil)
+				m.GetBucketPolicy(gomock.Any()).Return(nil, awserr.New("NoSuchBucketPolicy", "", nil))
+			},
+			expectedProblems: []string{
+				"bucket is in region us-east-1 instead of us-west-2",
+				"public access block has BlockPublicPolicy false instead of true",
+				"bucket has no default encryption",
+				"lifecycle rule expire-control-plane-bootstrap-data doesn't expire objects after 1 days",
+				"bucket has no lifecycle rule expire-node-bootstrap-data",
+				"bucket policy has no statement ForceSSLOnlyAccess",
+				"bucket policy has no statement DenyUnencryptedBootstrapData",
+				"bucket policy has no statement DenyOtherKMSKeys",
+				"bucket policy has no statement control-plane",
+				"bucket policy has no statement nodes.cluster-api-provider-aws.sigs.k8s.io",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			s3Mock.EXPECT().HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("test-bucket")}).Return(&s3.HeadBucketOutput{}, tc.headError)
+			if tc.expect != nil {
+				stsMock.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+				tc.expect(g, s3Mock.EXPECT())
+			}
+
+			problems, err := NewService(s3Mock, stsMock).ValidateBootstrapBucket(testInput())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(problems).To(Equal(tc.expectedProblems))
+		})
+	}
+}
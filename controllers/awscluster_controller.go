@@ -20,13 +20,19 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -40,17 +46,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ebsencryption"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/healthcheck"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iampermissions"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instanceprofile"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/oidc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/planner"
 	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -59,6 +73,26 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// iamPermissionsRequeueAfter is how long to wait before simulating again the IAM permissions found missing by the
+// preflight check.
+const iamPermissionsRequeueAfter = time.Minute
+
+// ebsEncryptionByDefaultRequeueAfter is how long to wait before checking again the EBS encryption by default found
+// disabled.
+const ebsEncryptionByDefaultRequeueAfter = time.Minute
+
+// apiServerHealthCheckRequeueAfter is how often the status of the Route 53 health check of the control plane endpoint
+// is refreshed.
+const apiServerHealthCheckRequeueAfter = time.Minute
+
+// staleBootstrapDataCleanupInterval is the minimum interval between two deletions of the stale bootstrap data of a
+// cluster, which lists all the bootstrap data objects of its S3 Bucket.
+const staleBootstrapDataCleanupInterval = time.Hour
+
+// deleteClusterRetryBudget is the number of times the failed deletions of the resources of a cluster are retried
+// overall, once other resources were deleted, before the deletion of the cluster is requeued.
+const deleteClusterRetryBudget = 3
+
 var defaultAWSSecurityGroupRoles = []infrav1.SecurityGroupRole{
 	infrav1.SecurityGroupAPIServerLB,
 	infrav1.SecurityGroupLB,
@@ -79,6 +113,13 @@ type AWSClusterReconciler struct {
 	ExternalResourceGC           bool
 	AlternativeGCStrategy        bool
 	TagUnmanagedNetworkResources bool
+	IAMPermissionsPreflight      bool
+	// ResyncPeriod is how long the reconciliations of a ready AWSCluster whose desired state is unchanged are skipped
+	// after the reconciliation of its AWS resources. Zero reconciles them every time.
+	ResyncPeriod time.Duration
+
+	// staleBootstrapDataCleanups records, for each AWSCluster, when its stale bootstrap data was last deleted.
+	staleBootstrapDataCleanups sync.Map
 }
 
 // getEC2Service factory func is added for testing purpose so that we can inject mocked EC2Service to the AWSClusterReconciler.
@@ -114,6 +155,9 @@ func securityGroupRolesForCluster(scope scope.ClusterScope) []infrav1.SecurityGr
 	if scope.Bastion().Enabled {
 		roles = append(roles, infrav1.SecurityGroupBastion)
 	}
+	if scope.AWSCluster.Spec.SecondaryControlPlaneLoadBalancer != nil {
+		roles = append(roles, infrav1.SecurityGroupSecondaryAPIServerLB)
+	}
 	return roles
 }
 
@@ -128,10 +172,16 @@ func (r *AWSClusterReconciler) getSecurityGroupService(scope scope.ClusterScope)
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclusterwebidentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclustercontrolleridentities,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsproviderconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+
+func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("AWSCluster", res, reterr)
+	}()
 
-func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := logger.FromContext(ctx)
 
 	// Fetch the AWSCluster instance
@@ -171,6 +221,7 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Context:                      ctx,
 		Client:                       r.Client,
 		Logger:                       log,
 		Cluster:                      cluster,
@@ -180,6 +231,10 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
 	})
 	if err != nil {
+		// The AWS session of a cluster whose credentials were removed can't be created, which would keep it forever.
+		if !awsCluster.DeletionTimestamp.IsZero() && isForceDeleted(awsCluster) && controllerutil.ContainsFinalizer(awsCluster, infrav1.ClusterFinalizer) {
+			return reconcile.Result{}, r.forceDeleteWithoutScope(ctx, log, awsCluster, err)
+		}
 		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
 	}
 
@@ -190,13 +245,140 @@ func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}()
 
+	// Handle externally managed clusters
+	if capiannotations.IsExternallyManaged(awsCluster) {
+		if !awsCluster.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, r.reconcileDeleteManagedComponents(ctx, clusterScope)
+		}
+		return r.reconcileManagedComponents(ctx, clusterScope)
+	}
+
 	// Handle deleted clusters
 	if !awsCluster.DeletionTimestamp.IsZero() {
 		return ctrl.Result{}, r.reconcileDelete(ctx, clusterScope)
 	}
 
 	// Handle non-deleted clusters
-	return r.reconcileNormal(clusterScope)
+	return r.reconcileNormal(ctx, clusterScope)
+}
+
+// reconcileManagedComponents reconciles the components of an externally managed AWSCluster listed in its
+// ManagedComponents. The readiness and the failure domains of the AWSCluster are left to the external manager.
+func (r *AWSClusterReconciler) reconcileManagedComponents(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	awsCluster := clusterScope.AWSCluster
+
+	if len(awsCluster.Spec.ManagedComponents) == 0 {
+		// None of the components is managed anymore, so there is nothing to delete with the AWSCluster.
+		controllerutil.RemoveFinalizer(awsCluster, infrav1.ClusterFinalizer)
+		return reconcile.Result{}, nil
+	}
+
+	clusterScope.Info("Reconciling managed components of externally managed AWSCluster", "components", awsCluster.Spec.ManagedComponents)
+
+	// If the AWSCluster doesn't have our finalizer, add it.
+	if controllerutil.AddFinalizer(awsCluster, infrav1.ClusterFinalizer) {
+		// Register the finalizer immediately to avoid orphaning AWS resources on delete
+		if err := clusterScope.PatchObject(); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentNetwork) {
+		if err := r.getNetworkService(*clusterScope).ReconcileNetwork(ctx); err != nil {
+			clusterScope.Error(err, "failed to reconcile network")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentSecurityGroups) {
+		if err := r.getSecurityGroupService(*clusterScope).ReconcileSecurityGroups(); err != nil {
+			clusterScope.Error(err, "failed to reconcile security groups")
+			conditions.MarkFalse(awsCluster, infrav1.ClusterSecurityGroupsReadyCondition, infrav1.ClusterSecurityGroupReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentBastion) {
+		if err := r.getEC2Service(clusterScope).ReconcileBastion(ctx); err != nil {
+			conditions.MarkFalse(awsCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
+			clusterScope.Error(err, "failed to reconcile bastion host")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentLoadBalancer) {
+		if requeueAfter, err := r.reconcileLoadBalancer(ctx, clusterScope, awsCluster); err != nil {
+			return reconcile.Result{}, err
+		} else if requeueAfter != nil {
+			return reconcile.Result{RequeueAfter: *requeueAfter}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileDeleteManagedComponents deletes the components of an externally managed AWSCluster listed in
+// its ManagedComponents, in the reverse order of their creation.
+func (r *AWSClusterReconciler) reconcileDeleteManagedComponents(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	awsCluster := clusterScope.AWSCluster
+
+	if !controllerutil.ContainsFinalizer(awsCluster, infrav1.ClusterFinalizer) {
+		clusterScope.Info("No finalizer on AWSCluster, skipping deletion reconciliation")
+		return nil
+	}
+
+	clusterScope.Info("Deleting managed components of externally managed AWSCluster", "components", awsCluster.Spec.ManagedComponents)
+
+	allErrs := []error{}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentLoadBalancer) {
+		if err := r.getELBService(clusterScope).DeleteLoadbalancers(ctx); err != nil && !r.orphanResources(clusterScope, orphanedLoadBalancers, err) {
+			allErrs = append(allErrs, errors.Wrapf(err, "error deleting load balancers"))
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentBastion) {
+		if err := r.getEC2Service(clusterScope).DeleteBastion(ctx); err != nil && !r.orphanResources(clusterScope, orphanedBastion, err) {
+			allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentSecurityGroups) {
+		if err := r.getSecurityGroupService(*clusterScope).DeleteSecurityGroups(); err != nil && !r.orphanResources(clusterScope, orphanedSecurityGroups, err) {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
+		}
+	}
+
+	if managesComponent(awsCluster, infrav1.AWSClusterComponentNetwork) {
+		if err := r.getNetworkService(*clusterScope).DeleteNetwork(ctx); err != nil && !r.orphanResources(clusterScope, orphanedNetwork, err) {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting network"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs)
+	}
+
+	controllerutil.RemoveFinalizer(awsCluster, infrav1.ClusterFinalizer)
+	return nil
+}
+
+// managesComponent returns whether a component of an externally managed AWSCluster is reconciled by the controller.
+func managesComponent(awsCluster *infrav1.AWSCluster, component infrav1.AWSClusterComponent) bool {
+	for _, c := range awsCluster.Spec.ManagedComponents {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// isReconciled returns whether an AWSCluster is reconciled by the controller: it is not externally managed,
+// some of its components are still managed by the controller, or the controller has to delete them.
+func isReconciled(awsCluster *infrav1.AWSCluster) bool {
+	return !capiannotations.IsExternallyManaged(awsCluster) ||
+		len(awsCluster.Spec.ManagedComponents) > 0 ||
+		controllerutil.ContainsFinalizer(awsCluster, infrav1.ClusterFinalizer)
 }
 
 func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
@@ -207,12 +389,6 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 
 	clusterScope.Info("Reconciling AWSCluster delete")
 
-	ec2svc := r.getEC2Service(clusterScope)
-	elbsvc := r.getELBService(clusterScope)
-	networkSvc := r.getNetworkService(*clusterScope)
-	sgService := r.getSecurityGroupService(*clusterScope)
-	s3Service := s3.NewService(clusterScope)
-
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(clusterScope)
 		if err := instancestateSvc.DeleteEC2Events(); err != nil {
@@ -224,49 +400,226 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 	// In this context we try to delete all the resources that we know about,
 	// and run the garbage collector to delete any resources that were tagged, if enabled.
 	//
-	// The reason the errors are collected and not returned immediately is that we want to
-	// try to delete as many resources as possible, and then return the errors.
-	// Resources like security groups, or load balancers can depende on each other, especially
-	// when external controllers might be using them.
-	allErrs := []error{}
+	// The resources are deleted once the resources depending on them are, e.g. the security groups once the
+	// load balancers and the bastion using them are deleted. The resources which don't depend on each other are
+	// deleted concurrently, the resources which don't depend on the ones whose deletion failed are still deleted,
+	// and the failed deletions are retried once other resources are deleted, as resources like security groups,
+	// or load balancers can depend on each other, especially when external controllers might be using them.
+	//
+	// Each step works on its own copy of the AWSCluster, whose changes are merged into the AWSCluster once all the
+	// steps are done, when the conditions of the steps are set from their results.
+	awsCluster := clusterScope.AWSCluster
+	stepScopes := []*scope.ClusterScope{}
+	step := func(name string, condition clusterv1.ConditionType, deleteFunc func(context.Context, *scope.ClusterScope) error) *infrautilconditions.DeletionStep {
+		stepScope := scope.NewDeletionStepScope(clusterScope)
+		stepScopes = append(stepScopes, stepScope)
+		return &infrautilconditions.DeletionStep{StepName: name, Object: awsCluster, Condition: condition, Delete: func(ctx context.Context) error {
+			return deleteFunc(ctx, stepScope)
+		}}
+	}
+	// The services of the optional resources are always called, as they do nothing when there is nothing to
+	// delete, but their conditions are only reported when the resources were configured.
+	conditionIf := func(configured bool, condition clusterv1.ConditionType) clusterv1.ConditionType {
+		if !configured {
+			return ""
+		}
+		return condition
+	}
+	networkDependencies := []string{"LoadBalancers", "Bastion", "SecurityGroups"}
 
-	if err := s3Service.DeleteBucket(); err != nil {
-		allErrs = append(allErrs, errors.Wrapf(err, "error deleting S3 Bucket"))
+	nodes := []planner.Node{
+		{Procedure: step("OIDCProvider", conditionIf(awsCluster.Spec.AssociateOIDCProvider || awsCluster.Status.OIDCProvider.ARN != "", infrav1.OIDCProviderReadyCondition), func(_ context.Context, stepScope *scope.ClusterScope) error {
+			if err := oidc.NewService(stepScope, r.Client).DeleteOIDCResources(); err != nil && !r.orphanResources(stepScope, orphanedOIDCProvider, err) {
+				return errors.Wrapf(err, "error deleting OIDC provider")
+			}
+			return nil
+		})},
+		{
+			Procedure: step("S3Bucket", conditionIf(awsCluster.Spec.S3Bucket != nil, infrav1.S3BucketReadyCondition), func(_ context.Context, stepScope *scope.ClusterScope) error {
+				if err := s3.NewService(stepScope).DeleteBucket(); err != nil && !r.orphanResources(stepScope, orphanedS3Bucket, err) {
+					return errors.Wrapf(err, "error deleting S3 Bucket")
+				}
+				return nil
+			}),
+			// The OIDC discovery documents are published in the bucket.
+			DependsOn: []string{"OIDCProvider"},
+		},
+		{Procedure: step("APIServerHealthCheck", conditionIf(awsCluster.Status.APIServerHealthCheck != nil, infrav1.APIServerHealthCheckReadyCondition), func(_ context.Context, stepScope *scope.ClusterScope) error {
+			if err := healthcheck.NewService(stepScope).DeleteAPIServerHealthCheck(); err != nil && !r.orphanResources(stepScope, orphanedAPIServerHealthCheck, err) {
+				return errors.Wrap(err, "error deleting API server health check")
+			}
+			return nil
+		})},
+		{Procedure: step("LoadBalancers", infrav1.LoadBalancerReadyCondition, func(ctx context.Context, stepScope *scope.ClusterScope) error {
+			if err := r.getELBService(stepScope).DeleteLoadbalancers(ctx); err != nil && !r.orphanResources(stepScope, orphanedLoadBalancers, err) {
+				return errors.Wrapf(err, "error deleting load balancers")
+			}
+			return nil
+		})},
+		{Procedure: step("Bastion", infrav1.BastionHostReadyCondition, func(ctx context.Context, stepScope *scope.ClusterScope) error {
+			if err := r.getEC2Service(stepScope).DeleteBastion(ctx); err != nil && !r.orphanResources(stepScope, orphanedBastion, err) {
+				return errors.Wrapf(err, "error deleting bastion")
+			}
+			return nil
+		})},
+		{Procedure: step("InstanceProfiles", conditionIf(awsCluster.Spec.InstanceProfiles != nil || awsCluster.Status.InstanceProfiles != nil, infrav1.InstanceProfilesReadyCondition), func(_ context.Context, stepScope *scope.ClusterScope) error {
+			if err := instanceprofile.NewService(stepScope).DeleteInstanceProfiles(); err != nil && !r.orphanResources(stepScope, orphanedInstanceProfiles, err) {
+				return errors.Wrap(err, "error deleting instance profiles")
+			}
+			return nil
+		})},
+		{
+			Procedure: step("SecurityGroups", infrav1.ClusterSecurityGroupsReadyCondition, func(_ context.Context, stepScope *scope.ClusterScope) error {
+				if err := r.getSecurityGroupService(*stepScope).DeleteSecurityGroups(); err != nil && !r.orphanResources(stepScope, orphanedSecurityGroups, err) {
+					return errors.Wrap(err, "error deleting security groups")
+				}
+				return nil
+			}),
+			DependsOn: []string{"LoadBalancers", "Bastion"},
+		},
 	}
 
-	if err := elbsvc.DeleteLoadbalancers(); err != nil {
-		allErrs = append(allErrs, errors.Wrapf(err, "error deleting load balancers"))
+	if awsCluster.Status.StandbyNetwork != nil {
+		nodes = append(nodes, planner.Node{Procedure: step("StandbyNetwork", infrav1.StandbyNetworkReadyCondition, func(ctx context.Context, stepScope *scope.ClusterScope) error {
+			if err := r.deleteStandbyNetwork(ctx, stepScope); err != nil && !r.orphanResources(stepScope, orphanedStandbyNetwork, err) {
+				return errors.Wrap(err, "error deleting network of the standby region")
+			}
+			return nil
+		})})
 	}
 
-	if err := ec2svc.DeleteBastion(); err != nil {
-		allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
+	if r.ExternalResourceGC {
+		nodes = append(nodes, planner.Node{
+			Procedure: step("ExternalResources", "", func(ctx context.Context, stepScope *scope.ClusterScope) error {
+				if gcErr := gc.NewService(stepScope, gc.WithGCStrategy(r.AlternativeGCStrategy)).ReconcileDelete(ctx); gcErr != nil && !r.orphanResources(stepScope, orphanedTaggedResources, gcErr) {
+					return fmt.Errorf("failed delete reconcile for gc service: %w", gcErr)
+				}
+				return nil
+			}),
+			// The garbage collection runs once the security groups of the cluster are deleted, as the security groups
+			// of the load balancers created by the workload cluster are referenced by their ingress rules until then.
+			DependsOn: []string{"SecurityGroups"},
+		})
+		networkDependencies = append(networkDependencies, "ExternalResources")
 	}
 
-	if err := sgService.DeleteSecurityGroups(); err != nil {
-		allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
+	nodes = append(nodes, planner.Node{
+		Procedure: step("Network", infrav1.VpcReadyCondition, func(ctx context.Context, stepScope *scope.ClusterScope) error {
+			if err := r.getNetworkService(*stepScope).DeleteNetwork(ctx); err != nil && !r.orphanResources(stepScope, orphanedNetwork, err) {
+				return errors.Wrap(err, "error deleting network")
+			}
+			return nil
+		}),
+		DependsOn: networkDependencies,
+	})
+
+	graph, err := planner.NewGraph(deleteClusterRetryBudget, nodes...)
+	if err != nil {
+		return err
+	}
+	infrautilconditions.MarkDeletingSteps(nodes)
+	if err := clusterScope.PatchObject(); err != nil {
+		return err
+	}
+	results, err := graph.Execute(ctx)
+	for _, stepScope := range stepScopes {
+		if mergeErr := clusterScope.MergeDeletionStep(stepScope); mergeErr != nil {
+			return mergeErr
+		}
+	}
+	infrautilconditions.MarkDeletionSteps(nodes, results)
+	if err != nil {
+		return err
 	}
 
-	if r.ExternalResourceGC {
-		gcSvc := gc.NewService(clusterScope, gc.WithGCStrategy(r.AlternativeGCStrategy))
-		if gcErr := gcSvc.ReconcileDelete(ctx); gcErr != nil {
-			allErrs = append(allErrs, fmt.Errorf("failed delete reconcile for gc service: %w", gcErr))
+	// Cluster is deleted so remove the finalizer.
+	controllerutil.RemoveFinalizer(clusterScope.AWSCluster, infrav1.ClusterFinalizer)
+	r.staleBootstrapDataCleanups.Delete(client.ObjectKeyFromObject(clusterScope.AWSCluster))
+	return nil
+}
+
+// reconcileStandbyNetwork reconciles the VPC, the subnets and the security groups of the cluster in its standby
+// region, once they are reconciled in the region of the cluster, and deletes the network of a previous standby region.
+func (r *AWSClusterReconciler) reconcileStandbyNetwork(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	awsCluster := clusterScope.AWSCluster
+	standby := awsCluster.Spec.StandbyRegion
+
+	if status := awsCluster.Status.StandbyNetwork; status != nil && (standby == nil || status.Region != standby.Name) {
+		if err := r.deleteStandbyNetwork(ctx, clusterScope); err != nil {
+			return err
 		}
 	}
+	if standby == nil {
+		conditions.Delete(awsCluster, infrav1.StandbyNetworkReadyCondition)
+		return nil
+	}
 
-	if err := networkSvc.DeleteNetwork(); err != nil {
-		allErrs = append(allErrs, errors.Wrap(err, "error deleting network"))
+	standbyScope, err := scope.NewStandbyNetworkScope(scope.StandbyNetworkScopeParams{
+		ClusterScope: clusterScope,
+		Region:       standby.Name,
+		Endpoints:    r.Endpoints,
+	})
+	if err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
 	}
+	// The resources created before a failure are recorded too, so that they are found again.
+	defer func() {
+		awsCluster.Status.StandbyNetwork = standbyScope.Status()
+	}()
 
-	if len(allErrs) > 0 {
-		return kerrors.NewAggregate(allErrs)
+	if err := network.NewService(standbyScope).ReconcileStandbyNetwork(ctx); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to reconcile network in standby region %s", standby.Name)
 	}
 
-	// Cluster is deleted so remove the finalizer.
-	controllerutil.RemoveFinalizer(clusterScope.AWSCluster, infrav1.ClusterFinalizer)
+	if err := securitygroup.NewService(standbyScope, securityGroupRolesForCluster(*clusterScope)).ReconcileSecurityGroups(); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to reconcile security groups in standby region %s", standby.Name)
+	}
+
+	conditions.MarkTrue(awsCluster, infrav1.StandbyNetworkReadyCondition)
 	return nil
 }
 
-func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.ClusterScope, awsCluster *infrav1.AWSCluster) (*time.Duration, error) {
+// deleteStandbyNetwork deletes the security groups and the network reconciled in the standby region recorded in the
+// status of the cluster.
+func (r *AWSClusterReconciler) deleteStandbyNetwork(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	awsCluster := clusterScope.AWSCluster
+	region := awsCluster.Status.StandbyNetwork.Region
+
+	// Nothing was created in the standby region.
+	if awsCluster.Status.StandbyNetwork.VPC.ID == "" {
+		awsCluster.Status.StandbyNetwork = nil
+		return nil
+	}
+
+	clusterScope.Info("Deleting network of standby region", "standbyRegion", region)
+	standbyScope, err := scope.NewStandbyNetworkScope(scope.StandbyNetworkScopeParams{
+		ClusterScope: clusterScope,
+		Region:       region,
+		Endpoints:    r.Endpoints,
+	})
+	if err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkDeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	if err := securitygroup.NewService(standbyScope, securityGroupRolesForCluster(*clusterScope)).DeleteSecurityGroups(); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkDeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to delete security groups in standby region %s", region)
+	}
+
+	if err := network.NewService(standbyScope).DeleteNetwork(ctx); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.StandbyNetworkReadyCondition, infrav1.StandbyNetworkDeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to delete network in standby region %s", region)
+	}
+
+	awsCluster.Status.StandbyNetwork = nil
+	return nil
+}
+
+func (r *AWSClusterReconciler) reconcileLoadBalancer(ctx context.Context, clusterScope *scope.ClusterScope, awsCluster *infrav1.AWSCluster) (*time.Duration, error) {
 	retryAfterDuration := 15 * time.Second
 	if clusterScope.AWSCluster.Spec.ControlPlaneLoadBalancer.LoadBalancerType == infrav1.LoadBalancerTypeDisabled {
 		clusterScope.Debug("load balancer reconciliation shifted to external provider, checking external endpoint")
@@ -276,7 +629,7 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 
 	elbService := r.getELBService(clusterScope)
 
-	if err := elbService.ReconcileLoadbalancers(); err != nil {
+	if err := elbService.ReconcileLoadbalancers(ctx); err != nil {
 		clusterScope.Error(err, "failed to reconcile load balancer")
 		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
 		return nil, err
@@ -288,6 +641,12 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 		return &retryAfterDuration, nil
 	}
 
+	if clusterScope.AWSCluster.Spec.SecondaryControlPlaneLoadBalancer != nil && awsCluster.Status.Network.SecondaryAPIServerELB.DNSName == "" {
+		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.WaitForDNSNameReason, clusterv1.ConditionSeverityInfo, "")
+		clusterScope.Info("Waiting on secondary API server ELB DNS name")
+		return &retryAfterDuration, nil
+	}
+
 	clusterScope.Debug("Looking up IP address for DNS", "dns", awsCluster.Status.Network.APIServerELB.DNSName)
 	if _, err := net.LookupIP(awsCluster.Status.Network.APIServerELB.DNSName); err != nil {
 		clusterScope.Error(err, "failed to get IP address for dns name", "dns", awsCluster.Status.Network.APIServerELB.DNSName)
@@ -305,7 +664,7 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 	return nil, nil
 }
 
-func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+func (r *AWSClusterReconciler) reconcileNormal(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster")
 
 	awsCluster := clusterScope.AWSCluster
@@ -318,12 +677,69 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		}
 	}
 
+	hasWindowsMachines, err := r.hasWindowsMachines(ctx, clusterScope.Cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	clusterScope.SetHasWindowsMachines(hasWindowsMachines)
+
+	stateHash, err := desiredStateHash(awsCluster.Spec, awsCluster.Labels, awsCluster.Annotations, clusterScope.Cluster.Spec, hasWindowsMachines)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// The status of the health check is refreshed on every reconciliation.
+	if awsCluster.Status.Ready && clusterScope.APIServerHealthCheck() == nil {
+		if requeueAfter := resyncAfter(awsCluster.Status.LastReconciliation, stateHash, awsCluster.Status.Conditions, r.ResyncPeriod, time.Now()); requeueAfter > 0 {
+			clusterScope.Debug("Skipping reconciliation of unchanged AWSCluster", "resyncAfter", requeueAfter)
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+	// Only a successful reconciliation is recorded.
+	awsCluster.Status.LastReconciliation = nil
+
+	if r.IAMPermissionsPreflight {
+		if ready, err := iampermissions.NewService(clusterScope).ReconcilePermissions(); err != nil {
+			// non fatal error, the identity may not be allowed to simulate its policies, so we continue
+			clusterScope.Error(err, "non-fatal: failed to check the IAM permissions")
+		} else if !ready {
+			clusterScope.Info("Waiting for the missing IAM permissions to be granted before creating the AWS resources")
+			return reconcile.Result{RequeueAfter: iamPermissionsRequeueAfter}, nil
+		}
+	}
+
+	if clusterScope.EBSEncryptionByDefault() != "" {
+		enabled, err := ebsencryption.NewService(clusterScope).ReconcileEBSEncryptionByDefault()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !enabled {
+			clusterScope.Info("Waiting for EBS encryption by default to be enabled before creating the AWS resources")
+			return reconcile.Result{RequeueAfter: ebsEncryptionByDefaultRequeueAfter}, nil
+		}
+	} else {
+		conditions.Delete(awsCluster, infrav1.EBSEncryptionByDefaultCondition)
+	}
+
+	if err := reconcileRequiredTags(awsCluster, clusterScope.AdditionalTags(), clusterScope.RequiredTags()); err != nil {
+		r.Recorder.Eventf(awsCluster, corev1.EventTypeWarning, "MissingRequiredTags", "Not creating the AWS resources of the cluster: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	if clusterScope.DefaultEBSEncryptionKeyARN() != "" {
+		if _, err := ebsencryption.NewService(clusterScope).ReconcileEBSEncryptionKey(); err != nil {
+			// non fatal error, the identity may not be allowed to read the key policy, so we continue
+			clusterScope.Error(err, "non-fatal: failed to check the default EBS encryption key")
+		}
+	} else {
+		conditions.Delete(awsCluster, infrav1.EBSEncryptionKeyReadyCondition)
+	}
+
 	ec2Service := r.getEC2Service(clusterScope)
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
 	s3Service := s3.NewService(clusterScope)
 
-	if err := networkSvc.ReconcileNetwork(); err != nil {
+	if err := networkSvc.ReconcileNetwork(ctx); err != nil {
 		clusterScope.Error(err, "failed to reconcile network")
 		return reconcile.Result{}, err
 	}
@@ -334,7 +750,17 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, err
 	}
 
-	if err := ec2Service.ReconcileBastion(); err != nil {
+	if err := r.reconcileStandbyNetwork(ctx, clusterScope); err != nil {
+		// non fatal error, the standby region is only used after a failover, so we continue
+		clusterScope.Error(err, "non-fatal: failed to reconcile the network of the standby region")
+	}
+
+	if err := instanceprofile.NewService(clusterScope).ReconcileInstanceProfiles(); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.InstanceProfilesReadyCondition, infrav1.InstanceProfilesReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile instance profiles for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
+	if err := ec2Service.ReconcileBastion(ctx); err != nil {
 		conditions.MarkFalse(awsCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), err.Error())
 		clusterScope.Error(err, "failed to reconcile bastion host")
 		return reconcile.Result{}, err
@@ -348,7 +774,7 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		}
 	}
 
-	if requeueAfter, err := r.reconcileLoadBalancer(clusterScope, awsCluster); err != nil {
+	if requeueAfter, err := r.reconcileLoadBalancer(ctx, clusterScope, awsCluster); err != nil {
 		return reconcile.Result{}, err
 	} else if requeueAfter != nil {
 		return reconcile.Result{RequeueAfter: *requeueAfter}, err
@@ -359,22 +785,105 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 Bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
 
+	if err := r.deleteStaleBootstrapData(ctx, clusterScope, s3Service); err != nil {
+		// non fatal error, so we continue
+		clusterScope.Error(err, "non-fatal: failed to delete stale bootstrap data from S3 Bucket")
+	}
+
+	if err := reconcileFailureDomains(ctx, clusterScope, ec2Service); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile failure domains for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
+	if err := healthcheck.NewService(clusterScope).ReconcileAPIServerHealthCheck(); err != nil {
+		// non fatal error, the health check is only referenced by DNS records managed outside of the cluster, so we continue
+		clusterScope.Error(err, "non-fatal: failed to reconcile the API server health check")
+	}
+
+	awsCluster.Status.Ready = true
+
+	// The service account signing key is generated by the control plane provider once the
+	// infrastructure is ready, so the OIDC provider is reconciled last.
+	oidcService := oidc.NewService(clusterScope, r.Client)
+	if ready, err := oidcService.ReconcileOIDCProvider(ctx); err != nil {
+		conditions.MarkFalse(awsCluster, infrav1.OIDCProviderReadyCondition, infrav1.OIDCProviderFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile OIDC provider for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	} else if !ready {
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if r.ResyncPeriod > 0 {
+		awsCluster.Status.LastReconciliation = lastReconciliation(stateHash, time.Now())
+	}
+
+	if clusterScope.APIServerHealthCheck() != nil {
+		return reconcile.Result{RequeueAfter: apiServerHealthCheckRequeueAfter}, nil
+	}
+
+	if r.ResyncPeriod > 0 {
+		return reconcile.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// deleteStaleBootstrapData deletes the bootstrap data left in the S3 Bucket by the AWSMachines of the cluster
+// which no longer exist. It runs at most once per staleBootstrapDataCleanupInterval, and not at all when the
+// lifecycle rules of the bucket expire the bootstrap data.
+func (r *AWSClusterReconciler) deleteStaleBootstrapData(ctx context.Context, clusterScope *scope.ClusterScope, s3Service *s3.Service) error {
+	if clusterScope.Bucket() == nil || clusterScope.Bucket().BootstrapDataExpirationDays != nil {
+		return nil
+	}
+
+	key := client.ObjectKeyFromObject(clusterScope.AWSCluster)
+	if last, ok := r.staleBootstrapDataCleanups.Load(key); ok && time.Since(last.(time.Time)) < staleBootstrapDataCleanupInterval {
+		return nil
+	}
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.Client.List(ctx, awsMachines, client.InNamespace(clusterScope.Namespace())); err != nil {
+		return errors.Wrap(err, "failed to list AWSMachines")
+	}
+
+	machineNames := sets.New[string]()
+	for _, awsMachine := range awsMachines.Items {
+		machineNames.Insert(awsMachine.Name)
+	}
+
+	if err := s3Service.DeleteStaleObjects(machineNames); err != nil {
+		return err
+	}
+	r.staleBootstrapDataCleanups.Store(key, time.Now())
+	return nil
+}
+
+// reconcileFailureDomains reports the availability zones with private subnets as the failure domains of the cluster,
+// excluding the availability zones that the failure domain selection of the cluster doesn't allow or that don't offer
+// its instance types. The failure domains which aren't viable anymore are removed.
+func reconcileFailureDomains(ctx context.Context, clusterScope *scope.ClusterScope, ec2Service services.EC2Interface) error {
+	selection := clusterScope.AWSCluster.Spec.FailureDomainSelection
+
+	var offeringZones sets.Set[string]
+	if selection != nil && len(selection.InstanceTypes) > 0 {
+		zones, err := ec2Service.AvailabilityZonesOfferingInstanceTypes(ctx, selection.InstanceTypes)
+		if err != nil {
+			return err
+		}
+		offeringZones = zones
+	}
+
+	clusterScope.AWSCluster.Status.FailureDomains = nil
 	for _, subnet := range clusterScope.Subnets().FilterPrivate() {
-		found := false
-		for _, az := range awsCluster.Status.Network.APIServerELB.AvailabilityZones {
-			if az == subnet.AvailabilityZone {
-				found = true
-				break
-			}
+		if !selection.AllowsAvailabilityZone(subnet.AvailabilityZone) {
+			continue
+		}
+		if offeringZones != nil && !offeringZones.Has(subnet.AvailabilityZone) {
+			continue
 		}
 
 		clusterScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
-			ControlPlane: found,
+			ControlPlane: slices.Contains(clusterScope.AWSCluster.Status.Network.APIServerELB.AvailabilityZones, subnet.AvailabilityZone),
 		})
 	}
-
-	awsCluster.Status.Ready = true
-	return reconcile.Result{}, nil
+	return nil
 }
 
 func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -405,19 +914,179 @@ func (r *AWSClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 				},
 			},
 		).
-		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log.GetLogger())).
+		WithEventFilter(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			awsCluster, ok := o.(*infrav1.AWSCluster)
+			if !ok {
+				return true
+			}
+			if !isReconciled(awsCluster) {
+				log.Trace("AWSCluster is externally managed, skipping", "awsCluster", klog.KObj(awsCluster))
+				return false
+			}
+			return true
+		})).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
 	}
 
-	return controller.Watch(
+	if err := controller.Watch(
 		source.Kind(mgr.GetCache(), &clusterv1.Cluster{}),
 		handler.EnqueueRequestsFromMapFunc(r.requeueAWSClusterForUnpausedCluster(ctx, log)),
 		predicates.ClusterUnpaused(log.GetLogger()),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for ready clusters")
+	}
+
+	// The CNI ingress rules depend on whether the cluster has Windows machines, so the clusters are reconciled when
+	// their Windows machines are created or deleted.
+	if err := controller.Watch(
+		source.Kind(mgr.GetCache(), &infrav1.AWSMachine{}),
+		handler.EnqueueRequestsFromMapFunc(r.requeueAWSClusterForWindowsMachine(ctx, log)),
+		windowsMachineCreatedOrDeleted(),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for Windows AWSMachines")
+	}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		if err := controller.Watch(
+			source.Kind(mgr.GetCache(), &expinfrav1.AWSMachinePool{}),
+			handler.EnqueueRequestsFromMapFunc(r.requeueAWSClusterForWindowsMachine(ctx, log)),
+			windowsMachineCreatedOrDeleted(),
+		); err != nil {
+			return errors.Wrap(err, "failed adding a watch for Windows AWSMachinePools")
+		}
+	}
+
+	// The sessions of the clusters are rebuilt when they are reconciled after the credentials of their identity are
+	// rotated, so that the controller doesn't need to be restarted.
+	return controller.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.requeueAWSClustersForIdentitySecret(log)),
 	)
 }
 
+// hasWindowsMachines returns true if the cluster has AWSMachines, or AWSMachinePools when machine pools are enabled,
+// running Windows.
+func (r *AWSClusterReconciler) hasWindowsMachines(ctx context.Context, cluster *clusterv1.Cluster) (bool, error) {
+	listOptions := []client.ListOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	}
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, listOptions...); err != nil {
+		return false, errors.Wrap(err, "failed to list the AWSMachines of the cluster")
+	}
+	for i := range awsMachines.Items {
+		if isWindowsMachine(&awsMachines.Items[i]) {
+			return true, nil
+		}
+	}
+
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return false, nil
+	}
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, listOptions...); err != nil {
+		// The AWSMachinePool CRD may not be installed even though the MachinePool feature gate is enabled.
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to list the AWSMachinePools of the cluster")
+	}
+	for i := range awsMachinePools.Items {
+		if isWindowsMachine(&awsMachinePools.Items[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isWindowsMachine returns true if the object is an AWSMachine or an AWSMachinePool running Windows.
+func isWindowsMachine(o client.Object) bool {
+	switch m := o.(type) {
+	case *infrav1.AWSMachine:
+		return m.Spec.OSFamily == infrav1.OSFamilyWindows
+	case *expinfrav1.AWSMachinePool:
+		return m.Spec.AWSLaunchTemplate.OSFamily == infrav1.OSFamilyWindows
+	default:
+		return false
+	}
+}
+
+// windowsMachineCreatedOrDeleted filters the creation and deletion events of Windows machines. The operating system
+// of a machine can't change, so its updates are ignored.
+func windowsMachineCreatedOrDeleted() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isWindowsMachine(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isWindowsMachine(e.Object) },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// requeueAWSClusterForWindowsMachine returns a mapper of Windows machines to the AWSCluster of their cluster.
+func (r *AWSClusterReconciler) requeueAWSClusterForWindowsMachine(ctx context.Context, log logger.Wrapper) handler.MapFunc {
+	clusterToAWSCluster := r.requeueAWSClusterForUnpausedCluster(ctx, log)
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		log := log.WithValues("objectMapper", "windowsMachineToAWSCluster", "machine", klog.KObj(o))
+
+		clusterName, ok := o.GetLabels()[clusterv1.ClusterNameLabel]
+		if !ok {
+			log.Trace("Machine does not belong to a cluster, skipping mapping.")
+			return nil
+		}
+
+		cluster := &clusterv1.Cluster{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: o.GetNamespace(), Name: clusterName}, cluster); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to get the cluster of the machine")
+			}
+			return nil
+		}
+		return clusterToAWSCluster(ctx, cluster)
+	}
+}
+
+// requeueAWSClustersForIdentitySecret returns a mapper of the secrets of AWSClusterStaticIdentities to the AWSClusters
+// using them, directly or through AWSClusterRoleIdentities.
+func (r *AWSClusterReconciler) requeueAWSClustersForIdentitySecret(log logger.Wrapper) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		log := log.WithValues("objectMapper", "identitySecretToAWSCluster", "secret", klog.KObj(o))
+
+		refs, err := scope.IdentityRefsForSecret(ctx, r.Client, o)
+		if err != nil {
+			log.Error(err, "Failed to get the identities using the secret")
+			return nil
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+
+		awsClusters := &infrav1.AWSClusterList{}
+		if err := r.List(ctx, awsClusters); err != nil {
+			log.Error(err, "Failed to list AWS clusters")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for i := range awsClusters.Items {
+			awsCluster := &awsClusters.Items[i]
+			if awsCluster.Spec.IdentityRef == nil || !isReconciled(awsCluster) {
+				continue
+			}
+			for _, ref := range refs {
+				if *awsCluster.Spec.IdentityRef == ref {
+					log.Trace("Adding request.", "awsCluster", klog.KObj(awsCluster))
+					requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(awsCluster)})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
 func (r *AWSClusterReconciler) requeueAWSClusterForUnpausedCluster(_ context.Context, log logger.Wrapper) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []ctrl.Request {
 		c, ok := o.(*clusterv1.Cluster)
@@ -452,7 +1121,7 @@ func (r *AWSClusterReconciler) requeueAWSClusterForUnpausedCluster(_ context.Con
 			return nil
 		}
 
-		if capiannotations.IsExternallyManaged(awsCluster) {
+		if !isReconciled(awsCluster) {
 			log.Trace("AWSCluster is externally managed, skipping mapping.")
 			return nil
 		}
@@ -23,24 +23,33 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/fakes"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestAWSClusterReconcilerReconcile(t *testing.T) {
@@ -195,9 +204,9 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 			t.Run("Should successfully create AWSCluster with Cluster Finalizer and LoadBalancerReady status true on AWSCluster", func(t *testing.T) {
 				g := NewWithT(t)
 				runningCluster := func() {
-					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
-					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					ec2Svc.EXPECT().ReconcileBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().ReconcileLoadbalancers(gomock.Any()).Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
 				}
 
@@ -242,7 +251,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 						IsPublic:         false,
 					},
 				})
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).To(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.LoadBalancerReadyCondition, corev1.ConditionTrue, "", ""}})
 				g.Expect(awsCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer))
@@ -254,7 +263,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(expectedErr)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
 				defer teardown()
@@ -267,14 +276,14 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					},
 				)
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).Should(Equal(expectedErr))
 			})
 			t.Run("Should fail AWSCluster create with ClusterSecurityGroupsReadyCondition status false", func(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
@@ -288,7 +297,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					},
 				)
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).ToNot(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.ClusterSecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.ClusterSecurityGroupReconciliationFailedReason}})
 			})
@@ -296,9 +305,9 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
-					ec2Svc.EXPECT().ReconcileBastion().Return(expectedErr)
+					ec2Svc.EXPECT().ReconcileBastion(gomock.Any()).Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
 				defer teardown()
@@ -311,7 +320,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					},
 				)
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).ToNot(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.BastionHostReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.BastionHostFailedReason}})
 			})
@@ -319,10 +328,10 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
-					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
-					elbSvc.EXPECT().ReconcileLoadbalancers().Return(expectedErr)
+					ec2Svc.EXPECT().ReconcileBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().ReconcileLoadbalancers(gomock.Any()).Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
 				defer teardown()
@@ -335,7 +344,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					},
 				)
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).ToNot(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.LoadBalancerReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.LoadBalancerFailedReason}})
 			})
@@ -343,10 +352,10 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
-					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
-					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
+					ec2Svc.EXPECT().ReconcileBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().ReconcileLoadbalancers(gomock.Any()).Return(nil)
 				}
 				csClient := setup(t, &awsCluster)
 				defer teardown()
@@ -359,7 +368,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					},
 				)
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).To(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.LoadBalancerReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitForDNSNameReason}})
 			})
@@ -367,10 +376,10 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g := NewWithT(t)
 				awsCluster := getAWSCluster("test", "test")
 				runningCluster := func() {
-					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
+					networkSvc.EXPECT().ReconcileNetwork(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
-					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
-					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
+					ec2Svc.EXPECT().ReconcileBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().ReconcileLoadbalancers(gomock.Any()).Return(nil)
 				}
 				csClient := setup(t, &awsCluster)
 				defer teardown()
@@ -384,7 +393,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				)
 				awsCluster.Status.Network.APIServerELB.DNSName = "test-apiserver.us-east-1.aws"
 				g.Expect(err).To(BeNil())
-				_, err = reconciler.reconcileNormal(cs)
+				_, err = reconciler.reconcileNormal(ctx, cs)
 				g.Expect(err).To(BeNil())
 				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{{infrav1.LoadBalancerReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitForDNSNameResolveReason}})
 			})
@@ -393,9 +402,9 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 	t.Run("Reconcile delete AWSCluster", func(t *testing.T) {
 		t.Run("Reconcile success", func(t *testing.T) {
 			deleteCluster := func() {
-				ec2Svc.EXPECT().DeleteBastion().Return(nil)
-				elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
-				networkSvc.EXPECT().DeleteNetwork().Return(nil)
+				ec2Svc.EXPECT().DeleteBastion(gomock.Any()).Return(nil)
+				elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(nil)
+				networkSvc.EXPECT().DeleteNetwork(gomock.Any()).Return(nil)
 				sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
 			}
 			t.Run("Should successfully delete AWSCluster with Cluster Finalizer removed", func(t *testing.T) {
@@ -420,14 +429,14 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 		})
 		t.Run("Reconcile failure", func(t *testing.T) {
 			expectedErr := errors.New("failed to get resource")
-			t.Run("Should fail AWSCluster delete with LoadBalancer deletion failed and Cluster Finalizer not removed", func(t *testing.T) {
+			t.Run("Should fail AWSCluster delete with LoadBalancer deletion failed, security groups and network not deleted and Cluster Finalizer not removed", func(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
 					t.Helper()
-					elbSvc.EXPECT().DeleteLoadbalancers().Return(expectedErr)
-					ec2Svc.EXPECT().DeleteBastion().Return(nil)
-					networkSvc.EXPECT().DeleteNetwork().Return(nil)
-					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
+					// The deletion of the load balancers is retried once, after the other resources were deleted, and not
+					// again as no other resource was deleted since.
+					elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(expectedErr).Times(2)
+					ec2Svc.EXPECT().DeleteBastion(gomock.Any()).Return(nil)
 				}
 				awsCluster := getAWSCluster("test", "test")
 				awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
@@ -446,13 +455,13 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				g.Expect(err).ToNot(BeNil())
 				g.Expect(awsCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer))
 			})
-			t.Run("Should fail AWSCluster delete with Bastion deletion failed and Cluster Finalizer not removed", func(t *testing.T) {
+			t.Run("Should fail AWSCluster delete with Bastion deletion failed, security groups and network not deleted and Cluster Finalizer not removed", func(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
-					ec2Svc.EXPECT().DeleteBastion().Return(expectedErr)
-					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
-					networkSvc.EXPECT().DeleteNetwork().Return(nil)
-					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
+					// The deletion of the bastion is retried once, after the other resources were deleted, and not again
+					// as no other resource was deleted since.
+					ec2Svc.EXPECT().DeleteBastion(gomock.Any()).Return(expectedErr).Times(2)
+					elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(nil)
 				}
 				awsCluster := getAWSCluster("test", "test")
 				awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
@@ -470,14 +479,20 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				err = reconciler.reconcileDelete(ctx, cs)
 				g.Expect(err).ToNot(BeNil())
 				g.Expect(awsCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer))
+				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{
+					{infrav1.LoadBalancerReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, clusterv1.DeletedReason},
+					{infrav1.BastionHostReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.DeletingFailedReason},
+					{infrav1.ClusterSecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.DeletingBlockedReason},
+				})
 			})
-			t.Run("Should fail AWSCluster delete with security group deletion failed and Cluster Finalizer not removed", func(t *testing.T) {
+			t.Run("Should fail AWSCluster delete with security group deletion failed, network not deleted and Cluster Finalizer not removed", func(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
-					ec2Svc.EXPECT().DeleteBastion().Return(nil)
-					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
-					sgSvc.EXPECT().DeleteSecurityGroups().Return(expectedErr)
-					networkSvc.EXPECT().DeleteNetwork().Return(nil)
+					ec2Svc.EXPECT().DeleteBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(nil)
+					// The deletion of the security groups is retried once, after the load balancers and the bastion were
+					// deleted.
+					sgSvc.EXPECT().DeleteSecurityGroups().Return(expectedErr).Times(2)
 				}
 				awsCluster := getAWSCluster("test", "test")
 				awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
@@ -495,14 +510,19 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				err = reconciler.reconcileDelete(ctx, cs)
 				g.Expect(err).ToNot(BeNil())
 				g.Expect(awsCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer))
+				expectAWSClusterConditions(g, cs.AWSCluster, []conditionAssertion{
+					{infrav1.ClusterSecurityGroupsReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityWarning, infrav1.DeletingFailedReason},
+					{infrav1.VpcReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.DeletingBlockedReason},
+				})
 			})
 			t.Run("Should fail AWSCluster delete with network deletion failed and Cluster Finalizer not removed", func(t *testing.T) {
 				g := NewWithT(t)
 				deleteCluster := func() {
-					ec2Svc.EXPECT().DeleteBastion().Return(nil)
-					elbSvc.EXPECT().DeleteLoadbalancers().Return(nil)
+					ec2Svc.EXPECT().DeleteBastion(gomock.Any()).Return(nil)
+					elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(nil)
 					sgSvc.EXPECT().DeleteSecurityGroups().Return(nil)
-					networkSvc.EXPECT().DeleteNetwork().Return(expectedErr)
+					// The deletion of the network is retried once, after the other resources were deleted.
+					networkSvc.EXPECT().DeleteNetwork(gomock.Any()).Return(expectedErr).Times(2)
 				}
 				awsCluster := getAWSCluster("test", "test")
 				awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
@@ -523,6 +543,72 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 			})
 		})
 	})
+	t.Run("Reconciling an externally managed AWSCluster", func(t *testing.T) {
+		t.Run("Should only reconcile the managed components", func(t *testing.T) {
+			g := NewWithT(t)
+			awsCluster := getAWSCluster("test", "test")
+			awsCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "external"}
+			awsCluster.Spec.ManagedComponents = []infrav1.AWSClusterComponent{infrav1.AWSClusterComponentSecurityGroups}
+			csClient := setup(t, &awsCluster)
+			defer teardown()
+			sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+			cs, err := scope.NewClusterScope(
+				scope.ClusterScopeParams{
+					Client:     csClient,
+					Cluster:    &clusterv1.Cluster{},
+					AWSCluster: &awsCluster,
+				},
+			)
+			g.Expect(err).To(BeNil())
+			_, err = reconciler.reconcileManagedComponents(context.TODO(), cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(awsCluster.GetFinalizers()).To(ContainElement(infrav1.ClusterFinalizer))
+			g.Expect(awsCluster.Status.Ready).To(BeFalse())
+		})
+		t.Run("Should remove the finalizer when no component is managed", func(t *testing.T) {
+			g := NewWithT(t)
+			awsCluster := getAWSCluster("test", "test")
+			awsCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "external"}
+			awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
+			csClient := setup(t, &awsCluster)
+			defer teardown()
+			cs, err := scope.NewClusterScope(
+				scope.ClusterScopeParams{
+					Client:     csClient,
+					Cluster:    &clusterv1.Cluster{},
+					AWSCluster: &awsCluster,
+				},
+			)
+			g.Expect(err).To(BeNil())
+			_, err = reconciler.reconcileManagedComponents(context.TODO(), cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(awsCluster.GetFinalizers()).ToNot(ContainElement(infrav1.ClusterFinalizer))
+		})
+		t.Run("Should only delete the managed components", func(t *testing.T) {
+			g := NewWithT(t)
+			awsCluster := getAWSCluster("test", "test")
+			awsCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "external"}
+			awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
+			awsCluster.Spec.ManagedComponents = []infrav1.AWSClusterComponent{infrav1.AWSClusterComponentSecurityGroups, infrav1.AWSClusterComponentLoadBalancer}
+			csClient := setup(t, &awsCluster)
+			defer teardown()
+			gomock.InOrder(
+				elbSvc.EXPECT().DeleteLoadbalancers(gomock.Any()).Return(nil),
+				sgSvc.EXPECT().DeleteSecurityGroups().Return(nil),
+			)
+			cs, err := scope.NewClusterScope(
+				scope.ClusterScopeParams{
+					Client:     csClient,
+					Cluster:    &clusterv1.Cluster{},
+					AWSCluster: &awsCluster,
+				},
+			)
+			g.Expect(err).To(BeNil())
+			err = reconciler.reconcileDeleteManagedComponents(context.TODO(), cs)
+			g.Expect(err).To(BeNil())
+			g.Expect(awsCluster.GetFinalizers()).ToNot(ContainElement(infrav1.ClusterFinalizer))
+		})
+	})
 }
 
 func TestAWSClusterReconcilerRequeueAWSClusterForUnpausedCluster(t *testing.T) {
@@ -549,6 +635,16 @@ func TestAWSClusterReconcilerRequeueAWSClusterForUnpausedCluster(t *testing.T) {
 			ownerCluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test"}},
 			requeue:      false,
 		},
+		{
+			name: "Should create reconcile request if AWSCluster is externally managed with managed components",
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "aws-test-", Annotations: map[string]string{clusterv1.ManagedByAnnotation: "capi-test"}},
+				TypeMeta:   metav1.TypeMeta{Kind: "AWSCluster", APIVersion: infrav1.GroupVersion.String()},
+				Spec:       infrav1.AWSClusterSpec{ManagedComponents: []infrav1.AWSClusterComponent{infrav1.AWSClusterComponentSecurityGroups}},
+			},
+			ownerCluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test"}},
+			requeue:      true,
+		},
 		{
 			name:         "Should not create reconcile request for deleted clusters",
 			ownerCluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capi-test", DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
@@ -644,6 +740,7 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 	tests := []struct {
 		name           string
 		bastionEnabled bool
+		secondaryLB    *infrav1.AWSLoadBalancerSpec
 		want           []infrav1.SecurityGroupRole
 	}{
 		{
@@ -656,6 +753,11 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 			bastionEnabled: false,
 			want:           defaultAWSSecurityGroupRoles,
 		},
+		{
+			name:        "Should use secondary load balancer security group when a secondary control plane load balancer is configured",
+			secondaryLB: &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeNLB},
+			want:        append(defaultAWSSecurityGroupRoles, infrav1.SecurityGroupSecondaryAPIServerLB),
+		},
 	}
 
 	for _, tt := range tests {
@@ -664,6 +766,7 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 
 			c := getAWSCluster("test", "test")
 			c.Spec.Bastion.Enabled = tt.bastionEnabled
+			c.Spec.SecondaryControlPlaneLoadBalancer = tt.secondaryLB
 			s, err := getClusterScope(c)
 			g.Expect(err).To(BeNil(), "failed to create cluster scope for test")
 
@@ -672,3 +775,309 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRequiredTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		additionalTags infrav1.Tags
+		requiredTags   []string
+		wantErr        bool
+		wantCondition  *corev1.ConditionStatus
+	}{
+		{
+			name:           "Should not set the condition when no tags are required",
+			additionalTags: infrav1.Tags{"team": "a"},
+		},
+		{
+			name:           "Should mark the condition true when the additional tags set the required tags",
+			additionalTags: infrav1.Tags{"team": "a", "cost-center": "b"},
+			requiredTags:   []string{"cost-center"},
+			wantCondition:  ptr.To(corev1.ConditionTrue),
+		},
+		{
+			name:           "Should fail and mark the condition false when a required tag is missing",
+			additionalTags: infrav1.Tags{"team": "a"},
+			requiredTags:   []string{"cost-center", "team"},
+			wantErr:        true,
+			wantCondition:  ptr.To(corev1.ConditionFalse),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := getAWSCluster("test", "test")
+			err := reconcileRequiredTags(&c, tt.additionalTags, tt.requiredTags)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ContainSubstring("cost-center")))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			condition := conditions.Get(&c, infrav1.RequiredTagsReadyCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(*tt.wantCondition))
+		})
+	}
+}
+
+func TestReconcileFailureDomains(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection *infrav1.FailureDomainSelection
+		offerings map[string][]string
+		wantErr   bool
+		want      clusterv1.FailureDomains
+	}{
+		{
+			name: "Should report the availability zones with private subnets",
+			want: clusterv1.FailureDomains{
+				"us-east-1a": {ControlPlane: true},
+				"us-east-1b": {ControlPlane: false},
+				"us-east-1c": {ControlPlane: true},
+			},
+		},
+		{
+			name:      "Should only report the allowed availability zones",
+			selection: &infrav1.FailureDomainSelection{AllowedAvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1d"}},
+			want: clusterv1.FailureDomains{
+				"us-east-1a": {ControlPlane: true},
+				"us-east-1b": {ControlPlane: false},
+			},
+		},
+		{
+			name:      "Should not report the denied availability zones",
+			selection: &infrav1.FailureDomainSelection{DeniedAvailabilityZones: []string{"us-east-1a"}},
+			want: clusterv1.FailureDomains{
+				"us-east-1b": {ControlPlane: false},
+				"us-east-1c": {ControlPlane: true},
+			},
+		},
+		{
+			name:      "Should not report the availability zones which don't offer the instance types",
+			selection: &infrav1.FailureDomainSelection{InstanceTypes: []string{"m5.large", "g5.xlarge"}},
+			offerings: map[string][]string{
+				"us-east-1a": {"m5.large", "g5.xlarge"},
+				"us-east-1b": {"m5.large"},
+				"us-east-1c": {"m5.large", "g5.xlarge", "t3.micro"},
+			},
+			want: clusterv1.FailureDomains{
+				"us-east-1a": {ControlPlane: true},
+				"us-east-1c": {ControlPlane: true},
+			},
+		},
+		{
+			name:      "Should fail when the instance type offerings can't be described",
+			selection: &infrav1.FailureDomainSelection{InstanceTypes: []string{"m5.large"}},
+			wantErr:   true,
+			want:      clusterv1.FailureDomains{"us-east-1d": {ControlPlane: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+			awsCluster := getAWSCluster("test", "test")
+			awsCluster.Spec.FailureDomainSelection = tt.selection
+			awsCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+				{ID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+				{ID: "subnet-private-1c", AvailabilityZone: "us-east-1c"},
+				{ID: "subnet-public-1d", AvailabilityZone: "us-east-1d", IsPublic: true},
+			}
+			awsCluster.Status.Network.APIServerELB.AvailabilityZones = []string{"us-east-1a", "us-east-1c", "us-east-1d"}
+			// A failure domain which isn't viable anymore, as its availability zone has no private subnet.
+			awsCluster.Status.FailureDomains = clusterv1.FailureDomains{"us-east-1d": {ControlPlane: true}}
+
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				AWSCluster: &awsCluster,
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Svc := fakes.NewEC2()
+			ec2Svc.InstanceTypeOfferings = tt.offerings
+			if tt.wantErr {
+				ec2Svc.InjectError("AvailabilityZonesOfferingInstanceTypes", errors.New("UnauthorizedOperation"))
+			}
+
+			err = reconcileFailureDomains(context.TODO(), cs, ec2Svc)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(awsCluster.Status.FailureDomains).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestAWSClusterReconcilerHasWindowsMachines(t *testing.T) {
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	awsMachine := func(name, clusterName string, osFamily infrav1.OSFamily) *infrav1.AWSMachine {
+		return &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: clusterName}},
+			Spec:       infrav1.AWSMachineSpec{OSFamily: osFamily},
+		}
+	}
+	awsMachinePool := func(name, clusterName string, osFamily infrav1.OSFamily) *expinfrav1.AWSMachinePool {
+		return &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: clusterName}},
+			Spec:       expinfrav1.AWSMachinePoolSpec{AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{OSFamily: osFamily}},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		objects               []client.Object
+		withoutAWSMachinePool bool
+		want                  bool
+	}{
+		{
+			name:    "cluster with Linux machines",
+			objects: []client.Object{awsMachine("linux", "test", ""), awsMachinePool("linux", "test", infrav1.OSFamilyLinux)},
+			want:    false,
+		},
+		{
+			name:    "cluster with a Windows AWSMachine",
+			objects: []client.Object{awsMachine("linux", "test", ""), awsMachine("windows", "test", infrav1.OSFamilyWindows)},
+			want:    true,
+		},
+		{
+			name:    "cluster with a Windows AWSMachinePool",
+			objects: []client.Object{awsMachinePool("windows", "test", infrav1.OSFamilyWindows)},
+			want:    true,
+		},
+		{
+			name:    "Windows machines of another cluster are ignored",
+			objects: []client.Object{awsMachine("windows", "other", infrav1.OSFamilyWindows), awsMachinePool("windows", "other", infrav1.OSFamilyWindows)},
+			want:    false,
+		},
+		{
+			name:                  "AWSMachinePools are ignored when their kind isn't registered",
+			objects:               []client.Object{awsMachine("linux", "test", "")},
+			withoutAWSMachinePool: true,
+			want:                  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			if !tt.withoutAWSMachinePool {
+				g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+			}
+
+			reconciler := &AWSClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+			got, err := reconciler.hasWindowsMachines(context.TODO(), cluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestOrphanResources(t *testing.T) {
+	credentialsErr := awserr.New(awserrors.InvalidClientTokenID, "The security token included in the request is invalid.", nil)
+
+	tests := []struct {
+		name       string
+		forced     bool
+		err        error
+		wantEvents int
+	}{
+		{
+			name: "Should not orphan resources when the deletion isn't forced",
+			err:  credentialsErr,
+		},
+		{
+			name:   "Should not orphan resources when the deletion fails for another reason",
+			forced: true,
+			err:    errors.New("DependencyViolation"),
+		},
+		{
+			name:       "Should orphan each resource when the credentials are invalid",
+			forced:     true,
+			err:        credentialsErr,
+			wantEvents: 2,
+		},
+		{
+			name:       "Should orphan each resource when a wrapped error is a credentials error",
+			forced:     true,
+			err:        kerrors.NewAggregate([]error{pkgerrors.Wrap(credentialsErr, "failed to delete security group")}),
+			wantEvents: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			awsCluster := getAWSCluster("test", "test")
+			if tt.forced {
+				awsCluster.Annotations = map[string]string{infrav1.ForceDeleteAnnotation: "true"}
+			}
+			awsCluster.Status.Network.SecurityGroups = map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupNode:         {ID: "sg-2"},
+				infrav1.SecurityGroupControlPlane: {ID: "sg-1"},
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &AWSClusterReconciler{Recorder: recorder}
+			cs := &scope.ClusterScope{Logger: *logger.NewLogger(ctrl.Log), AWSCluster: &awsCluster}
+
+			g.Expect(reconciler.orphanResources(cs, orphanedSecurityGroups, tt.err)).To(Equal(tt.wantEvents > 0))
+			g.Expect(recorder.Events).To(HaveLen(tt.wantEvents))
+			if tt.wantEvents > 0 {
+				g.Expect(<-recorder.Events).To(ContainSubstring("orphaning security group sg-1"))
+				g.Expect(<-recorder.Events).To(ContainSubstring("orphaning security group sg-2"))
+			}
+		})
+	}
+}
+
+func TestForceDeleteWithoutScope(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	awsCluster := getAWSCluster("test", "test")
+	awsCluster.Annotations = map[string]string{infrav1.ForceDeleteAnnotation: "true"}
+	awsCluster.Finalizers = []string{infrav1.ClusterFinalizer, "other"}
+	awsCluster.Spec.NetworkSpec.VPC.Tags = infrav1.Tags{infrav1.ClusterTagKey("test"): string(infrav1.ResourceLifecycleOwned)}
+	awsCluster.Spec.NetworkSpec.Subnets[0].ResourceID = "subnet-1"
+	awsCluster.Status.Bastion = &infrav1.Instance{ID: "i-bastion"}
+	awsCluster.Labels = map[string]string{clusterv1.ClusterNameLabel: "test"}
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &AWSClusterReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(&awsCluster).Build(),
+		Recorder: recorder,
+	}
+
+	err := reconciler.forceDeleteWithoutScope(context.TODO(), logger.NewLogger(ctrl.Log), &awsCluster, errors.New("secret not found"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	updated := &infrav1.AWSCluster{}
+	g.Expect(reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(&awsCluster), updated)).To(Succeed())
+	g.Expect(updated.Finalizers).To(ConsistOf("other"))
+
+	g.Expect(recorder.Events).To(HaveLen(3))
+	g.Expect(<-recorder.Events).To(ContainSubstring("orphaning bastion instance i-bastion"))
+	g.Expect(<-recorder.Events).To(ContainSubstring("orphaning network resource vpc-exists"))
+	g.Expect(<-recorder.Events).To(ContainSubstring("orphaning network resource subnet-1"))
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// orphanedResources lists the AWS resources of a kind recorded in an AWSCluster.
+type orphanedResources struct {
+	kind      string
+	resources func(awsCluster *infrav1.AWSCluster) []string
+}
+
+var (
+	orphanedOIDCProvider = orphanedResources{kind: "OIDC provider", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		return nonEmpty(awsCluster.Status.OIDCProvider.ARN)
+	}}
+	orphanedS3Bucket = orphanedResources{kind: "S3 bucket", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		if awsCluster.Spec.S3Bucket == nil {
+			return nil
+		}
+		return nonEmpty(awsCluster.Spec.S3Bucket.Name)
+	}}
+	orphanedAPIServerHealthCheck = orphanedResources{kind: "Route 53 health check", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		if awsCluster.Status.APIServerHealthCheck == nil {
+			return nil
+		}
+		return nonEmpty(awsCluster.Status.APIServerHealthCheck.ID)
+	}}
+	orphanedLoadBalancers = orphanedResources{kind: "load balancer", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		return nonEmpty(awsCluster.Status.Network.APIServerELB.Name, awsCluster.Status.Network.SecondaryAPIServerELB.Name)
+	}}
+	orphanedBastion = orphanedResources{kind: "bastion instance", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		if awsCluster.Status.Bastion == nil {
+			return nil
+		}
+		return nonEmpty(awsCluster.Status.Bastion.ID)
+	}}
+	orphanedInstanceProfiles = orphanedResources{kind: "instance profile", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		if awsCluster.Status.InstanceProfiles == nil {
+			return nil
+		}
+		return nonEmpty(awsCluster.Status.InstanceProfiles.ControlPlane, awsCluster.Status.InstanceProfiles.Nodes)
+	}}
+	orphanedSecurityGroups = orphanedResources{kind: "security group", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		return securityGroupIDs(awsCluster.Status.Network.SecurityGroups)
+	}}
+	orphanedStandbyNetwork = orphanedResources{kind: "standby network resource", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		status := awsCluster.Status.StandbyNetwork
+		if status == nil {
+			return nil
+		}
+		return append(networkResourceIDs(status.VPC, status.Subnets), securityGroupIDs(status.SecurityGroups)...)
+	}}
+	orphanedNetwork = orphanedResources{kind: "network resource", resources: func(awsCluster *infrav1.AWSCluster) []string {
+		network := awsCluster.Spec.NetworkSpec
+		if network.VPC.IsUnmanaged(awsCluster.Labels[clusterv1.ClusterNameLabel]) {
+			return nil
+		}
+		return networkResourceIDs(network.VPC, network.Subnets)
+	}}
+	// The resources of the garbage collector are found by their tags, and aren't recorded in the AWSCluster.
+	orphanedTaggedResources = orphanedResources{kind: "tagged resource", resources: func(*infrav1.AWSCluster) []string {
+		return nil
+	}}
+
+	// allOrphanedResources lists all the AWS resources recorded in an AWSCluster, in the order of their deletion.
+	allOrphanedResources = []orphanedResources{
+		orphanedOIDCProvider,
+		orphanedS3Bucket,
+		orphanedAPIServerHealthCheck,
+		orphanedLoadBalancers,
+		orphanedBastion,
+		orphanedInstanceProfiles,
+		orphanedSecurityGroups,
+		orphanedStandbyNetwork,
+		orphanedNetwork,
+	}
+)
+
+// isForceDeleted returns whether the deletion of an AWSCluster is forced with the force-delete annotation.
+func isForceDeleted(awsCluster *infrav1.AWSCluster) bool {
+	return awsCluster.Annotations[infrav1.ForceDeleteAnnotation] == "true"
+}
+
+// orphanResources returns whether the AWS resources whose deletion failed with err are orphaned, which is the case
+// when the deletion of the AWSCluster is forced and err is an error of its credentials, which would fail every
+// further attempt. The orphaned resources are logged, and reported in a warning event each.
+func (r *AWSClusterReconciler) orphanResources(clusterScope *scope.ClusterScope, orphaned orphanedResources, err error) bool {
+	if !isForceDeleted(clusterScope.AWSCluster) || !awserrors.IsCredentialsError(err) {
+		return false
+	}
+
+	r.recordOrphanedResources(clusterScope.AWSCluster, orphaned, err)
+	clusterScope.Info("Force deleting AWSCluster, orphaning AWS resources", "kind", orphaned.kind, "resources", orphaned.resources(clusterScope.AWSCluster), "reason", err.Error())
+	return true
+}
+
+// forceDeleteWithoutScope removes the finalizer of a force deleted AWSCluster whose scope, and so whose AWS session,
+// can't be created, orphaning all the AWS resources recorded in the AWSCluster.
+func (r *AWSClusterReconciler) forceDeleteWithoutScope(ctx context.Context, log *logger.Logger, awsCluster *infrav1.AWSCluster, scopeErr error) error {
+	patchHelper, err := patch.NewHelper(awsCluster, r.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+
+	for _, orphaned := range allOrphanedResources {
+		if resources := orphaned.resources(awsCluster); len(resources) > 0 {
+			r.recordOrphanedResources(awsCluster, orphaned, scopeErr)
+			log.Info("Force deleting AWSCluster, orphaning AWS resources", "kind", orphaned.kind, "resources", resources, "reason", scopeErr.Error())
+		}
+	}
+
+	controllerutil.RemoveFinalizer(awsCluster, infrav1.ClusterFinalizer)
+	return patchHelper.Patch(ctx, awsCluster)
+}
+
+// recordOrphanedResources records a warning event for each orphaned AWS resource, or for their kind when none is
+// recorded in the AWSCluster.
+func (r *AWSClusterReconciler) recordOrphanedResources(awsCluster *infrav1.AWSCluster, orphaned orphanedResources, err error) {
+	resources := orphaned.resources(awsCluster)
+	if len(resources) == 0 {
+		r.Recorder.Eventf(awsCluster, corev1.EventTypeWarning, "OrphanedResources", "Force deleting, not deleting the %ss of the cluster: %v", orphaned.kind, err)
+		return
+	}
+	for _, resource := range resources {
+		r.Recorder.Eventf(awsCluster, corev1.EventTypeWarning, "OrphanedResources", "Force deleting, orphaning %s %s: %v", orphaned.kind, resource, err)
+	}
+}
+
+// networkResourceIDs returns the IDs of the VPC, the gateways, the subnets and the route tables of a network.
+func networkResourceIDs(vpc infrav1.VPCSpec, subnets infrav1.Subnets) []string {
+	ids := nonEmpty(vpc.ID)
+	if vpc.InternetGatewayID != nil {
+		ids = append(ids, nonEmpty(*vpc.InternetGatewayID)...)
+	}
+	if vpc.CarrierGatewayID != nil {
+		ids = append(ids, nonEmpty(*vpc.CarrierGatewayID)...)
+	}
+	for _, subnet := range subnets {
+		ids = append(ids, nonEmpty(subnet.ResourceID)...)
+		if subnet.NatGatewayID != nil {
+			ids = append(ids, nonEmpty(*subnet.NatGatewayID)...)
+		}
+		if subnet.RouteTableID != nil {
+			ids = append(ids, nonEmpty(*subnet.RouteTableID)...)
+		}
+	}
+	return ids
+}
+
+// securityGroupIDs returns the sorted IDs of security groups.
+func securityGroupIDs(securityGroups map[infrav1.SecurityGroupRole]infrav1.SecurityGroup) []string {
+	ids := []string{}
+	for _, sg := range securityGroups {
+		ids = append(ids, nonEmpty(sg.ID)...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// nonEmpty returns the non-empty values.
+func nonEmpty(values ...string) []string {
+	ids := []string{}
+	for _, v := range values {
+		if v != "" {
+			ids = append(ids, v)
+		}
+	}
+	return ids
+}
@@ -18,8 +18,10 @@ package controllers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -32,7 +34,9 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -49,11 +53,13 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/pricing"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/secretsmanager"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm"
@@ -73,6 +79,9 @@ const (
 
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 30 * time.Second
+
+	// maxUserDataSize is the maximum size in bytes of the user data of an EC2 instance, before base64 encoding.
+	maxUserDataSize = 16 * 1024
 )
 
 // AWSMachineReconciler reconciles a AwsMachine object.
@@ -85,14 +94,37 @@ type AWSMachineReconciler struct {
 	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
 	SSMServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
 	objectStoreServiceFactory    func(cloud.ClusterScoper) services.ObjectStoreInterface
+	pricingServiceFactory        func(cloud.ClusterScoper) services.PricingInterface
 	Endpoints                    []scope.ServiceEndpoint
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	// RemediateTerminatedInstances enables deleting the Machines whose EC2 instance was terminated
+	// outside of Cluster API, so that their owner creates a replacement.
+	RemediateTerminatedInstances bool
+	// NodeJoinTimeout is how long after its instance started running the node of a machine is expected to join
+	// the cluster, before the console output and the SSM agent status of the instance are collected to help
+	// debugging its bootstrap. Zero disables collecting them.
+	NodeJoinTimeout time.Duration
+	// ResyncPeriod is how long the reconciliations of a ready AWSMachine whose instance is running and whose desired
+	// state is unchanged are skipped after the reconciliation of its instance. Zero reconciles it every time.
+	ResyncPeriod time.Duration
 }
 
 const (
 	// AWSManagedControlPlaneRefKind is the string value indicating that a cluster is AWS managed.
 	AWSManagedControlPlaneRefKind = "AWSManagedControlPlane"
+
+	// consoleOutputTailLines is the number of lines of the console output of the instances whose node didn't
+	// join the cluster reported in the NodeJoined condition.
+	consoleOutputTailLines = 20
+
+	// ssmConnectivityCheckInterval is how often the SSM agent of the instances of the clusters accessed with Systems
+	// Manager is checked until it is online.
+	ssmConnectivityCheckInterval = time.Minute
+
+	// connectionDrainingCheckInterval is how often the connections of a deleted control plane instance de-registered
+	// from the control plane load balancers are checked until they are drained.
+	connectionDrainingCheckInterval = 10 * time.Second
 )
 
 func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2Interface {
@@ -143,15 +175,28 @@ func (r *AWSMachineReconciler) getObjectStoreService(scope scope.S3Scope) servic
 	return s3.NewService(scope)
 }
 
+func (r *AWSMachineReconciler) getPricingService(scope cloud.ClusterScoper) services.PricingInterface {
+	if r.pricingServiceFactory != nil {
+		return r.pricingServiceFactory(scope)
+	}
+
+	return pricing.NewService(scope)
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=delete
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
-func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("AWSMachine", res, reterr)
+	}()
+
 	log := logger.FromContext(ctx)
 
 	// Fetch the AWSMachine instance.
@@ -221,16 +266,23 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}()
 
+	failed := machineScope.HasFailed()
+	defer func() {
+		if !failed && machineScope.HasFailed() {
+			awsmetrics.RecordTerminalFailure("AWSMachine", string(ptr.Deref(awsMachine.Status.FailureReason, "")))
+		}
+	}()
+
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.reconcileDelete(machineScope, infraScope, infraScope, nil, nil)
+			return r.reconcileDelete(ctx, machineScope, infraScope, infraScope, nil, nil)
 		}
 
 		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, nil)
 	case *scope.ClusterScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.reconcileDelete(machineScope, infraScope, infraScope, infraScope, infraScope)
+			return r.reconcileDelete(ctx, machineScope, infraScope, infraScope, infraScope, infraScope)
 		}
 
 		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, infraScope, infraScope)
@@ -298,8 +350,9 @@ func (r *AWSMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 	)
 }
 
-func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
+func (r *AWSMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
 	machineScope.Info("Handling deleted AWSMachine")
+	awsmetrics.DeleteEstimatedHourlyCost("AWSMachine", machineScope.Namespace(), machineScope.Name())
 
 	ec2Service := r.getEC2Service(ec2Scope)
 
@@ -308,7 +361,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		return ctrl.Result{}, err
 	}
 
-	instance, err := r.findInstance(machineScope, ec2Service)
+	instance, err := r.findInstance(ctx, machineScope, ec2Service)
 	if err != nil && err != ec2.ErrInstanceNotFoundByID {
 		machineScope.Error(err, "query to find instance failed")
 		return ctrl.Result{}, err
@@ -330,7 +383,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 
 	machineScope.Debug("EC2 instance found matching deleted AWSMachine", "instance-id", instance.ID)
 
-	if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
+	if err := r.reconcileLBAttachment(ctx, machineScope, elbScope, instance); err != nil {
 		// We are tolerating AccessDenied error, so this won't block for users with older version of IAM;
 		// all the other errors are blocking.
 		// Because we are reconciling all load balancers, attempt to treat the error as a list of errors.
@@ -340,7 +393,23 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		}
 	}
 
-	if machineScope.IsControlPlane() {
+	if err := r.reconcileLoadBalancerAttachments(ctx, machineScope, elbScope, instance); err != nil {
+		if err = kerrors.FilterOut(err, elb.IsAccessDenied, elb.IsNotFound); err != nil {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, errors.Errorf("failed to reconcile load balancer attachments: %+v", err)
+		}
+	}
+
+	// Wait for the connections to the de-registered instance to be drained before terminating it.
+	requeueAfter, err := r.reconcileLBConnectionDraining(ctx, machineScope, elbScope, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if machineScope.IsControlPlane() || machineScope.AWSMachine.Spec.LoadBalancerAttachments != nil {
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	}
 
@@ -372,7 +441,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			return ctrl.Result{}, err
 		}
 
-		if err := ec2Service.TerminateInstance(instance.ID); err != nil {
+		if err := ec2Service.TerminateInstance(ctx, instance.ID); err != nil {
 			machineScope.Error(err, "failed to terminate instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedTerminate", "Failed to terminate instance %q: %v", instance.ID, err)
@@ -382,7 +451,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 
 		// If the AWSMachine specifies NetworkStatus Interfaces, detach the cluster's core Security Groups from them as part of deletion.
 		if len(machineScope.AWSMachine.Spec.NetworkInterfaces) > 0 {
-			core, err := ec2Service.GetCoreSecurityGroups(machineScope)
+			core, err := ec2Service.GetCoreSecurityGroups(ctx, machineScope)
 			if err != nil {
 				machineScope.Error(err, "failed to get core security groups to detach from instance's network interfaces")
 				return ctrl.Result{}, err
@@ -400,7 +469,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 			}
 
 			for _, id := range machineScope.AWSMachine.Spec.NetworkInterfaces {
-				if err := ec2Service.DetachSecurityGroupsFromNetworkInterface(core, id); err != nil {
+				if err := ec2Service.DetachSecurityGroupsFromNetworkInterface(ctx, core, id); err != nil {
 					machineScope.Error(err, "failed to detach security groups from instance's network interfaces")
 					conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 					return ctrl.Result{}, err
@@ -420,7 +489,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 // findInstance queries the EC2 apis and retrieves the instance if it exists.
 // If providerID is empty, finds instance by tags and if it cannot be found, returns empty instance with nil error.
 // If providerID is set, either finds the instance by ID or returns error.
-func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec2svc services.EC2Interface) (*infrav1.Instance, error) {
+func (r *AWSMachineReconciler) findInstance(ctx context.Context, machineScope *scope.MachineScope, ec2svc services.EC2Interface) (*infrav1.Instance, error) {
 	var instance *infrav1.Instance
 
 	// Parse the ProviderID.
@@ -432,7 +501,7 @@ func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec
 		}
 		// If the ProviderID is empty, try to query the instance using tags.
 		// If an instance cannot be found, GetRunningInstanceByTags returns empty instance with nil error.
-		instance, err = ec2svc.GetRunningInstanceByTags(machineScope)
+		instance, err = ec2svc.GetRunningInstanceByTags(ctx, machineScope)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to query AWSMachine instance by tags")
 		}
@@ -440,7 +509,7 @@ func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec
 		// If the ProviderID is populated, describe the instance using the ID.
 		// InstanceIfExists() returns error (ErrInstanceNotFoundByID or ErrDescribeInstance) if the instance could not be found.
 		//nolint:staticcheck
-		instance, err = ec2svc.InstanceIfExists(ptr.To[string](pid.ID()))
+		instance, err = ec2svc.InstanceIfExists(ctx, ptr.To[string](pid.ID()))
 		if err != nil {
 			return nil, err
 		}
@@ -450,7 +519,7 @@ func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec
 	return instance, nil
 }
 
-func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
+func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
 	machineScope.Trace("Reconciling AWSMachine")
 
 	// If the AWSMachine is in an error state, return early.
@@ -463,6 +532,13 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			return ctrl.Result{}, err
 		}
 
+		if state := machineScope.GetInstanceState(); state != nil && *state == infrav1.InstanceStateTerminated {
+			if err := r.remediateTerminatedInstance(ctx, machineScope); err != nil {
+				machineScope.Error(err, "unable to remediate terminated instance")
+				return ctrl.Result{}, err
+			}
+		}
+
 		return ctrl.Result{}, nil
 	}
 
@@ -479,10 +555,31 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		return ctrl.Result{}, nil
 	}
 
+	if err := reconcileRequiredTags(machineScope.AWSMachine, machineScope.AdditionalTags(), machineScope.RequiredTags()); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "MissingRequiredTags", "Not creating the AWS resources of the machine: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	stateHash, err := awsMachineStateHash(machineScope, clusterScope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// The instance is reconciled until its node joined the cluster, and when the instance state labels of the
+	// AWSMachine are updated by instance state change events.
+	if state := machineScope.GetInstanceState(); machineScope.AWSMachine.Status.Ready && state != nil && *state == infrav1.InstanceStateRunning &&
+		machineScope.Machine.Status.NodeRef != nil {
+		if requeueAfter := resyncAfter(machineScope.AWSMachine.Status.LastReconciliation, stateHash, machineScope.AWSMachine.Status.Conditions, r.ResyncPeriod, time.Now()); requeueAfter > 0 {
+			machineScope.Debug("Skipping reconciliation of unchanged AWSMachine", "resyncAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+	// Only a successful reconciliation is recorded.
+	machineScope.AWSMachine.Status.LastReconciliation = nil
+
 	ec2svc := r.getEC2Service(ec2Scope)
 
 	// Find existing instance
-	instance, err := r.findInstance(machineScope, ec2svc)
+	instance, err := r.findInstance(ctx, machineScope, ec2svc)
 	if err != nil {
 		machineScope.Error(err, "unable to find instance")
 		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, err.Error())
@@ -515,7 +612,7 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			objectStoreSvc = r.getObjectStoreService(objectStoreScope)
 		}
 
-		instance, err = r.createInstance(ec2svc, machineScope, clusterScope, objectStoreSvc)
+		instance, err = r.createInstance(ctx, ec2svc, machineScope, clusterScope, objectStoreSvc)
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -537,6 +634,10 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// Sets the AWSMachine status Interruptible, when the SpotMarketOptions is enabled for AWSMachine, Interruptible is set as true.
 	machineScope.SetInterruptible()
 
+	if instance.ImageID != "" {
+		machineScope.SetImageID(instance.ImageID)
+	}
+
 	existingInstanceState := machineScope.GetInstanceState()
 	machineScope.SetInstanceState(instance.State)
 
@@ -578,34 +679,55 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	}
 
 	if instance.State == infrav1.InstanceStateTerminated {
+		// The failure is reported on the Machine, which MachineHealthChecks consider unhealthy.
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
 		machineScope.SetFailureMessage(errors.Errorf("EC2 instance state %q is unexpected", instance.State))
+
+		if err := r.remediateTerminatedInstance(ctx, machineScope); err != nil {
+			machineScope.Error(err, "unable to remediate terminated instance")
+			return ctrl.Result{}, err
+		}
 	}
 
 	// tasks that can take place during all known instance states
 	if machineScope.InstanceIsInKnownState() {
-		_, err = r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
+		_, err = r.ensureTags(ctx, ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
 		if err != nil {
 			machineScope.Error(err, "failed to ensure tags")
 			return ctrl.Result{}, err
 		}
 
 		if instance != nil {
-			r.ensureStorageTags(ec2svc, instance, machineScope.AWSMachine, machineScope.AdditionalTags())
+			r.ensureStorageTags(ctx, ec2svc, instance, machineScope.AWSMachine, machineScope.AdditionalTags())
 		}
 
-		if err := r.reconcileLBAttachment(machineScope, elbScope, instance); err != nil {
+		if err := r.reconcileLBAttachment(ctx, machineScope, elbScope, instance); err != nil {
 			machineScope.Error(err, "failed to reconcile LB attachment")
 			return ctrl.Result{}, err
 		}
+
+		if err := r.reconcileLoadBalancerAttachments(ctx, machineScope, elbScope, instance); err != nil {
+			machineScope.Error(err, "failed to reconcile load balancer attachments")
+			return ctrl.Result{}, err
+		}
 	}
 
 	// tasks that can only take place during operational instance states
+	var requeueAfter time.Duration
 	if machineScope.InstanceIsOperational() {
-		err := r.reconcileOperationalState(ec2svc, machineScope, instance)
+		err := r.reconcileOperationalState(ctx, ec2svc, machineScope, instance)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+
+		if feature.Gates.Enabled(feature.CostEstimation) {
+			r.reconcileCostEstimate(machineScope, clusterScope, instance)
+		}
+
+		requeueAfter = r.reconcileNodeJoin(ctx, ec2svc, machineScope)
+		if ssmWait := r.reconcileSSMConnectivity(ctx, ec2svc, machineScope); ssmWait > 0 && (requeueAfter == 0 || ssmWait < requeueAfter) {
+			requeueAfter = ssmWait
+		}
 	}
 
 	machineScope.Debug("done reconciling instance", "instance", instance)
@@ -613,20 +735,138 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		machineScope.Debug("but find the instance is pending, requeue", "instance", instance.ID)
 		return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
 	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if r.ResyncPeriod > 0 && instance.State == infrav1.InstanceStateRunning {
+		machineScope.AWSMachine.Status.LastReconciliation = lastReconciliation(stateHash, time.Now())
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
-func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
+// reconcileNodeJoin collects the tail of the console output and the SSM agent status of the instance when its node
+// doesn't join the cluster within the node join timeout, and reports them in the NodeJoined condition and in an
+// event, so that the reason the instance failed to bootstrap can be found without logging into it. The diagnostics
+// are collected once per machine; it returns how long to wait before they are due.
+func (r *AWSMachineReconciler) reconcileNodeJoin(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope) time.Duration {
+	awsMachine := machineScope.AWSMachine
+	if machineScope.Machine.Status.NodeRef != nil {
+		if conditions.Has(awsMachine, infrav1.NodeJoinedCondition) {
+			conditions.MarkTrue(awsMachine, infrav1.NodeJoinedCondition)
+		}
+		return 0
+	}
+	if r.NodeJoinTimeout <= 0 || !conditions.IsTrue(awsMachine, infrav1.InstanceReadyCondition) ||
+		conditions.GetReason(awsMachine, infrav1.NodeJoinedCondition) == infrav1.NodeJoinTimeoutReason {
+		return 0
+	}
+	if wait := r.NodeJoinTimeout - time.Since(conditions.GetLastTransitionTime(awsMachine, infrav1.InstanceReadyCondition).Time); wait > 0 {
+		return wait
+	}
+
+	instanceID := *machineScope.GetInstanceID()
+	ssmPingStatus, err := ec2svc.GetSSMPingStatus(ctx, instanceID)
+	if err != nil {
+		machineScope.Error(err, "failed to get the SSM agent status of the instance")
+		ssmPingStatus = "Unknown"
+	}
+	consoleOutput, err := ec2svc.GetConsoleOutputTail(ctx, instanceID, consoleOutputTailLines)
+	if err != nil {
+		machineScope.Error(err, "failed to get the console output of the instance")
+		consoleOutput = "unavailable"
+	} else if consoleOutput == "" {
+		consoleOutput = "none yet"
+	}
+
+	message := fmt.Sprintf("The node of instance %s didn't join the cluster within %s of the instance running. SSM agent status: %s. Console output:\n%s",
+		instanceID, r.NodeJoinTimeout, ssmPingStatus, consoleOutput)
+	conditions.MarkFalse(awsMachine, infrav1.NodeJoinedCondition, infrav1.NodeJoinTimeoutReason, clusterv1.ConditionSeverityWarning, "%s", message)
+	r.Recorder.Event(awsMachine, corev1.EventTypeWarning, "NodeJoinTimeout", message)
+	return 0
+}
+
+// reconcileCostEstimate sets the estimated hourly cost of the instance in the status of the AWSMachine, and exports
+// it as a metric. The estimate is informational, so failing to get it doesn't fail the reconciliation.
+func (r *AWSMachineReconciler) reconcileCostEstimate(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, instance *infrav1.Instance) {
+	estimate, err := r.getPricingService(clusterScope).EstimateCost([]pricing.Instance{{
+		InstanceType:     instance.Type,
+		AvailabilityZone: instance.AvailabilityZone,
+		Spot:             machineScope.AWSMachine.Spec.SpotMarketOptions != nil,
+	}})
+	if err != nil {
+		machineScope.Error(err, "failed to estimate the cost of the instance")
+		return
+	}
+
+	machineScope.AWSMachine.Status.CostEstimate = pricing.UpdatedEstimate(machineScope.AWSMachine.Status.CostEstimate, estimate)
+	if cost, err := strconv.ParseFloat(estimate.HourlyCost, 64); err == nil {
+		awsmetrics.RecordEstimatedHourlyCost("AWSMachine", machineScope.Namespace(), machineScope.Name(), machineScope.Machine.Spec.ClusterName, estimate.Currency, cost)
+	}
+}
+
+// reconcileSSMConnectivity reports whether the instance is registered with Systems Manager in the SSMConnectivity
+// condition, for the clusters whose instances are accessed with Systems Manager rather than with SSH keys. The SSM
+// agent isn't checked anymore once it is online; until then, it returns how long to wait before checking it again.
+func (r *AWSMachineReconciler) reconcileSSMConnectivity(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope) time.Duration {
+	awsMachine := machineScope.AWSMachine
+	if machineScope.InfraCluster.InstanceAccessMode() != infrav1.InstanceAccessModeSSM {
+		conditions.Delete(awsMachine, infrav1.SSMConnectivityCondition)
+		return 0
+	}
+	if conditions.IsTrue(awsMachine, infrav1.SSMConnectivityCondition) {
+		return 0
+	}
+
+	instanceID := *machineScope.GetInstanceID()
+	pingStatus, err := ec2svc.GetSSMPingStatus(ctx, instanceID)
+	if err != nil {
+		machineScope.Error(err, "failed to get the SSM agent status of the instance")
+		return ssmConnectivityCheckInterval
+	}
+
+	switch pingStatus {
+	case ec2.SSMPingStatusOnline:
+		conditions.MarkTrue(awsMachine, infrav1.SSMConnectivityCondition)
+		return 0
+	case ec2.SSMPingStatusNotRegistered:
+		conditions.MarkFalse(awsMachine, infrav1.SSMConnectivityCondition, infrav1.SSMAgentNotRegisteredReason, clusterv1.ConditionSeverityWarning,
+			"instance %s isn't registered with Systems Manager, its instance profile must allow the SSM agent to register it and it must reach the SSM endpoints", instanceID)
+	default:
+		conditions.MarkFalse(awsMachine, infrav1.SSMConnectivityCondition, infrav1.SSMAgentOfflineReason, clusterv1.ConditionSeverityWarning,
+			"the SSM agent of instance %s is %s", instanceID, pingStatus)
+	}
+	return ssmConnectivityCheckInterval
+}
+
+// remediateTerminatedInstance deletes the Machine of an EC2 instance terminated outside of Cluster API,
+// so that the MachineSet or the control plane owning it creates a replacement. Machines without
+// a controller are not deleted, as nothing would replace them.
+func (r *AWSMachineReconciler) remediateTerminatedInstance(ctx context.Context, machineScope *scope.MachineScope) error {
+	if !r.RemediateTerminatedInstances || !machineScope.Machine.DeletionTimestamp.IsZero() || metav1.GetControllerOf(machineScope.Machine) == nil {
+		return nil
+	}
+
+	machineScope.Info("Deleting Machine of terminated EC2 instance", "machine", klog.KObj(machineScope.Machine))
+	if err := r.Client.Delete(ctx, machineScope.Machine); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Machine %q", machineScope.Machine.Name)
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "TerminatedInstanceRemediated", "Deleted Machine %q of terminated EC2 instance", machineScope.Machine.Name)
+	return nil
+}
+
+func (r *AWSMachineReconciler) reconcileOperationalState(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope, instance *infrav1.Instance) error {
 	machineScope.SetAddresses(instance.Addresses)
+	reconcileInstanceAttachments(machineScope, instance)
 
-	existingSecurityGroups, err := ec2svc.GetInstanceSecurityGroups(*machineScope.GetInstanceID())
+	existingSecurityGroups, err := ec2svc.GetInstanceSecurityGroups(ctx, *machineScope.GetInstanceID())
 	if err != nil {
 		machineScope.Error(err, "unable to get instance security groups")
 		return err
 	}
 
 	// Ensure that the security groups are correct.
-	_, err = r.ensureSecurityGroups(ec2svc, machineScope, machineScope.AWSMachine.Spec.AdditionalSecurityGroups, existingSecurityGroups)
+	_, err = r.ensureSecurityGroups(ctx, ec2svc, machineScope, machineScope.AWSMachine.Spec.AdditionalSecurityGroups, existingSecurityGroups)
 	if err != nil {
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, infrav1.SecurityGroupsFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		machineScope.Error(err, "unable to ensure security groups")
@@ -634,7 +874,7 @@ func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Inte
 	}
 	conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
 
-	err = r.ensureInstanceMetadataOptions(ec2svc, instance, machineScope.AWSMachine)
+	err = r.ensureInstanceMetadataOptions(ctx, ec2svc, instance, machineScope.AWSMachine)
 	if err != nil {
 		machineScope.Error(err, "failed to ensure instance metadata options")
 		return err
@@ -643,6 +883,27 @@ func (r *AWSMachineReconciler) reconcileOperationalState(ec2svc services.EC2Inte
 	return nil
 }
 
+// reconcileInstanceAttachments reports whether the public IP address and the volumes of the AWSMachine are attached
+// to its instance with the EIPAssociated and VolumesAttached conditions.
+func reconcileInstanceAttachments(machineScope *scope.MachineScope, instance *infrav1.Instance) {
+	if ptr.Deref(machineScope.AWSMachine.Spec.PublicIP, false) {
+		if ptr.Deref(instance.PublicIP, "") == "" {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.EIPAssociatedCondition, infrav1.WaitingForPublicIPReason, clusterv1.ConditionSeverityInfo, "")
+		} else {
+			conditions.MarkTrue(machineScope.AWSMachine, infrav1.EIPAssociatedCondition)
+		}
+	}
+
+	// The root volume is attached along with the non-root ones.
+	expected := 1 + len(machineScope.AWSMachine.Spec.NonRootVolumes)
+	if attached := len(instance.VolumeIDs); attached < expected {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesAttachedCondition, infrav1.VolumesNotAttachedReason, clusterv1.ConditionSeverityWarning,
+			"%d of %d volumes are attached to instance %s", attached, expected, instance.ID)
+		return
+	}
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.VolumesAttachedCondition)
+}
+
 func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) error {
 	secretSvc, secretBackendErr := r.getSecretService(machineScope, clusterScope)
 	if secretBackendErr != nil {
@@ -676,7 +937,7 @@ func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *
 	return nil
 }
 
-func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2Interface, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, objectStoreSvc services.ObjectStoreInterface) (*infrav1.Instance, error) {
+func (r *AWSMachineReconciler) createInstance(ctx context.Context, ec2svc services.EC2Interface, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, objectStoreSvc services.ObjectStoreInterface) (*infrav1.Instance, error) {
 	machineScope.Info("Creating EC2 instance")
 
 	userData, userDataFormat, userDataErr := r.resolveUserData(machineScope, clusterScope, objectStoreSvc)
@@ -684,7 +945,7 @@ func (r *AWSMachineReconciler) createInstance(ec2svc services.EC2Interface, mach
 		return nil, errors.Wrapf(userDataErr, "failed to resolve userdata")
 	}
 
-	instance, err := ec2svc.CreateInstance(machineScope, userData, userDataFormat)
+	instance, err := ec2svc.CreateInstance(ctx, machineScope, userData, userDataFormat)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create AWSMachine instance")
 	}
@@ -703,6 +964,17 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		userData, err = r.cloudInitUserData(machineScope, clusterScope, userData)
 	}
 
+	if machineScope.IsWindows() {
+		// The webhook rejects Windows machines that don't skip AWS Secrets Manager, but machines created before
+		// it did still have to be warned that their user data is stored in plain text.
+		if !machineScope.AWSMachine.Spec.CloudInit.InsecureSkipSecretsManager {
+			machineScope.Info("Windows instances can't retrieve their user data from AWS Secrets Manager, storing it in plain text")
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "SecretsManagerNotSupported",
+				"Windows instances can't retrieve their user data from AWS Secrets Manager, it is stored in plain text in the user data of the instance. Set spec.cloudInit.insecureSkipSecretsManager to true.")
+		}
+		userData = userdata.WindowsUserData(userData)
+	}
+
 	if machineScope.UseIgnition(userDataFormat) {
 		var ignitionStorageType infrav1.IgnitionStorageTypeOption
 		if machineScope.AWSMachine.Spec.Ignition == nil {
@@ -715,7 +987,7 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		case infrav1.IgnitionStorageTypeOptionClusterObjectStore:
 			userData, err = r.generateIgnitionWithRemoteStorage(machineScope, objectStoreSvc, userData)
 		case infrav1.IgnitionStorageTypeOptionUnencryptedUserData:
-			// No further modifications to userdata are needed for plain storage in UnencryptedUserData.
+			userData, err = r.generateIgnitionWithUserData(machineScope, userData)
 		default:
 			return nil, "", errors.Errorf("unsupported ignition storageType %q", ignitionStorageType)
 		}
@@ -794,42 +1066,89 @@ func (r *AWSMachineReconciler) generateIgnitionWithRemoteStorage(scope *scope.Ma
 
 		return json.Marshal(ignData)
 	case 3:
-		ignData := &ignV3Types.Config{
-			Ignition: ignV3Types.Ignition{
-				Version: semver.String(),
-				Config: ignV3Types.IgnitionConfig{
-					Merge: []ignV3Types.Resource{
-						{
-							Source: aws.String(objectURL),
-						},
-					},
-				},
-			},
+		return json.Marshal(newIgnitionV3Config(scope, semver, ignV3Types.Resource{Source: aws.String(objectURL)}))
+	default:
+		return nil, errors.Errorf("unsupported ignition version %q", ignVersion)
+	}
+}
+
+// generateIgnitionWithUserData returns the user data of an instance embedding its Ignition config.
+// With Ignition v3, a config that is fetched with proxy or TLS settings, or that is too large for
+// the user data, is merged from a compressed data URL into a config with those settings.
+func (r *AWSMachineReconciler) generateIgnitionWithUserData(scope *scope.MachineScope, userData []byte) ([]byte, error) {
+	ignVersion := getIgnitionVersion(scope)
+	semver, err := semver.ParseTolerant(ignVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse ignition version %q", ignVersion)
+	}
+
+	ignition := scope.AWSMachine.Spec.Ignition
+	if semver.Major == 3 && (ignition.Proxy != nil || ignition.TLS != nil || len(userData) > maxUserDataSize) {
+		resource, err := ignitionDataURLResource(semver, userData)
+		if err != nil {
+			return nil, err
+		}
+		userData, err = json.Marshal(newIgnitionV3Config(scope, semver, resource))
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		if scope.AWSMachine.Spec.Ignition.Proxy != nil {
-			ignData.Ignition.Proxy = ignV3Types.Proxy{
-				HTTPProxy:  scope.AWSMachine.Spec.Ignition.Proxy.HTTPProxy,
-				HTTPSProxy: scope.AWSMachine.Spec.Ignition.Proxy.HTTPSProxy,
-			}
-			for _, noProxy := range scope.AWSMachine.Spec.Ignition.Proxy.NoProxy {
-				ignData.Ignition.Proxy.NoProxy = append(ignData.Ignition.Proxy.NoProxy, ignV3Types.NoProxyItem(noProxy))
-			}
+	if len(userData) > maxUserDataSize {
+		return nil, errors.Errorf("the Ignition config is %d bytes, more than the %d bytes allowed in EC2 user data: "+
+			"use the ClusterObjectStore storage type instead", len(userData), maxUserDataSize)
+	}
+	return userData, nil
+}
+
+// ignitionDataURLResource returns a resource embedding data in a data URL (RFC 2397), compressed
+// with gzip for the versions of Ignition supporting it.
+func ignitionDataURLResource(version semver.Version, data []byte) (ignV3Types.Resource, error) {
+	resource := ignV3Types.Resource{}
+	if version.Minor >= 1 {
+		compressed, err := userdata.GzipBytes(data)
+		if err != nil {
+			return resource, err
 		}
+		data = compressed
+		resource.Compression = aws.String("gzip")
+	}
+	resource.Source = aws.String("data:;base64," + base64.StdEncoding.EncodeToString(data))
+	return resource, nil
+}
 
-		if scope.AWSMachine.Spec.Ignition.TLS != nil {
-			for _, cert := range scope.AWSMachine.Spec.Ignition.TLS.CASources {
-				ignData.Ignition.Security.TLS.CertificateAuthorities = append(
-					ignData.Ignition.Security.TLS.CertificateAuthorities,
-					ignV3Types.Resource{Source: aws.String(string(cert))},
-				)
-			}
+// newIgnitionV3Config returns an Ignition v3 config merging the given config, with the proxy and
+// TLS settings of the AWSMachine.
+func newIgnitionV3Config(scope *scope.MachineScope, version semver.Version, config ignV3Types.Resource) *ignV3Types.Config {
+	ignData := &ignV3Types.Config{
+		Ignition: ignV3Types.Ignition{
+			Version: version.String(),
+			Config: ignV3Types.IgnitionConfig{
+				Merge: []ignV3Types.Resource{config},
+			},
+		},
+	}
+
+	if scope.AWSMachine.Spec.Ignition.Proxy != nil {
+		ignData.Ignition.Proxy = ignV3Types.Proxy{
+			HTTPProxy:  scope.AWSMachine.Spec.Ignition.Proxy.HTTPProxy,
+			HTTPSProxy: scope.AWSMachine.Spec.Ignition.Proxy.HTTPSProxy,
 		}
+		for _, noProxy := range scope.AWSMachine.Spec.Ignition.Proxy.NoProxy {
+			ignData.Ignition.Proxy.NoProxy = append(ignData.Ignition.Proxy.NoProxy, ignV3Types.NoProxyItem(noProxy))
+		}
+	}
 
-		return json.Marshal(ignData)
-	default:
-		return nil, errors.Errorf("unsupported ignition version %q", ignVersion)
+	if scope.AWSMachine.Spec.Ignition.TLS != nil {
+		for _, cert := range scope.AWSMachine.Spec.Ignition.TLS.CASources {
+			ignData.Ignition.Security.TLS.CertificateAuthorities = append(
+				ignData.Ignition.Security.TLS.CertificateAuthorities,
+				ignV3Types.Resource{Source: aws.String(string(cert))},
+			)
+		}
 	}
+
+	return ignData
 }
 
 func getIgnitionVersion(scope *scope.MachineScope) string {
@@ -897,7 +1216,7 @@ func (r *AWSMachineReconciler) deleteIgnitionBootstrapDataFromS3(machineScope *s
 
 // reconcileLBAttachment reconciles attachment to _all_ defined load balancers.
 // Callers are expected to filter out known-good errors out of the aggregate error list.
-func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) error {
+func (r *AWSMachineReconciler) reconcileLBAttachment(ctx context.Context, machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) error {
 	if !machineScope.IsControlPlane() {
 		return nil
 	}
@@ -914,14 +1233,14 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 		if machineScope.AWSMachineIsDeleted() || machineScope.MachineIsDeleted() || !machineScope.InstanceIsRunning() {
 			if lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeClassic {
 				machineScope.Debug("deregistering from classic load balancer")
-				return r.deregisterInstanceFromClassicLB(machineScope, elbsvc, i)
+				return r.deregisterInstanceFromClassicLB(ctx, machineScope, elbsvc, i)
 			}
 			machineScope.Debug("deregistering from v2 load balancer")
-			errs = append(errs, r.deregisterInstanceFromV2LB(machineScope, elbsvc, i, lbSpec))
+			errs = append(errs, r.deregisterInstanceFromV2LB(ctx, machineScope, elbsvc, i, lbSpec))
 			continue
 		}
 
-		if err := r.registerInstanceToLBs(machineScope, elbsvc, i, lbSpec); err != nil {
+		if err := r.registerInstanceToLBs(ctx, machineScope, elbsvc, i, lbSpec); err != nil {
 			errs = append(errs, errors.Wrapf(err, "could not register machine to load balancer"))
 		}
 	}
@@ -929,21 +1248,163 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 	return kerrors.NewAggregate(errs)
 }
 
-func (r *AWSMachineReconciler) registerInstanceToLBs(machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
+// reconcileLBConnectionDraining waits for the connections of a deleted control plane instance de-registered from the
+// target groups of the control plane load balancers to be drained, for at most the connection draining timeout of each
+// load balancer. It returns when to check the connections again, or zero once they are drained.
+func (r *AWSMachineReconciler) reconcileLBConnectionDraining(ctx context.Context, machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) (time.Duration, error) {
+	if !machineScope.IsControlPlane() || i.State == infrav1.InstanceStateShuttingDown || i.State == infrav1.InstanceStateTerminated {
+		return 0, nil
+	}
+
+	// The draining started when the ELBAttached condition was first marked as draining.
+	drainingSince := time.Now()
+	if conditions.GetReason(machineScope.AWSMachine, infrav1.ELBAttachedCondition) == infrav1.ELBConnectionDrainingReason {
+		drainingSince = conditions.GetLastTransitionTime(machineScope.AWSMachine, infrav1.ELBAttachedCondition).Time
+	}
+
+	elbsvc := r.getELBService(elbScope)
+	for _, lbSpec := range elbScope.ControlPlaneLoadBalancers() {
+		if lbSpec == nil || lbSpec.ConnectionDrainingTimeout == nil || lbSpec.LoadBalancerType == infrav1.LoadBalancerTypeClassic {
+			continue
+		}
+		if time.Since(drainingSince) >= lbSpec.ConnectionDrainingTimeout.Duration {
+			machineScope.Info("Timed out waiting for the connections of the instance to be drained", "instance-id", i.ID)
+			continue
+		}
+
+		draining, err := elbsvc.IsInstanceDrainingFromAPIServerLB(ctx, i, lbSpec)
+		if err != nil {
+			// Like the deregistration, the draining is not blocking for users with older version of IAM.
+			if elb.IsAccessDenied(err) || elb.IsNotFound(err) {
+				continue
+			}
+			return 0, errors.Wrapf(err, "could not determine whether the connections of control plane instance %q are drained", i.ID)
+		}
+		if !draining {
+			continue
+		}
+
+		if conditions.GetReason(machineScope.AWSMachine, infrav1.ELBAttachedCondition) != infrav1.ELBConnectionDrainingReason {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "DrainingControlPlaneELB",
+				"Draining the connections of control plane instance %q before terminating it", i.ID)
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBConnectionDrainingReason, clusterv1.ConditionSeverityInfo,
+			"Draining the connections of the instance")
+		return connectionDrainingCheckInterval, nil
+	}
+
+	return 0, nil
+}
+
+// reconcileLoadBalancerAttachments registers the instance with the load balancers listed in the load balancer
+// attachments of the machine, and de-registers it from the ones removed from them, which are found in the load
+// balancers recorded in the status of the machine. The instance is de-registered from all of them when the machine
+// is deleted or the instance is not running.
+func (r *AWSMachineReconciler) reconcileLoadBalancerAttachments(ctx context.Context, machineScope *scope.MachineScope, elbScope scope.ELBScope, i *infrav1.Instance) error {
+	attachments := machineScope.AWSMachine.Spec.LoadBalancerAttachments
+	registered := machineScope.AWSMachine.Status.LoadBalancerAttachments
+	if attachments == nil && registered == nil {
+		return nil
+	}
+
+	elbsvc := r.getELBService(elbScope)
+
+	if machineScope.AWSMachineIsDeleted() || machineScope.MachineIsDeleted() || !machineScope.InstanceIsRunning() {
+		machineScope.Debug("deregistering from attached load balancers")
+		if err := elbsvc.DeregisterInstanceFromLoadBalancerAttachments(ctx, i, unionLoadBalancerAttachments(attachments, registered)); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachLoadBalancers",
+				"Failed to deregister instance %q from attached load balancers: %v", i.ID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return errors.Wrapf(err, "could not deregister instance %q from attached load balancers", i.ID)
+		}
+		machineScope.AWSMachine.Status.LoadBalancerAttachments = nil
+		return nil
+	}
+
+	if removed := removedLoadBalancerAttachments(attachments, registered); removed != nil {
+		machineScope.Info("deregistering from removed load balancers", "target-groups", removed.TargetGroupARNs, "load-balancers", removed.ClassicLoadBalancerNames)
+		if err := elbsvc.DeregisterInstanceFromLoadBalancerAttachments(ctx, i, removed); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachLoadBalancers",
+				"Failed to deregister instance %q from removed load balancers: %v", i.ID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return errors.Wrapf(err, "could not deregister instance %q from removed load balancers", i.ID)
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDetachLoadBalancers",
+			"Instance %q is deregistered from removed load balancers", i.ID)
+	}
+	machineScope.AWSMachine.Status.LoadBalancerAttachments = attachments.DeepCopy()
+	if attachments == nil {
+		// The condition of a control plane machine reports its registration with the load balancers of the cluster.
+		if !machineScope.IsControlPlane() {
+			conditions.Delete(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
+		}
+		return nil
+	}
+
+	if err := elbsvc.RegisterInstanceWithLoadBalancerAttachments(ctx, i, attachments); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachLoadBalancers",
+			"Failed to register instance %q with attached load balancers: %v", i.ID, err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBAttachFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrapf(err, "could not register instance %q with attached load balancers", i.ID)
+	}
+	if !conditions.IsTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition) {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulAttachLoadBalancers",
+			"Instance %q is registered with attached load balancers", i.ID)
+	}
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
+	return nil
+}
+
+// removedLoadBalancerAttachments returns the load balancers of the registered attachments which are no longer
+// listed in the attachments, or nil when there are none.
+func removedLoadBalancerAttachments(attachments, registered *infrav1.LoadBalancerAttachments) *infrav1.LoadBalancerAttachments {
+	if registered == nil {
+		return nil
+	}
+	if attachments == nil {
+		attachments = &infrav1.LoadBalancerAttachments{}
+	}
+
+	removed := &infrav1.LoadBalancerAttachments{
+		TargetGroupARNs:          sets.List(sets.New(registered.TargetGroupARNs...).Difference(sets.New(attachments.TargetGroupARNs...))),
+		ClassicLoadBalancerNames: sets.List(sets.New(registered.ClassicLoadBalancerNames...).Difference(sets.New(attachments.ClassicLoadBalancerNames...))),
+	}
+	if len(removed.TargetGroupARNs) == 0 && len(removed.ClassicLoadBalancerNames) == 0 {
+		return nil
+	}
+	return removed
+}
+
+// unionLoadBalancerAttachments returns the load balancers of both attachments.
+func unionLoadBalancerAttachments(attachments, registered *infrav1.LoadBalancerAttachments) *infrav1.LoadBalancerAttachments {
+	union := &infrav1.LoadBalancerAttachments{}
+	for _, a := range []*infrav1.LoadBalancerAttachments{attachments, registered} {
+		if a == nil {
+			continue
+		}
+		union.TargetGroupARNs = append(union.TargetGroupARNs, a.TargetGroupARNs...)
+		union.ClassicLoadBalancerNames = append(union.ClassicLoadBalancerNames, a.ClassicLoadBalancerNames...)
+	}
+	union.TargetGroupARNs = sets.List(sets.New(union.TargetGroupARNs...))
+	union.ClassicLoadBalancerNames = sets.List(sets.New(union.ClassicLoadBalancerNames...))
+	return union
+}
+
+func (r *AWSMachineReconciler) registerInstanceToLBs(ctx context.Context, machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
 	switch lb.LoadBalancerType {
 	case infrav1.LoadBalancerTypeClassic, "":
 		machineScope.Debug("registering to classic load balancer")
-		return r.registerInstanceToClassicLB(machineScope, elbsvc, i)
+		return r.registerInstanceToClassicLB(ctx, machineScope, elbsvc, i)
 	case infrav1.LoadBalancerTypeELB, infrav1.LoadBalancerTypeALB, infrav1.LoadBalancerTypeNLB:
 		machineScope.Debug("registering to v2 load balancer")
-		return r.registerInstanceToV2LB(machineScope, elbsvc, i, lb)
+		return r.registerInstanceToV2LB(ctx, machineScope, elbsvc, i, lb)
 	}
 
 	return errors.Errorf("unknown load balancer type %q", lb.LoadBalancerType)
 }
 
-func (r *AWSMachineReconciler) registerInstanceToClassicLB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance) error {
-	registered, err := elbsvc.IsInstanceRegisteredWithAPIServerELB(i)
+func (r *AWSMachineReconciler) registerInstanceToClassicLB(ctx context.Context, machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance) error {
+	registered, err := elbsvc.IsInstanceRegisteredWithAPIServerELB(ctx, i)
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachControlPlaneELB",
 			"Failed to register control plane instance %q with classic load balancer: failed to determine registration status: %v", i.ID, err)
@@ -954,7 +1415,7 @@ func (r *AWSMachineReconciler) registerInstanceToClassicLB(machineScope *scope.M
 		return nil
 	}
 
-	if err := elbsvc.RegisterInstanceWithAPIServerELB(i); err != nil {
+	if err := elbsvc.RegisterInstanceWithAPIServerELB(ctx, i); err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachControlPlaneELB",
 			"Failed to register control plane instance %q with classic load balancer: %v", i.ID, err)
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBAttachFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -966,8 +1427,8 @@ func (r *AWSMachineReconciler) registerInstanceToClassicLB(machineScope *scope.M
 	return nil
 }
 
-func (r *AWSMachineReconciler) registerInstanceToV2LB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
-	_, registered, err := elbsvc.IsInstanceRegisteredWithAPIServerLB(instance, lb)
+func (r *AWSMachineReconciler) registerInstanceToV2LB(ctx context.Context, machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
+	_, registered, err := elbsvc.IsInstanceRegisteredWithAPIServerLB(ctx, instance, lb)
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachControlPlaneELB",
 			"Failed to register control plane instance %q with load balancer: failed to determine registration status: %v", instance.ID, err)
@@ -978,7 +1439,7 @@ func (r *AWSMachineReconciler) registerInstanceToV2LB(machineScope *scope.Machin
 		return nil
 	}
 
-	if err := elbsvc.RegisterInstanceWithAPIServerLB(instance, lb); err != nil {
+	if err := elbsvc.RegisterInstanceWithAPIServerLB(ctx, instance, lb); err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachControlPlaneELB",
 			"Failed to register control plane instance %q with load balancer: %v", instance.ID, err)
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBAttachFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -990,8 +1451,8 @@ func (r *AWSMachineReconciler) registerInstanceToV2LB(machineScope *scope.Machin
 	return nil
 }
 
-func (r *AWSMachineReconciler) deregisterInstanceFromClassicLB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance) error {
-	registered, err := elbsvc.IsInstanceRegisteredWithAPIServerELB(instance)
+func (r *AWSMachineReconciler) deregisterInstanceFromClassicLB(ctx context.Context, machineScope *scope.MachineScope, elbsvc services.ELBInterface, instance *infrav1.Instance) error {
+	registered, err := elbsvc.IsInstanceRegisteredWithAPIServerELB(ctx, instance)
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
 			"Failed to deregister control plane instance %q from load balancer: failed to determine registration status: %v", instance.ID, err)
@@ -1002,7 +1463,7 @@ func (r *AWSMachineReconciler) deregisterInstanceFromClassicLB(machineScope *sco
 		return nil
 	}
 
-	if err := elbsvc.DeregisterInstanceFromAPIServerELB(instance); err != nil {
+	if err := elbsvc.DeregisterInstanceFromAPIServerELB(ctx, instance); err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
 			"Failed to deregister control plane instance %q from load balancer: %v", instance.ID, err)
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -1014,8 +1475,8 @@ func (r *AWSMachineReconciler) deregisterInstanceFromClassicLB(machineScope *sco
 	return nil
 }
 
-func (r *AWSMachineReconciler) deregisterInstanceFromV2LB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
-	targetGroupARNs, registered, err := elbsvc.IsInstanceRegisteredWithAPIServerLB(i, lb)
+func (r *AWSMachineReconciler) deregisterInstanceFromV2LB(ctx context.Context, machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
+	targetGroupARNs, registered, err := elbsvc.IsInstanceRegisteredWithAPIServerLB(ctx, i, lb)
 	if err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
 			"Failed to deregister control plane instance %q from load balancer: failed to determine registration status: %v", i.ID, err)
@@ -1027,7 +1488,7 @@ func (r *AWSMachineReconciler) deregisterInstanceFromV2LB(machineScope *scope.Ma
 	}
 
 	for _, targetGroupArn := range targetGroupARNs {
-		if err := elbsvc.DeregisterInstanceFromAPIServerLB(targetGroupArn, i); err != nil {
+		if err := elbsvc.DeregisterInstanceFromAPIServerLB(ctx, targetGroupArn, i); err != nil {
 			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
 				"Failed to deregister control plane instance %q from load balancer: %v", i.ID, err)
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -1135,6 +1596,7 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+			Context:                      ctx,
 			Client:                       r.Client,
 			Logger:                       log,
 			Cluster:                      cluster,
@@ -1164,6 +1626,7 @@ func (r *AWSMachineReconciler) getInfraCluster(ctx context.Context, log *logger.
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
+		Context:                      ctx,
 		Client:                       r.Client,
 		Logger:                       log,
 		Cluster:                      cluster,
@@ -1192,7 +1655,7 @@ func (r *AWSMachineReconciler) indexAWSMachineByInstanceID(o client.Object) []st
 	return nil
 }
 
-func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine, additionalTags map[string]string) {
+func (r *AWSMachineReconciler) ensureStorageTags(ctx context.Context, ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine, additionalTags map[string]string) {
 	prevAnnotations, err := r.machineAnnotationJSON(machine, VolumeTagsLastAppliedAnnotation)
 	if err != nil {
 		r.Log.Error(err, "Failed to fetch the annotations for volume tags")
@@ -1200,13 +1663,13 @@ func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, i
 	annotations := make(map[string]interface{}, len(instance.VolumeIDs))
 	for _, volumeID := range instance.VolumeIDs {
 		if subAnnotation, ok := prevAnnotations[volumeID].(map[string]interface{}); ok {
-			newAnnotation, err := r.ensureVolumeTags(ec2svc, aws.String(volumeID), subAnnotation, additionalTags)
+			newAnnotation, err := r.ensureVolumeTags(ctx, ec2svc, aws.String(volumeID), subAnnotation, additionalTags)
 			if err != nil {
 				r.Log.Error(err, "Failed to fetch the changed volume tags in EC2 instance")
 			}
 			annotations[volumeID] = newAnnotation
 		} else {
-			newAnnotation, err := r.ensureVolumeTags(ec2svc, aws.String(volumeID), make(map[string]interface{}), additionalTags)
+			newAnnotation, err := r.ensureVolumeTags(ctx, ec2svc, aws.String(volumeID), make(map[string]interface{}), additionalTags)
 			if err != nil {
 				r.Log.Error(err, "Failed to fetch the changed volume tags in EC2 instance")
 			}
@@ -1223,10 +1686,10 @@ func (r *AWSMachineReconciler) ensureStorageTags(ec2svc services.EC2Interface, i
 	}
 }
 
-func (r *AWSMachineReconciler) ensureInstanceMetadataOptions(ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine) error {
+func (r *AWSMachineReconciler) ensureInstanceMetadataOptions(ctx context.Context, ec2svc services.EC2Interface, instance *infrav1.Instance, machine *infrav1.AWSMachine) error {
 	if cmp.Equal(machine.Spec.InstanceMetadataOptions, instance.InstanceMetadataOptions) {
 		return nil
 	}
 
-	return ec2svc.ModifyInstanceMetadataOptions(instance.ID, machine.Spec.InstanceMetadataOptions)
+	return ec2svc.ModifyInstanceMetadataOptions(ctx, instance.ID, machine.Spec.InstanceMetadataOptions)
 }
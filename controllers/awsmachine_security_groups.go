@@ -17,6 +17,7 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"sort"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -38,18 +39,18 @@ const (
 // Returns bool, error
 // Bool indicates if changes were made or not, allowing the caller to decide
 // if the machine should be updated.
-func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface, scope *scope.MachineScope, additional []infrav1.AWSResourceReference, existing map[string][]string) (bool, error) {
+func (r *AWSMachineReconciler) ensureSecurityGroups(ctx context.Context, ec2svc service.EC2Interface, scope *scope.MachineScope, additional []infrav1.AWSResourceReference, existing map[string][]string) (bool, error) {
 	annotation, err := r.machineAnnotationJSON(scope.AWSMachine, SecurityGroupsLastAppliedAnnotation)
 	if err != nil {
 		return false, err
 	}
 
-	core, err := ec2svc.GetCoreSecurityGroups(scope)
+	core, err := ec2svc.GetCoreSecurityGroups(ctx, scope)
 	if err != nil {
 		return false, err
 	}
 
-	additionalSecurityGroupsIDs, err := ec2svc.GetAdditionalSecurityGroupsIDs(additional)
+	additionalSecurityGroupsIDs, err := ec2svc.GetAdditionalSecurityGroupsIDs(ctx, additional)
 	if err != nil {
 		return false, err
 	}
@@ -59,7 +60,7 @@ func (r *AWSMachineReconciler) ensureSecurityGroups(ec2svc service.EC2Interface,
 		return false, nil
 	}
 
-	if err := ec2svc.UpdateInstanceSecurityGroups(*scope.GetInstanceID(), ids); err != nil {
+	if err := ec2svc.UpdateInstanceSecurityGroups(ctx, *scope.GetInstanceID(), ids); err != nil {
 		return false, err
 	}
 
@@ -17,6 +17,8 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 )
@@ -39,7 +41,7 @@ const (
 // Returns bool, error
 // Bool indicates if changes were made or not, allowing the caller to decide
 // if the machine should be updated.
-func (r *AWSMachineReconciler) ensureTags(svc service.EC2Interface, machine *infrav1.AWSMachine, instanceID *string, additionalTags map[string]string) (bool, error) {
+func (r *AWSMachineReconciler) ensureTags(ctx context.Context, svc service.EC2Interface, machine *infrav1.AWSMachine, instanceID *string, additionalTags map[string]string) (bool, error) {
 	annotation, err := r.machineAnnotationJSON(machine, TagsLastAppliedAnnotation)
 	if err != nil {
 		return false, err
@@ -51,7 +53,7 @@ func (r *AWSMachineReconciler) ensureTags(svc service.EC2Interface, machine *inf
 	// updated.
 	changed, created, deleted, newAnnotation := r.tagsChanged(annotation, additionalTags)
 	if changed {
-		err = svc.UpdateResourceTags(instanceID, created, deleted)
+		err = svc.UpdateResourceTags(ctx, instanceID, created, deleted)
 		if err != nil {
 			return false, err
 		}
@@ -68,14 +70,14 @@ func (r *AWSMachineReconciler) ensureTags(svc service.EC2Interface, machine *inf
 
 // Ensure that the tags of the volumes in the machine are correct
 // Returns tags which are being created/updated/deleted and error.
-func (r *AWSMachineReconciler) ensureVolumeTags(svc service.EC2Interface, volumeID *string, annotation map[string]interface{}, additionalTags map[string]string) (map[string]interface{}, error) {
+func (r *AWSMachineReconciler) ensureVolumeTags(ctx context.Context, svc service.EC2Interface, volumeID *string, annotation map[string]interface{}, additionalTags map[string]string) (map[string]interface{}, error) {
 	// Check if the volume tags were changed. If they were, update them.
 	// It would be possible here to only send new/updated tags, but for the
 	// moment we send everything, even if only a single tag was created or
 	// updated.
 	changed, created, deleted, subAnnotation := r.tagsChanged(annotation, additionalTags)
 	if changed {
-		err := svc.UpdateResourceTags(volumeID, created, deleted)
+		err := svc.UpdateResourceTags(ctx, volumeID, created, deleted)
 		if err != nil {
 			return nil, err
 		}
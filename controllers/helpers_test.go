@@ -16,10 +16,12 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
@@ -186,11 +188,11 @@ func getClusterScope(awsCluster infrav1.AWSCluster) (*scope.ClusterScope, error)
 
 func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 	t.Helper()
-	m.DescribeLoadBalancers(gomock.Eq(describeLBInput)).
+	m.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Eq(describeLBInput)).
 		Return(describeLBOutput, nil).MinTimes(1)
-	m.DescribeLoadBalancerAttributes(gomock.Eq(describeLBAttributesInput)).
+	m.DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Eq(describeLBAttributesInput)).
 		Return(describeLBAttributesOutput, nil)
-	m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice([]string{*lbName})}).Return(
+	m.DescribeTagsWithContext(gomock.Any(), &elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice([]string{*lbName})}).Return(
 		&elb.DescribeTagsOutput{
 			TagDescriptions: []*elb.TagDescription{
 				{
@@ -202,7 +204,7 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 				},
 			},
 		}, nil)
-	m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
+	m.ModifyLoadBalancerAttributesWithContext(gomock.Any(), gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
 			ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)},
@@ -210,8 +212,8 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 		LoadBalancerName: aws.String(""),
 	})).MaxTimes(1)
 
-	m.AddTags(gomock.AssignableToTypeOf(&elb.AddTagsInput{})).Return(&elb.AddTagsOutput{}, nil).Do(
-		func(actual *elb.AddTagsInput) {
+	m.AddTagsWithContext(gomock.Any(), gomock.AssignableToTypeOf(&elb.AddTagsInput{})).Return(&elb.AddTagsOutput{}, nil).Do(
+		func(_ context.Context, actual *elb.AddTagsInput, _ ...request.Option) {
 			sortTagsByKey := func(tags []*elb.Tag) {
 				sort.Slice(tags, func(i, j int) bool {
 					return *(tags[i].Key) < *(tags[j].Key)
@@ -223,7 +225,7 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 				t.Fatalf("Actual AddTagsInput did not match expected, Actual : %v, Expected: %v", actual.Tags, expectedTags)
 			}
 		}).AnyTimes()
-	m.RemoveTags(gomock.Eq(&elb.RemoveTagsInput{
+	m.RemoveTagsWithContext(gomock.Any(), gomock.Eq(&elb.RemoveTagsInput{
 		LoadBalancerNames: aws.StringSlice([]string{""}),
 		Tags: []*elb.TagKeyOnly{
 			{
@@ -231,23 +233,23 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder) {
 			},
 		},
 	})).MaxTimes(1)
-	m.ApplySecurityGroupsToLoadBalancer(gomock.Eq(&elb.ApplySecurityGroupsToLoadBalancerInput{
+	m.ApplySecurityGroupsToLoadBalancerWithContext(gomock.Any(), gomock.Eq(&elb.ApplySecurityGroupsToLoadBalancerInput{
 		LoadBalancerName: aws.String(""),
 		SecurityGroups:   aws.StringSlice([]string{"sg-apiserver-lb"}),
 	})).MaxTimes(1)
-	m.RegisterInstancesWithLoadBalancer(gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{Instances: []*elb.Instance{{InstanceId: aws.String("two")}}, LoadBalancerName: lbName})).MaxTimes(1)
+	m.RegisterInstancesWithLoadBalancerWithContext(gomock.Any(), gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{Instances: []*elb.Instance{{InstanceId: aws.String("two")}}, LoadBalancerName: lbName})).MaxTimes(1)
 }
 
 func mockedCreateLBV2Calls(t *testing.T, m *mocks.MockELBV2APIMockRecorder) {
 	t.Helper()
-	m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+	m.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 		Names: []*string{lbName},
 	})).
 		Return(describeLBOutputV2, nil).MinTimes(1)
-	m.DescribeLoadBalancerAttributes(gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
+	m.DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
 		LoadBalancerArn: lbArn,
 	})).Return(describeLBAttributesOutputV2, nil)
-	m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{lbArn}}).Return(
+	m.DescribeTagsWithContext(gomock.Any(), &elbv2.DescribeTagsInput{ResourceArns: []*string{lbArn}}).Return(
 		&elbv2.DescribeTagsOutput{
 			TagDescriptions: []*elbv2.TagDescription{
 				{
@@ -259,7 +261,7 @@ func mockedCreateLBV2Calls(t *testing.T, m *mocks.MockELBV2APIMockRecorder) {
 				},
 			},
 		}, nil)
-	m.ModifyLoadBalancerAttributes(gomock.Eq(&elbv2.ModifyLoadBalancerAttributesInput{
+	m.ModifyLoadBalancerAttributesWithContext(gomock.Any(), gomock.Eq(&elbv2.ModifyLoadBalancerAttributesInput{
 		Attributes: []*elbv2.LoadBalancerAttribute{
 			{
 				Key:   aws.String(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone),
@@ -268,8 +270,8 @@ func mockedCreateLBV2Calls(t *testing.T, m *mocks.MockELBV2APIMockRecorder) {
 		},
 		LoadBalancerArn: lbArn,
 	})).MaxTimes(1)
-	m.AddTags(gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).Return(&elbv2.AddTagsOutput{}, nil).Do(
-		func(actual *elbv2.AddTagsInput) {
+	m.AddTagsWithContext(gomock.Any(), gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).Return(&elbv2.AddTagsOutput{}, nil).Do(
+		func(_ context.Context, actual *elbv2.AddTagsInput, _ ...request.Option) {
 			sortTagsByKey := func(tags []*elbv2.Tag) {
 				sort.Slice(tags, func(i, j int) bool {
 					return *(tags[i].Key) < *(tags[j].Key)
@@ -281,11 +283,11 @@ func mockedCreateLBV2Calls(t *testing.T, m *mocks.MockELBV2APIMockRecorder) {
 				t.Fatalf("Actual AddTagsInput did not match expected, Actual : %v, Expected: %v", actual.Tags, expectedV2Tags)
 			}
 		}).AnyTimes()
-	m.RemoveTags(gomock.Eq(&elbv2.RemoveTagsInput{
+	m.RemoveTagsWithContext(gomock.Any(), gomock.Eq(&elbv2.RemoveTagsInput{
 		ResourceArns: []*string{lbArn},
 		TagKeys:      []*string{aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster-apiserver")},
 	})).MaxTimes(1)
-	m.SetSecurityGroups(gomock.Eq(&elbv2.SetSecurityGroupsInput{
+	m.SetSecurityGroupsWithContext(gomock.Any(), gomock.Eq(&elbv2.SetSecurityGroupsInput{
 		LoadBalancerArn: lbArn,
 		SecurityGroups:  aws.StringSlice([]string{"sg-apiserver-lb"}),
 	})).MaxTimes(1)
@@ -293,10 +295,10 @@ func mockedCreateLBV2Calls(t *testing.T, m *mocks.MockELBV2APIMockRecorder) {
 
 func mockedDeleteLBCalls(expectV2Call bool, mv2 *mocks.MockELBV2APIMockRecorder, m *mocks.MockELBAPIMockRecorder) {
 	if expectV2Call {
-		mv2.DescribeLoadBalancers(gomock.Any()).Return(describeLBOutputV2, nil)
-		mv2.DescribeLoadBalancerAttributes(gomock.Any()).
+		mv2.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Any()).Return(describeLBOutputV2, nil)
+		mv2.DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Any()).
 			Return(describeLBAttributesOutputV2, nil).MaxTimes(1)
-		mv2.DescribeTags(gomock.Any()).Return(
+		mv2.DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(
 			&elbv2.DescribeTagsOutput{
 				TagDescriptions: []*elbv2.TagDescription{
 					{
@@ -309,17 +311,17 @@ func mockedDeleteLBCalls(expectV2Call bool, mv2 *mocks.MockELBV2APIMockRecorder,
 					},
 				},
 			}, nil).MaxTimes(1)
-		mv2.DescribeTargetGroups(gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
-		mv2.DescribeListeners(gomock.Any()).Return(&elbv2.DescribeListenersOutput{}, nil)
-		mv2.DeleteLoadBalancer(gomock.Eq(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: lbArn})).
+		mv2.DescribeTargetGroupsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
+		mv2.DescribeListenersWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.DescribeListenersOutput{}, nil)
+		mv2.DeleteLoadBalancerWithContext(gomock.Any(), gomock.Eq(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: lbArn})).
 			Return(&elbv2.DeleteLoadBalancerOutput{}, nil).MaxTimes(1)
-		mv2.DescribeLoadBalancers(gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{}, nil)
+		mv2.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Any()).Return(&elbv2.DescribeLoadBalancersOutput{}, nil)
 	}
-	m.DescribeLoadBalancers(gomock.Eq(describeLBInput)).
+	m.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Eq(describeLBInput)).
 		Return(describeLBOutput, nil)
-	m.DescribeLoadBalancers(gomock.Eq(describeLBInput)).
+	m.DescribeLoadBalancersWithContext(gomock.Any(), gomock.Eq(describeLBInput)).
 		Return(&elb.DescribeLoadBalancersOutput{}, nil).AnyTimes()
-	m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice([]string{*lbName})}).Return(
+	m.DescribeTagsWithContext(gomock.Any(), &elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice([]string{*lbName})}).Return(
 		&elb.DescribeTagsOutput{
 			TagDescriptions: []*elb.TagDescription{
 				{
@@ -327,9 +329,9 @@ func mockedDeleteLBCalls(expectV2Call bool, mv2 *mocks.MockELBV2APIMockRecorder,
 				},
 			},
 		}, nil).MaxTimes(1)
-	m.DescribeLoadBalancerAttributes(gomock.Eq(describeLBAttributesInput)).
+	m.DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Eq(describeLBAttributesInput)).
 		Return(describeLBAttributesOutput, nil).MaxTimes(1)
-	m.DeleteLoadBalancer(gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: lbName})).
+	m.DeleteLoadBalancerWithContext(gomock.Any(), gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: lbName})).
 		Return(&elb.DeleteLoadBalancerOutput{}, nil).MaxTimes(1)
-	m.DescribeLoadBalancersPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m.DescribeLoadBalancersPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 }
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package controllers
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileRequiredTags sets the RequiredTagsReady condition of an AWSCluster or AWSMachine, and returns an error when
+// the additional tags of its AWS resources don't set all the required tags of the cluster, so that no resource is
+// created without them.
+func reconcileRequiredTags(obj conditions.Setter, additionalTags infrav1.Tags, requiredTags []string) error {
+	if len(requiredTags) == 0 {
+		conditions.Delete(obj, infrav1.RequiredTagsReadyCondition)
+		return nil
+	}
+
+	if missing := additionalTags.MissingKeys(requiredTags); len(missing) > 0 {
+		message := "additional tags don't set the required tags " + strings.Join(missing, ", ")
+		conditions.MarkFalse(obj, infrav1.RequiredTagsReadyCondition, infrav1.RequiredTagsMissingReason, clusterv1.ConditionSeverityError, message)
+		return errors.New(message)
+	}
+
+	conditions.MarkTrue(obj, infrav1.RequiredTagsReadyCondition)
+	return nil
+}
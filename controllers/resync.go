@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// desiredStateHashLength is the length of the hashes of the desired states of the AWS resources.
+const desiredStateHashLength = 16
+
+// desiredStateHash returns a hash of the objects the AWS resources of an object are reconciled from.
+func desiredStateHash(objs ...any) (string, error) {
+	data, err := json.Marshal(objs)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal desired state")
+	}
+	return hash.Base36TruncatedHash(string(data), desiredStateHashLength)
+}
+
+// awsMachineStateHash returns a hash of the desired state of the instance of an AWSMachine: the AWSMachine with its
+// labels and annotations, its Machine, and the spec and network status of its infrastructure cluster. The network
+// status holds the IDs of the security groups and the load balancers of the cluster, which the instance is attached
+// to. The rest of the status of the cluster, such as its conditions, changes without affecting the instance.
+func awsMachineStateHash(machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper) (string, error) {
+	infraCluster, err := runtime.DefaultUnstructuredConverter.ToUnstructured(clusterScope.InfraCluster())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert infrastructure cluster")
+	}
+	network, _, err := unstructured.NestedFieldNoCopy(infraCluster, "status", "networkStatus")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the network status of the infrastructure cluster")
+	}
+	awsMachine := machineScope.AWSMachine
+	return desiredStateHash(awsMachine.Spec, awsMachine.Labels, awsMachine.Annotations, machineScope.Machine.Spec, infraCluster["spec"], network)
+}
+
+// resyncAfter returns how long the reconciliation of the AWS resources of an object can be skipped, which is the case
+// when they were reconciled successfully from the same desired state less than resyncPeriod ago and all the
+// conditions of the object are true, or zero when they have to be reconciled.
+func resyncAfter(last *infrav1.LastReconciliation, stateHash string, objConditions clusterv1.Conditions, resyncPeriod time.Duration, now time.Time) time.Duration {
+	if resyncPeriod <= 0 || last == nil || last.Hash != stateHash {
+		return 0
+	}
+	for _, c := range objConditions {
+		if c.Status != corev1.ConditionTrue {
+			return 0
+		}
+	}
+
+	if remaining := last.Time.Add(resyncPeriod).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// lastReconciliation returns the record of a successful reconciliation of AWS resources from a desired state.
+func lastReconciliation(stateHash string, now time.Time) *infrav1.LastReconciliation {
+	return &infrav1.LastReconciliation{
+		Hash: stateHash,
+		Time: metav1.NewTime(now),
+	}
+}
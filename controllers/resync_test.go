@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestDesiredStateHash(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := infrav1.AWSClusterSpec{Region: "us-east-1"}
+	h1, err := desiredStateHash(spec, map[string]string{"a": "b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h1).To(HaveLen(desiredStateHashLength))
+
+	h2, err := desiredStateHash(spec, map[string]string{"a": "b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h2).To(Equal(h1))
+
+	spec.Region = "us-west-2"
+	h3, err := desiredStateHash(spec, map[string]string{"a": "b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h3).ToNot(Equal(h1))
+
+	h4, err := desiredStateHash(spec, map[string]string{"a": "c"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h4).ToNot(Equal(h3))
+}
+
+func TestAWSMachineStateHash(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	awsCluster := getAWSCluster("test", "test")
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		AWSCluster: &awsCluster,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster:      clusterScope.Cluster,
+		Machine:      &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		InfraCluster: clusterScope,
+		AWSMachine:   &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	h1, err := awsMachineStateHash(machineScope, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The conditions of the cluster don't affect the instance.
+	conditions.MarkFalse(&awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerFailedReason, clusterv1.ConditionSeverityError, "")
+	h2, err := awsMachineStateHash(machineScope, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h2).To(Equal(h1))
+
+	awsCluster.Status.Network.SecurityGroups = map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+		infrav1.SecurityGroupNode: {ID: "sg-node"},
+	}
+	h3, err := awsMachineStateHash(machineScope, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h3).ToNot(Equal(h2))
+
+	awsCluster.Status.Network.APIServerELB.DNSName = "test-apiserver.us-east-1.elb.amazonaws.com"
+	h4, err := awsMachineStateHash(machineScope, clusterScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h4).ToNot(Equal(h3))
+}
+
+func TestResyncAfter(t *testing.T) {
+	now := time.Now()
+	trueConditions := clusterv1.Conditions{{Type: infrav1.VpcReadyCondition, Status: corev1.ConditionTrue}}
+
+	testCases := []struct {
+		name         string
+		last         *infrav1.LastReconciliation
+		hash         string
+		conditions   clusterv1.Conditions
+		resyncPeriod time.Duration
+		expected     time.Duration
+	}{
+		{
+			name:         "disabled",
+			last:         lastReconciliation("abc", now),
+			hash:         "abc",
+			conditions:   trueConditions,
+			resyncPeriod: 0,
+			expected:     0,
+		},
+		{
+			name:         "never reconciled",
+			hash:         "abc",
+			conditions:   trueConditions,
+			resyncPeriod: time.Hour,
+			expected:     0,
+		},
+		{
+			name:         "desired state changed",
+			last:         lastReconciliation("abc", now),
+			hash:         "def",
+			conditions:   trueConditions,
+			resyncPeriod: time.Hour,
+			expected:     0,
+		},
+		{
+			name: "condition not true",
+			last: lastReconciliation("abc", now),
+			hash: "abc",
+			conditions: clusterv1.Conditions{
+				{Type: infrav1.VpcReadyCondition, Status: corev1.ConditionTrue},
+				{Type: infrav1.SubnetsReadyCondition, Status: corev1.ConditionFalse},
+			},
+			resyncPeriod: time.Hour,
+			expected:     0,
+		},
+		{
+			name:         "resync period elapsed",
+			last:         &infrav1.LastReconciliation{Hash: "abc", Time: metav1.NewTime(now.Add(-2 * time.Hour))},
+			hash:         "abc",
+			conditions:   trueConditions,
+			resyncPeriod: time.Hour,
+			expected:     0,
+		},
+		{
+			name:         "unchanged within the resync period",
+			last:         &infrav1.LastReconciliation{Hash: "abc", Time: metav1.NewTime(now.Add(-20 * time.Minute))},
+			hash:         "abc",
+			conditions:   trueConditions,
+			resyncPeriod: time.Hour,
+			expected:     40 * time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(resyncAfter(tc.last, tc.hash, tc.conditions, tc.resyncPeriod, now)).To(Equal(tc.expected))
+		})
+	}
+}
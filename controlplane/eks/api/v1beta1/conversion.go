@@ -40,6 +40,16 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.AccessConfig = restored.Spec.AccessConfig
+	dst.Spec.AWSAuthConfigMapManagement = restored.Spec.AWSAuthConfigMapManagement
+	dst.Spec.AccessEntries = restored.Spec.AccessEntries
+	dst.Spec.PodIdentityAssociations = restored.Spec.PodIdentityAssociations
+	dst.Spec.VersionUpdatePolicy = restored.Spec.VersionUpdatePolicy
+	dst.Spec.OutpostConfig = restored.Spec.OutpostConfig
+	if restored.Spec.Logging != nil && dst.Spec.Logging != nil {
+		dst.Spec.Logging.Retention = restored.Spec.Logging.Retention
+	}
+	dst.Status.Region = restored.Status.Region
 
 	return nil
 }
@@ -108,6 +118,11 @@ func Convert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlan
 	return autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s)
 }
 
+// Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec is a conversion function.
+func Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(in *ekscontrolplanev1.ControlPlaneLoggingSpec, out *ControlPlaneLoggingSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(in, out, s)
+}
+
 func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out *VpcCni, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
@@ -116,3 +131,8 @@ func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out
 func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, scope apiconversion.Scope) error {
 	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope)
 }
+
+// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is a conversion function.
+func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *ekscontrolplanev1.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, scope apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, scope)
+}
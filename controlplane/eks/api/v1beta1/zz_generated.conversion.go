@@ -105,11 +105,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.ControlPlaneLoggingSpec)(nil), (*ControlPlaneLoggingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(a.(*v1beta2.ControlPlaneLoggingSpec), b.(*ControlPlaneLoggingSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*EncryptionConfig)(nil), (*v1beta2.EncryptionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_EncryptionConfig_To_v1beta2_EncryptionConfig(a.(*EncryptionConfig), b.(*v1beta2.EncryptionConfig), scope)
 	}); err != nil {
@@ -245,6 +240,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.ControlPlaneLoggingSpec)(nil), (*ControlPlaneLoggingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(a.(*v1beta2.ControlPlaneLoggingSpec), b.(*ControlPlaneLoggingSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VpcCni)(nil), (*VpcCni)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(a.(*v1beta2.VpcCni), b.(*VpcCni), scope)
 	}); err != nil {
@@ -337,7 +337,15 @@ func autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControl
 	out.Version = (*string)(unsafe.Pointer(in.Version))
 	out.RoleName = (*string)(unsafe.Pointer(in.RoleName))
 	out.RoleAdditionalPolicies = (*[]string)(unsafe.Pointer(in.RoleAdditionalPolicies))
-	out.Logging = (*v1beta2.ControlPlaneLoggingSpec)(unsafe.Pointer(in.Logging))
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(v1beta2.ControlPlaneLoggingSpec)
+		if err := Convert_v1beta1_ControlPlaneLoggingSpec_To_v1beta2_ControlPlaneLoggingSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Logging = nil
+	}
 	out.EncryptionConfig = (*v1beta2.EncryptionConfig)(unsafe.Pointer(in.EncryptionConfig))
 	out.AdditionalTags = *(*apiv1beta2.Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.IAMAuthenticatorConfig = (*v1beta2.IAMAuthenticatorConfig)(unsafe.Pointer(in.IAMAuthenticatorConfig))
@@ -372,12 +380,25 @@ func autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControl
 	// WARNING: in.Partition requires manual conversion: does not exist in peer-type
 	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
 	out.Version = (*string)(unsafe.Pointer(in.Version))
+	// WARNING: in.VersionUpdatePolicy requires manual conversion: does not exist in peer-type
 	out.RoleName = (*string)(unsafe.Pointer(in.RoleName))
 	out.RoleAdditionalPolicies = (*[]string)(unsafe.Pointer(in.RoleAdditionalPolicies))
-	out.Logging = (*ControlPlaneLoggingSpec)(unsafe.Pointer(in.Logging))
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(ControlPlaneLoggingSpec)
+		if err := Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Logging = nil
+	}
 	out.EncryptionConfig = (*EncryptionConfig)(unsafe.Pointer(in.EncryptionConfig))
+	// WARNING: in.OutpostConfig requires manual conversion: does not exist in peer-type
 	out.AdditionalTags = *(*apiv1beta2.Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.IAMAuthenticatorConfig = (*IAMAuthenticatorConfig)(unsafe.Pointer(in.IAMAuthenticatorConfig))
+	// WARNING: in.AWSAuthConfigMapManagement requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessEntries requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(&in.EndpointAccess, &out.EndpointAccess, s); err != nil {
 		return err
 	}
@@ -389,6 +410,7 @@ func autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControl
 	out.TokenMethod = (*EKSTokenMethod)(unsafe.Pointer(in.TokenMethod))
 	out.AssociateOIDCProvider = in.AssociateOIDCProvider
 	out.Addons = (*[]Addon)(unsafe.Pointer(in.Addons))
+	// WARNING: in.PodIdentityAssociations requires manual conversion: does not exist in peer-type
 	out.OIDCIdentityProviderConfig = (*OIDCIdentityProviderConfig)(unsafe.Pointer(in.OIDCIdentityProviderConfig))
 	if err := Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(&in.VpcCni, &out.VpcCni, s); err != nil {
 		return err
@@ -439,14 +461,10 @@ func autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedContr
 	if err := Convert_v1beta2_IdentityProviderStatus_To_v1beta1_IdentityProviderStatus(&in.IdentityProviderStatus, &out.IdentityProviderStatus, s); err != nil {
 		return err
 	}
+	// WARNING: in.Region requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is an autogenerated conversion function.
-func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *v1beta2.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_Addon_To_v1beta2_Addon(in *Addon, out *v1beta2.Addon, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
@@ -553,14 +571,10 @@ func autoConvert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingS
 	out.Authenticator = in.Authenticator
 	out.ControllerManager = in.ControllerManager
 	out.Scheduler = in.Scheduler
+	// WARNING: in.Retention requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec is an autogenerated conversion function.
-func Convert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(in *v1beta2.ControlPlaneLoggingSpec, out *ControlPlaneLoggingSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_ControlPlaneLoggingSpec_To_v1beta1_ControlPlaneLoggingSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_EncryptionConfig_To_v1beta2_EncryptionConfig(in *EncryptionConfig, out *v1beta2.EncryptionConfig, s conversion.Scope) error {
 	out.Provider = (*string)(unsafe.Pointer(in.Provider))
 	out.Resources = *(*[]*string)(unsafe.Pointer(&in.Resources))
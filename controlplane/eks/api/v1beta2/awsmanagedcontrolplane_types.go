@@ -57,7 +57,9 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// The AWS Region the cluster lives in.
 	Region string `json:"region,omitempty"`
 
-	// Partition is the AWS security partition being used. Defaults to "aws"
+	// Partition is the AWS security partition being used, e.g. "aws-us-gov" or "aws-cn",
+	// used to build the ARNs of the AWS resources. Defaults to the partition of the region,
+	// and should be set for the regions of partitions which aren't known yet.
 	// +optional
 	Partition string `json:"partition,omitempty"`
 
@@ -73,6 +75,11 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	Version *string `json:"version,omitempty"`
 
+	// VersionUpdatePolicy defines the checks that must pass before a control plane
+	// minor version update is started. If not set, updates are started without checks.
+	// +optional
+	VersionUpdatePolicy *VersionUpdatePolicy `json:"versionUpdatePolicy,omitempty"`
+
 	// RoleName specifies the name of IAM role that gives EKS
 	// permission to make API calls. If the role is pre-existing
 	// we will treat it as unmanaged and not delete it on
@@ -97,6 +104,12 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
 
+	// OutpostConfig specifies the configuration of the control plane of an EKS local
+	// cluster on AWS Outposts. If set, the Kubernetes control plane runs on the Outpost
+	// instead of in the AWS Region. It can't be changed after the cluster is created.
+	// +optional
+	OutpostConfig *OutpostConfig `json:"outpostConfig,omitempty"`
+
 	// AdditionalTags is an optional set of tags to add to AWS resources managed by the AWS provider, in addition to the
 	// ones added by default.
 	// +optional
@@ -108,6 +121,24 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	IAMAuthenticatorConfig *IAMAuthenticatorConfig `json:"iamAuthenticatorConfig,omitempty"`
 
+	// AWSAuthConfigMapManagement specifies whether the aws-auth ConfigMap of the cluster is managed. When disabled,
+	// the aws-auth ConfigMap is left untouched, e.g. for it to be managed with GitOps or for the cluster to only use
+	// access entries, and IAMAuthenticatorConfig can't be set. Defaults to enabled.
+	// +kubebuilder:default=enabled
+	// +kubebuilder:validation:Enum=enabled;disabled
+	// +optional
+	AWSAuthConfigMapManagement AWSAuthConfigMapManagement `json:"awsAuthConfigMapManagement,omitempty"`
+
+	// AccessConfig specifies the access configuration information for the cluster.
+	// +optional
+	AccessConfig *AccessConfig `json:"accessConfig,omitempty"`
+
+	// AccessEntries is a list of IAM principals to grant access to the cluster
+	// using EKS access entries. The authentication mode must be api or
+	// api_and_config_map to use access entries.
+	// +optional
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+
 	// Endpoints specifies access to this cluster's control plane endpoints
 	// +optional
 	EndpointAccess EndpointAccess `json:"endpointAccess,omitempty"`
@@ -164,6 +195,12 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	Addons *[]Addon `json:"addons,omitempty"`
 
+	// PodIdentityAssociations is a list of EKS Pod Identity associations between
+	// service accounts and IAM roles. The eks-pod-identity-agent addon must be
+	// enabled to use pod identity associations.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+
 	// IdentityProviderconfig is used to specify the oidc provider config
 	// to be attached with this eks cluster
 	// +optional
@@ -279,6 +316,10 @@ type AWSManagedControlPlaneStatus struct {
 	// associated identity provider
 	// +optional
 	IdentityProviderStatus IdentityProviderStatus `json:"identityProviderStatus,omitempty"`
+	// Region is the effective AWS Region of the control plane: the region of its spec or, when it is
+	// omitted, the region resolved like the region of an AWSCluster. It doesn't change once it is resolved.
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,6 +21,7 @@ import (
 	"net"
 
 	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
@@ -47,10 +49,11 @@ const (
 var mcpLog = ctrl.Log.WithName("awsmanagedcontrolplane-resource")
 
 const (
-	cidrSizeMax    = 65536
-	cidrSizeMin    = 16
-	vpcCniAddon    = "vpc-cni"
-	kubeProxyAddon = "kube-proxy"
+	cidrSizeMax           = 65536
+	cidrSizeMin           = 16
+	vpcCniAddon           = "vpc-cni"
+	kubeProxyAddon        = "kube-proxy"
+	podIdentityAgentAddon = "eks-pod-identity-agent"
 )
 
 // SetupWebhookWithManager will setup the webhooks for the AWSManagedControlPlane.
@@ -88,13 +91,20 @@ func (r *AWSManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateEKSVersion(nil)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
+	allErrs = append(allErrs, r.validateAccessConfig(nil)...)
+	allErrs = append(allErrs, r.validateAccessEntries()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateOutpostConfig(nil)...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetReplacements(field.NewPath("spec", "network"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateIPv6NativeSubnets(field.NewPath("spec", "network"))...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateEndpointAccess()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -123,12 +133,17 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 	allErrs = append(allErrs, r.validateEKSVersion(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
+	allErrs = append(allErrs, r.validateAccessConfig(oldAWSManagedControlplane)...)
+	allErrs = append(allErrs, r.validateAccessEntries()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateOutpostConfig(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateEndpointAccess()...)
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
 		allErrs = append(allErrs,
@@ -167,6 +182,9 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 			field.Invalid(field.NewPath("spec", "networkSpec", "vpc", "enableIPv6"), r.Spec.NetworkSpec.VPC.IsIPv6Enabled(), "changing IP family is not allowed after it has been set"))
 	}
 
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSubnetReplacements(field.NewPath("spec", "network"))...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateIPv6NativeSubnets(field.NewPath("spec", "network"))...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -240,6 +258,19 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 		return allErrs
 	}
 
+	if r.Spec.Addons != nil {
+		for i, addon := range *r.Spec.Addons {
+			if addon.Configuration == "" {
+				continue
+			}
+			// JSON is a subset of YAML, so this accepts configurations in either format.
+			configuration := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(addon.Configuration), &configuration); err != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "addons").Index(i).Child("configuration"), addon.Configuration, fmt.Sprintf("configuration must be a JSON or YAML object: %v", err)))
+			}
+		}
+	}
+
 	if r.Spec.Version == nil {
 		return allErrs
 	}
@@ -297,6 +328,11 @@ func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 		return allErrs
 	}
 
+	if r.Spec.AWSAuthConfigMapManagement == AWSAuthConfigMapManagementDisabled {
+		allErrs = append(allErrs, field.Forbidden(parentPath, "iamAuthenticatorConfig can't be set when awsAuthConfigMapManagement is disabled"))
+		return allErrs
+	}
+
 	for i, userMapping := range cfg.UserMappings {
 		usersPathName := fmt.Sprintf("mapUsers[%d]", i)
 		usersPath := parentPath.Child(usersPathName)
@@ -318,6 +354,118 @@ func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateAccessConfig(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if old == nil {
+		return allErrs
+	}
+
+	modePath := field.NewPath("spec", "accessConfig", "authenticationMode")
+	oldMode, newMode := old.Spec.AccessConfig.GetAuthenticationMode(), r.Spec.AccessConfig.GetAuthenticationMode()
+	if oldMode != newMode {
+		// EKS only allows migrating from the aws-auth ConfigMap to access entries one step at a time.
+		switch {
+		case oldMode == EKSAuthenticationModeConfigMap && newMode == EKSAuthenticationModeAPIAndConfigMap:
+		case oldMode == EKSAuthenticationModeAPIAndConfigMap && newMode == EKSAuthenticationModeAPI:
+		default:
+			allErrs = append(allErrs, field.Invalid(modePath, newMode, fmt.Sprintf("changing the authentication mode from %s to %s is not allowed", oldMode, newMode)))
+		}
+	}
+
+	var oldBootstrap, newBootstrap *bool
+	if old.Spec.AccessConfig != nil {
+		oldBootstrap = old.Spec.AccessConfig.BootstrapClusterCreatorAdminPermissions
+	}
+	if r.Spec.AccessConfig != nil {
+		newBootstrap = r.Spec.AccessConfig.BootstrapClusterCreatorAdminPermissions
+	}
+	if oldBootstrap != nil && (newBootstrap == nil || *oldBootstrap != *newBootstrap) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "accessConfig", "bootstrapClusterCreatorAdminPermissions"), newBootstrap, "field is immutable"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateAccessEntries() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.AccessEntries) == 0 {
+		return allErrs
+	}
+
+	entriesPath := field.NewPath("spec", "accessEntries")
+	if r.Spec.AccessConfig.GetAuthenticationMode() == EKSAuthenticationModeConfigMap {
+		allErrs = append(allErrs, field.Invalid(entriesPath, r.Spec.AccessEntries, "access entries require the authentication mode to be api or api_and_config_map"))
+		return allErrs
+	}
+
+	principals := map[string]bool{}
+	for i, entry := range r.Spec.AccessEntries {
+		entryPath := entriesPath.Index(i)
+		if principals[entry.PrincipalARN] {
+			allErrs = append(allErrs, field.Duplicate(entryPath.Child("principalARN"), entry.PrincipalARN))
+		}
+		principals[entry.PrincipalARN] = true
+
+		if entry.Type != "" && entry.Type != EKSAccessEntryTypeStandard {
+			if len(entry.KubernetesGroups) > 0 || len(entry.AccessPolicies) > 0 || entry.Username != "" {
+				allErrs = append(allErrs, field.Invalid(entryPath, entry.Type, "username, kubernetesGroups and accessPolicies can only be set for standard access entries"))
+			}
+		}
+
+		for j, policy := range entry.AccessPolicies {
+			scopePath := entryPath.Child("accessPolicies").Index(j).Child("accessScope")
+			switch policy.AccessScope.Type {
+			case EKSAccessScopeTypeNamespace:
+				if len(policy.AccessScope.Namespaces) == 0 {
+					allErrs = append(allErrs, field.Required(scopePath.Child("namespaces"), "namespaces are required when the access scope type is namespace"))
+				}
+			default:
+				if len(policy.AccessScope.Namespaces) > 0 {
+					allErrs = append(allErrs, field.Invalid(scopePath.Child("namespaces"), policy.AccessScope.Namespaces, "namespaces can only be set when the access scope type is namespace"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validatePodIdentityAssociations() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.PodIdentityAssociations) == 0 {
+		return allErrs
+	}
+
+	associationsPath := field.NewPath("spec", "podIdentityAssociations")
+
+	hasAgentAddon := false
+	if r.Spec.Addons != nil {
+		for _, addon := range *r.Spec.Addons {
+			if addon.Name == podIdentityAgentAddon {
+				hasAgentAddon = true
+				break
+			}
+		}
+	}
+	if !hasAgentAddon {
+		allErrs = append(allErrs, field.Invalid(associationsPath, r.Spec.PodIdentityAssociations, fmt.Sprintf("the %s addon is required to use pod identity associations", podIdentityAgentAddon)))
+	}
+
+	serviceAccounts := map[string]bool{}
+	for i, association := range r.Spec.PodIdentityAssociations {
+		key := association.ServiceAccountNamespace + "/" + association.ServiceAccountName
+		if serviceAccounts[key] {
+			allErrs = append(allErrs, field.Duplicate(associationsPath.Index(i), key))
+		}
+		serviceAccounts[key] = true
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateSecondaryCIDR() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.SecondaryCidrBlock != nil {
@@ -392,6 +540,29 @@ func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateOutpostConfig(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+	outpostConfigField := field.NewPath("spec", "outpostConfig")
+
+	if old != nil && !cmp.Equal(old.Spec.OutpostConfig, r.Spec.OutpostConfig) {
+		allErrs = append(allErrs, field.Invalid(outpostConfigField, r.Spec.OutpostConfig, "field is immutable"))
+	}
+
+	if r.Spec.OutpostConfig == nil {
+		return allErrs
+	}
+
+	// EKS local clusters only support the IPv4 family and don't provide managed addons.
+	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "networkSpec", "vpc", "enableIPv6"), r.Spec.NetworkSpec.VPC.IsIPv6Enabled(), "IPv6 is not supported by local clusters on Outposts"))
+	}
+	if r.Spec.Addons != nil && len(*r.Spec.Addons) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "addons"), len(*r.Spec.Addons), "addons are not supported by local clusters on Outposts"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validatePrivateDNSHostnameTypeOnLaunch() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -403,6 +574,29 @@ func (r *AWSManagedControlPlane) validatePrivateDNSHostnameTypeOnLaunch() field.
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateEndpointAccess() field.ErrorList {
+	var allErrs field.ErrorList
+	endpointAccessField := field.NewPath("spec", "endpointAccess")
+
+	// EKS enables the public endpoint and disables the private one by default.
+	if r.Spec.EndpointAccess.Public != nil && !*r.Spec.EndpointAccess.Public &&
+		(r.Spec.EndpointAccess.Private == nil || !*r.Spec.EndpointAccess.Private) {
+		allErrs = append(allErrs, field.Invalid(endpointAccessField, r.Spec.EndpointAccess, "at least one of the public and private endpoints must be enabled"))
+	}
+
+	for i, publicCIDR := range r.Spec.EndpointAccess.PublicCIDRs {
+		if publicCIDR == nil {
+			allErrs = append(allErrs, field.Required(endpointAccessField.Child("publicCIDRs").Index(i), "must be a valid CIDR block"))
+			continue
+		}
+		if _, _, err := net.ParseCIDR(*publicCIDR); err != nil {
+			allErrs = append(allErrs, field.Invalid(endpointAccessField.Child("publicCIDRs").Index(i), *publicCIDR, "must be a valid CIDR block"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
 
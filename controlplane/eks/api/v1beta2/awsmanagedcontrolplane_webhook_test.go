@@ -176,6 +176,7 @@ func TestWebhookCreate(t *testing.T) {
 		additionalTags infrav1.Tags
 		secondaryCidr  *string
 		kubeProxy      KubeProxy
+		endpointAccess EndpointAccess
 	}{
 		{
 			name:           "ekscluster specified",
@@ -310,6 +311,24 @@ func TestWebhookCreate(t *testing.T) {
 				Disable: true,
 			},
 		},
+		{
+			name:           "private endpoint only",
+			eksClusterName: "default_cluster1",
+			expectError:    false,
+			endpointAccess: EndpointAccess{Public: ptr.To[bool](false), Private: ptr.To[bool](true)},
+		},
+		{
+			name:           "public and private endpoints disabled",
+			eksClusterName: "default_cluster1",
+			expectError:    true,
+			endpointAccess: EndpointAccess{Public: ptr.To[bool](false)},
+		},
+		{
+			name:           "invalid public access CIDR",
+			eksClusterName: "default_cluster1",
+			expectError:    true,
+			endpointAccess: EndpointAccess{PublicCIDRs: []*string{aws.String("10.0.0.0/33")}},
+		},
 	}
 
 	for _, tc := range tests {
@@ -327,6 +346,7 @@ func TestWebhookCreate(t *testing.T) {
 					KubeProxy:      tc.kubeProxy,
 					AdditionalTags: tc.additionalTags,
 					VpcCni:         tc.vpcCNI,
+					EndpointAccess: tc.endpointAccess,
 				},
 			}
 			if tc.eksVersion != "" {
@@ -700,6 +720,220 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "changing authentication mode from config_map to api_and_config_map is allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap,
+				},
+				AccessEntries: []AccessEntry{
+					{
+						PrincipalARN:     "arn:aws:iam::123456789012:role/admin",
+						KubernetesGroups: []string{"admins"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "changing authentication mode from config_map to api is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "changing authentication mode from api to api_and_config_map is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "access entries are not allowed with the config_map authentication mode",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessEntries: []AccessEntry{
+					{
+						PrincipalARN: "arn:aws:iam::123456789012:role/admin",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "namespace scoped access policies require namespaces",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig: &AccessConfig{
+					AuthenticationMode: EKSAuthenticationModeAPI,
+				},
+				AccessEntries: []AccessEntry{
+					{
+						PrincipalARN: "arn:aws:iam::123456789012:role/admin",
+						AccessPolicies: []AccessPolicyReference{
+							{
+								PolicyARN:   "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy",
+								AccessScope: AccessScope{Type: EKSAccessScopeTypeNamespace},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "pod identity associations with the pod identity agent addon are allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				Addons: &[]Addon{
+					{
+						Name:    "eks-pod-identity-agent",
+						Version: "v1.2.0-eksbuild.1",
+					},
+				},
+				PodIdentityAssociations: []PodIdentityAssociation{
+					{
+						ServiceAccountNamespace: "default",
+						ServiceAccountName:      "app",
+						RoleARN:                 "arn:aws:iam::123456789012:role/app",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "addon configuration in yaml is allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				Addons: &[]Addon{
+					{
+						Name:          "coredns",
+						Version:       "v1.10.1-eksbuild.6",
+						Configuration: "replicaCount: 3\n",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "addon configuration that isn't an object is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				Addons: &[]Addon{
+					{
+						Name:          "coredns",
+						Version:       "v1.10.1-eksbuild.6",
+						Configuration: "{\"replicaCount\": 3",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "pod identity associations require the pod identity agent addon",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				PodIdentityAssociations: []PodIdentityAssociation{
+					{
+						ServiceAccountNamespace: "default",
+						ServiceAccountName:      "app",
+						RoleARN:                 "arn:aws:iam::123456789012:role/app",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "unchanged outpost config is allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				OutpostConfig: &OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+					ControlPlaneInstanceType: "m5d.large",
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				OutpostConfig: &OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+					ControlPlaneInstanceType: "m5d.large",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "changing the outpost config is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				OutpostConfig: &OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+					ControlPlaneInstanceType: "m5d.large",
+				},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				OutpostConfig: &OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+					ControlPlaneInstanceType: "m5d.xlarge",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "adding an outpost config to an existing cluster is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				OutpostConfig: &OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+					ControlPlaneInstanceType: "m5d.large",
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -794,6 +1028,54 @@ func TestValidatingWebhookCreateSecondaryCidr(t *testing.T) {
 	}
 }
 
+func TestValidatingWebhookCreateAWSAuthConfigMapManagement(t *testing.T) {
+	tests := []struct {
+		name                   string
+		management             AWSAuthConfigMapManagement
+		iamAuthenticatorConfig *IAMAuthenticatorConfig
+		expectError            bool
+	}{
+		{
+			name:                   "enabled with iam authenticator config",
+			management:             AWSAuthConfigMapManagementEnabled,
+			iamAuthenticatorConfig: &IAMAuthenticatorConfig{},
+			expectError:            false,
+		},
+		{
+			name:        "disabled without iam authenticator config",
+			management:  AWSAuthConfigMapManagementDisabled,
+			expectError: false,
+		},
+		{
+			name:                   "disabled with iam authenticator config",
+			management:             AWSAuthConfigMapManagementDisabled,
+			iamAuthenticatorConfig: &IAMAuthenticatorConfig{},
+			expectError:            true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:             "default_cluster1",
+					AWSAuthConfigMapManagement: tc.management,
+					IAMAuthenticatorConfig:     tc.iamAuthenticatorConfig,
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
 func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -31,6 +31,20 @@ const (
 	EKSControlPlaneReconciliationFailedReason = "EKSControlPlaneReconciliationFailed"
 )
 
+const (
+	// EKSControlPlaneVersionUpdateReadyCondition condition reports on whether the checks of the version update
+	// policy allow the next control plane version update to start.
+	EKSControlPlaneVersionUpdateReadyCondition clusterv1.ConditionType = "EKSControlPlaneVersionUpdateReady"
+	// NodegroupVersionsNotUpdatedReason used when nodegroups don't run the current control plane version.
+	NodegroupVersionsNotUpdatedReason = "NodegroupVersionsNotUpdated"
+	// IncompatibleAddonsReason used when installed addons aren't compatible with the next control plane version.
+	IncompatibleAddonsReason = "IncompatibleAddons"
+	// PodDisruptionBudgetsNotReadyReason used when PodDisruptionBudgets of the workload cluster don't allow any disruption.
+	PodDisruptionBudgetsNotReadyReason = "PodDisruptionBudgetsNotReady"
+	// VersionUpdateCheckFailedReason used to report failures while running the version update checks.
+	VersionUpdateCheckFailedReason = "VersionUpdateCheckFailed"
+)
+
 const (
 	// IAMControlPlaneRolesReadyCondition condition reports on the successful reconciliation of eks control plane iam roles.
 	IAMControlPlaneRolesReadyCondition clusterv1.ConditionType = "IAMControlPlaneRolesReady"
@@ -45,6 +59,28 @@ const (
 	IAMAuthenticatorConfigurationFailedReason = "IAMAuthenticatorConfigurationFailed"
 )
 
+const (
+	// EKSEncryptionConfiguredCondition condition reports on whether the encryption configuration of the spec
+	// is applied to the EKS cluster.
+	EKSEncryptionConfiguredCondition clusterv1.ConditionType = "EKSEncryptionConfigured"
+	// EKSEncryptionConfigUpdateNotSupportedReason used when EKS doesn't support the requested change of the
+	// encryption configuration, e.g. changing the KMS key after encryption has been enabled. The change must
+	// be reverted in the spec.
+	EKSEncryptionConfigUpdateNotSupportedReason = "EKSEncryptionConfigUpdateNotSupported"
+	// EKSEncryptionConfigUpdateFailedReason used to report failures while updating the encryption configuration.
+	EKSEncryptionConfigUpdateFailedReason = "EKSEncryptionConfigUpdateFailed"
+)
+
+const (
+	// EKSEndpointAccessConfiguredCondition condition reports on whether the endpoint access of the spec is applied
+	// to the EKS cluster.
+	EKSEndpointAccessConfiguredCondition clusterv1.ConditionType = "EKSEndpointAccessConfigured"
+	// EKSEndpointAccessUpdatingReason used while the endpoint access of the EKS cluster is being updated.
+	EKSEndpointAccessUpdatingReason = "EKSEndpointAccessUpdating"
+	// EKSEndpointAccessUpdateFailedReason used to report failures while updating the endpoint access.
+	EKSEndpointAccessUpdateFailedReason = "EKSEndpointAccessUpdateFailed"
+)
+
 const (
 	// EKSAddonsConfiguredCondition condition reports on the successful reconciliation of EKS addons.
 	EKSAddonsConfiguredCondition clusterv1.ConditionType = "EKSAddonsConfigured"
@@ -52,6 +88,13 @@ const (
 	EKSAddonsConfiguredFailedReason = "EKSAddonsConfiguredFailed"
 )
 
+const (
+	// EKSPodIdentityAssociationsConfiguredCondition condition reports on the successful reconciliation of EKS pod identity associations.
+	EKSPodIdentityAssociationsConfiguredCondition clusterv1.ConditionType = "EKSPodIdentityAssociationsConfigured"
+	// EKSPodIdentityAssociationsConfiguredFailedReason used to report failures while reconciling the EKS pod identity associations.
+	EKSPodIdentityAssociationsConfiguredFailedReason = "EKSPodIdentityAssociationsConfiguredFailed"
+)
+
 const (
 	// EKSIdentityProviderConfiguredCondition condition reports on the successful association of identity provider config.
 	EKSIdentityProviderConfiguredCondition clusterv1.ConditionType = "EKSIdentityProviderConfigured"
@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/eks"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +44,23 @@ type ControlPlaneLoggingSpec struct {
 	// Scheduler indicates if the Kubernetes scheduler (kube-scheduler) log should be enabled
 	// +kubebuilder:default=false
 	Scheduler bool `json:"scheduler"`
+	// Retention configures the CloudWatch Logs group the control plane logs are sent to. When set,
+	// the log group is created and managed by CAPA instead of being created by EKS with logs that
+	// never expire.
+	// +optional
+	Retention *ControlPlaneLogRetentionSpec `json:"retention,omitempty"`
+}
+
+// ControlPlaneLogRetentionSpec defines the configuration of the CloudWatch Logs group of the EKS
+// control plane logs.
+type ControlPlaneLogRetentionSpec struct {
+	// Days is the number of days the control plane logs are retained. If not set, logs never expire.
+	// +kubebuilder:validation:Enum=1;3;5;7;14;30;60;90;120;150;180;365;400;545;731;1096;1827;2192;2557;2922;3288;3653
+	// +optional
+	Days *int64 `json:"days,omitempty"`
+	// KMSKeyARN is the ARN of the KMS key used to encrypt the control plane logs.
+	// +optional
+	KMSKeyARN *string `json:"kmsKeyARN,omitempty"`
 }
 
 // IsLogEnabled returns true if the log is enabled.
@@ -122,6 +140,214 @@ type UserMapping struct {
 	KubernetesMapping `json:",inline"`
 }
 
+// EKSAuthenticationMode defines the source of the authenticated IAM principals of the cluster.
+type EKSAuthenticationMode string
+
+var (
+	// EKSAuthenticationModeConfigMap indicates that only the aws-auth ConfigMap is used.
+	EKSAuthenticationModeConfigMap = EKSAuthenticationMode("config_map")
+
+	// EKSAuthenticationModeAPI indicates that only access entries are used.
+	EKSAuthenticationModeAPI = EKSAuthenticationMode("api")
+
+	// EKSAuthenticationModeAPIAndConfigMap indicates that both access entries and the
+	// aws-auth ConfigMap are used.
+	EKSAuthenticationModeAPIAndConfigMap = EKSAuthenticationMode("api_and_config_map")
+)
+
+// String returns the EKS API value of the authentication mode.
+func (m EKSAuthenticationMode) String() string {
+	return strings.ToUpper(string(m))
+}
+
+// AWSAuthConfigMapManagement defines whether the aws-auth ConfigMap of the cluster is managed.
+type AWSAuthConfigMapManagement string
+
+var (
+	// AWSAuthConfigMapManagementEnabled indicates that the aws-auth ConfigMap is managed.
+	AWSAuthConfigMapManagementEnabled = AWSAuthConfigMapManagement("enabled")
+
+	// AWSAuthConfigMapManagementDisabled indicates that the aws-auth ConfigMap is left untouched.
+	AWSAuthConfigMapManagementDisabled = AWSAuthConfigMapManagement("disabled")
+)
+
+// AccessConfig represents the access configuration information for the cluster.
+type AccessConfig struct {
+	// AuthenticationMode specifies the desired authentication mode for the cluster.
+	// The mode can only be changed from config_map to api_and_config_map and from
+	// api_and_config_map to api. Defaults to config_map.
+	// +kubebuilder:default=config_map
+	// +kubebuilder:validation:Enum=config_map;api;api_and_config_map
+	AuthenticationMode EKSAuthenticationMode `json:"authenticationMode,omitempty"`
+
+	// BootstrapClusterCreatorAdminPermissions grants cluster admin permissions
+	// to the IAM identity creating the cluster. This is only used at cluster
+	// creation time. Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	BootstrapClusterCreatorAdminPermissions *bool `json:"bootstrapClusterCreatorAdminPermissions,omitempty"`
+}
+
+// GetAuthenticationMode returns the authentication mode of the cluster, defaulting to config_map.
+func (c *AccessConfig) GetAuthenticationMode() EKSAuthenticationMode {
+	if c == nil || c.AuthenticationMode == "" {
+		return EKSAuthenticationModeConfigMap
+	}
+	return c.AuthenticationMode
+}
+
+// EKSAccessEntryType defines the type of an access entry.
+type EKSAccessEntryType string
+
+var (
+	// EKSAccessEntryTypeStandard is the type of an access entry for an IAM principal
+	// accessing the cluster like a user.
+	EKSAccessEntryTypeStandard = EKSAccessEntryType("standard")
+
+	// EKSAccessEntryTypeEC2Linux is the type of an access entry for the IAM role of
+	// self-managed Linux nodes.
+	EKSAccessEntryTypeEC2Linux = EKSAccessEntryType("ec2_linux")
+
+	// EKSAccessEntryTypeEC2Windows is the type of an access entry for the IAM role of
+	// self-managed Windows nodes.
+	EKSAccessEntryTypeEC2Windows = EKSAccessEntryType("ec2_windows")
+
+	// EKSAccessEntryTypeFargateLinux is the type of an access entry for the pod
+	// execution role of Fargate profiles.
+	EKSAccessEntryTypeFargateLinux = EKSAccessEntryType("fargate_linux")
+)
+
+// String returns the EKS API value of the access entry type.
+func (t EKSAccessEntryType) String() string {
+	return strings.ToUpper(string(t))
+}
+
+// AccessEntry represents an IAM principal that is granted access to the cluster.
+type AccessEntry struct {
+	// PrincipalARN is the ARN of the IAM user or role to grant access to.
+	// +kubebuilder:validation:MinLength:=20
+	PrincipalARN string `json:"principalARN"`
+
+	// Type is the type of the access entry. Defaults to standard.
+	// +kubebuilder:default=standard
+	// +kubebuilder:validation:Enum=standard;ec2_linux;ec2_windows;fargate_linux
+	// +optional
+	Type EKSAccessEntryType `json:"type,omitempty"`
+
+	// Username is the Kubernetes username of the principal. If omitted, EKS
+	// generates one from the principal ARN.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// KubernetesGroups is a list of Kubernetes RBAC groups the principal is a member of.
+	// +optional
+	KubernetesGroups []string `json:"kubernetesGroups,omitempty"`
+
+	// AccessPolicies is a list of EKS access policies to associate with the access entry.
+	// +optional
+	AccessPolicies []AccessPolicyReference `json:"accessPolicies,omitempty"`
+}
+
+// AccessPolicyReference represents an EKS access policy associated with an access entry.
+type AccessPolicyReference struct {
+	// PolicyARN is the ARN of the access policy, for example
+	// arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy.
+	// +kubebuilder:validation:MinLength:=20
+	PolicyARN string `json:"policyARN"`
+
+	// AccessScope is the scope of the access policy.
+	// +kubebuilder:validation:Required
+	AccessScope AccessScope `json:"accessScope"`
+}
+
+// EKSAccessScopeType defines the scope of an access policy.
+type EKSAccessScopeType string
+
+var (
+	// EKSAccessScopeTypeCluster scopes an access policy to the whole cluster.
+	EKSAccessScopeTypeCluster = EKSAccessScopeType("cluster")
+
+	// EKSAccessScopeTypeNamespace scopes an access policy to a list of namespaces.
+	EKSAccessScopeTypeNamespace = EKSAccessScopeType("namespace")
+)
+
+// AccessScope represents the scope of an access policy.
+type AccessScope struct {
+	// Type is the type of the scope. Defaults to cluster.
+	// +kubebuilder:default=cluster
+	// +kubebuilder:validation:Enum=cluster;namespace
+	Type EKSAccessScopeType `json:"type"`
+
+	// Namespaces is the list of namespaces the access policy applies to. It
+	// must be set when the type is namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// VersionUpdatePolicy defines the checks that must pass before a control plane minor
+// version update is started.
+type VersionUpdatePolicy struct {
+	// CheckNodegroupVersions blocks the update until all the nodegroups of the cluster
+	// run the current control plane version.
+	// +kubebuilder:default=true
+	CheckNodegroupVersions bool `json:"checkNodegroupVersions"`
+
+	// CheckAddonCompatibility blocks the update until the versions of all the installed
+	// addons are compatible with the next control plane version.
+	// +kubebuilder:default=true
+	CheckAddonCompatibility bool `json:"checkAddonCompatibility"`
+
+	// CheckPodDisruptionBudgets blocks the update while a PodDisruptionBudget of the
+	// workload cluster doesn't allow any disruption.
+	// +kubebuilder:default=true
+	CheckPodDisruptionBudgets bool `json:"checkPodDisruptionBudgets"`
+}
+
+// OutpostConfig defines the configuration of the control plane of an EKS local cluster
+// on AWS Outposts.
+type OutpostConfig struct {
+	// OutpostARNs is the ARN of the Outpost that hosts the control plane. Only a single
+	// Outpost is supported.
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=1
+	OutpostARNs []string `json:"outpostARNs"`
+
+	// ControlPlaneInstanceType is the EC2 instance type of the control plane instances,
+	// e.g. m5d.large. All the instances of the control plane use the same type.
+	// +kubebuilder:validation:MinLength:=1
+	ControlPlaneInstanceType string `json:"controlPlaneInstanceType"`
+
+	// ControlPlanePlacement specifies the placement of the control plane instances
+	// on the Outpost.
+	// +optional
+	ControlPlanePlacement *ControlPlanePlacement `json:"controlPlanePlacement,omitempty"`
+}
+
+// ControlPlanePlacement defines the placement of the control plane instances of an EKS
+// local cluster.
+type ControlPlanePlacement struct {
+	// GroupName is the name of the EC2 placement group used for the control plane
+	// instances. The placement group must use the spread strategy.
+	// +kubebuilder:validation:MinLength:=1
+	GroupName string `json:"groupName"`
+}
+
+// PodIdentityAssociation represents an EKS Pod Identity association between a
+// Kubernetes service account and an IAM role.
+type PodIdentityAssociation struct {
+	// ServiceAccountNamespace is the namespace of the service account.
+	// +kubebuilder:validation:MinLength:=1
+	ServiceAccountNamespace string `json:"serviceAccountNamespace"`
+
+	// ServiceAccountName is the name of the service account.
+	// +kubebuilder:validation:MinLength:=1
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// RoleARN is the ARN of the IAM role the pods using the service account assume.
+	// +kubebuilder:validation:MinLength:=20
+	RoleARN string `json:"roleARN"`
+}
+
 // Addon represents a EKS addon.
 type Addon struct {
 	// Name is the name of the addon
@@ -130,13 +356,14 @@ type Addon struct {
 	Name string `json:"name"`
 	// Version is the version of the addon to use
 	Version string `json:"version"`
-	// Configuration of the EKS addon
+	// Configuration of the EKS addon, as a JSON or YAML document matching the
+	// configuration schema of the addon version
 	// +optional
 	Configuration string `json:"configuration,omitempty"`
 	// ConflictResolution is used to declare what should happen if there
-	// are parameter conflicts. Defaults to none
+	// are parameter conflicts. Defaults to overwrite
 	// +kubebuilder:default=overwrite
-	// +kubebuilder:validation:Enum=overwrite;none
+	// +kubebuilder:validation:Enum=overwrite;none;preserve
 	ConflictResolution *AddonResolution `json:"conflictResolution,omitempty"`
 	// ServiceAccountRoleArn is the ARN of an IAM role to bind to the addons service account
 	// +optional
@@ -154,6 +381,10 @@ var (
 	// AddonResolutionNone indicates that if there are parameter conflicts then
 	// resolution will not be done and an error will be reported.
 	AddonResolutionNone = AddonResolution("none")
+
+	// AddonResolutionPreserve indicates that if there are parameter conflicts then
+	// the values changed on the cluster will be preserved when the addon is updated.
+	AddonResolutionPreserve = AddonResolution("preserve")
 )
 
 // AddonStatus defines the status for an addon.
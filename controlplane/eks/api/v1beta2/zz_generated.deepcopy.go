@@ -110,6 +110,11 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 		*out = new(string)
 		**out = **in
 	}
+	if in.VersionUpdatePolicy != nil {
+		in, out := &in.VersionUpdatePolicy, &out.VersionUpdatePolicy
+		*out = new(VersionUpdatePolicy)
+		**out = **in
+	}
 	if in.RoleName != nil {
 		in, out := &in.RoleName, &out.RoleName
 		*out = new(string)
@@ -127,13 +132,18 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 	if in.Logging != nil {
 		in, out := &in.Logging, &out.Logging
 		*out = new(ControlPlaneLoggingSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EncryptionConfig != nil {
 		in, out := &in.EncryptionConfig, &out.EncryptionConfig
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OutpostConfig != nil {
+		in, out := &in.OutpostConfig, &out.OutpostConfig
+		*out = new(OutpostConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make(apiv1beta2.Tags, len(*in))
@@ -146,6 +156,18 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 		*out = new(IAMAuthenticatorConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessConfig != nil {
+		in, out := &in.AccessConfig, &out.AccessConfig
+		*out = new(AccessConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessEntries != nil {
+		in, out := &in.AccessEntries, &out.AccessEntries
+		*out = make([]AccessEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.EndpointAccess.DeepCopyInto(&out.EndpointAccess)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	in.Bastion.DeepCopyInto(&out.Bastion)
@@ -165,6 +187,11 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 			}
 		}
 	}
+	if in.PodIdentityAssociations != nil {
+		in, out := &in.PodIdentityAssociations, &out.PodIdentityAssociations
+		*out = make([]PodIdentityAssociation, len(*in))
+		copy(*out, *in)
+	}
 	if in.OIDCIdentityProviderConfig != nil {
 		in, out := &in.OIDCIdentityProviderConfig, &out.OIDCIdentityProviderConfig
 		*out = new(OIDCIdentityProviderConfig)
@@ -238,6 +265,89 @@ func (in *AWSManagedControlPlaneStatus) DeepCopy() *AWSManagedControlPlaneStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessConfig) DeepCopyInto(out *AccessConfig) {
+	*out = *in
+	if in.BootstrapClusterCreatorAdminPermissions != nil {
+		in, out := &in.BootstrapClusterCreatorAdminPermissions, &out.BootstrapClusterCreatorAdminPermissions
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessConfig.
+func (in *AccessConfig) DeepCopy() *AccessConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessEntry) DeepCopyInto(out *AccessEntry) {
+	*out = *in
+	if in.KubernetesGroups != nil {
+		in, out := &in.KubernetesGroups, &out.KubernetesGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccessPolicies != nil {
+		in, out := &in.AccessPolicies, &out.AccessPolicies
+		*out = make([]AccessPolicyReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessEntry.
+func (in *AccessEntry) DeepCopy() *AccessEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessPolicyReference) DeepCopyInto(out *AccessPolicyReference) {
+	*out = *in
+	in.AccessScope.DeepCopyInto(&out.AccessScope)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessPolicyReference.
+func (in *AccessPolicyReference) DeepCopy() *AccessPolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessPolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessScope) DeepCopyInto(out *AccessScope) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessScope.
+func (in *AccessScope) DeepCopy() *AccessScope {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Addon) DeepCopyInto(out *Addon) {
 	*out = *in
@@ -327,9 +437,39 @@ func (in *AddonState) DeepCopy() *AddonState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneLogRetentionSpec) DeepCopyInto(out *ControlPlaneLogRetentionSpec) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KMSKeyARN != nil {
+		in, out := &in.KMSKeyARN, &out.KMSKeyARN
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneLogRetentionSpec.
+func (in *ControlPlaneLogRetentionSpec) DeepCopy() *ControlPlaneLogRetentionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneLogRetentionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneLoggingSpec) DeepCopyInto(out *ControlPlaneLoggingSpec) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ControlPlaneLogRetentionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneLoggingSpec.
@@ -342,6 +482,21 @@ func (in *ControlPlaneLoggingSpec) DeepCopy() *ControlPlaneLoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlanePlacement) DeepCopyInto(out *ControlPlanePlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlanePlacement.
+func (in *ControlPlanePlacement) DeepCopy() *ControlPlanePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlanePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EncryptionConfig) DeepCopyInto(out *EncryptionConfig) {
 	*out = *in
@@ -552,6 +707,46 @@ func (in *OIDCProviderStatus) DeepCopy() *OIDCProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutpostConfig) DeepCopyInto(out *OutpostConfig) {
+	*out = *in
+	if in.OutpostARNs != nil {
+		in, out := &in.OutpostARNs, &out.OutpostARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlanePlacement != nil {
+		in, out := &in.ControlPlanePlacement, &out.ControlPlanePlacement
+		*out = new(ControlPlanePlacement)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutpostConfig.
+func (in *OutpostConfig) DeepCopy() *OutpostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutpostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIdentityAssociation) DeepCopyInto(out *PodIdentityAssociation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIdentityAssociation.
+func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIdentityAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
 	*out = *in
@@ -584,6 +779,21 @@ func (in *UserMapping) DeepCopy() *UserMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionUpdatePolicy) DeepCopyInto(out *VersionUpdatePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionUpdatePolicy.
+func (in *VersionUpdatePolicy) DeepCopy() *VersionUpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionUpdatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcCni) DeepCopyInto(out *VpcCni) {
 	*out = *in
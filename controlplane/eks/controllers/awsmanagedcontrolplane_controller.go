@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -39,6 +40,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/awsnode"
@@ -191,6 +193,15 @@ func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 		return fmt.Errorf("failed adding a watch for AWSManagedCluster")
 	}
 
+	// The sessions of the control planes are rebuilt when they are reconciled after the credentials of their
+	// identity are rotated, so that the controller doesn't need to be restarted.
+	if err = c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.Secret{}),
+		handler.EnqueueRequestsFromMapFunc(r.identitySecretToManagedControlPlanes(log)),
+	); err != nil {
+		return fmt.Errorf("failed adding a watch for identity secrets: %w", err)
+	}
+
 	return nil
 }
 
@@ -206,11 +217,15 @@ func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools;awsmachinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclustercontrolleridentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities;awsclustercontrolleridentities;awsclusterwebidentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmanagedclusters;awsmanagedclusters/status,verbs=get;list;watch
 
 // Reconcile will reconcile AWSManagedControlPlane Resources.
 func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("AWSManagedControlPlane", res, reterr)
+	}()
+
 	log := logger.FromContext(ctx)
 
 	// Get the control plane instance
@@ -243,6 +258,7 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 	}
 
 	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Context:                      ctx,
 		Client:                       r.Client,
 		Cluster:                      cluster,
 		ControlPlane:                 awsManagedControlPlane,
@@ -335,7 +351,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 	awsnodeService := r.getAWSNodeService(managedScope)
 	kubeproxyService := r.getKubeProxyService(managedScope)
 
-	if err := networkSvc.ReconcileNetwork(); err != nil {
+	if err := networkSvc.ReconcileNetwork(ctx); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile network for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
 
@@ -344,7 +360,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile general security groups for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
 	}
 
-	if err := ec2Service.ReconcileBastion(); err != nil {
+	if err := ec2Service.ReconcileBastion(ctx); err != nil {
 		conditions.MarkFalse(awsManagedControlPlane, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile bastion host for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
@@ -369,11 +385,15 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 			managedScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
 	}
-	if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
-		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, err.Error())
-		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile aws-iam-authenticator config for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+	// The aws-auth ConfigMap is ignored by EKS when only access entries are used.
+	if awsManagedControlPlane.Spec.AccessConfig.GetAuthenticationMode() != ekscontrolplanev1.EKSAuthenticationModeAPI &&
+		awsManagedControlPlane.Spec.AWSAuthConfigMapManagement != ekscontrolplanev1.AWSAuthConfigMapManagementDisabled {
+		if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
+			conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile aws-iam-authenticator config for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+		}
+		conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 	}
-	conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 
 	for _, subnet := range managedScope.Subnets().FilterPrivate() {
 		managedScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
@@ -412,7 +432,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
-	if err := ec2svc.DeleteBastion(); err != nil {
+	if err := ec2svc.DeleteBastion(ctx); err != nil {
 		log.Error(err, "error deleting bastion for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
 	}
@@ -429,7 +449,7 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		}
 	}
 
-	if err := networkSvc.DeleteNetwork(); err != nil {
+	if err := networkSvc.DeleteNetwork(ctx); err != nil {
 		log.Error(err, "error deleting network for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
 	}
@@ -499,6 +519,42 @@ func (r *AWSManagedControlPlaneReconciler) dependencyCount(ctx context.Context,
 	return dependencies, nil
 }
 
+// identitySecretToManagedControlPlanes returns a mapper of the secrets of AWSClusterStaticIdentities to the
+// AWSManagedControlPlanes using them, directly or through AWSClusterRoleIdentities.
+func (r *AWSManagedControlPlaneReconciler) identitySecretToManagedControlPlanes(log *logger.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		refs, err := scope.IdentityRefsForSecret(ctx, r.Client, o)
+		if err != nil {
+			log.Error(err, "failed to get the identities using the secret", "secret", klog.KObj(o))
+			return nil
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+
+		controlPlanes := &ekscontrolplanev1.AWSManagedControlPlaneList{}
+		if err := r.List(ctx, controlPlanes); err != nil {
+			log.Error(err, "failed to list AWSManagedControlPlanes")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for i := range controlPlanes.Items {
+			controlPlane := &controlPlanes.Items[i]
+			if controlPlane.Spec.IdentityRef == nil {
+				continue
+			}
+			for _, ref := range refs {
+				if *controlPlane.Spec.IdentityRef == ref {
+					requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(controlPlane)})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
 func (r *AWSManagedControlPlaneReconciler) managedClusterToManagedControlPlane(_ context.Context, log *logger.Logger) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []ctrl.Request {
 		awsManagedCluster, ok := o.(*infrav1.AWSManagedCluster)
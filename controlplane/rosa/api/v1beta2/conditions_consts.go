@@ -28,6 +28,10 @@ const (
 	// ROSAControlPlaneUpgradingCondition condition reports whether ROSAControlPlane is upgrading or not.
 	ROSAControlPlaneUpgradingCondition clusterv1.ConditionType = "ROSAControlPlaneUpgrading"
 
+	// ROSAControlPlaneSubnetsValidCondition condition reports whether the subnets provided for ROSAControlPlane meet the
+	// requirements of ROSA.
+	ROSAControlPlaneSubnetsValidCondition clusterv1.ConditionType = "ROSAControlPlaneSubnetsValid"
+
 	// ExternalAuthConfiguredCondition condition reports whether external auth has beed correctly configured.
 	ExternalAuthConfiguredCondition clusterv1.ConditionType = "ExternalAuthConfigured"
 
@@ -39,4 +43,17 @@ const (
 
 	// ROSAControlPlaneInvalidConfigurationReason used to report invalid user input.
 	ROSAControlPlaneInvalidConfigurationReason = "InvalidConfiguration"
+
+	// SubnetsInvalidReason used to report subnets that don't meet the requirements of ROSA.
+	SubnetsInvalidReason = "SubnetsInvalid"
+
+	// SubnetsTaggingFailedReason used to report failures while applying the tags required by ROSA to the subnets.
+	SubnetsTaggingFailedReason = "SubnetsTaggingFailed"
+
+	// UpgradeRequiresAcknowledgementReason used when the upgrade of ROSAControlPlane waits for its version gates
+	// to be acknowledged.
+	UpgradeRequiresAcknowledgementReason = "UpgradeRequiresAcknowledgement"
+
+	// UpgradeFailedReason used to report failures while scheduling the upgrade of ROSAControlPlane.
+	UpgradeFailedReason = "UpgradeFailed"
 )
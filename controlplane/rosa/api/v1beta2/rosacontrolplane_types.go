@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta2
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -62,6 +64,14 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	DomainPrefix string `json:"domainPrefix,omitempty"`
 
+	// DNS configures the DNS of the cluster in a customer-owned domain and hosted zone.
+	// The DNS records of the cluster are created in a hosted zone managed by Red Hat when not set.
+	//
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="dns is immutable"
+	// +optional
+	DNS *DNSSpec `json:"dns,omitempty"`
+
 	// The Subnet IDs to use when installing the cluster.
 	// SubnetIDs should come in pairs; two per availability zone, one private and one public.
 	Subnets []string `json:"subnets"`
@@ -77,6 +87,32 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// OpenShift semantic version, for example "4.14.5".
 	Version string `json:"version"`
 
+	// VersionGate requires acknowledgment of the version gates, such as API removals, that an upgrade to a new
+	// version is subject to.
+	// With "WaitForAcknowledge", the upgrade waits until the version gates are acknowledged.
+	// With "Acknowledge", the version gates of the next upgrade are acknowledged, then the field is reset to
+	// "WaitForAcknowledge".
+	// With "AlwaysAcknowledge", the version gates of all upgrades are acknowledged.
+	//
+	// +kubebuilder:validation:Enum=Acknowledge;WaitForAcknowledge;AlwaysAcknowledge
+	// +kubebuilder:default=WaitForAcknowledge
+	// +optional
+	VersionGate VersionGateAckType `json:"versionGate,omitempty"`
+
+	// UpgradeWindow restricts the time at which the upgrades to a new version are scheduled.
+	// The upgrades are scheduled as soon as possible when not set.
+	// +optional
+	UpgradeWindow *UpgradeWindow `json:"upgradeWindow,omitempty"`
+
+	// NodeDrainGracePeriod is grace period for how long Pod Disruption Budget-protected workloads will be
+	// respected during upgrades. After this grace period, any workloads protected by Pod Disruption
+	// Budgets that have not been successfully drained from a node will be forcibly evicted.
+	//
+	// Valid values are from 0 to 1 week(10080m|168h).
+	//
+	// +optional
+	NodeDrainGracePeriod *metav1.Duration `json:"nodeDrainGracePeriod,omitempty"`
+
 	// AWS IAM roles used to perform credential requests by the openshift operators.
 	RolesRef AWSRolesRef `json:"rolesRef"`
 
@@ -182,6 +218,82 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
 }
 
+// VersionGateAckType specifies how the version gates of an upgrade are acknowledged.
+type VersionGateAckType string
+
+const (
+	// Acknowledge acknowledges the version gates of the next upgrade.
+	Acknowledge VersionGateAckType = "Acknowledge"
+
+	// WaitForAcknowledge waits for the version gates of an upgrade to be acknowledged.
+	WaitForAcknowledge VersionGateAckType = "WaitForAcknowledge"
+
+	// AlwaysAcknowledge acknowledges the version gates of all upgrades.
+	AlwaysAcknowledge VersionGateAckType = "AlwaysAcknowledge"
+)
+
+// UpgradeWindow is a weekly or daily window of one hour during which upgrades are scheduled.
+type UpgradeWindow struct {
+	// DayOfWeek is the day of the week of the window. The window is daily when not set.
+	//
+	// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	// +optional
+	DayOfWeek string `json:"dayOfWeek,omitempty"`
+
+	// StartHour is the hour of the day, in UTC, the window starts at.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	StartHour int `json:"startHour"`
+}
+
+// Contains returns whether a time falls in the window.
+func (w *UpgradeWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	return t.Hour() == w.StartHour && (w.DayOfWeek == "" || t.Weekday().String() == w.DayOfWeek)
+}
+
+// Next returns the earliest time from the specified one that falls in the window.
+func (w *UpgradeWindow) Next(from time.Time) time.Time {
+	from = from.UTC()
+	if w.Contains(from) {
+		return from
+	}
+
+	for days := 0; days <= 7; days++ {
+		start := time.Date(from.Year(), from.Month(), from.Day()+days, w.StartHour, 0, 0, 0, time.UTC)
+		if start.After(from) && w.Contains(start) {
+			return start
+		}
+	}
+
+	return from
+}
+
+// DNSSpec configures the DNS of a ROSA cluster.
+type DNSSpec struct {
+	// BaseDomain is the base DNS domain of the cluster, under which the DNS records of the cluster are created.
+	// It must be reserved beforehand, e.g. with `rosa create dns-domain --hosted-cp`.
+	//
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	BaseDomain string `json:"baseDomain"`
+
+	// PrivateHostedZoneID is the ID of an existing Route 53 private hosted zone of the base domain, associated
+	// with the VPC of the cluster, in which the DNS records of the cluster are created.
+	//
+	// +kubebuilder:validation:Pattern:=`^Z[A-Z0-9]+$`
+	// +optional
+	PrivateHostedZoneID string `json:"privateHostedZoneID,omitempty"`
+
+	// PrivateHostedZoneRoleARN is the ARN of the IAM role assumed to manage the records of the private hosted zone,
+	// e.g. when the hosted zone belongs to the AWS account sharing its VPC with the cluster.
+	// It is required along with the PrivateHostedZoneID.
+	//
+	// +optional
+	PrivateHostedZoneRoleARN string `json:"privateHostedZoneRoleARN,omitempty"`
+}
+
 // NetworkSpec for ROSA-HCP.
 type NetworkSpec struct {
 	// IP addresses block used by OpenShift while installing the cluster, for example "10.0.0.0/16".
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestUpgradeWindowNext(t *testing.T) {
+	// 2024-03-06 is a Wednesday.
+	wednesday := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		window UpgradeWindow
+		from   time.Time
+		want   time.Time
+	}{
+		{
+			name:   "daily window later the same day",
+			window: UpgradeWindow{StartHour: 22},
+			from:   wednesday,
+			want:   time.Date(2024, time.March, 6, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "daily window the next day",
+			window: UpgradeWindow{StartHour: 2},
+			from:   wednesday,
+			want:   time.Date(2024, time.March, 7, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "time in the window",
+			window: UpgradeWindow{DayOfWeek: "Wednesday", StartHour: 10},
+			from:   wednesday,
+			want:   wednesday,
+		},
+		{
+			name:   "weekly window later in the week",
+			window: UpgradeWindow{DayOfWeek: "Saturday", StartHour: 4},
+			from:   wednesday,
+			want:   time.Date(2024, time.March, 9, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "weekly window the next week",
+			window: UpgradeWindow{DayOfWeek: "Wednesday", StartHour: 9},
+			from:   wednesday,
+			want:   time.Date(2024, time.March, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "time in another time zone",
+			window: UpgradeWindow{DayOfWeek: "Thursday", StartHour: 1},
+			from:   time.Date(2024, time.March, 6, 20, 30, 0, 0, time.FixedZone("EST", -5*60*60)),
+			want:   time.Date(2024, time.March, 7, 1, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			next := tc.window.Next(tc.from)
+			g.Expect(next).To(BeTemporally("==", tc.want))
+			g.Expect(tc.window.Contains(next)).To(BeTrue())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package v1beta2
 import (
 	"net"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/blang/semver"
 	kmsArnRegexpValidator "github.com/openshift-online/ocm-common/pkg/resource/validations"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -38,11 +39,16 @@ func (r *ROSAControlPlane) ValidateCreate() (warnings admission.Warnings, err er
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateNodeDrainGracePeriod(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := r.validateExternalAuthProviders(); err != nil {
 		allErrs = append(allErrs, err)
 	}
 
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateDNS()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	if len(allErrs) == 0 {
@@ -68,6 +74,10 @@ func (r *ROSAControlPlane) ValidateUpdate(old runtime.Object) (warnings admissio
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateNodeDrainGracePeriod(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
@@ -128,6 +138,30 @@ func (r *ROSAControlPlane) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+func (r *ROSAControlPlane) validateDNS() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.DNS == nil {
+		return allErrs
+	}
+
+	rootPath := field.NewPath("spec", "dns")
+
+	if r.Spec.DNS.PrivateHostedZoneID != "" && r.Spec.DNS.PrivateHostedZoneRoleARN == "" {
+		allErrs = append(allErrs, field.Required(rootPath.Child("privateHostedZoneRoleARN"), "must be set along with privateHostedZoneID"))
+	}
+
+	if r.Spec.DNS.PrivateHostedZoneRoleARN != "" {
+		if r.Spec.DNS.PrivateHostedZoneID == "" {
+			allErrs = append(allErrs, field.Required(rootPath.Child("privateHostedZoneID"), "must be set along with privateHostedZoneRoleARN"))
+		}
+		if _, err := arn.Parse(r.Spec.DNS.PrivateHostedZoneRoleARN); err != nil {
+			allErrs = append(allErrs, field.Invalid(rootPath.Child("privateHostedZoneRoleARN"), r.Spec.DNS.PrivateHostedZoneRoleARN, "must be a valid ARN"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *ROSAControlPlane) validateEtcdEncryptionKMSArn() *field.Error {
 	err := kmsArnRegexpValidator.ValidateKMSKeyARN(&r.Spec.EtcdEncryptionKMSARN)
 	if err != nil {
@@ -137,6 +171,24 @@ func (r *ROSAControlPlane) validateEtcdEncryptionKMSArn() *field.Error {
 	return nil
 }
 
+func (r *ROSAControlPlane) validateNodeDrainGracePeriod() *field.Error {
+	if r.Spec.NodeDrainGracePeriod == nil {
+		return nil
+	}
+
+	if r.Spec.NodeDrainGracePeriod.Duration < 0 {
+		return field.Invalid(field.NewPath("spec.nodeDrainGracePeriod"), r.Spec.NodeDrainGracePeriod,
+			"duration can't be negative")
+	}
+
+	if r.Spec.NodeDrainGracePeriod.Minutes() > 10080 {
+		return field.Invalid(field.NewPath("spec.nodeDrainGracePeriod"), r.Spec.NodeDrainGracePeriod,
+			"max supported duration is 1 week (10080m|168h)")
+	}
+
+	return nil
+}
+
 func (r *ROSAControlPlane) validateExternalAuthProviders() *field.Error {
 	if !r.Spec.EnableExternalAuthProviders && len(r.Spec.ExternalAuthProviders) > 0 {
 		return field.Invalid(field.NewPath("spec.ExternalAuthProviders"), r.Spec.ExternalAuthProviders,
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestROSAControlPlaneValidateDNS(t *testing.T) {
+	tests := []struct {
+		name      string
+		dns       *DNSSpec
+		expectErr bool
+	}{
+		{
+			name: "no DNS",
+		},
+		{
+			name: "base domain",
+			dns:  &DNSSpec{BaseDomain: "abcd.p3.openshiftapps.com"},
+		},
+		{
+			name: "private hosted zone",
+			dns: &DNSSpec{
+				BaseDomain:               "abcd.p3.openshiftapps.com",
+				PrivateHostedZoneID:      "Z0123456789ABCDEFGHIJ",
+				PrivateHostedZoneRoleARN: "arn:aws:iam::123456789012:role/route53-shared-vpc",
+			},
+		},
+		{
+			name: "private hosted zone without role",
+			dns: &DNSSpec{
+				BaseDomain:          "abcd.p3.openshiftapps.com",
+				PrivateHostedZoneID: "Z0123456789ABCDEFGHIJ",
+			},
+			expectErr: true,
+		},
+		{
+			name: "role without private hosted zone",
+			dns: &DNSSpec{
+				BaseDomain:               "abcd.p3.openshiftapps.com",
+				PrivateHostedZoneRoleARN: "arn:aws:iam::123456789012:role/route53-shared-vpc",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid role ARN",
+			dns: &DNSSpec{
+				BaseDomain:               "abcd.p3.openshiftapps.com",
+				PrivateHostedZoneID:      "Z0123456789ABCDEFGHIJ",
+				PrivateHostedZoneRoleARN: "route53-shared-vpc",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			controlPlane := &ROSAControlPlane{
+				Spec: RosaControlPlaneSpec{DNS: tc.dns},
+			}
+			if tc.expectErr {
+				g.Expect(controlPlane.validateDNS()).NotTo(BeEmpty())
+				return
+			}
+			g.Expect(controlPlane.validateDNS()).To(BeEmpty())
+		})
+	}
+}
@@ -54,6 +54,7 @@ import (
 	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
@@ -132,6 +133,10 @@ func (r *ROSAControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr c
 
 // Reconcile will reconcile RosaControlPlane Resources.
 func (r *ROSAControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("ROSAControlPlane", res, reterr)
+	}()
+
 	log := logger.FromContext(ctx)
 
 	// Get the control plane instance
@@ -292,6 +297,15 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 		return ctrl.Result{RequeueAfter: time.Second * 60}, nil
 	}
 
+	subnetsValid, err := r.reconcileSubnets(ctx, rosaScope)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile subnets: %w", err)
+	}
+	if !subnetsValid {
+		// requeue as the subnets can be fixed without changing ROSAControlPlane.
+		return ctrl.Result{RequeueAfter: time.Second * 60}, nil
+	}
+
 	ocmClusterSpec, err := buildOCMClusterSpec(rosaScope.ControlPlane.Spec, creator)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -313,6 +327,40 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 	return ctrl.Result{}, nil
 }
 
+// reconcileSubnets validates the subnets provided for the cluster before it is created and applies the tags ROSA
+// requires to them, so that misconfigured subnets are reported explicitly instead of failing the cluster creation.
+func (r *ROSAControlPlaneReconciler) reconcileSubnets(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (bool, error) {
+	ec2Client := scope.NewEC2Client(rosaScope, rosaScope, &rosaScope.Logger, rosaScope.ControlPlane)
+	subnets, validationMessage, err := rosa.DescribeSubnets(ctx, ec2Client, rosaScope.ControlPlane.Spec.Subnets)
+	if err != nil {
+		return false, err
+	}
+	if validationMessage == "" {
+		validationMessage = rosa.ValidateSubnets(subnets, rosaScope.ControlPlane.Spec)
+	}
+	if validationMessage != "" {
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneSubnetsValidCondition,
+			rosacontrolplanev1.SubnetsInvalidReason,
+			clusterv1.ConditionSeverityError,
+			validationMessage)
+		rosaScope.Info("subnets don't meet the requirements of ROSA", "reason", validationMessage)
+		return false, nil
+	}
+
+	if err := rosa.TagSubnets(ctx, ec2Client, subnets); err != nil {
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneSubnetsValidCondition,
+			rosacontrolplanev1.SubnetsTaggingFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return false, err
+	}
+
+	conditions.MarkTrue(rosaScope.ControlPlane, rosacontrolplanev1.ROSAControlPlaneSubnetsValidCondition)
+	return true, nil
+}
+
 func (r *ROSAControlPlaneReconciler) reconcileDelete(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (res ctrl.Result, reterr error) {
 	rosaScope.Info("Reconciling ROSAControlPlane delete")
 
@@ -373,23 +421,53 @@ func (r *ROSAControlPlaneReconciler) reconcileClusterVersion(rosaScope *scope.RO
 		return nil
 	}
 
+	nextRun := rosa.EarliestNextRun(time.Now())
+	if window := rosaScope.ControlPlane.Spec.UpgradeWindow; window != nil {
+		nextRun = window.Next(nextRun)
+	}
+
 	scheduledUpgrade, err := rosa.CheckExistingScheduledUpgrade(ocmClient, cluster)
 	if err != nil {
 		return fmt.Errorf("failed to get existing scheduled upgrades: %w", err)
 	}
 
+	// an upgrade that didn't start yet is rescheduled when the version or the upgrade window changed.
+	if scheduledUpgrade != nil && scheduledUpgrade.State().Value() == cmv1.UpgradePolicyStateValueScheduled &&
+		(scheduledUpgrade.Version() != version || !inUpgradeWindow(rosaScope.ControlPlane.Spec.UpgradeWindow, scheduledUpgrade.NextRun())) {
+		rosaScope.Info("rescheduling control plane upgrade", "version", version, "nextRun", nextRun)
+		if err := rosa.CancelControlPlaneUpgrade(ocmClient, cluster, scheduledUpgrade); err != nil {
+			return err
+		}
+		scheduledUpgrade = nil
+	}
+
 	if scheduledUpgrade == nil {
-		scheduledUpgrade, err = rosa.ScheduleControlPlaneUpgrade(ocmClient, cluster, version, time.Now())
+		ack, err := r.reconcileVersionGates(rosaScope, ocmClient, cluster, version)
+		if err != nil || !ack {
+			return err
+		}
+
+		scheduledUpgrade, err = rosa.ScheduleControlPlaneUpgrade(ocmClient, cluster, version, nextRun)
 		if err != nil {
+			conditions.MarkFalse(rosaScope.ControlPlane,
+				rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
+				rosacontrolplanev1.UpgradeFailedReason,
+				clusterv1.ConditionSeverityError,
+				"failed to schedule upgrade to version %s: %s", version, err.Error())
 			return fmt.Errorf("failed to schedule control plane upgrade to version %s: %w", version, err)
 		}
+
+		// the version gates are only acknowledged once with "Acknowledge".
+		if rosaScope.ControlPlane.Spec.VersionGate == rosacontrolplanev1.Acknowledge {
+			rosaScope.ControlPlane.Spec.VersionGate = rosacontrolplanev1.WaitForAcknowledge
+		}
 	}
 
 	condition := &clusterv1.Condition{
 		Type:    rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
 		Status:  corev1.ConditionTrue,
 		Reason:  string(scheduledUpgrade.State().Value()),
-		Message: fmt.Sprintf("Upgrading to version %s", scheduledUpgrade.Version()),
+		Message: fmt.Sprintf("Upgrading to version %s, scheduled at %s", scheduledUpgrade.Version(), scheduledUpgrade.NextRun().UTC().Format(time.RFC3339)),
 	}
 	conditions.Set(rosaScope.ControlPlane, condition)
 
@@ -401,14 +479,59 @@ func (r *ROSAControlPlaneReconciler) reconcileClusterVersion(rosaScope *scope.RO
 	return nil
 }
 
+// reconcileVersionGates acknowledges the version gates of the upgrade to the specified version according to the
+// VersionGate of the ROSAControlPlane, and returns whether the upgrade can be scheduled.
+func (r *ROSAControlPlaneReconciler) reconcileVersionGates(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster, version string) (bool, error) {
+	versionGates, err := rosa.MissingVersionGateAgreements(ocmClient, cluster, version)
+	if err != nil {
+		return false, fmt.Errorf("failed to get version gates of the upgrade to version %s: %w", version, err)
+	}
+	if len(versionGates) == 0 {
+		return true, nil
+	}
+
+	if rosaScope.ControlPlane.Spec.VersionGate == rosacontrolplanev1.WaitForAcknowledge || rosaScope.ControlPlane.Spec.VersionGate == "" {
+		descriptions := make([]string, 0, len(versionGates))
+		for _, versionGate := range versionGates {
+			descriptions = append(descriptions, versionGate.Description())
+		}
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
+			rosacontrolplanev1.UpgradeRequiresAcknowledgementReason,
+			clusterv1.ConditionSeverityWarning,
+			"upgrade to version %s requires acknowledgement of the version gates: %s", version, strings.Join(descriptions, "; "))
+		rosaScope.Info("waiting for the version gates of the upgrade to be acknowledged", "version", version)
+		return false, nil
+	}
+
+	if err := rosa.AcknowledgeVersionGates(ocmClient, cluster, versionGates); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// inUpgradeWindow returns whether a time falls in the upgrade window, if any.
+func inUpgradeWindow(window *rosacontrolplanev1.UpgradeWindow, t time.Time) bool {
+	return window == nil || window.Contains(t)
+}
+
 func (r *ROSAControlPlaneReconciler) updateOCMCluster(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster, creator *rosaaws.Creator) error {
-	currentAuditLogRole := cluster.AWS().AuditLog().RoleArn()
-	if currentAuditLogRole == rosaScope.ControlPlane.Spec.AuditLogRoleARN {
-		return nil
+	ocmClusterSpec := ocm.Spec{}
+	updated := false
+
+	if currentAuditLogRole := cluster.AWS().AuditLog().RoleArn(); currentAuditLogRole != rosaScope.ControlPlane.Spec.AuditLogRoleARN {
+		ocmClusterSpec.AuditLogRoleARN = ptr.To(rosaScope.ControlPlane.Spec.AuditLogRoleARN)
+		updated = true
 	}
 
-	ocmClusterSpec := ocm.Spec{
-		AuditLogRoleARN: ptr.To(rosaScope.ControlPlane.Spec.AuditLogRoleARN),
+	if nodeDrainGracePeriod := rosaScope.ControlPlane.Spec.NodeDrainGracePeriod; nodeDrainGracePeriod != nil &&
+		nodeDrainGracePeriod.Minutes() != cluster.NodeDrainGracePeriod().Value() {
+		ocmClusterSpec.NodeDrainGracePeriodInMinutes = nodeDrainGracePeriod.Minutes()
+		updated = true
+	}
+
+	if !updated {
+		return nil
 	}
 
 	// if this fails, the provided role is likely invalid or it doesn't have the required permissions.
@@ -845,6 +968,16 @@ func buildOCMClusterSpec(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpe
 		ocmClusterSpec.ComputeNodes = len(controlPlaneSpec.AvailabilityZones)
 	}
 
+	if controlPlaneSpec.DNS != nil {
+		ocmClusterSpec.BaseDomain = controlPlaneSpec.DNS.BaseDomain
+		ocmClusterSpec.PrivateHostedZoneID = controlPlaneSpec.DNS.PrivateHostedZoneID
+		ocmClusterSpec.SharedVPCRoleArn = controlPlaneSpec.DNS.PrivateHostedZoneRoleARN
+	}
+
+	if controlPlaneSpec.NodeDrainGracePeriod != nil {
+		ocmClusterSpec.NodeDrainGracePeriodInMinutes = controlPlaneSpec.NodeDrainGracePeriod.Minutes()
+	}
+
 	if controlPlaneSpec.ProvisionShardID != "" {
 		ocmClusterSpec.CustomProperties = map[string]string{
 			"provision_shard_id": controlPlaneSpec.ProvisionShardID,
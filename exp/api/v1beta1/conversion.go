@@ -55,7 +55,25 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 	}
 
+	dst.Spec.AWSLaunchTemplate.PinnedVersion = restored.Spec.AWSLaunchTemplate.PinnedVersion
+	dst.Spec.AWSLaunchTemplate.OSFamily = restored.Spec.AWSLaunchTemplate.OSFamily
 	dst.Spec.DefaultInstanceWarmup = restored.Spec.DefaultInstanceWarmup
+	dst.Spec.LoadBalancerAttachments = restored.Spec.LoadBalancerAttachments
+	dst.Spec.SubnetSelector = restored.Spec.SubnetSelector
+	dst.Spec.SubnetSelectionStrategy = restored.Spec.SubnetSelectionStrategy
+	dst.Spec.PreferredAvailabilityZones = restored.Spec.PreferredAvailabilityZones
+	dst.Spec.TargetGroupARNs = restored.Spec.TargetGroupARNs
+	dst.Spec.ClusterAutoscaler = restored.Spec.ClusterAutoscaler
+
+	dst.Status.AvailabilityZones = restored.Status.AvailabilityZones
+	dst.Status.InfrastructureMachineKind = restored.Status.InfrastructureMachineKind
+	dst.Status.CostEstimate = restored.Status.CostEstimate
+	dst.Status.SpotRecommendations = restored.Status.SpotRecommendations
+	for i := range dst.Status.Instances {
+		if i < len(restored.Status.Instances) && restored.Status.Instances[i].InstanceID == dst.Status.Instances[i].InstanceID {
+			dst.Status.Instances[i] = restored.Status.Instances[i]
+		}
+	}
 
 	return nil
 }
@@ -105,10 +123,15 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 			dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 		}
+		dst.Spec.AWSLaunchTemplate.PinnedVersion = restored.Spec.AWSLaunchTemplate.PinnedVersion
+		dst.Spec.AWSLaunchTemplate.OSFamily = restored.Spec.AWSLaunchTemplate.OSFamily
 	}
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	dst.Spec.AdditionalUserDataSecretRef = restored.Spec.AdditionalUserDataSecretRef
+	dst.Spec.ClusterAutoscaler = restored.Spec.ClusterAutoscaler
+	dst.Spec.NodeRepairConfig = restored.Spec.NodeRepairConfig
 
 	return nil
 }
@@ -145,14 +168,34 @@ func (r *AWSManagedMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 // ConvertTo converts the v1beta1 AWSFargateProfile receiver to a v1beta2 AWSFargateProfile.
 func (src *AWSFargateProfile) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AWSFargateProfile)
-	return Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil)
+	if err := Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil); err != nil {
+		return err
+	}
+	// Manually restore data.
+	restored := &infrav1exp.AWSFargateProfile{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Status.SelectorStatuses = restored.Status.SelectorStatuses
+
+	return nil
 }
 
 // ConvertFrom converts the v1beta2 AWSFargateProfile receiver to v1beta1 AWSFargateProfile.
 func (r *AWSFargateProfile) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AWSFargateProfile)
 
-	return Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil)
+	if err := Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, r)
+}
+
+// Convert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus is a conversion function.
+func Convert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus(in *infrav1exp.FargateProfileStatus, out *FargateProfileStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus(in, out, s)
 }
 
 // ConvertTo converts the v1beta1 AWSFargateProfileList receiver to a v1beta2 AWSFargateProfileList.
@@ -210,6 +253,19 @@ func Convert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in *infrav1exp
 	return autoConvert_v1beta2_AutoScalingGroup_To_v1beta1_AutoScalingGroup(in, out, s)
 }
 
+// Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus converts the v1beta2 AWSMachinePoolStatus receiver to a v1beta1 AWSMachinePoolStatus.
+func Convert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in *infrav1exp.AWSMachinePoolStatus, out *AWSMachinePoolStatus, s apiconversion.Scope) error {
+	// status.availabilityZones, status.infrastructureMachineKind, status.costEstimate and status.spotRecommendations have been added to v1beta2.
+	return autoConvert_v1beta2_AWSMachinePoolStatus_To_v1beta1_AWSMachinePoolStatus(in, out, s)
+}
+
+// Convert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus converts the v1beta2 AWSMachinePoolInstanceStatus receiver to a v1beta1 AWSMachinePoolInstanceStatus.
+func Convert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus(in *infrav1exp.AWSMachinePoolInstanceStatus, out *AWSMachinePoolInstanceStatus, s apiconversion.Scope) error {
+	// the availability zone, lifecycle state, health status, launch template version and capacity type of the
+	// instances have been added to v1beta2.
+	return autoConvert_v1beta2_AWSMachinePoolInstanceStatus_To_v1beta1_AWSMachinePoolInstanceStatus(in, out, s)
+}
+
 // Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences converts the v1beta2 RefreshPreferences receiver to a v1beta1 RefreshPreferences.
 func Convert_v1beta2_RefreshPreferences_To_v1beta1_RefreshPreferences(in *infrav1exp.RefreshPreferences, out *RefreshPreferences, s apiconversion.Scope) error {
 	// spec.refreshPreferences.disable has been added to v1beta2.
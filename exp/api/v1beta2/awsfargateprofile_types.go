@@ -60,19 +60,43 @@ type FargateProfileSpec struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
-	// Selectors specify fargate pod selectors.
+	// Selectors specify fargate pod selectors. A pod is scheduled on Fargate
+	// if it matches any of the selectors. EKS supports up to five selectors.
+	// +kubebuilder:validation:MaxItems=5
 	Selectors []FargateSelector `json:"selectors,omitempty"`
 }
 
 // FargateSelector specifies a selector for pods that should run on this fargate pool.
 type FargateSelector struct {
-	// Labels specifies which pod labels this selector should match.
+	// Labels specifies which pod labels this selector should match. A pod
+	// must have all the labels to match the selector. Keys and values may
+	// contain the * and ? wildcards. EKS supports up to five labels.
+	// +kubebuilder:validation:MaxProperties=5
 	Labels map[string]string `json:"labels,omitempty"`
 
-	// Namespace specifies which namespace this selector should match.
+	// Namespace specifies which namespace this selector should match. The
+	// namespace may contain the * and ? wildcards.
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// FargateSelectorStatus is the observed state of a selector of the FargateProfile.
+type FargateSelectorStatus struct {
+	// Namespace is the namespace of the selector.
+	Namespace string `json:"namespace"`
+
+	// Labels are the labels of the selector.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Ready denotes that pods matching the selector are scheduled on Fargate.
+	Ready bool `json:"ready"`
+
+	// FailureMessage describes why the selector couldn't be added to the
+	// fargate profile.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
 // FargateProfileStatus defines the observed state of FargateProfile.
 type FargateProfileStatus struct {
 	// Ready denotes that the FargateProfile is available.
@@ -117,6 +141,10 @@ type FargateProfileStatus struct {
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
+	// SelectorStatuses reports the state of each selector of the Fargate profile.
+	// +optional
+	SelectorStatuses []FargateSelectorStatus `json:"selectorStatuses,omitempty"`
+
 	// Conditions defines current state of the Fargate profile.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
@@ -18,11 +18,14 @@ package v1beta2
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -35,8 +38,14 @@ import (
 const (
 	maxProfileNameLength = 100
 	maxIAMRoleNameLength = 64
+	maxSelectors         = 5
+	maxSelectorLabels    = 5
 )
 
+// selectorWildcardReplacer replaces the wildcards supported by fargate selectors so
+// that the rest of a namespace or label can be validated like a Kubernetes one.
+var selectorWildcardReplacer = strings.NewReplacer("*", "x", "?", "x")
+
 // SetupWebhookWithManager will setup the webhooks for the AWSFargateProfile.
 func (r *AWSFargateProfile) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -137,6 +146,7 @@ func (r *AWSFargateProfile) ValidateCreate() (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateSelectors()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -148,6 +158,46 @@ func (r *AWSFargateProfile) ValidateCreate() (admission.Warnings, error) {
 	)
 }
 
+func (r *AWSFargateProfile) validateSelectors() field.ErrorList {
+	var allErrs field.ErrorList
+	selectorsPath := field.NewPath("spec", "selectors")
+
+	if len(r.Spec.Selectors) > maxSelectors {
+		allErrs = append(allErrs, field.TooMany(selectorsPath, len(r.Spec.Selectors), maxSelectors))
+	}
+
+	for i, selector := range r.Spec.Selectors {
+		selectorPath := selectorsPath.Index(i)
+
+		if selector.Namespace == "" {
+			allErrs = append(allErrs, field.Required(selectorPath.Child("namespace"), "namespace is required"))
+		} else if errs := validation.IsDNS1123Label(selectorWildcardReplacer.Replace(selector.Namespace)); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(selectorPath.Child("namespace"), selector.Namespace, strings.Join(errs, "; ")))
+		}
+
+		if len(selector.Labels) > maxSelectorLabels {
+			allErrs = append(allErrs, field.TooMany(selectorPath.Child("labels"), len(selector.Labels), maxSelectorLabels))
+		}
+
+		keys := make([]string, 0, len(selector.Labels))
+		for key := range selector.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			labelPath := selectorPath.Child("labels").Key(key)
+			if errs := validation.IsQualifiedName(selectorWildcardReplacer.Replace(key)); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(labelPath, key, strings.Join(errs, "; ")))
+			}
+			if errs := validation.IsValidLabelValue(selectorWildcardReplacer.Replace(selector.Labels[key])); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(labelPath, selector.Labels[key], strings.Join(errs, "; ")))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSFargateProfile) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
@@ -177,6 +177,65 @@ func TestAWSFargateProfileValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "selectors with wildcards are accepted",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Namespace: "kube-system"},
+						{Namespace: "app-*", Labels: map[string]string{"app.kubernetes.io/*": "web-?", "tier": "*"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "more than five selectors are rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{
+						{Namespace: "ns-1"}, {Namespace: "ns-2"}, {Namespace: "ns-3"},
+						{Namespace: "ns-4"}, {Namespace: "ns-5"}, {Namespace: "ns-6"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector without namespace is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors:   []FargateSelector{{Labels: map[string]string{"app": "web"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector with more than five labels is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors: []FargateSelector{{
+						Namespace: "default",
+						Labels:    map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6"},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector with an invalid label is rejected",
+			profile: &AWSFargateProfile{
+				Spec: FargateProfileSpec{
+					ClusterName: "cluster-1",
+					Selectors:   []FargateSelector{{Namespace: "default", Labels: map[string]string{"app": "web/*"}}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// AWSIAMRoleSpec defines the desired state of AWSIAMRole.
+type AWSIAMRoleSpec struct {
+	// ClusterName is the name of the Cluster whose IAM OIDC identity provider the role trusts.
+	// The OIDC provider has to be associated with the cluster through the AWSManagedControlPlane
+	// or the AWSCluster of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+
+	// RoleName specifies the name of the IAM role. It defaults to a name based on the
+	// namespace and the name of the AWSIAMRole. A pre-existing role that isn't owned by
+	// the cluster is neither updated nor deleted.
+	// +optional
+	RoleName string `json:"roleName,omitempty"`
+
+	// ServiceAccounts are the service accounts of the workload cluster allowed to assume the role.
+	// +kubebuilder:validation:MinItems=1
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts"`
+
+	// ManagedPolicyARNs are the ARNs of the managed policies to attach to the role.
+	// +optional
+	ManagedPolicyARNs []string `json:"managedPolicyARNs,omitempty"`
+
+	// InlinePolicies are the inline policies to embed in the role.
+	// +optional
+	InlinePolicies []InlinePolicy `json:"inlinePolicies,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the IAM role, in addition to the
+	// ones added by default.
+	// +optional
+	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
+}
+
+// ServiceAccountReference references a service account of the workload cluster.
+type ServiceAccountReference struct {
+	// Namespace is the namespace of the service account.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service account. It may contain the * and ? wildcards,
+	// for instance to allow all the service accounts of the namespace.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// InlinePolicy is a policy embedded in an IAM role.
+type InlinePolicy struct {
+	// Name is the name of the policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Name string `json:"name"`
+
+	// PolicyDocument is the JSON policy document.
+	// +kubebuilder:validation:MinLength=1
+	PolicyDocument string `json:"policyDocument"`
+}
+
+// AWSIAMRoleStatus defines the observed state of AWSIAMRole.
+type AWSIAMRoleStatus struct {
+	// Ready denotes that the IAM role is available.
+	// +kubebuilder:default=false
+	Ready bool `json:"ready"`
+
+	// ARN is the ARN of the IAM role.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// Conditions defines current state of the AWSIAMRole.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsiamroles,scope=Namespaced,categories=cluster-api,shortName=awsiamr
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster to which this AWSIAMRole belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="AWSIAMRole ready status"
+// +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".status.arn",description="ARN of the IAM role"
+
+// AWSIAMRole is the Schema for the awsiamroles API. It declares an IAM role that service
+// accounts of a workload cluster can assume through the IAM OIDC identity provider of the cluster.
+type AWSIAMRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSIAMRoleSpec   `json:"spec,omitempty"`
+	Status AWSIAMRoleStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the AWSIAMRole resource.
+func (r *AWSIAMRole) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSIAMRole to the predescribed clusterv1.Conditions.
+func (r *AWSIAMRole) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// AWSIAMRoleList contains a list of AWSIAMRoles.
+type AWSIAMRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSIAMRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSIAMRole{}, &AWSIAMRoleList{})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var iamRoleLog = ctrl.Log.WithName("awsiamrole-resource")
+
+// SetupWebhookWithManager will setup the webhooks for the AWSIAMRole.
+func (r *AWSIAMRole) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsiamrole,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsiamroles,versions=v1beta2,name=default.awsiamrole.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsiamrole,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsiamroles,versions=v1beta2,name=validation.awsiamrole.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.Defaulter = &AWSIAMRole{}
+var _ webhook.Validator = &AWSIAMRole{}
+
+// Default will set default values for the AWSIAMRole.
+func (r *AWSIAMRole) Default() {
+	if r.Labels == nil {
+		r.Labels = make(map[string]string)
+	}
+	r.Labels[clusterv1.ClusterNameLabel] = r.Spec.ClusterName
+
+	if r.Spec.RoleName == "" {
+		name, err := eks.GenerateEKSName(r.Name, r.Namespace, maxIAMRoleNameLength)
+		if err != nil {
+			iamRoleLog.Error(err, "failed to create IAM role name")
+			return
+		}
+
+		r.Spec.RoleName = name
+	}
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSIAMRole) ValidateCreate() (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateSpec()...)
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(
+		r.GroupVersionKind().GroupKind(),
+		r.Name,
+		allErrs,
+	)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSIAMRole) ValidateUpdate(oldObj runtime.Object) (admission.Warnings, error) {
+	gv := r.GroupVersionKind().GroupKind()
+	old, ok := oldObj.(*AWSIAMRole)
+	if !ok {
+		return nil, apierrors.NewInvalid(gv, r.Name, field.ErrorList{
+			field.InternalError(nil, errors.Errorf("failed to convert old %s to object", gv.Kind)),
+		})
+	}
+
+	var allErrs field.ErrorList
+
+	if r.Spec.ClusterName != old.Spec.ClusterName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "clusterName"), r.Spec.ClusterName, "field is immutable"))
+	}
+	if r.Spec.RoleName != old.Spec.RoleName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "roleName"), r.Spec.RoleName, "field is immutable"))
+	}
+
+	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.validateSpec()...)
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(gv, r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *AWSIAMRole) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *AWSIAMRole) validateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.RoleName) > maxIAMRoleNameLength {
+		allErrs = append(allErrs, field.TooLongMaxLength(field.NewPath("spec", "roleName"), r.Spec.RoleName, maxIAMRoleNameLength))
+	}
+
+	serviceAccountsPath := field.NewPath("spec", "serviceAccounts")
+	if len(r.Spec.ServiceAccounts) == 0 {
+		allErrs = append(allErrs, field.Required(serviceAccountsPath, "at least one service account is required"))
+	}
+	for i, serviceAccount := range r.Spec.ServiceAccounts {
+		serviceAccountPath := serviceAccountsPath.Index(i)
+		if errs := validation.IsDNS1123Label(serviceAccount.Namespace); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(serviceAccountPath.Child("namespace"), serviceAccount.Namespace, strings.Join(errs, "; ")))
+		}
+		if errs := validation.IsDNS1123Subdomain(selectorWildcardReplacer.Replace(serviceAccount.Name)); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(serviceAccountPath.Child("name"), serviceAccount.Name, strings.Join(errs, "; ")))
+		}
+	}
+
+	inlinePoliciesPath := field.NewPath("spec", "inlinePolicies")
+	names := map[string]bool{}
+	for i, policy := range r.Spec.InlinePolicies {
+		policyPath := inlinePoliciesPath.Index(i)
+		if names[policy.Name] {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names[policy.Name] = true
+
+		if !json.Valid([]byte(policy.PolicyDocument)) {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("policyDocument"), policy.PolicyDocument, "must be a valid JSON document"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAWSIAMRoleDefault(t *testing.T) {
+	g := NewWithT(t)
+
+	iamRole := &AWSIAMRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec:       AWSIAMRoleSpec{ClusterName: "clustername"},
+	}
+	iamRole.Default()
+
+	g.Expect(iamRole.GetLabels()[clusterv1.ClusterNameLabel]).To(BeEquivalentTo(iamRole.Spec.ClusterName))
+	name, err := eks.GenerateEKSName(iamRole.Name, iamRole.Namespace, maxIAMRoleNameLength)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(iamRole.Spec.RoleName).To(BeEquivalentTo(name))
+}
+
+func TestAWSIAMRoleValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    AWSIAMRoleSpec
+		wantErr bool
+	}{
+		{
+			name: "valid spec",
+			spec: AWSIAMRoleSpec{
+				ClusterName:     "clustername",
+				RoleName:        "app-role",
+				ServiceAccounts: []ServiceAccountReference{{Namespace: "apps", Name: "app-*"}},
+				InlinePolicies: []InlinePolicy{
+					{Name: "s3", PolicyDocument: `{"Version":"2012-10-17","Statement":[]}`},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no service accounts",
+			spec: AWSIAMRoleSpec{
+				ClusterName: "clustername",
+				RoleName:    "app-role",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid service account namespace",
+			spec: AWSIAMRoleSpec{
+				ClusterName:     "clustername",
+				RoleName:        "app-role",
+				ServiceAccounts: []ServiceAccountReference{{Namespace: "Apps", Name: "app"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "role name too long",
+			spec: AWSIAMRoleSpec{
+				ClusterName:     "clustername",
+				RoleName:        strings.Repeat("a", maxIAMRoleNameLength+1),
+				ServiceAccounts: []ServiceAccountReference{{Namespace: "apps", Name: "app"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate inline policy names",
+			spec: AWSIAMRoleSpec{
+				ClusterName:     "clustername",
+				RoleName:        "app-role",
+				ServiceAccounts: []ServiceAccountReference{{Namespace: "apps", Name: "app"}},
+				InlinePolicies: []InlinePolicy{
+					{Name: "s3", PolicyDocument: `{}`},
+					{Name: "s3", PolicyDocument: `{}`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid inline policy document",
+			spec: AWSIAMRoleSpec{
+				ClusterName:     "clustername",
+				RoleName:        "app-role",
+				ServiceAccounts: []ServiceAccountReference{{Namespace: "apps", Name: "app"}},
+				InlinePolicies:  []InlinePolicy{{Name: "s3", PolicyDocument: `{"Version":`}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			iamRole := &AWSIAMRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			warn, err := iamRole.ValidateCreate()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestAWSIAMRoleValidateUpdate(t *testing.T) {
+	before := &AWSIAMRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: AWSIAMRoleSpec{
+			ClusterName:     "clustername",
+			RoleName:        "app-role",
+			ServiceAccounts: []ServiceAccountReference{{Namespace: "apps", Name: "app"}},
+		},
+	}
+
+	addServiceAccount := before.DeepCopy()
+	addServiceAccount.Spec.ServiceAccounts = append(addServiceAccount.Spec.ServiceAccounts, ServiceAccountReference{Namespace: "jobs", Name: "batch"})
+
+	changeRoleName := before.DeepCopy()
+	changeRoleName.Spec.RoleName = "other-role"
+
+	changeClusterName := before.DeepCopy()
+	changeClusterName.Spec.ClusterName = "other-cluster"
+
+	tests := []struct {
+		name    string
+		iamRole *AWSIAMRole
+		wantErr bool
+	}{
+		{
+			name:    "service accounts can be added",
+			iamRole: addServiceAccount,
+			wantErr: false,
+		},
+		{
+			name:    "role name is immutable",
+			iamRole: changeRoleName,
+			wantErr: true,
+		},
+		{
+			name:    "cluster name is immutable",
+			iamRole: changeClusterName,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			warn, err := tc.iamRole.ValidateUpdate(before)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
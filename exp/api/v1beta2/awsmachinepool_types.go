@@ -31,6 +31,9 @@ import (
 const (
 	// LaunchTemplateLatestVersion defines the launching of the latest version of the template.
 	LaunchTemplateLatestVersion = "$Latest"
+
+	// LaunchTemplateDefaultVersion defines the launching of the default version of the template.
+	LaunchTemplateDefaultVersion = "$Default"
 )
 
 // AWSMachinePoolSpec defines the desired state of AWSMachinePool.
@@ -61,6 +64,23 @@ type AWSMachinePoolSpec struct {
 	// +optional
 	Subnets []infrav1.AWSResourceReference `json:"subnets,omitempty"`
 
+	// SubnetSelector selects the subnets of the group by their tags and availability zones in the VPC of the
+	// cluster, instead of Subnets. The subnets are resolved on every reconcile.
+	// +optional
+	SubnetSelector *infrav1.SubnetSelector `json:"subnetSelector,omitempty"`
+
+	// SubnetSelectionStrategy controls which of the subnets matching the filters of Subnets or the SubnetSelector
+	// the group gets, so that it stays balanced across availability zones as subnets are added: all of them,
+	// one per availability zone, or one per availability zone in the PreferredAvailabilityZones. The subnets are
+	// selected again on every reconcile. Defaults to all.
+	// +kubebuilder:validation:Enum:=all;one-per-az;preferred-azs
+	// +optional
+	SubnetSelectionStrategy *SubnetSelectionStrategy `json:"subnetSelectionStrategy,omitempty"`
+
+	// PreferredAvailabilityZones are the availability zones of the preferred-azs subnet selection strategy.
+	// +optional
+	PreferredAvailabilityZones []string `json:"preferredAvailabilityZones,omitempty"`
+
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider.
 	// +optional
@@ -101,6 +121,23 @@ type AWSMachinePoolSpec struct {
 	// SuspendProcesses defines a list of processes to suspend for the given ASG. This is constantly reconciled.
 	// If a process is removed from this list it will automatically be resumed.
 	SuspendProcesses *SuspendProcessesTypes `json:"suspendProcesses,omitempty"`
+
+	// LoadBalancerAttachments lists the load balancers the instances of the ASG are registered with.
+	// This is constantly reconciled: the load balancers removed from the list are detached from the ASG.
+	// When not set, the load balancers attached to the ASG are left untouched.
+	// +optional
+	LoadBalancerAttachments *infrav1.LoadBalancerAttachments `json:"loadBalancerAttachments,omitempty"`
+
+	// TargetGroupARNs are the ARNs of externally managed target groups to attach to the ASG.
+	// When set, the target groups attached to the ASG are constantly reconciled to these ones and to
+	// the ones of LoadBalancerAttachments, and the health of the instances registered with them is
+	// reported by the TargetsHealthy condition.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// ClusterAutoscaler configures the tags of the ASG consumed by cluster-autoscaler.
+	// +optional
+	ClusterAutoscaler *ClusterAutoscalerOptions `json:"clusterAutoscaler,omitempty"`
 }
 
 // SuspendProcessesTypes contains user friendly auto-completable values for suspended process names.
@@ -192,6 +229,27 @@ type AWSMachinePoolStatus struct {
 	// +optional
 	Instances []AWSMachinePoolInstanceStatus `json:"instances,omitempty"`
 
+	// AvailabilityZones contains the number of instances and ready instances of the pool in each
+	// availability zone.
+	// +optional
+	AvailabilityZones []AWSMachinePoolAvailabilityZoneStatus `json:"availabilityZones,omitempty"`
+
+	// InfrastructureMachineKind is the kind of the infrastructure machines of the instances of the pool, which
+	// is set when an AWSMachinePoolMachine is created for each of them.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+
+	// CostEstimate is the estimated hourly cost of the instances of the pool, which is set when the
+	// CostEstimation feature gate is enabled.
+	// +optional
+	CostEstimate *infrav1.CostEstimate `json:"costEstimate,omitempty"`
+
+	// SpotRecommendations ranks the availability zones and instance types the spot instances of the pool can
+	// be launched in by their spot placement score and spot price history, which is set for pools using spot
+	// instances when the CostEstimation feature gate is enabled.
+	// +optional
+	SpotRecommendations *SpotRecommendations `json:"spotRecommendations,omitempty"`
+
 	// The ID of the launch template
 	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 
@@ -249,6 +307,80 @@ type AWSMachinePoolInstanceStatus struct {
 	// Version defines the Kubernetes version for the Machine Instance
 	// +optional
 	Version *string `json:"version,omitempty"`
+
+	// AvailabilityZone is the availability zone of the Machine Instance
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// LifecycleState is the lifecycle state of the Machine Instance within ASG, e.g. InService
+	// +optional
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// HealthStatus is the health status of the Machine Instance reported by ASG, either Healthy or Unhealthy
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// LaunchTemplateVersion is the version of the launch template the Machine Instance was launched with
+	// +optional
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// CapacityType is the capacity type of the Machine Instance, either onDemand or spot
+	// +optional
+	CapacityType ManagedMachinePoolCapacityType `json:"capacityType,omitempty"`
+}
+
+// AWSMachinePoolAvailabilityZoneStatus defines the number of instances of the AWSMachinePool in an availability zone.
+type AWSMachinePoolAvailabilityZoneStatus struct {
+	// Name is the name of the availability zone
+	Name string `json:"name"`
+
+	// Replicas is the number of instances in the availability zone
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of instances in the availability zone whose node is ready
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
+
+// SpotRecommendations are the combinations of availability zone and instance type the spot instances of an
+// AWSMachinePool can be launched in, from the most recommended.
+type SpotRecommendations struct {
+	// Recommendations are the most recommended combinations of availability zone and instance type, ordered by
+	// decreasing spot placement score and then by increasing average spot price.
+	// +optional
+	Recommendations []SpotRecommendation `json:"recommendations,omitempty"`
+
+	// LastUpdated is the time the recommendations were last computed.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// SpotRecommendation describes the spot prices of an instance type in an availability zone over the last week.
+type SpotRecommendation struct {
+	// InstanceType is the instance type, e.g. m5.large.
+	InstanceType string `json:"instanceType"`
+
+	// AvailabilityZone is the availability zone, e.g. us-east-1a.
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// CurrentPrice is the current hourly spot price.
+	CurrentPrice string `json:"currentPrice"`
+
+	// AveragePrice is the time-weighted average of the hourly spot price over the last week.
+	AveragePrice string `json:"averagePrice"`
+
+	// MaxPrice is the highest hourly spot price over the last week.
+	MaxPrice string `json:"maxPrice"`
+
+	// PriceChanges is the number of times the spot price changed over the last week, fewer changes meaning
+	// a more stable price.
+	PriceChanges int32 `json:"priceChanges"`
+
+	// PlacementScore is the spot placement score of the availability zone for the instance type and the
+	// capacity of the pool, from 1 to 10, 10 meaning that spot requests are highly likely to succeed.
+	// +optional
+	PlacementScore *int32 `json:"placementScore,omitempty"`
 }
 
 // +kubebuilder:object:root=true
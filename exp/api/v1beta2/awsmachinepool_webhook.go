@@ -17,8 +17,10 @@ limitations under the License.
 package v1beta2
 
 import (
+	"context"
 	"time"
 
+	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -79,12 +81,29 @@ func (r *AWSMachinePool) validateRootVolume() field.ErrorList {
 		log.Info("root volume shouldn't have a device name (this can be ignored if performing a `clusterctl move`)")
 	}
 
+	allErrs = append(allErrs, v1beta2.ValidateRootVolumeInstanceStore(r.Spec.AWSLaunchTemplate.RootVolume, field.NewPath("spec", "awsLaunchTemplate", "rootVolume"))...)
+
 	return allErrs
 }
 
 func (r *AWSMachinePool) validateSubnets() field.ErrorList {
 	var allErrs field.ErrorList
 
+	if r.Spec.SubnetSelector != nil {
+		if len(r.Spec.Subnets) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "subnetSelector"), "only one of subnets or subnetSelector may be specified"))
+		}
+		allErrs = append(allErrs, r.Spec.SubnetSelector.Validate(field.NewPath("spec", "subnetSelector"))...)
+	}
+
+	if r.Spec.SubnetSelectionStrategy != nil && *r.Spec.SubnetSelectionStrategy == SubnetSelectionStrategyPreferredAZs {
+		if len(r.Spec.PreferredAvailabilityZones) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "preferredAvailabilityZones"), "required by the preferred-azs subnet selection strategy"))
+		}
+	} else if len(r.Spec.PreferredAvailabilityZones) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "preferredAvailabilityZones"), "only used by the preferred-azs subnet selection strategy"))
+	}
+
 	if r.Spec.Subnets == nil {
 		return allErrs
 	}
@@ -128,33 +147,81 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.Spec.LoadBalancerAttachments.Validate(field.NewPath("spec", "loadBalancerAttachments"))...)
+	allErrs = append(allErrs, v1beta2.ValidateTargetGroupARNs(field.NewPath("spec", "targetGroupARNs"), r.Spec.TargetGroupARNs)...)
 
+	var warnings admission.Warnings
 	if len(allErrs) == 0 {
-		return nil, nil
+		var regionErrs field.ErrorList
+		warnings, regionErrs = r.validateRegion()
+		allErrs = append(allErrs, regionErrs...)
 	}
 
-	return nil, apierrors.NewInvalid(
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
 		r.GroupVersionKind().GroupKind(),
 		r.Name,
 		allErrs,
 	)
 }
 
+// validateRegion validates the instance types against the region and availability zones of the cluster, when the
+// region validation is enabled.
+func (r *AWSMachinePool) validateRegion() (admission.Warnings, field.ErrorList) {
+	if regionValidator == nil {
+		return nil, nil
+	}
+	return regionValidator.ValidateAWSMachinePool(context.Background(), r)
+}
+
+// validateImmutable validates the fields which can't be changed once the AWSMachinePool is created.
+func (r *AWSMachinePool) validateImmutable(old *AWSMachinePool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The AWSCluster controller only checks the OS family of the machine pools when they are created or deleted, to
+	// allow the CNI traffic of the Windows instances.
+	if r.Spec.AWSLaunchTemplate.OSFamily != old.Spec.AWSLaunchTemplate.OSFamily {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "awsLaunchTemplate", "osFamily"), r.Spec.AWSLaunchTemplate.OSFamily, "field is immutable"))
+	}
+
+	return allErrs
+}
+
 // ValidateUpdate will do any extra validation when updating a AWSMachinePool.
-func (r *AWSMachinePool) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	oldPool, ok := old.(*AWSMachinePool)
+	if !ok {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AWSMachinePool").GroupKind(), r.Name, field.ErrorList{
+			field.InternalError(nil, errors.New("failed to convert old AWSMachinePool to object")),
+		})
+	}
+
 	var allErrs field.ErrorList
 
+	allErrs = append(allErrs, r.validateImmutable(oldPool)...)
 	allErrs = append(allErrs, r.validateDefaultCoolDown()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, r.Spec.LoadBalancerAttachments.Validate(field.NewPath("spec", "loadBalancerAttachments"))...)
+	allErrs = append(allErrs, v1beta2.ValidateTargetGroupARNs(field.NewPath("spec", "targetGroupARNs"), r.Spec.TargetGroupARNs)...)
 
+	var warnings admission.Warnings
 	if len(allErrs) == 0 {
-		return nil, nil
+		var regionErrs field.ErrorList
+		warnings, regionErrs = r.validateRegion()
+		allErrs = append(allErrs, regionErrs...)
 	}
 
-	return nil, apierrors.NewInvalid(
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
 		r.GroupVersionKind().GroupKind(),
 		r.Name,
 		allErrs,
@@ -177,4 +244,6 @@ func (r *AWSMachinePool) Default() {
 		log.Info("DefaultInstanceWarmup is zero, setting 300 seconds as default")
 		r.Spec.DefaultInstanceWarmup.Duration = 300 * time.Second
 	}
+
+	r.Spec.AdditionalTags = r.Spec.AdditionalTags.WithDefaults()
 }
@@ -124,6 +124,59 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should fail if both subnets and a subnet selector are passed in AWSMachinePool spec",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					Subnets:        []infrav1.AWSResourceReference{{ID: ptr.To[string]("subnet-id")}},
+					SubnetSelector: &infrav1.SubnetSelector{AvailabilityZones: []string{"us-east-1a"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if a subnet selector is passed in AWSMachinePool spec",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SubnetSelector: &infrav1.SubnetSelector{
+						Tags: []infrav1.SubnetTagSelector{{Key: "subnet-role", Values: []string{"workers"}}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the preferred-azs subnet selection strategy has no preferred availability zones",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SubnetSelector:          &infrav1.SubnetSelector{Tags: []infrav1.SubnetTagSelector{{Key: "subnet-role"}}},
+					SubnetSelectionStrategy: ptr.To(SubnetSelectionStrategyPreferredAZs),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if preferred availability zones are passed without the preferred-azs subnet selection strategy",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SubnetSelector:             &infrav1.SubnetSelector{Tags: []infrav1.SubnetTagSelector{{Key: "subnet-role"}}},
+					SubnetSelectionStrategy:    ptr.To(SubnetSelectionStrategyOnePerAZ),
+					PreferredAvailabilityZones: []string{"us-east-1a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass with the preferred-azs subnet selection strategy and preferred availability zones",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					SubnetSelector:             &infrav1.SubnetSelector{Tags: []infrav1.SubnetTagSelector{{Key: "subnet-role"}}},
+					SubnetSelectionStrategy:    ptr.To(SubnetSelectionStrategyPreferredAZs),
+					PreferredAvailabilityZones: []string{"us-east-1a"},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Ensure root volume with device name works (for clusterctl move)",
 			pool: &AWSMachinePool{
@@ -153,6 +206,24 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should pass if target group ARNs are valid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs: []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if a target group ARN is invalid",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					TargetGroupARNs: []string{"ingress"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -287,6 +358,35 @@ func TestAWSMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should fail update if spec.awsLaunchTemplate.osFamily is changed",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{OSFamily: infrav1.OSFamilyLinux},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{OSFamily: infrav1.OSFamilyWindows},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass update of a Windows AWSMachinePool keeping its spec.awsLaunchTemplate.osFamily",
+			old: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{OSFamily: infrav1.OSFamilyWindows},
+				},
+			},
+			new: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AdditionalTags:    infrav1.Tags{"key-1": "value-1"},
+					AWSLaunchTemplate: AWSLaunchTemplate{OSFamily: infrav1.OSFamilyWindows},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// AWSMachinePoolMachineFinalizer allows the controller to terminate the instance of an AWSMachinePoolMachine
+	// before it is removed from the API Server.
+	AWSMachinePoolMachineFinalizer = "awsmachinepoolmachine.infrastructure.cluster.x-k8s.io"
+
+	// AWSMachinePoolMachineKind is the kind of the infrastructure machines of the instances of an AWSMachinePool.
+	AWSMachinePoolMachineKind = "AWSMachinePoolMachine"
+)
+
+// AWSMachinePoolMachineSpec defines the desired state of AWSMachinePoolMachine.
+type AWSMachinePoolMachineSpec struct {
+	// ProviderID is the provider ID of the instance, in the aws:///<availability zone>/<instance ID> format.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+
+	// InstanceID is the ID of the instance in the autoscaling group of the AWSMachinePool.
+	// +kubebuilder:validation:MinLength=1
+	InstanceID string `json:"instanceID"`
+}
+
+// AWSMachinePoolMachineStatus defines the observed state of AWSMachinePoolMachine.
+type AWSMachinePoolMachineStatus struct {
+	// Ready is true when the instance is in service in the autoscaling group and healthy.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// AvailabilityZone is the availability zone of the instance.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// LifecycleState is the lifecycle state of the instance within the autoscaling group, e.g. InService.
+	// +optional
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// HealthStatus is the health status of the instance reported by the autoscaling group, either Healthy or
+	// Unhealthy.
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// LaunchTemplateVersion is the version of the launch template the instance was launched with.
+	// +optional
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// LatestModelApplied is true when the instance was launched with the launch template version of the
+	// AWSMachinePool.
+	// +optional
+	LatestModelApplied bool `json:"latestModelApplied"`
+
+	// NodeRef is a reference to the node of the instance in the workload cluster.
+	// +optional
+	NodeRef *corev1.ObjectReference `json:"nodeRef,omitempty"`
+
+	// Version is the Kubernetes version of the kubelet of the node of the instance.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// Conditions defines current service state of the AWSMachinePoolMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsmachinepoolmachines,scope=Namespaced,categories=cluster-api,shortName=awsmpm
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Instance ready status"
+// +kubebuilder:printcolumn:name="InstanceID",type="string",JSONPath=".spec.instanceID",description="ID of the instance"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.lifecycleState",description="Lifecycle state of the instance in the autoscaling group"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Kubernetes version of the node"
+
+// AWSMachinePoolMachine is the Schema for the awsmachinepoolmachines API. It represents an instance of the
+// autoscaling group of an AWSMachinePool, which backs a Machine of the MachinePool.
+type AWSMachinePoolMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSMachinePoolMachineSpec   `json:"spec,omitempty"`
+	Status AWSMachinePoolMachineStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the AWSMachinePoolMachine resource.
+func (r *AWSMachinePoolMachine) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSMachinePoolMachine to the predescribed clusterv1.Conditions.
+func (r *AWSMachinePoolMachine) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// AWSMachinePoolMachineList contains a list of AWSMachinePoolMachines.
+type AWSMachinePoolMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSMachinePoolMachine `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSMachinePoolMachine{}, &AWSMachinePoolMachineList{})
+}
@@ -150,11 +150,28 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	UpdateConfig *UpdateConfig `json:"updateConfig,omitempty"`
 
+	// NodeRepairConfig configures the automatic repair of the unhealthy nodes of the nodegroup by EKS.
+	// +optional
+	NodeRepairConfig *NodeRepairConfig `json:"nodeRepairConfig,omitempty"`
+
 	// AWSLaunchTemplate specifies the launch template to use to create the managed node group.
 	// If AWSLaunchTemplate is specified, certain node group configuraions outside of launch template
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
 	// +optional
 	AWSLaunchTemplate *AWSLaunchTemplate `json:"awsLaunchTemplate,omitempty"`
+
+	// AdditionalUserDataSecretRef is the name of a secret in the same namespace as the AWSManagedMachinePool
+	// whose "value" key holds user data to merge with the bootstrap data generated by CAPA, instead of
+	// the bootstrap data overwriting it. The user data can be a MIME multi-part document or a single
+	// cloud-config or shell script, and runs before the bootstrap data. Requires AWSLaunchTemplate to be set.
+	// +optional
+	AdditionalUserDataSecretRef *string `json:"additionalUserDataSecretRef,omitempty"`
+
+	// ClusterAutoscaler configures the tags of the ASG of the nodegroup consumed by cluster-autoscaler.
+	// The labels and taints of the nodegroup are part of the node template.
+	// EKS applies the auto-discovery tags to the ASG of the nodegroup regardless of AutoDiscovery.
+	// +optional
+	ClusterAutoscaler *ClusterAutoscalerOptions `json:"clusterAutoscaler,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -121,6 +121,9 @@ func (r *AWSManagedMachinePool) validateRemoteAccess() field.ErrorList {
 func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.AWSLaunchTemplate == nil {
+		if r.Spec.AdditionalUserDataSecretRef != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AdditionalUserDataSecretRef"), r.Spec.AdditionalUserDataSecretRef, "AdditionalUserDataSecretRef can only be specified when LaunchTemplate is specified"))
+		}
 		return allErrs
 	}
 
@@ -135,6 +138,14 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	if r.Spec.AWSLaunchTemplate.PinnedVersion != nil && *r.Spec.AWSLaunchTemplate.PinnedVersion == LaunchTemplateDefaultVersion {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "PinnedVersion"), *r.Spec.AWSLaunchTemplate.PinnedVersion, "EKS managed node groups can only be pinned to a launch template version number or $Latest"))
+	}
+
+	if r.Spec.AWSLaunchTemplate.RootVolume.IsInstanceStore() {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "RootVolume", "Type"), r.Spec.AWSLaunchTemplate.RootVolume.Type, "EKS managed node groups don't support instance store root volumes"))
+	}
+
 	return allErrs
 }
 
@@ -281,4 +292,6 @@ func (r *AWSManagedMachinePool) Default() {
 			MaxUnavailable: ptr.To[int](1),
 		}
 	}
+
+	r.Spec.AdditionalTags = r.Spec.AdditionalTags.WithDefaults()
 }
@@ -150,6 +150,51 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "launch template pinned to a version number is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						PinnedVersion: aws.String("3"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template pinned to the default version is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						PinnedVersion: aws.String(LaunchTemplateDefaultVersion),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional user data without launch template is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:            "eks-node-group-3",
+					AdditionalUserDataSecretRef: aws.String("my-user-data"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional user data with launch template is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:            "eks-node-group-3",
+					AWSLaunchTemplate:           &AWSLaunchTemplate{},
+					AdditionalUserDataSecretRef: aws.String("my-user-data"),
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
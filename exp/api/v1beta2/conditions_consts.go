@@ -54,6 +54,35 @@ const (
 	InstanceRefreshNotReadyReason = "InstanceRefreshNotReady"
 	// InstanceRefreshFailedReason used to report when there instance refresh is not initiated.
 	InstanceRefreshFailedReason = "InstanceRefreshFailed"
+
+	// TargetsHealthyCondition reports on the health of the instances of the autoscaling group in the target groups
+	// of the AWSMachinePool.
+	TargetsHealthyCondition clusterv1.ConditionType = "TargetsHealthy"
+	// TargetsUnhealthyReason used when instances of the autoscaling group are not healthy in the target groups yet.
+	TargetsUnhealthyReason = "TargetsUnhealthy"
+	// TargetsHealthCheckFailedReason used when the health of the instances in the target groups couldn't be retrieved.
+	TargetsHealthCheckFailedReason = "TargetsHealthCheckFailed"
+)
+
+const (
+	// InstanceInServiceCondition reports on whether the instance of an AWSMachinePoolMachine is in service in the
+	// autoscaling group and healthy.
+	InstanceInServiceCondition clusterv1.ConditionType = "InstanceInService"
+	// InstanceNotInServiceReason used when the instance is not in service in the autoscaling group yet or anymore.
+	InstanceNotInServiceReason = "InstanceNotInService"
+	// InstanceUnhealthyReason used when the autoscaling group reports the instance as unhealthy.
+	InstanceUnhealthyReason = "InstanceUnhealthy"
+	// InstanceNotFoundReason used when the instance isn't part of the autoscaling group.
+	InstanceNotFoundReason = "InstanceNotFound"
+
+	// NodeReadyCondition reports on whether the node of the instance of an AWSMachinePoolMachine is ready.
+	NodeReadyCondition clusterv1.ConditionType = "NodeReady"
+	// NodeNotFoundReason used when the node of the instance hasn't registered with the workload cluster yet.
+	NodeNotFoundReason = "NodeNotFound"
+	// NodeNotReadyReason used when the node of the instance isn't ready.
+	NodeNotReadyReason = "NodeNotReady"
+	// NodeLookupFailedReason used when the node of the instance couldn't be retrieved from the workload cluster.
+	NodeLookupFailedReason = "NodeLookupFailed"
 )
 
 const (
@@ -116,3 +145,18 @@ const (
 	// RosaMachinePoolReconciliationFailedReason used to report failures while reconciling ROSAMachinePool.
 	RosaMachinePoolReconciliationFailedReason = "ReconciliationFailed"
 )
+
+const (
+	// IAMRoleReadyCondition condition reports on the successful reconciliation of the IAM role of an AWSIAMRole.
+	IAMRoleReadyCondition clusterv1.ConditionType = "IAMRoleReady"
+
+	// WaitingForOIDCProviderReason used when the IAM role is waiting for the IAM OIDC identity
+	// provider of the cluster to be associated.
+	WaitingForOIDCProviderReason = "WaitingForOIDCProvider"
+
+	// IAMRoleNotManagedReason used when a role with the same name exists and isn't owned by the cluster.
+	IAMRoleNotManagedReason = "IAMRoleNotManaged"
+
+	// IAMRoleReconciliationFailedReason used to report failures while reconciling the IAM role.
+	IAMRoleReconciliationFailedReason = "IAMRoleReconciliationFailed"
+)
@@ -20,6 +20,9 @@ const (
 	// FargateProfileFinalizer allows the controller to clean up resources on delete.
 	FargateProfileFinalizer = "awsfargateprofile.infrastructure.cluster.x-k8s.io"
 
+	// IAMRoleFinalizer allows the controller to clean up resources on delete.
+	IAMRoleFinalizer = "awsiamrole.infrastructure.cluster.x-k8s.io"
+
 	// MachinePoolFinalizer is the finalizer for the machine pool.
 	MachinePoolFinalizer = "awsmachinepool.infrastructure.cluster.x-k8s.io"
 
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// RegionValidator validates at admission time that the instance types of an AWSMachinePool are offered in the
+// region and availability zones of its cluster, like the RegionValidator of the AWSClusters and AWSMachines.
+// When the region can't be checked, for example because AWS can't be reached, a warning is returned
+// instead of an error.
+// +kubebuilder:object:generate=false
+type RegionValidator interface {
+	// ValidateAWSMachinePool validates the instance types of an AWSMachinePool against the region and availability
+	// zones of its cluster.
+	ValidateAWSMachinePool(ctx context.Context, pool *AWSMachinePool) (admission.Warnings, field.ErrorList)
+}
+
+// regionValidator is used by the webhooks when set.
+var regionValidator RegionValidator
+
+// SetRegionValidator sets the validator checking the instance types of the AWSMachinePools against the region and
+// availability zones of their cluster. The region is not checked by default.
+// It should be called before the webhooks are started.
+func SetRegionValidator(validator RegionValidator) {
+	regionValidator = validator
+}
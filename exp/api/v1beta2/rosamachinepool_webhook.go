@@ -1,6 +1,8 @@
 package v1beta2
 
 import (
+	"strings"
+
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -37,6 +39,12 @@ func (r *ROSAMachinePool) ValidateCreate() (warnings admission.Warnings, err err
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateAutoscaling(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	if len(allErrs) == 0 {
@@ -68,6 +76,12 @@ func (r *ROSAMachinePool) ValidateUpdate(old runtime.Object) (warnings admission
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateAutoscaling(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
+
 	allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalSecurityGroups, r.Spec.AdditionalSecurityGroups, "additionalSecurityGroups")...)
 	allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalTags, r.Spec.AdditionalTags, "additionalTags")...)
 
@@ -112,6 +126,31 @@ func (r *ROSAMachinePool) validateNodeDrainGracePeriod() *field.Error {
 	return nil
 }
 
+func (r *ROSAMachinePool) validateAutoscaling() *field.Error {
+	if r.Spec.Autoscaling == nil {
+		return nil
+	}
+
+	if r.Spec.Autoscaling.MaxReplicas < r.Spec.Autoscaling.MinReplicas {
+		return field.Invalid(field.NewPath("spec.autoscaling.maxReplicas"), r.Spec.Autoscaling.MaxReplicas,
+			"must be greater than or equal to minReplicas")
+	}
+
+	return nil
+}
+
+func (r *ROSAMachinePool) validateAdditionalSecurityGroups() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, id := range r.Spec.AdditionalSecurityGroups {
+		if !strings.HasPrefix(id, "sg-") {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "additionalSecurityGroups").Index(i), id, "must be a security group ID"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateImmutable(old, updated interface{}, name string) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestROSAMachinePoolValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    *ROSAMachinePool
+		wantErr bool
+	}{
+		{
+			name: "Should pass with autoscaling and additional security groups",
+			pool: &ROSAMachinePool{
+				Spec: RosaMachinePoolSpec{
+					Autoscaling:              &RosaMachinePoolAutoScaling{MinReplicas: 1, MaxReplicas: 3},
+					AdditionalSecurityGroups: []string{"sg-0123456789abcdef0"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if maxReplicas is lower than minReplicas",
+			pool: &ROSAMachinePool{
+				Spec: RosaMachinePoolSpec{
+					Autoscaling: &RosaMachinePoolAutoScaling{MinReplicas: 3, MaxReplicas: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if an additional security group is not an ID",
+			pool: &ROSAMachinePool{
+				Spec: RosaMachinePoolSpec{
+					AdditionalSecurityGroups: []string{"default"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			warn, err := tt.pool.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -89,6 +90,12 @@ type AWSLaunchTemplate struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// OSFamily is the operating system family of the instances, linux if not set. It defines the
+	// format of the user data, and the default base OS used for image lookup.
+	// +kubebuilder:validation:Enum:=linux;windows
+	// +optional
+	OSFamily infrav1.OSFamily `json:"osFamily,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	InstanceType string `json:"instanceType,omitempty"`
 
@@ -108,6 +115,16 @@ type AWSLaunchTemplate struct {
 	// 3) A new AMI is discovered.
 	VersionNumber *int64 `json:"versionNumber,omitempty"`
 
+	// PinnedVersion pins the machine pool to a specific version of the launch template instead of
+	// always using the latest version. New launch template versions are still created when the spec
+	// changes, but they are only rolled out once this field is updated, which allows staging rollouts
+	// and rolling back by editing the spec.
+	// Valid values are a version number, "$Latest" or "$Default". Defaults to "$Latest" when unset.
+	// "$Default" is not supported by AWSManagedMachinePool.
+	// +kubebuilder:validation:Pattern=`^(\$Latest|\$Default|[1-9][0-9]*)$`
+	// +optional
+	PinnedVersion *string `json:"pinnedVersion,omitempty"`
+
 	// AdditionalSecurityGroups is an array of references to security groups that should be applied to the
 	// instances. These security groups would be set in addition to any security groups defined
 	// at the cluster level or in the actuator.
@@ -210,11 +227,15 @@ type AutoScalingGroup struct {
 	DefaultCoolDown       metav1.Duration `json:"defaultCoolDown,omitempty"`
 	DefaultInstanceWarmup metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
 	CapacityRebalance     bool            `json:"capacityRebalance,omitempty"`
+	LaunchTemplateVersion string          `json:"launchTemplateVersion,omitempty"`
 
 	MixedInstancesPolicy      *MixedInstancesPolicy `json:"mixedInstancesPolicy,omitempty"`
 	Status                    ASGStatus
-	Instances                 []infrav1.Instance `json:"instances,omitempty"`
-	CurrentlySuspendProcesses []string           `json:"currentlySuspendProcesses,omitempty"`
+	Instances                 []infrav1.Instance             `json:"instances,omitempty"`
+	InstanceStatuses          []AWSMachinePoolInstanceStatus `json:"instanceStatuses,omitempty"`
+	CurrentlySuspendProcesses []string                       `json:"currentlySuspendProcesses,omitempty"`
+	TargetGroupARNs           []string                       `json:"targetGroupARNs,omitempty"`
+	LoadBalancerNames         []string                       `json:"loadBalancerNames,omitempty"`
 }
 
 // ASGStatus is a status string returned by the autoscaling API.
@@ -278,6 +299,40 @@ func (t *Taints) Contains(taint *Taint) bool {
 	return false
 }
 
+// ClusterAutoscalerTagPrefix is the prefix of the tags of the Auto Scaling groups consumed by cluster-autoscaler.
+const ClusterAutoscalerTagPrefix = "k8s.io/cluster-autoscaler/"
+
+// ClusterAutoscalerOptions configures the tags of an Auto Scaling group consumed by cluster-autoscaler.
+// When set, the tags prefixed with k8s.io/cluster-autoscaler/ are constantly reconciled, except for the
+// ones set in the additional tags.
+type ClusterAutoscalerOptions struct {
+	// AutoDiscovery applies the k8s.io/cluster-autoscaler/enabled and k8s.io/cluster-autoscaler/<cluster-name>
+	// tags used by cluster-autoscaler to auto-discover the Auto Scaling group.
+	// +optional
+	AutoDiscovery bool `json:"autoDiscovery,omitempty"`
+
+	// NodeTemplate describes the nodes of the Auto Scaling group with the node-template tags, so that
+	// cluster-autoscaler can scale it from zero.
+	// +optional
+	NodeTemplate *NodeTemplate `json:"nodeTemplate,omitempty"`
+}
+
+// NodeTemplate describes the nodes of an Auto Scaling group to cluster-autoscaler.
+type NodeTemplate struct {
+	// Labels are the labels of the nodes.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are the taints of the nodes.
+	// +optional
+	Taints Taints `json:"taints,omitempty"`
+
+	// Resources are the resources of the nodes in addition to the ones of their instance type,
+	// e.g. ephemeral-storage or extended resources.
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+}
+
 // UpdateConfig is the configuration options for updating a nodegroup. Only one of MaxUnavailable
 // and MaxUnavailablePercentage should be specified.
 type UpdateConfig struct {
@@ -296,6 +351,13 @@ type UpdateConfig struct {
 	MaxUnavailablePercentage *int `json:"maxUnavailablePercentage,omitempty"`
 }
 
+// NodeRepairConfig defines the node auto repair configuration of an EKS managed nodegroup.
+type NodeRepairConfig struct {
+	// Enabled specifies whether EKS replaces the nodes of the nodegroup that become unhealthy.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // AZSubnetType is the type of subnet to use when an availability zone is specified.
 type AZSubnetType string
 
@@ -312,3 +374,18 @@ const (
 func NewAZSubnetType(t AZSubnetType) *AZSubnetType {
 	return &t
 }
+
+// SubnetSelectionStrategy is the strategy choosing which of the subnets matching the filters or the subnet selector
+// of an AWSMachinePool its Auto Scaling group gets.
+type SubnetSelectionStrategy string
+
+const (
+	// SubnetSelectionStrategyAll gives all the matching subnets to the group.
+	SubnetSelectionStrategyAll SubnetSelectionStrategy = "all"
+	// SubnetSelectionStrategyOnePerAZ gives one matching subnet per availability zone to the group, the subnet
+	// with the lowest ID, so that the choice is stable.
+	SubnetSelectionStrategyOnePerAZ SubnetSelectionStrategy = "one-per-az"
+	// SubnetSelectionStrategyPreferredAZs gives one matching subnet per preferred availability zone to the group,
+	// or one per availability zone when none of the preferred availability zones has matching subnets.
+	SubnetSelectionStrategyPreferredAZs SubnetSelectionStrategy = "preferred-azs"
+)
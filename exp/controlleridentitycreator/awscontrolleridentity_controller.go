@@ -91,7 +91,8 @@ func (r *AWSControllerIdentityReconciler) Reconcile(ctx context.Context, req ctr
 
 	// If identity type is not AWSClusterControllerIdentity, then no need to create AWSClusterControllerIdentity singleton.
 	if identityRef.Kind == infrav1.ClusterRoleIdentityKind ||
-		identityRef.Kind == infrav1.ClusterStaticIdentityKind {
+		identityRef.Kind == infrav1.ClusterStaticIdentityKind ||
+		identityRef.Kind == infrav1.ClusterWebIdentityKind {
 		log.Trace("Cluster does not use AWSClusterControllerIdentity as identityRef, skipping new instance creation")
 		return ctrl.Result{}, nil
 	}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamrole"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// AWSIAMRoleReconciler reconciles a AWSIAMRole object.
+type AWSIAMRoleReconciler struct {
+	client.Client
+	Recorder         record.EventRecorder
+	Endpoints        []scope.ServiceEndpoint
+	WatchFilterValue string
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *AWSIAMRoleReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	clusterObjectToIAMRolesMap := clusterObjectToIAMRolesMapFunc(r.Client, logger.FromContext(ctx))
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&expinfrav1.AWSIAMRole{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(logger.FromContext(ctx).GetLogger(), r.WatchFilterValue)).
+		Watches(
+			&ekscontrolplanev1.AWSManagedControlPlane{},
+			handler.EnqueueRequestsFromMapFunc(clusterObjectToIAMRolesMap),
+		).
+		Watches(
+			&infrav1.AWSCluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterObjectToIAMRolesMap),
+		).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=awsmanagedcontrolplanes;awsmanagedcontrolplanes/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters;awsclusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsiamroles,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsiamroles/status,verbs=get;update;patch
+
+// Reconcile reconciles AWSIAMRoles.
+func (r *AWSIAMRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	iamRole := &expinfrav1.AWSIAMRole{}
+	if err := r.Get(ctx, req.NamespacedName, iamRole); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, iamRole.Namespace, iamRole.Spec.ClusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.reconcileDeleteWithoutCluster(ctx, iamRole, "Cluster")
+		}
+		log.Info("Failed to retrieve Cluster from AWSIAMRole")
+		return reconcile.Result{}, err
+	}
+
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	params := scope.IAMRoleScopeParams{
+		Client:         r.Client,
+		ControllerName: "awsiamrole",
+		Cluster:        cluster,
+		IAMRole:        iamRole,
+		Endpoints:      r.Endpoints,
+	}
+
+	// The IAM OIDC identity provider of the cluster is reported by the AWSManagedControlPlane
+	// of EKS clusters and by the AWSCluster of self-managed ones.
+	switch {
+	case cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == ekscontrolplanev1.AWSManagedControlPlaneKind:
+		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: iamRole.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, controlPlane); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.reconcileDeleteWithoutCluster(ctx, iamRole, ekscontrolplanev1.AWSManagedControlPlaneKind)
+			}
+			log.Info("Failed to retrieve ControlPlane from AWSIAMRole")
+			return reconcile.Result{}, err
+		}
+		params.ControlPlane = controlPlane
+	case cluster.Spec.InfrastructureRef != nil && cluster.Spec.InfrastructureRef.Kind == "AWSCluster":
+		awsCluster := &infrav1.AWSCluster{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: iamRole.Namespace, Name: cluster.Spec.InfrastructureRef.Name}, awsCluster); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.reconcileDeleteWithoutCluster(ctx, iamRole, "AWSCluster")
+			}
+			log.Info("Failed to retrieve AWSCluster from AWSIAMRole")
+			return reconcile.Result{}, err
+		}
+		params.AWSCluster = awsCluster
+	default:
+		log.Info("Cluster is managed neither by an AWSManagedControlPlane nor by an AWSCluster")
+		return reconcile.Result{}, nil
+	}
+
+	iamRoleScope, err := scope.NewIAMRoleScope(params)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	defer func() {
+		conditions.SetSummary(iamRoleScope.IAMRole, conditions.WithConditions(expinfrav1.IAMRoleReadyCondition))
+
+		if err := iamRoleScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !iamRole.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, iamRoleScope)
+	}
+
+	return r.reconcileNormal(ctx, iamRoleScope)
+}
+
+func (r *AWSIAMRoleReconciler) reconcileNormal(
+	_ context.Context,
+	iamRoleScope *scope.IAMRoleScope,
+) (ctrl.Result, error) {
+	iamRoleScope.Info("Reconciling AWSIAMRole")
+
+	if controllerutil.AddFinalizer(iamRoleScope.IAMRole, expinfrav1.IAMRoleFinalizer) {
+		if err := iamRoleScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if iamRoleScope.OIDCProviderARN() == "" {
+		iamRoleScope.Info("Waiting for the OIDC provider of the cluster")
+		conditions.MarkFalse(iamRoleScope.IAMRole, expinfrav1.IAMRoleReadyCondition, expinfrav1.WaitingForOIDCProviderReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	if err := iamrole.NewService(iamRoleScope).Reconcile(); err != nil {
+		conditions.MarkFalse(iamRoleScope.IAMRole, expinfrav1.IAMRoleReadyCondition, expinfrav1.IAMRoleReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile IAM role for AWSIAMRole %s/%s", iamRoleScope.IAMRole.Namespace, iamRoleScope.IAMRole.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AWSIAMRoleReconciler) reconcileDelete(
+	_ context.Context,
+	iamRoleScope *scope.IAMRoleScope,
+) (ctrl.Result, error) {
+	iamRoleScope.Info("Reconciling deletion of AWSIAMRole")
+
+	if err := iamrole.NewService(iamRoleScope).ReconcileDelete(); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile IAM role deletion for AWSIAMRole %s/%s", iamRoleScope.IAMRole.Namespace, iamRoleScope.IAMRole.Name)
+	}
+
+	controllerutil.RemoveFinalizer(iamRoleScope.IAMRole, expinfrav1.IAMRoleFinalizer)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDeleteWithoutCluster removes the finalizer of an AWSIAMRole that is being deleted
+// after its cluster is gone. Without the cluster there are no credentials to delete the IAM
+// role with, so it is left behind and reported in an event.
+func (r *AWSIAMRoleReconciler) reconcileDeleteWithoutCluster(ctx context.Context, iamRole *expinfrav1.AWSIAMRole, kind string) (ctrl.Result, error) {
+	log := logger.FromContext(ctx)
+
+	if iamRole.ObjectMeta.DeletionTimestamp.IsZero() {
+		log.Info(fmt.Sprintf("%s of AWSIAMRole not found", kind))
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(iamRole, expinfrav1.IAMRoleFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info(fmt.Sprintf("%s of AWSIAMRole not found, removing finalizer without deleting the IAM role", kind))
+	if iamRole.Spec.RoleName != "" && iamRole.Status.ARN != "" {
+		r.Recorder.Eventf(iamRole, corev1.EventTypeWarning, "IAMRoleNotDeleted",
+			"%s of cluster %s is gone, IAM role %q has to be deleted manually", kind, iamRole.Spec.ClusterName, iamRole.Spec.RoleName)
+	}
+
+	helper, err := patch.NewHelper(iamRole, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	controllerutil.RemoveFinalizer(iamRole, expinfrav1.IAMRoleFinalizer)
+	if err := helper.Patch(ctx, iamRole); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to remove finalizer from AWSIAMRole %s/%s", iamRole.Namespace, iamRole.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func clusterObjectToIAMRolesMapFunc(c client.Client, log logger.Wrapper) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		if !o.GetDeletionTimestamp().IsZero() {
+			return nil
+		}
+
+		clusterKey, err := GetOwnerClusterKey(metav1.ObjectMeta{Namespace: o.GetNamespace(), OwnerReferences: o.GetOwnerReferences()})
+		if err != nil {
+			log.Error(err, "couldn't get owner cluster ObjectKey")
+			return nil
+		}
+		if clusterKey == nil {
+			return nil
+		}
+
+		iamRolesForClusterList := expinfrav1.AWSIAMRoleList{}
+		if err := c.List(
+			ctx, &iamRolesForClusterList, client.InNamespace(clusterKey.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterKey.Name},
+		); err != nil {
+			log.Error(err, "couldn't list IAM roles for cluster")
+			return nil
+		}
+
+		var results []ctrl.Request
+		for i := range iamRolesForClusterList.Items {
+			role := iamRolesForClusterList.Items[i]
+			results = append(results, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: role.Namespace,
+					Name:      role.Name,
+				},
+			})
+		}
+
+		return results
+	}
+}
@@ -20,7 +20,12 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
@@ -28,8 +33,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -41,20 +48,36 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/pricing"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// AWSMachinePoolInstanceIDIndex defines the AWSMachinePool controller's index of the instance IDs.
+const AWSMachinePoolInstanceIDIndex = ".status.instances.instanceID"
+
+// targetsHealthCheckInterval is the interval at which the health of the instances in the target groups of an
+// AWSMachinePool is checked until they are all healthy.
+const targetsHealthCheckInterval = 30 * time.Second
+
+// spotRecommendationsPeriod is how often the spot recommendations of the AWSMachinePools using spot instances are
+// computed.
+const spotRecommendationsPeriod = time.Hour
+
 // AWSMachinePoolReconciler reconciles a AWSMachinePool object.
 type AWSMachinePoolReconciler struct {
 	client.Client
@@ -63,6 +86,7 @@ type AWSMachinePoolReconciler struct {
 	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2Interface
 	reconcileServiceFactory      func(scope.EC2Scope) services.MachinePoolReconcileInterface
+	pricingServiceFactory        func(cloud.ClusterScoper) services.PricingInterface
 	TagUnmanagedNetworkResources bool
 }
 
@@ -89,15 +113,29 @@ func (r *AWSMachinePoolReconciler) getReconcileService(scope scope.EC2Scope) ser
 	return ec2.NewService(scope)
 }
 
+func (r *AWSMachinePoolReconciler) getPricingService(scope cloud.ClusterScoper) services.PricingInterface {
+	if r.pricingServiceFactory != nil {
+		return r.pricingServiceFactory(scope)
+	}
+	return pricing.NewService(scope)
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepoolmachines,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // Reconcile is the reconciliation loop for AWSMachinePool.
-func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("AWSMachinePool", res, reterr)
+	}()
+
 	log := logger.FromContext(ctx)
 
 	// Fetch the AWSMachinePool .
@@ -130,7 +168,7 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	log = log.WithValues("cluster", klog.KObj(cluster))
 
-	infraCluster, err := r.getInfraCluster(ctx, log, cluster, awsMachinePool)
+	infraCluster, err := getInfraCluster(ctx, r.Client, log, cluster, awsMachinePool.Namespace, r.TagUnmanagedNetworkResources)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("getting infra provider cluster or control plane object: %w", err)
 	}
@@ -153,6 +191,13 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	failed := machinePoolScope.HasFailed()
+	defer func() {
+		if !failed && machinePoolScope.HasFailed() {
+			awsmetrics.RecordTerminalFailure("AWSMachinePool", string(ptr.Deref(awsMachinePool.Status.FailureReason, "")))
+		}
+	}()
+
 	// Always close the scope when exiting this function so we can persist any AWSMachine changes.
 	defer func() {
 		// set Ready condition before AWSMachinePool is patched
@@ -175,22 +220,44 @@ func (r *AWSMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
-			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
+			return ctrl.Result{}, r.reconcileDelete(ctx, machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		if err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope); err != nil {
+			return ctrl.Result{}, err
+		}
+		return targetsHealthResult(awsMachinePool), nil
 	case *scope.ClusterScope:
 		if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
-			return ctrl.Result{}, r.reconcileDelete(machinePoolScope, infraScope, infraScope)
+			return ctrl.Result{}, r.reconcileDelete(ctx, machinePoolScope, infraScope, infraScope)
 		}
 
-		return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope)
+		if err := r.reconcileNormal(ctx, machinePoolScope, infraScope, infraScope); err != nil {
+			return ctrl.Result{}, err
+		}
+		return targetsHealthResult(awsMachinePool), nil
 	default:
 		return ctrl.Result{}, errors.New("infraCluster has unknown type")
 	}
 }
 
+// targetsHealthResult requeues the AWSMachinePool while its instances are not healthy in its target groups.
+func targetsHealthResult(awsMachinePool *expinfrav1.AWSMachinePool) ctrl.Result {
+	if conditions.IsFalse(awsMachinePool, expinfrav1.TargetsHealthyCondition) {
+		return ctrl.Result{RequeueAfter: targetsHealthCheckInterval}
+	}
+	return ctrl.Result{}
+}
+
 func (r *AWSMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	// Add index to AWSMachinePool to find by the IDs of its instances.
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &expinfrav1.AWSMachinePool{},
+		AWSMachinePoolInstanceIDIndex,
+		indexAWSMachinePoolByInstanceID,
+	); err != nil {
+		return errors.Wrap(err, "error setting index fields")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&expinfrav1.AWSMachinePool{}).
@@ -202,6 +269,19 @@ func (r *AWSMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		Complete(r)
 }
 
+func indexAWSMachinePoolByInstanceID(o client.Object) []string {
+	awsMachinePool, ok := o.(*expinfrav1.AWSMachinePool)
+	if !ok {
+		return nil
+	}
+
+	instanceIDs := make([]string, 0, len(awsMachinePool.Status.Instances))
+	for _, instance := range awsMachinePool.Status.Instances {
+		instanceIDs = append(instanceIDs, instance.InstanceID)
+	}
+	return instanceIDs
+}
+
 func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
 	clusterScope.Info("Reconciling AWSMachinePool")
 
@@ -240,7 +320,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	reconSvc := r.getReconcileService(ec2Scope)
 
 	// Find existing ASG
-	asg, err := r.findASG(machinePoolScope, asgsvc)
+	asg, err := r.findASG(ctx, machinePoolScope, asgsvc)
 	if err != nil {
 		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGNotFoundReason, err.Error())
 		return err
@@ -255,7 +335,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 			// But we want to update the LaunchTemplate because an error in the LaunchTemplate may be blocking the ASG creation.
 			return true, nil
 		}
-		return asgsvc.CanStartASGInstanceRefresh(machinePoolScope)
+		return asgsvc.CanStartASGInstanceRefresh(ctx, machinePoolScope)
 	}
 	runPostLaunchTemplateUpdateOperation := func() error {
 		// skip instance refresh if ASG is not created yet
@@ -264,7 +344,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 			return nil
 		}
 		// skip instance refresh if explicitly disabled
-		if machinePoolScope.AWSMachinePool.Spec.RefreshPreferences != nil && machinePoolScope.AWSMachinePool.Spec.RefreshPreferences.Disable {
+		if instanceRefreshDisabled(machinePoolScope) {
 			machinePoolScope.Debug("instance refresh disabled, skipping instance refresh")
 			return nil
 		}
@@ -278,9 +358,9 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		// Launch Template version, and the difference between the older and current versions is _more_
 		// than userdata, we should start an Instance Refresh.
 		machinePoolScope.Info("starting instance refresh", "number of instances", machinePoolScope.MachinePool.Spec.Replicas)
-		return asgsvc.StartASGInstanceRefresh(machinePoolScope)
+		return asgsvc.StartASGInstanceRefresh(ctx, machinePoolScope)
 	}
-	if err := reconSvc.ReconcileLaunchTemplate(machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
+	if err := reconSvc.ReconcileLaunchTemplate(ctx, machinePoolScope, ec2Svc, canUpdateLaunchTemplate, runPostLaunchTemplateUpdateOperation); err != nil {
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedLaunchTemplateReconcile", "Failed to reconcile launch template: %v", err)
 		machinePoolScope.Error(err, "failed to reconcile launch template")
 		return err
@@ -291,7 +371,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 
 	if asg == nil {
 		// Create new ASG
-		if err := r.createPool(machinePoolScope, clusterScope); err != nil {
+		if err := r.createPool(ctx, machinePoolScope, clusterScope); err != nil {
 			conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition, expinfrav1.ASGProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return err
 		}
@@ -311,7 +391,11 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 		}
 	}
 
-	if err := r.updatePool(machinePoolScope, clusterScope, asg); err != nil {
+	if err := r.reconcileMachinesMarkedForDeletion(ctx, machinePoolScope, asgsvc, asg); err != nil {
+		return errors.Wrap(err, "failed to remove the instances of the machines marked for deletion")
+	}
+
+	if err := r.updatePool(ctx, machinePoolScope, clusterScope, asg); err != nil {
 		machinePoolScope.Error(err, "error updating AWSMachinePool")
 		return err
 	}
@@ -328,11 +412,15 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 			ResourceService: asgsvc,
 		},
 	}
-	err = reconSvc.ReconcileTags(machinePoolScope, resourceServiceToUpdate)
+	err = reconSvc.ReconcileTags(ctx, machinePoolScope, resourceServiceToUpdate)
 	if err != nil {
 		return errors.Wrap(err, "error updating tags")
 	}
 
+	if err := reconcileClusterAutoscalerTags(ctx, machinePoolScope, clusterScope, asgsvc, asg); err != nil {
+		return errors.Wrap(err, "error updating cluster-autoscaler tags")
+	}
+
 	// Make sure Spec.ProviderID is always set.
 	machinePoolScope.AWSMachinePool.Spec.ProviderID = asg.ID
 	providerIDList := make([]string, len(asg.Instances))
@@ -340,6 +428,8 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	for i, ec2 := range asg.Instances {
 		providerIDList[i] = fmt.Sprintf("aws:///%s/%s", ec2.AvailabilityZone, ec2.ID)
 	}
+	// keep the order of the provider IDs stable so that the list is only updated when the instances change.
+	sort.Strings(providerIDList)
 
 	machinePoolScope.SetAnnotation("cluster-api-provider-aws", "true")
 
@@ -348,21 +438,393 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(ctx context.Context, machineP
 	machinePoolScope.AWSMachinePool.Status.Ready = true
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.ASGReadyCondition)
 
-	err = machinePoolScope.UpdateInstanceStatuses(ctx, asg.Instances)
+	if err := reconcileTargetsHealth(ctx, machinePoolScope, asgsvc, asg); err != nil {
+		return err
+	}
+
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		// Best effort: a failure only delays instance state change events and must not block the status update below.
+		if err := reconcileInstanceStateEventPattern(machinePoolScope, clusterScope, ec2Scope, asg.Instances); err != nil {
+			machinePoolScope.Error(err, "failed to update instances in Event Bridge instance state rule")
+		}
+	}
+
+	instanceStatuses := instanceStatusesWithCapacityTypes(ctx, machinePoolScope, asgsvc, asg)
+	err = machinePoolScope.UpdateInstanceStatuses(ctx, instanceStatuses)
 	if err != nil {
 		machinePoolScope.Error(err, "failed updating instances", "instances", asg.Instances)
 	}
+	recordAvailabilityZoneReplicas(machinePoolScope.AWSMachinePool)
+
+	if feature.Gates.Enabled(feature.CostEstimation) {
+		r.reconcileCostEstimate(machinePoolScope, clusterScope, asg, instanceStatuses)
+		r.reconcileSpotRecommendations(machinePoolScope, clusterScope, ec2Scope, asg)
+	}
+
+	if feature.Gates.Enabled(feature.MachinePoolMachines) {
+		if err := r.reconcileMachinePoolMachines(ctx, machinePoolScope, asg); err != nil {
+			return errors.Wrap(err, "failed to reconcile AWSMachinePoolMachines")
+		}
+	}
+
+	return nil
+}
+
+// instanceStatusesWithCapacityTypes returns the statuses of the instances of the ASG along with their capacity type,
+// which isn't reported by the ASG.
+func instanceStatusesWithCapacityTypes(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) []expinfrav1.AWSMachinePoolInstanceStatus {
+	instanceStatuses := make([]expinfrav1.AWSMachinePoolInstanceStatus, len(asg.InstanceStatuses))
+	copy(instanceStatuses, asg.InstanceStatuses)
+	if len(instanceStatuses) == 0 {
+		return instanceStatuses
+	}
+
+	instanceIDs := make([]string, len(instanceStatuses))
+	for i, instance := range instanceStatuses {
+		instanceIDs[i] = instance.InstanceID
+	}
+	capacityTypes, err := asgsvc.InstanceCapacityTypes(ctx, instanceIDs)
+	if err != nil {
+		machinePoolScope.Error(err, "failed to get the capacity type of the instances")
+		return instanceStatuses
+	}
+
+	for i := range instanceStatuses {
+		instanceStatuses[i].CapacityType = capacityTypes[instanceStatuses[i].InstanceID]
+	}
+	return instanceStatuses
+}
+
+// recordAvailabilityZoneReplicas exports the number of instances and ready instances of the AWSMachinePool in each
+// availability zone as metrics.
+func recordAvailabilityZoneReplicas(awsMachinePool *expinfrav1.AWSMachinePool) {
+	zones := make([]awsmetrics.MachinePoolZoneReplicas, len(awsMachinePool.Status.AvailabilityZones))
+	for i, zone := range awsMachinePool.Status.AvailabilityZones {
+		zones[i] = awsmetrics.MachinePoolZoneReplicas{
+			AvailabilityZone: zone.Name,
+			Replicas:         zone.Replicas,
+			ReadyReplicas:    zone.ReadyReplicas,
+		}
+	}
+	awsmetrics.RecordMachinePoolReplicas(awsMachinePool.Namespace, awsMachinePool.Name, zones)
+}
+
+// reconcileCostEstimate sets the estimated hourly cost of the instances of the ASG in the status of the
+// AWSMachinePool, and exports it as a metric. The estimate is informational, so failing to get it doesn't fail the
+// reconciliation.
+func (r *AWSMachinePoolReconciler) reconcileCostEstimate(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, asg *expinfrav1.AutoScalingGroup, instanceStatuses []expinfrav1.AWSMachinePoolInstanceStatus) {
+	capacityTypes := make(map[string]expinfrav1.ManagedMachinePoolCapacityType, len(instanceStatuses))
+	for _, instanceStatus := range instanceStatuses {
+		capacityTypes[instanceStatus.InstanceID] = instanceStatus.CapacityType
+	}
+	instances := make([]pricing.Instance, len(asg.Instances))
+	for i, instance := range asg.Instances {
+		instances[i] = pricing.Instance{
+			InstanceType:     instance.Type,
+			AvailabilityZone: instance.AvailabilityZone,
+			Spot:             capacityTypes[instance.ID] == expinfrav1.ManagedMachinePoolCapacityTypeSpot,
+		}
+	}
+
+	estimate, err := r.getPricingService(clusterScope).EstimateCost(instances)
+	if err != nil {
+		machinePoolScope.Error(err, "failed to estimate the cost of the instances")
+		return
+	}
+
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	awsMachinePool.Status.CostEstimate = pricing.UpdatedEstimate(awsMachinePool.Status.CostEstimate, estimate)
+	if cost, err := strconv.ParseFloat(estimate.HourlyCost, 64); err == nil {
+		awsmetrics.RecordEstimatedHourlyCost("AWSMachinePool", awsMachinePool.Namespace, awsMachinePool.Name, machinePoolScope.MachinePool.Spec.ClusterName, estimate.Currency, cost)
+	}
+}
+
+// reconcileSpotRecommendations periodically ranks the availability zones of the subnets of the ASG and the instance
+// types of the AWSMachinePool by their spot placement score and spot price history, when the pool uses spot
+// instances. Like the cost estimate, the recommendations are informational and failing to get them is only logged.
+func (r *AWSMachinePoolReconciler) reconcileSpotRecommendations(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, asg *expinfrav1.AutoScalingGroup) {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	if !usesSpotInstances(awsMachinePool) {
+		awsMachinePool.Status.SpotRecommendations = nil
+		return
+	}
+	if current := awsMachinePool.Status.SpotRecommendations; current != nil && current.LastUpdated != nil && time.Since(current.LastUpdated.Time) < spotRecommendationsPeriod {
+		return
+	}
+
+	instanceTypes := sets.New[string]()
+	if awsMachinePool.Spec.AWSLaunchTemplate.InstanceType != "" {
+		instanceTypes.Insert(awsMachinePool.Spec.AWSLaunchTemplate.InstanceType)
+	}
+	if awsMachinePool.Spec.MixedInstancesPolicy != nil {
+		for _, override := range awsMachinePool.Spec.MixedInstancesPolicy.Overrides {
+			instanceTypes.Insert(override.InstanceType)
+		}
+	}
+	availabilityZones := sets.New[string]()
+	for _, subnetID := range asg.Subnets {
+		if subnet := ec2Scope.Subnets().FindByID(strings.TrimSpace(subnetID)); subnet != nil && subnet.AvailabilityZone != "" {
+			availabilityZones.Insert(subnet.AvailabilityZone)
+		}
+	}
+	if availabilityZones.Len() == 0 {
+		availabilityZones.Insert(awsMachinePool.Spec.AvailabilityZones...)
+	}
+	targetCapacity := int64(ptr.Deref(machinePoolScope.MachinePool.Spec.Replicas, 1))
+
+	recommendations, err := r.getPricingService(clusterScope).RecommendSpotPlacements(sets.List(instanceTypes), sets.List(availabilityZones), targetCapacity)
+	if err != nil {
+		machinePoolScope.Error(err, "failed to recommend where to launch the spot instances")
+		return
+	}
+
+	now := metav1.Now()
+	awsMachinePool.Status.SpotRecommendations = &expinfrav1.SpotRecommendations{
+		Recommendations: recommendations,
+		LastUpdated:     &now,
+	}
+}
+
+// usesSpotInstances returns whether the AWSMachinePool launches spot instances, either with the spot market options
+// of its launch template or with a mixed instances policy launching some instances above the on-demand capacity as
+// spot instances.
+func usesSpotInstances(awsMachinePool *expinfrav1.AWSMachinePool) bool {
+	if awsMachinePool.Spec.AWSLaunchTemplate.SpotMarketOptions != nil {
+		return true
+	}
+	policy := awsMachinePool.Spec.MixedInstancesPolicy
+	if policy == nil || policy.InstancesDistribution == nil {
+		return false
+	}
+	return ptr.Deref(policy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity, 100) < 100
+}
+
+// reconcileMachinesMarkedForDeletion removes the instances of the Machines of the MachinePool annotated with the
+// Cluster API delete-machine annotation first when the MachinePool is scaled down. The instances are terminated
+// while decrementing the desired capacity of the ASG, so that it doesn't pick the instances to remove itself.
+func (r *AWSMachinePoolReconciler) reconcileMachinesMarkedForDeletion(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) error {
+	// The replicas follow the desired capacity of the ASG when they are managed by an external autoscaler.
+	if annotations.ReplicasManagedByExternalAutoscaler(machinePoolScope.MachinePool) {
+		return nil
+	}
+	if machinePoolScope.MachinePool.Spec.Replicas == nil || asg.DesiredCapacity == nil {
+		return nil
+	}
+	excess := int(*asg.DesiredCapacity - *machinePoolScope.MachinePool.Spec.Replicas)
+	if excess <= 0 {
+		return nil
+	}
+
+	marked, err := machinePoolScope.InstancesMarkedForDeletion(ctx)
+	if err != nil {
+		return err
+	}
+	inService := make(map[string]struct{}, len(asg.Instances))
+	for _, instance := range asg.Instances {
+		inService[instance.ID] = struct{}{}
+	}
+	toDelete := make([]string, 0, excess)
+	for _, id := range marked {
+		if _, ok := inService[id]; ok && len(toDelete) < excess {
+			toDelete = append(toDelete, id)
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+
+	machinePoolScope.Info("Terminating the instances of the machines marked for deletion", "instances", toDelete)
+	if err := asgsvc.TerminateInstancesAndDecrementDesiredCapacity(ctx, toDelete); err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedTerminateInstances", "Failed to terminate the instances of the machines marked for deletion: %v", err)
+		return err
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulTerminateInstances", "Terminated the instances of the machines marked for deletion: %s", strings.Join(toDelete, ", "))
+
+	// Reflect the removal of the instances, so that the ASG isn't updated with a stale desired capacity.
+	asg.DesiredCapacity = ptr.To(*asg.DesiredCapacity - int32(len(toDelete)))
+	deleted := make(map[string]struct{}, len(toDelete))
+	for _, id := range toDelete {
+		deleted[id] = struct{}{}
+	}
+	instances := make([]infrav1.Instance, 0, len(asg.Instances))
+	for _, instance := range asg.Instances {
+		if _, ok := deleted[instance.ID]; !ok {
+			instances = append(instances, instance)
+		}
+	}
+	asg.Instances = instances
+	instanceStatuses := make([]expinfrav1.AWSMachinePoolInstanceStatus, 0, len(asg.InstanceStatuses))
+	for _, instance := range asg.InstanceStatuses {
+		if _, ok := deleted[instance.InstanceID]; !ok {
+			instanceStatuses = append(instanceStatuses, instance)
+		}
+	}
+	asg.InstanceStatuses = instanceStatuses
+	return nil
+}
+
+// reconcileMachinePoolMachines creates an AWSMachinePoolMachine for each instance of the ASG, which Cluster API backs
+// with a Machine of the MachinePool, and removes the ones of the instances that left the ASG.
+func (r *AWSMachinePoolReconciler) reconcileMachinePoolMachines(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asg *expinfrav1.AutoScalingGroup) error {
+	awsMachinePool := machinePoolScope.AWSMachinePool
+	awsMachinePool.Status.InfrastructureMachineKind = expinfrav1.AWSMachinePoolMachineKind
+
+	// Cluster API finds the infrastructure machines of the MachinePool by these labels.
+	labels := map[string]string{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(machinePoolScope.MachinePool.Name),
+		clusterv1.ClusterNameLabel:     machinePoolScope.MachinePool.Spec.ClusterName,
+	}
+	machineList := &expinfrav1.AWSMachinePoolMachineList{}
+	if err := r.List(ctx, machineList, client.InNamespace(awsMachinePool.Namespace), client.MatchingLabels(labels)); err != nil {
+		return errors.Wrap(err, "failed to list AWSMachinePoolMachines")
+	}
+	orphans := make(map[string]*expinfrav1.AWSMachinePoolMachine, len(machineList.Items))
+	for i := range machineList.Items {
+		orphans[machineList.Items[i].Spec.InstanceID] = &machineList.Items[i]
+	}
+
+	for _, instance := range asg.Instances {
+		if _, ok := orphans[instance.ID]; ok {
+			delete(orphans, instance.ID)
+			continue
+		}
+
+		machine := &expinfrav1.AWSMachinePoolMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", awsMachinePool.Name, instance.ID),
+				Namespace: awsMachinePool.Namespace,
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: expinfrav1.GroupVersion.String(),
+					Kind:       "AWSMachinePool",
+					Name:       awsMachinePool.Name,
+					UID:        awsMachinePool.UID,
+				}},
+			},
+			Spec: expinfrav1.AWSMachinePoolMachineSpec{
+				ProviderID: fmt.Sprintf("aws:///%s/%s", instance.AvailabilityZone, instance.ID),
+				InstanceID: instance.ID,
+			},
+		}
+		machinePoolScope.Info("Creating AWSMachinePoolMachine", "instance", instance.ID)
+		if err := r.Create(ctx, machine); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create AWSMachinePoolMachine for instance %q", instance.ID)
+		}
+	}
+
+	for instanceID, machine := range orphans {
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// Delete the Machine backed by the AWSMachinePoolMachine when there is one, which deletes the
+		// AWSMachinePoolMachine in turn.
+		var obj client.Object = machine
+		owner, err := util.GetOwnerMachine(ctx, r.Client, machine.ObjectMeta)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the Machine of AWSMachinePoolMachine %q", machine.Name)
+		}
+		if owner != nil {
+			obj = owner
+		}
+		machinePoolScope.Info("Deleting the machine of an instance that left the ASG", "instance", instanceID, "machine", klog.KObj(obj))
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the machine of instance %q", instanceID)
+		}
+	}
+
+	return nil
+}
+
+// reconcileClusterAutoscalerTags updates the tags of the ASG consumed by cluster-autoscaler when they are managed,
+// including the node-template tags of the labels and taints the nodes are registered with.
+func reconcileClusterAutoscalerTags(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, asgsvc services.ASGInterface, group *expinfrav1.AutoScalingGroup) error {
+	options := machinePoolScope.AWSMachinePool.Spec.ClusterAutoscaler
+	if options == nil {
+		return nil
+	}
+
+	labels, taints, err := machinePoolScope.NodeLabelsAndTaints(ctx)
+	if err != nil {
+		return err
+	}
+
+	desired := asg.ClusterAutoscalerTags(clusterScope.KubernetesClusterName(), options, labels, taints)
+	create, remove := asg.ClusterAutoscalerTagsDiff(group.Tags, desired, machinePoolScope.AdditionalTags())
+	if len(create) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	return asgsvc.UpdateResourceTags(ctx, &group.Name, create, remove)
+}
+
+// reconcileTargetsHealth reports on the health of the in-service instances of the ASG in the target groups of the
+// AWSMachinePool with the TargetsHealthy condition.
+func reconcileTargetsHealth(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, asg *expinfrav1.AutoScalingGroup) error {
+	targetGroupARNs := machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs
+	if len(targetGroupARNs) == 0 {
+		conditions.Delete(machinePoolScope.AWSMachinePool, expinfrav1.TargetsHealthyCondition)
+		return nil
+	}
 
+	// The instances are registered with the target groups before entering the InService state.
+	instanceIDs := []string{}
+	for _, instance := range asg.Instances {
+		if instance.State == infrav1.InstanceState(autoscaling.LifecycleStateInService) {
+			instanceIDs = append(instanceIDs, instance.ID)
+		}
+	}
+
+	unhealthy, err := asgSvc.UnhealthyTargetGroupInstances(ctx, targetGroupARNs, instanceIDs)
+	if err != nil {
+		conditions.MarkUnknown(machinePoolScope.AWSMachinePool, expinfrav1.TargetsHealthyCondition, expinfrav1.TargetsHealthCheckFailedReason, err.Error())
+		return errors.Wrap(err, "failed to check health of instances in target groups")
+	}
+	if len(unhealthy) > 0 {
+		machinePoolScope.Info("waiting for instances to be healthy in target groups", "instances", unhealthy)
+		conditions.MarkFalse(machinePoolScope.AWSMachinePool, expinfrav1.TargetsHealthyCondition, expinfrav1.TargetsUnhealthyReason, clusterv1.ConditionSeverityWarning,
+			"%d of %d instances are not healthy in the target groups: %s", len(unhealthy), len(instanceIDs), strings.Join(unhealthy, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(machinePoolScope.AWSMachinePool, expinfrav1.TargetsHealthyCondition)
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
+// reconcileInstanceStateEventPattern tracks the current instances of the ASG in the Event Bridge instance state
+// rule of the cluster, and stops tracking the instances that left the ASG since the last reconcile.
+func reconcileInstanceStateEventPattern(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, instances []infrav1.Instance) error {
+	// The rule and its queue are only managed for AWSClusters.
+	if _, ok := clusterScope.(*scope.ClusterScope); !ok {
+		return nil
+	}
+
+	current := make(map[string]struct{}, len(instances))
+	add := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		current[instance.ID] = struct{}{}
+		add = append(add, instance.ID)
+	}
+	remove := []string{}
+	for _, instance := range machinePoolScope.AWSMachinePool.Status.Instances {
+		if _, ok := current[instance.InstanceID]; !ok {
+			remove = append(remove, instance.InstanceID)
+		}
+	}
+
+	return instancestate.NewService(ec2Scope).UpdateInstancesInEventPattern(add, remove)
+}
+
+func (r *AWSMachinePoolReconciler) reconcileDelete(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
+	awsmetrics.DeleteMachinePoolReplicas(machinePoolScope.AWSMachinePool.Namespace, machinePoolScope.AWSMachinePool.Name)
+	awsmetrics.DeleteEstimatedHourlyCost("AWSMachinePool", machinePoolScope.AWSMachinePool.Namespace, machinePoolScope.AWSMachinePool.Name)
+
 	clusterScope.Info("Handling deleted AWSMachinePool")
 
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgSvc := r.getASGService(clusterScope)
 
-	asg, err := r.findASG(machinePoolScope, asgSvc)
+	asg, err := r.findASG(ctx, machinePoolScope, asgSvc)
 	if err != nil {
 		return err
 	}
@@ -381,15 +843,26 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 			machinePoolScope.Info("ASG is already deleting", "name", asg.Name)
 		default:
 			machinePoolScope.Info("Deleting ASG", "id", asg.Name, "status", asg.Status)
-			if err := asgSvc.DeleteASGAndWait(asg.Name); err != nil {
+			if err := asgSvc.DeleteASGAndWait(ctx, asg.Name); err != nil {
 				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete ASG %q: %v", asg.Name, err)
 				return errors.Wrap(err, "failed to delete ASG")
 			}
 		}
 	}
 
+	if _, ok := clusterScope.(*scope.ClusterScope); ok && feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		instanceIDs := make([]string, 0, len(machinePoolScope.AWSMachinePool.Status.Instances))
+		for _, instance := range machinePoolScope.AWSMachinePool.Status.Instances {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+		// Best effort, the rule is deleted with the cluster anyway.
+		if err := instancestate.NewService(ec2Scope).UpdateInstancesInEventPattern(nil, instanceIDs); err != nil {
+			machinePoolScope.Debug("Failed to remove instances from Event Bridge instance state rule", "error", err)
+		}
+	}
+
 	launchTemplateID := machinePoolScope.AWSMachinePool.Status.LaunchTemplateID
-	launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
+	launchTemplate, _, _, err := ec2Svc.GetLaunchTemplate(ctx, machinePoolScope.LaunchTemplateName())
 	if err != nil {
 		return err
 	}
@@ -402,7 +875,7 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	}
 
 	machinePoolScope.Info("deleting launch template", "name", launchTemplate.Name)
-	if err := ec2Svc.DeleteLaunchTemplate(launchTemplateID); err != nil {
+	if err := ec2Svc.DeleteLaunchTemplate(ctx, launchTemplateID); err != nil {
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete launch template %q: %v", launchTemplate.Name, err)
 		return errors.Wrap(err, "failed to delete ASG")
 	}
@@ -415,10 +888,10 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
+func (r *AWSMachinePoolReconciler) updatePool(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, existingASG *expinfrav1.AutoScalingGroup) error {
 	asgSvc := r.getASGService(clusterScope)
 
-	subnetIDs, err := asgSvc.SubnetIDs(machinePoolScope)
+	subnetIDs, err := asgSvc.SubnetIDs(ctx, machinePoolScope)
 	if err != nil {
 		return errors.Wrapf(err, "fail to get subnets for ASG")
 	}
@@ -436,12 +909,32 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 		machinePoolScope.Debug("asg diff detected", "asgDiff", asgDiff, "subnetDiff", subnetDiff)
 	}
 	if asgDiff != "" || subnetDiff != "" {
+		// Moving the ASG to another launch template version only affects new instances, so existing
+		// instances are rolled with an instance refresh unless it is explicitly disabled.
+		refreshInstances := launchTemplateVersionChanged(machinePoolScope, existingASG) && !instanceRefreshDisabled(machinePoolScope)
+		if refreshInstances {
+			canStart, err := asgSvc.CanStartASGInstanceRefresh(ctx, machinePoolScope)
+			if err != nil {
+				return errors.Wrap(err, "unable to check for an unfinished instance refresh")
+			}
+			if !canStart {
+				return errors.New("cannot change the launch template version of the ASG while an instance refresh is unfinished")
+			}
+		}
+
 		machinePoolScope.Info("updating AutoScalingGroup")
 
-		if err := asgSvc.UpdateASG(machinePoolScope); err != nil {
+		if err := asgSvc.UpdateASG(ctx, machinePoolScope); err != nil {
 			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedUpdate", "Failed to update ASG: %v", err)
 			return errors.Wrap(err, "unable to update ASG")
 		}
+
+		if refreshInstances {
+			machinePoolScope.Info("starting instance refresh", "launch template version", machinePoolScope.LaunchTemplateVersion())
+			if err := asgSvc.StartASGInstanceRefresh(ctx, machinePoolScope); err != nil {
+				return errors.Wrap(err, "unable to start instance refresh")
+			}
+		}
 	}
 
 	suspendedProcessesSlice := machinePoolScope.AWSMachinePool.Spec.SuspendProcesses.ConvertSetValuesToStringSlice()
@@ -485,36 +978,74 @@ func (r *AWSMachinePoolReconciler) updatePool(machinePoolScope *scope.MachinePoo
 
 		if len(toBeSuspended) > 0 {
 			clusterScope.Info("suspending processes", "processes", toBeSuspended)
-			if err := asgSvc.SuspendProcesses(existingASG.Name, toBeSuspended); err != nil {
+			if err := asgSvc.SuspendProcesses(ctx, existingASG.Name, toBeSuspended); err != nil {
 				return errors.Wrapf(err, "failed to suspend processes while trying update pool")
 			}
 		}
 		if len(toBeResumed) > 0 {
 			clusterScope.Info("resuming processes", "processes", toBeResumed)
-			if err := asgSvc.ResumeProcesses(existingASG.Name, toBeResumed); err != nil {
+			if err := asgSvc.ResumeProcesses(ctx, existingASG.Name, toBeResumed); err != nil {
 				return errors.Wrapf(err, "failed to resume processes while trying update pool")
 			}
 		}
 	}
+
+	return reconcileLoadBalancerAttachments(ctx, machinePoolScope, asgSvc, existingASG)
+}
+
+// reconcileLoadBalancerAttachments attaches the load balancers listed in the AWSMachinePool to the ASG, and detaches
+// the ones no longer listed. The load balancers of the ASG are left untouched when the AWSMachinePool lists none.
+func reconcileLoadBalancerAttachments(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgSvc services.ASGInterface, existingASG *expinfrav1.AutoScalingGroup) error {
+	attachments := machinePoolScope.AWSMachinePool.Spec.LoadBalancerAttachments
+	targetGroupARNs := machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs
+	if attachments == nil && len(targetGroupARNs) == 0 {
+		return nil
+	}
+
+	desiredTargetGroups, currentTargetGroups := sets.New(targetGroupARNs...), sets.New(existingASG.TargetGroupARNs...)
+	// The classic load balancers of the ASG are only managed through the load balancer attachments.
+	desiredLoadBalancers, currentLoadBalancers := sets.New[string](), sets.New[string]()
+	if attachments != nil {
+		desiredTargetGroups.Insert(attachments.TargetGroupARNs...)
+		desiredLoadBalancers.Insert(attachments.ClassicLoadBalancerNames...)
+		currentLoadBalancers.Insert(existingASG.LoadBalancerNames...)
+	}
+
+	attachTargetGroups, attachLoadBalancers := sets.List(desiredTargetGroups.Difference(currentTargetGroups)), sets.List(desiredLoadBalancers.Difference(currentLoadBalancers))
+	if len(attachTargetGroups) > 0 || len(attachLoadBalancers) > 0 {
+		machinePoolScope.Info("attaching load balancers", "target-groups", attachTargetGroups, "load-balancers", attachLoadBalancers)
+		if err := asgSvc.AttachLoadBalancers(ctx, existingASG.Name, attachTargetGroups, attachLoadBalancers); err != nil {
+			return errors.Wrap(err, "failed to attach load balancers while trying update pool")
+		}
+	}
+
+	detachTargetGroups, detachLoadBalancers := sets.List(currentTargetGroups.Difference(desiredTargetGroups)), sets.List(currentLoadBalancers.Difference(desiredLoadBalancers))
+	if len(detachTargetGroups) > 0 || len(detachLoadBalancers) > 0 {
+		machinePoolScope.Info("detaching load balancers", "target-groups", detachTargetGroups, "load-balancers", detachLoadBalancers)
+		if err := asgSvc.DetachLoadBalancers(ctx, existingASG.Name, detachTargetGroups, detachLoadBalancers); err != nil {
+			return errors.Wrap(err, "failed to detach load balancers while trying update pool")
+		}
+	}
+
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) createPool(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper) error {
+func (r *AWSMachinePoolReconciler) createPool(ctx context.Context, machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper) error {
 	clusterScope.Info("Initializing ASG client")
 
 	asgsvc := r.getASGService(clusterScope)
 
 	machinePoolScope.Info("Creating Autoscaling Group")
-	if _, err := asgsvc.CreateASG(machinePoolScope); err != nil {
+	if _, err := asgsvc.CreateASG(ctx, machinePoolScope); err != nil {
 		return errors.Wrapf(err, "failed to create AWSMachinePool")
 	}
 
 	return nil
 }
 
-func (r *AWSMachinePoolReconciler) findASG(machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
+func (r *AWSMachinePoolReconciler) findASG(ctx context.Context, machinePoolScope *scope.MachinePoolScope, asgsvc services.ASGInterface) (*expinfrav1.AutoScalingGroup, error) {
 	// Query the instance using tags.
-	asg, err := asgsvc.GetASGByName(machinePoolScope)
+	asg, err := asgsvc.GetASGByName(ctx, machinePoolScope)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to query AWSMachinePool by name")
 	}
@@ -533,6 +1064,10 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 		return diff
 	}
 
+	if launchTemplateVersionChanged(machinePoolScope, existingASG) {
+		return fmt.Sprintf("launch template version: %q => %q", existingASG.LaunchTemplateVersion, machinePoolScope.LaunchTemplateVersion())
+	}
+
 	detectedAWSMachinePoolSpec := machinePoolScope.AWSMachinePool.Spec.DeepCopy()
 	detectedAWSMachinePoolSpec.MaxSize = existingASG.MaxSize
 	detectedAWSMachinePoolSpec.MinSize = existingASG.MinSize
@@ -555,6 +1090,16 @@ func diffASG(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.A
 	return cmp.Diff(machinePoolScope.AWSMachinePool.Spec, *detectedAWSMachinePoolSpec)
 }
 
+// launchTemplateVersionChanged returns true if the ASG uses a different launch template version than the
+// one the AWSMachinePool is pinned to.
+func launchTemplateVersionChanged(machinePoolScope *scope.MachinePoolScope, existingASG *expinfrav1.AutoScalingGroup) bool {
+	return existingASG.LaunchTemplateVersion != "" && existingASG.LaunchTemplateVersion != machinePoolScope.LaunchTemplateVersion()
+}
+
+func instanceRefreshDisabled(machinePoolScope *scope.MachinePoolScope) bool {
+	return machinePoolScope.AWSMachinePool.Spec.RefreshPreferences != nil && machinePoolScope.AWSMachinePool.Spec.RefreshPreferences.Disable
+}
+
 // getOwnerMachinePool returns the MachinePool object owning the current resource.
 func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*expclusterv1.MachinePool, error) {
 	for _, ref := range obj.OwnerReferences {
@@ -607,7 +1152,9 @@ func machinePoolToInfrastructureMapFunc(gvk schema.GroupVersionKind) handler.Map
 	}
 }
 
-func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *logger.Logger, cluster *clusterv1.Cluster, awsMachinePool *expinfrav1.AWSMachinePool) (scope.EC2Scope, error) {
+// getInfraCluster returns the scope of the AWSManagedControlPlane or AWSCluster of the cluster, or nil if it doesn't
+// exist yet.
+func getInfraCluster(ctx context.Context, c client.Client, log *logger.Logger, cluster *clusterv1.Cluster, namespace string, tagUnmanagedNetworkResources bool) (scope.EC2Scope, error) {
 	var clusterScope *scope.ClusterScope
 	var managedControlPlaneScope *scope.ManagedControlPlaneScope
 	var err error
@@ -615,22 +1162,23 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 	if cluster.Spec.ControlPlaneRef != nil && cluster.Spec.ControlPlaneRef.Kind == controllers.AWSManagedControlPlaneRefKind {
 		controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
 		controlPlaneName := client.ObjectKey{
-			Namespace: awsMachinePool.Namespace,
+			Namespace: namespace,
 			Name:      cluster.Spec.ControlPlaneRef.Name,
 		}
 
-		if err := r.Get(ctx, controlPlaneName, controlPlane); err != nil {
+		if err := c.Get(ctx, controlPlaneName, controlPlane); err != nil {
 			// AWSManagedControlPlane is not ready
 			return nil, nil //nolint:nilerr
 		}
 
 		managedControlPlaneScope, err = scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
-			Client:                       r.Client,
+			Context:                      ctx,
+			Client:                       c,
 			Logger:                       log,
 			Cluster:                      cluster,
 			ControlPlane:                 controlPlane,
 			ControllerName:               "awsManagedControlPlane",
-			TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+			TagUnmanagedNetworkResources: tagUnmanagedNetworkResources,
 		})
 		if err != nil {
 			return nil, err
@@ -642,23 +1190,24 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log *log
 	awsCluster := &infrav1.AWSCluster{}
 
 	infraClusterName := client.ObjectKey{
-		Namespace: awsMachinePool.Namespace,
+		Namespace: namespace,
 		Name:      cluster.Spec.InfrastructureRef.Name,
 	}
 
-	if err := r.Client.Get(ctx, infraClusterName, awsCluster); err != nil {
+	if err := c.Get(ctx, infraClusterName, awsCluster); err != nil {
 		// AWSCluster is not ready
 		return nil, nil //nolint:nilerr
 	}
 
 	// Create the cluster scope
 	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:                       r.Client,
+		Context:                      ctx,
+		Client:                       c,
 		Logger:                       log,
 		Cluster:                      cluster,
 		AWSCluster:                   awsCluster,
 		ControllerName:               "awsmachine",
-		TagUnmanagedNetworkResources: r.TagUnmanagedNetworkResources,
+		TagUnmanagedNetworkResources: tagUnmanagedNetworkResources,
 	})
 	if err != nil {
 		return nil, err
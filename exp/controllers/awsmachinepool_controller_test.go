@@ -195,8 +195,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			getASG := func(t *testing.T, g *WithT) {
 				t.Helper()
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, expectedErr).AnyTimes()
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, expectedErr).AnyTimes()
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Any()).Return(nil, "", nil, expectedErr).AnyTimes()
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, expectedErr).AnyTimes()
 			}
 			t.Run("should exit immediately on an error state", func(t *testing.T) {
 				g := NewWithT(t)
@@ -266,8 +266,8 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			getASG := func(t *testing.T, g *WithT) {
 				t.Helper()
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil).AnyTimes()
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 			}
 			t.Run("should look up by provider ID when one exists", func(t *testing.T) {
 				g := NewWithT(t)
@@ -277,7 +277,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				getASG(t, g)
 
 				expectedErr := errors.New("no connection available ")
-				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
 			})
@@ -298,12 +298,12 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				defer teardown(t, g)
 				setSuspendedProcesses(t, g)
 
-				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
-				asgSvc.EXPECT().CreateASG(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, nil)
+				asgSvc.EXPECT().CreateASG(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
 					Name: "name",
 				}, nil)
-				asgSvc.EXPECT().SuspendProcesses("name", []string{"Launch", "Terminate"}).Return(nil).AnyTimes().Times(0)
+				asgSvc.EXPECT().SuspendProcesses(gomock.Any(), "name", []string{"Launch", "Terminate"}).Return(nil).AnyTimes().Times(0)
 
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -322,14 +322,14 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				defer teardown(t, g)
 				setSuspendedProcesses(t, g)
 				ms.AWSMachinePool.Spec.SuspendProcesses.All = true
-				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
 					Name: "name",
 				}, nil)
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
-				asgSvc.EXPECT().SuspendProcesses("name", gomock.InAnyOrder([]string{
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().SuspendProcesses(gomock.Any(), "name", gomock.InAnyOrder([]string{
 					"ScheduledActions",
 					"Launch",
 					"Terminate",
@@ -362,16 +362,102 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				defer teardown(t, g)
 				setSuspendedProcesses(t, g)
 
-				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
 					Name:                      "name",
 					CurrentlySuspendProcesses: []string{"Launch", "process3"},
 				}, nil)
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).AnyTimes()
-				asgSvc.EXPECT().SuspendProcesses("name", []string{"Terminate"}).Return(nil).AnyTimes().Times(1)
-				asgSvc.EXPECT().ResumeProcesses("name", []string{"process3"}).Return(nil).AnyTimes().Times(1)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().SuspendProcesses(gomock.Any(), "name", []string{"Terminate"}).Return(nil).AnyTimes().Times(1)
+				asgSvc.EXPECT().ResumeProcesses(gomock.Any(), "name", []string{"process3"}).Return(nil).AnyTimes().Times(1)
+
+				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+			})
+		})
+		t.Run("there are load balancers already attached", func(t *testing.T) {
+			t.Run("it should attach the listed load balancers and detach the other ones", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.AWSMachinePool.Spec.LoadBalancerAttachments = &infrav1.LoadBalancerAttachments{
+					TargetGroupARNs:          []string{"tg-1", "tg-2"},
+					ClassicLoadBalancerNames: []string{"lb-1"},
+				}
+
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name:              "name",
+					TargetGroupARNs:   []string{"tg-1", "tg-3"},
+					LoadBalancerNames: []string{"lb-1"},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().AttachLoadBalancers(gomock.Any(), "name", []string{"tg-2"}, []string{}).Return(nil)
+				asgSvc.EXPECT().DetachLoadBalancers(gomock.Any(), "name", []string{"tg-3"}, []string{}).Return(nil)
+
+				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+			})
+		})
+
+		t.Run("there are target groups", func(t *testing.T) {
+			t.Run("it should attach them and report the health of the in-service instances", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+				ms.AWSMachinePool.Spec.TargetGroupARNs = []string{"tg-1"}
+
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name:              "name",
+					LoadBalancerNames: []string{"lb-1"},
+					Instances: []infrav1.Instance{
+						{ID: "i-1", State: "InService"},
+						{ID: "i-2", State: "Pending"},
+					},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().AttachLoadBalancers(gomock.Any(), "name", []string{"tg-1"}, []string{}).Return(nil)
+				asgSvc.EXPECT().UnhealthyTargetGroupInstances(gomock.Any(), []string{"tg-1"}, []string{"i-1"}).Return([]string{"i-1"}, nil)
+
+				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
+				g.Expect(err).To(Succeed())
+				g.Expect(conditions.IsFalse(ms.AWSMachinePool, expinfrav1.TargetsHealthyCondition)).To(BeTrue())
+				g.Expect(targetsHealthResult(ms.AWSMachinePool).RequeueAfter).To(Equal(targetsHealthCheckInterval))
+			})
+		})
+
+		t.Run("the ASG reports the state of its instances", func(t *testing.T) {
+			t.Run("it should look up the capacity type of the instances", func(t *testing.T) {
+				g := NewWithT(t)
+				setup(t, g)
+				defer teardown(t, g)
+
+				reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&expinfrav1.AutoScalingGroup{
+					Name: "name",
+					Instances: []infrav1.Instance{
+						{ID: "i-1", State: "InService", AvailabilityZone: "us-east-1a"},
+						{ID: "i-2", State: "InService", AvailabilityZone: "us-east-1b"},
+					},
+					InstanceStatuses: []expinfrav1.AWSMachinePoolInstanceStatus{
+						{InstanceID: "i-1", AvailabilityZone: "us-east-1a", LifecycleState: "InService", LaunchTemplateVersion: "1"},
+						{InstanceID: "i-2", AvailabilityZone: "us-east-1b", LifecycleState: "InService", LaunchTemplateVersion: "2"},
+					},
+				}, nil)
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				asgSvc.EXPECT().InstanceCapacityTypes(gomock.Any(), []string{"i-1", "i-2"}).Return(map[string]expinfrav1.ManagedMachinePoolCapacityType{
+					"i-1": expinfrav1.ManagedMachinePoolCapacityTypeSpot,
+					"i-2": expinfrav1.ManagedMachinePoolCapacityTypeOnDemand,
+				}, nil)
 
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -387,11 +473,11 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				Name:            "an-asg",
 				DesiredCapacity: ptr.To[int32](1),
 			}
-			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil)
-			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil)
-			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil)
-			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
+			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&asg, nil)
+			asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil)
+			asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil)
+			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 			ms.MachinePool.Annotations = map[string]string{
 				clusterv1.ReplicasManagedByAnnotation: "somehow-externally-managed",
@@ -425,11 +511,11 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					},
 				},
 				Subnets: []string{"subnet1", "subnet2"}}
-			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
-			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
-			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(0)
+			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet2", "subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).Times(0)
 
 			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
@@ -443,11 +529,11 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				MinSize: int32(0),
 				MaxSize: int32(100),
 				Subnets: []string{"subnet1", "subnet2"}}
-			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
-			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet1"}, nil).Times(1)
-			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(1)
+			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet1"}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
@@ -461,11 +547,11 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				MinSize: int32(0),
 				MaxSize: int32(2),
 				Subnets: []string{}}
-			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any()).Return(nil)
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&asg, nil).AnyTimes()
-			asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{}, nil).Times(1)
-			asgSvc.EXPECT().UpdateASG(gomock.Any()).Return(nil).Times(1)
+			reconSvc.EXPECT().ReconcileLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			reconSvc.EXPECT().ReconcileTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&asg, nil).AnyTimes()
+			asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{}, nil).Times(1)
+			asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 			err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 			g.Expect(err).To(Succeed())
@@ -481,11 +567,11 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			launchTemplateIDExisting := "lt-existing"
 
 			t.Run("nothing exists, so launch template and ASG must be created", func(t *testing.T) {
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(nil, "", nil, nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-abcdef123"), nil)
-				ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Eq(ptr.To[string]("ami-abcdef123")), gomock.Eq(userDataSecretKey), gomock.Eq([]byte("shell-script"))).Return("lt-ghijkl456", nil)
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
-				asgSvc.EXPECT().CreateASG(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(nil, "", nil, nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-abcdef123"), nil)
+				ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-abcdef123")), gomock.Eq(userDataSecretKey), gomock.Eq([]byte("shell-script"))).Return("lt-ghijkl456", nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, nil)
+				asgSvc.EXPECT().CreateASG(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
@@ -501,7 +587,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				ms.AWSMachinePool.Status.LaunchTemplateID = launchTemplateIDExisting
 				ms.AWSMachinePool.Status.LaunchTemplateVersion = ptr.To[string]("1")
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(
 					&expinfrav1.AWSLaunchTemplate{
 						Name: "test",
 						AMI: infrav1.AMIReference{
@@ -512,10 +598,10 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					userdata.ComputeHash([]byte("shell-script")),
 					&userDataSecretKey,
 					nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil) // no change
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-existing"), nil) // no change
+				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
 
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 
 					// No difference to `AWSMachinePool.spec`
@@ -529,9 +615,9 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 						MixedInstancesPolicy: awsMachinePool.Spec.MixedInstancesPolicy.DeepCopy(),
 					}, nil
 				})
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
 				// No changes, so there must not be an ASG update!
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Times(0)
 
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -542,7 +628,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				ms.AWSMachinePool.Status.LaunchTemplateID = launchTemplateIDExisting
 				ms.AWSMachinePool.Status.LaunchTemplateVersion = ptr.To[string]("1")
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(
 					&expinfrav1.AWSLaunchTemplate{
 						Name: "test",
 						AMI: infrav1.AMIReference{
@@ -553,16 +639,16 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					userdata.ComputeHash([]byte("shell-script")),
 					&userDataSecretKey,
 					nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-different"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
-				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-different")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-different"), nil)
+				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any(), gomock.Any()).Return(true, nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-different")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any(), gomock.Any()).Return("2", nil)
 				// AMI change should trigger rolling out new nodes
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any(), gomock.Any())
 
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 
 					// No difference to `AWSMachinePool.spec`
@@ -576,9 +662,9 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 						MixedInstancesPolicy: awsMachinePool.Spec.MixedInstancesPolicy.DeepCopy(),
 					}, nil
 				})
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
 				// No changes, so there must not be an ASG update!
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Times(0)
 
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -589,7 +675,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				ms.AWSMachinePool.Status.LaunchTemplateID = launchTemplateIDExisting
 				ms.AWSMachinePool.Status.LaunchTemplateVersion = ptr.To[string]("1")
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(
 					&expinfrav1.AWSLaunchTemplate{
 						Name: "test",
 						AMI: infrav1.AMIReference{
@@ -601,18 +687,18 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					// But the name of the secret changes from `previous-secret-name` to `bootstrap-data`
 					&apimachinerytypes.NamespacedName{Namespace: "default", Name: "previous-secret-name"},
 					nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
-				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
+				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any(), gomock.Any()).Return(true, nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"}), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any(), gomock.Any()).Return("2", nil)
 				// Changing the bootstrap data secret name should trigger rolling out new nodes, no matter what the
 				// content (user data) is. This way, users can enforce a rollout by changing the bootstrap config
 				// reference (`MachinePool.spec.template.spec.bootstrap`).
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any(), gomock.Any())
 
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 
 					// No difference to `AWSMachinePool.spec`
@@ -626,20 +712,20 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 						MixedInstancesPolicy: awsMachinePool.Spec.MixedInstancesPolicy.DeepCopy(),
 					}, nil
 				})
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
 				// No changes, so there must not be an ASG update!
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Times(0)
 
 				err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
 			})
 
 			t.Run("launch template and ASG created from zero, then bootstrap config reference changes", func(t *testing.T) {
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(nil, "", nil, nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-abcdef123"), nil)
-				ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Eq(ptr.To[string]("ami-abcdef123")), gomock.Eq(userDataSecretKey), gomock.Eq([]byte("shell-script"))).Return("lt-ghijkl456", nil)
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
-				asgSvc.EXPECT().CreateASG(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(nil, "", nil, nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-abcdef123"), nil)
+				ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-abcdef123")), gomock.Eq(userDataSecretKey), gomock.Eq([]byte("shell-script"))).Return("lt-ghijkl456", nil)
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, nil)
+				asgSvc.EXPECT().CreateASG(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 					return &expinfrav1.AutoScalingGroup{
 						Name: scope.Name(),
@@ -665,7 +751,7 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				g.Expect(testEnv.Create(ctx, newBootstrapSecret)).To(Succeed())
 				ms.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = ptr.To[string](newBootstrapSecret.Name)
 
-				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Eq("test")).Return(
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Eq("test")).Return(
 					&expinfrav1.AWSLaunchTemplate{
 						Name: "test",
 						AMI: infrav1.AMIReference{
@@ -676,18 +762,18 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 					userdata.ComputeHash([]byte("shell-script")),
 					&apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data"},
 					nil)
-				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
-				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
-				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any()).Return(true, nil)
-				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data-new"}), gomock.Any()).Return(nil)
-				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any()).Return("2", nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any(), gomock.Any()).Return(ptr.To[string]("ami-existing"), nil)
+				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				asgSvc.EXPECT().CanStartASGInstanceRefresh(gomock.Any(), gomock.Any()).Return(true, nil)
+				ec2Svc.EXPECT().PruneLaunchTemplateVersions(gomock.Any(), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(ptr.To[string]("ami-existing")), gomock.Eq(apimachinerytypes.NamespacedName{Namespace: "default", Name: "bootstrap-data-new"}), gomock.Any()).Return(nil)
+				ec2Svc.EXPECT().GetLaunchTemplateLatestVersion(gomock.Any(), gomock.Any()).Return("2", nil)
 				// Changing the bootstrap data secret name should trigger rolling out new nodes, no matter what the
 				// content (user data) is. This way, users can enforce a rollout by changing the bootstrap config
 				// reference (`MachinePool.spec.template.spec.bootstrap.configRef`).
-				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any())
+				asgSvc.EXPECT().StartASGInstanceRefresh(gomock.Any(), gomock.Any())
 
-				asgSvc.EXPECT().GetASGByName(gomock.Any()).DoAndReturn(func(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+				asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 					g.Expect(scope.Name()).To(Equal("test"))
 
 					// No difference to `AWSMachinePool.spec`
@@ -701,9 +787,9 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 						MixedInstancesPolicy: awsMachinePool.Spec.MixedInstancesPolicy.DeepCopy(),
 					}, nil
 				})
-				asgSvc.EXPECT().SubnetIDs(gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
+				asgSvc.EXPECT().SubnetIDs(gomock.Any(), gomock.Any()).Return([]string{"subnet-1"}, nil) // no change
 				// No changes, so there must not be an ASG update!
-				asgSvc.EXPECT().UpdateASG(gomock.Any()).Times(0)
+				asgSvc.EXPECT().UpdateASG(gomock.Any(), gomock.Any()).Times(0)
 
 				err = reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				g.Expect(err).To(Succeed())
@@ -727,9 +813,9 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			finalizer(t, g)
 
 			expectedErr := errors.New("no connection available ")
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, expectedErr).AnyTimes()
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, expectedErr).AnyTimes()
 
-			err := reconciler.reconcileDelete(ms, cs, cs)
+			err := reconciler.reconcileDelete(context.TODO(), ms, cs, cs)
 			g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
 		})
 		t.Run("should log and remove finalizer when no machinepool exists", func(t *testing.T) {
@@ -738,13 +824,13 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 			defer teardown(t, g)
 			finalizer(t, g)
 
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(nil, nil)
-			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(nil, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
 
 			buf := new(bytes.Buffer)
 			klog.SetOutput(buf)
 
-			err := reconciler.reconcileDelete(ms, cs, cs)
+			err := reconciler.reconcileDelete(context.TODO(), ms, cs, cs)
 			g.Expect(err).To(BeNil())
 			g.Expect(buf.String()).To(ContainSubstring("Unable to locate ASG"))
 			g.Expect(ms.AWSMachinePool.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
@@ -760,12 +846,12 @@ func TestAWSMachinePoolReconciler(t *testing.T) {
 				Name:   "an-asg-that-is-currently-being-deleted",
 				Status: expinfrav1.ASGStatusDeleteInProgress,
 			}
-			asgSvc.EXPECT().GetASGByName(gomock.Any()).Return(&inProgressASG, nil)
-			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
+			asgSvc.EXPECT().GetASGByName(gomock.Any(), gomock.Any()).Return(&inProgressASG, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any(), gomock.Any()).Return(nil, "", nil, nil).AnyTimes()
 
 			buf := new(bytes.Buffer)
 			klog.SetOutput(buf)
-			err := reconciler.reconcileDelete(ms, cs, cs)
+			err := reconciler.reconcileDelete(context.TODO(), ms, cs, cs)
 			g.Expect(err).To(BeNil())
 			g.Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
 			g.Eventually(recorder.Events).Should(Receive(ContainSubstring("DeletionInProgress")))
@@ -799,7 +885,7 @@ func setupCluster(clusterName string) (*scope.ClusterScope, error) {
 	_ = infrav1.AddToScheme(scheme)
 	awsCluster := &infrav1.AWSCluster{
 		ObjectMeta: metav1.ObjectMeta{Name: "test"},
-		Spec:       infrav1.AWSClusterSpec{},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 	}
 	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
 	return scope.NewClusterScope(scope.ClusterScopeParams{
@@ -1197,6 +1283,50 @@ func TestDiffASG(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "pinned launch template version != asg.launchTemplateVersion",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{
+							AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{
+								PinnedVersion: aws.String("2"),
+							},
+						},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					LaunchTemplateVersion: expinfrav1.LaunchTemplateLatestVersion,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "unpinned launch template version == asg.launchTemplateVersion",
+			args: args{
+				machinePoolScope: &scope.MachinePoolScope{
+					MachinePool: &expclusterv1.MachinePool{
+						Spec: expclusterv1.MachinePoolSpec{
+							Replicas: ptr.To[int32](1),
+						},
+					},
+					AWSMachinePool: &expinfrav1.AWSMachinePool{
+						Spec: expinfrav1.AWSMachinePoolSpec{},
+					},
+				},
+				existingASG: &expinfrav1.AutoScalingGroup{
+					DesiredCapacity:       ptr.To[int32](1),
+					LaunchTemplateVersion: expinfrav1.LaunchTemplateLatestVersion,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1205,3 +1335,187 @@ func TestDiffASG(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileMachinesMarkedForDeletion(t *testing.T) {
+	marked := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "marked",
+			Namespace:   "default",
+			Labels:      map[string]string{clusterv1.MachinePoolNameLabel: "pool"},
+			Annotations: map[string]string{clusterv1.DeleteMachineAnnotation: ""},
+		},
+		Spec: clusterv1.MachineSpec{ProviderID: ptr.To("aws:///us-east-1a/i-2")},
+	}
+
+	tests := []struct {
+		name                   string
+		replicas               int32
+		expect                 func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantDesiredCapacity    int32
+		wantRemainingInstances []string
+	}{
+		{
+			name:                   "the instances of the marked machines are terminated when scaling down",
+			replicas:               2,
+			wantDesiredCapacity:    2,
+			wantRemainingInstances: []string{"i-1", "i-3"},
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.TerminateInstancesAndDecrementDesiredCapacity(context.TODO(), []string{"i-2"}).Return(nil)
+			},
+		},
+		{
+			name:                   "nothing is terminated without scaling down",
+			replicas:               3,
+			wantDesiredCapacity:    3,
+			wantRemainingInstances: []string{"i-1", "i-2", "i-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(asgSvc.EXPECT())
+			}
+
+			testScheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			machinePoolScope := &scope.MachinePoolScope{
+				Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(marked.DeepCopy()).Build(),
+				MachinePool: &expclusterv1.MachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+					Spec:       expclusterv1.MachinePoolSpec{Replicas: ptr.To[int32](tt.replicas)},
+				},
+				AWSMachinePool: &expinfrav1.AWSMachinePool{},
+				Logger:         *logger.NewLogger(logr.Discard()),
+			}
+			asg := &expinfrav1.AutoScalingGroup{
+				DesiredCapacity: ptr.To[int32](3),
+				Instances:       []infrav1.Instance{{ID: "i-1"}, {ID: "i-2"}, {ID: "i-3"}},
+			}
+			reconciler := AWSMachinePoolReconciler{Recorder: record.NewFakeRecorder(10)}
+
+			g.Expect(reconciler.reconcileMachinesMarkedForDeletion(context.TODO(), machinePoolScope, asgSvc, asg)).To(Succeed())
+			g.Expect(*asg.DesiredCapacity).To(Equal(tt.wantDesiredCapacity))
+			instanceIDs := []string{}
+			for _, instance := range asg.Instances {
+				instanceIDs = append(instanceIDs, instance.ID)
+			}
+			g.Expect(instanceIDs).To(Equal(tt.wantRemainingInstances))
+		})
+	}
+}
+
+func TestReconcileMachinePoolMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	labels := map[string]string{
+		clusterv1.MachinePoolNameLabel: "pool",
+		clusterv1.ClusterNameLabel:     "test",
+	}
+	machinePoolMachine := func(instanceID string, owner *clusterv1.Machine) *expinfrav1.AWSMachinePoolMachine {
+		m := &expinfrav1.AWSMachinePoolMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool-" + instanceID, Namespace: "default", Labels: labels},
+			Spec:       expinfrav1.AWSMachinePoolMachineSpec{InstanceID: instanceID},
+		}
+		if owner != nil {
+			m.OwnerReferences = []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: owner.Name}}
+		}
+		return m
+	}
+	orphanMachine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "pool-i-3", Namespace: "default"}}
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(expinfrav1.AddToScheme(testScheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		machinePoolMachine("i-1", nil),
+		machinePoolMachine("i-3", orphanMachine),
+		machinePoolMachine("i-4", nil),
+		orphanMachine,
+	).Build()
+
+	machinePoolScope := &scope.MachinePoolScope{
+		Client: c,
+		MachinePool: &expclusterv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+			Spec:       expclusterv1.MachinePoolSpec{ClusterName: "test"},
+		},
+		AWSMachinePool: &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"},
+		},
+		Logger: *logger.NewLogger(logr.Discard()),
+	}
+	asg := &expinfrav1.AutoScalingGroup{
+		Instances: []infrav1.Instance{
+			{ID: "i-1", AvailabilityZone: "us-east-1a"},
+			{ID: "i-2", AvailabilityZone: "us-east-1b"},
+		},
+	}
+	reconciler := AWSMachinePoolReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+	g.Expect(reconciler.reconcileMachinePoolMachines(context.TODO(), machinePoolScope, asg)).To(Succeed())
+	g.Expect(machinePoolScope.AWSMachinePool.Status.InfrastructureMachineKind).To(Equal(expinfrav1.AWSMachinePoolMachineKind))
+
+	created := &expinfrav1.AWSMachinePoolMachine{}
+	g.Expect(c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: "pool-i-2"}, created)).To(Succeed())
+	g.Expect(created.Labels).To(Equal(labels))
+	g.Expect(created.Spec.ProviderID).To(Equal("aws:///us-east-1b/i-2"))
+	g.Expect(created.OwnerReferences).To(HaveLen(1))
+	g.Expect(created.OwnerReferences[0].UID).To(Equal(apimachinerytypes.UID("pool-uid")))
+
+	// The Machine of an instance that left the ASG is deleted, which deletes its AWSMachinePoolMachine in turn.
+	g.Expect(c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: "pool-i-3"}, &clusterv1.Machine{})).NotTo(Succeed())
+	g.Expect(c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: "pool-i-3"}, &expinfrav1.AWSMachinePoolMachine{})).To(Succeed())
+	// The AWSMachinePoolMachine of an instance that left the ASG without a Machine is deleted directly.
+	g.Expect(c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: "pool-i-4"}, &expinfrav1.AWSMachinePoolMachine{})).NotTo(Succeed())
+	g.Expect(c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: "pool-i-1"}, &expinfrav1.AWSMachinePoolMachine{})).To(Succeed())
+}
+
+func TestUsesSpotInstances(t *testing.T) {
+	tests := []struct {
+		name           string
+		awsMachinePool *expinfrav1.AWSMachinePool
+		want           bool
+	}{
+		{
+			name:           "on-demand instances",
+			awsMachinePool: &expinfrav1.AWSMachinePool{},
+			want:           false,
+		},
+		{
+			name: "spot market options",
+			awsMachinePool: &expinfrav1.AWSMachinePool{Spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{SpotMarketOptions: &infrav1.SpotMarketOptions{}},
+			}},
+			want: true,
+		},
+		{
+			name: "mixed instances policy without spot instances",
+			awsMachinePool: &expinfrav1.AWSMachinePool{Spec: expinfrav1.AWSMachinePoolSpec{
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{OnDemandPercentageAboveBaseCapacity: ptr.To[int64](100)},
+				},
+			}},
+			want: false,
+		},
+		{
+			name: "mixed instances policy with spot instances above the base capacity",
+			awsMachinePool: &expinfrav1.AWSMachinePool{Spec: expinfrav1.AWSMachinePoolSpec{
+				MixedInstancesPolicy: &expinfrav1.MixedInstancesPolicy{
+					InstancesDistribution: &expinfrav1.InstancesDistribution{OnDemandPercentageAboveBaseCapacity: ptr.To[int64](20)},
+				},
+			}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(usesSpotInstances(tt.awsMachinePool)).To(Equal(tt.want))
+		})
+	}
+}
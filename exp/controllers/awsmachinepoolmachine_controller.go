@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	asg "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// nodeCheckInterval is the interval at which the node of the instance of an AWSMachinePoolMachine is checked.
+const nodeCheckInterval = time.Minute
+
+// AWSMachinePoolMachineReconciler reconciles a AWSMachinePoolMachine object.
+type AWSMachinePoolMachineReconciler struct {
+	client.Client
+	Recorder                     record.EventRecorder
+	WatchFilterValue             string
+	TagUnmanagedNetworkResources bool
+	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
+	workloadClientFactory        func(context.Context, *clusterv1.Cluster) (client.Client, error)
+}
+
+func (r *AWSMachinePoolMachineReconciler) getASGService(scope cloud.ClusterScoper) services.ASGInterface {
+	if r.asgServiceFactory != nil {
+		return r.asgServiceFactory(scope)
+	}
+	return asg.NewService(scope)
+}
+
+func (r *AWSMachinePoolMachineReconciler) getWorkloadClient(ctx context.Context, cluster *clusterv1.Cluster) (client.Client, error) {
+	if r.workloadClientFactory != nil {
+		return r.workloadClientFactory(ctx, cluster)
+	}
+	return remote.NewClusterClient(ctx, "", r.Client, util.ObjectKey(cluster))
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *AWSMachinePoolMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&expinfrav1.AWSMachinePoolMachine{}).
+		Watches(
+			&expinfrav1.AWSMachinePool{},
+			handler.EnqueueRequestsFromMapFunc(awsMachinePoolToMachinePoolMachinesMapFunc(r.Client, logger.FromContext(ctx))),
+		).
+		Watches(
+			&clusterv1.Machine{},
+			handler.EnqueueRequestsFromMapFunc(util.MachineToInfrastructureMapFunc(expinfrav1.GroupVersion.WithKind(expinfrav1.AWSMachinePoolMachineKind))),
+		).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(logger.FromContext(ctx).GetLogger(), r.WatchFilterValue)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepoolmachines,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepoolmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machines,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+// Reconcile reconciles AWSMachinePoolMachines.
+func (r *AWSMachinePoolMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile(expinfrav1.AWSMachinePoolMachineKind, res, reterr)
+	}()
+
+	log := logger.FromContext(ctx)
+
+	awsMachinePoolMachine := &expinfrav1.AWSMachinePoolMachine{}
+	if err := r.Get(ctx, req.NamespacedName, awsMachinePoolMachine); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	awsMachinePool, err := getOwnerAWSMachinePool(ctx, r.Client, awsMachinePoolMachine.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if awsMachinePool == nil {
+		// The instances of the AWSMachinePool are terminated along with it.
+		if !awsMachinePoolMachine.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, r.removeFinalizer(ctx, awsMachinePoolMachine)
+		}
+		log.Info("AWSMachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("awsMachinePool", klog.KObj(awsMachinePool))
+
+	machinePool, err := getOwnerMachinePool(ctx, r.Client, awsMachinePool.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		log.Info("MachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("machinePool", klog.KObj(machinePool))
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		log.Info("MachinePool is missing cluster label or cluster does not exist")
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("cluster", klog.KObj(cluster))
+
+	if annotations.IsPaused(cluster, awsMachinePoolMachine) {
+		log.Info("AWSMachinePoolMachine or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	machineScope, err := scope.NewMachinePoolMachineScope(scope.MachinePoolMachineScopeParams{
+		Client:                r.Client,
+		Logger:                log,
+		Cluster:               cluster,
+		MachinePool:           machinePool,
+		AWSMachinePool:        awsMachinePool,
+		AWSMachinePoolMachine: awsMachinePoolMachine,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	defer func() {
+		conditions.SetSummary(machineScope.AWSMachinePoolMachine,
+			conditions.WithConditions(
+				expinfrav1.InstanceInServiceCondition,
+				expinfrav1.NodeReadyCondition,
+			),
+		)
+
+		if err := machineScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	if !awsMachinePoolMachine.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, machineScope)
+	}
+
+	return r.reconcileNormal(ctx, machineScope)
+}
+
+func (r *AWSMachinePoolMachineReconciler) reconcileNormal(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (ctrl.Result, error) {
+	machineScope.Info("Reconciling AWSMachinePoolMachine")
+
+	if controllerutil.AddFinalizer(machineScope.AWSMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer) {
+		if err := machineScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	remediating, err := r.reconcileRemediation(ctx, machineScope)
+	if err != nil || remediating {
+		return ctrl.Result{}, err
+	}
+
+	machineScope.UpdateInstanceStatus()
+
+	workloadClient, err := r.getWorkloadClient(ctx, machineScope.Cluster)
+	if err != nil {
+		conditions.MarkFalse(machineScope.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition, expinfrav1.NodeLookupFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrap(err, "failed to create the client of the workload cluster")
+	}
+	if err := machineScope.UpdateNodeStatus(ctx, workloadClient); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The nodes of the workload cluster aren't watched, their state is checked periodically instead.
+	return ctrl.Result{RequeueAfter: nodeCheckInterval}, nil
+}
+
+// reconcileRemediation deletes the Machine backed by the AWSMachinePoolMachine when a MachineHealthCheck marked it
+// for remediation, which replaces the instance of the AWSMachinePoolMachine in turn. It returns true if the Machine
+// is being remediated.
+func (r *AWSMachinePoolMachineReconciler) reconcileRemediation(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (bool, error) {
+	machine, err := util.GetOwnerMachine(ctx, r.Client, machineScope.AWSMachinePoolMachine.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrap(err, "failed to get the Machine of the AWSMachinePoolMachine")
+	}
+	if machine == nil || !conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return false, nil
+	}
+	if !machine.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	machineScope.Info("Deleting Machine marked for remediation", "machine", klog.KObj(machine))
+	if err := r.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(machineScope.AWSMachinePoolMachine, corev1.EventTypeWarning, "FailedRemediation", "Failed to delete Machine %q marked for remediation: %v", machine.Name, err)
+		return false, errors.Wrapf(err, "failed to delete Machine %q marked for remediation", machine.Name)
+	}
+	r.Recorder.Eventf(machineScope.AWSMachinePoolMachine, corev1.EventTypeNormal, "SuccessfulRemediation", "Deleted Machine %q marked for remediation", machine.Name)
+	return true, nil
+}
+
+func (r *AWSMachinePoolMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachinePoolMachineScope) error {
+	machineScope.Info("Handling deleted AWSMachinePoolMachine")
+
+	// The instances are terminated along with the autoscaling group when the pool is deleted, and the ones that are
+	// being terminated or already left the autoscaling group don't need to be replaced.
+	if !machineScope.AWSMachinePool.DeletionTimestamp.IsZero() || !machineScope.MachinePool.DeletionTimestamp.IsZero() || machineScope.IsTerminating() {
+		controllerutil.RemoveFinalizer(machineScope.AWSMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer)
+		return nil
+	}
+
+	infraCluster, err := getInfraCluster(ctx, r.Client, &machineScope.Logger, machineScope.Cluster, machineScope.AWSMachinePoolMachine.Namespace, r.TagUnmanagedNetworkResources)
+	if err != nil {
+		return errors.Wrap(err, "getting infra provider cluster or control plane object")
+	}
+	clusterScope, ok := infraCluster.(cloud.ClusterScoper)
+	if !ok {
+		machineScope.Info("AWSCluster or AWSManagedControlPlane is gone, the instance is terminated along with the cluster")
+		controllerutil.RemoveFinalizer(machineScope.AWSMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer)
+		return nil
+	}
+
+	// The instance is replaced rather than removed, the desired capacity of the pool is set through its MachinePool.
+	machineScope.Info("Replacing instance", "instance", machineScope.InstanceID())
+	if err := r.getASGService(clusterScope).ReplaceInstance(ctx, machineScope.InstanceID()); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachinePoolMachine, corev1.EventTypeWarning, "FailedReplaceInstance", "Failed to replace instance %q: %v", machineScope.InstanceID(), err)
+		return err
+	}
+	r.Recorder.Eventf(machineScope.AWSMachinePoolMachine, corev1.EventTypeNormal, "SuccessfulReplaceInstance", "Replaced instance %q", machineScope.InstanceID())
+
+	controllerutil.RemoveFinalizer(machineScope.AWSMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer)
+	return nil
+}
+
+// removeFinalizer removes the finalizer of an AWSMachinePoolMachine whose AWSMachinePool is gone.
+func (r *AWSMachinePoolMachineReconciler) removeFinalizer(ctx context.Context, awsMachinePoolMachine *expinfrav1.AWSMachinePoolMachine) error {
+	patchHelper, err := patch.NewHelper(awsMachinePoolMachine, r.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init AWSMachinePoolMachine patch helper")
+	}
+	controllerutil.RemoveFinalizer(awsMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer)
+	return patchHelper.Patch(ctx, awsMachinePoolMachine)
+}
+
+// getOwnerAWSMachinePool returns the AWSMachinePool owning the current resource.
+func getOwnerAWSMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*expinfrav1.AWSMachinePool, error) {
+	for _, ref := range obj.OwnerReferences {
+		if ref.Kind != "AWSMachinePool" {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if gv.Group == expinfrav1.GroupVersion.Group {
+			awsMachinePool := &expinfrav1.AWSMachinePool{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: obj.Namespace, Name: ref.Name}, awsMachinePool); err != nil {
+				return nil, err
+			}
+			return awsMachinePool, nil
+		}
+	}
+	return nil, nil
+}
+
+// awsMachinePoolToMachinePoolMachinesMapFunc maps an AWSMachinePool to the AWSMachinePoolMachines of its instances.
+func awsMachinePoolToMachinePoolMachinesMapFunc(c client.Client, log logger.Wrapper) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		machineList := &expinfrav1.AWSMachinePoolMachineList{}
+		if err := c.List(ctx, machineList, client.InNamespace(o.GetNamespace())); err != nil {
+			log.Error(err, "couldn't list AWSMachinePoolMachines")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, machine := range machineList.Items {
+			for _, ref := range machine.OwnerReferences {
+				if ref.Kind == "AWSMachinePool" && ref.UID == o.GetUID() {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: client.ObjectKey{Namespace: machine.Namespace, Name: machine.Name},
+					})
+				}
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAWSMachinePoolMachineReconcileDelete(t *testing.T) {
+	deleting := metav1.Now()
+
+	tests := []struct {
+		name                string
+		lifecycleState      string
+		awsMachinePoolGone  bool
+		poolDeleting        bool
+		expect              func(m *mock_services.MockASGInterfaceMockRecorder)
+		wantErr             bool
+		wantFinalizerRemain bool
+	}{
+		{
+			name:           "the instance is replaced",
+			lifecycleState: "InService",
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.ReplaceInstance(context.TODO(), "i-1").Return(nil)
+			},
+		},
+		{
+			name:           "the finalizer is kept when the instance can't be replaced",
+			lifecycleState: "InService",
+			expect: func(m *mock_services.MockASGInterfaceMockRecorder) {
+				m.ReplaceInstance(context.TODO(), "i-1").Return(errors.New("failed"))
+			},
+			wantErr:             true,
+			wantFinalizerRemain: true,
+		},
+		{
+			name:           "an instance being terminated isn't replaced",
+			lifecycleState: "Terminating:Wait",
+		},
+		{
+			name:               "an instance that left the autoscaling group isn't replaced",
+			awsMachinePoolGone: true,
+		},
+		{
+			name:           "the instances aren't replaced when the pool is deleted",
+			lifecycleState: "InService",
+			poolDeleting:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			asgSvc := mock_services.NewMockASGInterface(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(asgSvc.EXPECT())
+			}
+
+			testScheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			awsCluster := &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}, Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}}
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(awsCluster).Build()
+
+			awsMachinePool := &expinfrav1.AWSMachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+			if !tt.awsMachinePoolGone {
+				awsMachinePool.Status.Instances = []expinfrav1.AWSMachinePoolInstanceStatus{{InstanceID: "i-1", LifecycleState: tt.lifecycleState}}
+			}
+			if tt.poolDeleting {
+				awsMachinePool.DeletionTimestamp = &deleting
+			}
+			machineScope := &scope.MachinePoolMachineScope{
+				Client: c,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec: clusterv1.ClusterSpec{
+						InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "test"},
+					},
+				},
+				MachinePool:    &expclusterv1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}},
+				AWSMachinePool: awsMachinePool,
+				AWSMachinePoolMachine: &expinfrav1.AWSMachinePoolMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "pool-i-1",
+						Namespace:  "default",
+						Finalizers: []string{expinfrav1.AWSMachinePoolMachineFinalizer},
+					},
+					Spec: expinfrav1.AWSMachinePoolMachineSpec{InstanceID: "i-1"},
+				},
+				Logger: *logger.NewLogger(logr.Discard()),
+			}
+			reconciler := AWSMachinePoolMachineReconciler{
+				Client:   c,
+				Recorder: record.NewFakeRecorder(10),
+				asgServiceFactory: func(cloud.ClusterScoper) services.ASGInterface {
+					return asgSvc
+				},
+			}
+
+			err := reconciler.reconcileDelete(context.TODO(), machineScope)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(controllerutil.ContainsFinalizer(machineScope.AWSMachinePoolMachine, expinfrav1.AWSMachinePoolMachineFinalizer)).To(Equal(tt.wantFinalizerRemain))
+		})
+	}
+}
+
+func TestAWSMachinePoolMachineReconcileRemediation(t *testing.T) {
+	tests := []struct {
+		name            string
+		remediated      *bool
+		wantRemediating bool
+	}{
+		{
+			name:            "the Machine marked for remediation is deleted",
+			remediated:      ptr.To(false),
+			wantRemediating: true,
+		},
+		{
+			name:       "the Machine remediated already is kept",
+			remediated: ptr.To(true),
+		},
+		{
+			name: "the healthy Machine is kept",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "pool-i-1", Namespace: "default"}}
+			if tt.remediated != nil {
+				if *tt.remediated {
+					conditions.MarkTrue(machine, clusterv1.MachineOwnerRemediatedCondition)
+				} else {
+					conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+				}
+			}
+
+			testScheme := runtime.NewScheme()
+			g.Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(machine).Build()
+
+			machineScope := &scope.MachinePoolMachineScope{
+				Client: c,
+				AWSMachinePoolMachine: &expinfrav1.AWSMachinePoolMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "pool-i-1",
+						Namespace:       "default",
+						OwnerReferences: []metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: machine.Name}},
+					},
+				},
+				Logger: *logger.NewLogger(logr.Discard()),
+			}
+			reconciler := AWSMachinePoolMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+			remediating, err := reconciler.reconcileRemediation(context.TODO(), machineScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(remediating).To(Equal(tt.wantRemediating))
+			err = c.Get(context.TODO(), apimachinerytypes.NamespacedName{Namespace: "default", Name: machine.Name}, &clusterv1.Machine{})
+			if tt.wantRemediating {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
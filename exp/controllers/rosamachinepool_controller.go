@@ -3,12 +3,14 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/pkg/errors"
@@ -29,6 +31,7 @@ import (
 
 	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
@@ -40,6 +43,10 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// rosaReplicasManager is the value of the cluster.x-k8s.io/replicas-managed-by annotation set on the MachinePools
+// whose replicas are managed by the autoscaling of their ROSA nodePool.
+const rosaReplicasManager = "rosa"
+
 // ROSAMachinePoolReconciler reconciles a ROSAMachinePool object.
 type ROSAMachinePoolReconciler struct {
 	client.Client
@@ -80,7 +87,11 @@ func (r *ROSAMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ct
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=rosamachinepools/finalizers,verbs=update
 
 // Reconcile reconciles ROSAMachinePool.
-func (r *ROSAMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+func (r *ROSAMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		awsmetrics.RecordReconcile("ROSAMachinePool", res, reterr)
+	}()
+
 	log := logger.FromContext(ctx)
 
 	rosaMachinePool := &expinfrav1.ROSAMachinePool{}
@@ -207,12 +218,19 @@ func (r *ROSAMachinePoolReconciler) reconcileNormal(ctx context.Context,
 	if rosaMachinePool.Spec.Autoscaling != nil && !annotations.ReplicasManagedByExternalAutoscaler(machinePool) {
 		// make sure cluster.x-k8s.io/replicas-managed-by annotation is set on CAPI MachinePool when autoscaling is enabled.
 		annotations.AddAnnotations(machinePool, map[string]string{
-			clusterv1.ReplicasManagedByAnnotation: "rosa",
+			clusterv1.ReplicasManagedByAnnotation: rosaReplicasManager,
 		})
 		if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
+	if rosaMachinePool.Spec.Autoscaling == nil && machinePool.Annotations[clusterv1.ReplicasManagedByAnnotation] == rosaReplicasManager {
+		// the replicas are managed by the MachinePool again once autoscaling is disabled.
+		delete(machinePool.Annotations, clusterv1.ReplicasManagedByAnnotation)
+		if err := machinePoolScope.PatchCAPIMachinePoolObject(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	nodePool, found, err := ocmClient.GetNodePool(machinePoolScope.ControlPlane.Status.ID, rosaMachinePool.Spec.NodePoolName)
 	if err != nil {
@@ -355,7 +373,11 @@ func (r *ROSAMachinePoolReconciler) updateNodePool(machinePoolScope *scope.RosaM
 	currentSpec.ProviderIDList = desiredSpec.ProviderIDList // providerIDList is set by the controller and shouldn't be compared here.
 	currentSpec.Version = desiredSpec.Version               // Version changes are reconciled separately and shouldn't be compared here.
 
-	if cmp.Equal(desiredSpec, currentSpec) {
+	// the replicas of the nodePool are only set when autoscaling is disabled.
+	replicasChanged := desiredSpec.Autoscaling == nil && machinePoolScope.MachinePool.Spec.Replicas != nil &&
+		nodePool.Replicas() != int(*machinePoolScope.MachinePool.Spec.Replicas)
+
+	if cmp.Equal(desiredSpec, currentSpec, cmpopts.EquateEmpty()) && !replicasChanged {
 		// no changes detected.
 		return nodePool, nil
 	}
@@ -484,7 +506,7 @@ func nodePoolToRosaMachinePoolSpec(nodePool *cmv1.NodePool) expinfrav1.RosaMachi
 		}
 	}
 	if nodePool.Taints() != nil {
-		rosaTaints := make([]expinfrav1.RosaTaint, len(nodePool.Taints()))
+		rosaTaints := make([]expinfrav1.RosaTaint, 0, len(nodePool.Taints()))
 		for _, taint := range nodePool.Taints() {
 			rosaTaints = append(rosaTaints, expinfrav1.RosaTaint{
 				Key:    taint.Key(),
@@ -511,27 +533,30 @@ func (r *ROSAMachinePoolReconciler) reconcileProviderIDList(ctx context.Context,
 	}
 
 	ec2Svc := scope.NewEC2Client(machinePoolScope, machinePoolScope, &machinePoolScope.Logger, machinePoolScope.InfraCluster())
-	response, err := ec2Svc.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{
+	var providerIDList []string
+	err := ec2Svc.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{
 		Filters: buildEC2FiltersFromTags(tags),
+	}, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				providerID := scope.GenerateProviderID(*instance.Placement.AvailabilityZone, *instance.InstanceId)
+				providerIDList = append(providerIDList, providerID)
+			}
+		}
+		return true
 	})
 	if err != nil {
 		return err
 	}
 
-	var providerIDList []string
-	for _, reservation := range response.Reservations {
-		for _, instance := range reservation.Instances {
-			providerID := scope.GenerateProviderID(*instance.Placement.AvailabilityZone, *instance.InstanceId)
-			providerIDList = append(providerIDList, providerID)
-		}
-	}
-
+	// keep the order of the provider IDs stable so that the list is only updated when the instances change.
+	sort.Strings(providerIDList)
 	machinePoolScope.RosaMachinePool.Spec.ProviderIDList = providerIDList
 	return nil
 }
 
 func buildEC2FiltersFromTags(tags map[string]string) []*ec2.Filter {
-	filters := make([]*ec2.Filter, len(tags)+1)
+	filters := make([]*ec2.Filter, 0, len(tags)+1)
 	for key, value := range tags {
 		filters = append(filters, &ec2.Filter{
 			Name: ptr.To(fmt.Sprintf("tag:%s", key)),
@@ -4,7 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -44,3 +47,36 @@ func TestNodePoolToRosaMachinePoolSpec(t *testing.T) {
 
 	g.Expect(expectedSpec).To(Equal(rosaMachinePoolSpec))
 }
+
+func TestNodePoolToRosaMachinePoolSpecWithAutoscaling(t *testing.T) {
+	g := NewWithT(t)
+
+	rosaMachinePoolSpec := expinfrav1.RosaMachinePoolSpec{
+		NodePoolName: "test-nodepool",
+		InstanceType: "m5.large",
+		Autoscaling: &expinfrav1.RosaMachinePoolAutoScaling{
+			MinReplicas: 2,
+			MaxReplicas: 5,
+		},
+		Taints: []expinfrav1.RosaTaint{
+			{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+		},
+		AdditionalSecurityGroups: []string{"sg-1"},
+	}
+
+	nodePoolSpec, err := nodePoolBuilder(rosaMachinePoolSpec, expclusterv1.MachinePoolSpec{}).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nodePoolSpec.Replicas()).To(BeZero())
+
+	g.Expect(nodePoolToRosaMachinePoolSpec(nodePoolSpec)).To(Equal(rosaMachinePoolSpec))
+}
+
+func TestBuildEC2FiltersFromTags(t *testing.T) {
+	g := NewWithT(t)
+
+	filters := buildEC2FiltersFromTags(map[string]string{"red-hat-managed": "true"})
+	g.Expect(filters).To(Equal([]*ec2.Filter{
+		{Name: ptr.To("tag:red-hat-managed"), Values: aws.StringSlice([]string{"true"})},
+		{Name: ptr.To("instance-state-name"), Values: aws.StringSlice([]string{"running", "pending"})},
+	}))
+}
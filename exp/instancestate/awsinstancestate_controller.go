@@ -21,6 +21,7 @@ package instancestate
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
@@ -38,6 +39,10 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	expcontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/exp/controllers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	awscache "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/cache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -60,6 +65,7 @@ type AwsInstanceStateReconciler struct {
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -97,6 +103,14 @@ func (r *AwsInstanceStateReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, nil
 	}
 
+	// The region of a cluster omitting it is only known once the AWSCluster controller recorded it, which
+	// updates the AWSCluster.
+	region := scope.AWSClusterRegion(awsCluster)
+	if region == "" {
+		r.Log.Info("waiting for the region of the cluster to be resolved", "cluster", klog.KObj(awsCluster))
+		return reconcile.Result{}, nil
+	}
+
 	// retrieve queue URL if it isn't already tracked
 	if _, ok := r.queueURLs.Load(awsCluster.Name); !ok {
 		URL, err := r.getQueueURL(awsCluster)
@@ -106,7 +120,7 @@ func (r *AwsInstanceStateReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			}
 			return reconcile.Result{}, err
 		}
-		r.queueURLs.Store(awsCluster.Name, queueParams{region: awsCluster.Spec.Region, URL: URL})
+		r.queueURLs.Store(awsCluster.Name, queueParams{region: region, URL: URL})
 	}
 
 	return ctrl.Result{}, nil
@@ -129,7 +143,7 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 	if err := r.Client.List(ctx, awsClusterList); err == nil {
 		for i, cluster := range awsClusterList.Items {
 			if URL, err := r.getQueueURL(&awsClusterList.Items[i]); err == nil {
-				r.queueURLs.Store(cluster.Name, queueParams{region: cluster.Spec.Region, URL: URL})
+				r.queueURLs.Store(cluster.Name, queueParams{region: scope.AWSClusterRegion(&awsClusterList.Items[i]), URL: URL})
 			}
 		}
 	}
@@ -175,12 +189,16 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 	}
 }
 
-// processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
+// processMessage triggers a reconcile on the AWSMachine or the AWSMachinePool owning an EC2 instance
+// if the state of the instance changed.
 func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
 	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
 		return
 	}
 
+	// The cached EC2 responses no longer reflect the state of the instance.
+	awscache.InvalidateEC2Resources(msg.MessageDetail.InstanceID)
+
 	// Fetch the awsMachine instance by InstanceID
 	awsMachines := &infrav1.AWSMachineList{}
 	err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: msg.MessageDetail.InstanceID})
@@ -190,33 +208,57 @@ func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg mes
 	}
 
 	if len(awsMachines.Items) > 0 {
-		machine := awsMachines.Items[0]
-		if !machine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return
-		}
-		patchHelper, err := patch.NewHelper(&machine, r.Client)
-		if err != nil {
-			r.Log.Error(err, "unable to create patch helper")
-		}
-		// Trigger an update on the machine
-		labels := machine.GetLabels()
-		if labels == nil {
-			labels = make(map[string]string)
-		}
+		r.setInstanceStateLabel(ctx, &awsMachines.Items[0], msg.MessageDetail.State)
+		return
+	}
 
-		labels[Ec2InstanceStateLabelKey] = string(msg.MessageDetail.State)
-		machine.SetLabels(labels)
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return
+	}
 
-		err = patchHelper.Patch(ctx, &machine)
-		if err != nil {
-			r.Log.Error(err, "unable to patch AWS machine")
-		}
+	// The instance may belong to the autoscaling group of an AWSMachinePool.
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, client.MatchingFields{expcontrollers.AWSMachinePoolInstanceIDIndex: msg.MessageDetail.InstanceID}); err != nil {
+		r.Log.Error(err, "unable to list machine pools by instance ID", "instanceID", msg.MessageDetail.InstanceID)
+		return
+	}
+
+	if len(awsMachinePools.Items) > 0 {
+		r.setInstanceStateLabel(ctx, &awsMachinePools.Items[0], msg.MessageDetail.State)
+	}
+}
+
+// setInstanceStateLabel triggers an update on an object by labelling it with the state of its instance.
+func (r *AwsInstanceStateReconciler) setInstanceStateLabel(ctx context.Context, obj client.Object, state infrav1.InstanceState) {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		r.Log.Error(err, "unable to create patch helper")
+		return
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+
+	labels[Ec2InstanceStateLabelKey] = string(state)
+	obj.SetLabels(labels)
+
+	if err := patchHelper.Patch(ctx, obj); err != nil {
+		r.Log.Error(err, "unable to patch object", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
 	}
 }
 
 // getQueueURL retrieves the SQS queue URL for a given cluster.
 func (r *AwsInstanceStateReconciler) getQueueURL(cluster *infrav1.AWSCluster) (string, error) {
-	sqsSvs, err := r.getSQSService(cluster.Spec.Region)
+	region := scope.AWSClusterRegion(cluster)
+	if region == "" {
+		return "", fmt.Errorf("the region of cluster %s isn't resolved yet", cluster.Name)
+	}
+	sqsSvs, err := r.getSQSService(region)
 	if err != nil {
 		return "", err
 	}
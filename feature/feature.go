@@ -86,6 +86,23 @@ const (
 	// owner: @enxebre
 	// alpha: v2.2
 	ROSA featuregate.Feature = "ROSA"
+
+	// IAMRoles is used to enable the provisioning of IAM roles for service accounts through AWSIAMRoles.
+	// owner: @miyadav
+	// alpha: v2.5
+	IAMRoles featuregate.Feature = "IAMRoles"
+
+	// MachinePoolMachines is used to enable the creation of an AWSMachinePoolMachine for each instance of an
+	// AWSMachinePool, which allows MachineHealthChecks to remediate the instances individually.
+	// owner: @miyadav
+	// alpha: v2.5
+	MachinePoolMachines featuregate.Feature = "MachinePoolMachines"
+
+	// CostEstimation is used to enable the estimation of the hourly cost of the instances of AWSMachines and
+	// AWSMachinePools from the AWS Pricing API and the spot price history.
+	// owner: @miyadav
+	// alpha: v2.5
+	CostEstimation featuregate.Feature = "CostEstimation"
 )
 
 func init() {
@@ -108,4 +125,7 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	TagUnmanagedNetworkResources:  {Default: true, PreRelease: featuregate.Alpha},
 	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	IAMRoles:                      {Default: false, PreRelease: featuregate.Alpha},
+	MachinePoolMachines:           {Default: false, PreRelease: featuregate.Alpha},
+	CostEstimation:                {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,60 @@
+#!/usr/bin/env bash
+# Copyright 2024 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Runs the integration tests of test/localstack against LocalStack. LocalStack is started in a
+# container unless LOCALSTACK_ENDPOINT points to a running instance.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+LOCALSTACK_IMAGE="${LOCALSTACK_IMAGE:-localstack/localstack:3.8}"
+LOCALSTACK_CONTAINER="${LOCALSTACK_CONTAINER:-capa-localstack}"
+LOCALSTACK_PORT="${LOCALSTACK_PORT:-4566}"
+TEST_ARGS="${TEST_ARGS:-}"
+
+# shellcheck source=./hack/utils.sh
+source "$(dirname "$0")/utils.sh"
+ROOT_PATH=$(get_root_path)
+
+if [[ -z "${LOCALSTACK_ENDPOINT:-}" ]]; then
+  LOCALSTACK_ENDPOINT="http://localhost:${LOCALSTACK_PORT}"
+
+  cleanup() {
+    docker rm -f "${LOCALSTACK_CONTAINER}" >/dev/null 2>&1 || true
+  }
+  trap cleanup EXIT
+
+  docker run --detach --rm --name "${LOCALSTACK_CONTAINER}" \
+    --publish "${LOCALSTACK_PORT}:4566" \
+    --env SERVICES=ec2,elb,elbv2,sts,ssm,resourcegroupstaggingapi \
+    "${LOCALSTACK_IMAGE}" >/dev/null
+
+  echo "Waiting for LocalStack at ${LOCALSTACK_ENDPOINT}"
+  for _ in $(seq 1 60); do
+    if curl --silent --fail "${LOCALSTACK_ENDPOINT}/_localstack/health" >/dev/null; then
+      break
+    fi
+    sleep 2
+  done
+fi
+
+export LOCALSTACK_ENDPOINT
+export AWS_ACCESS_KEY_ID="${AWS_ACCESS_KEY_ID:-test}"
+export AWS_SECRET_ACCESS_KEY="${AWS_SECRET_ACCESS_KEY:-test}"
+
+cd "${ROOT_PATH}"
+# shellcheck disable=SC2086
+go test -tags=localstack -count=1 ./test/localstack/... ${TEST_ARGS}
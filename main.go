@@ -29,6 +29,7 @@ import (
 
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	cgscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	cgrecord "k8s.io/client-go/tools/record"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -60,8 +62,14 @@ import (
 	expcontrollers "sigs.k8s.io/cluster-api-provider-aws/v2/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	awscache "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/cache"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/regionvalidation"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/version"
@@ -103,12 +111,35 @@ var (
 	awsClusterConcurrency       int
 	instanceStateConcurrency    int
 	awsMachineConcurrency       int
+	awsMachinePoolConcurrency   int
+	awsManagedCPConcurrency     int
+	awsManagedMPConcurrency     int
 	waitInfraPeriod             time.Duration
 	syncPeriod                  time.Duration
 	webhookPort                 int
 	webhookCertDir              string
 	healthAddr                  string
 	serviceEndpoints            string
+	amiLookupCacheTTL           time.Duration
+	disableAMILookupCache       bool
+	ec2DescribeCacheTTL         time.Duration
+	ec2TagBatchWindow           time.Duration
+	awsRequestTimeout           time.Duration
+	serviceLimiterScope         string
+	serviceLimiterMultiplier    float64
+	defaultRegion               string
+	useFIPSEndpoints            bool
+	useDualStackEndpoints       bool
+	remediateTerminatedMachines bool
+	nodeJoinTimeout             time.Duration
+	awsClusterResyncPeriod      time.Duration
+	awsMachineResyncPeriod      time.Duration
+	validateRegionResources     bool
+	iamPermissionsPreflight     bool
+	regionValidationCacheTTL    time.Duration
+	defaultTags                 map[string]string
+	rolesAnywhereSigningHelper  string
+	webIdentityFilesDir         string
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -222,6 +253,39 @@ func main() {
 		setupLog.Error(err, "unable to parse service endpoints", "controller", "AWSCluster")
 		os.Exit(1)
 	}
+	scope.SetEndpointOptions(useFIPSEndpoints, useDualStackEndpoints)
+	scope.SetRequestTimeout(awsRequestTimeout)
+
+	if !disableAMILookupCache {
+		ec2service.SetAMICacheTTL(amiLookupCacheTTL)
+	}
+	awscache.SetEC2DescribeCacheTTL(ec2DescribeCacheTTL)
+	tags.SetEC2BatchWindow(ec2TagBatchWindow)
+	if validateRegionResources {
+		setupLog.Info("enabling validation of the AWS resources referenced by the specs against their region")
+		regionValidator := regionvalidation.NewValidator(mgr.GetClient(), awsServiceEndpoints, regionValidationCacheTTL)
+		infrav1.SetRegionValidator(regionValidator)
+		expinfrav1.SetRegionValidator(regionValidator)
+	}
+
+	if len(defaultTags) > 0 {
+		tags := infrav1.Tags(defaultTags)
+		if errs := tags.ValidateWithPath(field.NewPath("default-tags")); len(errs) > 0 {
+			setupLog.Error(field.ErrorList(errs).ToAggregate(), "invalid default tags")
+			os.Exit(1)
+		}
+		infrav1.SetDefaultTags(tags)
+	}
+
+	if err := scope.SetServiceLimiterOptions(scope.ServiceLimiterScope(serviceLimiterScope), serviceLimiterMultiplier); err != nil {
+		setupLog.Error(err, "unable to configure AWS API rate limiters")
+		os.Exit(1)
+	}
+	scope.SetDefaultRegion(defaultRegion)
+	if err := identity.SetWebIdentityOptions(rolesAnywhereSigningHelper, webIdentityFilesDir); err != nil {
+		setupLog.Error(err, "unable to configure AWSClusterWebIdentities")
+		os.Exit(1)
+	}
 
 	setupReconcilersAndWebhooks(ctx, mgr, awsServiceEndpoints, externalResourceGC, alternativeGCStrategy)
 	if feature.Gates.Enabled(feature.EKS) {
@@ -273,6 +337,8 @@ func main() {
 		}
 	}
 
+	setupMetrics(mgr)
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
@@ -292,6 +358,33 @@ func main() {
 	}
 }
 
+// setupMetrics registers the collector of the number of objects being deleted, for the kinds whose controllers
+// are enabled.
+func setupMetrics(mgr ctrl.Manager) {
+	lists := map[string]func() client.ObjectList{
+		"AWSCluster": func() client.ObjectList { return &infrav1.AWSClusterList{} },
+		"AWSMachine": func() client.ObjectList { return &infrav1.AWSMachineList{} },
+	}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		lists["AWSMachinePool"] = func() client.ObjectList { return &expinfrav1.AWSMachinePoolList{} }
+		if feature.Gates.Enabled(feature.EKS) {
+			lists["AWSManagedMachinePool"] = func() client.ObjectList { return &expinfrav1.AWSManagedMachinePoolList{} }
+		}
+	}
+	if feature.Gates.Enabled(feature.EKS) {
+		lists["AWSManagedControlPlane"] = func() client.ObjectList { return &ekscontrolplanev1.AWSManagedControlPlaneList{} }
+	}
+	if feature.Gates.Enabled(feature.ROSA) {
+		lists["ROSAControlPlane"] = func() client.ObjectList { return &rosacontrolplanev1.ROSAControlPlaneList{} }
+		lists["ROSAMachinePool"] = func() client.ObjectList { return &expinfrav1.ROSAMachinePoolList{} }
+	}
+
+	if err := awsmetrics.RegisterPendingDeletionCollector(mgr.GetClient(), lists); err != nil {
+		setupLog.Error(err, "unable to register metrics collector")
+		os.Exit(1)
+	}
+}
+
 func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServiceEndpoints []scope.ServiceEndpoint,
 	externalResourceGC, alternativeGCStrategy bool,
 ) {
@@ -302,6 +395,9 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		Endpoints:                    awsServiceEndpoints,
 		WatchFilterValue:             watchFilterValue,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		RemediateTerminatedInstances: remediateTerminatedMachines,
+		NodeJoinTimeout:              nodeJoinTimeout,
+		ResyncPeriod:                 awsMachineResyncPeriod,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 		os.Exit(1)
@@ -315,6 +411,8 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		ExternalResourceGC:           externalResourceGC,
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+		IAMPermissionsPreflight:      iamPermissionsPreflight,
+		ResyncPeriod:                 awsClusterResyncPeriod,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSCluster")
 		os.Exit(1)
@@ -327,7 +425,7 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 			Recorder:                     mgr.GetEventRecorderFor("awsmachinepool-controller"),
 			WatchFilterValue:             watchFilterValue,
 			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePool")
 			os.Exit(1)
 		}
@@ -336,6 +434,19 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachinePool")
 			os.Exit(1)
 		}
+
+		if feature.Gates.Enabled(feature.MachinePoolMachines) {
+			setupLog.Debug("enabling machine pool machine controller")
+			if err := (&expcontrollers.AWSMachinePoolMachineReconciler{
+				Client:                       mgr.GetClient(),
+				Recorder:                     mgr.GetEventRecorderFor("awsmachinepoolmachine-controller"),
+				WatchFilterValue:             watchFilterValue,
+				TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
+			}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePoolMachine")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
@@ -364,6 +475,24 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		}
 	}
 
+	if feature.Gates.Enabled(feature.IAMRoles) {
+		setupLog.Debug("enabling IAM role controller")
+		if err := (&expcontrollers.AWSIAMRoleReconciler{
+			Client:           mgr.GetClient(),
+			Recorder:         mgr.GetEventRecorderFor("awsiamrole-reconciler"),
+			Endpoints:        awsServiceEndpoints,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AWSIAMRole")
+			os.Exit(1)
+		}
+
+		if err := (&expinfrav1.AWSIAMRole{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSIAMRole")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&infrav1.AWSMachineTemplateWebhook{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineTemplate")
 		os.Exit(1)
@@ -388,10 +517,19 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterStaticIdentity")
 		os.Exit(1)
 	}
+	if err := (&infrav1.AWSClusterWebIdentity{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterWebIdentity")
+		os.Exit(1)
+	}
 	if err := (&infrav1.AWSMachine{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachine")
 		os.Exit(1)
 	}
+	if err := (&infrav1.AWSProviderConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AWSProviderConfig")
+		os.Exit(1)
+	}
+	infrav1.SetProviderConfigReader(mgr.GetClient())
 }
 
 func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServiceEndpoints []scope.ServiceEndpoint,
@@ -424,7 +562,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 		AlternativeGCStrategy:        alternativeGCStrategy,
 		WaitInfraPeriod:              waitInfraPeriod,
 		TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsManagedCPConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSManagedControlPlane")
 		os.Exit(1)
 	}
@@ -476,7 +614,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 			Recorder:                     mgr.GetEventRecorderFor("awsmanagedmachinepool-reconciler"),
 			WatchFilterValue:             watchFilterValue,
 			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsManagedMPConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSManagedMachinePool")
 			os.Exit(1)
 		}
@@ -561,6 +699,24 @@ func initFlags(fs *pflag.FlagSet) {
 		"Number of AWSMachines to process simultaneously",
 	)
 
+	fs.IntVar(&awsMachinePoolConcurrency,
+		"awsmachinepool-concurrency",
+		5,
+		"Number of AWSMachinePools to process simultaneously",
+	)
+
+	fs.IntVar(&awsManagedCPConcurrency,
+		"awsmanagedcontrolplane-concurrency",
+		5,
+		"Number of AWSManagedControlPlanes to process simultaneously",
+	)
+
+	fs.IntVar(&awsManagedMPConcurrency,
+		"awsmanagedmachinepool-concurrency",
+		5,
+		"Number of AWSManagedMachinePools to process simultaneously",
+	)
+
 	fs.DurationVar(&waitInfraPeriod,
 		"wait-infra-period",
 		1*time.Minute,
@@ -591,7 +747,7 @@ func initFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&serviceEndpoints,
 		"service-endpoints",
 		"",
-		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
+		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}... They can be overridden for the clusters with the serviceEndpoints of the AWSClusters and of their identities.",
 	)
 
 	fs.StringVar(
@@ -601,6 +757,126 @@ func initFlags(fs *pflag.FlagSet) {
 		fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel),
 	)
 
+	fs.DurationVar(&amiLookupCacheTTL,
+		"ami-lookup-cache-ttl",
+		ec2service.DefaultAMICacheTTL,
+		"The duration for which the AMIs found by looking up images by name are cached, to reduce the calls to the EC2 API.",
+	)
+
+	fs.BoolVar(&disableAMILookupCache,
+		"disable-ami-lookup-cache",
+		false,
+		"Disable the caching of the AMIs found by looking up images by name.",
+	)
+
+	fs.BoolVar(&remediateTerminatedMachines,
+		"remediate-terminated-instances",
+		false,
+		"Delete the Machines whose EC2 instance was terminated outside of Cluster API, so that their MachineSet or control plane replaces them. Machines without an owner are only marked as failed.",
+	)
+
+	fs.DurationVar(&nodeJoinTimeout,
+		"node-join-timeout",
+		0,
+		"The duration after which the console output and the SSM agent status of the running instances whose node didn't join the cluster are reported in the NodeJoined condition and in an event of their AWSMachine. Zero disables it.",
+	)
+
+	fs.DurationVar(&awsClusterResyncPeriod,
+		"awscluster-resync-period",
+		0,
+		"The duration for which the reconciliations of ready AWSClusters whose desired state didn't change since their last successful reconciliation are skipped, saving the calls to the AWS APIs. Zero reconciles them every time.",
+	)
+
+	fs.DurationVar(&awsMachineResyncPeriod,
+		"awsmachine-resync-period",
+		0,
+		"The duration for which the reconciliations of ready AWSMachines with a running instance whose desired state didn't change since their last successful reconciliation are skipped, saving the calls to the AWS APIs. Zero reconciles them every time.",
+	)
+
+	fs.DurationVar(&ec2DescribeCacheTTL,
+		"ec2-describe-cache-ttl",
+		0,
+		"The duration for which the responses of DescribeInstances and DescribeSubnets are cached and shared between reconciles, to reduce the calls to the EC2 API. The responses are invalidated when the controllers change the instances or subnets, or receive an instance state change event. Zero disables the cache.",
+	)
+
+	fs.DurationVar(&ec2TagBatchWindow,
+		"ec2-tag-batch-window",
+		0,
+		fmt.Sprintf("The duration for which the updates of the additional tags of EC2 resources are held to be sent together with the updates of the other resources of the cluster, in requests of up to %d resources. Zero, the default, sends every update on its own.", tags.MaxEC2BatchSize),
+	)
+
+	fs.DurationVar(&awsRequestTimeout,
+		"aws-request-timeout",
+		5*time.Minute,
+		"The deadline of each call to the AWS APIs, retries included, so that a call which doesn't return doesn't block the reconciliation of the cluster. The calls are also cancelled with their reconciliation. Zero disables the deadline.",
+	)
+
+	fs.StringVar(&serviceLimiterScope,
+		"aws-api-rate-limiter-scope",
+		string(scope.ServiceLimiterScopeCluster),
+		fmt.Sprintf("Which clusters share the client-side AWS API rate limiters. %q gives every cluster its own limiters, %q shares them between all the clusters using the same identity in a region.", scope.ServiceLimiterScopeCluster, scope.ServiceLimiterScopeIdentity),
+	)
+
+	fs.Float64Var(&serviceLimiterMultiplier,
+		"aws-api-rate-limit-multiplier",
+		1,
+		"Multiplier applied to the refill rates and bursts of the client-side AWS API rate limiters.",
+	)
+
+	fs.StringVar(&defaultRegion,
+		"default-region",
+		"",
+		"The AWS Region of the clusters which set neither their own region nor a default region in their identity. When it is empty, the region of the instance the controllers run on is read from the instance metadata service.",
+	)
+
+	fs.StringVar(&rolesAnywhereSigningHelper,
+		"roles-anywhere-signing-helper",
+		identity.DefaultRolesAnywhereSigningHelper,
+		"The IAM Roles Anywhere credential helper run to get the credentials of the AWSClusterWebIdentities using IAM Roles Anywhere. It is looked up in the PATH of the controllers unless it is a path.",
+	)
+
+	fs.StringVar(&webIdentityFilesDir,
+		"web-identity-files-dir",
+		identity.DefaultWebIdentityFilesDir,
+		"The directory the token, certificate and private key files of the AWSClusterWebIdentities must be in. The AWSClusterWebIdentities referencing other files are not used.",
+	)
+
+	fs.BoolVar(&useFIPSEndpoints,
+		"use-fips-endpoints",
+		false,
+		"Use the FIPS endpoints of the AWS services. The custom service endpoints are used as is.",
+	)
+
+	fs.BoolVar(&useDualStackEndpoints,
+		"use-dualstack-endpoints",
+		false,
+		"Use the dual-stack (IPv4 and IPv6) endpoints of the AWS services. The custom service endpoints are used as is.",
+	)
+
+	fs.BoolVar(&validateRegionResources,
+		"validate-region-resources",
+		false,
+		"Reject at admission time the AWSClusters, AWSMachines and AWSMachinePools referencing availability zones, instance types or AMIs which don't exist in the region of their cluster, or instance types not offered in their availability zones. The validation is skipped, with a warning, when AWS can't be reached.",
+	)
+
+	fs.BoolVar(&iamPermissionsPreflight,
+		"iam-permissions-preflight",
+		false,
+		"Simulate, before creating the AWS resources of an AWSCluster, the key actions needed by the controllers with the policies of the identity they use, and wait for the denied actions, reported in the IAMPermissionsReady condition, to be allowed.",
+	)
+
+	fs.DurationVar(&regionValidationCacheTTL,
+		"region-validation-cache-ttl",
+		regionvalidation.DefaultCacheTTL,
+		"The duration for which the availability zones, instance type offerings and AMIs of a region are cached by the validation of the AWS resources referenced by the specs.",
+	)
+
+	fs.StringToStringVar(&defaultTags,
+		"default-tags",
+		nil,
+		"Tags added to the additional tags of the AWSClusters, AWSMachines, AWSMachinePools and AWSManagedMachinePools which don't already set them, e.g. the tags required on all the AWS resources of an organization (e.g. cost-center=platform,owner=team).",
+	)
+
 	logs.AddFlags(fs, logs.SkipLoggingConfigurationFlags())
 	v1.AddFlags(logOptions, fs)
 
@@ -18,14 +18,18 @@ limitations under the License.
 package awserrors
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/smithy-go"
 )
 
 // Error singletons for AWS errors.
 const (
+	AccessDenied                      = "AccessDenied"
+	AccessDeniedException             = "AccessDeniedException"
 	AssociationIDNotFound             = "InvalidAssociationID.NotFound"
 	AuthFailure                       = "AuthFailure"
 	BucketAlreadyOwnedByYou           = "BucketAlreadyOwnedByYou"
@@ -35,7 +39,10 @@ const (
 	InternetGatewayNotFound           = "InvalidInternetGatewayID.NotFound"
 	InvalidCarrierGatewayNotFound     = "InvalidCarrierGatewayID.NotFound"
 	EgressOnlyInternetGatewayNotFound = "InvalidEgressOnlyInternetGatewayID.NotFound"
+	ExpiredToken                      = "ExpiredToken"
 	InUseIPAddress                    = "InvalidIPAddress.InUse"
+	InvalidAMIIDMalformed             = "InvalidAMIID.Malformed"
+	InvalidAMIIDNotFound              = "InvalidAMIID.NotFound"
 	InvalidAccessKeyID                = "InvalidAccessKeyId"
 	InvalidClientTokenID              = "InvalidClientTokenId"
 	InvalidInstanceID                 = "InvalidInstanceID.NotFound"
@@ -60,11 +67,15 @@ const (
 
 var _ error = &EC2Error{}
 
-// Code returns the AWS error code as a string.
+// Code returns the AWS error code as a string. The errors of the AWS SDK for Go v2 clients are supported too.
 func Code(err error) (string, bool) {
 	if awserr, ok := err.(awserr.Error); ok {
 		return awserr.Code(), true
 	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode(), true
+	}
 	return "", false
 }
 
@@ -187,6 +198,31 @@ func IsPermissionsError(err error) bool {
 	return false
 }
 
+// IsCredentialsError tests for the errors of AWS calls made with invalid, expired or revoked credentials, or
+// credentials which aren't allowed to make the call, which keep on failing until the credentials are fixed.
+// The AWS errors wrapped in err, or in the errors aggregated by err, are tested too.
+func IsCredentialsError(err error) bool {
+	if agg, ok := err.(interface{ Errors() []error }); ok {
+		for _, e := range agg.Errors() {
+			if IsCredentialsError(e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return false
+	}
+	switch aerr.Code() {
+	case AccessDenied, AccessDeniedException, AuthFailure, ExpiredToken, InvalidAccessKeyID, InvalidClientTokenID,
+		NoCredentialProviders, UnauthorizedOperation, UnrecognizedClientException:
+		return true
+	}
+	return false
+}
+
 // ReasonForError returns the HTTP status for a particular error.
 func ReasonForError(err error) int {
 	if t, ok := err.(*EC2Error); ok {
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache provides a read-through cache for AWS Describe calls that are repeated
+// by many reconciles within a short window.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"k8s.io/utils/clock"
+)
+
+// DescribeCache caches the responses of AWS Describe calls for a TTL.
+// Every entry is indexed by the IDs of the resources it contains, so it can be
+// invalidated when one of these resources changes.
+// It is safe for concurrent use.
+type DescribeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	clock   clock.Clock
+	entries map[entryKey]*entry
+}
+
+// entryKey identifies a Describe call made with a session.
+// Sessions are bound to a region and to credentials, so responses are never
+// shared between accounts.
+type entryKey struct {
+	session   interface{}
+	operation string
+	input     string
+}
+
+type entry struct {
+	output      interface{}
+	resourceIDs []string
+	expiresAt   time.Time
+}
+
+// NewDescribeCache returns a cache keeping the Describe responses for the given TTL.
+// A TTL of zero disables the cache.
+func NewDescribeCache(ttl time.Duration) *DescribeCache {
+	return &DescribeCache{
+		ttl:     ttl,
+		clock:   clock.RealClock{},
+		entries: map[entryKey]*entry{},
+	}
+}
+
+// Enabled returns whether the cache keeps any response.
+func (c *DescribeCache) Enabled() bool {
+	return c != nil && c.ttl > 0
+}
+
+// get returns a copy of the cached response of a Describe call, if any.
+func (c *DescribeCache) get(key entryKey) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.clock.Now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return awsutil.CopyOf(e.output), true
+}
+
+// set caches a copy of the response of a Describe call, indexed by the IDs of the resources it contains.
+func (c *DescribeCache) set(key entryKey, output interface{}, resourceIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	c.pruneLocked(now)
+	c.entries[key] = &entry{
+		output:      awsutil.CopyOf(output),
+		resourceIDs: resourceIDs,
+		expiresAt:   now.Add(c.ttl),
+	}
+}
+
+// Invalidate drops the cached responses that contain any of the given resources.
+func (c *DescribeCache) Invalidate(resourceIDs ...string) {
+	if !c.Enabled() || len(resourceIDs) == 0 {
+		return
+	}
+
+	ids := make(map[string]struct{}, len(resourceIDs))
+	for _, id := range resourceIDs {
+		ids[id] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range c.entries {
+		for _, id := range e.resourceIDs {
+			if _, ok := ids[id]; ok {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// invalidateOperation drops all the cached responses of an operation made with a session,
+// for changes that may add resources to the responses, such as the creation of a resource.
+func (c *DescribeCache) invalidateOperation(session interface{}, operation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.session == session && key.operation == operation {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *DescribeCache) pruneLocked(now time.Time) {
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+const (
+	describeInstancesOperation = "DescribeInstances"
+	describeSubnetsOperation   = "DescribeSubnets"
+)
+
+var ec2Cache = struct {
+	mu    sync.RWMutex
+	cache *DescribeCache
+}{
+	cache: NewDescribeCache(0),
+}
+
+// SetEC2DescribeCacheTTL sets how long the responses of DescribeInstances and DescribeSubnets
+// are cached. A TTL of zero, the default, disables the cache.
+// It should be called before the controllers are started.
+func SetEC2DescribeCacheTTL(ttl time.Duration) {
+	ec2Cache.mu.Lock()
+	defer ec2Cache.mu.Unlock()
+
+	ec2Cache.cache = NewDescribeCache(ttl)
+}
+
+// InvalidateEC2Resources drops the cached EC2 responses containing any of the given
+// resources, such as instances, network interfaces or subnets.
+func InvalidateEC2Resources(resourceIDs ...string) {
+	getEC2Cache().Invalidate(resourceIDs...)
+}
+
+func getEC2Cache() *DescribeCache {
+	ec2Cache.mu.RLock()
+	defer ec2Cache.mu.RUnlock()
+
+	return ec2Cache.cache
+}
+
+// EC2Client is an EC2 API client serving DescribeInstances and DescribeSubnets from the cache.
+// The cached responses are invalidated by the calls changing the instances or the subnets.
+type EC2Client struct {
+	ec2iface.EC2API
+
+	session interface{}
+	cache   *DescribeCache
+}
+
+// NewEC2Client wraps an EC2 API client of a session with the EC2 Describe cache.
+// The client is returned unchanged when the cache is disabled.
+func NewEC2Client(client ec2iface.EC2API, session interface{}) ec2iface.EC2API {
+	c := getEC2Cache()
+	if !c.Enabled() {
+		return client
+	}
+	return &EC2Client{
+		EC2API:  client,
+		session: session,
+		cache:   c,
+	}
+}
+
+// DescribeInstancesWithContext returns the cached response of a DescribeInstances call, or calls the API.
+func (c *EC2Client) DescribeInstancesWithContext(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.Option) (*ec2.DescribeInstancesOutput, error) {
+	key := entryKey{session: c.session, operation: describeInstancesOperation, input: input.String()}
+	if out, ok := c.cache.get(key); ok {
+		return out.(*ec2.DescribeInstancesOutput), nil
+	}
+
+	out, err := c.EC2API.DescribeInstancesWithContext(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := aws.StringValueSlice(input.InstanceIds)
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			ids = append(ids, aws.StringValue(instance.InstanceId))
+			for _, eni := range instance.NetworkInterfaces {
+				ids = append(ids, aws.StringValue(eni.NetworkInterfaceId))
+			}
+		}
+	}
+	c.cache.set(key, out, ids)
+	return out, nil
+}
+
+// DescribeSubnetsWithContext returns the cached response of a DescribeSubnets call, or calls the API.
+func (c *EC2Client) DescribeSubnetsWithContext(ctx aws.Context, input *ec2.DescribeSubnetsInput, opts ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	key := entryKey{session: c.session, operation: describeSubnetsOperation, input: input.String()}
+	if out, ok := c.cache.get(key); ok {
+		return out.(*ec2.DescribeSubnetsOutput), nil
+	}
+
+	out, err := c.EC2API.DescribeSubnetsWithContext(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := aws.StringValueSlice(input.SubnetIds)
+	for _, subnet := range out.Subnets {
+		ids = append(ids, aws.StringValue(subnet.SubnetId))
+	}
+	c.cache.set(key, out, ids)
+	return out, nil
+}
+
+// RunInstancesWithContext runs instances and invalidates the cached DescribeInstances responses.
+func (c *EC2Client) RunInstancesWithContext(ctx aws.Context, input *ec2.RunInstancesInput, opts ...request.Option) (*ec2.Reservation, error) {
+	defer c.cache.invalidateOperation(c.session, describeInstancesOperation)
+	return c.EC2API.RunInstancesWithContext(ctx, input, opts...)
+}
+
+// TerminateInstancesWithContext terminates instances and invalidates their cached responses.
+func (c *EC2Client) TerminateInstancesWithContext(ctx aws.Context, input *ec2.TerminateInstancesInput, opts ...request.Option) (*ec2.TerminateInstancesOutput, error) {
+	defer c.cache.Invalidate(aws.StringValueSlice(input.InstanceIds)...)
+	return c.EC2API.TerminateInstancesWithContext(ctx, input, opts...)
+}
+
+// ModifyInstanceMetadataOptionsWithContext modifies the metadata options of an instance and invalidates its cached responses.
+func (c *EC2Client) ModifyInstanceMetadataOptionsWithContext(ctx aws.Context, input *ec2.ModifyInstanceMetadataOptionsInput, opts ...request.Option) (*ec2.ModifyInstanceMetadataOptionsOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.InstanceId))
+	return c.EC2API.ModifyInstanceMetadataOptionsWithContext(ctx, input, opts...)
+}
+
+// ModifyInstanceAttributeWithContext modifies an attribute of an instance and invalidates its cached responses.
+func (c *EC2Client) ModifyInstanceAttributeWithContext(ctx aws.Context, input *ec2.ModifyInstanceAttributeInput, opts ...request.Option) (*ec2.ModifyInstanceAttributeOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.InstanceId))
+	return c.EC2API.ModifyInstanceAttributeWithContext(ctx, input, opts...)
+}
+
+// StopInstancesWithContext stops instances and invalidates their cached responses.
+func (c *EC2Client) StopInstancesWithContext(ctx aws.Context, input *ec2.StopInstancesInput, opts ...request.Option) (*ec2.StopInstancesOutput, error) {
+	defer c.cache.Invalidate(aws.StringValueSlice(input.InstanceIds)...)
+	return c.EC2API.StopInstancesWithContext(ctx, input, opts...)
+}
+
+// StartInstancesWithContext starts instances and invalidates their cached responses.
+func (c *EC2Client) StartInstancesWithContext(ctx aws.Context, input *ec2.StartInstancesInput, opts ...request.Option) (*ec2.StartInstancesOutput, error) {
+	defer c.cache.Invalidate(aws.StringValueSlice(input.InstanceIds)...)
+	return c.EC2API.StartInstancesWithContext(ctx, input, opts...)
+}
+
+// AssociateAddressWithContext associates an Elastic IP address with an instance or a network interface and
+// invalidates the cached responses of the instance.
+func (c *EC2Client) AssociateAddressWithContext(ctx aws.Context, input *ec2.AssociateAddressInput, opts ...request.Option) (*ec2.AssociateAddressOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.InstanceId), aws.StringValue(input.NetworkInterfaceId))
+	return c.EC2API.AssociateAddressWithContext(ctx, input, opts...)
+}
+
+// ModifyNetworkInterfaceAttributeWithContext modifies a network interface and invalidates the cached responses of its instance.
+func (c *EC2Client) ModifyNetworkInterfaceAttributeWithContext(ctx aws.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...request.Option) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.NetworkInterfaceId))
+	return c.EC2API.ModifyNetworkInterfaceAttributeWithContext(ctx, input, opts...)
+}
+
+// CreateSubnetWithContext creates a subnet and invalidates the cached DescribeSubnets responses.
+func (c *EC2Client) CreateSubnetWithContext(ctx aws.Context, input *ec2.CreateSubnetInput, opts ...request.Option) (*ec2.CreateSubnetOutput, error) {
+	defer c.cache.invalidateOperation(c.session, describeSubnetsOperation)
+	return c.EC2API.CreateSubnetWithContext(ctx, input, opts...)
+}
+
+// DeleteSubnetWithContext deletes a subnet and invalidates its cached responses.
+func (c *EC2Client) DeleteSubnetWithContext(ctx aws.Context, input *ec2.DeleteSubnetInput, opts ...request.Option) (*ec2.DeleteSubnetOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.SubnetId))
+	return c.EC2API.DeleteSubnetWithContext(ctx, input, opts...)
+}
+
+// ModifySubnetAttributeWithContext modifies a subnet and invalidates its cached responses.
+func (c *EC2Client) ModifySubnetAttributeWithContext(ctx aws.Context, input *ec2.ModifySubnetAttributeInput, opts ...request.Option) (*ec2.ModifySubnetAttributeOutput, error) {
+	defer c.cache.Invalidate(aws.StringValue(input.SubnetId))
+	return c.EC2API.ModifySubnetAttributeWithContext(ctx, input, opts...)
+}
+
+// CreateTagsWithContext tags resources and invalidates the cached responses of their operations,
+// as the tags are used to filter the Describe calls.
+func (c *EC2Client) CreateTagsWithContext(ctx aws.Context, input *ec2.CreateTagsInput, opts ...request.Option) (*ec2.CreateTagsOutput, error) {
+	defer c.invalidateTagged(aws.StringValueSlice(input.Resources))
+	return c.EC2API.CreateTagsWithContext(ctx, input, opts...)
+}
+
+// DeleteTagsWithContext untags resources and invalidates their cached responses.
+func (c *EC2Client) DeleteTagsWithContext(ctx aws.Context, input *ec2.DeleteTagsInput, opts ...request.Option) (*ec2.DeleteTagsOutput, error) {
+	defer c.cache.Invalidate(aws.StringValueSlice(input.Resources)...)
+	return c.EC2API.DeleteTagsWithContext(ctx, input, opts...)
+}
+
+func (c *EC2Client) invalidateTagged(resourceIDs []string) {
+	for _, id := range resourceIDs {
+		switch {
+		case strings.HasPrefix(id, "i-"):
+			c.cache.invalidateOperation(c.session, describeInstancesOperation)
+		case strings.HasPrefix(id, "subnet-"):
+			c.cache.invalidateOperation(c.session, describeSubnetsOperation)
+		}
+	}
+}
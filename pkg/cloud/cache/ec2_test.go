@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestEC2ClientDescribeInstances(t *testing.T) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("tag:Name"), Values: []*string{aws.String("machine")}}},
+	}
+	output := &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{
+			Instances: []*ec2.Instance{{
+				InstanceId:        aws.String("i-1"),
+				NetworkInterfaces: []*ec2.InstanceNetworkInterface{{NetworkInterfaceId: aws.String("eni-1")}},
+			}},
+		}},
+	}
+
+	testCases := []struct {
+		name          string
+		between       func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock)
+		expectedCalls int
+	}{
+		{
+			name:          "serves repeated calls from the cache",
+			between:       func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {},
+			expectedCalls: 1,
+		},
+		{
+			name: "calls the API once the response expired",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				clock.Step(time.Minute)
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once the instance is invalidated",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				c.cache.Invalidate("i-1")
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once a network interface of the instance is modified",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), gomock.Any()).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+				_, err := c.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), &ec2.ModifyNetworkInterfaceAttributeInput{NetworkInterfaceId: aws.String("eni-1")})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once an attribute of the instance is modified",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Any()).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+				_, err := c.ModifyInstanceAttributeWithContext(context.TODO(), &ec2.ModifyInstanceAttributeInput{InstanceId: aws.String("i-1")})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once the instance is stopped",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.StopInstancesWithContext(context.TODO(), gomock.Any()).Return(&ec2.StopInstancesOutput{}, nil)
+				_, err := c.StopInstancesWithContext(context.TODO(), &ec2.StopInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once the instance is started",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.StartInstancesWithContext(context.TODO(), gomock.Any()).Return(&ec2.StartInstancesOutput{}, nil)
+				_, err := c.StartInstancesWithContext(context.TODO(), &ec2.StartInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once an address is associated with the instance",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.AssociateAddressWithContext(context.TODO(), gomock.Any()).Return(&ec2.AssociateAddressOutput{}, nil)
+				_, err := c.AssociateAddressWithContext(context.TODO(), &ec2.AssociateAddressInput{InstanceId: aws.String("i-1")})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once an address is associated with a network interface of the instance",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.AssociateAddressWithContext(context.TODO(), gomock.Any()).Return(&ec2.AssociateAddressOutput{}, nil)
+				_, err := c.AssociateAddressWithContext(context.TODO(), &ec2.AssociateAddressInput{NetworkInterfaceId: aws.String("eni-1")})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "calls the API once an instance is run",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				m.RunInstancesWithContext(context.TODO(), gomock.Any()).Return(&ec2.Reservation{}, nil)
+				_, err := c.RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "serves the cached response when another instance is invalidated",
+			between: func(c *EC2Client, m *mocks.MockEC2APIMockRecorder, clock *clocktesting.FakeClock) {
+				c.cache.Invalidate("i-2")
+			},
+			expectedCalls: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeInstancesWithContext(context.TODO(), gomock.Eq(input)).Return(output, nil).Times(tc.expectedCalls)
+
+			clock := clocktesting.NewFakeClock(time.Now())
+			cache := NewDescribeCache(30 * time.Second)
+			cache.clock = clock
+			c := &EC2Client{EC2API: ec2Mock, session: "session", cache: cache}
+
+			out, err := c.DescribeInstancesWithContext(context.TODO(), input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(out).To(Equal(output))
+
+			tc.between(c, ec2Mock.EXPECT(), clock)
+
+			out, err = c.DescribeInstancesWithContext(context.TODO(), input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(out).To(Equal(output))
+			if tc.expectedCalls == 1 {
+				// Cached responses are copies, so callers cannot modify the cache.
+				g.Expect(out).NotTo(BeIdenticalTo(output))
+			}
+		})
+	}
+}
+
+func TestEC2ClientDescribeSubnets(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	input := &ec2.DescribeSubnetsInput{SubnetIds: []*string{aws.String("subnet-1")}}
+	output := &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1")}}}
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), gomock.Eq(input)).Return(output, nil).Times(2)
+	ec2Mock.EXPECT().ModifySubnetAttributeWithContext(context.TODO(), gomock.Any()).Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+
+	c := &EC2Client{EC2API: ec2Mock, session: "session", cache: NewDescribeCache(time.Minute)}
+
+	for i := 0; i < 3; i++ {
+		_, err := c.DescribeSubnetsWithContext(context.TODO(), input)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	_, err := c.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{SubnetId: aws.String("subnet-1")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out, err := c.DescribeSubnetsWithContext(context.TODO(), input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(out).To(Equal(output))
+}
+
+func TestNewEC2ClientDisabled(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	g.Expect(NewEC2Client(ec2Mock, "session")).To(BeIdenticalTo(ec2Mock))
+}
@@ -105,14 +105,14 @@ func TaintsToSDK(taints expinfrav1.Taints) ([]*eks.Taint, error) {
 func TaintsFromSDK(taints []*eks.Taint) (expinfrav1.Taints, error) {
 	converted := expinfrav1.Taints{}
 	for _, taint := range taints {
-		convertedEffect, err := TaintEffectFromSDK(*taint.Effect)
+		convertedEffect, err := TaintEffectFromSDK(aws.StringValue(taint.Effect))
 		if err != nil {
-			return nil, fmt.Errorf("converting taint effect %s: %w", *taint.Effect, err)
+			return nil, fmt.Errorf("converting taint effect %s: %w", aws.StringValue(taint.Effect), err)
 		}
 		converted = append(converted, expinfrav1.Taint{
 			Effect: convertedEffect,
-			Key:    *taint.Key,
-			Value:  *taint.Value,
+			Key:    aws.StringValue(taint.Key),
+			Value:  aws.StringValue(taint.Value),
 		})
 	}
 
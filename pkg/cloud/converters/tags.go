@@ -19,6 +19,7 @@ package converters
 import (
 	"sort"
 
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -26,7 +27,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
-	"github.com/aws/aws-sdk-go/service/ssm"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 )
@@ -151,12 +151,12 @@ func MapToSecretsManagerTags(src infrav1.Tags) []*secretsmanager.Tag {
 	return tags
 }
 
-// MapToSSMTags converts a infrav1.Tags to a []*ssm.Tag.
-func MapToSSMTags(src infrav1.Tags) []*ssm.Tag {
-	tags := make([]*ssm.Tag, 0, len(src))
+// MapToSSMTags converts a infrav1.Tags to a []ssmtypes.Tag of the AWS SDK for Go v2.
+func MapToSSMTags(src infrav1.Tags) []ssmtypes.Tag {
+	tags := make([]ssmtypes.Tag, 0, len(src))
 
 	for k, v := range src {
-		tag := &ssm.Tag{
+		tag := ssmtypes.Tag{
 			Key:   aws.String(k),
 			Value: aws.String(v),
 		}
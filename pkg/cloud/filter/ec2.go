@@ -145,6 +145,39 @@ func (ec2Filters) VPCStates(states ...string) *ec2.Filter {
 	}
 }
 
+// InstanceTypes returns a filter based on the list of instance types passed in.
+func (ec2Filters) InstanceTypes(instanceTypes ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("instance-type"),
+		Values: aws.StringSlice(instanceTypes),
+	}
+}
+
+// SubnetSelector returns the filters selecting the subnets of the selector, by their tags and availability zones.
+func (ec2Filters) SubnetSelector(selector *infrav1.SubnetSelector) []*ec2.Filter {
+	filters := make([]*ec2.Filter, 0, len(selector.Tags)+1)
+	for _, tag := range selector.Tags {
+		if len(tag.Values) == 0 {
+			filters = append(filters, &ec2.Filter{
+				Name:   aws.String(filterNameTagKey),
+				Values: aws.StringSlice([]string{tag.Key}),
+			})
+			continue
+		}
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String(fmt.Sprintf("tag:%s", tag.Key)),
+			Values: aws.StringSlice(tag.Values),
+		})
+	}
+	if len(selector.AvailabilityZones) > 0 {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String(filterAvailabilityZone),
+			Values: aws.StringSlice(selector.AvailabilityZones),
+		})
+	}
+	return filters
+}
+
 // SubnetStates returns a filter based on the list of states passed in.
 func (ec2Filters) SubnetStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// HTTPClient returns the HTTP client reaching the AWS APIs through the proxy of an identity and trusting
+// the certificates of its CA bundle in addition to the system ones. It returns nil when the identity has
+// no proxy configuration, in which case the default HTTP client of the AWS SDK is used, which honours
+// the HTTPS_PROXY and NO_PROXY environment variables.
+func HTTPClient(proxy *infrav1.ProxyConfig) (*http.Client, error) {
+	if proxy == nil || (proxy.URL == "" && proxy.CABundle == "") {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy.URL != "" {
+		proxyURL, err := url.Parse(proxy.URL)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse proxy URL")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if proxy.CABundle != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(proxy.CABundle)) {
+			return nil, errors.New("failed to parse CA bundle: no PEM encoded certificates found")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    rootCAs,
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestHTTPClient(t *testing.T) {
+	t.Run("uses the default HTTP client without proxy configuration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		httpClient, err := HTTPClient(&infrav1.ProxyConfig{})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(httpClient).To(BeNil())
+	})
+
+	t.Run("sends the requests through the proxy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var proxiedHost string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxiedHost = r.Host
+		}))
+		defer proxy.Close()
+
+		httpClient, err := HTTPClient(&infrav1.ProxyConfig{URL: proxy.URL})
+		g.Expect(err).NotTo(HaveOccurred())
+		resp, err := httpClient.Get("http://ec2.us-east-1.amazonaws.com/")
+		g.Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		g.Expect(proxiedHost).To(Equal("ec2.us-east-1.amazonaws.com"))
+	})
+
+	t.Run("trusts the CA bundle", func(t *testing.T) {
+		g := NewWithT(t)
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		httpClient, err := HTTPClient(&infrav1.ProxyConfig{CABundle: string(caBundle)})
+		g.Expect(err).NotTo(HaveOccurred())
+		resp, err := httpClient.Get(server.URL)
+		g.Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+	})
+
+	t.Run("fails with an invalid CA bundle", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := HTTPClient(&infrav1.ProxyConfig{CABundle: "not a certificate"})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
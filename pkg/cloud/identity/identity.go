@@ -21,19 +21,105 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/gob"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	corev1 "k8s.io/api/core/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 )
 
+const (
+	// maxSourceIdentityLength is the maximum length of the source identity of a role session.
+	maxSourceIdentityLength = 64
+	// sourceIdentityHashLength is the length of the hash suffixing the source identities which are too long.
+	sourceIdentityHashLength = 16
+	// staticCredentialsExpiryWindow is how long before their expiration the temporary credentials of a static
+	// identity are refreshed.
+	staticCredentialsExpiryWindow = time.Minute
+)
+
+// stsEndpointOptions forces the use of the FIPS and dual-stack endpoints of STS.
+var stsEndpointOptions = struct {
+	mu           sync.RWMutex
+	useFIPS      bool
+	useDualStack bool
+}{}
+
+// SetSTSEndpointOptions forces the use of the FIPS and the dual-stack endpoints of STS to assume the
+// roles of the identities. It only affects the credentials retrieved afterwards.
+func SetSTSEndpointOptions(useFIPS, useDualStack bool) {
+	stsEndpointOptions.mu.Lock()
+	defer stsEndpointOptions.mu.Unlock()
+	stsEndpointOptions.useFIPS = useFIPS
+	stsEndpointOptions.useDualStack = useDualStack
+}
+
+const (
+	// DefaultRolesAnywhereSigningHelper is the default IAM Roles Anywhere credential helper run by the controllers.
+	DefaultRolesAnywhereSigningHelper = "aws_signing_helper"
+	// DefaultWebIdentityFilesDir is the default directory the files of the AWSClusterWebIdentities must be in.
+	DefaultWebIdentityFilesDir = "/var/run/secrets/capa"
+)
+
+// webIdentityOptions configures the credential helper run for, and the files readable by, the AWSClusterWebIdentities.
+var webIdentityOptions = struct {
+	mu            sync.RWMutex
+	signingHelper string
+	filesDir      string
+}{
+	signingHelper: DefaultRolesAnywhereSigningHelper,
+	filesDir:      DefaultWebIdentityFilesDir,
+}
+
+// SetWebIdentityOptions sets the IAM Roles Anywhere credential helper run by the controllers, and the directory the
+// token, certificate and private key files of the AWSClusterWebIdentities must be in.
+func SetWebIdentityOptions(signingHelper, filesDir string) error {
+	if signingHelper == "" {
+		return fmt.Errorf("the IAM Roles Anywhere credential helper can't be empty")
+	}
+	if !filepath.IsAbs(filesDir) {
+		return fmt.Errorf("the directory of the web identity files must be an absolute path, got %q", filesDir)
+	}
+	webIdentityOptions.mu.Lock()
+	defer webIdentityOptions.mu.Unlock()
+	webIdentityOptions.signingHelper = signingHelper
+	webIdentityOptions.filesDir = filepath.Clean(filesDir)
+	return nil
+}
+
+// validateWebIdentityFile returns an error if a file of an AWSClusterWebIdentity is not in the directory of the
+// web identity files, so that the identities can't read any other file of the controllers.
+func validateWebIdentityFile(name string) error {
+	webIdentityOptions.mu.RLock()
+	dir := webIdentityOptions.filesDir
+	webIdentityOptions.mu.RUnlock()
+
+	if !filepath.IsAbs(name) || slices.Contains(strings.Split(name, "/"), "..") {
+		return fmt.Errorf("web identity file %q must be an absolute path without \"..\"", name)
+	}
+	if rel, err := filepath.Rel(dir, filepath.Clean(name)); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("web identity file %q is not in the directory %q", name, dir)
+	}
+	return nil
+}
+
 // AWSPrincipalTypeProvider defines the interface for AWS Principal Type Provider.
 type AWSPrincipalTypeProvider interface {
 	credentials.Provider
@@ -44,18 +130,15 @@ type AWSPrincipalTypeProvider interface {
 }
 
 // NewAWSStaticPrincipalTypeProvider will create a new AWSStaticPrincipalTypeProvider from a given AWSClusterStaticIdentity.
-func NewAWSStaticPrincipalTypeProvider(identity *infrav1.AWSClusterStaticIdentity, secret *corev1.Secret) *AWSStaticPrincipalTypeProvider {
-	accessKeyID := string(secret.Data["AccessKeyID"])
-	secretAccessKey := string(secret.Data["SecretAccessKey"])
-	sessionToken := string(secret.Data["SessionToken"])
-
-	return &AWSStaticPrincipalTypeProvider{
-		Principal:       identity,
-		credentials:     credentials.NewStaticCredentials(accessKeyID, secretAccessKey, sessionToken),
-		AccessKeyID:     accessKeyID,
-		SecretAccessKey: secretAccessKey,
-		SessionToken:    sessionToken,
+// When refreshSecret is not nil, the temporary credentials of the secret are refreshed from the secret it returns once they
+// expire.
+func NewAWSStaticPrincipalTypeProvider(identity *infrav1.AWSClusterStaticIdentity, secret *corev1.Secret, refreshSecret func() (*corev1.Secret, error)) *AWSStaticPrincipalTypeProvider {
+	provider := &AWSStaticPrincipalTypeProvider{
+		Principal:     identity,
+		refreshSecret: refreshSecret,
 	}
+	provider.setCredentials(secret)
+	return provider
 }
 
 // GetAssumeRoleCredentials will return the Credentials of a given AWSRolePrincipalTypeProvider.
@@ -71,6 +154,13 @@ func GetAssumeRoleCredentials(roleIdentityProvider *AWSRolePrincipalTypeProvider
 			p.Policy = aws.String(roleIdentityProvider.Principal.Spec.InlinePolicy)
 		}
 		p.Duration = time.Duration(roleIdentityProvider.Principal.Spec.DurationSeconds) * time.Second
+		p.Tags = roleIdentityProvider.SessionTags
+		if len(roleIdentityProvider.Principal.Spec.TransitiveTagKeys) > 0 {
+			p.TransitiveTagKeys = aws.StringSlice(roleIdentityProvider.Principal.Spec.TransitiveTagKeys)
+		}
+		if roleIdentityProvider.SourceIdentity != "" {
+			p.SourceIdentity = aws.String(roleIdentityProvider.SourceIdentity)
+		}
 		// For testing
 		if roleIdentityProvider.stsClient != nil {
 			p.Client = roleIdentityProvider.stsClient
@@ -80,15 +170,56 @@ func GetAssumeRoleCredentials(roleIdentityProvider *AWSRolePrincipalTypeProvider
 }
 
 // NewAWSRolePrincipalTypeProvider will create a new AWSRolePrincipalTypeProvider from an AWSClusterRoleIdentity.
-func NewAWSRolePrincipalTypeProvider(identity *infrav1.AWSClusterRoleIdentity, sourceProvider AWSPrincipalTypeProvider, region string, log logger.Wrapper) *AWSRolePrincipalTypeProvider {
-	return &AWSRolePrincipalTypeProvider{
+// The session tags and the source identity of the identity are resolved for the cluster with the given name and namespace.
+func NewAWSRolePrincipalTypeProvider(identity *infrav1.AWSClusterRoleIdentity, sourceProvider AWSPrincipalTypeProvider, region, clusterName, clusterNamespace string, log logger.Wrapper) *AWSRolePrincipalTypeProvider {
+	provider := &AWSRolePrincipalTypeProvider{
 		credentials:    nil,
 		stsClient:      nil,
 		region:         region,
 		Principal:      identity,
+		SessionTags:    sessionTags(identity.Spec.SessionTags, clusterName, clusterNamespace),
 		sourceProvider: sourceProvider,
 		log:            log.WithName("AWSRolePrincipalTypeProvider"),
 	}
+	if identity.Spec.SetSourceIdentity {
+		provider.SourceIdentity = sourceIdentity(clusterName, clusterNamespace)
+	}
+	return provider
+}
+
+// sourceIdentity returns the source identity of the cluster with the given name and namespace, in the form
+// <namespace>.<name>. It is truncated and suffixed with a hash of the namespace and the name when it is
+// longer than the 64 characters allowed by STS.
+func sourceIdentity(clusterName, clusterNamespace string) string {
+	identity := clusterNamespace + "." + clusterName
+	if len(identity) <= maxSourceIdentityLength {
+		return identity
+	}
+
+	suffix := fmt.Sprintf("%x", sha256.Sum256([]byte(identity)))[:sourceIdentityHashLength]
+	return identity[:maxSourceIdentityLength-len(suffix)-1] + "-" + suffix
+}
+
+// sessionTags returns the STS session tags of the given tags, with the values computed for the cluster
+// with the given name and namespace.
+func sessionTags(tags []infrav1.SessionTag, clusterName, clusterNamespace string) []*sts.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	stsTags := make([]*sts.Tag, 0, len(tags))
+	for _, tag := range tags {
+		value := tag.Value
+		switch tag.ValueFrom {
+		case infrav1.SessionTagValueClusterName:
+			value = clusterName
+		case infrav1.SessionTagValueClusterNamespace:
+			value = clusterNamespace
+		}
+		stsTags = append(stsTags, &sts.Tag{Key: aws.String(tag.Key), Value: aws.String(value)})
+	}
+
+	return stsTags
 }
 
 // AWSStaticPrincipalTypeProvider defines the specs for a static AWSPrincipalTypeProvider.
@@ -99,10 +230,19 @@ type AWSStaticPrincipalTypeProvider struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	SessionToken    string
+	// Expiration is the expiration time of temporary credentials, zero for long-term credentials. It is part of
+	// the hash of the provider, so that refreshed temporary credentials get a new session.
+	Expiration time.Time
+
+	mu            sync.Mutex
+	refreshSecret func() (*corev1.Secret, error)
 }
 
 // Hash returns the byte encoded AWSStaticPrincipalTypeProvider.
 func (p *AWSStaticPrincipalTypeProvider) Hash() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	var roleIdentityValue bytes.Buffer
 	err := gob.NewEncoder(&roleIdentityValue).Encode(p)
 	if err != nil {
@@ -112,8 +252,23 @@ func (p *AWSStaticPrincipalTypeProvider) Hash() (string, error) {
 	return string(hash.Sum(roleIdentityValue.Bytes())), nil
 }
 
-// Retrieve returns the credential values for the AWSStaticPrincipalTypeProvider.
+// Retrieve returns the credential values for the AWSStaticPrincipalTypeProvider. Expired temporary credentials are
+// refreshed from the secret of the identity first.
 func (p *AWSStaticPrincipalTypeProvider) Retrieve() (credentials.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.expired() && p.refreshSecret != nil {
+		awsmetrics.RecordCredentialExpiry("AWSClusterStaticIdentity/" + p.Principal.Name)
+		secret, err := p.refreshSecret()
+		if err != nil {
+			return credentials.Value{}, fmt.Errorf("failed to refresh the credentials of AWSClusterStaticIdentity %q: %w", p.Principal.Name, err)
+		}
+		p.setCredentials(secret)
+	}
+	if p.expired() {
+		return credentials.Value{}, fmt.Errorf("the temporary credentials of AWSClusterStaticIdentity %q expired at %s", p.Principal.Name, p.Expiration.Format(time.RFC3339))
+	}
 	return p.credentials.Get()
 }
 
@@ -124,12 +279,40 @@ func (p *AWSStaticPrincipalTypeProvider) Name() string {
 
 // IsExpired checks the expiration state of the AWSStaticPrincipalTypeProvider.
 func (p *AWSStaticPrincipalTypeProvider) IsExpired() bool {
-	return p.credentials.IsExpired()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.expired() || p.credentials.IsExpired()
+}
+
+// expired returns true when the temporary credentials of the provider expire in less than
+// staticCredentialsExpiryWindow.
+func (p *AWSStaticPrincipalTypeProvider) expired() bool {
+	return !p.Expiration.IsZero() && time.Now().Add(staticCredentialsExpiryWindow).After(p.Expiration)
+}
+
+// setCredentials sets the credentials of the provider from the given secret. An invalid expiration time is ignored,
+// so that the credentials are used until AWS rejects them.
+func (p *AWSStaticPrincipalTypeProvider) setCredentials(secret *corev1.Secret) {
+	p.AccessKeyID = string(secret.Data["AccessKeyID"])
+	p.SecretAccessKey = string(secret.Data["SecretAccessKey"])
+	p.SessionToken = string(secret.Data["SessionToken"])
+	p.Expiration = time.Time{}
+	if expiration, err := time.Parse(time.RFC3339, string(secret.Data["Expiration"])); err == nil {
+		p.Expiration = expiration
+	}
+	p.credentials = credentials.NewStaticCredentials(p.AccessKeyID, p.SecretAccessKey, p.SessionToken)
 }
 
 // AWSRolePrincipalTypeProvider defines the specs for a AWSPrincipalTypeProvider with a role.
 type AWSRolePrincipalTypeProvider struct {
-	Principal      *infrav1.AWSClusterRoleIdentity
+	Principal *infrav1.AWSClusterRoleIdentity
+	// SessionTags are the session tags of the identity resolved for the cluster. They are part of the
+	// hash of the provider, so that the clusters sharing the identity don't share their credentials
+	// when the values of the tags differ.
+	SessionTags []*sts.Tag
+	// SourceIdentity is the source identity of the role sessions resolved for the cluster, if any.
+	SourceIdentity string
 	credentials    *credentials.Credentials
 	region         string
 	sourceProvider AWSPrincipalTypeProvider
@@ -137,13 +320,21 @@ type AWSRolePrincipalTypeProvider struct {
 	stsClient      stsiface.STSAPI
 }
 
-// Hash returns the byte encoded AWSRolePrincipalTypeProvider.
+// Hash returns the byte encoded AWSRolePrincipalTypeProvider. It includes the hash of the source provider, so that
+// the role is assumed again when the credentials of the source identity are rotated.
 func (p *AWSRolePrincipalTypeProvider) Hash() (string, error) {
 	var roleIdentityValue bytes.Buffer
 	err := gob.NewEncoder(&roleIdentityValue).Encode(p)
 	if err != nil {
 		return "", err
 	}
+	if p.sourceProvider != nil {
+		sourceHash, err := p.sourceProvider.Hash()
+		if err != nil {
+			return "", err
+		}
+		roleIdentityValue.WriteString(sourceHash)
+	}
 	hash := sha256.New()
 	return string(hash.Sum(roleIdentityValue.Bytes())), nil
 }
@@ -156,7 +347,13 @@ func (p *AWSRolePrincipalTypeProvider) Name() string {
 // Retrieve returns the credential values for the AWSRolePrincipalTypeProvider.
 func (p *AWSRolePrincipalTypeProvider) Retrieve() (credentials.Value, error) {
 	if p.credentials == nil || p.IsExpired() {
-		awsConfig := aws.NewConfig().WithRegion(p.region)
+		if p.credentials != nil {
+			awsmetrics.RecordCredentialExpiry("AWSClusterRoleIdentity/" + p.Principal.Name)
+		}
+		awsConfig, err := stsConfig(p.region, &p.Principal.Spec.AWSClusterIdentitySpec)
+		if err != nil {
+			return credentials.Value{}, err
+		}
 		if p.sourceProvider != nil {
 			sourceCreds, err := p.sourceProvider.Retrieve()
 			if err != nil {
@@ -176,3 +373,139 @@ func (p *AWSRolePrincipalTypeProvider) Retrieve() (credentials.Value, error) {
 func (p *AWSRolePrincipalTypeProvider) IsExpired() bool {
 	return p.credentials.IsExpired()
 }
+
+// NewAWSWebIdentityPrincipalTypeProvider will create a new AWSWebIdentityPrincipalTypeProvider from an AWSClusterWebIdentity.
+// It returns an error if the identity references files outside of the directory of the web identity files.
+func NewAWSWebIdentityPrincipalTypeProvider(identity *infrav1.AWSClusterWebIdentity, region string, log logger.Wrapper) (*AWSWebIdentityPrincipalTypeProvider, error) {
+	files := []string{identity.Spec.TokenFile}
+	if identity.Spec.RolesAnywhere != nil {
+		files = []string{identity.Spec.RolesAnywhere.CertificateFile, identity.Spec.RolesAnywhere.PrivateKeyFile}
+	}
+	for _, file := range files {
+		if err := validateWebIdentityFile(file); err != nil {
+			return nil, fmt.Errorf("invalid AWSClusterWebIdentity %q: %w", identity.Name, err)
+		}
+	}
+
+	return &AWSWebIdentityPrincipalTypeProvider{
+		credentials: nil,
+		stsClient:   nil,
+		region:      region,
+		Principal:   identity,
+		log:         log.WithName("AWSWebIdentityPrincipalTypeProvider"),
+	}, nil
+}
+
+// GetWebIdentityCredentials will return the Credentials of a given AWSWebIdentityPrincipalTypeProvider.
+// Credentials are obtained with sts:AssumeRoleWithWebIdentity when a token file is configured,
+// and with the IAM Roles Anywhere credential helper otherwise.
+func GetWebIdentityCredentials(webIdentityProvider *AWSWebIdentityPrincipalTypeProvider, awsConfig *aws.Config) *credentials.Credentials {
+	spec := webIdentityProvider.Principal.Spec
+	if spec.RolesAnywhere != nil {
+		return processcreds.NewCredentialsCommand(rolesAnywhereCommand(spec))
+	}
+
+	stsClient := webIdentityProvider.stsClient
+	if stsClient == nil {
+		stsClient = sts.New(session.Must(session.NewSession(awsConfig)))
+	}
+	return credentials.NewCredentials(stscreds.NewWebIdentityRoleProviderWithOptions(stsClient, spec.RoleArn, spec.SessionName, stscreds.FetchTokenPath(spec.TokenFile), func(p *stscreds.WebIdentityRoleProvider) {
+		p.Duration = time.Duration(spec.DurationSeconds) * time.Second
+		for _, arn := range spec.PolicyARNs {
+			p.PolicyArns = append(p.PolicyArns, &sts.PolicyDescriptorType{Arn: aws.String(arn)})
+		}
+	}))
+}
+
+// rolesAnywhereCommand builds the credential-process invocation of the IAM Roles Anywhere credential helper
+// configured for the controllers.
+func rolesAnywhereCommand(spec infrav1.AWSClusterWebIdentitySpec) *exec.Cmd {
+	webIdentityOptions.mu.RLock()
+	helper := webIdentityOptions.signingHelper
+	webIdentityOptions.mu.RUnlock()
+
+	args := []string{
+		"credential-process",
+		"--certificate", spec.RolesAnywhere.CertificateFile,
+		"--private-key", spec.RolesAnywhere.PrivateKeyFile,
+		"--trust-anchor-arn", spec.RolesAnywhere.TrustAnchorARN,
+		"--profile-arn", spec.RolesAnywhere.ProfileARN,
+		"--role-arn", spec.RoleArn,
+	}
+	if spec.SessionName != "" {
+		args = append(args, "--role-session-name", spec.SessionName)
+	}
+	if spec.DurationSeconds != 0 {
+		args = append(args, "--session-duration", strconv.Itoa(int(spec.DurationSeconds)))
+	}
+	return exec.Command(helper, args...) //nolint:gosec // The helper is set by a controller flag and its arguments are not run by a shell.
+}
+
+// AWSWebIdentityPrincipalTypeProvider defines the specs for a AWSPrincipalTypeProvider using a web identity
+// token or IAM Roles Anywhere.
+type AWSWebIdentityPrincipalTypeProvider struct {
+	Principal   *infrav1.AWSClusterWebIdentity
+	credentials *credentials.Credentials
+	region      string
+	log         logger.Wrapper
+	stsClient   stsiface.STSAPI
+}
+
+// Hash returns the byte encoded AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Hash() (string, error) {
+	var webIdentityValue bytes.Buffer
+	err := gob.NewEncoder(&webIdentityValue).Encode(p)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	return string(hash.Sum(webIdentityValue.Bytes())), nil
+}
+
+// Name returns the name of the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Name() string {
+	return p.Principal.Name
+}
+
+// Retrieve returns the credential values for the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) Retrieve() (credentials.Value, error) {
+	if p.credentials == nil {
+		awsConfig, err := stsConfig(p.region, &p.Principal.Spec.AWSClusterIdentitySpec)
+		if err != nil {
+			return credentials.Value{}, err
+		}
+		p.credentials = GetWebIdentityCredentials(p, awsConfig)
+	}
+	return p.credentials.Get()
+}
+
+// IsExpired checks the expiration state of the AWSWebIdentityPrincipalTypeProvider.
+func (p *AWSWebIdentityPrincipalTypeProvider) IsExpired() bool {
+	return p.credentials == nil || p.credentials.IsExpired()
+}
+
+// stsConfig returns the configuration of the STS client used to assume the role of an identity,
+// using the STS endpoint and the proxy of the identity if any.
+func stsConfig(region string, spec *infrav1.AWSClusterIdentitySpec) (*aws.Config, error) {
+	awsConfig := aws.NewConfig().WithRegion(region)
+	if endpoint := spec.ServiceEndpoints[sts.EndpointsID]; endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(endpoint)
+	}
+	httpClient, err := HTTPClient(spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if httpClient != nil {
+		awsConfig = awsConfig.WithHTTPClient(httpClient)
+	}
+
+	stsEndpointOptions.mu.RLock()
+	defer stsEndpointOptions.mu.RUnlock()
+	if stsEndpointOptions.useFIPS {
+		awsConfig.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if stsEndpointOptions.useDualStack {
+		awsConfig.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+	return awsConfig, nil
+}
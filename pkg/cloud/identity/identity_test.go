@@ -17,21 +17,29 @@ limitations under the License.
 package identity
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 )
 
 func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
@@ -45,7 +53,7 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 		},
 	}
 
-	staticProvider := NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, secret)
+	staticProvider := NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, secret, nil)
 
 	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
 	roleIdentity := &infrav1.AWSClusterRoleIdentity{
@@ -201,3 +209,464 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSWebIdentityPrincipalTypeProvider(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("web-identity-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	webIdentity := &infrav1.AWSClusterWebIdentity{
+		Spec: infrav1.AWSClusterWebIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:*:iam::*:role/aws-role/webidentityprovider",
+				SessionName:     "web-identity-provider-session",
+				DurationSeconds: 900,
+				PolicyARNs:      []string{"arn:*:iam::*:policy/session-policy"},
+			},
+			TokenFile: tokenFile,
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mock_stsiface.MockSTSAPIMockRecorder)
+		expectErr bool
+		value     credentials.Value
+	}{
+		{
+			name: "Web identity provider successfully retrieves",
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				m.AssumeRoleWithWebIdentityRequest(&sts.AssumeRoleWithWebIdentityInput{
+					RoleArn:          aws.String(webIdentity.Spec.RoleArn),
+					RoleSessionName:  aws.String(webIdentity.Spec.SessionName),
+					WebIdentityToken: aws.String("web-identity-token"),
+					DurationSeconds:  ptr.To[int64](int64(webIdentity.Spec.DurationSeconds)),
+					PolicyArns:       []*sts.PolicyDescriptorType{{Arn: aws.String("arn:*:iam::*:policy/session-policy")}},
+				}).DoAndReturn(assumeRoleWithWebIdentityRequest(&sts.Credentials{
+					AccessKeyId:     aws.String("webAccessKeyId"),
+					SecretAccessKey: aws.String("webSecretAccessKey"),
+					SessionToken:    aws.String("webSessionToken"),
+					Expiration:      aws.Time(time.Now().AddDate(+1, 0, 0)),
+				}, nil))
+			},
+			expectErr: false,
+			value: credentials.Value{
+				AccessKeyID:     "webAccessKeyId",
+				SecretAccessKey: "webSecretAccessKey",
+				SessionToken:    "webSessionToken",
+				ProviderName:    stscreds.WebIdentityProviderName,
+			},
+		},
+		{
+			name: "Web identity provider fails to retrieve when the role cannot be assumed",
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				m.AssumeRoleWithWebIdentityRequest(gomock.Any()).
+					DoAndReturn(assumeRoleWithWebIdentityRequest(nil, errors.New("Not authorized to assume role")))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			provider := &AWSWebIdentityPrincipalTypeProvider{
+				Principal: webIdentity,
+				region:    "us-west-2",
+				stsClient: stsMock,
+			}
+
+			tc.expect(stsMock.EXPECT())
+			value, err := provider.Retrieve()
+			if tc.expectErr {
+				g.Expect(err).ToNot(BeNil())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+
+			if !cmp.Equal(tc.value, value) {
+				t.Fatal("Did not get expected result")
+			}
+		})
+	}
+}
+
+func TestRolesAnywhereCommand(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := infrav1.AWSClusterWebIdentitySpec{
+		AWSRoleSpec: infrav1.AWSRoleSpec{
+			RoleArn:         "arn:aws:iam::123456789012:role/capa",
+			SessionName:     "capa",
+			DurationSeconds: 3600,
+		},
+		RolesAnywhere: &infrav1.RolesAnywhereSpec{
+			TrustAnchorARN:  "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+			ProfileARN:      "arn:aws:rolesanywhere:us-east-1:123456789012:profile/p",
+			CertificateFile: "/var/run/secrets/capa/tls.crt",
+			PrivateKeyFile:  "/var/run/secrets/capa/tls.key",
+		},
+	}
+
+	cmd := rolesAnywhereCommand(spec)
+	g.Expect(cmd.Args).To(Equal([]string{
+		DefaultRolesAnywhereSigningHelper,
+		"credential-process",
+		"--certificate", "/var/run/secrets/capa/tls.crt",
+		"--private-key", "/var/run/secrets/capa/tls.key",
+		"--trust-anchor-arn", "arn:aws:rolesanywhere:us-east-1:123456789012:trust-anchor/ta",
+		"--profile-arn", "arn:aws:rolesanywhere:us-east-1:123456789012:profile/p",
+		"--role-arn", "arn:aws:iam::123456789012:role/capa",
+		"--role-session-name", "capa",
+		"--session-duration", "3600",
+	}))
+
+	g.Expect(SetWebIdentityOptions("/usr/local/bin/aws_signing_helper", DefaultWebIdentityFilesDir)).To(Succeed())
+	defer func() {
+		g.Expect(SetWebIdentityOptions(DefaultRolesAnywhereSigningHelper, DefaultWebIdentityFilesDir)).To(Succeed())
+	}()
+	g.Expect(rolesAnywhereCommand(spec).Path).To(Equal("/usr/local/bin/aws_signing_helper"))
+}
+
+func TestNewAWSWebIdentityPrincipalTypeProviderFiles(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      infrav1.AWSClusterWebIdentitySpec
+		expectErr bool
+	}{
+		{
+			name: "accepts a token file in the web identity files directory",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				TokenFile: "/var/run/secrets/capa/token",
+			},
+		},
+		{
+			name: "accepts IAM Roles Anywhere files in a subdirectory of the web identity files directory",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				RolesAnywhere: &infrav1.RolesAnywhereSpec{
+					CertificateFile: "/var/run/secrets/capa/tls/tls.crt",
+					PrivateKeyFile:  "/var/run/secrets/capa/tls/tls.key",
+				},
+			},
+		},
+		{
+			name: "rejects a token file outside of the web identity files directory",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				TokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			},
+			expectErr: true,
+		},
+		{
+			name: "rejects a relative token file",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				TokenFile: "capa/token",
+			},
+			expectErr: true,
+		},
+		{
+			name: "rejects the web identity files directory itself",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				TokenFile: "/var/run/secrets/capa",
+			},
+			expectErr: true,
+		},
+		{
+			name: "rejects a private key file escaping the web identity files directory",
+			spec: infrav1.AWSClusterWebIdentitySpec{
+				RolesAnywhere: &infrav1.RolesAnywhereSpec{
+					CertificateFile: "/var/run/secrets/capa/tls.crt",
+					PrivateKeyFile:  "/var/run/secrets/capa/../kubernetes.io/serviceaccount/token",
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webIdentity := &infrav1.AWSClusterWebIdentity{Spec: tc.spec}
+			_, err := NewAWSWebIdentityPrincipalTypeProvider(webIdentity, "us-east-1", logger.NewLogger(klog.Background()))
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+// assumeRoleWithWebIdentityRequest returns a mock implementation of AssumeRoleWithWebIdentityRequest
+// whose request completes with the given credentials or error without calling AWS.
+func TestSTSConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		useFIPS          bool
+		useDualStack     bool
+		serviceEndpoints infrav1.ServiceEndpoints
+		expected         string
+	}{
+		{
+			name:     "uses the default endpoint",
+			expected: "https://sts.ap-east-1.amazonaws.com",
+		},
+		{
+			name:     "uses the FIPS endpoint",
+			useFIPS:  true,
+			expected: "https://sts-fips.ap-east-1.amazonaws.com",
+		},
+		{
+			name:         "uses the dual-stack endpoint",
+			useDualStack: true,
+			expected:     "https://sts.ap-east-1.api.aws",
+		},
+		{
+			name:             "uses the endpoint of the identity as is",
+			useFIPS:          true,
+			serviceEndpoints: infrav1.ServiceEndpoints{"sts": "https://sts.example.com"},
+			expected:         "https://sts.example.com",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			SetSTSEndpointOptions(tc.useFIPS, tc.useDualStack)
+			defer SetSTSEndpointOptions(false, false)
+
+			awsConfig, err := stsConfig("ap-east-1", &infrav1.AWSClusterIdentitySpec{ServiceEndpoints: tc.serviceEndpoints})
+			g.Expect(err).NotTo(HaveOccurred())
+			sess, err := session.NewSession(awsConfig)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(sts.New(sess).Endpoint).To(Equal(tc.expected))
+		})
+	}
+}
+
+func assumeRoleWithWebIdentityRequest(creds *sts.Credentials, err error) func(*sts.AssumeRoleWithWebIdentityInput) (*request.Request, *sts.AssumeRoleWithWebIdentityOutput) {
+	return func(*sts.AssumeRoleWithWebIdentityInput) (*request.Request, *sts.AssumeRoleWithWebIdentityOutput) {
+		out := &sts.AssumeRoleWithWebIdentityOutput{Credentials: creds}
+		req := request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{}, nil, out)
+		req.Handlers.Send.PushBack(func(r *request.Request) {
+			r.Error = err
+		})
+		return req, out
+	}
+}
+
+func TestAWSRolePrincipalTypeProviderSessionTags(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleIdentity := &infrav1.AWSClusterRoleIdentity{
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:aws:iam::123456789012:role/capa",
+				SessionName:     "capa",
+				DurationSeconds: 900,
+			},
+			SessionTags: []infrav1.SessionTag{
+				{Key: "team", Value: "platform"},
+				{Key: "cluster", ValueFrom: infrav1.SessionTagValueClusterName},
+				{Key: "namespace", ValueFrom: infrav1.SessionTagValueClusterNamespace},
+			},
+			TransitiveTagKeys: []string{"cluster"},
+			SetSourceIdentity: true,
+		},
+	}
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	stsMock.EXPECT().AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+		RoleArn:         aws.String(roleIdentity.Spec.RoleArn),
+		RoleSessionName: aws.String(roleIdentity.Spec.SessionName),
+		DurationSeconds: ptr.To[int64](900),
+		Tags: []*sts.Tag{
+			{Key: aws.String("team"), Value: aws.String("platform")},
+			{Key: aws.String("cluster"), Value: aws.String("cluster-a")},
+			{Key: aws.String("namespace"), Value: aws.String("team-a")},
+		},
+		TransitiveTagKeys: aws.StringSlice([]string{"cluster"}),
+		SourceIdentity:    aws.String("team-a.cluster-a"),
+	}).Return(&sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("assumedAccessKeyId"),
+			SecretAccessKey: aws.String("assumedSecretAccessKey"),
+			SessionToken:    aws.String("assumedSessionToken"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil)
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"AccessKeyID":     []byte("static-AccessKeyID"),
+			"SecretAccessKey": []byte("static-SecretAccessKey"),
+		},
+	}
+	staticProvider := NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, secret, nil)
+
+	provider := &AWSRolePrincipalTypeProvider{
+		Principal:      roleIdentity,
+		SessionTags:    sessionTags(roleIdentity.Spec.SessionTags, "cluster-a", "team-a"),
+		SourceIdentity: sourceIdentity("cluster-a", "team-a"),
+		region:         "us-west-2",
+		sourceProvider: staticProvider,
+		stsClient:      stsMock,
+	}
+	_, err := provider.Retrieve()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Clusters sharing the identity don't share their credentials when the values of the tags differ.
+	other := &AWSRolePrincipalTypeProvider{
+		Principal:      roleIdentity,
+		SessionTags:    sessionTags(roleIdentity.Spec.SessionTags, "cluster-b", "team-a"),
+		region:         "us-west-2",
+		sourceProvider: staticProvider,
+	}
+	hash, err := provider.Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	otherHash, err := other.Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).NotTo(Equal(otherHash))
+}
+
+func TestSourceIdentity(t *testing.T) {
+	tests := []struct {
+		name             string
+		clusterName      string
+		clusterNamespace string
+		expected         string
+	}{
+		{
+			name:             "short names",
+			clusterName:      "cluster-a",
+			clusterNamespace: "team-a",
+			expected:         "team-a.cluster-a",
+		},
+		{
+			name:             "long names",
+			clusterName:      "a-very-long-cluster-name-which-does-not-fit",
+			clusterNamespace: "a-very-long-namespace",
+			expected:         "a-very-long-namespace.a-very-long-cluster-name--1f445bb332e90ed6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(sourceIdentity(tt.clusterName, tt.clusterNamespace)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestAWSStaticPrincipalTypeProviderTemporaryCredentials(t *testing.T) {
+	newSecret := func(accessKeyID string, expiration time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{
+				"AccessKeyID":     []byte(accessKeyID),
+				"SecretAccessKey": []byte("SecretAccessKey"),
+				"SessionToken":    []byte("SessionToken"),
+				"Expiration":      []byte(expiration.Format(time.RFC3339)),
+			},
+		}
+	}
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		secret        *corev1.Secret
+		refreshSecret func() (*corev1.Secret, error)
+		expectErr     bool
+		expectedKeyID string
+	}{
+		{
+			name:          "uses valid temporary credentials",
+			secret:        newSecret("valid", now.Add(time.Hour)),
+			expectedKeyID: "valid",
+		},
+		{
+			name:   "refreshes expired temporary credentials from the secret",
+			secret: newSecret("expired", now.Add(-time.Hour)),
+			refreshSecret: func() (*corev1.Secret, error) {
+				return newSecret("refreshed", now.Add(time.Hour)), nil
+			},
+			expectedKeyID: "refreshed",
+		},
+		{
+			name:   "refreshes temporary credentials about to expire",
+			secret: newSecret("expiring", now.Add(30*time.Second)),
+			refreshSecret: func() (*corev1.Secret, error) {
+				return newSecret("refreshed", now.Add(time.Hour)), nil
+			},
+			expectedKeyID: "refreshed",
+		},
+		{
+			name:   "fails when the secret still holds expired credentials",
+			secret: newSecret("expired", now.Add(-time.Hour)),
+			refreshSecret: func() (*corev1.Secret, error) {
+				return newSecret("expired", now.Add(-time.Hour)), nil
+			},
+			expectErr: true,
+		},
+		{
+			name:   "fails when the secret can't be read",
+			secret: newSecret("expired", now.Add(-time.Hour)),
+			refreshSecret: func() (*corev1.Secret, error) {
+				return nil, errors.New("not found")
+			},
+			expectErr: true,
+		},
+		{
+			name:      "fails when expired credentials can't be refreshed",
+			secret:    newSecret("expired", now.Add(-time.Hour)),
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			provider := NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, tc.secret, tc.refreshSecret)
+
+			value, err := provider.Retrieve()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(value.AccessKeyID).To(Equal(tc.expectedKeyID))
+			g.Expect(provider.IsExpired()).To(BeFalse())
+		})
+	}
+}
+
+func TestAWSRolePrincipalTypeProviderHashIncludesSource(t *testing.T) {
+	g := NewWithT(t)
+
+	newRoleProvider := func(accessKeyID string) *AWSRolePrincipalTypeProvider {
+		secret := &corev1.Secret{
+			Data: map[string][]byte{
+				"AccessKeyID":     []byte(accessKeyID),
+				"SecretAccessKey": []byte("SecretAccessKey"),
+			},
+		}
+		return &AWSRolePrincipalTypeProvider{
+			Principal:      &infrav1.AWSClusterRoleIdentity{},
+			sourceProvider: NewAWSStaticPrincipalTypeProvider(&infrav1.AWSClusterStaticIdentity{}, secret, nil),
+		}
+	}
+
+	hash, err := newRoleProvider("old").Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	sameHash, err := newRoleProvider("old").Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+	rotatedHash, err := newRoleProvider("rotated").Hash()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(sameHash).To(Equal(hash))
+	g.Expect(rotatedHash).NotTo(Equal(hash))
+}
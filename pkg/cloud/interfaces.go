@@ -60,6 +60,8 @@ type ClusterScoper interface {
 	InfraClusterName() string
 	// Region returns the cluster region.
 	Region() string
+	// Partition returns the partition of the cluster, used to build the ARNs of its AWS resources.
+	Partition() string
 	// KubernetesClusterName is the name of the Kubernetes cluster. For EKS this
 	// will differ to the CAPI cluster name
 	KubernetesClusterName() string
@@ -18,7 +18,11 @@ limitations under the License.
 package logs
 
 import (
+	"fmt"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/smithy-go/logging"
 	"github.com/go-logr/logr"
 )
 
@@ -61,3 +65,24 @@ func (l *logrWrapper) Log(msgs ...interface{}) {
 		l.log.Info(msgs[0].(string), msgs[:1]...)
 	}
 }
+
+// GetAWSClientLogMode will return the log mode of an AWS SDK for Go v2 client, matching the log levels of
+// GetAWSLogLevel.
+func GetAWSClientLogMode(logger logr.Logger) awsv2.ClientLogMode {
+	if logger.V(logWithHTTPBody).Enabled() {
+		return awsv2.LogRequestWithBody | awsv2.LogResponseWithBody | awsv2.LogRetries
+	}
+
+	if logger.V(logWithHTTPHeader).Enabled() {
+		return awsv2.LogRequest | awsv2.LogResponse | awsv2.LogRetries
+	}
+
+	return 0
+}
+
+// NewWrapLogrV2 will create an AWS SDK for Go v2 Logger wrapper.
+func NewWrapLogrV2(logger logr.Logger) logging.Logger {
+	return logging.LoggerFunc(func(classification logging.Classification, format string, v ...interface{}) {
+		logger.Info(fmt.Sprintf(format, v...), "classification", classification)
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricEstimatedHourlyCostKey = "estimated_hourly_cost"
+	metricClusterLabel           = "cluster"
+	metricCurrencyLabel          = "currency"
+)
+
+var estimatedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: metricCAPASubsystem,
+	Name:      metricEstimatedHourlyCostKey,
+	Help:      "Estimated hourly cost of the instances of an AWSMachine or an AWSMachinePool",
+}, []string{metricKindLabel, metricNamespaceLabel, metricNameLabel, metricClusterLabel, metricCurrencyLabel})
+
+func init() {
+	metrics.Registry.MustRegister(estimatedHourlyCost)
+}
+
+// RecordEstimatedHourlyCost records the estimated hourly cost of the instances of the AWSMachine or AWSMachinePool
+// of the specified kind, namespace and name, which belongs to the specified cluster.
+func RecordEstimatedHourlyCost(kind, namespace, name, cluster, currency string, cost float64) {
+	DeleteEstimatedHourlyCost(kind, namespace, name)
+	estimatedHourlyCost.WithLabelValues(kind, namespace, name, cluster, currency).Set(cost)
+}
+
+// DeleteEstimatedHourlyCost deletes the estimated hourly cost recorded for the AWSMachine or AWSMachinePool of the
+// specified kind, namespace and name.
+func DeleteEstimatedHourlyCost(kind, namespace, name string) {
+	estimatedHourlyCost.DeletePartialMatch(prometheus.Labels{metricKindLabel: kind, metricNamespaceLabel: namespace, metricNameLabel: name})
+}
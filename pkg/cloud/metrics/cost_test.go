@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordEstimatedHourlyCost(t *testing.T) {
+	g := NewWithT(t)
+
+	RecordEstimatedHourlyCost("AWSMachine", "default", "machine", "cluster", "USD", 0.096)
+	RecordEstimatedHourlyCost("AWSMachinePool", "default", "machine", "cluster", "USD", 0.5)
+	g.Expect(testutil.ToFloat64(estimatedHourlyCost.WithLabelValues("AWSMachine", "default", "machine", "cluster", "USD"))).To(Equal(0.096))
+
+	RecordEstimatedHourlyCost("AWSMachine", "default", "machine", "cluster", "USD", 0.192)
+	g.Expect(testutil.CollectAndCount(estimatedHourlyCost)).To(Equal(2))
+	g.Expect(testutil.ToFloat64(estimatedHourlyCost.WithLabelValues("AWSMachine", "default", "machine", "cluster", "USD"))).To(Equal(0.192))
+
+	DeleteEstimatedHourlyCost("AWSMachine", "default", "machine")
+	g.Expect(testutil.CollectAndCount(estimatedHourlyCost)).To(Equal(1))
+}
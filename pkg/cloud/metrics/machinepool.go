@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricMachinePoolReplicasKey      = "machinepool_replicas"
+	metricMachinePoolReadyReplicasKey = "machinepool_ready_replicas"
+	metricNamespaceLabel              = "namespace"
+	metricNameLabel                   = "name"
+	metricAvailabilityZoneLabel       = "availability_zone"
+)
+
+var (
+	machinePoolReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricCAPASubsystem,
+		Name:      metricMachinePoolReplicasKey,
+		Help:      "Number of instances of an AWSMachinePool per availability zone",
+	}, []string{metricNamespaceLabel, metricNameLabel, metricAvailabilityZoneLabel})
+	machinePoolReadyReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricCAPASubsystem,
+		Name:      metricMachinePoolReadyReplicasKey,
+		Help:      "Number of instances of an AWSMachinePool whose node is ready per availability zone",
+	}, []string{metricNamespaceLabel, metricNameLabel, metricAvailabilityZoneLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(machinePoolReplicas)
+	metrics.Registry.MustRegister(machinePoolReadyReplicas)
+}
+
+// MachinePoolZoneReplicas is the number of instances and ready instances of a machine pool in an availability zone.
+type MachinePoolZoneReplicas struct {
+	AvailabilityZone string
+	Replicas         int32
+	ReadyReplicas    int32
+}
+
+// RecordMachinePoolReplicas records the number of instances and ready instances in each availability zone of the
+// machine pool with the specified namespace and name, replacing the ones of the zones it no longer has instances in.
+func RecordMachinePoolReplicas(namespace, name string, zones []MachinePoolZoneReplicas) {
+	DeleteMachinePoolReplicas(namespace, name)
+	for _, zone := range zones {
+		machinePoolReplicas.WithLabelValues(namespace, name, zone.AvailabilityZone).Set(float64(zone.Replicas))
+		machinePoolReadyReplicas.WithLabelValues(namespace, name, zone.AvailabilityZone).Set(float64(zone.ReadyReplicas))
+	}
+}
+
+// DeleteMachinePoolReplicas deletes the number of instances recorded for the machine pool with the specified
+// namespace and name.
+func DeleteMachinePoolReplicas(namespace, name string) {
+	labels := prometheus.Labels{metricNamespaceLabel: namespace, metricNameLabel: name}
+	machinePoolReplicas.DeletePartialMatch(labels)
+	machinePoolReadyReplicas.DeletePartialMatch(labels)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordMachinePoolReplicas(t *testing.T) {
+	g := NewWithT(t)
+
+	RecordMachinePoolReplicas("default", "pool", []MachinePoolZoneReplicas{
+		{AvailabilityZone: "us-east-1a", Replicas: 3, ReadyReplicas: 2},
+		{AvailabilityZone: "us-east-1b", Replicas: 1, ReadyReplicas: 1},
+	})
+	RecordMachinePoolReplicas("default", "other", []MachinePoolZoneReplicas{
+		{AvailabilityZone: "us-east-1a", Replicas: 1},
+	})
+
+	g.Expect(testutil.ToFloat64(machinePoolReplicas.WithLabelValues("default", "pool", "us-east-1a"))).To(Equal(float64(3)))
+	g.Expect(testutil.ToFloat64(machinePoolReadyReplicas.WithLabelValues("default", "pool", "us-east-1a"))).To(Equal(float64(2)))
+	g.Expect(testutil.ToFloat64(machinePoolReadyReplicas.WithLabelValues("default", "pool", "us-east-1b"))).To(Equal(float64(1)))
+
+	// the zones the machine pool no longer has instances in are removed.
+	RecordMachinePoolReplicas("default", "pool", []MachinePoolZoneReplicas{
+		{AvailabilityZone: "us-east-1a", Replicas: 4, ReadyReplicas: 4},
+	})
+	g.Expect(testutil.CollectAndCount(machinePoolReplicas)).To(Equal(2))
+
+	DeleteMachinePoolReplicas("default", "pool")
+	g.Expect(testutil.CollectAndCount(machinePoolReplicas)).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(machinePoolReadyReplicas)).To(Equal(1))
+}
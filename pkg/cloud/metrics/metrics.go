@@ -36,6 +36,9 @@ const (
 	metricRequestCountKey    = "api_requests_total"
 	metricRequestDurationKey = "api_request_duration_seconds"
 	metricAPICallRetries     = "api_call_retries"
+	metricRateLimiterWaitKey = "api_rate_limiter_wait_seconds"
+	metricThrottledKey       = "api_throttled_requests_total"
+	metricLimiterLabel       = "limiter"
 	metricServiceLabel       = "service"
 	metricRegionLabel        = "region"
 	metricOperationLabel     = "operation"
@@ -61,12 +64,25 @@ var (
 		Help:      "Number of retries made against an AWS API",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel})
+	awsRateLimiterWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricRateLimiterWaitKey,
+		Help:      "Time AWS requests spent waiting on the client-side rate limiters",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{metricLimiterLabel, metricServiceLabel, metricOperationLabel})
+	awsThrottledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricThrottledKey,
+		Help:      "Total number of AWS request attempts rejected with a throttling error, such as RequestLimitExceeded",
+	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel, metricErrorCodeLabel})
 )
 
 func init() {
 	metrics.Registry.MustRegister(awsRequestCount)
 	metrics.Registry.MustRegister(awsRequestDurationSeconds)
 	metrics.Registry.MustRegister(awsCallRetries)
+	metrics.Registry.MustRegister(awsRateLimiterWaitSeconds)
+	metrics.Registry.MustRegister(awsThrottledRequests)
 }
 
 // CaptureRequestMetrics will monitor and capture request metrics.
@@ -86,6 +102,9 @@ func CaptureRequestMetrics(controller string) func(r *request.Request) {
 			if errorCode, ok = awserrors.Code(r.Error); !ok {
 				errorCode = "internal"
 			}
+			if request.IsErrorThrottle(r.Error) {
+				awsThrottledRequests.WithLabelValues(controller, service, region, operation, errorCode).Inc()
+			}
 		}
 		awsRequestCount.WithLabelValues(controller, service, region, operation, statusCode, errorCode).Inc()
 		awsRequestDurationSeconds.WithLabelValues(controller, service, region, operation).Observe(duration.Seconds())
@@ -93,6 +112,12 @@ func CaptureRequestMetrics(controller string) func(r *request.Request) {
 	}
 }
 
+// ObserveRateLimiterWait records the time a request spent waiting on a client-side rate limiter.
+// The limiter is empty for the rate limiters that aren't shared by the clusters of an identity.
+func ObserveRateLimiterWait(limiter, service, operation string, wait time.Duration) {
+	awsRateLimiterWaitSeconds.WithLabelValues(limiter, service, operation).Observe(wait.Seconds())
+}
+
 func endpointToService(endpoint string) string {
 	endpointURL, err := url.Parse(endpoint)
 	// If possible extract the service name, else return entire endpoint address
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCaptureRequestMetrics(t *testing.T) {
+	testCases := []struct {
+		name              string
+		operation         string
+		statusCode        int
+		err               error
+		expectedErrorCode string
+		expectedThrottles float64
+	}{
+		{
+			name:       "successful request",
+			operation:  "DescribeInstances",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:              "request throttled by AWS",
+			operation:         "DescribeSubnets",
+			statusCode:        http.StatusServiceUnavailable,
+			err:               awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			expectedErrorCode: "RequestLimitExceeded",
+			expectedThrottles: 1,
+		},
+		{
+			name:              "request failed with a non throttling error",
+			operation:         "DescribeVpcs",
+			statusCode:        http.StatusBadRequest,
+			err:               awserr.New("InvalidVpcID.NotFound", "not found", nil),
+			expectedErrorCode: "InvalidVpcID.NotFound",
+		},
+		{
+			name:              "request failed with an internal error",
+			operation:         "DescribeRouteTables",
+			err:               errors.New("connection reset"),
+			expectedErrorCode: "internal",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &request.Request{
+				Config:      aws.Config{Region: aws.String("us-east-1")},
+				ClientInfo:  metadata.ClientInfo{Endpoint: "https://ec2.us-east-1.amazonaws.com"},
+				Operation:   &request.Operation{Name: tc.operation},
+				AttemptTime: time.Now(),
+				Error:       tc.err,
+			}
+			statusCode := "0"
+			if tc.statusCode != 0 {
+				r.HTTPResponse = &http.Response{StatusCode: tc.statusCode}
+				statusCode = strconv.Itoa(tc.statusCode)
+			}
+
+			CaptureRequestMetrics("test")(r)
+
+			g.Expect(testutil.ToFloat64(awsRequestCount.WithLabelValues("test", "ec2", "us-east-1", tc.operation, statusCode, tc.expectedErrorCode))).To(Equal(float64(1)))
+			g.Expect(testutil.ToFloat64(awsThrottledRequests.WithLabelValues("test", "ec2", "us-east-1", tc.operation, tc.expectedErrorCode))).To(Equal(tc.expectedThrottles))
+		})
+	}
+}
+
+func TestObserveRateLimiterWait(t *testing.T) {
+	g := NewWithT(t)
+	before := testutil.CollectAndCount(awsRateLimiterWaitSeconds)
+
+	ObserveRateLimiterWait("", "EC2", "DescribeInstances", time.Millisecond)
+	ObserveRateLimiterWait("", "EC2", "DescribeInstances", time.Millisecond)
+	ObserveRateLimiterWait("eu-west-1-AWSClusterRoleIdentity-tenant-a", "EC2", "DescribeInstances", time.Millisecond)
+	ObserveRateLimiterWait("eu-west-1-AWSClusterRoleIdentity-tenant-b", "EC2", "DescribeInstances", time.Millisecond)
+
+	g.Expect(testutil.CollectAndCount(awsRateLimiterWaitSeconds)).To(Equal(before + 3))
+}
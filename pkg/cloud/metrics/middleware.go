@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+// attemptCountKey is the key of the stack value counting the attempts of a call.
+type attemptCountKey struct{}
+
+// CaptureRequestMetricsMiddleware captures the same metrics as CaptureRequestMetrics for the request attempts
+// of the AWS SDK for Go v2 clients. It is meant to be added to the APIOptions of the clients.
+func CaptureRequestMetricsMiddleware(controller string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		countAttempts := middleware.InitializeMiddlewareFunc("capa/CountRequestAttempts", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			return next.HandleInitialize(middleware.WithStackValue(ctx, attemptCountKey{}, new(int)), in)
+		})
+		if err := stack.Initialize.Add(countAttempts, middleware.Before); err != nil {
+			return err
+		}
+
+		// The middleware is called once per attempt, as it is run after the retry middleware.
+		captureMetrics := middleware.FinalizeMiddlewareFunc("capa/CaptureRequestMetrics", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			attemptTime := time.Now()
+			out, metadata, err := next.HandleFinalize(ctx, in)
+
+			retryCount := 0
+			if count, ok := middleware.GetStackValue(ctx, attemptCountKey{}).(*int); ok {
+				retryCount = *count
+				*count++
+			}
+			operation := awsmiddleware.GetOperationName(ctx)
+			region := awsmiddleware.GetRegion(ctx)
+			service := awsmiddleware.GetServiceID(ctx)
+			if req, ok := in.Request.(*smithyhttp.Request); ok {
+				service = endpointToService(req.URL.String())
+			}
+			statusCode := "0"
+			if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+				statusCode = strconv.Itoa(resp.StatusCode)
+			}
+			errorCode := ""
+			if err != nil {
+				var ok bool
+				if errorCode, ok = awserrors.Code(err); !ok {
+					errorCode = "internal"
+				}
+				if retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == awsv2.TrueTernary {
+					awsThrottledRequests.WithLabelValues(controller, service, region, operation, errorCode).Inc()
+				}
+			}
+			awsRequestCount.WithLabelValues(controller, service, region, operation, statusCode, errorCode).Inc()
+			awsRequestDurationSeconds.WithLabelValues(controller, service, region, operation).Observe(time.Since(attemptTime).Seconds())
+			awsCallRetries.WithLabelValues(controller, service, region, operation).Observe(float64(retryCount))
+
+			return out, metadata, err
+		})
+		return stack.Finalize.Insert(captureMetrics, "Retry", middleware.After)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCaptureRequestMetricsMiddleware(t *testing.T) {
+	g := NewWithT(t)
+
+	// The first attempt is throttled, and the second one succeeds.
+	responses := []*http.Response{
+		{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))},
+		{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))},
+	}
+	client := ssm.New(ssm.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: awsv2.String("https://ssm.us-east-1.amazonaws.com"),
+		Credentials:  awsv2.AnonymousCredentials{},
+		HTTPClient: smithyhttp.ClientDoFunc(func(r *http.Request) (*http.Response, error) {
+			resp := responses[0]
+			responses = responses[1:]
+			resp.Header = http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}}
+			resp.Request = r
+			return resp, nil
+		}),
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+		}),
+		APIOptions: []func(*middleware.Stack) error{CaptureRequestMetricsMiddleware("test-middleware")},
+	})
+
+	_, err := client.DeleteParameter(context.TODO(), &ssm.DeleteParameterInput{Name: awsv2.String("parameter")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(testutil.ToFloat64(awsRequestCount.WithLabelValues("test-middleware", "ssm", "us-east-1", "DeleteParameter", "400", "ThrottlingException"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(awsRequestCount.WithLabelValues("test-middleware", "ssm", "us-east-1", "DeleteParameter", "200", ""))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(awsThrottledRequests.WithLabelValues("test-middleware", "ssm", "us-east-1", "DeleteParameter", "ThrottlingException"))).To(Equal(float64(1)))
+
+	retries := &dto.Metric{}
+	g.Expect(awsCallRetries.WithLabelValues("test-middleware", "ssm", "us-east-1", "DeleteParameter").(prometheus.Histogram).Write(retries)).To(Succeed())
+	g.Expect(retries.GetHistogram().GetSampleCount()).To(Equal(uint64(2)))
+	g.Expect(retries.GetHistogram().GetSampleSum()).To(Equal(float64(1)))
+}
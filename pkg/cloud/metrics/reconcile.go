@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	metricCAPASubsystem        = "capa"
+	metricReconcileKey         = "reconcile_total"
+	metricReconcileFailuresKey = "reconcile_failures_total"
+	metricPendingDeletionKey   = "objects_pending_deletion"
+	metricKindLabel            = "kind"
+	metricResultLabel          = "result"
+	metricReasonLabel          = "reason"
+
+	// ReconcileResultSuccess is the result of a reconcile that succeeded without requeueing.
+	ReconcileResultSuccess = "success"
+	// ReconcileResultRequeue is the result of a reconcile that succeeded and requested a requeue.
+	ReconcileResultRequeue = "requeue"
+	// ReconcileResultError is the result of a reconcile that failed and is retried.
+	ReconcileResultError = "error"
+	// ReconcileResultThrottled is the result of a reconcile that failed because AWS throttled a request.
+	ReconcileResultThrottled = "throttled"
+	// ReconcileResultTerminal is the result of a reconcile that failed and isn't retried, either because of a
+	// terminal error or because the object was marked as failed.
+	ReconcileResultTerminal = "terminal"
+
+	// internalErrorReason is the failure reason of the errors that are not AWS errors.
+	internalErrorReason = "internal"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricCAPASubsystem,
+		Name:      metricReconcileKey,
+		Help:      "Total number of reconciles per kind and result",
+	}, []string{metricKindLabel, metricResultLabel})
+	reconcileFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricCAPASubsystem,
+		Name:      metricReconcileFailuresKey,
+		Help:      "Total number of failed reconciles per kind, result and failure reason, such as the AWS error code",
+	}, []string{metricKindLabel, metricResultLabel, metricReasonLabel})
+	pendingDeletionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("", metricCAPASubsystem, metricPendingDeletionKey),
+		"Number of objects per kind being deleted",
+		[]string{metricKindLabel}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileTotal)
+	metrics.Registry.MustRegister(reconcileFailures)
+}
+
+// RecordReconcile records the outcome of a reconcile of an object of the specified kind.
+func RecordReconcile(kind string, result reconcile.Result, err error) {
+	if err == nil {
+		outcome := ReconcileResultSuccess
+		if result.Requeue || result.RequeueAfter > 0 {
+			outcome = ReconcileResultRequeue
+		}
+		reconcileTotal.WithLabelValues(kind, outcome).Inc()
+		return
+	}
+
+	outcome := ReconcileResultError
+	reason := internalErrorReason
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		reason = awsErr.Code()
+		if request.IsErrorThrottle(awsErr) {
+			outcome = ReconcileResultThrottled
+		}
+	}
+	if errors.Is(err, reconcile.TerminalError(nil)) {
+		outcome = ReconcileResultTerminal
+	}
+	reconcileTotal.WithLabelValues(kind, outcome).Inc()
+	reconcileFailures.WithLabelValues(kind, outcome, reason).Inc()
+}
+
+// RecordTerminalFailure records a reconcile that marked an object of the specified kind as failed, with the
+// failure reason set on the object.
+func RecordTerminalFailure(kind, reason string) {
+	reconcileTotal.WithLabelValues(kind, ReconcileResultTerminal).Inc()
+	reconcileFailures.WithLabelValues(kind, ReconcileResultTerminal, reason).Inc()
+}
+
+// pendingDeletionCollector reports the number of objects of each kind being deleted when the metrics are scraped.
+type pendingDeletionCollector struct {
+	reader client.Reader
+	lists  map[string]func() client.ObjectList
+}
+
+// RegisterPendingDeletionCollector registers a collector reporting the number of objects being deleted for each
+// kind, listed with the reader from the lists returned by the specified functions.
+func RegisterPendingDeletionCollector(reader client.Reader, lists map[string]func() client.ObjectList) error {
+	return metrics.Registry.Register(&pendingDeletionCollector{reader: reader, lists: lists})
+}
+
+// Describe implements prometheus.Collector.
+func (c *pendingDeletionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pendingDeletionDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *pendingDeletionCollector) Collect(ch chan<- prometheus.Metric) {
+	for kind, newList := range c.lists {
+		list := newList()
+		if err := c.reader.List(context.TODO(), list); err != nil {
+			ch <- prometheus.NewInvalidMetric(pendingDeletionDesc, err)
+			continue
+		}
+
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(pendingDeletionDesc, err)
+			continue
+		}
+
+		pending := 0
+		for _, obj := range objs {
+			if accessor, err := meta.Accessor(obj); err == nil && !accessor.GetDeletionTimestamp().IsZero() {
+				pending++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(pendingDeletionDesc, prometheus.GaugeValue, float64(pending), kind)
+	}
+}
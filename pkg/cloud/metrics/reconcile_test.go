@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRecordReconcile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		kind           string
+		result         reconcile.Result
+		err            error
+		expectedResult string
+		expectedReason string
+	}{
+		{
+			name:           "successful reconcile",
+			kind:           "Success",
+			expectedResult: ReconcileResultSuccess,
+		},
+		{
+			name:           "requeued reconcile",
+			kind:           "Requeue",
+			result:         reconcile.Result{RequeueAfter: time.Minute},
+			expectedResult: ReconcileResultRequeue,
+		},
+		{
+			name:           "reconcile failed with an AWS error",
+			kind:           "AWSError",
+			err:            errors.Wrap(awserr.New("InvalidSubnetID.NotFound", "not found", nil), "failed to describe subnets"),
+			expectedResult: ReconcileResultError,
+			expectedReason: "InvalidSubnetID.NotFound",
+		},
+		{
+			name:           "reconcile failed because of throttling",
+			kind:           "Throttled",
+			err:            errors.Wrap(awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil), "failed to describe instances"),
+			expectedResult: ReconcileResultThrottled,
+			expectedReason: "RequestLimitExceeded",
+		},
+		{
+			name:           "reconcile failed with a terminal error",
+			kind:           "Terminal",
+			err:            reconcile.TerminalError(errors.New("invalid spec")),
+			expectedResult: ReconcileResultTerminal,
+			expectedReason: internalErrorReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			RecordReconcile(tc.kind, tc.result, tc.err)
+
+			g.Expect(testutil.ToFloat64(reconcileTotal.WithLabelValues(tc.kind, tc.expectedResult))).To(Equal(float64(1)))
+			if tc.expectedReason == "" {
+				return
+			}
+			g.Expect(testutil.ToFloat64(reconcileFailures.WithLabelValues(tc.kind, tc.expectedResult, tc.expectedReason))).To(Equal(float64(1)))
+		})
+	}
+}
+
+func TestRecordTerminalFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	RecordTerminalFailure("FailedMachine", "UpdateError")
+
+	g.Expect(testutil.ToFloat64(reconcileTotal.WithLabelValues("FailedMachine", ReconcileResultTerminal))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(reconcileFailures.WithLabelValues("FailedMachine", ReconcileResultTerminal, "UpdateError"))).To(Equal(float64(1)))
+}
+
+func TestPendingDeletionCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	deleting := metav1.Now()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default", DeletionTimestamp: &deleting, Finalizers: []string{"test"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "default"}},
+	).Build()
+
+	collector := &pendingDeletionCollector{
+		reader: c,
+		lists: map[string]func() client.ObjectList{
+			"ConfigMap": func() client.ObjectList { return &corev1.ConfigMapList{} },
+			"Secret":    func() client.ObjectList { return &corev1.SecretList{} },
+		},
+	}
+
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP capa_objects_pending_deletion Number of objects per kind being deleted
+# TYPE capa_objects_pending_deletion gauge
+capa_objects_pending_deletion{kind="ConfigMap"} 1
+capa_objects_pending_deletion{kind="Secret"} 0
+`))).To(Succeed())
+}
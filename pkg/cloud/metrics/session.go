@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricSessionCacheLookupsKey       = "session_cache_lookups_total"
+	metricSessionCacheInvalidationsKey = "session_cache_invalidations_total"
+	metricCredentialExpiriesKey        = "credential_expiries_total"
+	metricIdentityLabel                = "identity"
+
+	// SessionCacheHit is the result of a lookup of the session cache which found a valid session.
+	SessionCacheHit = "hit"
+	// SessionCacheMiss is the result of a lookup of the session cache which created a session.
+	SessionCacheMiss = "miss"
+
+	// InvalidationCredentialsChanged is the reason of the invalidation of a session whose identity changed, e.g.
+	// because its credentials were rotated.
+	InvalidationCredentialsChanged = "credentials_changed"
+	// InvalidationConfigChanged is the reason of the invalidation of a session whose endpoints or proxy changed.
+	InvalidationConfigChanged = "config_changed"
+	// InvalidationForced is the reason of the invalidation of a session requested with an annotation.
+	InvalidationForced = "forced"
+	// InvalidationRetrieveFailed is the reason of the invalidation of a session whose credentials can't be retrieved.
+	InvalidationRetrieveFailed = "retrieve_failed"
+)
+
+var (
+	sessionCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricSessionCacheLookupsKey,
+		Help:      "Total number of lookups of the cached AWS sessions per identity and result, hit or miss",
+	}, []string{metricIdentityLabel, metricResultLabel})
+	sessionCacheInvalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricSessionCacheInvalidationsKey,
+		Help:      "Total number of cached AWS sessions invalidated per identity and reason",
+	}, []string{metricIdentityLabel, metricReasonLabel})
+	credentialExpiries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricCredentialExpiriesKey,
+		Help:      "Total number of expired credentials of identities which were retrieved again",
+	}, []string{metricIdentityLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(sessionCacheLookups)
+	metrics.Registry.MustRegister(sessionCacheInvalidations)
+	metrics.Registry.MustRegister(credentialExpiries)
+}
+
+// RecordSessionCacheLookup records a lookup of the session cache for the given identity, e.g.
+// AWSClusterRoleIdentity/tenant-a, with its result.
+func RecordSessionCacheLookup(identity, result string) {
+	sessionCacheLookups.WithLabelValues(identity, result).Inc()
+}
+
+// RecordSessionCacheInvalidation records the invalidation of a cached session of the given identity.
+func RecordSessionCacheInvalidation(identity, reason string) {
+	sessionCacheInvalidations.WithLabelValues(identity, reason).Inc()
+}
+
+// RecordCredentialExpiry records that the credentials of the given identity expired and were retrieved again.
+func RecordCredentialExpiry(identity string) {
+	credentialExpiries.WithLabelValues(identity).Inc()
+}
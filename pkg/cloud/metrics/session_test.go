@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordSessionCache(t *testing.T) {
+	g := NewWithT(t)
+	identity := "AWSClusterRoleIdentity/session-cache-test"
+
+	RecordSessionCacheLookup(identity, SessionCacheMiss)
+	RecordSessionCacheLookup(identity, SessionCacheHit)
+	RecordSessionCacheLookup(identity, SessionCacheHit)
+	RecordSessionCacheInvalidation(identity, InvalidationForced)
+	RecordCredentialExpiry(identity)
+
+	g.Expect(testutil.ToFloat64(sessionCacheLookups.WithLabelValues(identity, SessionCacheHit))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(sessionCacheLookups.WithLabelValues(identity, SessionCacheMiss))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(sessionCacheInvalidations.WithLabelValues(identity, InvalidationForced))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(sessionCacheInvalidations.WithLabelValues(identity, InvalidationCredentialsChanged))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(credentialExpiries.WithLabelValues(identity))).To(Equal(1.0))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestObjectUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   runtime.Object
+		expected string
+	}{
+		{
+			name: "object of a cluster",
+			target: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "team-a",
+					Name:      "machine-a",
+					UID:       "c7d6a0cf-5c0d-4c0c-9b1e-4a7b0e0cbb1e",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-a"},
+				},
+			},
+			expected: "cluster/team-a/cluster-a object/team-a/machine-a uid/c7d6a0cf-5c0d-4c0c-9b1e-4a7b0e0cbb1e",
+		},
+		{
+			name: "object without cluster",
+			target: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "team-a",
+					Name:      "cluster-a",
+					UID:       "0f1d8e36-3c5e-4a4f-8c1b-2d6c1f0a9e7d",
+				},
+			},
+			expected: "object/team-a/cluster-a uid/0f1d8e36-3c5e-4a4f-8c1b-2d6c1f0a9e7d",
+		},
+		{
+			name: "no object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(objectUserAgent(tt.target)).To(Equal(tt.expected))
+
+			r := &request.Request{HTTPRequest: &http.Request{Header: http.Header{"User-Agent": []string{"aws-sdk-go/1.0"}}}}
+			getObjectUserAgentHandler(tt.target).Fn(r)
+			if tt.expected == "" {
+				g.Expect(r.HTTPRequest.Header.Get("User-Agent")).To(Equal("aws-sdk-go/1.0"))
+			} else {
+				g.Expect(r.HTTPRequest.Header.Get("User-Agent")).To(Equal("aws-sdk-go/1.0 " + tt.expected))
+			}
+		})
+	}
+}
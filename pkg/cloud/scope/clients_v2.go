@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	ssmv2 "github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go/aws"
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	awslogs "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/logs"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/version"
+)
+
+// NewSSMClientV2 creates a new AWS SDK for Go v2 SSM API client for a given session.
+func NewSSMClientV2(scopeUser cloud.ScopeUsage, session cloud.Session, logger logger.Wrapper, target runtime.Object) *ssmv2.Client {
+	return ssmv2.NewFromConfig(newConfigV2(scopeUser, session.Session(), ssm.EndpointsID, logger, target))
+}
+
+// newConfigV2 returns the configuration of the AWS SDK for Go v2 clients of a service, sharing the credentials,
+// the endpoint of the service and the HTTP client of the session, so that they are configured like the clients
+// of the AWS SDK for Go created with the session.
+func newConfigV2(scopeUser cloud.ScopeUsage, session awsclient.ConfigProvider, service string, logger logger.Wrapper, target runtime.Object) awsv2.Config {
+	clientConfig := session.ClientConfig(service)
+	cfg := awsv2.Config{
+		Region:        aws.StringValue(clientConfig.Config.Region),
+		Credentials:   newCredentialsProviderV2(clientConfig.Config.Credentials),
+		RetryMode:     awsv2.RetryModeAdaptive,
+		Logger:        awslogs.NewWrapLogrV2(logger.GetLogger()),
+		ClientLogMode: awslogs.GetAWSClientLogMode(logger.GetLogger()),
+		APIOptions: []func(*middleware.Stack) error{
+			awsmiddleware.AddUserAgentKeyValue("aws.cluster.x-k8s.io", version.Get().String()),
+			addObjectUserAgentMiddleware(target),
+			awsmetrics.CaptureRequestMetricsMiddleware(scopeUser.ControllerName()),
+			addRecordAWSPermissionsIssueMiddleware(target),
+			addRequestDeadlineMiddleware,
+		},
+	}
+	// The endpoint resolved by the session is used as is, as it is either a custom service endpoint, or the
+	// endpoint of the service in the region, FIPS and dual-stack endpoints included.
+	if clientConfig.Endpoint != "" {
+		cfg.BaseEndpoint = aws.String(clientConfig.Endpoint)
+	}
+	if clientConfig.SigningRegion != "" {
+		cfg.Region = clientConfig.SigningRegion
+	}
+	if clientConfig.Config.HTTPClient != nil {
+		cfg.HTTPClient = clientConfig.Config.HTTPClient
+	}
+	return cfg
+}
+
+// newCredentialsProviderV2 returns a credentials provider of the AWS SDK for Go v2 retrieving the credentials of
+// a session, so that the clients of both SDKs share the credentials cached by the providers of the identities.
+func newCredentialsProviderV2(creds *credentials.Credentials) awsv2.CredentialsProvider {
+	return awsv2.CredentialsProviderFunc(func(ctx context.Context) (awsv2.Credentials, error) {
+		value, err := creds.GetWithContext(ctx)
+		if err != nil {
+			return awsv2.Credentials{}, err
+		}
+		credsV2 := awsv2.Credentials{
+			AccessKeyID:     value.AccessKeyID,
+			SecretAccessKey: value.SecretAccessKey,
+			SessionToken:    value.SessionToken,
+			Source:          value.ProviderName,
+		}
+		if expires, err := creds.ExpiresAt(); err == nil {
+			credsV2.CanExpire = true
+			credsV2.Expires = expires
+		}
+		return credsV2, nil
+	})
+}
+
+// addObjectUserAgentMiddleware appends the user agent of the object being reconciled to the user agent of the
+// requests, like the handler returned by getObjectUserAgentHandler.
+func addObjectUserAgentMiddleware(target runtime.Object) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Build.Add(middleware.BuildMiddlewareFunc("capa/object-user-agent", func(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (middleware.BuildOutput, middleware.Metadata, error) {
+			if req, ok := in.Request.(*smithyhttp.Request); ok {
+				if userAgent := objectUserAgent(target); userAgent != "" {
+					req.Header.Set("User-Agent", req.Header.Get("User-Agent")+" "+userAgent)
+				}
+			}
+			return next.HandleBuild(ctx, in)
+		}), middleware.After)
+	}
+}
+
+// addRecordAWSPermissionsIssueMiddleware records the calls failing with a credentials or permission issue, like
+// the handler returned by recordAWSPermissionsIssue.
+func addRecordAWSPermissionsIssueMiddleware(target runtime.Object) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("capa/RecordAWSPermissionsIssue", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				switch apiErr.ErrorCode() {
+				case "AuthFailure", "UnauthorizedOperation", "NoCredentialProviders":
+					record.Warnf(target, apiErr.ErrorCode(), "Operation %s failed with a credentials or permission issue", awsmiddleware.GetOperationName(ctx))
+				}
+			}
+			return out, metadata, err
+		}), middleware.After)
+	}
+}
+
+// addRequestDeadlineMiddleware sets the deadline of the calls made with the AWS SDK for Go v2 clients, like the
+// handler added to the sessions by addRequestDeadline.
+func addRequestDeadlineMiddleware(stack *middleware.Stack) error {
+	requestTimeout.mu.RLock()
+	timeout := requestTimeout.timeout
+	requestTimeout.mu.RUnlock()
+	if timeout <= 0 {
+		return nil
+	}
+
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("capa/RequestDeadline", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next.HandleInitialize(ctx, in)
+	}), middleware.Before)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ssmv2 "github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestNewConfigV2(t *testing.T) {
+	g := NewWithT(t)
+
+	var req *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	sess, err := session.NewSession(newSessionConfig("us-east-1", []ServiceEndpoint{
+		{ServiceID: "ssm", URL: server.URL, SigningRegion: "us-west-2"},
+	}).WithCredentials(credentials.NewStaticCredentials("AKID", "SECRET", "")))
+	g.Expect(err).NotTo(HaveOccurred())
+	target := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team-a",
+			Name:      "cluster-a",
+			UID:       "0f1d8e36-3c5e-4a4f-8c1b-2d6c1f0a9e7d",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster-a"},
+		},
+	}
+	cfg := newConfigV2(&ClusterScope{controllerName: "awscluster"}, sess, "ssm", logger.NewLogger(logr.Discard()), target)
+
+	_, err = ssmv2.NewFromConfig(cfg).DeleteParameter(context.TODO(), &ssmv2.DeleteParameterInput{Name: aws.String("parameter")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The call is made to the custom service endpoint of the session, with its credentials and signing region.
+	g.Expect(req).NotTo(BeNil())
+	g.Expect(req.Header.Get("X-Amz-Target")).To(Equal("AmazonSSM.DeleteParameter"))
+	g.Expect(req.Header.Get("Authorization")).To(ContainSubstring("Credential=AKID/"))
+	g.Expect(req.Header.Get("Authorization")).To(ContainSubstring("/us-west-2/ssm/aws4_request"))
+	g.Expect(req.Header.Get("User-Agent")).To(ContainSubstring("aws.cluster.x-k8s.io/"))
+	g.Expect(req.Header.Get("User-Agent")).To(HaveSuffix(" cluster/team-a/cluster-a object/team-a/cluster-a uid/0f1d8e36-3c5e-4a4f-8c1b-2d6c1f0a9e7d"))
+}
+
+func TestRequestDeadlineV2(t *testing.T) {
+	// The SSM API doesn't answer until the call is aborted, or the test is done.
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	defer server.Close()
+	defer close(stop)
+
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+	}{
+		{
+			name:    "aborts the calls exceeding the deadline",
+			timeout: 100 * time.Millisecond,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name: "aborts the calls whose context expires",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			SetRequestTimeout(tc.timeout)
+			defer SetRequestTimeout(0)
+
+			sess, err := session.NewSession(&aws.Config{
+				Region:      aws.String("us-east-1"),
+				Endpoint:    aws.String(server.URL),
+				Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			client := ssmv2.NewFromConfig(newConfigV2(&ClusterScope{}, sess, "ssm", logger.NewLogger(logr.Discard()), nil))
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			done := make(chan error)
+			go func() {
+				_, err := client.DeleteParameter(ctx, &ssmv2.DeleteParameterInput{Name: aws.String("parameter")})
+				done <- err
+			}()
+
+			var callErr error
+			g.Eventually(done, 5*time.Second).Should(Receive(&callErr))
+			g.Expect(errors.Is(callErr, context.DeadlineExceeded)).To(BeTrue())
+		})
+	}
+}
@@ -38,6 +38,8 @@ import (
 
 // ClusterScopeParams defines the input parameters used to create a new Scope.
 type ClusterScopeParams struct {
+	// Context is the context of the reconcile, used to resolve the region of the cluster.
+	Context                      context.Context
 	Client                       client.Client
 	Logger                       *logger.Logger
 	Cluster                      *clusterv1.Cluster
@@ -72,7 +74,16 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		tagUnmanagedNetworkResources: params.TagUnmanagedNetworkResources,
 	}
 
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScope, params.AWSCluster.Spec.Region, params.Endpoints, params.Logger)
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	region, err := resolveRegion(ctx, params.Client, params.AWSCluster.Spec.IdentityRef, params.AWSCluster.Spec.Region, params.AWSCluster.Status.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve the region of the cluster")
+	}
+
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScope, region, params.Endpoints, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -82,6 +93,8 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// The region is recorded after the patch helper is initialized, so that it is persisted with the status.
+	params.AWSCluster.Status.Region = region
 	clusterScope.patchHelper = helper
 	clusterScope.session = session
 	clusterScope.serviceLimiters = serviceLimiters
@@ -103,6 +116,12 @@ type ClusterScope struct {
 	controllerName  string
 
 	tagUnmanagedNetworkResources bool
+
+	// hasWindowsMachines is set when the cluster has Windows machines.
+	hasWindowsMachines bool
+
+	// deletionStepBase, set in the scope of a deletion step, is the AWSCluster the copy of the step was made from.
+	deletionStepBase *infrav1.AWSCluster
 }
 
 // Network returns the cluster network object.
@@ -130,12 +149,25 @@ func (s *ClusterScope) SetSubnets(subnets infrav1.Subnets) {
 	s.AWSCluster.Spec.NetworkSpec.Subnets = subnets
 }
 
-// CNIIngressRules returns the CNI spec ingress rules.
+// CNIIngressRules returns the CNI spec ingress rules, with the rules needed by Windows machines if the cluster has any.
 func (s *ClusterScope) CNIIngressRules() infrav1.CNIIngressRules {
-	if s.AWSCluster.Spec.NetworkSpec.CNI != nil {
-		return s.AWSCluster.Spec.NetworkSpec.CNI.CNIIngressRules
+	if s.AWSCluster.Spec.NetworkSpec.CNI == nil {
+		return infrav1.CNIIngressRules{}
 	}
-	return infrav1.CNIIngressRules{}
+	if s.hasWindowsMachines {
+		return infrav1.WindowsCNIIngressRules(s.AWSCluster.Spec.NetworkSpec.CNI.CNIIngressRules)
+	}
+	return s.AWSCluster.Spec.NetworkSpec.CNI.CNIIngressRules
+}
+
+// SetHasWindowsMachines records whether the cluster has Windows machines.
+func (s *ClusterScope) SetHasWindowsMachines(hasWindowsMachines bool) {
+	s.hasWindowsMachines = hasWindowsMachines
+}
+
+// HasWindowsMachines returns true if the cluster has Windows machines.
+func (s *ClusterScope) HasWindowsMachines() bool {
+	return s.hasWindowsMachines
 }
 
 // SecurityGroupOverrides returns the cluster security group overrides.
@@ -170,7 +202,7 @@ func (s *ClusterScope) InfraClusterName() string {
 
 // Region returns the cluster region.
 func (s *ClusterScope) Region() string {
-	return s.AWSCluster.Spec.Region
+	return AWSClusterRegion(s.AWSCluster)
 }
 
 // KubernetesClusterName is the name of the Kubernetes cluster. For the cluster
@@ -219,6 +251,30 @@ func (s *ClusterScope) Bucket() *infrav1.S3Bucket {
 	return s.AWSCluster.Spec.S3Bucket
 }
 
+// AssociateOIDCProvider returns whether the service account issuer of the cluster should be
+// associated with an IAM OIDC identity provider.
+func (s *ClusterScope) AssociateOIDCProvider() bool {
+	return s.AWSCluster.Spec.AssociateOIDCProvider
+}
+
+// OIDCProvider returns the status of the IAM OIDC identity provider of the cluster.
+func (s *ClusterScope) OIDCProvider() *infrav1.OIDCProviderStatus {
+	return &s.AWSCluster.Status.OIDCProvider
+}
+
+// InstanceProfiles returns the templates of the IAM instance profiles managed for the cluster.
+func (s *ClusterScope) InstanceProfiles() *infrav1.ManagedInstanceProfiles {
+	return s.AWSCluster.Spec.InstanceProfiles
+}
+
+// InstanceProfilesStatus returns the status of the IAM instance profiles managed for the cluster.
+func (s *ClusterScope) InstanceProfilesStatus() *infrav1.ManagedInstanceProfilesStatus {
+	if s.AWSCluster.Status.InstanceProfiles == nil {
+		s.AWSCluster.Status.InstanceProfiles = &infrav1.ManagedInstanceProfilesStatus{}
+	}
+	return s.AWSCluster.Status.InstanceProfiles
+}
+
 // ControlPlaneConfigMapName returns the name of the ConfigMap used to
 // coordinate the bootstrapping of control plane nodes.
 func (s *ClusterScope) ControlPlaneConfigMapName() string {
@@ -234,6 +290,11 @@ func (s *ClusterScope) ListOptionsLabelSelector() client.ListOption {
 
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject() error {
+	// The copy of the AWSCluster of a deletion step is merged into the AWSCluster instead.
+	if s.deletionStepBase != nil {
+		return nil
+	}
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	// A step counter is added to represent progress during the provisioning process (instead we are hiding during the deletion process).
 	applicableConditions := []clusterv1.ConditionType{
@@ -259,6 +320,10 @@ func (s *ClusterScope) PatchObject() error {
 		}
 	}
 
+	if len(s.RequiredTags()) > 0 {
+		applicableConditions = append(applicableConditions, infrav1.RequiredTagsReadyCondition)
+	}
+
 	conditions.SetSummary(s.AWSCluster,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(s.AWSCluster.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -277,9 +342,16 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
+			infrav1.SecondaryCidrsReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.LoadBalancerReadyCondition,
+			infrav1.IAMPermissionsReadyCondition,
+			infrav1.RequiredTagsReadyCondition,
+			infrav1.EBSEncryptionKeyReadyCondition,
+			infrav1.EBSEncryptionByDefaultCondition,
+			infrav1.APIServerHealthCheckReadyCondition,
+			infrav1.StandbyNetworkReadyCondition,
 			infrav1.PrincipalUsageAllowedCondition,
 			infrav1.PrincipalCredentialRetrievedCondition,
 		}})
@@ -299,6 +371,11 @@ func (s *ClusterScope) AdditionalTags() infrav1.Tags {
 	return s.AWSCluster.Spec.AdditionalTags.DeepCopy()
 }
 
+// RequiredTags returns the keys of the tags all the AWS resources of the cluster must carry.
+func (s *ClusterScope) RequiredTags() []string {
+	return s.AWSCluster.Spec.RequiredTags
+}
+
 // APIServerPort returns the APIServerPort to use when creating the load balancer.
 func (s *ClusterScope) APIServerPort() int32 {
 	if s.Cluster.Spec.ClusterNetwork != nil && s.Cluster.Spec.ClusterNetwork.APIServerPort != nil {
@@ -368,6 +445,39 @@ func (s *ClusterScope) SSHKeyName() *string {
 	return s.AWSCluster.Spec.SSHKeyName
 }
 
+// InstanceAccessMode returns how the instances are accessed.
+func (s *ClusterScope) InstanceAccessMode() infrav1.InstanceAccessMode {
+	if s.AWSCluster.Spec.InstanceAccessMode == "" {
+		return infrav1.InstanceAccessModeSSHKey
+	}
+	return s.AWSCluster.Spec.InstanceAccessMode
+}
+
+// DefaultEBSEncryptionKeyARN returns the ARN of the KMS key encrypting the volumes which don't specify one.
+func (s *ClusterScope) DefaultEBSEncryptionKeyARN() string {
+	return s.AWSCluster.Spec.DefaultEBSEncryptionKeyARN
+}
+
+// EBSEncryptionByDefault returns how the EBS encryption by default setting of the account is reconciled.
+func (s *ClusterScope) EBSEncryptionByDefault() infrav1.EBSEncryptionByDefaultMode {
+	return s.AWSCluster.Spec.EBSEncryptionByDefault
+}
+
+// APIServerHealthCheck returns the spec of the Route 53 health check of the control plane endpoint, nil when disabled.
+func (s *ClusterScope) APIServerHealthCheck() *infrav1.Route53HealthCheck {
+	return s.AWSCluster.Spec.APIServerHealthCheck
+}
+
+// APIServerHealthCheckStatus returns the status of the Route 53 health check of the control plane endpoint.
+func (s *ClusterScope) APIServerHealthCheckStatus() *infrav1.Route53HealthCheckStatus {
+	return s.AWSCluster.Status.APIServerHealthCheck
+}
+
+// SetAPIServerHealthCheckStatus sets the status of the Route 53 health check of the control plane endpoint.
+func (s *ClusterScope) SetAPIServerHealthCheckStatus(status *infrav1.Route53HealthCheckStatus) {
+	s.AWSCluster.Status.APIServerHealthCheck = status
+}
+
 // ControllerName returns the name of the controller that
 // created the ClusterScope.
 func (s *ClusterScope) ControllerName() string {
@@ -389,6 +499,11 @@ func (s *ClusterScope) ImageLookupBaseOS() string {
 	return s.AWSCluster.Spec.ImageLookupBaseOS
 }
 
+// SSMParameterPrefix returns the prefix of the SSM parameters storing the bootstrap data of instances.
+func (s *ClusterScope) SSMParameterPrefix() string {
+	return s.AWSCluster.Spec.SSMParameterPrefix
+}
+
 // Partition returns the cluster partition.
 func (s *ClusterScope) Partition() string {
 	if s.AWSCluster.Spec.Partition == "" {
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestClusterScopeCNIIngressRules(t *testing.T) {
+	vxlanRule := infrav1.CNIIngressRule{
+		Description: "vxlan (calico)",
+		Protocol:    infrav1.SecurityGroupProtocolUDP,
+		FromPort:    4789,
+		ToPort:      4789,
+	}
+	customRules := infrav1.CNIIngressRules{
+		{
+			Description: "Antrea 1",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    10349,
+			ToPort:      10349,
+		},
+	}
+	defaultNetworkSpec := infrav1.NetworkSpec{}
+	infrav1.SetDefaults_NetworkSpec(&defaultNetworkSpec)
+	defaultRules := defaultNetworkSpec.CNI.CNIIngressRules
+
+	tests := []struct {
+		name               string
+		cni                *infrav1.CNISpec
+		hasWindowsMachines bool
+		want               infrav1.CNIIngressRules
+	}{
+		{
+			name: "returns the default rules of clusters without Windows machines",
+			cni:  &infrav1.CNISpec{CNIIngressRules: defaultRules},
+			want: defaultRules,
+		},
+		{
+			name:               "adds the Calico VXLAN rule to the default rules of clusters with Windows machines",
+			cni:                &infrav1.CNISpec{CNIIngressRules: defaultRules},
+			hasWindowsMachines: true,
+			want:               append(append(infrav1.CNIIngressRules{}, defaultRules...), vxlanRule),
+		},
+		{
+			name:               "returns custom rules as is",
+			cni:                &infrav1.CNISpec{CNIIngressRules: customRules},
+			hasWindowsMachines: true,
+			want:               customRules,
+		},
+		{
+			name:               "returns no rules if the CNI spec isn't set",
+			hasWindowsMachines: true,
+			want:               infrav1.CNIIngressRules{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{CNI: tt.cni},
+					},
+				},
+			}
+			clusterScope.SetHasWindowsMachines(tt.hasWindowsMachines)
+
+			g.Expect(clusterScope.CNIIngressRules()).To(Equal(tt.want))
+		})
+	}
+}
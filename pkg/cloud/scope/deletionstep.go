@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// NewDeletionStepScope creates the scope of a step of the deletion of a cluster, which is done concurrently with the
+// other steps. Like the scope of a standby network, it works on a copy of the AWSCluster which is never patched: the
+// changes of the step are merged into the AWSCluster with MergeDeletionStep once the steps are done.
+func NewDeletionStepScope(clusterScope *ClusterScope) *ClusterScope {
+	stepScope := *clusterScope
+	stepScope.AWSCluster = clusterScope.AWSCluster.DeepCopy()
+	stepScope.deletionStepBase = clusterScope.AWSCluster.DeepCopy()
+	return &stepScope
+}
+
+// MergeDeletionStep merges the changes a deletion step made to its copy of the AWSCluster into the AWSCluster of the
+// scope. The steps change different fields of the AWSCluster, and different conditions, which are merged one by one.
+func (s *ClusterScope) MergeDeletionStep(stepScope *ClusterScope) error {
+	base := stepScope.deletionStepBase
+	if base == nil {
+		return errors.New("failed to merge the changes of a scope which isn't the one of a deletion step")
+	}
+
+	changed := stepScope.AWSCluster.DeepCopy()
+	for i := range changed.Status.Conditions {
+		condition := &changed.Status.Conditions[i]
+		if previous := conditions.Get(base, condition.Type); previous == nil || *previous != *condition {
+			conditions.Set(s.AWSCluster, condition)
+		}
+	}
+	changed.Status.Conditions = base.Status.Conditions
+
+	baseJSON, err := json.Marshal(base)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal AWSCluster")
+	}
+	changedJSON, err := json.Marshal(changed)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal AWSCluster")
+	}
+	patch, err := strategicpatch.CreateTwoWayMergePatch(baseJSON, changedJSON, &infrav1.AWSCluster{})
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the changes of the deletion step")
+	}
+
+	currentJSON, err := json.Marshal(s.AWSCluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal AWSCluster")
+	}
+	mergedJSON, err := strategicpatch.StrategicMergePatch(currentJSON, patch, &infrav1.AWSCluster{})
+	if err != nil {
+		return errors.Wrap(err, "failed to merge the changes of the deletion step")
+	}
+	merged := &infrav1.AWSCluster{}
+	if err := json.Unmarshal(mergedJSON, merged); err != nil {
+		return errors.Wrap(err, "failed to unmarshal AWSCluster")
+	}
+	*s.AWSCluster = *merged
+	return nil
+}
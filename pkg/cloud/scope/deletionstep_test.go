@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestMergeDeletionStep(t *testing.T) {
+	g := NewWithT(t)
+
+	awsCluster := &infrav1.AWSCluster{
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+		},
+		Status: infrav1.AWSClusterStatus{
+			Network: infrav1.NetworkStatus{
+				SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{infrav1.SecurityGroupNode: {ID: "sg-node"}},
+			},
+			Bastion:              &infrav1.Instance{ID: "i-bastion"},
+			APIServerHealthCheck: &infrav1.Route53HealthCheckStatus{ID: "health-check"},
+		},
+	}
+	conditions.MarkTrue(awsCluster, infrav1.BastionHostReadyCondition)
+	conditions.MarkTrue(awsCluster, infrav1.ClusterSecurityGroupsReadyCondition)
+	clusterScope := &ClusterScope{AWSCluster: awsCluster}
+
+	bastionScope := NewDeletionStepScope(clusterScope)
+	healthCheckScope := NewDeletionStepScope(clusterScope)
+
+	bastionScope.SetBastionInstance(nil)
+	conditions.MarkFalse(bastionScope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	g.Expect(bastionScope.PatchObject()).To(Succeed())
+	healthCheckScope.SetAPIServerHealthCheckStatus(nil)
+
+	// The AWSCluster is only changed once the changes of the steps are merged.
+	g.Expect(awsCluster.Status.Bastion).ToNot(BeNil())
+	g.Expect(conditions.IsTrue(awsCluster, infrav1.BastionHostReadyCondition)).To(BeTrue())
+
+	g.Expect(clusterScope.MergeDeletionStep(bastionScope)).To(Succeed())
+	g.Expect(clusterScope.MergeDeletionStep(healthCheckScope)).To(Succeed())
+
+	g.Expect(clusterScope.AWSCluster).To(BeIdenticalTo(awsCluster))
+	g.Expect(awsCluster.Status.Bastion).To(BeNil())
+	g.Expect(awsCluster.Status.APIServerHealthCheck).To(BeNil())
+	g.Expect(awsCluster.Status.Network.SecurityGroups).To(HaveKey(infrav1.SecurityGroupNode))
+	g.Expect(conditions.GetReason(awsCluster, infrav1.BastionHostReadyCondition)).To(Equal(clusterv1.DeletedReason))
+	g.Expect(conditions.IsTrue(awsCluster, infrav1.ClusterSecurityGroupsReadyCondition)).To(BeTrue())
+
+	g.Expect(clusterScope.MergeDeletionStep(clusterScope)).ToNot(Succeed())
+}
@@ -46,6 +46,12 @@ type EC2Scope interface {
 	// SSHKeyName returns the SSH key name to use for instances.
 	SSHKeyName() *string
 
+	// InstanceAccessMode returns how the instances are accessed.
+	InstanceAccessMode() infrav1.InstanceAccessMode
+
+	// DefaultEBSEncryptionKeyARN returns the ARN of the KMS key encrypting the volumes which don't specify one.
+	DefaultEBSEncryptionKeyARN() string
+
 	// ImageLookupFormat returns the format string to use when looking up AMIs
 	ImageLookupFormat() string
 
@@ -54,4 +60,7 @@ type EC2Scope interface {
 
 	// ImageLookupBaseOS returns the base operating system name to use when looking up AMIs
 	ImageLookupBaseOS() string
+
+	// SSMParameterPrefix returns the prefix of the SSM parameters storing the bootstrap data of instances
+	SSMParameterPrefix() string
 }
@@ -69,7 +69,7 @@ func NewFargateProfileScope(params FargateProfileScopeParams) (*FargateProfileSc
 		controllerName: params.ControllerName,
 	}
 
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, controlPlaneRegion(params.ControlPlane), params.Endpoints, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -158,10 +158,10 @@ func (s *FargateProfileScope) SubnetIDs() []string {
 	return s.FargateProfile.Spec.SubnetIDs
 }
 
-// Partition returns the machine pool subnet IDs.
+// Partition returns the partition of the control plane of the fargate profile.
 func (s *FargateProfileScope) Partition() string {
 	if s.ControlPlane.Spec.Partition == "" {
-		s.ControlPlane.Spec.Partition = system.GetPartitionFromRegion(s.ControlPlane.Spec.Region)
+		s.ControlPlane.Spec.Partition = system.GetPartitionFromRegion(controlPlaneRegion(s.ControlPlane))
 	}
 	return s.ControlPlane.Spec.Partition
 }
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// HealthCheckScope is the interface for the scope to be used with the Route 53 health check service.
+type HealthCheckScope interface {
+	cloud.ClusterScoper
+
+	// ControlPlaneEndpoint returns the endpoint checked by the health check.
+	ControlPlaneEndpoint() clusterv1.APIEndpoint
+	// APIServerHealthCheck returns the spec of the health check of the control plane endpoint, nil when disabled.
+	APIServerHealthCheck() *infrav1.Route53HealthCheck
+	// APIServerHealthCheckStatus returns the status of the health check of the control plane endpoint.
+	APIServerHealthCheckStatus() *infrav1.Route53HealthCheckStatus
+	// SetAPIServerHealthCheckStatus sets the status of the health check of the control plane endpoint.
+	SetAPIServerHealthCheckStatus(status *infrav1.Route53HealthCheckStatus)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+)
+
+// IAMPermissionsScope is the interface for the scope to be used with the IAM permissions preflight service.
+type IAMPermissionsScope interface {
+	cloud.ClusterScoper
+
+	// VPC returns the cluster VPC.
+	VPC() *infrav1.VPCSpec
+	// ControlPlaneLoadBalancers returns the load balancers of the control plane.
+	ControlPlaneLoadBalancers() []*infrav1.AWSLoadBalancerSpec
+	// Bucket returns the bucket details.
+	Bucket() *infrav1.S3Bucket
+	// Bastion returns the bastion details.
+	Bastion() *infrav1.Bastion
+	// InstanceProfiles returns the templates of the IAM instance profiles managed for the cluster.
+	InstanceProfiles() *infrav1.ManagedInstanceProfiles
+}
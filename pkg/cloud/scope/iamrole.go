@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// IAMRoleScopeParams defines the input parameters used to create a new Scope.
+// One of ControlPlane or AWSCluster has to be set, depending on how the cluster is managed.
+type IAMRoleScopeParams struct {
+	Client         client.Client
+	Logger         *logger.Logger
+	Cluster        *clusterv1.Cluster
+	ControlPlane   *ekscontrolplanev1.AWSManagedControlPlane
+	AWSCluster     *infrav1.AWSCluster
+	IAMRole        *expinfrav1.AWSIAMRole
+	ControllerName string
+	Endpoints      []ServiceEndpoint
+}
+
+// NewIAMRoleScope creates a new Scope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewIAMRoleScope(params IAMRoleScopeParams) (*IAMRoleScope, error) {
+	if params.IAMRole == nil {
+		return nil, errors.New("failed to generate new scope from nil AWSIAMRole")
+	}
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	var (
+		clusterScoper   cloud.SessionMetadata
+		infraCluster    cloud.ClusterObject
+		region          string
+		oidcProviderARN string
+	)
+	switch {
+	case params.ControlPlane != nil:
+		clusterScoper = &ManagedControlPlaneScope{
+			Logger:         *params.Logger,
+			Client:         params.Client,
+			Cluster:        params.Cluster,
+			ControlPlane:   params.ControlPlane,
+			controllerName: params.ControllerName,
+		}
+		infraCluster = params.ControlPlane
+		region = controlPlaneRegion(params.ControlPlane)
+		oidcProviderARN = params.ControlPlane.Status.OIDCProvider.ARN
+	case params.AWSCluster != nil:
+		clusterScoper = &ClusterScope{
+			Logger:         *params.Logger,
+			client:         params.Client,
+			Cluster:        params.Cluster,
+			AWSCluster:     params.AWSCluster,
+			controllerName: params.ControllerName,
+		}
+		infraCluster = params.AWSCluster
+		region = AWSClusterRegion(params.AWSCluster)
+		oidcProviderARN = params.AWSCluster.Status.OIDCProvider.ARN
+	default:
+		return nil, errors.New("failed to generate new scope without AWSManagedControlPlane or AWSCluster")
+	}
+
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, clusterScoper, region, params.Endpoints, params.Logger)
+	if err != nil {
+		return nil, errors.Errorf("failed to create aws session: %v", err)
+	}
+
+	helper, err := patch.NewHelper(params.IAMRole, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &IAMRoleScope{
+		Logger:          *params.Logger,
+		Client:          params.Client,
+		Cluster:         params.Cluster,
+		IAMRole:         params.IAMRole,
+		infraCluster:    infraCluster,
+		oidcProviderARN: oidcProviderARN,
+		patchHelper:     helper,
+		session:         session,
+		serviceLimiters: serviceLimiters,
+		controllerName:  params.ControllerName,
+	}, nil
+}
+
+// IAMRoleScope defines the basic context for an actuator to operate upon.
+type IAMRoleScope struct {
+	logger.Logger
+	Client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster *clusterv1.Cluster
+	IAMRole *expinfrav1.AWSIAMRole
+
+	infraCluster    cloud.ClusterObject
+	oidcProviderARN string
+	session         awsclient.ConfigProvider
+	serviceLimiters throttle.ServiceLimiters
+	controllerName  string
+}
+
+// ClusterName returns the cluster name.
+func (s *IAMRoleScope) ClusterName() string {
+	return s.Cluster.Name
+}
+
+// RoleName returns the name of the IAM role.
+func (s *IAMRoleScope) RoleName() string {
+	return s.IAMRole.Spec.RoleName
+}
+
+// OIDCProviderARN returns the ARN of the IAM OIDC identity provider of the cluster, or an
+// empty string if it hasn't been associated yet.
+func (s *IAMRoleScope) OIDCProviderARN() string {
+	return s.oidcProviderARN
+}
+
+// AdditionalTags returns AdditionalTags from the scope's AWSIAMRole.
+// The returned value will never be nil.
+func (s *IAMRoleScope) AdditionalTags() infrav1.Tags {
+	if s.IAMRole.Spec.AdditionalTags == nil {
+		s.IAMRole.Spec.AdditionalTags = infrav1.Tags{}
+	}
+
+	return s.IAMRole.Spec.AdditionalTags.DeepCopy()
+}
+
+// PatchObject persists the AWSIAMRole configuration and status.
+func (s *IAMRoleScope) PatchObject() error {
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.IAMRole,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			expinfrav1.IAMRoleReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the AWSIAMRole configuration and status.
+func (s *IAMRoleScope) Close() error {
+	return s.PatchObject()
+}
+
+// InfraCluster returns the AWS infrastructure cluster or control plane object.
+func (s *IAMRoleScope) InfraCluster() cloud.ClusterObject {
+	return s.infraCluster
+}
+
+// ServiceLimiter returns the AWS SDK session. Used for creating clients.
+func (s *IAMRoleScope) ServiceLimiter(service string) *throttle.ServiceLimiter {
+	if sl, ok := s.serviceLimiters[service]; ok {
+		return sl
+	}
+	return nil
+}
+
+// Session returns the AWS SDK session. Used for creating clients.
+func (s *IAMRoleScope) Session() awsclient.ConfigProvider {
+	return s.session
+}
+
+// ControllerName returns the name of the controller that
+// created the AWSIAMRole.
+func (s *IAMRoleScope) ControllerName() string {
+	return s.controllerName
+}
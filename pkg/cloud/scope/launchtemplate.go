@@ -17,6 +17,8 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,5 +61,5 @@ type ResourceServiceToUpdate struct {
 
 // ResourceService defines the interface for resources.
 type ResourceService interface {
-	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
+	UpdateResourceTags(ctx context.Context, resourceID *string, create, remove map[string]string) error
 }
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"encoding/base64"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -122,6 +123,23 @@ func (m *MachineScope) Role() string {
 	return "node"
 }
 
+// IAMInstanceProfile returns the IAM instance profile of the machine: the one of its spec if set,
+// otherwise the instance profile managed for its role by the AWSCluster, if any.
+func (m *MachineScope) IAMInstanceProfile() string {
+	if m.AWSMachine.Spec.IAMInstanceProfile != "" {
+		return m.AWSMachine.Spec.IAMInstanceProfile
+	}
+
+	awsCluster, ok := m.InfraCluster.InfraCluster().(*infrav1.AWSCluster)
+	if !ok || awsCluster.Status.InstanceProfiles == nil {
+		return ""
+	}
+	if m.IsControlPlane() {
+		return awsCluster.Status.InstanceProfiles.ControlPlane
+	}
+	return awsCluster.Status.InstanceProfiles.Nodes
+}
+
 // GetInstanceID returns the AWSMachine instance id by parsing Spec.ProviderID.
 func (m *MachineScope) GetInstanceID() *string {
 	parsed, err := NewProviderID(m.GetProviderID())
@@ -160,6 +178,11 @@ func (m *MachineScope) SetInstanceState(v infrav1.InstanceState) {
 	m.AWSMachine.Status.InstanceState = &v
 }
 
+// SetImageID sets the AWSMachine status image ID.
+func (m *MachineScope) SetImageID(imageID string) {
+	m.AWSMachine.Status.ImageID = ptr.To[string](imageID)
+}
+
 // SetReady sets the AWSMachine Ready Status.
 func (m *MachineScope) SetReady() {
 	m.AWSMachine.Status.Ready = true
@@ -190,8 +213,15 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 
 // UseSecretsManager returns the computed value of whether or not
 // userdata should be stored using AWS Secrets Manager.
+// Windows instances can't fetch it from AWS Secrets Manager, as it requires a cloud-init boothook, and their
+// webhook requires spec.cloudInit.insecureSkipSecretsManager to be set.
 func (m *MachineScope) UseSecretsManager(userDataFormat string) bool {
-	return !m.AWSMachine.Spec.CloudInit.InsecureSkipSecretsManager && !m.UseIgnition(userDataFormat)
+	return !m.AWSMachine.Spec.CloudInit.InsecureSkipSecretsManager && !m.UseIgnition(userDataFormat) && !m.IsWindows()
+}
+
+// IsWindows returns true if the AWSMachine runs Windows.
+func (m *MachineScope) IsWindows() bool {
+	return m.AWSMachine.Spec.OSFamily == infrav1.OSFamilyWindows
 }
 
 // UseIgnition returns true if the AWSMachine should use Ignition.
@@ -199,15 +229,22 @@ func (m *MachineScope) UseIgnition(userDataFormat string) bool {
 	return userDataFormat == "ignition" || (m.AWSMachine.Spec.Ignition != nil)
 }
 
-// SecureSecretsBackend returns the chosen secret backend.
+// SecureSecretsBackend returns the chosen secret backend. Clusters storing bootstrap data in SSM parameters
+// override the backend of their machines, unless the userdata was already stored outside of their prefix.
 func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
+	if clusterPrefix := m.InfraCluster.SSMParameterPrefix(); clusterPrefix != "" {
+		secretPrefix := m.GetSecretPrefix()
+		if secretPrefix == "" || strings.HasPrefix(secretPrefix, "/"+strings.TrimPrefix(clusterPrefix, "/")) {
+			return infrav1.SecretBackendSSMParameterStore
+		}
+	}
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
 }
 
 // CompressUserData returns the computed value of whether or not
 // userdata should be compressed using gzip.
 func (m *MachineScope) CompressUserData(userDataFormat string) bool {
-	if m.UseIgnition(userDataFormat) {
+	if m.UseIgnition(userDataFormat) || m.IsWindows() {
 		return false
 	}
 
@@ -292,12 +329,21 @@ func (m *MachineScope) PatchObject() error {
 	applicableConditions := []clusterv1.ConditionType{
 		infrav1.InstanceReadyCondition,
 		infrav1.SecurityGroupsReadyCondition,
+		infrav1.VolumesAttachedCondition,
 	}
 
-	if m.IsControlPlane() {
+	if ptr.Deref(m.AWSMachine.Spec.PublicIP, false) {
+		applicableConditions = append(applicableConditions, infrav1.EIPAssociatedCondition)
+	}
+
+	if m.IsControlPlane() || m.AWSMachine.Spec.LoadBalancerAttachments != nil {
 		applicableConditions = append(applicableConditions, infrav1.ELBAttachedCondition)
 	}
 
+	if len(m.RequiredTags()) > 0 {
+		applicableConditions = append(applicableConditions, infrav1.RequiredTagsReadyCondition)
+	}
+
 	conditions.SetSummary(m.AWSMachine,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(m.AWSMachine.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -312,6 +358,11 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.EIPAssociatedCondition,
+			infrav1.VolumesAttachedCondition,
+			infrav1.RequiredTagsReadyCondition,
+			infrav1.NodeJoinedCondition,
+			infrav1.SSMConnectivityCondition,
 		}})
 }
 
@@ -333,6 +384,15 @@ func (m *MachineScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// RequiredTags returns the keys of the tags the AWS resources of the machine must carry, which are the required tags
+// of its cluster.
+func (m *MachineScope) RequiredTags() []string {
+	if requiredTagsScope, ok := m.InfraCluster.(RequiredTagsScope); ok {
+		return requiredTagsScope.RequiredTags()
+	}
+	return nil
+}
+
 // HasFailed returns the failure state of the machine scope.
 func (m *MachineScope) HasFailed() bool {
 	return m.AWSMachine.Status.FailureReason != nil || m.AWSMachine.Status.FailureMessage != nil
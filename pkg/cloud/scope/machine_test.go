@@ -258,6 +258,72 @@ func TestUseSecretsManagerTrue(t *testing.T) {
 	}
 }
 
+func TestUseSecretsManagerFalseForWindows(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope.AWSMachine.Spec.OSFamily = infrav1.OSFamilyWindows
+
+	if scope.UseSecretsManager("cloud-config") {
+		t.Fatalf("UseSecretsManager should be false for Windows machines")
+	}
+}
+
+func TestSecureSecretsBackend(t *testing.T) {
+	tests := []struct {
+		name               string
+		ssmParameterPrefix string
+		specBackend        infrav1.SecretBackend
+		secretPrefix       string
+		want               infrav1.SecretBackend
+	}{
+		{
+			name:        "uses the backend of the spec without a cluster prefix",
+			specBackend: infrav1.SecretBackendSecretsManager,
+			want:        infrav1.SecretBackendSecretsManager,
+		},
+		{
+			name:               "uses SSM parameters with a cluster prefix",
+			ssmParameterPrefix: "/cluster-api-provider-aws/bootstrap",
+			specBackend:        infrav1.SecretBackendSecretsManager,
+			want:               infrav1.SecretBackendSSMParameterStore,
+		},
+		{
+			name:               "uses SSM parameters for userdata stored under the cluster prefix",
+			ssmParameterPrefix: "cluster-api-provider-aws/bootstrap",
+			specBackend:        infrav1.SecretBackendSecretsManager,
+			secretPrefix:       "/cluster-api-provider-aws/bootstrap/my-cluster/uuid",
+			want:               infrav1.SecretBackendSSMParameterStore,
+		},
+		{
+			name:               "keeps the backend of userdata stored before setting the cluster prefix",
+			ssmParameterPrefix: "/cluster-api-provider-aws/bootstrap",
+			specBackend:        infrav1.SecretBackendSecretsManager,
+			secretPrefix:       "aws.cluster.x-k8s.io/uuid",
+			want:               infrav1.SecretBackendSecretsManager,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, err := setupMachineScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			scope.InfraCluster.(*ClusterScope).AWSCluster.Spec.SSMParameterPrefix = tt.ssmParameterPrefix
+			scope.AWSMachine.Spec.CloudInit.SecureSecretsBackend = tt.specBackend
+			scope.AWSMachine.Spec.CloudInit.SecretPrefix = tt.secretPrefix
+
+			if got := scope.SecureSecretsBackend(); got != tt.want {
+				t.Fatalf("SecureSecretsBackend() = %q, want %q", got, tt.want)
+			}
+			if scope.AWSMachine.Spec.CloudInit.SecureSecretsBackend != tt.specBackend {
+				t.Fatalf("SecureSecretsBackend() changed the spec of the AWSMachine")
+			}
+		})
+	}
+}
+
 func TestUseIgnition(t *testing.T) {
 	t.Run("returns_true_when_given_bootstrap_data_format_is_ignition", func(t *testing.T) {
 		scope, err := setupMachineScope()
@@ -295,6 +361,20 @@ func TestCompressUserData(t *testing.T) {
 			t.Fatalf("User data would be compressed despite Ignition format")
 		}
 	})
+
+	// EC2Launch does not support compressed user data.
+	t.Run("returns_false_for_windows_machines", func(t *testing.T) {
+		scope, err := setupMachineScope()
+		if err != nil {
+			t.Fatal(err)
+		}
+		scope.AWSMachine.Spec.OSFamily = infrav1.OSFamilyWindows
+		scope.AWSMachine.Spec.UncompressedUserData = ptr.To[bool](false)
+
+		if scope.CompressUserData("cloud-config") {
+			t.Fatalf("User data would be compressed for a Windows machine")
+		}
+	})
 }
 
 func TestGetSecretARNDefaultIsNil(t *testing.T) {
@@ -341,3 +421,32 @@ func TestSetProviderID(t *testing.T) {
 		t.Fatalf("Expected providerID %s, got %s", expectedProviderID, providerID)
 	}
 }
+
+func TestIAMInstanceProfile(t *testing.T) {
+	scope, err := setupMachineScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if profile := scope.IAMInstanceProfile(); profile != "" {
+		t.Fatalf("Expected no instance profile, got %s", profile)
+	}
+
+	scope.InfraCluster.InfraCluster().(*infrav1.AWSCluster).Status.InstanceProfiles = &infrav1.ManagedInstanceProfilesStatus{
+		ControlPlane: "capa-default-my-cluster-control-plane",
+		Nodes:        "capa-default-my-cluster-nodes",
+	}
+	if profile := scope.IAMInstanceProfile(); profile != "capa-default-my-cluster-nodes" {
+		t.Fatalf("Expected the managed instance profile of the nodes, got %s", profile)
+	}
+
+	scope.Machine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	if profile := scope.IAMInstanceProfile(); profile != "capa-default-my-cluster-control-plane" {
+		t.Fatalf("Expected the managed instance profile of the control plane, got %s", profile)
+	}
+
+	scope.AWSMachine.Spec.IAMInstanceProfile = "custom"
+	if profile := scope.IAMInstanceProfile(); profile != "custom" {
+		t.Fatalf("Expected the instance profile of the spec, got %s", profile)
+	}
+}
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -31,15 +32,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
@@ -172,6 +177,129 @@ func (m *MachinePoolScope) AdditionalTags() infrav1.Tags {
 	return tags
 }
 
+// InstancesMarkedForDeletion returns the IDs of the instances of the Machines of the MachinePool annotated with
+// the Cluster API delete-machine annotation, which are removed first when the MachinePool is scaled down.
+func (m *MachinePoolScope) InstancesMarkedForDeletion(ctx context.Context) ([]string, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := m.Client.List(ctx, machineList, client.InNamespace(m.MachinePool.Namespace), client.MatchingLabels{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(m.MachinePool.Name),
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the machines of MachinePool %q", m.MachinePool.Name)
+	}
+
+	instanceIDs := []string{}
+	for _, machine := range machineList.Items {
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; !ok || machine.Spec.ProviderID == nil {
+			continue
+		}
+		providerID := *machine.Spec.ProviderID
+		instanceIDs = append(instanceIDs, providerID[strings.LastIndex(providerID, "/")+1:])
+	}
+	sort.Strings(instanceIDs)
+	return instanceIDs, nil
+}
+
+// NodeLabelsAndTaints returns the labels and the taints the nodes of the MachinePool are registered with: the labels
+// of the MachinePool template that Cluster API propagates to the nodes, and the ones and the taints the kubelet is
+// configured with by a KubeadmConfig or an EKSConfig bootstrap configuration.
+func (m *MachinePoolScope) NodeLabelsAndTaints(ctx context.Context) (map[string]string, expinfrav1.Taints, error) {
+	labels := map[string]string{}
+	for key, value := range m.MachinePool.Spec.Template.Labels {
+		if isManagedNodeLabel(key) {
+			labels[key] = value
+		}
+	}
+
+	ref := m.MachinePool.Spec.Template.Spec.Bootstrap.ConfigRef
+	if ref == nil {
+		return labels, nil, nil
+	}
+
+	gk := ref.GroupVersionKind().GroupKind()
+	kubeadmConfigGK := bootstrapv1.GroupVersion.WithKind("KubeadmConfig").GroupKind()
+	eksConfigGK := eksbootstrapv1.GroupVersion.WithKind("EKSConfig").GroupKind()
+	if gk != kubeadmConfigGK && gk != eksConfigGK {
+		return labels, nil, nil
+	}
+
+	obj, err := external.Get(ctx, m.Client, ref, m.MachinePool.Namespace)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get bootstrap config %s/%s", m.MachinePool.Namespace, ref.Name)
+	}
+
+	var kubeletExtraArgs map[string]string
+	var taints expinfrav1.Taints
+	if gk == kubeadmConfigGK {
+		config := &bootstrapv1.KubeadmConfig{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), config); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to convert bootstrap config %s/%s", m.MachinePool.Namespace, ref.Name)
+		}
+		if config.Spec.JoinConfiguration != nil {
+			kubeletExtraArgs = config.Spec.JoinConfiguration.NodeRegistration.KubeletExtraArgs
+			for _, taint := range config.Spec.JoinConfiguration.NodeRegistration.Taints {
+				taints = append(taints, expinfrav1.Taint{Key: taint.Key, Value: taint.Value, Effect: taintEffect(taint.Effect)})
+			}
+		}
+	} else {
+		config := &eksbootstrapv1.EKSConfig{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), config); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to convert bootstrap config %s/%s", m.MachinePool.Namespace, ref.Name)
+		}
+		kubeletExtraArgs = config.Spec.KubeletExtraArgs
+	}
+
+	for _, label := range splitKubeletFlag(kubeletExtraArgs["node-labels"]) {
+		key, value, _ := strings.Cut(label, "=")
+		labels[key] = value
+	}
+	for _, taint := range splitKubeletFlag(kubeletExtraArgs["register-with-taints"]) {
+		// taints are registered as key=value:effect or key:effect.
+		keyValue, effect, _ := strings.Cut(taint, ":")
+		key, value, _ := strings.Cut(keyValue, "=")
+		taints = append(taints, expinfrav1.Taint{Key: key, Value: value, Effect: taintEffect(corev1.TaintEffect(effect))})
+	}
+
+	return labels, taints, nil
+}
+
+// isManagedNodeLabel returns whether Cluster API propagates a label of a MachinePool template to the nodes.
+func isManagedNodeLabel(key string) bool {
+	domain, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	if domain == clusterv1.NodeRoleLabelPrefix {
+		return true
+	}
+	for _, managedDomain := range []string{clusterv1.NodeRestrictionLabelDomain, clusterv1.ManagedNodeLabelDomain} {
+		if domain == managedDomain || strings.HasSuffix(domain, "."+managedDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitKubeletFlag(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func taintEffect(effect corev1.TaintEffect) expinfrav1.TaintEffect {
+	switch effect {
+	case corev1.TaintEffectNoExecute:
+		return expinfrav1.TaintEffectNoExecute
+	case corev1.TaintEffectPreferNoSchedule:
+		return expinfrav1.TaintEffectPreferNoSchedule
+	default:
+		return expinfrav1.TaintEffectNoSchedule
+	}
+}
+
 // PatchObject persists the machinepool spec and status.
 func (m *MachinePoolScope) PatchObject() error {
 	return m.patchHelper.Patch(
@@ -180,6 +308,7 @@ func (m *MachinePoolScope) PatchObject() error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.ASGReadyCondition,
 			expinfrav1.LaunchTemplateReadyCondition,
+			expinfrav1.TargetsHealthyCondition,
 		}})
 }
 
@@ -272,6 +401,15 @@ func (m *MachinePoolScope) SetLaunchTemplateLatestVersionStatus(version string)
 	m.AWSMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+// LaunchTemplateVersion returns the launch template version the ASG should use: the pinned version
+// if one is set on the launch template spec, or the latest version otherwise.
+func (m *MachinePoolScope) LaunchTemplateVersion() string {
+	if v := m.AWSMachinePool.Spec.AWSLaunchTemplate.PinnedVersion; v != nil {
+		return *v
+	}
+	return expinfrav1.LaunchTemplateLatestVersion
+}
+
 // IsEKSManaged checks if the AWSMachinePool is EKS managed.
 func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
@@ -300,11 +438,12 @@ type NodeStatus struct {
 }
 
 // UpdateInstanceStatuses ties ASG instances and Node status data together and updates AWSMachinePool
-// This updates if ASG instances ready and kubelet version running on the node..
-func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances []infrav1.Instance) error {
+// This updates if ASG instances ready and kubelet version running on the node, as well as the number of
+// instances and ready instances in each availability zone.
+func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances []expinfrav1.AWSMachinePoolInstanceStatus) error {
 	providerIDs := make([]string, len(instances))
 	for i, instance := range instances {
-		providerIDs[i] = fmt.Sprintf("aws:////%s", instance.ID)
+		providerIDs[i] = fmt.Sprintf("aws:////%s", instance.InstanceID)
 	}
 
 	nodeStatusByProviderID, err := m.getNodeStatusByProviderID(ctx, providerIDs)
@@ -314,22 +453,40 @@ func (m *MachinePoolScope) UpdateInstanceStatuses(ctx context.Context, instances
 
 	var readyReplicas int32
 	instanceStatuses := make([]expinfrav1.AWSMachinePoolInstanceStatus, len(instances))
+	zoneStatuses := map[string]*expinfrav1.AWSMachinePoolAvailabilityZoneStatus{}
 	for i, instance := range instances {
-		instanceStatuses[i] = expinfrav1.AWSMachinePoolInstanceStatus{
-			InstanceID: instance.ID,
+		instanceStatuses[i] = instance
+
+		zoneStatus, ok := zoneStatuses[instance.AvailabilityZone]
+		if !ok {
+			zoneStatus = &expinfrav1.AWSMachinePoolAvailabilityZoneStatus{Name: instance.AvailabilityZone}
+			zoneStatuses[instance.AvailabilityZone] = zoneStatus
 		}
+		zoneStatus.Replicas++
 
-		instanceStatus := instanceStatuses[i]
+		instanceStatus := &instanceStatuses[i]
 		if nodeStatus, ok := nodeStatusByProviderID[fmt.Sprintf("aws:////%s", instanceStatus.InstanceID)]; ok {
-			instanceStatus.Version = &nodeStatus.Version
+			if nodeStatus.Version != "" {
+				instanceStatus.Version = &nodeStatus.Version
+			}
 			if nodeStatus.Ready {
 				readyReplicas++
+				zoneStatus.ReadyReplicas++
 			}
 		}
 	}
 
+	availabilityZones := make([]expinfrav1.AWSMachinePoolAvailabilityZoneStatus, 0, len(zoneStatuses))
+	for _, zoneStatus := range zoneStatuses {
+		availabilityZones = append(availabilityZones, *zoneStatus)
+	}
+	sort.Slice(availabilityZones, func(i, j int) bool {
+		return availabilityZones[i].Name < availabilityZones[j].Name
+	})
+
 	// TODO: readyReplicas can be used as status.replicas but this will delay machinepool to become ready. next reconcile updates this.
 	m.AWSMachinePool.Status.Instances = instanceStatuses
+	m.AWSMachinePool.Status.AvailabilityZones = availabilityZones
 	return nil
 }
 
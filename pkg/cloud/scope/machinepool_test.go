@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestMachinePoolScopeNodeLabelsAndTaints(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateLabels map[string]string
+		configRef      *corev1.ObjectReference
+		config         client.Object
+		expectLabels   map[string]string
+		expectTaints   expinfrav1.Taints
+		expectErr      bool
+	}{
+		{
+			name: "only the labels propagated to the nodes are returned without bootstrap config",
+			templateLabels: map[string]string{
+				"node-role.kubernetes.io/worker":     "",
+				"tier.node.cluster.x-k8s.io/gpu":     "true",
+				"node-restriction.kubernetes.io/env": "prod",
+				"app":                                "web",
+				"example.com/team":                   "ml",
+			},
+			expectLabels: map[string]string{
+				"node-role.kubernetes.io/worker":     "",
+				"tier.node.cluster.x-k8s.io/gpu":     "true",
+				"node-restriction.kubernetes.io/env": "prod",
+			},
+		},
+		{
+			name: "labels and taints of a KubeadmConfig",
+			configRef: &corev1.ObjectReference{
+				APIVersion: bootstrapv1.GroupVersion.String(),
+				Kind:       "KubeadmConfig",
+				Name:       "pool",
+			},
+			config: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"node-labels": "workload=gpu, zone=a"},
+							Taints:           []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute}},
+						},
+					},
+				},
+			},
+			expectLabels: map[string]string{"workload": "gpu", "zone": "a"},
+			expectTaints: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoExecute}},
+		},
+		{
+			name: "labels and taints of an EKSConfig",
+			configRef: &corev1.ObjectReference{
+				APIVersion: eksbootstrapv1.GroupVersion.String(),
+				Kind:       "EKSConfig",
+				Name:       "pool",
+			},
+			config: &eksbootstrapv1.EKSConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				Spec: eksbootstrapv1.EKSConfigSpec{
+					KubeletExtraArgs: map[string]string{
+						"node-labels":          "workload=gpu",
+						"register-with-taints": "dedicated=gpu:PreferNoSchedule,spot:NoSchedule",
+					},
+				},
+			},
+			expectLabels: map[string]string{"workload": "gpu"},
+			expectTaints: expinfrav1.Taints{
+				{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectPreferNoSchedule},
+				{Key: "spot", Effect: expinfrav1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name: "other bootstrap configs are ignored",
+			configRef: &corev1.ObjectReference{
+				APIVersion: "bootstrap.cluster.x-k8s.io/v1alpha1",
+				Kind:       "TalosConfig",
+				Name:       "pool",
+			},
+			expectLabels: map[string]string{},
+		},
+		{
+			name: "missing bootstrap config returns an error",
+			configRef: &corev1.ObjectReference{
+				APIVersion: bootstrapv1.GroupVersion.String(),
+				Kind:       "KubeadmConfig",
+				Name:       "missing",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(eksbootstrapv1.AddToScheme(scheme)).To(Succeed())
+
+			clientBuilder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.config != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.config)
+			}
+
+			s := &MachinePoolScope{
+				Client: clientBuilder.Build(),
+				MachinePool: &expclusterv1.MachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				},
+			}
+			s.MachinePool.Spec.Template.Labels = tt.templateLabels
+			s.MachinePool.Spec.Template.Spec.Bootstrap.ConfigRef = tt.configRef
+
+			labels, taints, err := s.NodeLabelsAndTaints(context.TODO())
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(labels).To(Equal(tt.expectLabels))
+			g.Expect(taints).To(Equal(tt.expectTaints))
+		})
+	}
+}
+
+func TestMachinePoolScopeInstancesMarkedForDeletion(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	machine := func(name, pool, providerID string, marked bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.MachinePoolNameLabel: pool},
+			},
+		}
+		if providerID != "" {
+			m.Spec.ProviderID = ptr.To(providerID)
+		}
+		if marked {
+			m.Annotations = map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+		}
+		return m
+	}
+
+	s := &MachinePoolScope{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			machine("marked-2", "pool", "aws:///us-east-1b/i-2", true),
+			machine("marked-1", "pool", "aws:///us-east-1a/i-1", true),
+			machine("not-marked", "pool", "aws:///us-east-1a/i-3", false),
+			machine("without-provider-id", "pool", "", true),
+			machine("other-pool", "other", "aws:///us-east-1a/i-4", true),
+		).Build(),
+		MachinePool: &expclusterv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		},
+	}
+
+	instanceIDs, err := s.InstancesMarkedForDeletion(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instanceIDs).To(Equal([]string{"i-1", "i-2"}))
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// MachinePoolMachineScopeParams defines the input parameters used to create a new MachinePoolMachineScope.
+type MachinePoolMachineScopeParams struct {
+	client.Client
+	Logger *logger.Logger
+
+	Cluster               *clusterv1.Cluster
+	MachinePool           *expclusterv1.MachinePool
+	AWSMachinePool        *expinfrav1.AWSMachinePool
+	AWSMachinePoolMachine *expinfrav1.AWSMachinePoolMachine
+}
+
+// MachinePoolMachineScope defines a scope defined around an instance of an AWSMachinePool and its cluster.
+type MachinePoolMachineScope struct {
+	logger.Logger
+	client.Client
+	patchHelper *patch.Helper
+
+	Cluster               *clusterv1.Cluster
+	MachinePool           *expclusterv1.MachinePool
+	AWSMachinePool        *expinfrav1.AWSMachinePool
+	AWSMachinePoolMachine *expinfrav1.AWSMachinePoolMachine
+}
+
+// NewMachinePoolMachineScope creates a new MachinePoolMachineScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachinePoolMachineScope(params MachinePoolMachineScopeParams) (*MachinePoolMachineScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachinePoolMachineScope")
+	}
+	if params.Cluster == nil {
+		return nil, errors.New("cluster is required when creating a MachinePoolMachineScope")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("machinepool is required when creating a MachinePoolMachineScope")
+	}
+	if params.AWSMachinePool == nil {
+		return nil, errors.New("aws machine pool is required when creating a MachinePoolMachineScope")
+	}
+	if params.AWSMachinePoolMachine == nil {
+		return nil, errors.New("aws machine pool machine is required when creating a MachinePoolMachineScope")
+	}
+
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	helper, err := patch.NewHelper(params.AWSMachinePoolMachine, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init AWSMachinePoolMachine patch helper")
+	}
+
+	return &MachinePoolMachineScope{
+		Logger:      *params.Logger,
+		Client:      params.Client,
+		patchHelper: helper,
+
+		Cluster:               params.Cluster,
+		MachinePool:           params.MachinePool,
+		AWSMachinePool:        params.AWSMachinePool,
+		AWSMachinePoolMachine: params.AWSMachinePoolMachine,
+	}, nil
+}
+
+// InstanceID returns the ID of the instance of the AWSMachinePoolMachine.
+func (m *MachinePoolMachineScope) InstanceID() string {
+	return m.AWSMachinePoolMachine.Spec.InstanceID
+}
+
+// InstanceStatus returns the status of the instance reported by the AWSMachinePool, or nil if the instance isn't
+// part of its autoscaling group anymore.
+func (m *MachinePoolMachineScope) InstanceStatus() *expinfrav1.AWSMachinePoolInstanceStatus {
+	for i, instance := range m.AWSMachinePool.Status.Instances {
+		if instance.InstanceID == m.InstanceID() {
+			return &m.AWSMachinePool.Status.Instances[i]
+		}
+	}
+	return nil
+}
+
+// IsTerminating returns true if the instance is being terminated by the autoscaling group or already left it.
+func (m *MachinePoolMachineScope) IsTerminating() bool {
+	instance := m.InstanceStatus()
+	return instance == nil || strings.HasPrefix(instance.LifecycleState, autoscaling.LifecycleStateTerminating)
+}
+
+// UpdateInstanceStatus updates the status of the AWSMachinePoolMachine from the one of its instance reported by the
+// AWSMachinePool.
+func (m *MachinePoolMachineScope) UpdateInstanceStatus() {
+	status := &m.AWSMachinePoolMachine.Status
+	instance := m.InstanceStatus()
+	if instance == nil {
+		status.Ready = false
+		status.LifecycleState = ""
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition, expinfrav1.InstanceNotFoundReason, clusterv1.ConditionSeverityWarning, "instance %s isn't part of the autoscaling group", m.InstanceID())
+		return
+	}
+
+	status.AvailabilityZone = instance.AvailabilityZone
+	status.LifecycleState = instance.LifecycleState
+	status.HealthStatus = instance.HealthStatus
+	status.LaunchTemplateVersion = instance.LaunchTemplateVersion
+	latestVersion := ptr.Deref(m.AWSMachinePool.Status.LaunchTemplateVersion, "")
+	status.LatestModelApplied = latestVersion != "" && instance.LaunchTemplateVersion == latestVersion
+
+	switch {
+	case instance.LifecycleState != autoscaling.LifecycleStateInService:
+		status.Ready = false
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition, expinfrav1.InstanceNotInServiceReason, clusterv1.ConditionSeverityInfo, "instance is %s", instance.LifecycleState)
+	case instance.HealthStatus == "Unhealthy":
+		status.Ready = false
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition, expinfrav1.InstanceUnhealthyReason, clusterv1.ConditionSeverityWarning, "")
+	default:
+		status.Ready = true
+		conditions.MarkTrue(m.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition)
+	}
+}
+
+// UpdateNodeStatus updates the node reference and readiness of the AWSMachinePoolMachine from the node of its
+// instance, which is listed with the client of the workload cluster.
+func (m *MachinePoolMachineScope) UpdateNodeStatus(ctx context.Context, workloadClient client.Reader) error {
+	node, err := m.getNode(ctx, workloadClient)
+	if err != nil {
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition, expinfrav1.NodeLookupFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	status := &m.AWSMachinePoolMachine.Status
+	if node == nil {
+		status.NodeRef = nil
+		status.Version = nil
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition, expinfrav1.NodeNotFoundReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	status.NodeRef = &corev1.ObjectReference{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "Node",
+		Name:       node.Name,
+		UID:        node.UID,
+	}
+	status.Version = ptr.To(node.Status.NodeInfo.KubeletVersion)
+	if nodeIsReady(*node) {
+		conditions.MarkTrue(m.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition)
+	} else {
+		conditions.MarkFalse(m.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition, expinfrav1.NodeNotReadyReason, clusterv1.ConditionSeverityWarning, "")
+	}
+	return nil
+}
+
+func (m *MachinePoolMachineScope) getNode(ctx context.Context, workloadClient client.Reader) (*corev1.Node, error) {
+	nodeList := corev1.NodeList{}
+	for {
+		if err := workloadClient.List(ctx, &nodeList, client.Continue(nodeList.Continue)); err != nil {
+			return nil, errors.Wrapf(err, "failed to List nodes")
+		}
+
+		for i, node := range nodeList.Items {
+			if strings.HasSuffix(node.Spec.ProviderID, "/"+m.InstanceID()) {
+				return &nodeList.Items[i], nil
+			}
+		}
+
+		if nodeList.Continue == "" {
+			return nil, nil
+		}
+	}
+}
+
+// PatchObject persists the AWSMachinePoolMachine spec and status.
+func (m *MachinePoolMachineScope) PatchObject() error {
+	return m.patchHelper.Patch(
+		context.TODO(),
+		m.AWSMachinePoolMachine,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			expinfrav1.InstanceInServiceCondition,
+			expinfrav1.NodeReadyCondition,
+		}})
+}
+
+// Close the MachinePoolMachineScope by updating the AWSMachinePoolMachine spec and status.
+func (m *MachinePoolMachineScope) Close() error {
+	return m.PatchObject()
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestMachinePoolMachineScopeUpdateInstanceStatus(t *testing.T) {
+	tests := []struct {
+		name                   string
+		instances              []expinfrav1.AWSMachinePoolInstanceStatus
+		wantReady              bool
+		wantLatestModelApplied bool
+		wantReason             string
+	}{
+		{
+			name: "instance in service and healthy",
+			instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-1", LifecycleState: "InService", HealthStatus: "Healthy", LaunchTemplateVersion: "2"},
+			},
+			wantReady:              true,
+			wantLatestModelApplied: true,
+		},
+		{
+			name: "instance launched with an older launch template version",
+			instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-1", LifecycleState: "InService", HealthStatus: "Healthy", LaunchTemplateVersion: "1"},
+			},
+			wantReady: true,
+		},
+		{
+			name: "instance pending",
+			instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-1", LifecycleState: "Pending", HealthStatus: "Healthy", LaunchTemplateVersion: "2"},
+			},
+			wantLatestModelApplied: true,
+			wantReason:             expinfrav1.InstanceNotInServiceReason,
+		},
+		{
+			name: "instance unhealthy",
+			instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-1", LifecycleState: "InService", HealthStatus: "Unhealthy", LaunchTemplateVersion: "2"},
+			},
+			wantLatestModelApplied: true,
+			wantReason:             expinfrav1.InstanceUnhealthyReason,
+		},
+		{
+			name: "instance not part of the autoscaling group",
+			instances: []expinfrav1.AWSMachinePoolInstanceStatus{
+				{InstanceID: "i-2", LifecycleState: "InService", HealthStatus: "Healthy", LaunchTemplateVersion: "2"},
+			},
+			wantReason: expinfrav1.InstanceNotFoundReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &MachinePoolMachineScope{
+				AWSMachinePool: &expinfrav1.AWSMachinePool{
+					Status: expinfrav1.AWSMachinePoolStatus{
+						Instances:             tt.instances,
+						LaunchTemplateVersion: ptr.To("2"),
+					},
+				},
+				AWSMachinePoolMachine: &expinfrav1.AWSMachinePoolMachine{
+					Spec: expinfrav1.AWSMachinePoolMachineSpec{InstanceID: "i-1"},
+				},
+			}
+
+			s.UpdateInstanceStatus()
+			g.Expect(s.AWSMachinePoolMachine.Status.Ready).To(Equal(tt.wantReady))
+			g.Expect(s.AWSMachinePoolMachine.Status.LatestModelApplied).To(Equal(tt.wantLatestModelApplied))
+			if tt.wantReason == "" {
+				g.Expect(conditions.IsTrue(s.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition)).To(BeTrue())
+				return
+			}
+			g.Expect(conditions.GetReason(s.AWSMachinePoolMachine, expinfrav1.InstanceInServiceCondition)).To(Equal(tt.wantReason))
+		})
+	}
+}
+
+func TestMachinePoolMachineScopeUpdateNodeStatus(t *testing.T) {
+	node := func(name, providerID string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{ProviderID: providerID},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.29.0"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		nodes       []*corev1.Node
+		wantNodeRef string
+		wantReason  string
+	}{
+		{
+			name:        "ready node of the instance",
+			nodes:       []*corev1.Node{node("other", "aws:///us-east-1a/i-2", corev1.ConditionTrue), node("node-1", "aws:///us-east-1a/i-1", corev1.ConditionTrue)},
+			wantNodeRef: "node-1",
+		},
+		{
+			name:        "node of the instance not ready",
+			nodes:       []*corev1.Node{node("node-1", "aws:///us-east-1a/i-1", corev1.ConditionFalse)},
+			wantNodeRef: "node-1",
+			wantReason:  expinfrav1.NodeNotReadyReason,
+		},
+		{
+			name:       "node of the instance not registered yet",
+			nodes:      []*corev1.Node{node("other", "aws:///us-east-1a/i-2", corev1.ConditionTrue)},
+			wantReason: expinfrav1.NodeNotFoundReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clientBuilder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, n := range tt.nodes {
+				clientBuilder = clientBuilder.WithObjects(n)
+			}
+
+			s := &MachinePoolMachineScope{
+				AWSMachinePoolMachine: &expinfrav1.AWSMachinePoolMachine{
+					Spec: expinfrav1.AWSMachinePoolMachineSpec{InstanceID: "i-1"},
+				},
+			}
+
+			g.Expect(s.UpdateNodeStatus(context.TODO(), clientBuilder.Build())).To(Succeed())
+			status := s.AWSMachinePoolMachine.Status
+			if tt.wantNodeRef == "" {
+				g.Expect(status.NodeRef).To(BeNil())
+			} else {
+				g.Expect(status.NodeRef.Name).To(Equal(tt.wantNodeRef))
+				g.Expect(status.Version).To(Equal(ptr.To("v1.29.0")))
+			}
+			if tt.wantReason == "" {
+				g.Expect(conditions.IsTrue(s.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition)).To(BeTrue())
+				return
+			}
+			g.Expect(conditions.GetReason(s.AWSMachinePoolMachine, expinfrav1.NodeReadyCondition)).To(Equal(tt.wantReason))
+		})
+	}
+}
@@ -56,6 +56,8 @@ func init() {
 
 // ManagedControlPlaneScopeParams defines the input parameters used to create a new Scope.
 type ManagedControlPlaneScopeParams struct {
+	// Context is the context of the reconcile, used to resolve the region of the control plane.
+	Context        context.Context
 	Client         client.Client
 	Logger         *logger.Logger
 	Cluster        *clusterv1.Cluster
@@ -96,7 +98,16 @@ func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*Manage
 		enableIAM:                    params.EnableIAM,
 		tagUnmanagedNetworkResources: params.TagUnmanagedNetworkResources,
 	}
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	region, err := resolveRegion(ctx, params.Client, params.ControlPlane.Spec.IdentityRef, params.ControlPlane.Spec.Region, params.ControlPlane.Status.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve the region of the control plane")
+	}
+
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, region, params.Endpoints, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -109,6 +120,8 @@ func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*Manage
 		return nil, errors.Wrap(err, "failed to init patch helper")
 	}
 
+	// The region is recorded after the patch helper is initialized, so that it is persisted with the status.
+	params.ControlPlane.Status.Region = region
 	managedScope.patchHelper = helper
 	return managedScope, nil
 }
@@ -230,7 +243,7 @@ func (s *ManagedControlPlaneScope) Namespace() string {
 
 // Region returns the cluster region.
 func (s *ManagedControlPlaneScope) Region() string {
-	return s.ControlPlane.Spec.Region
+	return controlPlaneRegion(s.ControlPlane)
 }
 
 // ListOptionsLabelSelector returns a ListOptions with a label selector for clusterName.
@@ -253,6 +266,7 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
+			infrav1.SecondaryCidrsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneCreatingCondition,
@@ -330,6 +344,18 @@ func (s *ManagedControlPlaneScope) SSHKeyName() *string {
 	return s.ControlPlane.Spec.SSHKeyName
 }
 
+// InstanceAccessMode returns how the instances are accessed. The instances of managed control planes are always
+// accessed with SSH keys.
+func (s *ManagedControlPlaneScope) InstanceAccessMode() infrav1.InstanceAccessMode {
+	return infrav1.InstanceAccessModeSSHKey
+}
+
+// DefaultEBSEncryptionKeyARN returns the ARN of the KMS key encrypting the volumes which don't specify one. Managed
+// control planes don't have a default key, the volumes are encrypted with the default key of the account.
+func (s *ManagedControlPlaneScope) DefaultEBSEncryptionKeyARN() string {
+	return ""
+}
+
 // ControllerName returns the name of the controller that
 // created the ManagedControlPlane.
 func (s *ManagedControlPlaneScope) ControllerName() string {
@@ -376,6 +402,12 @@ func (s *ManagedControlPlaneScope) ImageLookupBaseOS() string {
 	return s.ControlPlane.Spec.ImageLookupBaseOS
 }
 
+// SSMParameterPrefix returns the prefix of the SSM parameters storing the bootstrap data of instances,
+// which is not supported for managed control planes.
+func (s *ManagedControlPlaneScope) SSMParameterPrefix() string {
+	return ""
+}
+
 // IAMAuthConfig returns the IAM authenticator config. The returned value will never be nil.
 func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig {
 	if s.ControlPlane.Spec.IAMAuthenticatorConfig == nil {
@@ -34,6 +34,7 @@ import (
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/mime"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -84,7 +85,7 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 		ControlPlane:   params.ControlPlane,
 		controllerName: params.ControllerName,
 	}
-	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, params.ControlPlane.Spec.Region, params.Endpoints, params.Logger)
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, managedScope, controlPlaneRegion(params.ControlPlane), params.Endpoints, params.Logger)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -166,9 +167,12 @@ func (s *ManagedMachinePoolScope) AllowAdditionalRoles() bool {
 	return s.allowAdditionalRoles
 }
 
-// Partition returns the machine pool subnet IDs.
+// Partition returns the partition of the control plane of the machine pool.
 func (s *ManagedMachinePoolScope) Partition() string {
-	return system.GetPartitionFromRegion(s.ControlPlane.Spec.Region)
+	if s.ControlPlane.Spec.Partition == "" {
+		s.ControlPlane.Spec.Partition = system.GetPartitionFromRegion(controlPlaneRegion(s.ControlPlane))
+	}
+	return s.ControlPlane.Spec.Partition
 }
 
 // IdentityRef returns the cluster identityRef.
@@ -343,9 +347,40 @@ func (s *ManagedMachinePoolScope) GetRawBootstrapData() ([]byte, *types.Namespac
 		return nil, nil, errors.New("error retrieving bootstrap data: secret value key is missing")
 	}
 
+	if s.ManagedMachinePool.Spec.AWSLaunchTemplate != nil && s.ManagedMachinePool.Spec.AdditionalUserDataSecretRef != nil {
+		merged, err := s.mergeAdditionalUserData(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return merged, &key, nil
+	}
+
 	return value, &key, nil
 }
 
+// mergeAdditionalUserData merges the user data referenced by AdditionalUserDataSecretRef with the bootstrap data,
+// so that the user provided customization runs before the node is bootstrapped.
+func (s *ManagedMachinePoolScope) mergeAdditionalUserData(bootstrapData []byte) ([]byte, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: s.Namespace(), Name: *s.ManagedMachinePool.Spec.AdditionalUserDataSecretRef}
+
+	if err := s.Client.Get(context.TODO(), key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve additional user data secret for AWSManagedMachinePool %s/%s", s.Namespace(), s.Name())
+	}
+
+	value, ok := secret.Data["value"]
+	if !ok {
+		return nil, errors.New("error retrieving additional user data: secret value key is missing")
+	}
+
+	merged, err := mime.MergeUserData(value, bootstrapData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to merge additional user data with bootstrap data")
+	}
+
+	return merged, nil
+}
+
 // GetObjectMeta returns the ObjectMeta for the AWSManagedMachinePool.
 func (s *ManagedMachinePoolScope) GetObjectMeta() *metav1.ObjectMeta {
 	return &s.ManagedMachinePool.ObjectMeta
@@ -392,6 +427,16 @@ func (s *ManagedMachinePoolScope) SetLaunchTemplateLatestVersionStatus(version s
 	s.ManagedMachinePool.Status.LaunchTemplateVersion = &version
 }
 
+// LaunchTemplateVersion returns the launch template version the nodegroup should use: the pinned
+// version if the launch template spec pins one, or the latest version from the status otherwise.
+func (s *ManagedMachinePoolScope) LaunchTemplateVersion() *string {
+	lt := s.ManagedMachinePool.Spec.AWSLaunchTemplate
+	if lt != nil && lt.PinnedVersion != nil && *lt.PinnedVersion != expinfrav1.LaunchTemplateLatestVersion {
+		return lt.PinnedVersion
+	}
+	return s.ManagedMachinePool.Status.LaunchTemplateVersion
+}
+
 // GetLaunchTemplate returns the launch template.
 func (s *ManagedMachinePoolScope) GetLaunchTemplate() *expinfrav1.AWSLaunchTemplate {
 	return s.ManagedMachinePool.Spec.AWSLaunchTemplate
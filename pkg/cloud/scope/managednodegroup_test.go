@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"bytes"
+	"net/mail"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestManagedMachinePoolScopeGetRawBootstrapData(t *testing.T) {
+	bootstrapSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-data", Namespace: "default"},
+		Data:       map[string][]byte{"value": []byte("#cloud-config\n")},
+	}
+	additionalUserDataSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "additional-user-data", Namespace: "default"},
+		Data:       map[string][]byte{"value": []byte("#!/bin/bash\necho hello\n")},
+	}
+
+	tests := []struct {
+		name              string
+		awsLaunchTemplate *expinfrav1.AWSLaunchTemplate
+		secretRef         *string
+		expectMerged      bool
+		expectErr         bool
+	}{
+		{
+			name:              "bootstrap data is returned as is without additional user data",
+			awsLaunchTemplate: &expinfrav1.AWSLaunchTemplate{},
+		},
+		{
+			name:              "additional user data is merged with bootstrap data",
+			awsLaunchTemplate: &expinfrav1.AWSLaunchTemplate{},
+			secretRef:         ptr.To[string]("additional-user-data"),
+			expectMerged:      true,
+		},
+		{
+			name:              "missing additional user data secret returns an error",
+			awsLaunchTemplate: &expinfrav1.AWSLaunchTemplate{},
+			secretRef:         ptr.To[string]("missing"),
+			expectErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &ManagedMachinePoolScope{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(bootstrapSecret.DeepCopy(), additionalUserDataSecret.DeepCopy()).Build(),
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{
+						AWSLaunchTemplate:           tt.awsLaunchTemplate,
+						AdditionalUserDataSecretRef: tt.secretRef,
+					},
+				},
+				MachinePool: &expclusterv1.MachinePool{},
+			}
+			s.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = ptr.To[string]("bootstrap-data")
+
+			data, key, err := s.GetRawBootstrapData()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(key.Name).To(Equal("bootstrap-data"))
+
+			if !tt.expectMerged {
+				g.Expect(data).To(Equal(bootstrapSecret.Data["value"]))
+				return
+			}
+			msg, err := mail.ReadMessage(bytes.NewReader(data))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(msg.Header.Get("Content-Type")).To(HavePrefix("multipart/mixed"))
+			g.Expect(string(data)).To(ContainSubstring("echo hello"))
+			g.Expect(string(data)).To(ContainSubstring("#cloud-config"))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// OIDCScope is the interface for the scope to be used with the OIDC service.
+type OIDCScope interface {
+	S3Scope
+
+	// AssociateOIDCProvider returns whether the service account issuer of the cluster should be
+	// published and associated with an IAM OIDC identity provider.
+	AssociateOIDCProvider() bool
+	// OIDCProvider returns the status of the IAM OIDC identity provider of the cluster.
+	OIDCProvider() *infrav1.OIDCProviderStatus
+}
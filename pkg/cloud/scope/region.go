@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+)
+
+// instanceMetadataTimeout bounds the lookup of the region in the instance metadata service, which is not reachable
+// when the controllers don't run on EC2.
+const instanceMetadataTimeout = 2 * time.Second
+
+var (
+	defaultRegionMu sync.RWMutex
+	defaultRegion   string
+
+	// instanceRegion returns the region of the instance the controllers run on. It is replaced in tests.
+	instanceRegion = instanceMetadataRegion
+
+	instanceRegionMu  sync.Mutex
+	instanceRegionVal string
+)
+
+// SetDefaultRegion sets the region of the clusters which set neither their own region nor a default region
+// in their identity.
+func SetDefaultRegion(region string) {
+	defaultRegionMu.Lock()
+	defer defaultRegionMu.Unlock()
+	defaultRegion = region
+}
+
+func getDefaultRegion() string {
+	defaultRegionMu.RLock()
+	defer defaultRegionMu.RUnlock()
+	return defaultRegion
+}
+
+// resolveRegion returns the effective region of a cluster. It is the region of its spec or, when it is omitted,
+// the region resolved by a previous reconcile and recorded in its status, so that it doesn't change, then, in this
+// order, the default region of its identity, the default region of the controllers and the region of the instance
+// they run on, read from the instance metadata service.
+func resolveRegion(ctx context.Context, k8sClient client.Client, identityRef *infrav1.AWSIdentityReference, specRegion, statusRegion string) (string, error) {
+	if specRegion != "" {
+		return specRegion, nil
+	}
+	if statusRegion != "" {
+		return statusRegion, nil
+	}
+
+	identitySpec, err := getIdentitySpec(ctx, k8sClient, identityRef)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get identity for cluster")
+	}
+	if identitySpec != nil && identitySpec.DefaultRegion != "" {
+		return identitySpec.DefaultRegion, nil
+	}
+
+	if region := getDefaultRegion(); region != "" {
+		return region, nil
+	}
+
+	region, err := instanceRegion()
+	if err != nil {
+		return "", errors.Wrap(err, "the region is set neither by the cluster, nor by the defaultRegion of its identity, nor by the --default-region flag, and it can't be read from the instance metadata service")
+	}
+	return region, nil
+}
+
+// instanceMetadataRegion reads the region of the instance the controllers run on from the instance metadata
+// service. A region read successfully is kept, as it doesn't change for the life of the controllers, while a failed
+// read is retried by the next call.
+func instanceMetadataRegion() (string, error) {
+	instanceRegionMu.Lock()
+	defer instanceRegionMu.Unlock()
+
+	if instanceRegionVal != "" {
+		return instanceRegionVal, nil
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		HTTPClient: &http.Client{Timeout: instanceMetadataTimeout},
+		MaxRetries: aws.Int(0),
+	})
+	if err != nil {
+		return "", err
+	}
+	region, err := ec2metadata.New(sess).Region()
+	if err != nil {
+		return "", err
+	}
+	instanceRegionVal = region
+	return region, nil
+}
+
+// AWSClusterRegion returns the effective region of an AWSCluster: the region of its spec or, when it is omitted,
+// the region resolved by the controllers and recorded in its status. It is empty until the controllers resolved the
+// region of an AWSCluster omitting it.
+func AWSClusterRegion(awsCluster *infrav1.AWSCluster) string {
+	if awsCluster.Spec.Region != "" {
+		return awsCluster.Spec.Region
+	}
+	return awsCluster.Status.Region
+}
+
+// ResolveAWSClusterRegion returns the effective region of an AWSCluster, resolving it as the controllers do when the
+// AWSCluster omits it and it wasn't recorded in its status yet.
+func ResolveAWSClusterRegion(ctx context.Context, k8sClient client.Client, awsCluster *infrav1.AWSCluster) (string, error) {
+	return resolveRegion(ctx, k8sClient, awsCluster.Spec.IdentityRef, awsCluster.Spec.Region, awsCluster.Status.Region)
+}
+
+// controlPlaneRegion returns the effective region of an AWSManagedControlPlane.
+func controlPlaneRegion(controlPlane *ekscontrolplanev1.AWSManagedControlPlane) string {
+	if controlPlane.Spec.Region != "" {
+		return controlPlane.Spec.Region
+	}
+	return controlPlane.Status.Region
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestResolveRegion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	roleIdentity := func(name, defaultRegion string) *infrav1.AWSClusterRoleIdentity {
+		return &infrav1.AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: infrav1.AWSClusterRoleIdentitySpec{
+				AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{DefaultRegion: defaultRegion},
+			},
+		}
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects([]client.Object{
+		roleIdentity("with-region", "eu-central-1"),
+		roleIdentity("without-region", ""),
+	}...).Build()
+
+	testCases := []struct {
+		name           string
+		specRegion     string
+		statusRegion   string
+		identity       string
+		defaultRegion  string
+		instanceRegion string
+		expected       string
+		expectErr      bool
+	}{
+		{
+			name:           "the region of the spec takes precedence",
+			specRegion:     "us-west-2",
+			statusRegion:   "us-east-1",
+			identity:       "with-region",
+			defaultRegion:  "eu-west-1",
+			instanceRegion: "ap-south-1",
+			expected:       "us-west-2",
+		},
+		{
+			name:           "the region resolved by a previous reconcile doesn't change",
+			statusRegion:   "us-east-1",
+			identity:       "with-region",
+			defaultRegion:  "eu-west-1",
+			instanceRegion: "ap-south-1",
+			expected:       "us-east-1",
+		},
+		{
+			name:           "the default region of the identity takes precedence over the default region of the controllers",
+			identity:       "with-region",
+			defaultRegion:  "eu-west-1",
+			instanceRegion: "ap-south-1",
+			expected:       "eu-central-1",
+		},
+		{
+			name:           "the default region of the controllers takes precedence over the instance metadata",
+			identity:       "without-region",
+			defaultRegion:  "eu-west-1",
+			instanceRegion: "ap-south-1",
+			expected:       "eu-west-1",
+		},
+		{
+			name:           "the region of the instance is the last resort",
+			identity:       "without-region",
+			instanceRegion: "ap-south-1",
+			expected:       "ap-south-1",
+		},
+		{
+			name:      "an error is returned when the region can't be resolved",
+			identity:  "without-region",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			SetDefaultRegion(tc.defaultRegion)
+			defer SetDefaultRegion("")
+			defer func(f func() (string, error)) { instanceRegion = f }(instanceRegion)
+			instanceRegion = func() (string, error) {
+				if tc.instanceRegion == "" {
+					return "", errors.New("EC2 IMDS unreachable")
+				}
+				return tc.instanceRegion, nil
+			}
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+				Spec: infrav1.AWSClusterSpec{
+					Region:      tc.specRegion,
+					IdentityRef: &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: tc.identity},
+				},
+				Status: infrav1.AWSClusterStatus{Region: tc.statusRegion},
+			}
+			region, err := ResolveAWSClusterRegion(context.Background(), k8sClient, awsCluster)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(region).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestNewClusterScopeRecordsDefaultedRegion(t *testing.T) {
+	g := NewWithT(t)
+	SetDefaultRegion("eu-west-1")
+	defer SetDefaultRegion("")
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+
+	clusterScope, err := NewClusterScope(ClusterScopeParams{
+		Client:     k8sClient,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusterScope.Region()).To(Equal("eu-west-1"))
+	g.Expect(awsCluster.Status.Region).To(Equal("eu-west-1"))
+	g.Expect(AWSClusterRegion(awsCluster)).To(Equal("eu-west-1"))
+
+	// The recorded region is kept when the default region of the controllers changes.
+	SetDefaultRegion("eu-central-1")
+	clusterScope, err = NewClusterScope(ClusterScopeParams{
+		Client:     k8sClient,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+		AWSCluster: awsCluster,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusterScope.Region()).To(Equal("eu-west-1"))
+}
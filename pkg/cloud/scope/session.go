@@ -19,11 +19,15 @@ package scope
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -39,7 +43,9 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/rate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -62,23 +68,109 @@ type ServiceEndpoint struct {
 var sessionCache sync.Map
 var providerCache sync.Map
 
-type sessionCacheEntry struct {
-	session         *session.Session
-	serviceLimiters throttle.ServiceLimiters
-}
+// serviceLimitersCache holds the service limiters shared by the sessions of all the clusters
+// using the same identity, when limiters are scoped to identities.
+var serviceLimitersCache sync.Map
 
-// SessionInterface is the interface for AWSCluster and ManagedCluster to be used to get session using identityRef.
-var SessionInterface interface {
+// ServiceLimiterScope defines which sessions share the client-side AWS API rate limiters.
+type ServiceLimiterScope string
+
+const (
+	// ServiceLimiterScopeCluster gives every cluster its own rate limiters.
+	ServiceLimiterScopeCluster = ServiceLimiterScope("cluster")
+
+	// ServiceLimiterScopeIdentity shares the rate limiters between all the clusters using the same
+	// identity in a region, so the clusters of one tenant cannot exhaust the API budget of others.
+	ServiceLimiterScopeIdentity = ServiceLimiterScope("identity")
+)
+
+var serviceLimiterOptions = struct {
+	mu         sync.RWMutex
+	scope      ServiceLimiterScope
+	multiplier float64
+}{
+	scope:      ServiceLimiterScopeCluster,
+	multiplier: 1,
 }
 
-func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Session, throttle.ServiceLimiters, error) {
-	if s, ok := sessionCache.Load(region); ok {
-		entry := s.(*sessionCacheEntry)
-		return entry.session, entry.serviceLimiters, nil
+// SetServiceLimiterOptions configures which sessions share the client-side AWS API rate limiters and
+// scales their refill rates and bursts by the given multiplier. It only affects sessions created
+// afterwards, so it should be called before the controllers are started.
+func SetServiceLimiterOptions(scope ServiceLimiterScope, multiplier float64) error {
+	if scope != ServiceLimiterScopeCluster && scope != ServiceLimiterScopeIdentity {
+		return errors.Errorf("invalid service limiter scope %q, must be one of %q or %q", scope, ServiceLimiterScopeCluster, ServiceLimiterScopeIdentity)
 	}
+	if multiplier <= 0 {
+		return errors.Errorf("invalid service limiter multiplier %v, must be greater than 0", multiplier)
+	}
+
+	serviceLimiterOptions.mu.Lock()
+	defer serviceLimiterOptions.mu.Unlock()
+	serviceLimiterOptions.scope = scope
+	serviceLimiterOptions.multiplier = multiplier
+	return nil
+}
+
+// endpointOptions forces the use of the FIPS and dual-stack endpoints of the AWS services.
+var endpointOptions = struct {
+	mu           sync.RWMutex
+	useFIPS      bool
+	useDualStack bool
+}{}
+
+// SetEndpointOptions forces the use of the FIPS and the dual-stack endpoints of the AWS services by the
+// sessions, as well as by the STS clients assuming the roles of the identities. The custom service endpoints
+// are used as is. It only affects sessions created afterwards, so it should be called before the controllers
+// are started.
+func SetEndpointOptions(useFIPS, useDualStack bool) {
+	endpointOptions.mu.Lock()
+	defer endpointOptions.mu.Unlock()
+	endpointOptions.useFIPS = useFIPS
+	endpointOptions.useDualStack = useDualStack
+
+	identity.SetSTSEndpointOptions(useFIPS, useDualStack)
+}
+
+// requestTimeout bounds each call to the AWS APIs made with the sessions, retries included.
+var requestTimeout = struct {
+	mu      sync.RWMutex
+	timeout time.Duration
+}{}
+
+// SetRequestTimeout sets the deadline of each call to the AWS APIs, retries included, made by the services with the
+// sessions. The context of a call is the one the service is called with, so a call is also cancelled with the
+// reconcile, and zero leaves it without other deadline. It only affects sessions created afterwards, so it should
+// be called before the controllers are started.
+func SetRequestTimeout(timeout time.Duration) {
+	requestTimeout.mu.Lock()
+	defer requestTimeout.mu.Unlock()
+	requestTimeout.timeout = timeout
+}
+
+// addRequestDeadline adds the handlers setting the deadline of the calls made with the session, if any.
+func addRequestDeadline(sess *session.Session) {
+	requestTimeout.mu.RLock()
+	timeout := requestTimeout.timeout
+	requestTimeout.mu.RUnlock()
+	if timeout <= 0 {
+		return
+	}
+
+	sess.Handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: "capa/RequestDeadline",
+		Fn: func(r *request.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			r.SetContext(ctx)
+			r.Handlers.Complete.PushBack(func(*request.Request) { cancel() })
+		},
+	})
+}
 
+// newSessionConfig returns the configuration of a session in a region, resolving the endpoints of
+// the services with the custom service endpoints first.
+func newSessionConfig(region string, serviceEndpoints []ServiceEndpoint) *aws.Config {
 	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-		for _, s := range endpoint {
+		for _, s := range serviceEndpoints {
 			if service == s.ServiceID {
 				return endpoints.ResolvedEndpoint{
 					URL:           s.URL,
@@ -88,15 +180,48 @@ func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Sessi
 		}
 		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
 	}
-	ns, err := session.NewSession(&aws.Config{
+	awsConfig := &aws.Config{
 		Region:           aws.String(region),
 		EndpointResolver: endpoints.ResolverFunc(resolver),
-	})
+	}
+
+	endpointOptions.mu.RLock()
+	defer endpointOptions.mu.RUnlock()
+	if endpointOptions.useFIPS {
+		awsConfig.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if endpointOptions.useDualStack {
+		awsConfig.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+	return awsConfig
+}
+
+type sessionCacheEntry struct {
+	session         *session.Session
+	serviceLimiters throttle.ServiceLimiters
+	endpoints       []ServiceEndpoint
+	proxy           *infrav1.ProxyConfig
+	// invalidation is the value of the InvalidateCredentialsAnnotation of the cluster when the session was created.
+	invalidation string
+}
+
+// SessionInterface is the interface for AWSCluster and ManagedCluster to be used to get session using identityRef.
+var SessionInterface interface {
+}
+
+func sessionForRegion(region string, endpoint []ServiceEndpoint) (*session.Session, throttle.ServiceLimiters, error) {
+	if s, ok := sessionCache.Load(region); ok {
+		entry := s.(*sessionCacheEntry)
+		return entry.session, entry.serviceLimiters, nil
+	}
+
+	ns, err := session.NewSession(newSessionConfig(region, endpoint))
 	if err != nil {
 		return nil, nil, err
 	}
+	addRequestDeadline(ns)
 
-	sl := newServiceLimiters()
+	sl := newServiceLimiters("")
 	sessionCache.Store(region, &sessionCacheEntry{
 		session:         ns,
 		serviceLimiters: sl,
@@ -108,18 +233,6 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 	log = log.WithName("identity")
 	log.Trace("Creating an AWS Session")
 
-	resolver := func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-		for _, s := range endpoint {
-			if service == s.ServiceID {
-				return endpoints.ResolvedEndpoint{
-					URL:           s.URL,
-					SigningRegion: s.SigningRegion,
-				}, nil
-			}
-		}
-		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
-	}
-
 	providers, err := getProvidersForCluster(context.Background(), k8sClient, clusterScoper, region, log)
 	if err != nil {
 		// could not get providers and retrieve the credentials
@@ -127,6 +240,27 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 		return nil, nil, errors.Wrap(err, "Failed to get providers for cluster")
 	}
 
+	identitySpec, err := getIdentitySpec(context.Background(), k8sClient, clusterScoper.IdentityRef())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to get identity for cluster")
+	}
+	endpoint = getServiceEndpointsForCluster(clusterScoper, identitySpec, region, endpoint)
+	var proxy *infrav1.ProxyConfig
+	if identitySpec != nil {
+		proxy = identitySpec.Proxy
+	}
+
+	identityName := identityLabel(clusterScoper.IdentityRef())
+	sessionName := getSessionName(region, clusterScoper)
+	invalidation := clusterScoper.InfraCluster().GetAnnotations()[infrav1.InvalidateCredentialsAnnotation]
+	var cachedEntry *sessionCacheEntry
+	if s, ok := sessionCache.Load(sessionName); ok {
+		cachedEntry = s.(*sessionCacheEntry)
+	}
+	// A new value of the annotation drops the cached session and the cached providers of the cluster, so that the
+	// credentials of its identity are retrieved again.
+	forceInvalidation := cachedEntry != nil && cachedEntry.invalidation != invalidation
+
 	isChanged := false
 	awsProviders := make([]credentials.Provider, len(providers))
 	for i, provider := range providers {
@@ -135,6 +269,9 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "Failed to calculate provider hash")
 		}
+		if forceInvalidation {
+			providerCache.Delete(providerHash)
+		}
 		cachedProvider, ok := providerCache.Load(providerHash)
 		if ok {
 			provider = cachedProvider.(identity.AWSPrincipalTypeProvider)
@@ -146,15 +283,27 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 		awsProviders[i] = provider.(credentials.Provider)
 	}
 
-	if !isChanged {
-		if s, ok := sessionCache.Load(getSessionName(region, clusterScoper)); ok {
-			entry := s.(*sessionCacheEntry)
-			return entry.session, entry.serviceLimiters, nil
+	if cachedEntry != nil {
+		switch {
+		case forceInvalidation:
+			awsmetrics.RecordSessionCacheInvalidation(identityName, awsmetrics.InvalidationForced)
+		case isChanged:
+			awsmetrics.RecordSessionCacheInvalidation(identityName, awsmetrics.InvalidationCredentialsChanged)
+		case !cmp.Equal(cachedEntry.endpoints, endpoint) || !cmp.Equal(cachedEntry.proxy, proxy):
+			awsmetrics.RecordSessionCacheInvalidation(identityName, awsmetrics.InvalidationConfigChanged)
+		default:
+			awsmetrics.RecordSessionCacheLookup(identityName, awsmetrics.SessionCacheHit)
+			return cachedEntry.session, cachedEntry.serviceLimiters, nil
 		}
 	}
-	awsConfig := &aws.Config{
-		Region:           aws.String(region),
-		EndpointResolver: endpoints.ResolverFunc(resolver),
+	awsmetrics.RecordSessionCacheLookup(identityName, awsmetrics.SessionCacheMiss)
+	awsConfig := newSessionConfig(region, endpoint)
+	httpClient, err := identity.HTTPClient(proxy)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to configure the proxy of the identity")
+	}
+	if httpClient != nil {
+		awsConfig = awsConfig.WithHTTPClient(httpClient)
 	}
 
 	if len(providers) > 0 {
@@ -164,7 +313,9 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 			conditions.MarkUnknown(clusterScoper.InfraCluster(), infrav1.PrincipalCredentialRetrievedCondition, infrav1.CredentialProviderBuildFailedReason, err.Error())
 
 			// delete the existing session from cache. Otherwise, we give back a defective session on next method invocation with same cluster scope
-			sessionCache.Delete(getSessionName(region, clusterScoper))
+			if _, ok := sessionCache.LoadAndDelete(sessionName); ok {
+				awsmetrics.RecordSessionCacheInvalidation(identityName, awsmetrics.InvalidationRetrieveFailed)
+			}
 
 			return nil, nil, errors.Wrap(err, "Failed to retrieve identity credentials")
 		}
@@ -177,27 +328,154 @@ func sessionForClusterWithRegion(k8sClient client.Client, clusterScoper cloud.Se
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to create a new AWS session")
 	}
-	sl := newServiceLimiters()
-	sessionCache.Store(getSessionName(region, clusterScoper), &sessionCacheEntry{
+	addRequestDeadline(ns)
+	sl := serviceLimitersForCluster(region, clusterScoper)
+	sessionCache.Store(sessionName, &sessionCacheEntry{
 		session:         ns,
 		serviceLimiters: sl,
+		endpoints:       endpoint,
+		proxy:           proxy,
+		invalidation:    invalidation,
 	})
 
 	return ns, sl, nil
 }
 
+// getServiceEndpointsForCluster returns the service endpoints of the session of a cluster. The endpoints of
+// the AWSCluster take precedence over the endpoints of its identity, which take precedence over the endpoints
+// of the controllers. The endpoints of the AWSCluster and of its identity are signed for the region of the cluster.
+func getServiceEndpointsForCluster(clusterScoper cloud.SessionMetadata, identitySpec *infrav1.AWSClusterIdentitySpec, region string, controllerEndpoints []ServiceEndpoint) []ServiceEndpoint {
+	overrides := infrav1.ServiceEndpoints{}
+
+	if identitySpec != nil {
+		for serviceID, url := range identitySpec.ServiceEndpoints {
+			overrides[serviceID] = url
+		}
+	}
+
+	if awsCluster, ok := clusterScoper.InfraCluster().(*infrav1.AWSCluster); ok {
+		for serviceID, url := range awsCluster.Spec.ServiceEndpoints {
+			overrides[serviceID] = url
+		}
+	}
+
+	if len(overrides) == 0 {
+		return controllerEndpoints
+	}
+
+	serviceIDs := make([]string, 0, len(overrides))
+	for serviceID := range overrides {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	sort.Strings(serviceIDs)
+
+	// The overrides come first, as the first endpoint of a service is used.
+	serviceEndpoints := make([]ServiceEndpoint, 0, len(overrides)+len(controllerEndpoints))
+	for _, serviceID := range serviceIDs {
+		serviceEndpoints = append(serviceEndpoints, ServiceEndpoint{
+			ServiceID:     serviceID,
+			URL:           overrides[serviceID],
+			SigningRegion: region,
+		})
+	}
+	return append(serviceEndpoints, controllerEndpoints...)
+}
+
+// getIdentitySpec returns the spec common to all the kinds of identities, or nil when no identity is referenced.
+func getIdentitySpec(ctx context.Context, k8sClient client.Client, ref *infrav1.AWSIdentityReference) (*infrav1.AWSClusterIdentitySpec, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	// spec points to the spec of the identity, so that it is set once the identity is retrieved.
+	var identity client.Object
+	var spec *infrav1.AWSClusterIdentitySpec
+	switch ref.Kind {
+	case infrav1.ControllerIdentityKind:
+		controllerIdentity := &infrav1.AWSClusterControllerIdentity{}
+		identity, spec = controllerIdentity, &controllerIdentity.Spec.AWSClusterIdentitySpec
+	case infrav1.ClusterStaticIdentityKind:
+		staticIdentity := &infrav1.AWSClusterStaticIdentity{}
+		identity, spec = staticIdentity, &staticIdentity.Spec.AWSClusterIdentitySpec
+	case infrav1.ClusterRoleIdentityKind:
+		roleIdentity := &infrav1.AWSClusterRoleIdentity{}
+		identity, spec = roleIdentity, &roleIdentity.Spec.AWSClusterIdentitySpec
+	case infrav1.ClusterWebIdentityKind:
+		webIdentity := &infrav1.AWSClusterWebIdentity{}
+		identity, spec = webIdentity, &webIdentity.Spec.AWSClusterIdentitySpec
+	default:
+		return nil, errors.Errorf("No such provider known: '%s'", ref.Kind)
+	}
+
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ref.Name}, identity); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// identityLabel returns the label of the given identity in the metrics of the session cache. Clusters without an
+// identity use the credentials of the controllers.
+func identityLabel(ref *infrav1.AWSIdentityReference) string {
+	if ref == nil {
+		return fmt.Sprintf("%s/%s", infrav1.ControllerIdentityKind, infrav1.AWSClusterControllerIdentityName)
+	}
+	return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+}
+
 func getSessionName(region string, clusterScoper cloud.SessionMetadata) string {
 	return fmt.Sprintf("%s-%s-%s", region, clusterScoper.InfraClusterName(), clusterScoper.Namespace())
 }
 
-func newServiceLimiters() throttle.ServiceLimiters {
-	return throttle.ServiceLimiters{
+// serviceLimitersForCluster returns the service limiters for the session of a cluster, according
+// to the configured ServiceLimiterScope.
+func serviceLimitersForCluster(region string, clusterScoper cloud.SessionMetadata) throttle.ServiceLimiters {
+	serviceLimiterOptions.mu.RLock()
+	scope := serviceLimiterOptions.scope
+	serviceLimiterOptions.mu.RUnlock()
+
+	if scope != ServiceLimiterScopeIdentity {
+		return newServiceLimiters("")
+	}
+
+	key := getServiceLimitersKey(region, clusterScoper.IdentityRef())
+	if sl, ok := serviceLimitersCache.Load(key); ok {
+		return sl.(throttle.ServiceLimiters)
+	}
+	sl, _ := serviceLimitersCache.LoadOrStore(key, newServiceLimiters(key))
+	return sl.(throttle.ServiceLimiters)
+}
+
+// getServiceLimitersKey returns the key of the service limiters shared by the clusters using an identity in a region.
+// Clusters without an identity use the controller's credentials, the same as AWSClusterControllerIdentity.
+func getServiceLimitersKey(region string, ref *infrav1.AWSIdentityReference) string {
+	if ref == nil {
+		return fmt.Sprintf("%s-%s-%s", region, infrav1.ControllerIdentityKind, infrav1.AWSClusterControllerIdentityName)
+	}
+	return fmt.Sprintf("%s-%s-%s", region, ref.Kind, ref.Name)
+}
+
+// newServiceLimiters returns new service limiters, identified by the key in the metrics.
+func newServiceLimiters(key string) throttle.ServiceLimiters {
+	serviceLimiterOptions.mu.RLock()
+	multiplier := serviceLimiterOptions.multiplier
+	serviceLimiterOptions.mu.RUnlock()
+
+	limiters := throttle.ServiceLimiters{
 		ec2.ServiceID:                      newEC2ServiceLimiter(),
 		elb.ServiceID:                      newGenericServiceLimiter(),
 		elbv2.ServiceID:                    newGenericServiceLimiter(),
 		resourcegroupstaggingapi.ServiceID: newGenericServiceLimiter(),
 		secretsmanager.ServiceID:           newGenericServiceLimiter(),
 	}
+	for _, sl := range limiters {
+		for _, ol := range *sl {
+			ol.Key = key
+			ol.RefillRate *= rate.Limit(multiplier)
+			ol.Burst = int(math.Max(1, math.Round(float64(ol.Burst)*multiplier)))
+		}
+	}
+	return limiters
 }
 
 func newGenericServiceLimiter() *throttle.ServiceLimiter {
@@ -314,7 +592,29 @@ func buildProvidersForRef(
 			}
 		}
 
-		provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, sourceProvider, region, log)
+		provider = identity.NewAWSRolePrincipalTypeProvider(roleIdentity, sourceProvider, region, clusterNameForSessionTags(clusterScoper), clusterScoper.Namespace(), log)
+		providers = append(providers, provider)
+	case infrav1.ClusterWebIdentityKind:
+		webIdentity := &infrav1.AWSClusterWebIdentity{}
+		err := k8sClient.Get(ctx, identityObjectKey, webIdentity)
+		if err != nil {
+			return providers, err
+		}
+		log.Trace("Principal retrieved")
+		canUse, err := isClusterPermittedToUsePrincipal(k8sClient, webIdentity.Spec.AllowedNamespaces, clusterScoper.Namespace())
+		if err != nil {
+			return providers, err
+		}
+		if !canUse {
+			setPrincipalUsageNotAllowedCondition(infrav1.ClusterWebIdentityKind, identityObjectKey, clusterScoper)
+			return providers, errors.Errorf(notPermittedError, infrav1.ClusterWebIdentityKind, webIdentity.Name)
+		}
+		setPrincipalUsageAllowedCondition(clusterScoper)
+
+		provider, err = identity.NewAWSWebIdentityPrincipalTypeProvider(webIdentity, region, log)
+		if err != nil {
+			return providers, err
+		}
 		providers = append(providers, provider)
 	default:
 		return providers, errors.Errorf("No such provider known: '%s'", ref.Kind)
@@ -376,7 +676,65 @@ func buildAWSClusterStaticIdentity(ctx context.Context, identityObjectKey client
 	}
 	setPrincipalUsageAllowedCondition(clusterScoper)
 
-	return identity.NewAWSStaticPrincipalTypeProvider(staticPrincipal, secret), nil
+	secretKey := client.ObjectKeyFromObject(secret)
+	refreshSecret := func() (*corev1.Secret, error) {
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(context.Background(), secretKey, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+	return identity.NewAWSStaticPrincipalTypeProvider(staticPrincipal, secret, refreshSecret), nil
+}
+
+// IdentityRefsForSecret returns the references to the identities whose credentials come from the given secret: the
+// AWSClusterStaticIdentities referencing the secret, and the AWSClusterRoleIdentities chained to them.
+func IdentityRefsForSecret(ctx context.Context, k8sClient client.Client, secret client.Object) ([]infrav1.AWSIdentityReference, error) {
+	if secret.GetNamespace() != system.GetManagerNamespace() {
+		return nil, nil
+	}
+
+	staticIdentities := &infrav1.AWSClusterStaticIdentityList{}
+	if err := k8sClient.List(ctx, staticIdentities); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSClusterStaticIdentities")
+	}
+	refs := []infrav1.AWSIdentityReference{}
+	for _, staticIdentity := range staticIdentities.Items {
+		if staticIdentity.Spec.SecretRef == secret.GetName() {
+			refs = append(refs, infrav1.AWSIdentityReference{Kind: infrav1.ClusterStaticIdentityKind, Name: staticIdentity.Name})
+		}
+	}
+	if len(refs) == 0 {
+		return refs, nil
+	}
+
+	roleIdentities := &infrav1.AWSClusterRoleIdentityList{}
+	if err := k8sClient.List(ctx, roleIdentities); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSClusterRoleIdentities")
+	}
+	// Role identities can be chained to each other, so the chains are followed until no identity is added.
+	for added := true; added; {
+		added = false
+		for _, roleIdentity := range roleIdentities.Items {
+			ref := infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: roleIdentity.Name}
+			source := roleIdentity.Spec.SourceIdentityRef
+			if source == nil || containsIdentityRef(refs, ref) || !containsIdentityRef(refs, *source) {
+				continue
+			}
+			refs = append(refs, ref)
+			added = true
+		}
+	}
+	return refs, nil
+}
+
+func containsIdentityRef(refs []infrav1.AWSIdentityReference, ref infrav1.AWSIdentityReference) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
 }
 
 func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey client.ObjectKey, k8sClient client.Client, clusterScoper cloud.SessionMetadata) error {
@@ -405,6 +763,15 @@ func buildAWSClusterControllerIdentity(ctx context.Context, identityObjectKey cl
 	return nil
 }
 
+// clusterNameForSessionTags returns the name of the cluster of the infrastructure cluster, falling back to the
+// name of the infrastructure cluster when it isn't labelled with it yet.
+func clusterNameForSessionTags(clusterScoper cloud.SessionMetadata) string {
+	if name := clusterScoper.InfraCluster().GetLabels()[clusterv1.ClusterNameLabel]; name != "" {
+		return name
+	}
+	return clusterScoper.InfraClusterName()
+}
+
 func getProvidersForCluster(ctx context.Context, k8sClient client.Client, clusterScoper cloud.SessionMetadata, region string, log logger.Wrapper) ([]identity.AWSPrincipalTypeProvider, error) {
 	providers := make([]identity.AWSPrincipalTypeProvider, 0)
 	providers, err := buildProvidersForRef(ctx, providers, k8sClient, clusterScoper, clusterScoper.IdentityRef(), region, log)
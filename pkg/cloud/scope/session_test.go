@@ -18,8 +18,17 @@ package scope
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +41,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/identity"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -477,6 +487,101 @@ func TestPrincipalParsing(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Can get a session for a web identity Principal",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster4",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "web-identity",
+						Kind: infrav1.ClusterWebIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				identity := &infrav1.AWSClusterWebIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "web-identity",
+					},
+					Spec: infrav1.AWSClusterWebIdentitySpec{
+						AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+							AllowedNamespaces: &infrav1.AllowedNamespaces{},
+						},
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "web-role-arn",
+						},
+						TokenFile: "/var/run/secrets/capa/token",
+					},
+				}
+				identity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterWebIdentity"))
+				err := c.Create(context.Background(), identity)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(providers []identity.AWSPrincipalTypeProvider) {
+				if len(providers) != 1 {
+					t.Fatalf("Expected 1 providers, got %v", len(providers))
+				}
+				provider := providers[0]
+				p, ok := provider.(*identity.AWSWebIdentityPrincipalTypeProvider)
+				if !ok {
+					t.Fatal("Expected providers to be of type AWSWebIdentityPrincipalTypeProvider")
+				}
+				if p.Principal.Spec.TokenFile != "/var/run/secrets/capa/token" {
+					t.Fatal(errors.Errorf("Expected Web Identity Provider token file to be '/var/run/secrets/capa/token', got '%s'", p.Principal.Spec.TokenFile))
+				}
+			},
+		},
+		{
+			name: "Cannot get a session for a web identity Principal from a namespace that is not allowed",
+			awsCluster: infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster5",
+					Namespace: "default",
+				},
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "AWSCluster",
+				},
+				Spec: infrav1.AWSClusterSpec{
+					IdentityRef: &infrav1.AWSIdentityReference{
+						Name: "web-identity",
+						Kind: infrav1.ClusterWebIdentityKind,
+					},
+				},
+			},
+			setup: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				identity := &infrav1.AWSClusterWebIdentity{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "web-identity",
+					},
+					Spec: infrav1.AWSClusterWebIdentitySpec{
+						AWSRoleSpec: infrav1.AWSRoleSpec{
+							RoleArn: "web-role-arn",
+						},
+						TokenFile: "/var/run/secrets/capa/token",
+					},
+				}
+				identity.SetGroupVersionKind(infrav1.GroupVersion.WithKind("AWSClusterWebIdentity"))
+				err := c.Create(context.Background(), identity)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -503,3 +608,355 @@ func TestPrincipalParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceLimitersForCluster(t *testing.T) {
+	newClusterScope := func(name string, identityRef *infrav1.AWSIdentityReference) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			},
+			AWSCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       infrav1.AWSClusterSpec{IdentityRef: identityRef},
+			},
+		}
+	}
+	tenantA := &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "tenant-a"}
+	tenantB := &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "tenant-b"}
+
+	t.Run("clusters get their own limiters with the cluster scope", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeCluster, 1)).To(Succeed())
+
+		first := serviceLimitersForCluster("us-west-2", newClusterScope("first", tenantA))
+		second := serviceLimitersForCluster("us-west-2", newClusterScope("second", tenantA))
+		g.Expect(first[ec2.ServiceID]).ToNot(BeIdenticalTo(second[ec2.ServiceID]))
+		g.Expect((*first[ec2.ServiceID])[0].Key).To(BeEmpty())
+	})
+
+	t.Run("clusters using the same identity share limiters with the identity scope", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeIdentity, 1)).To(Succeed())
+		defer func() { g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeCluster, 1)).To(Succeed()) }()
+
+		first := serviceLimitersForCluster("eu-west-1", newClusterScope("first", tenantA))
+		second := serviceLimitersForCluster("eu-west-1", newClusterScope("second", tenantA))
+		other := serviceLimitersForCluster("eu-west-1", newClusterScope("third", tenantB))
+		otherRegion := serviceLimitersForCluster("eu-west-2", newClusterScope("first", tenantA))
+		g.Expect(first[ec2.ServiceID]).To(BeIdenticalTo(second[ec2.ServiceID]))
+		g.Expect(first[ec2.ServiceID]).ToNot(BeIdenticalTo(other[ec2.ServiceID]))
+		g.Expect(first[ec2.ServiceID]).ToNot(BeIdenticalTo(otherRegion[ec2.ServiceID]))
+		shared, ok := serviceLimitersCache.Load("eu-west-1-AWSClusterRoleIdentity-tenant-a")
+		g.Expect(ok).To(BeTrue())
+		g.Expect(shared.(throttle.ServiceLimiters)[ec2.ServiceID]).To(BeIdenticalTo(first[ec2.ServiceID]))
+		g.Expect((*first[ec2.ServiceID])[0].Key).To(Equal("eu-west-1-AWSClusterRoleIdentity-tenant-a"))
+
+		controller := serviceLimitersForCluster("eu-west-1", newClusterScope("fourth", nil))
+		shared, ok = serviceLimitersCache.Load("eu-west-1-AWSClusterControllerIdentity-default")
+		g.Expect(ok).To(BeTrue())
+		g.Expect(shared.(throttle.ServiceLimiters)[ec2.ServiceID]).To(BeIdenticalTo(controller[ec2.ServiceID]))
+		g.Expect((*controller[ec2.ServiceID])[0].Key).To(Equal("eu-west-1-AWSClusterControllerIdentity-default"))
+	})
+
+	t.Run("the multiplier scales refill rates and bursts", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeCluster, 0.5)).To(Succeed())
+		defer func() { g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeCluster, 1)).To(Succeed()) }()
+
+		limiters := serviceLimitersForCluster("us-east-1", newClusterScope("scaled", nil))
+		g.Expect(float64((*limiters[ec2.ServiceID])[0].RefillRate)).To(Equal(10.0))
+		g.Expect((*limiters[ec2.ServiceID])[0].Burst).To(Equal(50))
+	})
+
+	t.Run("invalid options are rejected", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(SetServiceLimiterOptions("tenant", 1)).ToNot(Succeed())
+		g.Expect(SetServiceLimiterOptions(ServiceLimiterScopeIdentity, 0)).ToNot(Succeed())
+	})
+}
+
+func TestGetServiceEndpointsForCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	roleIdentity := &infrav1.AWSClusterRoleIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+				ServiceEndpoints: infrav1.ServiceEndpoints{
+					"ec2": "https://ec2.identity.example.com",
+					"sts": "https://sts.identity.example.com",
+				},
+			},
+		},
+	}
+	newClusterScope := func(identityRef *infrav1.AWSIdentityReference, serviceEndpoints infrav1.ServiceEndpoints) *ClusterScope {
+		return &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+			},
+			AWSCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+				Spec:       infrav1.AWSClusterSpec{IdentityRef: identityRef, ServiceEndpoints: serviceEndpoints},
+			},
+		}
+	}
+	controllerEndpoints := []ServiceEndpoint{
+		{ServiceID: "ec2", URL: "https://ec2.controller.example.com", SigningRegion: "us-east-1"},
+		{ServiceID: "eks", URL: "https://eks.controller.example.com", SigningRegion: "us-east-1"},
+	}
+	tenantA := &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "tenant-a"}
+
+	testCases := []struct {
+		name         string
+		clusterScope *ClusterScope
+		expected     []ServiceEndpoint
+		expectError  bool
+	}{
+		{
+			name:         "uses the endpoints of the controllers by default",
+			clusterScope: newClusterScope(nil, nil),
+			expected:     controllerEndpoints,
+		},
+		{
+			name:         "the endpoints of the identity take precedence over the ones of the controllers",
+			clusterScope: newClusterScope(tenantA, nil),
+			expected: append([]ServiceEndpoint{
+				{ServiceID: "ec2", URL: "https://ec2.identity.example.com", SigningRegion: "eu-west-1"},
+				{ServiceID: "sts", URL: "https://sts.identity.example.com", SigningRegion: "eu-west-1"},
+			}, controllerEndpoints...),
+		},
+		{
+			name:         "the endpoints of the cluster take precedence over the ones of the identity",
+			clusterScope: newClusterScope(tenantA, infrav1.ServiceEndpoints{"ec2": "https://ec2.cluster.example.com"}),
+			expected: append([]ServiceEndpoint{
+				{ServiceID: "ec2", URL: "https://ec2.cluster.example.com", SigningRegion: "eu-west-1"},
+				{ServiceID: "sts", URL: "https://sts.identity.example.com", SigningRegion: "eu-west-1"},
+			}, controllerEndpoints...),
+		},
+		{
+			name:         "fails when the identity doesn't exist",
+			clusterScope: newClusterScope(&infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "tenant-b"}, nil),
+			expectError:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(roleIdentity.DeepCopy()).Build()
+
+			identitySpec, err := getIdentitySpec(context.TODO(), k8sClient, tc.clusterScope.IdentityRef())
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			serviceEndpoints := getServiceEndpointsForCluster(tc.clusterScope, identitySpec, "eu-west-1", controllerEndpoints)
+			g.Expect(serviceEndpoints).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestNewSessionConfigEndpointOptions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		useFIPS          bool
+		useDualStack     bool
+		serviceEndpoints []ServiceEndpoint
+		expected         string
+	}{
+		{
+			name:     "uses the default endpoints",
+			expected: "https://ec2.us-east-1.amazonaws.com",
+		},
+		{
+			name:     "uses the FIPS endpoints",
+			useFIPS:  true,
+			expected: "https://ec2-fips.us-east-1.amazonaws.com",
+		},
+		{
+			name:         "uses the dual-stack endpoints",
+			useDualStack: true,
+			expected:     "https://ec2.us-east-1.api.aws",
+		},
+		{
+			name:             "uses the custom endpoints as is",
+			useFIPS:          true,
+			useDualStack:     true,
+			serviceEndpoints: []ServiceEndpoint{{ServiceID: "ec2", URL: "https://ec2.example.com", SigningRegion: "us-east-1"}},
+			expected:         "https://ec2.example.com",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			SetEndpointOptions(tc.useFIPS, tc.useDualStack)
+			defer SetEndpointOptions(false, false)
+
+			sess, err := session.NewSession(newSessionConfig("us-east-1", tc.serviceEndpoints))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(ec2.New(sess).Endpoint).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestRequestDeadline(t *testing.T) {
+	// The EC2 API doesn't answer until the call is aborted, or the test is done.
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-stop:
+		}
+	}))
+	defer server.Close()
+	defer close(stop)
+
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+	}{
+		{
+			name:    "aborts the calls exceeding the deadline",
+			timeout: 100 * time.Millisecond,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name: "aborts the calls whose context expires",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			SetRequestTimeout(tc.timeout)
+			defer SetRequestTimeout(0)
+
+			sess, err := session.NewSession(&aws.Config{
+				Region:      aws.String("us-east-1"),
+				Endpoint:    aws.String(server.URL),
+				Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+				MaxRetries:  aws.Int(0),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			addRequestDeadline(sess)
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			done := make(chan error)
+			go func() {
+				_, err := ec2.New(sess).DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{})
+				done <- err
+			}()
+
+			var callErr error
+			g.Eventually(done, 5*time.Second).Should(Receive(&callErr))
+			var awsErr awserr.Error
+			g.Expect(errors.As(callErr, &awsErr)).To(BeTrue())
+			g.Expect(awsErr.Code()).To(Equal(request.CanceledErrorCode))
+		})
+	}
+}
+
+func TestIdentityRefsForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	staticIdentity := func(name, secretRef string) *infrav1.AWSClusterStaticIdentity {
+		return &infrav1.AWSClusterStaticIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       infrav1.AWSClusterStaticIdentitySpec{SecretRef: secretRef},
+		}
+	}
+	roleIdentity := func(name string, source infrav1.AWSIdentityReference) *infrav1.AWSClusterRoleIdentity {
+		return &infrav1.AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       infrav1.AWSClusterRoleIdentitySpec{SourceIdentityRef: &source},
+		}
+	}
+	staticRef := func(name string) infrav1.AWSIdentityReference {
+		return infrav1.AWSIdentityReference{Kind: infrav1.ClusterStaticIdentityKind, Name: name}
+	}
+	roleRef := func(name string) infrav1.AWSIdentityReference {
+		return infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: name}
+	}
+	objects := []client.Object{
+		staticIdentity("static-a", "creds-a"),
+		staticIdentity("static-b", "creds-b"),
+		roleIdentity("role-a", staticRef("static-a")),
+		roleIdentity("role-chained", roleRef("role-a")),
+		roleIdentity("role-b", staticRef("static-b")),
+		roleIdentity("role-controller", infrav1.AWSIdentityReference{Kind: infrav1.ControllerIdentityKind, Name: "default"}),
+	}
+
+	testCases := []struct {
+		name     string
+		secret   *corev1.Secret
+		expected []infrav1.AWSIdentityReference
+	}{
+		{
+			name:     "returns the static identity of the secret and the role identities chained to it",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds-a", Namespace: system.GetManagerNamespace()}},
+			expected: []infrav1.AWSIdentityReference{staticRef("static-a"), roleRef("role-a"), roleRef("role-chained")},
+		},
+		{
+			name:     "returns nothing for a secret which isn't used by an identity",
+			secret:   &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: system.GetManagerNamespace()}},
+			expected: []infrav1.AWSIdentityReference{},
+		},
+		{
+			name:   "ignores the secrets outside of the namespace of the controller",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds-a", Namespace: "default"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			refs, err := IdentityRefsForSecret(context.TODO(), k8sClient, tc.secret)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(refs).To(ConsistOf(tc.expected))
+		})
+	}
+}
+
+func TestSessionForClusterWithRegionForcedInvalidation(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	log := logger.NewLogger(klog.Background())
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalidated", Namespace: "default"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalidated", Namespace: "default"},
+			Spec:       infrav1.AWSClusterSpec{Region: "ap-south-2"},
+		},
+	}
+
+	first, _, err := sessionForClusterWithRegion(k8sClient, clusterScope, "ap-south-2", nil, log)
+	g.Expect(err).ToNot(HaveOccurred())
+	cached, _, err := sessionForClusterWithRegion(k8sClient, clusterScope, "ap-south-2", nil, log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cached).To(BeIdenticalTo(first))
+
+	clusterScope.AWSCluster.Annotations = map[string]string{infrav1.InvalidateCredentialsAnnotation: "2024-05-01T10:00:00Z"}
+	invalidated, _, err := sessionForClusterWithRegion(k8sClient, clusterScope, "ap-south-2", nil, log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(invalidated).ToNot(BeIdenticalTo(first))
+
+	// The session is cached again until the value of the annotation changes.
+	cached, _, err = sessionForClusterWithRegion(k8sClient, clusterScope, "ap-south-2", nil, log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cached).To(BeIdenticalTo(invalidated))
+}
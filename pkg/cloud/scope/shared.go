@@ -100,7 +100,7 @@ func (p *defaultSubnetPlacementStrategy) Place(input *placementInput) ([]string,
 		return subnetIDs, nil
 	}
 
-	controlPlaneSubnetIDs := input.ControlplaneSubnets.FilterPrivate().IDs()
+	controlPlaneSubnetIDs := input.ControlplaneSubnets.WithoutReplaced().WithoutIPv6Native().FilterPrivate().IDs()
 	if len(controlPlaneSubnetIDs) > 0 {
 		p.logger.Debug("using all the private subnets from the control plane")
 		return controlPlaneSubnetIDs, nil
@@ -113,7 +113,7 @@ func (p *defaultSubnetPlacementStrategy) getSubnetsForAZs(azs []string, controlP
 	subnetIDs := []string{}
 
 	for _, zone := range azs {
-		subnets := controlPlaneSubnets.FilterByZone(zone)
+		subnets := controlPlaneSubnets.WithoutReplaced().WithoutIPv6Native().FilterByZone(zone)
 		if placementType != nil {
 			switch *placementType {
 			case expinfrav1.AZSubnetTypeAll:
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package scope
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// StandbyNetworkScopeParams defines the input parameters used to create a new StandbyNetworkScope.
+type StandbyNetworkScopeParams struct {
+	ClusterScope *ClusterScope
+	// Region is the standby region, which can differ from the one of the spec of the AWSCluster when the network
+	// of a previous standby region is deleted.
+	Region    string
+	Endpoints []ServiceEndpoint
+}
+
+// StandbyNetworkScope is the scope used to reconcile the network of a cluster in its standby region. It works on a
+// copy of the AWSCluster whose region, VPC, subnets and security groups are the ones of the standby region, which
+// is never patched: the network of the standby region is persisted in the status of the AWSCluster with Status.
+type StandbyNetworkScope struct {
+	*ClusterScope
+}
+
+// NewStandbyNetworkScope creates a new StandbyNetworkScope from the supplied parameters.
+func NewStandbyNetworkScope(params StandbyNetworkScopeParams) (*StandbyNetworkScope, error) {
+	if params.ClusterScope == nil {
+		return nil, errors.New("failed to generate new scope from nil ClusterScope")
+	}
+	if params.Region == "" {
+		return nil, errors.New("failed to generate new scope without standby region")
+	}
+
+	clusterScope := params.ClusterScope
+	standbyScope := &ClusterScope{
+		Logger:                       *clusterScope.Logger.WithValues("standbyRegion", params.Region),
+		client:                       clusterScope.client,
+		Cluster:                      clusterScope.Cluster,
+		AWSCluster:                   standbyAWSCluster(clusterScope.Name(), clusterScope.AWSCluster, params.Region),
+		controllerName:               clusterScope.controllerName,
+		tagUnmanagedNetworkResources: clusterScope.tagUnmanagedNetworkResources,
+	}
+
+	session, serviceLimiters, err := sessionForClusterWithRegion(standbyScope.client, standbyScope, params.Region, params.Endpoints, &standbyScope.Logger)
+	if err != nil {
+		return nil, errors.Errorf("failed to create aws session: %v", err)
+	}
+	standbyScope.session = session
+	standbyScope.serviceLimiters = serviceLimiters
+
+	return &StandbyNetworkScope{ClusterScope: standbyScope}, nil
+}
+
+// PatchObject doesn't persist the copy of the AWSCluster of the standby region.
+func (s *StandbyNetworkScope) PatchObject() error {
+	return nil
+}
+
+// Close doesn't persist the copy of the AWSCluster of the standby region.
+func (s *StandbyNetworkScope) Close() error {
+	return nil
+}
+
+// Status returns the network resources of the standby region, to store in the status of the AWSCluster.
+func (s *StandbyNetworkScope) Status() *infrav1.StandbyNetworkStatus {
+	return &infrav1.StandbyNetworkStatus{
+		Region:         s.Region(),
+		VPC:            *s.VPC().DeepCopy(),
+		Subnets:        s.Subnets().DeepCopy(),
+		SecurityGroups: s.SecurityGroups(),
+	}
+}
+
+// standbyAWSCluster returns a copy of an AWSCluster whose network is the one of its standby region: its VPC and
+// subnets are copied without their resource IDs, in the mapped availability zones, and the resources already
+// reconciled in the standby region are restored from the status.
+func standbyAWSCluster(clusterName string, awsCluster *infrav1.AWSCluster, region string) *infrav1.AWSCluster {
+	standby := awsCluster.DeepCopy()
+	standby.Spec.Region = region
+	standby.Spec.NetworkSpec.SecurityGroupOverrides = nil
+	standby.Status = infrav1.AWSClusterStatus{}
+
+	var status *infrav1.StandbyNetworkStatus
+	if awsCluster.Status.StandbyNetwork != nil && awsCluster.Status.StandbyNetwork.Region == region {
+		status = awsCluster.Status.StandbyNetwork.DeepCopy()
+	}
+
+	vpc := &standby.Spec.NetworkSpec.VPC
+	vpc.ID = ""
+	vpc.InternetGatewayID = nil
+	vpc.CarrierGatewayID = nil
+	vpc.Tags = nil
+	if status != nil && status.VPC.ID != "" {
+		status.VPC.DeepCopyInto(vpc)
+	}
+
+	var availabilityZones map[string]string
+	if awsCluster.Spec.StandbyRegion != nil && awsCluster.Spec.StandbyRegion.Name == region {
+		availabilityZones = awsCluster.Spec.StandbyRegion.AvailabilityZones
+	}
+
+	subnets := infrav1.Subnets{}
+	for _, subnet := range awsCluster.Spec.NetworkSpec.Subnets {
+		// Edge zones don't have counterparts in other regions.
+		if subnet.IsEdge() {
+			continue
+		}
+		standbySubnet := standbySubnet(clusterName, subnet, standbyAvailabilityZone(subnet.AvailabilityZone, AWSClusterRegion(awsCluster), region, availabilityZones))
+		if status != nil {
+			if existing := status.Subnets.FindEqual(&standbySubnet); existing != nil {
+				standbySubnet = *existing
+			}
+		}
+		subnets = append(subnets, standbySubnet)
+	}
+	// Without the subnets of the region of the cluster, the network of the standby region is only deleted.
+	if len(subnets) == 0 && status != nil {
+		subnets = status.Subnets
+	}
+	standby.Spec.NetworkSpec.Subnets = subnets
+
+	if status != nil {
+		standby.Status.Network.SecurityGroups = status.SecurityGroups
+	}
+
+	return standby
+}
+
+// standbySubnet returns the copy of a subnet in an availability zone of the standby region.
+func standbySubnet(clusterName string, subnet infrav1.SubnetSpec, availabilityZone string) infrav1.SubnetSpec {
+	role := infrav1.PrivateRoleTagValue
+	if subnet.IsPublic {
+		role = infrav1.PublicRoleTagValue
+	}
+
+	tags := subnet.Tags.DeepCopy()
+	// The default name of the subnet, which contains its availability zone, is generated again.
+	if name, ok := tags["Name"]; ok && strings.HasSuffix(name, fmt.Sprintf("-subnet-%s-%s", role, subnet.AvailabilityZone)) {
+		delete(tags, "Name")
+	}
+
+	return infrav1.SubnetSpec{
+		ID:               fmt.Sprintf("%s-subnet-%s-%s", clusterName, role, availabilityZone),
+		CidrBlock:        subnet.CidrBlock,
+		AvailabilityZone: availabilityZone,
+		IsPublic:         subnet.IsPublic,
+		Tags:             tags,
+	}
+}
+
+// standbyAvailabilityZone maps an availability zone of the region of a cluster to one of its standby region, the one
+// with the same letter when not mapped explicitly.
+func standbyAvailabilityZone(availabilityZone, region, standbyRegion string, availabilityZones map[string]string) string {
+	if mapped, ok := availabilityZones[availabilityZone]; ok {
+		return mapped
+	}
+	return standbyRegion + strings.TrimPrefix(availabilityZone, region)
+}
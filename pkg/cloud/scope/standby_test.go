@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestStandbyAWSCluster(t *testing.T) {
+	newAWSCluster := func() *infrav1.AWSCluster {
+		return &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				Region: "us-east-1",
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID:                "vpc-primary",
+						CidrBlock:         "10.0.0.0/16",
+						InternetGatewayID: ptr.To("igw-primary"),
+						Tags:              infrav1.Tags{"Name": "test-cluster-vpc"},
+					},
+					Subnets: infrav1.Subnets{
+						{
+							ID:               "test-cluster-subnet-private-us-east-1a",
+							ResourceID:       "subnet-private-a",
+							CidrBlock:        "10.0.0.0/24",
+							AvailabilityZone: "us-east-1a",
+							RouteTableID:     ptr.To("rtb-private-a"),
+							Tags:             infrav1.Tags{"Name": "test-cluster-subnet-private-us-east-1a", "team": "infra"},
+						},
+						{
+							ID:               "subnet-public-b",
+							ResourceID:       "subnet-public-b",
+							CidrBlock:        "10.0.1.0/24",
+							AvailabilityZone: "us-east-1b",
+							IsPublic:         true,
+							Tags:             infrav1.Tags{"Name": "public-b"},
+						},
+						{
+							ID:               "test-cluster-subnet-private-us-east-1-bos-1a",
+							CidrBlock:        "10.0.2.0/24",
+							AvailabilityZone: "us-east-1-bos-1a",
+							ZoneType:         ptr.To(infrav1.ZoneTypeLocalZone),
+						},
+					},
+					SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{infrav1.SecurityGroupNode: "sg-primary"},
+				},
+				StandbyRegion: &infrav1.StandbyRegion{
+					Name:              "us-west-2",
+					AvailabilityZones: map[string]string{"us-east-1b": "us-west-2c"},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{infrav1.SecurityGroupNode: {ID: "sg-primary"}},
+				},
+			},
+		}
+	}
+
+	t.Run("network is copied to the standby region", func(t *testing.T) {
+		g := NewWithT(t)
+
+		standby := standbyAWSCluster("test-cluster", newAWSCluster(), "us-west-2")
+
+		g.Expect(standby.Spec.Region).To(Equal("us-west-2"))
+		g.Expect(standby.Spec.NetworkSpec.VPC).To(Equal(infrav1.VPCSpec{CidrBlock: "10.0.0.0/16"}))
+		g.Expect(standby.Spec.NetworkSpec.Subnets).To(Equal(infrav1.Subnets{
+			{
+				ID:               "test-cluster-subnet-private-us-west-2a",
+				CidrBlock:        "10.0.0.0/24",
+				AvailabilityZone: "us-west-2a",
+				Tags:             infrav1.Tags{"team": "infra"},
+			},
+			{
+				ID:               "test-cluster-subnet-public-us-west-2c",
+				CidrBlock:        "10.0.1.0/24",
+				AvailabilityZone: "us-west-2c",
+				IsPublic:         true,
+				Tags:             infrav1.Tags{"Name": "public-b"},
+			},
+		}))
+		g.Expect(standby.Spec.NetworkSpec.SecurityGroupOverrides).To(BeNil())
+		g.Expect(standby.Status.Network.SecurityGroups).To(BeEmpty())
+	})
+
+	t.Run("resources of the standby region are restored from the status", func(t *testing.T) {
+		g := NewWithT(t)
+
+		awsCluster := newAWSCluster()
+		standbySubnet := infrav1.SubnetSpec{
+			ID:               "test-cluster-subnet-private-us-west-2a",
+			ResourceID:       "subnet-standby-a",
+			CidrBlock:        "10.0.0.0/24",
+			AvailabilityZone: "us-west-2a",
+		}
+		awsCluster.Status.StandbyNetwork = &infrav1.StandbyNetworkStatus{
+			Region:         "us-west-2",
+			VPC:            infrav1.VPCSpec{ID: "vpc-standby", CidrBlock: "10.0.0.0/16"},
+			Subnets:        infrav1.Subnets{standbySubnet},
+			SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{infrav1.SecurityGroupNode: {ID: "sg-standby"}},
+		}
+
+		standby := standbyAWSCluster("test-cluster", awsCluster, "us-west-2")
+
+		g.Expect(standby.Spec.NetworkSpec.VPC.ID).To(Equal("vpc-standby"))
+		g.Expect(standby.Spec.NetworkSpec.Subnets).To(HaveLen(2))
+		g.Expect(standby.Spec.NetworkSpec.Subnets[0]).To(Equal(standbySubnet))
+		g.Expect(standby.Spec.NetworkSpec.Subnets[1].ResourceID).To(BeEmpty())
+		g.Expect(standby.Status.Network.SecurityGroups).To(HaveKeyWithValue(infrav1.SecurityGroupNode, infrav1.SecurityGroup{ID: "sg-standby"}))
+	})
+
+	t.Run("status of another standby region is ignored", func(t *testing.T) {
+		g := NewWithT(t)
+
+		awsCluster := newAWSCluster()
+		awsCluster.Status.StandbyNetwork = &infrav1.StandbyNetworkStatus{
+			Region: "eu-west-1",
+			VPC:    infrav1.VPCSpec{ID: "vpc-standby"},
+		}
+
+		standby := standbyAWSCluster("test-cluster", awsCluster, "us-west-2")
+
+		g.Expect(standby.Spec.NetworkSpec.VPC.ID).To(BeEmpty())
+	})
+
+	t.Run("previous standby region keeps its resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		awsCluster := newAWSCluster()
+		awsCluster.Spec.StandbyRegion = nil
+		awsCluster.Status.StandbyNetwork = &infrav1.StandbyNetworkStatus{
+			Region: "eu-west-1",
+			VPC:    infrav1.VPCSpec{ID: "vpc-standby", CidrBlock: "10.0.0.0/16"},
+		}
+
+		standby := standbyAWSCluster("test-cluster", awsCluster, "eu-west-1")
+
+		g.Expect(standby.Spec.Region).To(Equal("eu-west-1"))
+		g.Expect(standby.Spec.NetworkSpec.VPC.ID).To(Equal("vpc-standby"))
+		g.Expect(standby.Spec.NetworkSpec.Subnets[0].AvailabilityZone).To(Equal("eu-west-1a"))
+		g.Expect(standby.Spec.NetworkSpec.Subnets[1].AvailabilityZone).To(Equal("eu-west-1b"))
+	})
+}
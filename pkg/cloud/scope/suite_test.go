@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestMain(m *testing.M) {
+	// The tests which omit the region of their cluster must not reach the instance metadata service.
+	instanceRegion = func() (string, error) {
+		return "", errors.New("the instance metadata service is not available in the tests")
+	}
+	os.Exit(m.Run())
+}
@@ -20,12 +20,14 @@ package asg
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
@@ -34,11 +36,15 @@ import (
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api/util/annotations"
 )
 
+// describeInstancesBatchSize is the maximum number of instance IDs of a DescribeInstances request.
+const describeInstancesBatchSize = 200
+
 // SDKToAutoScalingGroup converts an AWS EC2 SDK AutoScalingGroup to the CAPA AutoScalingGroup type.
 func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoScalingGroup, error) {
 	i := &expinfrav1.AutoScalingGroup{
@@ -93,6 +99,13 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		}
 	}
 
+	switch {
+	case v.LaunchTemplate != nil:
+		i.LaunchTemplateVersion = aws.StringValue(v.LaunchTemplate.Version)
+	case v.MixedInstancesPolicy != nil && v.MixedInstancesPolicy.LaunchTemplate != nil && v.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification != nil:
+		i.LaunchTemplateVersion = aws.StringValue(v.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification.Version)
+	}
+
 	if v.Status != nil {
 		i.Status = expinfrav1.ASGStatus(*v.Status)
 	}
@@ -105,13 +118,32 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 		for _, autoscalingInstance := range v.Instances {
 			tmp := &infrav1.Instance{
 				ID:               aws.StringValue(autoscalingInstance.InstanceId),
+				Type:             aws.StringValue(autoscalingInstance.InstanceType),
 				State:            infrav1.InstanceState(*autoscalingInstance.LifecycleState),
 				AvailabilityZone: *autoscalingInstance.AvailabilityZone,
 			}
 			i.Instances = append(i.Instances, *tmp)
+
+			instanceStatus := expinfrav1.AWSMachinePoolInstanceStatus{
+				InstanceID:       tmp.ID,
+				AvailabilityZone: tmp.AvailabilityZone,
+				LifecycleState:   aws.StringValue(autoscalingInstance.LifecycleState),
+				HealthStatus:     aws.StringValue(autoscalingInstance.HealthStatus),
+			}
+			if autoscalingInstance.LaunchTemplate != nil {
+				instanceStatus.LaunchTemplateVersion = aws.StringValue(autoscalingInstance.LaunchTemplate.Version)
+			}
+			i.InstanceStatuses = append(i.InstanceStatuses, instanceStatus)
 		}
 	}
 
+	if len(v.TargetGroupARNs) > 0 {
+		i.TargetGroupARNs = aws.StringValueSlice(v.TargetGroupARNs)
+	}
+	if len(v.LoadBalancerNames) > 0 {
+		i.LoadBalancerNames = aws.StringValueSlice(v.LoadBalancerNames)
+	}
+
 	if len(v.SuspendedProcesses) > 0 {
 		currentlySuspendedProcesses := make([]string, len(v.SuspendedProcesses))
 		for i, service := range v.SuspendedProcesses {
@@ -124,7 +156,7 @@ func (s *Service) SDKToAutoScalingGroup(v *autoscaling.Group) (*expinfrav1.AutoS
 }
 
 // ASGIfExists returns the existing autoscaling group or nothing if it doesn't exist.
-func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error) {
+func (s *Service) ASGIfExists(ctx context.Context, name *string) (*expinfrav1.AutoScalingGroup, error) {
 	if name == nil {
 		s.scope.Info("Autoscaling Group does not have a name")
 		return nil, nil
@@ -136,7 +168,7 @@ func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error
 		AutoScalingGroupNames: []*string{name},
 	}
 
-	out, err := s.ASGClient.DescribeAutoScalingGroupsWithContext(context.TODO(), input)
+	out, err := s.ASGClient.DescribeAutoScalingGroupsWithContext(ctx, input)
 	switch {
 	case awserrors.IsNotFound(err):
 		return nil, nil
@@ -151,14 +183,14 @@ func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error
 }
 
 // GetASGByName returns the existing ASG or nothing if it doesn't exist.
-func (s *Service) GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+func (s *Service) GetASGByName(ctx context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
 	name := scope.Name()
-	return s.ASGIfExists(&name)
+	return s.ASGIfExists(ctx, &name)
 }
 
 // CreateASG runs an autoscaling group.
-func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
-	subnets, err := s.SubnetIDs(machinePoolScope)
+func (s *Service) CreateASG(ctx context.Context, machinePoolScope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+	subnets, err := s.SubnetIDs(ctx, machinePoolScope)
 	if err != nil {
 		return nil, fmt.Errorf("getting subnets for ASG: %w", err)
 	}
@@ -171,9 +203,20 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 		DefaultCoolDown:       machinePoolScope.AWSMachinePool.Spec.DefaultCoolDown,
 		DefaultInstanceWarmup: machinePoolScope.AWSMachinePool.Spec.DefaultInstanceWarmup,
 		CapacityRebalance:     machinePoolScope.AWSMachinePool.Spec.CapacityRebalance,
+		LaunchTemplateVersion: machinePoolScope.LaunchTemplateVersion(),
 		MixedInstancesPolicy:  machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy,
 	}
 
+	if attachments := machinePoolScope.AWSMachinePool.Spec.LoadBalancerAttachments; attachments != nil {
+		input.TargetGroupARNs = attachments.TargetGroupARNs
+		input.LoadBalancerNames = attachments.ClassicLoadBalancerNames
+	}
+	for _, arn := range machinePoolScope.AWSMachinePool.Spec.TargetGroupARNs {
+		if !slices.Contains(input.TargetGroupARNs, arn) {
+			input.TargetGroupARNs = append(input.TargetGroupARNs, arn)
+		}
+	}
+
 	// Default value of MachinePool replicas set by CAPI is 1.
 	mpReplicas := *machinePoolScope.MachinePool.Spec.Replicas
 
@@ -191,6 +234,12 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 
 	// Make sure to use the MachinePoolScope here to get the merger of AWSCluster and AWSMachinePool tags
 	additionalTags := machinePoolScope.AdditionalTags()
+	// Set the cluster-autoscaler tags so that the ASG is discovered as soon as it is created
+	for key, value := range ClusterAutoscalerTags(s.scope.KubernetesClusterName(), machinePoolScope.AWSMachinePool.Spec.ClusterAutoscaler, nil, nil) {
+		if _, ok := additionalTags[key]; !ok {
+			additionalTags[key] = value
+		}
+	}
 	// Set the cloud provider tag
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
 
@@ -203,7 +252,7 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	})
 
 	s.scope.Info("Running instance")
-	if err := s.runPool(input, machinePoolScope.AWSMachinePool.Status.LaunchTemplateID); err != nil {
+	if err := s.runPool(ctx, input, machinePoolScope.AWSMachinePool.Status.LaunchTemplateID); err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
 		// if !awserrors.IsFailedDependency(errors.Cause(err)) {
@@ -217,7 +266,7 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	return nil, nil
 }
 
-func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID string) error {
+func (s *Service) runPool(ctx context.Context, i *expinfrav1.AutoScalingGroup, launchTemplateID string) error {
 	input := &autoscaling.CreateAutoScalingGroupInput{
 		AutoScalingGroupName:  aws.String(i.Name),
 		MaxSize:               aws.Int64(int64(i.MaxSize)),
@@ -233,19 +282,27 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 	}
 
 	if i.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, i.MixedInstancesPolicy)
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(i.Name, i.LaunchTemplateVersion, i.MixedInstancesPolicy)
 	} else {
 		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
 			LaunchTemplateId: aws.String(launchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
+			Version:          aws.String(i.LaunchTemplateVersion),
 		}
 	}
 
+	if len(i.TargetGroupARNs) > 0 {
+		input.TargetGroupARNs = aws.StringSlice(i.TargetGroupARNs)
+	}
+
+	if len(i.LoadBalancerNames) > 0 {
+		input.LoadBalancerNames = aws.StringSlice(i.LoadBalancerNames)
+	}
+
 	if i.Tags != nil {
 		input.Tags = BuildTagsFromMap(i.Name, i.Tags)
 	}
 
-	if _, err := s.ASGClient.CreateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+	if _, err := s.ASGClient.CreateAutoScalingGroupWithContext(ctx, input); err != nil {
 		return errors.Wrap(err, "failed to create autoscaling group")
 	}
 
@@ -253,8 +310,8 @@ func (s *Service) runPool(i *expinfrav1.AutoScalingGroup, launchTemplateID strin
 }
 
 // DeleteASGAndWait will delete an ASG and wait until it is deleted.
-func (s *Service) DeleteASGAndWait(name string) error {
-	if err := s.DeleteASG(name); err != nil {
+func (s *Service) DeleteASGAndWait(ctx context.Context, name string) error {
+	if err := s.DeleteASG(ctx, name); err != nil {
 		return err
 	}
 
@@ -264,7 +321,7 @@ func (s *Service) DeleteASGAndWait(name string) error {
 		AutoScalingGroupNames: aws.StringSlice([]string{name}),
 	}
 
-	if err := s.ASGClient.WaitUntilGroupNotExistsWithContext(context.TODO(), input); err != nil {
+	if err := s.ASGClient.WaitUntilGroupNotExistsWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to wait for ASG %q deletion", name)
 	}
 
@@ -272,7 +329,7 @@ func (s *Service) DeleteASGAndWait(name string) error {
 }
 
 // DeleteASG will delete the ASG of a service.
-func (s *Service) DeleteASG(name string) error {
+func (s *Service) DeleteASG(ctx context.Context, name string) error {
 	s.scope.Debug("Attempting to delete ASG", "name", name)
 
 	input := &autoscaling.DeleteAutoScalingGroupInput{
@@ -280,7 +337,7 @@ func (s *Service) DeleteASG(name string) error {
 		ForceDelete:          aws.Bool(true),
 	}
 
-	if _, err := s.ASGClient.DeleteAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+	if _, err := s.ASGClient.DeleteAutoScalingGroupWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to delete ASG %q", name)
 	}
 
@@ -289,8 +346,8 @@ func (s *Service) DeleteASG(name string) error {
 }
 
 // UpdateASG will update the ASG of a service.
-func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
-	subnetIDs, err := s.SubnetIDs(machinePoolScope)
+func (s *Service) UpdateASG(ctx context.Context, machinePoolScope *scope.MachinePoolScope) error {
+	subnetIDs, err := s.SubnetIDs(ctx, machinePoolScope)
 	if err != nil {
 		return fmt.Errorf("getting subnets for ASG: %w", err)
 	}
@@ -308,15 +365,15 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 	}
 
 	if machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
-		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.Name(), machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy)
+		input.MixedInstancesPolicy = createSDKMixedInstancesPolicy(machinePoolScope.Name(), machinePoolScope.LaunchTemplateVersion(), machinePoolScope.AWSMachinePool.Spec.MixedInstancesPolicy)
 	} else {
 		input.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
 			LaunchTemplateId: aws.String(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID),
-			Version:          aws.String(expinfrav1.LaunchTemplateLatestVersion),
+			Version:          aws.String(machinePoolScope.LaunchTemplateVersion()),
 		}
 	}
 
-	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(context.TODO(), input); err != nil {
+	if _, err := s.ASGClient.UpdateAutoScalingGroupWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to update ASG %q", machinePoolScope.Name())
 	}
 
@@ -324,9 +381,9 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 }
 
 // CanStartASGInstanceRefresh will start an ASG instance with refresh.
-func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error) {
+func (s *Service) CanStartASGInstanceRefresh(ctx context.Context, scope *scope.MachinePoolScope) (bool, error) {
 	describeInput := &autoscaling.DescribeInstanceRefreshesInput{AutoScalingGroupName: aws.String(scope.Name())}
-	refreshes, err := s.ASGClient.DescribeInstanceRefreshesWithContext(context.TODO(), describeInput)
+	refreshes, err := s.ASGClient.DescribeInstanceRefreshesWithContext(ctx, describeInput)
 	if err != nil {
 		return false, err
 	}
@@ -347,7 +404,7 @@ func (s *Service) CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (boo
 }
 
 // StartASGInstanceRefresh will start an ASG instance with refresh.
-func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
+func (s *Service) StartASGInstanceRefresh(ctx context.Context, scope *scope.MachinePoolScope) error {
 	strategy := ptr.To[string](autoscaling.RefreshStrategyRolling)
 	var minHealthyPercentage, instanceWarmup *int64
 	if scope.AWSMachinePool.Spec.RefreshPreferences != nil {
@@ -371,19 +428,19 @@ func (s *Service) StartASGInstanceRefresh(scope *scope.MachinePoolScope) error {
 		},
 	}
 
-	if _, err := s.ASGClient.StartInstanceRefreshWithContext(context.TODO(), input); err != nil {
+	if _, err := s.ASGClient.StartInstanceRefreshWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to start ASG instance refresh %q", scope.Name())
 	}
 
 	return nil
 }
 
-func createSDKMixedInstancesPolicy(name string, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
+func createSDKMixedInstancesPolicy(name, launchTemplateVersion string, i *expinfrav1.MixedInstancesPolicy) *autoscaling.MixedInstancesPolicy {
 	mixedInstancesPolicy := &autoscaling.MixedInstancesPolicy{
 		LaunchTemplate: &autoscaling.LaunchTemplate{
 			LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
 				LaunchTemplateName: aws.String(name),
-				Version:            aws.String(expinfrav1.LaunchTemplateLatestVersion),
+				Version:            aws.String(launchTemplateVersion),
 			},
 		},
 	}
@@ -434,7 +491,7 @@ func BuildTagsFromMap(asgName string, inTags map[string]string) []*autoscaling.T
 // This will be called if there is anything to create (update) or delete.
 // We may not always have to perform each action, so we check what we're
 // receiving to avoid calling AWS if we don't need to.
-func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[string]string) error {
+func (s *Service) UpdateResourceTags(ctx context.Context, resourceID *string, create, remove map[string]string) error {
 	s.scope.Debug("Attempting to update tags on resource", "resource-id", *resourceID)
 	s.scope.Info("updating tags on resource", "resource-id", *resourceID, "create", create, "remove", remove)
 
@@ -446,7 +503,7 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 
 		createOrUpdateTagsInput.Tags = mapToTags(create, resourceID)
 
-		if _, err := s.ASGClient.CreateOrUpdateTagsWithContext(context.TODO(), createOrUpdateTagsInput); err != nil {
+		if _, err := s.ASGClient.CreateOrUpdateTagsWithContext(ctx, createOrUpdateTagsInput); err != nil {
 			return errors.Wrapf(err, "failed to update tags on AutoScalingGroup %q", *resourceID)
 		}
 	}
@@ -464,7 +521,7 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 		}
 
 		// Delete tags in AWS.
-		if _, err := s.ASGClient.DeleteTagsWithContext(context.TODO(), input); err != nil {
+		if _, err := s.ASGClient.DeleteTagsWithContext(ctx, input); err != nil {
 			return errors.Wrapf(err, "failed to delete tags on AutoScalingGroup %q: %v", *resourceID, remove)
 		}
 	}
@@ -473,29 +530,103 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 }
 
 // SuspendProcesses suspends the processes for an autoscaling group.
-func (s *Service) SuspendProcesses(name string, processes []string) error {
+func (s *Service) SuspendProcesses(ctx context.Context, name string, processes []string) error {
 	input := autoscaling.ScalingProcessQuery{
 		AutoScalingGroupName: aws.String(name),
 		ScalingProcesses:     aws.StringSlice(processes),
 	}
-	if _, err := s.ASGClient.SuspendProcessesWithContext(context.TODO(), &input); err != nil {
+	if _, err := s.ASGClient.SuspendProcessesWithContext(ctx, &input); err != nil {
 		return errors.Wrapf(err, "failed to suspend processes for AutoScalingGroup: %q", name)
 	}
 	return nil
 }
 
 // ResumeProcesses resumes the processes for an autoscaling group.
-func (s *Service) ResumeProcesses(name string, processes []string) error {
+func (s *Service) ResumeProcesses(ctx context.Context, name string, processes []string) error {
 	input := autoscaling.ScalingProcessQuery{
 		AutoScalingGroupName: aws.String(name),
 		ScalingProcesses:     aws.StringSlice(processes),
 	}
-	if _, err := s.ASGClient.ResumeProcessesWithContext(context.TODO(), &input); err != nil {
+	if _, err := s.ASGClient.ResumeProcessesWithContext(ctx, &input); err != nil {
 		return errors.Wrapf(err, "failed to resume processes for AutoScalingGroup: %q", name)
 	}
 	return nil
 }
 
+// TerminateInstancesAndDecrementDesiredCapacity terminates the specified instances of an autoscaling group and
+// decrements its desired capacity accordingly, so that they are not replaced.
+func (s *Service) TerminateInstancesAndDecrementDesiredCapacity(ctx context.Context, instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		input := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+			InstanceId:                     aws.String(id),
+			ShouldDecrementDesiredCapacity: aws.Bool(true),
+		}
+		if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroupWithContext(ctx, input); err != nil {
+			return errors.Wrapf(err, "failed to terminate instance %q of AutoScalingGroup", id)
+		}
+	}
+	return nil
+}
+
+// ReplaceInstance terminates the specified instance of an autoscaling group without decrementing its desired
+// capacity, so that the autoscaling group launches a new instance in its place.
+func (s *Service) ReplaceInstance(ctx context.Context, instanceID string) error {
+	input := &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(false),
+	}
+	if _, err := s.ASGClient.TerminateInstanceInAutoScalingGroupWithContext(ctx, input); err != nil {
+		return errors.Wrapf(err, "failed to replace instance %q of AutoScalingGroup", instanceID)
+	}
+	return nil
+}
+
+// AttachLoadBalancers attaches target groups and classic load balancers to an autoscaling group.
+func (s *Service) AttachLoadBalancers(ctx context.Context, name string, targetGroupARNs, loadBalancerNames []string) error {
+	if len(targetGroupARNs) > 0 {
+		input := &autoscaling.AttachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(targetGroupARNs),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancerTargetGroupsWithContext(ctx, input); err != nil {
+			return errors.Wrapf(err, "failed to attach target groups to AutoScalingGroup: %q", name)
+		}
+	}
+	if len(loadBalancerNames) > 0 {
+		input := &autoscaling.AttachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(name),
+			LoadBalancerNames:    aws.StringSlice(loadBalancerNames),
+		}
+		if _, err := s.ASGClient.AttachLoadBalancersWithContext(ctx, input); err != nil {
+			return errors.Wrapf(err, "failed to attach load balancers to AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
+// DetachLoadBalancers detaches target groups and classic load balancers from an autoscaling group.
+func (s *Service) DetachLoadBalancers(ctx context.Context, name string, targetGroupARNs, loadBalancerNames []string) error {
+	if len(targetGroupARNs) > 0 {
+		input := &autoscaling.DetachLoadBalancerTargetGroupsInput{
+			AutoScalingGroupName: aws.String(name),
+			TargetGroupARNs:      aws.StringSlice(targetGroupARNs),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancerTargetGroupsWithContext(ctx, input); err != nil {
+			return errors.Wrapf(err, "failed to detach target groups from AutoScalingGroup: %q", name)
+		}
+	}
+	if len(loadBalancerNames) > 0 {
+		input := &autoscaling.DetachLoadBalancersInput{
+			AutoScalingGroupName: aws.String(name),
+			LoadBalancerNames:    aws.StringSlice(loadBalancerNames),
+		}
+		if _, err := s.ASGClient.DetachLoadBalancersWithContext(ctx, input); err != nil {
+			return errors.Wrapf(err, "failed to detach load balancers from AutoScalingGroup: %q", name)
+		}
+	}
+	return nil
+}
+
 func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	tags := make([]*autoscaling.Tag, 0)
 	for k, v := range input {
@@ -514,11 +645,21 @@ func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	return tags
 }
 
-// SubnetIDs return subnet IDs of a AWSMachinePool based on given subnetIDs and filters.
-func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
+// SubnetIDs return subnet IDs of a AWSMachinePool based on given subnetIDs, filters or subnet selector.
+func (s *Service) SubnetIDs(ctx context.Context, scope *scope.MachinePoolScope) ([]string, error) {
 	subnetIDs := make([]string, 0)
 	var inputFilters = make([]*ec2.Filter, 0)
 
+	if selector := scope.AWSMachinePool.Spec.SubnetSelector; selector != nil {
+		// The selected subnets are looked up in the VPC of the cluster, so that the spec of the pool can be used by
+		// clusters in different VPCs.
+		inputFilters = append(inputFilters,
+			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+			filter.EC2.VPC(scope.InfraCluster.VPC().ID),
+		)
+		inputFilters = append(inputFilters, filter.EC2.SubnetSelector(selector)...)
+	}
+
 	for _, subnet := range scope.AWSMachinePool.Spec.Subnets {
 		switch {
 		case subnet.ID != nil:
@@ -534,16 +675,14 @@ func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 	}
 
 	if len(inputFilters) > 0 {
-		out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+		out, err := s.EC2Client.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{
 			Filters: inputFilters,
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		for _, subnet := range out.Subnets {
-			subnetIDs = append(subnetIDs, *subnet.SubnetId)
-		}
+		subnetIDs = append(subnetIDs, selectSubnets(out.Subnets, scope.AWSMachinePool.Spec.SubnetSelectionStrategy, scope.AWSMachinePool.Spec.PreferredAvailabilityZones)...)
 
 		if len(subnetIDs) == 0 {
 			errMessage := fmt.Sprintf("failed to create ASG %q, no subnets available matching criteria %q", scope.Name(), inputFilters)
@@ -554,3 +693,117 @@ func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 
 	return scope.SubnetIDs(subnetIDs)
 }
+
+// selectSubnets returns the IDs of the subnets the group gets among the matching subnets, according to the subnet
+// selection strategy.
+func selectSubnets(subnets []*ec2.Subnet, strategy *expinfrav1.SubnetSelectionStrategy, preferredAZs []string) []string {
+	if strategy == nil || *strategy == expinfrav1.SubnetSelectionStrategyAll {
+		subnetIDs := make([]string, 0, len(subnets))
+		for _, subnet := range subnets {
+			subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetId))
+		}
+		return subnetIDs
+	}
+
+	if *strategy == expinfrav1.SubnetSelectionStrategyPreferredAZs {
+		preferred := make([]*ec2.Subnet, 0, len(subnets))
+		for _, subnet := range subnets {
+			if slices.Contains(preferredAZs, aws.StringValue(subnet.AvailabilityZone)) {
+				preferred = append(preferred, subnet)
+			}
+		}
+		// Falls back to all the availability zones when none of the preferred ones has matching subnets.
+		if len(preferred) > 0 {
+			subnets = preferred
+		}
+	}
+
+	// The subnet with the lowest ID of each availability zone is chosen, so that the subnets of the group don't
+	// change when a subnet is added to an availability zone which already has one.
+	byAZ := map[string]string{}
+	for _, subnet := range subnets {
+		az, id := aws.StringValue(subnet.AvailabilityZone), aws.StringValue(subnet.SubnetId)
+		if current, ok := byAZ[az]; !ok || id < current {
+			byAZ[az] = id
+		}
+	}
+	azs := make([]string, 0, len(byAZ))
+	for az := range byAZ {
+		azs = append(azs, az)
+	}
+	sort.Strings(azs)
+	subnetIDs := make([]string, 0, len(azs))
+	for _, az := range azs {
+		subnetIDs = append(subnetIDs, byAZ[az])
+	}
+	return subnetIDs
+}
+
+// UnhealthyTargetGroupInstances returns the IDs of the instances that are not healthy in at least one of the target
+// groups, including the instances that are not registered with them yet.
+func (s *Service) UnhealthyTargetGroupInstances(ctx context.Context, targetGroupARNs, instanceIDs []string) ([]string, error) {
+	unhealthy := map[string]struct{}{}
+	for _, arn := range targetGroupARNs {
+		out, err := s.ELBV2Client.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(arn),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe health of targets in target group %q", arn)
+		}
+
+		healthy := map[string]struct{}{}
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.Target == nil || desc.TargetHealth == nil {
+				continue
+			}
+			if aws.StringValue(desc.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+				healthy[aws.StringValue(desc.Target.Id)] = struct{}{}
+			}
+		}
+		for _, id := range instanceIDs {
+			if _, ok := healthy[id]; !ok {
+				unhealthy[id] = struct{}{}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(unhealthy))
+	for id := range unhealthy {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// InstanceCapacityTypes returns the capacity type, spot or on-demand, of each of the specified instances.
+func (s *Service) InstanceCapacityTypes(ctx context.Context, instanceIDs []string) (map[string]expinfrav1.ManagedMachinePoolCapacityType, error) {
+	capacityTypes := make(map[string]expinfrav1.ManagedMachinePoolCapacityType, len(instanceIDs))
+	if len(instanceIDs) == 0 {
+		return capacityTypes, nil
+	}
+
+	// the instances are described in batches so that the requests of the pools with many instances stay small.
+	for start := 0; start < len(instanceIDs); start += describeInstancesBatchSize {
+		end := min(start+describeInstancesBatchSize, len(instanceIDs))
+		input := &ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice(instanceIDs[start:end]),
+		}
+		err := s.EC2Client.DescribeInstancesPagesWithContext(ctx, input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, reservation := range out.Reservations {
+				for _, instance := range reservation.Instances {
+					capacityType := expinfrav1.ManagedMachinePoolCapacityTypeOnDemand
+					if aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot {
+						capacityType = expinfrav1.ManagedMachinePoolCapacityTypeSpot
+					}
+					capacityTypes[aws.StringValue(instance.InstanceId)] = capacityType
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe instances")
+		}
+	}
+
+	return capacityTypes, nil
+}
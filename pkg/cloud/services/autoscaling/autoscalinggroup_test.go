@@ -18,6 +18,7 @@ package asg
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
@@ -125,7 +127,7 @@ func TestServiceGetASGByName(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = tt.machinePoolName
 
-			asg, err := s.GetASGByName(mps)
+			asg, err := s.GetASGByName(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 			checkASG(tt.wantASG, asg, g)
 		})
@@ -246,6 +248,11 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 						InstanceId:       aws.String("instanceId"),
 						LifecycleState:   aws.String("lifecycleState"),
 						AvailabilityZone: aws.String("us-east-1a"),
+						HealthStatus:     aws.String("Healthy"),
+						LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+							LaunchTemplateId: aws.String("lt-1"),
+							Version:          aws.String("2"),
+						},
 					},
 				},
 			},
@@ -280,6 +287,15 @@ func TestServiceSDKToAutoScalingGroup(t *testing.T) {
 						AvailabilityZone: "us-east-1a",
 					},
 				},
+				InstanceStatuses: []expinfrav1.AWSMachinePoolInstanceStatus{
+					{
+						InstanceID:            "instanceId",
+						AvailabilityZone:      "us-east-1a",
+						LifecycleState:        "lifecycleState",
+						HealthStatus:          "Healthy",
+						LaunchTemplateVersion: "2",
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -462,7 +478,7 @@ func TestServiceASGIfExists(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			asg, err := s.ASGIfExists(tt.asgName)
+			asg, err := s.ASGIfExists(context.TODO(), tt.asgName)
 			checkErr(tt.wantErr, err, g)
 			checkASG(tt.wantASG, asg, g)
 		})
@@ -685,7 +701,7 @@ func TestServiceCreateASG(t *testing.T) {
 			mps.MachinePool.Spec.Replicas = aws.Int32(1)
 
 			tt.setupMachinePoolScope(mps)
-			asg, err := s.CreateASG(mps)
+			asg, err := s.CreateASG(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 			checkASG(tt.wantASG, asg, g)
 		})
@@ -774,7 +790,7 @@ func TestServiceUpdateASG(t *testing.T) {
 			mps.AWSMachinePool.Name = tt.machinePoolName
 			tt.setupMachinePoolScope(mps)
 
-			err = s.UpdateASG(mps)
+			err = s.UpdateASG(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 		})
 	}
@@ -863,12 +879,129 @@ func TestServiceUpdateASGWithSubnetFilters(t *testing.T) {
 			mps.AWSMachinePool.Name = tt.machinePoolName
 			mps.AWSMachinePool.Spec.Subnets = tt.awsResourceReference
 
-			err = s.UpdateASG(mps)
+			err = s.UpdateASG(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 		})
 	}
 }
 
+func TestServiceSubnetIDsWithSubnetSelector(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	selector := &infrav1.SubnetSelector{
+		Tags: []infrav1.SubnetTagSelector{
+			{Key: "subnet-role", Values: []string{"workers", "batch"}},
+			{Key: "kubernetes.io/role/internal-elb"},
+		},
+		AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+	}
+	expectedFilters := []*ec2.Filter{
+		{Name: aws.String("state"), Values: aws.StringSlice([]string{ec2.SubnetStatePending, ec2.SubnetStateAvailable})},
+		{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-selected"})},
+		{Name: aws.String("tag:subnet-role"), Values: aws.StringSlice([]string{"workers", "batch"})},
+		{Name: aws.String("tag-key"), Values: aws.StringSlice([]string{"kubernetes.io/role/internal-elb"})},
+		{Name: aws.String("availability-zone"), Values: aws.StringSlice([]string{"us-east-1a", "us-east-1b"})},
+	}
+
+	tests := []struct {
+		name      string
+		subnets   []*ec2.Subnet
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "returns the subnets of the cluster VPC matching the selector",
+			subnets:  []*ec2.Subnet{{SubnetId: aws.String("subnet-a")}, {SubnetId: aws.String("subnet-b")}},
+			expected: []string{"subnet-a", "subnet-b"},
+		},
+		{
+			name:      "returns an error if no subnet matches the selector",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			clusterScope.AWSCluster.Spec.NetworkSpec.VPC.ID = "vpc-selected"
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: expectedFilters}).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: tt.subnets,
+			}, nil)
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			mps, err := getMachinePoolScope(fakeClient, clusterScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			mps.AWSMachinePool.Spec.Subnets = nil
+			mps.AWSMachinePool.Spec.SubnetSelector = selector
+
+			subnetIDs, err := s.SubnetIDs(context.TODO(), mps)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(subnetIDs).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestSelectSubnets(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{SubnetId: aws.String("subnet-c"), AvailabilityZone: aws.String("us-east-1b")},
+		{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1a")},
+		{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1b")},
+		{SubnetId: aws.String("subnet-d"), AvailabilityZone: aws.String("us-east-1c")},
+		{SubnetId: aws.String("subnet-e"), AvailabilityZone: aws.String("us-east-1a")},
+	}
+
+	tests := []struct {
+		name         string
+		strategy     *expinfrav1.SubnetSelectionStrategy
+		preferredAZs []string
+		expected     []string
+	}{
+		{
+			name:     "all the subnets without a strategy",
+			expected: []string{"subnet-c", "subnet-b", "subnet-a", "subnet-d", "subnet-e"},
+		},
+		{
+			name:     "all the subnets",
+			strategy: ptr.To(expinfrav1.SubnetSelectionStrategyAll),
+			expected: []string{"subnet-c", "subnet-b", "subnet-a", "subnet-d", "subnet-e"},
+		},
+		{
+			name:     "the subnet with the lowest ID of each availability zone",
+			strategy: ptr.To(expinfrav1.SubnetSelectionStrategyOnePerAZ),
+			expected: []string{"subnet-b", "subnet-a", "subnet-d"},
+		},
+		{
+			name:         "one subnet per preferred availability zone",
+			strategy:     ptr.To(expinfrav1.SubnetSelectionStrategyPreferredAZs),
+			preferredAZs: []string{"us-east-1b", "us-east-1c", "us-east-1d"},
+			expected:     []string{"subnet-a", "subnet-d"},
+		},
+		{
+			name:         "one subnet per availability zone when no preferred availability zone has subnets",
+			strategy:     ptr.To(expinfrav1.SubnetSelectionStrategyPreferredAZs),
+			preferredAZs: []string{"us-east-1d"},
+			expected:     []string{"subnet-b", "subnet-a", "subnet-d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(selectSubnets(subnets, tt.strategy, tt.preferredAZs)).To(Equal(tt.expected))
+		})
+	}
+}
+
 func TestServiceUpdateResourceTags(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -979,7 +1112,7 @@ func TestServiceUpdateResourceTags(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			err = s.UpdateResourceTags(tt.args.resourceID, tt.args.create, tt.args.remove)
+			err = s.UpdateResourceTags(context.TODO(), tt.args.resourceID, tt.args.create, tt.args.remove)
 			checkErr(tt.wantErr, err, g)
 		})
 	}
@@ -1030,12 +1163,397 @@ func TestServiceDeleteASG(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			err = s.DeleteASG("asgName")
+			err = s.DeleteASG(context.TODO(), "asgName")
 			checkErr(tt.wantErr, err, g)
 		})
 	}
 }
 
+func TestServiceTerminateInstancesAndDecrementDesiredCapacity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "Terminate the instances successfully",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				for _, id := range []string{"i-1", "i-2"} {
+					m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+						InstanceId:                     aws.String(id),
+						ShouldDecrementDesiredCapacity: aws.Bool(true),
+					})).
+						Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+				}
+			},
+		},
+		{
+			name:    "Terminate the instances should fail when an instance can't be terminated",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.TerminateInstancesAndDecrementDesiredCapacity(context.TODO(), []string{"i-1", "i-2"})
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+func TestServiceReplaceInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		expect  func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:    "Replace the instance successfully",
+			wantErr: false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Eq(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+					InstanceId:                     aws.String("i-1"),
+					ShouldDecrementDesiredCapacity: aws.Bool(false),
+				})).
+					Return(&autoscaling.TerminateInstanceInAutoScalingGroupOutput{}, nil)
+			},
+		},
+		{
+			name:    "Replace the instance should fail when the instance can't be terminated",
+			wantErr: true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.TerminateInstanceInAutoScalingGroupWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.ReplaceInstance(context.TODO(), "i-1")
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceAttachLoadBalancers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name              string
+		targetGroupARNs   []string
+		loadBalancerNames []string
+		wantErr           bool
+		expect            func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+	}{
+		{
+			name:              "Attach target groups and load balancers",
+			targetGroupARNs:   []string{"tg-1"},
+			loadBalancerNames: []string{"lb-1"},
+			wantErr:           false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancerTargetGroupsInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					TargetGroupARNs:      aws.StringSlice([]string{"tg-1"}),
+				})).
+					Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+				m.AttachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.AttachLoadBalancersInput{
+					AutoScalingGroupName: aws.String("asgName"),
+					LoadBalancerNames:    aws.StringSlice([]string{"lb-1"}),
+				})).
+					Return(&autoscaling.AttachLoadBalancersOutput{}, nil)
+			},
+		},
+		{
+			name:            "Attach only target groups",
+			targetGroupARNs: []string{"tg-1"},
+			wantErr:         false,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(&autoscaling.AttachLoadBalancerTargetGroupsOutput{}, nil)
+			},
+		},
+		{
+			name:            "Attach should fail when the target groups can't be attached",
+			targetGroupARNs: []string{"tg-1"},
+			wantErr:         true,
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.AttachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			tt.expect(asgMock.EXPECT())
+			s := NewService(clusterScope)
+			s.ASGClient = asgMock
+
+			err = s.AttachLoadBalancers(context.TODO(), "asgName", tt.targetGroupARNs, tt.loadBalancerNames)
+			checkErr(tt.wantErr, err, g)
+		})
+	}
+}
+
+func TestServiceDetachLoadBalancers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	clusterScope, err := getClusterScope(getFakeClient())
+	g.Expect(err).ToNot(HaveOccurred())
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	asgMock.EXPECT().DetachLoadBalancerTargetGroupsWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancerTargetGroupsInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		TargetGroupARNs:      aws.StringSlice([]string{"tg-1"}),
+	})).
+		Return(&autoscaling.DetachLoadBalancerTargetGroupsOutput{}, nil)
+	asgMock.EXPECT().DetachLoadBalancersWithContext(context.TODO(), gomock.Eq(&autoscaling.DetachLoadBalancersInput{
+		AutoScalingGroupName: aws.String("asgName"),
+		LoadBalancerNames:    aws.StringSlice([]string{"lb-1"}),
+	})).
+		Return(&autoscaling.DetachLoadBalancersOutput{}, nil)
+	s := NewService(clusterScope)
+	s.ASGClient = asgMock
+
+	g.Expect(s.DetachLoadBalancers(context.TODO(), "asgName", []string{"tg-1"}, []string{"lb-1"})).To(Succeed())
+}
+
+func TestServiceUnhealthyTargetGroupInstances(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	targetHealth := func(id, state string) *elbv2.TargetHealthDescription {
+		return &elbv2.TargetHealthDescription{
+			Target:       &elbv2.TargetDescription{Id: aws.String(id), Port: aws.Int64(80)},
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		wantUnhealthy []string
+		wantErr       bool
+		expect        func(m *mocks.MockELBV2APIMockRecorder)
+	}{
+		{
+			name:          "All instances are healthy",
+			wantUnhealthy: []string{},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealthWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String("tg-1"),
+				})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							targetHealth("i-1", elbv2.TargetHealthStateEnumHealthy),
+							targetHealth("i-2", elbv2.TargetHealthStateEnumHealthy),
+						},
+					}, nil)
+				m.DescribeTargetHealthWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String("tg-2"),
+				})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							targetHealth("i-1", elbv2.TargetHealthStateEnumHealthy),
+							targetHealth("i-2", elbv2.TargetHealthStateEnumHealthy),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:          "Instances not healthy or not registered in a target group",
+			wantUnhealthy: []string{"i-1", "i-2"},
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealthWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String("tg-1"),
+				})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							targetHealth("i-1", elbv2.TargetHealthStateEnumInitial),
+							targetHealth("i-2", elbv2.TargetHealthStateEnumHealthy),
+						},
+					}, nil)
+				m.DescribeTargetHealthWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String("tg-2"),
+				})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							targetHealth("i-1", elbv2.TargetHealthStateEnumHealthy),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "Health of a target group can't be described",
+			wantErr: true,
+			expect: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetHealthWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserrors.NewNotFound("not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+			tt.expect(elbv2Mock.EXPECT())
+			s := NewService(clusterScope)
+			s.ELBV2Client = elbv2Mock
+
+			unhealthy, err := s.UnhealthyTargetGroupInstances(context.TODO(), []string{"tg-1", "tg-2"}, []string{"i-1", "i-2"})
+			checkErr(tt.wantErr, err, g)
+			if !tt.wantErr {
+				g.Expect(unhealthy).To(Equal(tt.wantUnhealthy))
+			}
+		})
+	}
+}
+
+func TestServiceInstanceCapacityTypes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	manyInstanceIDs := make([]string, 250)
+	manyCapacityTypes := make(map[string]expinfrav1.ManagedMachinePoolCapacityType, 250)
+	for i := range manyInstanceIDs {
+		manyInstanceIDs[i] = fmt.Sprintf("i-%d", i)
+		manyCapacityTypes[manyInstanceIDs[i]] = expinfrav1.ManagedMachinePoolCapacityTypeOnDemand
+	}
+
+	tests := []struct {
+		name              string
+		instanceIDs       []string
+		wantCapacityTypes map[string]expinfrav1.ManagedMachinePoolCapacityType
+		wantErr           bool
+		expect            func(m *mocks.MockEC2APIMockRecorder)
+	}{
+		{
+			name:              "No instances",
+			wantCapacityTypes: map[string]expinfrav1.ManagedMachinePoolCapacityType{},
+		},
+		{
+			name:        "Spot and on-demand instances",
+			instanceIDs: []string{"i-1", "i-2"},
+			wantCapacityTypes: map[string]expinfrav1.ManagedMachinePoolCapacityType{
+				"i-1": expinfrav1.ManagedMachinePoolCapacityTypeSpot,
+				"i-2": expinfrav1.ManagedMachinePoolCapacityTypeOnDemand,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-1", "i-2"}),
+				}), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{
+									Instances: []*ec2.Instance{
+										{InstanceId: aws.String("i-1"), InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot)},
+										{InstanceId: aws.String("i-2")},
+									},
+								},
+							},
+						}, true)
+						return nil
+					})
+			},
+		},
+		{
+			name:              "Instances of large pools are described in batches",
+			instanceIDs:       manyInstanceIDs,
+			wantCapacityTypes: manyCapacityTypes,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				for _, batch := range [][]string{manyInstanceIDs[:200], manyInstanceIDs[200:]} {
+					m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+						InstanceIds: aws.StringSlice(batch),
+					}), gomock.Any()).
+						DoAndReturn(func(_ context.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+							reservation := &ec2.Reservation{}
+							for _, id := range input.InstanceIds {
+								reservation.Instances = append(reservation.Instances, &ec2.Instance{InstanceId: id})
+							}
+							fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{reservation}}, true)
+							return nil
+						})
+				}
+			},
+		},
+		{
+			name:        "Instances can't be described",
+			instanceIDs: []string{"i-1"},
+			wantErr:     true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Return(awserrors.NewFailedDependency("dependency failure"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := getFakeClient()
+
+			clusterScope, err := getClusterScope(fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			if tt.expect != nil {
+				tt.expect(ec2Mock.EXPECT())
+			}
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			capacityTypes, err := s.InstanceCapacityTypes(context.TODO(), tt.instanceIDs)
+			checkErr(tt.wantErr, err, g)
+			if !tt.wantErr {
+				g.Expect(capacityTypes).To(Equal(tt.wantCapacityTypes))
+			}
+		})
+	}
+}
+
 func TestServiceDeleteASGAndWait(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1100,7 +1618,7 @@ func TestServiceDeleteASGAndWait(t *testing.T) {
 			s := NewService(clusterScope)
 			s.ASGClient = asgMock
 
-			err = s.DeleteASGAndWait("asgName")
+			err = s.DeleteASGAndWait(context.TODO(), "asgName")
 			checkErr(tt.wantErr, err, g)
 		})
 	}
@@ -1173,7 +1691,7 @@ func TestServiceCanStartASGInstanceRefresh(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "machinePoolName"
 
-			out, err := s.CanStartASGInstanceRefresh(mps)
+			out, err := s.CanStartASGInstanceRefresh(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 			if tt.canStart {
 				g.Expect(out).To(BeTrue())
@@ -1241,7 +1759,7 @@ func TestServiceStartASGInstanceRefresh(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			mps.AWSMachinePool.Name = "mpn"
 
-			err = s.StartASGInstanceRefresh(mps)
+			err = s.StartASGInstanceRefresh(context.TODO(), mps)
 			checkErr(tt.wantErr, err, g)
 		})
 	}
@@ -1282,6 +1800,7 @@ func getClusterScope(client client.Client) (*scope.ClusterScope, error) {
 		Cluster: cluster,
 		AWSCluster: &infrav1.AWSCluster{
 			Spec: infrav1.AWSClusterSpec{
+				Region: "us-east-1",
 				NetworkSpec: infrav1.NetworkSpec{
 					Subnets: []infrav1.SubnetSpec{
 						{
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+const (
+	// ClusterAutoscalerEnabledTag is the tag cluster-autoscaler uses to auto-discover the Auto Scaling groups.
+	ClusterAutoscalerEnabledTag = expinfrav1.ClusterAutoscalerTagPrefix + "enabled"
+
+	nodeTemplateLabelTagPrefix     = expinfrav1.ClusterAutoscalerTagPrefix + "node-template/label/"
+	nodeTemplateTaintTagPrefix     = expinfrav1.ClusterAutoscalerTagPrefix + "node-template/taint/"
+	nodeTemplateResourcesTagPrefix = expinfrav1.ClusterAutoscalerTagPrefix + "node-template/resources/"
+)
+
+// ClusterAutoscalerDiscoveryTagKey returns the tag cluster-autoscaler uses to auto-discover the Auto Scaling groups
+// of a cluster.
+func ClusterAutoscalerDiscoveryTagKey(clusterName string) string {
+	return expinfrav1.ClusterAutoscalerTagPrefix + clusterName
+}
+
+// ClusterAutoscalerTags returns the tags consumed by cluster-autoscaler of an Auto Scaling group whose nodes have
+// the specified labels and taints, in addition to the ones of the node template.
+func ClusterAutoscalerTags(clusterName string, options *expinfrav1.ClusterAutoscalerOptions, labels map[string]string, taints expinfrav1.Taints) infrav1.Tags {
+	tags := infrav1.Tags{}
+	if options == nil {
+		return tags
+	}
+
+	if options.AutoDiscovery {
+		tags[ClusterAutoscalerEnabledTag] = "true"
+		tags[ClusterAutoscalerDiscoveryTagKey(clusterName)] = string(infrav1.ResourceLifecycleOwned)
+	}
+
+	var resources corev1.ResourceList
+	if options.NodeTemplate != nil {
+		labels = mergeLabels(labels, options.NodeTemplate.Labels)
+		taints = append(append(expinfrav1.Taints{}, taints...), options.NodeTemplate.Taints...)
+		resources = options.NodeTemplate.Resources
+	}
+
+	for key, value := range labels {
+		tags[nodeTemplateLabelTagPrefix+key] = value
+	}
+	// the taints of the node template override the ones with the same key.
+	for _, taint := range taints {
+		tags[nodeTemplateTaintTagPrefix+taint.Key] = taint.Value + ":" + string(nodeTaintEffect(taint.Effect))
+	}
+	for name, quantity := range resources {
+		tags[nodeTemplateResourcesTagPrefix+string(name)] = quantity.String()
+	}
+
+	return tags
+}
+
+// ClusterAutoscalerTagsDiff returns the cluster-autoscaler tags of an Auto Scaling group to create or update and to
+// remove to get to the desired ones. The tags to keep, e.g. the additional tags, are left untouched.
+func ClusterAutoscalerTagsDiff(current, desired, keep map[string]string) (map[string]string, map[string]string) {
+	create := map[string]string{}
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			create[key] = value
+		}
+	}
+
+	remove := map[string]string{}
+	for key, value := range current {
+		if !strings.HasPrefix(key, expinfrav1.ClusterAutoscalerTagPrefix) {
+			continue
+		}
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := keep[key]; ok {
+			continue
+		}
+		remove[key] = value
+	}
+
+	return create, remove
+}
+
+func mergeLabels(labels, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(labels)+len(overrides))
+	for key, value := range labels {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// nodeTaintEffect converts the effect of a taint to the one of the taints of the nodes.
+func nodeTaintEffect(effect expinfrav1.TaintEffect) corev1.TaintEffect {
+	switch effect {
+	case expinfrav1.TaintEffectNoExecute:
+		return corev1.TaintEffectNoExecute
+	case expinfrav1.TaintEffectPreferNoSchedule:
+		return corev1.TaintEffectPreferNoSchedule
+	default:
+		return corev1.TaintEffectNoSchedule
+	}
+}
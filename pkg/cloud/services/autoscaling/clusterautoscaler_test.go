@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asg
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+func TestClusterAutoscalerTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *expinfrav1.ClusterAutoscalerOptions
+		labels  map[string]string
+		taints  expinfrav1.Taints
+		want    infrav1.Tags
+	}{
+		{
+			name:   "no options",
+			labels: map[string]string{"role": "worker"},
+			want:   infrav1.Tags{},
+		},
+		{
+			name:    "auto-discovery",
+			options: &expinfrav1.ClusterAutoscalerOptions{AutoDiscovery: true},
+			want: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/enabled":      "true",
+				"k8s.io/cluster-autoscaler/test-cluster": "owned",
+			},
+		},
+		{
+			name:    "labels and taints of the nodes",
+			options: &expinfrav1.ClusterAutoscalerOptions{},
+			labels:  map[string]string{"role": "worker"},
+			taints:  expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoExecute}},
+			want: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/node-template/label/role":      "worker",
+				"k8s.io/cluster-autoscaler/node-template/taint/dedicated": "gpu:NoExecute",
+			},
+		},
+		{
+			name: "node template overrides the labels and taints of the nodes",
+			options: &expinfrav1.ClusterAutoscalerOptions{
+				NodeTemplate: &expinfrav1.NodeTemplate{
+					Labels: map[string]string{"role": "gpu", "zone": "a"},
+					Taints: expinfrav1.Taints{{Key: "dedicated", Value: "ml", Effect: expinfrav1.TaintEffectNoSchedule}},
+					Resources: corev1.ResourceList{
+						"nvidia.com/gpu":                resource.MustParse("1"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+					},
+				},
+			},
+			labels: map[string]string{"role": "worker"},
+			taints: expinfrav1.Taints{{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoExecute}},
+			want: infrav1.Tags{
+				"k8s.io/cluster-autoscaler/node-template/label/role":                  "gpu",
+				"k8s.io/cluster-autoscaler/node-template/label/zone":                  "a",
+				"k8s.io/cluster-autoscaler/node-template/taint/dedicated":             "ml:NoSchedule",
+				"k8s.io/cluster-autoscaler/node-template/resources/nvidia.com/gpu":    "1",
+				"k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage": "100Gi",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ClusterAutoscalerTags("test-cluster", tc.options, tc.labels, tc.taints)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestClusterAutoscalerTagsDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	current := map[string]string{
+		"Name":                              "test-pool",
+		"k8s.io/cluster-autoscaler/enabled": "true",
+		"k8s.io/cluster-autoscaler/node-template/label/role": "worker",
+		"k8s.io/cluster-autoscaler/node-template/label/zone": "a",
+		"k8s.io/cluster-autoscaler/node-template/label/team": "ml",
+	}
+	desired := map[string]string{
+		"k8s.io/cluster-autoscaler/enabled":                  "true",
+		"k8s.io/cluster-autoscaler/node-template/label/role": "gpu",
+	}
+	keep := map[string]string{
+		"k8s.io/cluster-autoscaler/node-template/label/team": "ml",
+	}
+
+	create, remove := ClusterAutoscalerTagsDiff(current, desired, keep)
+	g.Expect(create).To(Equal(map[string]string{
+		"k8s.io/cluster-autoscaler/node-template/label/role": "gpu",
+	}))
+	g.Expect(remove).To(Equal(map[string]string{
+		"k8s.io/cluster-autoscaler/node-template/label/zone": "a",
+	}))
+}
@@ -19,6 +19,7 @@ package asg
 import (
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -28,16 +29,18 @@ import (
 // The interfaces are broken down like this to group functions together.
 // One alternative is to have a large list of functions from the asg client.
 type Service struct {
-	scope     cloud.ClusterScoper
-	ASGClient autoscalingiface.AutoScalingAPI
-	EC2Client ec2iface.EC2API
+	scope       cloud.ClusterScoper
+	ASGClient   autoscalingiface.AutoScalingAPI
+	EC2Client   ec2iface.EC2API
+	ELBV2Client elbv2iface.ELBV2API
 }
 
 // NewService returns a new service given the asg api client.
 func NewService(clusterScope cloud.ClusterScoper) *Service {
 	return &Service{
-		scope:     clusterScope,
-		ASGClient: scope.NewASGClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
-		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		scope:       clusterScope,
+		ASGClient:   scope.NewASGClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		EC2Client:   scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+		ELBV2Client: scope.NewELBv2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 	}
 }
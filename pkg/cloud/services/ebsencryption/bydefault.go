@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ebsencryption
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ReconcileEBSEncryptionByDefault checks the EBS encryption by default setting of the account in the region of the
+// cluster, enables it when the cluster asks for it, and reports it in the EBSEncryptionByDefault condition. It returns
+// whether the setting is enabled, and an error if it couldn't be read or enabled.
+func (s *Service) ReconcileEBSEncryptionByDefault() (bool, error) {
+	s.scope.Debug("Checking the EBS encryption by default")
+
+	out, err := s.EC2Client.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{})
+	if err != nil {
+		err = errors.Wrap(err, "failed to get the EBS encryption by default")
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionByDefaultCondition, infrav1.EBSEncryptionByDefaultCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	if aws.BoolValue(out.EbsEncryptionByDefault) {
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.EBSEncryptionByDefaultCondition)
+		return true, nil
+	}
+
+	if s.scope.EBSEncryptionByDefault() != infrav1.EBSEncryptionByDefaultModeEnable {
+		record.Warnf(s.scope.InfraCluster(), "EBSEncryptionByDefaultDisabled", "EBS encryption by default is disabled in region %s", s.scope.Region())
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionByDefaultCondition, infrav1.EBSEncryptionByDefaultDisabledReason, clusterv1.ConditionSeverityError,
+			"EBS encryption by default is disabled in region %s", s.scope.Region())
+		return false, nil
+	}
+
+	s.scope.Info("Enabling EBS encryption by default", "region", s.scope.Region())
+	if _, err := s.EC2Client.EnableEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.EnableEbsEncryptionByDefaultInput{}); err != nil {
+		err = errors.Wrap(err, "failed to enable the EBS encryption by default")
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionByDefaultCondition, infrav1.EBSEncryptionByDefaultCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+	record.Eventf(s.scope.InfraCluster(), "EBSEncryptionByDefaultEnabled", "Enabled EBS encryption by default in region %s", s.scope.Region())
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.EBSEncryptionByDefaultCondition)
+
+	return true, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ebsencryption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEBSEncryptionByDefault(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            infrav1.EBSEncryptionByDefaultMode
+		expect          func(m *mocks.MockEC2APIMockRecorder)
+		expectEnabled   bool
+		expectErr       bool
+		expectReason    string
+		expectInMessage string
+	}{
+		{
+			name: "enabled encryption by default is reported",
+			mode: infrav1.EBSEncryptionByDefaultModeCheck,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(true)}, nil)
+			},
+			expectEnabled: true,
+		},
+		{
+			name: "disabled encryption by default is reported",
+			mode: infrav1.EBSEncryptionByDefaultModeCheck,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(false)}, nil)
+			},
+			expectReason:    infrav1.EBSEncryptionByDefaultDisabledReason,
+			expectInMessage: "disabled in region eu-west-1",
+		},
+		{
+			name: "disabled encryption by default is enabled",
+			mode: infrav1.EBSEncryptionByDefaultModeEnable,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(false)}, nil)
+				m.EnableEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.EnableEbsEncryptionByDefaultInput{}).
+					Return(&ec2.EnableEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(true)}, nil)
+			},
+			expectEnabled: true,
+		},
+		{
+			name: "enabled encryption by default isn't enabled again",
+			mode: infrav1.EBSEncryptionByDefaultModeEnable,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(true)}, nil)
+			},
+			expectEnabled: true,
+		},
+		{
+			name: "failing to enable encryption by default is reported",
+			mode: infrav1.EBSEncryptionByDefaultModeEnable,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(false)}, nil)
+				m.EnableEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.EnableEbsEncryptionByDefaultInput{}).
+					Return(nil, awserr.New("UnauthorizedOperation", "not allowed to perform ec2:EnableEbsEncryptionByDefault", nil))
+			},
+			expectErr:       true,
+			expectReason:    infrav1.EBSEncryptionByDefaultCheckFailedReason,
+			expectInMessage: "ec2:EnableEbsEncryptionByDefault",
+		},
+		{
+			name: "failing to read encryption by default is reported",
+			mode: infrav1.EBSEncryptionByDefaultModeCheck,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{}).
+					Return(nil, awserr.New("UnauthorizedOperation", "not allowed to perform ec2:GetEbsEncryptionByDefault", nil))
+			},
+			expectErr:       true,
+			expectReason:    infrav1.EBSEncryptionByDefaultCheckFailedReason,
+			expectInMessage: "ec2:GetEbsEncryptionByDefault",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1", EBSEncryptionByDefault: tc.mode}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			enabled, err := s.ReconcileEBSEncryptionByDefault()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(enabled).To(Equal(tc.expectEnabled))
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.EBSEncryptionByDefaultCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectEnabled {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			g.Expect(condition.Message).To(ContainSubstring(tc.expectInMessage))
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ebsencryption
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultKeyPolicyName is the name of the policy of every KMS key.
+	defaultKeyPolicyName = "default"
+
+	// usableKeyTTL is how long a key which can be used by the service-linked role isn't checked again.
+	usableKeyTTL = time.Hour
+)
+
+// requiredActions are the actions the Auto Scaling service-linked role needs to launch instances whose volumes are
+// encrypted with a customer managed key.
+var requiredActions = []string{
+	"kms:CreateGrant",
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:Encrypt",
+	"kms:GenerateDataKeyWithoutPlaintext",
+	"kms:ReEncryptFrom",
+	"kms:ReEncryptTo",
+}
+
+// usableKeys caches the keys which can be used by the service-linked role of an account, not to read their key
+// policy and grants at each reconciliation of the clusters.
+var usableKeys = cache.NewExpiring()
+
+// ReconcileEBSEncryptionKey checks that the key policy or the grants of the default EBS encryption key of the cluster
+// allow the Auto Scaling service-linked role, which launches the instances of the machine pools, to use the key, and
+// reports the actions it isn't allowed to perform in the EBSEncryptionKeyReady condition. It returns whether the key
+// can be used, and an error if its key policy or grants couldn't be read.
+func (s *Service) ReconcileEBSEncryptionKey() (bool, error) {
+	keyARN := s.scope.DefaultEBSEncryptionKeyARN()
+
+	s.scope.Debug("Checking the default EBS encryption key", "key", keyARN)
+
+	role, err := s.serviceLinkedRoleARN()
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionKeyReadyCondition, infrav1.EBSEncryptionKeyCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	cacheKey := keyARN + "|" + role
+	if _, ok := usableKeys.Get(cacheKey); ok {
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.EBSEncryptionKeyReadyCondition)
+		return true, nil
+	}
+
+	allowed, err := s.allowedActions(keyARN, role)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to check the permissions of %q on %q", role, keyARN)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionKeyReadyCondition, infrav1.EBSEncryptionKeyCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	denied := []string{}
+	for _, action := range requiredActions {
+		if !allowed[action] {
+			denied = append(denied, action)
+		}
+	}
+
+	if len(denied) > 0 {
+		record.Warnf(s.scope.InfraCluster(), "EBSEncryptionKeyNotUsable", "%s isn't allowed to perform %s on %s", role, strings.Join(denied, ", "), keyARN)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EBSEncryptionKeyReadyCondition, infrav1.EBSEncryptionKeyNotUsableReason, clusterv1.ConditionSeverityError,
+			"%s isn't allowed to perform %s on %s", role, strings.Join(denied, ", "), keyARN)
+		return false, nil
+	}
+
+	usableKeys.Set(cacheKey, true, usableKeyTTL)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.EBSEncryptionKeyReadyCondition)
+
+	return true, nil
+}
+
+// serviceLinkedRoleARN returns the ARN of the Auto Scaling service-linked role of the account of the identity used
+// by the controllers.
+func (s *Service) serviceLinkedRoleARN() (string, error) {
+	identity, err := s.STSClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the caller identity")
+	}
+
+	callerARN := aws.StringValue(identity.Arn)
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the caller identity %q", callerARN)
+	}
+
+	return fmt.Sprintf("arn:%s:iam::%s:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling", parsed.Partition, parsed.AccountID), nil
+}
+
+// allowedActions returns the actions the key policy and the grants of the key allow the principal to perform. The
+// conditions of the statements of the key policy aren't evaluated: the statements allowing actions are assumed to
+// apply, and only the statements denying actions without conditions are assumed to apply.
+func (s *Service) allowedActions(keyARN, principal string) (map[string]bool, error) {
+	out, err := s.KMSClient.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(defaultKeyPolicyName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the key policy")
+	}
+
+	policy := iamv1.PolicyDocument{}
+	if err := json.Unmarshal([]byte(aws.StringValue(out.Policy)), &policy); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the key policy")
+	}
+
+	allowed := map[string]bool{}
+	for _, statement := range policy.Statement {
+		if statement.Effect != iamv1.EffectAllow || !appliesTo(statement, principal) {
+			continue
+		}
+		for _, action := range requiredActions {
+			if matchesAnyAction(statement.Action, action) {
+				allowed[action] = true
+			}
+		}
+	}
+
+	err = s.KMSClient.ListGrantsPages(&kms.ListGrantsInput{KeyId: aws.String(keyARN)}, func(page *kms.ListGrantsResponse, _ bool) bool {
+		for _, grant := range page.Grants {
+			if aws.StringValue(grant.GranteePrincipal) != principal {
+				continue
+			}
+			for _, operation := range grant.Operations {
+				allowed["kms:"+aws.StringValue(operation)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the grants of the key")
+	}
+
+	for _, statement := range policy.Statement {
+		if statement.Effect != iamv1.EffectDeny || len(statement.Condition) > 0 || !appliesTo(statement, principal) {
+			continue
+		}
+		for _, action := range requiredActions {
+			if matchesAnyAction(statement.Action, action) {
+				delete(allowed, action)
+			}
+		}
+	}
+
+	return allowed, nil
+}
+
+// appliesTo returns whether the principal of a statement of a key policy is the principal, or all the principals.
+func appliesTo(statement iamv1.StatementEntry, principal string) bool {
+	for _, id := range statement.Principal[iamv1.PrincipalAWS] {
+		if id == "*" || id == principal {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyAction returns whether the action matches one of the actions of a statement, which may contain wildcards.
+// Actions are case insensitive.
+func matchesAnyAction(patterns iamv1.Actions, action string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action)); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ebsencryption
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ebsencryption/mock_kmsiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testKeyARN    = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	testCallerARN = "arn:aws:sts::123456789012:assumed-role/controllers/session"
+	testRoleARN   = "arn:aws:iam::123456789012:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"
+)
+
+func TestReconcileEBSEncryptionKey(t *testing.T) {
+	listGrants := func(grants ...*kms.GrantListEntry) func(*kms.ListGrantsInput, func(*kms.ListGrantsResponse, bool) bool) error {
+		return func(_ *kms.ListGrantsInput, fn func(*kms.ListGrantsResponse, bool) bool) error {
+			fn(&kms.ListGrantsResponse{Grants: grants}, true)
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name            string
+		keyPolicy       string
+		grants          []*kms.GrantListEntry
+		getPolicyErr    error
+		expectReady     bool
+		expectErr       bool
+		expectReason    string
+		expectInMessage string
+	}{
+		{
+			name: "the key policy allows the service-linked role to use the key",
+			keyPolicy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*", "Resource": "*"},
+				{"Effect": "Allow", "Principal": {"AWS": ["` + testRoleARN + `"]}, "Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey"], "Resource": "*"},
+				{"Effect": "Allow", "Principal": {"AWS": "` + testRoleARN + `"}, "Action": "KMS:CreateGrant", "Resource": "*", "Condition": {"Bool": {"kms:GrantIsForAWSResource": true}}}
+			]}`,
+			expectReady: true,
+		},
+		{
+			name: "a grant allows the service-linked role to perform the actions missing from the key policy",
+			keyPolicy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Principal": "*", "Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey"], "Resource": "*"}
+			]}`,
+			grants: []*kms.GrantListEntry{
+				{GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/other"), Operations: aws.StringSlice([]string{"CreateGrant"})},
+				{GranteePrincipal: aws.String(testRoleARN), Operations: aws.StringSlice([]string{"CreateGrant"})},
+			},
+			expectReady: true,
+		},
+		{
+			name: "a key policy only delegating to IAM policies doesn't allow the service-linked role",
+			keyPolicy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*", "Resource": "*"}
+			]}`,
+			expectReason:    infrav1.EBSEncryptionKeyNotUsableReason,
+			expectInMessage: "kms:CreateGrant, kms:Decrypt, kms:DescribeKey, kms:Encrypt, kms:GenerateDataKeyWithoutPlaintext, kms:ReEncryptFrom, kms:ReEncryptTo",
+		},
+		{
+			name: "the actions denied without conditions are reported",
+			keyPolicy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "` + testRoleARN + `"}, "Action": "kms:*", "Resource": "*"},
+				{"Effect": "Deny", "Principal": {"AWS": "*"}, "Action": "kms:Decrypt", "Resource": "*"},
+				{"Effect": "Deny", "Principal": {"AWS": "*"}, "Action": "kms:Encrypt", "Resource": "*", "Condition": {"Bool": {"aws:SecureTransport": false}}}
+			]}`,
+			expectReason:    infrav1.EBSEncryptionKeyNotUsableReason,
+			expectInMessage: "isn't allowed to perform kms:Decrypt on",
+		},
+		{
+			name:            "failing to read the key policy is reported",
+			getPolicyErr:    awserr.New("AccessDeniedException", "not allowed to perform kms:GetKeyPolicy", nil),
+			expectErr:       true,
+			expectReason:    infrav1.EBSEncryptionKeyCheckFailedReason,
+			expectInMessage: "kms:GetKeyPolicy",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			usableKeys = cache.NewExpiring()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			kmsMock := mock_kmsiface.NewMockKMSAPI(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+			stsMock.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(testCallerARN)}, nil)
+			kmsMock.EXPECT().GetKeyPolicy(&kms.GetKeyPolicyInput{KeyId: aws.String(testKeyARN), PolicyName: aws.String("default")}).
+				Return(&kms.GetKeyPolicyOutput{Policy: aws.String(tc.keyPolicy)}, tc.getPolicyErr)
+			if tc.getPolicyErr == nil {
+				kmsMock.EXPECT().ListGrantsPages(&kms.ListGrantsInput{KeyId: aws.String(testKeyARN)}, gomock.Any()).DoAndReturn(listGrants(tc.grants...))
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1", DefaultEBSEncryptionKeyARN: testKeyARN}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.KMSClient = kmsMock
+			s.STSClient = stsMock
+
+			ready, err := s.ReconcileEBSEncryptionKey()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(ready).To(Equal(tc.expectReady))
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.EBSEncryptionKeyReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectReady {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			g.Expect(condition.Message).To(ContainSubstring(tc.expectInMessage))
+		})
+	}
+}
+
+func TestReconcileEBSEncryptionKeyCachesUsableKeys(t *testing.T) {
+	g := NewWithT(t)
+	usableKeys = cache.NewExpiring()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	kmsMock := mock_kmsiface.NewMockKMSAPI(mockCtrl)
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+	// the key policy and the grants are only read once.
+	stsMock.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(testCallerARN)}, nil).Times(2)
+	kmsMock.EXPECT().GetKeyPolicy(gomock.Any()).Return(&kms.GetKeyPolicyOutput{
+		Policy: aws.String(`{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "` + testRoleARN + `"}, "Action": "kms:*", "Resource": "*"}]}`),
+	}, nil)
+	kmsMock.EXPECT().ListGrantsPages(gomock.Any(), gomock.Any()).Return(nil)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1", DefaultEBSEncryptionKeyARN: testKeyARN}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(clusterScope)
+	s.KMSClient = kmsMock
+	s.STSClient = stsMock
+
+	for i := 0; i < 2; i++ {
+		ready, err := s.ReconcileEBSEncryptionKey()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ready).To(BeTrue())
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ebsencryption provides a service to check that the default EBS encryption key of a cluster can be used
+// to encrypt the volumes of its instances, and to check or enable the EBS encryption by default of the account.
+package ebsencryption
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope     scope.EBSEncryptionScope
+	EC2Client ec2iface.EC2API
+	KMSClient kmsiface.KMSAPI
+	STSClient stsiface.STSAPI
+}
+
+// NewService returns a new service given the api clients.
+func NewService(encryptionScope scope.EBSEncryptionScope) *Service {
+	return &Service{
+		scope:     encryptionScope,
+		EC2Client: scope.NewEC2Client(encryptionScope, encryptionScope, encryptionScope, encryptionScope.InfraCluster()),
+		KMSClient: scope.NewKMSClient(encryptionScope, encryptionScope, encryptionScope, encryptionScope.InfraCluster()),
+		STSClient: scope.NewSTSClient(encryptionScope, encryptionScope, encryptionScope, encryptionScope.InfraCluster()),
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -33,10 +34,8 @@ import (
 	"github.com/pkg/errors"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
-	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
-	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 )
 
 const (
@@ -59,6 +58,8 @@ const (
 
 	ubuntuOwnerIDUsGov = "513442679011"
 
+	ubuntuOwnerIDChina = "837727238323"
+
 	// Description regex for fetching Ubuntu AMIs for bastion host.
 	ubuntuImageDescription = "Canonical??Ubuntu??20.04?LTS??amd64?focal?image*"
 
@@ -66,6 +67,10 @@ const (
 	// when looking up machine AMIs.
 	defaultMachineAMILookupBaseOS = "ubuntu-18.04"
 
+	// DefaultWindowsAMILookupBaseOS is the default base operating system to use
+	// when looking up the AMIs of Windows machines.
+	DefaultWindowsAMILookupBaseOS = "windows-2019"
+
 	// DefaultAmiNameFormat is defined in the build/ directory of this project.
 	// The pattern is:
 	// 1. the string value `capa-ami-`
@@ -85,6 +90,9 @@ const (
 
 	// EKS GPU AMI ID SSM Parameter name.
 	eksGPUAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-gpu/recommended/image_id"
+
+	// EKS Windows AMI ID SSM Parameter name.
+	eksWindowsAmiSSMParameterFormat = "/aws/service/ami-windows-latest/Windows_Server-2019-English-Core-EKS_Optimized-%s/image_id"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
@@ -113,11 +121,11 @@ func GenerateAmiName(amiNameFormat, baseOS, kubernetesVersion string) (string, e
 }
 
 // Determine architecture based on instance type.
-func (s *Service) pickArchitectureForInstanceType(instanceType string) (string, error) {
+func (s *Service) pickArchitectureForInstanceType(ctx context.Context, instanceType string) (string, error) {
 	descInstanceTypeInput := &ec2.DescribeInstanceTypesInput{
 		InstanceTypes: []*string{&instanceType},
 	}
-	describeInstanceTypeResult, err := s.EC2Client.DescribeInstanceTypesWithContext(context.TODO(), descInstanceTypeInput)
+	describeInstanceTypeResult, err := s.EC2Client.DescribeInstanceTypesWithContext(ctx, descInstanceTypeInput)
 	if err != nil {
 		// if call to DescribeInstanceTypes fails due to permissions error, log a warning and return the default architecture.
 		if awserrors.IsPermissionsError(err) {
@@ -161,7 +169,7 @@ archCheck:
 }
 
 // DefaultAMILookup will do a default AMI lookup.
-func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string) (*ec2.Image, error) {
+func DefaultAMILookup(ctx context.Context, ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat string) (*ec2.Image, error) {
 	if amiNameFormat == "" {
 		amiNameFormat = DefaultAmiNameFormat
 	}
@@ -201,7 +209,7 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 		},
 	}
 
-	out, err := ec2Client.DescribeImagesWithContext(context.TODO(), describeImageInput)
+	out, err := ec2Client.DescribeImagesWithContext(ctx, describeImageInput)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find ami: %q", amiName)
 	}
@@ -217,14 +225,28 @@ func DefaultAMILookup(ec2Client ec2iface.EC2API, ownerID, baseOS, kubernetesVers
 }
 
 // defaultAMIIDLookup returns the default AMI based on region.
-func (s *Service) defaultAMIIDLookup(amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion string) (string, error) {
-	latestImage, err := DefaultAMILookup(s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat)
+func (s *Service) defaultAMIIDLookup(ctx context.Context, amiNameFormat, ownerID, baseOS, architecture, kubernetesVersion string) (string, error) {
+	key := amiCacheKey{
+		region:            s.scope.Region(),
+		ownerID:           ownerID,
+		baseOS:            baseOS,
+		architecture:      architecture,
+		kubernetesVersion: kubernetesVersion,
+		amiNameFormat:     amiNameFormat,
+	}
+	if id, ok := amiCache.get(key); ok {
+		s.scope.Debug("Using cached AMI", "ami-id", id)
+		return id, nil
+	}
+
+	latestImage, err := DefaultAMILookup(ctx, s.EC2Client, ownerID, baseOS, kubernetesVersion, architecture, amiNameFormat)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeImages", "Failed to find ami for OS=%s, Architecture=%s and Kubernetes-version=%s: %v", baseOS, architecture, kubernetesVersion, err)
 		return "", errors.Wrapf(err, "failed to find ami")
 	}
 
 	s.scope.Debug("Found and using an existing AMI", "ami-id", aws.StringValue(latestImage.ImageId))
+	amiCache.set(key, aws.StringValue(latestImage.ImageId))
 	return aws.StringValue(latestImage.ImageId), nil
 }
 
@@ -261,7 +283,7 @@ func GetLatestImage(imgs []*ec2.Image) (*ec2.Image, error) {
 	return imgs[len(imgs)-1], nil
 }
 
-func (s *Service) defaultBastionAMILookup() (string, error) {
+func (s *Service) defaultBastionAMILookup(ctx context.Context) (string, error) {
 	describeImageInput := &ec2.DescribeImagesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -284,9 +306,11 @@ func (s *Service) defaultBastionAMILookup() (string, error) {
 	}
 
 	ownerID := ubuntuOwnerID
-	partition := system.GetPartitionFromRegion(s.scope.Region())
-	if strings.Contains(partition, v1beta1.PartitionNameUSGov) {
+	switch s.scope.Partition() {
+	case endpoints.AwsUsGovPartitionID:
 		ownerID = ubuntuOwnerIDUsGov
+	case endpoints.AwsCnPartitionID:
+		ownerID = ubuntuOwnerIDChina
 	}
 
 	filter := &ec2.Filter{
@@ -295,7 +319,7 @@ func (s *Service) defaultBastionAMILookup() (string, error) {
 	}
 	describeImageInput.Filters = append(describeImageInput.Filters, filter)
 
-	out, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), describeImageInput)
+	out, err := s.EC2Client.DescribeImagesWithContext(ctx, describeImageInput)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to describe images within region: %q", s.scope.Region())
 	}
@@ -309,7 +333,7 @@ func (s *Service) defaultBastionAMILookup() (string, error) {
 	return *latestImage.ImageId, nil
 }
 
-func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, amiType *infrav1.EKSAMILookupType) (string, error) {
+func (s *Service) eksAMILookup(ctx context.Context, kubernetesVersion string, architecture string, amiType *infrav1.EKSAMILookupType) (string, error) {
 	// format ssm parameter path properly
 	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
 	if err != nil {
@@ -336,11 +360,24 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 		}
 	}
 
-	input := &ssm.GetParameterInput{
-		Name: aws.String(paramName),
+	return s.ssmParameterAMILookup(ctx, paramName)
+}
+
+// eksWindowsAMILookup returns the EKS optimized Windows Server AMI for a Kubernetes version.
+func (s *Service) eksWindowsAMILookup(ctx context.Context, kubernetesVersion string) (string, error) {
+	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
+	if err != nil {
+		return "", err
 	}
 
-	out, err := s.SSMClient.GetParameter(input)
+	return s.ssmParameterAMILookup(ctx, fmt.Sprintf(eksWindowsAmiSSMParameterFormat, formattedVersion))
+}
+
+// ssmParameterAMILookup returns the AMI ID stored in an AWS Systems Manager parameter.
+func (s *Service) ssmParameterAMILookup(ctx context.Context, paramName string) (string, error) {
+	out, err := s.SSMClient.GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name: aws.String(paramName),
+	})
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedGetParameter", "Failed to get ami SSM parameter %q: %v", paramName, err)
 
@@ -352,7 +389,7 @@ func (s *Service) eksAMILookup(kubernetesVersion string, architecture string, am
 	}
 
 	id := aws.StringValue(out.Parameter.Value)
-	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
+	s.scope.Info("found AMI", "id", id, "parameter", paramName)
 
 	return id, nil
 }
@@ -19,8 +19,10 @@ package ec2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
@@ -115,7 +117,7 @@ func TestDefaultAMILookup(t *testing.T) {
 			ec2Mock := mocks.NewMockEC2API(mockCtrl)
 			tc.expect(ec2Mock.EXPECT())
 
-			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
+			img, err := DefaultAMILookup(context.TODO(), ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
 			tc.check(g, img, err)
 		})
 	}
@@ -203,7 +205,7 @@ func TestDefaultAMILookupArm64(t *testing.T) {
 			ec2Mock := mocks.NewMockEC2API(mockCtrl)
 			tc.expect(ec2Mock.EXPECT())
 
-			img, err := DefaultAMILookup(ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
+			img, err := DefaultAMILookup(context.TODO(), ec2Mock, tc.args.ownerID, tc.args.baseOS, tc.args.kubernetesVersion, tc.args.architecture, tc.args.amiNameFormat)
 			tc.check(g, img, err)
 		})
 	}
@@ -288,12 +290,156 @@ func TestAMIs(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			id, err := s.defaultAMIIDLookup("", "", "base os-baseos version", "x86_64", "v1.11.1")
+			id, err := s.defaultAMIIDLookup(context.TODO(), "", "", "base os-baseos version", "x86_64", "v1.11.1")
 			tc.check(g, id, err)
 		})
 	}
 }
 
+func TestEKSWindowsAMILookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+	ssmMock.EXPECT().GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
+		Name: aws.String("/aws/service/ami-windows-latest/Windows_Server-2019-English-Core-EKS_Optimized-1.28/image_id"),
+	})).Return(&ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{
+			Value: aws.String("ami-windows"),
+		},
+	}, nil)
+
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(clusterScope)
+	s.SSMClient = ssmMock
+
+	id, err := s.eksWindowsAMILookup(context.TODO(), "v1.28.3")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("ami-windows"))
+}
+
+func TestAMICache(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+
+	SetAMICacheTTL(time.Minute)
+	defer SetAMICacheTTL(0)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+		Return(&ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{
+				{
+					ImageId:      aws.String("ami-1"),
+					CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+				},
+			},
+		}, nil).Times(2)
+
+	clusterScope, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	for i := 0; i < 3; i++ {
+		id, err := s.defaultAMIIDLookup(context.TODO(), "", "", "ubuntu-22.04", "x86_64", "v1.28.3")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(id).To(Equal("ami-1"))
+	}
+
+	// A lookup with different filters isn't served from the cache.
+	id, err := s.defaultAMIIDLookup(context.TODO(), "", "", "ubuntu-22.04", "x86_64", "v1.29.0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("ami-1"))
+}
+
+func TestDefaultBastionAMILookupPartitions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		region          string
+		partition       string
+		expectedOwnerID string
+	}{
+		{
+			name:            "uses the Canonical account of the commercial partition",
+			region:          "us-east-1",
+			expectedOwnerID: ubuntuOwnerID,
+		},
+		{
+			name:            "uses the Canonical account of the GovCloud partition",
+			region:          "us-gov-west-1",
+			expectedOwnerID: ubuntuOwnerIDUsGov,
+		},
+		{
+			name:            "uses the Canonical account of the China partition",
+			region:          "cn-north-1",
+			expectedOwnerID: ubuntuOwnerIDChina,
+		},
+		{
+			name:            "honours the partition of the cluster",
+			region:          "us-east-1",
+			partition:       "aws-cn",
+			expectedOwnerID: ubuntuOwnerIDChina,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+				DoAndReturn(func(_ context.Context, input *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+					ownerFilter := input.Filters[len(input.Filters)-1]
+					g.Expect(aws.StringValue(ownerFilter.Name)).To(Equal("owner-id"))
+					g.Expect(aws.StringValueSlice(ownerFilter.Values)).To(ConsistOf(tc.expectedOwnerID))
+
+					return &ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								ImageId:      aws.String("ami-1"),
+								CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil
+				})
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope.AWSCluster.Spec.Region = tc.region
+			clusterScope.AWSCluster.Spec.Partition = tc.partition
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			id, err := s.defaultBastionAMILookup(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(id).To(Equal("ami-1"))
+		})
+	}
+}
+
 func TestFormatVersionForEKS(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -495,7 +641,7 @@ func TestEKSAMILookUp(t *testing.T) {
 			arch:       "x86_64",
 			amiType:    &gpuAMI,
 			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
 					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2-gpu/recommended/image_id"),
 				})).Return(&ssm.GetParameterOutput{
 					Parameter: &ssm.Parameter{
@@ -511,7 +657,7 @@ func TestEKSAMILookUp(t *testing.T) {
 			k8sVersion: "v1.23.3",
 			arch:       "x86_64",
 			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
 					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2/recommended/image_id"),
 				})).Return(&ssm.GetParameterOutput{
 					Parameter: &ssm.Parameter{
@@ -527,7 +673,7 @@ func TestEKSAMILookUp(t *testing.T) {
 			k8sVersion: "v1.23.3",
 			arch:       "x86_64",
 			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
 					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2/recommended/image_id"),
 				})).Return(nil, awserrors.NewFailedDependency("dependency failure"))
 			},
@@ -544,7 +690,7 @@ func TestEKSAMILookUp(t *testing.T) {
 			k8sVersion: "v1.23.3",
 			arch:       "x86_64",
 			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
 					Name: aws.String("/aws/service/eks/optimized-ami/1.23/amazon-linux-2/recommended/image_id"),
 				})).Return(&ssm.GetParameterOutput{}, nil)
 			},
@@ -570,7 +716,7 @@ func TestEKSAMILookUp(t *testing.T) {
 			s := NewService(clusterScope)
 			s.SSMClient = ssmMock
 
-			got, err := s.eksAMILookup(tt.k8sVersion, tt.arch, tt.amiType)
+			got, err := s.eksAMILookup(context.TODO(), tt.k8sVersion, tt.arch, tt.amiType)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -579,3 +725,75 @@ func TestEKSAMILookUp(t *testing.T) {
 		})
 	}
 }
+
+func TestSSMParameterAMILookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	paramName := "/aws/service/bottlerocket/aws-k8s-1.28/x86_64/latest/image_id"
+
+	tests := []struct {
+		name    string
+		expect  func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Should return the AMI ID stored in the SSM parameter",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(&ssm.GetParameterOutput{
+					Parameter: &ssm.Parameter{
+						Value: aws.String("ami-0123456789abcdef0"),
+					},
+				}, nil)
+			},
+			want: "ami-0123456789abcdef0",
+		},
+		{
+			name: "Should return an error if the SSM parameter has no value",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(&ssm.GetParameterOutput{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if the SSM parameter can't be read",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameterWithContext(context.TODO(), gomock.Eq(&ssm.GetParameterInput{
+					Name: aws.String(paramName),
+				})).Return(nil, awserrors.NewNotFound("not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			tt.expect(ssmMock.EXPECT())
+
+			clusterScope, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+			s.SSMClient = ssmMock
+
+			got, err := s.ssmParameterAMILookup(context.TODO(), paramName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
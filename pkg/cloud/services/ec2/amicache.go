@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// DefaultAMICacheTTL is the default duration for which the AMIs found by DescribeImages-based
+// lookups are cached by the controllers.
+const DefaultAMICacheTTL = 5 * time.Minute
+
+// amiCache caches the IDs of the AMIs found by DescribeImages-based lookups. It is shared by all
+// the services, as the same AMIs are usually looked up for all the machines of a cluster.
+var amiCache = &imageCache{cache: cache.NewExpiring()}
+
+// amiCacheKey identifies the DescribeImages filters of an AMI lookup in a region.
+type amiCacheKey struct {
+	region            string
+	ownerID           string
+	baseOS            string
+	architecture      string
+	kubernetesVersion string
+	amiNameFormat     string
+}
+
+type imageCache struct {
+	mu    sync.RWMutex
+	ttl   time.Duration
+	cache *cache.Expiring
+}
+
+// SetAMICacheTTL sets how long the AMIs found by DescribeImages-based lookups are cached.
+// A TTL of zero, the default, disables the cache.
+func SetAMICacheTTL(ttl time.Duration) {
+	amiCache.mu.Lock()
+	defer amiCache.mu.Unlock()
+
+	amiCache.ttl = ttl
+	if ttl == 0 {
+		amiCache.cache = cache.NewExpiring()
+	}
+}
+
+func (c *imageCache) get(key amiCacheKey) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.ttl == 0 {
+		return "", false
+	}
+	id, ok := c.cache.Get(key)
+	if !ok {
+		return "", false
+	}
+	return id.(string), true
+}
+
+func (c *imageCache) set(key amiCacheKey, id string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.ttl == 0 {
+		return
+	}
+	c.cache.Set(key, id, c.ttl)
+}
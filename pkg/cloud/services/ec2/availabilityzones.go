@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+)
+
+// AvailabilityZonesOfferingInstanceTypes returns the availability zones of the region of the cluster which offer
+// all the given instance types.
+func (s *Service) AvailabilityZonesOfferingInstanceTypes(ctx context.Context, instanceTypes []string) (sets.Set[string], error) {
+	wanted := sets.New(instanceTypes...)
+	offerings := map[string]sets.Set[string]{}
+
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters:      []*ec2.Filter{filter.EC2.InstanceTypes(sets.List(wanted)...)},
+	}
+	if err := s.EC2Client.DescribeInstanceTypeOfferingsPagesWithContext(ctx, input, func(out *ec2.DescribeInstanceTypeOfferingsOutput, last bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			zone := aws.StringValue(offering.Location)
+			if offerings[zone] == nil {
+				offerings[zone] = sets.New[string]()
+			}
+			offerings[zone].Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the offerings of instance types %v", sets.List(wanted))
+	}
+
+	zones := sets.New[string]()
+	for zone, offered := range offerings {
+		if offered.IsSuperset(wanted) {
+			zones.Insert(zone)
+		}
+	}
+	return zones, nil
+}
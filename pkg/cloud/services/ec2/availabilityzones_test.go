@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestAvailabilityZonesOfferingInstanceTypes(t *testing.T) {
+	offeringsInput := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("instance-type"), Values: aws.StringSlice([]string{"g5.xlarge", "m5.large"})},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		want    sets.Set[string]
+		wantErr bool
+	}{
+		{
+			name: "returns the availability zones offering all the instance types",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypeOfferingsPagesWithContext(context.TODO(), offeringsInput, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool, _ ...request.Option) error {
+						fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+							InstanceTypeOfferings: []*ec2.InstanceTypeOffering{
+								{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1a")},
+								{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1b")},
+							},
+						}, false)
+						fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+							InstanceTypeOfferings: []*ec2.InstanceTypeOffering{
+								{InstanceType: aws.String("g5.xlarge"), Location: aws.String("us-east-1a")},
+								{InstanceType: aws.String("g5.xlarge"), Location: aws.String("us-east-1c")},
+							},
+						}, true)
+						return nil
+					})
+			},
+			want: sets.New("us-east-1a"),
+		},
+		{
+			name: "returns an error when the offerings can't be described",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypeOfferingsPagesWithContext(context.TODO(), offeringsInput, gomock.Any()).
+					Return(awserr.New(awserrors.UnauthorizedOperation, "not authorized", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tt.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			zones, err := s.AvailabilityZonesOfferingInstanceTypes(context.TODO(), []string{"m5.large", "g5.xlarge", "m5.large"})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(zones).To(Equal(tt.want))
+		})
+	}
+}
@@ -45,17 +45,17 @@ var (
 )
 
 // ReconcileBastion ensures a bastion is created for the cluster.
-func (s *Service) ReconcileBastion() error {
+func (s *Service) ReconcileBastion(ctx context.Context) error {
 	if !s.scope.Bastion().Enabled {
 		s.scope.Trace("Skipping bastion reconcile")
-		_, err := s.describeBastionInstance()
+		_, err := s.describeBastionInstance(ctx)
 		if err != nil {
 			if awserrors.IsNotFound(err) {
 				return nil
 			}
 			return err
 		}
-		return s.DeleteBastion()
+		return s.DeleteBastion(ctx)
 	}
 
 	s.scope.Debug("Reconciling bastion host")
@@ -69,7 +69,7 @@ func (s *Service) ReconcileBastion() error {
 	}
 
 	// Describe bastion instance, if any.
-	instance, err := s.describeBastionInstance()
+	instance, err := s.describeBastionInstance(ctx)
 	if awserrors.IsNotFound(err) { //nolint:nestif
 		if !conditions.Has(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition) {
 			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, clusterv1.ConditionSeverityInfo, "")
@@ -77,12 +77,12 @@ func (s *Service) ReconcileBastion() error {
 				return errors.Wrap(err, "failed to patch conditions")
 			}
 		}
-		defaultBastion, err := s.getDefaultBastion(s.scope.Bastion().InstanceType, s.scope.Bastion().AMI)
+		defaultBastion, err := s.getDefaultBastion(ctx, s.scope.Bastion().InstanceType, s.scope.Bastion().AMI)
 		if err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedFetchingBastion", "Failed to fetch default bastion instance: %v", err)
 			return err
 		}
-		instance, err = s.runInstance("bastion", defaultBastion)
+		instance, err = s.runInstance(ctx, "bastion", defaultBastion, s.scope.AdditionalTags())
 		if err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
 			return err
@@ -104,8 +104,8 @@ func (s *Service) ReconcileBastion() error {
 }
 
 // DeleteBastion deletes the Bastion instance.
-func (s *Service) DeleteBastion() error {
-	instance, err := s.describeBastionInstance()
+func (s *Service) DeleteBastion(ctx context.Context) error {
+	instance, err := s.describeBastionInstance(ctx)
 	if err != nil {
 		if awserrors.IsNotFound(err) {
 			s.scope.Trace("bastion instance does not exist")
@@ -119,7 +119,7 @@ func (s *Service) DeleteBastion() error {
 		return err
 	}
 
-	if err := s.TerminateInstanceAndWait(instance.ID); err != nil {
+	if err := s.TerminateInstanceAndWait(ctx, instance.ID); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 		record.Warnf(s.scope.InfraCluster(), "FailedTerminateBastion", "Failed to terminate bastion instance %q: %v", instance.ID, err)
 		return errors.Wrap(err, "unable to delete bastion instance")
@@ -134,7 +134,7 @@ func (s *Service) DeleteBastion() error {
 	return nil
 }
 
-func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
+func (s *Service) describeBastionInstance(ctx context.Context) (*infrav1.Instance, error) {
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
@@ -148,7 +148,7 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 		},
 	}
 
-	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeInstancesWithContext(ctx, input)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeBastionHost", "Failed to describe bastion host: %v", err)
 		return nil, errors.Wrap(err, "failed to describe bastion host")
@@ -167,13 +167,16 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	return nil, awserrors.NewNotFound("bastion host not found")
 }
 
-func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance, error) {
+func (s *Service) getDefaultBastion(ctx context.Context, instanceType, ami string) (*infrav1.Instance, error) {
 	name := fmt.Sprintf("%s-bastion", s.scope.Name())
 	userData, _ := userdata.NewBastion(&userdata.BastionInput{})
 
-	// If SSHKeyName WAS NOT provided, use the defaultSSHKeyName
+	// If SSHKeyName WAS NOT provided, use the defaultSSHKeyName, unless the instances of the cluster are accessed with Systems Manager.
 	keyName := s.scope.SSHKeyName()
-	if keyName == nil {
+	switch {
+	case s.scope.InstanceAccessMode() == infrav1.InstanceAccessModeSSM:
+		keyName = nil
+	case keyName == nil:
 		keyName = aws.String(defaultSSHKeyName)
 	}
 
@@ -189,7 +192,7 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 
 	if ami == "" {
 		var err error
-		ami, err = s.defaultBastionAMILookup()
+		ami, err = s.defaultBastionAMILookup(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -178,6 +178,7 @@ func TestServiceDeleteBastion(t *testing.T) {
 				awsCluster := &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
 						NetworkSpec: infrav1.NetworkSpec{
 							VPC: infrav1.VPCSpec{
 								ID: "vpcID",
@@ -210,7 +211,7 @@ func TestServiceDeleteBastion(t *testing.T) {
 				s := NewService(scope)
 				s.EC2Client = ec2Mock
 
-				err = s.DeleteBastion()
+				err = s.DeleteBastion(context.TODO())
 				if tc.expectError {
 					g.Expect(err).NotTo(BeNil())
 					return
@@ -400,6 +401,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 				awsCluster := &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
 						NetworkSpec: infrav1.NetworkSpec{
 							VPC: infrav1.VPCSpec{
 								ID: "vpcID",
@@ -442,7 +444,7 @@ func TestServiceReconcileBastion(t *testing.T) {
 				s := NewService(scope)
 				s.EC2Client = ec2Mock
 
-				err = s.ReconcileBastion()
+				err = s.ReconcileBastion(context.TODO())
 				if tc.expectError {
 					g.Expect(err).NotTo(BeNil())
 					return
@@ -675,7 +677,7 @@ func TestServiceReconcileBastionUSGOV(t *testing.T) {
 				s := NewService(scope)
 				s.EC2Client = ec2Mock
 
-				err = s.ReconcileBastion()
+				err = s.ReconcileBastion(context.TODO())
 				if tc.expectError {
 					g.Expect(err).NotTo(BeNil())
 					return
@@ -135,6 +135,7 @@ func newAWSCluster() *infrav1.AWSCluster {
 			Namespace: "aws-cluster-ns",
 		},
 		Spec: infrav1.AWSClusterSpec{
+			Region:            "us-east-1",
 			ImageLookupFormat: "img-lookup-format",
 			ImageLookupBaseOS: "img-lookup-os",
 			ImageLookupOrg:    "img-lookup-org",
@@ -161,6 +162,7 @@ func newAWSManagedControlPlane() *ekscontrolplanev1.AWSManagedControlPlane {
 			Name:      "aws-cluster-name",
 			Namespace: "aws-cluster-ns",
 		},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{Region: "us-east-1"},
 	}
 }
 
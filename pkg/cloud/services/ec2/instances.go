@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
 
@@ -35,13 +36,23 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
 
+const (
+	// SSMPingStatusOnline is the SSM ping status of the instances whose SSM agent is connected to Systems Manager.
+	SSMPingStatusOnline = ssm.PingStatusOnline
+
+	// SSMPingStatusNotRegistered is the SSM ping status of the instances whose SSM agent never registered them with
+	// Systems Manager, e.g. because they have no instance profile allowing it or no route to the SSM endpoints.
+	SSMPingStatusNotRegistered = "NotRegistered"
+)
+
 // GetRunningInstanceByTags returns the existing instance or nothing if it doesn't exist.
-func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error) {
+func (s *Service) GetRunningInstanceByTags(ctx context.Context, scope *scope.MachineScope) (*infrav1.Instance, error) {
 	s.scope.Debug("Looking for existing machine instance by tags")
 
 	input := &ec2.DescribeInstancesInput{
@@ -52,7 +63,7 @@ func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.
 		},
 	}
 
-	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeInstancesWithContext(ctx, input)
 	switch {
 	case awserrors.IsNotFound(err):
 		return nil, nil
@@ -75,7 +86,7 @@ func (s *Service) GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.
 
 // InstanceIfExists returns the existing instance by id and errors if it cannot find the instance(ErrInstanceNotFoundByID) or API call fails (ErrDescribeInstance).
 // Returns empty instance with nil error, only when providerID is nil.
-func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
+func (s *Service) InstanceIfExists(ctx context.Context, id *string) (*infrav1.Instance, error) {
 	if id == nil {
 		s.scope.Info("Instance does not have an instance id")
 		return nil, nil
@@ -87,7 +98,7 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 		InstanceIds: []*string{id},
 	}
 
-	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeInstancesWithContext(ctx, input)
 	switch {
 	case awserrors.IsNotFound(err):
 		record.Eventf(s.scope.InfraCluster(), "FailedFindInstances", "failed to find instance by providerId %q: %v", *id, err)
@@ -109,19 +120,36 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 // CreateInstance runs an ec2 instance.
 //
 //nolint:gocyclo // this function has multiple processes to perform
-func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error) {
+func (s *Service) CreateInstance(ctx context.Context, scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error) {
 	s.scope.Debug("Creating an instance for a machine")
 
 	input := &infrav1.Instance{
 		Type:              scope.AWSMachine.Spec.InstanceType,
-		IAMProfile:        scope.AWSMachine.Spec.IAMInstanceProfile,
+		IAMProfile:        scope.IAMInstanceProfile(),
 		RootVolume:        scope.AWSMachine.Spec.RootVolume.DeepCopy(),
 		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
 	}
 
+	// Encrypt the volumes which don't set their own key with the default key of the cluster, including the root
+	// volume of the AMI when the machine doesn't configure it.
+	if key := scope.InfraCluster.DefaultEBSEncryptionKeyARN(); key != "" {
+		if input.RootVolume == nil {
+			input.RootVolume = &infrav1.Volume{}
+		}
+		setDefaultEncryptionKey(input.RootVolume, key)
+
+		input.NonRootVolumes = append([]infrav1.Volume{}, input.NonRootVolumes...)
+		for i := range input.NonRootVolumes {
+			setDefaultEncryptionKey(&input.NonRootVolumes[i], key)
+		}
+	}
+
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
 	additionalTags := scope.AdditionalTags()
+	// Build adds the cloud provider and machine name tags to the additional tags it is given, so keep a copy
+	// of the additional tags alone for the volumes.
+	volumeTags := additionalTags.DeepCopy()
 	input.Tags = infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.KubernetesClusterName(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -132,7 +160,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	var err error
 
-	imageArchitecture, err := s.pickArchitectureForInstanceType(input.Type)
+	imageArchitecture, err := s.pickArchitectureForInstanceType(ctx, input.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +168,11 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	// Pick image from the machine configuration, or use a default one.
 	if scope.AWSMachine.Spec.AMI.ID != nil { //nolint:nestif
 		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
+	} else if scope.AWSMachine.Spec.ImageLookupSSMParameter != "" {
+		input.ImageID, err = s.ssmParameterAMILookup(ctx, scope.AWSMachine.Spec.ImageLookupSSMParameter)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		if scope.Machine.Spec.Version == nil {
 			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
@@ -158,32 +191,41 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 			imageLookupOrg = scope.InfraCluster.ImageLookupOrg()
 		}
 
+		// The base OS of the cluster is ignored for Windows machines, as it is the one of the Linux machines
+		// of mixed clusters.
 		imageLookupBaseOS := scope.AWSMachine.Spec.ImageLookupBaseOS
-		if imageLookupBaseOS == "" {
+		if imageLookupBaseOS == "" && !scope.IsWindows() {
 			imageLookupBaseOS = scope.InfraCluster.ImageLookupBaseOS()
 		}
 
 		if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
-			input.ImageID, err = s.eksAMILookup(*scope.Machine.Spec.Version, imageArchitecture, scope.AWSMachine.Spec.AMI.EKSOptimizedLookupType)
+			if scope.IsWindows() {
+				input.ImageID, err = s.eksWindowsAMILookup(ctx, *scope.Machine.Spec.Version)
+			} else {
+				input.ImageID, err = s.eksAMILookup(ctx, *scope.Machine.Spec.Version, imageArchitecture, scope.AWSMachine.Spec.AMI.EKSOptimizedLookupType)
+			}
 			if err != nil {
 				return nil, err
 			}
 		} else {
-			input.ImageID, err = s.defaultAMIIDLookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
+			if imageLookupBaseOS == "" && scope.IsWindows() {
+				imageLookupBaseOS = DefaultWindowsAMILookupBaseOS
+			}
+			input.ImageID, err = s.defaultAMIIDLookup(ctx, imageLookupFormat, imageLookupOrg, imageLookupBaseOS, imageArchitecture, *scope.Machine.Spec.Version)
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
 
-	subnetID, err := s.findSubnet(scope)
+	subnetID, err := s.findSubnet(ctx, scope)
 	if err != nil {
 		return nil, err
 	}
 	input.SubnetID = subnetID
 
 	if ptr.Deref(scope.AWSMachine.Spec.PublicIP, false) {
-		subnets, err := s.getFilteredSubnets(&ec2.Filter{
+		subnets, err := s.getFilteredSubnets(ctx, &ec2.Filter{
 			Name:   aws.String("subnet-id"),
 			Values: aws.StringSlice([]string{subnetID}),
 		})
@@ -212,7 +254,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	input.UserData = ptr.To[string](base64.StdEncoding.EncodeToString(userData))
 
 	// Set security groups.
-	ids, err := s.GetCoreSecurityGroups(scope)
+	ids, err := s.GetCoreSecurityGroups(ctx, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -239,8 +281,9 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		}
 	}
 
-	// Only set input.SSHKeyName if the user did not explicitly request no ssh key be set (explicitly setting "" on either the Machine or related Cluster)
-	if prioritizedSSHKeyName != "" {
+	// Only set input.SSHKeyName if the user did not explicitly request no ssh key be set (explicitly setting "" on either the Machine or related Cluster),
+	// and the instances of the cluster are not accessed with Systems Manager.
+	if prioritizedSSHKeyName != "" && scope.InfraCluster.InstanceAccessMode() != infrav1.InstanceAccessModeSSM {
 		input.SSHKeyName = aws.String(prioritizedSSHKeyName)
 	}
 
@@ -256,9 +299,11 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 
 	input.PrivateDNSName = scope.AWSMachine.Spec.PrivateDNSName
 
+	input.IPv6Only = scope.AWSMachine.Spec.IPv6Only
+
 	s.scope.Debug("Running instance", "machine-role", scope.Role())
 	s.scope.Debug("Running instance with instance metadata options", "metadata options", input.InstanceMetadataOptions)
-	out, err := s.runInstance(scope.Role(), input)
+	out, err := s.runInstance(ctx, scope.Role(), input, volumeTags)
 	if err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
@@ -275,7 +320,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	if len(input.NetworkInterfaces) > 0 {
 		for _, id := range input.NetworkInterfaces {
 			s.scope.Debug("Attaching security groups to provided network interface", "groups", input.SecurityGroupIDs, "interface", id)
-			if err := s.attachSecurityGroupsToNetworkInterface(input.SecurityGroupIDs, id); err != nil {
+			if err := s.attachSecurityGroupsToNetworkInterface(ctx, input.SecurityGroupIDs, id); err != nil {
 				return nil, err
 			}
 		}
@@ -284,7 +329,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	s.scope.Debug("Adding tags on each network interface from resource", "resource-id", out.ID)
 
 	// Fetching the network interfaces attached to the specific instance
-	networkInterfaces, err := s.getInstanceENIs(out.ID)
+	networkInterfaces, err := s.getInstanceENIs(ctx, out.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -296,7 +341,7 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		s.scope.Debug("Attempting to create tags from resource", "resource-id", out.ID)
 		for _, networkInterface := range networkInterfaces {
 			// Create/Update tags in AWS.
-			if err := s.UpdateResourceTags(networkInterface.NetworkInterfaceId, out.Tags, nil); err != nil {
+			if err := s.UpdateResourceTags(ctx, networkInterface.NetworkInterfaceId, out.Tags, nil); err != nil {
 				return nil, errors.Wrapf(err, "failed to create tags for resource %q: ", *networkInterface.NetworkInterfaceId)
 			}
 		}
@@ -309,29 +354,39 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 // findSubnet attempts to retrieve a subnet ID in the following order:
 // - subnetID specified in machine configuration,
 // - subnet based on filters in machine configuration
+// - subnet of the cluster VPC based on the subnet selector in machine configuration
 // - subnet based on the availability zone specified,
 // - default to the first private subnet available.
-func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
+func (s *Service) findSubnet(ctx context.Context, scope *scope.MachineScope) (string, error) {
 	// Check Machine.Spec.FailureDomain first as it's used by KubeadmControlPlane to spread machines across failure domains.
 	failureDomain := scope.Machine.Spec.FailureDomain
 
 	// We basically have 2 sources for subnets:
-	//   1. If subnet.id or subnet.filters are specified, we directly query AWS
-	//   2. All other cases use the subnets provided in the cluster network spec without ever calling AWS
+	//   1. If subnet.id, subnet.filters or subnetSelector are specified, we directly query AWS
+	//   2. All other cases use the subnets provided in the cluster network spec without ever calling AWS,
+	//      skipping the subnets which are replaced by another subnet of the spec, and the subnets whose
+	//      IPv6-native addressing doesn't match the machine's.
 
 	switch {
-	case scope.AWSMachine.Spec.Subnet != nil && (scope.AWSMachine.Spec.Subnet.ID != nil || scope.AWSMachine.Spec.Subnet.Filters != nil):
+	case scope.AWSMachine.Spec.SubnetSelector != nil || scope.AWSMachine.Spec.Subnet != nil && (scope.AWSMachine.Spec.Subnet.ID != nil || scope.AWSMachine.Spec.Subnet.Filters != nil):
 		criteria := []*ec2.Filter{
 			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
 		}
-		if scope.AWSMachine.Spec.Subnet.ID != nil {
-			criteria = append(criteria, &ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{*scope.AWSMachine.Spec.Subnet.ID})})
-		}
-		for _, f := range scope.AWSMachine.Spec.Subnet.Filters {
-			criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+		if selector := scope.AWSMachine.Spec.SubnetSelector; selector != nil {
+			// The selected subnets are looked up in the VPC of the cluster, so that the template of the machine can
+			// be used by clusters in different VPCs.
+			criteria = append(criteria, filter.EC2.VPC(s.scope.VPC().ID))
+			criteria = append(criteria, filter.EC2.SubnetSelector(selector)...)
+		} else {
+			if scope.AWSMachine.Spec.Subnet.ID != nil {
+				criteria = append(criteria, &ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{*scope.AWSMachine.Spec.Subnet.ID})})
+			}
+			for _, f := range scope.AWSMachine.Spec.Subnet.Filters {
+				criteria = append(criteria, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+			}
 		}
 
-		subnets, err := s.getFilteredSubnets(criteria...)
+		subnets, err := s.getFilteredSubnets(ctx, criteria...)
 		if err != nil {
 			return "", errors.Wrapf(err, "failed to filter subnets for criteria %q", criteria)
 		}
@@ -352,6 +407,14 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 					*subnet.SubnetId, *subnet.AvailabilityZone, *failureDomain)
 				continue
 			}
+			if scope.AWSMachine.Spec.IPv6Only != aws.BoolValue(subnet.Ipv6Native) {
+				if scope.AWSMachine.Spec.IPv6Only {
+					errMessage += fmt.Sprintf(" subnet %q is not an IPv6-native subnet.", *subnet.SubnetId)
+				} else {
+					errMessage += fmt.Sprintf(" subnet %q is an IPv6-native subnet, which requires an IPv6-only machine.", *subnet.SubnetId)
+				}
+				continue
+			}
 			if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP && !s.scope.Subnets().FindByID(*subnet.SubnetId).IsPublic {
 				errMessage += fmt.Sprintf(" subnet %q is a private subnet.", *subnet.SubnetId)
 				continue
@@ -372,7 +435,7 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		return *filtered[0].SubnetId, nil
 	case failureDomain != nil:
 		if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP {
-			subnets := s.scope.Subnets().FilterPublic().FilterByZone(*failureDomain)
+			subnets := s.placementSubnets(scope).FilterPublic().FilterByZone(*failureDomain)
 			if len(subnets) == 0 {
 				errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available in availability zone %q",
 					scope.Name(), *failureDomain)
@@ -382,7 +445,7 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 			return subnets[0].GetResourceID(), nil
 		}
 
-		subnets := s.scope.Subnets().FilterPrivate().FilterByZone(*failureDomain)
+		subnets := s.placementSubnets(scope).FilterPrivate().FilterByZone(*failureDomain)
 		if len(subnets) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q",
 				scope.Name(), *failureDomain)
@@ -391,7 +454,7 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		}
 		return subnets[0].GetResourceID(), nil
 	case scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP:
-		subnets := s.scope.Subnets().FilterPublic()
+		subnets := s.placementSubnets(scope).FilterPublic()
 		if len(subnets) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available", scope.Name())
 			record.Eventf(scope.AWSMachine, "FailedCreate", errMessage)
@@ -403,7 +466,7 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		// with control plane machines.
 
 	default:
-		sns := s.scope.Subnets().FilterPrivate()
+		sns := s.placementSubnets(scope).FilterPrivate()
 		if len(sns) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available", scope.Name())
 			record.Eventf(s.scope.InfraCluster(), "FailedCreateInstance", errMessage)
@@ -413,9 +476,19 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	}
 }
 
+// placementSubnets returns the subnets of the cluster network spec a machine can be placed in: the subnets which
+// aren't replaced by another subnet, and which are IPv6-native if and only if the machine is IPv6-only.
+func (s *Service) placementSubnets(scope *scope.MachineScope) infrav1.Subnets {
+	subnets := s.scope.Subnets().WithoutReplaced()
+	if scope.AWSMachine.Spec.IPv6Only {
+		return subnets.FilterIPv6Native()
+	}
+	return subnets.WithoutIPv6Native()
+}
+
 // getFilteredSubnets fetches subnets filtered based on the criteria passed.
-func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, error) {
-	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: criteria})
+func (s *Service) getFilteredSubnets(ctx context.Context, criteria ...*ec2.Filter) ([]*ec2.Subnet, error) {
+	out, err := s.EC2Client.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{Filters: criteria})
 	if err != nil {
 		return nil, err
 	}
@@ -424,7 +497,7 @@ func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, er
 
 // GetCoreSecurityGroups looks up the security group IDs managed by this actuator
 // They are considered "core" to its proper functioning.
-func (s *Service) GetCoreSecurityGroups(scope *scope.MachineScope) ([]string, error) {
+func (s *Service) GetCoreSecurityGroups(_ context.Context, scope *scope.MachineScope) ([]string, error) {
 	if scope.IsExternallyManaged() {
 		ids := make([]string, 0)
 		for _, sg := range scope.AWSMachine.Spec.AdditionalSecurityGroups {
@@ -499,14 +572,14 @@ func (s *Service) GetCoreNodeSecurityGroups(scope scope.LaunchTemplateScope) ([]
 
 // TerminateInstance terminates an EC2 instance.
 // Returns nil on success, error in all other cases.
-func (s *Service) TerminateInstance(instanceID string) error {
+func (s *Service) TerminateInstance(ctx context.Context, instanceID string) error {
 	s.scope.Debug("Attempting to terminate instance", "instance-id", instanceID)
 
 	input := &ec2.TerminateInstancesInput{
 		InstanceIds: aws.StringSlice([]string{instanceID}),
 	}
 
-	if _, err := s.EC2Client.TerminateInstancesWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.TerminateInstancesWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to terminate instance with id %q", instanceID)
 	}
 
@@ -516,8 +589,8 @@ func (s *Service) TerminateInstance(instanceID string) error {
 
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
-func (s *Service) TerminateInstanceAndWait(instanceID string) error {
-	if err := s.TerminateInstance(instanceID); err != nil {
+func (s *Service) TerminateInstanceAndWait(ctx context.Context, instanceID string) error {
+	if err := s.TerminateInstance(ctx, instanceID); err != nil {
 		return err
 	}
 
@@ -527,14 +600,14 @@ func (s *Service) TerminateInstanceAndWait(instanceID string) error {
 		InstanceIds: aws.StringSlice([]string{instanceID}),
 	}
 
-	if err := s.EC2Client.WaitUntilInstanceTerminatedWithContext(context.TODO(), input); err != nil {
+	if err := s.EC2Client.WaitUntilInstanceTerminatedWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to wait for instance %q termination", instanceID)
 	}
 
 	return nil
 }
 
-func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instance, error) {
+func (s *Service) runInstance(ctx context.Context, role string, i *infrav1.Instance, volumeTags infrav1.Tags) (*infrav1.Instance, error) {
 	input := &ec2.RunInstancesInput{
 		InstanceType: aws.String(i.Type),
 		ImageId:      aws.String(i.ImageID),
@@ -576,6 +649,15 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 				input.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
 			}
 		}
+
+		// IPv6-only instances are addressed by the IPv6 address assigned to their primary network interface.
+		if i.IPv6Only {
+			if len(input.NetworkInterfaces) > 0 {
+				input.NetworkInterfaces[0].Ipv6AddressCount = aws.Int64(1)
+			} else {
+				input.Ipv6AddressCount = aws.Int64(1)
+			}
+		}
 	}
 
 	if i.IAMProfile != "" {
@@ -586,8 +668,13 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 
 	blockdeviceMappings := []*ec2.BlockDeviceMapping{}
 
-	if i.RootVolume != nil {
-		rootDeviceName, err := s.checkRootVolume(i.RootVolume, i.ImageID)
+	if i.RootVolume.IsInstanceStore() {
+		// the root device of an instance store-backed AMI is an instance store volume, which isn't mapped.
+		if err := s.checkInstanceStoreRootVolume(ctx, i.ImageID); err != nil {
+			return nil, err
+		}
+	} else if i.RootVolume != nil {
+		rootDeviceName, err := s.checkRootVolume(ctx, i.RootVolume, i.ImageID)
 		if err != nil {
 			return nil, err
 		}
@@ -613,26 +700,25 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	if len(i.Tags) > 0 {
-		spec := &ec2.TagSpecification{ResourceType: aws.String(ec2.ResourceTypeInstance)}
-		// We need to sort keys for tests to work
-		keys := make([]string, 0, len(i.Tags))
-		for k := range i.Tags {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, key := range keys {
-			spec.Tags = append(spec.Tags, &ec2.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(i.Tags[key]),
-			})
-		}
+		input.TagSpecifications = append(input.TagSpecifications, buildTagSpecification(ec2.ResourceTypeInstance, i.Tags))
+	}
 
-		input.TagSpecifications = append(input.TagSpecifications, spec)
+	// Tag the volumes with the additional tags when they are created, rather than only once the instance is running,
+	// so that they always carry the tags required by the organization, e.g. cost allocation tags.
+	if len(volumeTags) > 0 {
+		input.TagSpecifications = append(input.TagSpecifications, buildTagSpecification(ec2.ResourceTypeVolume, volumeTags))
 	}
 
 	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
 	input.MetadataOptions = getInstanceMetadataOptionsRequest(i.InstanceMetadataOptions)
 	input.PrivateDnsNameOptions = getPrivateDNSNameOptionsRequest(i.PrivateDNSName)
+	if i.IPv6Only && i.PrivateDNSName == nil {
+		// IPv6-only instances can't have IP based hostnames.
+		input.PrivateDnsNameOptions = &ec2.PrivateDnsNameOptionsRequest{
+			HostnameType:                    aws.String(ec2.HostnameTypeResourceName),
+			EnableResourceNameDnsAAAARecord: aws.Bool(true),
+		}
+	}
 
 	if i.Tenancy != "" {
 		input.Placement = &ec2.Placement{
@@ -654,7 +740,7 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
-	out, err := s.EC2Client.RunInstancesWithContext(context.TODO(), input)
+	out, err := s.EC2Client.RunInstancesWithContext(ctx, input)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to run instance")
 	}
@@ -666,6 +752,15 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	return s.SDKToInstance(out.Instances[0])
 }
 
+// setDefaultEncryptionKey sets the default encryption key of the cluster on a volume which doesn't set its own key,
+// unless it explicitly disables encryption.
+func setDefaultEncryptionKey(v *infrav1.Volume, key string) {
+	if v.IsInstanceStore() || v.EncryptionKey != "" || (v.Encrypted != nil && !*v.Encrypted) {
+		return
+	}
+	v.EncryptionKey = key
+}
+
 func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 	ebsDevice := &ec2.EbsBlockDevice{
 		DeleteOnTermination: aws.Bool(true),
@@ -698,8 +793,8 @@ func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 
 // GetInstanceSecurityGroups returns a map from ENI id to the security groups applied to that ENI
 // While some security group operations take place at the "instance" level, these are in fact an API convenience for manipulating the first ("primary") ENI's properties.
-func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]string, error) {
-	enis, err := s.getInstanceENIs(instanceID)
+func (s *Service) GetInstanceSecurityGroups(ctx context.Context, instanceID string) (map[string][]string, error) {
+	enis, err := s.getInstanceENIs(ctx, instanceID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get ENIs for instance %q", instanceID)
 	}
@@ -717,10 +812,10 @@ func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]str
 
 // UpdateInstanceSecurityGroups modifies the security groups of the given
 // EC2 instance.
-func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string) error {
+func (s *Service) UpdateInstanceSecurityGroups(ctx context.Context, instanceID string, ids []string) error {
 	s.scope.Debug("Attempting to update security groups on instance", "instance-id", instanceID)
 
-	enis, err := s.getInstanceENIs(instanceID)
+	enis, err := s.getInstanceENIs(ctx, instanceID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get ENIs for instance %q", instanceID)
 	}
@@ -728,7 +823,7 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 	s.scope.Debug("Found ENIs on instance", "number-of-enis", len(enis), "instance-id", instanceID)
 
 	for _, eni := range enis {
-		if err := s.attachSecurityGroupsToNetworkInterface(ids, aws.StringValue(eni.NetworkInterfaceId)); err != nil {
+		if err := s.attachSecurityGroupsToNetworkInterface(ctx, ids, aws.StringValue(eni.NetworkInterfaceId)); err != nil {
 			return errors.Wrapf(err, "failed to modify network interfaces on instance %q", instanceID)
 		}
 	}
@@ -740,24 +835,15 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 // This will be called if there is anything to create (update) or delete.
 // We may not always have to perform each action, so we check what we're
 // receiving to avoid calling AWS if we don't need to.
-func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[string]string) error {
+func (s *Service) UpdateResourceTags(ctx context.Context, resourceID *string, create, remove map[string]string) error {
 	s.scope.Debug("Attempting to update tags on resource", "resource-id", *resourceID)
 
 	// If we have anything to create or update
 	if len(create) > 0 {
 		s.scope.Debug("Attempting to create tags on resource", "resource-id", *resourceID)
 
-		// Convert our create map into an array of *ec2.Tag
-		createTagsInput := converters.MapToTags(create)
-
-		// Create the CreateTags input.
-		input := &ec2.CreateTagsInput{
-			Resources: []*string{resourceID},
-			Tags:      createTagsInput,
-		}
-
-		// Create/Update tags in AWS.
-		if _, err := s.EC2Client.CreateTagsWithContext(context.TODO(), input); err != nil {
+		// Create/Update tags in AWS, together with the other resources of the cluster getting the same tags.
+		if err := tags.DefaultEC2Batcher.CreateTags(ctx, s.scope.Session(), s.EC2Client, resourceID, create); err != nil {
 			return errors.Wrapf(err, "failed to create tags for resource %q: %+v", *resourceID, create)
 		}
 	}
@@ -766,17 +852,8 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 	if len(remove) > 0 {
 		s.scope.Debug("Attempting to delete tags on resource", "resource-id", *resourceID)
 
-		// Convert our remove map into an array of *ec2.Tag
-		removeTagsInput := converters.MapToTags(remove)
-
-		// Create the DeleteTags input
-		input := &ec2.DeleteTagsInput{
-			Resources: []*string{resourceID},
-			Tags:      removeTagsInput,
-		}
-
-		// Delete tags in AWS.
-		if _, err := s.EC2Client.DeleteTagsWithContext(context.TODO(), input); err != nil {
+		// Delete tags in AWS, together with the other resources of the cluster losing the same tags.
+		if err := tags.DefaultEC2Batcher.DeleteTags(ctx, s.scope.Session(), s.EC2Client, resourceID, remove); err != nil {
 			return errors.Wrapf(err, "failed to delete tags for resource %q: %v", *resourceID, remove)
 		}
 	}
@@ -784,7 +861,7 @@ func (s *Service) UpdateResourceTags(resourceID *string, create, remove map[stri
 	return nil
 }
 
-func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, error) {
+func (s *Service) getInstanceENIs(ctx context.Context, instanceID string) ([]*ec2.NetworkInterface, error) {
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -794,7 +871,7 @@ func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, e
 		},
 	}
 
-	output, err := s.EC2Client.DescribeNetworkInterfacesWithContext(context.TODO(), input)
+	output, err := s.EC2Client.DescribeNetworkInterfacesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -802,12 +879,12 @@ func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, e
 	return output.NetworkInterfaces, nil
 }
 
-func (s *Service) getImageRootDevice(imageID string) (*string, error) {
+func (s *Service) getImageRootDevice(ctx context.Context, imageID string) (*string, error) {
 	input := &ec2.DescribeImagesInput{
 		ImageIds: []*string{aws.String(imageID)},
 	}
 
-	output, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), input)
+	output, err := s.EC2Client.DescribeImagesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -819,12 +896,12 @@ func (s *Service) getImageRootDevice(imageID string) (*string, error) {
 	return output.Images[0].RootDeviceName, nil
 }
 
-func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
+func (s *Service) getImageSnapshotSize(ctx context.Context, imageID string) (*int64, error) {
 	input := &ec2.DescribeImagesInput{
 		ImageIds: []*string{aws.String(imageID)},
 	}
 
-	output, err := s.EC2Client.DescribeImagesWithContext(context.TODO(), input)
+	output, err := s.EC2Client.DescribeImagesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -930,12 +1007,23 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 			Type:    clusterv1.MachineInternalDNS,
 			Address: aws.StringValue(eni.PrivateDnsName),
 		}
-		privateIPAddress := clusterv1.MachineAddress{
-			Type:    clusterv1.MachineInternalIP,
-			Address: aws.StringValue(eni.PrivateIpAddress),
-		}
+		addresses = append(addresses, privateDNSAddress)
 
-		addresses = append(addresses, privateDNSAddress, privateIPAddress)
+		if eni.PrivateIpAddress != nil {
+			privateIPAddress := clusterv1.MachineAddress{
+				Type:    clusterv1.MachineInternalIP,
+				Address: aws.StringValue(eni.PrivateIpAddress),
+			}
+			addresses = append(addresses, privateIPAddress)
+		} else {
+			// The network interfaces of IPv6-only instances only have IPv6 addresses.
+			for _, ipv6Address := range eni.Ipv6Addresses {
+				addresses = append(addresses, clusterv1.MachineAddress{
+					Type:    clusterv1.MachineInternalIP,
+					Address: aws.StringValue(ipv6Address.Ipv6Address),
+				})
+			}
+		}
 
 		if domainName != nil {
 			// Add secondary private DNS Name with domain name set in DHCP Option Set
@@ -963,13 +1051,13 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 	return addresses
 }
 
-func (s *Service) getNetworkInterfaceSecurityGroups(interfaceID string) ([]string, error) {
+func (s *Service) getNetworkInterfaceSecurityGroups(ctx context.Context, interfaceID string) ([]string, error) {
 	input := &ec2.DescribeNetworkInterfaceAttributeInput{
 		Attribute:          aws.String("groupSet"),
 		NetworkInterfaceId: aws.String(interfaceID),
 	}
 
-	output, err := s.EC2Client.DescribeNetworkInterfaceAttributeWithContext(context.TODO(), input)
+	output, err := s.EC2Client.DescribeNetworkInterfaceAttributeWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -982,7 +1070,7 @@ func (s *Service) getNetworkInterfaceSecurityGroups(interfaceID string) ([]strin
 	return groups, nil
 }
 
-func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interfaceID string) error {
+func (s *Service) attachSecurityGroupsToNetworkInterface(ctx context.Context, groups []string, interfaceID string) error {
 	s.scope.Info("Updating security groups", "groups", groups)
 
 	input := &ec2.ModifyNetworkInterfaceAttributeInput{
@@ -990,7 +1078,7 @@ func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interf
 		Groups:             aws.StringSlice(groups),
 	}
 
-	if _, err := s.EC2Client.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.ModifyNetworkInterfaceAttributeWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to modify interface %q to have security groups %v", interfaceID, groups)
 	}
 	return nil
@@ -998,8 +1086,8 @@ func (s *Service) attachSecurityGroupsToNetworkInterface(groups []string, interf
 
 // DetachSecurityGroupsFromNetworkInterface looks up an ENI by interfaceID and
 // detaches a list of Security Groups from that ENI.
-func (s *Service) DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error {
-	existingGroups, err := s.getNetworkInterfaceSecurityGroups(interfaceID)
+func (s *Service) DetachSecurityGroupsFromNetworkInterface(ctx context.Context, groups []string, interfaceID string) error {
+	existingGroups, err := s.getNetworkInterfaceSecurityGroups(ctx, interfaceID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to look up network interface security groups")
 	}
@@ -1014,25 +1102,30 @@ func (s *Service) DetachSecurityGroupsFromNetworkInterface(groups []string, inte
 		Groups:             aws.StringSlice(remainingGroups),
 	}
 
-	if _, err := s.EC2Client.ModifyNetworkInterfaceAttributeWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.ModifyNetworkInterfaceAttributeWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to modify interface %q", interfaceID)
 	}
 	return nil
 }
 
 // checkRootVolume checks the input root volume options against the requested AMI's defaults
-// and returns the AMI's root device name.
-func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*string, error) {
-	rootDeviceName, err := s.getImageRootDevice(imageID)
+// and returns the AMI's root device name. A root volume without size, only set to encrypt the
+// root volume of the AMI, gets the size of the AMI's snapshot.
+func (s *Service) checkRootVolume(ctx context.Context, rootVolume *infrav1.Volume, imageID string) (*string, error) {
+	rootDeviceName, err := s.getImageRootDevice(ctx, imageID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get root volume from image %q", imageID)
 	}
 
-	snapshotSize, err := s.getImageSnapshotSize(imageID)
+	snapshotSize, err := s.getImageSnapshotSize(ctx, imageID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get root volume from image %q", imageID)
 	}
 
+	if rootVolume.Size == 0 {
+		rootVolume.Size = *snapshotSize
+	}
+
 	if rootVolume.Size < *snapshotSize {
 		return nil, errors.Errorf("root volume size (%d) must be greater than or equal to snapshot size (%d)", rootVolume.Size, *snapshotSize)
 	}
@@ -1040,8 +1133,29 @@ func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*
 	return rootDeviceName, nil
 }
 
+// checkInstanceStoreRootVolume checks that the requested AMI is instance store-backed, as the root volume of the
+// instances can only be an instance store volume when the root device of their AMI is.
+func (s *Service) checkInstanceStoreRootVolume(ctx context.Context, imageID string) error {
+	output, err := s.EC2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(imageID)},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get root device type of image %q", imageID)
+	}
+
+	if len(output.Images) == 0 {
+		return errors.Errorf("no images returned when looking up ID %q", imageID)
+	}
+
+	if rootDeviceType := aws.StringValue(output.Images[0].RootDeviceType); rootDeviceType != ec2.DeviceTypeInstanceStore {
+		return errors.Errorf("root volume type is %q but the root device type of image %q is %q", infrav1.VolumeTypeInstanceStore, imageID, rootDeviceType)
+	}
+
+	return nil
+}
+
 // ModifyInstanceMetadataOptions modifies the metadata options of the given EC2 instance.
-func (s *Service) ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error {
+func (s *Service) ModifyInstanceMetadataOptions(ctx context.Context, instanceID string, options *infrav1.InstanceMetadataOptions) error {
 	input := &ec2.ModifyInstanceMetadataOptionsInput{
 		HttpEndpoint:            aws.String(string(options.HTTPEndpoint)),
 		HttpPutResponseHopLimit: aws.Int64(options.HTTPPutResponseHopLimit),
@@ -1051,13 +1165,60 @@ func (s *Service) ModifyInstanceMetadataOptions(instanceID string, options *infr
 	}
 
 	s.scope.Info("Updating instance metadata options", "instance id", instanceID, "options", input)
-	if _, err := s.EC2Client.ModifyInstanceMetadataOptionsWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.ModifyInstanceMetadataOptionsWithContext(ctx, input); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// GetConsoleOutputTail returns the last lines of the console output of the given EC2 instance, or an empty string
+// if the instance has no console output yet.
+func (s *Service) GetConsoleOutputTail(ctx context.Context, instanceID string, lines int) (string, error) {
+	out, err := s.EC2Client.GetConsoleOutputWithContext(ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(instanceID),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get console output of instance %q", instanceID)
+	}
+
+	output, err := base64.StdEncoding.DecodeString(aws.StringValue(out.Output))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to decode console output of instance %q", instanceID)
+	}
+
+	trimmed := strings.TrimRight(strings.ReplaceAll(string(output), "\r\n", "\n"), " \t\r\n")
+	if trimmed == "" {
+		return "", nil
+	}
+	outputLines := strings.Split(trimmed, "\n")
+	if len(outputLines) > lines {
+		outputLines = outputLines[len(outputLines)-lines:]
+	}
+	return strings.Join(outputLines, "\n"), nil
+}
+
+// GetSSMPingStatus returns the ping status of the SSM agent of the given EC2 instance, e.g. Online or
+// ConnectionLost, or NotRegistered if the agent never registered the instance with Systems Manager.
+func (s *Service) GetSSMPingStatus(ctx context.Context, instanceID string) (string, error) {
+	out, err := s.SSMClient.DescribeInstanceInformationWithContext(ctx, &ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{
+			{
+				Key:    aws.String("InstanceIds"),
+				Values: aws.StringSlice([]string{instanceID}),
+			},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe the SSM instance information of instance %q", instanceID)
+	}
+
+	if len(out.InstanceInformationList) == 0 {
+		return SSMPingStatusNotRegistered, nil
+	}
+	return aws.StringValue(out.InstanceInformationList[0].PingStatus), nil
+}
+
 // GetDHCPOptionSetDomainName returns the domain DNS name for the VPC from the DHCP Options.
 func (s *Service) GetDHCPOptionSetDomainName(ec2client ec2iface.EC2API, vpcID *string) *string {
 	log := s.scope.GetLogger()
@@ -1179,3 +1340,21 @@ func getPrivateDNSNameOptionsRequest(privateDNSName *infrav1.PrivateDNSName) *ec
 		HostnameType:                    privateDNSName.HostnameType,
 	}
 }
+
+// buildTagSpecification returns the specification of the tags of a resource created along with an instance.
+func buildTagSpecification(resourceType string, tags infrav1.Tags) *ec2.TagSpecification {
+	spec := &ec2.TagSpecification{ResourceType: aws.String(resourceType)}
+	// We need to sort keys for tests to work
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		spec.Tags = append(spec.Tags, &ec2.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tags[key]),
+		})
+	}
+	return spec
+}
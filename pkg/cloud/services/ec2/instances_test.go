@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssmiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -180,6 +182,7 @@ func TestInstanceIfExists(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
 						NetworkSpec: infrav1.NetworkSpec{
 							VPC: infrav1.VPCSpec{
 								ID: "test-vpc",
@@ -197,7 +200,7 @@ func TestInstanceIfExists(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			instance, err := s.InstanceIfExists(aws.String(tc.instanceID))
+			instance, err := s.InstanceIfExists(context.TODO(), aws.String(tc.instanceID))
 			tc.check(instance, err)
 		})
 	}
@@ -257,7 +260,7 @@ func TestTerminateInstance(t *testing.T) {
 			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
 				Client:     client,
 				Cluster:    &clusterv1.Cluster{},
-				AWSCluster: &infrav1.AWSCluster{},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
 			})
 			if err != nil {
 				t.Fatalf("Failed to create test context: %v", err)
@@ -268,7 +271,7 @@ func TestTerminateInstance(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.TerminateInstance(tc.instanceID)
+			err = s.TerminateInstance(context.TODO(), tc.instanceID)
 			tc.check(err)
 		})
 	}
@@ -326,6 +329,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -443,6 +447,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -586,6 +591,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-foo",
@@ -790,6 +796,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-foo",
@@ -970,6 +977,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -1122,6 +1130,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -1276,6 +1285,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -1438,6 +1448,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -1561,6 +1572,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -1687,6 +1699,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -1779,6 +1792,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -1905,6 +1919,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2000,6 +2015,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2085,6 +2101,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2227,6 +2244,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2383,6 +2401,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2484,6 +2503,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2633,6 +2653,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2790,6 +2811,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -2920,6 +2942,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -3064,6 +3087,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
@@ -3150,6 +3174,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3275,6 +3300,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3434,6 +3460,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3596,6 +3623,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3757,6 +3785,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3915,6 +3944,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -3994,6 +4024,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4126,6 +4157,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4260,6 +4292,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4394,6 +4427,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4525,6 +4559,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4656,6 +4691,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -4788,6 +4824,7 @@ func TestCreateInstance(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
@@ -5002,12 +5039,115 @@ func TestCreateInstance(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			instance, err := s.CreateInstance(machineScope, data, "")
+			instance, err := s.CreateInstance(context.TODO(), machineScope, data, "")
 			tc.check(instance, err)
 		})
 	}
 }
 
+func TestFindSubnetWithSubnetSelector(t *testing.T) {
+	selector := &infrav1.SubnetSelector{
+		Tags: []infrav1.SubnetTagSelector{{Key: "subnet-role", Values: []string{"workers"}}},
+	}
+	expectedFilters := []*ec2.Filter{
+		{Name: aws.String("state"), Values: aws.StringSlice([]string{ec2.SubnetStatePending, ec2.SubnetStateAvailable})},
+		{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-selected"})},
+		{Name: aws.String("tag:subnet-role"), Values: aws.StringSlice([]string{"workers"})},
+	}
+
+	testCases := []struct {
+		name          string
+		failureDomain *string
+		subnets       []*ec2.Subnet
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name: "returns the first subnet matching the selector",
+			subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-selected"), AvailabilityZone: aws.String("us-east-1a")},
+				{SubnetId: aws.String("subnet-b"), VpcId: aws.String("vpc-selected"), AvailabilityZone: aws.String("us-east-1b")},
+			},
+			expected: "subnet-a",
+		},
+		{
+			name:          "returns the subnet matching the selector in the failure domain of the machine",
+			failureDomain: aws.String("us-east-1b"),
+			subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-selected"), AvailabilityZone: aws.String("us-east-1a")},
+				{SubnetId: aws.String("subnet-b"), VpcId: aws.String("vpc-selected"), AvailabilityZone: aws.String("us-east-1b")},
+			},
+			expected: "subnet-b",
+		},
+		{
+			name:          "returns an error if no subnet matching the selector is in the failure domain of the machine",
+			failureDomain: aws.String("us-east-1c"),
+			subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-selected"), AvailabilityZone: aws.String("us-east-1a")},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "returns an error if no subnet matches the selector",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test1"}}
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				Spec:       clusterv1.MachineSpec{FailureDomain: tc.failureDomain},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-test1"},
+				Spec:       infrav1.AWSMachineSpec{SubnetSelector: selector},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: cluster,
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
+						NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-selected"}},
+					},
+				},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: expectedFilters}).
+				Return(&ec2.DescribeSubnetsOutput{Subnets: tc.subnets}, nil)
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			subnetID, err := s.findSubnet(context.TODO(), machineScope)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(subnetID).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -5244,7 +5384,7 @@ func TestGetFilteredSecurityGroupID(t *testing.T) {
 				EC2Client: ec2Mock,
 			}
 
-			ids, err := s.getFilteredSecurityGroupIDs(tc.securityGroup)
+			ids, err := s.getFilteredSecurityGroupIDs(context.TODO(), tc.securityGroup)
 			tc.check(ids, err)
 		})
 	}
@@ -5313,6 +5453,7 @@ func TestGetDHCPOptionSetDomainName(t *testing.T) {
 					AWSCluster: &infrav1.AWSCluster{
 						ObjectMeta: metav1.ObjectMeta{Name: "test"},
 						Spec: infrav1.AWSClusterSpec{
+							Region: "us-east-1",
 							NetworkSpec: infrav1.NetworkSpec{
 								VPC: infrav1.VPCSpec{
 									ID: tc.vpcID,
@@ -5394,3 +5535,103 @@ func mockedGetPrivateDNSDomainNameFromDHCPOptionsEmptyCalls(m *mocks.MockEC2APIM
 		},
 	}, nil)
 }
+
+func TestGetConsoleOutputTail(t *testing.T) {
+	testCases := []struct {
+		name    string
+		output  *string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "only the last lines are returned",
+			output: aws.String(base64.StdEncoding.EncodeToString([]byte("line 1\r\nline 2\r\nline 3\r\nline 4\r\n\r\n"))),
+			want:   "line 2\nline 3\nline 4",
+		},
+		{
+			name:   "all the lines are returned when there are fewer",
+			output: aws.String(base64.StdEncoding.EncodeToString([]byte("line 1\nline 2\n"))),
+			want:   "line 1\nline 2",
+		},
+		{
+			name: "no console output yet",
+			want: "",
+		},
+		{
+			name:    "console output that isn't base64 encoded",
+			output:  aws.String("not base64"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().GetConsoleOutputWithContext(context.TODO(), &ec2.GetConsoleOutputInput{
+				InstanceId: aws.String("i-1"),
+			}).Return(&ec2.GetConsoleOutputOutput{InstanceId: aws.String("i-1"), Output: tc.output}, nil)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			got, err := s.GetConsoleOutputTail(context.TODO(), "i-1", 3)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestGetSSMPingStatus(t *testing.T) {
+	testCases := []struct {
+		name        string
+		information []*ssm.InstanceInformation
+		want        string
+	}{
+		{
+			name:        "instance registered with Systems Manager",
+			information: []*ssm.InstanceInformation{{InstanceId: aws.String("i-1"), PingStatus: aws.String("ConnectionLost")}},
+			want:        "ConnectionLost",
+		},
+		{
+			name: "instance not registered with Systems Manager",
+			want: SSMPingStatusNotRegistered,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ssmMock.EXPECT().DescribeInstanceInformationWithContext(context.TODO(), &ssm.DescribeInstanceInformationInput{
+				Filters: []*ssm.InstanceInformationStringFilter{
+					{
+						Key:    aws.String("InstanceIds"),
+						Values: aws.StringSlice([]string{"i-1"}),
+					},
+				},
+			}).Return(&ssm.DescribeInstanceInformationOutput{InstanceInformationList: tc.information}, nil)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := setupClusterScope(fake.NewClientBuilder().WithScheme(scheme).Build())
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.SSMClient = ssmMock
+
+			got, err := s.GetSSMPingStatus(context.TODO(), "i-1")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
@@ -58,6 +58,7 @@ const (
 //
 //nolint:gocyclo
 func (s *Service) ReconcileLaunchTemplate(
+	ctx context.Context,
 	scope scope.LaunchTemplateScope,
 	ec2svc services.EC2Interface,
 	canUpdateLaunchTemplate func() (bool, error),
@@ -68,16 +69,19 @@ func (s *Service) ReconcileLaunchTemplate(
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
 		return err
 	}
+	if scope.GetLaunchTemplate().OSFamily == infrav1.OSFamilyWindows {
+		bootstrapData = userdata.WindowsUserData(bootstrapData)
+	}
 	bootstrapDataHash := userdata.ComputeHash(bootstrapData)
 
 	scope.Info("checking for existing launch template")
-	launchTemplate, launchTemplateUserDataHash, launchTemplateUserDataSecretKey, err := ec2svc.GetLaunchTemplate(scope.LaunchTemplateName())
+	launchTemplate, launchTemplateUserDataHash, launchTemplateUserDataSecretKey, err := ec2svc.GetLaunchTemplate(ctx, scope.LaunchTemplateName())
 	if err != nil {
 		conditions.MarkUnknown(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
 		return err
 	}
 
-	imageID, err := ec2svc.DiscoverLaunchTemplateAMI(scope)
+	imageID, err := ec2svc.DiscoverLaunchTemplateAMI(ctx, scope)
 	if err != nil {
 		conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return err
@@ -85,7 +89,7 @@ func (s *Service) ReconcileLaunchTemplate(
 
 	if launchTemplate == nil {
 		scope.Info("no existing launch template found, creating")
-		launchTemplateID, err := ec2svc.CreateLaunchTemplate(scope, imageID, *bootstrapDataSecretKey, bootstrapData)
+		launchTemplateID, err := ec2svc.CreateLaunchTemplate(ctx, scope, imageID, *bootstrapDataSecretKey, bootstrapData)
 		if err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return err
@@ -98,7 +102,7 @@ func (s *Service) ReconcileLaunchTemplate(
 	// LaunchTemplateID is set during LaunchTemplate creation, but for a scenario such as `clusterctl move`, status fields become blank.
 	// If launchTemplate already exists but LaunchTemplateID field in the status is empty, get the ID and update the status.
 	if scope.GetLaunchTemplateIDStatus() == "" {
-		launchTemplateID, err := ec2svc.GetLaunchTemplateID(scope.LaunchTemplateName())
+		launchTemplateID, err := ec2svc.GetLaunchTemplateID(ctx, scope.LaunchTemplateName())
 		if err != nil {
 			conditions.MarkUnknown(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
 			return err
@@ -108,7 +112,7 @@ func (s *Service) ReconcileLaunchTemplate(
 	}
 
 	if scope.GetLaunchTemplateLatestVersionStatus() == "" {
-		launchTemplateVersion, err := ec2svc.GetLaunchTemplateLatestVersion(scope.GetLaunchTemplateIDStatus())
+		launchTemplateVersion, err := ec2svc.GetLaunchTemplateLatestVersion(ctx, scope.GetLaunchTemplateIDStatus())
 		if err != nil {
 			conditions.MarkUnknown(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateNotFoundReason, err.Error())
 			return err
@@ -125,7 +129,7 @@ func (s *Service) ReconcileLaunchTemplate(
 	// Check if the instance tags were changed. If they were, create a new LaunchTemplate.
 	tagsChanged, _, _, _ := tagsChanged(annotation, scope.AdditionalTags()) //nolint:dogsled
 
-	needsUpdate, err := ec2svc.LaunchTemplateNeedsUpdate(scope, scope.GetLaunchTemplate(), launchTemplate)
+	needsUpdate, err := ec2svc.LaunchTemplateNeedsUpdate(ctx, scope, scope.GetLaunchTemplate(), launchTemplate)
 	if err != nil {
 		return err
 	}
@@ -159,13 +163,16 @@ func (s *Service) ReconcileLaunchTemplate(
 		scope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", scope.GetLaunchTemplate(), "needsUpdate", needsUpdate, "tagsChanged", tagsChanged, "amiChanged", amiChanged, "userDataHashChanged", userDataHashChanged, "userDataSecretKeyChanged", userDataSecretKeyChanged)
 		// There is a limit to the number of Launch Template Versions.
 		// We ensure that the number of versions does not grow without bound by following a simple rule: Before we create a new version, we delete one old version, if there is at least one old version that is not in use.
-		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus()); err != nil {
-			return err
+		// Pruning is skipped while the pool is pinned to a version, because the pruned version could be the pinned one.
+		if !launchTemplateVersionPinned(scope.GetLaunchTemplate()) {
+			if err := ec2svc.PruneLaunchTemplateVersions(ctx, scope.GetLaunchTemplateIDStatus()); err != nil {
+				return err
+			}
 		}
-		if err := ec2svc.CreateLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, bootstrapData); err != nil {
+		if err := ec2svc.CreateLaunchTemplateVersion(ctx, scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, bootstrapData); err != nil {
 			return err
 		}
-		version, err := ec2svc.GetLaunchTemplateLatestVersion(scope.GetLaunchTemplateIDStatus())
+		version, err := ec2svc.GetLaunchTemplateLatestVersion(ctx, scope.GetLaunchTemplateIDStatus())
 		if err != nil {
 			return err
 		}
@@ -176,6 +183,11 @@ func (s *Service) ReconcileLaunchTemplate(
 		}
 	}
 
+	if (needsUpdate || tagsChanged || amiChanged || userDataSecretKeyChanged) && launchTemplateVersionPinned(scope.GetLaunchTemplate()) {
+		scope.Info("launch template version is pinned, skipping rollout of the new version", "pinnedVersion", *scope.GetLaunchTemplate().PinnedVersion)
+		return nil
+	}
+
 	if needsUpdate || tagsChanged || amiChanged || userDataSecretKeyChanged {
 		if err := runPostLaunchTemplateUpdateOperation(); err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.PostLaunchTemplateUpdateOperationCondition, expinfrav1.PostLaunchTemplateUpdateOperationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -187,18 +199,24 @@ func (s *Service) ReconcileLaunchTemplate(
 	return nil
 }
 
+// launchTemplateVersionPinned returns true if the pool is pinned to a launch template version other than
+// the latest one, in which case newly created versions are not rolled out.
+func launchTemplateVersionPinned(lt *expinfrav1.AWSLaunchTemplate) bool {
+	return lt != nil && lt.PinnedVersion != nil && *lt.PinnedVersion != expinfrav1.LaunchTemplateLatestVersion
+}
+
 // ReconcileTags reconciles the tags for the AWSMachinePool instances.
-func (s *Service) ReconcileTags(scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate) error {
+func (s *Service) ReconcileTags(ctx context.Context, scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate) error {
 	additionalTags := scope.AdditionalTags()
 
-	_, err := s.ensureTags(scope, resourceServicesToUpdate, additionalTags)
+	_, err := s.ensureTags(ctx, scope, resourceServicesToUpdate, additionalTags)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *Service) ensureTags(scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate, additionalTags map[string]string) (bool, error) {
+func (s *Service) ensureTags(ctx context.Context, scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate, additionalTags map[string]string) (bool, error) {
 	annotation, err := MachinePoolAnnotationJSON(scope, TagsLastAppliedAnnotation)
 	if err != nil {
 		return false, err
@@ -211,7 +229,7 @@ func (s *Service) ensureTags(scope scope.LaunchTemplateScope, resourceServicesTo
 	changed, created, deleted, newAnnotation := tagsChanged(annotation, additionalTags)
 	if changed {
 		for _, resourceServiceToUpdate := range resourceServicesToUpdate {
-			err := resourceServiceToUpdate.ResourceService.UpdateResourceTags(resourceServiceToUpdate.ResourceID, created, deleted)
+			err := resourceServiceToUpdate.ResourceService.UpdateResourceTags(ctx, resourceServiceToUpdate.ResourceID, created, deleted)
 			if err != nil {
 				return false, err
 			}
@@ -343,7 +361,7 @@ func tagsChanged(annotation map[string]interface{}, src map[string]string) (bool
 
 // GetLaunchTemplate returns the existing LaunchTemplate or nothing if it doesn't exist.
 // For now by name until we need the input to be something different.
-func (s *Service) GetLaunchTemplate(launchTemplateName string) (*expinfrav1.AWSLaunchTemplate, string, *apimachinerytypes.NamespacedName, error) {
+func (s *Service) GetLaunchTemplate(ctx context.Context, launchTemplateName string) (*expinfrav1.AWSLaunchTemplate, string, *apimachinerytypes.NamespacedName, error) {
 	if launchTemplateName == "" {
 		return nil, "", nil, nil
 	}
@@ -355,7 +373,7 @@ func (s *Service) GetLaunchTemplate(launchTemplateName string) (*expinfrav1.AWSL
 		Versions:           aws.StringSlice([]string{expinfrav1.LaunchTemplateLatestVersion}),
 	}
 
-	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(ctx, input)
 	switch {
 	case awserrors.IsNotFound(err):
 		return nil, "", nil, nil
@@ -371,7 +389,7 @@ func (s *Service) GetLaunchTemplate(launchTemplateName string) (*expinfrav1.AWSL
 }
 
 // GetLaunchTemplateID returns the existing LaunchTemplateId or empty string if it doesn't exist.
-func (s *Service) GetLaunchTemplateID(launchTemplateName string) (string, error) {
+func (s *Service) GetLaunchTemplateID(ctx context.Context, launchTemplateName string) (string, error) {
 	if launchTemplateName == "" {
 		return "", nil
 	}
@@ -381,7 +399,7 @@ func (s *Service) GetLaunchTemplateID(launchTemplateName string) (string, error)
 		Versions:           aws.StringSlice([]string{expinfrav1.LaunchTemplateLatestVersion}),
 	}
 
-	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(ctx, input)
 	switch {
 	case awserrors.IsNotFound(err):
 		return "", nil
@@ -398,10 +416,10 @@ func (s *Service) GetLaunchTemplateID(launchTemplateName string) (string, error)
 }
 
 // CreateLaunchTemplate generates a launch template to be used with the autoscaling group.
-func (s *Service) CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error) {
+func (s *Service) CreateLaunchTemplate(ctx context.Context, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error) {
 	s.scope.Info("Create a new launch template")
 
-	launchTemplateData, err := s.createLaunchTemplateData(scope, imageID, userDataSecretKey, userData)
+	launchTemplateData, err := s.createLaunchTemplateData(ctx, scope, imageID, userDataSecretKey, userData)
 	if err != nil {
 		return "", errors.Wrapf(err, "unable to form launch template data")
 	}
@@ -434,7 +452,7 @@ func (s *Service) CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID
 		input.TagSpecifications = append(input.TagSpecifications, spec)
 	}
 
-	result, err := s.EC2Client.CreateLaunchTemplateWithContext(context.TODO(), input)
+	result, err := s.EC2Client.CreateLaunchTemplateWithContext(ctx, input)
 	if err != nil {
 		return "", err
 	}
@@ -442,10 +460,10 @@ func (s *Service) CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID
 }
 
 // CreateLaunchTemplateVersion will create a launch template.
-func (s *Service) CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error {
+func (s *Service) CreateLaunchTemplateVersion(ctx context.Context, id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error {
 	s.scope.Debug("creating new launch template version", "machine-pool", scope.LaunchTemplateName())
 
-	launchTemplateData, err := s.createLaunchTemplateData(scope, imageID, userDataSecretKey, userData)
+	launchTemplateData, err := s.createLaunchTemplateData(ctx, scope, imageID, userDataSecretKey, userData)
 	if err != nil {
 		return errors.Wrapf(err, "unable to form launch template data")
 	}
@@ -455,7 +473,7 @@ func (s *Service) CreateLaunchTemplateVersion(id string, scope scope.LaunchTempl
 		LaunchTemplateId:   &id,
 	}
 
-	_, err = s.EC2Client.CreateLaunchTemplateVersionWithContext(context.TODO(), input)
+	_, err = s.EC2Client.CreateLaunchTemplateVersionWithContext(ctx, input)
 	if err != nil {
 		return errors.Wrapf(err, "unable to create launch template version")
 	}
@@ -463,12 +481,13 @@ func (s *Service) CreateLaunchTemplateVersion(id string, scope scope.LaunchTempl
 	return nil
 }
 
-func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (*ec2.RequestLaunchTemplateData, error) {
+func (s *Service) createLaunchTemplateData(ctx context.Context, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (*ec2.RequestLaunchTemplateData, error) {
 	lt := scope.GetLaunchTemplate()
 
-	// An explicit empty string for SSHKeyName means do not specify a key in the ASG launch
+	// An explicit empty string for SSHKeyName means do not specify a key in the ASG launch, as does accessing the
+	// instances of the cluster with Systems Manager.
 	var sshKeyNamePtr *string
-	if lt.SSHKeyName != nil && *lt.SSHKeyName != "" {
+	if lt.SSHKeyName != nil && *lt.SSHKeyName != "" && s.scope.InstanceAccessMode() != infrav1.InstanceAccessModeSSM {
 		sshKeyNamePtr = lt.SSHKeyName
 	}
 
@@ -508,7 +527,7 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	}
 
 	// add additional security groups as well
-	securityGroupIDs, err := s.GetAdditionalSecurityGroupsIDs(scope.GetLaunchTemplate().AdditionalSecurityGroups)
+	securityGroupIDs, err := s.GetAdditionalSecurityGroupsIDs(ctx, scope.GetLaunchTemplate().AdditionalSecurityGroups)
 	if err != nil {
 		return nil, err
 	}
@@ -520,16 +539,30 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	data.InstanceMarketOptions = getLaunchTemplateInstanceMarketOptionsRequest(scope.GetLaunchTemplate().SpotMarketOptions)
 	data.PrivateDnsNameOptions = getLaunchTemplatePrivateDNSNameOptionsRequest(scope.GetLaunchTemplate().PrivateDNSName)
 
-	// Set up root volume
-	if lt.RootVolume != nil {
-		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
+	// Set up root volume, encrypted with the default key of the cluster unless it sets its own key. The root
+	// volume is copied not to set the default key in the spec of the machine pool.
+	rootVolume := lt.RootVolume
+	if key := s.scope.DefaultEBSEncryptionKeyARN(); key != "" {
+		rootVolume = rootVolume.DeepCopy()
+		if rootVolume == nil {
+			rootVolume = &infrav1.Volume{}
+		}
+		setDefaultEncryptionKey(rootVolume, key)
+	}
+	if rootVolume.IsInstanceStore() {
+		// the root device of an instance store-backed AMI is an instance store volume, which isn't mapped.
+		if err := s.checkInstanceStoreRootVolume(ctx, *data.ImageId); err != nil {
+			return nil, err
+		}
+	} else if rootVolume != nil {
+		rootDeviceName, err := s.checkRootVolume(ctx, rootVolume, *data.ImageId)
 		if err != nil {
 			return nil, err
 		}
 
-		lt.RootVolume.DeviceName = aws.StringValue(rootDeviceName)
+		rootVolume.DeviceName = aws.StringValue(rootDeviceName)
 
-		req := volumeToLaunchTemplateBlockDeviceMappingRequest(lt.RootVolume)
+		req := volumeToLaunchTemplateBlockDeviceMappingRequest(rootVolume)
 		data.BlockDeviceMappings = []*ec2.LaunchTemplateBlockDeviceMappingRequest{
 			req,
 		}
@@ -571,14 +604,14 @@ func volumeToLaunchTemplateBlockDeviceMappingRequest(v *infrav1.Volume) *ec2.Lau
 }
 
 // DeleteLaunchTemplate delete a launch template.
-func (s *Service) DeleteLaunchTemplate(id string) error {
+func (s *Service) DeleteLaunchTemplate(ctx context.Context, id string) error {
 	s.scope.Debug("Deleting launch template", "id", id)
 
 	input := &ec2.DeleteLaunchTemplateInput{
 		LaunchTemplateId: aws.String(id),
 	}
 
-	if _, err := s.EC2Client.DeleteLaunchTemplateWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.DeleteLaunchTemplateWithContext(ctx, input); err != nil {
 		return errors.Wrapf(err, "failed to delete launch template %q", id)
 	}
 
@@ -590,7 +623,7 @@ func (s *Service) DeleteLaunchTemplate(id string) error {
 // It does not delete the "latest" version, because that version may still be in use.
 // It does not delete the "default" version, because that version cannot be deleted.
 // It does not assume that versions are sequential. Versions may be deleted out of band.
-func (s *Service) PruneLaunchTemplateVersions(id string) error {
+func (s *Service) PruneLaunchTemplateVersions(ctx context.Context, id string) error {
 	// When there is one version available, it is the default and the latest.
 	// When there are two versions available, one the is the default, the other is the latest.
 	// Therefore we only prune when there are at least 3 versions available.
@@ -603,7 +636,7 @@ func (s *Service) PruneLaunchTemplateVersions(id string) error {
 		MaxResults:       aws.Int64(minCountToAllowPrune),
 	}
 
-	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(ctx, input)
 	if err != nil {
 		s.scope.Info("", "aerr", err.Error())
 		return err
@@ -618,17 +651,17 @@ func (s *Service) PruneLaunchTemplateVersions(id string) error {
 		return nil
 	}
 	versionToPrune := out.LaunchTemplateVersions[1].VersionNumber
-	return s.deleteLaunchTemplateVersion(id, versionToPrune)
+	return s.deleteLaunchTemplateVersion(ctx, id, versionToPrune)
 }
 
 // GetLaunchTemplateLatestVersion returns the latest version of a launch template.
-func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
+func (s *Service) GetLaunchTemplateLatestVersion(ctx context.Context, id string) (string, error) {
 	input := &ec2.DescribeLaunchTemplateVersionsInput{
 		LaunchTemplateId: aws.String(id),
 		Versions:         aws.StringSlice([]string{expinfrav1.LaunchTemplateLatestVersion}),
 	}
 
-	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeLaunchTemplateVersionsWithContext(ctx, input)
 	if err != nil {
 		s.scope.Info("", "aerr", err.Error())
 		return "", err
@@ -641,7 +674,7 @@ func (s *Service) GetLaunchTemplateLatestVersion(id string) (string, error) {
 	return strconv.Itoa(int(*out.LaunchTemplateVersions[0].VersionNumber)), nil
 }
 
-func (s *Service) deleteLaunchTemplateVersion(id string, version *int64) error {
+func (s *Service) deleteLaunchTemplateVersion(ctx context.Context, id string, version *int64) error {
 	s.scope.Debug("Deleting launch template version", "id", id)
 
 	if version == nil {
@@ -654,7 +687,7 @@ func (s *Service) deleteLaunchTemplateVersion(id string, version *int64) error {
 		Versions:         aws.StringSlice(versions),
 	}
 
-	_, err := s.EC2Client.DeleteLaunchTemplateVersionsWithContext(context.TODO(), input)
+	_, err := s.EC2Client.DeleteLaunchTemplateVersionsWithContext(ctx, input)
 	if err != nil {
 		return err
 	}
@@ -749,7 +782,7 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 //
 // FIXME(dlipovetsky): This check should account for changed userdata, but does not yet do so.
 // Although userdata is stored in an EC2 Launch Template, it is not a field of AWSLaunchTemplate.
-func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
+func (s *Service) LaunchTemplateNeedsUpdate(ctx context.Context, scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
 	if incoming.IamInstanceProfile != existing.IamInstanceProfile {
 		return true, nil
 	}
@@ -761,7 +794,7 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
-	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
+	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(ctx, incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
 	}
@@ -772,7 +805,7 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 	}
 
 	incomingIDs = append(incomingIDs, coreIDs...)
-	existingIDs, err := s.GetAdditionalSecurityGroupsIDs(existing.AdditionalSecurityGroups)
+	existingIDs, err := s.GetAdditionalSecurityGroupsIDs(ctx, existing.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
 	}
@@ -787,7 +820,7 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 }
 
 // DiscoverLaunchTemplateAMI will discover the AMI launch template.
-func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*string, error) {
+func (s *Service) DiscoverLaunchTemplateAMI(ctx context.Context, scope scope.LaunchTemplateScope) (*string, error) {
 	lt := scope.GetLaunchTemplate()
 
 	if lt.AMI.ID != nil {
@@ -814,8 +847,11 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 		imageLookupOrg = scope.GetEC2Scope().ImageLookupOrg()
 	}
 
+	// The base OS of the cluster is ignored for Windows instances, as it is the one of the Linux instances
+	// of mixed clusters.
+	isWindows := lt.OSFamily == infrav1.OSFamilyWindows
 	imageLookupBaseOS := lt.ImageLookupBaseOS
-	if imageLookupBaseOS == "" {
+	if imageLookupBaseOS == "" && !isWindows {
 		imageLookupBaseOS = scope.GetEC2Scope().ImageLookupBaseOS()
 	}
 
@@ -827,23 +863,32 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 	imageArchitecture := Amd64ArchitectureTag
 
 	if instanceType != "" {
-		imageArchitecture, err = s.pickArchitectureForInstanceType(instanceType)
+		imageArchitecture, err = s.pickArchitectureForInstanceType(ctx, instanceType)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if scope.IsEKSManaged() && imageLookupFormat == "" && imageLookupOrg == "" && imageLookupBaseOS == "" {
-		lookupAMI, err = s.eksAMILookup(
-			*templateVersion,
-			imageArchitecture,
-			scope.GetLaunchTemplate().AMI.EKSOptimizedLookupType,
-		)
+		if isWindows {
+			lookupAMI, err = s.eksWindowsAMILookup(ctx, *templateVersion)
+		} else {
+			lookupAMI, err = s.eksAMILookup(
+				ctx,
+				*templateVersion,
+				imageArchitecture,
+				scope.GetLaunchTemplate().AMI.EKSOptimizedLookupType,
+			)
+		}
 		if err != nil {
 			return nil, err
 		}
 	} else {
+		if imageLookupBaseOS == "" && isWindows {
+			imageLookupBaseOS = DefaultWindowsAMILookupBaseOS
+		}
 		lookupAMI, err = s.defaultAMIIDLookup(
+			ctx,
 			imageLookupFormat,
 			imageLookupOrg,
 			imageLookupBaseOS,
@@ -859,14 +904,14 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*s
 }
 
 // GetAdditionalSecurityGroupsIDs returns the security group IDs for the additional security groups.
-func (s *Service) GetAdditionalSecurityGroupsIDs(securityGroups []infrav1.AWSResourceReference) ([]string, error) {
+func (s *Service) GetAdditionalSecurityGroupsIDs(ctx context.Context, securityGroups []infrav1.AWSResourceReference) ([]string, error) {
 	var additionalSecurityGroupsIDs []string
 
 	for _, sg := range securityGroups {
 		if sg.ID != nil {
 			additionalSecurityGroupsIDs = append(additionalSecurityGroupsIDs, *sg.ID)
 		} else if sg.Filters != nil {
-			ids, err := s.getFilteredSecurityGroupIDs(sg)
+			ids, err := s.getFilteredSecurityGroupIDs(ctx, sg)
 			if err != nil {
 				return nil, err
 			}
@@ -927,7 +972,7 @@ func (s *Service) buildLaunchTemplateTagSpecificationRequest(scope scope.LaunchT
 }
 
 // getFilteredSecurityGroupIDs get security group IDs using filters.
-func (s *Service) getFilteredSecurityGroupIDs(securityGroup infrav1.AWSResourceReference) ([]string, error) {
+func (s *Service) getFilteredSecurityGroupIDs(ctx context.Context, securityGroup infrav1.AWSResourceReference) ([]string, error) {
 	if securityGroup.Filters == nil {
 		return nil, nil
 	}
@@ -937,7 +982,7 @@ func (s *Service) getFilteredSecurityGroupIDs(securityGroup infrav1.AWSResourceR
 		filters = append(filters, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
 	}
 
-	sgs, err := s.EC2Client.DescribeSecurityGroupsWithContext(context.TODO(), &ec2.DescribeSecurityGroupsInput{Filters: filters})
+	sgs, err := s.EC2Client.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{Filters: filters})
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +295,7 @@ func TestGetLaunchTemplate(t *testing.T) {
 				tc.expect(mockEC2Client.EXPECT())
 			}
 
-			launchTemplate, userData, _, err := s.GetLaunchTemplate(tc.launchTemplateName)
+			launchTemplate, userData, _, err := s.GetLaunchTemplate(context.TODO(), tc.launchTemplateName)
 			tc.check(g, launchTemplate, userData, err)
 		})
 	}
@@ -592,7 +592,7 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 				tt.expect(mockEC2Client.EXPECT())
 			}
 
-			got, err := s.LaunchTemplateNeedsUpdate(machinePoolScope, tt.incoming, tt.existing)
+			got, err := s.LaunchTemplateNeedsUpdate(context.TODO(), machinePoolScope, tt.incoming, tt.existing)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -733,7 +733,7 @@ func TestGetLaunchTemplateID(t *testing.T) {
 			if tc.expect != nil {
 				tc.expect(mockEC2Client.EXPECT())
 			}
-			launchTemplate, err := s.GetLaunchTemplateID(tc.launchTemplateName)
+			launchTemplate, err := s.GetLaunchTemplateID(context.TODO(), tc.launchTemplateName)
 			tc.check(g, launchTemplate, err)
 		})
 	}
@@ -785,7 +785,7 @@ func TestDeleteLaunchTemplate(t *testing.T) {
 			s.EC2Client = mockEC2Client
 			tc.expect(mockEC2Client.EXPECT())
 
-			err = s.DeleteLaunchTemplate(tc.versionID)
+			err = s.DeleteLaunchTemplate(context.TODO(), tc.versionID)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -1022,7 +1022,7 @@ func TestCreateLaunchTemplate(t *testing.T) {
 				tc.expect(g, mockEC2Client.EXPECT())
 			}
 
-			launchTemplate, err := s.CreateLaunchTemplate(ms, aws.String("imageID"), userDataSecretKey, userData)
+			launchTemplate, err := s.CreateLaunchTemplate(context.TODO(), ms, aws.String("imageID"), userDataSecretKey, userData)
 			tc.check(g, launchTemplate, err)
 		})
 	}
@@ -1050,7 +1050,7 @@ func TestLaunchTemplateDataCreation(t *testing.T) {
 			Namespace: "bootstrap-secret-ns",
 			Name:      "bootstrap-secret",
 		}
-		launchTemplate, err := s.CreateLaunchTemplate(ms, aws.String("imageID"), userDataSecretKey, nil)
+		launchTemplate, err := s.CreateLaunchTemplate(context.TODO(), ms, aws.String("imageID"), userDataSecretKey, nil)
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(launchTemplate).Should(BeEmpty())
 	})
@@ -1202,10 +1202,10 @@ func TestCreateLaunchTemplateVersion(t *testing.T) {
 				tc.expect(mockEC2Client.EXPECT())
 			}
 			if tc.wantErr {
-				g.Expect(s.CreateLaunchTemplateVersion("launch-template-id", ms, aws.String("imageID"), userDataSecretKey, userData)).To(HaveOccurred())
+				g.Expect(s.CreateLaunchTemplateVersion(context.TODO(), "launch-template-id", ms, aws.String("imageID"), userDataSecretKey, userData)).To(HaveOccurred())
 				return
 			}
-			g.Expect(s.CreateLaunchTemplateVersion("launch-template-id", ms, aws.String("imageID"), userDataSecretKey, userData)).NotTo(HaveOccurred())
+			g.Expect(s.CreateLaunchTemplateVersion(context.TODO(), "launch-template-id", ms, aws.String("imageID"), userDataSecretKey, userData)).NotTo(HaveOccurred())
 		})
 	}
 }
@@ -1514,7 +1514,7 @@ func TestDiscoverLaunchTemplateAMI(t *testing.T) {
 			s := NewService(cs)
 			s.EC2Client = ec2Mock
 
-			id, err := s.DiscoverLaunchTemplateAMI(ms)
+			id, err := s.DiscoverLaunchTemplateAMI(context.TODO(), ms)
 			tc.check(g, id, err)
 		})
 	}
@@ -1549,7 +1549,7 @@ func TestDiscoverLaunchTemplateAMIForEKS(t *testing.T) {
 					}, nil)
 			},
 			expectSSM: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.GetParameter(gomock.AssignableToTypeOf(&ssm.GetParameterInput{})).
+				m.GetParameterWithContext(context.TODO(), gomock.AssignableToTypeOf(&ssm.GetParameterInput{})).
 					Return(&ssm.GetParameterOutput{
 						Parameter: &ssm.Parameter{
 							Value: aws.String("latest"),
@@ -1591,7 +1591,7 @@ func TestDiscoverLaunchTemplateAMIForEKS(t *testing.T) {
 			s.EC2Client = ec2Mock
 			s.SSMClient = ssmMock
 
-			id, err := s.DiscoverLaunchTemplateAMI(ms)
+			id, err := s.DiscoverLaunchTemplateAMI(context.TODO(), ms)
 			tc.check(g, id, err)
 		})
 	}
@@ -1668,10 +1668,216 @@ func TestDeleteLaunchTemplateVersion(t *testing.T) {
 			}
 
 			if tc.wantErr {
-				g.Expect(s.deleteLaunchTemplateVersion(tc.args.id, tc.args.version)).To(HaveOccurred())
+				g.Expect(s.deleteLaunchTemplateVersion(context.TODO(), tc.args.id, tc.args.version)).To(HaveOccurred())
 				return
 			}
-			g.Expect(s.deleteLaunchTemplateVersion(tc.args.id, tc.args.version)).NotTo(HaveOccurred())
+			g.Expect(s.deleteLaunchTemplateVersion(context.TODO(), tc.args.id, tc.args.version)).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestLaunchTemplateDataSSHKeyName(t *testing.T) {
+	tests := []struct {
+		name               string
+		instanceAccessMode infrav1.InstanceAccessMode
+		sshKeyName         *string
+		want               *string
+	}{
+		{
+			name:       "SSH key name of the launch template",
+			sshKeyName: aws.String("my-key"),
+			want:       aws.String("my-key"),
+		},
+		{
+			name:       "empty SSH key name",
+			sshKeyName: aws.String(""),
+		},
+		{
+			name:               "SSH key name of the launch template when instances are accessed with SSM",
+			instanceAccessMode: infrav1.InstanceAccessModeSSM,
+			sshKeyName:         aws.String("my-key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			cs.AWSCluster.Spec.InstanceAccessMode = tt.instanceAccessMode
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupNode] = infrav1.SecurityGroup{ID: "sg-node"}
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupLB] = infrav1.SecurityGroup{ID: "sg-lb"}
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.SSHKeyName = tt.sshKeyName
+
+			s := NewService(cs)
+			data, err := s.createLaunchTemplateData(context.TODO(), ms, aws.String("imageID"), types.NamespacedName{Namespace: "ns", Name: "bootstrap"}, []byte("userdata"))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(data.KeyName).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestLaunchTemplateDataDefaultEBSEncryptionKey(t *testing.T) {
+	const defaultKey = "arn:aws:kms:us-east-1:123456789012:key/default"
+
+	tests := []struct {
+		name             string
+		defaultKey       string
+		rootVolume       *infrav1.Volume
+		wantMappings     bool
+		wantSize         int64
+		wantEncryptedKey string
+	}{
+		{
+			name: "no default key and no root volume",
+		},
+		{
+			name:             "root volume of the AMI encrypted with the default key",
+			defaultKey:       defaultKey,
+			wantMappings:     true,
+			wantSize:         8,
+			wantEncryptedKey: defaultKey,
+		},
+		{
+			name:             "root volume encrypted with the default key",
+			defaultKey:       defaultKey,
+			rootVolume:       &infrav1.Volume{Size: 16},
+			wantMappings:     true,
+			wantSize:         16,
+			wantEncryptedKey: defaultKey,
+		},
+		{
+			name:             "root volume encrypted with its own key",
+			defaultKey:       defaultKey,
+			rootVolume:       &infrav1.Volume{Size: 16, EncryptionKey: "alias/own"},
+			wantMappings:     true,
+			wantSize:         16,
+			wantEncryptedKey: "alias/own",
+		},
+		{
+			name:         "root volume explicitly not encrypted",
+			defaultKey:   defaultKey,
+			rootVolume:   &infrav1.Volume{Size: 16, Encrypted: aws.Bool(false)},
+			wantMappings: true,
+			wantSize:     16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			cs.AWSCluster.Spec.DefaultEBSEncryptionKeyARN = tt.defaultKey
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupNode] = infrav1.SecurityGroup{ID: "sg-node"}
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupLB] = infrav1.SecurityGroup{ID: "sg-lb"}
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.RootVolume = tt.rootVolume
+
+			if tt.wantMappings {
+				ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{{
+						RootDeviceName:      aws.String("/dev/xvda"),
+						BlockDeviceMappings: []*ec2.BlockDeviceMapping{{Ebs: &ec2.EbsBlockDevice{VolumeSize: aws.Int64(8)}}},
+					}},
+				}, nil).Times(2)
+			}
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			data, err := s.createLaunchTemplateData(context.TODO(), ms, aws.String("imageID"), types.NamespacedName{Namespace: "ns", Name: "bootstrap"}, []byte("userdata"))
+			g.Expect(err).NotTo(HaveOccurred())
+			if !tt.wantMappings {
+				g.Expect(data.BlockDeviceMappings).To(BeEmpty())
+				return
+			}
+
+			g.Expect(data.BlockDeviceMappings).To(HaveLen(1))
+			ebs := data.BlockDeviceMappings[0].Ebs
+			g.Expect(aws.StringValue(data.BlockDeviceMappings[0].DeviceName)).To(Equal("/dev/xvda"))
+			g.Expect(aws.Int64Value(ebs.VolumeSize)).To(Equal(tt.wantSize))
+			g.Expect(aws.StringValue(ebs.KmsKeyId)).To(Equal(tt.wantEncryptedKey))
+			// the default key isn't set in the spec of the machine pool.
+			g.Expect(ms.AWSMachinePool.Spec.AWSLaunchTemplate.RootVolume).To(Equal(tt.rootVolume))
+		})
+	}
+}
+
+func TestLaunchTemplateDataInstanceStoreRootVolume(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultKey     string
+		rootDeviceType string
+		wantErr        bool
+	}{
+		{
+			name:           "instance store-backed AMI",
+			rootDeviceType: ec2.DeviceTypeInstanceStore,
+		},
+		{
+			name:           "instance store-backed AMI isn't encrypted with the default key",
+			defaultKey:     "arn:aws:kms:us-east-1:123456789012:key/default",
+			rootDeviceType: ec2.DeviceTypeInstanceStore,
+		},
+		{
+			name:           "EBS-backed AMI",
+			rootDeviceType: ec2.DeviceTypeEbs,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			cs, err := setupClusterScope(client)
+			g.Expect(err).NotTo(HaveOccurred())
+			cs.AWSCluster.Spec.DefaultEBSEncryptionKeyARN = tt.defaultKey
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupNode] = infrav1.SecurityGroup{ID: "sg-node"}
+			cs.AWSCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupLB] = infrav1.SecurityGroup{ID: "sg-lb"}
+
+			ms, err := setupMachinePoolScope(client, cs)
+			g.Expect(err).NotTo(HaveOccurred())
+			ms.AWSMachinePool.Spec.AWSLaunchTemplate.RootVolume = &infrav1.Volume{Type: infrav1.VolumeTypeInstanceStore}
+
+			ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), &ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"imageID"})}).
+				Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{{RootDeviceName: aws.String("/dev/sda1"), RootDeviceType: aws.String(tt.rootDeviceType)}},
+				}, nil)
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+			data, err := s.createLaunchTemplateData(context.TODO(), ms, aws.String("imageID"), types.NamespacedName{Namespace: "ns", Name: "bootstrap"}, []byte("userdata"))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(data.BlockDeviceMappings).To(BeEmpty())
 		})
 	}
 }
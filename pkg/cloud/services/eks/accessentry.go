@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcileAccessConfig returns the access config update required to reach the desired
+// authentication mode, or nil if the cluster is already using it.
+func (s *Service) reconcileAccessConfig(accessConfig *eks.AccessConfigResponse) *eks.UpdateAccessConfigRequest {
+	desired := s.scope.ControlPlane.Spec.AccessConfig.GetAuthenticationMode().String()
+
+	current := eks.AuthenticationModeConfigMap
+	if accessConfig != nil && accessConfig.AuthenticationMode != nil {
+		current = *accessConfig.AuthenticationMode
+	}
+
+	if current == desired {
+		return nil
+	}
+
+	s.scope.Debug("Authentication mode differs from spec", "current", current, "desired", desired)
+	return &eks.UpdateAccessConfigRequest{
+		AuthenticationMode: aws.String(desired),
+	}
+}
+
+func (s *Service) reconcileAccessEntries(ctx context.Context, cluster *eks.Cluster) error {
+	if cluster.AccessConfig == nil || aws.StringValue(cluster.AccessConfig.AuthenticationMode) == eks.AuthenticationModeConfigMap {
+		s.scope.Debug("Access entries are not enabled for the cluster, skipping reconcile")
+		return nil
+	}
+
+	eksClusterName := s.scope.KubernetesClusterName()
+	current, err := s.getAccessEntries(ctx, eksClusterName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get access entries")
+	}
+
+	desired := map[string]bool{}
+	for _, entry := range s.scope.ControlPlane.Spec.AccessEntries {
+		desired[entry.PrincipalARN] = true
+
+		existing := current[entry.PrincipalARN]
+		if existing != nil && aws.StringValue(existing.Type) != entryType(entry) {
+			s.scope.Debug("Access entry type changed, recreating", "principal", entry.PrincipalARN)
+			if err := s.deleteAccessEntry(ctx, eksClusterName, entry.PrincipalARN); err != nil {
+				return err
+			}
+			existing = nil
+		}
+
+		if existing == nil {
+			if err := s.createAccessEntry(ctx, eksClusterName, entry); err != nil {
+				return err
+			}
+		} else if err := s.updateAccessEntry(ctx, eksClusterName, entry, existing); err != nil {
+			return err
+		}
+
+		if err := s.reconcileAccessPolicies(ctx, eksClusterName, entry); err != nil {
+			return err
+		}
+	}
+
+	ownedTagKey := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Cluster.Name)
+	for principalARN, entry := range current {
+		if desired[principalARN] {
+			continue
+		}
+		// Only delete access entries created by CAPA, entries created by EKS (e.g. for
+		// the cluster creator or node roles) or by users are left untouched.
+		if aws.StringValue(entry.Tags[ownedTagKey]) != string(infrav1.ResourceLifecycleOwned) {
+			continue
+		}
+		if err := s.deleteAccessEntry(ctx, eksClusterName, principalARN); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) getAccessEntries(ctx context.Context, eksClusterName string) (map[string]*eks.AccessEntry, error) {
+	var principalARNs []*string
+	if err := s.EKSClient.ListAccessEntriesPagesWithContext(ctx, &eks.ListAccessEntriesInput{
+		ClusterName: aws.String(eksClusterName),
+	}, func(page *eks.ListAccessEntriesOutput, lastPage bool) bool {
+		principalARNs = append(principalARNs, page.AccessEntries...)
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list access entries")
+	}
+
+	entries := map[string]*eks.AccessEntry{}
+	for _, principalARN := range principalARNs {
+		out, err := s.EKSClient.DescribeAccessEntryWithContext(ctx, &eks.DescribeAccessEntryInput{
+			ClusterName:  aws.String(eksClusterName),
+			PrincipalArn: principalARN,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe access entry %s", aws.StringValue(principalARN))
+		}
+		entries[aws.StringValue(principalARN)] = out.AccessEntry
+	}
+
+	return entries, nil
+}
+
+func (s *Service) createAccessEntry(ctx context.Context, eksClusterName string, entry ekscontrolplanev1.AccessEntry) error {
+	input := &eks.CreateAccessEntryInput{
+		ClusterName:  aws.String(eksClusterName),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+		Type:         aws.String(entryType(entry)),
+		Tags:         aws.StringMap(ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags())),
+	}
+	if entry.Username != "" {
+		input.Username = aws.String(entry.Username)
+	}
+	if len(entry.KubernetesGroups) > 0 {
+		input.KubernetesGroups = aws.StringSlice(entry.KubernetesGroups)
+	}
+
+	if _, err := s.EKSClient.CreateAccessEntryWithContext(ctx, input); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSAccessEntry", "Failed to create access entry %s: %v", entry.PrincipalARN, err)
+		return errors.Wrapf(err, "failed to create access entry %s", entry.PrincipalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSAccessEntry", "Created access entry %s", entry.PrincipalARN)
+
+	return nil
+}
+
+func (s *Service) updateAccessEntry(ctx context.Context, eksClusterName string, entry ekscontrolplanev1.AccessEntry, existing *eks.AccessEntry) error {
+	input := &eks.UpdateAccessEntryInput{
+		ClusterName:  aws.String(eksClusterName),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	}
+
+	var needsUpdate bool
+	if !stringSetsEqual(entry.KubernetesGroups, aws.StringValueSlice(existing.KubernetesGroups)) {
+		input.KubernetesGroups = aws.StringSlice(entry.KubernetesGroups)
+		needsUpdate = true
+	}
+	if entry.Username != "" && entry.Username != aws.StringValue(existing.Username) {
+		input.Username = aws.String(entry.Username)
+		needsUpdate = true
+	}
+	if !needsUpdate {
+		return nil
+	}
+
+	if _, err := s.EKSClient.UpdateAccessEntryWithContext(ctx, input); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSAccessEntry", "Failed to update access entry %s: %v", entry.PrincipalARN, err)
+		return errors.Wrapf(err, "failed to update access entry %s", entry.PrincipalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSAccessEntry", "Updated access entry %s", entry.PrincipalARN)
+
+	return nil
+}
+
+func (s *Service) deleteAccessEntry(ctx context.Context, eksClusterName, principalARN string) error {
+	if _, err := s.EKSClient.DeleteAccessEntryWithContext(ctx, &eks.DeleteAccessEntryInput{
+		ClusterName:  aws.String(eksClusterName),
+		PrincipalArn: aws.String(principalARN),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedDeleteEKSAccessEntry", "Failed to delete access entry %s: %v", principalARN, err)
+		return errors.Wrapf(err, "failed to delete access entry %s", principalARN)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSAccessEntry", "Deleted access entry %s", principalARN)
+
+	return nil
+}
+
+func (s *Service) reconcileAccessPolicies(ctx context.Context, eksClusterName string, entry ekscontrolplanev1.AccessEntry) error {
+	current := map[string]*eks.AccessScope{}
+	input := &eks.ListAssociatedAccessPoliciesInput{
+		ClusterName:  aws.String(eksClusterName),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	}
+	if err := s.EKSClient.ListAssociatedAccessPoliciesPagesWithContext(ctx, input, func(page *eks.ListAssociatedAccessPoliciesOutput, lastPage bool) bool {
+		for _, policy := range page.AssociatedAccessPolicies {
+			current[aws.StringValue(policy.PolicyArn)] = policy.AccessScope
+		}
+		return !lastPage
+	}); err != nil {
+		return errors.Wrapf(err, "failed to list access policies of %s", entry.PrincipalARN)
+	}
+
+	desired := map[string]bool{}
+	for _, policy := range entry.AccessPolicies {
+		desired[policy.PolicyARN] = true
+
+		scope := &eks.AccessScope{
+			Type: aws.String(string(policy.AccessScope.Type)),
+		}
+		if len(policy.AccessScope.Namespaces) > 0 {
+			scope.Namespaces = aws.StringSlice(policy.AccessScope.Namespaces)
+		}
+		if existing, ok := current[policy.PolicyARN]; ok && accessScopesEqual(existing, scope) {
+			continue
+		}
+
+		if _, err := s.EKSClient.AssociateAccessPolicyWithContext(ctx, &eks.AssociateAccessPolicyInput{
+			ClusterName:  aws.String(eksClusterName),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policy.PolicyARN),
+			AccessScope:  scope,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to associate access policy %s with %s", policy.PolicyARN, entry.PrincipalARN)
+		}
+		s.scope.Debug("Associated access policy", "principal", entry.PrincipalARN, "policy", policy.PolicyARN)
+	}
+
+	for policyARN := range current {
+		if desired[policyARN] {
+			continue
+		}
+		if _, err := s.EKSClient.DisassociateAccessPolicyWithContext(ctx, &eks.DisassociateAccessPolicyInput{
+			ClusterName:  aws.String(eksClusterName),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policyARN),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to disassociate access policy %s from %s", policyARN, entry.PrincipalARN)
+		}
+		s.scope.Debug("Disassociated access policy", "principal", entry.PrincipalARN, "policy", policyARN)
+	}
+
+	return nil
+}
+
+func entryType(entry ekscontrolplanev1.AccessEntry) string {
+	if entry.Type == "" {
+		return ekscontrolplanev1.EKSAccessEntryTypeStandard.String()
+	}
+	return entry.Type.String()
+}
+
+func accessScopesEqual(a, b *eks.AccessScope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return aws.StringValue(a.Type) == aws.StringValue(b.Type) &&
+		stringSetsEqual(aws.StringValueSlice(a.Namespaces), aws.StringValueSlice(b.Namespaces))
+}
+
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileAccessConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		accessConfig *ekscontrolplanev1.AccessConfig
+		current      *eks.AccessConfigResponse
+		expect       *eks.UpdateAccessConfigRequest
+	}{
+		{
+			name:         "defaults to config_map",
+			accessConfig: nil,
+			current:      nil,
+			expect:       nil,
+		},
+		{
+			name: "no update when the mode matches",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+			},
+			current: &eks.AccessConfigResponse{AuthenticationMode: aws.String(eks.AuthenticationModeApi)},
+			expect:  nil,
+		},
+		{
+			name: "update when the mode differs",
+			accessConfig: &ekscontrolplanev1.AccessConfig{
+				AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPIAndConfigMap,
+			},
+			current: &eks.AccessConfigResponse{AuthenticationMode: aws.String(eks.AuthenticationModeConfigMap)},
+			expect:  &eks.UpdateAccessConfigRequest{AuthenticationMode: aws.String(eks.AuthenticationModeApiAndConfigMap)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := NewService(newManagedControlPlaneTestScope(g, ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				AccessConfig: tc.accessConfig,
+			}))
+			g.Expect(s.reconcileAccessConfig(tc.current)).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestReconcileAccessEntries(t *testing.T) {
+	clusterName := "default.cluster"
+	adminARN := "arn:aws:iam::123456789012:role/admin"
+	viewPolicyARN := "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"
+	ownedTags := map[string]*string{infrav1.ClusterAWSCloudProviderTagKey(clusterName): aws.String("owned")}
+
+	listEntries := func(m *mock_eksiface.MockEKSAPIMockRecorder, principalARNs ...string) {
+		m.ListAccessEntriesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAccessEntriesInput, fn func(*eks.ListAccessEntriesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAccessEntriesOutput{AccessEntries: aws.StringSlice(principalARNs)}, true)
+				return nil
+			})
+	}
+	listPolicies := func(m *mock_eksiface.MockEKSAPIMockRecorder, policies ...*eks.AssociatedAccessPolicy) {
+		m.ListAssociatedAccessPoliciesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAssociatedAccessPoliciesInput, fn func(*eks.ListAssociatedAccessPoliciesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAssociatedAccessPoliciesOutput{AssociatedAccessPolicies: policies}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name          string
+		mode          ekscontrolplanev1.EKSAuthenticationMode
+		accessEntries []ekscontrolplanev1.AccessEntry
+		expect        func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name: "access entries are not reconciled with the config_map authentication mode",
+			mode: ekscontrolplanev1.EKSAuthenticationModeConfigMap,
+			accessEntries: []ekscontrolplanev1.AccessEntry{
+				{PrincipalARN: adminARN},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name: "missing access entry is created and its policies associated",
+			mode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+			accessEntries: []ekscontrolplanev1.AccessEntry{
+				{
+					PrincipalARN:     adminARN,
+					KubernetesGroups: []string{"admins"},
+					AccessPolicies: []ekscontrolplanev1.AccessPolicyReference{
+						{
+							PolicyARN:   viewPolicyARN,
+							AccessScope: ekscontrolplanev1.AccessScope{Type: ekscontrolplanev1.EKSAccessScopeTypeCluster},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m)
+				m.CreateAccessEntryWithContext(gomock.Any(), &eks.CreateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(adminARN),
+					Type:             aws.String("STANDARD"),
+					KubernetesGroups: aws.StringSlice([]string{"admins"}),
+					Tags:             ownedTags,
+				}).Return(&eks.CreateAccessEntryOutput{}, nil)
+				listPolicies(m)
+				m.AssociateAccessPolicyWithContext(gomock.Any(), &eks.AssociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+					PolicyArn:    aws.String(viewPolicyARN),
+					AccessScope:  &eks.AccessScope{Type: aws.String(eks.AccessScopeTypeCluster)},
+				}).Return(&eks.AssociateAccessPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "changed groups are updated and removed policies disassociated",
+			mode: ekscontrolplanev1.EKSAuthenticationModeAPIAndConfigMap,
+			accessEntries: []ekscontrolplanev1.AccessEntry{
+				{PrincipalARN: adminARN, KubernetesGroups: []string{"admins"}},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, adminARN)
+				m.DescribeAccessEntryWithContext(gomock.Any(), gomock.Any()).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{
+						PrincipalArn:     aws.String(adminARN),
+						Type:             aws.String("STANDARD"),
+						KubernetesGroups: aws.StringSlice([]string{"viewers"}),
+						Tags:             ownedTags,
+					},
+				}, nil)
+				m.UpdateAccessEntryWithContext(gomock.Any(), &eks.UpdateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(adminARN),
+					KubernetesGroups: aws.StringSlice([]string{"admins"}),
+				}).Return(&eks.UpdateAccessEntryOutput{}, nil)
+				listPolicies(m, &eks.AssociatedAccessPolicy{
+					PolicyArn:   aws.String(viewPolicyARN),
+					AccessScope: &eks.AccessScope{Type: aws.String(eks.AccessScopeTypeCluster)},
+				})
+				m.DisassociateAccessPolicyWithContext(gomock.Any(), &eks.DisassociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+					PolicyArn:    aws.String(viewPolicyARN),
+				}).Return(&eks.DisassociateAccessPolicyOutput{}, nil)
+			},
+		},
+		{
+			name:          "only owned access entries are deleted",
+			mode:          ekscontrolplanev1.EKSAuthenticationModeAPI,
+			accessEntries: nil,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, adminARN, "arn:aws:iam::123456789012:role/creator")
+				m.DescribeAccessEntryWithContext(gomock.Any(), &eks.DescribeAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+				}).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{PrincipalArn: aws.String(adminARN), Tags: ownedTags},
+				}, nil)
+				m.DescribeAccessEntryWithContext(gomock.Any(), &eks.DescribeAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String("arn:aws:iam::123456789012:role/creator"),
+				}).Return(&eks.DescribeAccessEntryOutput{
+					AccessEntry: &eks.AccessEntry{PrincipalArn: aws.String("arn:aws:iam::123456789012:role/creator")},
+				}, nil)
+				m.DeleteAccessEntryWithContext(gomock.Any(), &eks.DeleteAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+				}).Return(&eks.DeleteAccessEntryOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			tc.expect(eksMock.EXPECT())
+
+			s := NewService(newManagedControlPlaneTestScope(g, ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				AccessConfig:  &ekscontrolplanev1.AccessConfig{AuthenticationMode: tc.mode},
+				AccessEntries: tc.accessEntries,
+			}))
+			s.EKSClient = eksMock
+
+			err := s.reconcileAccessEntries(context.TODO(), &eks.Cluster{
+				Name:         aws.String(clusterName),
+				AccessConfig: &eks.AccessConfigResponse{AuthenticationMode: aws.String(tc.mode.String())},
+			})
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func newManagedControlPlaneTestScope(g *WithT, spec ekscontrolplanev1.AWSManagedControlPlaneSpec) *scope.ManagedControlPlaneScope {
+	spec.EKSClusterName = "default.cluster"
+	spec.Region = "us-east-1"
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "default.cluster",
+			},
+		},
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec: spec,
+		},
+	})
+	g.Expect(err).To(BeNil())
+	return scope
+}
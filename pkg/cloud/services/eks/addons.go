@@ -210,8 +210,12 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 }
 
 func convertConflictResolution(conflict ekscontrolplanev1.AddonResolution) *string {
-	if conflict == ekscontrolplanev1.AddonResolutionNone {
+	switch conflict {
+	case ekscontrolplanev1.AddonResolutionNone:
 		return aws.String(eks.ResolveConflictsNone)
+	case ekscontrolplanev1.AddonResolutionPreserve:
+		return aws.String(eks.ResolveConflictsPreserve)
+	default:
+		return aws.String(eks.ResolveConflictsOverwrite)
 	}
-	return aws.String(eks.ResolveConflictsOverwrite)
 }
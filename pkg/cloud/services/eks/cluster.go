@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
@@ -49,6 +51,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 
 	eksClusterName := s.scope.KubernetesClusterName()
 
+	if err := s.reconcileLogGroup(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile control plane log group")
+	}
+
 	cluster, err := s.describeEKSCluster(eksClusterName)
 	if err != nil {
 		return errors.Wrap(err, "failed to describe eks clusters")
@@ -113,7 +119,7 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling additional kubeconfigs")
 	}
 
-	if err := s.reconcileClusterVersion(cluster); err != nil {
+	if err := s.reconcileClusterVersion(ctx, cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling cluster version")
 	}
 
@@ -121,6 +127,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling cluster config")
 	}
 
+	if err := s.reconcileAccessEntries(ctx, cluster); err != nil {
+		return errors.Wrap(err, "failed reconciling access entries")
+	}
+
 	if err := s.reconcileEKSEncryptionConfig(cluster.EncryptionConfig); err != nil {
 		return errors.Wrap(err, "failed reconciling eks encryption config")
 	}
@@ -357,7 +367,7 @@ func makeEksLogging(loggingSpec *ekscontrolplanev1.ControlPlaneLoggingSpec) *eks
 func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	logging := makeEksLogging(s.scope.ControlPlane.Spec.Logging)
 	encryptionConfigs := makeEksEncryptionConfigs(s.scope.ControlPlane.Spec.EncryptionConfig)
-	vpcConfig, err := makeVpcConfig(s.scope.Subnets(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
+	vpcConfig, err := makeVpcConfig(s.scope.Subnets().WithoutIPv6Native(), s.scope.ControlPlane.Spec.EndpointAccess, s.scope.SecurityGroups())
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create vpc config for cluster")
 	}
@@ -410,6 +420,25 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 		KubernetesNetworkConfig: netConfig,
 	}
 
+	if outpostConfig := s.scope.ControlPlane.Spec.OutpostConfig; outpostConfig != nil {
+		input.OutpostConfig = &eks.OutpostConfigRequest{
+			OutpostArns:              aws.StringSlice(outpostConfig.OutpostARNs),
+			ControlPlaneInstanceType: aws.String(outpostConfig.ControlPlaneInstanceType),
+		}
+		if outpostConfig.ControlPlanePlacement != nil {
+			input.OutpostConfig.ControlPlanePlacement = &eks.ControlPlanePlacementRequest{
+				GroupName: aws.String(outpostConfig.ControlPlanePlacement.GroupName),
+			}
+		}
+	}
+
+	if accessConfig := s.scope.ControlPlane.Spec.AccessConfig; accessConfig != nil {
+		input.AccessConfig = &eks.CreateAccessConfigRequest{
+			AuthenticationMode:                      aws.String(accessConfig.GetAuthenticationMode().String()),
+			BootstrapClusterCreatorAdminPermissions: accessConfig.BootstrapClusterCreatorAdminPermissions,
+		}
+	}
+
 	var out *eks.CreateClusterOutput
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		if out, err = s.EKSClient.CreateCluster(input); err != nil {
@@ -466,11 +495,29 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 	if err != nil {
 		return errors.Wrap(err, "couldn't create vpc config for cluster")
 	}
-	if updateVpcConfig != nil {
+	switch {
+	case updateVpcConfig == nil:
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition)
+	case needsUpdate:
+		// EKS only allows a single type of update at a time.
+		s.scope.Debug("Deferring endpoint access update until the other cluster config updates are done")
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition, ekscontrolplanev1.EKSEndpointAccessUpdatingReason, clusterv1.ConditionSeverityInfo,
+			"waiting for the other cluster config updates to be done")
+	default:
 		needsUpdate = true
 		input.ResourcesVpcConfig = updateVpcConfig
 	}
 
+	if updateAccessConfig := s.reconcileAccessConfig(cluster.AccessConfig); updateAccessConfig != nil {
+		// EKS does not allow updating the access config together with other settings.
+		if needsUpdate {
+			s.scope.Debug("Deferring access config update until the other cluster config updates are done")
+		} else {
+			needsUpdate = true
+			input.AccessConfig = updateAccessConfig
+		}
+	}
+
 	if needsUpdate {
 		if err := input.Validate(); err != nil {
 			return errors.Wrap(err, "created invalid UpdateClusterConfigInput")
@@ -483,9 +530,16 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 				return false, err
 			}
 			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)
+			if input.ResourcesVpcConfig != nil {
+				conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition, ekscontrolplanev1.EKSEndpointAccessUpdatingReason, clusterv1.ConditionSeverityInfo,
+					"updating endpoint access to %s", describeEndpointAccess(input.ResourcesVpcConfig))
+			}
 			record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSControlPlane", "Initiated update of a new EKS control plane %s", s.scope.KubernetesClusterName())
 			return true, nil
 		}); err != nil {
+			if input.ResourcesVpcConfig != nil {
+				conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition, ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			}
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Failed to update the EKS control plane: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
@@ -493,6 +547,16 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 	return nil
 }
 
+// describeEndpointAccess returns a description of the endpoint access of a VPC config update.
+func describeEndpointAccess(vpcConfig *eks.VpcConfigRequest) string {
+	publicCIDRs := aws.StringValueSlice(vpcConfig.PublicAccessCidrs)
+	if len(publicCIDRs) == 0 {
+		publicCIDRs = []string{"0.0.0.0/0"}
+	}
+	return fmt.Sprintf("public endpoint %t (%s), private endpoint %t",
+		ptr.Deref(vpcConfig.EndpointPublicAccess, true), strings.Join(publicCIDRs, ", "), ptr.Deref(vpcConfig.EndpointPrivateAccess, false))
+}
+
 func (s *Service) reconcileLogging(logging *eks.Logging) *eks.Logging {
 	for _, logSetup := range logging.ClusterLogging {
 		for _, l := range logSetup.Types {
@@ -520,7 +584,7 @@ func publicAccessCIDRsEqual(as []*string, bs []*string) bool {
 
 func (s *Service) reconcileVpcConfig(vpcConfig *eks.VpcConfigResponse) (*eks.VpcConfigRequest, error) {
 	endpointAccess := s.scope.ControlPlane.Spec.EndpointAccess
-	updatedVpcConfig, err := makeVpcConfig(s.scope.Subnets(), endpointAccess, s.scope.SecurityGroups())
+	updatedVpcConfig, err := makeVpcConfig(s.scope.Subnets().WithoutIPv6Native(), endpointAccess, s.scope.SecurityGroups())
 	if err != nil {
 		return nil, err
 	}
@@ -548,21 +612,43 @@ func (s *Service) reconcileEKSEncryptionConfig(currentClusterConfig []*eks.Encry
 
 	if compareEncryptionConfig(currentClusterConfig, updatedEncryptionConfigs) {
 		s.Debug("encryption configuration unchanged, no action")
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition)
 		return nil
 	}
 
+	// EKS only allows associating an encryption configuration with a cluster that doesn't
+	// have one yet, so the KMS key can be changed until the association succeeded.
 	if len(currentClusterConfig) == 0 && len(updatedEncryptionConfigs) > 0 {
 		s.Debug("enabling encryption for eks cluster", "cluster", s.scope.KubernetesClusterName())
 		if err := s.updateEncryptionConfig(updatedEncryptionConfigs); err != nil {
+			// A rejected association won't succeed on a retry, so it is only reported and
+			// the remaining cluster configuration is still reconciled.
+			if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == eks.ErrCodeInvalidRequestException || aerr.Code() == eks.ErrCodeInvalidParameterException) {
+				s.markEncryptionConfigUpdateNotSupported(aerr.Message())
+				return nil
+			}
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition, ekscontrolplanev1.EKSEncryptionConfigUpdateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane encryption configuration: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition)
 
 		return nil
 	}
 
-	record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
-	return errors.Errorf("failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
+	message := "disabling EKS encryption is not allowed after it has been enabled"
+	if len(updatedEncryptionConfigs) > 0 {
+		message = fmt.Sprintf("changing the EKS encryption configuration is not allowed after it has been enabled with key %s", getKeyArn(currentClusterConfig[0]))
+	}
+	s.markEncryptionConfigUpdateNotSupported(message)
+	return nil
+}
+
+// markEncryptionConfigUpdateNotSupported reports an encryption configuration change that EKS
+// doesn't support. The change has to be reverted in the spec, so it isn't retried.
+func (s *Service) markEncryptionConfigUpdateNotSupported(message string) {
+	conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition, ekscontrolplanev1.EKSEncryptionConfigUpdateNotSupportedReason, clusterv1.ConditionSeverityWarning, message)
+	record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: %s", message)
 }
 
 func parseEKSVersion(raw string) (*version.Version, error) {
@@ -577,7 +663,7 @@ func versionToEKS(v *version.Version) string {
 	return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
 }
 
-func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
+func (s *Service) reconcileClusterVersion(ctx context.Context, cluster *eks.Cluster) error {
 	var specVersion *version.Version
 	if s.scope.ControlPlane.Spec.Version != nil {
 		var err error
@@ -594,6 +680,15 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 		// need to go 1.14-> 1.15 and then 1.15 -> 1.16.
 		nextVersionString := versionToEKS(clusterVersion.WithMinor(clusterVersion.Minor() + 1))
 
+		ready, err := s.checkVersionUpdate(ctx, cluster, nextVersionString)
+		if err != nil {
+			return errors.Wrap(err, "failed to check version update policy")
+		}
+		if !ready {
+			s.scope.Info("Control plane version update blocked by the version update policy", "version", nextVersionString)
+			return nil
+		}
+
 		input := &eks.UpdateClusterVersionInput{
 			Name:    aws.String(s.scope.KubernetesClusterName()),
 			Version: &nextVersionString,
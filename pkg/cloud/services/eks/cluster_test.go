@@ -17,9 +17,11 @@ limitations under the License.
 package eks
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/golang/mock/gomock"
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMakeEKSEncryptionConfigs(t *testing.T) {
@@ -440,6 +443,7 @@ func TestReconcileClusterVersion(t *testing.T) {
 				},
 				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Region:  "us-east-1",
 						Version: aws.String("1.16"),
 					},
 				},
@@ -453,7 +457,7 @@ func TestReconcileClusterVersion(t *testing.T) {
 			cluster, err := s.describeEKSCluster(clusterName)
 			g.Expect(err).To(BeNil())
 
-			err = s.reconcileClusterVersion(cluster)
+			err = s.reconcileClusterVersion(context.TODO(), cluster)
 			if tc.expectError {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -467,12 +471,14 @@ func TestCreateCluster(t *testing.T) {
 	clusterName := "cluster.default"
 	version := aws.String("1.24")
 	tests := []struct {
-		name        string
-		expectEKS   func(m *mock_eksiface.MockEKSAPIMockRecorder)
-		expectError bool
-		role        *string
-		tags        map[string]*string
-		subnets     []infrav1.SubnetSpec
+		name          string
+		expectEKS     func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError   bool
+		role          *string
+		tags          map[string]*string
+		subnets       []infrav1.SubnetSpec
+		outpostConfig *ekscontrolplanev1.OutpostConfig
+		expectOutpost *eks.OutpostConfigRequest
 	}{
 		{
 			name:        "cluster create with 2 subnets",
@@ -486,6 +492,28 @@ func TestCreateCluster(t *testing.T) {
 				{ID: "1", AvailabilityZone: "us-west-2a"}, {ID: "2", AvailabilityZone: "us-west-2b"},
 			},
 		},
+		{
+			name:        "local cluster create on an outpost",
+			expectEKS:   func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectError: false,
+			role:        aws.String("arn:role"),
+			tags: map[string]*string{
+				"kubernetes.io/cluster/" + clusterName: aws.String("owned"),
+			},
+			subnets: []infrav1.SubnetSpec{
+				{ID: "1", AvailabilityZone: "us-west-2a"}, {ID: "2", AvailabilityZone: "us-west-2b"},
+			},
+			outpostConfig: &ekscontrolplanev1.OutpostConfig{
+				OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"},
+				ControlPlaneInstanceType: "m5d.large",
+				ControlPlanePlacement:    &ekscontrolplanev1.ControlPlanePlacement{GroupName: "cp-spread"},
+			},
+			expectOutpost: &eks.OutpostConfigRequest{
+				OutpostArns:              aws.StringSlice([]string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-1234"}),
+				ControlPlaneInstanceType: aws.String("m5d.large"),
+				ControlPlanePlacement:    &eks.ControlPlanePlacementRequest{GroupName: aws.String("cp-spread")},
+			},
+		},
 		{
 			name:        "cluster create without subnets",
 			expectEKS:   func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
@@ -519,10 +547,12 @@ func TestCreateCluster(t *testing.T) {
 				},
 				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Region:         "us-east-1",
 						EKSClusterName: clusterName,
 						Version:        version,
 						RoleName:       tc.role,
 						NetworkSpec:    infrav1.NetworkSpec{Subnets: tc.subnets},
+						OutpostConfig:  tc.outpostConfig,
 					},
 				},
 			})
@@ -541,9 +571,10 @@ func TestCreateCluster(t *testing.T) {
 					ResourcesVpcConfig: &eks.VpcConfigRequest{
 						SubnetIds: subnetIDs,
 					},
-					RoleArn: tc.role,
-					Tags:    tc.tags,
-					Version: version,
+					RoleArn:       tc.role,
+					Tags:          tc.tags,
+					Version:       version,
+					OutpostConfig: tc.expectOutpost,
 				}).Return(&eks.CreateClusterOutput{}, nil)
 			}
 			s := NewService(scope)
@@ -568,6 +599,7 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 		newEncryptionConfig *ekscontrolplanev1.EncryptionConfig
 		expect              func(m *mock_eksiface.MockEKSAPIMockRecorder)
 		expectError         bool
+		expectReason        string
 	}{
 		{
 			name:                "no upgrade necessary - encryption disabled",
@@ -612,7 +644,8 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			},
 			newEncryptionConfig: nil,
 			expect:              func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
-			expectError:         true,
+			expectError:         false,
+			expectReason:        ekscontrolplanev1.EKSEncryptionConfigUpdateNotSupportedReason,
 		},
 		{
 			name: "upgrade not allowed if encryption config exists",
@@ -624,8 +657,37 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				Provider:  ptr.To[string]("new-provider"),
 				Resources: []*string{ptr.To[string]("foo"), ptr.To[string]("bar")},
 			},
-			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
-			expectError: true,
+			expect:       func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectError:  false,
+			expectReason: ekscontrolplanev1.EKSEncryptionConfigUpdateNotSupportedReason,
+		},
+		{
+			name:                "association rejected by EKS",
+			oldEncryptionConfig: nil,
+			newEncryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				Provider:  ptr.To[string]("provider"),
+				Resources: []*string{ptr.To[string]("foo"), ptr.To[string]("bar")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.AssociateEncryptionConfig(gomock.AssignableToTypeOf(&eks.AssociateEncryptionConfigInput{})).
+					Return(nil, awserr.New(eks.ErrCodeInvalidRequestException, "encryption config is already associated", nil))
+			},
+			expectError:  false,
+			expectReason: ekscontrolplanev1.EKSEncryptionConfigUpdateNotSupportedReason,
+		},
+		{
+			name:                "association failed",
+			oldEncryptionConfig: nil,
+			newEncryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				Provider:  ptr.To[string]("provider"),
+				Resources: []*string{ptr.To[string]("foo"), ptr.To[string]("bar")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.AssociateEncryptionConfig(gomock.AssignableToTypeOf(&eks.AssociateEncryptionConfigInput{})).
+					Return(nil, awserr.New(eks.ErrCodeServerException, "internal error", nil))
+			},
+			expectError:  true,
+			expectReason: ekscontrolplanev1.EKSEncryptionConfigUpdateFailedReason,
 		},
 	}
 
@@ -652,6 +714,7 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				},
 				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Region:           "us-east-1",
 						Version:          aws.String("1.16"),
 						EncryptionConfig: tc.newEncryptionConfig,
 					},
@@ -666,9 +729,15 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			err = s.reconcileEKSEncryptionConfig(makeEksEncryptionConfigs(tc.oldEncryptionConfig))
 			if tc.expectError {
 				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.expectReason != "" {
+				g.Expect(conditions.IsFalse(scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition)).To(Equal(tc.expectReason))
 				return
 			}
-			g.Expect(err).To(BeNil())
+			g.Expect(conditions.IsTrue(scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfiguredCondition)).To(BeTrue())
 		})
 	}
 }
@@ -705,6 +774,7 @@ func TestCreateIPv6Cluster(t *testing.T) {
 		},
 		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				Region:   "us-east-1",
 				RoleName: ptr.To[string]("arn-role"),
 				Version:  aws.String("1.22"),
 				NetworkSpec: infrav1.NetworkSpec{
@@ -770,3 +840,154 @@ func TestCreateIPv6Cluster(t *testing.T) {
 	_, err = s.createCluster("cluster-name")
 	g.Expect(err).To(BeNil())
 }
+
+func TestReconcileClusterConfig(t *testing.T) {
+	clusterName := "default.cluster"
+	subnets := infrav1.Subnets{
+		{ID: "subnet-1", AvailabilityZone: "us-west-2a"},
+		{ID: "subnet-2", AvailabilityZone: "us-west-2b"},
+	}
+	tests := []struct {
+		name           string
+		endpointAccess ekscontrolplanev1.EndpointAccess
+		logging        *ekscontrolplanev1.ControlPlaneLoggingSpec
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectError    bool
+		expectReason   string
+	}{
+		{
+			name: "endpoint access up to date",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{ptr.To[string]("10.0.0.0/8")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+		},
+		{
+			name: "public access CIDRs updated",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{ptr.To[string]("10.0.0.0/8"), ptr.To[string]("192.168.0.0/16")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					ResourcesVpcConfig: &eks.VpcConfigRequest{
+						PublicAccessCidrs: []*string{ptr.To[string]("10.0.0.0/8"), ptr.To[string]("192.168.0.0/16")},
+					},
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+			expectReason: ekscontrolplanev1.EKSEndpointAccessUpdatingReason,
+		},
+		{
+			name: "private endpoint enabled",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				Public:      ptr.To[bool](false),
+				PublicCIDRs: []*string{ptr.To[string]("10.0.0.0/8")},
+				Private:     ptr.To[bool](true),
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					ResourcesVpcConfig: &eks.VpcConfigRequest{
+						EndpointPublicAccess:  ptr.To[bool](false),
+						EndpointPrivateAccess: ptr.To[bool](true),
+						PublicAccessCidrs:     []*string{ptr.To[string]("10.0.0.0/8")},
+					},
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+			expectReason: ekscontrolplanev1.EKSEndpointAccessUpdatingReason,
+		},
+		{
+			name: "endpoint access update deferred after logging update",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{ptr.To[string]("192.168.0.0/16")},
+			},
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{APIServer: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.Any()).DoAndReturn(func(input *eks.UpdateClusterConfigInput) (*eks.UpdateClusterConfigOutput, error) {
+					if input.Logging == nil || input.ResourcesVpcConfig != nil {
+						return nil, errors.Errorf("unexpected update %s", input)
+					}
+					return &eks.UpdateClusterConfigOutput{}, nil
+				})
+			},
+			expectReason: ekscontrolplanev1.EKSEndpointAccessUpdatingReason,
+		},
+		{
+			name: "endpoint access update rejected by EKS",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				PublicCIDRs: []*string{ptr.To[string]("192.168.0.0/16")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.Any()).
+					Return(nil, awserr.New(eks.ErrCodeInvalidParameterException, "Only one type of update can be allowed", nil))
+			},
+			expectError:  true,
+			expectReason: ekscontrolplanev1.EKSEndpointAccessUpdateFailedReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Region:         "us-east-1",
+						EKSClusterName: clusterName,
+						Version:        aws.String("1.16"),
+						EndpointAccess: tc.endpointAccess,
+						Logging:        tc.logging,
+						NetworkSpec:    infrav1.NetworkSpec{Subnets: subnets},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			err = s.reconcileClusterConfig(&eks.Cluster{
+				Logging: &eks.Logging{
+					ClusterLogging: []*eks.LogSetup{{
+						Enabled: aws.Bool(false),
+						Types:   aws.StringSlice([]string{eks.LogTypeApi}),
+					}},
+				},
+				ResourcesVpcConfig: &eks.VpcConfigResponse{
+					EndpointPublicAccess:  aws.Bool(true),
+					EndpointPrivateAccess: aws.Bool(false),
+					PublicAccessCidrs:     []*string{ptr.To[string]("10.0.0.0/8")},
+				},
+			})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.expectReason == "" {
+				g.Expect(conditions.IsTrue(scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition)).To(BeTrue())
+				return
+			}
+			g.Expect(conditions.IsFalse(scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(scope.ControlPlane, ekscontrolplanev1.EKSEndpointAccessConfiguredCondition)).To(Equal(tc.expectReason))
+		})
+	}
+}
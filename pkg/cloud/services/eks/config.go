@@ -115,7 +115,7 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *eks.C
 		return fmt.Errorf("creating base kubeconfig: %w", err)
 	}
 
-	token, err := s.generateToken()
+	token, err := s.generateToken(cluster)
 	if err != nil {
 		return fmt.Errorf("generating presigned token: %w", err)
 	}
@@ -153,7 +153,7 @@ func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *
 		return errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
 	}
 
-	token, err := s.generateToken()
+	token, err := s.generateToken(cluster)
 	if err != nil {
 		return fmt.Errorf("generating presigned token: %w", err)
 	}
@@ -198,15 +198,19 @@ func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *eks.C
 		execConfig.Args = []string{
 			"token",
 			"-i",
-			clusterName,
+			tokenClusterID(cluster, clusterName),
 		}
 	case ekscontrolplanev1.EKSTokenMethodAWSCli:
+		clusterFlag := "--cluster-name"
+		if cluster.Id != nil {
+			clusterFlag = "--cluster-id"
+		}
 		execConfig.Command = "aws"
 		execConfig.Args = []string{
 			"eks",
 			"get-token",
-			"--cluster-name",
-			clusterName,
+			clusterFlag,
+			tokenClusterID(cluster, clusterName),
 		}
 	default:
 		return fmt.Errorf("using token method %s: %w", s.scope.TokenMethod(), ErrUnknownTokenMethod)
@@ -260,11 +264,11 @@ func (s *Service) createBaseKubeConfig(cluster *eks.Cluster, userName string) (*
 	return cfg, nil
 }
 
-func (s *Service) generateToken() (string, error) {
-	eksClusterName := s.scope.KubernetesClusterName()
+func (s *Service) generateToken(cluster *eks.Cluster) (string, error) {
+	clusterID := tokenClusterID(cluster, s.scope.KubernetesClusterName())
 
 	req, output := s.STSClient.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
-	req.HTTPRequest.Header.Add(clusterNameHeader, eksClusterName)
+	req.HTTPRequest.Header.Add(clusterNameHeader, clusterID)
 	s.Trace("generating token for AWS identity", "user", output.UserId, "account", output.Account, "arn", output.Arn)
 
 	presignedURL, err := req.Presign(tokenAgeMins * time.Minute)
@@ -276,6 +280,15 @@ func (s *Service) generateToken() (string, error) {
 	return fmt.Sprintf("%s%s", tokenPrefix, encodedURL), nil
 }
 
+// tokenClusterID returns the cluster identifier that authentication tokens are issued for.
+// Local clusters on Outposts are identified by their cluster ID instead of their name.
+func tokenClusterID(cluster *eks.Cluster, clusterName string) string {
+	if cluster != nil && cluster.Id != nil {
+		return *cluster.Id
+	}
+	return clusterName
+}
+
 func (s *Service) getKubeConfigUserName(clusterName string, isUser bool) string {
 	if isUser {
 		return fmt.Sprintf("%s-user", clusterName)
@@ -56,6 +56,16 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 	}
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonsConfiguredCondition)
 
+	// EKS Pod Identity Associations, skipped until associations have been configured once so
+	// that clusters not using them don't need the related permissions.
+	if len(s.scope.ControlPlane.Spec.PodIdentityAssociations) > 0 || conditions.Has(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition) {
+		if err := s.reconcilePodIdentityAssociations(ctx); err != nil {
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return errors.Wrap(err, "failed reconciling eks pod identity associations")
+		}
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition)
+	}
+
 	// EKS Identity Provider
 	if err := s.reconcileIdentityProvider(ctx); err != nil {
 		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition, ekscontrolplanev1.EKSIdentityProviderConfiguredFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
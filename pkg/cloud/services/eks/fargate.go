@@ -95,6 +95,7 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 	if eksClusterName := s.scope.KubernetesClusterName(); profile == nil {
 		profile, err = s.createFargateProfile()
 		if err != nil {
+			s.markSelectorsFailed(err)
 			return false, errors.Wrap(err, "failed to create profile")
 		}
 		// Force status to creating
@@ -118,6 +119,7 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 
 func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool) {
 	s.Debug("fargate profile", "status", *profile.Status)
+	s.updateSelectorStatuses(profile)
 	switch *profile.Status {
 	case eks.FargateProfileStatusCreating:
 		s.scope.FargateProfile.Status.Ready = false
@@ -158,6 +160,69 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 	}
 }
 
+// updateSelectorStatuses reports the state of each selector of the spec based on the
+// EKS fargate profile.
+func (s *FargateService) updateSelectorStatuses(profile *eks.FargateProfile) {
+	statuses := make([]expinfrav1.FargateSelectorStatus, 0, len(s.scope.FargateProfile.Spec.Selectors))
+	for _, selector := range s.scope.FargateProfile.Spec.Selectors {
+		status := expinfrav1.FargateSelectorStatus{
+			Namespace: selector.Namespace,
+			Labels:    selector.Labels,
+		}
+		found := hasFargateSelector(profile.Selectors, selector)
+		switch aws.StringValue(profile.Status) {
+		case eks.FargateProfileStatusActive:
+			status.Ready = found
+			if !found {
+				status.FailureMessage = aws.String("selector is not part of the EKS fargate profile")
+			}
+		case eks.FargateProfileStatusCreateFailed:
+			status.FailureMessage = aws.String("creation of the EKS fargate profile failed")
+		}
+		statuses = append(statuses, status)
+	}
+	s.scope.FargateProfile.Status.SelectorStatuses = statuses
+}
+
+// markSelectorsFailed reports the selectors as failed when EKS rejects the profile. The error
+// doesn't identify the invalid selectors, so the failure is reported for all of them.
+func (s *FargateService) markSelectorsFailed(err error) {
+	aerr, ok := errors.Cause(err).(awserr.Error)
+	if !ok || aerr.Code() != eks.ErrCodeInvalidParameterException {
+		return
+	}
+
+	selectors := s.scope.FargateProfile.Spec.Selectors
+	statuses := make([]expinfrav1.FargateSelectorStatus, 0, len(selectors))
+	for _, selector := range selectors {
+		statuses = append(statuses, expinfrav1.FargateSelectorStatus{
+			Namespace:      selector.Namespace,
+			Labels:         selector.Labels,
+			FailureMessage: aws.String(aerr.Message()),
+		})
+	}
+	s.scope.FargateProfile.Status.SelectorStatuses = statuses
+}
+
+func hasFargateSelector(selectors []*eks.FargateProfileSelector, selector expinfrav1.FargateSelector) bool {
+	for _, candidate := range selectors {
+		if aws.StringValue(candidate.Namespace) != selector.Namespace || len(candidate.Labels) != len(selector.Labels) {
+			continue
+		}
+		matches := true
+		for key, value := range selector.Labels {
+			if candidateValue, ok := candidate.Labels[key]; !ok || aws.StringValue(candidateValue) != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
 // ReconcileDelete is the entrypoint for FargateProfile reconciliation.
 func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 	s.scope.Debug("Reconciling EKS fargate profile deletion")
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestFargateSelectorStatuses(t *testing.T) {
+	selectors := []expinfrav1.FargateSelector{
+		{Namespace: "kube-system", Labels: map[string]string{"k8s-app": "kube-dns"}},
+		{Namespace: "app-*", Labels: map[string]string{"tier": "web-?"}},
+	}
+
+	tests := []struct {
+		name         string
+		profile      *eks.FargateProfile
+		createErr    error
+		expectReady  []bool
+		expectFailed []bool
+	}{
+		{
+			name: "selectors of an active profile are ready",
+			profile: &eks.FargateProfile{
+				Status: aws.String(eks.FargateProfileStatusActive),
+				Selectors: []*eks.FargateProfileSelector{
+					{Namespace: aws.String("kube-system"), Labels: aws.StringMap(map[string]string{"k8s-app": "kube-dns"})},
+					{Namespace: aws.String("app-*"), Labels: aws.StringMap(map[string]string{"tier": "web-?"})},
+				},
+			},
+			expectReady:  []bool{true, true},
+			expectFailed: []bool{false, false},
+		},
+		{
+			name: "selectors missing from an active profile are reported",
+			profile: &eks.FargateProfile{
+				Status: aws.String(eks.FargateProfileStatusActive),
+				Selectors: []*eks.FargateProfileSelector{
+					{Namespace: aws.String("kube-system"), Labels: aws.StringMap(map[string]string{"k8s-app": "kube-dns"})},
+				},
+			},
+			expectReady:  []bool{true, false},
+			expectFailed: []bool{false, true},
+		},
+		{
+			name:         "selectors of a failed profile are reported",
+			profile:      &eks.FargateProfile{Status: aws.String(eks.FargateProfileStatusCreateFailed)},
+			expectReady:  []bool{false, false},
+			expectFailed: []bool{true, true},
+		},
+		{
+			name:         "all selectors are reported when the profile is rejected",
+			createErr:    errors.Wrap(awserr.New(eks.ErrCodeInvalidParameterException, "invalid selector for namespace app-*", nil), "failed to create fargate profile"),
+			expectReady:  []bool{false, false},
+			expectFailed: []bool{true, true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := NewFargateService(newFargateProfileTestScope(g, expinfrav1.FargateProfileSpec{
+				ClusterName: "default.cluster",
+				ProfileName: "profile",
+				Selectors:   selectors,
+			}))
+			if tc.createErr != nil {
+				s.markSelectorsFailed(tc.createErr)
+			} else {
+				s.handleStatus(tc.profile)
+			}
+
+			statuses := s.scope.FargateProfile.Status.SelectorStatuses
+			g.Expect(statuses).To(HaveLen(len(selectors)))
+			for i, status := range statuses {
+				g.Expect(status.Namespace).To(Equal(selectors[i].Namespace))
+				g.Expect(status.Ready).To(Equal(tc.expectReady[i]))
+				g.Expect(status.FailureMessage != nil).To(Equal(tc.expectFailed[i]))
+			}
+		})
+	}
+}
+
+func newFargateProfileTestScope(g *WithT, spec expinfrav1.FargateProfileSpec) *scope.FargateProfileScope {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	_ = expinfrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewFargateProfileScope(scope.FargateProfileScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "default.cluster",
+			},
+		},
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "default.cluster"},
+		},
+		FargateProfile: &expinfrav1.AWSFargateProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "profile",
+			},
+			Spec: spec,
+		},
+	})
+	g.Expect(err).To(BeNil())
+	return scope
+}
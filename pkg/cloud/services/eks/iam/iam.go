@@ -28,7 +28,6 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/google/go-cmp/cmp"
@@ -43,6 +42,8 @@ import (
 const (
 	// EKSFargateService is the service to trust for fargate pod execution roles.
 	EKSFargateService = "eks-fargate-pods.amazonaws.com"
+	// EC2Service is the service to trust for the control plane roles of local clusters on Outposts.
+	EC2Service = "ec2.amazonaws.com"
 )
 
 // IAMService defines the specs for an IAM service.
@@ -363,6 +364,27 @@ func ControlPlaneTrustRelationship(enableFargate bool) *iamv1.PolicyDocument {
 	return policy
 }
 
+// LocalClusterTrustRelationship will generate the control plane PolicyDocument of a local cluster on Outposts.
+func LocalClusterTrustRelationship() *iamv1.PolicyDocument {
+	identity := make(iamv1.Principals)
+	identity["Service"] = []string{EC2Service}
+
+	policy := &iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"sts:AssumeRole",
+				},
+				Principal: identity,
+			},
+		},
+	}
+
+	return policy
+}
+
 // FargateTrustRelationship will generate a Fargate PolicyDocument.
 func FargateTrustRelationship() *iamv1.PolicyDocument {
 	identity := make(iamv1.Principals)
@@ -405,6 +427,31 @@ func NodegroupTrustRelationship() *iamv1.PolicyDocument {
 	return policy
 }
 
+// OIDCTrustPolicy returns the boilerplate trust policy allowing the service accounts of a cluster
+// to assume a role through the OIDC provider with the given ARN.
+func OIDCTrustPolicy(providerARN string) iamv1.PolicyDocument {
+	conditionValue := providerARN[strings.Index(providerARN, "/")+1:] + ":sub"
+
+	return iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: iamv1.Statements{
+			iamv1.StatementEntry{
+				Sid:    "",
+				Effect: "Allow",
+				Principal: iamv1.Principals{
+					iamv1.PrincipalFederated: iamv1.PrincipalID{providerARN},
+				},
+				Action: iamv1.Actions{"sts:AssumeRoleWithWebIdentity"},
+				Condition: iamv1.Conditions{
+					"ForAnyValue:StringLike": map[string][]string{
+						conditionValue: {"system:serviceaccount:${SERVICE_ACCOUNT_NAMESPACE}:${SERVICE_ACCOUNT_NAME}"},
+					},
+				},
+			},
+		},
+	}
+}
+
 func findStringInSlice(slice []*string, toFind string) bool {
 	for _, item := range slice {
 		if *item == toFind {
@@ -417,9 +464,9 @@ func findStringInSlice(slice []*string, toFind string) bool {
 
 const stsAWSAudience = "sts.amazonaws.com"
 
-// CreateOIDCProvider will create an OIDC provider.
-func (s *IAMService) CreateOIDCProvider(cluster *eks.Cluster) (string, error) {
-	issuerURL, err := url.Parse(*cluster.Identity.Oidc.Issuer)
+// CreateOIDCProvider will create an OIDC provider for the given issuer URL.
+func (s *IAMService) CreateOIDCProvider(issuer string) (string, error) {
+	issuerURL, err := url.Parse(issuer)
 	if err != nil {
 		return "", err
 	}
@@ -443,10 +490,10 @@ func (s *IAMService) CreateOIDCProvider(cluster *eks.Cluster) (string, error) {
 	return *provider.OpenIDConnectProviderArn, nil
 }
 
-// FindAndVerifyOIDCProvider will try to find an OIDC provider. It will return an error if the found provider does not
-// match the cluster spec.
-func (s *IAMService) FindAndVerifyOIDCProvider(cluster *eks.Cluster) (string, error) {
-	issuerURL, err := url.Parse(*cluster.Identity.Oidc.Issuer)
+// FindAndVerifyOIDCProvider will try to find an OIDC provider for the given issuer URL. It will return an error if the
+// found provider does not match the expected thumbprint or client ID.
+func (s *IAMService) FindAndVerifyOIDCProvider(issuer string) (string, error) {
+	issuerURL, err := url.Parse(issuer)
 	if err != nil {
 		return "", err
 	}
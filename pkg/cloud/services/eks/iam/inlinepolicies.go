@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// EnsureInlinePolicies puts the given inline policies that are missing or differ in a role, and
+// deletes the ones of the role that aren't given.
+func (s *IAMService) EnsureInlinePolicies(roleName string, policies []infrav1.InlinePolicy) error {
+	desired := map[string]bool{}
+	for _, policy := range policies {
+		desired[policy.Name] = true
+	}
+
+	existing, err := s.inlinePolicyNames(roleName)
+	if err != nil {
+		return err
+	}
+	for _, name := range existing {
+		if desired[name] {
+			continue
+		}
+		if err := s.deleteInlinePolicy(roleName, name); err != nil {
+			return err
+		}
+		s.Debug("Deleted inline policy from role", "role", roleName, "policy", name)
+	}
+
+	for _, policy := range policies {
+		out, err := s.IAMClient.GetRolePolicy(&iam.GetRolePolicyInput{
+			RoleName:   aws.String(roleName),
+			PolicyName: aws.String(policy.Name),
+		})
+		if err != nil && !isNoSuchEntity(err) {
+			return errors.Wrapf(err, "failed to get inline policy %s", policy.Name)
+		}
+		if err == nil {
+			equal, err := policyDocumentsEqual(aws.StringValue(out.PolicyDocument), policy.PolicyDocument)
+			if err != nil {
+				return errors.Wrapf(err, "failed to compare inline policy %s", policy.Name)
+			}
+			if equal {
+				continue
+			}
+		}
+
+		if _, err := s.IAMClient.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       aws.String(roleName),
+			PolicyName:     aws.String(policy.Name),
+			PolicyDocument: aws.String(policy.PolicyDocument),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to put inline policy %s", policy.Name)
+		}
+		s.Debug("Put inline policy in role", "role", roleName, "policy", policy.Name)
+	}
+
+	return nil
+}
+
+// DeleteInlinePolicies deletes all the inline policies of a role, which must be done before deleting it.
+func (s *IAMService) DeleteInlinePolicies(roleName string) error {
+	names, err := s.inlinePolicyNames(roleName)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := s.deleteInlinePolicy(roleName, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *IAMService) deleteInlinePolicy(roleName, name string) error {
+	if _, err := s.IAMClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(name),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete inline policy %s of role %s", name, roleName)
+	}
+
+	return nil
+}
+
+func (s *IAMService) inlinePolicyNames(roleName string) ([]string, error) {
+	names := []string{}
+	if err := s.IAMClient.ListRolePoliciesPages(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
+		names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list inline policies of role %s", roleName)
+	}
+	return names, nil
+}
+
+// policyDocumentsEqual compares the URL encoded policy document returned by IAM with a JSON one.
+func policyDocumentsEqual(encoded, document string) (bool, error) {
+	decoded, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	var current, desired interface{}
+	if err := json.Unmarshal([]byte(decoded), &current); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(document), &desired); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(current, desired), nil
+}
+
+func isNoSuchEntity(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == iam.ErrCodeNoSuchEntityException
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+)
+
+// CloudProviderControlPlanePolicy returns the policy granting the permissions required by the AWS cloud
+// provider on the control plane nodes.
+// From https://github.com/kubernetes/cloud-provider-aws
+func CloudProviderControlPlanePolicy() *iamv1.PolicyDocument {
+	return &iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{iamv1.Any},
+				Action: iamv1.Actions{
+					"autoscaling:DescribeAutoScalingGroups",
+					"autoscaling:DescribeLaunchConfigurations",
+					"autoscaling:DescribeTags",
+					"ec2:AssignIpv6Addresses",
+					"ec2:DescribeInstances",
+					"ec2:DescribeImages",
+					"ec2:DescribeRegions",
+					"ec2:DescribeRouteTables",
+					"ec2:DescribeSecurityGroups",
+					"ec2:DescribeSubnets",
+					"ec2:DescribeVolumes",
+					"ec2:CreateSecurityGroup",
+					"ec2:CreateTags",
+					"ec2:CreateVolume",
+					"ec2:ModifyInstanceAttribute",
+					"ec2:ModifyVolume",
+					"ec2:AttachVolume",
+					"ec2:AuthorizeSecurityGroupIngress",
+					"ec2:CreateRoute",
+					"ec2:DeleteRoute",
+					"ec2:DeleteSecurityGroup",
+					"ec2:DeleteVolume",
+					"ec2:DetachVolume",
+					"ec2:RevokeSecurityGroupIngress",
+					"ec2:DescribeVpcs",
+					"elasticloadbalancing:AddTags",
+					"elasticloadbalancing:AttachLoadBalancerToSubnets",
+					"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+					"elasticloadbalancing:CreateLoadBalancer",
+					"elasticloadbalancing:CreateLoadBalancerPolicy",
+					"elasticloadbalancing:CreateLoadBalancerListeners",
+					"elasticloadbalancing:ConfigureHealthCheck",
+					"elasticloadbalancing:DeleteLoadBalancer",
+					"elasticloadbalancing:DeleteLoadBalancerListeners",
+					"elasticloadbalancing:DescribeLoadBalancers",
+					"elasticloadbalancing:DescribeLoadBalancerAttributes",
+					"elasticloadbalancing:DetachLoadBalancerFromSubnets",
+					"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+					"elasticloadbalancing:ModifyLoadBalancerAttributes",
+					"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+					"elasticloadbalancing:SetLoadBalancerPoliciesForBackendServer",
+					"elasticloadbalancing:CreateListener",
+					"elasticloadbalancing:CreateTargetGroup",
+					"elasticloadbalancing:DeleteListener",
+					"elasticloadbalancing:DeleteTargetGroup",
+					"elasticloadbalancing:DeregisterTargets",
+					"elasticloadbalancing:DescribeListeners",
+					"elasticloadbalancing:DescribeLoadBalancerPolicies",
+					"elasticloadbalancing:DescribeTargetGroups",
+					"elasticloadbalancing:DescribeTargetHealth",
+					"elasticloadbalancing:ModifyListener",
+					"elasticloadbalancing:ModifyTargetGroup",
+					"elasticloadbalancing:RegisterTargets",
+					"elasticloadbalancing:SetLoadBalancerPoliciesOfListener",
+					"iam:CreateServiceLinkedRole",
+					"kms:DescribeKey",
+				},
+			},
+		},
+	}
+}
+
+// CloudProviderNodePolicy returns the policy granting the permissions required by the AWS cloud provider on all the nodes.
+// From https://github.com/kubernetes/cloud-provider-aws
+func CloudProviderNodePolicy() *iamv1.PolicyDocument {
+	return &iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect:   iamv1.EffectAllow,
+				Resource: iamv1.Resources{iamv1.Any},
+				Action: iamv1.Actions{
+					"ec2:AssignIpv6Addresses",
+					"ec2:DescribeInstances",
+					"ec2:DescribeRegions",
+					"ec2:CreateTags",
+					"ec2:DescribeTags",
+					"ec2:DescribeNetworkInterfaces",
+					"ec2:DescribeInstanceTypes",
+					"ecr:GetAuthorizationToken",
+					"ecr:BatchCheckLayerAvailability",
+					"ecr:GetDownloadUrlForLayer",
+					"ecr:GetRepositoryPolicy",
+					"ecr:DescribeRepositories",
+					"ecr:ListImages",
+					"ecr:BatchGetImage",
+				},
+			},
+		},
+	}
+}
+
+// SecretBackendPolicyStatement returns the statement allowing the nodes to retrieve and delete their
+// bootstrap data from a secure secret backend.
+func SecretBackendPolicyStatement(secureSecretsBackend infrav1.SecretBackend) iamv1.StatementEntry {
+	switch secureSecretsBackend {
+	case infrav1.SecretBackendSecretsManager:
+		return iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:secretsmanager:*:*:secret:aws.cluster.x-k8s.io/*",
+			},
+			Action: iamv1.Actions{
+				"secretsmanager:DeleteSecret",
+				"secretsmanager:GetSecretValue",
+			},
+		}
+	case infrav1.SecretBackendSSMParameterStore:
+		return iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:ssm:*:*:parameter/cluster.x-k8s.io/*",
+			},
+			Action: iamv1.Actions{
+				"ssm:DeleteParameter",
+				"ssm:GetParameter",
+			},
+		}
+	}
+	return iamv1.StatementEntry{}
+}
+
+// SessionManagerPolicyStatement returns the statement allowing the nodes to be managed through AWS Systems Manager
+// Session Manager.
+func SessionManagerPolicyStatement() iamv1.StatementEntry {
+	return iamv1.StatementEntry{
+		Effect:   iamv1.EffectAllow,
+		Resource: iamv1.Resources{iamv1.Any},
+		Action: iamv1.Actions{
+			"ssm:UpdateInstanceInformation",
+			"ssmmessages:CreateControlChannel",
+			"ssmmessages:CreateDataChannel",
+			"ssmmessages:OpenControlChannel",
+			"ssmmessages:OpenDataChannel",
+			"s3:GetEncryptionConfiguration",
+		},
+	}
+}
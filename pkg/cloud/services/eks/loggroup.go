@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// logGroupName returns the name of the CloudWatch Logs group EKS sends the control plane logs to.
+func logGroupName(eksClusterName string) string {
+	return fmt.Sprintf("/aws/eks/%s/cluster", eksClusterName)
+}
+
+// reconcileLogGroup creates and configures the CloudWatch Logs group of the control plane logs.
+// It runs before the cluster is created so that EKS uses the group instead of creating one with
+// logs that never expire.
+func (s *Service) reconcileLogGroup(ctx context.Context) error {
+	logging := s.scope.ControlPlane.Spec.Logging
+	if logging == nil || logging.Retention == nil {
+		return nil
+	}
+
+	name := logGroupName(s.scope.KubernetesClusterName())
+	retention := logging.Retention
+
+	logGroup, err := s.describeLogGroup(ctx, name)
+	if err != nil {
+		return err
+	}
+	if logGroup == nil {
+		return s.createLogGroup(ctx, name)
+	}
+
+	if aws.Int64Value(logGroup.RetentionInDays) != aws.Int64Value(retention.Days) {
+		if err := s.updateLogGroupRetention(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	if aws.StringValue(logGroup.KmsKeyId) != aws.StringValue(retention.KMSKeyARN) {
+		if err := s.updateLogGroupKMSKey(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return s.reconcileLogGroupTags(ctx, logGroup)
+}
+
+func (s *Service) describeLogGroup(ctx context.Context, name string) (*cloudwatchlogs.LogGroup, error) {
+	var logGroup *cloudwatchlogs.LogGroup
+	if err := s.CloudWatchLogsClient.DescribeLogGroupsPagesWithContext(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(name),
+	}, func(page *cloudwatchlogs.DescribeLogGroupsOutput, lastPage bool) bool {
+		for _, group := range page.LogGroups {
+			if aws.StringValue(group.LogGroupName) == name {
+				logGroup = group
+				return false
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe log group %s", name)
+	}
+
+	return logGroup, nil
+}
+
+func (s *Service) createLogGroup(ctx context.Context, name string) error {
+	retention := s.scope.ControlPlane.Spec.Logging.Retention
+
+	if _, err := s.CloudWatchLogsClient.CreateLogGroupWithContext(ctx, &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(name),
+		KmsKeyId:     retention.KMSKeyARN,
+		Tags:         aws.StringMap(ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags())),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSLogGroup", "Failed to create log group %s: %v", name, err)
+		return errors.Wrapf(err, "failed to create log group %s", name)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSLogGroup", "Created log group %s", name)
+
+	if retention.Days != nil {
+		return s.updateLogGroupRetention(ctx, name)
+	}
+
+	return nil
+}
+
+func (s *Service) updateLogGroupRetention(ctx context.Context, name string) error {
+	days := s.scope.ControlPlane.Spec.Logging.Retention.Days
+
+	var err error
+	if days == nil {
+		_, err = s.CloudWatchLogsClient.DeleteRetentionPolicyWithContext(ctx, &cloudwatchlogs.DeleteRetentionPolicyInput{
+			LogGroupName: aws.String(name),
+		})
+	} else {
+		_, err = s.CloudWatchLogsClient.PutRetentionPolicyWithContext(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(name),
+			RetentionInDays: days,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update retention of log group %s", name)
+	}
+	s.scope.Debug("Updated log group retention", "logGroup", name, "days", aws.Int64Value(days))
+
+	return nil
+}
+
+func (s *Service) updateLogGroupKMSKey(ctx context.Context, name string) error {
+	keyARN := s.scope.ControlPlane.Spec.Logging.Retention.KMSKeyARN
+
+	var err error
+	if keyARN == nil {
+		_, err = s.CloudWatchLogsClient.DisassociateKmsKeyWithContext(ctx, &cloudwatchlogs.DisassociateKmsKeyInput{
+			LogGroupName: aws.String(name),
+		})
+	} else {
+		_, err = s.CloudWatchLogsClient.AssociateKmsKeyWithContext(ctx, &cloudwatchlogs.AssociateKmsKeyInput{
+			LogGroupName: aws.String(name),
+			KmsKeyId:     keyARN,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update kms key of log group %s", name)
+	}
+	s.scope.Debug("Updated log group kms key", "logGroup", name, "kmsKeyARN", aws.StringValue(keyARN))
+
+	return nil
+}
+
+func (s *Service) reconcileLogGroupTags(ctx context.Context, logGroup *cloudwatchlogs.LogGroup) error {
+	out, err := s.CloudWatchLogsClient.ListTagsForResourceWithContext(ctx, &cloudwatchlogs.ListTagsForResourceInput{
+		ResourceArn: logGroup.LogGroupArn,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tags of log group %s", aws.StringValue(logGroup.LogGroupName))
+	}
+
+	missing := map[string]*string{}
+	for key, value := range ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags()) {
+		if aws.StringValue(out.Tags[key]) != value {
+			missing[key] = aws.String(value)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if _, err := s.CloudWatchLogsClient.TagResourceWithContext(ctx, &cloudwatchlogs.TagResourceInput{
+		ResourceArn: logGroup.LogGroupArn,
+		Tags:        missing,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to tag log group %s", aws.StringValue(logGroup.LogGroupName))
+	}
+
+	return nil
+}
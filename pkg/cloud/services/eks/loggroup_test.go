@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_cloudwatchlogsiface"
+)
+
+func TestReconcileLogGroup(t *testing.T) {
+	name := "/aws/eks/default.cluster/cluster"
+	arn := "arn:aws:logs:us-east-1:123456789012:log-group:/aws/eks/default.cluster/cluster"
+	keyARN := "arn:aws:kms:us-east-1:123456789012:key/1234"
+	ownedTags := map[string]*string{infrav1.ClusterAWSCloudProviderTagKey("default.cluster"): aws.String("owned")}
+
+	describeLogGroups := func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder, groups ...*cloudwatchlogs.LogGroup) {
+		m.DescribeLogGroupsPagesWithContext(gomock.Any(), &cloudwatchlogs.DescribeLogGroupsInput{LogGroupNamePrefix: aws.String(name)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, fn func(*cloudwatchlogs.DescribeLogGroupsOutput, bool) bool, _ ...request.Option) error {
+				fn(&cloudwatchlogs.DescribeLogGroupsOutput{LogGroups: groups}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name    string
+		logging *ekscontrolplanev1.ControlPlaneLoggingSpec
+		expect  func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder)
+	}{
+		{
+			name:    "log group is not managed without retention",
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{APIServer: true},
+			expect:  func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder) {},
+		},
+		{
+			name: "missing log group is created with its retention",
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				APIServer: true,
+				Retention: &ekscontrolplanev1.ControlPlaneLogRetentionSpec{
+					Days:      aws.Int64(30),
+					KMSKeyARN: aws.String(keyARN),
+				},
+			},
+			expect: func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder) {
+				describeLogGroups(m, &cloudwatchlogs.LogGroup{LogGroupName: aws.String(name + "-other")})
+				m.CreateLogGroupWithContext(gomock.Any(), &cloudwatchlogs.CreateLogGroupInput{
+					LogGroupName: aws.String(name),
+					KmsKeyId:     aws.String(keyARN),
+					Tags:         ownedTags,
+				}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+				m.PutRetentionPolicyWithContext(gomock.Any(), &cloudwatchlogs.PutRetentionPolicyInput{
+					LogGroupName:    aws.String(name),
+					RetentionInDays: aws.Int64(30),
+				}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+			},
+		},
+		{
+			name: "existing log group is updated",
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				Audit: true,
+				Retention: &ekscontrolplanev1.ControlPlaneLogRetentionSpec{
+					Days:      aws.Int64(90),
+					KMSKeyARN: aws.String(keyARN),
+				},
+			},
+			expect: func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder) {
+				describeLogGroups(m, &cloudwatchlogs.LogGroup{
+					LogGroupName:    aws.String(name),
+					LogGroupArn:     aws.String(arn),
+					RetentionInDays: aws.Int64(30),
+				})
+				m.PutRetentionPolicyWithContext(gomock.Any(), &cloudwatchlogs.PutRetentionPolicyInput{
+					LogGroupName:    aws.String(name),
+					RetentionInDays: aws.Int64(90),
+				}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+				m.AssociateKmsKeyWithContext(gomock.Any(), &cloudwatchlogs.AssociateKmsKeyInput{
+					LogGroupName: aws.String(name),
+					KmsKeyId:     aws.String(keyARN),
+				}).Return(&cloudwatchlogs.AssociateKmsKeyOutput{}, nil)
+				m.ListTagsForResourceWithContext(gomock.Any(), &cloudwatchlogs.ListTagsForResourceInput{
+					ResourceArn: aws.String(arn),
+				}).Return(&cloudwatchlogs.ListTagsForResourceOutput{}, nil)
+				m.TagResourceWithContext(gomock.Any(), &cloudwatchlogs.TagResourceInput{
+					ResourceArn: aws.String(arn),
+					Tags:        ownedTags,
+				}).Return(&cloudwatchlogs.TagResourceOutput{}, nil)
+			},
+		},
+		{
+			name: "retention is removed from an up to date log group",
+			logging: &ekscontrolplanev1.ControlPlaneLoggingSpec{
+				Audit:     true,
+				Retention: &ekscontrolplanev1.ControlPlaneLogRetentionSpec{},
+			},
+			expect: func(m *mock_cloudwatchlogsiface.MockCloudWatchLogsAPIMockRecorder) {
+				describeLogGroups(m, &cloudwatchlogs.LogGroup{
+					LogGroupName:    aws.String(name),
+					LogGroupArn:     aws.String(arn),
+					RetentionInDays: aws.Int64(30),
+				})
+				m.DeleteRetentionPolicyWithContext(gomock.Any(), &cloudwatchlogs.DeleteRetentionPolicyInput{
+					LogGroupName: aws.String(name),
+				}).Return(&cloudwatchlogs.DeleteRetentionPolicyOutput{}, nil)
+				m.ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+					Return(&cloudwatchlogs.ListTagsForResourceOutput{Tags: ownedTags}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			logsMock := mock_cloudwatchlogsiface.NewMockCloudWatchLogsAPI(mockControl)
+			tc.expect(logsMock.EXPECT())
+
+			s := NewService(newManagedControlPlaneTestScope(g, ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				Logging: tc.logging,
+			}))
+			s.CloudWatchLogsClient = logsMock
+
+			g.Expect(s.reconcileLogGroup(context.TODO())).To(Succeed())
+		})
+	}
+}
@@ -19,6 +19,8 @@ package eks
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -245,7 +247,7 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 	if managedPool.AWSLaunchTemplate != nil {
 		input.LaunchTemplate = &eks.LaunchTemplateSpecification{
 			Id:      s.scope.ManagedMachinePool.Status.LaunchTemplateID,
-			Version: s.scope.ManagedMachinePool.Status.LaunchTemplateVersion,
+			Version: s.scope.LaunchTemplateVersion(),
 		}
 	}
 
@@ -253,7 +255,7 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		return nil, errors.Wrap(err, "created invalid CreateNodegroupInput")
 	}
 
-	out, err := s.EKSClient.CreateNodegroup(input)
+	out, err := s.EKSClient.CreateNodegroupWithContext(context.TODO(), input, withNodeRepairConfig(nodeRepairConfigToSDK(managedPool.NodeRepairConfig)))
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -336,14 +338,11 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 	ngVersion := version.MustParseGeneric(*ng.Version)
 	specAMI := s.scope.ManagedMachinePool.Spec.AMIVersion
 	ngAMI := *ng.ReleaseVersion
-	statusLaunchTemplateVersion := s.scope.ManagedMachinePool.Status.LaunchTemplateVersion
-	var ngLaunchTemplateVersion *string
-	if ng.LaunchTemplate != nil {
-		ngLaunchTemplateVersion = ng.LaunchTemplate.Version
-	}
+	desiredLaunchTemplateVersion := s.scope.LaunchTemplateVersion()
+	launchTemplateChanged := s.launchTemplateChanged(ng)
 
 	eksClusterName := s.scope.KubernetesClusterName()
-	if (specVersion != nil && ngVersion.LessThan(specVersion)) || (specAMI != nil && *specAMI != ngAMI) || (statusLaunchTemplateVersion != nil && *statusLaunchTemplateVersion != *ngLaunchTemplateVersion) {
+	if (specVersion != nil && ngVersion.LessThan(specVersion)) || (specAMI != nil && *specAMI != ngAMI) || launchTemplateChanged {
 		input := &eks.UpdateNodegroupVersionInput{
 			ClusterName:   aws.String(eksClusterName),
 			NodegroupName: aws.String(s.scope.NodegroupName()),
@@ -352,12 +351,18 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 		var updateMsg string
 		// Either update k8s version or AMI version
 		switch {
-		case statusLaunchTemplateVersion != nil && *statusLaunchTemplateVersion != *ngLaunchTemplateVersion:
+		case launchTemplateChanged:
+			if s.launchTemplateDrifted(ng) {
+				s.scope.Info("Launch template of the nodegroup diverged from the desired one, reconciling it back",
+					"launchTemplateID", aws.StringValue(ng.LaunchTemplate.Id), "launchTemplateVersion", aws.StringValue(ng.LaunchTemplate.Version))
+				record.Warnf(s.scope.ManagedMachinePool, "LaunchTemplateDrift", "Launch template %s version %s of EKS nodegroup %s diverged from the desired one",
+					aws.StringValue(ng.LaunchTemplate.Id), aws.StringValue(ng.LaunchTemplate.Version), s.scope.NodegroupName())
+			}
 			input.LaunchTemplate = &eks.LaunchTemplateSpecification{
 				Id:      s.scope.ManagedMachinePool.Status.LaunchTemplateID,
-				Version: statusLaunchTemplateVersion,
+				Version: desiredLaunchTemplateVersion,
 			}
-			updateMsg = fmt.Sprintf("to launch template version %s", *statusLaunchTemplateVersion)
+			updateMsg = fmt.Sprintf("to launch template version %s", *desiredLaunchTemplateVersion)
 		case specVersion != nil && ngVersion.LessThan(specVersion):
 			// NOTE: you can only upgrade increments of minor versions. If you want to upgrade 1.14 to 1.16 we
 			// need to go 1.14-> 1.15 and then 1.15 -> 1.16.
@@ -385,6 +390,45 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 	return nil
 }
 
+// launchTemplateChanged returns true if the nodegroup doesn't use the desired version of the launch template of the
+// AWSManagedMachinePool.
+func (s *NodegroupService) launchTemplateChanged(ng *eks.Nodegroup) bool {
+	desiredVersion := s.scope.LaunchTemplateVersion()
+	// The launch template of a nodegroup created without one can't be set.
+	if desiredVersion == nil || ng.LaunchTemplate == nil {
+		return false
+	}
+	if desiredID := s.scope.ManagedMachinePool.Status.LaunchTemplateID; desiredID != nil && aws.StringValue(ng.LaunchTemplate.Id) != *desiredID {
+		return true
+	}
+	return aws.StringValue(ng.LaunchTemplate.Version) != *desiredVersion
+}
+
+// launchTemplateDrifted returns true if the launch template of the nodegroup was changed outside of the
+// AWSManagedMachinePool, e.g. in the console, to another launch template or to a version newer than the desired one,
+// as opposed to the nodegroup not having been updated to a new version of the launch template yet.
+func (s *NodegroupService) launchTemplateDrifted(ng *eks.Nodegroup) bool {
+	if !s.launchTemplateChanged(ng) {
+		return false
+	}
+	if desiredID := s.scope.ManagedMachinePool.Status.LaunchTemplateID; desiredID != nil && aws.StringValue(ng.LaunchTemplate.Id) != *desiredID {
+		return true
+	}
+	// A pinned version older than the one of the nodegroup rolls the nodegroup back.
+	if lt := s.scope.ManagedMachinePool.Spec.AWSLaunchTemplate; lt != nil && lt.PinnedVersion != nil && *lt.PinnedVersion != expinfrav1.LaunchTemplateLatestVersion {
+		return false
+	}
+	current, err := strconv.ParseInt(aws.StringValue(ng.LaunchTemplate.Version), 10, 64)
+	if err != nil {
+		return false
+	}
+	desired, err := strconv.ParseInt(aws.StringValue(s.scope.LaunchTemplateVersion()), 10, 64)
+	if err != nil {
+		return false
+	}
+	return current > desired
+}
+
 func createLabelUpdate(specLabels map[string]string, ng *eks.Nodegroup) *eks.UpdateLabelsPayload {
 	current := ng.Labels
 	payload := eks.UpdateLabelsPayload{
@@ -425,7 +469,8 @@ func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *
 	}
 	for _, currentTaint := range current {
 		ct := currentTaint.DeepCopy()
-		if !specTaints.Contains(ct) {
+		// A taint whose value changed is updated via AddOrUpdateTaints, removing it as well would be rejected.
+		if !specTaints.Contains(ct) && !taintKeyAndEffectIn(payload.AddOrUpdateTaints, ct) {
 			sdkTaint, err := converters.TaintToSDK(*ct)
 			if err != nil {
 				return nil, fmt.Errorf("converting taint to sdk: %w", err)
@@ -442,6 +487,19 @@ func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *
 	return nil, nil
 }
 
+func taintKeyAndEffectIn(taints []*eks.Taint, taint *expinfrav1.Taint) bool {
+	for _, t := range taints {
+		effect, err := converters.TaintEffectFromSDK(aws.StringValue(t.Effect))
+		if err != nil {
+			continue
+		}
+		if aws.StringValue(t.Key) == taint.Key && effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 	eksClusterName := s.scope.KubernetesClusterName()
 	s.Debug("reconciling node group config", "cluster", eksClusterName, "name", *ng.NodegroupName)
@@ -489,6 +547,18 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		input.UpdateConfig = s.updateConfig()
 		needsUpdate = true
 	}
+	var repairConfig *nodeRepairConfig
+	if desired := nodeRepairConfigToSDK(managedPool.NodeRepairConfig); desired != nil {
+		current, err := s.describeNodeRepairConfig()
+		if err != nil {
+			return err
+		}
+		if nodeRepairConfigNeedsUpdate(desired, current) {
+			s.Debug("Nodegroup node repair configuration differs from spec, updating it", "nodegroup", ng.NodegroupName)
+			repairConfig = desired
+			needsUpdate = true
+		}
+	}
 	if !needsUpdate {
 		s.Debug("node group config update not needed", "cluster", eksClusterName, "name", *ng.NodegroupName)
 		return nil
@@ -497,7 +567,7 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "created invalid UpdateNodegroupConfigInput")
 	}
 
-	_, err = s.EKSClient.UpdateNodegroupConfig(input)
+	_, err = s.EKSClient.UpdateNodegroupConfigWithContext(context.TODO(), input, withNodeRepairConfig(repairConfig))
 	if err != nil {
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
@@ -600,19 +670,23 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 		for _, asg := range ng.Resources.AutoScalingGroups {
 			req.AutoScalingGroupNames = append(req.AutoScalingGroupNames, asg.Name)
 		}
-		groups, err := s.AutoscalingClient.DescribeAutoScalingGroupsWithContext(context.TODO(), &req)
-		if err != nil {
-			return errors.Wrap(err, "failed to describe AutoScalingGroup for nodegroup")
-		}
-
 		var replicas int32
 		var providerIDList []string
-		for _, group := range groups.AutoScalingGroups {
-			replicas += int32(len(group.Instances))
-			for _, instance := range group.Instances {
-				providerIDList = append(providerIDList, fmt.Sprintf("aws:///%s/%s", *instance.AvailabilityZone, *instance.InstanceId))
+		err := s.AutoscalingClient.DescribeAutoScalingGroupsPagesWithContext(context.TODO(), &req, func(out *autoscaling.DescribeAutoScalingGroupsOutput, _ bool) bool {
+			for _, group := range out.AutoScalingGroups {
+				replicas += int32(len(group.Instances))
+				for _, instance := range group.Instances {
+					providerIDList = append(providerIDList, fmt.Sprintf("aws:///%s/%s", *instance.AvailabilityZone, *instance.InstanceId))
+				}
 			}
+			return true
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe AutoScalingGroup for nodegroup")
 		}
+
+		// keep the order of the provider IDs stable so that the list is only updated when the instances change.
+		sort.Strings(providerIDList)
 		managedPool.Spec.ProviderIDList = providerIDList
 		managedPool.Status.Replicas = replicas
 	}
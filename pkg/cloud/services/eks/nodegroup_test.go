@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+func TestCreateLabelUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		specLabels    map[string]string
+		currentLabels map[string]*string
+		expect        *eks.UpdateLabelsPayload
+	}{
+		{
+			name:          "no changes",
+			specLabels:    map[string]string{"a": "1"},
+			currentLabels: map[string]*string{"a": aws.String("1")},
+			expect:        nil,
+		},
+		{
+			name:          "added, changed and removed labels",
+			specLabels:    map[string]string{"a": "2", "b": "1"},
+			currentLabels: map[string]*string{"a": aws.String("1"), "c": aws.String("1")},
+			expect: &eks.UpdateLabelsPayload{
+				AddOrUpdateLabels: map[string]*string{"a": aws.String("2"), "b": aws.String("1")},
+				RemoveLabels:      []*string{aws.String("c")},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			payload := createLabelUpdate(tc.specLabels, &eks.Nodegroup{Labels: tc.currentLabels})
+			g.Expect(payload).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestCreateTaintsUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		specTaints    expinfrav1.Taints
+		currentTaints []*eks.Taint
+		expect        *eks.UpdateTaintsPayload
+	}{
+		{
+			name:          "no changes",
+			specTaints:    expinfrav1.Taints{{Key: "a", Value: "1", Effect: expinfrav1.TaintEffectNoSchedule}},
+			currentTaints: []*eks.Taint{{Key: aws.String("a"), Value: aws.String("1"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			expect:        nil,
+		},
+		{
+			name:          "changed value is only updated",
+			specTaints:    expinfrav1.Taints{{Key: "a", Value: "2", Effect: expinfrav1.TaintEffectNoSchedule}},
+			currentTaints: []*eks.Taint{{Key: aws.String("a"), Value: aws.String("1"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			expect: &eks.UpdateTaintsPayload{
+				AddOrUpdateTaints: []*eks.Taint{{Key: aws.String("a"), Value: aws.String("2"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			},
+		},
+		{
+			name:          "taint without value added outside of CAPA is removed",
+			specTaints:    nil,
+			currentTaints: []*eks.Taint{{Key: aws.String("a"), Effect: aws.String(eks.TaintEffectNoExecute)}},
+			expect: &eks.UpdateTaintsPayload{
+				RemoveTaints: []*eks.Taint{{Key: aws.String("a"), Value: aws.String(""), Effect: aws.String(eks.TaintEffectNoExecute)}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &NodegroupService{
+				IAMService: iam.IAMService{Wrapper: logger.NewLogger(klog.Background())},
+			}
+			payload, err := s.createTaintsUpdate(tc.specTaints, &eks.Nodegroup{
+				NodegroupName: aws.String("ng"),
+				Taints:        tc.currentTaints,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(payload).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestLaunchTemplateDrift(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pinnedVersion *string
+		nodegroupLT   *eks.LaunchTemplateSpecification
+		expectChanged bool
+		expectDrifted bool
+	}{
+		{
+			name: "nodegroup without launch template",
+		},
+		{
+			name:        "nodegroup uses the desired launch template version",
+			nodegroupLT: &eks.LaunchTemplateSpecification{Id: aws.String("lt-1"), Version: aws.String("2")},
+		},
+		{
+			name:          "nodegroup not updated to the new launch template version yet",
+			nodegroupLT:   &eks.LaunchTemplateSpecification{Id: aws.String("lt-1"), Version: aws.String("1")},
+			expectChanged: true,
+		},
+		{
+			name:          "nodegroup updated to a newer launch template version",
+			nodegroupLT:   &eks.LaunchTemplateSpecification{Id: aws.String("lt-1"), Version: aws.String("3")},
+			expectChanged: true,
+			expectDrifted: true,
+		},
+		{
+			name:          "nodegroup updated to another launch template",
+			nodegroupLT:   &eks.LaunchTemplateSpecification{Id: aws.String("lt-2"), Version: aws.String("2")},
+			expectChanged: true,
+			expectDrifted: true,
+		},
+		{
+			name:          "nodegroup rolled back to a pinned launch template version",
+			pinnedVersion: aws.String("1"),
+			nodegroupLT:   &eks.LaunchTemplateSpecification{Id: aws.String("lt-1"), Version: aws.String("2")},
+			expectChanged: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						Spec: expinfrav1.AWSManagedMachinePoolSpec{
+							AWSLaunchTemplate: &expinfrav1.AWSLaunchTemplate{PinnedVersion: tc.pinnedVersion},
+						},
+						Status: expinfrav1.AWSManagedMachinePoolStatus{
+							LaunchTemplateID:      aws.String("lt-1"),
+							LaunchTemplateVersion: aws.String("2"),
+						},
+					},
+				},
+			}
+			ng := &eks.Nodegroup{LaunchTemplate: tc.nodegroupLT}
+			g.Expect(s.launchTemplateChanged(ng)).To(Equal(tc.expectChanged))
+			g.Expect(s.launchTemplateDrifted(ng)).To(Equal(tc.expectDrifted))
+		})
+	}
+}
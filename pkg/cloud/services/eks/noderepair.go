@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// The node auto repair configuration of the nodegroups isn't modelled by the vendored AWS SDK, so it is added to the
+// bodies of the requests to the EKS API, and read from the ones of its responses, by request options.
+
+// nodeRepairConfigKey is the key of the node repair configuration in the JSON documents of the EKS API.
+const nodeRepairConfigKey = "nodeRepairConfig"
+
+// nodeRepairConfig is the node repair configuration of a nodegroup in the EKS API.
+type nodeRepairConfig struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// nodeRepairConfigToSDK converts the node repair configuration of an AWSManagedMachinePool to the EKS API one.
+func nodeRepairConfigToSDK(config *expinfrav1.NodeRepairConfig) *nodeRepairConfig {
+	if config == nil {
+		return nil
+	}
+	return &nodeRepairConfig{Enabled: config.Enabled}
+}
+
+// withNodeRepairConfig returns a request option adding the node repair configuration to the body of a
+// CreateNodegroup or UpdateNodegroupConfig request.
+func withNodeRepairConfig(config *nodeRepairConfig) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Build.PushBack(func(r *request.Request) {
+			if r.Error != nil || config == nil {
+				return
+			}
+
+			raw, err := io.ReadAll(r.GetBody())
+			if err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to read request body", err)
+				return
+			}
+			body := map[string]json.RawMessage{}
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &body); err != nil {
+					r.Error = awserr.New(request.ErrCodeSerialization, "failed to unmarshal request body", err)
+					return
+				}
+			}
+			if body[nodeRepairConfigKey], err = json.Marshal(config); err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to marshal node repair config", err)
+				return
+			}
+			if raw, err = json.Marshal(body); err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to marshal request body", err)
+				return
+			}
+			r.SetBufferBody(raw)
+		})
+	}
+}
+
+// readNodeRepairConfig returns a request option reading the node repair configuration of the nodegroup from the body
+// of a DescribeNodegroup response into config.
+func readNodeRepairConfig(config **nodeRepairConfig) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Unmarshal.PushFront(func(r *request.Request) {
+			raw, err := io.ReadAll(r.HTTPResponse.Body)
+			r.HTTPResponse.Body.Close()
+			if err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to read response body", err)
+				return
+			}
+			// Restore the body for the SDK to unmarshal the output.
+			r.HTTPResponse.Body = io.NopCloser(bytes.NewReader(raw))
+
+			out := struct {
+				Nodegroup *struct {
+					NodeRepairConfig *nodeRepairConfig `json:"nodeRepairConfig"`
+				} `json:"nodegroup"`
+			}{}
+			if err := json.Unmarshal(raw, &out); err == nil && out.Nodegroup != nil {
+				*config = out.Nodegroup.NodeRepairConfig
+			}
+		})
+	}
+}
+
+// describeNodeRepairConfig returns the node repair configuration of the nodegroup.
+func (s *NodegroupService) describeNodeRepairConfig() (*nodeRepairConfig, error) {
+	input := &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		NodegroupName: aws.String(s.scope.NodegroupName()),
+	}
+
+	var config *nodeRepairConfig
+	if _, err := s.EKSClient.DescribeNodegroupWithContext(context.TODO(), input, readNodeRepairConfig(&config)); err != nil {
+		return nil, errors.Wrap(err, "failed to describe nodegroup")
+	}
+	return config, nil
+}
+
+// nodeRepairConfigNeedsUpdate returns true if the node repair configuration of the nodegroup differs from the
+// desired one. The configuration of the nodegroup is left untouched when none is desired.
+func nodeRepairConfigNeedsUpdate(desired, current *nodeRepairConfig) bool {
+	if desired == nil || desired.Enabled == nil {
+		return false
+	}
+	return current == nil || aws.BoolValue(current.Enabled) != *desired.Enabled
+}
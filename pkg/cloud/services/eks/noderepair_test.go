@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+)
+
+func TestNodeRepairConfigRequestOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		requestBody = nil
+		_ = json.Unmarshal(raw, &requestBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodegroup":{"nodegroupName":"ng","nodeRepairConfig":{"enabled":true}}}`))
+	}))
+	defer server.Close()
+
+	client := eks.New(session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})))
+
+	_, err := client.CreateNodegroupWithContext(context.TODO(), &eks.CreateNodegroupInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("ng"),
+		NodeRole:      aws.String("role"),
+		Subnets:       []*string{aws.String("subnet-1")},
+	}, withNodeRepairConfig(&nodeRepairConfig{Enabled: aws.Bool(true)}))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requestBody).To(HaveKeyWithValue("nodeRepairConfig", map[string]interface{}{"enabled": true}))
+	g.Expect(requestBody).To(HaveKeyWithValue("nodeRole", "role"))
+
+	_, err = client.UpdateNodegroupConfigWithContext(context.TODO(), &eks.UpdateNodegroupConfigInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("ng"),
+	}, withNodeRepairConfig(nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requestBody).NotTo(HaveKey("nodeRepairConfig"))
+
+	var config *nodeRepairConfig
+	out, err := client.DescribeNodegroupWithContext(context.TODO(), &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("ng"),
+	}, readNodeRepairConfig(&config))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(aws.StringValue(out.Nodegroup.NodegroupName)).To(Equal("ng"))
+	g.Expect(config).To(Equal(&nodeRepairConfig{Enabled: aws.Bool(true)}))
+}
+
+func TestNodeRepairConfigNeedsUpdate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		desired *nodeRepairConfig
+		current *nodeRepairConfig
+		expect  bool
+	}{
+		{
+			name:    "no desired config",
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			expect:  false,
+		},
+		{
+			name:    "enabled without current config",
+			desired: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			expect:  true,
+		},
+		{
+			name:    "disabled without current config",
+			desired: &nodeRepairConfig{Enabled: aws.Bool(false)},
+			expect:  true,
+		},
+		{
+			name:    "same config",
+			desired: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			expect:  false,
+		},
+		{
+			name:    "disabled",
+			desired: &nodeRepairConfig{Enabled: aws.Bool(false)},
+			current: &nodeRepairConfig{Enabled: aws.Bool(true)},
+			expect:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(nodeRepairConfigNeedsUpdate(tc.desired, tc.current)).To(Equal(tc.expect))
+		})
+	}
+}
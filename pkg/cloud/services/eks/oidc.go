@@ -20,8 +20,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
 	tagConverter "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 )
 
@@ -55,12 +56,12 @@ func (s *Service) reconcileOIDCProvider(cluster *eks.Cluster) error {
 
 	s.scope.Info("Reconciling EKS OIDC Provider", "cluster-name", cluster.Name)
 
-	oidcProvider, err := s.FindAndVerifyOIDCProvider(cluster)
+	oidcProvider, err := s.FindAndVerifyOIDCProvider(aws.StringValue(cluster.Identity.Oidc.Issuer))
 	if err != nil {
 		return errors.Wrap(err, "failed to reconcile OIDC provider")
 	}
 	if oidcProvider == "" {
-		oidcProvider, err = s.CreateOIDCProvider(cluster)
+		oidcProvider, err = s.CreateOIDCProvider(aws.StringValue(cluster.Identity.Oidc.Issuer))
 		if err != nil {
 			return errors.Wrap(err, "failed to create OIDC provider")
 		}
@@ -161,25 +162,5 @@ func (s *Service) deleteOIDCProvider() error {
 }
 
 func (s *Service) buildOIDCTrustPolicy() iamv1.PolicyDocument {
-	providerARN := s.scope.ControlPlane.Status.OIDCProvider.ARN
-	conditionValue := providerARN[strings.Index(providerARN, "/")+1:] + ":sub"
-
-	return iamv1.PolicyDocument{
-		Version: "2012-10-17",
-		Statement: iamv1.Statements{
-			iamv1.StatementEntry{
-				Sid:    "",
-				Effect: "Allow",
-				Principal: iamv1.Principals{
-					iamv1.PrincipalFederated: iamv1.PrincipalID{providerARN},
-				},
-				Action: iamv1.Actions{"sts:AssumeRoleWithWebIdentity"},
-				Condition: iamv1.Conditions{
-					"ForAnyValue:StringLike": map[string][]string{
-						conditionValue: {"system:serviceaccount:${SERVICE_ACCOUNT_NAMESPACE}:${SERVICE_ACCOUNT_NAME}"},
-					},
-				},
-			},
-		},
-	}
+	return eksiam.OIDCTrustPolicy(s.scope.ControlPlane.Status.OIDCProvider.ARN)
 }
@@ -138,6 +138,7 @@ func TestOIDCReconcile(t *testing.T) {
 					Namespace: "ns",
 				},
 				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					Region:                "us-east-1",
 					Version:               aws.String("1.25"),
 					AssociateOIDCProvider: true,
 				},
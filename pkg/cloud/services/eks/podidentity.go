@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+func (s *Service) reconcilePodIdentityAssociations(ctx context.Context) error {
+	s.scope.Debug("Reconciling EKS pod identity associations")
+
+	eksClusterName := s.scope.KubernetesClusterName()
+	current, err := s.getPodIdentityAssociations(ctx, eksClusterName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get pod identity associations")
+	}
+
+	desired := map[string]bool{}
+	for _, association := range s.scope.ControlPlane.Spec.PodIdentityAssociations {
+		key := podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)
+		desired[key] = true
+
+		existing, ok := current[key]
+		if !ok {
+			if err := s.createPodIdentityAssociation(ctx, eksClusterName, association); err != nil {
+				return err
+			}
+			continue
+		}
+		if aws.StringValue(existing.RoleArn) != association.RoleARN {
+			if err := s.updatePodIdentityAssociation(ctx, eksClusterName, association, existing); err != nil {
+				return err
+			}
+		}
+	}
+
+	ownedTagKey := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Cluster.Name)
+	for key, existing := range current {
+		if desired[key] {
+			continue
+		}
+		// Only delete associations created by CAPA.
+		if aws.StringValue(existing.Tags[ownedTagKey]) != string(infrav1.ResourceLifecycleOwned) {
+			continue
+		}
+		if err := s.deletePodIdentityAssociation(ctx, eksClusterName, existing); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func podIdentityAssociationKey(namespace, serviceAccount string) string {
+	return namespace + "/" + serviceAccount
+}
+
+func (s *Service) getPodIdentityAssociations(ctx context.Context, eksClusterName string) (map[string]*eks.PodIdentityAssociation, error) {
+	var summaries []*eks.PodIdentityAssociationSummary
+	if err := s.EKSClient.ListPodIdentityAssociationsPagesWithContext(ctx, &eks.ListPodIdentityAssociationsInput{
+		ClusterName: aws.String(eksClusterName),
+	}, func(page *eks.ListPodIdentityAssociationsOutput, lastPage bool) bool {
+		summaries = append(summaries, page.Associations...)
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list pod identity associations")
+	}
+
+	associations := map[string]*eks.PodIdentityAssociation{}
+	for _, summary := range summaries {
+		out, err := s.EKSClient.DescribePodIdentityAssociationWithContext(ctx, &eks.DescribePodIdentityAssociationInput{
+			ClusterName:   aws.String(eksClusterName),
+			AssociationId: summary.AssociationId,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe pod identity association %s", aws.StringValue(summary.AssociationId))
+		}
+		key := podIdentityAssociationKey(aws.StringValue(summary.Namespace), aws.StringValue(summary.ServiceAccount))
+		associations[key] = out.Association
+	}
+
+	return associations, nil
+}
+
+func (s *Service) createPodIdentityAssociation(ctx context.Context, eksClusterName string, association ekscontrolplanev1.PodIdentityAssociation) error {
+	key := podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)
+	if _, err := s.EKSClient.CreatePodIdentityAssociationWithContext(ctx, &eks.CreatePodIdentityAssociationInput{
+		ClusterName:    aws.String(eksClusterName),
+		Namespace:      aws.String(association.ServiceAccountNamespace),
+		ServiceAccount: aws.String(association.ServiceAccountName),
+		RoleArn:        aws.String(association.RoleARN),
+		Tags:           aws.StringMap(ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags())),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSPodIdentityAssociation", "Failed to create pod identity association for %s: %v", key, err)
+		return errors.Wrapf(err, "failed to create pod identity association for %s", key)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSPodIdentityAssociation", "Created pod identity association for %s", key)
+
+	return nil
+}
+
+func (s *Service) updatePodIdentityAssociation(ctx context.Context, eksClusterName string, association ekscontrolplanev1.PodIdentityAssociation, existing *eks.PodIdentityAssociation) error {
+	key := podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)
+	if _, err := s.EKSClient.UpdatePodIdentityAssociationWithContext(ctx, &eks.UpdatePodIdentityAssociationInput{
+		ClusterName:   aws.String(eksClusterName),
+		AssociationId: existing.AssociationId,
+		RoleArn:       aws.String(association.RoleARN),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSPodIdentityAssociation", "Failed to update pod identity association for %s: %v", key, err)
+		return errors.Wrapf(err, "failed to update pod identity association for %s", key)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSPodIdentityAssociation", "Updated pod identity association for %s", key)
+
+	return nil
+}
+
+func (s *Service) deletePodIdentityAssociation(ctx context.Context, eksClusterName string, existing *eks.PodIdentityAssociation) error {
+	key := podIdentityAssociationKey(aws.StringValue(existing.Namespace), aws.StringValue(existing.ServiceAccount))
+	if _, err := s.EKSClient.DeletePodIdentityAssociationWithContext(ctx, &eks.DeletePodIdentityAssociationInput{
+		ClusterName:   aws.String(eksClusterName),
+		AssociationId: existing.AssociationId,
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedDeleteEKSPodIdentityAssociation", "Failed to delete pod identity association for %s: %v", key, err)
+		return errors.Wrapf(err, "failed to delete pod identity association for %s", key)
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSPodIdentityAssociation", "Deleted pod identity association for %s", key)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+)
+
+func TestReconcilePodIdentityAssociations(t *testing.T) {
+	clusterName := "default.cluster"
+	roleARN := "arn:aws:iam::123456789012:role/app"
+	ownedTags := map[string]*string{infrav1.ClusterAWSCloudProviderTagKey(clusterName): aws.String("owned")}
+
+	listAssociations := func(m *mock_eksiface.MockEKSAPIMockRecorder, associations ...*eks.PodIdentityAssociation) {
+		summaries := []*eks.PodIdentityAssociationSummary{}
+		for _, association := range associations {
+			summaries = append(summaries, &eks.PodIdentityAssociationSummary{
+				AssociationId:  association.AssociationId,
+				Namespace:      association.Namespace,
+				ServiceAccount: association.ServiceAccount,
+			})
+		}
+		m.ListPodIdentityAssociationsPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListPodIdentityAssociationsInput, fn func(*eks.ListPodIdentityAssociationsOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListPodIdentityAssociationsOutput{Associations: summaries}, true)
+				return nil
+			})
+		for _, association := range associations {
+			m.DescribePodIdentityAssociationWithContext(gomock.Any(), &eks.DescribePodIdentityAssociationInput{
+				ClusterName:   aws.String(clusterName),
+				AssociationId: association.AssociationId,
+			}).Return(&eks.DescribePodIdentityAssociationOutput{Association: association}, nil)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		associations []ekscontrolplanev1.PodIdentityAssociation
+		expect       func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name: "missing association is created",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{
+				{ServiceAccountNamespace: "default", ServiceAccountName: "app", RoleARN: roleARN},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m)
+				m.CreatePodIdentityAssociationWithContext(gomock.Any(), &eks.CreatePodIdentityAssociationInput{
+					ClusterName:    aws.String(clusterName),
+					Namespace:      aws.String("default"),
+					ServiceAccount: aws.String("app"),
+					RoleArn:        aws.String(roleARN),
+					Tags:           ownedTags,
+				}).Return(&eks.CreatePodIdentityAssociationOutput{}, nil)
+			},
+		},
+		{
+			name: "association with a different role is updated",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{
+				{ServiceAccountNamespace: "default", ServiceAccountName: "app", RoleARN: roleARN},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, &eks.PodIdentityAssociation{
+					AssociationId:  aws.String("a-1"),
+					Namespace:      aws.String("default"),
+					ServiceAccount: aws.String("app"),
+					RoleArn:        aws.String("arn:aws:iam::123456789012:role/old"),
+					Tags:           ownedTags,
+				})
+				m.UpdatePodIdentityAssociationWithContext(gomock.Any(), &eks.UpdatePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+					RoleArn:       aws.String(roleARN),
+				}).Return(&eks.UpdatePodIdentityAssociationOutput{}, nil)
+			},
+		},
+		{
+			name:         "only owned associations are deleted",
+			associations: nil,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, &eks.PodIdentityAssociation{
+					AssociationId:  aws.String("a-1"),
+					Namespace:      aws.String("default"),
+					ServiceAccount: aws.String("app"),
+					RoleArn:        aws.String(roleARN),
+					Tags:           ownedTags,
+				}, &eks.PodIdentityAssociation{
+					AssociationId:  aws.String("a-2"),
+					Namespace:      aws.String("kube-system"),
+					ServiceAccount: aws.String("other"),
+					RoleArn:        aws.String(roleARN),
+				})
+				m.DeletePodIdentityAssociationWithContext(gomock.Any(), &eks.DeletePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+				}).Return(&eks.DeletePodIdentityAssociationOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			tc.expect(eksMock.EXPECT())
+
+			s := NewService(newManagedControlPlaneTestScope(g, ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				PodIdentityAssociations: tc.associations,
+			}))
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcilePodIdentityAssociations(context.TODO())).To(Succeed())
+		})
+	}
+}
@@ -18,14 +18,12 @@ package eks
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
 
-	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/api/bootstrap/v1beta1"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
@@ -38,36 +36,25 @@ const (
 	maxIAMRoleNameLength = 64
 )
 
-// NodegroupRolePolicies gives the policies required for a nodegroup role.
-func NodegroupRolePolicies() []string {
+// NodegroupRolePolicies gives the policies required for a nodegroup role in a partition.
+func NodegroupRolePolicies(partition string) []string {
 	return []string{
-		"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
-		"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy", //TODO: Can remove when CAPA supports provisioning of OIDC web identity federation with service account token volume projection
-		"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+		awsManagedPolicyARN(partition, "AmazonEKSWorkerNodePolicy"),
+		awsManagedPolicyARN(partition, "AmazonEKS_CNI_Policy"), //TODO: Can remove when CAPA supports provisioning of OIDC web identity federation with service account token volume projection
+		awsManagedPolicyARN(partition, "AmazonEC2ContainerRegistryReadOnly"),
 	}
 }
 
-// FargateRolePolicies gives the policies required for a fargate role.
-func FargateRolePolicies() []string {
+// FargateRolePolicies gives the policies required for a fargate role in a partition.
+func FargateRolePolicies(partition string) []string {
 	return []string{
-		"arn:aws:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy",
+		awsManagedPolicyARN(partition, "AmazonEKSFargatePodExecutionRolePolicy"),
 	}
 }
 
-// NodegroupRolePoliciesUSGov gives the policies required for a nodegroup role.
-func NodegroupRolePoliciesUSGov() []string {
-	return []string{
-		"arn:aws-us-gov:iam::aws:policy/AmazonEKSWorkerNodePolicy",
-		"arn:aws-us-gov:iam::aws:policy/AmazonEKS_CNI_Policy", //TODO: Can remove when CAPA supports provisioning of OIDC web identity federation with service account token volume projection
-		"arn:aws-us-gov:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
-	}
-}
-
-// FargateRolePoliciesUSGov gives the policies required for a fargate role.
-func FargateRolePoliciesUSGov() []string {
-	return []string{
-		"arn:aws-us-gov:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy",
-	}
+// awsManagedPolicyARN returns the ARN of an AWS managed policy in a partition.
+func awsManagedPolicyARN(partition, name string) string {
+	return fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, name)
 }
 
 func (s *Service) reconcileControlPlaneIAMRole() error {
@@ -95,7 +82,11 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 			return fmt.Errorf("getting role %s: %w", *s.scope.ControlPlane.Spec.RoleName, ErrClusterRoleNotFound)
 		}
 
-		role, err = s.CreateRole(*s.scope.ControlPlane.Spec.RoleName, s.scope.Name(), eksiam.ControlPlaneTrustRelationship(false), s.scope.AdditionalTags())
+		trustRelationship := eksiam.ControlPlaneTrustRelationship(false)
+		if s.scope.ControlPlane.Spec.OutpostConfig != nil {
+			trustRelationship = eksiam.LocalClusterTrustRelationship()
+		}
+		role, err = s.CreateRole(*s.scope.ControlPlane.Spec.RoleName, s.scope.Name(), trustRelationship, s.scope.AdditionalTags())
 		if err != nil {
 			record.Warnf(s.scope.ControlPlane, "FailedIAMRoleCreation", "Failed to create control plane IAM role %q: %v", *s.scope.ControlPlane.Spec.RoleName, err)
 
@@ -111,8 +102,12 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 
 	//TODO: check tags and trust relationship to see if they need updating
 
+	clusterPolicy := "AmazonEKSClusterPolicy"
+	if s.scope.ControlPlane.Spec.OutpostConfig != nil {
+		clusterPolicy = "AmazonEKSLocalOutpostClusterPolicy"
+	}
 	policies := []*string{
-		aws.String(fmt.Sprintf("arn:%s:iam::aws:policy/AmazonEKSClusterPolicy", s.scope.Partition())),
+		aws.String(awsManagedPolicyARN(s.scope.Partition(), clusterPolicy)),
 	}
 
 	if s.scope.ControlPlane.Spec.RoleAdditionalPolicies != nil {
@@ -222,10 +217,7 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		return errors.Wrapf(err, "error ensuring tags and policy document are set on node role")
 	}
 
-	policies := NodegroupRolePolicies()
-	if strings.Contains(s.scope.Partition(), v1beta1.PartitionNameUSGov) {
-		policies = NodegroupRolePoliciesUSGov()
-	}
+	policies := NodegroupRolePolicies(s.scope.Partition())
 
 	if len(s.scope.ManagedMachinePool.Spec.RoleAdditionalPolicies) > 0 {
 		if !s.scope.AllowAdditionalRoles() {
@@ -342,10 +334,7 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 		return updatedRole, errors.Wrapf(err, "error ensuring tags and policy document are set on fargate role")
 	}
 
-	policies := FargateRolePolicies()
-	if strings.Contains(s.scope.Partition(), v1beta1.PartitionNameUSGov) {
-		policies = FargateRolePoliciesUSGov()
-	}
+	policies := FargateRolePolicies(s.scope.Partition())
 
 	updatedPolicies, err := s.EnsurePoliciesAttached(role, aws.StringSlice(policies))
 	if err != nil {
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRolePolicies(t *testing.T) {
+	testCases := []struct {
+		partition         string
+		expectedNodegroup []string
+		expectedFargate   []string
+	}{
+		{
+			partition: "aws",
+			expectedNodegroup: []string{
+				"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy",
+				"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+			},
+			expectedFargate: []string{"arn:aws:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy"},
+		},
+		{
+			partition: "aws-us-gov",
+			expectedNodegroup: []string{
+				"arn:aws-us-gov:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws-us-gov:iam::aws:policy/AmazonEKS_CNI_Policy",
+				"arn:aws-us-gov:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+			},
+			expectedFargate: []string{"arn:aws-us-gov:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy"},
+		},
+		{
+			partition: "aws-cn",
+			expectedNodegroup: []string{
+				"arn:aws-cn:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws-cn:iam::aws:policy/AmazonEKS_CNI_Policy",
+				"arn:aws-cn:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+			},
+			expectedFargate: []string{"arn:aws-cn:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.partition, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(NodegroupRolePolicies(tc.partition)).To(Equal(tc.expectedNodegroup))
+			g.Expect(FargateRolePolicies(tc.partition)).To(Equal(tc.expectedFargate))
+		})
+	}
+}
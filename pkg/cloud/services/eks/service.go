@@ -21,6 +21,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
@@ -49,7 +50,8 @@ type Service struct {
 	EC2Client ec2iface.EC2API
 	EKSClient EKSAPI
 	iam.IAMService
-	STSClient stsiface.STSAPI
+	STSClient            stsiface.STSAPI
+	CloudWatchLogsClient cloudwatchlogsiface.CloudWatchLogsAPI
 }
 
 // ServiceOpts defines the functional arguments for the service.
@@ -75,7 +77,8 @@ func NewService(controlPlaneScope *scope.ManagedControlPlaneScope, opts ...Servi
 			IAMClient: scope.NewIAMClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
 			Client:    http.DefaultClient,
 		},
-		STSClient: scope.NewSTSClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+		STSClient:            scope.NewSTSClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
+		CloudWatchLogsClient: scope.NewCloudWatchLogsClient(controlPlaneScope, controlPlaneScope, controlPlaneScope, controlPlaneScope.ControlPlane),
 	}
 
 	for _, opt := range opts {
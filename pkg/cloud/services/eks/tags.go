@@ -29,6 +29,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	asgsvc "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 )
 
@@ -42,7 +43,7 @@ func (s *Service) reconcileTags(cluster *eks.Cluster) error {
 	clusterTags := converters.MapPtrToMap(cluster.Tags)
 	buildParams := s.getEKSTagParams(*cluster.Arn)
 	tagsBuilder := tags.New(buildParams, tags.WithEKS(s.EKSClient))
-	if err := tagsBuilder.Ensure(clusterTags); err != nil {
+	if err := tagsBuilder.Ensure(context.TODO(), clusterTags); err != nil {
 		return fmt.Errorf("failed ensuring tags on cluster: %w", err)
 	}
 
@@ -130,7 +131,16 @@ func (s *NodegroupService) reconcileASGTags(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "failed to describe ASG for nodegroup")
 	}
 
-	tagsToDelete, tagsToAdd := getASGTagUpdates(s.scope.ClusterName(), tagDescriptionsToMap(asg.Tags), s.scope.AdditionalTags())
+	managedPool := s.scope.ManagedMachinePool.Spec
+	desiredTags := s.scope.AdditionalTags()
+	// the additional tags take precedence over the cluster-autoscaler ones.
+	for k, v := range asgsvc.ClusterAutoscalerTags(s.scope.ClusterName(), managedPool.ClusterAutoscaler, managedPool.Labels, managedPool.Taints) {
+		if _, ok := desiredTags[k]; !ok {
+			desiredTags[k] = v
+		}
+	}
+
+	tagsToDelete, tagsToAdd := getASGTagUpdates(s.scope.ClusterName(), tagDescriptionsToMap(asg.Tags), desiredTags)
 	s.scope.Debug("Tags", "tagsToAdd", tagsToAdd, "tagsToDelete", tagsToDelete)
 
 	if len(tagsToAdd) > 0 {
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// checkVersionUpdate runs the checks of the version update policy before the control plane is
+// updated to nextVersion and reports the result in the EKSControlPlaneVersionUpdateReady condition.
+// It returns true if the update can start.
+func (s *Service) checkVersionUpdate(ctx context.Context, cluster *eks.Cluster, nextVersion string) (bool, error) {
+	policy := s.scope.ControlPlane.Spec.VersionUpdatePolicy
+	if policy == nil {
+		return true, nil
+	}
+
+	blocked := func(reason, format string, args ...interface{}) (bool, error) {
+		message := fmt.Sprintf(format, args...)
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition, reason, clusterv1.ConditionSeverityWarning, message)
+		record.Warnf(s.scope.ControlPlane, "BlockedUpdateEKSControlPlane", "Update of EKS control plane to version %s blocked: %s", nextVersion, message)
+		return false, nil
+	}
+	failed := func(err error) (bool, error) {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition, ekscontrolplanev1.VersionUpdateCheckFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return false, err
+	}
+
+	if policy.CheckNodegroupVersions {
+		nodegroups, err := s.nodegroupsNotUpdated(ctx, aws.StringValue(cluster.Version))
+		if err != nil {
+			return failed(err)
+		}
+		if len(nodegroups) > 0 {
+			return blocked(ekscontrolplanev1.NodegroupVersionsNotUpdatedReason, "nodegroups %s don't run version %s", strings.Join(nodegroups, ", "), aws.StringValue(cluster.Version))
+		}
+	}
+
+	if policy.CheckAddonCompatibility {
+		addons, err := s.addonsIncompatibleWith(ctx, nextVersion)
+		if err != nil {
+			return failed(err)
+		}
+		if len(addons) > 0 {
+			return blocked(ekscontrolplanev1.IncompatibleAddonsReason, "addons %s aren't compatible with version %s", strings.Join(addons, ", "), nextVersion)
+		}
+	}
+
+	if policy.CheckPodDisruptionBudgets {
+		remoteClient, err := s.scope.RemoteClient()
+		if err != nil {
+			return failed(errors.Wrap(err, "failed to create remote cluster client"))
+		}
+		budgets, err := podDisruptionBudgetsNotReady(ctx, remoteClient)
+		if err != nil {
+			return failed(err)
+		}
+		if len(budgets) > 0 {
+			return blocked(ekscontrolplanev1.PodDisruptionBudgetsNotReadyReason, "pod disruption budgets %s don't allow any disruption", strings.Join(budgets, ", "))
+		}
+	}
+
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition)
+	return true, nil
+}
+
+// nodegroupsNotUpdated returns the names of the nodegroups of the cluster running a version older
+// than the control plane version.
+func (s *Service) nodegroupsNotUpdated(ctx context.Context, clusterVersion string) ([]string, error) {
+	eksClusterName := s.scope.KubernetesClusterName()
+	controlPlaneVersion, err := version.ParseGeneric(clusterVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse control plane version %s", clusterVersion)
+	}
+
+	var names []*string
+	if err := s.EKSClient.ListNodegroupsPagesWithContext(ctx, &eks.ListNodegroupsInput{
+		ClusterName: aws.String(eksClusterName),
+	}, func(page *eks.ListNodegroupsOutput, lastPage bool) bool {
+		names = append(names, page.Nodegroups...)
+		return !lastPage
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list nodegroups")
+	}
+
+	notUpdated := []string{}
+	for _, name := range names {
+		out, err := s.EKSClient.DescribeNodegroupWithContext(ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(eksClusterName),
+			NodegroupName: name,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe nodegroup %s", aws.StringValue(name))
+		}
+		if out.Nodegroup == nil || out.Nodegroup.Version == nil {
+			continue
+		}
+		nodegroupVersion, err := version.ParseGeneric(*out.Nodegroup.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse version of nodegroup %s", aws.StringValue(name))
+		}
+		if nodegroupVersion.WithPatch(0).LessThan(controlPlaneVersion.WithPatch(0)) {
+			notUpdated = append(notUpdated, aws.StringValue(name))
+		}
+	}
+
+	return notUpdated, nil
+}
+
+// addonsIncompatibleWith returns the names of the installed addons whose version isn't compatible
+// with the given Kubernetes version.
+func (s *Service) addonsIncompatibleWith(ctx context.Context, kubernetesVersion string) ([]string, error) {
+	eksClusterName := s.scope.KubernetesClusterName()
+
+	addonNames, err := s.listAddons(eksClusterName)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := s.getClusterAddonsInstalled(eksClusterName, addonNames)
+	if err != nil {
+		return nil, err
+	}
+
+	incompatible := []string{}
+	for _, addon := range installed {
+		compatible := false
+		if err := s.EKSClient.DescribeAddonVersionsPagesWithContext(ctx, &eks.DescribeAddonVersionsInput{
+			AddonName:         addon.Name,
+			KubernetesVersion: aws.String(kubernetesVersion),
+		}, func(page *eks.DescribeAddonVersionsOutput, lastPage bool) bool {
+			for _, info := range page.Addons {
+				for _, addonVersion := range info.AddonVersions {
+					if aws.StringValue(addonVersion.AddonVersion) == aws.StringValue(addon.Version) {
+						compatible = true
+						return false
+					}
+				}
+			}
+			return !lastPage
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to describe versions of addon %s", aws.StringValue(addon.Name))
+		}
+		if !compatible {
+			incompatible = append(incompatible, aws.StringValue(addon.Name))
+		}
+	}
+
+	return incompatible, nil
+}
+
+// podDisruptionBudgetsNotReady returns the PodDisruptionBudgets of the workload cluster that
+// currently don't allow any disruption of the pods they select.
+func podDisruptionBudgetsNotReady(ctx context.Context, c client.Client) ([]string, error) {
+	budgets := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(ctx, budgets); err != nil {
+		return nil, errors.Wrap(err, "failed to list pod disruption budgets")
+	}
+
+	notReady := []string{}
+	for _, budget := range budgets.Items {
+		if budget.Status.ExpectedPods > 0 && budget.Status.DisruptionsAllowed == 0 {
+			notReady = append(notReady, client.ObjectKeyFromObject(&budget).String())
+		}
+	}
+
+	return notReady, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestCheckVersionUpdate(t *testing.T) {
+	listNodegroups := func(m *mock_eksiface.MockEKSAPIMockRecorder, versions map[string]string) {
+		names := []*string{}
+		for name := range versions {
+			names = append(names, aws.String(name))
+		}
+		m.ListNodegroupsPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListNodegroupsInput, fn func(*eks.ListNodegroupsOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListNodegroupsOutput{Nodegroups: names}, true)
+				return nil
+			})
+		for name, version := range versions {
+			m.DescribeNodegroupWithContext(gomock.Any(), &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String("default.cluster"),
+				NodegroupName: aws.String(name),
+			}).Return(&eks.DescribeNodegroupOutput{
+				Nodegroup: &eks.Nodegroup{NodegroupName: aws.String(name), Version: aws.String(version)},
+			}, nil)
+		}
+	}
+	installedAddon := func(m *mock_eksiface.MockEKSAPIMockRecorder, name, version string, compatibleVersions ...string) {
+		m.ListAddons(gomock.Any()).Return(&eks.ListAddonsOutput{Addons: []*string{aws.String(name)}}, nil)
+		m.DescribeAddon(gomock.Any()).Return(&eks.DescribeAddonOutput{
+			Addon: &eks.Addon{AddonName: aws.String(name), AddonVersion: aws.String(version)},
+		}, nil)
+		addonVersions := []*eks.AddonVersionInfo{}
+		for _, v := range compatibleVersions {
+			addonVersions = append(addonVersions, &eks.AddonVersionInfo{AddonVersion: aws.String(v)})
+		}
+		m.DescribeAddonVersionsPagesWithContext(gomock.Any(), &eks.DescribeAddonVersionsInput{
+			AddonName:         aws.String(name),
+			KubernetesVersion: aws.String("1.28"),
+		}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.DescribeAddonVersionsInput, fn func(*eks.DescribeAddonVersionsOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.DescribeAddonVersionsOutput{
+					Addons: []*eks.AddonInfo{{AddonName: aws.String(name), AddonVersions: addonVersions}},
+				}, true)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name         string
+		policy       *ekscontrolplanev1.VersionUpdatePolicy
+		expect       func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectReady  bool
+		expectReason string
+	}{
+		{
+			name:        "no policy",
+			policy:      nil,
+			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectReady: true,
+		},
+		{
+			name:   "nodegroup running an older version blocks the update",
+			policy: &ekscontrolplanev1.VersionUpdatePolicy{CheckNodegroupVersions: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listNodegroups(m, map[string]string{"ng-1": "1.26"})
+			},
+			expectReady:  false,
+			expectReason: ekscontrolplanev1.NodegroupVersionsNotUpdatedReason,
+		},
+		{
+			name:   "incompatible addon blocks the update",
+			policy: &ekscontrolplanev1.VersionUpdatePolicy{CheckNodegroupVersions: true, CheckAddonCompatibility: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listNodegroups(m, map[string]string{"ng-1": "1.27"})
+				installedAddon(m, "vpc-cni", "v1.12.0-eksbuild.1", "v1.15.0-eksbuild.1")
+			},
+			expectReady:  false,
+			expectReason: ekscontrolplanev1.IncompatibleAddonsReason,
+		},
+		{
+			name:   "update starts when the checks pass",
+			policy: &ekscontrolplanev1.VersionUpdatePolicy{CheckNodegroupVersions: true, CheckAddonCompatibility: true},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listNodegroups(m, map[string]string{"ng-1": "1.27"})
+				installedAddon(m, "vpc-cni", "v1.15.0-eksbuild.1", "v1.14.0-eksbuild.1", "v1.15.0-eksbuild.1")
+			},
+			expectReady: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			tc.expect(eksMock.EXPECT())
+
+			s := NewService(newManagedControlPlaneTestScope(g, ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				VersionUpdatePolicy: tc.policy,
+			}))
+			s.EKSClient = eksMock
+
+			ready, err := s.checkVersionUpdate(context.TODO(), &eks.Cluster{Version: aws.String("1.27")}, "1.28")
+			g.Expect(err).To(BeNil())
+			g.Expect(ready).To(Equal(tc.expectReady))
+			if tc.policy == nil {
+				g.Expect(conditions.Has(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.IsTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition)).To(Equal(tc.expectReady))
+			if !tc.expectReady {
+				g.Expect(conditions.GetReason(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneVersionUpdateReadyCondition)).To(Equal(tc.expectReason))
+			}
+		})
+	}
+}
+
+func TestPodDisruptionBudgetsNotReady(t *testing.T) {
+	g := NewWithT(t)
+
+	budget := func(name string, expectedPods, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				ExpectedPods:       expectedPods,
+				DisruptionsAllowed: disruptionsAllowed,
+			},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	_ = policyv1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		budget("ready", 3, 1),
+		budget("blocking", 2, 0),
+		budget("no-pods", 0, 0),
+	).Build()
+
+	notReady, err := podDisruptionBudgetsNotReady(context.TODO(), c)
+	g.Expect(err).To(BeNil())
+	g.Expect(notReady).To(ConsistOf("default/blocking"))
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	// errCodeNoSuchBucketPolicy is the error code returned by S3 when a bucket has no policy.
+	errCodeNoSuchBucketPolicy = "NoSuchBucketPolicy"
+	// errCodeAccessDenied is the error code returned by S3 when the policy of a bucket can't be read.
+	errCodeAccessDenied = "AccessDenied"
+)
+
+// accessLogsDeliveryServices are the service principals delivering the access logs of the load balancers.
+// In the regions available before August 2022, the access logs are delivered by a regional AWS account instead.
+var accessLogsDeliveryServices = []string{
+	"logdelivery.elasticloadbalancing.amazonaws.com",
+	"delivery.logs.amazonaws.com",
+}
+
+// normalizeAccessLogs returns the access logs configuration as it is compared with the one of a load balancer:
+// the bucket and the prefix are irrelevant when the access logs are disabled.
+func normalizeAccessLogs(accessLogs *infrav1.LoadBalancerAccessLogs) *infrav1.LoadBalancerAccessLogs {
+	if accessLogs == nil {
+		return nil
+	}
+	if !accessLogs.Enabled {
+		return &infrav1.LoadBalancerAccessLogs{}
+	}
+	return accessLogs.DeepCopy()
+}
+
+// accessLogsAttributes returns the attributes configuring the access logs of a v2 load balancer.
+func accessLogsAttributes(accessLogs *infrav1.LoadBalancerAccessLogs) map[string]*string {
+	attributes := map[string]*string{
+		infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String(strconv.FormatBool(accessLogs.Enabled)),
+	}
+	if accessLogs.Enabled {
+		attributes[infrav1.LoadBalancerAttributeAccessLogsBucket] = aws.String(accessLogs.Bucket)
+		attributes[infrav1.LoadBalancerAttributeAccessLogsPrefix] = aws.String(accessLogs.Prefix)
+	}
+	return attributes
+}
+
+// validateAccessLogsBucketPolicy checks that the policy of the S3 bucket of the access logs allows the load balancers
+// to write them, so that a misconfigured bucket is reported explicitly. The check is skipped when the bucket policy
+// can't be read, as the bucket may belong to another account.
+func (s *Service) validateAccessLogsBucketPolicy(ctx context.Context, accessLogs *infrav1.LoadBalancerAccessLogs) error {
+	if accessLogs == nil || !accessLogs.Enabled {
+		return nil
+	}
+
+	out, err := s.S3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(accessLogs.Bucket),
+	})
+	if err != nil {
+		switch code, _ := awserrors.Code(err); code {
+		case errCodeNoSuchBucketPolicy:
+			return errors.Errorf("S3 bucket %q of the access logs has no policy allowing the load balancer to write them", accessLogs.Bucket)
+		case errCodeAccessDenied:
+			s.scope.Info("Not allowed to get the policy of the S3 bucket of the access logs, skipping its validation", "bucket", accessLogs.Bucket)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get policy of S3 bucket %q", accessLogs.Bucket)
+	}
+
+	policy := iamv1.PolicyDocument{}
+	if err := json.Unmarshal([]byte(aws.StringValue(out.Policy)), &policy); err != nil {
+		return errors.Wrapf(err, "failed to parse policy of S3 bucket %q", accessLogs.Bucket)
+	}
+
+	location := accessLogsLocation(s.scope.Partition(), accessLogs)
+	for _, statement := range policy.Statement {
+		if statement.Effect == iamv1.EffectAllow &&
+			allowsPutObject(statement.Action) &&
+			coversLocation(statement.Resource, location) &&
+			allowsLogDelivery(statement.Principal) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("policy of S3 bucket %q doesn't allow the load balancer to write the access logs to %q", accessLogs.Bucket, location)
+}
+
+// accessLogsLocation returns the ARN of the S3 location the access logs are written to.
+func accessLogsLocation(partition string, accessLogs *infrav1.LoadBalancerAccessLogs) string {
+	key := "AWSLogs/"
+	if accessLogs.Prefix != "" {
+		key = accessLogs.Prefix + "/" + key
+	}
+	return fmt.Sprintf("arn:%s:s3:::%s/%s", partition, accessLogs.Bucket, key)
+}
+
+// allowsPutObject returns whether the actions of a statement include s3:PutObject.
+func allowsPutObject(actions iamv1.Actions) bool {
+	for _, action := range actions {
+		if ok, _ := path.Match(strings.ToLower(action), "s3:putobject"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// coversLocation returns whether the resources of a statement cover the objects of an S3 location, or a part of them
+// as the account ID of the location is unknown.
+func coversLocation(resources iamv1.Resources, location string) bool {
+	for _, resource := range resources {
+		wildcard := strings.IndexAny(resource, "*?")
+		if wildcard < 0 {
+			continue
+		}
+		literal := resource[:wildcard]
+		if strings.HasPrefix(location, literal) || strings.HasPrefix(literal, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsLogDelivery returns whether the principals of a statement include the ones delivering the access logs.
+func allowsLogDelivery(principals iamv1.Principals) bool {
+	// The regional accounts delivering the access logs are not checked.
+	if len(principals[iamv1.PrincipalAWS]) > 0 {
+		return true
+	}
+	for _, service := range principals[iamv1.PrincipalService] {
+		for _, deliveryService := range accessLogsDeliveryServices {
+			if service == deliveryService {
+				return true
+			}
+		}
+	}
+	return false
+}
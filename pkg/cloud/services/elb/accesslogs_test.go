@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3/mock_s3iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAccessLogsAttributes(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(accessLogsAttributes(&infrav1.LoadBalancerAccessLogs{
+		Enabled: true,
+		Bucket:  "logs",
+		Prefix:  "apiserver",
+	})).To(Equal(map[string]*string{
+		infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("true"),
+		infrav1.LoadBalancerAttributeAccessLogsBucket:  aws.String("logs"),
+		infrav1.LoadBalancerAttributeAccessLogsPrefix:  aws.String("apiserver"),
+	}))
+	g.Expect(accessLogsAttributes(&infrav1.LoadBalancerAccessLogs{
+		Bucket: "logs",
+	})).To(Equal(map[string]*string{
+		infrav1.LoadBalancerAttributeAccessLogsEnabled: aws.String("false"),
+	}))
+}
+
+func TestValidateAccessLogsBucketPolicy(t *testing.T) {
+	const (
+		serviceDeliveryPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "logdelivery.elasticloadbalancing.amazonaws.com"},
+    "Action": "s3:PutObject",
+    "Resource": "arn:aws:s3:::logs/apiserver/AWSLogs/123456789012/*"
+  }]
+}`
+		accountDeliveryPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"AWS": "arn:aws:iam::127311923021:root"},
+    "Action": ["s3:PutObject"],
+    "Resource": ["arn:aws:s3:::logs/*"]
+  }]
+}`
+		otherPrefixPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "logdelivery.elasticloadbalancing.amazonaws.com"},
+    "Action": "s3:PutObject",
+    "Resource": "arn:aws:s3:::logs/other/AWSLogs/123456789012/*"
+  }]
+}`
+		otherPrincipalPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Principal": {"Service": "cloudtrail.amazonaws.com"},
+    "Action": "s3:*",
+    "Resource": "arn:aws:s3:::logs/*"
+  }]
+}`
+	)
+
+	tests := []struct {
+		name      string
+		s3Mocks   func(m *mock_s3iface.MockS3APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "policy allowing the delivery service to write to the prefix",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), &s3.GetBucketPolicyInput{Bucket: aws.String("logs")}).
+					Return(&s3.GetBucketPolicyOutput{Policy: aws.String(serviceDeliveryPolicy)}, nil)
+			},
+		},
+		{
+			name: "policy allowing a delivery account to write to the bucket",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), gomock.Any()).
+					Return(&s3.GetBucketPolicyOutput{Policy: aws.String(accountDeliveryPolicy)}, nil)
+			},
+		},
+		{
+			name: "policy allowing writes to another prefix",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), gomock.Any()).
+					Return(&s3.GetBucketPolicyOutput{Policy: aws.String(otherPrefixPolicy)}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "policy allowing writes to another principal",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), gomock.Any()).
+					Return(&s3.GetBucketPolicyOutput{Policy: aws.String(otherPrincipalPolicy)}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "bucket without policy",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(errCodeNoSuchBucketPolicy, "The bucket policy does not exist", nil))
+			},
+			expectErr: true,
+		},
+		{
+			name: "policy that can't be read",
+			s3Mocks: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.GetBucketPolicyWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(errCodeAccessDenied, "Access Denied", nil))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+					Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.s3Mocks(s3Mock.EXPECT())
+
+			s := &Service{
+				scope:    clusterScope,
+				S3Client: s3Mock,
+			}
+			err = s.validateAccessLogsBucketPolicy(context.TODO(), &infrav1.LoadBalancerAccessLogs{
+				Enabled: true,
+				Bucket:  "logs",
+				Prefix:  "apiserver",
+			})
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+// RegisterInstanceWithLoadBalancerAttachments registers an instance with the target groups and the classic
+// load balancers of its load balancer attachments. Registering an instance already registered is a no-op.
+func (s *Service) RegisterInstanceWithLoadBalancerAttachments(ctx context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error {
+	if attachments == nil {
+		return nil
+	}
+
+	errs := []error{}
+	for _, arn := range attachments.TargetGroupARNs {
+		// The port of the target group is used when none is given.
+		if _, err := s.ELBV2Client.RegisterTargetsWithContext(ctx, &elbv2.RegisterTargetsInput{
+			TargetGroupArn: aws.String(arn),
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(i.ID)}},
+		}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to register instance %q with target group %q", i.ID, arn))
+		}
+	}
+	for _, name := range attachments.ClassicLoadBalancerNames {
+		if _, err := s.ELBClient.RegisterInstancesWithLoadBalancerWithContext(ctx, &elb.RegisterInstancesWithLoadBalancerInput{
+			Instances:        []*elb.Instance{{InstanceId: aws.String(i.ID)}},
+			LoadBalancerName: aws.String(name),
+		}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to register instance %q with classic load balancer %q", i.ID, name))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// DeregisterInstanceFromLoadBalancerAttachments de-registers an instance from the target groups and the classic
+// load balancers of its load balancer attachments. The target groups and the load balancers that no longer exist
+// are ignored.
+func (s *Service) DeregisterInstanceFromLoadBalancerAttachments(ctx context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error {
+	if attachments == nil {
+		return nil
+	}
+
+	errs := []error{}
+	for _, arn := range attachments.TargetGroupARNs {
+		if _, err := s.ELBV2Client.DeregisterTargetsWithContext(ctx, &elbv2.DeregisterTargetsInput{
+			TargetGroupArn: aws.String(arn),
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(i.ID)}},
+		}); err != nil {
+			if code, _ := awserrors.Code(err); code == elbv2.ErrCodeTargetGroupNotFoundException || code == elbv2.ErrCodeInvalidTargetException {
+				continue
+			}
+			errs = append(errs, errors.Wrapf(err, "failed to deregister instance %q from target group %q", i.ID, arn))
+		}
+	}
+	for _, name := range attachments.ClassicLoadBalancerNames {
+		if _, err := s.ELBClient.DeregisterInstancesFromLoadBalancerWithContext(ctx, &elb.DeregisterInstancesFromLoadBalancerInput{
+			Instances:        []*elb.Instance{{InstanceId: aws.String(i.ID)}},
+			LoadBalancerName: aws.String(name),
+		}); err != nil {
+			if code, _ := awserrors.Code(err); code == elb.ErrCodeAccessPointNotFoundException || code == elb.ErrCodeInvalidEndPointException {
+				continue
+			}
+			errs = append(errs, errors.Wrapf(err, "failed to deregister instance %q from classic load balancer %q", i.ID, name))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
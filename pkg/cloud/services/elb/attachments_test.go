@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+const (
+	attachedTargetGroupARN   = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"
+	attachedLoadBalancerName = "ingress"
+)
+
+func TestRegisterInstanceWithLoadBalancerAttachments(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments *infrav1.LoadBalancerAttachments
+		elbAPIMocks func(m *mocks.MockELBAPIMockRecorder)
+		elbV2Mocks  func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr   bool
+	}{
+		{
+			name:        "no attachments",
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {},
+			elbV2Mocks:  func(m *mocks.MockELBV2APIMockRecorder) {},
+		},
+		{
+			name: "registers with target groups and classic load balancers",
+			attachments: &infrav1.LoadBalancerAttachments{
+				TargetGroupARNs:          []string{attachedTargetGroupARN},
+				ClassicLoadBalancerNames: []string{attachedLoadBalancerName},
+			},
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.RegisterInstancesWithLoadBalancerWithContext(context.TODO(), &elb.RegisterInstancesWithLoadBalancerInput{
+					Instances:        []*elb.Instance{{InstanceId: aws.String("i-123")}},
+					LoadBalancerName: aws.String(attachedLoadBalancerName),
+				}).Return(&elb.RegisterInstancesWithLoadBalancerOutput{}, nil)
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.RegisterTargetsWithContext(context.TODO(), &elbv2.RegisterTargetsInput{
+					TargetGroupArn: aws.String(attachedTargetGroupARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-123")}},
+				}).Return(&elbv2.RegisterTargetsOutput{}, nil)
+			},
+		},
+		{
+			name: "reports the target groups that can't be registered with",
+			attachments: &infrav1.LoadBalancerAttachments{
+				TargetGroupARNs:          []string{attachedTargetGroupARN},
+				ClassicLoadBalancerNames: []string{attachedLoadBalancerName},
+			},
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.RegisterInstancesWithLoadBalancerWithContext(context.TODO(), gomock.Any()).Return(&elb.RegisterInstancesWithLoadBalancerOutput{}, nil)
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.RegisterTargetsWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbAPIMock := mocks.NewMockELBAPI(mockCtrl)
+			elbV2APIMock := mocks.NewMockELBV2API(mockCtrl)
+			tc.elbAPIMocks(elbAPIMock.EXPECT())
+			tc.elbV2Mocks(elbV2APIMock.EXPECT())
+
+			s := &Service{
+				ELBClient:   elbAPIMock,
+				ELBV2Client: elbV2APIMock,
+			}
+			err := s.RegisterInstanceWithLoadBalancerAttachments(context.TODO(), &infrav1.Instance{ID: "i-123"}, tc.attachments)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestDeregisterInstanceFromLoadBalancerAttachments(t *testing.T) {
+	tests := []struct {
+		name        string
+		elbAPIMocks func(m *mocks.MockELBAPIMockRecorder)
+		elbV2Mocks  func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr   bool
+	}{
+		{
+			name: "deregisters from target groups and classic load balancers",
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DeregisterInstancesFromLoadBalancerWithContext(context.TODO(), &elb.DeregisterInstancesFromLoadBalancerInput{
+					Instances:        []*elb.Instance{{InstanceId: aws.String("i-123")}},
+					LoadBalancerName: aws.String(attachedLoadBalancerName),
+				}).Return(&elb.DeregisterInstancesFromLoadBalancerOutput{}, nil)
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeregisterTargetsWithContext(context.TODO(), &elbv2.DeregisterTargetsInput{
+					TargetGroupArn: aws.String(attachedTargetGroupARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-123")}},
+				}).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+			},
+		},
+		{
+			name: "ignores the target groups and load balancers that no longer exist",
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DeregisterInstancesFromLoadBalancerWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "", nil))
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeregisterTargetsWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "", nil))
+			},
+		},
+		{
+			name: "reports the other errors",
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DeregisterInstancesFromLoadBalancerWithContext(context.TODO(), gomock.Any()).Return(&elb.DeregisterInstancesFromLoadBalancerOutput{}, nil)
+			},
+			elbV2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeregisterTargetsWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbAPIMock := mocks.NewMockELBAPI(mockCtrl)
+			elbV2APIMock := mocks.NewMockELBV2API(mockCtrl)
+			tc.elbAPIMocks(elbAPIMock.EXPECT())
+			tc.elbV2Mocks(elbV2APIMock.EXPECT())
+
+			s := &Service{
+				ELBClient:   elbAPIMock,
+				ELBV2Client: elbV2APIMock,
+			}
+			err := s.DeregisterInstanceFromLoadBalancerAttachments(context.TODO(), &infrav1.Instance{ID: "i-123"}, &infrav1.LoadBalancerAttachments{
+				TargetGroupARNs:          []string{attachedTargetGroupARN},
+				ClassicLoadBalancerNames: []string{attachedLoadBalancerName},
+			})
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+)
+
+// reconcileExistingLB reconciles an existing load balancer referenced by its ARN. The listeners of the
+// spec are created along with their target groups when they don't exist, but the load balancer itself
+// is never modified.
+func (s *Service) reconcileExistingLB(ctx context.Context, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	lb, err := s.describeLBByARN(ctx, *lbSpec.ARN, lbSpec)
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe existing control plane load balancer %q", *lbSpec.ARN)
+	}
+
+	spec, err := s.getAPIServerLBSpec(ctx, lb.Name, lbSpec)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reconcileOwnedListeners(ctx, lb, spec, lbSpec); err != nil {
+		return errors.Wrapf(err, "failed to reconcile listeners of existing control plane load balancer %q", lb.Name)
+	}
+
+	if len(lbSpec.AdditionalTargetGroupAttributes) > 0 {
+		targetGroups, err := s.ownedTargetGroups(ctx, lb.ARN)
+		if err != nil {
+			return err
+		}
+		if err := s.reconcileTargetGroupAttributes(ctx, targetGroups, lbSpec); err != nil {
+			return errors.Wrapf(err, "failed to reconcile target groups of existing control plane load balancer %q", lb.Name)
+		}
+	}
+
+	lb.LoadBalancerType = lbSpec.LoadBalancerType
+	lb.DeepCopyInto(&s.scope.Network().APIServerELB)
+
+	return nil
+}
+
+// reconcileOwnedListeners creates the listeners of the spec missing from a load balancer, along with their
+// target groups, owned by the cluster. The ports used by the listeners not owned by the cluster are rejected.
+func (s *Service) reconcileOwnedListeners(ctx context.Context, lb *infrav1.LoadBalancer, spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	targetGroups, err := s.ownedTargetGroups(ctx, lb.ARN)
+	if err != nil {
+		return err
+	}
+	owned := map[string]bool{}
+	for _, tg := range targetGroups {
+		owned[aws.StringValue(tg.TargetGroupArn)] = true
+	}
+
+	out, err := s.ELBV2Client.DescribeListenersWithContext(ctx, &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(lb.ARN),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe listeners")
+	}
+	listeners := map[int64]*elbv2.Listener{}
+	for _, listener := range out.Listeners {
+		listeners[aws.Int64Value(listener.Port)] = listener
+	}
+
+	for _, ln := range spec.ELBListeners {
+		listener, ok := listeners[ln.Port]
+		if !ok {
+			if err := s.createListener(ctx, lb.ARN, ln, spec.Tags, lbSpec); err != nil {
+				return err
+			}
+			s.scope.Info("Created listener of existing control plane load balancer", "api-server-elb-name", lb.Name, "port", ln.Port)
+			continue
+		}
+
+		if !forwardsToOwnedTargetGroup(listener, owned) {
+			return errors.Errorf("port %d is used by a listener not owned by the cluster", ln.Port)
+		}
+	}
+
+	return nil
+}
+
+// deleteOwnedListeners deletes the listeners and the target groups owned by the cluster of an existing
+// load balancer, leaving the load balancer and its other listeners untouched.
+func (s *Service) deleteOwnedListeners(ctx context.Context, lb *infrav1.LoadBalancer) error {
+	// The target groups must be gathered first, as they are no longer associated with the load balancer
+	// once their listeners are deleted.
+	targetGroups, err := s.ownedTargetGroups(ctx, lb.ARN)
+	if err != nil {
+		return err
+	}
+	if len(targetGroups) == 0 {
+		return nil
+	}
+	owned := map[string]bool{}
+	for _, tg := range targetGroups {
+		owned[aws.StringValue(tg.TargetGroupArn)] = true
+	}
+
+	out, err := s.ELBV2Client.DescribeListenersWithContext(ctx, &elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(lb.ARN),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe listeners")
+	}
+	for _, listener := range out.Listeners {
+		if !forwardsToOwnedTargetGroup(listener, owned) {
+			continue
+		}
+		if _, err := s.ELBV2Client.DeleteListenerWithContext(ctx, &elbv2.DeleteListenerInput{ListenerArn: listener.ListenerArn}); err != nil {
+			return errors.Wrapf(err, "failed to delete listener %q", aws.StringValue(listener.ListenerArn))
+		}
+	}
+
+	for _, tg := range targetGroups {
+		if _, err := s.ELBV2Client.DeleteTargetGroupWithContext(ctx, &elbv2.DeleteTargetGroupInput{TargetGroupArn: tg.TargetGroupArn}); err != nil {
+			return errors.Wrapf(err, "failed to delete target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+	}
+
+	s.scope.Info("Deleted listeners of existing control plane load balancer", "api-server-elb-name", lb.Name)
+	return nil
+}
+
+// ownedTargetGroups returns the target groups of a load balancer owned by the cluster.
+func (s *Service) ownedTargetGroups(ctx context.Context, lbARN string) ([]*elbv2.TargetGroup, error) {
+	out, err := s.ELBV2Client.DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(lbARN),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe target groups")
+	}
+
+	return s.filterOwnedTargetGroups(ctx, out.TargetGroups)
+}
+
+// filterOwnedTargetGroups returns the target groups owned by the cluster among the given ones.
+func (s *Service) filterOwnedTargetGroups(ctx context.Context, targetGroups []*elbv2.TargetGroup) ([]*elbv2.TargetGroup, error) {
+	byARN := map[string]*elbv2.TargetGroup{}
+	arns := make([]string, 0, len(targetGroups))
+	for _, tg := range targetGroups {
+		byARN[aws.StringValue(tg.TargetGroupArn)] = tg
+		arns = append(arns, aws.StringValue(tg.TargetGroupArn))
+	}
+
+	owned := []*elbv2.TargetGroup{}
+	for _, chunk := range chunkELBs(arns) {
+		out, err := s.ELBV2Client.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice(chunk),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe target group tags")
+		}
+		for _, description := range out.TagDescriptions {
+			if converters.V2TagsToMap(description.Tags).HasOwned(s.scope.Name()) {
+				owned = append(owned, byARN[aws.StringValue(description.ResourceArn)])
+			}
+		}
+	}
+
+	return owned, nil
+}
+
+// forwardsToOwnedTargetGroup returns whether the default actions of a listener forward to the given target groups.
+func forwardsToOwnedTargetGroup(listener *elbv2.Listener, owned map[string]bool) bool {
+	for _, action := range listener.DefaultActions {
+		if owned[aws.StringValue(action.TargetGroupArn)] {
+			return true
+		}
+	}
+	return false
+}
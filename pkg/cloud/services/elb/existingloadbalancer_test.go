@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	existingLBName    = "shared-nlb"
+	existingLBARN     = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/shared-nlb/50dc6c495c0c9188"
+	ownedTGARN        = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/apiserver-target/1"
+	userTGARN         = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/2"
+	ownedListenerARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/net/shared-nlb/1"
+	userListenerARN   = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/net/shared-nlb/2"
+	existingLBCluster = "cluster-a"
+)
+
+func TestReconcileExistingLB(t *testing.T) {
+	tests := []struct {
+		name          string
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr     bool
+	}{
+		{
+			name: "creates the missing listeners",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				expectDescribeExistingLB(m)
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(existingLBARN)}).
+					Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
+				m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(existingLBARN)}).
+					Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Any()).
+					Return(&elbv2.CreateTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(ownedTGARN)}}}, nil)
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Any()).Return(&elbv2.ModifyTargetGroupAttributesOutput{}, nil)
+				m.CreateListenerWithContext(context.TODO(), gomock.Any()).DoAndReturn(func(_ context.Context, input *elbv2.CreateListenerInput, _ ...request.Option) (*elbv2.CreateListenerOutput, error) {
+					if aws.StringValue(input.LoadBalancerArn) != existingLBARN || aws.Int64Value(input.Port) != infrav1.DefaultAPIServerPort {
+						t.Errorf("unexpected listener: %v", input)
+					}
+					return &elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{{ListenerArn: aws.String(ownedListenerARN)}}}, nil
+				})
+			},
+		},
+		{
+			name: "keeps the listeners owned by the cluster",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				expectDescribeExistingLB(m)
+				expectExistingTargetGroups(m)
+				m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(existingLBARN)}).
+					Return(&elbv2.DescribeListenersOutput{Listeners: []*elbv2.Listener{
+						listener(ownedListenerARN, infrav1.DefaultAPIServerPort, ownedTGARN),
+						listener(userListenerARN, 443, userTGARN),
+					}}, nil)
+			},
+		},
+		{
+			name: "rejects a port used by a listener not owned by the cluster",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				expectDescribeExistingLB(m)
+				expectExistingTargetGroups(m)
+				m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(existingLBARN)}).
+					Return(&elbv2.DescribeListenersOutput{Listeners: []*elbv2.Listener{
+						listener(userListenerARN, infrav1.DefaultAPIServerPort, userTGARN),
+					}}, nil)
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+
+			s := newExistingLBService(t, elbV2APIMocks)
+			err := s.ReconcileLoadbalancers(context.TODO())
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s.scope.Network().APIServerELB.ARN).To(Equal(existingLBARN))
+			g.Expect(s.scope.Network().APIServerELB.DNSName).To(Equal("shared-nlb.elb.amazonaws.com"))
+		})
+	}
+}
+
+func TestRegisterInstanceWithExistingLB(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+	m := elbV2APIMocks.EXPECT()
+	expectDescribeExistingLB(m)
+	expectExistingTargetGroups(m)
+	m.RegisterTargetsWithContext(context.TODO(), &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(ownedTGARN),
+		Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(infrav1.DefaultAPIServerPort)}},
+	}).Return(&elbv2.RegisterTargetsOutput{}, nil)
+
+	s := newExistingLBService(t, elbV2APIMocks)
+	err := s.RegisterInstanceWithAPIServerLB(context.TODO(), &infrav1.Instance{ID: "i-1"}, s.scope.ControlPlaneLoadBalancer())
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestDeleteExistingLB(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+	m := elbV2APIMocks.EXPECT()
+	expectDescribeExistingLB(m)
+	expectExistingTargetGroups(m)
+	m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(existingLBARN)}).
+		Return(&elbv2.DescribeListenersOutput{Listeners: []*elbv2.Listener{
+			listener(ownedListenerARN, infrav1.DefaultAPIServerPort, ownedTGARN),
+			listener(userListenerARN, 443, userTGARN),
+		}}, nil)
+	m.DeleteListenerWithContext(context.TODO(), &elbv2.DeleteListenerInput{ListenerArn: aws.String(ownedListenerARN)}).Return(&elbv2.DeleteListenerOutput{}, nil)
+	m.DeleteTargetGroupWithContext(context.TODO(), &elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(ownedTGARN)}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+
+	s := newExistingLBService(t, elbV2APIMocks)
+	g.Expect(s.deleteExistingNLB(context.TODO(), s.scope.ControlPlaneLoadBalancer())).To(Succeed())
+}
+
+func newExistingLBService(t *testing.T, elbV2APIMocks *mocks.MockELBV2API) *Service {
+	t.Helper()
+
+	scheme, err := setupScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: existingLBCluster},
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+			ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				ARN:              aws.String(existingLBARN),
+				Scheme:           &infrav1.ELBSchemeInternetFacing,
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: existingLBCluster},
+		},
+		AWSCluster: awsCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Service{
+		scope:       clusterScope,
+		ELBV2Client: elbV2APIMocks,
+	}
+}
+
+func expectDescribeExistingLB(m *mocks.MockELBV2APIMockRecorder) {
+	m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice([]string{existingLBARN})}).
+		Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{{
+			LoadBalancerArn:  aws.String(existingLBARN),
+			LoadBalancerName: aws.String(existingLBName),
+			DNSName:          aws.String("shared-nlb.elb.amazonaws.com"),
+			Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+			VpcId:            aws.String("vpc-1"),
+		}}}, nil)
+	m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(existingLBARN)}).
+		Return(&elbv2.DescribeLoadBalancerAttributesOutput{}, nil)
+	m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{existingLBARN})}).
+		Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String(existingLBARN)}}}, nil)
+}
+
+// expectExistingTargetGroups expects the target groups of the existing load balancer: one owned by the cluster
+// and one created by the user.
+func expectExistingTargetGroups(m *mocks.MockELBV2APIMockRecorder) {
+	m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(existingLBARN)}).
+		Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{
+			{TargetGroupArn: aws.String(ownedTGARN), Port: aws.Int64(infrav1.DefaultAPIServerPort)},
+			{TargetGroupArn: aws.String(userTGARN), Port: aws.Int64(443)},
+		}}, nil)
+	m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{ownedTGARN, userTGARN})}).
+		Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String(ownedTGARN),
+				Tags: []*elbv2.Tag{{
+					Key:   aws.String(infrav1.ClusterTagKey(existingLBCluster)),
+					Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+				}},
+			},
+			{ResourceArn: aws.String(userTGARN)},
+		}}, nil)
+}
+
+func listener(arn string, port int64, targetGroupARN string) *elbv2.Listener {
+	return &elbv2.Listener{
+		ListenerArn:    aws.String(arn),
+		Port:           aws.Int64(port),
+		DefaultActions: []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String(targetGroupARN)}},
+	}
+}
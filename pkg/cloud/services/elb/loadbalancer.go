@@ -19,6 +19,8 @@ package elb
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -55,7 +57,7 @@ const elbResourceType = "elasticloadbalancing:loadbalancer"
 const maxELBsDescribeTagsRequest = 20
 
 // ReconcileLoadbalancers reconciles the load balancers for the given cluster.
-func (s *Service) ReconcileLoadbalancers() error {
+func (s *Service) ReconcileLoadbalancers(ctx context.Context) error {
 	s.scope.Debug("Reconciling load balancers")
 
 	var errs []error
@@ -66,9 +68,9 @@ func (s *Service) ReconcileLoadbalancers() error {
 		}
 		switch lbSpec.LoadBalancerType {
 		case infrav1.LoadBalancerTypeClassic:
-			errs = append(errs, s.reconcileClassicLoadBalancer())
+			errs = append(errs, s.reconcileClassicLoadBalancer(ctx))
 		case infrav1.LoadBalancerTypeNLB, infrav1.LoadBalancerTypeALB, infrav1.LoadBalancerTypeELB:
-			errs = append(errs, s.reconcileV2LB(lbSpec))
+			errs = append(errs, s.reconcileV2LB(ctx, lbSpec))
 		default:
 			errs = append(errs, fmt.Errorf("unknown or unsupported load balancer type on primary load balancer: %s", lbSpec.LoadBalancerType))
 		}
@@ -79,24 +81,29 @@ func (s *Service) ReconcileLoadbalancers() error {
 
 // reconcileV2LB creates a load balancer. It also takes care of generating unique names across
 // namespaces by appending the namespace to the name.
-func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
+func (s *Service) reconcileV2LB(ctx context.Context, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	if lbSpec.ARN != nil {
+		return s.reconcileExistingLB(ctx, lbSpec)
+	}
+
 	name, err := LBName(s.scope, lbSpec)
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
 	}
 
 	// Get default api server spec.
-	spec, err := s.getAPIServerLBSpec(name, lbSpec)
+	spec, err := s.getAPIServerLBSpec(ctx, name, lbSpec)
 	if err != nil {
 		return err
 	}
-	lb, err := s.describeLB(name, lbSpec)
+	lb, err := s.describeLB(ctx, name, lbSpec)
 	switch {
-	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
+	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid() && !s.isSecondaryControlPlaneLB(lbSpec):
 		// if elb is not found and owner cluster ControlPlaneEndpoint is already populated, then we should not recreate the elb.
+		// The secondary load balancer doesn't serve the control plane endpoint, so it can be created at any time.
 		return errors.Wrapf(err, "no loadbalancer exists for the AWSCluster %s, the cluster has become unrecoverable and should be deleted manually", s.scope.InfraClusterName())
 	case IsNotFound(err):
-		lb, err = s.createLB(spec, lbSpec)
+		lb, err = s.createLB(ctx, spec, lbSpec)
 		if err != nil {
 			s.scope.Error(err, "failed to create LB")
 			return err
@@ -112,19 +119,46 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 	lb.LoadBalancerType = lbSpec.LoadBalancerType
 	if lb.IsManaged(s.scope.Name()) {
 		if !cmp.Equal(spec.ELBAttributes, lb.ELBAttributes) {
-			if err := s.configureLBAttributes(lb.ARN, spec.ELBAttributes); err != nil {
+			if err := s.validateAccessLogsBucketPolicy(ctx, lbSpec.AccessLogs); err != nil {
+				return err
+			}
+			if err := s.configureLBAttributes(ctx, lb.ARN, spec.ELBAttributes); err != nil {
 				return err
 			}
 		}
 
-		if err := s.reconcileV2LBTags(lb, spec.Tags); err != nil {
+		if err := s.reconcileV2LBTags(ctx, lb, spec.Tags); err != nil {
 			return errors.Wrapf(err, "failed to reconcile tags for apiserver load balancer %q", lb.Name)
 		}
 
+		if len(lbSpec.AdditionalTargetGroupAttributes) > 0 {
+			out, err := s.ELBV2Client.DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
+				LoadBalancerArn: aws.String(lb.ARN),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to describe target groups of apiserver load balancer %q", lb.Name)
+			}
+			if err := s.reconcileTargetGroupAttributes(ctx, out.TargetGroups, lbSpec); err != nil {
+				return errors.Wrapf(err, "failed to reconcile target groups of apiserver load balancer %q", lb.Name)
+			}
+		}
+
+		// The IP address type is only reconciled when set, to leave the one of the load balancers of
+		// VPCs with IPv6 enabled as created. The subnets must have an IPv6 CIDR block to switch to dual-stack.
+		if lbSpec.IPAddressType != "" && lb.IPAddressType != lbSpec.IPAddressType {
+			if _, err := s.ELBV2Client.SetIpAddressTypeWithContext(ctx, &elbv2.SetIpAddressTypeInput{
+				LoadBalancerArn: aws.String(lb.ARN),
+				IpAddressType:   aws.String(string(lbSpec.IPAddressType)),
+			}); err != nil {
+				return errors.Wrapf(err, "failed to set IP address type of apiserver load balancer %q", lb.Name)
+			}
+			lb.IPAddressType = lbSpec.IPAddressType
+		}
+
 		// Reconcile the subnets and availability zones from the spec
 		// and the ones currently attached to the load balancer.
-		if len(lb.SubnetIDs) != len(spec.SubnetIDs) {
-			_, err := s.ELBV2Client.SetSubnets(&elbv2.SetSubnetsInput{
+		if len(lb.SubnetIDs) != len(spec.SubnetIDs) || s.attachesReplacedSubnets(lb.SubnetIDs, spec.SubnetIDs) {
+			_, err := s.ELBV2Client.SetSubnetsWithContext(ctx, &elbv2.SetSubnetsInput{
 				LoadBalancerArn: &lb.ARN,
 				Subnets:         aws.StringSlice(spec.SubnetIDs),
 			})
@@ -138,7 +172,7 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 
 		// Reconcile the security groups from the spec and the ones currently attached to the load balancer
 		if shouldReconcileSGs(s.scope, lb, spec.SecurityGroupIDs) {
-			_, err := s.ELBV2Client.SetSecurityGroups(&elbv2.SetSecurityGroupsInput{
+			_, err := s.ELBV2Client.SetSecurityGroupsWithContext(ctx, &elbv2.SetSecurityGroupsInput{
 				LoadBalancerArn: &lb.ARN,
 				SecurityGroups:  aws.StringSlice(spec.SecurityGroupIDs),
 			})
@@ -150,7 +184,7 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 		s.scope.Trace("Unmanaged control plane load balancer, skipping load balancer configuration", "api-server-elb", lb)
 	}
 
-	if s.scope.ControlPlaneLoadBalancers()[1] != nil && lb.Name == *s.scope.ControlPlaneLoadBalancers()[1].Name {
+	if s.isSecondaryControlPlaneLB(lbSpec) {
 		lb.DeepCopyInto(&s.scope.Network().SecondaryAPIServerELB)
 	} else {
 		lb.DeepCopyInto(&s.scope.Network().APIServerELB)
@@ -239,11 +273,25 @@ func (s *Service) getAdditionalTargetGroupHealthCheck(ln infrav1.AdditionalListe
 	return healthCheck
 }
 
-func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+// isSecondaryControlPlaneLB returns whether a load balancer spec is the one of the secondary control plane load balancer.
+func (s *Service) isSecondaryControlPlaneLB(lbSpec *infrav1.AWSLoadBalancerSpec) bool {
+	lbs := s.scope.ControlPlaneLoadBalancers()
+	return lbSpec != nil && len(lbs) > 1 && lbs[1] == lbSpec
+}
+
+// controlPlaneLBSecurityGroupRole returns the role of the security group managed for a control plane load balancer.
+func (s *Service) controlPlaneLBSecurityGroupRole(lbSpec *infrav1.AWSLoadBalancerSpec) infrav1.SecurityGroupRole {
+	if s.isSecondaryControlPlaneLB(lbSpec) {
+		return infrav1.SecurityGroupSecondaryAPIServerLB
+	}
+	return infrav1.SecurityGroupAPIServerLB
+}
+
+func (s *Service) getAPIServerLBSpec(ctx context.Context, elbName string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
 	var securityGroupIDs []string
 	if lbSpec != nil {
 		securityGroupIDs = append(securityGroupIDs, lbSpec.AdditionalSecurityGroups...)
-		securityGroupIDs = append(securityGroupIDs, s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID)
+		securityGroupIDs = append(securityGroupIDs, s.scope.SecurityGroups()[s.controlPlaneLBSecurityGroupRole(lbSpec)].ID)
 	}
 
 	// Since we're no longer relying on s.scope.ControlPlaneLoadBalancerScheme to do the defaulting for us, do it here.
@@ -307,6 +355,20 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 		res.ELBAttributes[infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone] = aws.String(strconv.FormatBool(isCrossZoneLB))
 	}
 
+	if lbSpec != nil {
+		res.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection] = aws.String(strconv.FormatBool(lbSpec.DeletionProtection))
+	}
+
+	if lbSpec != nil && lbSpec.AccessLogs != nil {
+		for k, v := range accessLogsAttributes(lbSpec.AccessLogs) {
+			res.ELBAttributes[k] = v
+		}
+	}
+
+	if lbSpec != nil && lbSpec.DNSClientRoutingPolicy != "" {
+		res.ELBAttributes[infrav1.LoadBalancerAttributeDNSClientRoutingPolicy] = aws.String(string(lbSpec.DNSClientRoutingPolicy))
+	}
+
 	res.Tags = infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -322,7 +384,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 		input := &ec2.DescribeSubnetsInput{
 			SubnetIds: aws.StringSlice(lbSpec.Subnets),
 		}
-		out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+		out, err := s.EC2Client.DescribeSubnetsWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -332,14 +394,19 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
-		subnets := s.scope.Subnets().FilterPrivate()
+		subnets := s.scope.Subnets().WithoutReplaced().WithoutIPv6Native().FilterPrivate()
 
 		if scheme == infrav1.ELBSchemeInternetFacing {
-			subnets = s.scope.Subnets().FilterPublic()
+			subnets = s.scope.Subnets().WithoutReplaced().WithoutIPv6Native().FilterPublic()
 		}
+		dualStack := s.ipAddressType(lbSpec) == infrav1.LoadBalancerIPAddressTypeDualStack
 
 	subnetLoop:
 		for _, sn := range subnets {
+			// A dual-stack load balancer can only be attached to subnets with an IPv6 CIDR block.
+			if dualStack && !sn.IsIPv6 {
+				continue
+			}
 			for _, az := range res.AvailabilityZones {
 				if sn.AvailabilityZone == az {
 					// If we already attached another subnet in the same AZ, there is no need to
@@ -355,7 +422,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 	return res, nil
 }
 
-func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+func (s *Service) createLB(ctx context.Context, spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
 	var t *string
 	switch lbSpec.LoadBalancerType {
 	case infrav1.LoadBalancerTypeNLB:
@@ -374,11 +441,11 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBa
 		Type:           t,
 	}
 
-	if s.scope.VPC().IsIPv6Enabled() {
-		input.IpAddressType = aws.String("dualstack")
+	if ipAddressType := s.ipAddressType(lbSpec); ipAddressType != "" {
+		input.IpAddressType = aws.String(string(ipAddressType))
 	}
 
-	out, err := s.ELBV2Client.CreateLoadBalancer(input)
+	out, err := s.ELBV2Client.CreateLoadBalancerWithContext(ctx, input)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create load balancer: %v", spec)
 	}
@@ -390,101 +457,145 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBa
 	// TODO(Skarlso): Add options to set up SSL.
 	// https://github.com/kubernetes-sigs/cluster-api-provider-aws/issues/3899
 	for _, ln := range spec.ELBListeners {
-		// create the target group first
-		targetGroupInput := &elbv2.CreateTargetGroupInput{
-			Name:                       aws.String(ln.TargetGroup.Name),
-			Port:                       aws.Int64(ln.TargetGroup.Port),
-			Protocol:                   aws.String(ln.TargetGroup.Protocol.String()),
-			VpcId:                      aws.String(ln.TargetGroup.VpcID),
-			Tags:                       input.Tags,
-			HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
-			HealthCheckTimeoutSeconds:  aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
-			HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
-			UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
-		}
-		if s.scope.VPC().IsIPv6Enabled() {
-			targetGroupInput.IpAddressType = aws.String("ipv6")
-		}
-		if ln.TargetGroup.HealthCheck != nil {
-			targetGroupInput.HealthCheckEnabled = aws.Bool(true)
-			targetGroupInput.HealthCheckProtocol = ln.TargetGroup.HealthCheck.Protocol
-			targetGroupInput.HealthCheckPort = ln.TargetGroup.HealthCheck.Port
-			if ln.TargetGroup.HealthCheck.Path != nil {
-				targetGroupInput.HealthCheckPath = ln.TargetGroup.HealthCheck.Path
-			}
-			if ln.TargetGroup.HealthCheck.IntervalSeconds != nil {
-				targetGroupInput.HealthCheckIntervalSeconds = ln.TargetGroup.HealthCheck.IntervalSeconds
-			}
-			if ln.TargetGroup.HealthCheck.TimeoutSeconds != nil {
-				targetGroupInput.HealthCheckTimeoutSeconds = ln.TargetGroup.HealthCheck.TimeoutSeconds
-			}
-			if ln.TargetGroup.HealthCheck.ThresholdCount != nil {
-				targetGroupInput.HealthyThresholdCount = ln.TargetGroup.HealthCheck.ThresholdCount
-			}
-			if ln.TargetGroup.HealthCheck.UnhealthyThresholdCount != nil {
-				targetGroupInput.UnhealthyThresholdCount = ln.TargetGroup.HealthCheck.UnhealthyThresholdCount
-			}
+		if err := s.createListener(ctx, aws.StringValue(out.LoadBalancers[0].LoadBalancerArn), ln, spec.Tags, lbSpec); err != nil {
+			return nil, err
 		}
-		s.scope.Debug("creating target group", "group", targetGroupInput, "listener", ln)
-		group, err := s.ELBV2Client.CreateTargetGroup(targetGroupInput)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to create target group for load balancer")
+	}
+
+	s.scope.Info("Created network load balancer", "dns-name", *out.LoadBalancers[0].DNSName)
+
+	res := spec.DeepCopy()
+	s.scope.Debug("applying load balancer DNS to result", "dns", *out.LoadBalancers[0].DNSName)
+	res.DNSName = *out.LoadBalancers[0].DNSName
+	res.IPAddressType = infrav1.LoadBalancerIPAddressType(aws.StringValue(out.LoadBalancers[0].IpAddressType))
+	return res, nil
+}
+
+// ipAddressType returns the IP address type of a control plane load balancer: the one set in its spec, or
+// dualstack for the VPCs with IPv6 enabled. It returns an empty string when the IP address type is left to AWS.
+func (s *Service) ipAddressType(lbSpec *infrav1.AWSLoadBalancerSpec) infrav1.LoadBalancerIPAddressType {
+	if lbSpec != nil && lbSpec.IPAddressType != "" {
+		return lbSpec.IPAddressType
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		return infrav1.LoadBalancerIPAddressTypeDualStack
+	}
+	return ""
+}
+
+// createListener creates a listener of a load balancer, along with the target group it forwards to.
+func (s *Service) createListener(ctx context.Context, lbARN string, ln infrav1.Listener, tags map[string]string, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	// create the target group first
+	targetGroupInput := &elbv2.CreateTargetGroupInput{
+		Name:                       aws.String(ln.TargetGroup.Name),
+		Port:                       aws.Int64(ln.TargetGroup.Port),
+		Protocol:                   aws.String(ln.TargetGroup.Protocol.String()),
+		VpcId:                      aws.String(ln.TargetGroup.VpcID),
+		Tags:                       converters.MapToV2Tags(tags),
+		HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+		HealthCheckTimeoutSeconds:  aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+		HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+		UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		targetGroupInput.IpAddressType = aws.String("ipv6")
+	}
+	if lbSpec.IsIPTargetType() {
+		targetGroupInput.TargetType = aws.String(elbv2.TargetTypeEnumIp)
+	}
+	if ln.TargetGroup.HealthCheck != nil {
+		targetGroupInput.HealthCheckEnabled = aws.Bool(true)
+		targetGroupInput.HealthCheckProtocol = ln.TargetGroup.HealthCheck.Protocol
+		targetGroupInput.HealthCheckPort = ln.TargetGroup.HealthCheck.Port
+		if ln.TargetGroup.HealthCheck.Path != nil {
+			targetGroupInput.HealthCheckPath = ln.TargetGroup.HealthCheck.Path
 		}
-		if len(group.TargetGroups) == 0 {
-			return nil, errors.New("no target group was created; the returned list is empty")
+		if ln.TargetGroup.HealthCheck.IntervalSeconds != nil {
+			targetGroupInput.HealthCheckIntervalSeconds = ln.TargetGroup.HealthCheck.IntervalSeconds
 		}
-
-		if !lbSpec.PreserveClientIP {
-			targetGroupAttributeInput := &elbv2.ModifyTargetGroupAttributesInput{
-				TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
-				Attributes: []*elbv2.TargetGroupAttribute{
-					{
-						Key:   aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP),
-						Value: aws.String("false"),
-					},
-				},
-			}
-			if _, err := s.ELBV2Client.ModifyTargetGroupAttributes(targetGroupAttributeInput); err != nil {
-				return nil, errors.Wrapf(err, "failed to modify target group attribute")
-			}
+		if ln.TargetGroup.HealthCheck.TimeoutSeconds != nil {
+			targetGroupInput.HealthCheckTimeoutSeconds = ln.TargetGroup.HealthCheck.TimeoutSeconds
 		}
-
-		listenerInput := &elbv2.CreateListenerInput{
-			DefaultActions: []*elbv2.Action{
-				{
-					TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
-					Type:           aws.String(elbv2.ActionTypeEnumForward),
-				},
-			},
-			LoadBalancerArn: out.LoadBalancers[0].LoadBalancerArn,
-			Port:            aws.Int64(ln.Port),
-			Protocol:        aws.String(string(ln.Protocol)),
-			Tags:            converters.MapToV2Tags(spec.Tags),
+		if ln.TargetGroup.HealthCheck.ThresholdCount != nil {
+			targetGroupInput.HealthyThresholdCount = ln.TargetGroup.HealthCheck.ThresholdCount
 		}
-		// Create ClassicELBListeners
-		listener, err := s.ELBV2Client.CreateListener(listenerInput)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create listener")
+		if ln.TargetGroup.HealthCheck.UnhealthyThresholdCount != nil {
+			targetGroupInput.UnhealthyThresholdCount = ln.TargetGroup.HealthCheck.UnhealthyThresholdCount
 		}
-		if len(listener.Listeners) == 0 {
-			return nil, errors.New("no listener was created; the returned list is empty")
+	}
+	s.scope.Debug("creating target group", "group", targetGroupInput, "listener", ln)
+	group, err := s.ELBV2Client.CreateTargetGroupWithContext(ctx, targetGroupInput)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create target group for load balancer")
+	}
+	if len(group.TargetGroups) == 0 {
+		return errors.New("no target group was created; the returned list is empty")
+	}
+
+	if attributes := targetGroupAttributes(lbSpec); len(attributes) > 0 {
+		targetGroupAttributeInput := &elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
+			Attributes:     attributes,
+		}
+		if _, err := s.ELBV2Client.ModifyTargetGroupAttributesWithContext(ctx, targetGroupAttributeInput); err != nil {
+			return errors.Wrapf(err, "failed to modify target group attribute")
 		}
 	}
 
-	s.scope.Info("Created network load balancer", "dns-name", *out.LoadBalancers[0].DNSName)
+	listenerInput := &elbv2.CreateListenerInput{
+		DefaultActions: []*elbv2.Action{
+			{
+				TargetGroupArn: group.TargetGroups[0].TargetGroupArn,
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+			},
+		},
+		LoadBalancerArn: aws.String(lbARN),
+		Port:            aws.Int64(ln.Port),
+		Protocol:        aws.String(string(ln.Protocol)),
+		Tags:            converters.MapToV2Tags(tags),
+	}
+	// Create ClassicELBListeners
+	listener, err := s.ELBV2Client.CreateListenerWithContext(ctx, listenerInput)
+	if err != nil {
+		return errors.Wrap(err, "failed to create listener")
+	}
+	if len(listener.Listeners) == 0 {
+		return errors.New("no listener was created; the returned list is empty")
+	}
 
-	res := spec.DeepCopy()
-	s.scope.Debug("applying load balancer DNS to result", "dns", *out.LoadBalancers[0].DNSName)
-	res.DNSName = *out.LoadBalancers[0].DNSName
-	return res, nil
+	return nil
 }
 
-func (s *Service) describeLB(name string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
-	input := &elbv2.DescribeLoadBalancersInput{
-		Names: aws.StringSlice([]string{name}),
+// describeControlPlaneLB describes the control plane load balancer of a spec: the existing load balancer
+// referenced by its ARN, or the load balancer with the name of the spec.
+func (s *Service) describeControlPlaneLB(ctx context.Context, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+	if lbSpec.ARN != nil {
+		return s.describeLBByARN(ctx, *lbSpec.ARN, lbSpec)
 	}
 
-	out, err := s.ELBV2Client.DescribeLoadBalancers(input)
+	name, err := LBName(s.scope, lbSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get control plane load balancer name")
+	}
+	return s.describeLB(ctx, name, lbSpec)
+}
+
+func (s *Service) describeLB(ctx context.Context, name string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+	return s.describeLBWithInput(ctx, name, &elbv2.DescribeLoadBalancersInput{
+		Names: aws.StringSlice([]string{name}),
+	}, lbSpec)
+}
+
+// describeLBByARN describes the load balancer with the given ARN.
+func (s *Service) describeLBByARN(ctx context.Context, arn string, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+	return s.describeLBWithInput(ctx, arn, &elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: aws.StringSlice([]string{arn}),
+	}, lbSpec)
+}
+
+// describeLBWithInput describes the load balancer matching the input, identified by name in the errors.
+func (s *Service) describeLBWithInput(ctx context.Context, name string, input *elbv2.DescribeLoadBalancersInput, lbSpec *infrav1.AWSLoadBalancerSpec) (*infrav1.LoadBalancer, error) {
+	out, err := s.ELBV2Client.DescribeLoadBalancersWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -520,14 +631,14 @@ func (s *Service) describeLB(name string, lbSpec *infrav1.AWSLoadBalancerSpec) (
 			name, *out.LoadBalancers[0].Scheme)
 	}
 
-	outAtt, err := s.ELBV2Client.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+	outAtt, err := s.ELBV2Client.DescribeLoadBalancerAttributesWithContext(ctx, &elbv2.DescribeLoadBalancerAttributesInput{
 		LoadBalancerArn: out.LoadBalancers[0].LoadBalancerArn,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to describe load balancer %q attributes", name)
 	}
 
-	tags, err := s.describeLBTags(aws.StringValue(out.LoadBalancers[0].LoadBalancerArn))
+	tags, err := s.describeLBTags(ctx, aws.StringValue(out.LoadBalancers[0].LoadBalancerArn))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to describe load balancer tags")
 	}
@@ -535,7 +646,7 @@ func (s *Service) describeLB(name string, lbSpec *infrav1.AWSLoadBalancerSpec) (
 	return fromSDKTypeToLB(out.LoadBalancers[0], outAtt.Attributes, tags), nil
 }
 
-func (s *Service) reconcileClassicLoadBalancer() error {
+func (s *Service) reconcileClassicLoadBalancer(ctx context.Context) error {
 	// Generate a default control plane load balancer name. The load balancer name cannot be
 	// generated by the defaulting webhook, because it is derived from the cluster name, and that
 	// name is undefined at defaulting time when generateName is used.
@@ -545,18 +656,18 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	}
 
 	// Get default api server spec.
-	spec, err := s.getAPIServerClassicELBSpec(name)
+	spec, err := s.getAPIServerClassicELBSpec(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	apiELB, err := s.describeClassicELB(spec.Name)
+	apiELB, err := s.describeClassicELB(ctx, spec.Name)
 	switch {
 	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
 		// if elb is not found and owner cluster ControlPlaneEndpoint is already populated, then we should not recreate the elb.
 		return errors.Wrapf(err, "no loadbalancer exists for the AWSCluster %s, the cluster has become unrecoverable and should be deleted manually", s.scope.InfraClusterName())
 	case IsNotFound(err):
-		apiELB, err = s.createClassicELB(spec)
+		apiELB, err = s.createClassicELB(ctx, spec)
 		if err != nil {
 			return err
 		}
@@ -567,21 +678,30 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	}
 
 	if apiELB.IsManaged(s.scope.Name()) {
-		if !cmp.Equal(spec.ClassicElbAttributes, apiELB.ClassicElbAttributes) {
-			err := s.configureAttributes(apiELB.Name, spec.ClassicElbAttributes)
+		currentAttributes := apiELB.ClassicElbAttributes
+		if spec.ClassicElbAttributes.AccessLogs == nil {
+			// The access logs are left untouched when not configured.
+			currentAttributes.AccessLogs = nil
+		}
+		if !cmp.Equal(spec.ClassicElbAttributes, currentAttributes) {
+			if err := s.validateAccessLogsBucketPolicy(ctx, spec.ClassicElbAttributes.AccessLogs); err != nil {
+				return err
+			}
+			err := s.configureAttributes(ctx, apiELB.Name, spec.ClassicElbAttributes)
 			if err != nil {
 				return err
 			}
 		}
 
-		if err := s.reconcileELBTags(apiELB, spec.Tags); err != nil {
+		if err := s.reconcileELBTags(ctx, apiELB, spec.Tags); err != nil {
 			return errors.Wrapf(err, "failed to reconcile tags for apiserver load balancer %q", apiELB.Name)
 		}
 
 		// Reconcile the subnets and availability zones from the spec
 		// and the ones currently attached to the load balancer.
-		if len(apiELB.SubnetIDs) != len(spec.SubnetIDs) {
-			_, err := s.ELBClient.AttachLoadBalancerToSubnets(&elb.AttachLoadBalancerToSubnetsInput{
+		if len(apiELB.SubnetIDs) != len(spec.SubnetIDs) || s.attachesReplacedSubnets(apiELB.SubnetIDs, spec.SubnetIDs) {
+			// Attaching a subnet of an availability zone replaces the subnet previously attached in this zone.
+			_, err := s.ELBClient.AttachLoadBalancerToSubnetsWithContext(ctx, &elb.AttachLoadBalancerToSubnetsInput{
 				LoadBalancerName: &apiELB.Name,
 				Subnets:          aws.StringSlice(spec.SubnetIDs),
 			})
@@ -592,7 +712,7 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 
 		// Reconcile the security groups from the spec and the ones currently attached to the load balancer
 		if !sets.NewString(apiELB.SecurityGroupIDs...).Equal(sets.NewString(spec.SecurityGroupIDs...)) {
-			_, err := s.ELBClient.ApplySecurityGroupsToLoadBalancer(&elb.ApplySecurityGroupsToLoadBalancerInput{
+			_, err := s.ELBClient.ApplySecurityGroupsToLoadBalancerWithContext(ctx, &elb.ApplySecurityGroupsToLoadBalancerInput{
 				LoadBalancerName: &apiELB.Name,
 				SecurityGroups:   aws.StringSlice(spec.SecurityGroupIDs),
 			})
@@ -616,7 +736,7 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 	return nil
 }
 
-func (s *Service) deleteAPIServerELB() error {
+func (s *Service) deleteAPIServerELB(ctx context.Context) error {
 	s.scope.Debug("Deleting control plane load balancer")
 
 	elbName, err := ELBName(s.scope)
@@ -629,7 +749,7 @@ func (s *Service) deleteAPIServerELB() error {
 		return err
 	}
 
-	apiELB, err := s.describeClassicELB(elbName)
+	apiELB, err := s.describeClassicELB(ctx, elbName)
 	if IsNotFound(err) {
 		s.scope.Debug("Control plane load balancer not found, skipping deletion")
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
@@ -646,13 +766,13 @@ func (s *Service) deleteAPIServerELB() error {
 	}
 
 	s.scope.Debug("deleting load balancer", "name", elbName)
-	if err := s.deleteClassicELB(elbName); err != nil {
+	if err := s.deleteClassicELB(ctx, elbName); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 		return err
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (done bool, err error) {
-		_, err = s.describeClassicELB(elbName)
+		_, err = s.describeClassicELB(ctx, elbName)
 		done = IsNotFound(err)
 		return done, nil
 	}); err != nil {
@@ -668,23 +788,23 @@ func (s *Service) deleteAPIServerELB() error {
 // LoadBalancer-type Service on the cluster, there is one ELB. If the Service is deleted before the
 // cluster is deleted, its ELB is deleted; the ELBs found in this function will typically be for
 // Services that were not deleted before the cluster was deleted.
-func (s *Service) deleteAWSCloudProviderELBs() error {
+func (s *Service) deleteAWSCloudProviderELBs(ctx context.Context) error {
 	s.scope.Debug("Deleting AWS cloud provider load balancers (created for LoadBalancer-type Services)")
 
-	elbs, err := s.listAWSCloudProviderOwnedELBs()
+	elbs, err := s.listAWSCloudProviderOwnedELBs(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, elb := range elbs {
 		s.scope.Debug("Deleting AWS cloud provider load balancer", "arn", elb)
-		if err := s.deleteClassicELB(elb); err != nil {
+		if err := s.deleteClassicELB(ctx, elb); err != nil {
 			return err
 		}
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (done bool, err error) {
-		elbs, err := s.listAWSCloudProviderOwnedELBs()
+		elbs, err := s.listAWSCloudProviderOwnedELBs(ctx)
 		if err != nil {
 			return false, err
 		}
@@ -698,67 +818,84 @@ func (s *Service) deleteAWSCloudProviderELBs() error {
 }
 
 // DeleteLoadbalancers deletes the load balancers for the given cluster.
-func (s *Service) DeleteLoadbalancers() error {
+func (s *Service) DeleteLoadbalancers(ctx context.Context) error {
 	s.scope.Debug("Deleting load balancers")
 
-	if err := s.deleteAPIServerELB(); err != nil {
+	if err := s.deleteAPIServerELB(ctx); err != nil {
 		return errors.Wrap(err, "failed to delete control plane load balancer")
 	}
 
-	if err := s.deleteAWSCloudProviderELBs(); err != nil {
+	if err := s.deleteAWSCloudProviderELBs(ctx); err != nil {
 		return errors.Wrap(err, "failed to delete AWS cloud provider load balancer(s)")
 	}
 
-	if err := s.deleteExistingNLBs(); err != nil {
+	if err := s.deleteExistingNLBs(ctx); err != nil {
 		return errors.Wrap(err, "failed to delete AWS cloud provider load balancer(s)")
 	}
 
 	return nil
 }
 
-func (s *Service) deleteExistingNLBs() error {
+func (s *Service) deleteExistingNLBs(ctx context.Context) error {
 	errs := make([]error, 0)
 
 	for _, lbSpec := range s.scope.ControlPlaneLoadBalancers() {
 		if lbSpec == nil {
 			continue
 		}
-		errs = append(errs, s.deleteExistingNLB(lbSpec))
+		errs = append(errs, s.deleteExistingNLB(ctx, lbSpec))
 	}
 
 	return kerrors.NewAggregate(errs)
 }
 
-func (s *Service) deleteExistingNLB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
-	name, err := LBName(s.scope, lbSpec)
-	if err != nil {
-		return errors.Wrap(err, "failed to get control plane load balancer name")
-	}
+func (s *Service) deleteExistingNLB(ctx context.Context, lbSpec *infrav1.AWSLoadBalancerSpec) error {
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
 		return err
 	}
 
-	lb, err := s.describeLB(name, lbSpec)
+	lb, err := s.describeControlPlaneLB(ctx, lbSpec)
 	if IsNotFound(err) {
 		return nil
 	}
 	if err != nil {
 		return err
 	}
+	name := lb.Name
+
+	// An existing load balancer is never deleted, only the listeners created for the cluster are.
+	if lbSpec.ARN != nil {
+		return s.deleteOwnedListeners(ctx, lb)
+	}
 
 	if lb.IsUnmanaged(s.scope.Name()) {
 		s.scope.Debug("Found unmanaged load balancer for apiserver, skipping deletion", "api-server-elb-name", lb.Name)
 		return nil
 	}
+
+	if aws.StringValue(lb.ELBAttributes[infrav1.LoadBalancerAttributeDeletionProtection]) == "true" {
+		// The deletion protection is only lifted when the whole cluster goes away, so that a load balancer
+		// still in use is never deleted.
+		if s.scope.InfraCluster().GetDeletionTimestamp().IsZero() {
+			return errors.Errorf("control plane load balancer %q has deletion protection enabled and the cluster is not being deleted", name)
+		}
+		s.scope.Debug("disabling deletion protection of load balancer", "name", name)
+		if err := s.configureLBAttributes(ctx, lb.ARN, map[string]*string{
+			infrav1.LoadBalancerAttributeDeletionProtection: aws.String("false"),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to disable deletion protection of load balancer %q", name)
+		}
+	}
+
 	s.scope.Debug("deleting load balancer", "name", name)
-	if err := s.deleteLB(lb.ARN); err != nil {
+	if err := s.deleteLB(ctx, lb.ARN); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
 		return err
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (done bool, err error) {
-		_, err = s.describeLB(name, lbSpec)
+		_, err = s.describeLB(ctx, name, lbSpec)
 		done = IsNotFound(err)
 		return done, nil
 	}); err != nil {
@@ -772,7 +909,7 @@ func (s *Service) deleteExistingNLB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 }
 
 // IsInstanceRegisteredWithAPIServerELB returns true if the instance is already registered with the APIServer ELB.
-func (s *Service) IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error) {
+func (s *Service) IsInstanceRegisteredWithAPIServerELB(ctx context.Context, i *infrav1.Instance) (bool, error) {
 	name, err := ELBName(s.scope)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get control plane load balancer name")
@@ -782,7 +919,7 @@ func (s *Service) IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (boo
 		LoadBalancerNames: []*string{aws.String(name)},
 	}
 
-	output, err := s.ELBClient.DescribeLoadBalancers(input)
+	output, err := s.ELBClient.DescribeLoadBalancersWithContext(ctx, input)
 	if err != nil {
 		return false, errors.Wrapf(err, "error describing ELB %q", name)
 	}
@@ -800,62 +937,122 @@ func (s *Service) IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (boo
 }
 
 // IsInstanceRegisteredWithAPIServerLB returns true if the instance is already registered with the APIServer LB.
-func (s *Service) IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error) {
-	name, err := LBName(s.scope, lb)
+func (s *Service) IsInstanceRegisteredWithAPIServerLB(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error) {
+	targets, err := s.describeInstanceTargets(ctx, i, lb)
 	if err != nil {
-		return nil, false, errors.Wrap(err, "failed to get control plane load balancer name")
+		return nil, false, err
 	}
 
-	input := &elbv2.DescribeLoadBalancersInput{
-		Names: []*string{aws.String(name)},
+	targetGroupARNs := []string{}
+	for _, target := range targets {
+		// A draining target is already being de-registered.
+		if target.isDraining() {
+			continue
+		}
+		targetGroupARNs = append(targetGroupARNs, target.targetGroupARN)
+	}
+	if len(targetGroupARNs) > 0 {
+		return targetGroupARNs, true, nil
 	}
 
-	output, err := s.ELBV2Client.DescribeLoadBalancers(input)
+	return nil, false, nil
+}
+
+// IsInstanceDrainingFromAPIServerLB returns true if the connections of an instance de-registered from the target groups
+// of the APIServer LB are still being drained.
+func (s *Service) IsInstanceDrainingFromAPIServerLB(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) (bool, error) {
+	targets, err := s.describeInstanceTargets(ctx, i, lb)
 	if err != nil {
-		return nil, false, errors.Wrapf(err, "error describing ELB %q", name)
+		return false, err
+	}
+
+	for _, target := range targets {
+		if target.isDraining() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// instanceTarget is the health of an instance registered with a target group.
+type instanceTarget struct {
+	targetGroupARN string
+	health         *elbv2.TargetHealth
+}
+
+// isDraining returns true if the connections of the de-registered target are being drained.
+func (t instanceTarget) isDraining() bool {
+	return t.health != nil && aws.StringValue(t.health.State) == elbv2.TargetHealthStateEnumDraining
+}
+
+// describeInstanceTargets describes the health of an instance in the target groups of the APIServer LB it is registered
+// with, including the target groups it is being de-registered from.
+func (s *Service) describeInstanceTargets(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]instanceTarget, error) {
+	var name string
+	input := &elbv2.DescribeLoadBalancersInput{}
+	if lb.ARN != nil {
+		name = *lb.ARN
+		input.LoadBalancerArns = []*string{lb.ARN}
+	} else {
+		var err error
+		name, err = LBName(s.scope, lb)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get control plane load balancer name")
+		}
+		input.Names = []*string{aws.String(name)}
+	}
+
+	output, err := s.ELBV2Client.DescribeLoadBalancersWithContext(ctx, input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error describing ELB %q", name)
 	}
 	if len(output.LoadBalancers) != 1 {
-		return nil, false, errors.Errorf("expected 1 ELB description for %q, got %d", name, len(output.LoadBalancers))
+		return nil, errors.Errorf("expected 1 ELB description for %q, got %d", name, len(output.LoadBalancers))
 	}
 
 	describeTargetGroupInput := &elbv2.DescribeTargetGroupsInput{
 		LoadBalancerArn: output.LoadBalancers[0].LoadBalancerArn,
 	}
 
-	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(describeTargetGroupInput)
+	targetGroups, err := s.apiServerTargetGroups(ctx, describeTargetGroupInput, lb)
 	if err != nil {
-		return nil, false, errors.Wrapf(err, "error describing ELB's target groups %q", name)
+		return nil, errors.Wrapf(err, "error describing ELB's target groups %q", name)
 	}
 
-	targetGroupARNs := []string{}
-	for _, tg := range targetGroups.TargetGroups {
+	targets := []instanceTarget{}
+	for _, tg := range targetGroups {
 		healthInput := &elbv2.DescribeTargetHealthInput{
 			TargetGroupArn: tg.TargetGroupArn,
 		}
-		instanceHealth, err := s.ELBV2Client.DescribeTargetHealth(healthInput)
+		instanceHealth, err := s.ELBV2Client.DescribeTargetHealthWithContext(ctx, healthInput)
 		if err != nil {
-			return nil, false, errors.Wrapf(err, "error describing ELB's target groups health %q", name)
+			return nil, errors.Wrapf(err, "error describing ELB's target groups health %q", name)
 		}
-		for _, id := range instanceHealth.TargetHealthDescriptions {
-			if aws.StringValue(id.Target.Id) == i.ID {
-				targetGroupARNs = append(targetGroupARNs, aws.StringValue(tg.TargetGroupArn))
+		id, err := targetID(tg, i)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range instanceHealth.TargetHealthDescriptions {
+			if aws.StringValue(target.Target.Id) == id {
+				targets = append(targets, instanceTarget{
+					targetGroupARN: aws.StringValue(tg.TargetGroupArn),
+					health:         target.TargetHealth,
+				})
 			}
 		}
 	}
-	if len(targetGroupARNs) > 0 {
-		return targetGroupARNs, true, nil
-	}
 
-	return nil, false, nil
+	return targets, nil
 }
 
 // RegisterInstanceWithAPIServerELB registers an instance with a classic ELB.
-func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
+func (s *Service) RegisterInstanceWithAPIServerELB(ctx context.Context, i *infrav1.Instance) error {
 	name, err := ELBName(s.scope)
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
 	}
-	out, err := s.describeClassicELB(name)
+	out, err := s.describeClassicELB(ctx, name)
 	if err != nil {
 		return err
 	}
@@ -869,7 +1066,7 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 	instanceAZ := instanceSubnet.AvailabilityZone
 
 	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
-		subnets, err = s.getControlPlaneLoadBalancerSubnets()
+		subnets, err = s.getControlPlaneLoadBalancerSubnets(ctx)
 		if err != nil {
 			return err
 		}
@@ -891,46 +1088,47 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 		LoadBalancerName: aws.String(name),
 	}
 
-	_, err = s.ELBClient.RegisterInstancesWithLoadBalancer(input)
+	_, err = s.ELBClient.RegisterInstancesWithLoadBalancerWithContext(ctx, input)
 	return err
 }
 
 // RegisterInstanceWithAPIServerLB registers an instance with a LB.
-func (s *Service) RegisterInstanceWithAPIServerLB(instance *infrav1.Instance, lbSpec *infrav1.AWSLoadBalancerSpec) error {
-	name, err := LBName(s.scope, lbSpec)
-	if err != nil {
-		return errors.Wrap(err, "failed to get control plane load balancer name")
-	}
-	out, err := s.describeLB(name, lbSpec)
+func (s *Service) RegisterInstanceWithAPIServerLB(ctx context.Context, instance *infrav1.Instance, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	out, err := s.describeControlPlaneLB(ctx, lbSpec)
 	if err != nil {
 		return err
 	}
+	name := out.Name
 	s.scope.Debug("found load balancer with name", "name", out.Name)
 	describeTargetGroupInput := &elbv2.DescribeTargetGroupsInput{
 		LoadBalancerArn: aws.String(out.ARN),
 	}
 
-	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(describeTargetGroupInput)
+	targetGroups, err := s.apiServerTargetGroups(ctx, describeTargetGroupInput, lbSpec)
 	if err != nil {
 		return errors.Wrapf(err, "error describing ELB's target groups %q", name)
 	}
-	if len(targetGroups.TargetGroups) == 0 {
+	if len(targetGroups) == 0 {
 		return fmt.Errorf("no target groups found for load balancer with arn '%s'", out.ARN)
 	}
 	// Since TargetGroups and Listeners don't care, or are not aware, of subnets before registration, we ignore that check.
 	// Also, registering with AZ is not supported using the an InstanceID.
-	s.scope.Debug("found number of target groups", "target-groups", len(targetGroups.TargetGroups))
-	for _, tg := range targetGroups.TargetGroups {
+	s.scope.Debug("found number of target groups", "target-groups", len(targetGroups))
+	for _, tg := range targetGroups {
+		id, err := targetID(tg, instance)
+		if err != nil {
+			return err
+		}
 		input := &elbv2.RegisterTargetsInput{
 			TargetGroupArn: tg.TargetGroupArn,
 			Targets: []*elbv2.TargetDescription{
 				{
-					Id:   aws.String(instance.ID),
+					Id:   aws.String(id),
 					Port: tg.Port,
 				},
 			},
 		}
-		if _, err = s.ELBV2Client.RegisterTargets(input); err != nil {
+		if _, err = s.ELBV2Client.RegisterTargetsWithContext(ctx, input); err != nil {
 			return fmt.Errorf("failed to register instance with target group '%s': %w", aws.StringValue(tg.TargetGroupName), err)
 		}
 	}
@@ -938,14 +1136,43 @@ func (s *Service) RegisterInstanceWithAPIServerLB(instance *infrav1.Instance, lb
 	return nil
 }
 
+// apiServerTargetGroups returns the target groups the control plane instances are registered with: all the
+// target groups of the load balancer, or only the ones owned by the cluster for an existing load balancer.
+func (s *Service) apiServerTargetGroups(ctx context.Context, input *elbv2.DescribeTargetGroupsInput, lbSpec *infrav1.AWSLoadBalancerSpec) ([]*elbv2.TargetGroup, error) {
+	out, err := s.ELBV2Client.DescribeTargetGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if lbSpec.ARN == nil {
+		return out.TargetGroups, nil
+	}
+
+	return s.filterOwnedTargetGroups(ctx, out.TargetGroups)
+}
+
 // getControlPlaneLoadBalancerSubnets retrieves ControlPlaneLoadBalancer subnets information.
-func (s *Service) getControlPlaneLoadBalancerSubnets() (infrav1.Subnets, error) {
+// attachesReplacedSubnets returns true if the subnets currently attached to a load balancer include a subnet
+// which is replaced by another subnet of the cluster, and which the desired subnets don't include anymore.
+func (s *Service) attachesReplacedSubnets(attached, desired []string) bool {
+	subnets := s.scope.Subnets()
+	for _, id := range attached {
+		if slices.Contains(desired, id) {
+			continue
+		}
+		if sn := subnets.FindByID(id); sn != nil && subnets.IsReplaced(sn) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) getControlPlaneLoadBalancerSubnets(ctx context.Context) (infrav1.Subnets, error) {
 	var subnets infrav1.Subnets
 
 	input := &ec2.DescribeSubnetsInput{
 		SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
 	}
-	res, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+	res, err := s.EC2Client.DescribeSubnetsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -963,7 +1190,7 @@ func (s *Service) getControlPlaneLoadBalancerSubnets() (infrav1.Subnets, error)
 }
 
 // DeregisterInstanceFromAPIServerELB de-registers an instance from a classic ELB.
-func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error {
+func (s *Service) DeregisterInstanceFromAPIServerELB(ctx context.Context, i *infrav1.Instance) error {
 	name, err := ELBName(s.scope)
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
@@ -974,7 +1201,7 @@ func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
 		LoadBalancerName: aws.String(name),
 	}
 
-	_, err = s.ELBClient.DeregisterInstancesFromLoadBalancer(input)
+	_, err = s.ELBClient.DeregisterInstancesFromLoadBalancerWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -990,17 +1217,35 @@ func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
 }
 
 // DeregisterInstanceFromAPIServerLB de-registers an instance from a LB.
-func (s *Service) DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *infrav1.Instance) error {
+func (s *Service) DeregisterInstanceFromAPIServerLB(ctx context.Context, targetGroupArn string, i *infrav1.Instance) error {
+	// The target type of the target group determines whether the instance is registered by ID or by IP address.
+	out, err := s.ELBV2Client.DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: aws.StringSlice([]string{targetGroupArn}),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+			return nil
+		}
+		return err
+	}
+	if len(out.TargetGroups) == 0 {
+		return nil
+	}
+	id, err := targetID(out.TargetGroups[0], i)
+	if err != nil {
+		return err
+	}
+
 	input := &elbv2.DeregisterTargetsInput{
 		TargetGroupArn: aws.String(targetGroupArn),
 		Targets: []*elbv2.TargetDescription{
 			{
-				Id: aws.String(i.ID),
+				Id: aws.String(id),
 			},
 		},
 	}
 
-	_, err := s.ELBV2Client.DeregisterTargets(input)
+	_, err = s.ELBV2Client.DeregisterTargetsWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -1084,7 +1329,7 @@ func generateHashedELBName(clusterName string) (string, error) {
 	return fmt.Sprintf("%s-%s", shortName, "k8s"), nil
 }
 
-func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalancer, error) {
+func (s *Service) getAPIServerClassicELBSpec(ctx context.Context, elbName string) (*infrav1.LoadBalancer, error) {
 	securityGroupIDs := []string{}
 	controlPlaneLoadBalancer := s.scope.ControlPlaneLoadBalancer()
 	if controlPlaneLoadBalancer != nil && len(controlPlaneLoadBalancer.AdditionalSecurityGroups) != 0 {
@@ -1123,6 +1368,7 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 
 	if s.scope.ControlPlaneLoadBalancer() != nil {
 		res.ClassicElbAttributes.CrossZoneLoadBalancing = s.scope.ControlPlaneLoadBalancer().CrossZoneLoadBalancing
+		res.ClassicElbAttributes.AccessLogs = normalizeAccessLogs(s.scope.ControlPlaneLoadBalancer().AccessLogs)
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
@@ -1140,7 +1386,7 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 		input := &ec2.DescribeSubnetsInput{
 			SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
 		}
-		out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+		out, err := s.EC2Client.DescribeSubnetsWithContext(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -1150,10 +1396,10 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 		}
 	} else {
 		// The load balancer APIs require us to only attach one subnet for each AZ.
-		subnets := s.scope.Subnets().FilterPrivate()
+		subnets := s.scope.Subnets().WithoutReplaced().WithoutIPv6Native().FilterPrivate()
 
 		if scheme == infrav1.ELBSchemeInternetFacing {
-			subnets = s.scope.Subnets().FilterPublic()
+			subnets = s.scope.Subnets().WithoutReplaced().WithoutIPv6Native().FilterPublic()
 		}
 
 	subnetLoop:
@@ -1173,7 +1419,7 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 	return res, nil
 }
 
-func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBalancer, error) {
+func (s *Service) createClassicELB(ctx context.Context, spec *infrav1.LoadBalancer) (*infrav1.LoadBalancer, error) {
 	input := &elb.CreateLoadBalancerInput{
 		LoadBalancerName: aws.String(spec.Name),
 		Subnets:          aws.StringSlice(spec.SubnetIDs),
@@ -1191,14 +1437,14 @@ func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBal
 		})
 	}
 
-	out, err := s.ELBClient.CreateLoadBalancer(input)
+	out, err := s.ELBClient.CreateLoadBalancerWithContext(ctx, input)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create classic load balancer: %v", spec)
 	}
 
 	if spec.HealthCheck != nil {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.ELBClient.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+			if _, err := s.ELBClient.ConfigureHealthCheckWithContext(ctx, &elb.ConfigureHealthCheckInput{
 				LoadBalancerName: aws.String(spec.Name),
 				HealthCheck: &elb.HealthCheck{
 					Target:             aws.String(spec.HealthCheck.Target),
@@ -1223,7 +1469,7 @@ func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBal
 	return res, nil
 }
 
-func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELBAttributes) error {
+func (s *Service) configureAttributes(ctx context.Context, name string, attributes infrav1.ClassicELBAttributes) error {
 	attrs := &elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerName: aws.String(name),
 		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
@@ -1239,8 +1485,18 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 		}
 	}
 
+	if attributes.AccessLogs != nil {
+		attrs.LoadBalancerAttributes.AccessLog = &elb.AccessLog{
+			Enabled: aws.Bool(attributes.AccessLogs.Enabled),
+		}
+		if attributes.AccessLogs.Enabled {
+			attrs.LoadBalancerAttributes.AccessLog.S3BucketName = aws.String(attributes.AccessLogs.Bucket)
+			attrs.LoadBalancerAttributes.AccessLog.S3BucketPrefix = aws.String(attributes.AccessLogs.Prefix)
+		}
+	}
+
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		if _, err := s.ELBClient.ModifyLoadBalancerAttributes(attrs); err != nil {
+		if _, err := s.ELBClient.ModifyLoadBalancerAttributesWithContext(ctx, attrs); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -1251,12 +1507,18 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 	return nil
 }
 
-func (s *Service) configureLBAttributes(arn string, attributes map[string]*string) error {
-	attrs := make([]*elbv2.LoadBalancerAttribute, 0)
-	for k, v := range attributes {
+func (s *Service) configureLBAttributes(ctx context.Context, arn string, attributes map[string]*string) error {
+	// The attributes are sorted by key, so that the request doesn't depend on the iteration order of the map.
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]*elbv2.LoadBalancerAttribute, 0, len(keys))
+	for _, k := range keys {
 		attrs = append(attrs, &elbv2.LoadBalancerAttribute{
 			Key:   aws.String(k),
-			Value: v,
+			Value: attributes[k],
 		})
 	}
 	s.scope.Debug("adding attributes to load balancer", "attrs", attrs)
@@ -1266,7 +1528,7 @@ func (s *Service) configureLBAttributes(arn string, attributes map[string]*strin
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		if _, err := s.ELBV2Client.ModifyLoadBalancerAttributes(modifyInput); err != nil {
+		if _, err := s.ELBV2Client.ModifyLoadBalancerAttributesWithContext(ctx, modifyInput); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -1276,12 +1538,12 @@ func (s *Service) configureLBAttributes(arn string, attributes map[string]*strin
 	return nil
 }
 
-func (s *Service) deleteClassicELB(name string) error {
+func (s *Service) deleteClassicELB(ctx context.Context, name string) error {
 	input := &elb.DeleteLoadBalancerInput{
 		LoadBalancerName: aws.String(name),
 	}
 
-	if _, err := s.ELBClient.DeleteLoadBalancer(input); err != nil {
+	if _, err := s.ELBClient.DeleteLoadBalancerWithContext(ctx, input); err != nil {
 		return err
 	}
 
@@ -1289,18 +1551,18 @@ func (s *Service) deleteClassicELB(name string) error {
 	return nil
 }
 
-func (s *Service) deleteLB(arn string) error {
+func (s *Service) deleteLB(ctx context.Context, arn string) error {
 	// remove listeners and target groups
 	// Order is important. ClassicELBListeners have to be deleted first.
 	// However, we must first gather the groups because after the listeners are deleted the groups
 	// are no longer associated with the LB, so we can't describe them afterwards.
-	groups, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+	groups, err := s.ELBV2Client.DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
 		LoadBalancerArn: aws.String(arn),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to gather target groups for LB: %w", err)
 	}
-	listeners, err := s.ELBV2Client.DescribeListeners(&elbv2.DescribeListenersInput{
+	listeners, err := s.ELBV2Client.DescribeListenersWithContext(ctx, &elbv2.DescribeListenersInput{
 		LoadBalancerArn: aws.String(arn),
 	})
 	if err != nil {
@@ -1311,7 +1573,7 @@ func (s *Service) deleteLB(arn string) error {
 		deleteListener := &elbv2.DeleteListenerInput{
 			ListenerArn: listener.ListenerArn,
 		}
-		if _, err := s.ELBV2Client.DeleteListener(deleteListener); err != nil {
+		if _, err := s.ELBV2Client.DeleteListenerWithContext(ctx, deleteListener); err != nil {
 			return fmt.Errorf("failed to delete listener '%s': %w", aws.StringValue(listener.ListenerArn), err)
 		}
 	}
@@ -1322,7 +1584,7 @@ func (s *Service) deleteLB(arn string) error {
 		deleteTargetGroup := &elbv2.DeleteTargetGroupInput{
 			TargetGroupArn: group.TargetGroupArn,
 		}
-		if _, err := s.ELBV2Client.DeleteTargetGroup(deleteTargetGroup); err != nil {
+		if _, err := s.ELBV2Client.DeleteTargetGroupWithContext(ctx, deleteTargetGroup); err != nil {
 			return fmt.Errorf("failed to delete target group '%s': %w", aws.StringValue(group.TargetGroupName), err)
 		}
 	}
@@ -1333,7 +1595,7 @@ func (s *Service) deleteLB(arn string) error {
 		LoadBalancerArn: aws.String(arn),
 	}
 
-	if _, err := s.ELBV2Client.DeleteLoadBalancer(deleteLoadBalancerInput); err != nil {
+	if _, err := s.ELBV2Client.DeleteLoadBalancerWithContext(ctx, deleteLoadBalancerInput); err != nil {
 		return err
 	}
 
@@ -1341,7 +1603,7 @@ func (s *Service) deleteLB(arn string) error {
 	return nil
 }
 
-func (s *Service) listByTag(tag string) ([]string, error) {
+func (s *Service) listByTag(ctx context.Context, tag string) ([]string, error) {
 	input := rgapi.GetResourcesInput{
 		ResourceTypeFilters: aws.StringSlice([]string{elbResourceType}),
 		TagFilters: []*rgapi.TagFilter{
@@ -1354,7 +1616,7 @@ func (s *Service) listByTag(tag string) ([]string, error) {
 
 	names := []string{}
 
-	err := s.ResourceTaggingClient.GetResourcesPages(&input, func(r *rgapi.GetResourcesOutput, last bool) bool {
+	err := s.ResourceTaggingClient.GetResourcesPagesWithContext(ctx, &input, func(r *rgapi.GetResourcesOutput, last bool) bool {
 		for _, tagmapping := range r.ResourceTagMappingList {
 			if tagmapping.ResourceARN == nil {
 				continue
@@ -1389,9 +1651,9 @@ func (s *Service) listByTag(tag string) ([]string, error) {
 	return names, nil
 }
 
-func (s *Service) filterByOwnedTag(tagKey string) ([]string, error) {
+func (s *Service) filterByOwnedTag(ctx context.Context, tagKey string) ([]string, error) {
 	var names []string
-	err := s.ELBClient.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(r *elb.DescribeLoadBalancersOutput, last bool) bool {
+	err := s.ELBClient.DescribeLoadBalancersPagesWithContext(ctx, &elb.DescribeLoadBalancersInput{}, func(r *elb.DescribeLoadBalancersOutput, last bool) bool {
 		for _, lb := range r.LoadBalancerDescriptions {
 			names = append(names, *lb.LoadBalancerName)
 		}
@@ -1408,7 +1670,7 @@ func (s *Service) filterByOwnedTag(tagKey string) ([]string, error) {
 	var ownedElbs []string
 	lbChunks := chunkELBs(names)
 	for _, chunk := range lbChunks {
-		output, err := s.ELBClient.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice(chunk)})
+		output, err := s.ELBClient.DescribeTagsWithContext(ctx, &elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice(chunk)})
 		if err != nil {
 			return nil, err
 		}
@@ -1424,13 +1686,13 @@ func (s *Service) filterByOwnedTag(tagKey string) ([]string, error) {
 	return ownedElbs, nil
 }
 
-func (s *Service) listAWSCloudProviderOwnedELBs() ([]string, error) {
+func (s *Service) listAWSCloudProviderOwnedELBs(ctx context.Context) ([]string, error) {
 	// k8s.io/cluster/<name>, created by k/k cloud provider
 	serviceTag := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())
-	arns, err := s.listByTag(serviceTag)
+	arns, err := s.listByTag(ctx, serviceTag)
 	if err != nil {
 		// retry by listing all ELBs as listByTag will fail in air-gapped environments
-		arns, err = s.filterByOwnedTag(serviceTag)
+		arns, err = s.filterByOwnedTag(ctx, serviceTag)
 		if err != nil {
 			return nil, err
 		}
@@ -1439,12 +1701,12 @@ func (s *Service) listAWSCloudProviderOwnedELBs() ([]string, error) {
 	return arns, nil
 }
 
-func (s *Service) describeClassicELB(name string) (*infrav1.LoadBalancer, error) {
+func (s *Service) describeClassicELB(ctx context.Context, name string) (*infrav1.LoadBalancer, error) {
 	input := &elb.DescribeLoadBalancersInput{
 		LoadBalancerNames: aws.StringSlice([]string{name}),
 	}
 
-	out, err := s.ELBClient.DescribeLoadBalancers(input)
+	out, err := s.ELBClient.DescribeLoadBalancersWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -1478,14 +1740,14 @@ func (s *Service) describeClassicELB(name string) (*infrav1.LoadBalancer, error)
 			name, *out.LoadBalancerDescriptions[0].Scheme)
 	}
 
-	outAtt, err := s.ELBClient.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{
+	outAtt, err := s.ELBClient.DescribeLoadBalancerAttributesWithContext(ctx, &elb.DescribeLoadBalancerAttributesInput{
 		LoadBalancerName: aws.String(name),
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to describe classic load balancer %q attributes", name)
 	}
 
-	tags, err := s.describeClassicELBTags(name)
+	tags, err := s.describeClassicELBTags(ctx, name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to describe classic load balancer tags")
 	}
@@ -1493,8 +1755,8 @@ func (s *Service) describeClassicELB(name string) (*infrav1.LoadBalancer, error)
 	return fromSDKTypeToClassicELB(out.LoadBalancerDescriptions[0], outAtt.LoadBalancerAttributes, tags), nil
 }
 
-func (s *Service) describeClassicELBTags(name string) ([]*elb.Tag, error) {
-	output, err := s.ELBClient.DescribeTags(&elb.DescribeTagsInput{
+func (s *Service) describeClassicELBTags(ctx context.Context, name string) ([]*elb.Tag, error) {
+	output, err := s.ELBClient.DescribeTagsWithContext(ctx, &elb.DescribeTagsInput{
 		LoadBalancerNames: []*string{aws.String(name)},
 	})
 	if err != nil {
@@ -1508,8 +1770,8 @@ func (s *Service) describeClassicELBTags(name string) ([]*elb.Tag, error) {
 	return output.TagDescriptions[0].Tags, nil
 }
 
-func (s *Service) describeLBTags(arn string) ([]*elbv2.Tag, error) {
-	output, err := s.ELBV2Client.DescribeTags(&elbv2.DescribeTagsInput{
+func (s *Service) describeLBTags(ctx context.Context, arn string) ([]*elbv2.Tag, error) {
+	output, err := s.ELBV2Client.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{
 		ResourceArns: []*string{aws.String(arn)},
 	})
 	if err != nil {
@@ -1523,7 +1785,7 @@ func (s *Service) describeLBTags(arn string) ([]*elbv2.Tag, error) {
 	return output.TagDescriptions[0].Tags, nil
 }
 
-func (s *Service) reconcileELBTags(lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
+func (s *Service) reconcileELBTags(ctx context.Context, lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
 	addTagsInput := &elb.AddTagsInput{
 		LoadBalancerNames: []*string{aws.String(lb.Name)},
 	}
@@ -1549,13 +1811,13 @@ func (s *Service) reconcileELBTags(lb *infrav1.LoadBalancer, desiredTags map[str
 	}
 
 	if len(addTagsInput.Tags) > 0 {
-		if _, err := s.ELBClient.AddTags(addTagsInput); err != nil {
+		if _, err := s.ELBClient.AddTagsWithContext(ctx, addTagsInput); err != nil {
 			return err
 		}
 	}
 
 	if len(removeTagsInput.Tags) > 0 {
-		if _, err := s.ELBClient.RemoveTags(removeTagsInput); err != nil {
+		if _, err := s.ELBClient.RemoveTagsWithContext(ctx, removeTagsInput); err != nil {
 			return err
 		}
 	}
@@ -1563,7 +1825,7 @@ func (s *Service) reconcileELBTags(lb *infrav1.LoadBalancer, desiredTags map[str
 	return nil
 }
 
-func (s *Service) reconcileV2LBTags(lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
+func (s *Service) reconcileV2LBTags(ctx context.Context, lb *infrav1.LoadBalancer, desiredTags map[string]string) error {
 	addTagsInput := &elbv2.AddTagsInput{
 		ResourceArns: []*string{aws.String(lb.ARN)},
 	}
@@ -1589,13 +1851,13 @@ func (s *Service) reconcileV2LBTags(lb *infrav1.LoadBalancer, desiredTags map[st
 	}
 
 	if len(addTagsInput.Tags) > 0 {
-		if _, err := s.ELBV2Client.AddTags(addTagsInput); err != nil {
+		if _, err := s.ELBV2Client.AddTagsWithContext(ctx, addTagsInput); err != nil {
 			return err
 		}
 	}
 
 	if len(removeTagsInput.TagKeys) > 0 {
-		if _, err := s.ELBV2Client.RemoveTags(removeTagsInput); err != nil {
+		if _, err := s.ELBV2Client.RemoveTagsWithContext(ctx, removeTagsInput); err != nil {
 			return err
 		}
 	}
@@ -1632,6 +1894,14 @@ func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBala
 
 	res.ClassicElbAttributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
 
+	if attrs.AccessLog != nil {
+		res.ClassicElbAttributes.AccessLogs = normalizeAccessLogs(&infrav1.LoadBalancerAccessLogs{
+			Enabled: aws.BoolValue(attrs.AccessLog.Enabled),
+			Bucket:  aws.StringValue(attrs.AccessLog.S3BucketName),
+			Prefix:  aws.StringValue(attrs.AccessLog.S3BucketPrefix),
+		})
+	}
+
 	return res
 }
 
@@ -1651,6 +1921,7 @@ func fromSDKTypeToLB(v *elbv2.LoadBalancer, attrs []*elbv2.LoadBalancerAttribute
 		AvailabilityZones: aws.StringValueSlice(availabilityZones),
 		DNSName:           aws.StringValue(v.DNSName),
 		Tags:              converters.V2TagsToMap(tags),
+		IPAddressType:     infrav1.LoadBalancerIPAddressType(aws.StringValue(v.IpAddressType)),
 	}
 
 	infraAttrs := make(map[string]*string, len(attrs))
@@ -22,9 +22,11 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -70,6 +72,7 @@ func TestELBName(t *testing.T) {
 					Name:      "example",
 					Namespace: metav1.NamespaceDefault,
 				},
+				Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
 			},
 			expected: "example-apiserver",
 		},
@@ -81,6 +84,7 @@ func TestELBName(t *testing.T) {
 					Namespace: metav1.NamespaceDefault,
 				},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name: ptr.To[string]("myapiserver"),
 					},
@@ -288,6 +292,7 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:                   "us-east-1",
 						ControlPlaneLoadBalancer: tc.lb,
 					},
 				},
@@ -303,7 +308,7 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				EC2Client: ec2Mock,
 			}
 
-			spec, err := s.getAPIServerClassicELBSpec(clusterScope.Name())
+			spec, err := s.getAPIServerClassicELBSpec(context.TODO(), clusterScope.Name())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -344,6 +349,29 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "load balancer config with deletion protection enabled",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				DeletionProtection: true,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeDeletionProtection, aws.String("true")))
+			},
+		},
+		{
+			name: "load balancer config with a DNS client routing policy",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:       infrav1.LoadBalancerTypeNLB,
+				DNSClientRoutingPolicy: infrav1.DNSClientRoutingPolicyAvailabilityZoneAffinity,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeDNSClientRoutingPolicy, aws.String("availability_zone_affinity")))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -448,6 +476,7 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:                   "us-east-1",
 						ControlPlaneLoadBalancer: tc.lb,
 					},
 				},
@@ -463,7 +492,7 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				EC2Client: ec2Mock,
 			}
 
-			spec, err := s.getAPIServerLBSpec(clusterScope.Name(), clusterScope.ControlPlaneLoadBalancer())
+			spec, err := s.getAPIServerLBSpec(context.TODO(), clusterScope.Name(), clusterScope.ControlPlaneLoadBalancer())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -473,6 +502,72 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 	}
 }
 
+func TestGetAPIServerV2ELBSpecSecondaryControlPlaneLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				Region: "us-east-1",
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					Scheme:           &infrav1.ELBSchemeInternetFacing,
+					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				},
+				SecondaryControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					Name:                aws.String("bar-internal"),
+					Scheme:              &infrav1.ELBSchemeInternal,
+					LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+					HealthCheckProtocol: &infrav1.ELBProtocolHTTPS,
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+						{ID: "subnet-private", AvailabilityZone: "us-east-1a"},
+					},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupAPIServerLB:          {ID: "sg-primary"},
+						infrav1.SecurityGroupSecondaryAPIServerLB: {ID: "sg-secondary"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := &Service{
+		scope: clusterScope,
+	}
+
+	primary, err := s.getAPIServerLBSpec(context.TODO(), "bar-apiserver", clusterScope.ControlPlaneLoadBalancers()[0])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(primary.Scheme).To(Equal(infrav1.ELBSchemeInternetFacing))
+	g.Expect(primary.SubnetIDs).To(Equal([]string{"subnet-public"}))
+	g.Expect(primary.SecurityGroupIDs).To(Equal([]string{"sg-primary"}))
+	g.Expect(primary.ELBListeners[0].TargetGroup.HealthCheck.Protocol).To(Equal(aws.String("TCP")))
+
+	secondary, err := s.getAPIServerLBSpec(context.TODO(), "bar-internal", clusterScope.ControlPlaneLoadBalancers()[1])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(secondary.Scheme).To(Equal(infrav1.ELBSchemeInternal))
+	g.Expect(secondary.SubnetIDs).To(Equal([]string{"subnet-private"}))
+	g.Expect(secondary.SecurityGroupIDs).To(Equal([]string{"sg-secondary"}))
+	g.Expect(secondary.ELBListeners[0].TargetGroup.HealthCheck.Protocol).To(Equal(aws.String("HTTPS")))
+}
+
 func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 	const (
 		namespace       = "foo"
@@ -497,6 +592,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name: aws.String(elbName),
 					},
@@ -509,7 +605,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 				},
 			},
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancersInput{
 					LoadBalancerNames: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elb.DescribeLoadBalancersOutput{
@@ -521,7 +617,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
 					LoadBalancerName: aws.String(elbName),
 				})).
 					Return(&elb.DescribeLoadBalancerAttributesOutput{
@@ -531,7 +627,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeTagsOutput{
 						TagDescriptions: []*elb.TagDescription{
 							{
@@ -544,7 +640,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 						},
 					}, nil)
 
-				m.RegisterInstancesWithLoadBalancer(gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{
+				m.RegisterInstancesWithLoadBalancerWithContext(context.TODO(), gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{
 					Instances:        []*elb.Instance{{InstanceId: aws.String(instanceID)}},
 					LoadBalancerName: aws.String(elbName),
 				})).
@@ -565,6 +661,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{{
 							ID:               clusterSubnetID,
@@ -578,7 +675,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 				},
 			},
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancersInput{
 					LoadBalancerNames: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elb.DescribeLoadBalancersOutput{
@@ -590,7 +687,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
 					LoadBalancerName: aws.String(elbName),
 				})).
 					Return(&elb.DescribeLoadBalancerAttributesOutput{
@@ -600,7 +697,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeTagsOutput{
 						TagDescriptions: []*elb.TagDescription{
 							{
@@ -613,7 +710,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 						},
 					}, nil)
 
-				m.RegisterInstancesWithLoadBalancer(gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{
+				m.RegisterInstancesWithLoadBalancerWithContext(context.TODO(), gomock.Eq(&elb.RegisterInstancesWithLoadBalancerInput{
 					Instances:        []*elb.Instance{{InstanceId: aws.String(instanceID)}},
 					LoadBalancerName: aws.String(elbName),
 				})).
@@ -648,6 +745,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{{
 							ID:               clusterSubnetID,
@@ -661,7 +759,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 				},
 			},
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancersInput{
 					LoadBalancerNames: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elb.DescribeLoadBalancersOutput{
@@ -673,7 +771,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancerAttributesInput{
 					LoadBalancerName: aws.String(elbName),
 				})).
 					Return(&elb.DescribeLoadBalancerAttributesOutput{
@@ -683,7 +781,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeTagsOutput{
 						TagDescriptions: []*elb.TagDescription{
 							{
@@ -766,7 +864,7 @@ func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 				ELBClient: elbAPIMocks,
 			}
 
-			err = s.RegisterInstanceWithAPIServerELB(instance)
+			err = s.RegisterInstanceWithAPIServerELB(context.TODO(), instance)
 			tc.check(t, err)
 		})
 	}
@@ -797,6 +895,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
@@ -810,7 +909,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 				},
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -827,7 +926,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
 					LoadBalancerArn: aws.String(elbArn),
 				})).
 					Return(&elbv2.DescribeLoadBalancerAttributesOutput{
@@ -838,7 +937,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -850,7 +949,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{
 					LoadBalancerArn: aws.String(elbArn),
 				}).Return(&elbv2.DescribeTargetGroupsOutput{
 					TargetGroups: []*elbv2.TargetGroup{
@@ -867,7 +966,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+				m.RegisterTargetsWithContext(context.TODO(), gomock.Eq(&elbv2.RegisterTargetsInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Targets: []*elbv2.TargetDescription{
 						{
@@ -890,6 +989,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
@@ -913,7 +1013,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 				},
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -930,7 +1030,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
 					LoadBalancerArn: aws.String(elbArn),
 				})).
 					Return(&elbv2.DescribeLoadBalancerAttributesOutput{
@@ -941,7 +1041,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -953,7 +1053,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{
 					LoadBalancerArn: aws.String(elbArn),
 				}).Return(&elbv2.DescribeTargetGroupsOutput{
 					TargetGroups: []*elbv2.TargetGroup{
@@ -992,7 +1092,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+				m.RegisterTargetsWithContext(context.TODO(), gomock.Eq(&elbv2.RegisterTargetsInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Targets: []*elbv2.TargetDescription{
 						{
@@ -1001,7 +1101,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 						},
 					},
 				})).Return(&elbv2.RegisterTargetsOutput{}, nil)
-				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+				m.RegisterTargetsWithContext(context.TODO(), gomock.Eq(&elbv2.RegisterTargetsInput{
 					TargetGroupArn: aws.String("target-group::arn::443"),
 					Targets: []*elbv2.TargetDescription{
 						{
@@ -1010,7 +1110,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 						},
 					},
 				})).Return(&elbv2.RegisterTargetsOutput{}, nil)
-				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+				m.RegisterTargetsWithContext(context.TODO(), gomock.Eq(&elbv2.RegisterTargetsInput{
 					TargetGroupArn: aws.String("target-group::arn::8443"),
 					Targets: []*elbv2.TargetDescription{
 						{
@@ -1033,6 +1133,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						Subnets: infrav1.Subnets{{
 							ID:               clusterSubnetID,
@@ -1047,7 +1148,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 				},
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -1064,7 +1165,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancerAttributesInput{
 					LoadBalancerArn: aws.String(elbArn),
 				})).
 					Return(&elbv2.DescribeLoadBalancerAttributesOutput{
@@ -1075,7 +1176,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -1087,7 +1188,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{
 					LoadBalancerArn: aws.String(elbArn),
 				}).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
 			},
@@ -1147,7 +1248,7 @@ func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
 				ELBV2Client: elbV2APIMocks,
 			}
 
-			err = s.RegisterInstanceWithAPIServerLB(instance, clusterScope.ControlPlaneLoadBalancer())
+			err = s.RegisterInstanceWithAPIServerLB(context.TODO(), instance, clusterScope.ControlPlaneLoadBalancer())
 			tc.check(t, err)
 		})
 	}
@@ -1180,7 +1281,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: []*string{},
@@ -1202,7 +1303,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					Name:     aws.String("name"),
 					Port:     aws.Int64(infrav1.DefaultAPIServerPort),
 					Protocol: aws.String("TCP"),
@@ -1233,7 +1334,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -1242,7 +1343,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				})).Return(nil, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1289,7 +1390,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					IpAddressType:  aws.String("dualstack"),
 					Scheme:         aws.String("internet-facing"),
@@ -1312,7 +1413,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					Name:          aws.String("name"),
 					Port:          aws.Int64(infrav1.DefaultAPIServerPort),
 					Protocol:      aws.String("TCP"),
@@ -1344,7 +1445,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -1353,7 +1454,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				})).Return(nil, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1396,7 +1497,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: []*string{},
@@ -1441,7 +1542,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: aws.StringSlice([]string{}),
@@ -1463,7 +1564,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					Name:     aws.String("name"),
 					Port:     aws.Int64(infrav1.DefaultAPIServerPort),
 					Protocol: aws.String("TCP"),
@@ -1491,7 +1592,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -1500,7 +1601,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				})).Return(nil, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1544,7 +1645,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: aws.StringSlice([]string{}),
@@ -1566,7 +1667,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					HealthCheckEnabled:  aws.Bool(true),
 					HealthCheckPort:     aws.String(infrav1.DefaultAPIServerPortString),
 					HealthCheckProtocol: aws.String("tcp"),
@@ -1597,7 +1698,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1642,7 +1743,7 @@ func TestCreateNLB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:    aws.String(elbName),
 					Scheme:  aws.String("internet-facing"),
 					Type:    aws.String("application"),
@@ -1664,7 +1765,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					HealthCheckEnabled:  aws.Bool(true),
 					HealthCheckPort:     aws.String(infrav1.DefaultAPIServerPortString),
 					HealthCheckProtocol: aws.String("tcp"),
@@ -1695,7 +1796,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -1704,7 +1805,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				})).Return(nil, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1762,7 +1863,7 @@ func TestCreateNLB(t *testing.T) {
 				return spec
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: aws.StringSlice([]string{}),
@@ -1784,7 +1885,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					Name:                       aws.String("name"),
 					Port:                       aws.Int64(infrav1.DefaultAPIServerPort),
 					Protocol:                   aws.String("TCP"),
@@ -1820,7 +1921,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1843,7 +1944,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -1889,7 +1990,7 @@ func TestCreateNLB(t *testing.T) {
 				return spec
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.CreateLoadBalancer(gomock.Eq(&elbv2.CreateLoadBalancerInput{
+				m.CreateLoadBalancerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateLoadBalancerInput{
 					Name:           aws.String(elbName),
 					Scheme:         aws.String("internet-facing"),
 					SecurityGroups: aws.StringSlice([]string{}),
@@ -1911,7 +2012,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
+				m.CreateTargetGroupWithContext(context.TODO(), gomock.Eq(&elbv2.CreateTargetGroupInput{
 					Name:                       aws.String("name"),
 					Port:                       aws.Int64(infrav1.DefaultAPIServerPort),
 					Protocol:                   aws.String("TCP"),
@@ -1947,7 +2048,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.CreateListener(gomock.Eq(&elbv2.CreateListenerInput{
+				m.CreateListenerWithContext(context.TODO(), gomock.Eq(&elbv2.CreateListenerInput{
 					DefaultActions: []*elbv2.Action{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
@@ -1970,7 +2071,7 @@ func TestCreateNLB(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.ModifyTargetGroupAttributes(gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), gomock.Eq(&elbv2.ModifyTargetGroupAttributesInput{
 					TargetGroupArn: aws.String("target-group::arn"),
 					Attributes: []*elbv2.TargetGroupAttribute{
 						{
@@ -2007,6 +2108,7 @@ func TestCreateNLB(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
@@ -2069,7 +2171,7 @@ func TestCreateNLB(t *testing.T) {
 			}
 
 			spec := tc.spec(*loadBalancerSpec)
-			lb, err := s.createLB(&spec, clusterScope.ControlPlaneLoadBalancer())
+			lb, err := s.createLB(context.TODO(), &spec, clusterScope.ControlPlaneLoadBalancer())
 			tc.check(t, lb, err)
 		})
 	}
@@ -2103,7 +2205,7 @@ func TestReconcileV2LB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -2122,7 +2224,7 @@ func TestReconcileV2LB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2133,7 +2235,7 @@ func TestReconcileV2LB(t *testing.T) {
 					},
 					nil,
 				)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2167,7 +2269,7 @@ func TestReconcileV2LB(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -2186,16 +2288,20 @@ func TestReconcileV2LB(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
+				m.ModifyLoadBalancerAttributesWithContext(context.TODO(), &elbv2.ModifyLoadBalancerAttributesInput{
 					LoadBalancerArn: aws.String(elbArn),
 					Attributes: []*elbv2.LoadBalancerAttribute{
+						{
+							Key:   aws.String("deletion_protection.enabled"),
+							Value: aws.String("false"),
+						},
 						{
 							Key:   aws.String("load_balancing.cross_zone.enabled"),
 							Value: aws.String("false"),
 						},
 					}}).
 					Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, nil)
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2210,7 +2316,7 @@ func TestReconcileV2LB(t *testing.T) {
 					},
 					nil,
 				)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2228,9 +2334,9 @@ func TestReconcileV2LB(t *testing.T) {
 				)
 
 				// Avoid the need to sort the AddTagsInput.Tags slice
-				m.AddTags(gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).Return(&elbv2.AddTagsOutput{}, nil)
+				m.AddTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).Return(&elbv2.AddTagsOutput{}, nil)
 
-				m.SetSubnets(&elbv2.SetSubnetsInput{
+				m.SetSubnetsWithContext(context.TODO(), &elbv2.SetSubnetsInput{
 					LoadBalancerArn: aws.String(elbArn),
 					Subnets:         []*string{},
 				}).Return(&elbv2.SetSubnetsOutput{}, nil)
@@ -2245,6 +2351,67 @@ func TestReconcileV2LB(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "ensure the IP address type of a managed NLB is reconciled",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.Name = aws.String(elbName)
+				acl.Spec.ControlPlaneLoadBalancer.IPAddressType = infrav1.LoadBalancerIPAddressTypeDualStack
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+					Names: aws.StringSlice([]string{elbName}),
+				})).
+					Return(&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String(elbArn),
+								LoadBalancerName: aws.String(elbName),
+								Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+								IpAddressType:    aws.String(elbv2.IpAddressTypeIpv4),
+								VpcId:            aws.String(vpcID),
+							},
+						},
+					}, nil)
+				m.ModifyLoadBalancerAttributesWithContext(context.TODO(), gomock.AssignableToTypeOf(&elbv2.ModifyLoadBalancerAttributesInput{})).
+					Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, nil)
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+					&elbv2.DescribeLoadBalancerAttributesOutput{}, nil)
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+					&elbv2.DescribeTagsOutput{
+						TagDescriptions: []*elbv2.TagDescription{
+							{
+								ResourceArn: aws.String(elbArn),
+								Tags: []*elbv2.Tag{
+									{
+										Key:   aws.String(infrav1.ClusterTagKey(clusterName)),
+										Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+									},
+								},
+							},
+						},
+					},
+					nil,
+				)
+				m.AddTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).Return(&elbv2.AddTagsOutput{}, nil)
+				m.SetIpAddressTypeWithContext(context.TODO(), &elbv2.SetIpAddressTypeInput{
+					LoadBalancerArn: aws.String(elbArn),
+					IpAddressType:   aws.String(elbv2.IpAddressTypeDualstack),
+				}).Return(&elbv2.SetIpAddressTypeOutput{}, nil)
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if lb.IPAddressType != infrav1.LoadBalancerIPAddressTypeDualStack {
+					t.Errorf("Expected LB IP address type to be dualstack, got %q", lb.IPAddressType)
+				}
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2260,6 +2427,7 @@ func TestReconcileV2LB(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
@@ -2293,7 +2461,7 @@ func TestReconcileV2LB(t *testing.T) {
 				scope:       clusterScope,
 				ELBV2Client: elbV2APIMocks,
 			}
-			err = s.reconcileV2LB(clusterScope.ControlPlaneLoadBalancer())
+			err = s.reconcileV2LB(context.TODO(), clusterScope.ControlPlaneLoadBalancer())
 			lb := s.scope.Network().APIServerELB
 
 			tc.check(t, &lb, err)
@@ -2333,7 +2501,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 				return acl
 			},
 			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -2352,7 +2520,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2363,7 +2531,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 					},
 					nil,
 				)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2375,7 +2543,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{secondElbName}),
 				})).
 					Return(&elbv2.DescribeLoadBalancersOutput{
@@ -2394,7 +2562,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(secondElbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(secondElbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2405,7 +2573,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 					},
 					nil,
 				)
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(secondElbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(secondElbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2446,6 +2614,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
@@ -2479,7 +2648,7 @@ func TestReconcileLoadbalancers(t *testing.T) {
 				scope:       clusterScope,
 				ELBV2Client: elbV2APIMocks,
 			}
-			err = s.ReconcileLoadbalancers()
+			err = s.ReconcileLoadbalancers(context.TODO())
 			firstLB := s.scope.Network().APIServerELB
 			secondLB := s.scope.Network().SecondaryAPIServerELB
 			tc.check(t, &firstLB, &secondLB, err)
@@ -2498,7 +2667,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 		{
 			name: "if control plane ELB is not found, do nothing",
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancersInput{
 					LoadBalancerNames: aws.StringSlice([]string{elbName}),
 				})).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "", nil))
 			},
@@ -2516,7 +2685,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 		{
 			name: "if control plane ELB is found, and it is not managed, do nothing",
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeLoadBalancersOutput{
 						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
 							{
@@ -2528,7 +2697,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: aws.String(elbName)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: aws.String(elbName)}).Return(
 					&elb.DescribeLoadBalancerAttributesOutput{
 						LoadBalancerAttributes: &elb.LoadBalancerAttributes{
 							CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
@@ -2539,7 +2708,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeTagsOutput{
 						TagDescriptions: []*elb.TagDescription{
 							{
@@ -2565,7 +2734,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 		{
 			name: "if control plane ELB is found, and it is managed, delete the ELB",
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeLoadBalancersOutput{
 						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
 							{
@@ -2577,7 +2746,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeLoadBalancerAttributes(&elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: aws.String(elbName)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elb.DescribeLoadBalancerAttributesInput{LoadBalancerName: aws.String(elbName)}).Return(
 					&elb.DescribeLoadBalancerAttributesOutput{
 						LoadBalancerAttributes: &elb.LoadBalancerAttributes{
 							CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
@@ -2588,7 +2757,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeTagsOutput{
 						TagDescriptions: []*elb.TagDescription{
 							{
@@ -2603,10 +2772,10 @@ func TestDeleteAPIServerELB(t *testing.T) {
 					nil,
 				)
 
-				m.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(elbName)}).Return(
+				m.DeleteLoadBalancerWithContext(context.TODO(), &elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String(elbName)}).Return(
 					&elb.DeleteLoadBalancerOutput{}, nil)
 
-				m.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elb.DescribeLoadBalancersInput{LoadBalancerNames: []*string{aws.String(elbName)}}).Return(
 					&elb.DescribeLoadBalancersOutput{
 						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{},
 					},
@@ -2641,6 +2810,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name: aws.String(elbName),
 					},
@@ -2671,7 +2841,7 @@ func TestDeleteAPIServerELB(t *testing.T) {
 				ELBClient:             elbapiMock,
 			}
 
-			err = s.deleteAPIServerELB()
+			err = s.deleteAPIServerELB(context.TODO())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2685,14 +2855,55 @@ func TestDeleteNLB(t *testing.T) {
 	clusterName := "bar"
 	elbName := "bar-apiserver"
 	elbArn := "apiserver::arn"
+	protectedLBMocks := func(m *mocks.MockELBV2APIMockRecorder) {
+		m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+			&elbv2.DescribeLoadBalancersOutput{
+				LoadBalancers: []*elbv2.LoadBalancer{
+					{
+						LoadBalancerArn:  aws.String(elbArn),
+						LoadBalancerName: aws.String(elbName),
+						Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+					},
+				},
+			},
+			nil,
+		)
+		m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+			&elbv2.DescribeLoadBalancerAttributesOutput{
+				Attributes: []*elbv2.LoadBalancerAttribute{
+					{
+						Key:   aws.String("deletion_protection.enabled"),
+						Value: aws.String("true"),
+					},
+				},
+			},
+			nil,
+		)
+		m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+			&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String(elbArn),
+						Tags: []*elbv2.Tag{{
+							Key:   aws.String(infrav1.ClusterTagKey(clusterName)),
+							Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+						}},
+					},
+				},
+			},
+			nil,
+		)
+	}
 	tests := []struct {
-		name         string
-		elbv2ApiMock func(m *mocks.MockELBV2APIMockRecorder)
+		name            string
+		clusterDeleting bool
+		elbv2ApiMock    func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr       bool
 	}{
 		{
 			name: "if control plane NLB is not found, do nothing",
 			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{elbName}),
 				})).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "", nil))
 			},
@@ -2700,7 +2911,7 @@ func TestDeleteNLB(t *testing.T) {
 		{
 			name: "if control plane NLB is found, and it is not managed, do nothing",
 			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
 					&elbv2.DescribeLoadBalancersOutput{
 						LoadBalancers: []*elbv2.LoadBalancer{
 							{
@@ -2713,7 +2924,7 @@ func TestDeleteNLB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2725,7 +2936,7 @@ func TestDeleteNLB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2741,7 +2952,7 @@ func TestDeleteNLB(t *testing.T) {
 		{
 			name: "if control plane ELB is found, and it is managed, delete the ELB",
 			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
 					&elbv2.DescribeLoadBalancersOutput{
 						LoadBalancers: []*elbv2.LoadBalancer{
 							{
@@ -2754,7 +2965,7 @@ func TestDeleteNLB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DescribeLoadBalancerAttributesWithContext(context.TODO(), &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DescribeLoadBalancerAttributesOutput{
 						Attributes: []*elbv2.LoadBalancerAttribute{
 							{
@@ -2766,7 +2977,7 @@ func TestDeleteNLB(t *testing.T) {
 					nil,
 				)
 
-				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
+				m.DescribeTagsWithContext(context.TODO(), &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(elbArn)}}).Return(
 					&elbv2.DescribeTagsOutput{
 						TagDescriptions: []*elbv2.TagDescription{
 							{
@@ -2782,29 +2993,63 @@ func TestDeleteNLB(t *testing.T) {
 				)
 
 				// delete listeners
-				m.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeListenersOutput{
+				m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeListenersOutput{
 					Listeners: []*elbv2.Listener{
 						{
 							ListenerArn: aws.String("listener::arn"),
 						},
 					},
 				}, nil)
-				m.DeleteListener(&elbv2.DeleteListenerInput{ListenerArn: aws.String("listener::arn")}).Return(&elbv2.DeleteListenerOutput{}, nil)
+				m.DeleteListenerWithContext(context.TODO(), &elbv2.DeleteListenerInput{ListenerArn: aws.String("listener::arn")}).Return(&elbv2.DeleteListenerOutput{}, nil)
 				// delete target groups
-				m.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeTargetGroupsOutput{
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeTargetGroupsOutput{
 					TargetGroups: []*elbv2.TargetGroup{
 						{
 							TargetGroupArn: aws.String("target-group::arn"),
 						},
 					},
 				}, nil)
-				m.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String("target-group::arn")}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+				m.DeleteTargetGroupWithContext(context.TODO(), &elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String("target-group::arn")}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
 				// delete the load balancer
 
-				m.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+				m.DeleteLoadBalancerWithContext(context.TODO(), &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(elbArn)}).Return(
 					&elbv2.DeleteLoadBalancerOutput{}, nil)
 
-				m.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
+					&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{},
+					},
+					nil,
+				)
+			},
+		},
+		{
+			name: "if control plane NLB has deletion protection and the cluster is not being deleted, keep the NLB",
+			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
+				protectedLBMocks(m)
+			},
+			expectErr: true,
+		},
+		{
+			name:            "if control plane NLB has deletion protection and the cluster is being deleted, disable the protection and delete the NLB",
+			clusterDeleting: true,
+			elbv2ApiMock: func(m *mocks.MockELBV2APIMockRecorder) {
+				protectedLBMocks(m)
+
+				m.ModifyLoadBalancerAttributesWithContext(context.TODO(), &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String(elbArn),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						{
+							Key:   aws.String("deletion_protection.enabled"),
+							Value: aws.String("false"),
+						},
+					},
+				}).Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, nil)
+				m.DescribeListenersWithContext(context.TODO(), &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(elbArn)}).Return(&elbv2.DescribeTargetGroupsOutput{}, nil)
+				m.DeleteLoadBalancerWithContext(context.TODO(), &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(elbArn)}).Return(
+					&elbv2.DeleteLoadBalancerOutput{}, nil)
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(elbName)}}).Return(
 					&elbv2.DescribeLoadBalancersOutput{
 						LoadBalancers: []*elbv2.LoadBalancer{},
 					},
@@ -2829,12 +3074,17 @@ func TestDeleteNLB(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Name:             aws.String(elbName),
 						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
 					},
 				},
 			}
+			if tc.clusterDeleting {
+				awsCluster.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				awsCluster.Finalizers = []string{infrav1.ClusterFinalizer}
+			}
 
 			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
 
@@ -2860,7 +3110,13 @@ func TestDeleteNLB(t *testing.T) {
 				ELBV2Client:           elbv2ApiMock,
 			}
 
-			err = s.deleteExistingNLBs()
+			err = s.deleteExistingNLBs(context.TODO())
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2880,7 +3136,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 		{
 			name: "discover ELBs with Resource Groups Tagging API and then delete successfully",
 			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
-				m.GetResourcesPages(&rgapi.GetResourcesInput{
+				m.GetResourcesPagesWithContext(context.TODO(), &rgapi.GetResourcesInput{
 					ResourceTypeFilters: aws.StringSlice([]string{elbResourceType}),
 					TagFilters: []*rgapi.TagFilter{
 						{
@@ -2888,7 +3144,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 							Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
 						},
 					},
-				}, gomock.Any()).Do(func(_, y interface{}) {
+				}, gomock.Any()).Do(func(_ context.Context, _, y interface{}, _ ...request.Option) {
 					funct := y.(func(output *rgapi.GetResourcesOutput, lastPage bool) bool)
 					funct(&rgapi.GetResourcesOutput{
 						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
@@ -2904,10 +3160,10 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 				}).Return(nil)
 			},
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DeleteLoadBalancer(gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("lb-service-name")})).Return(nil, nil)
+				m.DeleteLoadBalancerWithContext(context.TODO(), gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("lb-service-name")})).Return(nil, nil)
 			},
 			postDeleteRGAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
-				m.GetResourcesPages(&rgapi.GetResourcesInput{
+				m.GetResourcesPagesWithContext(context.TODO(), &rgapi.GetResourcesInput{
 					ResourceTypeFilters: aws.StringSlice([]string{elbResourceType}),
 					TagFilters: []*rgapi.TagFilter{
 						{
@@ -2915,7 +3171,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 							Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
 						},
 					},
-				}, gomock.Any()).Do(func(_, y interface{}) {
+				}, gomock.Any()).Do(func(_ context.Context, _, y interface{}, _ ...request.Option) {
 					funct := y.(func(output *rgapi.GetResourcesOutput, lastPage bool) bool)
 					funct(&rgapi.GetResourcesOutput{
 						ResourceTagMappingList: []*rgapi.ResourceTagMapping{},
@@ -2926,10 +3182,10 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 		{
 			name: "fall back to ELB API when Resource Groups Tagging API fails and then delete successfully",
 			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
-				m.GetResourcesPages(gomock.Any(), gomock.Any()).Return(errors.Errorf("connection failure")).AnyTimes()
+				m.GetResourcesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(errors.Errorf("connection failure")).AnyTimes()
 			},
 			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancersPages(gomock.Any(), gomock.Any()).Do(func(_, y interface{}) {
+				m.DescribeLoadBalancersPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, _, y interface{}, _ ...request.Option) {
 					funct := y.(func(output *elb.DescribeLoadBalancersOutput, lastPage bool) bool)
 					funct(&elb.DescribeLoadBalancersOutput{
 						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
@@ -2945,7 +3201,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 						},
 					}, true)
 				}).Return(nil)
-				m.DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String("lb-service-name"), aws.String("another-service-not-owned"), aws.String("service-without-tags")}}).Return(&elb.DescribeTagsOutput{
+				m.DescribeTagsWithContext(context.TODO(), &elb.DescribeTagsInput{LoadBalancerNames: []*string{aws.String("lb-service-name"), aws.String("another-service-not-owned"), aws.String("service-without-tags")}}).Return(&elb.DescribeTagsOutput{
 					TagDescriptions: []*elb.TagDescription{
 						{
 							LoadBalancerName: aws.String("lb-service-name"),
@@ -2967,10 +3223,10 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 						},
 					},
 				}, nil)
-				m.DeleteLoadBalancer(gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("lb-service-name")})).Return(nil, nil)
+				m.DeleteLoadBalancerWithContext(context.TODO(), gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("lb-service-name")})).Return(nil, nil)
 			},
 			postDeleteElbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancersPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.DescribeLoadBalancersPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 	}
@@ -2989,7 +3245,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
-				Spec:       infrav1.AWSClusterSpec{},
+				Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 			}
 
 			client := fake.NewClientBuilder().WithScheme(scheme).Build()
@@ -3025,7 +3281,7 @@ func TestDeleteAWSCloudProviderELBs(t *testing.T) {
 				ELBClient:             elbapiMock,
 			}
 
-			err = s.deleteAWSCloudProviderELBs()
+			err = s.deleteAWSCloudProviderELBs(context.TODO())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -3045,10 +3301,10 @@ func TestDescribeLoadbalancers(t *testing.T) {
 			name:   "Error if existing loadbalancer with same name doesn't have same scheme",
 			lbName: "bar-apiserver",
 			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
-				m.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				m.GetResourcesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 			},
 			DescribeElbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elb.DescribeLoadBalancersInput{
 					LoadBalancerNames: aws.StringSlice([]string{"bar-apiserver"}),
 				})).Return(&elb.DescribeLoadBalancersOutput{LoadBalancerDescriptions: []*elb.LoadBalancerDescription{{Scheme: ptr.To[string](string(infrav1.ELBSchemeInternal))}}}, nil)
 			},
@@ -3068,7 +3324,7 @@ func TestDescribeLoadbalancers(t *testing.T) {
 			}
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
-				Spec: infrav1.AWSClusterSpec{ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				Spec: infrav1.AWSClusterSpec{Region: "us-east-1", ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 					Scheme: &infrav1.ELBSchemeInternetFacing,
 				}},
 			}
@@ -3100,7 +3356,7 @@ func TestDescribeLoadbalancers(t *testing.T) {
 				ELBClient:             elbapiMock,
 			}
 
-			_, err = s.describeClassicELB(tc.lbName)
+			_, err = s.describeClassicELB(context.TODO(), tc.lbName)
 			if err == nil {
 				t.Fatal(err)
 			}
@@ -3120,10 +3376,10 @@ func TestDescribeV2Loadbalancers(t *testing.T) {
 			name:   "Error if existing loadbalancer with same name doesn't have same scheme",
 			lbName: "bar-apiserver",
 			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
-				m.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				m.GetResourcesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 			},
 			DescribeElbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
-				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+				m.DescribeLoadBalancersWithContext(context.TODO(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
 					Names: aws.StringSlice([]string{"bar-apiserver"}),
 				})).Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{{Scheme: ptr.To[string](string(infrav1.ELBSchemeInternal))}}}, nil)
 			},
@@ -3143,7 +3399,7 @@ func TestDescribeV2Loadbalancers(t *testing.T) {
 			}
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
-				Spec: infrav1.AWSClusterSpec{ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+				Spec: infrav1.AWSClusterSpec{Region: "us-east-1", ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 					Scheme:           &infrav1.ELBSchemeInternetFacing,
 					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
 				}},
@@ -3176,7 +3432,7 @@ func TestDescribeV2Loadbalancers(t *testing.T) {
 				ELBV2Client:           elbV2ApiMock,
 			}
 
-			_, err = s.describeLB(tc.lbName, clusterScope.ControlPlaneLoadBalancer())
+			_, err = s.describeLB(context.TODO(), tc.lbName, clusterScope.ControlPlaneLoadBalancer())
 			if err == nil {
 				t.Fatal(err)
 			}
@@ -3268,6 +3524,7 @@ func TestGetHealthCheckProtocol(t *testing.T) {
 				},
 				AWSCluster: &infrav1.AWSCluster{
 					Spec: infrav1.AWSClusterSpec{
+						Region:                   "us-east-1",
 						ControlPlaneLoadBalancer: tc.lbSpec,
 					},
 				},
@@ -3311,7 +3568,7 @@ func stubGetBaseService(t *testing.T, clusterName string) *Service {
 	}
 	awsCluster := &infrav1.AWSCluster{
 		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
-		Spec: infrav1.AWSClusterSpec{ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+		Spec: infrav1.AWSClusterSpec{Region: "us-east-1", ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 			Scheme:           &infrav1.ELBSchemeInternetFacing,
 			LoadBalancerType: infrav1.LoadBalancerTypeNLB,
 		}},
@@ -3550,3 +3807,193 @@ func TestService_getAdditionalTargetGroupHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInstanceDrainingFromAPIServerLB(t *testing.T) {
+	tests := []struct {
+		name           string
+		state          string
+		wantDraining   bool
+		wantRegistered bool
+	}{
+		{
+			name:           "registered instance",
+			state:          elbv2.TargetHealthStateEnumHealthy,
+			wantRegistered: true,
+		},
+		{
+			name:         "de-registered instance being drained",
+			state:        elbv2.TargetHealthStateEnumDraining,
+			wantDraining: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+			m := elbV2APIMocks.EXPECT()
+			for i := 0; i < 2; i++ {
+				m.DescribeLoadBalancersWithContext(context.TODO(), &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice([]string{existingLBARN})}).
+					Return(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{{LoadBalancerArn: aws.String(existingLBARN)}}}, nil)
+				expectExistingTargetGroups(m)
+				m.DescribeTargetHealthWithContext(context.TODO(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(ownedTGARN)}).
+					Return(&elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{
+						Target:       &elbv2.TargetDescription{Id: aws.String("i-1")},
+						TargetHealth: &elbv2.TargetHealth{State: aws.String(tc.state)},
+					}}}, nil)
+			}
+
+			s := newExistingLBService(t, elbV2APIMocks)
+			instance := &infrav1.Instance{ID: "i-1"}
+			draining, err := s.IsInstanceDrainingFromAPIServerLB(context.TODO(), instance, s.scope.ControlPlaneLoadBalancer())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(draining).To(Equal(tc.wantDraining))
+
+			_, registered, err := s.IsInstanceRegisteredWithAPIServerLB(context.TODO(), instance, s.scope.ControlPlaneLoadBalancer())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(registered).To(Equal(tc.wantRegistered))
+		})
+	}
+}
+
+func TestLoadBalancerSubnetReplacement(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-1a", ResourceID: "subnet-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-1a-grown", ResourceID: "subnet-1a-grown", AvailabilityZone: "us-east-1a", IsPublic: true, Replaces: "subnet-1a"},
+		{ID: "subnet-1b", ResourceID: "subnet-1b", AvailabilityZone: "us-east-1b", IsPublic: true},
+		{ID: "subnet-1b-grown", AvailabilityZone: "us-east-1b", IsPublic: true, Replaces: "subnet-1b"},
+	}
+
+	tests := []struct {
+		name     string
+		attached []string
+		desired  []string
+		want     bool
+	}{
+		{
+			name:     "attached subnets aren't replaced",
+			attached: []string{"subnet-1a-grown", "subnet-1b"},
+			desired:  []string{"subnet-1a-grown", "subnet-1b"},
+		},
+		{
+			name:     "attached subnet is replaced",
+			attached: []string{"subnet-1a", "subnet-1b"},
+			desired:  []string{"subnet-1a-grown", "subnet-1b"},
+			want:     true,
+		},
+		{
+			name:     "attached subnet is replaced but still desired",
+			attached: []string{"subnet-1a", "subnet-1b"},
+			desired:  []string{"subnet-1a", "subnet-1b"},
+		},
+		{
+			name:     "attached subnet is unknown",
+			attached: []string{"subnet-other", "subnet-1b"},
+			desired:  []string{"subnet-1a-grown", "subnet-1b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
+						NetworkSpec: infrav1.NetworkSpec{Subnets: subnets},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{scope: clusterScope}
+
+			spec, err := s.getAPIServerLBSpec(context.TODO(), clusterScope.Name(), clusterScope.ControlPlaneLoadBalancer())
+			g.Expect(err).NotTo(HaveOccurred())
+			// The replacement subnets which haven't been created yet don't replace their subnet.
+			g.Expect(spec.SubnetIDs).To(Equal([]string{"subnet-1a-grown", "subnet-1b"}))
+
+			g.Expect(s.attachesReplacedSubnets(tc.attached, tc.desired)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestGetAPIServerLBSpecDualStackSubnets(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-1a", ResourceID: "subnet-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-1a-dualstack", ResourceID: "subnet-1a-dualstack", AvailabilityZone: "us-east-1a", IsPublic: true, IsIPv6: true, IPv6CidrBlock: "2001:db8::/64"},
+		{ID: "subnet-1b", ResourceID: "subnet-1b", AvailabilityZone: "us-east-1b", IsPublic: true},
+	}
+
+	tests := []struct {
+		name          string
+		ipAddressType infrav1.LoadBalancerIPAddressType
+		want          []string
+	}{
+		{
+			name: "IP address type not set",
+			want: []string{"subnet-1a", "subnet-1b"},
+		},
+		{
+			name:          "IPv4 load balancer",
+			ipAddressType: infrav1.LoadBalancerIPAddressTypeIPv4,
+			want:          []string{"subnet-1a", "subnet-1b"},
+		},
+		{
+			name:          "dual-stack load balancer is only attached to the subnets with an IPv6 CIDR block",
+			ipAddressType: infrav1.LoadBalancerIPAddressTypeDualStack,
+			want:          []string{"subnet-1a-dualstack"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
+						ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+							LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+							IPAddressType:    tc.ipAddressType,
+						},
+						NetworkSpec: infrav1.NetworkSpec{Subnets: subnets},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{scope: clusterScope}
+
+			spec, err := s.getAPIServerLBSpec(context.TODO(), clusterScope.Name(), clusterScope.ControlPlaneLoadBalancer())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(spec.SubnetIDs).To(Equal(tc.want))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// targetGroupAttributes returns the attributes of the target groups of a load balancer, sorted by key.
+// The additional target group attributes of the spec take precedence over the ones set by CAPA.
+func targetGroupAttributes(lbSpec *infrav1.AWSLoadBalancerSpec) []*elbv2.TargetGroupAttribute {
+	attributes := map[string]string{}
+	if !lbSpec.PreserveClientIP {
+		attributes[infrav1.TargetGroupAttributeEnablePreserveClientIP] = "false"
+	}
+	for k, v := range lbSpec.AdditionalTargetGroupAttributes {
+		attributes[k] = v
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]*elbv2.TargetGroupAttribute, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, &elbv2.TargetGroupAttribute{
+			Key:   aws.String(k),
+			Value: aws.String(attributes[k]),
+		})
+	}
+	return res
+}
+
+// reconcileTargetGroupAttributes applies the target group attributes to the target groups whose attributes differ.
+// The attributes are only reconciled when additional target group attributes are set.
+func (s *Service) reconcileTargetGroupAttributes(ctx context.Context, targetGroups []*elbv2.TargetGroup, lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	if len(lbSpec.AdditionalTargetGroupAttributes) == 0 {
+		return nil
+	}
+
+	for _, tg := range targetGroups {
+		out, err := s.ELBV2Client.DescribeTargetGroupAttributesWithContext(ctx, &elbv2.DescribeTargetGroupAttributesInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe attributes of target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+		current := map[string]string{}
+		for _, a := range out.Attributes {
+			current[aws.StringValue(a.Key)] = aws.StringValue(a.Value)
+		}
+
+		var attributes []*elbv2.TargetGroupAttribute
+		for _, a := range targetGroupAttributes(lbSpec) {
+			if current[aws.StringValue(a.Key)] != aws.StringValue(a.Value) {
+				attributes = append(attributes, a)
+			}
+		}
+		if len(attributes) == 0 {
+			continue
+		}
+
+		if _, err := s.ELBV2Client.ModifyTargetGroupAttributesWithContext(ctx, &elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Attributes:     attributes,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to modify attributes of target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+		s.scope.Debug("Modified target group attributes", "target-group", aws.StringValue(tg.TargetGroupName), "attributes", attributes)
+	}
+
+	return nil
+}
+
+// targetID returns the ID of an instance as a target of a target group: its instance ID, or its private IP address
+// for a target group of the ip target type.
+func targetID(tg *elbv2.TargetGroup, i *infrav1.Instance) (string, error) {
+	if aws.StringValue(tg.TargetType) != elbv2.TargetTypeEnumIp {
+		return i.ID, nil
+	}
+	if aws.StringValue(i.PrivateIP) == "" {
+		return "", errors.Errorf("instance %q has no private IP address to register with target group %q", i.ID, aws.StringValue(tg.TargetGroupName))
+	}
+	return *i.PrivateIP, nil
+}
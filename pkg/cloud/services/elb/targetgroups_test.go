@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestTargetGroupAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		lbSpec *infrav1.AWSLoadBalancerSpec
+		want   []*elbv2.TargetGroupAttribute
+	}{
+		{
+			name:   "disables the preservation of the client IP by default",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{},
+			want: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+			},
+		},
+		{
+			name:   "no attributes when preserving the client IP",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{PreserveClientIP: true},
+			want:   []*elbv2.TargetGroupAttribute{},
+		},
+		{
+			name: "additional attributes take precedence",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				AdditionalTargetGroupAttributes: map[string]string{
+					infrav1.TargetGroupAttributeEnablePreserveClientIP: "true",
+					"deregistration_delay.timeout_seconds":             "30",
+				},
+			},
+			want: []*elbv2.TargetGroupAttribute{
+				{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+				{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("true")},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(targetGroupAttributes(tc.lbSpec)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestReconcileTargetGroupAttributes(t *testing.T) {
+	lbSpec := &infrav1.AWSLoadBalancerSpec{
+		AdditionalTargetGroupAttributes: map[string]string{
+			"deregistration_delay.timeout_seconds": "30",
+		},
+	}
+	tests := []struct {
+		name          string
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr     bool
+	}{
+		{
+			name: "modifies the attributes that differ",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroupAttributesWithContext(context.TODO(), &elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: aws.String(ownedTGARN)}).
+					Return(&elbv2.DescribeTargetGroupAttributesOutput{Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("300")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+					}}, nil)
+				m.ModifyTargetGroupAttributesWithContext(context.TODO(), &elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String(ownedTGARN),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+					},
+				}).Return(&elbv2.ModifyTargetGroupAttributesOutput{}, nil)
+			},
+		},
+		{
+			name: "leaves the target groups already configured untouched",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroupAttributesWithContext(context.TODO(), gomock.Any()).
+					Return(&elbv2.DescribeTargetGroupAttributesOutput{Attributes: []*elbv2.TargetGroupAttribute{
+						{Key: aws.String("deregistration_delay.timeout_seconds"), Value: aws.String("30")},
+						{Key: aws.String(infrav1.TargetGroupAttributeEnablePreserveClientIP), Value: aws.String("false")},
+					}}, nil)
+			},
+		},
+		{
+			name: "fails when the attributes can't be described",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroupAttributesWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("AccessDenied", "", nil))
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+
+			s := newExistingLBService(t, elbV2APIMocks)
+			err := s.reconcileTargetGroupAttributes(context.TODO(), []*elbv2.TargetGroup{{TargetGroupArn: aws.String(ownedTGARN)}}, lbSpec)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestRegisterInstanceByIPAddress(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+	m := elbV2APIMocks.EXPECT()
+	expectDescribeExistingLB(m)
+	m.DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(existingLBARN)}).
+		Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{
+			{TargetGroupArn: aws.String(ownedTGARN), Port: aws.Int64(infrav1.DefaultAPIServerPort), TargetType: aws.String(elbv2.TargetTypeEnumIp)},
+		}}, nil)
+	m.DescribeTagsWithContext(context.TODO(), gomock.Any()).
+		Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{
+			ResourceArn: aws.String(ownedTGARN),
+			Tags: []*elbv2.Tag{{
+				Key:   aws.String(infrav1.ClusterTagKey(existingLBCluster)),
+				Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+			}},
+		}}}, nil)
+	m.RegisterTargetsWithContext(context.TODO(), &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(ownedTGARN),
+		Targets:        []*elbv2.TargetDescription{{Id: aws.String("10.0.0.10"), Port: aws.Int64(infrav1.DefaultAPIServerPort)}},
+	}).Return(&elbv2.RegisterTargetsOutput{}, nil)
+
+	s := newExistingLBService(t, elbV2APIMocks)
+	err := s.RegisterInstanceWithAPIServerLB(context.TODO(), &infrav1.Instance{ID: "i-1", PrivateIP: aws.String("10.0.0.10")}, s.scope.ControlPlaneLoadBalancer())
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestDeregisterInstanceFromAPIServerLB(t *testing.T) {
+	tests := []struct {
+		name          string
+		targetType    string
+		instance      *infrav1.Instance
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr     bool
+	}{
+		{
+			name:       "deregisters the instance by ID",
+			targetType: elbv2.TargetTypeEnumInstance,
+			instance:   &infrav1.Instance{ID: "i-1", PrivateIP: aws.String("10.0.0.10")},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeregisterTargetsWithContext(context.TODO(), &elbv2.DeregisterTargetsInput{
+					TargetGroupArn: aws.String(ownedTGARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1")}},
+				}).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+			},
+		},
+		{
+			name:       "deregisters the instance by IP address",
+			targetType: elbv2.TargetTypeEnumIp,
+			instance:   &infrav1.Instance{ID: "i-1", PrivateIP: aws.String("10.0.0.10")},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DeregisterTargetsWithContext(context.TODO(), &elbv2.DeregisterTargetsInput{
+					TargetGroupArn: aws.String(ownedTGARN),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("10.0.0.10")}},
+				}).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+			},
+		},
+		{
+			name:          "fails without the IP address of the instance",
+			targetType:    elbv2.TargetTypeEnumIp,
+			instance:      &infrav1.Instance{ID: "i-1"},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			expectErr:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+			elbV2APIMocks.EXPECT().DescribeTargetGroupsWithContext(context.TODO(), &elbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice([]string{ownedTGARN})}).
+				Return(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{
+					{TargetGroupArn: aws.String(ownedTGARN), TargetType: aws.String(tc.targetType)},
+				}}, nil)
+			tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+
+			s := newExistingLBService(t, elbV2APIMocks)
+			err := s.DeregisterInstanceFromAPIServerLB(context.TODO(), ownedTGARN, tc.instance)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -0,0 +1,489 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+var _ services.ASGInterface = &Autoscaling{}
+
+// Autoscaling is an in-memory implementation of services.ASGInterface.
+//
+// The autoscaling groups of the fake are scaled to their desired capacity on every change, with running instances.
+// Use CompleteInstanceRefresh to finish an instance refresh started by StartASGInstanceRefresh.
+type Autoscaling struct {
+	faults
+
+	mu         sync.Mutex
+	ec2        *EC2
+	groups     map[string]*expinfrav1.AutoScalingGroup
+	refreshing map[string]bool
+	unhealthy  map[string]bool
+}
+
+// NewAutoscaling returns an autoscaling fake without any autoscaling group. When ec2 is not nil, the instances of
+// the autoscaling groups are added to it and terminated in it.
+func NewAutoscaling(ec2 *EC2) *Autoscaling {
+	if ec2 == nil {
+		ec2 = NewEC2()
+	}
+	return &Autoscaling{
+		ec2:        ec2,
+		groups:     map[string]*expinfrav1.AutoScalingGroup{},
+		refreshing: map[string]bool{},
+		unhealthy:  map[string]bool{},
+	}
+}
+
+// Groups returns a copy of every autoscaling group of the fake, sorted by name.
+func (f *Autoscaling) Groups() []expinfrav1.AutoScalingGroup {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]expinfrav1.AutoScalingGroup, 0, len(f.groups))
+	for _, g := range f.groups {
+		out = append(out, *g.DeepCopy())
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Name < out[b].Name })
+	return out
+}
+
+// CompleteInstanceRefresh finishes the instance refresh of the given autoscaling group.
+func (f *Autoscaling) CompleteInstanceRefresh(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.refreshing, name)
+}
+
+// MarkUnhealthy makes the given instances unhealthy in every target group.
+func (f *Autoscaling) MarkUnhealthy(instanceIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range instanceIDs {
+		f.unhealthy[id] = true
+	}
+}
+
+// ASGIfExists returns the autoscaling group with the given name, or nil if it does not exist.
+func (f *Autoscaling) ASGIfExists(_ context.Context, id *string) (*expinfrav1.AutoScalingGroup, error) {
+	if err := f.fault("ASGIfExists"); err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if g, ok := f.groups[*id]; ok {
+		return g.DeepCopy(), nil
+	}
+	return nil, nil
+}
+
+// GetASGByName returns the autoscaling group of the given machine pool, or nil if it does not exist.
+func (f *Autoscaling) GetASGByName(ctx context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+	if err := f.fault("GetASGByName"); err != nil {
+		return nil, err
+	}
+	return f.ASGIfExists(ctx, ptr.To(scope.Name()))
+}
+
+// CreateASG creates the autoscaling group of the given machine pool and scales it to its desired capacity.
+func (f *Autoscaling) CreateASG(_ context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error) {
+	if err := f.fault("CreateASG"); err != nil {
+		return nil, err
+	}
+	subnets, err := subnetIDs(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.groups[scope.Name()]; ok {
+		return nil, errors.Errorf("AutoScalingGroup %q already exists", scope.Name())
+	}
+	g := &expinfrav1.AutoScalingGroup{
+		ID:   f.ec2.ids.generate("asg"),
+		Name: scope.Name(),
+		Tags: copyTags(scope.AWSMachinePool.Spec.AdditionalTags, nil, nil),
+	}
+	f.groups[g.Name] = g
+	f.update(g, scope, subnets)
+	return g.DeepCopy(), nil
+}
+
+// UpdateASG updates the sizes, subnets and policies of the autoscaling group of the given machine pool and scales
+// it to its desired capacity.
+func (f *Autoscaling) UpdateASG(_ context.Context, scope *scope.MachinePoolScope) error {
+	if err := f.fault("UpdateASG"); err != nil {
+		return err
+	}
+	subnets, err := subnetIDs(scope)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[scope.Name()]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", scope.Name())
+	}
+	f.update(g, scope, subnets)
+	return nil
+}
+
+// StartASGInstanceRefresh starts an instance refresh of the autoscaling group of the given machine pool.
+func (f *Autoscaling) StartASGInstanceRefresh(_ context.Context, scope *scope.MachinePoolScope) error {
+	if err := f.fault("StartASGInstanceRefresh"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.refreshing[scope.Name()] {
+		return errors.Errorf("an instance refresh of AutoScalingGroup %q is already in progress", scope.Name())
+	}
+	f.refreshing[scope.Name()] = true
+	return nil
+}
+
+// CanStartASGInstanceRefresh returns false while an instance refresh of the autoscaling group of the given machine
+// pool is in progress.
+func (f *Autoscaling) CanStartASGInstanceRefresh(_ context.Context, scope *scope.MachinePoolScope) (bool, error) {
+	if err := f.fault("CanStartASGInstanceRefresh"); err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return !f.refreshing[scope.Name()], nil
+}
+
+// UpdateResourceTags updates the tags of the autoscaling group with the given name.
+func (f *Autoscaling) UpdateResourceTags(_ context.Context, resourceID *string, create, remove map[string]string) error {
+	if err := f.fault("UpdateResourceTags"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[*resourceID]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", *resourceID)
+	}
+	g.Tags = copyTags(g.Tags, create, remove)
+	return nil
+}
+
+// DeleteASGAndWait deletes the autoscaling group with the given name and terminates its instances.
+func (f *Autoscaling) DeleteASGAndWait(_ context.Context, id string) error {
+	if err := f.fault("DeleteASGAndWait"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[id]
+	if !ok {
+		return nil
+	}
+	for _, i := range g.Instances {
+		_ = f.ec2.SetInstanceState(i.ID, infrav1.InstanceStateTerminated)
+	}
+	delete(f.groups, id)
+	delete(f.refreshing, id)
+	return nil
+}
+
+// SuspendProcesses suspends the given processes of the autoscaling group with the given name.
+func (f *Autoscaling) SuspendProcesses(_ context.Context, name string, processes []string) error {
+	if err := f.fault("SuspendProcesses"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[name]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", name)
+	}
+	for _, p := range processes {
+		if !contains(g.CurrentlySuspendProcesses, p) {
+			g.CurrentlySuspendProcesses = append(g.CurrentlySuspendProcesses, p)
+		}
+	}
+	return nil
+}
+
+// ResumeProcesses resumes the given processes of the autoscaling group with the given name.
+func (f *Autoscaling) ResumeProcesses(_ context.Context, name string, processes []string) error {
+	if err := f.fault("ResumeProcesses"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[name]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", name)
+	}
+	g.CurrentlySuspendProcesses = remove(g.CurrentlySuspendProcesses, processes)
+	return nil
+}
+
+// TerminateInstancesAndDecrementDesiredCapacity terminates the given instances and decrements the desired capacity
+// of their autoscaling groups.
+func (f *Autoscaling) TerminateInstancesAndDecrementDesiredCapacity(_ context.Context, instanceIDs []string) error {
+	if err := f.fault("TerminateInstancesAndDecrementDesiredCapacity"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range instanceIDs {
+		g := f.groupOf(id)
+		if g == nil {
+			return errors.Errorf("failed to terminate instance %q of AutoScalingGroup: instance not found", id)
+		}
+		f.terminate(g, id)
+		g.DesiredCapacity = ptr.To(ptr.Deref(g.DesiredCapacity, 0) - 1)
+	}
+	return nil
+}
+
+// ReplaceInstance terminates the given instance and launches a new instance in its autoscaling group.
+func (f *Autoscaling) ReplaceInstance(_ context.Context, instanceID string) error {
+	if err := f.fault("ReplaceInstance"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g := f.groupOf(instanceID)
+	if g == nil {
+		return errors.Errorf("failed to replace instance %q of AutoScalingGroup: instance not found", instanceID)
+	}
+	f.terminate(g, instanceID)
+	f.scale(g)
+	return nil
+}
+
+// AttachLoadBalancers attaches the given target groups and classic load balancers to the autoscaling group with
+// the given name.
+func (f *Autoscaling) AttachLoadBalancers(_ context.Context, name string, targetGroupARNs, loadBalancerNames []string) error {
+	if err := f.fault("AttachLoadBalancers"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[name]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", name)
+	}
+	for _, arn := range targetGroupARNs {
+		if !contains(g.TargetGroupARNs, arn) {
+			g.TargetGroupARNs = append(g.TargetGroupARNs, arn)
+		}
+	}
+	for _, lb := range loadBalancerNames {
+		if !contains(g.LoadBalancerNames, lb) {
+			g.LoadBalancerNames = append(g.LoadBalancerNames, lb)
+		}
+	}
+	return nil
+}
+
+// DetachLoadBalancers detaches the given target groups and classic load balancers from the autoscaling group with
+// the given name.
+func (f *Autoscaling) DetachLoadBalancers(_ context.Context, name string, targetGroupARNs, loadBalancerNames []string) error {
+	if err := f.fault("DetachLoadBalancers"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g, ok := f.groups[name]
+	if !ok {
+		return errors.Errorf("AutoScalingGroup %q not found", name)
+	}
+	g.TargetGroupARNs = remove(g.TargetGroupARNs, targetGroupARNs)
+	g.LoadBalancerNames = remove(g.LoadBalancerNames, loadBalancerNames)
+	return nil
+}
+
+// UnhealthyTargetGroupInstances returns the given instances marked unhealthy with MarkUnhealthy.
+func (f *Autoscaling) UnhealthyTargetGroupInstances(_ context.Context, targetGroupARNs, instanceIDs []string) ([]string, error) {
+	if err := f.fault("UnhealthyTargetGroupInstances"); err != nil {
+		return nil, err
+	}
+	if len(targetGroupARNs) == 0 {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var out []string
+	for _, id := range instanceIDs {
+		if f.unhealthy[id] {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+// InstanceCapacityTypes returns the capacity type of the given instances: spot for the instances with spot market
+// options, on-demand for the others.
+func (f *Autoscaling) InstanceCapacityTypes(ctx context.Context, instanceIDs []string) (map[string]expinfrav1.ManagedMachinePoolCapacityType, error) {
+	if err := f.fault("InstanceCapacityTypes"); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]expinfrav1.ManagedMachinePoolCapacityType, len(instanceIDs))
+	for _, id := range instanceIDs {
+		i, err := f.ec2.InstanceIfExists(ctx, ptr.To(id))
+		if err != nil {
+			continue
+		}
+		out[id] = expinfrav1.ManagedMachinePoolCapacityTypeOnDemand
+		if i.SpotMarketOptions != nil {
+			out[id] = expinfrav1.ManagedMachinePoolCapacityTypeSpot
+		}
+	}
+	return out, nil
+}
+
+// SubnetIDs returns the IDs of the subnets of the given machine pool. The fake only resolves subnets referenced by
+// ID.
+func (f *Autoscaling) SubnetIDs(_ context.Context, scope *scope.MachinePoolScope) ([]string, error) {
+	if err := f.fault("SubnetIDs"); err != nil {
+		return nil, err
+	}
+	return subnetIDs(scope)
+}
+
+// update sets the sizes, subnets and policies of the given autoscaling group from the given machine pool, then
+// scales it.
+func (f *Autoscaling) update(g *expinfrav1.AutoScalingGroup, scope *scope.MachinePoolScope, subnets []string) {
+	spec := scope.AWSMachinePool.Spec
+	g.MinSize = spec.MinSize
+	g.MaxSize = spec.MaxSize
+	g.Subnets = subnets
+	g.CapacityRebalance = spec.CapacityRebalance
+	g.MixedInstancesPolicy = spec.MixedInstancesPolicy.DeepCopy()
+	g.DesiredCapacity = ptr.To(spec.MinSize)
+	if replicas := scope.MachinePool.Spec.Replicas; replicas != nil {
+		g.DesiredCapacity = ptr.To(*replicas)
+	}
+	f.scale(g)
+}
+
+// scale launches or terminates instances until the given autoscaling group runs its desired capacity.
+func (f *Autoscaling) scale(g *expinfrav1.AutoScalingGroup) {
+	desired := int(ptr.Deref(g.DesiredCapacity, 0))
+	for len(g.Instances) > desired {
+		f.terminate(g, g.Instances[len(g.Instances)-1].ID)
+	}
+	for len(g.Instances) < desired {
+		i := infrav1.Instance{
+			ID:    f.ec2.ids.generate("i"),
+			State: infrav1.InstanceStateRunning,
+			Tags:  map[string]string{"aws:autoscaling:groupName": g.Name},
+		}
+		if len(g.Subnets) > 0 {
+			i.SubnetID = g.Subnets[len(g.Instances)%len(g.Subnets)]
+		}
+		f.ec2.AddInstance(&i)
+		g.Instances = append(g.Instances, i)
+	}
+}
+
+// terminate removes the given instance from the given autoscaling group and terminates it.
+func (f *Autoscaling) terminate(g *expinfrav1.AutoScalingGroup, instanceID string) {
+	for n := range g.Instances {
+		if g.Instances[n].ID == instanceID {
+			g.Instances = append(g.Instances[:n], g.Instances[n+1:]...)
+			break
+		}
+	}
+	_ = f.ec2.SetInstanceState(instanceID, infrav1.InstanceStateTerminated)
+}
+
+// groupOf returns the autoscaling group running the given instance, if any.
+func (f *Autoscaling) groupOf(instanceID string) *expinfrav1.AutoScalingGroup {
+	for _, g := range f.groups {
+		for _, i := range g.Instances {
+			if i.ID == instanceID {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+// subnetIDs returns the IDs of the subnets of the given machine pool, which must be referenced by ID.
+func subnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
+	ids := make([]string, 0, len(scope.AWSMachinePool.Spec.Subnets))
+	for _, subnet := range scope.AWSMachinePool.Spec.Subnets {
+		if subnet.ID == nil {
+			return nil, errors.New("the autoscaling fake only resolves subnets referenced by ID")
+		}
+		ids = append(ids, *subnet.ID)
+	}
+	return ids, nil
+}
+
+// remove returns the elements of s that are not in values.
+func remove(s, values []string) []string {
+	out := []string{}
+	for _, e := range s {
+		if !contains(values, e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
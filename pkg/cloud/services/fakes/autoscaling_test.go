@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func newMachinePoolScope(name string, replicas int32) *scope.MachinePoolScope {
+	return &scope.MachinePoolScope{
+		MachinePool: &expclusterv1.MachinePool{
+			Spec: expclusterv1.MachinePoolSpec{Replicas: ptr.To(replicas)},
+		},
+		AWSMachinePool: &expinfrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: expinfrav1.AWSMachinePoolSpec{
+				MinSize: 0,
+				MaxSize: 5,
+				Subnets: []infrav1.AWSResourceReference{{ID: ptr.To("subnet-a")}, {ID: ptr.To("subnet-b")}},
+			},
+		},
+	}
+}
+
+func TestAutoscalingScaling(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas []int32
+		want     int
+	}{
+		{
+			name:     "creates the desired capacity",
+			replicas: []int32{3},
+			want:     3,
+		},
+		{
+			name:     "scales up",
+			replicas: []int32{1, 4},
+			want:     4,
+		},
+		{
+			name:     "scales down",
+			replicas: []int32{4, 2},
+			want:     2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+
+			ec2 := NewEC2()
+			f := NewAutoscaling(ec2)
+			_, err := f.CreateASG(ctx, newMachinePoolScope("pool", tc.replicas[0]))
+			g.Expect(err).NotTo(HaveOccurred())
+			for _, replicas := range tc.replicas[1:] {
+				g.Expect(f.UpdateASG(ctx, newMachinePoolScope("pool", replicas))).To(Succeed())
+			}
+
+			asg, err := f.ASGIfExists(ctx, ptr.To("pool"))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(asg.Instances).To(HaveLen(tc.want))
+			g.Expect(asg.Subnets).To(Equal([]string{"subnet-a", "subnet-b"}))
+
+			running := 0
+			for _, i := range ec2.Instances() {
+				if i.State == infrav1.InstanceStateRunning {
+					running++
+				}
+			}
+			g.Expect(running).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestAutoscalingInstances(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewAutoscaling(nil)
+	pool := newMachinePoolScope("pool", 2)
+	asg, err := f.CreateASG(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	first, second := asg.Instances[0].ID, asg.Instances[1].ID
+
+	g.Expect(f.ReplaceInstance(ctx, first)).To(Succeed())
+	asg, err = f.GetASGByName(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(asg.Instances).To(HaveLen(2))
+	g.Expect(asg.Instances[0].ID).To(Equal(second))
+
+	g.Expect(f.TerminateInstancesAndDecrementDesiredCapacity(ctx, []string{second})).To(Succeed())
+	asg, err = f.GetASGByName(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(asg.Instances).To(HaveLen(1))
+	g.Expect(*asg.DesiredCapacity).To(BeEquivalentTo(1))
+
+	g.Expect(f.StartASGInstanceRefresh(ctx, pool)).To(Succeed())
+	canStart, err := f.CanStartASGInstanceRefresh(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(canStart).To(BeFalse())
+	f.CompleteInstanceRefresh("pool")
+	canStart, err = f.CanStartASGInstanceRefresh(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(canStart).To(BeTrue())
+
+	g.Expect(f.DeleteASGAndWait(ctx, "pool")).To(Succeed())
+	asg, err = f.GetASGByName(ctx, pool)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(asg).To(BeNil())
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakes provides stateful, in-memory implementations of the EC2, autoscaling and ELB services.
+//
+// Unlike the gomock mocks in mock_services, the fakes keep track of the resources they create, so that tests
+// can drive a reconciliation flow over several reconciles without setting up an expectation for every AWS call.
+// The fakes do not talk to AWS and are safe for concurrent use.
+package fakes
@@ -0,0 +1,583 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	ec2svc "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
+)
+
+var _ services.EC2Interface = &EC2{}
+
+// EC2 is an in-memory implementation of services.EC2Interface.
+//
+// Instances are created in the running state and stay known after they are terminated, like on AWS. Use
+// SetInstanceState to simulate the other transitions of an instance.
+type EC2 struct {
+	faults
+
+	mu              sync.Mutex
+	ids             ids
+	instances       map[string]*infrav1.Instance
+	interfaces      map[string][]string
+	launchTemplates map[string]*launchTemplate
+	bastionID       string
+
+	// CoreSecurityGroups are the IDs of the security groups returned by GetCoreSecurityGroups and attached to the
+	// created instances. It must be set before the fake is used.
+	CoreSecurityGroups []string
+
+	// InstanceTypeOfferings maps availability zones to the instance types offered in them, as seen by
+	// AvailabilityZonesOfferingInstanceTypes.
+	InstanceTypeOfferings map[string][]string
+}
+
+// launchTemplate is a launch template known to the EC2 fake.
+type launchTemplate struct {
+	name              string
+	template          *expinfrav1.AWSLaunchTemplate
+	userDataHash      string
+	userDataSecretKey apimachinerytypes.NamespacedName
+	tags              map[string]string
+	versions          []int64
+}
+
+// NewEC2 returns an EC2 fake without any instance or launch template.
+func NewEC2() *EC2 {
+	return &EC2{
+		instances:       map[string]*infrav1.Instance{},
+		interfaces:      map[string][]string{},
+		launchTemplates: map[string]*launchTemplate{},
+	}
+}
+
+// Instances returns a copy of every instance known to the fake, terminated instances included, sorted by ID.
+func (f *EC2) Instances() []infrav1.Instance {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]infrav1.Instance, 0, len(f.instances))
+	for _, i := range f.instances {
+		out = append(out, *i.DeepCopy())
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].ID < out[b].ID })
+	return out
+}
+
+// AddInstance adds a copy of the given instance to the fake, e.g. to simulate an instance created out of band.
+func (f *EC2) AddInstance(instance *infrav1.Instance) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := instance.DeepCopy()
+	if i.ID == "" {
+		i.ID = f.ids.generate("i")
+	}
+	f.instances[i.ID] = i
+	for _, eni := range i.NetworkInterfaces {
+		f.interfaces[eni] = append([]string{}, i.SecurityGroupIDs...)
+	}
+}
+
+// SetInstanceState sets the state of the given instance.
+func (f *EC2) SetInstanceState(instanceID string, state infrav1.InstanceState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[instanceID]
+	if !ok {
+		return ec2svc.ErrInstanceNotFoundByID
+	}
+	i.State = state
+	return nil
+}
+
+// InstanceIfExists returns the instance with the given ID, or ErrInstanceNotFoundByID.
+func (f *EC2) InstanceIfExists(_ context.Context, id *string) (*infrav1.Instance, error) {
+	if err := f.fault("InstanceIfExists"); err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[*id]
+	if !ok {
+		return nil, ec2svc.ErrInstanceNotFoundByID
+	}
+	return i.DeepCopy(), nil
+}
+
+// TerminateInstance moves the given instance to the terminated state.
+func (f *EC2) TerminateInstance(_ context.Context, id string) error {
+	if err := f.fault("TerminateInstance"); err != nil {
+		return err
+	}
+	return f.SetInstanceState(id, infrav1.InstanceStateTerminated)
+}
+
+// TerminateInstanceAndWait moves the given instance to the terminated state.
+func (f *EC2) TerminateInstanceAndWait(_ context.Context, instanceID string) error {
+	if err := f.fault("TerminateInstanceAndWait"); err != nil {
+		return err
+	}
+	return f.SetInstanceState(instanceID, infrav1.InstanceStateTerminated)
+}
+
+// CreateInstance creates a running instance for the given machine, tagged with its name and role.
+func (f *EC2) CreateInstance(_ context.Context, scope *scope.MachineScope, userData []byte, _ string) (*infrav1.Instance, error) {
+	if err := f.fault("CreateInstance"); err != nil {
+		return nil, err
+	}
+
+	spec := scope.AWSMachine.Spec
+	if spec.AMI.ID == nil {
+		return nil, errors.New("the EC2 fake cannot look up AMIs, spec.ami.id must be set")
+	}
+	securityGroups, err := securityGroupIDs(spec.AdditionalSecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &infrav1.Instance{
+		State:            infrav1.InstanceStateRunning,
+		Type:             spec.InstanceType,
+		ImageID:          *spec.AMI.ID,
+		SSHKeyName:       spec.SSHKeyName,
+		SecurityGroupIDs: append(append([]string{}, f.CoreSecurityGroups...), securityGroups...),
+		UserData:         ptr.To(string(userData)),
+		IAMProfile:       spec.IAMInstanceProfile,
+		Tags: copyTags(spec.AdditionalTags, map[string]string{
+			infrav1.NameAWSClusterAPIRole: scope.Role(),
+			"Name":                        scope.Name(),
+		}, nil),
+		RootVolume:              spec.RootVolume.DeepCopy(),
+		SpotMarketOptions:       spec.SpotMarketOptions.DeepCopy(),
+		InstanceMetadataOptions: spec.InstanceMetadataOptions.DeepCopy(),
+		Tenancy:                 spec.Tenancy,
+	}
+	if spec.Subnet != nil && spec.Subnet.ID != nil {
+		instance.SubnetID = *spec.Subnet.ID
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance.ID = f.ids.generate("i")
+	eni := f.ids.generate("eni")
+	instance.NetworkInterfaces = []string{eni}
+	f.interfaces[eni] = append([]string{}, instance.SecurityGroupIDs...)
+	f.instances[instance.ID] = instance
+	return instance.DeepCopy(), nil
+}
+
+// GetRunningInstanceByTags returns the pending or running instance tagged with the name and role of the given
+// machine, if any.
+func (f *EC2) GetRunningInstanceByTags(_ context.Context, scope *scope.MachineScope) (*infrav1.Instance, error) {
+	if err := f.fault("GetRunningInstanceByTags"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, i := range f.instances {
+		if i.State != infrav1.InstanceStatePending && i.State != infrav1.InstanceStateRunning {
+			continue
+		}
+		if i.Tags["Name"] == scope.Name() && i.Tags[infrav1.NameAWSClusterAPIRole] == scope.Role() {
+			return i.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+// GetAdditionalSecurityGroupsIDs returns the IDs of the given security groups. The fake only resolves security
+// groups referenced by ID.
+func (f *EC2) GetAdditionalSecurityGroupsIDs(_ context.Context, securityGroups []infrav1.AWSResourceReference) ([]string, error) {
+	if err := f.fault("GetAdditionalSecurityGroupsIDs"); err != nil {
+		return nil, err
+	}
+	return securityGroupIDs(securityGroups)
+}
+
+// GetCoreSecurityGroups returns the CoreSecurityGroups of the fake.
+func (f *EC2) GetCoreSecurityGroups(_ context.Context, _ *scope.MachineScope) ([]string, error) {
+	if err := f.fault("GetCoreSecurityGroups"); err != nil {
+		return nil, err
+	}
+	return append([]string{}, f.CoreSecurityGroups...), nil
+}
+
+// GetInstanceSecurityGroups returns the security groups of the network interfaces of the given instance, keyed by
+// network interface ID.
+func (f *EC2) GetInstanceSecurityGroups(_ context.Context, instanceID string) (map[string][]string, error) {
+	if err := f.fault("GetInstanceSecurityGroups"); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[instanceID]
+	if !ok {
+		return nil, ec2svc.ErrInstanceNotFoundByID
+	}
+	out := map[string][]string{}
+	for _, eni := range i.NetworkInterfaces {
+		out[eni] = append([]string{}, f.interfaces[eni]...)
+	}
+	return out, nil
+}
+
+// UpdateInstanceSecurityGroups sets the security groups of the given instance and of its network interfaces.
+func (f *EC2) UpdateInstanceSecurityGroups(_ context.Context, id string, securityGroups []string) error {
+	if err := f.fault("UpdateInstanceSecurityGroups"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[id]
+	if !ok {
+		return ec2svc.ErrInstanceNotFoundByID
+	}
+	i.SecurityGroupIDs = append([]string{}, securityGroups...)
+	for _, eni := range i.NetworkInterfaces {
+		f.interfaces[eni] = append([]string{}, securityGroups...)
+	}
+	return nil
+}
+
+// DetachSecurityGroupsFromNetworkInterface removes the given security groups from the given network interface.
+func (f *EC2) DetachSecurityGroupsFromNetworkInterface(_ context.Context, groups []string, interfaceID string) error {
+	if err := f.fault("DetachSecurityGroupsFromNetworkInterface"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.interfaces[interfaceID]
+	if !ok {
+		return errors.Errorf("network interface %q not found", interfaceID)
+	}
+	f.interfaces[interfaceID] = remove(existing, groups)
+	return nil
+}
+
+// UpdateResourceTags updates the tags of the instance or launch template with the given ID.
+func (f *EC2) UpdateResourceTags(_ context.Context, resourceID *string, create, remove map[string]string) error {
+	if err := f.fault("UpdateResourceTags"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if i, ok := f.instances[*resourceID]; ok {
+		i.Tags = copyTags(i.Tags, create, remove)
+		return nil
+	}
+	if lt, ok := f.launchTemplates[*resourceID]; ok {
+		lt.tags = copyTags(lt.tags, create, remove)
+		return nil
+	}
+	return errors.Errorf("resource %q not found", *resourceID)
+}
+
+// ModifyInstanceMetadataOptions sets the metadata options of the given instance.
+func (f *EC2) ModifyInstanceMetadataOptions(_ context.Context, instanceID string, options *infrav1.InstanceMetadataOptions) error {
+	if err := f.fault("ModifyInstanceMetadataOptions"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.instances[instanceID]
+	if !ok {
+		return ec2svc.ErrInstanceNotFoundByID
+	}
+	i.InstanceMetadataOptions = options.DeepCopy()
+	return nil
+}
+
+// GetConsoleOutputTail always returns an empty console output, as the fake does not boot its instances.
+func (f *EC2) GetConsoleOutputTail(_ context.Context, _ string, _ int) (string, error) {
+	return "", f.fault("GetConsoleOutputTail")
+}
+
+// GetSSMPingStatus always returns an empty status, as the instances of the fake are not registered with SSM.
+func (f *EC2) GetSSMPingStatus(_ context.Context, _ string) (string, error) {
+	return "", f.fault("GetSSMPingStatus")
+}
+
+// DiscoverLaunchTemplateAMI returns the AMI ID of the launch template of the given scope. The fake cannot look up
+// AMIs.
+func (f *EC2) DiscoverLaunchTemplateAMI(_ context.Context, scope scope.LaunchTemplateScope) (*string, error) {
+	if err := f.fault("DiscoverLaunchTemplateAMI"); err != nil {
+		return nil, err
+	}
+	if id := scope.GetLaunchTemplate().AMI.ID; id != nil {
+		return id, nil
+	}
+	return nil, errors.New("the EC2 fake cannot look up AMIs, spec.awsLaunchTemplate.ami.id must be set")
+}
+
+// GetLaunchTemplate returns the latest version of the launch template with the given name, or nil if it does not
+// exist.
+func (f *EC2) GetLaunchTemplate(_ context.Context, id string) (*expinfrav1.AWSLaunchTemplate, string, *apimachinerytypes.NamespacedName, error) {
+	if err := f.fault("GetLaunchTemplate"); err != nil {
+		return nil, "", nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, lt := f.launchTemplateByName(id)
+	if lt == nil {
+		return nil, "", nil, nil
+	}
+	key := lt.userDataSecretKey
+	return lt.template.DeepCopy(), lt.userDataHash, &key, nil
+}
+
+// GetLaunchTemplateID returns the ID of the launch template with the given name, or an empty string if it does not
+// exist.
+func (f *EC2) GetLaunchTemplateID(_ context.Context, id string) (string, error) {
+	if err := f.fault("GetLaunchTemplateID"); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ltID, _ := f.launchTemplateByName(id)
+	return ltID, nil
+}
+
+// GetLaunchTemplateLatestVersion returns the latest version of the launch template with the given ID.
+func (f *EC2) GetLaunchTemplateLatestVersion(_ context.Context, id string) (string, error) {
+	if err := f.fault("GetLaunchTemplateLatestVersion"); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lt, ok := f.launchTemplates[id]
+	if !ok {
+		return "", errors.Errorf("launch template %q not found", id)
+	}
+	return strconv.FormatInt(lt.versions[len(lt.versions)-1], 10), nil
+}
+
+// CreateLaunchTemplate creates a launch template named after the given scope and returns its ID.
+func (f *EC2) CreateLaunchTemplate(_ context.Context, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error) {
+	if err := f.fault("CreateLaunchTemplate"); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, _ := f.launchTemplateByName(scope.LaunchTemplateName()); id != "" {
+		return "", errors.Errorf("launch template %q already exists", scope.LaunchTemplateName())
+	}
+	id := f.ids.generate("lt")
+	f.launchTemplates[id] = &launchTemplate{
+		name:     scope.LaunchTemplateName(),
+		tags:     copyTags(scope.AdditionalTags(), nil, nil),
+		versions: []int64{1},
+	}
+	f.launchTemplates[id].update(scope, imageID, userDataSecretKey, userData)
+	return id, nil
+}
+
+// CreateLaunchTemplateVersion adds a version to the launch template with the given ID.
+func (f *EC2) CreateLaunchTemplateVersion(_ context.Context, id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error {
+	if err := f.fault("CreateLaunchTemplateVersion"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lt, ok := f.launchTemplates[id]
+	if !ok {
+		return errors.Errorf("launch template %q not found", id)
+	}
+	lt.versions = append(lt.versions, lt.versions[len(lt.versions)-1]+1)
+	lt.update(scope, imageID, userDataSecretKey, userData)
+	return nil
+}
+
+// PruneLaunchTemplateVersions deletes the versions of the launch template with the given ID, keeping the latest
+// two versions.
+func (f *EC2) PruneLaunchTemplateVersions(_ context.Context, id string) error {
+	if err := f.fault("PruneLaunchTemplateVersions"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lt, ok := f.launchTemplates[id]
+	if !ok {
+		return errors.Errorf("launch template %q not found", id)
+	}
+	if len(lt.versions) > 2 {
+		lt.versions = lt.versions[len(lt.versions)-2:]
+	}
+	return nil
+}
+
+// DeleteLaunchTemplate deletes the launch template with the given ID.
+func (f *EC2) DeleteLaunchTemplate(_ context.Context, id string) error {
+	if err := f.fault("DeleteLaunchTemplate"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.launchTemplates, id)
+	return nil
+}
+
+// LaunchTemplateNeedsUpdate returns true when the incoming launch template differs from the existing one.
+func (f *EC2) LaunchTemplateNeedsUpdate(_ context.Context, _ scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
+	if err := f.fault("LaunchTemplateNeedsUpdate"); err != nil {
+		return false, err
+	}
+	return !apiequality.Semantic.DeepEqual(incoming, existing), nil
+}
+
+// AvailabilityZonesOfferingInstanceTypes returns the availability zones of InstanceTypeOfferings which offer all
+// the given instance types.
+func (f *EC2) AvailabilityZonesOfferingInstanceTypes(_ context.Context, instanceTypes []string) (sets.Set[string], error) {
+	if err := f.fault("AvailabilityZonesOfferingInstanceTypes"); err != nil {
+		return nil, err
+	}
+
+	zones := sets.New[string]()
+	for zone, offered := range f.InstanceTypeOfferings {
+		if sets.New(offered...).HasAll(instanceTypes...) {
+			zones.Insert(zone)
+		}
+	}
+	return zones, nil
+}
+
+// ReconcileBastion creates a running bastion instance if the fake has none.
+func (f *EC2) ReconcileBastion(_ context.Context) error {
+	if err := f.fault("ReconcileBastion"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if i, ok := f.instances[f.bastionID]; ok && i.State != infrav1.InstanceStateTerminated {
+		return nil
+	}
+	f.bastionID = f.ids.generate("i")
+	f.instances[f.bastionID] = &infrav1.Instance{
+		ID:    f.bastionID,
+		State: infrav1.InstanceStateRunning,
+		Tags:  map[string]string{infrav1.NameAWSClusterAPIRole: infrav1.BastionRoleTagValue},
+	}
+	return nil
+}
+
+// DeleteBastion terminates the bastion instance of the fake, if any.
+func (f *EC2) DeleteBastion(_ context.Context) error {
+	if err := f.fault("DeleteBastion"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if i, ok := f.instances[f.bastionID]; ok {
+		i.State = infrav1.InstanceStateTerminated
+	}
+	return nil
+}
+
+// launchTemplateByName returns the launch template with the given name and its ID, if any.
+func (f *EC2) launchTemplateByName(name string) (string, *launchTemplate) {
+	if name == "" {
+		return "", nil
+	}
+	for id, lt := range f.launchTemplates {
+		if lt.name == name {
+			return id, lt
+		}
+	}
+	return "", nil
+}
+
+// update sets the latest version of the launch template from the given scope.
+func (lt *launchTemplate) update(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) {
+	lt.template = scope.GetLaunchTemplate().DeepCopy()
+	lt.template.AMI.ID = imageID
+	lt.userDataHash = userdata.ComputeHash(userData)
+	lt.userDataSecretKey = userDataSecretKey
+}
+
+// securityGroupIDs returns the IDs of the given security groups, which must be referenced by ID.
+func securityGroupIDs(securityGroups []infrav1.AWSResourceReference) ([]string, error) {
+	ids := make([]string, 0, len(securityGroups))
+	for _, sg := range securityGroups {
+		if sg.ID == nil {
+			return nil, errors.New("the EC2 fake only resolves security groups referenced by ID")
+		}
+		ids = append(ids, *sg.ID)
+	}
+	return ids, nil
+}
+
+// contains returns true if s contains v.
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	ec2svc "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func newMachineScope(name string) *scope.MachineScope {
+	return &scope.MachineScope{
+		Machine: &clusterv1.Machine{},
+		AWSMachine: &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: infrav1.AWSMachineSpec{
+				AMI:                      infrav1.AMIReference{ID: ptr.To("ami-1")},
+				InstanceType:             "m5.large",
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: ptr.To("sg-additional")}},
+			},
+		},
+	}
+}
+
+func TestEC2Instances(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewEC2()
+	f.CoreSecurityGroups = []string{"sg-core"}
+	machine := newMachineScope("machine-1")
+
+	instance, err := f.GetRunningInstanceByTags(ctx, machine)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance).To(BeNil())
+
+	created, err := f.CreateInstance(ctx, machine, []byte("data"), "cloud-config")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created.State).To(Equal(infrav1.InstanceStateRunning))
+	g.Expect(created.ImageID).To(Equal("ami-1"))
+	g.Expect(created.SecurityGroupIDs).To(Equal([]string{"sg-core", "sg-additional"}))
+
+	instance, err = f.GetRunningInstanceByTags(ctx, machine)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance).To(Equal(created))
+
+	g.Expect(f.UpdateInstanceSecurityGroups(ctx, created.ID, []string{"sg-core"})).To(Succeed())
+	groups, err := f.GetInstanceSecurityGroups(ctx, created.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(groups).To(Equal(map[string][]string{created.NetworkInterfaces[0]: {"sg-core"}}))
+
+	g.Expect(f.UpdateResourceTags(ctx, &created.ID, map[string]string{"team": "a"}, map[string]string{"Name": ""})).To(Succeed())
+	g.Expect(f.TerminateInstance(ctx, created.ID)).To(Succeed())
+
+	instance, err = f.InstanceIfExists(ctx, &created.ID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance.State).To(Equal(infrav1.InstanceStateTerminated))
+	g.Expect(instance.Tags).To(HaveKeyWithValue("team", "a"))
+	g.Expect(instance.Tags).NotTo(HaveKey("Name"))
+
+	instance, err = f.GetRunningInstanceByTags(ctx, machine)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(instance).To(BeNil())
+
+	_, err = f.InstanceIfExists(ctx, ptr.To("i-unknown"))
+	g.Expect(err).To(MatchError(ec2svc.ErrInstanceNotFoundByID))
+}
+
+func TestEC2InjectError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewEC2()
+	injected := errors.New("RequestLimitExceeded")
+	f.InjectError("CreateInstance", injected)
+
+	_, err := f.CreateInstance(ctx, newMachineScope("machine-1"), nil, "")
+	g.Expect(err).To(MatchError(injected))
+	g.Expect(f.Instances()).To(BeEmpty())
+
+	f.InjectError("CreateInstance", nil)
+	_, err = f.CreateInstance(ctx, newMachineScope("machine-1"), nil, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.Instances()).To(HaveLen(1))
+}
+
+func TestEC2Bastion(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewEC2()
+	g.Expect(f.ReconcileBastion(ctx)).To(Succeed())
+	g.Expect(f.ReconcileBastion(ctx)).To(Succeed())
+	g.Expect(f.Instances()).To(HaveLen(1))
+
+	g.Expect(f.DeleteBastion(ctx)).To(Succeed())
+	g.Expect(f.Instances()[0].State).To(Equal(infrav1.InstanceStateTerminated))
+}
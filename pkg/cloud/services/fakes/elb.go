@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+)
+
+// APIServerELBName is the name of the classic load balancer of the API server in the ELB fake, and of the
+// load balancers without a name.
+const APIServerELBName = "apiserver"
+
+var _ services.ELBInterface = &ELB{}
+
+// ELB is an in-memory implementation of services.ELBInterface.
+//
+// Each load balancer of the fake has a single target group, identified by TargetGroupARN. The deregistration of an
+// instance completes immediately, so that instances are never draining.
+type ELB struct {
+	faults
+
+	mu         sync.Mutex
+	reconciled bool
+	targets    map[string]map[string]bool
+}
+
+// NewELB returns an ELB fake without any load balancer.
+func NewELB() *ELB {
+	return &ELB{
+		targets: map[string]map[string]bool{},
+	}
+}
+
+// TargetGroupARN returns the ARN of the target group of the load balancer with the given name in the fake.
+func TargetGroupARN(lbName string) string {
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:::targetgroup/%s", lbName)
+}
+
+// Reconciled returns true if the load balancers have been reconciled and not deleted since.
+func (f *ELB) Reconciled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.reconciled
+}
+
+// Registered returns the IDs of the instances registered with the given classic load balancer name or target group
+// ARN, sorted.
+func (f *ELB) Registered(target string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]string, 0, len(f.targets[target]))
+	for id := range f.targets[target] {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ReconcileLoadbalancers marks the load balancers as reconciled.
+func (f *ELB) ReconcileLoadbalancers(_ context.Context) error {
+	if err := f.fault("ReconcileLoadbalancers"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reconciled = true
+	return nil
+}
+
+// DeleteLoadbalancers deletes the load balancers and their registrations.
+func (f *ELB) DeleteLoadbalancers(_ context.Context) error {
+	if err := f.fault("DeleteLoadbalancers"); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reconciled = false
+	f.targets = map[string]map[string]bool{}
+	return nil
+}
+
+// IsInstanceRegisteredWithAPIServerELB returns true if the instance is registered with the classic load balancer of
+// the API server.
+func (f *ELB) IsInstanceRegisteredWithAPIServerELB(_ context.Context, i *infrav1.Instance) (bool, error) {
+	if err := f.fault("IsInstanceRegisteredWithAPIServerELB"); err != nil {
+		return false, err
+	}
+	return f.registered(APIServerELBName, i.ID), nil
+}
+
+// IsInstanceRegisteredWithAPIServerLB returns the ARN of the target group of the given load balancer and true if the
+// instance is registered with it.
+func (f *ELB) IsInstanceRegisteredWithAPIServerLB(_ context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error) {
+	if err := f.fault("IsInstanceRegisteredWithAPIServerLB"); err != nil {
+		return nil, false, err
+	}
+	arn := TargetGroupARN(lbName(lb))
+	if !f.registered(arn, i.ID) {
+		return []string{}, false, nil
+	}
+	return []string{arn}, true, nil
+}
+
+// IsInstanceDrainingFromAPIServerLB always returns false, as the deregistrations of the fake complete immediately.
+func (f *ELB) IsInstanceDrainingFromAPIServerLB(_ context.Context, _ *infrav1.Instance, _ *infrav1.AWSLoadBalancerSpec) (bool, error) {
+	return false, f.fault("IsInstanceDrainingFromAPIServerLB")
+}
+
+// RegisterInstanceWithAPIServerELB registers the instance with the classic load balancer of the API server.
+func (f *ELB) RegisterInstanceWithAPIServerELB(_ context.Context, i *infrav1.Instance) error {
+	if err := f.fault("RegisterInstanceWithAPIServerELB"); err != nil {
+		return err
+	}
+	return f.register(APIServerELBName, i.ID)
+}
+
+// DeregisterInstanceFromAPIServerELB deregisters the instance from the classic load balancer of the API server.
+func (f *ELB) DeregisterInstanceFromAPIServerELB(_ context.Context, i *infrav1.Instance) error {
+	if err := f.fault("DeregisterInstanceFromAPIServerELB"); err != nil {
+		return err
+	}
+	f.deregister(APIServerELBName, i.ID)
+	return nil
+}
+
+// RegisterInstanceWithAPIServerLB registers the instance with the target group of the given load balancer.
+func (f *ELB) RegisterInstanceWithAPIServerLB(_ context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error {
+	if err := f.fault("RegisterInstanceWithAPIServerLB"); err != nil {
+		return err
+	}
+	return f.register(TargetGroupARN(lbName(lb)), i.ID)
+}
+
+// DeregisterInstanceFromAPIServerLB deregisters the instance from the given target group.
+func (f *ELB) DeregisterInstanceFromAPIServerLB(_ context.Context, targetGroupArn string, i *infrav1.Instance) error {
+	if err := f.fault("DeregisterInstanceFromAPIServerLB"); err != nil {
+		return err
+	}
+	f.deregister(targetGroupArn, i.ID)
+	return nil
+}
+
+// RegisterInstanceWithLoadBalancerAttachments registers the instance with the given target groups and classic load
+// balancers. Unlike the other registrations, these do not require the load balancers to be reconciled.
+func (f *ELB) RegisterInstanceWithLoadBalancerAttachments(_ context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error {
+	if err := f.fault("RegisterInstanceWithLoadBalancerAttachments"); err != nil {
+		return err
+	}
+	if attachments == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, target := range append(append([]string{}, attachments.TargetGroupARNs...), attachments.ClassicLoadBalancerNames...) {
+		if f.targets[target] == nil {
+			f.targets[target] = map[string]bool{}
+		}
+		f.targets[target][i.ID] = true
+	}
+	return nil
+}
+
+// DeregisterInstanceFromLoadBalancerAttachments deregisters the instance from the given target groups and classic
+// load balancers.
+func (f *ELB) DeregisterInstanceFromLoadBalancerAttachments(_ context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error {
+	if err := f.fault("DeregisterInstanceFromLoadBalancerAttachments"); err != nil {
+		return err
+	}
+	if attachments == nil {
+		return nil
+	}
+	for _, target := range append(append([]string{}, attachments.TargetGroupARNs...), attachments.ClassicLoadBalancerNames...) {
+		f.deregister(target, i.ID)
+	}
+	return nil
+}
+
+// register registers the instance with the given target, which requires the load balancers to be reconciled.
+func (f *ELB) register(target, instanceID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.reconciled {
+		return errors.Errorf("load balancer of target %q not found", target)
+	}
+	if f.targets[target] == nil {
+		f.targets[target] = map[string]bool{}
+	}
+	f.targets[target][instanceID] = true
+	return nil
+}
+
+// deregister deregisters the instance from the given target.
+func (f *ELB) deregister(target, instanceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.targets[target], instanceID)
+}
+
+// registered returns true if the instance is registered with the given target.
+func (f *ELB) registered(target, instanceID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.targets[target][instanceID]
+}
+
+// lbName returns the name of the given load balancer in the fake.
+func lbName(lb *infrav1.AWSLoadBalancerSpec) string {
+	if lb == nil || lb.Name == nil {
+		return APIServerELBName
+	}
+	return *lb.Name
+}
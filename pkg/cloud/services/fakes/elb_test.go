@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+func TestELBRegistrations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewELB()
+	instance := &infrav1.Instance{ID: "i-1"}
+	lb := &infrav1.AWSLoadBalancerSpec{Name: ptr.To("api")}
+
+	g.Expect(f.RegisterInstanceWithAPIServerLB(ctx, instance, lb)).NotTo(Succeed())
+
+	g.Expect(f.ReconcileLoadbalancers(ctx)).To(Succeed())
+	g.Expect(f.RegisterInstanceWithAPIServerLB(ctx, instance, lb)).To(Succeed())
+	g.Expect(f.RegisterInstanceWithAPIServerELB(ctx, instance)).To(Succeed())
+
+	arns, registered, err := f.IsInstanceRegisteredWithAPIServerLB(ctx, instance, lb)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeTrue())
+	g.Expect(arns).To(Equal([]string{TargetGroupARN("api")}))
+
+	registered, err = f.IsInstanceRegisteredWithAPIServerELB(ctx, instance)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(registered).To(BeTrue())
+
+	g.Expect(f.DeregisterInstanceFromAPIServerLB(ctx, arns[0], instance)).To(Succeed())
+	g.Expect(f.Registered(TargetGroupARN("api"))).To(BeEmpty())
+	g.Expect(f.Registered(APIServerELBName)).To(Equal([]string{"i-1"}))
+
+	g.Expect(f.DeleteLoadbalancers(ctx)).To(Succeed())
+	g.Expect(f.Reconciled()).To(BeFalse())
+	g.Expect(f.Registered(APIServerELBName)).To(BeEmpty())
+}
+
+func TestELBLoadBalancerAttachments(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	f := NewELB()
+	instance := &infrav1.Instance{ID: "i-1"}
+	attachments := &infrav1.LoadBalancerAttachments{
+		TargetGroupARNs:          []string{"arn:tg"},
+		ClassicLoadBalancerNames: []string{"classic"},
+	}
+
+	g.Expect(f.RegisterInstanceWithLoadBalancerAttachments(ctx, instance, attachments)).To(Succeed())
+	g.Expect(f.Registered("arn:tg")).To(Equal([]string{"i-1"}))
+	g.Expect(f.Registered("classic")).To(Equal([]string{"i-1"}))
+
+	g.Expect(f.DeregisterInstanceFromLoadBalancerAttachments(ctx, instance, attachments)).To(Succeed())
+	g.Expect(f.Registered("arn:tg")).To(BeEmpty())
+	g.Expect(f.Registered("classic")).To(BeEmpty())
+}
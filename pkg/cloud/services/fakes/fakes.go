@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"fmt"
+	"sync"
+)
+
+// faults holds the errors injected into the methods of a fake.
+type faults struct {
+	mu   sync.Mutex
+	errs map[string]error
+}
+
+// InjectError makes every following call to the given method of the fake return err. A nil err clears the
+// injected error.
+func (f *faults) InjectError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+	if f.errs == nil {
+		f.errs = map[string]error{}
+	}
+	f.errs[method] = err
+}
+
+// fault returns the error injected into the given method, if any.
+func (f *faults) fault(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.errs[method]
+}
+
+// ids generates the identifiers of the resources of a fake.
+type ids struct {
+	mu   sync.Mutex
+	next int
+}
+
+// generate returns a new identifier with the given prefix, in the format of the AWS identifiers.
+func (g *ids) generate(prefix string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.next++
+	return fmt.Sprintf("%s-%017x", prefix, g.next)
+}
+
+// copyTags returns a copy of the given tags, with the tags of create set and the tags of remove deleted.
+func copyTags(tags map[string]string, create, remove map[string]string) map[string]string {
+	out := make(map[string]string, len(tags)+len(create))
+	for k, v := range tags {
+		out[k] = v
+	}
+	for k := range remove {
+		delete(out, k)
+	}
+	for k, v := range create {
+		out[k] = v
+	}
+	return out
+}
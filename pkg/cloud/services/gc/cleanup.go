@@ -35,6 +35,14 @@ const (
 	eksClusterNameTag = "aws:eks:cluster-name"
 )
 
+// persistentVolumeTags are the tags of the volumes provisioned for persistent volumes.
+var persistentVolumeTags = []string{
+	"kubernetes.io/created-for/pv/name",
+	"kubernetes.io/created-for/pvc/name",
+	"CSIVolumeName",
+	"ebs.csi.aws.com/cluster",
+}
+
 // ReconcileDelete is responsible for determining if the infra cluster needs to be garbage collected. If
 // does then it will perform garbage collection. For example, it will delete the ELB/NLBs that where created
 // as a result of Services of type load balancer.
@@ -68,29 +76,76 @@ func (s *Service) deleteResources(ctx context.Context) error {
 		return fmt.Errorf("collecting resources: %w", err)
 	}
 
-	cleanupFuncs := s.cleanupFuncs
+	if deleteErr := s.clusterCleanupFuncs().Execute(ctx, resources); deleteErr != nil {
+		return fmt.Errorf("deleting resources: %w", deleteErr)
+	}
 
-	if val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation); found {
-		var gcTaskToFunc = map[infrav1.GCTask]ResourceCleanupFunc{
-			infrav1.GCTaskLoadBalancer:  s.deleteLoadBalancers,
-			infrav1.GCTaskTargetGroup:   s.deleteTargetGroups,
-			infrav1.GCTaskSecurityGroup: s.deleteSecurityGroups,
-		}
+	return nil
+}
 
-		cleanupFuncs = ResourceCleanupFuncs{}
+// ListResourcesToDelete returns the resources the garbage collection of the cluster would delete, without
+// deleting them. The cleanup tasks configured for the cluster are honoured, but not its opt-out, so the
+// scope of the garbage collection can be checked before enabling it.
+func (s *Service) ListResourcesToDelete(ctx context.Context) ([]*AWSResource, error) {
+	s.scope.Info("listing aws resources created by tenant cluster", "cluster", s.scope.InfraClusterName())
 
-		tasks := strings.Split(val, ",")
+	s.dryRun = true
+	s.resourcesToDelete = []*AWSResource{}
+	defer func() {
+		s.dryRun = false
+	}()
 
-		for _, task := range tasks {
-			cleanupFuncs = append(cleanupFuncs, gcTaskToFunc[infrav1.GCTask(task)])
-		}
+	resources, err := s.collectFuncs.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("collecting resources: %w", err)
 	}
 
-	if deleteErr := cleanupFuncs.Execute(ctx, resources); deleteErr != nil {
-		return fmt.Errorf("deleting resources: %w", deleteErr)
+	if err := s.clusterCleanupFuncs().Execute(ctx, resources); err != nil {
+		return nil, fmt.Errorf("listing resources to delete: %w", err)
 	}
 
-	return nil
+	return s.resourcesToDelete, nil
+}
+
+// clusterCleanupFuncs returns the cleanup functions of the tasks set in the gc tasks annotation
+// of the cluster, or the default ones, which don't delete the network interfaces, volumes and
+// launch templates, when it is not set.
+func (s *Service) clusterCleanupFuncs() ResourceCleanupFuncs {
+	val, found := annotations.Get(s.scope.InfraCluster(), infrav1.ExternalResourceGCTasksAnnotation)
+	if !found {
+		return s.cleanupFuncs
+	}
+
+	var gcTaskToFunc = map[infrav1.GCTask]ResourceCleanupFunc{
+		infrav1.GCTaskLoadBalancer:     s.deleteLoadBalancers,
+		infrav1.GCTaskTargetGroup:      s.deleteTargetGroups,
+		infrav1.GCTaskSecurityGroup:    s.deleteSecurityGroups,
+		infrav1.GCTaskNetworkInterface: s.deleteNetworkInterfaces,
+		infrav1.GCTaskVolume:           s.deleteVolumes,
+		infrav1.GCTaskLaunchTemplate:   s.deleteLaunchTemplates,
+	}
+
+	cleanupFuncs := ResourceCleanupFuncs{}
+
+	tasks := strings.Split(val, ",")
+
+	for _, task := range tasks {
+		cleanupFuncs = append(cleanupFuncs, gcTaskToFunc[infrav1.GCTask(task)])
+	}
+
+	return cleanupFuncs
+}
+
+// skipDeletion records a resource to delete when in dry-run mode, and reports whether its deletion must be skipped.
+func (s *Service) skipDeletion(resource *AWSResource) bool {
+	if !s.dryRun {
+		return false
+	}
+
+	s.scope.Debug("Dry run, skipping deletion of resource", "arn", resource.ARN.String())
+	s.resourcesToDelete = append(s.resourcesToDelete, resource)
+
+	return true
 }
 
 func (s *Service) defaultGetResources(ctx context.Context) ([]*AWSResource, error) {
@@ -795,6 +795,170 @@ func TestReconcileDelete(t *testing.T) {
 			ec2Mocks:   func(m *mocks.MockEC2APIMockRecorder) {},
 			expectErr:  false,
 		},
+		{
+			name:         "ec2 cluster with orphaned network interfaces, volumes and launch templates and their tasks enabled",
+			clusterScope: createUnManageScope(t, "", "network-interface,volume,launch-template"),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/cluster1"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-2"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-2"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/created-for/pv/name"),
+										Value: aws.String("pvc-1"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:launch-template/lt-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfacesPagesWithContext(gomock.Any(), &ec2.DescribeNetworkInterfacesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("network-interface-id"), Values: aws.StringSlice([]string{"eni-1", "eni-2"})},
+						{Name: aws.String("status"), Values: aws.StringSlice([]string{"available"})},
+					},
+				}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...request.Option) error {
+					// eni-2 is still attached to an instance.
+					fn(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{{NetworkInterfaceId: aws.String("eni-1")}},
+					}, true)
+					return nil
+				})
+				m.DeleteNetworkInterfaceWithContext(gomock.Any(), &ec2.DeleteNetworkInterfaceInput{
+					NetworkInterfaceId: aws.String("eni-1"),
+				}).Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+				m.DescribeVolumesPagesWithContext(gomock.Any(), &ec2.DescribeVolumesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("volume-id"), Values: aws.StringSlice([]string{"vol-1"})},
+						{Name: aws.String("status"), Values: aws.StringSlice([]string{"available"})},
+					},
+				}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+					fn(&ec2.DescribeVolumesOutput{
+						Volumes: []*ec2.Volume{{VolumeId: aws.String("vol-1")}},
+					}, true)
+					return nil
+				})
+				m.DeleteVolumeWithContext(gomock.Any(), &ec2.DeleteVolumeInput{
+					VolumeId: aws.String("vol-1"),
+				}).Return(&ec2.DeleteVolumeOutput{}, nil)
+				m.DeleteLaunchTemplateWithContext(gomock.Any(), &ec2.DeleteLaunchTemplateInput{
+					LaunchTemplateId: aws.String("lt-1"),
+				}).Return(&ec2.DeleteLaunchTemplateOutput{}, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name:         "ec2 cluster without the network interface, volume and launch template tasks enabled",
+			clusterScope: createUnManageScope(t, "", ""),
+			rgAPIMocks: func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+					TagFilters: []*rgapi.TagFilter{
+						{
+							Key:    aws.String("kubernetes.io/cluster/cluster1"),
+							Values: []*string{aws.String("owned")},
+						},
+					},
+				}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+					return &rgapi.GetResourcesOutput{
+						ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-2"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:volume/vol-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:launch-template/lt-1"),
+								Tags: []*rgapi.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/cluster1"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil
+				})
+			},
+			elbMocks:   func(m *mocks.MockELBAPIMockRecorder) {},
+			elbv2Mocks: func(m *mocks.MockELBV2APIMockRecorder) {},
+			ec2Mocks:   func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr:  false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -835,6 +999,136 @@ func TestReconcileDelete(t *testing.T) {
 	}
 }
 
+func TestListResourcesToDelete(t *testing.T) {
+	getResources := func(m *mocks.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+		m.GetResourcesWithContext(gomock.Any(), &rgapi.GetResourcesInput{
+			TagFilters: []*rgapi.TagFilter{
+				{
+					Key:    aws.String("kubernetes.io/cluster/cluster1"),
+					Values: []*string{aws.String("owned")},
+				},
+			},
+		}).DoAndReturn(func(awsCtx context.Context, input *rgapi.GetResourcesInput, opts ...request.Option) (*rgapi.GetResourcesOutput, error) {
+			return &rgapi.GetResourcesOutput{
+				ResourceTagMappingList: []*rgapi.ResourceTagMapping{
+					{
+						ResourceARN: aws.String("arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/lb-service-name"),
+						Tags: []*rgapi.Tag{
+							{
+								Key:   aws.String("kubernetes.io/cluster/cluster1"),
+								Value: aws.String("owned"),
+							},
+							{
+								Key:   aws.String(serviceNameTag),
+								Value: aws.String("default/svc1"),
+							},
+						},
+					},
+					{
+						ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:security-group/sg-123456"),
+						Tags: []*rgapi.Tag{
+							{
+								Key:   aws.String("kubernetes.io/cluster/cluster1"),
+								Value: aws.String("owned"),
+							},
+						},
+					},
+					{
+						ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-1"),
+						Tags: []*rgapi.Tag{
+							{
+								Key:   aws.String("kubernetes.io/cluster/cluster1"),
+								Value: aws.String("owned"),
+							},
+						},
+					},
+					{
+						ResourceARN: aws.String("arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-2"),
+						Tags: []*rgapi.Tag{
+							{
+								Key:   aws.String("kubernetes.io/cluster/cluster1"),
+								Value: aws.String("owned"),
+							},
+						},
+					},
+				},
+			}, nil
+		})
+	}
+
+	testCases := []struct {
+		name         string
+		clusterScope cloud.ClusterScoper
+		ec2Mocks     func(m *mocks.MockEC2APIMockRecorder)
+		expected     []string
+	}{
+		{
+			name:         "ec2 cluster with cluster opt-out and the network interface task enabled",
+			clusterScope: createUnManageScope(t, "false", "load-balancer,target-group,security-group,network-interface"),
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNetworkInterfacesPagesWithContext(gomock.Any(), &ec2.DescribeNetworkInterfacesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("network-interface-id"), Values: aws.StringSlice([]string{"eni-1", "eni-2"})},
+						{Name: aws.String("status"), Values: aws.StringSlice([]string{"available"})},
+					},
+				}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...request.Option) error {
+					// eni-2 is still attached to an instance.
+					fn(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{{NetworkInterfaceId: aws.String("eni-1")}},
+					}, true)
+					return nil
+				})
+			},
+			expected: []string{
+				"arn:aws:elasticloadbalancing:eu-west-2:1234567890:loadbalancer/lb-service-name",
+				"arn:aws:ec2:eu-west-2:1234567890:security-group/sg-123456",
+				"arn:aws:ec2:eu-west-2:1234567890:network-interface/eni-1",
+			},
+		},
+		{
+			name:         "ec2 cluster with only security groups clean-up func",
+			clusterScope: createUnManageScope(t, "", "security-group"),
+			ec2Mocks:     func(m *mocks.MockEC2APIMockRecorder) {},
+			expected: []string{
+				"arn:aws:ec2:eu-west-2:1234567890:security-group/sg-123456",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			rgapiMock := mocks.NewMockResourceGroupsTaggingAPIAPI(mockCtrl)
+			elbapiMock := mocks.NewMockELBAPI(mockCtrl)
+			elbv2Mock := mocks.NewMockELBV2API(mockCtrl)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			getResources(rgapiMock.EXPECT())
+			tc.ec2Mocks(ec2Mock.EXPECT())
+
+			opts := []ServiceOption{
+				withELBClient(elbapiMock),
+				withELBv2Client(elbv2Mock),
+				withResourceTaggingClient(rgapiMock),
+				withEC2Client(ec2Mock),
+				WithGCStrategy(false),
+			}
+			wkSvc := NewService(tc.clusterScope, opts...)
+			resources, err := wkSvc.ListResourcesToDelete(context.TODO())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			arns := []string{}
+			for _, resource := range resources {
+				arns = append(arns, resource.ARN.String())
+			}
+			g.Expect(arns).To(Equal(tc.expected))
+		})
+	}
+}
+
 func createManageScope(t *testing.T, gcAnnotationValue, gcTasksAnnotationValue string) *scope.ManagedControlPlaneScope {
 	t.Helper()
 	g := NewWithT(t)
@@ -917,6 +1211,7 @@ func createManagedControlPlane(gcAnnotationValue, gcTasksAnnotationValue string)
 			Namespace: "default",
 		},
 		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			Region:         "us-east-1",
 			EKSClusterName: "eks-test-cluster",
 		},
 	}
@@ -950,7 +1245,7 @@ func createAWSCluser(gcAnnotationValue, gcTasksAnnotationValue string) *infrav1.
 			Name:      "cluster1",
 			Namespace: "default",
 		},
-		Spec: infrav1.AWSClusterSpec{},
+		Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
 	}
 
 	if gcAnnotationValue != "" {
@@ -33,6 +33,10 @@ const (
 	sgService         = "ec2"
 	sgResourcePrefix  = "security-group/"
 
+	eniResourcePrefix            = "network-interface/"
+	volumeResourcePrefix         = "volume/"
+	launchTemplateResourcePrefix = "launch-template/"
+
 	// maxDescribeTagsRequest is the maximum number of resources for the DescribeTags API call
 	// see: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTags.html.
 	maxDescribeTagsRequest = 20
@@ -35,6 +35,10 @@ func (s *Service) deleteSecurityGroups(ctx context.Context, resources []*AWSReso
 			continue
 		}
 
+		if s.skipDeletion(resource) {
+			continue
+		}
+
 		groupID := strings.ReplaceAll(resource.ARN.Resource, "security-group/", "")
 		if err := s.deleteSecurityGroup(ctx, groupID); err != nil {
 			return fmt.Errorf("deleting security group %q with ID %s: %w", resource.ARN, groupID, err)
@@ -98,3 +102,223 @@ func (s *Service) getProviderOwnedSecurityGroups(_ context.Context) ([]*AWSResou
 
 	return resources, nil
 }
+
+// deleteNetworkInterfaces deletes the network interfaces of the cluster which are no longer attached to an instance,
+// such as the ones left behind by the VPC CNI or by load balancers.
+func (s *Service) deleteNetworkInterfaces(ctx context.Context, resources []*AWSResource) error {
+	var ids []string
+	resourcesByID := map[string]*AWSResource{}
+	for _, resource := range resources {
+		if !s.isMatchingResource(resource, ec2.ServiceName, "network-interface") {
+			continue
+		}
+		id := strings.TrimPrefix(resource.ARN.Resource, eniResourcePrefix)
+		ids = append(ids, id)
+		resourcesByID[id] = resource
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("network-interface-id"), Values: aws.StringSlice(ids)},
+			{Name: aws.String("status"), Values: aws.StringSlice([]string{ec2.NetworkInterfaceStatusAvailable})},
+		},
+	}
+
+	var available []string
+	if err := s.ec2Client.DescribeNetworkInterfacesPagesWithContext(ctx, input, func(out *ec2.DescribeNetworkInterfacesOutput, last bool) bool {
+		for _, eni := range out.NetworkInterfaces {
+			available = append(available, aws.StringValue(eni.NetworkInterfaceId))
+		}
+		return true
+	}); err != nil {
+		return fmt.Errorf("describing network interfaces: %w", err)
+	}
+
+	for _, id := range available {
+		if s.skipDeletion(resourcesByID[id]) {
+			continue
+		}
+
+		s.scope.Debug("Deleting network interface", "network_interface_id", id)
+		if _, err := s.ec2Client.DeleteNetworkInterfaceWithContext(ctx, &ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String(id)}); err != nil {
+			return fmt.Errorf("deleting network interface %s: %w", id, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for network interface deletion")
+
+	return nil
+}
+
+// deleteVolumes deletes the EBS volumes of the cluster which are no longer attached to an instance.
+// The volumes of persistent volumes are kept, as their reclaim policy may be Retain.
+func (s *Service) deleteVolumes(ctx context.Context, resources []*AWSResource) error {
+	var ids []string
+	resourcesByID := map[string]*AWSResource{}
+	for _, resource := range resources {
+		if !s.isMatchingResource(resource, ec2.ServiceName, "volume") {
+			continue
+		}
+		if tag, ok := persistentVolumeTag(resource); ok {
+			s.scope.Debug("Volume was provisioned for a persistent volume", "arn", resource.ARN.String(), "check", "volume", "tag", tag)
+			continue
+		}
+		id := strings.TrimPrefix(resource.ARN.Resource, volumeResourcePrefix)
+		ids = append(ids, id)
+		resourcesByID[id] = resource
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("volume-id"), Values: aws.StringSlice(ids)},
+			{Name: aws.String("status"), Values: aws.StringSlice([]string{ec2.VolumeStateAvailable})},
+		},
+	}
+
+	var available []string
+	if err := s.ec2Client.DescribeVolumesPagesWithContext(ctx, input, func(out *ec2.DescribeVolumesOutput, last bool) bool {
+		for _, volume := range out.Volumes {
+			available = append(available, aws.StringValue(volume.VolumeId))
+		}
+		return true
+	}); err != nil {
+		return fmt.Errorf("describing volumes: %w", err)
+	}
+
+	for _, id := range available {
+		if s.skipDeletion(resourcesByID[id]) {
+			continue
+		}
+
+		s.scope.Debug("Deleting volume", "volume_id", id)
+		if _, err := s.ec2Client.DeleteVolumeWithContext(ctx, &ec2.DeleteVolumeInput{VolumeId: aws.String(id)}); err != nil {
+			return fmt.Errorf("deleting volume %s: %w", id, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for volume deletion")
+
+	return nil
+}
+
+// persistentVolumeTag returns the tag marking a volume as provisioned for a persistent volume, by the EBS CSI
+// driver or the in-tree volume plugin, if any.
+func persistentVolumeTag(resource *AWSResource) (string, bool) {
+	for _, tag := range persistentVolumeTags {
+		if _, ok := resource.Tags[tag]; ok {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// deleteLaunchTemplates deletes the launch templates of the cluster. They are only left behind by
+// machine pools whose deletion didn't complete, as the machine pools are deleted before the cluster.
+func (s *Service) deleteLaunchTemplates(ctx context.Context, resources []*AWSResource) error {
+	for _, resource := range resources {
+		if !s.isMatchingResource(resource, ec2.ServiceName, "launch-template") {
+			continue
+		}
+
+		if s.skipDeletion(resource) {
+			continue
+		}
+
+		id := strings.TrimPrefix(resource.ARN.Resource, launchTemplateResourcePrefix)
+		s.scope.Debug("Deleting launch template", "launch_template_id", id)
+		if _, err := s.ec2Client.DeleteLaunchTemplateWithContext(ctx, &ec2.DeleteLaunchTemplateInput{LaunchTemplateId: aws.String(id)}); err != nil {
+			return fmt.Errorf("deleting launch template %q with ID %s: %w", resource.ARN, id, err)
+		}
+	}
+	s.scope.Debug("Finished processing resources for launch template deletion")
+
+	return nil
+}
+
+// getProviderOwnedNetworkInterfaces gets the network interfaces of this cluster, filtering by tag: kubernetes.io/cluster/<cluster-name>:owned.
+func (s *Service) getProviderOwnedNetworkInterfaces(ctx context.Context) ([]*AWSResource, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.KubernetesClusterName()),
+		},
+	}
+
+	var resources []*AWSResource
+	err := s.ec2Client.DescribeNetworkInterfacesPagesWithContext(ctx, input, func(out *ec2.DescribeNetworkInterfacesOutput, last bool) bool {
+		for _, eni := range out.NetworkInterfaces {
+			arn := composeFakeArn(ec2.ServiceName, eniResourcePrefix+aws.StringValue(eni.NetworkInterfaceId))
+			resource, err := composeAWSResource(arn, converters.TagsToMap(eni.TagSet))
+			if err != nil {
+				s.scope.Error(err, "error compose aws network interface resource", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe network interfaces error: %w", err)
+	}
+
+	return resources, nil
+}
+
+// getProviderOwnedVolumes gets the EBS volumes of this cluster, filtering by tag: kubernetes.io/cluster/<cluster-name>:owned.
+func (s *Service) getProviderOwnedVolumes(ctx context.Context) ([]*AWSResource, error) {
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.KubernetesClusterName()),
+		},
+	}
+
+	var resources []*AWSResource
+	err := s.ec2Client.DescribeVolumesPagesWithContext(ctx, input, func(out *ec2.DescribeVolumesOutput, last bool) bool {
+		for _, volume := range out.Volumes {
+			arn := composeFakeArn(ec2.ServiceName, volumeResourcePrefix+aws.StringValue(volume.VolumeId))
+			resource, err := composeAWSResource(arn, converters.TagsToMap(volume.Tags))
+			if err != nil {
+				s.scope.Error(err, "error compose aws volume resource", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe volumes error: %w", err)
+	}
+
+	return resources, nil
+}
+
+// getProviderOwnedLaunchTemplates gets the launch templates of this cluster, filtering by tag: kubernetes.io/cluster/<cluster-name>:owned.
+func (s *Service) getProviderOwnedLaunchTemplates(ctx context.Context) ([]*AWSResource, error) {
+	input := &ec2.DescribeLaunchTemplatesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderOwned(s.scope.KubernetesClusterName()),
+		},
+	}
+
+	var resources []*AWSResource
+	err := s.ec2Client.DescribeLaunchTemplatesPagesWithContext(ctx, input, func(out *ec2.DescribeLaunchTemplatesOutput, last bool) bool {
+		for _, lt := range out.LaunchTemplates {
+			arn := composeFakeArn(ec2.ServiceName, launchTemplateResourcePrefix+aws.StringValue(lt.LaunchTemplateId))
+			resource, err := composeAWSResource(arn, converters.TagsToMap(lt.Tags))
+			if err != nil {
+				s.scope.Error(err, "error compose aws launch template resource", "name", arn)
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe launch templates error: %w", err)
+	}
+
+	return resources, nil
+}
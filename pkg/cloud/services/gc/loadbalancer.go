@@ -36,6 +36,10 @@ func (s *Service) deleteLoadBalancers(ctx context.Context, resources []*AWSResou
 			continue
 		}
 
+		if s.skipDeletion(resource) {
+			continue
+		}
+
 		switch {
 		case strings.HasPrefix(resource.ARN.Resource, "loadbalancer/app/"):
 			s.scope.Debug("Deleting ALB for Service", "arn", resource.ARN.String())
@@ -70,6 +74,10 @@ func (s *Service) deleteTargetGroups(ctx context.Context, resources []*AWSResour
 			continue
 		}
 
+		if s.skipDeletion(resource) {
+			continue
+		}
+
 		if err := s.deleteTargetGroup(ctx, resource.ARN.String()); err != nil {
 			return fmt.Errorf("deleting target group %q: %w", resource.ARN, err)
 		}
@@ -39,6 +39,11 @@ type Service struct {
 	ec2Client             ec2iface.EC2API
 	cleanupFuncs          ResourceCleanupFuncs
 	collectFuncs          ResourceCollectFuncs
+
+	// dryRun makes the cleanup functions record the resources they would delete
+	// in resourcesToDelete instead of deleting them.
+	dryRun            bool
+	resourcesToDelete []*AWSResource
 }
 
 // NewService creates a new Service.
@@ -61,6 +66,8 @@ func NewService(clusterScope cloud.ClusterScoper, opts ...ServiceOption) *Servic
 	return svc
 }
 
+// addDefaultCleanupFuncs adds the default clean up functions. The network interfaces, volumes and launch templates
+// are only deleted when their tasks are listed in the GC tasks annotation of the cluster.
 func addDefaultCleanupFuncs(s *Service) {
 	s.cleanupFuncs = []ResourceCleanupFunc{
 		s.deleteLoadBalancers,
@@ -81,6 +88,9 @@ func addAlternativeCollectFuncs(s *Service) {
 		s.getProviderOwnedLoadBalancersV2,
 		s.getProviderOwnedTargetgroups,
 		s.getProviderOwnedSecurityGroups,
+		s.getProviderOwnedNetworkInterfaces,
+		s.getProviderOwnedVolumes,
+		s.getProviderOwnedLaunchTemplates,
 	}
 }
 
@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// readinessPath is the path of the API server checked by the HTTPS health checks.
+	readinessPath = "/readyz"
+
+	// healthyPercentThreshold is the percentage of the health checkers which must report the endpoint as healthy
+	// for Route 53 to consider it healthy.
+	healthyPercentThreshold = 18
+)
+
+// ReconcileAPIServerHealthCheck creates or updates the Route 53 health check of the control plane endpoint once it
+// is known, and reports whether the health checkers consider the endpoint healthy in the status of the cluster and
+// in the APIServerHealthCheckReady condition. The health check is deleted when it is no longer enabled.
+func (s *Service) ReconcileAPIServerHealthCheck() error {
+	spec := s.scope.APIServerHealthCheck()
+	if spec == nil {
+		if err := s.DeleteAPIServerHealthCheck(); err != nil {
+			return err
+		}
+		conditions.Delete(s.scope.InfraCluster(), infrav1.APIServerHealthCheckReadyCondition)
+		return nil
+	}
+
+	endpoint := s.scope.ControlPlaneEndpoint()
+	if !endpoint.IsValid() {
+		s.scope.Debug("Waiting for the control plane endpoint before creating the API server health check")
+		return nil
+	}
+
+	id, err := s.reconcileHealthCheck(healthCheckConfig(spec, endpoint))
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.APIServerHealthCheckReadyCondition, infrav1.APIServerHealthCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	healthy, err := s.isHealthy(id)
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.APIServerHealthCheckReadyCondition, infrav1.APIServerHealthCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	s.scope.SetAPIServerHealthCheckStatus(&infrav1.Route53HealthCheckStatus{ID: id, Healthy: healthy})
+
+	if !healthy {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.APIServerHealthCheckReadyCondition, infrav1.APIServerHealthCheckUnhealthyReason, clusterv1.ConditionSeverityWarning,
+			"Route 53 health check %s doesn't report %s:%d as healthy", id, endpoint.Host, endpoint.Port)
+		return nil
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.APIServerHealthCheckReadyCondition)
+
+	return nil
+}
+
+// DeleteAPIServerHealthCheck deletes the Route 53 health check of the control plane endpoint, if any.
+func (s *Service) DeleteAPIServerHealthCheck() error {
+	status := s.scope.APIServerHealthCheckStatus()
+	if status == nil || status.ID == "" {
+		return nil
+	}
+
+	s.scope.Info("Deleting API server health check", "id", status.ID)
+	if _, err := s.Route53Client.DeleteHealthCheckWithContext(context.TODO(), &route53.DeleteHealthCheckInput{
+		HealthCheckId: aws.String(status.ID),
+	}); err != nil && !isNoSuchHealthCheck(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteAPIServerHealthCheck", "Failed to delete Route 53 health check %s: %v", status.ID, err)
+		return errors.Wrapf(err, "failed to delete health check %q", status.ID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteAPIServerHealthCheck", "Deleted Route 53 health check %s", status.ID)
+
+	s.scope.SetAPIServerHealthCheckStatus(nil)
+
+	return nil
+}
+
+// reconcileHealthCheck updates the health check of the status, or creates it when it doesn't exist, and returns
+// its ID.
+func (s *Service) reconcileHealthCheck(desired *route53.HealthCheckConfig) (string, error) {
+	if status := s.scope.APIServerHealthCheckStatus(); status != nil && status.ID != "" {
+		out, err := s.Route53Client.GetHealthCheckWithContext(context.TODO(), &route53.GetHealthCheckInput{
+			HealthCheckId: aws.String(status.ID),
+		})
+		switch {
+		case err == nil:
+			return status.ID, s.updateHealthCheck(out.HealthCheck, desired)
+		case isNoSuchHealthCheck(err):
+			s.scope.Info("API server health check not found, creating a new one", "id", status.ID)
+			s.scope.SetAPIServerHealthCheckStatus(nil)
+		default:
+			return "", errors.Wrapf(err, "failed to get health check %q", status.ID)
+		}
+	}
+
+	return s.createHealthCheck(desired)
+}
+
+func (s *Service) createHealthCheck(desired *route53.HealthCheckConfig) (string, error) {
+	s.scope.Info("Creating API server health check")
+	out, err := s.Route53Client.CreateHealthCheckWithContext(context.TODO(), &route53.CreateHealthCheckInput{
+		CallerReference:   aws.String(s.callerReference()),
+		HealthCheckConfig: desired,
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateAPIServerHealthCheck", "Failed to create Route 53 health check: %v", err)
+		return "", errors.Wrap(err, "failed to create health check")
+	}
+	id := aws.StringValue(out.HealthCheck.Id)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateAPIServerHealthCheck", "Created Route 53 health check %s", id)
+
+	// The ID is stored right away, so that the health check isn't leaked if tagging it fails.
+	s.scope.SetAPIServerHealthCheckStatus(&infrav1.Route53HealthCheckStatus{ID: id})
+
+	if _, err := s.Route53Client.ChangeTagsForResourceWithContext(context.TODO(), &route53.ChangeTagsForResourceInput{
+		ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+		ResourceId:   aws.String(id),
+		AddTags:      s.tags(),
+	}); err != nil {
+		return "", errors.Wrapf(err, "failed to tag health check %q", id)
+	}
+
+	return id, nil
+}
+
+func (s *Service) updateHealthCheck(current *route53.HealthCheck, desired *route53.HealthCheckConfig) error {
+	if !needsUpdate(current.HealthCheckConfig, desired) {
+		return nil
+	}
+
+	input := &route53.UpdateHealthCheckInput{
+		HealthCheckId:            current.Id,
+		HealthCheckVersion:       current.HealthCheckVersion,
+		FullyQualifiedDomainName: desired.FullyQualifiedDomainName,
+		IPAddress:                desired.IPAddress,
+		Port:                     desired.Port,
+		ResourcePath:             desired.ResourcePath,
+		EnableSNI:                desired.EnableSNI,
+		FailureThreshold:         desired.FailureThreshold,
+		Regions:                  desired.Regions,
+	}
+	// Without regions, the default ones are restored instead of leaving the current ones unchanged.
+	if len(desired.Regions) == 0 && len(current.HealthCheckConfig.Regions) > 0 {
+		input.ResetElements = aws.StringSlice([]string{route53.ResettableElementNameRegions})
+	}
+
+	s.scope.Info("Updating API server health check", "id", aws.StringValue(current.Id))
+	if _, err := s.Route53Client.UpdateHealthCheckWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to update health check %q", aws.StringValue(current.Id))
+	}
+
+	return nil
+}
+
+// isHealthy returns whether more than 18% of the health checkers last reported the endpoint as healthy, the
+// threshold used by Route 53 to consider it healthy.
+func (s *Service) isHealthy(id string) (bool, error) {
+	out, err := s.Route53Client.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{
+		HealthCheckId: aws.String(id),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the status of health check %q", id)
+	}
+
+	if len(out.HealthCheckObservations) == 0 {
+		return false, nil
+	}
+
+	successes := 0
+	for _, observation := range out.HealthCheckObservations {
+		if observation.StatusReport != nil && strings.HasPrefix(aws.StringValue(observation.StatusReport.Status), "Success") {
+			successes++
+		}
+	}
+
+	return successes*100 > healthyPercentThreshold*len(out.HealthCheckObservations), nil
+}
+
+// callerReference returns the unique reference of the health check requests, which makes retrying to create the
+// health check after a failure return the existing one. It changes with the generation of the cluster, as Route 53
+// rejects the reference of a deleted health check.
+func (s *Service) callerReference() string {
+	return fmt.Sprintf("%s-%d", s.scope.InfraCluster().GetUID(), s.scope.InfraCluster().GetGeneration())
+}
+
+func (s *Service) tags() []*route53.Tag {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(fmt.Sprintf("%s-apiserver", s.scope.Name())),
+		Role:        aws.String(infrav1.APIServerRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	route53Tags := make([]*route53.Tag, 0, len(tags))
+	for _, k := range keys {
+		route53Tags = append(route53Tags, &route53.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+	return route53Tags
+}
+
+// healthCheckConfig returns the configuration of the health check of an endpoint, checked by IP address when its
+// host is one.
+func healthCheckConfig(spec *infrav1.Route53HealthCheck, endpoint clusterv1.APIEndpoint) *route53.HealthCheckConfig {
+	config := &route53.HealthCheckConfig{
+		Type:             aws.String(string(spec.GetProtocol())),
+		Port:             aws.Int64(int64(endpoint.Port)),
+		RequestInterval:  aws.Int64(spec.GetRequestInterval()),
+		FailureThreshold: aws.Int64(spec.GetFailureThreshold()),
+	}
+	isIP := net.ParseIP(endpoint.Host) != nil
+	if isIP {
+		config.IPAddress = aws.String(endpoint.Host)
+	} else {
+		config.FullyQualifiedDomainName = aws.String(endpoint.Host)
+	}
+	if spec.GetProtocol() == infrav1.Route53HealthCheckProtocolHTTPS {
+		config.ResourcePath = aws.String(readinessPath)
+		config.EnableSNI = aws.Bool(!isIP)
+	}
+	if len(spec.Regions) > 0 {
+		config.Regions = aws.StringSlice(spec.Regions)
+	}
+	return config
+}
+
+// needsUpdate returns whether the fields of a health check which can be updated differ from the desired ones. The
+// regions are only compared when set, as Route 53 reports the default ones otherwise.
+func needsUpdate(current, desired *route53.HealthCheckConfig) bool {
+	currentRegions := aws.StringValueSlice(current.Regions)
+	desiredRegions := aws.StringValueSlice(desired.Regions)
+	sort.Strings(currentRegions)
+	sort.Strings(desiredRegions)
+
+	return aws.StringValue(current.FullyQualifiedDomainName) != aws.StringValue(desired.FullyQualifiedDomainName) ||
+		aws.StringValue(current.IPAddress) != aws.StringValue(desired.IPAddress) ||
+		aws.Int64Value(current.Port) != aws.Int64Value(desired.Port) ||
+		aws.StringValue(current.ResourcePath) != aws.StringValue(desired.ResourcePath) ||
+		aws.BoolValue(current.EnableSNI) != aws.BoolValue(desired.EnableSNI) ||
+		aws.Int64Value(current.FailureThreshold) != aws.Int64Value(desired.FailureThreshold) ||
+		(len(desiredRegions) > 0 && strings.Join(currentRegions, ",") != strings.Join(desiredRegions, ","))
+}
+
+func isNoSuchHealthCheck(err error) bool {
+	code, _ := awserrors.Code(errors.Cause(err))
+	return code == route53.ErrCodeNoSuchHealthCheck
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/healthcheck/mock_route53iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const testHost = "test-cluster-apiserver-123.elb.eu-west-1.amazonaws.com"
+
+func TestReconcileAPIServerHealthCheck(t *testing.T) {
+	desiredConfig := &route53.HealthCheckConfig{
+		Type:                     aws.String("HTTPS"),
+		FullyQualifiedDomainName: aws.String(testHost),
+		Port:                     aws.Int64(6443),
+		ResourcePath:             aws.String("/readyz"),
+		EnableSNI:                aws.Bool(true),
+		RequestInterval:          aws.Int64(30),
+		FailureThreshold:         aws.Int64(3),
+	}
+	healthyObservations := &route53.GetHealthCheckStatusOutput{
+		HealthCheckObservations: []*route53.HealthCheckObservation{
+			{StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: Connection timed out.")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: Connection timed out.")}},
+		},
+	}
+	unhealthyObservations := &route53.GetHealthCheckStatusOutput{
+		HealthCheckObservations: []*route53.HealthCheckObservation{
+			{StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: HTTP Status Code 500, Internal Server Error.")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: HTTP Status Code 500, Internal Server Error.")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: HTTP Status Code 500, Internal Server Error.")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: HTTP Status Code 500, Internal Server Error.")}},
+			{StatusReport: &route53.StatusReport{Status: aws.String("Failure: HTTP Status Code 500, Internal Server Error.")}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		spec            *infrav1.Route53HealthCheck
+		endpoint        clusterv1.APIEndpoint
+		status          *infrav1.Route53HealthCheckStatus
+		expect          func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		expectErr       bool
+		expectStatus    *infrav1.Route53HealthCheckStatus
+		expectCondition bool
+		expectReason    string
+	}{
+		{
+			name:     "health check isn't created before the control plane endpoint is known",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{},
+			expect:   func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name:     "health check is created and tagged",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.CreateHealthCheckWithContext(context.TODO(), &route53.CreateHealthCheckInput{
+					CallerReference:   aws.String("cluster-uid-2"),
+					HealthCheckConfig: desiredConfig,
+				}).Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1")}}, nil)
+				m.ChangeTagsForResourceWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *route53.ChangeTagsForResourceInput, _ ...interface{}) (*route53.ChangeTagsForResourceOutput, error) {
+						if aws.StringValue(input.ResourceId) != "hc-1" || aws.StringValue(input.ResourceType) != "healthcheck" {
+							t.Errorf("unexpected tagged resource %s/%s", aws.StringValue(input.ResourceType), aws.StringValue(input.ResourceId))
+						}
+						if !containsTag(input.AddTags, "sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster", "owned") || !containsTag(input.AddTags, "Name", "test-cluster-apiserver") {
+							t.Errorf("unexpected tags %v", input.AddTags)
+						}
+						return &route53.ChangeTagsForResourceOutput{}, nil
+					})
+				m.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckStatusOutput{}, nil)
+			},
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expectCondition: true,
+			expectReason:    infrav1.APIServerHealthCheckUnhealthyReason,
+		},
+		{
+			name:     "health check of an IP address is created without SNI",
+			spec:     &infrav1.Route53HealthCheck{Protocol: infrav1.Route53HealthCheckProtocolTCP, RequestInterval: ptr.To[int64](10), FailureThreshold: ptr.To[int64](2)},
+			endpoint: clusterv1.APIEndpoint{Host: "203.0.113.10", Port: 443},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.CreateHealthCheckWithContext(context.TODO(), &route53.CreateHealthCheckInput{
+					CallerReference: aws.String("cluster-uid-2"),
+					HealthCheckConfig: &route53.HealthCheckConfig{
+						Type:             aws.String("TCP"),
+						IPAddress:        aws.String("203.0.113.10"),
+						Port:             aws.Int64(443),
+						RequestInterval:  aws.Int64(10),
+						FailureThreshold: aws.Int64(2),
+					},
+				}).Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1")}}, nil)
+				m.ChangeTagsForResourceWithContext(context.TODO(), gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil)
+				m.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-1")}).
+					Return(healthyObservations, nil)
+			},
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-1", Healthy: true},
+			expectCondition: true,
+		},
+		{
+			name:     "up to date health check is reported as healthy",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			status:   &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				current := *desiredConfig
+				current.Regions = aws.StringSlice([]string{"us-east-1", "eu-west-1", "ap-southeast-1"})
+				m.GetHealthCheckWithContext(context.TODO(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1"), HealthCheckConfig: &current}}, nil)
+				m.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-1")}).
+					Return(healthyObservations, nil)
+			},
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-1", Healthy: true},
+			expectCondition: true,
+		},
+		{
+			name:     "outdated health check is updated",
+			spec:     &infrav1.Route53HealthCheck{FailureThreshold: ptr.To[int64](5), Regions: []string{"us-west-2", "us-east-1", "eu-west-1"}},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			status:   &infrav1.Route53HealthCheckStatus{ID: "hc-1", Healthy: true},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				current := *desiredConfig
+				current.Regions = aws.StringSlice([]string{"us-east-1", "eu-west-1", "ap-southeast-1"})
+				m.GetHealthCheckWithContext(context.TODO(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.GetHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1"), HealthCheckVersion: aws.Int64(4), HealthCheckConfig: &current}}, nil)
+				m.UpdateHealthCheckWithContext(context.TODO(), &route53.UpdateHealthCheckInput{
+					HealthCheckId:            aws.String("hc-1"),
+					HealthCheckVersion:       aws.Int64(4),
+					FullyQualifiedDomainName: aws.String(testHost),
+					Port:                     aws.Int64(6443),
+					ResourcePath:             aws.String("/readyz"),
+					EnableSNI:                aws.Bool(true),
+					FailureThreshold:         aws.Int64(5),
+					Regions:                  aws.StringSlice([]string{"us-west-2", "us-east-1", "eu-west-1"}),
+				}).Return(&route53.UpdateHealthCheckOutput{}, nil)
+				m.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-1")}).
+					Return(unhealthyObservations, nil)
+			},
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expectCondition: true,
+			expectReason:    infrav1.APIServerHealthCheckUnhealthyReason,
+		},
+		{
+			name:     "health check deleted outside of the controller is created again",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			status:   &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.GetHealthCheckWithContext(context.TODO(), &route53.GetHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(nil, awserr.New(route53.ErrCodeNoSuchHealthCheck, "not found", nil))
+				m.CreateHealthCheckWithContext(context.TODO(), gomock.Any()).
+					Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-2")}}, nil)
+				m.ChangeTagsForResourceWithContext(context.TODO(), gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil)
+				m.GetHealthCheckStatusWithContext(context.TODO(), &route53.GetHealthCheckStatusInput{HealthCheckId: aws.String("hc-2")}).
+					Return(healthyObservations, nil)
+			},
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-2", Healthy: true},
+			expectCondition: true,
+		},
+		{
+			name:     "failing to create the health check is reported",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.CreateHealthCheckWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New("AccessDenied", "not allowed to perform route53:CreateHealthCheck", nil))
+			},
+			expectErr:       true,
+			expectCondition: true,
+			expectReason:    infrav1.APIServerHealthCheckFailedReason,
+		},
+		{
+			name:     "failing to tag the health check keeps its ID",
+			spec:     &infrav1.Route53HealthCheck{},
+			endpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.CreateHealthCheckWithContext(context.TODO(), gomock.Any()).
+					Return(&route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: aws.String("hc-1")}}, nil)
+				m.ChangeTagsForResourceWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New("AccessDenied", "not allowed to perform route53:ChangeTagsForResource", nil))
+			},
+			expectErr:       true,
+			expectStatus:    &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expectCondition: true,
+			expectReason:    infrav1.APIServerHealthCheckFailedReason,
+		},
+		{
+			name:   "disabled health check is deleted",
+			status: &infrav1.Route53HealthCheckStatus{ID: "hc-1", Healthy: true},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.DeleteHealthCheckWithContext(context.TODO(), &route53.DeleteHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(&route53.DeleteHealthCheckOutput{}, nil)
+			},
+		},
+		{
+			name:   "disabled health check already deleted is forgotten",
+			status: &infrav1.Route53HealthCheckStatus{ID: "hc-1"},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.DeleteHealthCheckWithContext(context.TODO(), &route53.DeleteHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+					Return(nil, awserr.New(route53.ErrCodeNoSuchHealthCheck, "not found", nil))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			tc.expect(route53Mock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster", UID: "cluster-uid", Generation: 2},
+					Spec: infrav1.AWSClusterSpec{
+						Region:               "eu-west-1",
+						ControlPlaneEndpoint: tc.endpoint,
+						APIServerHealthCheck: tc.spec,
+					},
+					Status: infrav1.AWSClusterStatus{APIServerHealthCheck: tc.status},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.Route53Client = route53Mock
+
+			err = s.ReconcileAPIServerHealthCheck()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(clusterScope.APIServerHealthCheckStatus()).To(Equal(tc.expectStatus))
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.APIServerHealthCheckReadyCondition)
+			if !tc.expectCondition {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectReason == "" {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+		})
+	}
+}
+
+func TestDeleteAPIServerHealthCheck(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+	route53Mock.EXPECT().DeleteHealthCheckWithContext(context.TODO(), &route53.DeleteHealthCheckInput{HealthCheckId: aws.String("hc-1")}).
+		Return(nil, awserr.New("AccessDenied", "not allowed to perform route53:DeleteHealthCheck", nil))
+
+	status := &infrav1.Route53HealthCheckStatus{ID: "hc-1"}
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:  client,
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster", UID: "cluster-uid", Generation: 2},
+			Spec: infrav1.AWSClusterSpec{
+				Region:               "eu-west-1",
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: testHost, Port: 6443},
+				APIServerHealthCheck: &infrav1.Route53HealthCheck{},
+			},
+			Status: infrav1.AWSClusterStatus{APIServerHealthCheck: status},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(clusterScope)
+	s.Route53Client = route53Mock
+
+	g.Expect(s.DeleteAPIServerHealthCheck()).To(HaveOccurred())
+	g.Expect(clusterScope.APIServerHealthCheckStatus()).To(Equal(status))
+}
+
+func containsTag(tags []*route53.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package healthcheck provides a service to manage the Route 53 health check of the control plane endpoint of a
+// cluster, which externally managed DNS failover records can reference.
+package healthcheck
+
+import (
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope         scope.HealthCheckScope
+	Route53Client route53iface.Route53API
+}
+
+// NewService returns a new service given the api clients.
+func NewService(healthCheckScope scope.HealthCheckScope) *Service {
+	return &Service{
+		scope:         healthCheckScope,
+		Route53Client: scope.NewRoute53Client(healthCheckScope, healthCheckScope, healthCheckScope, healthCheckScope.InfraCluster()),
+	}
+}
@@ -119,7 +119,7 @@ func createEKSCluster(name, namespace string) *ekscontrolplanev1.AWSManagedContr
 				clusterv1.ClusterNameLabel: name,
 			},
 		},
-		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{},
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{Region: "us-east-1"},
 	}
 	return eksCluster
 }
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package iampermissions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+var (
+	// baseActions are the actions needed to reconcile the security groups and the instances of every cluster.
+	baseActions = []string{
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateTags",
+		"ec2:DescribeImages",
+		"ec2:DescribeInstances",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcs",
+		"ec2:RunInstances",
+		"ec2:TerminateInstances",
+		"secretsmanager:CreateSecret",
+		"secretsmanager:DeleteSecret",
+	}
+
+	// managedNetworkActions are the actions needed to create the network of a cluster.
+	managedNetworkActions = []string{
+		"ec2:AllocateAddress",
+		"ec2:AssociateRouteTable",
+		"ec2:AttachInternetGateway",
+		"ec2:CreateInternetGateway",
+		"ec2:CreateNatGateway",
+		"ec2:CreateRoute",
+		"ec2:CreateRouteTable",
+		"ec2:CreateSubnet",
+		"ec2:CreateVpc",
+		"ec2:ModifyVpcAttribute",
+	}
+
+	// classicLoadBalancerActions are the actions needed to create a classic load balancer.
+	classicLoadBalancerActions = []string{
+		"elasticloadbalancing:ConfigureHealthCheck",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+	}
+
+	// loadBalancerActions are the actions needed to create a network or application load balancer.
+	loadBalancerActions = []string{
+		"elasticloadbalancing:CreateListener",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"elasticloadbalancing:CreateTargetGroup",
+		"elasticloadbalancing:RegisterTargets",
+	}
+
+	// bucketActions are the actions needed to store the bootstrap data of the instances in an S3 bucket.
+	bucketActions = []string{
+		"s3:CreateBucket",
+		"s3:PutBucketPolicy",
+		"s3:PutObject",
+	}
+
+	// instanceProfileActions are the actions needed to manage the instance profiles of the nodes.
+	instanceProfileActions = []string{
+		"iam:AddRoleToInstanceProfile",
+		"iam:CreateInstanceProfile",
+		"iam:CreateRole",
+		"iam:PassRole",
+	}
+)
+
+// ReconcilePermissions simulates, once, the key actions the controllers need to create the AWS resources of the
+// cluster with the policies of the identity they use, and reports the denied actions in the IAMPermissionsReady
+// condition. It returns whether all the actions are allowed, and an error if they couldn't be simulated.
+func (s *Service) ReconcilePermissions() (bool, error) {
+	if conditions.IsTrue(s.scope.InfraCluster(), infrav1.IAMPermissionsReadyCondition) {
+		return true, nil
+	}
+
+	s.scope.Debug("Simulating IAM permissions")
+
+	principal, err := s.principalARN()
+	if err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.IAMPermissionsReadyCondition, infrav1.IAMPermissionsCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	denied, err := s.deniedActions(principal, s.requiredActions())
+	if err != nil {
+		err = errors.Wrapf(err, "failed to simulate the policies of %q", principal)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.IAMPermissionsReadyCondition, infrav1.IAMPermissionsCheckFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return false, err
+	}
+
+	if len(denied) > 0 {
+		record.Warnf(s.scope.InfraCluster(), "IAMPermissionsMissing", "%s isn't allowed to perform %s", principal, strings.Join(denied, ", "))
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.IAMPermissionsReadyCondition, infrav1.IAMPermissionsMissingReason, clusterv1.ConditionSeverityError,
+			"%s isn't allowed to perform %s", principal, strings.Join(denied, ", "))
+		return false, nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.IAMPermissionsReadyCondition)
+
+	return true, nil
+}
+
+// requiredActions returns the sorted actions the controllers need to create the AWS resources of the cluster.
+func (s *Service) requiredActions() []string {
+	actions := append([]string{}, baseActions...)
+
+	if !s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		actions = append(actions, managedNetworkActions...)
+	}
+
+	for i, lb := range s.scope.ControlPlaneLoadBalancers() {
+		switch {
+		case lb == nil && i == 0:
+			// the primary load balancer defaults to a classic one.
+			actions = append(actions, classicLoadBalancerActions...)
+		case lb == nil || lb.LoadBalancerType == infrav1.LoadBalancerTypeDisabled:
+		case lb.LoadBalancerType == "" || lb.LoadBalancerType == infrav1.LoadBalancerTypeClassic:
+			actions = append(actions, classicLoadBalancerActions...)
+		default:
+			actions = append(actions, loadBalancerActions...)
+		}
+	}
+
+	if s.scope.Bucket() != nil {
+		actions = append(actions, bucketActions...)
+	}
+
+	if s.scope.InstanceProfiles() != nil {
+		actions = append(actions, instanceProfileActions...)
+	}
+
+	sort.Strings(actions)
+	unique := actions[:0]
+	for i, action := range actions {
+		if i == 0 || action != actions[i-1] {
+			unique = append(unique, action)
+		}
+	}
+	return unique
+}
+
+// principalARN returns the ARN of the IAM user or role used by the controllers. The policy simulator doesn't accept
+// the ARN of an assumed role session, so it's converted to the ARN of its role.
+func (s *Service) principalARN() (string, error) {
+	identity, err := s.STSClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the caller identity")
+	}
+
+	callerARN := aws.StringValue(identity.Arn)
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the caller identity %q", callerARN)
+	}
+	if parsed.Service != "sts" || !strings.HasPrefix(parsed.Resource, "assumed-role/") {
+		return callerARN, nil
+	}
+
+	roleName := strings.Split(parsed.Resource, "/")[1]
+	// the role is looked up to get its path, which isn't part of the ARN of the session.
+	role, err := s.IAMClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err == nil && role.Role != nil {
+		return aws.StringValue(role.Role.Arn), nil
+	}
+	s.scope.Debug("Failed to get the role of the caller identity, assuming it has no path", "role", roleName, "error", err)
+
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parsed.Partition, parsed.AccountID, roleName), nil
+}
+
+// deniedActions returns the actions not allowed by the policies of the principal.
+func (s *Service) deniedActions(principal string, actions []string) ([]string, error) {
+	denied := []string{}
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice(actions),
+	}
+	err := s.IAMClient.SimulatePrincipalPolicyPages(input, func(page *iam.SimulatePolicyResponse, _ bool) bool {
+		for _, result := range page.EvaluationResults {
+			if aws.StringValue(result.EvalDecision) != iam.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, aws.StringValue(result.EvalActionName))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(denied)
+	return denied, nil
+}
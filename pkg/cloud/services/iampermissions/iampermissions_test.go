@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package iampermissions
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/sts/mock_stsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testRoleARN    = "arn:aws:iam::123456789012:role/capa/controllers"
+	testSessionARN = "arn:aws:sts::123456789012:assumed-role/controllers/session"
+)
+
+func TestReconcilePermissions(t *testing.T) {
+	allowed := func(actions ...string) []*iam.EvaluationResult {
+		results := []*iam.EvaluationResult{}
+		for _, action := range actions {
+			results = append(results, &iam.EvaluationResult{EvalActionName: aws.String(action), EvalDecision: aws.String(iam.PolicyEvaluationDecisionTypeAllowed)})
+		}
+		return results
+	}
+	denied := func(decision string, actions ...string) []*iam.EvaluationResult {
+		results := []*iam.EvaluationResult{}
+		for _, action := range actions {
+			results = append(results, &iam.EvaluationResult{EvalActionName: aws.String(action), EvalDecision: aws.String(decision)})
+		}
+		return results
+	}
+	simulate := func(principal string, results ...[]*iam.EvaluationResult) func(*iam.SimulatePrincipalPolicyInput, func(*iam.SimulatePolicyResponse, bool) bool) error {
+		return func(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+			if aws.StringValue(input.PolicySourceArn) != principal {
+				return awserr.New(iam.ErrCodeInvalidInputException, "unexpected principal", nil)
+			}
+			for i, page := range results {
+				if !fn(&iam.SimulatePolicyResponse{EvaluationResults: page}, i == len(results)-1) {
+					break
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name            string
+		expect          func(iamMock *mock_iamauth.MockIAMAPIMockRecorder, stsMock *mock_stsiface.MockSTSAPIMockRecorder)
+		expectReady     bool
+		expectErr       bool
+		expectReason    string
+		expectInMessage string
+	}{
+		{
+			name: "all the actions are allowed to a user",
+			expect: func(iamMock *mock_iamauth.MockIAMAPIMockRecorder, stsMock *mock_stsiface.MockSTSAPIMockRecorder) {
+				userARN := "arn:aws:iam::123456789012:user/capa"
+				stsMock.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(userARN)}, nil)
+				iamMock.SimulatePrincipalPolicyPages(gomock.Any(), gomock.Any()).DoAndReturn(simulate(userARN, allowed("ec2:CreateVpc", "ec2:RunInstances")))
+			},
+			expectReady: true,
+		},
+		{
+			name: "the denied actions of the role of an assumed role session are reported",
+			expect: func(iamMock *mock_iamauth.MockIAMAPIMockRecorder, stsMock *mock_stsiface.MockSTSAPIMockRecorder) {
+				stsMock.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(testSessionARN)}, nil)
+				iamMock.GetRole(&iam.GetRoleInput{RoleName: aws.String("controllers")}).Return(&iam.GetRoleOutput{Role: &iam.Role{Arn: aws.String(testRoleARN)}}, nil)
+				iamMock.SimulatePrincipalPolicyPages(gomock.Any(), gomock.Any()).DoAndReturn(simulate(testRoleARN,
+					allowed("ec2:RunInstances"),
+					denied(iam.PolicyEvaluationDecisionTypeImplicitDeny, "ec2:CreateVpc"),
+					denied(iam.PolicyEvaluationDecisionTypeExplicitDeny, "ec2:CreateNatGateway"),
+				))
+			},
+			expectReason:    infrav1.IAMPermissionsMissingReason,
+			expectInMessage: "ec2:CreateNatGateway, ec2:CreateVpc",
+		},
+		{
+			name: "the role is assumed to have no path when it can't be read",
+			expect: func(iamMock *mock_iamauth.MockIAMAPIMockRecorder, stsMock *mock_stsiface.MockSTSAPIMockRecorder) {
+				stsMock.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(testSessionARN)}, nil)
+				iamMock.GetRole(gomock.Any()).Return(nil, awserr.New("AccessDenied", "access denied", nil))
+				iamMock.SimulatePrincipalPolicyPages(gomock.Any(), gomock.Any()).DoAndReturn(simulate("arn:aws:iam::123456789012:role/controllers", allowed("ec2:RunInstances")))
+			},
+			expectReady: true,
+		},
+		{
+			name: "failing to simulate the policies is reported",
+			expect: func(iamMock *mock_iamauth.MockIAMAPIMockRecorder, stsMock *mock_stsiface.MockSTSAPIMockRecorder) {
+				stsMock.GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String(testRoleARN)}, nil)
+				iamMock.SimulatePrincipalPolicyPages(gomock.Any(), gomock.Any()).Return(awserr.New("AccessDenied", "not allowed to perform iam:SimulatePrincipalPolicy", nil))
+			},
+			expectErr:       true,
+			expectReason:    infrav1.IAMPermissionsCheckFailedReason,
+			expectInMessage: "iam:SimulatePrincipalPolicy",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			tc.expect(iamMock.EXPECT(), stsMock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.IAMClient = iamMock
+			s.STSClient = stsMock
+
+			ready, err := s.ReconcilePermissions()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(ready).To(Equal(tc.expectReady))
+
+			condition := conditions.Get(clusterScope.AWSCluster, infrav1.IAMPermissionsReadyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectReady {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+			g.Expect(condition.Message).To(ContainSubstring(tc.expectInMessage))
+		})
+	}
+}
+
+func TestReconcilePermissionsOnce(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	conditions.MarkTrue(clusterScope.AWSCluster, infrav1.IAMPermissionsReadyCondition)
+	s := NewService(clusterScope)
+	s.IAMClient = mock_iamauth.NewMockIAMAPI(mockCtrl)
+	s.STSClient = mock_stsiface.NewMockSTSAPI(mockCtrl)
+
+	ready, err := s.ReconcilePermissions()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ready).To(BeTrue())
+}
+
+func TestRequiredActions(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          infrav1.AWSClusterSpec
+		expectActions []string
+		notExpected   []string
+	}{
+		{
+			name:          "managed network with the default classic load balancer",
+			expectActions: []string{"ec2:CreateVpc", "ec2:RunInstances", "elasticloadbalancing:ConfigureHealthCheck"},
+			notExpected:   []string{"elasticloadbalancing:CreateTargetGroup", "s3:CreateBucket", "iam:CreateRole"},
+		},
+		{
+			name: "unmanaged network with a network load balancer, a bucket and instance profiles",
+			spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				},
+				S3Bucket:         &infrav1.S3Bucket{Name: "bucket"},
+				InstanceProfiles: &infrav1.ManagedInstanceProfiles{},
+			},
+			expectActions: []string{"ec2:RunInstances", "elasticloadbalancing:CreateTargetGroup", "s3:CreateBucket", "iam:CreateRole"},
+			notExpected:   []string{"ec2:CreateVpc", "elasticloadbalancing:ConfigureHealthCheck"},
+		},
+		{
+			name: "disabled load balancer",
+			spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeDisabled,
+				},
+			},
+			notExpected: []string{"elasticloadbalancing:CreateLoadBalancer"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			spec := tc.spec
+			spec.Region = "eu-west-1"
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: spec},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			actions := NewService(clusterScope).requiredActions()
+
+			g.Expect(actions).To(ContainElements(tc.expectActions))
+			for _, action := range tc.notExpected {
+				g.Expect(actions).NotTo(ContainElement(action))
+			}
+			for i := 1; i < len(actions); i++ {
+				g.Expect(actions[i-1] < actions[i]).To(BeTrue(), "actions should be sorted and unique")
+			}
+		})
+	}
+}
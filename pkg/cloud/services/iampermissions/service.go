@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package iampermissions provides a service to check, before the AWS resources of a cluster are
+// created, that the identity used by the controllers is allowed to create them.
+package iampermissions
+
+import (
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope     scope.IAMPermissionsScope
+	IAMClient iamiface.IAMAPI
+	STSClient stsiface.STSAPI
+}
+
+// NewService returns a new service given the api clients.
+func NewService(permissionsScope scope.IAMPermissionsScope) *Service {
+	return &Service{
+		scope:     permissionsScope,
+		IAMClient: scope.NewIAMClient(permissionsScope, permissionsScope, permissionsScope, permissionsScope.InfraCluster()),
+		STSClient: scope.NewSTSClient(permissionsScope, permissionsScope, permissionsScope, permissionsScope.InfraCluster()),
+	}
+}
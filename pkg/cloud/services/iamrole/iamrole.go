@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iamrole
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	stsAWSAudience = "sts.amazonaws.com"
+
+	// clusterNamespaceTagKey is the tag recording the namespace of the cluster that owns a role.
+	// The ownership tag only has the cluster name, which clusters of other namespaces can share.
+	clusterNamespaceTagKey = infrav1.NameAWSProviderPrefix + "cluster-namespace"
+)
+
+// Reconcile creates or updates the IAM role of the AWSIAMRole, its trust policy and its policies.
+func (s *Service) Reconcile() error {
+	iamRole := s.scope.IAMRole
+	roleName := s.scope.RoleName()
+	s.scope.Debug("Reconciling IAM role", "role", roleName)
+
+	trustPolicy := TrustPolicy(s.scope.OIDCProviderARN(), iamRole.Spec.ServiceAccounts)
+
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if !isNotFound(err) {
+			return errors.Wrapf(err, "failed to get role %s", roleName)
+		}
+
+		role, err = s.CreateRole(roleName, s.scope.ClusterName(), trustPolicy, s.roleTags())
+		if err != nil {
+			record.Warnf(iamRole, "FailedIAMRoleCreation", "Failed to create IAM role %q: %v", roleName, err)
+			return errors.Wrap(err, "failed to create role")
+		}
+		record.Eventf(iamRole, "SuccessfulIAMRoleCreation", "Created IAM role %q", roleName)
+	} else if !s.isOwned(role) {
+		conditions.MarkFalse(iamRole, expinfrav1.IAMRoleReadyCondition, expinfrav1.IAMRoleNotManagedReason, clusterv1.ConditionSeverityWarning,
+			"role %s exists and isn't owned by cluster %s/%s", roleName, s.scope.Cluster.Namespace, s.scope.ClusterName())
+		return nil
+	}
+
+	if _, err := s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), trustPolicy, s.roleTags()); err != nil {
+		return errors.Wrapf(err, "error ensuring tags and policy document are set on role %s", roleName)
+	}
+
+	if _, err := s.EnsurePoliciesAttached(role, aws.StringSlice(iamRole.Spec.ManagedPolicyARNs)); err != nil {
+		return errors.Wrapf(err, "error ensuring policies are attached to role %s", roleName)
+	}
+
+	inlinePolicies := make([]infrav1.InlinePolicy, 0, len(iamRole.Spec.InlinePolicies))
+	for _, policy := range iamRole.Spec.InlinePolicies {
+		inlinePolicies = append(inlinePolicies, infrav1.InlinePolicy(policy))
+	}
+	if err := s.EnsureInlinePolicies(roleName, inlinePolicies); err != nil {
+		return errors.Wrapf(err, "error ensuring inline policies of role %s", roleName)
+	}
+
+	iamRole.Status.ARN = aws.StringValue(role.Arn)
+	iamRole.Status.Ready = true
+	conditions.MarkTrue(iamRole, expinfrav1.IAMRoleReadyCondition)
+
+	return nil
+}
+
+// ReconcileDelete deletes the IAM role of the AWSIAMRole if it's owned by the cluster.
+func (s *Service) ReconcileDelete() error {
+	roleName := s.scope.RoleName()
+	if roleName == "" {
+		return nil
+	}
+
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get role %s", roleName)
+	}
+
+	if !s.isOwned(role) {
+		s.scope.Debug("Skipping deletion of unmanaged role", "role", roleName)
+		return nil
+	}
+
+	if err := s.DeleteInlinePolicies(roleName); err != nil {
+		return err
+	}
+
+	if err := s.DeleteRole(roleName); err != nil {
+		record.Warnf(s.scope.IAMRole, "FailedIAMRoleDeletion", "Failed to delete IAM role %q: %v", roleName, err)
+		return err
+	}
+	record.Eventf(s.scope.IAMRole, "SuccessfulIAMRoleDeletion", "Deleted IAM role %q", roleName)
+
+	return nil
+}
+
+// roleTags returns the additional tags of the role, including the namespace of its cluster.
+func (s *Service) roleTags() infrav1.Tags {
+	tags := s.scope.AdditionalTags()
+	tags[clusterNamespaceTagKey] = s.scope.Cluster.Namespace
+	return tags
+}
+
+// isOwned returns whether the role is owned by the cluster of the AWSIAMRole, matching both the
+// name and the namespace of the cluster.
+func (s *Service) isOwned(role *iam.Role) bool {
+	if s.IsUnmanaged(role, s.scope.ClusterName()) {
+		return false
+	}
+	for _, tag := range role.Tags {
+		if aws.StringValue(tag.Key) == clusterNamespaceTagKey {
+			return aws.StringValue(tag.Value) == s.scope.Cluster.Namespace
+		}
+	}
+	return false
+}
+
+// TrustPolicy returns the trust policy allowing the given service accounts to assume a role
+// through the IAM OIDC identity provider with the given ARN.
+func TrustPolicy(providerARN string, serviceAccounts []expinfrav1.ServiceAccountReference) *iamv1.PolicyDocument {
+	issuer := providerARN[strings.Index(providerARN, "/")+1:]
+
+	// The conditions use the types they have once unmarshalled, so that the policy compares
+	// equal to the one of an existing role.
+	subjects := []interface{}{}
+	for _, serviceAccount := range serviceAccounts {
+		subjects = append(subjects, fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name))
+	}
+
+	return &iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: iamv1.Statements{
+			iamv1.StatementEntry{
+				Effect: iamv1.EffectAllow,
+				Principal: iamv1.Principals{
+					iamv1.PrincipalFederated: iamv1.PrincipalID{providerARN},
+				},
+				Action: iamv1.Actions{"sts:AssumeRoleWithWebIdentity"},
+				Condition: iamv1.Conditions{
+					"StringEquals": map[string]interface{}{
+						issuer + ":aud": stsAWSAudience,
+					},
+					"StringLike": map[string]interface{}{
+						issuer + ":sub": subjects,
+					},
+				},
+			},
+		},
+	}
+}
+
+func isNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == iam.ErrCodeNoSuchEntityException
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iamrole
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testProviderARN = "arn:aws:iam::123456789012:oidc-provider/cluster-oidc.s3.eu-west-1.amazonaws.com/oidc/default/test-cluster"
+	testRoleARN     = "arn:aws:iam::123456789012:role/app-role"
+	testPolicyARN   = "arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"
+	testPolicy      = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+)
+
+func TestReconcile(t *testing.T) {
+	serviceAccounts := []expinfrav1.ServiceAccountReference{{Namespace: "apps", Name: "app"}}
+	trustPolicy, err := converters.IAMPolicyDocumentToJSON(*TrustPolicy(testProviderARN, serviceAccounts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingRole := func(tags ...*iam.Tag) *iam.Role {
+		return &iam.Role{
+			RoleName:                 aws.String("app-role"),
+			Arn:                      aws.String(testRoleARN),
+			AssumeRolePolicyDocument: aws.String(url.PathEscape(trustPolicy)),
+			Tags:                     tags,
+		}
+	}
+	ownedTag := &iam.Tag{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey("test-cluster")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}
+	namespaceTag := &iam.Tag{Key: aws.String(clusterNamespaceTagKey), Value: aws.String("default")}
+	notFound := awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+
+	tests := []struct {
+		name           string
+		inlinePolicies []expinfrav1.InlinePolicy
+		expect         func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectReady    bool
+		expectReason   string
+	}{
+		{
+			name:           "creates the role",
+			inlinePolicies: []expinfrav1.InlinePolicy{{Name: "s3", PolicyDocument: testPolicy}},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String("app-role")}).Return(nil, notFound)
+				m.CreateRole(gomock.Any()).DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.AssumeRolePolicyDocument)).To(Equal(trustPolicy))
+					g.Expect(input.Tags).To(ContainElements(ownedTag, namespaceTag))
+					return &iam.CreateRoleOutput{Role: existingRole(input.Tags...)}, nil
+				})
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(testPolicyARN)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String("app-role"), PolicyArn: aws.String(testPolicyARN)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.GetRolePolicy(gomock.Any()).Return(nil, notFound)
+				m.PutRolePolicy(&iam.PutRolePolicyInput{
+					RoleName:       aws.String("app-role"),
+					PolicyName:     aws.String("s3"),
+					PolicyDocument: aws.String(testPolicy),
+				}).Return(&iam.PutRolePolicyOutput{}, nil)
+			},
+			expectReady: true,
+		},
+		{
+			name:           "leaves an up to date role unchanged",
+			inlinePolicies: []expinfrav1.InlinePolicy{{Name: "s3", PolicyDocument: testPolicy}},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole(ownedTag, namespaceTag)}, nil)
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{
+					AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String(testPolicyARN)}},
+				}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+					fn(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice([]string{"s3"})}, true)
+					return nil
+				})
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.QueryEscape(testPolicy))}, nil)
+			},
+			expectReady: true,
+		},
+		{
+			name: "deletes inline policies removed from the spec",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole(ownedTag, namespaceTag)}, nil)
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{
+					AttachedPolicies: []*iam.AttachedPolicy{{PolicyArn: aws.String(testPolicyARN)}},
+				}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+					fn(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice([]string{"s3"})}, true)
+					return nil
+				})
+				m.DeleteRolePolicy(&iam.DeleteRolePolicyInput{RoleName: aws.String("app-role"), PolicyName: aws.String("s3")}).Return(&iam.DeleteRolePolicyOutput{}, nil)
+			},
+			expectReady: true,
+		},
+		{
+			name: "refuses a role that isn't owned by the cluster",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole()}, nil)
+			},
+			expectReady:  false,
+			expectReason: expinfrav1.IAMRoleNotManagedReason,
+		},
+		{
+			name: "refuses a role owned by a cluster of another namespace",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole(
+					ownedTag, &iam.Tag{Key: aws.String(clusterNamespaceTagKey), Value: aws.String("other")},
+				)}, nil)
+			},
+			expectReady:  false,
+			expectReason: expinfrav1.IAMRoleNotManagedReason,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tc.expect(iamMock.EXPECT())
+
+			iamRole := &expinfrav1.AWSIAMRole{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+				Spec: expinfrav1.AWSIAMRoleSpec{
+					ClusterName:       "test-cluster",
+					RoleName:          "app-role",
+					ServiceAccounts:   serviceAccounts,
+					ManagedPolicyARNs: []string{testPolicyARN},
+					InlinePolicies:    tc.inlinePolicies,
+				},
+			}
+			iamRoleScope := newIAMRoleScope(g, iamRole)
+
+			s := NewService(iamRoleScope)
+			s.IAMClient = iamMock
+
+			g.Expect(s.Reconcile()).To(Succeed())
+			g.Expect(iamRole.Status.Ready).To(Equal(tc.expectReady))
+			g.Expect(conditions.IsTrue(iamRole, expinfrav1.IAMRoleReadyCondition)).To(Equal(tc.expectReady))
+			if tc.expectReady {
+				g.Expect(iamRole.Status.ARN).To(Equal(testRoleARN))
+			} else {
+				g.Expect(conditions.GetReason(iamRole, expinfrav1.IAMRoleReadyCondition)).To(Equal(tc.expectReason))
+			}
+		})
+	}
+}
+
+func TestTrustPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	policy := TrustPolicy(testProviderARN, []expinfrav1.ServiceAccountReference{
+		{Namespace: "apps", Name: "app"},
+		{Namespace: "jobs", Name: "batch-*"},
+	})
+
+	issuer := "cluster-oidc.s3.eu-west-1.amazonaws.com/oidc/default/test-cluster"
+	g.Expect(policy.Statement).To(HaveLen(1))
+	g.Expect(policy.Statement[0].Principal).To(HaveKeyWithValue(BeEquivalentTo("Federated"), ConsistOf(testProviderARN)))
+	g.Expect(policy.Statement[0].Condition["StringEquals"]).To(HaveKeyWithValue(issuer+":aud", "sts.amazonaws.com"))
+	g.Expect(policy.Statement[0].Condition["StringLike"]).To(HaveKeyWithValue(issuer+":sub", ConsistOf(
+		"system:serviceaccount:apps:app",
+		"system:serviceaccount:jobs:batch-*",
+	)))
+}
+
+func newIAMRoleScope(g *WithT, iamRole *expinfrav1.AWSIAMRole) *scope.IAMRoleScope {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = expinfrav1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(iamRole).Build()
+
+	iamRoleScope, err := scope.NewIAMRoleScope(scope.IAMRoleScopeParams{
+		Client: c,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"},
+			Status: infrav1.AWSClusterStatus{
+				OIDCProvider: infrav1.OIDCProviderStatus{ARN: testProviderARN},
+			},
+		},
+		IAMRole: iamRole,
+	})
+	g.Expect(err).To(BeNil())
+
+	return iamRoleScope
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instanceprofile
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	controlPlaneRole = "control-plane"
+	nodesRole        = "nodes"
+
+	// maxNameLength is the maximum length of the name of an IAM role, which is shorter than the one
+	// of an instance profile.
+	maxNameLength = 64
+	// nameHashLength is the length of the hash replacing the namespace and the name of the cluster in
+	// the names that would be too long.
+	nameHashLength = 16
+)
+
+// ReconcileInstanceProfiles creates or updates the instance profiles of the templates of the cluster,
+// their roles and the policies of the roles.
+func (s *Service) ReconcileInstanceProfiles() error {
+	profiles := s.scope.InstanceProfiles()
+	if profiles == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling IAM instance profiles")
+
+	status := s.scope.InstanceProfilesStatus()
+	if profiles.ControlPlane != nil {
+		name, err := s.reconcileInstanceProfile(controlPlaneRole, profiles.ControlPlane)
+		if err != nil {
+			return errors.Wrap(err, "failed to reconcile the instance profile of the control plane nodes")
+		}
+		status.ControlPlane = name
+	}
+	if profiles.Nodes != nil {
+		name, err := s.reconcileInstanceProfile(nodesRole, profiles.Nodes)
+		if err != nil {
+			return errors.Wrap(err, "failed to reconcile the instance profile of the worker nodes")
+		}
+		status.Nodes = name
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.InstanceProfilesReadyCondition)
+
+	return nil
+}
+
+// DeleteInstanceProfiles deletes the instance profiles of the templates of the cluster and their roles,
+// if they are owned by the cluster.
+func (s *Service) DeleteInstanceProfiles() error {
+	profiles := s.scope.InstanceProfiles()
+	if profiles == nil {
+		return nil
+	}
+
+	if profiles.ControlPlane != nil {
+		if err := s.deleteInstanceProfile(controlPlaneRole); err != nil {
+			return errors.Wrap(err, "failed to delete the instance profile of the control plane nodes")
+		}
+	}
+	if profiles.Nodes != nil {
+		if err := s.deleteInstanceProfile(nodesRole); err != nil {
+			return errors.Wrap(err, "failed to delete the instance profile of the worker nodes")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) reconcileInstanceProfile(role string, template *infrav1.InstanceProfileTemplate) (string, error) {
+	name, err := s.name(role)
+	if err != nil {
+		return "", err
+	}
+
+	trustPolicy := eksiam.NodegroupTrustRelationship()
+	tags := s.scope.AdditionalTags()
+	for k, v := range template.AdditionalTags {
+		tags[k] = v
+	}
+
+	iamRole, err := s.GetIAMRole(name)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", errors.Wrapf(err, "failed to get role %s", name)
+		}
+
+		iamRole, err = s.CreateRole(name, s.scope.Name(), trustPolicy, tags.DeepCopy())
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedIAMRoleCreation", "Failed to create IAM role %q: %v", name, err)
+			return "", errors.Wrapf(err, "failed to create role %s", name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulIAMRoleCreation", "Created IAM role %q", name)
+	} else if s.IsUnmanaged(iamRole, s.scope.Name()) {
+		return "", errors.Errorf("role %s exists and isn't owned by cluster %s", name, s.scope.Name())
+	}
+
+	if _, err := s.EnsureTagsAndPolicy(iamRole, s.scope.Name(), trustPolicy, tags); err != nil {
+		return "", errors.Wrapf(err, "error ensuring tags and policy document are set on role %s", name)
+	}
+
+	if _, err := s.EnsurePoliciesAttached(iamRole, aws.StringSlice(template.ManagedPolicyARNs)); err != nil {
+		return "", errors.Wrapf(err, "error ensuring policies are attached to role %s", name)
+	}
+
+	inlinePolicies, err := inlinePolicies(role, template)
+	if err != nil {
+		return "", err
+	}
+	if err := s.EnsureInlinePolicies(name, inlinePolicies); err != nil {
+		return "", errors.Wrapf(err, "error ensuring inline policies of role %s", name)
+	}
+
+	if err := s.ensureInstanceProfile(name, tags); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ensureInstanceProfile creates the instance profile with the given name if it doesn't exist, and
+// ensures it has the given tags and holds the role with the same name.
+func (s *Service) ensureInstanceProfile(name string, tags infrav1.Tags) error {
+	out, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	if err != nil && !isNotFound(err) {
+		return errors.Wrapf(err, "failed to get instance profile %s", name)
+	}
+
+	var profile *iam.InstanceProfile
+	if err == nil {
+		profile = out.InstanceProfile
+		if !isOwned(profile.Tags, s.scope.Name()) {
+			return errors.Errorf("instance profile %s exists and isn't owned by cluster %s", name, s.scope.Name())
+		}
+		if err := s.ensureInstanceProfileTags(profile, tags); err != nil {
+			return err
+		}
+	} else {
+		created, err := s.IAMClient.CreateInstanceProfile(&iam.CreateInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+			Tags:                eksiam.RoleTags(s.scope.Name(), tags.DeepCopy()),
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedIAMInstanceProfileCreation", "Failed to create IAM instance profile %q: %v", name, err)
+			return errors.Wrapf(err, "failed to create instance profile %s", name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulIAMInstanceProfileCreation", "Created IAM instance profile %q", name)
+		profile = created.InstanceProfile
+	}
+
+	// An instance profile holds at most one role.
+	if len(profile.Roles) > 0 {
+		if roleName := aws.StringValue(profile.Roles[0].RoleName); roleName != name {
+			return errors.Errorf("instance profile %s holds unexpected role %s", name, roleName)
+		}
+		return nil
+	}
+
+	if _, err := s.IAMClient.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+		RoleName:            aws.String(name),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to add role %s to instance profile %s", name, name)
+	}
+
+	return nil
+}
+
+// ensureInstanceProfileTags updates the tags of an instance profile which differ from the given ones,
+// and removes the ones that aren't given, except the ownership tag of the cluster.
+func (s *Service) ensureInstanceProfileTags(profile *iam.InstanceProfile, tags infrav1.Tags) error {
+	ownedKey := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())
+
+	current := map[string]string{}
+	var removed []*string
+	for _, tag := range profile.Tags {
+		current[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		if _, ok := tags[aws.StringValue(tag.Key)]; !ok && aws.StringValue(tag.Key) != ownedKey {
+			removed = append(removed, tag.Key)
+		}
+	}
+
+	var updated []*iam.Tag
+	for k, v := range tags {
+		if value, ok := current[k]; !ok || value != v {
+			updated = append(updated, &iam.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+	}
+
+	if len(updated) > 0 {
+		if _, err := s.IAMClient.TagInstanceProfile(&iam.TagInstanceProfileInput{
+			InstanceProfileName: profile.InstanceProfileName,
+			Tags:                updated,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to tag instance profile %s", aws.StringValue(profile.InstanceProfileName))
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := s.IAMClient.UntagInstanceProfile(&iam.UntagInstanceProfileInput{
+			InstanceProfileName: profile.InstanceProfileName,
+			TagKeys:             removed,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to untag instance profile %s", aws.StringValue(profile.InstanceProfileName))
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteInstanceProfile(role string) error {
+	name, err := s.name(role)
+	if err != nil {
+		return err
+	}
+
+	out, err := s.IAMClient.GetInstanceProfile(&iam.GetInstanceProfileInput{
+		InstanceProfileName: aws.String(name),
+	})
+	switch {
+	case err != nil && !isNotFound(err):
+		return errors.Wrapf(err, "failed to get instance profile %s", name)
+	case err == nil && !isOwned(out.InstanceProfile.Tags, s.scope.Name()):
+		s.scope.Debug("Skipping deletion of unmanaged instance profile", "instance-profile", name)
+	case err == nil:
+		for _, r := range out.InstanceProfile.Roles {
+			if _, err := s.IAMClient.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+				InstanceProfileName: aws.String(name),
+				RoleName:            r.RoleName,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to remove role %s from instance profile %s", aws.StringValue(r.RoleName), name)
+			}
+		}
+		if _, err := s.IAMClient.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{
+			InstanceProfileName: aws.String(name),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedIAMInstanceProfileDeletion", "Failed to delete IAM instance profile %q: %v", name, err)
+			return errors.Wrapf(err, "failed to delete instance profile %s", name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulIAMInstanceProfileDeletion", "Deleted IAM instance profile %q", name)
+	}
+
+	iamRole, err := s.GetIAMRole(name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get role %s", name)
+	}
+	if s.IsUnmanaged(iamRole, s.scope.Name()) {
+		s.scope.Debug("Skipping deletion of unmanaged role", "role", name)
+		return nil
+	}
+
+	if err := s.DeleteInlinePolicies(name); err != nil {
+		return err
+	}
+	if err := s.DeleteRole(name); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedIAMRoleDeletion", "Failed to delete IAM role %q: %v", name, err)
+		return err
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulIAMRoleDeletion", "Deleted IAM role %q", name)
+
+	return nil
+}
+
+// name returns the name of the instance profile of the given role, which is also the name of its
+// IAM role. The namespace and the name of the cluster are replaced by their hash when the name would
+// be too long.
+func (s *Service) name(role string) (string, error) {
+	name := fmt.Sprintf("%s%s-%s-%s", infrav1.ManagedInstanceProfileNamePrefix, s.scope.Namespace(), s.scope.Name(), role)
+	if len(name) <= maxNameLength {
+		return name, nil
+	}
+
+	clusterHash, err := hash.Base36TruncatedHash(s.scope.Namespace()+"/"+s.scope.Name(), nameHashLength)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash the name of the cluster")
+	}
+
+	return fmt.Sprintf("%s%s-%s", infrav1.ManagedInstanceProfileNamePrefix, clusterHash, role), nil
+}
+
+// inlinePolicies returns the inline policies of the role of an instance profile: the default policy,
+// unless it is disabled, and the inline policies of the template.
+func inlinePolicies(role string, template *infrav1.InstanceProfileTemplate) ([]infrav1.InlinePolicy, error) {
+	policies := []infrav1.InlinePolicy{}
+	if !template.DisableDefaultPolicy {
+		document, err := converters.IAMPolicyDocumentToJSON(*DefaultPolicy(role == controlPlaneRole))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert the default policy to JSON")
+		}
+		policies = append(policies, infrav1.InlinePolicy{
+			Name:           infrav1.DefaultInstanceProfilePolicyName,
+			PolicyDocument: document,
+		})
+	}
+
+	return append(policies, template.InlinePolicies...), nil
+}
+
+// DefaultPolicy returns the default inline policy of the role of a managed instance profile. It grants
+// the permissions required by the AWS cloud provider, to retrieve the bootstrap data of the nodes from
+// the secure secret backends and to manage the nodes through Session Manager, as the policies generated
+// by clusterawsadm do.
+func DefaultPolicy(controlPlane bool) *iamv1.PolicyDocument {
+	statements := iamv1.Statements{}
+	if controlPlane {
+		statements = append(statements, eksiam.CloudProviderControlPlanePolicy().Statement...)
+	}
+	statements = append(statements, eksiam.CloudProviderNodePolicy().Statement...)
+	statements = append(statements,
+		eksiam.SecretBackendPolicyStatement(infrav1.SecretBackendSecretsManager),
+		eksiam.SecretBackendPolicyStatement(infrav1.SecretBackendSSMParameterStore),
+		eksiam.SessionManagerPolicyStatement(),
+	)
+
+	return &iamv1.PolicyDocument{
+		Version:   iamv1.CurrentVersion,
+		Statement: statements,
+	}
+}
+
+func isOwned(tags []*iam.Tag, clusterName string) bool {
+	key := infrav1.ClusterAWSCloudProviderTagKey(clusterName)
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == string(infrav1.ResourceLifecycleOwned) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == iam.ErrCodeNoSuchEntityException
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instanceprofile
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testName      = "capa-default-test-cluster-nodes"
+	testPolicyARN = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly"
+	testPolicy    = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+)
+
+func TestReconcileInstanceProfiles(t *testing.T) {
+	trustPolicy, err := converters.IAMPolicyDocumentToJSON(*eksiam.NodegroupTrustRelationship())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedTag := &iam.Tag{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey("test-cluster")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}
+	existingRole := func(tags ...*iam.Tag) *iam.Role {
+		return &iam.Role{
+			RoleName:                 aws.String(testName),
+			AssumeRolePolicyDocument: aws.String(url.PathEscape(trustPolicy)),
+			Tags:                     tags,
+		}
+	}
+	notFound := awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+
+	tests := []struct {
+		name      string
+		template  *infrav1.InstanceProfileTemplate
+		expect    func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "creates the role and the instance profile",
+			template: &infrav1.InstanceProfileTemplate{
+				ManagedPolicyARNs: []string{testPolicyARN},
+				InlinePolicies:    []infrav1.InlinePolicy{{Name: "s3", PolicyDocument: testPolicy}},
+			},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(testName)}).Return(nil, notFound)
+				m.CreateRole(gomock.Any()).DoAndReturn(func(input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.AssumeRolePolicyDocument)).To(Equal(trustPolicy))
+					g.Expect(input.Tags).To(ContainElement(ownedTag))
+					return &iam.CreateRoleOutput{Role: existingRole(input.Tags...)}, nil
+				})
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(testPolicyARN)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(testName), PolicyArn: aws.String(testPolicyARN)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.GetRolePolicy(gomock.Any()).Return(nil, notFound).Times(2)
+				m.PutRolePolicy(gomock.Any()).DoAndReturn(func(input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.PolicyName)).To(Equal(infrav1.DefaultInstanceProfilePolicyName))
+					g.Expect(aws.StringValue(input.PolicyDocument)).To(ContainSubstring("ec2:DescribeInstances"))
+					g.Expect(aws.StringValue(input.PolicyDocument)).NotTo(ContainSubstring("elasticloadbalancing"))
+					return &iam.PutRolePolicyOutput{}, nil
+				})
+				m.PutRolePolicy(&iam.PutRolePolicyInput{
+					RoleName:       aws.String(testName),
+					PolicyName:     aws.String("s3"),
+					PolicyDocument: aws.String(testPolicy),
+				}).Return(&iam.PutRolePolicyOutput{}, nil)
+				m.GetInstanceProfile(&iam.GetInstanceProfileInput{InstanceProfileName: aws.String(testName)}).Return(nil, notFound)
+				m.CreateInstanceProfile(gomock.Any()).DoAndReturn(func(input *iam.CreateInstanceProfileInput) (*iam.CreateInstanceProfileOutput, error) {
+					g := NewWithT(t)
+					g.Expect(input.Tags).To(ContainElement(ownedTag))
+					return &iam.CreateInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{InstanceProfileName: input.InstanceProfileName, Tags: input.Tags}}, nil
+				})
+				m.AddRoleToInstanceProfile(&iam.AddRoleToInstanceProfileInput{
+					InstanceProfileName: aws.String(testName),
+					RoleName:            aws.String(testName),
+				}).Return(&iam.AddRoleToInstanceProfileOutput{}, nil)
+			},
+		},
+		{
+			name:     "leaves an up to date instance profile unchanged",
+			template: &infrav1.InstanceProfileTemplate{DisableDefaultPolicy: true},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole(ownedTag)}, nil)
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.GetInstanceProfile(gomock.Any()).Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+					InstanceProfileName: aws.String(testName),
+					Roles:               []*iam.Role{existingRole(ownedTag)},
+					Tags:                []*iam.Tag{ownedTag},
+				}}, nil)
+			},
+		},
+		{
+			name:     "refuses a role that isn't owned by the cluster",
+			template: &infrav1.InstanceProfileTemplate{},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole()}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name:     "refuses an instance profile that isn't owned by the cluster",
+			template: &infrav1.InstanceProfileTemplate{DisableDefaultPolicy: true},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: existingRole(ownedTag)}, nil)
+				m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.GetInstanceProfile(gomock.Any()).Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+					InstanceProfileName: aws.String(testName),
+				}}, nil)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tc.expect(iamMock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1", InstanceProfiles: &infrav1.ManagedInstanceProfiles{Nodes: tc.template}}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.IAMClient = iamMock
+
+			err = s.ReconcileInstanceProfiles()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(clusterScope.AWSCluster.Status.InstanceProfiles.Nodes).To(Equal(testName))
+			g.Expect(clusterScope.AWSCluster.Status.InstanceProfiles.ControlPlane).To(BeEmpty())
+			g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.InstanceProfilesReadyCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestDeleteInstanceProfiles(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+
+	ownedTag := &iam.Tag{Key: aws.String(infrav1.ClusterAWSCloudProviderTagKey("test-cluster")), Value: aws.String(string(infrav1.ResourceLifecycleOwned))}
+	m := iamMock.EXPECT()
+	m.GetInstanceProfile(gomock.Any()).Return(&iam.GetInstanceProfileOutput{InstanceProfile: &iam.InstanceProfile{
+		InstanceProfileName: aws.String(testName),
+		Roles:               []*iam.Role{{RoleName: aws.String(testName)}},
+		Tags:                []*iam.Tag{ownedTag},
+	}}, nil)
+	m.RemoveRoleFromInstanceProfile(&iam.RemoveRoleFromInstanceProfileInput{
+		InstanceProfileName: aws.String(testName),
+		RoleName:            aws.String(testName),
+	}).Return(&iam.RemoveRoleFromInstanceProfileOutput{}, nil)
+	m.DeleteInstanceProfile(&iam.DeleteInstanceProfileInput{InstanceProfileName: aws.String(testName)}).Return(&iam.DeleteInstanceProfileOutput{}, nil)
+	m.GetRole(gomock.Any()).Return(&iam.GetRoleOutput{Role: &iam.Role{RoleName: aws.String(testName), Tags: []*iam.Tag{ownedTag}}}, nil)
+	m.ListRolePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+		fn(&iam.ListRolePoliciesOutput{PolicyNames: aws.StringSlice([]string{infrav1.DefaultInstanceProfilePolicyName})}, true)
+		return nil
+	})
+	m.DeleteRolePolicy(&iam.DeleteRolePolicyInput{RoleName: aws.String(testName), PolicyName: aws.String(infrav1.DefaultInstanceProfilePolicyName)}).Return(&iam.DeleteRolePolicyOutput{}, nil)
+	m.ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+	m.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(testName)}).Return(&iam.DeleteRoleOutput{}, nil)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1", InstanceProfiles: &infrav1.ManagedInstanceProfiles{Nodes: &infrav1.InstanceProfileTemplate{}}}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(clusterScope)
+	s.IAMClient = iamMock
+
+	g.Expect(s.DeleteInstanceProfiles()).To(Succeed())
+}
+
+func TestName(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	name, err := NewService(clusterScope).name(controlPlaneRole)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(Equal("capa-default-test-cluster-control-plane"))
+
+	clusterScope, err = scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: strings.Repeat("a", 60)}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	name, err = NewService(clusterScope).name(controlPlaneRole)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(HavePrefix(infrav1.ManagedInstanceProfileNamePrefix))
+	g.Expect(name).To(HaveSuffix("-control-plane"))
+	g.Expect(len(name)).To(BeNumerically("<=", maxNameLength))
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlane, err := converters.IAMPolicyDocumentToJSON(*DefaultPolicy(true))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(controlPlane).To(ContainSubstring("elasticloadbalancing:CreateLoadBalancer"))
+	g.Expect(controlPlane).To(ContainSubstring("secretsmanager:GetSecretValue"))
+
+	nodes, err := converters.IAMPolicyDocumentToJSON(*DefaultPolicy(false))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nodes).NotTo(ContainSubstring("elasticloadbalancing:CreateLoadBalancer"))
+	g.Expect(nodes).To(ContainSubstring("ssm:GetParameter"))
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package instanceprofile provides a service to manage the IAM instance profiles of the control
+// plane and worker nodes of a cluster, and their roles.
+package instanceprofile
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	iam.IAMService
+
+	scope scope.InstanceProfileScope
+}
+
+// NewService returns a new service given the api clients.
+func NewService(instanceProfileScope scope.InstanceProfileScope) *Service {
+	return &Service{
+		IAMService: iam.IAMService{
+			Wrapper:   instanceProfileScope,
+			IAMClient: scope.NewIAMClient(instanceProfileScope, instanceProfileScope, instanceProfileScope, instanceProfileScope.InfraCluster()),
+		},
+		scope: instanceProfileScope,
+	}
+}
@@ -31,7 +31,7 @@ func setupCluster(clusterName string) (*scope.ClusterScope, error) {
 	_ = infrav1.AddToScheme(scheme)
 	awsCluster := &infrav1.AWSCluster{
 		ObjectMeta: metav1.ObjectMeta{Name: "test"},
-		Spec:       infrav1.AWSClusterSpec{},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 	}
 	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
 	return scope.NewClusterScope(scope.ClusterScopeParams{
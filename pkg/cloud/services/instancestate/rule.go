@@ -241,6 +241,76 @@ func (s Service) RemoveInstanceFromEventPattern(instanceID string) {
 	}
 }
 
+// UpdateInstancesInEventPattern adds and removes instances from the event rule in a single update,
+// such as the instances of an autoscaling group as it scales.
+func (s Service) UpdateInstancesInEventPattern(add, remove []string) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getEC2RuleName()),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to describe rule %s", s.getEC2RuleName())
+	}
+	e := eventPattern{}
+	err = json.Unmarshal([]byte(*ruleResp.EventPattern), &e)
+	if err != nil {
+		return err
+	}
+	e.DetailType = []string{Ec2StateChangeNotification}
+	if e.EventDetail == nil {
+		e.EventDetail = &eventDetail{}
+	}
+
+	removed := make(map[string]struct{}, len(remove))
+	for _, id := range remove {
+		removed[id] = struct{}{}
+	}
+	tracked := make(map[string]struct{}, len(e.EventDetail.InstanceIDs))
+	changed := false
+	instanceIDs := make([]string, 0, len(e.EventDetail.InstanceIDs)+len(add))
+	for _, id := range e.EventDetail.InstanceIDs {
+		if _, ok := removed[id]; ok {
+			changed = true
+			continue
+		}
+		tracked[id] = struct{}{}
+		instanceIDs = append(instanceIDs, id)
+	}
+	for _, id := range add {
+		if _, ok := tracked[id]; ok {
+			continue
+		}
+		if _, ok := removed[id]; ok {
+			continue
+		}
+		tracked[id] = struct{}{}
+		instanceIDs = append(instanceIDs, id)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	e.EventDetail.InstanceIDs = instanceIDs
+	eventData, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	input := &eventbridge.PutRuleInput{
+		Name:         aws.String(s.getEC2RuleName()),
+		EventPattern: aws.String(string(eventData)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+	}
+	if len(instanceIDs) == 0 {
+		input.State = aws.String(eventbridge.RuleStateDisabled)
+	}
+	_, err = s.EventBridgeClient.PutRule(input)
+	return errors.Wrapf(err, "unable to update rule %s", s.getEC2RuleName())
+}
+
 func (s Service) getEC2RuleName() string {
 	return fmt.Sprintf("%s-ec2-rule", s.scope.Name())
 }
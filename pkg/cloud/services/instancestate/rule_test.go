@@ -411,3 +411,93 @@ func TestRemoveInstanceStateFromEventPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateInstancesInEventPattern(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	patternWith := func(instanceIDs ...string) string {
+		data, err := json.Marshal(eventPattern{
+			DetailType: []string{Ec2StateChangeNotification},
+			Source:     []string{"aws.ec2"},
+			EventDetail: &eventDetail{
+				InstanceIDs: instanceIDs,
+			},
+		})
+		if err != nil {
+			t.Fatalf("got an unexpected error: %v", err)
+		}
+		return string(data)
+	}
+
+	testCases := []struct {
+		name              string
+		add               []string
+		remove            []string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+	}{
+		{
+			name:   "adds and removes instances in a single update",
+			add:    []string{"instance-a", "instance-c"},
+			remove: []string{"instance-b"},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				}).Return(&eventbridge.DescribeRuleOutput{
+					EventPattern: aws.String(patternWith("instance-a", "instance-b")),
+				}, nil)
+				m.PutRule(&eventbridge.PutRuleInput{
+					Name:         aws.String("test-cluster-ec2-rule"),
+					EventPattern: aws.String(patternWith("instance-a", "instance-c")),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name:   "disables the rule when no instance is left",
+			remove: []string{"instance-a"},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				}).Return(&eventbridge.DescribeRuleOutput{
+					EventPattern: aws.String(patternWith("instance-a")),
+				}, nil)
+				m.PutRule(&eventbridge.PutRuleInput{
+					Name:         aws.String("test-cluster-ec2-rule"),
+					EventPattern: aws.String(patternWith()),
+					State:        aws.String(eventbridge.RuleStateDisabled),
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name: "does nothing if the instances are already tracked",
+			add:  []string{"instance-a"},
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				}).Return(&eventbridge.DescribeRuleOutput{
+					EventPattern: aws.String(patternWith("instance-a")),
+				}, nil)
+			},
+		},
+		{
+			name:              "does nothing without instances to add or remove",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			clusterScope, err := setupCluster("test-cluster")
+			g.Expect(err).To(Not(HaveOccurred()))
+			tc.eventBridgeExpect(eventbridgeMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventbridgeMock
+
+			g.Expect(s.UpdateInstancesInEventPattern(tc.add, tc.remove)).To(Succeed())
+		})
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"context"
 
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/pricing"
 )
 
 const (
@@ -34,61 +36,72 @@ const (
 	AnyIPv4CidrBlock = "0.0.0.0/0"
 	// AnyIPv6CidrBlock is the CIDR block to match all IPv6 addresses.
 	AnyIPv6CidrBlock = "::/0"
+	// NAT64CidrBlock is the CIDR block of the IPv6 addresses synthesized by DNS64 for IPv4 destinations.
+	NAT64CidrBlock = "64:ff9b::/96"
 )
 
 // ASGInterface encapsulates the methods exposed to the machinepool
 // actuator.
 type ASGInterface interface {
-	ASGIfExists(id *string) (*expinfrav1.AutoScalingGroup, error)
-	GetASGByName(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
-	CreateASG(scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
-	UpdateASG(scope *scope.MachinePoolScope) error
-	StartASGInstanceRefresh(scope *scope.MachinePoolScope) error
-	CanStartASGInstanceRefresh(scope *scope.MachinePoolScope) (bool, error)
-	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
-	DeleteASGAndWait(id string) error
-	SuspendProcesses(name string, processes []string) error
-	ResumeProcesses(name string, processes []string) error
-	SubnetIDs(scope *scope.MachinePoolScope) ([]string, error)
+	ASGIfExists(ctx context.Context, id *string) (*expinfrav1.AutoScalingGroup, error)
+	GetASGByName(ctx context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
+	CreateASG(ctx context.Context, scope *scope.MachinePoolScope) (*expinfrav1.AutoScalingGroup, error)
+	UpdateASG(ctx context.Context, scope *scope.MachinePoolScope) error
+	StartASGInstanceRefresh(ctx context.Context, scope *scope.MachinePoolScope) error
+	CanStartASGInstanceRefresh(ctx context.Context, scope *scope.MachinePoolScope) (bool, error)
+	UpdateResourceTags(ctx context.Context, resourceID *string, create, remove map[string]string) error
+	DeleteASGAndWait(ctx context.Context, id string) error
+	SuspendProcesses(ctx context.Context, name string, processes []string) error
+	ResumeProcesses(ctx context.Context, name string, processes []string) error
+	TerminateInstancesAndDecrementDesiredCapacity(ctx context.Context, instanceIDs []string) error
+	ReplaceInstance(ctx context.Context, instanceID string) error
+	AttachLoadBalancers(ctx context.Context, name string, targetGroupARNs, loadBalancerNames []string) error
+	DetachLoadBalancers(ctx context.Context, name string, targetGroupARNs, loadBalancerNames []string) error
+	UnhealthyTargetGroupInstances(ctx context.Context, targetGroupARNs, instanceIDs []string) ([]string, error)
+	InstanceCapacityTypes(ctx context.Context, instanceIDs []string) (map[string]expinfrav1.ManagedMachinePoolCapacityType, error)
+	SubnetIDs(ctx context.Context, scope *scope.MachinePoolScope) ([]string, error)
 }
 
 // EC2Interface encapsulates the methods exposed to the machine
 // actuator.
 type EC2Interface interface {
-	InstanceIfExists(id *string) (*infrav1.Instance, error)
-	TerminateInstance(id string) error
-	CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
-	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
-
-	GetAdditionalSecurityGroupsIDs(securityGroup []infrav1.AWSResourceReference) ([]string, error)
-	GetCoreSecurityGroups(machine *scope.MachineScope) ([]string, error)
-	GetInstanceSecurityGroups(instanceID string) (map[string][]string, error)
-	UpdateInstanceSecurityGroups(id string, securityGroups []string) error
-	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
-	ModifyInstanceMetadataOptions(instanceID string, options *infrav1.InstanceMetadataOptions) error
-
-	TerminateInstanceAndWait(instanceID string) error
-	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
-
-	DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*string, error)
-	GetLaunchTemplate(id string) (lt *expinfrav1.AWSLaunchTemplate, userDataHash string, userDataSecretKey *apimachinerytypes.NamespacedName, err error)
-	GetLaunchTemplateID(id string) (string, error)
-	GetLaunchTemplateLatestVersion(id string) (string, error)
-	CreateLaunchTemplate(scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error)
-	CreateLaunchTemplateVersion(id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error
-	PruneLaunchTemplateVersions(id string) error
-	DeleteLaunchTemplate(id string) error
-	LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
-	DeleteBastion() error
-	ReconcileBastion() error
+	InstanceIfExists(ctx context.Context, id *string) (*infrav1.Instance, error)
+	TerminateInstance(ctx context.Context, id string) error
+	CreateInstance(ctx context.Context, scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
+	GetRunningInstanceByTags(ctx context.Context, scope *scope.MachineScope) (*infrav1.Instance, error)
+
+	GetAdditionalSecurityGroupsIDs(ctx context.Context, securityGroup []infrav1.AWSResourceReference) ([]string, error)
+	GetCoreSecurityGroups(ctx context.Context, machine *scope.MachineScope) ([]string, error)
+	GetInstanceSecurityGroups(ctx context.Context, instanceID string) (map[string][]string, error)
+	UpdateInstanceSecurityGroups(ctx context.Context, id string, securityGroups []string) error
+	UpdateResourceTags(ctx context.Context, resourceID *string, create, remove map[string]string) error
+	ModifyInstanceMetadataOptions(ctx context.Context, instanceID string, options *infrav1.InstanceMetadataOptions) error
+	GetConsoleOutputTail(ctx context.Context, instanceID string, lines int) (string, error)
+	GetSSMPingStatus(ctx context.Context, instanceID string) (string, error)
+
+	TerminateInstanceAndWait(ctx context.Context, instanceID string) error
+	DetachSecurityGroupsFromNetworkInterface(ctx context.Context, groups []string, interfaceID string) error
+
+	DiscoverLaunchTemplateAMI(ctx context.Context, scope scope.LaunchTemplateScope) (*string, error)
+	GetLaunchTemplate(ctx context.Context, id string) (lt *expinfrav1.AWSLaunchTemplate, userDataHash string, userDataSecretKey *apimachinerytypes.NamespacedName, err error)
+	GetLaunchTemplateID(ctx context.Context, id string) (string, error)
+	GetLaunchTemplateLatestVersion(ctx context.Context, id string) (string, error)
+	CreateLaunchTemplate(ctx context.Context, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) (string, error)
+	CreateLaunchTemplateVersion(ctx context.Context, id string, scope scope.LaunchTemplateScope, imageID *string, userDataSecretKey apimachinerytypes.NamespacedName, userData []byte) error
+	PruneLaunchTemplateVersions(ctx context.Context, id string) error
+	DeleteLaunchTemplate(ctx context.Context, id string) error
+	LaunchTemplateNeedsUpdate(ctx context.Context, scope scope.LaunchTemplateScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error)
+	AvailabilityZonesOfferingInstanceTypes(ctx context.Context, instanceTypes []string) (sets.Set[string], error)
+	DeleteBastion(ctx context.Context) error
+	ReconcileBastion(ctx context.Context) error
 }
 
 // MachinePoolReconcileInterface encapsulates high-level reconciliation functions regarding EC2 reconciliation. It is
 // separate from EC2Interface so that we can mock AWS requests separately. For example, by not mocking the
 // ReconcileLaunchTemplate function, but mocking EC2Interface, we can test which EC2 API operations would have been called.
 type MachinePoolReconcileInterface interface {
-	ReconcileLaunchTemplate(scope scope.LaunchTemplateScope, ec2svc EC2Interface, canUpdateLaunchTemplate func() (bool, error), runPostLaunchTemplateUpdateOperation func() error) error
-	ReconcileTags(scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate) error
+	ReconcileLaunchTemplate(ctx context.Context, scope scope.LaunchTemplateScope, ec2svc EC2Interface, canUpdateLaunchTemplate func() (bool, error), runPostLaunchTemplateUpdateOperation func() error) error
+	ReconcileTags(ctx context.Context, scope scope.LaunchTemplateScope, resourceServicesToUpdate []scope.ResourceServiceToUpdate) error
 }
 
 // SecretInterface encapsulated the methods exposed to the
@@ -102,21 +115,24 @@ type SecretInterface interface {
 // ELBInterface encapsulates the methods exposed to the cluster and machine
 // controller.
 type ELBInterface interface {
-	DeleteLoadbalancers() error
-	ReconcileLoadbalancers() error
-	IsInstanceRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error)
-	IsInstanceRegisteredWithAPIServerLB(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error)
-	DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
-	DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *infrav1.Instance) error
-	RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error
-	RegisterInstanceWithAPIServerLB(i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error
+	DeleteLoadbalancers(ctx context.Context) error
+	ReconcileLoadbalancers(ctx context.Context) error
+	IsInstanceRegisteredWithAPIServerELB(ctx context.Context, i *infrav1.Instance) (bool, error)
+	IsInstanceRegisteredWithAPIServerLB(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) ([]string, bool, error)
+	IsInstanceDrainingFromAPIServerLB(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) (bool, error)
+	DeregisterInstanceFromAPIServerELB(ctx context.Context, i *infrav1.Instance) error
+	DeregisterInstanceFromAPIServerLB(ctx context.Context, targetGroupArn string, i *infrav1.Instance) error
+	RegisterInstanceWithAPIServerELB(ctx context.Context, i *infrav1.Instance) error
+	RegisterInstanceWithAPIServerLB(ctx context.Context, i *infrav1.Instance, lb *infrav1.AWSLoadBalancerSpec) error
+	RegisterInstanceWithLoadBalancerAttachments(ctx context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error
+	DeregisterInstanceFromLoadBalancerAttachments(ctx context.Context, i *infrav1.Instance, attachments *infrav1.LoadBalancerAttachments) error
 }
 
 // NetworkInterface encapsulates the methods exposed to the cluster
 // controller.
 type NetworkInterface interface {
-	DeleteNetwork() error
-	ReconcileNetwork() error
+	DeleteNetwork(ctx context.Context) error
+	ReconcileNetwork(ctx context.Context) error
 }
 
 // SecurityGroupInterface encapsulates the methods exposed to the cluster
@@ -148,3 +164,10 @@ type IAMAuthenticatorInterface interface {
 type KubeProxyInterface interface {
 	ReconcileKubeProxy(ctx context.Context) error
 }
+
+// PricingInterface estimates the hourly cost of the instances of AWSMachines and AWSMachinePools, and recommends
+// where to launch the spot instances of AWSMachinePools.
+type PricingInterface interface {
+	EstimateCost(instances []pricing.Instance) (*infrav1.CostEstimate, error)
+	RecommendSpotPlacements(instanceTypes, availabilityZones []string, targetCapacity int64) ([]expinfrav1.SpotRecommendation, error)
+}
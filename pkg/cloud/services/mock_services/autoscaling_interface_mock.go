@@ -21,6 +21,7 @@ limitations under the License.
 package mock_services
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -52,160 +53,246 @@ func (m *MockASGInterface) EXPECT() *MockASGInterfaceMockRecorder {
 }
 
 // ASGIfExists mocks base method.
-func (m *MockASGInterface) ASGIfExists(arg0 *string) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) ASGIfExists(arg0 context.Context, arg1 *string) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ASGIfExists", arg0)
+	ret := m.ctrl.Call(m, "ASGIfExists", arg0, arg1)
 	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ASGIfExists indicates an expected call of ASGIfExists.
-func (mr *MockASGInterfaceMockRecorder) ASGIfExists(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) ASGIfExists(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASGIfExists", reflect.TypeOf((*MockASGInterface)(nil).ASGIfExists), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASGIfExists", reflect.TypeOf((*MockASGInterface)(nil).ASGIfExists), arg0, arg1)
+}
+
+// AttachLoadBalancers mocks base method.
+func (m *MockASGInterface) AttachLoadBalancers(arg0 context.Context, arg1 string, arg2, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLoadBalancers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachLoadBalancers indicates an expected call of AttachLoadBalancers.
+func (mr *MockASGInterfaceMockRecorder) AttachLoadBalancers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLoadBalancers", reflect.TypeOf((*MockASGInterface)(nil).AttachLoadBalancers), arg0, arg1, arg2, arg3)
 }
 
 // CanStartASGInstanceRefresh mocks base method.
-func (m *MockASGInterface) CanStartASGInstanceRefresh(arg0 *scope.MachinePoolScope) (bool, error) {
+func (m *MockASGInterface) CanStartASGInstanceRefresh(arg0 context.Context, arg1 *scope.MachinePoolScope) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CanStartASGInstanceRefresh", arg0)
+	ret := m.ctrl.Call(m, "CanStartASGInstanceRefresh", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CanStartASGInstanceRefresh indicates an expected call of CanStartASGInstanceRefresh.
-func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) CanStartASGInstanceRefresh(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanStartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CanStartASGInstanceRefresh), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanStartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).CanStartASGInstanceRefresh), arg0, arg1)
 }
 
 // CreateASG mocks base method.
-func (m *MockASGInterface) CreateASG(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) CreateASG(arg0 context.Context, arg1 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateASG", arg0)
+	ret := m.ctrl.Call(m, "CreateASG", arg0, arg1)
 	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateASG indicates an expected call of CreateASG.
-func (mr *MockASGInterfaceMockRecorder) CreateASG(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) CreateASG(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateASG", reflect.TypeOf((*MockASGInterface)(nil).CreateASG), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateASG", reflect.TypeOf((*MockASGInterface)(nil).CreateASG), arg0, arg1)
 }
 
 // DeleteASGAndWait mocks base method.
-func (m *MockASGInterface) DeleteASGAndWait(arg0 string) error {
+func (m *MockASGInterface) DeleteASGAndWait(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteASGAndWait", arg0)
+	ret := m.ctrl.Call(m, "DeleteASGAndWait", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteASGAndWait indicates an expected call of DeleteASGAndWait.
-func (mr *MockASGInterfaceMockRecorder) DeleteASGAndWait(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) DeleteASGAndWait(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteASGAndWait", reflect.TypeOf((*MockASGInterface)(nil).DeleteASGAndWait), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteASGAndWait", reflect.TypeOf((*MockASGInterface)(nil).DeleteASGAndWait), arg0, arg1)
+}
+
+// DetachLoadBalancers mocks base method.
+func (m *MockASGInterface) DetachLoadBalancers(arg0 context.Context, arg1 string, arg2, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLoadBalancers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachLoadBalancers indicates an expected call of DetachLoadBalancers.
+func (mr *MockASGInterfaceMockRecorder) DetachLoadBalancers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLoadBalancers", reflect.TypeOf((*MockASGInterface)(nil).DetachLoadBalancers), arg0, arg1, arg2, arg3)
 }
 
 // GetASGByName mocks base method.
-func (m *MockASGInterface) GetASGByName(arg0 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
+func (m *MockASGInterface) GetASGByName(arg0 context.Context, arg1 *scope.MachinePoolScope) (*v1beta2.AutoScalingGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetASGByName", arg0)
+	ret := m.ctrl.Call(m, "GetASGByName", arg0, arg1)
 	ret0, _ := ret[0].(*v1beta2.AutoScalingGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetASGByName indicates an expected call of GetASGByName.
-func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) GetASGByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0, arg1)
+}
+
+// InstanceCapacityTypes mocks base method.
+func (m *MockASGInterface) InstanceCapacityTypes(arg0 context.Context, arg1 []string) (map[string]v1beta2.ManagedMachinePoolCapacityType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceCapacityTypes", arg0, arg1)
+	ret0, _ := ret[0].(map[string]v1beta2.ManagedMachinePoolCapacityType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceCapacityTypes indicates an expected call of InstanceCapacityTypes.
+func (mr *MockASGInterfaceMockRecorder) InstanceCapacityTypes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetASGByName", reflect.TypeOf((*MockASGInterface)(nil).GetASGByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceCapacityTypes", reflect.TypeOf((*MockASGInterface)(nil).InstanceCapacityTypes), arg0, arg1)
+}
+
+// ReplaceInstance mocks base method.
+func (m *MockASGInterface) ReplaceInstance(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceInstance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceInstance indicates an expected call of ReplaceInstance.
+func (mr *MockASGInterfaceMockRecorder) ReplaceInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceInstance", reflect.TypeOf((*MockASGInterface)(nil).ReplaceInstance), arg0, arg1)
 }
 
 // ResumeProcesses mocks base method.
-func (m *MockASGInterface) ResumeProcesses(arg0 string, arg1 []string) error {
+func (m *MockASGInterface) ResumeProcesses(arg0 context.Context, arg1 string, arg2 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResumeProcesses", arg0, arg1)
+	ret := m.ctrl.Call(m, "ResumeProcesses", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ResumeProcesses indicates an expected call of ResumeProcesses.
-func (mr *MockASGInterfaceMockRecorder) ResumeProcesses(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) ResumeProcesses(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProcesses", reflect.TypeOf((*MockASGInterface)(nil).ResumeProcesses), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeProcesses", reflect.TypeOf((*MockASGInterface)(nil).ResumeProcesses), arg0, arg1, arg2)
 }
 
 // StartASGInstanceRefresh mocks base method.
-func (m *MockASGInterface) StartASGInstanceRefresh(arg0 *scope.MachinePoolScope) error {
+func (m *MockASGInterface) StartASGInstanceRefresh(arg0 context.Context, arg1 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartASGInstanceRefresh", arg0)
+	ret := m.ctrl.Call(m, "StartASGInstanceRefresh", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StartASGInstanceRefresh indicates an expected call of StartASGInstanceRefresh.
-func (mr *MockASGInterfaceMockRecorder) StartASGInstanceRefresh(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) StartASGInstanceRefresh(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).StartASGInstanceRefresh), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartASGInstanceRefresh", reflect.TypeOf((*MockASGInterface)(nil).StartASGInstanceRefresh), arg0, arg1)
 }
 
 // SubnetIDs mocks base method.
-func (m *MockASGInterface) SubnetIDs(arg0 *scope.MachinePoolScope) ([]string, error) {
+func (m *MockASGInterface) SubnetIDs(arg0 context.Context, arg1 *scope.MachinePoolScope) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubnetIDs", arg0)
+	ret := m.ctrl.Call(m, "SubnetIDs", arg0, arg1)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubnetIDs indicates an expected call of SubnetIDs.
-func (mr *MockASGInterfaceMockRecorder) SubnetIDs(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) SubnetIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetIDs", reflect.TypeOf((*MockASGInterface)(nil).SubnetIDs), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetIDs", reflect.TypeOf((*MockASGInterface)(nil).SubnetIDs), arg0, arg1)
 }
 
 // SuspendProcesses mocks base method.
-func (m *MockASGInterface) SuspendProcesses(arg0 string, arg1 []string) error {
+func (m *MockASGInterface) SuspendProcesses(arg0 context.Context, arg1 string, arg2 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SuspendProcesses", arg0, arg1)
+	ret := m.ctrl.Call(m, "SuspendProcesses", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SuspendProcesses indicates an expected call of SuspendProcesses.
-func (mr *MockASGInterfaceMockRecorder) SuspendProcesses(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) SuspendProcesses(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendProcesses", reflect.TypeOf((*MockASGInterface)(nil).SuspendProcesses), arg0, arg1, arg2)
+}
+
+// TerminateInstancesAndDecrementDesiredCapacity mocks base method.
+func (m *MockASGInterface) TerminateInstancesAndDecrementDesiredCapacity(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateInstancesAndDecrementDesiredCapacity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateInstancesAndDecrementDesiredCapacity indicates an expected call of TerminateInstancesAndDecrementDesiredCapacity.
+func (mr *MockASGInterfaceMockRecorder) TerminateInstancesAndDecrementDesiredCapacity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateInstancesAndDecrementDesiredCapacity", reflect.TypeOf((*MockASGInterface)(nil).TerminateInstancesAndDecrementDesiredCapacity), arg0, arg1)
+}
+
+// UnhealthyTargetGroupInstances mocks base method.
+func (m *MockASGInterface) UnhealthyTargetGroupInstances(arg0 context.Context, arg1, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhealthyTargetGroupInstances", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnhealthyTargetGroupInstances indicates an expected call of UnhealthyTargetGroupInstances.
+func (mr *MockASGInterfaceMockRecorder) UnhealthyTargetGroupInstances(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendProcesses", reflect.TypeOf((*MockASGInterface)(nil).SuspendProcesses), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhealthyTargetGroupInstances", reflect.TypeOf((*MockASGInterface)(nil).UnhealthyTargetGroupInstances), arg0, arg1, arg2)
 }
 
 // UpdateASG mocks base method.
-func (m *MockASGInterface) UpdateASG(arg0 *scope.MachinePoolScope) error {
+func (m *MockASGInterface) UpdateASG(arg0 context.Context, arg1 *scope.MachinePoolScope) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateASG", arg0)
+	ret := m.ctrl.Call(m, "UpdateASG", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateASG indicates an expected call of UpdateASG.
-func (mr *MockASGInterfaceMockRecorder) UpdateASG(arg0 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) UpdateASG(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateASG", reflect.TypeOf((*MockASGInterface)(nil).UpdateASG), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateASG", reflect.TypeOf((*MockASGInterface)(nil).UpdateASG), arg0, arg1)
 }
 
 // UpdateResourceTags mocks base method.
-func (m *MockASGInterface) UpdateResourceTags(arg0 *string, arg1, arg2 map[string]string) error {
+func (m *MockASGInterface) UpdateResourceTags(arg0 context.Context, arg1 *string, arg2, arg3 map[string]string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateResourceTags", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateResourceTags", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateResourceTags indicates an expected call of UpdateResourceTags.
-func (mr *MockASGInterfaceMockRecorder) UpdateResourceTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockASGInterfaceMockRecorder) UpdateResourceTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceTags", reflect.TypeOf((*MockASGInterface)(nil).UpdateResourceTags), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceTags", reflect.TypeOf((*MockASGInterface)(nil).UpdateResourceTags), arg0, arg1, arg2, arg3)
 }
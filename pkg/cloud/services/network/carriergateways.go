@@ -35,7 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
-func (s *Service) reconcileCarrierGateway() error {
+func (s *Service) reconcileCarrierGateway(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping carrier gateway reconcile in unmanaged mode")
 		return nil
@@ -48,13 +48,13 @@ func (s *Service) reconcileCarrierGateway() error {
 
 	s.scope.Debug("Reconciling carrier gateway")
 
-	cagw, err := s.describeVpcCarrierGateway()
+	cagw, err := s.describeVpcCarrierGateway(ctx)
 	if awserrors.IsNotFound(err) {
 		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 			return errors.Errorf("failed to validate network: no carrier gateway found in VPC %q", s.scope.VPC().ID)
 		}
 
-		cg, err := s.createCarrierGateway()
+		cg, err := s.createCarrierGateway(ctx)
 		if err != nil {
 			return err
 		}
@@ -69,7 +69,7 @@ func (s *Service) reconcileCarrierGateway() error {
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		buildParams := s.getGatewayTagParams(*cagw.CarrierGatewayId)
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-		if err := tagsBuilder.Ensure(converters.TagsToMap(cagw.Tags)); err != nil {
+		if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(cagw.Tags)); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -81,13 +81,13 @@ func (s *Service) reconcileCarrierGateway() error {
 	return nil
 }
 
-func (s *Service) deleteCarrierGateway() error {
+func (s *Service) deleteCarrierGateway(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping carrier gateway deletion in unmanaged mode")
 		return nil
 	}
 
-	cagw, err := s.describeVpcCarrierGateway()
+	cagw, err := s.describeVpcCarrierGateway(ctx)
 	if awserrors.IsNotFound(err) {
 		return nil
 	} else if err != nil {
@@ -98,7 +98,7 @@ func (s *Service) deleteCarrierGateway() error {
 		CarrierGatewayId: cagw.CarrierGatewayId,
 	}
 
-	if _, err = s.EC2Client.DeleteCarrierGatewayWithContext(context.TODO(), deleteReq); err != nil {
+	if _, err = s.EC2Client.DeleteCarrierGatewayWithContext(ctx, deleteReq); err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedDeleteCarrierGateway", "Failed to delete Carrier Gateway %q previously attached to VPC %q: %v", *cagw.CarrierGatewayId, s.scope.VPC().ID, err)
 		return errors.Wrapf(err, "failed to delete carrier gateway %q", *cagw.CarrierGatewayId)
 	}
@@ -109,8 +109,8 @@ func (s *Service) deleteCarrierGateway() error {
 	return nil
 }
 
-func (s *Service) createCarrierGateway() (*ec2.CarrierGateway, error) {
-	ig, err := s.EC2Client.CreateCarrierGatewayWithContext(context.TODO(), &ec2.CreateCarrierGatewayInput{
+func (s *Service) createCarrierGateway(ctx context.Context) (*ec2.CarrierGateway, error) {
+	ig, err := s.EC2Client.CreateCarrierGatewayWithContext(ctx, &ec2.CreateCarrierGatewayInput{
 		VpcId: aws.String(s.scope.VPC().ID),
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeCarrierGateway, s.getGatewayTagParams(services.TemporaryResourceID)),
@@ -126,8 +126,8 @@ func (s *Service) createCarrierGateway() (*ec2.CarrierGateway, error) {
 	return ig.CarrierGateway, nil
 }
 
-func (s *Service) describeVpcCarrierGateway() (*ec2.CarrierGateway, error) {
-	out, err := s.EC2Client.DescribeCarrierGatewaysWithContext(context.TODO(), &ec2.DescribeCarrierGatewaysInput{
+func (s *Service) describeVpcCarrierGateway(ctx context.Context) (*ec2.CarrierGateway, error) {
+	out, err := s.EC2Client.DescribeCarrierGatewaysWithContext(ctx, &ec2.DescribeCarrierGatewaysInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
 		},
@@ -129,6 +129,7 @@ func TestReconcileCarrierGateway(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -142,7 +143,7 @@ func TestReconcileCarrierGateway(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileCarrierGateway(); err != nil {
+			if err := s.reconcileCarrierGateway(context.TODO()); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 			mockCtrl.Finish()
@@ -235,6 +236,7 @@ func TestDeleteCarrierGateway(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -246,7 +248,7 @@ func TestDeleteCarrierGateway(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteCarrierGateway()
+			err = s.deleteCarrierGateway(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
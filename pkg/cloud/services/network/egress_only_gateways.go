@@ -35,7 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
-func (s *Service) reconcileEgressOnlyInternetGateways() error {
+func (s *Service) reconcileEgressOnlyInternetGateways(ctx context.Context) error {
 	if !s.scope.VPC().IsIPv6Enabled() {
 		s.scope.Trace("Skipping egress only internet gateways reconcile in not ipv6 mode")
 		return nil
@@ -48,13 +48,13 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 
 	s.scope.Debug("Reconciling egress only internet gateways")
 
-	eigws, err := s.describeEgressOnlyVpcInternetGateways()
+	eigws, err := s.describeEgressOnlyVpcInternetGateways(ctx)
 	if awserrors.IsNotFound(err) {
 		if !s.scope.VPC().IsIPv6Enabled() {
 			return errors.Errorf("failed to validate network: no egress only internet gateways found in VPC %q", s.scope.VPC().ID)
 		}
 
-		ig, err := s.createEgressOnlyInternetGateway()
+		ig, err := s.createEgressOnlyInternetGateway(ctx)
 		if err != nil {
 			return err
 		}
@@ -70,7 +70,7 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		buildParams := s.getEgressOnlyGatewayTagParams(*gateway.EgressOnlyInternetGatewayId)
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-		if err := tagsBuilder.Ensure(converters.TagsToMap(gateway.Tags)); err != nil {
+		if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(gateway.Tags)); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -82,7 +82,7 @@ func (s *Service) reconcileEgressOnlyInternetGateways() error {
 	return nil
 }
 
-func (s *Service) deleteEgressOnlyInternetGateways() error {
+func (s *Service) deleteEgressOnlyInternetGateways(ctx context.Context) error {
 	if !s.scope.VPC().IsIPv6Enabled() {
 		s.scope.Trace("Skipping egress only internet gateway deletion in none ipv6 mode")
 		return nil
@@ -93,7 +93,7 @@ func (s *Service) deleteEgressOnlyInternetGateways() error {
 		return nil
 	}
 
-	eigws, err := s.describeEgressOnlyVpcInternetGateways()
+	eigws, err := s.describeEgressOnlyVpcInternetGateways(ctx)
 	if awserrors.IsNotFound(err) {
 		return nil
 	} else if err != nil {
@@ -105,7 +105,7 @@ func (s *Service) deleteEgressOnlyInternetGateways() error {
 			EgressOnlyInternetGatewayId: ig.EgressOnlyInternetGatewayId,
 		}
 
-		if _, err = s.EC2Client.DeleteEgressOnlyInternetGatewayWithContext(context.TODO(), deleteReq); err != nil {
+		if _, err = s.EC2Client.DeleteEgressOnlyInternetGatewayWithContext(ctx, deleteReq); err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedDeleteEgressOnlyInternetGateway", "Failed to delete Egress Only Internet Gateway %q previously attached to VPC %q: %v", *ig.EgressOnlyInternetGatewayId, s.scope.VPC().ID, err)
 			return errors.Wrapf(err, "failed to delete egress only internet gateway %q", *ig.EgressOnlyInternetGatewayId)
 		}
@@ -117,8 +117,8 @@ func (s *Service) deleteEgressOnlyInternetGateways() error {
 	return nil
 }
 
-func (s *Service) createEgressOnlyInternetGateway() (*ec2.EgressOnlyInternetGateway, error) {
-	ig, err := s.EC2Client.CreateEgressOnlyInternetGatewayWithContext(context.TODO(), &ec2.CreateEgressOnlyInternetGatewayInput{
+func (s *Service) createEgressOnlyInternetGateway(ctx context.Context) (*ec2.EgressOnlyInternetGateway, error) {
+	ig, err := s.EC2Client.CreateEgressOnlyInternetGatewayWithContext(ctx, &ec2.CreateEgressOnlyInternetGatewayInput{
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeEgressOnlyInternetGateway, s.getEgressOnlyGatewayTagParams(services.TemporaryResourceID)),
 		},
@@ -134,8 +134,8 @@ func (s *Service) createEgressOnlyInternetGateway() (*ec2.EgressOnlyInternetGate
 	return ig.EgressOnlyInternetGateway, nil
 }
 
-func (s *Service) describeEgressOnlyVpcInternetGateways() ([]*ec2.EgressOnlyInternetGateway, error) {
-	out, err := s.EC2Client.DescribeEgressOnlyInternetGatewaysWithContext(context.TODO(), &ec2.DescribeEgressOnlyInternetGatewaysInput{
+func (s *Service) describeEgressOnlyVpcInternetGateways(ctx context.Context) ([]*ec2.EgressOnlyInternetGateway, error) {
+	out, err := s.EC2Client.DescribeEgressOnlyInternetGatewaysWithContext(ctx, &ec2.DescribeEgressOnlyInternetGatewaysInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPCAttachment(s.scope.VPC().ID),
 		},
@@ -134,6 +134,7 @@ func TestReconcileEgressOnlyInternetGateways(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -147,7 +148,7 @@ func TestReconcileEgressOnlyInternetGateways(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileEgressOnlyInternetGateways(); err != nil {
+			if err := s.reconcileEgressOnlyInternetGateways(context.TODO()); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -255,6 +256,7 @@ func TestDeleteEgressOnlyInternetGateways(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -266,7 +268,7 @@ func TestDeleteEgressOnlyInternetGateways(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteEgressOnlyInternetGateways()
+			err = s.deleteEgressOnlyInternetGateways(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -26,14 +26,15 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
-func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, err error) {
-	out, err := s.describeAddresses(role)
+func (s *Service) getOrAllocateAddresses(ctx context.Context, num int, role string) (eips []string, err error) {
+	out, err := s.describeAddresses(ctx, role)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses for role %q: %v", role, err)
 		return nil, errors.Wrap(err, "failed to query addresses")
@@ -46,7 +47,7 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 	}
 
 	for len(eips) < num {
-		ip, err := s.allocateAddress(role)
+		ip, err := s.allocateAddress(ctx, role)
 		if err != nil {
 			return nil, err
 		}
@@ -56,9 +57,9 @@ func (s *Service) getOrAllocateAddresses(num int, role string) (eips []string, e
 	return eips, nil
 }
 
-func (s *Service) allocateAddress(role string) (string, error) {
+func (s *Service) allocateAddress(ctx context.Context, role string) (string, error) {
 	tagSpecifications := tags.BuildParamsToTagSpecification(ec2.ResourceTypeElasticIp, s.getEIPTagParams(role))
-	out, err := s.EC2Client.AllocateAddressWithContext(context.TODO(), &ec2.AllocateAddressInput{
+	out, err := s.EC2Client.AllocateAddressWithContext(ctx, &ec2.AllocateAddressInput{
 		Domain: aws.String("vpc"),
 		TagSpecifications: []*ec2.TagSpecification{
 			tagSpecifications,
@@ -72,20 +73,47 @@ func (s *Service) allocateAddress(role string) (string, error) {
 	return aws.StringValue(out.AllocationId), nil
 }
 
-func (s *Service) describeAddresses(role string) (*ec2.DescribeAddressesOutput, error) {
+// ensureAddressesTags makes sure the Elastic IPs with the specified allocation IDs have the tags of their role, e.g.
+// when additional tags were added to the cluster after they were allocated.
+func (s *Service) ensureAddressesTags(ctx context.Context, allocationIDs []string, role string) error {
+	if len(allocationIDs) == 0 {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{
+		AllocationIds: aws.StringSlice(allocationIDs),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to query addresses")
+	}
+
+	for _, address := range out.Addresses {
+		buildParams := s.getEIPTagParams(role)
+		buildParams.ResourceID = aws.StringValue(address.AllocationId)
+		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
+		if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(address.Tags)); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedTagEIP", "Failed to tag Elastic IP %q: %v", buildParams.ResourceID, err)
+			return errors.Wrapf(err, "failed to tag Elastic IP %q", buildParams.ResourceID)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) describeAddresses(ctx context.Context, role string) (*ec2.DescribeAddressesOutput, error) {
 	x := []*ec2.Filter{filter.EC2.Cluster(s.scope.Name())}
 	if role != "" {
 		x = append(x, filter.EC2.ProviderRole(role))
 	}
 
-	return s.EC2Client.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+	return s.EC2Client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{
 		Filters: x,
 	})
 }
 
-func (s *Service) disassociateAddress(ip *ec2.Address) error {
+func (s *Service) disassociateAddress(ctx context.Context, ip *ec2.Address) error {
 	err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		_, err := s.EC2Client.DisassociateAddressWithContext(context.TODO(), &ec2.DisassociateAddressInput{
+		_, err := s.EC2Client.DisassociateAddressWithContext(ctx, &ec2.DisassociateAddressInput{
 			AssociationId: ip.AssociationId,
 		})
 		if err != nil {
@@ -103,8 +131,8 @@ func (s *Service) disassociateAddress(ip *ec2.Address) error {
 	return nil
 }
 
-func (s *Service) releaseAddresses() error {
-	out, err := s.EC2Client.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+func (s *Service) releaseAddresses(ctx context.Context) error {
+	out, err := s.EC2Client.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{
 		Filters: []*ec2.Filter{filter.EC2.Cluster(s.scope.Name())},
 	})
 	if err != nil {
@@ -116,7 +144,7 @@ func (s *Service) releaseAddresses() error {
 	for i := range out.Addresses {
 		ip := out.Addresses[i]
 		if ip.AssociationId != nil {
-			if _, err := s.EC2Client.DisassociateAddressWithContext(context.TODO(), &ec2.DisassociateAddressInput{
+			if _, err := s.EC2Client.DisassociateAddressWithContext(ctx, &ec2.DisassociateAddressInput{
 				AssociationId: ip.AssociationId,
 			}); err != nil {
 				record.Warnf(s.scope.InfraCluster(), "FailedDisassociateEIP", "Failed to disassociate Elastic IP %q: %v", *ip.AllocationId, err)
@@ -125,10 +153,10 @@ func (s *Service) releaseAddresses() error {
 		}
 
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			_, err := s.EC2Client.ReleaseAddressWithContext(context.TODO(), &ec2.ReleaseAddressInput{AllocationId: ip.AllocationId})
+			_, err := s.EC2Client.ReleaseAddressWithContext(ctx, &ec2.ReleaseAddressInput{AllocationId: ip.AllocationId})
 			if err != nil {
 				if ip.AssociationId != nil {
-					if s.disassociateAddress(ip) != nil {
+					if s.disassociateAddress(ctx, ip) != nil {
 						return false, err
 					}
 				}
@@ -26,6 +26,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -156,7 +157,7 @@ func TestServiceReleaseAddresses(t *testing.T) {
 			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
 				Client:     client,
 				Cluster:    &clusterv1.Cluster{},
-				AWSCluster: &infrav1.AWSCluster{},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
 			})
 			g.Expect(err).NotTo(HaveOccurred())
 
@@ -167,9 +168,116 @@ func TestServiceReleaseAddresses(t *testing.T) {
 				tt.expect(ec2Mock.EXPECT())
 			}
 
-			if err := s.releaseAddresses(); (err != nil) != tt.wantErr {
+			if err := s.releaseAddresses(context.TODO()); (err != nil) != tt.wantErr {
 				t.Errorf("releaseAddresses() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestServiceEnsureAddressesTags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name          string
+		allocationIDs []string
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		wantErr       bool
+	}{
+		{
+			name: "Should do nothing without addresses",
+		},
+		{
+			name:          "Should add the missing additional tags",
+			allocationIDs: []string{"eipalloc-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+					AllocationIds: aws.StringSlice([]string{"eipalloc-1"}),
+				}).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{
+							AllocationId: aws.String("eipalloc-1"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("test-cluster-eip-apiserver")},
+								{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+								{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("apiserver")},
+							},
+						},
+					},
+				}, nil)
+				m.CreateTagsWithContext(context.TODO(), &ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"eipalloc-1"}),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("Name"), Value: aws.String("test-cluster-eip-apiserver")},
+						{Key: aws.String("cost-center"), Value: aws.String("platform")},
+						{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+						{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("apiserver")},
+					},
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name:          "Should not tag addresses which are up to date",
+			allocationIDs: []string{"eipalloc-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{
+							AllocationId: aws.String("eipalloc-1"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("test-cluster-eip-apiserver")},
+								{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+								{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("apiserver")},
+								{Key: aws.String("cost-center"), Value: aws.String("platform")},
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "Should return error if failed to describe IP addresses",
+			allocationIDs: []string{"eipalloc-1"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Any()).Return(nil, awserrors.NewFailedDependency("dependency failure"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			err := infrav1.AddToScheme(scheme)
+			g.Expect(err).NotTo(HaveOccurred())
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{Region: "us-east-1", AdditionalTags: infrav1.Tags{"cost-center": "platform"}},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(cs)
+			s.EC2Client = ec2Mock
+
+			if tt.expect != nil {
+				tt.expect(ec2Mock.EXPECT())
+			}
+
+			err = s.ensureAddressesTags(context.TODO(), tt.allocationIDs, infrav1.APIServerRoleTagValue)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -35,7 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
-func (s *Service) reconcileInternetGateways() error {
+func (s *Service) reconcileInternetGateways(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping internet gateways reconcile in unmanaged mode")
 		return nil
@@ -43,13 +43,13 @@ func (s *Service) reconcileInternetGateways() error {
 
 	s.scope.Debug("Reconciling internet gateways")
 
-	igs, err := s.describeVpcInternetGateways()
+	igs, err := s.describeVpcInternetGateways(ctx)
 	if awserrors.IsNotFound(err) {
 		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 			return errors.Errorf("failed to validate network: no internet gateways found in VPC %q", s.scope.VPC().ID)
 		}
 
-		ig, err := s.createInternetGateway()
+		ig, err := s.createInternetGateway(ctx)
 		if err != nil {
 			return err
 		}
@@ -65,7 +65,7 @@ func (s *Service) reconcileInternetGateways() error {
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		buildParams := s.getGatewayTagParams(*gateway.InternetGatewayId)
 		tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-		if err := tagsBuilder.Ensure(converters.TagsToMap(gateway.Tags)); err != nil {
+		if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(gateway.Tags)); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -77,13 +77,13 @@ func (s *Service) reconcileInternetGateways() error {
 	return nil
 }
 
-func (s *Service) deleteInternetGateways() error {
+func (s *Service) deleteInternetGateways(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping internet gateway deletion in unmanaged mode")
 		return nil
 	}
 
-	igs, err := s.describeVpcInternetGateways()
+	igs, err := s.describeVpcInternetGateways(ctx)
 	if awserrors.IsNotFound(err) {
 		return nil
 	} else if err != nil {
@@ -96,7 +96,7 @@ func (s *Service) deleteInternetGateways() error {
 			VpcId:             aws.String(s.scope.VPC().ID),
 		}
 
-		if _, err := s.EC2Client.DetachInternetGatewayWithContext(context.TODO(), detachReq); err != nil {
+		if _, err := s.EC2Client.DetachInternetGatewayWithContext(ctx, detachReq); err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedDetachInternetGateway", "Failed to detach Internet Gateway %q from VPC %q: %v", *ig.InternetGatewayId, s.scope.VPC().ID, err)
 			return errors.Wrapf(err, "failed to detach internet gateway %q", *ig.InternetGatewayId)
 		}
@@ -108,7 +108,7 @@ func (s *Service) deleteInternetGateways() error {
 			InternetGatewayId: ig.InternetGatewayId,
 		}
 
-		if _, err = s.EC2Client.DeleteInternetGatewayWithContext(context.TODO(), deleteReq); err != nil {
+		if _, err = s.EC2Client.DeleteInternetGatewayWithContext(ctx, deleteReq); err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedDeleteInternetGateway", "Failed to delete Internet Gateway %q previously attached to VPC %q: %v", *ig.InternetGatewayId, s.scope.VPC().ID, err)
 			return errors.Wrapf(err, "failed to delete internet gateway %q", *ig.InternetGatewayId)
 		}
@@ -120,8 +120,8 @@ func (s *Service) deleteInternetGateways() error {
 	return nil
 }
 
-func (s *Service) createInternetGateway() (*ec2.InternetGateway, error) {
-	ig, err := s.EC2Client.CreateInternetGatewayWithContext(context.TODO(), &ec2.CreateInternetGatewayInput{
+func (s *Service) createInternetGateway(ctx context.Context) (*ec2.InternetGateway, error) {
+	ig, err := s.EC2Client.CreateInternetGatewayWithContext(ctx, &ec2.CreateInternetGatewayInput{
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeInternetGateway, s.getGatewayTagParams(services.TemporaryResourceID)),
 		},
@@ -134,7 +134,7 @@ func (s *Service) createInternetGateway() (*ec2.InternetGateway, error) {
 	s.scope.Info("Created Internet gateway for VPC", "internet-gateway-id", *ig.InternetGateway.InternetGatewayId, "vpc-id", s.scope.VPC().ID)
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		if _, err := s.EC2Client.AttachInternetGatewayWithContext(context.TODO(), &ec2.AttachInternetGatewayInput{
+		if _, err := s.EC2Client.AttachInternetGatewayWithContext(ctx, &ec2.AttachInternetGatewayInput{
 			InternetGatewayId: ig.InternetGateway.InternetGatewayId,
 			VpcId:             aws.String(s.scope.VPC().ID),
 		}); err != nil {
@@ -151,8 +151,8 @@ func (s *Service) createInternetGateway() (*ec2.InternetGateway, error) {
 	return ig.InternetGateway, nil
 }
 
-func (s *Service) describeVpcInternetGateways() ([]*ec2.InternetGateway, error) {
-	out, err := s.EC2Client.DescribeInternetGatewaysWithContext(context.TODO(), &ec2.DescribeInternetGatewaysInput{
+func (s *Service) describeVpcInternetGateways(ctx context.Context) ([]*ec2.InternetGateway, error) {
+	out, err := s.EC2Client.DescribeInternetGatewaysWithContext(ctx, &ec2.DescribeInternetGatewaysInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPCAttachment(s.scope.VPC().ID),
 		},
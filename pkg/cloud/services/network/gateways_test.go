@@ -132,6 +132,7 @@ func TestReconcileInternetGateways(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -145,7 +146,7 @@ func TestReconcileInternetGateways(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileInternetGateways(); err != nil {
+			if err := s.reconcileInternetGateways(context.TODO()); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -245,6 +246,7 @@ func TestDeleteInternetGateways(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -256,7 +258,7 @@ func TestDeleteInternetGateways(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteInternetGateways()
+			err = s.deleteInternetGateways(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -38,7 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
-func (s *Service) reconcileNatGateways() error {
+func (s *Service) reconcileNatGateways(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping NAT gateway reconcile in unmanaged mode")
 		return nil
@@ -66,16 +66,18 @@ func (s *Service) reconcileNatGateways() error {
 		return nil
 	}
 
-	existing, err := s.describeNatGatewaysBySubnet()
+	existing, err := s.describeNatGatewaysBySubnet(ctx)
 	if err != nil {
 		return err
 	}
 
 	natGatewaysIPs := []string{}
 	subnetIDs := []string{}
+	allocationIDs := []string{}
 
 	for _, sn := range s.scope.Subnets().FilterPublic() {
-		if sn.GetResourceID() == "" {
+		// NAT gateways need an IPv4 address, which IPv6-native subnets don't have.
+		if sn.GetResourceID() == "" || sn.IPv6Native {
 			continue
 		}
 
@@ -83,11 +85,16 @@ func (s *Service) reconcileNatGateways() error {
 			if len(ngw.NatGatewayAddresses) > 0 && ngw.NatGatewayAddresses[0].PublicIp != nil {
 				natGatewaysIPs = append(natGatewaysIPs, *ngw.NatGatewayAddresses[0].PublicIp)
 			}
+			for _, address := range ngw.NatGatewayAddresses {
+				if address.AllocationId != nil {
+					allocationIDs = append(allocationIDs, *address.AllocationId)
+				}
+			}
 			// Make sure tags are up to date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getNatGatewayTagParams(*ngw.NatGatewayId)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-				if err := tagsBuilder.Ensure(converters.TagsToMap(ngw.Tags)); err != nil {
+				if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(ngw.Tags)); err != nil {
 					return false, err
 				}
 				return true, nil
@@ -104,6 +111,11 @@ func (s *Service) reconcileNatGateways() error {
 
 	s.scope.SetNatGatewaysIPs(natGatewaysIPs)
 
+	// Make sure the tags of the Elastic IPs of the existing NAT gateways are up to date too.
+	if err := s.ensureAddressesTags(ctx, allocationIDs, infrav1.APIServerRoleTagValue); err != nil {
+		return err
+	}
+
 	// Batch the creation of NAT gateways
 	if len(subnetIDs) > 0 {
 		// set NatGatewayCreationStarted if the condition has never been set before
@@ -113,7 +125,7 @@ func (s *Service) reconcileNatGateways() error {
 				return errors.Wrap(err, "failed to patch conditions")
 			}
 		}
-		ngws, err := s.createNatGateways(subnetIDs)
+		ngws, err := s.createNatGateways(ctx, subnetIDs)
 
 		subnets := s.scope.Subnets()
 		defer func() {
@@ -133,7 +145,7 @@ func (s *Service) reconcileNatGateways() error {
 	return nil
 }
 
-func (s *Service) deleteNatGateways() error {
+func (s *Service) deleteNatGateways(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping NAT gateway deletion in unmanaged mode")
 		return nil
@@ -147,7 +159,7 @@ func (s *Service) deleteNatGateways() error {
 		return nil
 	}
 
-	existing, err := s.describeNatGatewaysBySubnet()
+	existing, err := s.describeNatGatewaysBySubnet(ctx)
 	if err != nil {
 		return err
 	}
@@ -168,7 +180,7 @@ func (s *Service) deleteNatGateways() error {
 
 	for _, ngID := range ngIDs {
 		go func(c chan error, ngID *ec2.NatGateway) {
-			err := s.deleteNatGateway(*ngID.NatGatewayId)
+			err := s.deleteNatGateway(ctx, *ngID.NatGatewayId)
 			c <- err
 		}(c, ngID)
 	}
@@ -183,7 +195,7 @@ func (s *Service) deleteNatGateways() error {
 	return kerrors.NewAggregate(errs)
 }
 
-func (s *Service) describeNatGatewaysBySubnet() (map[string]*ec2.NatGateway, error) {
+func (s *Service) describeNatGatewaysBySubnet(ctx context.Context) (map[string]*ec2.NatGateway, error) {
 	describeNatGatewayInput := &ec2.DescribeNatGatewaysInput{
 		Filter: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
@@ -193,7 +205,7 @@ func (s *Service) describeNatGatewaysBySubnet() (map[string]*ec2.NatGateway, err
 
 	gateways := make(map[string]*ec2.NatGateway)
 
-	err := s.EC2Client.DescribeNatGatewaysPagesWithContext(context.TODO(), describeNatGatewayInput,
+	err := s.EC2Client.DescribeNatGatewaysPagesWithContext(ctx, describeNatGatewayInput,
 		func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
 			for _, r := range page.NatGateways {
 				gateways[*r.SubnetId] = r
@@ -221,8 +233,8 @@ func (s *Service) getNatGatewayTagParams(id string) infrav1.BuildParams {
 	}
 }
 
-func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
-	eips, err := s.getOrAllocateAddresses(len(subnetIDs), infrav1.APIServerRoleTagValue)
+func (s *Service) createNatGateways(ctx context.Context, subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
+	eips, err := s.getOrAllocateAddresses(ctx, len(subnetIDs), infrav1.APIServerRoleTagValue)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
 	}
@@ -234,7 +246,7 @@ func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatG
 
 	for i, sn := range subnetIDs {
 		go func(c chan ngwCreation, subnetID, ip string) {
-			ngw, err := s.createNatGateway(subnetID, ip)
+			ngw, err := s.createNatGateway(ctx, subnetID, ip)
 			c <- ngwCreation{natGateway: ngw, error: err}
 		}(c, sn, eips[i])
 	}
@@ -249,12 +261,12 @@ func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatG
 	return natgateways, nil
 }
 
-func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error) {
+func (s *Service) createNatGateway(ctx context.Context, subnetID, ip string) (*ec2.NatGateway, error) {
 	var out *ec2.CreateNatGatewayOutput
 	var err error
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		if out, err = s.EC2Client.CreateNatGatewayWithContext(context.TODO(), &ec2.CreateNatGatewayInput{
+		if out, err = s.EC2Client.CreateNatGatewayWithContext(ctx, &ec2.CreateNatGatewayInput{
 			SubnetId:          aws.String(subnetID),
 			AllocationId:      aws.String(ip),
 			TagSpecifications: []*ec2.TagSpecification{tags.BuildParamsToTagSpecification(ec2.ResourceTypeNatgateway, s.getNatGatewayTagParams(services.TemporaryResourceID))},
@@ -269,7 +281,7 @@ func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error)
 	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNATGateway", "Created new NAT Gateway %q", *out.NatGateway.NatGatewayId)
 
 	wReq := &ec2.DescribeNatGatewaysInput{NatGatewayIds: []*string{out.NatGateway.NatGatewayId}}
-	if err := s.EC2Client.WaitUntilNatGatewayAvailableWithContext(context.TODO(), wReq); err != nil {
+	if err := s.EC2Client.WaitUntilNatGatewayAvailableWithContext(ctx, wReq); err != nil {
 		return nil, errors.Wrapf(err, "failed to wait for nat gateway %q in subnet %q", *out.NatGateway.NatGatewayId, subnetID)
 	}
 
@@ -277,8 +289,8 @@ func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error)
 	return out.NatGateway, nil
 }
 
-func (s *Service) deleteNatGateway(id string) error {
-	_, err := s.EC2Client.DeleteNatGatewayWithContext(context.TODO(), &ec2.DeleteNatGatewayInput{
+func (s *Service) deleteNatGateway(ctx context.Context, id string) error {
+	_, err := s.EC2Client.DeleteNatGatewayWithContext(ctx, &ec2.DeleteNatGatewayInput{
 		NatGatewayId: aws.String(id),
 	})
 	if err != nil {
@@ -293,7 +305,7 @@ func (s *Service) deleteNatGateway(id string) error {
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (done bool, err error) {
-		out, err := s.EC2Client.DescribeNatGatewaysWithContext(context.TODO(), describeInput)
+		out, err := s.EC2Client.DescribeNatGatewaysWithContext(ctx, describeInput)
 		if err != nil {
 			return false, err
 		}
@@ -326,6 +338,18 @@ func (s *Service) deleteNatGateway(id string) error {
 // NAT gateways in edge zones (Local Zones) are not globally supported,
 // private subnets in those locations uses Nat Gateways from the
 // Parent Zone or, when not available, the first zone in the Region.
+// getNatGatewayForPublicSubnet returns the NAT gateway of the availability zone of a public subnet, through which
+// the subnet routes its NAT64 traffic.
+func (s *Service) getNatGatewayForPublicSubnet(sn *infrav1.SubnetSpec) (string, error) {
+	publicSubnets := s.scope.Subnets().FilterPublic().FilterByZone(sn.AvailabilityZone)
+	for _, psn := range append(publicSubnets.WithoutReplaced(), publicSubnets.Replaced()...) {
+		if psn.NatGatewayID != nil {
+			return *psn.NatGatewayID, nil
+		}
+	}
+	return "", errors.Errorf("no nat gateways available in %q for the NAT64 route of public subnet %q", sn.AvailabilityZone, sn.GetResourceID())
+}
+
 func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error) {
 	if sn.IsPublic {
 		return "", errors.Errorf("cannot get NAT gateway for a public subnet, got id %q", sn.GetResourceID())
@@ -334,7 +358,10 @@ func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error)
 	// Check if public edge subnet in the edge zone has nat gateway
 	azGateways := make(map[string]string)
 	azNames := []string{}
-	for _, psn := range s.scope.Subnets().FilterPublic() {
+	// Prefer the NAT gateways of the replacement subnets, so that the routes of the private subnets move
+	// away from the replaced subnets once the NAT gateways of their replacements are available.
+	publicSubnets := s.scope.Subnets().FilterPublic()
+	for _, psn := range append(publicSubnets.WithoutReplaced(), publicSubnets.Replaced()...) {
 		if psn.NatGatewayID == nil {
 			continue
 		}
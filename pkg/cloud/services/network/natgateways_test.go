@@ -372,6 +372,7 @@ func TestReconcileNatGateways(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: subnetsVPCID,
@@ -401,7 +402,7 @@ func TestReconcileNatGateways(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileNatGateways(); err != nil {
+			if err := s.reconcileNatGateways(context.TODO()); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -682,6 +683,7 @@ func TestDeleteNatGateways(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: "managed-vpc",
@@ -712,7 +714,7 @@ func TestDeleteNatGateways(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteNatGateways()
+			err = s.deleteNatGateways(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -884,6 +886,57 @@ func TestGetdNatGatewayForEdgeSubnet(t *testing.T) {
 			},
 			expect: "natgw-az-1b-last",
 		},
+		{
+			name: "zone availability-zone, nat gateway of the replacement subnet",
+			spec: infrav1.Subnets{
+				{
+					ID:               "subnet-az-1a-public",
+					ResourceID:       "subnet-az-1a-public",
+					AvailabilityZone: "us-east-1a",
+					IsPublic:         true,
+					NatGatewayID:     aws.String("natgw-az-1a-replaced"),
+				},
+				{
+					ID:               "subnet-az-1a-public-grown",
+					ResourceID:       "subnet-az-1a-public-grown",
+					AvailabilityZone: "us-east-1a",
+					IsPublic:         true,
+					NatGatewayID:     aws.String("natgw-az-1a-replacement"),
+					Replaces:         "subnet-az-1a-public",
+				},
+			},
+			input: infrav1.SubnetSpec{
+				ID:               "subnet-az-1a-private",
+				AvailabilityZone: "us-east-1a",
+				IsPublic:         false,
+			},
+			expect: "natgw-az-1a-replacement",
+		},
+		{
+			name: "zone availability-zone, nat gateway of the replaced subnet until the replacement has one",
+			spec: infrav1.Subnets{
+				{
+					ID:               "subnet-az-1a-public",
+					ResourceID:       "subnet-az-1a-public",
+					AvailabilityZone: "us-east-1a",
+					IsPublic:         true,
+					NatGatewayID:     aws.String("natgw-az-1a-replaced"),
+				},
+				{
+					ID:               "subnet-az-1a-public-grown",
+					ResourceID:       "subnet-az-1a-public-grown",
+					AvailabilityZone: "us-east-1a",
+					IsPublic:         true,
+					Replaces:         "subnet-az-1a-public",
+				},
+			},
+			input: infrav1.SubnetSpec{
+				ID:               "subnet-az-1a-private",
+				AvailabilityZone: "us-east-1a",
+				IsPublic:         false,
+			},
+			expect: "natgw-az-1a-replaced",
+		},
 		// errors
 		{
 			name: "error if the subnet is public",
@@ -952,6 +1005,7 @@ func TestGetdNatGatewayForEdgeSubnet(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
 							ID: subnetsVPCID,
@@ -17,87 +17,125 @@ limitations under the License.
 package network
 
 import (
+	"context"
+
 	"k8s.io/klog/v2"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/planner"
 	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// deleteNetworkRetryBudget is the number of times the failed deletions of network resources are retried overall
+// when deleting the network, once other resources were deleted.
+const deleteNetworkRetryBudget = 3
+
 // ReconcileNetwork reconciles the network of the given cluster.
-func (s *Service) ReconcileNetwork() (err error) {
+func (s *Service) ReconcileNetwork(ctx context.Context) (err error) {
 	s.scope.Debug("Reconciling network for cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
 	// VPC.
-	if err := s.reconcileVPC(); err != nil {
+	if err := s.reconcileVPC(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
 
 	// Secondary CIDR
-	if err := s.associateSecondaryCidr(); err != nil {
+	if err := s.associateSecondaryCidr(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, infrav1.SecondaryCidrReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
+	if len(s.secondaryCidrBlocks()) > 0 {
+		conditions.MarkTrue(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition)
+	}
 
 	// Subnets.
-	if err := s.reconcileSubnets(); err != nil {
+	if err := s.reconcileSubnets(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// Internet Gateways.
-	if err := s.reconcileInternetGateways(); err != nil {
+	if err := s.reconcileInternetGateways(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, infrav1.InternetGatewayFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// Carrier Gateway.
-	if err := s.reconcileCarrierGateway(); err != nil {
+	if err := s.reconcileCarrierGateway(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.CarrierGatewayReadyCondition, infrav1.CarrierGatewayFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// Egress Only Internet Gateways.
-	if err := s.reconcileEgressOnlyInternetGateways(); err != nil {
+	if err := s.reconcileEgressOnlyInternetGateways(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EgressOnlyInternetGatewayReadyCondition, infrav1.EgressOnlyInternetGatewayFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// NAT Gateways.
-	if err := s.reconcileNatGateways(); err != nil {
+	if err := s.reconcileNatGateways(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// Routing tables.
-	if err := s.reconcileRouteTables(); err != nil {
+	if err := s.reconcileRouteTables(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
 
 	// VPC Endpoints.
-	if err := s.reconcileVPCEndpoints(); err != nil {
+	if err := s.reconcileVPCEndpoints(ctx); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, infrav1.VpcEndpointsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), err.Error())
 		return err
 	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition)
 
 	s.scope.Debug("Reconcile network completed successfully")
 	return nil
 }
 
+// ReconcileStandbyNetwork reconciles the VPC and the subnets of the given cluster, without the gateways and the route
+// tables connecting them, as the network of a standby region isn't used before the cluster fails over to it.
+func (s *Service) ReconcileStandbyNetwork(ctx context.Context) error {
+	s.scope.Debug("Reconciling standby network for cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()), "region", s.scope.Region())
+
+	if err := s.reconcileVPC(ctx); err != nil {
+		return err
+	}
+
+	if err := s.reconcileSubnets(ctx); err != nil {
+		return err
+	}
+
+	s.scope.Debug("Reconcile standby network completed successfully")
+	return nil
+}
+
+// deletionStep returns the step of the deletion of the network deleting resources whose state is reported in condition.
+func (s *Service) deletionStep(name string, condition clusterv1.ConditionType, deleteFunc func(context.Context) error) *infrautilconditions.DeletionStep {
+	return &infrautilconditions.DeletionStep{
+		StepName:  name,
+		Object:    s.scope.InfraCluster(),
+		Condition: condition,
+		Delete:    deleteFunc,
+	}
+}
+
 // DeleteNetwork deletes the network of the given cluster.
-func (s *Service) DeleteNetwork() (err error) {
+func (s *Service) DeleteNetwork(ctx context.Context) (err error) {
 	s.scope.Debug("Deleting network")
 
 	vpc := &infrav1.VPCSpec{}
 	// Get VPC used for the cluster
 	if s.scope.VPC().ID != "" {
 		var err error
-		vpc, err = s.describeVPCByID()
+		vpc, err = s.describeVPCByID(ctx)
 		if err != nil {
 			if awserrors.IsNotFound(err) {
 				// If the VPC does not exist, nothing to do
@@ -111,110 +149,36 @@ func (s *Service) DeleteNetwork() (err error) {
 
 	vpc.DeepCopyInto(s.scope.VPC())
 
-	// VPC Endpoints.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteVPCEndpoints(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// Routing tables.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteRouteTables(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// NAT Gateways.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteNatGateways(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatGatewaysReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// EIPs.
-	if err := s.releaseAddresses(); err != nil {
-		return err
-	}
-
-	// Internet Gateways.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteInternetGateways(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.InternetGatewayReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// Carrier Gateway.
+	// The resources are deleted once the resources depending on them are.
+	nodes := []planner.Node{
+		{Procedure: s.deletionStep("VpcEndpoints", infrav1.VpcEndpointsReadyCondition, s.deleteVPCEndpoints)},
+		{Procedure: s.deletionStep("NatGateways", infrav1.NatGatewaysReadyCondition, s.deleteNatGateways)},
+		{Procedure: s.deletionStep("ElasticIPs", "", s.releaseAddresses), DependsOn: []string{"NatGateways"}},
+		{Procedure: s.deletionStep("Subnets", infrav1.SubnetsReadyCondition, s.deleteSubnets), DependsOn: []string{"VpcEndpoints", "ElasticIPs"}},
+		{Procedure: s.deletionStep("RouteTables", infrav1.RouteTablesReadyCondition, s.deleteRouteTables), DependsOn: []string{"Subnets"}},
+		{Procedure: s.deletionStep("InternetGateways", infrav1.InternetGatewayReadyCondition, s.deleteInternetGateways), DependsOn: []string{"RouteTables"}},
+		{Procedure: s.deletionStep("EgressOnlyInternetGateways", infrav1.EgressOnlyInternetGatewayReadyCondition, s.deleteEgressOnlyInternetGateways), DependsOn: []string{"RouteTables"}},
+		{Procedure: s.deletionStep("SecondaryCidrs", infrav1.SecondaryCidrsReadyCondition, s.disassociateSecondaryCidr), DependsOn: []string{"Subnets"}},
+	}
+	vpcDependencies := []string{"InternetGateways", "EgressOnlyInternetGateways", "SecondaryCidrs"}
 	if s.scope.VPC().CarrierGatewayID != nil {
-		if err := s.deleteCarrierGateway(); err != nil {
-			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.CarrierGatewayReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-			return err
-		}
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.CarrierGatewayReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-	}
-
-	// Egress Only Internet Gateways.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EgressOnlyInternetGatewayReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteEgressOnlyInternetGateways(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EgressOnlyInternetGatewayReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.EgressOnlyInternetGatewayReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// Subnets.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
+		nodes = append(nodes, planner.Node{Procedure: s.deletionStep("CarrierGateway", infrav1.CarrierGatewayReadyCondition, s.deleteCarrierGateway), DependsOn: []string{"RouteTables"}})
+		vpcDependencies = append(vpcDependencies, "CarrierGateway")
 	}
+	nodes = append(nodes, planner.Node{Procedure: s.deletionStep("Vpc", infrav1.VpcReadyCondition, s.deleteVPC), DependsOn: vpcDependencies})
 
-	if err := s.deleteSubnets(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+	graph, err := planner.NewGraph(deleteNetworkRetryBudget, nodes...)
+	if err != nil {
 		return err
 	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-
-	// Secondary CIDR.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.disassociateSecondaryCidr(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, "DisassociateFailed", clusterv1.ConditionSeverityWarning, err.Error())
-		return err
-	}
-
-	// VPC.
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
-	if err := s.scope.PatchObject(); err != nil {
-		return err
-	}
-
-	if err := s.deleteVPC(); err != nil {
-		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+	// The scope isn't patched here, as the network can be deleted by a step of the deletion of the cluster, running
+	// concurrently with the other steps: the conditions of the steps are patched by the caller.
+	infrautilconditions.MarkDeletingSteps(nodes)
+	results, err := graph.Execute(ctx)
+	infrautilconditions.MarkDeletionSteps(nodes, results)
+	if err != nil {
 		return err
 	}
-	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
 	s.scope.Debug("Delete network completed successfully")
 	return nil
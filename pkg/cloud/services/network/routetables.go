@@ -39,7 +39,7 @@ const (
 	mainRouteTableInVPCKey = "main"
 )
 
-func (s *Service) reconcileRouteTables() error {
+func (s *Service) reconcileRouteTables(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping routing tables reconcile in unmanaged mode")
 		return nil
@@ -47,7 +47,7 @@ func (s *Service) reconcileRouteTables() error {
 
 	s.scope.Debug("Reconciling routing tables")
 
-	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet(ctx)
 	if err != nil {
 		return err
 	}
@@ -77,7 +77,7 @@ func (s *Service) reconcileRouteTables() error {
 				for i := range routes {
 					// Routes destination cidr blocks must be unique within a routing table.
 					// If there is a mistmatch, we replace the routing association.
-					if err := s.fixMismatchedRouting(routes[i], currentRoute, rt); err != nil {
+					if err := s.fixMismatchedRouting(ctx, routes[i], currentRoute, rt); err != nil {
 						return err
 					}
 				}
@@ -87,7 +87,7 @@ func (s *Service) reconcileRouteTables() error {
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getRouteTableTagParams(*rt.RouteTableId, sn.IsPublic, sn.AvailabilityZone)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-				if err := tagsBuilder.Ensure(converters.TagsToMap(rt.Tags)); err != nil {
+				if err := tagsBuilder.Ensure(ctx, converters.TagsToMap(rt.Tags)); err != nil {
 					return false, err
 				}
 				return true, nil
@@ -103,13 +103,13 @@ func (s *Service) reconcileRouteTables() error {
 
 		// For each subnet that doesn't have a routing table associated with it,
 		// create a new table with the appropriate default routes and associate it to the subnet.
-		rt, err := s.createRouteTableWithRoutes(routes, sn.IsPublic, sn.AvailabilityZone)
+		rt, err := s.createRouteTableWithRoutes(ctx, routes, sn.IsPublic, sn.AvailabilityZone)
 		if err != nil {
 			return err
 		}
 
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if err := s.associateRouteTable(rt, sn.GetResourceID()); err != nil {
+			if err := s.associateRouteTable(ctx, rt, sn.GetResourceID()); err != nil {
 				s.scope.Error(err, "trying to associate route table", "subnet_id", sn.GetResourceID())
 				return false, err
 			}
@@ -125,7 +125,7 @@ func (s *Service) reconcileRouteTables() error {
 	return nil
 }
 
-func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentRoute *ec2.Route, rt *ec2.RouteTable) error {
+func (s *Service) fixMismatchedRouting(ctx context.Context, specRoute *ec2.CreateRouteInput, currentRoute *ec2.Route, rt *ec2.RouteTable) error {
 	var input *ec2.ReplaceRouteInput
 	if specRoute.DestinationCidrBlock != nil {
 		if (currentRoute.DestinationCidrBlock != nil &&
@@ -157,7 +157,7 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 	}
 	if input != nil {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EC2Client.ReplaceRouteWithContext(context.TODO(), input); err != nil {
+			if _, err := s.EC2Client.ReplaceRouteWithContext(ctx, input); err != nil {
 				return false, err
 			}
 			return true, nil
@@ -169,8 +169,8 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 	return nil
 }
 
-func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
-	rts, err := s.describeVpcRouteTables()
+func (s *Service) describeVpcRouteTablesBySubnet(ctx context.Context) (map[string]*ec2.RouteTable, error) {
+	rts, err := s.describeVpcRouteTables(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -194,13 +194,13 @@ func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable,
 	return res, nil
 }
 
-func (s *Service) deleteRouteTable(rt *ec2.RouteTable) error {
+func (s *Service) deleteRouteTable(ctx context.Context, rt *ec2.RouteTable) error {
 	for _, as := range rt.Associations {
 		if as.SubnetId == nil {
 			continue
 		}
 
-		if _, err := s.EC2Client.DisassociateRouteTableWithContext(context.TODO(), &ec2.DisassociateRouteTableInput{AssociationId: as.RouteTableAssociationId}); err != nil {
+		if _, err := s.EC2Client.DisassociateRouteTableWithContext(ctx, &ec2.DisassociateRouteTableInput{AssociationId: as.RouteTableAssociationId}); err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedDisassociateRouteTable", "Failed to disassociate managed RouteTable %q from Subnet %q: %v", *rt.RouteTableId, *as.SubnetId, err)
 			return errors.Wrapf(err, "failed to disassociate route table %q from subnet %q", *rt.RouteTableId, *as.SubnetId)
 		}
@@ -209,7 +209,7 @@ func (s *Service) deleteRouteTable(rt *ec2.RouteTable) error {
 		s.scope.Debug("Deleted association between route table and subnet", "route-table-id", *rt.RouteTableId, "subnet-id", *as.SubnetId)
 	}
 
-	if _, err := s.EC2Client.DeleteRouteTableWithContext(context.TODO(), &ec2.DeleteRouteTableInput{RouteTableId: rt.RouteTableId}); err != nil {
+	if _, err := s.EC2Client.DeleteRouteTableWithContext(ctx, &ec2.DeleteRouteTableInput{RouteTableId: rt.RouteTableId}); err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedDeleteRouteTable", "Failed to delete managed RouteTable %q: %v", *rt.RouteTableId, err)
 		return errors.Wrapf(err, "failed to delete route table %q", *rt.RouteTableId)
 	}
@@ -220,19 +220,19 @@ func (s *Service) deleteRouteTable(rt *ec2.RouteTable) error {
 	return nil
 }
 
-func (s *Service) deleteRouteTables() error {
+func (s *Service) deleteRouteTables(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping routing tables deletion in unmanaged mode")
 		return nil
 	}
 
-	rts, err := s.describeVpcRouteTables()
+	rts, err := s.describeVpcRouteTables(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "failed to describe route tables in vpc %q", s.scope.VPC().ID)
 	}
 
 	for _, rt := range rts {
-		err := s.deleteRouteTable(rt)
+		err := s.deleteRouteTable(ctx, rt)
 		if err != nil {
 			return err
 		}
@@ -240,7 +240,7 @@ func (s *Service) deleteRouteTables() error {
 	return nil
 }
 
-func (s *Service) describeVpcRouteTables() ([]*ec2.RouteTable, error) {
+func (s *Service) describeVpcRouteTables(ctx context.Context) ([]*ec2.RouteTable, error) {
 	filters := []*ec2.Filter{
 		filter.EC2.VPC(s.scope.VPC().ID),
 	}
@@ -249,7 +249,7 @@ func (s *Service) describeVpcRouteTables() ([]*ec2.RouteTable, error) {
 		filters = append(filters, filter.EC2.Cluster(s.scope.Name()))
 	}
 
-	out, err := s.EC2Client.DescribeRouteTablesWithContext(context.TODO(), &ec2.DescribeRouteTablesInput{
+	out, err := s.EC2Client.DescribeRouteTablesWithContext(ctx, &ec2.DescribeRouteTablesInput{
 		Filters: filters,
 	})
 	if err != nil {
@@ -260,8 +260,8 @@ func (s *Service) describeVpcRouteTables() ([]*ec2.RouteTable, error) {
 	return out.RouteTables, nil
 }
 
-func (s *Service) createRouteTableWithRoutes(routes []*ec2.CreateRouteInput, isPublic bool, zone string) (*infrav1.RouteTable, error) {
-	out, err := s.EC2Client.CreateRouteTableWithContext(context.TODO(), &ec2.CreateRouteTableInput{
+func (s *Service) createRouteTableWithRoutes(ctx context.Context, routes []*ec2.CreateRouteInput, isPublic bool, zone string) (*infrav1.RouteTable, error) {
+	out, err := s.EC2Client.CreateRouteTableWithContext(ctx, &ec2.CreateRouteTableInput{
 		VpcId: aws.String(s.scope.VPC().ID),
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeRouteTable, s.getRouteTableTagParams(services.TemporaryResourceID, isPublic, zone))},
@@ -277,13 +277,13 @@ func (s *Service) createRouteTableWithRoutes(routes []*ec2.CreateRouteInput, isP
 		route := routes[i]
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 			route.RouteTableId = out.RouteTable.RouteTableId
-			if _, err := s.EC2Client.CreateRouteWithContext(context.TODO(), route); err != nil {
+			if _, err := s.EC2Client.CreateRouteWithContext(ctx, route); err != nil {
 				return false, err
 			}
 			return true, nil
 		}, awserrors.RouteTableNotFound, awserrors.NATGatewayNotFound, awserrors.GatewayNotFound); err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create route %s for RouteTable %q: %v", route.GoString(), *out.RouteTable.RouteTableId, err)
-			errDel := s.deleteRouteTable(out.RouteTable)
+			errDel := s.deleteRouteTable(ctx, out.RouteTable)
 			if errDel != nil {
 				record.Warnf(s.scope.InfraCluster(), "FailedDeleteRouteTable", "Failed to delete managed RouteTable %q: %v", *out.RouteTable.RouteTableId, errDel)
 			}
@@ -297,8 +297,8 @@ func (s *Service) createRouteTableWithRoutes(routes []*ec2.CreateRouteInput, isP
 	}, nil
 }
 
-func (s *Service) associateRouteTable(rt *infrav1.RouteTable, subnetID string) error {
-	_, err := s.EC2Client.AssociateRouteTableWithContext(context.TODO(), &ec2.AssociateRouteTableInput{
+func (s *Service) associateRouteTable(ctx context.Context, rt *infrav1.RouteTable, subnetID string) error {
+	_, err := s.EC2Client.AssociateRouteTableWithContext(ctx, &ec2.AssociateRouteTableInput{
 		RouteTableId: aws.String(rt.ID),
 		SubnetId:     aws.String(subnetID),
 	})
@@ -319,6 +319,13 @@ func (s *Service) getNatGatewayPrivateRoute(natGatewayID string) *ec2.CreateRout
 	}
 }
 
+func (s *Service) getNat64Route(natGatewayID string) *ec2.CreateRouteInput {
+	return &ec2.CreateRouteInput{
+		NatGatewayId:             aws.String(natGatewayID),
+		DestinationIpv6CidrBlock: aws.String(services.NAT64CidrBlock),
+	}
+}
+
 func (s *Service) getEgressOnlyInternetGateway() *ec2.CreateRouteInput {
 	return &ec2.CreateRouteInput{
 		DestinationIpv6CidrBlock:    aws.String(services.AnyIPv6CidrBlock),
@@ -391,10 +398,20 @@ func (s *Service) getRoutesToPublicSubnet(sn *infrav1.SubnetSpec) ([]*ec2.Create
 	if s.scope.VPC().InternetGatewayID == nil {
 		return routes, errors.Errorf("failed to create routing tables: internet gateway for VPC %q is not present", s.scope.VPC().ID)
 	}
-	routes = append(routes, s.getGatewayPublicRoute())
+	// IPv6-native subnets have no IPv4 traffic to route.
+	if !sn.IPv6Native {
+		routes = append(routes, s.getGatewayPublicRoute())
+	}
 	if sn.IsIPv6 {
 		routes = append(routes, s.getGatewayPublicIPv6Route())
 	}
+	if sn.EnableDNS64 {
+		natGatewayID, err := s.getNatGatewayForPublicSubnet(sn)
+		if err != nil {
+			return routes, err
+		}
+		routes = append(routes, s.getNat64Route(natGatewayID))
+	}
 
 	return routes, nil
 }
@@ -406,12 +423,17 @@ func (s *Service) getRoutesToPrivateSubnet(sn *infrav1.SubnetSpec) (routes []*ec
 		return nil, errors.Errorf("can't determine routes for unsupported ipv6 subnet in zone type %q", sn.ZoneType)
 	}
 
-	natGatewayID, err = s.getNatGatewayForSubnet(sn)
-	if err != nil {
-		return routes, err
+	// IPv6-native subnets only route their traffic through a NAT gateway for NAT64.
+	if !sn.IPv6Native || sn.EnableDNS64 {
+		natGatewayID, err = s.getNatGatewayForSubnet(sn)
+		if err != nil {
+			return routes, err
+		}
 	}
 
-	routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
+	if !sn.IPv6Native {
+		routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
+	}
 	if sn.IsIPv6 {
 		if !s.scope.VPC().IsIPv6Enabled() {
 			// Safety net because EgressOnlyInternetGateway needs the ID from the ipv6 block.
@@ -421,6 +443,9 @@ func (s *Service) getRoutesToPrivateSubnet(sn *infrav1.SubnetSpec) (routes []*ec
 		}
 		routes = append(routes, s.getEgressOnlyInternetGateway())
 	}
+	if sn.EnableDNS64 {
+		routes = append(routes, s.getNat64Route(natGatewayID))
+	}
 
 	return routes, nil
 }
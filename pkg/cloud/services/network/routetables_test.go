@@ -576,6 +576,7 @@ func TestReconcileRouteTables(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -589,7 +590,7 @@ func TestReconcileRouteTables(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileRouteTables(); err != nil && tc.err != nil {
+			if err := s.reconcileRouteTables(context.TODO()); err != nil && tc.err != nil {
 				if !strings.Contains(err.Error(), tc.err.Error()) {
 					t.Fatalf("was expecting error to look like '%v', but got '%v'", tc.err, err)
 				}
@@ -754,6 +755,7 @@ func TestDeleteRouteTables(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -766,7 +768,7 @@ func TestDeleteRouteTables(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteRouteTables()
+			err = s.deleteRouteTables(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -830,7 +832,7 @@ func TestDeleteRouteTable(t *testing.T) {
 				},
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
-					Spec:       infrav1.AWSClusterSpec{},
+					Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 				},
 			})
 			g.Expect(err).NotTo(HaveOccurred())
@@ -841,7 +843,7 @@ func TestDeleteRouteTable(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteRouteTable(tc.input)
+			err = s.deleteRouteTable(context.TODO(), tc.input)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -1302,6 +1304,98 @@ func TestService_getRoutesForSubnet(t *testing.T) {
 			},
 			wantErrMessage: `can't determine routes for unsupported ipv6 subnet in zone type "wavelength-zone"`,
 		},
+		// IPv6-native subnets and DNS64
+		{
+			name: "public ipv6-native subnet with dns64, availability zone, must have ipv6 default route to igw and nat64 route",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1a-public-ipv6",
+				AvailabilityZone: "us-east-1a",
+				IsPublic:         true,
+				IsIPv6:           true,
+				IPv6Native:       true,
+				EnableDNS64:      true,
+			},
+			want: []*ec2.CreateRouteInput{
+				{
+					DestinationIpv6CidrBlock: aws.String("::/0"),
+					GatewayId:                aws.String("vpc-igw"),
+				},
+				{
+					DestinationIpv6CidrBlock: aws.String("64:ff9b::/96"),
+					NatGatewayId:             aws.String("nat-gw-fromZone-us-east-1a"),
+				},
+			},
+		},
+		{
+			name: "public ipv6 subnet with dns64, availability zone, must return error when no nat gateway available",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1b-public",
+				AvailabilityZone: "us-east-1b",
+				IsPublic:         true,
+				IsIPv6:           true,
+				EnableDNS64:      true,
+			},
+			wantErrMessage: `no nat gateways available in "us-east-1b" for the NAT64 route of public subnet "subnet-az-1b-public"`,
+		},
+		{
+			name:                "private ipv6-native subnet, availability zone, must only have ipv6 default route to eigw",
+			specOverrideSubnets: &infrav1.Subnets{},
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1a-private-ipv6",
+				AvailabilityZone: "us-east-1a",
+				IsIPv6:           true,
+				IPv6Native:       true,
+			},
+			want: []*ec2.CreateRouteInput{
+				{
+					DestinationIpv6CidrBlock:    aws.String("::/0"),
+					EgressOnlyInternetGatewayId: aws.String("vpc-eigw"),
+				},
+			},
+		},
+		{
+			name: "private ipv6-native subnet with dns64, availability zone, must have ipv6 default route to eigw and nat64 route",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1a-private-ipv6",
+				AvailabilityZone: "us-east-1a",
+				IsIPv6:           true,
+				IPv6Native:       true,
+				EnableDNS64:      true,
+			},
+			want: []*ec2.CreateRouteInput{
+				{
+					DestinationIpv6CidrBlock:    aws.String("::/0"),
+					EgressOnlyInternetGatewayId: aws.String("vpc-eigw"),
+				},
+				{
+					DestinationIpv6CidrBlock: aws.String("64:ff9b::/96"),
+					NatGatewayId:             aws.String("nat-gw-fromZone-us-east-1a"),
+				},
+			},
+		},
+		{
+			name: "private ipv6 subnet with dns64, availability zone, must have default routes and nat64 route",
+			inputSubnet: &infrav1.SubnetSpec{
+				ResourceID:       "subnet-az-1a-private",
+				AvailabilityZone: "us-east-1a",
+				IsIPv6:           true,
+				EnableDNS64:      true,
+			},
+			want: []*ec2.CreateRouteInput{
+				{
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					NatGatewayId:         aws.String("nat-gw-fromZone-us-east-1a"),
+				},
+				{
+					DestinationIpv6CidrBlock:    aws.String("::/0"),
+					EgressOnlyInternetGatewayId: aws.String("vpc-eigw"),
+				},
+				{
+					DestinationIpv6CidrBlock: aws.String("64:ff9b::/96"),
+					NatGatewayId:             aws.String("nat-gw-fromZone-us-east-1a"),
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1315,7 +1409,7 @@ func TestService_getRoutesForSubnet(t *testing.T) {
 				},
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
-					Spec:       infrav1.AWSClusterSpec{},
+					Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 				},
 			}
 			cluster.AWSCluster.Spec.NetworkSpec = defaultNetwork
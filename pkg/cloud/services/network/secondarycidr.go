@@ -19,6 +19,7 @@ package network
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -30,12 +31,28 @@ func isVPCPresent(vpcs *ec2.DescribeVpcsOutput) bool {
 	return vpcs != nil && len(vpcs.Vpcs) > 0
 }
 
-func (s *Service) associateSecondaryCidr() error {
-	if s.scope.SecondaryCidrBlock() == nil {
+// secondaryCidrBlocks returns the secondary CIDR blocks to associate with the VPC: the CIDR block used for the pod
+// IPs of EKS clusters and, when the VPC is managed, the secondary CIDR blocks of its spec.
+func (s *Service) secondaryCidrBlocks() []string {
+	var cidrBlocks []string
+	if s.scope.SecondaryCidrBlock() != nil {
+		cidrBlocks = append(cidrBlocks, *s.scope.SecondaryCidrBlock())
+	}
+	if s.scope.VPC().IsManaged(s.scope.Name()) {
+		for _, block := range s.scope.VPC().SecondaryCidrBlocks {
+			cidrBlocks = append(cidrBlocks, block.IPv4CidrBlock)
+		}
+	}
+	return cidrBlocks
+}
+
+func (s *Service) associateSecondaryCidr(ctx context.Context) error {
+	cidrBlocks := s.secondaryCidrBlocks()
+	if len(cidrBlocks) == 0 {
 		return nil
 	}
 
-	vpcs, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+	vpcs, err := s.EC2Client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
 		VpcIds: []*string{&s.scope.VPC().ID},
 	})
 	if err != nil {
@@ -47,33 +64,36 @@ func (s *Service) associateSecondaryCidr() error {
 	}
 
 	existingAssociations := vpcs.Vpcs[0].CidrBlockAssociationSet
-	for _, existing := range existingAssociations {
-		if *existing.CidrBlock == *s.scope.SecondaryCidrBlock() {
-			return nil
+cidrBlocks:
+	for _, cidrBlock := range cidrBlocks {
+		for _, existing := range existingAssociations {
+			if *existing.CidrBlock == cidrBlock {
+				continue cidrBlocks
+			}
 		}
-	}
 
-	out, err := s.EC2Client.AssociateVpcCidrBlockWithContext(context.TODO(), &ec2.AssociateVpcCidrBlockInput{
-		VpcId:     &s.scope.VPC().ID,
-		CidrBlock: s.scope.SecondaryCidrBlock(),
-	})
-	if err != nil {
-		record.Warnf(s.scope.InfraCluster(), "FailedAssociateSecondaryCidr", "Failed associating secondary CIDR with VPC %v", err)
-		return err
-	}
+		out, err := s.EC2Client.AssociateVpcCidrBlockWithContext(ctx, &ec2.AssociateVpcCidrBlockInput{
+			VpcId:     &s.scope.VPC().ID,
+			CidrBlock: aws.String(cidrBlock),
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateSecondaryCidr", "Failed associating secondary CIDR with VPC %v", err)
+			return err
+		}
 
-	// once IPv6 is supported, we need to modify out.CidrBlockAssociation.AssociationId to out.Ipv6CidrBlockAssociation.AssociationId
-	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateSecondaryCidr", "Associated secondary CIDR with VPC %q", *out.CidrBlockAssociation.AssociationId)
+		// once IPv6 is supported, we need to modify out.CidrBlockAssociation.AssociationId to out.Ipv6CidrBlockAssociation.AssociationId
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateSecondaryCidr", "Associated secondary CIDR with VPC %q", *out.CidrBlockAssociation.AssociationId)
+	}
 
 	return nil
 }
 
-func (s *Service) disassociateSecondaryCidr() error {
+func (s *Service) disassociateSecondaryCidr(ctx context.Context) error {
 	if s.scope.SecondaryCidrBlock() == nil {
 		return nil
 	}
 
-	vpcs, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+	vpcs, err := s.EC2Client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
 		VpcIds: []*string{&s.scope.VPC().ID},
 	})
 	if err != nil {
@@ -87,7 +107,7 @@ func (s *Service) disassociateSecondaryCidr() error {
 	existingAssociations := vpcs.Vpcs[0].CidrBlockAssociationSet
 	for _, existing := range existingAssociations {
 		if cmp.Equal(existing.CidrBlock, s.scope.SecondaryCidrBlock()) {
-			if _, err := s.EC2Client.DisassociateVpcCidrBlockWithContext(context.TODO(), &ec2.DisassociateVpcCidrBlockInput{
+			if _, err := s.EC2Client.DisassociateVpcCidrBlockWithContext(ctx, &ec2.DisassociateVpcCidrBlockInput{
 				AssociationId: existing.AssociationId,
 			}); err != nil {
 				record.Warnf(s.scope.InfraCluster(), "FailedDisassociateSecondaryCidr", "Failed disassociating secondary CIDR with VPC %v", err)
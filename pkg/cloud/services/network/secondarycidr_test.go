@@ -43,6 +43,7 @@ func setupNewManagedControlPlaneScope(cl client.Client) (*scope.ManagedControlPl
 		Cluster: &v1beta1.Cluster{},
 		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				Region:             "us-east-1",
 				SecondaryCidrBlock: ptr.To[string]("secondary-cidr"),
 				NetworkSpec: infrav1.NetworkSpec{
 					VPC: infrav1.VPCSpec{ID: "vpc-id"},
@@ -65,14 +66,42 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	tests := []struct {
-		name              string
-		haveSecondaryCIDR bool
-		expect            func(m *mocks.MockEC2APIMockRecorder)
-		wantErr           bool
+		name                string
+		haveSecondaryCIDR   bool
+		managedVPC          bool
+		secondaryCidrBlocks []infrav1.VpcCidrBlock
+		expect              func(m *mocks.MockEC2APIMockRecorder)
+		wantErr             bool
 	}{
 		{
 			name: "Should not associate secondary CIDR if no secondary cidr block info present in control plane",
 		},
+		{
+			name:                "Should not associate the secondary cidr blocks of an unmanaged VPC",
+			secondaryCidrBlocks: []infrav1.VpcCidrBlock{{IPv4CidrBlock: "10.1.0.0/16"}},
+		},
+		{
+			name:                "Should associate the secondary cidr blocks of a managed VPC which don't exist in VPC",
+			managedVPC:          true,
+			secondaryCidrBlocks: []infrav1.VpcCidrBlock{{IPv4CidrBlock: "10.1.0.0/16"}, {IPv4CidrBlock: "10.2.0.0/16"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{
+						{
+							CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+								{CidrBlock: aws.String("10.0.0.0/16")},
+								{CidrBlock: aws.String("10.1.0.0/16")},
+							},
+						},
+					}}, nil)
+				m.AssociateVpcCidrBlockWithContext(context.TODO(), &ec2.AssociateVpcCidrBlockInput{
+					VpcId:     aws.String("vpc-id"),
+					CidrBlock: aws.String("10.2.0.0/16"),
+				}).Return(&ec2.AssociateVpcCidrBlockOutput{
+					CidrBlockAssociation: &ec2.VpcCidrBlockAssociation{AssociationId: aws.String("association-id")},
+				}, nil)
+			},
+		},
 		{
 			name:              "Should return error if unable to describe VPC",
 			haveSecondaryCIDR: true,
@@ -136,6 +165,10 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 			if !tt.haveSecondaryCIDR {
 				mcpScope.ControlPlane.Spec.SecondaryCidrBlock = nil
 			}
+			if tt.managedVPC {
+				mcpScope.VPC().Tags = infrav1.Tags{infrav1.ClusterTagKey(mcpScope.Name()): string(infrav1.ResourceLifecycleOwned)}
+			}
+			mcpScope.VPC().SecondaryCidrBlocks = tt.secondaryCidrBlocks
 
 			s := NewService(mcpScope)
 			s.EC2Client = ec2Mock
@@ -144,7 +177,7 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 				tt.expect(ec2Mock.EXPECT())
 			}
 
-			err = s.associateSecondaryCidr()
+			err = s.associateSecondaryCidr(context.TODO())
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -239,7 +272,7 @@ func TestServiceDiassociateSecondaryCidr(t *testing.T) {
 				tt.expect(ec2Mock.EXPECT())
 			}
 
-			err = s.disassociateSecondaryCidr()
+			err = s.disassociateSecondaryCidr(context.TODO())
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -46,7 +46,7 @@ const (
 	defaultMaxNumAZs        = 3
 )
 
-func (s *Service) reconcileSubnets() error {
+func (s *Service) reconcileSubnets(ctx context.Context) error {
 	s.scope.Info("Reconciling subnets")
 
 	subnets := s.scope.Subnets()
@@ -61,7 +61,7 @@ func (s *Service) reconcileSubnets() error {
 	// Describing the VPC Subnets tags the resources.
 	if s.scope.TagUnmanagedNetworkResources() {
 		// Describe subnets in the vpc.
-		if existing, err = s.describeVpcSubnets(); err != nil {
+		if existing, err = s.describeVpcSubnets(ctx); err != nil {
 			return err
 		}
 	}
@@ -80,7 +80,7 @@ func (s *Service) reconcileSubnets() error {
 		// for each az in a region up to a maximum of 3 azs
 		s.scope.Info("no subnets specified, setting defaults")
 
-		subnets, err = s.getDefaultSubnets()
+		subnets, err = s.getDefaultSubnets(ctx)
 		if err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedDefaultSubnets", "Failed getting default subnets: %v", err)
 			return errors.Wrap(err, "failed getting default subnets")
@@ -96,7 +96,7 @@ func (s *Service) reconcileSubnets() error {
 	// Describing the VPC Subnets tags the resources.
 	if !s.scope.TagUnmanagedNetworkResources() {
 		// Describe subnets in the vpc.
-		if existing, err = s.describeVpcSubnets(); err != nil {
+		if existing, err = s.describeVpcSubnets(ctx); err != nil {
 			return err
 		}
 	}
@@ -107,7 +107,7 @@ func (s *Service) reconcileSubnets() error {
 			return err
 		}
 
-		zones, err := s.getAvailableZones()
+		zones, err := s.getAvailableZones(ctx)
 		if err != nil {
 			return err
 		}
@@ -139,14 +139,27 @@ func (s *Service) reconcileSubnets() error {
 			}
 
 			// Update subnet spec with the existing subnet details
+			existingSubnet.Replaces = sub.Replaces
 			existingSubnet.DeepCopyInto(sub)
 
 			// Make sure tags are up-to-date.
 			subnetTags := sub.Tags
+			if replacement := subnets.ReplacementOf(sub); !unmanagedVPC && replacement != nil && replacement.ResourceID != "" {
+				// Mark the replaced subnet, so that it can be drained and deleted once the workloads moved
+				// to the replacement subnet.
+				subnetTags = subnetTags.DeepCopy()
+				if subnetTags == nil {
+					subnetTags = infrav1.Tags{}
+				}
+				if subnetTags[infrav1.NameAWSSubnetReplacedBy] != replacement.ResourceID {
+					record.Eventf(s.scope.InfraCluster(), "SubnetReplaced", "Subnet %q is replaced by subnet %q and can be drained", existingSubnet.GetResourceID(), replacement.ResourceID)
+				}
+				subnetTags[infrav1.NameAWSSubnetReplacedBy] = replacement.ResourceID
+			}
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.GetResourceID(), existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.IsEdge())
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-				if err := tagsBuilder.Ensure(existingSubnet.Tags); err != nil {
+				if err := tagsBuilder.Ensure(ctx, existingSubnet.Tags); err != nil {
 					return false, err
 				}
 				return true, nil
@@ -177,7 +190,7 @@ func (s *Service) reconcileSubnets() error {
 	// Reconciling the zone information for the subnets. Subnets are grouped
 	// by regular zones (availability zones) or edge zones (local zones or wavelength zones)
 	// based in the zone-type attribute for zone.
-	if err := s.reconcileZoneInfo(subnets); err != nil {
+	if err := s.reconcileZoneInfo(ctx, subnets); err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedNoZoneInfo", "Expected the zone attributes to be populated to subnet")
 		return errors.Wrapf(err, "expected the zone attributes to be populated to subnet")
 	}
@@ -203,10 +216,11 @@ func (s *Service) reconcileSubnets() error {
 				continue
 			}
 
-			nsn, err := s.createSubnet(subnet)
+			nsn, err := s.createSubnet(ctx, subnet)
 			if err != nil {
 				return err
 			}
+			nsn.Replaces = subnet.Replaces
 			nsn.DeepCopyInto(subnet)
 		}
 	}
@@ -216,8 +230,8 @@ func (s *Service) reconcileSubnets() error {
 	return nil
 }
 
-func (s *Service) retrieveZoneInfo(zoneNames []string) ([]*ec2.AvailabilityZone, error) {
-	zones, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+func (s *Service) retrieveZoneInfo(ctx context.Context, zoneNames []string) ([]*ec2.AvailabilityZone, error) {
+	zones, err := s.EC2Client.DescribeAvailabilityZonesWithContext(ctx, &ec2.DescribeAvailabilityZonesInput{
 		ZoneNames: aws.StringSlice(zoneNames),
 	})
 	if err != nil {
@@ -231,9 +245,9 @@ func (s *Service) retrieveZoneInfo(zoneNames []string) ([]*ec2.AvailabilityZone,
 // reconcileZoneInfo discover the zones for all subnets, and retrieve
 // persist the zone information from resource API, such as Type and
 // Parent Zone.
-func (s *Service) reconcileZoneInfo(subnets infrav1.Subnets) error {
+func (s *Service) reconcileZoneInfo(ctx context.Context, subnets infrav1.Subnets) error {
 	if len(subnets) > 0 {
-		zones, err := s.retrieveZoneInfo(subnets.GetUniqueZones())
+		zones, err := s.retrieveZoneInfo(ctx, subnets.GetUniqueZones())
 		if err != nil {
 			return err
 		}
@@ -245,8 +259,8 @@ func (s *Service) reconcileZoneInfo(subnets infrav1.Subnets) error {
 	return nil
 }
 
-func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
-	zones, err := s.getAvailableZones()
+func (s *Service) getDefaultSubnets(ctx context.Context) (infrav1.Subnets, error) {
+	zones, err := s.getAvailableZones(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -338,20 +352,20 @@ func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
-func (s *Service) deleteSubnets() error {
+func (s *Service) deleteSubnets(ctx context.Context) error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping subnets deletion in unmanaged mode")
 		return nil
 	}
 
 	// Describe subnets in the vpc.
-	existing, err := s.describeSubnets()
+	existing, err := s.describeSubnets(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, sn := range existing.Subnets {
-		if err := s.deleteSubnet(aws.StringValue(sn.SubnetId)); err != nil {
+		if err := s.deleteSubnet(ctx, aws.StringValue(sn.SubnetId)); err != nil {
 			return err
 		}
 	}
@@ -359,18 +373,18 @@ func (s *Service) deleteSubnets() error {
 	return nil
 }
 
-func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
-	sns, err := s.describeSubnets()
+func (s *Service) describeVpcSubnets(ctx context.Context) (infrav1.Subnets, error) {
+	sns, err := s.describeSubnets(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	routeTables, err := s.describeVpcRouteTablesBySubnet()
+	routeTables, err := s.describeVpcRouteTablesBySubnet(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	natGateways, err := s.describeNatGatewaysBySubnet()
+	natGateways, err := s.describeNatGatewaysBySubnet(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -385,8 +399,11 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 			AvailabilityZone: *ec2sn.AvailabilityZone,
 			Tags:             converters.TagsToMap(ec2sn.Tags),
 		}
-		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges.
+		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges,
+		// unless the subnet is IPv6-native.
 		spec.CidrBlock = aws.StringValue(ec2sn.CidrBlock)
+		spec.IPv6Native = aws.BoolValue(ec2sn.Ipv6Native)
+		spec.EnableDNS64 = aws.BoolValue(ec2sn.EnableDns64)
 		for _, set := range ec2sn.Ipv6CidrBlockAssociationSet {
 			if *set.Ipv6CidrBlockState.State == ec2.SubnetCidrBlockStateCodeAssociated {
 				spec.IPv6CidrBlock = aws.StringValue(set.Ipv6CidrBlock)
@@ -426,7 +443,7 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 	return subnets, nil
 }
 
-func (s *Service) describeSubnets() (*ec2.DescribeSubnetsOutput, error) {
+func (s *Service) describeSubnets(ctx context.Context) (*ec2.DescribeSubnetsOutput, error) {
 	input := &ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
@@ -439,7 +456,7 @@ func (s *Service) describeSubnets() (*ec2.DescribeSubnetsOutput, error) {
 		input.Filters = append(input.Filters, filter.EC2.VPC(s.scope.VPC().ID))
 	}
 
-	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeSubnetsWithContext(ctx, input)
 	if err != nil {
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeSubnet", "Failed to describe subnets in vpc %q: %v", s.scope.VPC().ID, err)
 		return nil, errors.Wrapf(err, "failed to describe subnets in vpc %q", s.scope.VPC().ID)
@@ -447,7 +464,7 @@ func (s *Service) describeSubnets() (*ec2.DescribeSubnetsOutput, error) {
 	return out, nil
 }
 
-func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, error) {
+func (s *Service) createSubnet(ctx context.Context, sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, error) {
 	// When managing subnets, the ID specified in the spec is the name of the subnet.
 	if sn.Tags == nil {
 		sn.Tags = make(infrav1.Tags)
@@ -459,7 +476,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 
 	// Retrieve zone information used later to change the zone attributes.
 	if len(sn.AvailabilityZone) > 0 {
-		zones, err := s.retrieveZoneInfo([]string{sn.AvailabilityZone})
+		zones, err := s.retrieveZoneInfo(ctx, []string{sn.AvailabilityZone})
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to discover zone information for subnet's zone %q", sn.AvailabilityZone)
 		}
@@ -482,7 +499,6 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	// Build the subnet creation request.
 	input := &ec2.CreateSubnetInput{
 		VpcId:            aws.String(s.scope.VPC().ID),
-		CidrBlock:        aws.String(sn.CidrBlock),
 		AvailabilityZone: aws.String(sn.AvailabilityZone),
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(
@@ -491,11 +507,18 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 			),
 		},
 	}
+	// IPv6-native subnets don't have an IPv4 CIDR block.
+	if sn.CidrBlock != "" {
+		input.CidrBlock = aws.String(sn.CidrBlock)
+	}
 	if s.scope.VPC().IsIPv6Enabled() {
 		input.Ipv6CidrBlock = aws.String(sn.IPv6CidrBlock)
 		sn.IsIPv6 = true
 	}
-	out, err := s.EC2Client.CreateSubnetWithContext(context.TODO(), input)
+	if sn.IPv6Native {
+		input.Ipv6Native = aws.Bool(true)
+	}
+	out, err := s.EC2Client.CreateSubnetWithContext(ctx, input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
 		return nil, errors.Wrap(err, "failed to create subnet")
@@ -505,7 +528,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	s.scope.Info("Created subnet", "id", *out.Subnet.SubnetId, "public", sn.IsPublic, "az", sn.AvailabilityZone, "cidr", sn.CidrBlock, "ipv6", sn.IsIPv6, "ipv6-cidr", sn.IPv6CidrBlock)
 
 	wReq := &ec2.DescribeSubnetsInput{SubnetIds: []*string{out.Subnet.SubnetId}}
-	if err := s.EC2Client.WaitUntilSubnetAvailableWithContext(context.TODO(), wReq); err != nil {
+	if err := s.EC2Client.WaitUntilSubnetAvailableWithContext(ctx, wReq); err != nil {
 		return nil, errors.Wrapf(err, "failed to wait for subnet %q", *out.Subnet.SubnetId)
 	}
 
@@ -515,7 +538,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		// regardless of the subnet being public or not, ipv6 address needs to be assigned
 		// on creation. There is no such thing as private ipv6 address.
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(ctx, &ec2.ModifySubnetAttributeInput{
 				SubnetId: out.Subnet.SubnetId,
 				AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
 					Value: aws.Bool(true),
@@ -531,14 +554,33 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
 	}
 
+	if sn.EnableDNS64 {
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(ctx, &ec2.ModifySubnetAttributeInput{
+				SubnetId: out.Subnet.SubnetId,
+				EnableDns64: &ec2.AttributeBooleanValue{
+					Value: aws.Bool(true),
+				},
+			}); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.SubnetNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", *out.Subnet.SubnetId, err)
+			return nil, errors.Wrapf(err, "failed to set subnet %q attribute enable DNS64", *out.Subnet.SubnetId)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
+	}
+
 	// AWS Wavelength Zone's public subnets does not support to map Carrier IP address on launch, and
 	// MapPublicIpOnLaunch option[1] set to the subnet will fail, instead set the EC2 instance's network
 	// interface to associate Carrier IP Address on launch[2].
 	// [1] https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ModifySubnetAttribute.html
 	// [2] https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_InstanceNetworkInterfaceSpecification.html
-	if sn.IsPublic && !sn.IsEdgeWavelength() {
+	// IPv6-native subnets have no IPv4 address to map.
+	if sn.IsPublic && !sn.IsEdgeWavelength() && !sn.IPv6Native {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(ctx, &ec2.ModifySubnetAttributeInput{
 				SubnetId: out.Subnet.SubnetId,
 				MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
 					Value: aws.Bool(true),
@@ -554,9 +596,10 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", *out.Subnet.SubnetId)
 	}
 
-	if s.scope.VPC().PrivateDNSHostnameTypeOnLaunch != nil {
+	// The instances of IPv6-native subnets can only have resource-name hostnames, which is the subnet default.
+	if s.scope.VPC().PrivateDNSHostnameTypeOnLaunch != nil && !sn.IPv6Native {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+			if _, err := s.EC2Client.ModifySubnetAttributeWithContext(ctx, &ec2.ModifySubnetAttributeInput{
 				SubnetId:                       out.Subnet.SubnetId,
 				PrivateDnsHostnameTypeOnLaunch: s.scope.VPC().PrivateDNSHostnameTypeOnLaunch,
 			}); err != nil {
@@ -575,8 +618,10 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		ID:               sn.ID,
 		ResourceID:       *out.Subnet.SubnetId,
 		AvailabilityZone: *out.Subnet.AvailabilityZone,
-		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
+		CidrBlock:        aws.StringValue(out.Subnet.CidrBlock),
 		IsPublic:         sn.IsPublic,
+		IPv6Native:       aws.BoolValue(out.Subnet.Ipv6Native),
+		EnableDNS64:      sn.EnableDNS64,
 		Tags:             sn.Tags,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
@@ -589,15 +634,15 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	s.scope.Debug("Created new subnet in VPC with cidr and availability zone ",
 		"subnet-id", *out.Subnet.SubnetId,
 		"vpc-id", *out.Subnet.VpcId,
-		"cidr-block", *out.Subnet.CidrBlock,
+		"cidr-block", subnet.CidrBlock,
 		"ipv6-cidr-block", subnet.IPv6CidrBlock,
 		"availability-zone", *out.Subnet.AvailabilityZone)
 
 	return subnet, nil
 }
 
-func (s *Service) deleteSubnet(id string) error {
-	_, err := s.EC2Client.DeleteSubnetWithContext(context.TODO(), &ec2.DeleteSubnetInput{
+func (s *Service) deleteSubnet(ctx context.Context, id string) error {
+	_, err := s.EC2Client.DeleteSubnetWithContext(ctx, &ec2.DeleteSubnetInput{
 		SubnetId: aws.String(id),
 	})
 	if err != nil {
@@ -1895,6 +1895,186 @@ func TestReconcileSubnets(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name: "Managed IPv6 VPC, no existing subnets exist, IPv6-native private subnet with DNS64 in spec, expect subnet created without IPv4 CIDR block",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					CidrBlock: defaultVPCCidr,
+					IPv6: &infrav1.IPv6{
+						CidrBlock: "2001:db8:1234:1a01::/56",
+						PoolID:    "amazon",
+					},
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						AvailabilityZone: "us-east-1c",
+						CidrBlock:        "10.0.0.0/17",
+						IPv6CidrBlock:    "2001:db8:1234:1a03::/64",
+						IsPublic:         true,
+					},
+					{
+						AvailabilityZone: "us-east-1c",
+						IPv6CidrBlock:    "2001:db8:1234:1a02::/64",
+						IPv6Native:       true,
+						EnableDNS64:      true,
+					},
+				},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeCall := m.DescribeSubnetsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1c"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil).AnyTimes()
+
+				publicSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.0.0.0/17"),
+					AvailabilityZone: aws.String("us-east-1c"),
+					Ipv6CidrBlock:    aws.String("2001:db8:1234:1a03::/64"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-public-us-east-1c"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("shared"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("public"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:            aws.String(subnetsVPCID),
+							SubnetId:         aws.String("subnet-1"),
+							CidrBlock:        aws.String("10.0.0.0/17"),
+							AvailabilityZone: aws.String("us-east-1c"),
+						},
+					}, nil).
+					After(describeCall)
+
+				privateSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					AvailabilityZone: aws.String("us-east-1c"),
+					Ipv6CidrBlock:    aws.String("2001:db8:1234:1a02::/64"),
+					Ipv6Native:       aws.Bool(true),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1c"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("shared"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/internal-elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("private"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:      aws.String(subnetsVPCID),
+							SubnetId:   aws.String("subnet-2"),
+							Ipv6Native: aws.Bool(true),
+							Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+								{
+									AssociationId: aws.String("amazon"),
+									Ipv6CidrBlock: aws.String("2001:db8:1234:1a02::/64"),
+									Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+										State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+									},
+								},
+							},
+							AvailabilityZone: aws.String("us-east-1c"),
+						},
+					}, nil).
+					After(publicSubnet)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).Times(2)
+
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-1"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-1"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-2"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+					After(privateSubnet)
+
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					EnableDns64: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-2"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+					After(privateSubnet)
+			},
+		},
 		{
 			name: "Managed VPC, no existing subnets exist, two az's, expect two private and two public from default",
 			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
@@ -3088,7 +3268,7 @@ func TestReconcileSubnets(t *testing.T) {
 
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
-			err = s.reconcileSubnets()
+			err = s.reconcileSubnets(context.TODO())
 
 			if tc.errorExpected && err == nil {
 				t.Fatal("expected error reconciling but not no error")
@@ -3298,6 +3478,7 @@ func TestDiscoverSubnets(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -3311,7 +3492,7 @@ func TestDiscoverSubnets(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileSubnets(); err != nil {
+			if err := s.reconcileSubnets(context.TODO()); err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 
@@ -3437,6 +3618,7 @@ func TestDeleteSubnets(t *testing.T) {
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: *tc.input,
 					},
 				},
@@ -3450,7 +3632,7 @@ func TestDeleteSubnets(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteSubnets()
+			err = s.deleteSubnets(context.TODO())
 			if tc.errorExpected && err == nil {
 				t.Fatal("expected error but not no error")
 			}
@@ -3496,22 +3678,20 @@ func (b *ClusterScopeBuilder) WithTagUnmanagedNetworkResources(value bool) *Clus
 func (b *ClusterScopeBuilder) Build() (scope.NetworkScope, error) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
-
 	param := &scope.ClusterScopeParams{
-		Client: client,
 		Cluster: &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
 		},
 		AWSCluster: &infrav1.AWSCluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test"},
-			Spec:       infrav1.AWSClusterSpec{},
+			Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 		},
 	}
 
 	for _, customizer := range b.customizers {
 		customizer(param)
 	}
+	param.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(param.AWSCluster).WithStatusSubresource(param.AWSCluster).Build()
 
 	return scope.NewClusterScope(*param)
 }
@@ -3546,22 +3726,20 @@ func (b *ManagedControlPlaneScopeBuilder) Build() (scope.NetworkScope, error) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
 	_ = ekscontrolplanev1.AddToScheme(scheme)
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
-
 	param := &scope.ManagedControlPlaneScopeParams{
-		Client: client,
 		Cluster: &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
 		},
 		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
 			ObjectMeta: metav1.ObjectMeta{Name: "test"},
-			Spec:       ekscontrolplanev1.AWSManagedControlPlaneSpec{},
+			Spec:       ekscontrolplanev1.AWSManagedControlPlaneSpec{Region: "us-east-1"},
 		},
 	}
 
 	for _, customizer := range b.customizers {
 		customizer(param)
 	}
+	param.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(param.ControlPlane).WithStatusSubresource(param.ControlPlane).Build()
 
 	return scope.NewManagedControlPlaneScope(*param)
 }
@@ -3772,7 +3950,7 @@ func TestService_retrieveZoneInfo(t *testing.T) {
 				},
 				AWSCluster: &infrav1.AWSCluster{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
-					Spec:       infrav1.AWSClusterSpec{},
+					Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
 				},
 			})
 			g.Expect(err).NotTo(HaveOccurred())
@@ -3783,7 +3961,7 @@ func TestService_retrieveZoneInfo(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			got, err := s.retrieveZoneInfo(tc.inputZoneNames)
+			got, err := s.retrieveZoneInfo(context.TODO(), tc.inputZoneNames)
 			if err != nil {
 				if tc.wantErrMessage != err.Error() {
 					t.Errorf("Service.retrieveZoneInfo() error != wanted, got: '%v', want: '%v'", err, tc.wantErrMessage)
@@ -44,12 +44,12 @@ const (
 	defaultIpamV6NetmaskLength = 56
 )
 
-func (s *Service) reconcileVPC() error {
+func (s *Service) reconcileVPC(ctx context.Context) error {
 	s.scope.Debug("Reconciling VPC")
 
 	// If the ID is not nil, VPC is either managed or unmanaged but should exist in the AWS.
 	if s.scope.VPC().ID != "" {
-		vpc, err := s.describeVPCByID()
+		vpc, err := s.describeVPCByID(ctx)
 		if err != nil {
 			return errors.Wrap(err, ".spec.vpc.id is set but VPC resource is missing in AWS; failed to describe VPC resources. (might be in creation process)")
 		}
@@ -81,7 +81,7 @@ func (s *Service) reconcileVPC() error {
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 			buildParams := s.getVPCTagParams(s.scope.VPC().ID)
 			tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-			if err := tagsBuilder.Ensure(s.scope.VPC().Tags); err != nil {
+			if err := tagsBuilder.Ensure(ctx, s.scope.VPC().Tags); err != nil {
 				return false, err
 			}
 			return true, nil
@@ -92,7 +92,7 @@ func (s *Service) reconcileVPC() error {
 
 		// if the VPC is managed, make managed sure attributes are configured.
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if err := s.ensureManagedVPCAttributes(vpc); err != nil {
+			if err := s.ensureManagedVPCAttributes(ctx, vpc); err != nil {
 				return false, err
 			}
 			return true, nil
@@ -106,7 +106,7 @@ func (s *Service) reconcileVPC() error {
 	// .spec.vpc.id is nil. This means no managed VPC exists or we failed to save its ID before. Check if a managed VPC
 	// with the desired name exists, or if not, create a new managed VPC.
 
-	vpc, err := s.describeVPCByName()
+	vpc, err := s.describeVPCByName(ctx)
 	if err == nil {
 		// An VPC already exists with the desired name
 
@@ -123,7 +123,7 @@ func (s *Service) reconcileVPC() error {
 		}
 
 		// VPC with that name does not exist yet. Create it.
-		vpc, err = s.createVPC()
+		vpc, err = s.createVPC(ctx)
 		if err != nil {
 			return errors.Wrap(err, "failed to create new managed VPC")
 		}
@@ -144,7 +144,7 @@ func (s *Service) reconcileVPC() error {
 
 	// Make sure attributes are configured
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-		if err := s.ensureManagedVPCAttributes(vpc); err != nil {
+		if err := s.ensureManagedVPCAttributes(ctx, vpc); err != nil {
 			return false, err
 		}
 		return true, nil
@@ -155,7 +155,7 @@ func (s *Service) reconcileVPC() error {
 	return nil
 }
 
-func (s *Service) describeVPCEndpoints(filters ...*ec2.Filter) ([]*ec2.VpcEndpoint, error) {
+func (s *Service) describeVPCEndpoints(ctx context.Context, filters ...*ec2.Filter) ([]*ec2.VpcEndpoint, error) {
 	vpc := s.scope.VPC()
 	if vpc == nil || vpc.ID == "" {
 		return nil, errors.New("vpc is nil or vpc id is not set")
@@ -167,7 +167,7 @@ func (s *Service) describeVPCEndpoints(filters ...*ec2.Filter) ([]*ec2.VpcEndpoi
 		}),
 	}
 	endpoints := []*ec2.VpcEndpoint{}
-	if err := s.EC2Client.DescribeVpcEndpointsPages(input, func(dveo *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+	if err := s.EC2Client.DescribeVpcEndpointsPagesWithContext(ctx, input, func(dveo *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
 		endpoints = append(endpoints, dveo.VpcEndpoints...)
 		return true
 	}); err != nil {
@@ -179,7 +179,7 @@ func (s *Service) describeVPCEndpoints(filters ...*ec2.Filter) ([]*ec2.VpcEndpoi
 // reconcileVPCEndpoints registers the AWS endpoints for the services that need to be enabled
 // in the VPC routing tables. If the VPC is unmanaged, this is a no-op.
 // For more information, see: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-endpoints.html
-func (s *Service) reconcileVPCEndpoints() error {
+func (s *Service) reconcileVPCEndpoints(ctx context.Context) error {
 	// If the VPC is unmanaged or not yet populated, return early.
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
 		return nil
@@ -215,7 +215,7 @@ func (s *Service) reconcileVPCEndpoints() error {
 	}
 
 	// Get all existing endpoints.
-	endpoints, err := s.describeVPCEndpoints(filters...)
+	endpoints, err := s.describeVPCEndpoints(ctx, filters...)
 	if err != nil {
 		return errors.Wrap(err, "failed to describe vpc endpoints")
 	}
@@ -247,7 +247,7 @@ func (s *Service) reconcileVPCEndpoints() error {
 				if removals.Len() > 0 {
 					modify.RemoveRouteTableIds = aws.StringSlice(removals.UnsortedList())
 				}
-				if _, err := s.EC2Client.ModifyVpcEndpoint(modify); err != nil {
+				if _, err := s.EC2Client.ModifyVpcEndpointWithContext(ctx, modify); err != nil {
 					return errors.Wrapf(err, "failed to modify vpc endpoint for service %q", service)
 				}
 			}
@@ -255,7 +255,7 @@ func (s *Service) reconcileVPCEndpoints() error {
 		}
 
 		// Create the endpoint.
-		if _, err := s.EC2Client.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+		if _, err := s.EC2Client.CreateVpcEndpointWithContext(ctx, &ec2.CreateVpcEndpointInput{
 			VpcId:         aws.String(s.scope.VPC().ID),
 			ServiceName:   aws.String(service),
 			RouteTableIds: aws.StringSlice(routeTables.UnsortedList()),
@@ -270,7 +270,7 @@ func (s *Service) reconcileVPCEndpoints() error {
 	return nil
 }
 
-func (s *Service) deleteVPCEndpoints() error {
+func (s *Service) deleteVPCEndpoints(ctx context.Context) error {
 	// If the VPC is unmanaged or not yet populated, return early.
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
 		return nil
@@ -286,7 +286,7 @@ func (s *Service) deleteVPCEndpoints() error {
 	}
 
 	// Get all existing endpoints.
-	endpoints, err := s.describeVPCEndpoints()
+	endpoints, err := s.describeVPCEndpoints(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to describe vpc endpoints")
 	}
@@ -305,7 +305,7 @@ func (s *Service) deleteVPCEndpoints() error {
 	}
 
 	// Iterate over all services and delete endpoints.
-	if _, err := s.EC2Client.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+	if _, err := s.EC2Client.DeleteVpcEndpointsWithContext(ctx, &ec2.DeleteVpcEndpointsInput{
 		VpcEndpointIds: ids,
 	}); err != nil {
 		return errors.Wrapf(err, "failed to delete vpc endpoints %+v", ids)
@@ -313,7 +313,7 @@ func (s *Service) deleteVPCEndpoints() error {
 	return nil
 }
 
-func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
+func (s *Service) ensureManagedVPCAttributes(ctx context.Context, vpc *infrav1.VPCSpec) error {
 	var (
 		errs    []error
 		updated bool
@@ -324,7 +324,7 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 		VpcId:     aws.String(vpc.ID),
 		Attribute: aws.String("enableDnsHostnames"),
 	}
-	vpcAttr, err := s.EC2Client.DescribeVpcAttributeWithContext(context.TODO(), descAttrInput)
+	vpcAttr, err := s.EC2Client.DescribeVpcAttributeWithContext(ctx, descAttrInput)
 	if err != nil {
 		// If the returned error is a 'NotFound' error it should trigger retry
 		if code, ok := awserrors.Code(errors.Cause(err)); ok && code == awserrors.VPCNotFound {
@@ -336,7 +336,7 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 			VpcId:              aws.String(vpc.ID),
 			EnableDnsHostnames: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
 		}
-		if _, err := s.EC2Client.ModifyVpcAttributeWithContext(context.TODO(), attrInput); err != nil {
+		if _, err := s.EC2Client.ModifyVpcAttributeWithContext(ctx, attrInput); err != nil {
 			errs = append(errs, errors.Wrap(err, "failed to set enableDnsHostnames vpc attribute"))
 		} else {
 			updated = true
@@ -347,7 +347,7 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 		VpcId:     aws.String(vpc.ID),
 		Attribute: aws.String("enableDnsSupport"),
 	}
-	vpcAttr, err = s.EC2Client.DescribeVpcAttributeWithContext(context.TODO(), descAttrInput)
+	vpcAttr, err = s.EC2Client.DescribeVpcAttributeWithContext(ctx, descAttrInput)
 	if err != nil {
 		// If the returned error is a 'NotFound' error it should trigger retry
 		if code, ok := awserrors.Code(errors.Cause(err)); ok && code == awserrors.VPCNotFound {
@@ -359,7 +359,7 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 			VpcId:            aws.String(vpc.ID),
 			EnableDnsSupport: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
 		}
-		if _, err := s.EC2Client.ModifyVpcAttributeWithContext(context.TODO(), attrInput); err != nil {
+		if _, err := s.EC2Client.ModifyVpcAttributeWithContext(ctx, attrInput); err != nil {
 			errs = append(errs, errors.Wrap(err, "failed to set enableDnsSupport vpc attribute"))
 		} else {
 			updated = true
@@ -378,7 +378,7 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 	return nil
 }
 
-func (s *Service) getIPAMPoolID() (*string, error) {
+func (s *Service) getIPAMPoolID(ctx context.Context) (*string, error) {
 	input := &ec2.DescribeIpamPoolsInput{}
 
 	if s.scope.VPC().IPAMPool.ID != "" {
@@ -389,7 +389,7 @@ func (s *Service) getIPAMPoolID() (*string, error) {
 		input.Filters = append(input.Filters, filter.EC2.Name(s.scope.VPC().IPAMPool.Name))
 	}
 
-	output, err := s.EC2Client.DescribeIpamPools(input)
+	output, err := s.EC2Client.DescribeIpamPoolsWithContext(ctx, input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateVPC", "Failed to describe IPAM Pools: %v", err)
 		return nil, errors.Wrap(err, "failed to describe IPAM Pools")
@@ -407,7 +407,7 @@ func (s *Service) getIPAMPoolID() (*string, error) {
 	}
 }
 
-func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
+func (s *Service) createVPC(ctx context.Context) (*infrav1.VPCSpec, error) {
 	input := &ec2.CreateVpcInput{
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpc, s.getVPCTagParams(services.TemporaryResourceID)),
@@ -422,7 +422,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 			input.Ipv6Pool = aws.String(s.scope.VPC().IPv6.PoolID)
 			input.AmazonProvidedIpv6CidrBlock = aws.Bool(false)
 		case s.scope.VPC().IPv6.IPAMPool != nil:
-			ipamPoolID, err := s.getIPAMPoolID()
+			ipamPoolID, err := s.getIPAMPoolID(ctx)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get IPAM Pool ID")
 			}
@@ -440,7 +440,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 
 	// IPv4-specific configuration
 	if s.scope.VPC().IPAMPool != nil {
-		ipamPoolID, err := s.getIPAMPoolID()
+		ipamPoolID, err := s.getIPAMPoolID(ctx)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get IPAM Pool ID")
 		}
@@ -459,7 +459,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 		input.CidrBlock = &s.scope.VPC().CidrBlock
 	}
 
-	out, err := s.EC2Client.CreateVpcWithContext(context.TODO(), input)
+	out, err := s.EC2Client.CreateVpcWithContext(ctx, input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateVPC", "Failed to create new managed VPC: %v", err)
 		return nil, errors.Wrap(err, "failed to create vpc")
@@ -490,7 +490,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 	}
 
 	// We have to describe the VPC again because the `create` output will **NOT** contain the associated IPv6 address.
-	vpc, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+	vpc, err := s.EC2Client.DescribeVpcsWithContext(ctx, &ec2.DescribeVpcsInput{
 		VpcIds: aws.StringSlice([]string{aws.StringValue(out.Vpc.VpcId)}),
 	})
 	if err != nil {
@@ -518,7 +518,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 	return nil, fmt.Errorf("no IPv6 associated CIDR block sets found for IPv6 enabled cluster with vpc id %s", *out.Vpc.VpcId)
 }
 
-func (s *Service) deleteVPC() error {
+func (s *Service) deleteVPC(ctx context.Context) error {
 	vpc := s.scope.VPC()
 
 	if vpc.IsUnmanaged(s.scope.Name()) {
@@ -530,7 +530,7 @@ func (s *Service) deleteVPC() error {
 		VpcId: aws.String(vpc.ID),
 	}
 
-	if _, err := s.EC2Client.DeleteVpcWithContext(context.TODO(), input); err != nil {
+	if _, err := s.EC2Client.DeleteVpcWithContext(ctx, input); err != nil {
 		// Ignore if it's already deleted
 		if code, ok := awserrors.Code(err); ok && code == awserrors.VPCNotFound {
 			s.scope.Trace("Skipping VPC deletion, VPC not found")
@@ -552,7 +552,7 @@ func (s *Service) deleteVPC() error {
 	return nil
 }
 
-func (s *Service) describeVPCByID() (*infrav1.VPCSpec, error) {
+func (s *Service) describeVPCByID(ctx context.Context) (*infrav1.VPCSpec, error) {
 	if s.scope.VPC().ID == "" {
 		return nil, errors.New("VPC ID is not set, failed to describe VPCs by ID")
 	}
@@ -565,7 +565,7 @@ func (s *Service) describeVPCByID() (*infrav1.VPCSpec, error) {
 
 	input.VpcIds = []*string{aws.String(s.scope.VPC().ID)}
 
-	out, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeVpcsWithContext(ctx, input)
 	if err != nil {
 		if awserrors.IsNotFound(err) {
 			return nil, err
@@ -605,7 +605,7 @@ func (s *Service) describeVPCByID() (*infrav1.VPCSpec, error) {
 
 // describeVPCByName finds the VPC by `Name` tag. Use this if the ID is not available yet, either because no
 // VPC was created until now or if storing the ID could have failed.
-func (s *Service) describeVPCByName() (*infrav1.VPCSpec, error) {
+func (s *Service) describeVPCByName(ctx context.Context) (*infrav1.VPCSpec, error) {
 	vpcName := *s.getVPCTagParams(services.TemporaryResourceID).Name
 
 	input := &ec2.DescribeVpcsInput{
@@ -617,7 +617,7 @@ func (s *Service) describeVPCByName() (*infrav1.VPCSpec, error) {
 		},
 	}
 
-	out, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), input)
+	out, err := s.EC2Client.DescribeVpcsWithContext(ctx, input)
 	if (err != nil && awserrors.IsNotFound(err)) || (out != nil && len(out.Vpcs) == 0) {
 		return nil, awserrors.NewNotFound(fmt.Sprintf("could not find VPC by name %q", vpcName))
 	}
@@ -568,7 +568,7 @@ func TestReconcileVPC(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			err = s.reconcileVPC()
+			err = s.reconcileVPC(context.TODO())
 			if tc.wantErrContaining != nil {
 				g.Expect(err).ToNot(BeNil())
 				g.Expect(err.Error()).To(ContainSubstring(*tc.wantErrContaining))
@@ -651,7 +651,7 @@ func TestDeleteVPC(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			err = s.deleteVPC()
+			err = s.deleteVPC(context.TODO())
 			if tc.wantErr {
 				g.Expect(err).ToNot(BeNil())
 				return
@@ -668,6 +668,7 @@ func getClusterScope(vpcSpec *infrav1.VPCSpec, additionalTags map[string]string)
 	awsCluster := &infrav1.AWSCluster{
 		ObjectMeta: metav1.ObjectMeta{Name: "test"},
 		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
 			NetworkSpec: infrav1.NetworkSpec{
 				VPC: *vpcSpec,
 			},
@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	tagConverter "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// KeyPrefix is the prefix of the S3 objects holding the OIDC discovery documents.
+	KeyPrefix = "oidc"
+
+	discoveryDocumentPath = ".well-known/openid-configuration"
+	jwksPath              = "openid/v1/jwks"
+)
+
+var whitespaceRe = regexp.MustCompile(`(?m)[\t\n]`)
+
+// ReconcileOIDCProvider publishes the OIDC discovery document and the signing keys of the service
+// account issuer of the cluster in its S3 bucket and associates an IAM OIDC identity provider with
+// the issuer. It returns false if the service account signing key of the cluster hasn't been
+// generated yet.
+func (s *Service) ReconcileOIDCProvider(ctx context.Context) (bool, error) {
+	if !s.scope.AssociateOIDCProvider() {
+		return true, nil
+	}
+
+	if s.scope.Bucket() == nil {
+		return false, errors.New("'associateOIDCProvider' requires the S3 bucket of the cluster to be configured")
+	}
+
+	s.scope.Debug("Reconciling OIDC provider")
+
+	status := s.scope.OIDCProvider()
+	status.IssuerURL = s.issuerURL()
+
+	publicKey, err := s.serviceAccountPublicKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	if publicKey == nil {
+		s.scope.Info("Waiting for the service account signing key of the cluster")
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.OIDCProviderReadyCondition, infrav1.WaitingForServiceAccountKeyReason, clusterv1.ConditionSeverityInfo, "")
+		return false, nil
+	}
+
+	if err := s.publishDiscoveryDocuments(status.IssuerURL, publicKey); err != nil {
+		return false, err
+	}
+
+	if status.ARN == "" {
+		providerARN, err := s.FindAndVerifyOIDCProvider(status.IssuerURL)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to reconcile OIDC provider")
+		}
+		if providerARN == "" {
+			providerARN, err = s.CreateOIDCProvider(status.IssuerURL)
+			if err != nil {
+				return false, errors.Wrap(err, "failed to create OIDC provider")
+			}
+			record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateOIDCProvider", "Created OIDC provider %s", providerARN)
+		}
+		status.ARN = providerARN
+
+		// tagging the OIDC provider with the same tags as the other resources of the cluster
+		if _, err := s.IAMClient.TagOpenIDConnectProvider(&iam.TagOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+			Tags:                     tagConverter.MapToIAMTags(s.providerTags()),
+		}); err != nil {
+			return false, errors.Wrap(err, "failed to tag OIDC provider")
+		}
+	}
+
+	policy, err := converters.IAMPolicyDocumentToJSON(eksiam.OIDCTrustPolicy(status.ARN))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse IAM policy")
+	}
+	status.TrustPolicy = whitespaceRe.ReplaceAllString(policy, "")
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.OIDCProviderReadyCondition)
+	return true, nil
+}
+
+// DeleteOIDCResources deletes the IAM OIDC identity provider and the discovery documents of the cluster.
+func (s *Service) DeleteOIDCResources() error {
+	status := s.scope.OIDCProvider()
+	if status.ARN != "" {
+		if err := s.DeleteOIDCProvider(aws.String(status.ARN)); err != nil {
+			if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != iam.ErrCodeNoSuchEntityException {
+				return errors.Wrap(err, "failed to delete OIDC provider")
+			}
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteOIDCProvider", "Deleted OIDC provider %s", status.ARN)
+		status.ARN = ""
+	}
+
+	if !s.scope.AssociateOIDCProvider() || s.scope.Bucket() == nil {
+		return nil
+	}
+
+	for _, key := range []string{s.objectKey(discoveryDocumentPath), s.objectKey(jwksPath)} {
+		if _, err := s.S3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(s.scope.Bucket().Name),
+			Key:    aws.String(key),
+		}); err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchBucket {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to delete object %q", key)
+		}
+	}
+
+	return nil
+}
+
+// serviceAccountPublicKey returns the public key of the service account signing key pair of the
+// cluster, or nil if it hasn't been generated yet.
+func (s *Service) serviceAccountPublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	saSecret, err := secret.GetFromNamespacedName(ctx, s.client, client.ObjectKey{
+		Namespace: s.scope.Namespace(),
+		Name:      s.scope.Name(),
+	}, secret.ServiceAccount)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get service account secret")
+	}
+
+	return parsePublicKey(saSecret.Data[secret.TLSCrtDataName])
+}
+
+func parsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode service account public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse service account public key")
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported service account public key type %T", key)
+	}
+
+	return rsaKey, nil
+}
+
+// publishDiscoveryDocuments uploads the OIDC discovery document and the JSON web key set of the
+// service account issuer to the S3 bucket of the cluster.
+func (s *Service) publishDiscoveryDocuments(issuerURL string, publicKey *rsa.PublicKey) error {
+	discovery, err := discoveryDocument(issuerURL)
+	if err != nil {
+		return err
+	}
+
+	jwks, err := keySet(publicKey)
+	if err != nil {
+		return err
+	}
+
+	for key, data := range map[string][]byte{
+		s.objectKey(discoveryDocumentPath): discovery,
+		s.objectKey(jwksPath):              jwks,
+	} {
+		// The documents are read anonymously, so they must not be encrypted with a KMS key,
+		// even when it is the default encryption key of the bucket.
+		if _, err := s.S3Client.PutObject(&s3.PutObjectInput{
+			Body:                 aws.ReadSeekCloser(bytes.NewReader(data)),
+			Bucket:               aws.String(s.scope.Bucket().Name),
+			Key:                  aws.String(key),
+			ContentType:          aws.String("application/json"),
+			ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to put object %q", key)
+		}
+	}
+
+	return nil
+}
+
+func discoveryDocument(issuerURL string) ([]byte, error) {
+	document := struct {
+		Issuer                           string   `json:"issuer"`
+		JWKSURI                          string   `json:"jwks_uri"`
+		ResponseTypesSupported           []string `json:"response_types_supported"`
+		SubjectTypesSupported            []string `json:"subject_types_supported"`
+		IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	}{
+		Issuer:                           issuerURL,
+		JWKSURI:                          issuerURL + "/" + jwksPath,
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256"},
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal OIDC discovery document")
+	}
+	return data, nil
+}
+
+// keySet returns the JSON web key set holding the given public key. The key ID is computed the same
+// way kube-apiserver computes the kid header of the tokens it signs.
+func keySet(publicKey *rsa.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal service account public key")
+	}
+	sum := sha256.Sum256(der)
+
+	type jsonWebKey struct {
+		Use       string `json:"use"`
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Algorithm string `json:"alg"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+	}
+	keys := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{
+		Keys: []jsonWebKey{{
+			Use:       "sig",
+			KeyType:   "RSA",
+			KeyID:     base64.RawURLEncoding.EncodeToString(sum[:]),
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON web key set")
+	}
+	return data, nil
+}
+
+// issuerURL returns the URL of the service account issuer, which is the location of the
+// discovery documents in the S3 bucket of the cluster.
+func (s *Service) issuerURL() string {
+	dnsSuffix := "amazonaws.com"
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), s.scope.Region()); ok {
+		dnsSuffix = partition.DNSSuffix()
+	}
+
+	return fmt.Sprintf("https://%s.s3.%s.%s/%s", s.scope.Bucket().Name, s.scope.Region(), dnsSuffix, s.objectKey(""))
+}
+
+func (s *Service) objectKey(name string) string {
+	return path.Join(KeyPrefix, s.scope.Namespace(), s.scope.Name(), name)
+}
+
+func (s *Service) providerTags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Additional:  s.scope.AdditionalTags(),
+	})
+}
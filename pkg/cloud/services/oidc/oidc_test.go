@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3/mock_s3iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const testIssuerURL = "https://cluster-oidc.s3.eu-west-1.amazonaws.com/oidc/default/test-cluster"
+
+func TestReconcileOIDCProvider(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster-sa"},
+		Data: map[string][]byte{
+			"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		associate    bool
+		objects      []client.Object
+		expect       func(m *mock_s3iface.MockS3APIMockRecorder)
+		expectReady  bool
+		expectReason string
+	}{
+		{
+			name:        "disabled",
+			associate:   false,
+			expect:      func(m *mock_s3iface.MockS3APIMockRecorder) {},
+			expectReady: true,
+		},
+		{
+			name:         "waits for the service account key",
+			associate:    true,
+			expect:       func(m *mock_s3iface.MockS3APIMockRecorder) {},
+			expectReady:  false,
+			expectReason: infrav1.WaitingForServiceAccountKeyReason,
+		},
+		{
+			name:      "publishes the discovery documents",
+			associate: true,
+			objects:   []client.Object{saSecret},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.PutObject(gomock.Any()).DoAndReturn(func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+					g := NewWithT(t)
+					g.Expect(aws.StringValue(input.Bucket)).To(Equal("cluster-oidc"))
+					g.Expect(aws.StringValue(input.ServerSideEncryption)).To(Equal(s3.ServerSideEncryptionAes256))
+					g.Expect(aws.StringValue(input.Key)).To(BeElementOf(
+						"oidc/default/test-cluster/.well-known/openid-configuration",
+						"oidc/default/test-cluster/openid/v1/jwks",
+					))
+					return &s3.PutObjectOutput{}, nil
+				}).Times(2)
+			},
+			expectReady: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockCtrl)
+			tc.expect(s3Mock.EXPECT())
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: c,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						Region:                "eu-west-1",
+						S3Bucket:              &infrav1.S3Bucket{Name: "cluster-oidc"},
+						AssociateOIDCProvider: tc.associate,
+					},
+					Status: infrav1.AWSClusterStatus{
+						// A known provider ARN skips the lookup, which fetches the issuer URL.
+						OIDCProvider: infrav1.OIDCProviderStatus{
+							ARN: "arn:aws:iam::123456789012:oidc-provider/cluster-oidc.s3.eu-west-1.amazonaws.com/oidc/default/test-cluster",
+						},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(clusterScope, c)
+			s.S3Client = s3Mock
+			s.IAMClient = iamMock
+
+			ready, err := s.ReconcileOIDCProvider(context.TODO())
+			g.Expect(err).To(BeNil())
+			g.Expect(ready).To(Equal(tc.expectReady))
+			if !tc.associate {
+				g.Expect(conditions.Has(clusterScope.AWSCluster, infrav1.OIDCProviderReadyCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(clusterScope.OIDCProvider().IssuerURL).To(Equal(testIssuerURL))
+			g.Expect(conditions.IsTrue(clusterScope.AWSCluster, infrav1.OIDCProviderReadyCondition)).To(Equal(tc.expectReady))
+			if tc.expectReady {
+				g.Expect(clusterScope.OIDCProvider().TrustPolicy).To(ContainSubstring("sts:AssumeRoleWithWebIdentity"))
+			} else {
+				g.Expect(conditions.GetReason(clusterScope.AWSCluster, infrav1.OIDCProviderReadyCondition)).To(Equal(tc.expectReason))
+			}
+		})
+	}
+}
+
+func TestDiscoveryDocuments(t *testing.T) {
+	g := NewWithT(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).To(BeNil())
+
+	data, err := discoveryDocument(testIssuerURL)
+	g.Expect(err).To(BeNil())
+	discovery := map[string]interface{}{}
+	g.Expect(json.Unmarshal(data, &discovery)).To(Succeed())
+	g.Expect(discovery).To(HaveKeyWithValue("issuer", testIssuerURL))
+	g.Expect(discovery).To(HaveKeyWithValue("jwks_uri", testIssuerURL+"/openid/v1/jwks"))
+
+	data, err = keySet(&key.PublicKey)
+	g.Expect(err).To(BeNil())
+	jwks := struct {
+		Keys []map[string]string `json:"keys"`
+	}{}
+	g.Expect(json.Unmarshal(data, &jwks)).To(Succeed())
+	g.Expect(jwks.Keys).To(HaveLen(1))
+	g.Expect(jwks.Keys[0]).To(HaveKeyWithValue("alg", "RS256"))
+	g.Expect(jwks.Keys[0]["kid"]).NotTo(BeEmpty())
+
+	n, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["n"])
+	g.Expect(err).To(BeNil())
+	g.Expect(new(big.Int).SetBytes(n)).To(Equal(key.PublicKey.N))
+	e, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0]["e"])
+	g.Expect(err).To(BeNil())
+	g.Expect(int(new(big.Int).SetBytes(e).Int64())).To(Equal(key.PublicKey.E))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oidc provides a service to publish the service account issuer of self-managed clusters
+// in S3 and to associate an IAM OIDC identity provider with it.
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	iam.IAMService
+
+	scope    scope.OIDCScope
+	client   client.Client
+	S3Client s3iface.S3API
+}
+
+// NewService returns a new service given the api clients.
+func NewService(oidcScope scope.OIDCScope, client client.Client) *Service {
+	return &Service{
+		IAMService: iam.IAMService{
+			Wrapper:   oidcScope,
+			IAMClient: scope.NewIAMClient(oidcScope, oidcScope, oidcScope, oidcScope.InfraCluster()),
+			Client:    http.DefaultClient,
+		},
+		scope:    oidcScope,
+		client:   client,
+		S3Client: scope.NewS3Client(oidcScope, oidcScope, oidcScope, oidcScope.InfraCluster()),
+	}
+}
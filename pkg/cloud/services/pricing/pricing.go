@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+const (
+	// onDemandPriceTTL is how long the on-demand prices are cached, as they rarely change.
+	onDemandPriceTTL = 24 * time.Hour
+	// spotPriceTTL is how long the spot prices are cached.
+	spotPriceTTL = 15 * time.Minute
+
+	linuxSpotProductDescription = "Linux/UNIX"
+)
+
+// priceCache caches the prices of the instance types. It is shared by all the services, as the machines of
+// the clusters mostly use the same few instance types.
+var priceCache = cache.NewExpiring()
+
+// priceCacheKey identifies the on-demand price of an instance type in a region, or its spot price in an
+// availability zone of the region.
+type priceCacheKey struct {
+	region           string
+	availabilityZone string
+	instanceType     string
+}
+
+// Instance is an instance whose hourly cost is estimated.
+type Instance struct {
+	InstanceType     string
+	AvailabilityZone string
+	Spot             bool
+}
+
+// EstimateCost returns the estimated hourly cost of the instances, priced at the current spot price of their
+// availability zone for spot instances and at the on-demand price of their instance type otherwise.
+func (s *Service) EstimateCost(instances []Instance) (*infrav1.CostEstimate, error) {
+	var hourlyCost float64
+	prices := map[priceCacheKey]*infrav1.InstanceTypePrice{}
+	for _, instance := range instances {
+		key := priceCacheKey{availabilityZone: instance.AvailabilityZone, instanceType: instance.InstanceType}
+		price, ok := prices[key]
+		if !ok {
+			onDemandPrice, err := s.onDemandHourlyPrice(instance.InstanceType)
+			if err != nil {
+				return nil, err
+			}
+			spotPrice, err := s.spotHourlyPrice(instance.InstanceType, instance.AvailabilityZone)
+			if err != nil {
+				return nil, err
+			}
+			price = &infrav1.InstanceTypePrice{
+				InstanceType:     instance.InstanceType,
+				AvailabilityZone: instance.AvailabilityZone,
+			}
+			if onDemandPrice > 0 {
+				price.OnDemandHourlyPrice = formatPrice(onDemandPrice)
+			}
+			if spotPrice > 0 {
+				price.SpotHourlyPrice = formatPrice(spotPrice)
+			}
+			prices[key] = price
+		}
+
+		instancePrice := price.OnDemandHourlyPrice
+		if instance.Spot {
+			instancePrice = price.SpotHourlyPrice
+		}
+		if instancePrice == "" {
+			return nil, errors.Errorf("no price found for instance type %q in availability zone %q", instance.InstanceType, instance.AvailabilityZone)
+		}
+		cost, err := strconv.ParseFloat(instancePrice, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the price %q of instance type %q", instancePrice, instance.InstanceType)
+		}
+		hourlyCost += cost
+	}
+
+	estimate := &infrav1.CostEstimate{
+		Currency:   currency(s.scope.Region()),
+		HourlyCost: formatPrice(hourlyCost),
+	}
+	for _, price := range prices {
+		estimate.Prices = append(estimate.Prices, *price)
+	}
+	sort.Slice(estimate.Prices, func(i, j int) bool {
+		if estimate.Prices[i].InstanceType != estimate.Prices[j].InstanceType {
+			return estimate.Prices[i].InstanceType < estimate.Prices[j].InstanceType
+		}
+		return estimate.Prices[i].AvailabilityZone < estimate.Prices[j].AvailabilityZone
+	})
+	return estimate, nil
+}
+
+// UpdatedEstimate returns the estimate to set in the status of an object whose current estimate is previous. The
+// time the previous estimate was updated is kept when the cost and the prices didn't change, so that the status of
+// the object isn't updated on each reconciliation.
+func UpdatedEstimate(previous, estimate *infrav1.CostEstimate) *infrav1.CostEstimate {
+	if previous != nil && previous.LastUpdated != nil && previous.Currency == estimate.Currency && previous.HourlyCost == estimate.HourlyCost && reflect.DeepEqual(previous.Prices, estimate.Prices) {
+		estimate.LastUpdated = previous.LastUpdated
+		return estimate
+	}
+
+	now := metav1.Now()
+	estimate.LastUpdated = &now
+	return estimate
+}
+
+// onDemandHourlyPrice returns the hourly price of a Linux on-demand instance with shared tenancy of the instance
+// type in the region of the cluster, or zero when the Pricing API has no price for it.
+func (s *Service) onDemandHourlyPrice(instanceType string) (float64, error) {
+	key := priceCacheKey{region: s.scope.Region(), instanceType: instanceType}
+	if price, ok := priceCache.Get(key); ok {
+		return price.(float64), nil
+	}
+
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []*pricing.Filter{
+			termMatch("instanceType", instanceType),
+			termMatch("regionCode", s.scope.Region()),
+			termMatch("operatingSystem", "Linux"),
+			termMatch("tenancy", "Shared"),
+			termMatch("preInstalledSw", "NA"),
+			termMatch("capacitystatus", "Used"),
+		},
+		MaxResults: aws.Int64(1),
+	}
+	out, err := s.PricingClient.GetProductsWithContext(context.TODO(), input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the on-demand price of instance type %q", instanceType)
+	}
+
+	var price float64
+	if len(out.PriceList) > 0 {
+		price, err = onDemandPricePerHour(out.PriceList[0], currency(s.scope.Region()))
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to read the on-demand price of instance type %q", instanceType)
+		}
+	}
+	priceCache.Set(key, price, onDemandPriceTTL)
+	return price, nil
+}
+
+// spotHourlyPrice returns the current hourly spot price of a Linux instance of the instance type in the
+// availability zone, or zero when the instance type isn't available as a spot instance.
+func (s *Service) spotHourlyPrice(instanceType, availabilityZone string) (float64, error) {
+	key := priceCacheKey{region: s.scope.Region(), availabilityZone: availabilityZone, instanceType: instanceType}
+	if price, ok := priceCache.Get(key); ok {
+		return price.(float64), nil
+	}
+
+	// The spot price history starting now only holds the price currently in effect.
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		InstanceTypes:       aws.StringSlice([]string{instanceType}),
+		AvailabilityZone:    aws.String(availabilityZone),
+		ProductDescriptions: aws.StringSlice([]string{linuxSpotProductDescription}),
+		StartTime:           aws.Time(time.Now()),
+	}
+	out, err := s.EC2Client.DescribeSpotPriceHistoryWithContext(context.TODO(), input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the spot price of instance type %q in availability zone %q", instanceType, availabilityZone)
+	}
+
+	var price float64
+	var latest time.Time
+	for _, spotPrice := range out.SpotPriceHistory {
+		if spotPrice.Timestamp != nil && spotPrice.Timestamp.Before(latest) {
+			continue
+		}
+		price, err = strconv.ParseFloat(aws.StringValue(spotPrice.SpotPrice), 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse the spot price of instance type %q", instanceType)
+		}
+		latest = aws.TimeValue(spotPrice.Timestamp)
+	}
+	priceCache.Set(key, price, spotPriceTTL)
+	return price, nil
+}
+
+// onDemandPricePerHour reads the hourly on-demand price in the currency from a product of the Pricing API, whose
+// on-demand terms are formatted like:
+//
+//	{"terms": {"OnDemand": {"<sku>.<term>": {"priceDimensions": {"<sku>.<term>.<rate>": {"unit": "Hrs", "pricePerUnit": {"USD": "0.0960000000"}}}}}}}
+func onDemandPricePerHour(product aws.JSONValue, currency string) (float64, error) {
+	terms, _ := product["terms"].(map[string]interface{})
+	onDemandTerms, _ := terms["OnDemand"].(map[string]interface{})
+	for _, term := range onDemandTerms {
+		term, _ := term.(map[string]interface{})
+		dimensions, _ := term["priceDimensions"].(map[string]interface{})
+		for _, dimension := range dimensions {
+			dimension, _ := dimension.(map[string]interface{})
+			if unit, _ := dimension["unit"].(string); unit != "Hrs" {
+				continue
+			}
+			pricePerUnit, _ := dimension["pricePerUnit"].(map[string]interface{})
+			price, ok := pricePerUnit[currency].(string)
+			if !ok {
+				continue
+			}
+			return strconv.ParseFloat(price, 64)
+		}
+	}
+	return 0, errors.Errorf("no hourly price in %s", currency)
+}
+
+func termMatch(field, value string) *pricing.Filter {
+	return &pricing.Filter{
+		Type:  aws.String(pricing.FilterTypeTermMatch),
+		Field: aws.String(field),
+		Value: aws.String(value),
+	}
+}
+
+// currency returns the currency of the prices of the region.
+func currency(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return "CNY"
+	}
+	return "USD"
+}
+
+// formatPrice formats a price with at most 6 decimals, the precision of the spot prices.
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(math.Round(price*1e6)/1e6, 'f', -1, 64)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/pricing/mock_pricingiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestEstimateCost(t *testing.T) {
+	// onDemandPrices and spotPrices are the prices returned by the mocks, by instance type and by instance type
+	// and availability zone.
+	onDemandPrices := map[string]string{"m5.large": "0.0960000000", "c5.xlarge": "0.1700000000"}
+	spotPrices := map[string]string{"m5.large/us-east-1a": "0.036100", "m5.large/us-east-1b": "0.038200"}
+
+	tests := []struct {
+		name           string
+		instances      []Instance
+		expectErr      bool
+		expectEstimate *infrav1.CostEstimate
+	}{
+		{
+			name:      "an on-demand instance is priced at the on-demand price",
+			instances: []Instance{{InstanceType: "m5.large", AvailabilityZone: "us-east-1a"}},
+			expectEstimate: &infrav1.CostEstimate{
+				Currency:   "USD",
+				HourlyCost: "0.096",
+				Prices: []infrav1.InstanceTypePrice{
+					{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.096", SpotHourlyPrice: "0.0361"},
+				},
+			},
+		},
+		{
+			name: "spot instances are priced at the spot price of their availability zone",
+			instances: []Instance{
+				{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", Spot: true},
+				{InstanceType: "m5.large", AvailabilityZone: "us-east-1a"},
+				{InstanceType: "m5.large", AvailabilityZone: "us-east-1b", Spot: true},
+			},
+			expectEstimate: &infrav1.CostEstimate{
+				Currency:   "USD",
+				HourlyCost: "0.1703",
+				Prices: []infrav1.InstanceTypePrice{
+					{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.096", SpotHourlyPrice: "0.0361"},
+					{InstanceType: "m5.large", AvailabilityZone: "us-east-1b", OnDemandHourlyPrice: "0.096", SpotHourlyPrice: "0.0382"},
+				},
+			},
+		},
+		{
+			name:      "the spot price is optional for on-demand instances",
+			instances: []Instance{{InstanceType: "c5.xlarge", AvailabilityZone: "us-east-1a"}},
+			expectEstimate: &infrav1.CostEstimate{
+				Currency:   "USD",
+				HourlyCost: "0.17",
+				Prices: []infrav1.InstanceTypePrice{
+					{InstanceType: "c5.xlarge", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.17"},
+				},
+			},
+		},
+		{
+			name:      "a spot instance without spot price can't be priced",
+			instances: []Instance{{InstanceType: "c5.xlarge", AvailabilityZone: "us-east-1a", Spot: true}},
+			expectErr: true,
+		},
+		{
+			name:           "no instances cost nothing",
+			expectEstimate: &infrav1.CostEstimate{Currency: "USD", HourlyCost: "0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			pricingMock := mock_pricingiface.NewMockPricingAPI(mockCtrl)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			priceCache = cache.NewExpiring()
+
+			// each price is only fetched once, and then read from the cache.
+			pricingMock.EXPECT().GetProductsWithContext(gomock.Any(), gomock.Any()).DoAndReturn(func(_ aws.Context, input *pricing.GetProductsInput, _ ...request.Option) (*pricing.GetProductsOutput, error) {
+				out := &pricing.GetProductsOutput{}
+				for _, filter := range input.Filters {
+					if price, ok := onDemandPrices[aws.StringValue(filter.Value)]; ok && aws.StringValue(filter.Field) == "instanceType" {
+						out.PriceList = []aws.JSONValue{onDemandProduct(price)}
+					}
+				}
+				return out, nil
+			}).MaxTimes(len(onDemandPrices))
+			ec2Mock.EXPECT().DescribeSpotPriceHistoryWithContext(gomock.Any(), gomock.Any()).DoAndReturn(func(_ aws.Context, input *ec2.DescribeSpotPriceHistoryInput, _ ...request.Option) (*ec2.DescribeSpotPriceHistoryOutput, error) {
+				out := &ec2.DescribeSpotPriceHistoryOutput{}
+				if price, ok := spotPrices[aws.StringValue(input.InstanceTypes[0])+"/"+aws.StringValue(input.AvailabilityZone)]; ok {
+					out.SpotPriceHistory = []*ec2.SpotPrice{
+						{SpotPrice: aws.String(price), Timestamp: aws.Time(time.Now())},
+						{SpotPrice: aws.String("1.000000"), Timestamp: aws.Time(time.Now().Add(-time.Hour))},
+					}
+				}
+				return out, nil
+			}).MaxTimes(len(spotPrices) + 1)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:     client,
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			s := NewService(clusterScope)
+			s.PricingClient = pricingMock
+			s.EC2Client = ec2Mock
+
+			for i := 0; i < 2; i++ {
+				estimate, err := s.EstimateCost(tc.instances)
+				if tc.expectErr {
+					g.Expect(err).To(HaveOccurred())
+					return
+				}
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(estimate).To(Equal(tc.expectEstimate))
+			}
+		})
+	}
+}
+
+func TestUpdatedEstimate(t *testing.T) {
+	g := NewWithT(t)
+
+	lastUpdated := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	previous := &infrav1.CostEstimate{
+		Currency:    "USD",
+		HourlyCost:  "0.096",
+		Prices:      []infrav1.InstanceTypePrice{{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.096"}},
+		LastUpdated: &lastUpdated,
+	}
+
+	// the time of the previous estimate is kept when nothing changed.
+	estimate := UpdatedEstimate(previous, &infrav1.CostEstimate{
+		Currency:   "USD",
+		HourlyCost: "0.096",
+		Prices:     []infrav1.InstanceTypePrice{{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.096"}},
+	})
+	g.Expect(estimate.LastUpdated).To(Equal(&lastUpdated))
+
+	estimate = UpdatedEstimate(previous, &infrav1.CostEstimate{
+		Currency:   "USD",
+		HourlyCost: "0.0361",
+		Prices:     []infrav1.InstanceTypePrice{{InstanceType: "m5.large", AvailabilityZone: "us-east-1a", OnDemandHourlyPrice: "0.096", SpotHourlyPrice: "0.0361"}},
+	})
+	g.Expect(estimate.LastUpdated.After(lastUpdated.Time)).To(BeTrue())
+
+	estimate = UpdatedEstimate(nil, &infrav1.CostEstimate{Currency: "USD", HourlyCost: "0"})
+	g.Expect(estimate.LastUpdated).NotTo(BeNil())
+}
+
+func onDemandProduct(price string) aws.JSONValue {
+	return aws.JSONValue{
+		"terms": map[string]interface{}{
+			"OnDemand": map[string]interface{}{
+				"SKU.TERM": map[string]interface{}{
+					"priceDimensions": map[string]interface{}{
+						"SKU.TERM.RATE": map[string]interface{}{
+							"unit":         "Hrs",
+							"pricePerUnit": map[string]interface{}{"USD": price},
+						},
+					},
+				},
+			},
+		},
+	}
+}
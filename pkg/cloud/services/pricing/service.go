@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing provides a service to estimate the hourly cost of instances from the AWS Pricing API
+// and the spot price history.
+package pricing
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	scope         cloud.ClusterScoper
+	PricingClient pricingiface.PricingAPI
+	EC2Client     ec2iface.EC2API
+}
+
+// NewService returns a new service given the api clients.
+func NewService(clusterScope cloud.ClusterScoper) *Service {
+	return &Service{
+		scope:         clusterScope,
+		PricingClient: scope.NewPricingClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster(), endpointRegion(clusterScope.Region())),
+		EC2Client:     scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
+	}
+}
+
+// endpointRegion returns the region of the Pricing API endpoint serving the prices of the specified region, as the
+// Pricing API is only available in a few regions of each partition.
+func endpointRegion(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return "cn-northwest-1"
+	}
+	return "us-east-1"
+}
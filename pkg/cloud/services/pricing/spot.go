@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+const (
+	// spotPriceHistoryWindow is the period of the spot price history the recommendations are based on.
+	spotPriceHistoryWindow = 7 * 24 * time.Hour
+	// maxSpotRecommendations is the maximum number of recommendations returned.
+	maxSpotRecommendations = 10
+)
+
+// spotPool is a spot capacity pool, i.e. an instance type in an availability zone, with its spot price history.
+type spotPool struct {
+	instanceType     string
+	availabilityZone string
+	prices           []*ec2.SpotPrice
+}
+
+// RecommendSpotPlacements ranks the combinations of the instance types and the availability zones by their spot
+// placement score for the target capacity, and then by their average spot price over the last week. All the
+// availability zones of the region are considered when none are specified.
+func (s *Service) RecommendSpotPlacements(instanceTypes, availabilityZones []string, targetCapacity int64) ([]expinfrav1.SpotRecommendation, error) {
+	now := time.Now()
+	windowStart := now.Add(-spotPriceHistoryWindow)
+	zones := sets.New[string](availabilityZones...)
+
+	pools := map[string]*spotPool{}
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		InstanceTypes:       aws.StringSlice(instanceTypes),
+		ProductDescriptions: aws.StringSlice([]string{linuxSpotProductDescription}),
+		StartTime:           aws.Time(windowStart),
+		EndTime:             aws.Time(now),
+	}
+	err := s.EC2Client.DescribeSpotPriceHistoryPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSpotPriceHistoryOutput, _ bool) bool {
+		for _, price := range out.SpotPriceHistory {
+			zone := aws.StringValue(price.AvailabilityZone)
+			if zones.Len() > 0 && !zones.Has(zone) {
+				continue
+			}
+			key := aws.StringValue(price.InstanceType) + "/" + zone
+			if _, ok := pools[key]; !ok {
+				pools[key] = &spotPool{instanceType: aws.StringValue(price.InstanceType), availabilityZone: zone}
+			}
+			pools[key].prices = append(pools[key].prices, price)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the spot price history")
+	}
+
+	scores, err := s.spotPlacementScores(instanceTypes, targetCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]expinfrav1.SpotRecommendation, 0, len(pools))
+	averages := map[string]float64{}
+	for key, pool := range pools {
+		recommendation, average, err := pool.recommendation(windowStart, now)
+		if err != nil {
+			return nil, err
+		}
+		if score, ok := scores[key]; ok {
+			recommendation.PlacementScore = ptr.To(score)
+		}
+		averages[key] = average
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if scoreA, scoreB := ptr.Deref(a.PlacementScore, 0), ptr.Deref(b.PlacementScore, 0); scoreA != scoreB {
+			return scoreA > scoreB
+		}
+		if averageA, averageB := averages[a.InstanceType+"/"+a.AvailabilityZone], averages[b.InstanceType+"/"+b.AvailabilityZone]; averageA != averageB {
+			return averageA < averageB
+		}
+		if a.InstanceType != b.InstanceType {
+			return a.InstanceType < b.InstanceType
+		}
+		return a.AvailabilityZone < b.AvailabilityZone
+	})
+	if len(recommendations) > maxSpotRecommendations {
+		recommendations = recommendations[:maxSpotRecommendations]
+	}
+	return recommendations, nil
+}
+
+// spotPlacementScores returns the spot placement scores of the availability zones of the region for each instance
+// type, by instance type and availability zone name.
+func (s *Service) spotPlacementScores(instanceTypes []string, targetCapacity int64) (map[string]int32, error) {
+	if targetCapacity < 1 {
+		targetCapacity = 1
+	}
+
+	// The placement scores identify the availability zones by their ID, which maps to a different name in each
+	// account.
+	zonesOut, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe the availability zones")
+	}
+	zoneNames := map[string]string{}
+	for _, zone := range zonesOut.AvailabilityZones {
+		zoneNames[aws.StringValue(zone.ZoneId)] = aws.StringValue(zone.ZoneName)
+	}
+
+	scores := map[string]int32{}
+	for _, instanceType := range instanceTypes {
+		out, err := s.EC2Client.GetSpotPlacementScoresWithContext(context.TODO(), &ec2.GetSpotPlacementScoresInput{
+			InstanceTypes:          aws.StringSlice([]string{instanceType}),
+			RegionNames:            aws.StringSlice([]string{s.scope.Region()}),
+			SingleAvailabilityZone: aws.Bool(true),
+			TargetCapacity:         aws.Int64(targetCapacity),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the spot placement scores of instance type %q", instanceType)
+		}
+		for _, score := range out.SpotPlacementScores {
+			if zoneName, ok := zoneNames[aws.StringValue(score.AvailabilityZoneId)]; ok {
+				scores[instanceType+"/"+zoneName] = int32(aws.Int64Value(score.Score))
+			}
+		}
+	}
+	return scores, nil
+}
+
+// recommendation summarizes the spot price history of the pool between the start of the window and now, and
+// returns its time-weighted average price. The history may start with the price in effect before the window,
+// which is counted from the start of the window.
+func (p *spotPool) recommendation(windowStart, now time.Time) (expinfrav1.SpotRecommendation, float64, error) {
+	sort.Slice(p.prices, func(i, j int) bool {
+		return aws.TimeValue(p.prices[i].Timestamp).Before(aws.TimeValue(p.prices[j].Timestamp))
+	})
+
+	recommendation := expinfrav1.SpotRecommendation{
+		InstanceType:     p.instanceType,
+		AvailabilityZone: p.availabilityZone,
+	}
+	var weightedSum, maxPrice, currentPrice float64
+	var duration time.Duration
+	for i, spotPrice := range p.prices {
+		price, err := strconv.ParseFloat(aws.StringValue(spotPrice.SpotPrice), 64)
+		if err != nil {
+			return recommendation, 0, errors.Wrapf(err, "failed to parse the spot price of instance type %q", p.instanceType)
+		}
+		if i > 0 && price != currentPrice {
+			recommendation.PriceChanges++
+		}
+
+		start := aws.TimeValue(spotPrice.Timestamp)
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+		end := now
+		if i < len(p.prices)-1 {
+			end = aws.TimeValue(p.prices[i+1].Timestamp)
+		}
+		if end.After(start) {
+			weightedSum += price * end.Sub(start).Hours()
+			duration += end.Sub(start)
+		}
+		if price > maxPrice {
+			maxPrice = price
+		}
+		currentPrice = price
+	}
+
+	average := currentPrice
+	if duration > 0 {
+		average = weightedSum / duration.Hours()
+	}
+	recommendation.CurrentPrice = formatPrice(currentPrice)
+	recommendation.AveragePrice = formatPrice(average)
+	recommendation.MaxPrice = formatPrice(maxPrice)
+	return recommendation, average, nil
+}
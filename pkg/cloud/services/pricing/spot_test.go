@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestRecommendSpotPlacements(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	now := time.Now()
+	spotPrice := func(instanceType, availabilityZone, price string, age time.Duration) *ec2.SpotPrice {
+		return &ec2.SpotPrice{
+			InstanceType:     aws.String(instanceType),
+			AvailabilityZone: aws.String(availabilityZone),
+			SpotPrice:        aws.String(price),
+			Timestamp:        aws.Time(now.Add(-age)),
+		}
+	}
+	day := 24 * time.Hour
+
+	ec2Mock.EXPECT().DescribeSpotPriceHistoryPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ aws.Context, input *ec2.DescribeSpotPriceHistoryInput, fn func(*ec2.DescribeSpotPriceHistoryOutput, bool) bool, _ ...request.Option) error {
+		g.Expect(aws.StringValueSlice(input.InstanceTypes)).To(Equal([]string{"c5.large", "m5.large"}))
+		g.Expect(aws.TimeValue(input.EndTime).Sub(aws.TimeValue(input.StartTime))).To(Equal(7 * day))
+		fn(&ec2.DescribeSpotPriceHistoryOutput{SpotPriceHistory: []*ec2.SpotPrice{
+			// the price in effect at the start of the window is only counted from the start of the window.
+			spotPrice("m5.large", "us-east-1a", "0.050000", day),
+			spotPrice("m5.large", "us-east-1a", "0.040000", 7*day+time.Hour),
+			spotPrice("m5.large", "us-east-1b", "0.030000", 3*day),
+		}}, false)
+		fn(&ec2.DescribeSpotPriceHistoryOutput{SpotPriceHistory: []*ec2.SpotPrice{
+			spotPrice("c5.large", "us-east-1a", "0.020000", 12*time.Hour),
+			spotPrice("c5.large", "us-east-1a", "0.025000", day),
+			spotPrice("c5.large", "us-east-1a", "0.020000", 2*day),
+			// the availability zones the pool has no subnets in are ignored.
+			spotPrice("c5.large", "us-east-1c", "0.010000", day),
+		}}, true)
+		return nil
+	})
+	ec2Mock.EXPECT().DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneId: aws.String("use1-az1"), ZoneName: aws.String("us-east-1a")},
+			{ZoneId: aws.String("use1-az2"), ZoneName: aws.String("us-east-1b")},
+		},
+	}, nil)
+	ec2Mock.EXPECT().GetSpotPlacementScoresWithContext(gomock.Any(), &ec2.GetSpotPlacementScoresInput{
+		InstanceTypes:          aws.StringSlice([]string{"c5.large"}),
+		RegionNames:            aws.StringSlice([]string{"us-east-1"}),
+		SingleAvailabilityZone: aws.Bool(true),
+		TargetCapacity:         aws.Int64(3),
+	}).Return(&ec2.GetSpotPlacementScoresOutput{SpotPlacementScores: []*ec2.SpotPlacementScore{
+		{AvailabilityZoneId: aws.String("use1-az1"), Score: aws.Int64(3)},
+	}}, nil)
+	ec2Mock.EXPECT().GetSpotPlacementScoresWithContext(gomock.Any(), &ec2.GetSpotPlacementScoresInput{
+		InstanceTypes:          aws.StringSlice([]string{"m5.large"}),
+		RegionNames:            aws.StringSlice([]string{"us-east-1"}),
+		SingleAvailabilityZone: aws.Bool(true),
+		TargetCapacity:         aws.Int64(3),
+	}).Return(&ec2.GetSpotPlacementScoresOutput{SpotPlacementScores: []*ec2.SpotPlacementScore{
+		{AvailabilityZoneId: aws.String("use1-az1"), Score: aws.Int64(9)},
+		{AvailabilityZoneId: aws.String("use1-az2"), Score: aws.Int64(9)},
+	}}, nil)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"}},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+
+	recommendations, err := s.RecommendSpotPlacements([]string{"c5.large", "m5.large"}, []string{"us-east-1a", "us-east-1b"}, 3)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recommendations).To(Equal([]expinfrav1.SpotRecommendation{
+		{
+			InstanceType:     "m5.large",
+			AvailabilityZone: "us-east-1b",
+			CurrentPrice:     "0.03",
+			AveragePrice:     "0.03",
+			MaxPrice:         "0.03",
+			PlacementScore:   ptr.To[int32](9),
+		},
+		{
+			InstanceType:     "m5.large",
+			AvailabilityZone: "us-east-1a",
+			CurrentPrice:     "0.05",
+			AveragePrice:     "0.041429",
+			MaxPrice:         "0.05",
+			PriceChanges:     1,
+			PlacementScore:   ptr.To[int32](9),
+		},
+		{
+			InstanceType:     "c5.large",
+			AvailabilityZone: "us-east-1a",
+			CurrentPrice:     "0.02",
+			AveragePrice:     "0.02125",
+			MaxPrice:         "0.025",
+			PriceChanges:     2,
+			PlacementScore:   ptr.To[int32](3),
+		},
+	}))
+}
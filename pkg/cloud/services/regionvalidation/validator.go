@@ -0,0 +1,445 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package regionvalidation provides the admission time validation of the AWS resources
+// referenced by the specs against the region of their cluster.
+package regionvalidation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// DefaultCacheTTL is the default duration for which the availability zones, instance type
+// offerings and AMIs of a region are cached.
+const DefaultCacheTTL = 10 * time.Minute
+
+// timeout bounds the AWS calls of a validation, as they are made while the admission request is pending.
+const timeout = 5 * time.Second
+
+var log = ctrl.Log.WithName("region-validation")
+
+// Validator validates the AWS resources referenced by the AWSClusters, the AWSMachines and the AWSMachinePools
+// against their region, using the credentials of the controllers. The AMIs are only checked for the clusters
+// using these credentials, as the AMIs shared with other accounts can't be seen.
+type Validator struct {
+	client       client.Client
+	ttl          time.Duration
+	cache        *cache.Expiring
+	newEC2Client func(region string) (ec2iface.EC2API, error)
+}
+
+type cacheKey struct {
+	region    string
+	operation string
+	id        string
+}
+
+// NewValidator returns a validator caching the resources of a region for the given TTL.
+func NewValidator(c client.Client, endpoints []scope.ServiceEndpoint, ttl time.Duration) *Validator {
+	return &Validator{
+		client: c,
+		ttl:    ttl,
+		cache:  cache.NewExpiring(),
+		newEC2Client: func(region string) (ec2iface.EC2API, error) {
+			globalScope, err := scope.NewGlobalScope(scope.GlobalScopeParams{
+				ControllerName: "region-validation",
+				Region:         region,
+				Endpoints:      endpoints,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return scope.NewGlobalEC2Client(globalScope, globalScope), nil
+		},
+	}
+}
+
+// ValidateAWSCluster validates the availability zones of the subnets, and the instance type and AMI
+// of the bastion, against the region of an AWSCluster.
+func (v *Validator) ValidateAWSCluster(ctx context.Context, cluster *infrav1.AWSCluster) (admission.Warnings, field.ErrorList) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	region, err := scope.ResolveAWSClusterRegion(ctx, v.client, cluster)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validation against the region of the cluster: %v", err)}, nil
+	}
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	subnetsPath := field.NewPath("spec", "network", "subnets")
+	for i, subnet := range cluster.Spec.NetworkSpec.Subnets {
+		if subnet.AvailabilityZone == "" {
+			continue
+		}
+
+		zones, err := v.availabilityZones(ctx, region)
+		if err != nil {
+			warnings = append(warnings, skippedWarning("availability zones", region, err))
+			break
+		}
+		if !zones.Has(subnet.AvailabilityZone) {
+			allErrs = append(allErrs, field.Invalid(subnetsPath.Index(i).Child("availabilityZone"), subnet.AvailabilityZone,
+				fmt.Sprintf("availability zone doesn't exist in region %s", region)))
+		}
+	}
+
+	if cluster.Spec.Bastion.Enabled {
+		bastionPath := field.NewPath("spec", "bastion")
+		if instanceType := cluster.Spec.Bastion.InstanceType; instanceType != "" {
+			w, errs := v.validateInstanceType(ctx, region, instanceType, bastionPath.Child("instanceType"))
+			warnings = append(warnings, w...)
+			allErrs = append(allErrs, errs...)
+		}
+		if ami := cluster.Spec.Bastion.AMI; ami != "" && usesControllerIdentity(cluster) {
+			w, errs := v.validateAMI(ctx, region, ami, bastionPath.Child("ami"))
+			warnings = append(warnings, w...)
+			allErrs = append(allErrs, errs...)
+		}
+	}
+
+	return warnings, allErrs
+}
+
+// ValidateAWSMachine validates the instance type and the AMI of an AWSMachine against the region of its AWSCluster,
+// and the instance type against the availability zone of its subnet when it is one of the AWSCluster referenced by ID.
+// The AWSMachines which don't belong to an AWSCluster yet are not validated.
+func (v *Validator) ValidateAWSMachine(ctx context.Context, machine *infrav1.AWSMachine) (admission.Warnings, field.ErrorList) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	awsCluster, err := v.getAWSCluster(ctx, machine.ObjectMeta)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validation against the region of the cluster: %v", err)}, nil
+	}
+	if awsCluster == nil {
+		return nil, nil
+	}
+	region, err := scope.ResolveAWSClusterRegion(ctx, v.client, awsCluster)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validation against the region of the cluster: %v", err)}, nil
+	}
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+
+	if instanceType := machine.Spec.InstanceType; instanceType != "" {
+		w, errs := v.validateInstanceType(ctx, region, instanceType, field.NewPath("spec", "instanceType"))
+		warnings = append(warnings, w...)
+		allErrs = append(allErrs, errs...)
+
+		if machine.Spec.Subnet != nil && len(errs) == 0 {
+			zones := subnetAvailabilityZones(awsCluster, []infrav1.AWSResourceReference{*machine.Spec.Subnet})
+			w, errs := v.validateInstanceTypeZones(ctx, region, instanceType, zones, field.NewPath("spec", "instanceType"))
+			warnings = append(warnings, w...)
+			allErrs = append(allErrs, errs...)
+		}
+	}
+
+	if id := aws.StringValue(machine.Spec.AMI.ID); id != "" && usesControllerIdentity(awsCluster) {
+		w, errs := v.validateAMI(ctx, region, id, field.NewPath("spec", "ami", "id"))
+		warnings = append(warnings, w...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	return warnings, allErrs
+}
+
+// ValidateAWSMachinePool validates the instance types of an AWSMachinePool against the region of its AWSCluster and
+// the availability zones the pool is restricted to. The AWSMachinePools which don't belong to an AWSCluster yet are
+// not validated.
+func (v *Validator) ValidateAWSMachinePool(ctx context.Context, pool *expinfrav1.AWSMachinePool) (admission.Warnings, field.ErrorList) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	awsCluster, err := v.getAWSCluster(ctx, pool.ObjectMeta)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validation against the region of the cluster: %v", err)}, nil
+	}
+	if awsCluster == nil {
+		return nil, nil
+	}
+	region, err := scope.ResolveAWSClusterRegion(ctx, v.client, awsCluster)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validation against the region of the cluster: %v", err)}, nil
+	}
+
+	zones := append(append([]string{}, pool.Spec.AvailabilityZones...), subnetAvailabilityZones(awsCluster, pool.Spec.Subnets)...)
+
+	instanceTypes := map[string]*field.Path{}
+	if instanceType := pool.Spec.AWSLaunchTemplate.InstanceType; instanceType != "" {
+		instanceTypes[instanceType] = field.NewPath("spec", "awsLaunchTemplate", "instanceType")
+	}
+	if pool.Spec.MixedInstancesPolicy != nil {
+		for i, override := range pool.Spec.MixedInstancesPolicy.Overrides {
+			if _, ok := instanceTypes[override.InstanceType]; !ok && override.InstanceType != "" {
+				instanceTypes[override.InstanceType] = field.NewPath("spec", "mixedInstancesPolicy", "overrides").Index(i).Child("instanceType")
+			}
+		}
+	}
+
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	for _, instanceType := range sets.List(sets.KeySet(instanceTypes)) {
+		path := instanceTypes[instanceType]
+		w, errs := v.validateInstanceType(ctx, region, instanceType, path)
+		warnings = append(warnings, w...)
+		allErrs = append(allErrs, errs...)
+		if len(errs) > 0 {
+			continue
+		}
+
+		w, errs = v.validateInstanceTypeZones(ctx, region, instanceType, zones, path)
+		warnings = append(warnings, w...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	return warnings, allErrs
+}
+
+func (v *Validator) getAWSCluster(ctx context.Context, meta metav1.ObjectMeta) (*infrav1.AWSCluster, error) {
+	if meta.Labels[clusterv1.ClusterNameLabel] == "" {
+		return nil, nil
+	}
+
+	cluster, err := util.GetClusterFromMetadata(ctx, v.client, meta)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster: %w", err)
+	}
+
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != "AWSCluster" {
+		return nil, nil
+	}
+
+	awsCluster := &infrav1.AWSCluster{}
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, awsCluster); err != nil {
+		return nil, fmt.Errorf("getting AWSCluster: %w", err)
+	}
+
+	return awsCluster, nil
+}
+
+func (v *Validator) validateInstanceType(ctx context.Context, region, instanceType string, path *field.Path) (admission.Warnings, field.ErrorList) {
+	offerings, err := v.instanceTypeOfferings(ctx, region)
+	if err != nil {
+		return admission.Warnings{skippedWarning("instance type offerings", region, err)}, nil
+	}
+	if !offerings.Has(instanceType) {
+		return nil, field.ErrorList{field.Invalid(path, instanceType, fmt.Sprintf("instance type is not offered in region %s", region))}
+	}
+
+	return nil, nil
+}
+
+// validateInstanceTypeZones validates that an instance type offered in a region is offered in the given availability
+// zones of the region.
+func (v *Validator) validateInstanceTypeZones(ctx context.Context, region, instanceType string, zones []string, path *field.Path) (admission.Warnings, field.ErrorList) {
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	offerings, err := v.instanceTypeZoneOfferings(ctx, region)
+	if err != nil {
+		return admission.Warnings{skippedWarning("instance type offerings of the availability zones", region, err)}, nil
+	}
+
+	var allErrs field.ErrorList
+	for _, zone := range sets.List(sets.New(zones...)) {
+		if !offerings[zone].Has(instanceType) {
+			allErrs = append(allErrs, field.Invalid(path, instanceType, fmt.Sprintf("instance type is not offered in availability zone %s", zone)))
+		}
+	}
+
+	return nil, allErrs
+}
+
+func (v *Validator) validateAMI(ctx context.Context, region, id string, path *field.Path) (admission.Warnings, field.ErrorList) {
+	found, err := v.imageExists(ctx, region, id)
+	if err != nil {
+		return admission.Warnings{skippedWarning("AMIs", region, err)}, nil
+	}
+	if !found {
+		return nil, field.ErrorList{field.Invalid(path, id, fmt.Sprintf("AMI doesn't exist in region %s", region))}
+	}
+
+	return nil, nil
+}
+
+// availabilityZones returns the names of the availability zones, local zones and wavelength zones of a region.
+func (v *Validator) availabilityZones(ctx context.Context, region string) (sets.Set[string], error) {
+	key := cacheKey{region: region, operation: "DescribeAvailabilityZones"}
+	if zones, ok := v.cache.Get(key); ok {
+		return zones.(sets.Set[string]), nil
+	}
+
+	ec2Client, err := v.newEC2Client(region)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := ec2Client.DescribeAvailabilityZonesWithContext(ctx, &ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing availability zones: %w", err)
+	}
+
+	zones := sets.New[string]()
+	for _, zone := range out.AvailabilityZones {
+		zones.Insert(aws.StringValue(zone.ZoneName))
+	}
+	v.cache.Set(key, zones, v.ttl)
+
+	return zones, nil
+}
+
+// instanceTypeOfferings returns the instance types offered in a region.
+func (v *Validator) instanceTypeOfferings(ctx context.Context, region string) (sets.Set[string], error) {
+	key := cacheKey{region: region, operation: "DescribeInstanceTypeOfferings"}
+	if offerings, ok := v.cache.Get(key); ok {
+		return offerings.(sets.Set[string]), nil
+	}
+
+	ec2Client, err := v.newEC2Client(region)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings := sets.New[string]()
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeRegion),
+	}
+	if err := ec2Client.DescribeInstanceTypeOfferingsPagesWithContext(ctx, input, func(out *ec2.DescribeInstanceTypeOfferingsOutput, last bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			offerings.Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("describing instance type offerings: %w", err)
+	}
+	v.cache.Set(key, offerings, v.ttl)
+
+	return offerings, nil
+}
+
+// instanceTypeZoneOfferings returns the instance types offered in each availability zone of a region.
+func (v *Validator) instanceTypeZoneOfferings(ctx context.Context, region string) (map[string]sets.Set[string], error) {
+	key := cacheKey{region: region, operation: "DescribeInstanceTypeOfferings", id: ec2.LocationTypeAvailabilityZone}
+	if offerings, ok := v.cache.Get(key); ok {
+		return offerings.(map[string]sets.Set[string]), nil
+	}
+
+	ec2Client, err := v.newEC2Client(region)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings := map[string]sets.Set[string]{}
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+	}
+	if err := ec2Client.DescribeInstanceTypeOfferingsPagesWithContext(ctx, input, func(out *ec2.DescribeInstanceTypeOfferingsOutput, last bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			zone := aws.StringValue(offering.Location)
+			if offerings[zone] == nil {
+				offerings[zone] = sets.New[string]()
+			}
+			offerings[zone].Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("describing instance type offerings of the availability zones: %w", err)
+	}
+	v.cache.Set(key, offerings, v.ttl)
+
+	return offerings, nil
+}
+
+// imageExists returns whether an AMI exists in a region.
+func (v *Validator) imageExists(ctx context.Context, region, id string) (bool, error) {
+	key := cacheKey{region: region, operation: "DescribeImages", id: id}
+	if found, ok := v.cache.Get(key); ok {
+		return found.(bool), nil
+	}
+
+	ec2Client, err := v.newEC2Client(region)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := ec2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		ImageIds: aws.StringSlice([]string{id}),
+	})
+	if err != nil {
+		code, _ := awserrors.Code(err)
+		if code != awserrors.InvalidAMIIDNotFound && code != awserrors.InvalidAMIIDMalformed {
+			return false, fmt.Errorf("describing images: %w", err)
+		}
+		out = &ec2.DescribeImagesOutput{}
+	}
+
+	found := len(out.Images) > 0
+	v.cache.Set(key, found, v.ttl)
+
+	return found, nil
+}
+
+// subnetAvailabilityZones returns the availability zones of the subnets of an AWSCluster referenced by ID. The
+// subnets referenced by filters, or which are not part of the AWSCluster, are ignored.
+func subnetAvailabilityZones(cluster *infrav1.AWSCluster, refs []infrav1.AWSResourceReference) []string {
+	var zones []string
+	for _, ref := range refs {
+		if ref.ID == nil {
+			continue
+		}
+		if subnet := cluster.Spec.NetworkSpec.Subnets.FindByID(*ref.ID); subnet != nil && subnet.AvailabilityZone != "" {
+			zones = append(zones, subnet.AvailabilityZone)
+		}
+	}
+	return zones
+}
+
+func usesControllerIdentity(cluster *infrav1.AWSCluster) bool {
+	ref := cluster.Spec.IdentityRef
+	return ref == nil || ref.Kind == infrav1.ControllerIdentityKind
+}
+
+func skippedWarning(resources, region string, err error) string {
+	log.Info("Skipped validation against the region", "resources", resources, "region", region, "error", err.Error())
+	return fmt.Sprintf("skipped validation of the %s of region %s: %v", resources, region, err)
+}
@@ -0,0 +1,451 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regionvalidation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidateAWSCluster(t *testing.T) {
+	testCases := []struct {
+		name           string
+		awsCluster     *infrav1.AWSCluster
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		expectErrs     int
+		expectWarnings int
+	}{
+		{
+			name: "accepts the availability zones of the region",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.NetworkSpec.Subnets = infrav1.Subnets{{AvailabilityZone: "us-east-1a"}, {AvailabilityZone: "us-east-1b"}}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectAvailabilityZones(m)
+			},
+		},
+		{
+			name: "rejects the availability zones of another region",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.NetworkSpec.Subnets = infrav1.Subnets{{AvailabilityZone: "us-east-1a"}, {AvailabilityZone: "eu-west-1a"}}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectAvailabilityZones(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "rejects a bastion instance type not offered in the region",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.Bastion = infrav1.Bastion{Enabled: true, InstanceType: "x9.large"}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "ignores the bastion when it is disabled",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.Bastion = infrav1.Bastion{InstanceType: "x9.large"}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "warns when the region can't be checked",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.NetworkSpec.Subnets = infrav1.Subnets{{AvailabilityZone: "us-east-1a"}, {AvailabilityZone: "eu-west-1a"}}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(nil, errors.New("no credentials"))
+			},
+			expectWarnings: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			v := newTestValidator(ec2Mock)
+			warnings, errs := v.ValidateAWSCluster(context.TODO(), tc.awsCluster)
+			g.Expect(errs).To(HaveLen(tc.expectErrs))
+			g.Expect(warnings).To(HaveLen(tc.expectWarnings))
+		})
+	}
+}
+
+func TestValidateAWSMachine(t *testing.T) {
+	testCases := []struct {
+		name       string
+		awsMachine *infrav1.AWSMachine
+		awsCluster *infrav1.AWSCluster
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		expectErrs int
+	}{
+		{
+			name:       "accepts an instance type offered in the region",
+			awsMachine: newAWSMachine("m5.large", ""),
+			awsCluster: newAWSCluster(),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+		},
+		{
+			name:       "rejects an instance type not offered in the region",
+			awsMachine: newAWSMachine("x9.large", ""),
+			awsCluster: newAWSCluster(),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name:       "rejects an AMI of another region",
+			awsMachine: newAWSMachine("m5.large", "ami-1"),
+			awsCluster: newAWSCluster(),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+				m.DescribeImagesWithContext(gomock.Any(), &ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-1"})}).
+					Return(nil, awserr.New(awserrors.InvalidAMIIDNotFound, "not found", nil))
+			},
+			expectErrs: 1,
+		},
+		{
+			name:       "doesn't check the AMI of a cluster using another identity",
+			awsMachine: newAWSMachine("m5.large", "ami-1"),
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.IdentityRef = &infrav1.AWSIdentityReference{Kind: infrav1.ClusterRoleIdentityKind, Name: "role"}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+		},
+		{
+			name: "rejects an instance type not offered in the availability zone of the subnet",
+			awsMachine: func() *infrav1.AWSMachine {
+				m := newAWSMachine("t3.medium", "")
+				m.Spec.Subnet = &infrav1.AWSResourceReference{ID: aws.String("subnet-b")}
+				return m
+			}(),
+			awsCluster: newAWSCluster(withSubnets),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+				expectInstanceTypeZoneOfferings(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "doesn't validate a machine without cluster",
+			awsMachine: func() *infrav1.AWSMachine {
+				m := newAWSMachine("x9.large", "ami-1")
+				m.Labels = nil
+				return m
+			}(),
+			awsCluster: newAWSCluster(),
+			expect:     func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			v := newTestValidator(ec2Mock, newCluster(), tc.awsCluster)
+			warnings, errs := v.ValidateAWSMachine(context.TODO(), tc.awsMachine)
+			g.Expect(errs).To(HaveLen(tc.expectErrs))
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidateAWSMachinePool(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pool       *expinfrav1.AWSMachinePool
+		expect     func(m *mocks.MockEC2APIMockRecorder)
+		expectErrs int
+	}{
+		{
+			name: "accepts an instance type offered in the availability zones",
+			pool: newAWSMachinePool("m5.large", func(p *expinfrav1.AWSMachinePool) {
+				p.Spec.AvailabilityZones = []string{"us-east-1a"}
+				p.Spec.Subnets = []infrav1.AWSResourceReference{{ID: aws.String("subnet-b")}}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+				expectInstanceTypeZoneOfferings(m)
+			},
+		},
+		{
+			name: "rejects an instance type not offered in the region",
+			pool: newAWSMachinePool("x9.large", func(p *expinfrav1.AWSMachinePool) {
+				p.Spec.AvailabilityZones = []string{"us-east-1a"}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "rejects the overrides not offered in the availability zones",
+			pool: newAWSMachinePool("", func(p *expinfrav1.AWSMachinePool) {
+				p.Spec.AvailabilityZones = []string{"us-east-1a", "us-east-1b"}
+				p.Spec.MixedInstancesPolicy = &expinfrav1.MixedInstancesPolicy{
+					Overrides: []expinfrav1.Overrides{{InstanceType: "m5.large"}, {InstanceType: "t3.medium"}},
+				}
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+				expectInstanceTypeZoneOfferings(m)
+			},
+			expectErrs: 1,
+		},
+		{
+			name: "doesn't check the availability zones of a pool using all of them",
+			pool: newAWSMachinePool("t3.medium"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				expectInstanceTypeOfferings(m)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			v := newTestValidator(ec2Mock, newCluster(), newAWSCluster(withSubnets))
+			warnings, errs := v.ValidateAWSMachinePool(context.TODO(), tc.pool)
+			g.Expect(errs).To(HaveLen(tc.expectErrs))
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidatorCache(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	expectInstanceTypeOfferings(ec2Mock.EXPECT())
+
+	v := newTestValidator(ec2Mock, newCluster(), newAWSCluster())
+	for _, instanceType := range []string{"m5.large", "x9.large", "t3.medium"} {
+		_, _ = v.ValidateAWSMachine(context.TODO(), newAWSMachine(instanceType, ""))
+	}
+
+	_, errs := v.ValidateAWSMachine(context.TODO(), newAWSMachine("m5.large", ""))
+	g.Expect(errs).To(BeEmpty())
+}
+
+func TestValidateOmittedRegion(t *testing.T) {
+	testCases := []struct {
+		name          string
+		awsCluster    *infrav1.AWSCluster
+		defaultRegion string
+		expectRegion  string
+	}{
+		{
+			name: "validates against the region recorded in the status",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.Region = ""
+				c.Status.Region = "eu-west-1"
+			}),
+			expectRegion: "eu-west-1",
+		},
+		{
+			name: "validates against the default region of the controllers",
+			awsCluster: newAWSCluster(func(c *infrav1.AWSCluster) {
+				c.Spec.Region = ""
+			}),
+			defaultRegion: "eu-central-1",
+			expectRegion:  "eu-central-1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scope.SetDefaultRegion(tc.defaultRegion)
+			defer scope.SetDefaultRegion("")
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			expectInstanceTypeOfferings(ec2Mock.EXPECT())
+
+			v := newTestValidator(ec2Mock, newCluster(), tc.awsCluster)
+			regions := []string{}
+			v.newEC2Client = func(region string) (ec2iface.EC2API, error) {
+				regions = append(regions, region)
+				return ec2Mock, nil
+			}
+			warnings, errs := v.ValidateAWSMachine(context.TODO(), newAWSMachine("x9.large", ""))
+			g.Expect(errs).To(HaveLen(1))
+			g.Expect(warnings).To(BeEmpty())
+			g.Expect(regions).To(ConsistOf(tc.expectRegion))
+		})
+	}
+}
+
+func newTestValidator(ec2Mock ec2iface.EC2API, objs ...client.Object) *Validator {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	v := NewValidator(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(), nil, time.Minute)
+	v.newEC2Client = func(region string) (ec2iface.EC2API, error) {
+		return ec2Mock, nil
+	}
+	return v
+}
+
+func expectAvailabilityZones(m *mocks.MockEC2APIMockRecorder) {
+	m.DescribeAvailabilityZonesWithContext(gomock.Any(), &ec2.DescribeAvailabilityZonesInput{AllAvailabilityZones: aws.Bool(true)}).
+		Return(&ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*ec2.AvailabilityZone{
+				{ZoneName: aws.String("us-east-1a")},
+				{ZoneName: aws.String("us-east-1b")},
+			},
+		}, nil)
+}
+
+func expectInstanceTypeOfferings(m *mocks.MockEC2APIMockRecorder) {
+	m.DescribeInstanceTypeOfferingsPagesWithContext(gomock.Any(), &ec2.DescribeInstanceTypeOfferingsInput{LocationType: aws.String("region")}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+				InstanceTypeOfferings: []*ec2.InstanceTypeOffering{{InstanceType: aws.String("m5.large")}},
+			}, false)
+			fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+				InstanceTypeOfferings: []*ec2.InstanceTypeOffering{{InstanceType: aws.String("t3.medium")}},
+			}, true)
+			return nil
+		})
+}
+
+// expectInstanceTypeZoneOfferings offers m5.large in us-east-1a and us-east-1b, and t3.medium in us-east-1a.
+func expectInstanceTypeZoneOfferings(m *mocks.MockEC2APIMockRecorder) {
+	m.DescribeInstanceTypeOfferingsPagesWithContext(gomock.Any(), &ec2.DescribeInstanceTypeOfferingsInput{LocationType: aws.String("availability-zone")}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool, _ ...request.Option) error {
+			fn(&ec2.DescribeInstanceTypeOfferingsOutput{
+				InstanceTypeOfferings: []*ec2.InstanceTypeOffering{
+					{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1a")},
+					{InstanceType: aws.String("m5.large"), Location: aws.String("us-east-1b")},
+					{InstanceType: aws.String("t3.medium"), Location: aws.String("us-east-1a")},
+				},
+			}, true)
+			return nil
+		})
+}
+
+func newCluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind:       "AWSCluster",
+				APIVersion: infrav1.GroupVersion.String(),
+				Name:       "cluster1",
+			},
+		},
+	}
+}
+
+func newAWSCluster(opts ...func(*infrav1.AWSCluster)) *infrav1.AWSCluster {
+	c := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "default"},
+		Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func withSubnets(c *infrav1.AWSCluster) {
+	c.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+		{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-b", AvailabilityZone: "us-east-1b"},
+	}
+}
+
+func newAWSMachinePool(instanceType string, opts ...func(*expinfrav1.AWSMachinePool)) *expinfrav1.AWSMachinePool {
+	p := &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster1"},
+		},
+		Spec: expinfrav1.AWSMachinePoolSpec{
+			AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{InstanceType: instanceType},
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func newAWSMachine(instanceType, ami string) *infrav1.AWSMachine {
+	m := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine1",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster1"},
+		},
+		Spec: infrav1.AWSMachineSpec{InstanceType: instanceType},
+	}
+	if ami != "" {
+		m.Spec.AMI.ID = aws.String(ami)
+	}
+	return m
+}
@@ -24,6 +24,8 @@ import (
 	"net/url"
 	"path"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -32,17 +34,32 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	iam "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
-	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/oidc"
 )
 
 // AWSDefaultRegion is the default AWS region.
 const AWSDefaultRegion string = "us-east-1"
 
+const (
+	// clusterMetadataKey is the metadata key of the bootstrap data objects recording the namespaced name
+	// of their cluster.
+	clusterMetadataKey = "Cluster"
+
+	// staleObjectGracePeriod is the age from which a bootstrap data object whose machine doesn't exist
+	// is stale, so that objects of machines which are being created are kept.
+	staleObjectGracePeriod = 10 * time.Minute
+)
+
+// bootstrapDataPrefixes are the key prefixes of the bootstrap data objects, for each machine role.
+var bootstrapDataPrefixes = []string{"control-plane/", "node/"}
+
 // Service holds a collection of interfaces.
 // The interfaces are broken down like this to group functions together.
 // One alternative is to have a large list of functions from the ec2 client.
@@ -80,6 +97,24 @@ func (s *Service) ReconcileBucket() error {
 		return errors.Wrap(err, "tagging bucket")
 	}
 
+	if s.scope.Bucket().KMSKeyARN != "" {
+		if err := s.ensureBucketEncryption(bucketName); err != nil {
+			return errors.Wrap(err, "ensuring bucket encryption")
+		}
+	}
+
+	if s.scope.Bucket().BootstrapDataExpirationDays != nil {
+		if err := s.ensureBucketLifecycle(bucketName); err != nil {
+			return errors.Wrap(err, "ensuring bucket lifecycle")
+		}
+	}
+
+	if s.oidcDiscoveryEnabled() {
+		if err := s.allowPublicBucketPolicy(bucketName); err != nil {
+			return errors.Wrap(err, "allowing public bucket policy")
+		}
+	}
+
 	if err := s.ensureBucketPolicy(bucketName); err != nil {
 		return errors.Wrap(err, "ensuring bucket policy")
 	}
@@ -142,12 +177,20 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, error) {
 
 	s.scope.Info("Creating object", "bucket_name", bucket, "key", key)
 
-	if _, err := s.S3Client.PutObject(&s3.PutObjectInput{
+	input := &s3.PutObjectInput{
 		Body:                 aws.ReadSeekCloser(bytes.NewReader(data)),
 		Bucket:               aws.String(bucket),
 		Key:                  aws.String(key),
-		ServerSideEncryption: aws.String("aws:kms"),
-	}); err != nil {
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+		Metadata: map[string]*string{
+			clusterMetadataKey: aws.String(s.clusterMetadata()),
+		},
+	}
+	if keyARN := s.scope.Bucket().KMSKeyARN; keyARN != "" {
+		input.SSEKMSKeyId = aws.String(keyARN)
+	}
+
+	if _, err := s.S3Client.PutObject(input); err != nil {
 		return "", errors.Wrap(err, "putting object")
 	}
 
@@ -221,6 +264,75 @@ func (s *Service) Delete(m *scope.MachineScope) error {
 	return s.deleteObject(bucket, key)
 }
 
+// DeleteStaleObjects deletes the bootstrap data objects of the cluster whose machine isn't one of the given
+// machines. Objects without cluster metadata, which were created by previous versions, are ignored.
+func (s *Service) DeleteStaleObjects(machineNames sets.Set[string]) error {
+	if !s.bucketManagementEnabled() {
+		return nil
+	}
+
+	bucket := s.bucketName()
+
+	var keys []string
+	for _, prefix := range bootstrapDataPrefixes {
+		input := &s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String(prefix),
+		}
+		if err := s.S3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+			for _, object := range page.Contents {
+				key := aws.StringValue(object.Key)
+				if machineNames.Has(path.Base(key)) || time.Since(aws.TimeValue(object.LastModified)) < staleObjectGracePeriod {
+					continue
+				}
+				keys = append(keys, key)
+			}
+			return true
+		}); err != nil {
+			return errors.Wrap(err, "listing S3 objects")
+		}
+	}
+
+	var errs []error
+	for _, key := range keys {
+		out, err := s.S3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "getting metadata of S3 object %q", key))
+			continue
+		}
+
+		// Buckets can be shared between clusters, whose machines are unknown.
+		if !s.ownsObject(out.Metadata) {
+			continue
+		}
+
+		s.scope.Info("Deleting stale S3 object", "bucket", bucket, "key", key)
+		if err := s.deleteObject(bucket, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// ownsObject returns whether the object with the given metadata belongs to the cluster. The metadata keys
+// returned by S3 are canonicalized.
+func (s *Service) ownsObject(metadata map[string]*string) bool {
+	for key, value := range metadata {
+		if strings.EqualFold(key, clusterMetadataKey) {
+			return aws.StringValue(value) == s.clusterMetadata()
+		}
+	}
+	return false
+}
+
+func (s *Service) clusterMetadata() string {
+	return path.Join(s.scope.Namespace(), s.scope.Name())
+}
+
 func (s *Service) deleteObject(bucket, key string) error {
 	if _, err := s.S3Client.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -294,33 +406,63 @@ func (s *Service) ensureBucketPolicy(bucketName string) error {
 	return nil
 }
 
+// ensureBucketEncryption makes the customer managed KMS key of the bucket its default encryption key.
+func (s *Service) ensureBucketEncryption(bucketName string) error {
+	input := &s3.PutBucketEncryptionInput{
+		Bucket:                            aws.String(bucketName),
+		ServerSideEncryptionConfiguration: BucketEncryption(s.scope.Bucket().KMSKeyARN),
+	}
+
+	if _, err := s.S3Client.PutBucketEncryption(input); err != nil {
+		return errors.Wrap(err, "putting bucket encryption")
+	}
+
+	s.scope.Trace("Updated bucket encryption", "bucket_name", bucketName)
+
+	return nil
+}
+
+// ensureBucketLifecycle expires the bootstrap data objects of the bucket after the configured number of days.
+func (s *Service) ensureBucketLifecycle(bucketName string) error {
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucketName),
+		LifecycleConfiguration: BucketLifecycle(*s.scope.Bucket().BootstrapDataExpirationDays),
+	}
+
+	if _, err := s.S3Client.PutBucketLifecycleConfiguration(input); err != nil {
+		return errors.Wrap(err, "putting bucket lifecycle configuration")
+	}
+
+	s.scope.Trace("Updated bucket lifecycle configuration", "bucket_name", bucketName)
+
+	return nil
+}
+
+// allowPublicBucketPolicy lets the bucket policy grant public read access, which is needed for the
+// OIDC discovery documents. Public ACLs remain blocked.
+func (s *Service) allowPublicBucketPolicy(bucketName string) error {
+	input := &s3.PutPublicAccessBlockInput{
+		Bucket:                         aws.String(bucketName),
+		PublicAccessBlockConfiguration: PublicAccessBlock(true),
+	}
+
+	if _, err := s.S3Client.PutPublicAccessBlock(input); err != nil {
+		return errors.Wrap(err, "putting public access block")
+	}
+
+	s.scope.Trace("Updated bucket public access block", "bucket_name", bucketName)
+
+	return nil
+}
+
 func (s *Service) tagBucket(bucketName string) error {
 	taggingInput := &s3.PutBucketTaggingInput{
 		Bucket: aws.String(bucketName),
 		Tagging: &s3.Tagging{
-			TagSet: nil,
+			TagSet: BucketTags(s.scope.Name(), s.scope.AdditionalTags()),
 		},
 	}
 
-	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        nil,
-		Role:        aws.String("node"),
-		Additional:  s.scope.AdditionalTags(),
-	})
-
-	for key, value := range tags {
-		taggingInput.Tagging.TagSet = append(taggingInput.Tagging.TagSet, &s3.Tag{
-			Key:   aws.String(key),
-			Value: aws.String(value),
-		})
-	}
-
-	sort.Slice(taggingInput.Tagging.TagSet, func(i, j int) bool {
-		return *taggingInput.Tagging.TagSet[i].Key < *taggingInput.Tagging.TagSet[j].Key
-	})
-
 	_, err := s.S3Client.PutBucketTagging(taggingInput)
 	if err != nil {
 		return err
@@ -337,8 +479,15 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 		return "", errors.Wrap(err, "getting account ID")
 	}
 
-	bucket := s.scope.Bucket()
-	partition := system.GetPartitionFromRegion(s.scope.Region())
+	return BucketPolicy(s.scope.Bucket(), s.scope.Partition(), *accountID.Account, s.oidcDiscoveryEnabled())
+}
+
+// BucketPolicy returns the policy of a bootstrap data bucket. It denies insecure transport and unencrypted
+// bootstrap data, and allows the IAM instance profiles of the bucket, in the account, to read the bootstrap
+// data of their role unless presigned URLs are used. oidcDiscovery allows public read access to the OIDC
+// discovery documents.
+func BucketPolicy(bucket *infrav1.S3Bucket, partition, accountID string, oidcDiscovery bool) (string, error) {
+	bucketName := bucket.Name
 
 	statements := []iam.StatementEntry{
 		{
@@ -357,13 +506,49 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 		},
 	}
 
+	// Bootstrap data must be encrypted with AWS KMS, using the customer managed key if any.
+	bootstrapDataResources := make([]string, 0, len(bootstrapDataPrefixes))
+	for _, prefix := range bootstrapDataPrefixes {
+		bootstrapDataResources = append(bootstrapDataResources, fmt.Sprintf("arn:%s:s3:::%s/%s*", partition, bucketName, prefix))
+	}
+	statements = append(statements, iam.StatementEntry{
+		Sid:    "DenyUnencryptedBootstrapData",
+		Effect: iam.EffectDeny,
+		Principal: map[iam.PrincipalType]iam.PrincipalID{
+			iam.PrincipalAWS: []string{"*"},
+		},
+		Action:   []string{"s3:PutObject"},
+		Resource: bootstrapDataResources,
+		Condition: iam.Conditions{
+			"StringNotEquals": map[string]interface{}{
+				"s3:x-amz-server-side-encryption": s3.ServerSideEncryptionAwsKms,
+			},
+		},
+	})
+	if bucket.KMSKeyARN != "" {
+		statements = append(statements, iam.StatementEntry{
+			Sid:    "DenyOtherKMSKeys",
+			Effect: iam.EffectDeny,
+			Principal: map[iam.PrincipalType]iam.PrincipalID{
+				iam.PrincipalAWS: []string{"*"},
+			},
+			Action:   []string{"s3:PutObject"},
+			Resource: bootstrapDataResources,
+			Condition: iam.Conditions{
+				"StringNotEquals": map[string]interface{}{
+					"s3:x-amz-server-side-encryption-aws-kms-key-id": bucket.KMSKeyARN,
+				},
+			},
+		})
+	}
+
 	if bucket.PresignedURLDuration == nil {
 		if bucket.ControlPlaneIAMInstanceProfile != "" {
 			statements = append(statements, iam.StatementEntry{
 				Sid:    "control-plane",
 				Effect: iam.EffectAllow,
 				Principal: map[iam.PrincipalType]iam.PrincipalID{
-					iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, *accountID.Account, bucket.ControlPlaneIAMInstanceProfile)},
+					iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, bucket.ControlPlaneIAMInstanceProfile)},
 				},
 				Action:   []string{"s3:GetObject"},
 				Resource: []string{fmt.Sprintf("arn:%s:s3:::%s/control-plane/*", partition, bucketName)},
@@ -375,7 +560,7 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 				Sid:    iamInstanceProfile,
 				Effect: iam.EffectAllow,
 				Principal: map[iam.PrincipalType]iam.PrincipalID{
-					iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, *accountID.Account, iamInstanceProfile)},
+					iam.PrincipalAWS: []string{fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, iamInstanceProfile)},
 				},
 				Action:   []string{"s3:GetObject"},
 				Resource: []string{fmt.Sprintf("arn:%s:s3:::%s/node/*", partition, bucketName)},
@@ -383,6 +568,18 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 		}
 	}
 
+	if oidcDiscovery {
+		statements = append(statements, iam.StatementEntry{
+			Sid:    "oidc-discovery",
+			Effect: iam.EffectAllow,
+			Principal: map[iam.PrincipalType]iam.PrincipalID{
+				iam.PrincipalAWS: []string{"*"},
+			},
+			Action:   []string{"s3:GetObject"},
+			Resource: []string{fmt.Sprintf("arn:%s:s3:::%s/%s/*", partition, bucketName, oidc.KeyPrefix)},
+		})
+	}
+
 	policy := iam.PolicyDocument{
 		Version:   "2012-10-17",
 		Statement: statements,
@@ -396,10 +593,89 @@ func (s *Service) bucketPolicy(bucketName string) (string, error) {
 	return string(policyRaw), nil
 }
 
+// BucketEncryption returns the default encryption of a bootstrap data bucket with a customer managed KMS key.
+func BucketEncryption(kmsKeyARN string) *s3.ServerSideEncryptionConfiguration {
+	return &s3.ServerSideEncryptionConfiguration{
+		Rules: []*s3.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+					SSEAlgorithm:   aws.String(s3.ServerSideEncryptionAwsKms),
+					KMSMasterKeyID: aws.String(kmsKeyARN),
+				},
+				BucketKeyEnabled: aws.Bool(true),
+			},
+		},
+	}
+}
+
+// BucketLifecycle returns the lifecycle rules of a bootstrap data bucket expiring the bootstrap data objects
+// after a number of days.
+func BucketLifecycle(expirationDays int32) *s3.BucketLifecycleConfiguration {
+	rules := make([]*s3.LifecycleRule, 0, len(bootstrapDataPrefixes))
+	for _, prefix := range bootstrapDataPrefixes {
+		rules = append(rules, &s3.LifecycleRule{
+			ID:     aws.String("expire-" + strings.TrimSuffix(prefix, "/") + "-bootstrap-data"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				Prefix: aws.String(prefix),
+			},
+			Expiration: &s3.LifecycleExpiration{
+				Days: aws.Int64(int64(expirationDays)),
+			},
+		})
+	}
+
+	return &s3.BucketLifecycleConfiguration{
+		Rules: rules,
+	}
+}
+
+// PublicAccessBlock returns the public access block of a bootstrap data bucket. Public ACLs are always
+// blocked, public bucket policies only when the bucket doesn't host OIDC discovery documents.
+func PublicAccessBlock(oidcDiscovery bool) *s3.PublicAccessBlockConfiguration {
+	return &s3.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       aws.Bool(true),
+		IgnorePublicAcls:      aws.Bool(true),
+		BlockPublicPolicy:     aws.Bool(!oidcDiscovery),
+		RestrictPublicBuckets: aws.Bool(!oidcDiscovery),
+	}
+}
+
+// BucketTags returns the tags of the bootstrap data bucket of a cluster, sorted by key.
+func BucketTags(clusterName string, additionalTags infrav1.Tags) []*s3.Tag {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: clusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        nil,
+		Role:        aws.String("node"),
+		Additional:  additionalTags,
+	})
+
+	tagSet := make([]*s3.Tag, 0, len(tags))
+	for key, value := range tags {
+		tagSet = append(tagSet, &s3.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+
+	sort.Slice(tagSet, func(i, j int) bool {
+		return *tagSet[i].Key < *tagSet[j].Key
+	})
+
+	return tagSet
+}
+
 func (s *Service) bucketManagementEnabled() bool {
 	return s.scope.Bucket() != nil
 }
 
+// oidcDiscoveryEnabled returns whether the bucket hosts the OIDC discovery documents of the cluster.
+func (s *Service) oidcDiscoveryEnabled() bool {
+	oidcScope, ok := s.scope.(scope.OIDCScope)
+	return ok && oidcScope.AssociateOIDCProvider()
+}
+
 func (s *Service) bucketName() string {
 	return s.scope.Bucket().Name
 }
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -32,6 +33,7 @@ import (
 	"github.com/golang/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -135,6 +137,7 @@ func TestReconcileBucket(t *testing.T) {
 			},
 			AWSCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region:   "us-east-1",
 					S3Bucket: &infrav1.S3Bucket{},
 				},
 			},
@@ -219,6 +222,125 @@ func TestReconcileBucket(t *testing.T) {
 		}
 	})
 
+	t.Run("allows_public_read_of_oidc_discovery_documents_when_oidc_provider_is_associated", func(t *testing.T) {
+		t.Parallel()
+
+		bucketName := "bar"
+
+		svc, s3Mock := testService(t, &testServiceInput{
+			Bucket:                &infrav1.S3Bucket{Name: bucketName},
+			AssociateOIDCProvider: true,
+		})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutPublicAccessBlock(gomock.Any()).Do(func(input *s3svc.PutPublicAccessBlockInput) {
+			if aws.BoolValue(input.PublicAccessBlockConfiguration.BlockPublicPolicy) {
+				t.Errorf("Public bucket policies must be allowed")
+			}
+
+			if !aws.BoolValue(input.PublicAccessBlockConfiguration.BlockPublicAcls) {
+				t.Errorf("Public ACLs must remain blocked")
+			}
+		}).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+			if !strings.Contains(*input.Policy, fmt.Sprintf("%s/oidc/*", bucketName)) {
+				t.Errorf("Expected policy to allow reading objects with %q prefix, got: %v", "oidc", *input.Policy)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("creates_bucket_with_policy_denying_unencrypted_bootstrap_data", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: "bar"}})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+			policy := *input.Policy
+
+			if !strings.Contains(policy, `"s3:x-amz-server-side-encryption":"aws:kms"`) {
+				t.Errorf("Expected deny when not using aws:kms server side encryption; got: %v", policy)
+			}
+
+			if strings.Contains(policy, "DenyOtherKMSKeys") {
+				t.Errorf("Expected no KMS key restriction without KMS key; got: %v", policy)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("creates_bucket_encrypted_with_customer_managed_kms_key", func(t *testing.T) {
+		t.Parallel()
+
+		keyARN := "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: "bar", KMSKeyARN: keyARN}})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketEncryption(gomock.Any()).Do(func(input *s3svc.PutBucketEncryptionInput) {
+			rule := input.ServerSideEncryptionConfiguration.Rules[0]
+
+			if aws.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm) != s3svc.ServerSideEncryptionAwsKms {
+				t.Errorf("Expected aws:kms default encryption, got: %v", rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+			}
+
+			if aws.StringValue(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID) != keyARN {
+				t.Errorf("Expected default encryption with key %q, got: %v", keyARN, rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+			}
+		}).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Do(func(input *s3svc.PutBucketPolicyInput) {
+			policy := *input.Policy
+
+			if !strings.Contains(policy, fmt.Sprintf(`"s3:x-amz-server-side-encryption-aws-kms-key-id":%q`, keyARN)) {
+				t.Errorf("Expected deny when not using the KMS key; got: %v", policy)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("creates_bucket_with_lifecycle_rules_expiring_bootstrap_data", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: "bar", BootstrapDataExpirationDays: aws.Int32(2)}})
+
+		s3Mock.EXPECT().CreateBucket(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketTagging(gomock.Any()).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketLifecycleConfiguration(gomock.Any()).Do(func(input *s3svc.PutBucketLifecycleConfigurationInput) {
+			rules := input.LifecycleConfiguration.Rules
+			if len(rules) != 2 {
+				t.Fatalf("Expected a lifecycle rule for each machine role, got: %v", rules)
+			}
+
+			for i, prefix := range []string{"control-plane/", "node/"} {
+				if aws.StringValue(rules[i].Filter.Prefix) != prefix {
+					t.Errorf("Expected lifecycle rule for objects with %q prefix, got: %v", prefix, rules[i].Filter.Prefix)
+				}
+
+				if aws.Int64Value(rules[i].Expiration.Days) != 2 {
+					t.Errorf("Expected objects to expire after 2 days, got: %v", rules[i].Expiration.Days)
+				}
+			}
+		}).Return(nil, nil).Times(1)
+		s3Mock.EXPECT().PutBucketPolicy(gomock.Any()).Return(nil, nil).Times(1)
+
+		if err := svc.ReconcileBucket(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
 	t.Run("is_idempotent", func(t *testing.T) {
 		t.Parallel()
 
@@ -471,6 +593,15 @@ func TestCreateObject(t *testing.T) {
 				}
 			})
 
+			t.Run("records_cluster_in_metadata", func(t *testing.T) {
+				t.Parallel()
+
+				expected := fmt.Sprintf("%s/%s", testClusterNamespace, testClusterName)
+				if aws.StringValue(putObjectInput.Metadata["Cluster"]) != expected {
+					t.Errorf("Expected cluster metadata %q, got: %v", expected, putObjectInput.Metadata)
+				}
+			})
+
 			t.Run("puts_given_bootstrap_data_untouched", func(t *testing.T) {
 				t.Parallel()
 
@@ -513,6 +644,37 @@ func TestCreateObject(t *testing.T) {
 		})
 	})
 
+	t.Run("encrypts_object_with_customer_managed_kms_key", func(t *testing.T) {
+		t.Parallel()
+
+		keyARN := "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: bucketName, KMSKeyARN: keyARN}})
+
+		machineScope := &scope.MachineScope{
+			Machine: &clusterv1.Machine{},
+			AWSMachine: &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+		}
+
+		s3Mock.EXPECT().PutObject(gomock.Any()).Do(func(putObjectInput *s3svc.PutObjectInput) {
+			if aws.StringValue(putObjectInput.ServerSideEncryption) != s3svc.ServerSideEncryptionAwsKms {
+				t.Errorf("Expected aws:kms server side encryption, got: %v", putObjectInput.ServerSideEncryption)
+			}
+
+			if aws.StringValue(putObjectInput.SSEKMSKeyId) != keyARN {
+				t.Errorf("Expected object to be encrypted with key %q, got: %v", keyARN, putObjectInput.SSEKMSKeyId)
+			}
+		}).Return(nil, nil).Times(1)
+
+		if _, err := svc.Create(machineScope, []byte("foo")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
 	t.Run("is_idempotent", func(t *testing.T) {
 		t.Parallel()
 
@@ -855,9 +1017,86 @@ func TestDeleteObject(t *testing.T) {
 	})
 }
 
+func TestDeleteStaleObjects(t *testing.T) {
+	t.Parallel()
+
+	const bucketName = "foo"
+
+	t.Run("does_nothing_when_bucket_management_is_disabled", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := testService(t, nil)
+
+		if err := svc.DeleteStaleObjects(sets.New[string]()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("deletes_old_objects_of_the_cluster_whose_machine_does_not_exist", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: bucketName}})
+
+		old := time.Now().Add(-time.Hour)
+		objects := map[string][]*s3svc.Object{
+			"control-plane/": {
+				{Key: aws.String("control-plane/existing"), LastModified: aws.Time(old)},
+			},
+			"node/": {
+				{Key: aws.String("node/deleted"), LastModified: aws.Time(old)},
+				{Key: aws.String("node/other-cluster"), LastModified: aws.Time(old)},
+				{Key: aws.String("node/legacy"), LastModified: aws.Time(old)},
+				{Key: aws.String("node/recent"), LastModified: aws.Time(time.Now())},
+			},
+		}
+		metadata := map[string]map[string]*string{
+			"node/deleted":       {"Cluster": aws.String(fmt.Sprintf("%s/%s", testClusterNamespace, testClusterName))},
+			"node/other-cluster": {"Cluster": aws.String("other-namespace/other-cluster")},
+			"node/legacy":        {},
+		}
+
+		s3Mock.EXPECT().ListObjectsV2Pages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(input *s3svc.ListObjectsV2Input, fn func(*s3svc.ListObjectsV2Output, bool) bool) error {
+				if aws.StringValue(input.Bucket) != bucketName {
+					t.Errorf("Expected objects to be listed in bucket %q, got %q", bucketName, aws.StringValue(input.Bucket))
+				}
+				fn(&s3svc.ListObjectsV2Output{Contents: objects[aws.StringValue(input.Prefix)]}, true)
+				return nil
+			}).Times(2)
+		s3Mock.EXPECT().HeadObject(gomock.Any()).DoAndReturn(func(input *s3svc.HeadObjectInput) (*s3svc.HeadObjectOutput, error) {
+			m, ok := metadata[aws.StringValue(input.Key)]
+			if !ok {
+				t.Fatalf("Unexpected metadata lookup for object %q", aws.StringValue(input.Key))
+			}
+			return &s3svc.HeadObjectOutput{Metadata: m}, nil
+		}).Times(3)
+		s3Mock.EXPECT().DeleteObject(gomock.Eq(&s3svc.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String("node/deleted"),
+		})).Return(nil, nil).Times(1)
+
+		if err := svc.DeleteStaleObjects(sets.New[string]("existing")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns_error_when_listing_objects_fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, s3Mock := testService(t, &testServiceInput{Bucket: &infrav1.S3Bucket{Name: bucketName}})
+
+		s3Mock.EXPECT().ListObjectsV2Pages(gomock.Any(), gomock.Any()).Return(errors.New("error")).Times(1)
+
+		if err := svc.DeleteStaleObjects(sets.New[string]()); err == nil {
+			t.Fatalf("Expected error")
+		}
+	})
+}
+
 type testServiceInput struct {
-	Bucket *infrav1.S3Bucket
-	Region string
+	Bucket                *infrav1.S3Bucket
+	Region                string
+	AssociateOIDCProvider bool
 }
 
 const testAWSRegion string = "us-west-2"
@@ -893,8 +1132,9 @@ func testService(t *testing.T, si *testServiceInput) (*s3.Service, *mock_s3iface
 		},
 		AWSCluster: &infrav1.AWSCluster{
 			Spec: infrav1.AWSClusterSpec{
-				S3Bucket: si.Bucket,
-				Region:   si.Region,
+				S3Bucket:              si.Bucket,
+				Region:                si.Region,
+				AssociateOIDCProvider: si.AssociateOIDCProvider,
 				AdditionalTags: infrav1.Tags{
 					"additional": "from-aws-cluster",
 				},
@@ -273,7 +273,7 @@ func getClusterScope(client client.Client) (*scope.ClusterScope, error) {
 	return scope.NewClusterScope(scope.ClusterScopeParams{
 		Client:     client,
 		Cluster:    cluster,
-		AWSCluster: &infrav1.AWSCluster{},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
 	})
 }
 
@@ -58,6 +58,8 @@ const (
 func (s *Service) ReconcileSecurityGroups() error {
 	s.scope.Debug("Reconciling security groups")
 
+	defer s.lockCluster()()
+
 	if s.scope.Network().SecurityGroups == nil {
 		s.scope.Network().SecurityGroups = make(map[infrav1.SecurityGroupRole]infrav1.SecurityGroup)
 	}
@@ -133,12 +135,12 @@ func (s *Service) ReconcileSecurityGroups() error {
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getSecurityGroupTagParams(existing.Name, existing.ID, role)
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
-				if err := tagsBuilder.Ensure(existing.Tags); err != nil {
+				if err := tagsBuilder.Ensure(context.TODO(), existing.Tags); err != nil {
 					return false, err
 				}
 				return true, nil
 			}, awserrors.GroupNotFound); err != nil {
-				return errors.Wrapf(err, "failed to ensure tags on security group %q", existing.ID)
+				return errors.Wrapf(err, "failed to ensure tags on %s security group %q", role, existing.ID)
 			}
 		}
 	}
@@ -162,7 +164,7 @@ func (s *Service) ReconcileSecurityGroups() error {
 
 		want, err := s.getSecurityGroupIngressRules(role)
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "failed to get ingress rules of %s security group %q", role, sg.ID)
 		}
 
 		toRevoke := current.Difference(want)
@@ -173,7 +175,7 @@ func (s *Service) ReconcileSecurityGroups() error {
 				}
 				return true, nil
 			}, awserrors.GroupNotFound); err != nil {
-				return errors.Wrapf(err, "failed to revoke security group ingress rules for %q", sg.ID)
+				return errors.Wrapf(err, "failed to revoke ingress rules of %s security group %q", role, sg.ID)
 			}
 
 			s.scope.Debug("Revoked ingress rules from security group", "revoked-ingress-rules", toRevoke, "security-group-id", sg.ID)
@@ -187,7 +189,7 @@ func (s *Service) ReconcileSecurityGroups() error {
 				}
 				return true, nil
 			}, awserrors.GroupNotFound); err != nil {
-				return err
+				return errors.Wrapf(err, "failed to authorize ingress rules of %s security group %q", role, sg.ID)
 			}
 
 			s.scope.Debug("Authorized ingress rules in security group", "authorized-ingress-rules", toAuthorize, "security-group-id", sg.ID)
@@ -261,6 +263,8 @@ func (s *Service) ec2SecurityGroupToSecurityGroup(ec2SecurityGroup *ec2.Security
 
 // DeleteSecurityGroups will delete a service's security groups.
 func (s *Service) DeleteSecurityGroups() error {
+	defer s.lockCluster()()
+
 	if s.scope.VPC().ID == "" {
 		s.scope.Debug("Skipping security group deletion, vpc-id is nil", "vpc-id", s.scope.VPC().ID)
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.ClusterSecurityGroupsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
@@ -563,15 +567,11 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	case infrav1.SecurityGroupControlPlane:
 		rules := infrav1.IngressRules{
 			{
-				Description: "Kubernetes API",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    infrav1.DefaultAPIServerPort,
-				ToPort:      infrav1.DefaultAPIServerPort,
-				SourceSecurityGroupIDs: []string{
-					s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-				},
+				Description:            "Kubernetes API",
+				Protocol:               infrav1.SecurityGroupProtocolTCP,
+				FromPort:               infrav1.DefaultAPIServerPort,
+				ToPort:                 infrav1.DefaultAPIServerPort,
+				SourceSecurityGroupIDs: s.getAPIServerSourceSecurityGroupIDs(),
 			},
 			{
 				Description:            "etcd",
@@ -655,10 +655,9 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 		}
 		return infrav1.IngressRules{}, nil
 	case infrav1.SecurityGroupAPIServerLB:
-		kubeletRules := s.getIngressRulesToAllowKubeletToAccessTheControlPlaneLB()
-		customIngressRules := s.getControlPlaneLBIngressRules()
-		rulesToApply := customIngressRules.Difference(kubeletRules)
-		return append(kubeletRules, rulesToApply...), nil
+		return s.getControlPlaneLBSecurityGroupIngressRules(s.scope.ControlPlaneLoadBalancer()), nil
+	case infrav1.SecurityGroupSecondaryAPIServerLB:
+		return s.getControlPlaneLBSecurityGroupIngressRules(s.secondaryControlPlaneLoadBalancer()), nil
 	case infrav1.SecurityGroupLB:
 		rules := infrav1.IngressRules{}
 		allowedNLBTraffic := false
@@ -678,7 +677,7 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 			if s.scope.VPC().IsIPv6Enabled() {
 				ipv6CidrBlocks = []string{s.scope.VPC().IPv6.CidrBlock}
 			}
-			if lb.PreserveClientIP {
+			if lb.PreservesClientIP() {
 				ipv4CidrBlocks = []string{services.AnyIPv4CidrBlock}
 				if s.scope.VPC().IsIPv6Enabled() {
 					ipv6CidrBlocks = []string{services.AnyIPv6CidrBlock}
@@ -887,11 +886,40 @@ func ingressRuleFromSDKProtocol(v *ec2.IpPermission) infrav1.IngressRule {
 	}
 }
 
+// getAPIServerSourceSecurityGroupIDs returns the security groups allowed to access the Kubernetes API of the
+// control plane instances: the ones of the control plane load balancers, the control plane and the nodes.
+func (s *Service) getAPIServerSourceSecurityGroupIDs() []string {
+	ids := []string{s.scope.SecurityGroups()[infrav1.SecurityGroupAPIServerLB].ID}
+	if sg, ok := s.scope.SecurityGroups()[infrav1.SecurityGroupSecondaryAPIServerLB]; ok {
+		ids = append(ids, sg.ID)
+	}
+	return append(ids,
+		s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
+		s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
+	)
+}
+
+// secondaryControlPlaneLoadBalancer returns the secondary control plane load balancer, or nil when not configured.
+func (s *Service) secondaryControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec {
+	if lbs := s.scope.ControlPlaneLoadBalancers(); len(lbs) > 1 {
+		return lbs[1]
+	}
+	return nil
+}
+
+// getControlPlaneLBSecurityGroupIngressRules returns the ingress rules of the security group of a control plane LB.
+func (s *Service) getControlPlaneLBSecurityGroupIngressRules(lb *infrav1.AWSLoadBalancerSpec) infrav1.IngressRules {
+	kubeletRules := s.getIngressRulesToAllowKubeletToAccessTheControlPlaneLB(lb)
+	customIngressRules := s.getControlPlaneLBIngressRules(lb)
+	rulesToApply := customIngressRules.Difference(kubeletRules)
+	return append(kubeletRules, rulesToApply...)
+}
+
 // getIngressRulesToAllowKubeletToAccessTheControlPlaneLB returns ingress rules required in the control plane LB.
 // The control plane LB will be accessed by in-cluster components like the kubelet, that means allowing the NatGateway IPs
 // when using an internet-facing LB, or the VPC CIDR when using an internal LB.
-func (s *Service) getIngressRulesToAllowKubeletToAccessTheControlPlaneLB() infrav1.IngressRules {
-	if s.scope.ControlPlaneLoadBalancer() != nil && infrav1.ELBSchemeInternal.Equals(s.scope.ControlPlaneLoadBalancer().Scheme) {
+func (s *Service) getIngressRulesToAllowKubeletToAccessTheControlPlaneLB(lb *infrav1.AWSLoadBalancerSpec) infrav1.IngressRules {
+	if lb != nil && infrav1.ELBSchemeInternal.Equals(lb.Scheme) {
 		return s.getIngressRuleToAllowVPCCidrInTheAPIServer()
 	}
 
@@ -918,19 +946,25 @@ func (s *Service) getIngressRulesToAllowKubeletToAccessTheControlPlaneLB() infra
 
 // getControlPlaneLBIngressRules returns the ingress rules for the control plane LB.
 // We allow all traffic when no other rules are defined.
-func (s *Service) getControlPlaneLBIngressRules() infrav1.IngressRules {
-	ingressRules := infrav1.IngressRules{}
-	for _, lb := range s.scope.ControlPlaneLoadBalancers() {
-		if lb != nil && len(lb.IngressRules) > 0 {
-			ingressRules = append(ingressRules, lb.IngressRules...)
-		}
-	}
-	if len(ingressRules) > 0 {
-		return ingressRules
+func (s *Service) getControlPlaneLBIngressRules(lb *infrav1.AWSLoadBalancerSpec) infrav1.IngressRules {
+	if lb != nil && len(lb.IngressRules) > 0 {
+		return lb.IngressRules
 	}
 
 	// If no custom ingress rules have been defined we allow all traffic so that the MC can access the WC API
-	return s.getIngressRuleToAllowAnyIPInTheAPIServer()
+	rules := s.getIngressRuleToAllowAnyIPInTheAPIServer()
+	// A dual-stack load balancer also serves the clients connecting over IPv6, which are already allowed
+	// for VPCs with IPv6 enabled.
+	if lb.IsDualStack() && !s.scope.VPC().IsIPv6Enabled() {
+		rules = append(rules, infrav1.IngressRule{
+			Description:    "Kubernetes API IPv6",
+			Protocol:       infrav1.SecurityGroupProtocolTCP,
+			FromPort:       int64(s.scope.APIServerPort()),
+			ToPort:         int64(s.scope.APIServerPort()),
+			IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+		})
+	}
+	return rules
 }
 
 func (s *Service) getIngressRuleToAllowAnyIPInTheAPIServer() infrav1.IngressRules {
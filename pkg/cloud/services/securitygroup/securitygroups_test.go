@@ -839,6 +839,7 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			cluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region:      "us-east-1",
 					NetworkSpec: *tc.input,
 				},
 			}
@@ -879,7 +880,7 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 		Cluster: &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
 		},
-		AWSCluster: &infrav1.AWSCluster{},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
 	})
 	if err != nil {
 		t.Fatalf("Failed to create test context: %v", err)
@@ -1022,6 +1023,7 @@ func TestAdditionalControlPlaneSecurityGroup(t *testing.T) {
 				},
 				AWSCluster: &infrav1.AWSCluster{
 					Spec: infrav1.AWSClusterSpec{
+						Region:      "us-east-1",
 						NetworkSpec: tc.networkSpec,
 					},
 					Status: infrav1.AWSClusterStatus{
@@ -1086,12 +1088,14 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 	testCases := []struct {
 		name                string
 		awsCluster          *infrav1.AWSCluster
+		role                infrav1.SecurityGroupRole
 		expectedIngresRules infrav1.IngressRules
 	}{
 		{
 			name: "when no ingress rules are passed and nat gateway IPs are not available, the default is set",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region:                   "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{},
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
@@ -1115,6 +1119,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			name: "when no ingress rules are passed and nat gateway IPs are not available, the default for IPv6 is set",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region:                   "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{},
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
@@ -1139,6 +1144,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			name: "when no ingress rules are passed, allow the Nat Gateway IPs and default to allow all",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region:                   "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{},
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
@@ -1169,10 +1175,56 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "when no ingress rules are passed to a dual-stack load balancer, allow the Nat Gateway IPs and default to allow all over IPv4 and IPv6",
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+						IPAddressType:    infrav1.LoadBalancerIPAddressTypeDualStack,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							CidrBlock: "10.0.0.0/16",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						NatGatewaysIPs: []string{"1.2.3.4"},
+					},
+				},
+			},
+			expectedIngresRules: infrav1.IngressRules{
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{"1.2.3.4/32"},
+				},
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{services.AnyIPv4CidrBlock},
+				},
+				infrav1.IngressRule{
+					Description:    "Kubernetes API IPv6",
+					Protocol:       infrav1.SecurityGroupProtocolTCP,
+					FromPort:       6443,
+					ToPort:         6443,
+					IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+				},
+			},
+		},
 		{
 			name: "defined rules are used",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						IngressRules: infrav1.IngressRules{
 							{
@@ -1217,6 +1269,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			name: "when no ingress rules are passed while using internal LB",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Scheme: &infrav1.ELBSchemeInternal,
 					},
@@ -1248,6 +1301,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			name: "when no ingress rules are passed while using internal LB and IPv6",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						Scheme: &infrav1.ELBSchemeInternal,
 					},
@@ -1281,6 +1335,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			name: "defined rules are used while using internal LB",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						IngressRules: infrav1.IngressRules{
 							{
@@ -1318,9 +1373,10 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			},
 		},
 		{
-			name: "defined rules are used when using internal and external LB",
+			name: "only the rules of the primary LB are used when using internal and external LB",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
 						IngressRules: []infrav1.IngressRule{
 							{
@@ -1366,6 +1422,52 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 					ToPort:      1234,
 					CidrBlocks:  []string{"172.126.1.1/0"},
 				},
+			},
+		},
+		{
+			name: "only the rules of the secondary LB are used for its security group",
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					Region: "us-east-1",
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						IngressRules: []infrav1.IngressRule{
+							{
+								Description: "My custom ingress rule",
+								Protocol:    infrav1.SecurityGroupProtocolTCP,
+								FromPort:    1234,
+								ToPort:      1234,
+								CidrBlocks:  []string{"172.126.1.1/0"},
+							},
+						},
+						Scheme: &infrav1.ELBSchemeInternal,
+					},
+					SecondaryControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						IngressRules: []infrav1.IngressRule{
+							{
+								Description: "Another custom ingress rule",
+								Protocol:    infrav1.SecurityGroupProtocolTCP,
+								FromPort:    2345,
+								ToPort:      2345,
+								CidrBlocks:  []string{"0.0.0.0/0"},
+							},
+						},
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							CidrBlock: "10.0.0.0/16",
+						},
+					},
+				},
+			},
+			role: infrav1.SecurityGroupSecondaryAPIServerLB,
+			expectedIngresRules: infrav1.IngressRules{
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{"0.0.0.0/0"},
+				},
 				infrav1.IngressRule{
 					Description: "Another custom ingress rule",
 					Protocol:    infrav1.SecurityGroupProtocolTCP,
@@ -1390,8 +1492,12 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 				t.Fatalf("Failed to create test context: %v", err)
 			}
 
+			role := tc.role
+			if role == "" {
+				role = infrav1.SecurityGroupAPIServerLB
+			}
 			s := NewService(cs, testSecurityGroupRoles)
-			rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupAPIServerLB)
+			rules, err := s.getSecurityGroupIngressRules(role)
 			if err != nil {
 				t.Fatalf("Failed to lookup controlplane load balancer security group ingress rules: %v", err)
 			}
@@ -1553,6 +1659,7 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			awsCluster := &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
+					Region:      "us-east-1",
 					NetworkSpec: *tc.input,
 				},
 			}
@@ -18,12 +18,20 @@ limitations under the License.
 package securitygroup
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/keymutex"
 )
 
+// clusterLocks serializes the changes to the security groups of a cluster. The rules of a security
+// group are edited with read-modify-write sequences, which race when several reconciles of the same
+// cluster run concurrently.
+var clusterLocks keymutex.KeyMutex
+
 // Service holds a collection of interfaces.
 // The interfaces are broken down like this to group functions together.
 // One alternative is to have a large list of functions from the ec2 client.
@@ -42,3 +50,8 @@ func NewService(sgScope scope.SGScope, roles []infrav1.SecurityGroupRole) *Servi
 		EC2Client: scope.NewEC2Client(sgScope, sgScope, sgScope, sgScope.InfraCluster()),
 	}
 }
+
+// lockCluster locks the security groups of the cluster until the returned function is called.
+func (s *Service) lockCluster() (unlock func()) {
+	return clusterLocks.Lock(fmt.Sprintf("%s/%s", s.scope.Namespace(), s.scope.Name()))
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package ssm
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"regexp"
 
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ssm"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
@@ -44,7 +46,7 @@ const (
 var (
 	prefixRe        = regexp.MustCompile(`(?i)^[\/]?(aws|ssm)[.]?`)
 	retryableErrors = []string{
-		ssm.ErrCodeParameterLimitExceeded,
+		(&types.ParameterLimitExceeded{}).ErrorCode(),
 	}
 )
 
@@ -66,6 +68,9 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	prefix := m.GetSecretPrefix()
 	if prefix == "" {
 		prefix = path.Join(entryPrefix, string(uuid.NewUUID()))
+		if clusterPrefix := m.InfraCluster.SSMParameterPrefix(); clusterPrefix != "" {
+			prefix = path.Join(clusterPrefix, s.scope.Name(), string(uuid.NewUUID()))
+		}
 	}
 	// SSM Validation does not allow (/)aws|ssm in the beginning of the string
 	prefix = prefixRe.ReplaceAllString(prefix, "")
@@ -93,11 +98,11 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
 func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags) (bool, error) {
-	_, err := s.SSMClient.PutParameter(&ssm.PutParameterInput{
+	_, err := s.SSMClient.PutParameter(context.TODO(), &ssm.PutParameterInput{
 		Name:  aws.String(name),
 		Value: aws.String(string(chunk)),
 		Tags:  converters.MapToSSMTags(tags),
-		Type:  aws.String("SecureString"),
+		Type:  types.ParameterTypeSecureString,
 	})
 	if err != nil {
 		return false, err
@@ -107,7 +112,7 @@ func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.
 
 // forceDeleteSecretEntry deletes a single secret, ignoring if it is absent.
 func (s *Service) forceDeleteSecretEntry(name string) error {
-	_, err := s.SSMClient.DeleteParameter(&ssm.DeleteParameterInput{
+	_, err := s.SSMClient.DeleteParameter(context.TODO(), &ssm.DeleteParameterInput{
 		Name: aws.String(name),
 	})
 	if awserrors.IsNotFound(err) {
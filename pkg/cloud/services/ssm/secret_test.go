@@ -17,15 +17,19 @@ limitations under the License.
 package ssm
 
 import (
+	"context"
 	"crypto/rand"
 	"sort"
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,7 +39,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
-	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssmiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm/mock_ssm"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -61,13 +65,13 @@ func TestServiceCreate(t *testing.T) {
 		}
 	}
 
-	sortTagsByKey := func(tags []*ssm.Tag) {
+	sortTagsByKey := func(tags []types.Tag) {
 		sort.Slice(tags, func(i, j int) bool {
 			return *(tags[i].Key) < *(tags[j].Key)
 		})
 	}
 
-	expectedTags := []*ssm.Tag{
+	expectedTags := []types.Tag{
 		{
 			Key:   aws.String("Name"),
 			Value: aws.String("infra-cluster"),
@@ -87,12 +91,13 @@ func TestServiceCreate(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		bytesCount     int64
-		secretPrefix   string
-		expectedPrefix string
-		wantErr        bool
-		expect         func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		name               string
+		bytesCount         int64
+		secretPrefix       string
+		ssmParameterPrefix string
+		expectedPrefix     string
+		wantErr            bool
+		expect             func(m *mock_ssm.MockSSMAPIMockRecorder)
 	}{
 		{
 			name:           "Should not store data in SSM if data is having zero bytes",
@@ -105,14 +110,14 @@ func TestServiceCreate(t *testing.T) {
 			bytesCount:     10000,
 			secretPrefix:   "prefix",
 			expectedPrefix: "/prefix",
-			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).MinTimes(1).Return(&ssm.PutParameterOutput{}, nil).Do(
-					func(putParameterInput *ssm.PutParameterInput) {
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.PutParameter(gomock.Any(), gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).MinTimes(1).Return(&ssm.PutParameterOutput{}, nil).Do(
+					func(_ context.Context, putParameterInput *ssm.PutParameterInput, _ ...func(*ssm.Options)) {
 						if !strings.HasPrefix(*(putParameterInput.Name), "/prefix/") {
 							t.Fatalf("Prefix is not as expected: %v", putParameterInput.Name)
 						}
 						sortTagsByKey(putParameterInput.Tags)
-						if !cmp.Equal(putParameterInput.Tags, expectedTags) {
+						if !cmp.Equal(putParameterInput.Tags, expectedTags, cmpopts.IgnoreUnexported(types.Tag{})) {
 							t.Fatalf("Tags are not as expected, actual: %v, expected: %v", putParameterInput.Tags, expectedTags)
 						}
 					},
@@ -125,14 +130,14 @@ func TestServiceCreate(t *testing.T) {
 			secretPrefix:   "/prefix",
 			expectedPrefix: "/prefix",
 			wantErr:        true,
-			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(nil, &ssm.ParameterAlreadyExists{}).Do(
-					func(putParameterInput *ssm.PutParameterInput) {
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.PutParameter(gomock.Any(), gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(nil, &types.ParameterAlreadyExists{}).Do(
+					func(_ context.Context, putParameterInput *ssm.PutParameterInput, _ ...func(*ssm.Options)) {
 						if !strings.HasPrefix(*(putParameterInput.Name), "/prefix/") {
 							t.Fatalf("Prefix is not as expected: %v", putParameterInput.Name)
 						}
 						sortTagsByKey(putParameterInput.Tags)
-						if !cmp.Equal(putParameterInput.Tags, expectedTags) {
+						if !cmp.Equal(putParameterInput.Tags, expectedTags, cmpopts.IgnoreUnexported(types.Tag{})) {
 							t.Fatalf("Tags are not as expected, actual: %v, expected: %v", putParameterInput.Tags, expectedTags)
 						}
 					},
@@ -144,9 +149,27 @@ func TestServiceCreate(t *testing.T) {
 			bytesCount:     10,
 			secretPrefix:   "",
 			expectedPrefix: "/cluster.x-k8s.io",
-			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(nil, &ssm.ParameterLimitExceeded{})
-				m.PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(&ssm.PutParameterOutput{}, nil)
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.PutParameter(gomock.Any(), gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(nil, &types.ParameterLimitExceeded{})
+				m.PutParameter(gomock.Any(), gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(&ssm.PutParameterOutput{}, nil)
+			},
+		},
+		{
+			name:               "Should store data under the SSM parameter prefix of the cluster",
+			bytesCount:         10,
+			ssmParameterPrefix: "/bootstrap",
+			expectedPrefix:     "/bootstrap/test/",
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.PutParameter(gomock.Any(), gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).Return(&ssm.PutParameterOutput{}, nil).Do(
+					func(_ context.Context, putParameterInput *ssm.PutParameterInput, _ ...func(*ssm.Options)) {
+						if !strings.HasPrefix(*(putParameterInput.Name), "/bootstrap/test/") {
+							t.Fatalf("Prefix is not as expected: %v", putParameterInput.Name)
+						}
+						if putParameterInput.Type != types.ParameterTypeSecureString {
+							t.Fatalf("Type is not as expected: %v", putParameterInput.Type)
+						}
+					},
+				)
 			},
 		},
 	}
@@ -159,7 +182,8 @@ func TestServiceCreate(t *testing.T) {
 
 			clusterScope, err := getClusterScope(client)
 			g.Expect(err).NotTo(HaveOccurred())
-			ssmClientMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			clusterScope.AWSCluster.Spec.SSMParameterPrefix = tt.ssmParameterPrefix
+			ssmClientMock := mock_ssm.NewMockSSMAPI(mockCtrl)
 			if tt.expect != nil {
 				tt.expect(ssmClientMock.EXPECT())
 			}
@@ -184,35 +208,44 @@ func TestServiceDelete(t *testing.T) {
 	tests := []struct {
 		name        string
 		secretCount int32
-		expect      func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		expect      func(m *mock_ssm.MockSSMAPIMockRecorder)
 		wantErr     bool
 		check       func(error)
 	}{
 		{
 			name:        "Should not call AWS when secret count has zero value",
 			secretCount: 0,
-			expect:      func(m *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expect:      func(m *mock_ssm.MockSSMAPIMockRecorder) {},
 		},
 		{
 			name:        "Should not return error when delete is successful",
 			secretCount: 1,
-			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.DeleteParameter(gomock.Any(), gomock.Eq(&ssm.DeleteParameterInput{
 					Name: aws.String("prefix/0"),
 				})).Return(&ssm.DeleteParameterOutput{}, nil)
 			},
 		},
+		{
+			name:        "Should not return error when the secret is already deleted",
+			secretCount: 1,
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.DeleteParameter(gomock.Any(), gomock.Eq(&ssm.DeleteParameterInput{
+					Name: aws.String("prefix/0"),
+				})).Return(nil, &smithy.OperationError{ServiceID: "SSM", OperationName: "DeleteParameter", Err: &types.ParameterNotFound{}})
+			},
+		},
 		{
 			name:        "Should return all errors except not found errors",
 			secretCount: 3,
-			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
-				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+			expect: func(m *mock_ssm.MockSSMAPIMockRecorder) {
+				m.DeleteParameter(gomock.Any(), gomock.Eq(&ssm.DeleteParameterInput{
 					Name: aws.String("prefix/0"),
 				})).Return(nil, awserrors.NewFailedDependency("failed dependency"))
-				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+				m.DeleteParameter(gomock.Any(), gomock.Eq(&ssm.DeleteParameterInput{
 					Name: aws.String("prefix/1"),
 				})).Return(nil, awserrors.NewNotFound("not found"))
-				m.DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{
+				m.DeleteParameter(gomock.Any(), gomock.Eq(&ssm.DeleteParameterInput{
 					Name: aws.String("prefix/2"),
 				})).Return(nil, awserrors.NewConflict("new conflict"))
 			},
@@ -234,7 +267,7 @@ func TestServiceDelete(t *testing.T) {
 			clusterScope, err := getClusterScope(client)
 			g.Expect(err).NotTo(HaveOccurred())
 
-			ssmClientMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+			ssmClientMock := mock_ssm.NewMockSSMAPI(mockCtrl)
 			tt.expect(ssmClientMock.EXPECT())
 			s := NewService(clusterScope)
 			s.SSMClient = ssmClientMock
@@ -266,7 +299,7 @@ func getClusterScope(client client.Client) (*scope.ClusterScope, error) {
 	return scope.NewClusterScope(scope.ClusterScopeParams{
 		Client:     client,
 		Cluster:    cluster,
-		AWSCluster: &infrav1.AWSCluster{},
+		AWSCluster: &infrav1.AWSCluster{Spec: infrav1.AWSClusterSpec{Region: "us-east-1"}},
 	})
 }
 
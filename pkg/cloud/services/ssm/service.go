@@ -17,7 +17,9 @@ limitations under the License.
 package ssm
 
 import (
-	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -28,13 +30,19 @@ import (
 // One alternative is to have a large list of functions from the ec2 client.
 type Service struct {
 	scope     cloud.ClusterScoper
-	SSMClient ssmiface.SSMAPI
+	SSMClient SSMAPI
+}
+
+// SSMAPI is the part of the AWS SDK for Go v2 SSM API client used by the service.
+type SSMAPI interface {
+	PutParameter(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	DeleteParameter(ctx context.Context, params *ssm.DeleteParameterInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
 }
 
 // NewService returns a new service given the api clients.
 func NewService(secretsScope cloud.ClusterScoper) *Service {
 	return &Service{
 		scope:     secretsScope,
-		SSMClient: scope.NewSSMClient(secretsScope, secretsScope, secretsScope, secretsScope.InfraCluster()),
+		SSMClient: scope.NewSSMClientV2(secretsScope, secretsScope, secretsScope, secretsScope.InfraCluster()),
 	}
 }
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+)
+
+// ec2LaunchPrefixes are the prefixes of the user data already in a format supported by EC2Launch:
+// XML-like <powershell> or <script> tags, or an EC2Launch v2 agent configuration.
+var ec2LaunchPrefixes = [][]byte{
+	[]byte("<powershell>"),
+	[]byte("<script>"),
+	[]byte("version:"),
+}
+
+// WindowsUserData formats the user data of a Windows instance for EC2Launch. User data that isn't
+// already in a format supported by EC2Launch is run as a PowerShell script on the first boot.
+func WindowsUserData(data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	for _, prefix := range ec2LaunchPrefixes {
+		if bytes.HasPrefix(trimmed, prefix) {
+			return data
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<powershell>\n")
+	buf.Write(trimmed)
+	buf.WriteString("\n</powershell>\n<persist>false</persist>\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWindowsUserData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "wraps a script in powershell tags",
+			data: "Write-Host hello\n",
+			want: "<powershell>\nWrite-Host hello\n</powershell>\n<persist>false</persist>\n",
+		},
+		{
+			name: "keeps powershell user data",
+			data: "<powershell>\nWrite-Host hello\n</powershell>",
+			want: "<powershell>\nWrite-Host hello\n</powershell>",
+		},
+		{
+			name: "keeps EC2Launch v2 configuration",
+			data: "version: 1.0\ntasks: []\n",
+			want: "version: 1.0\ntasks: []\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(string(WindowsUserData([]byte(tt.data)))).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+)
+
+// MaxEC2BatchSize is the maximum number of resources of a CreateTags or DeleteTags request.
+const MaxEC2BatchSize = 1000
+
+const (
+	createTagsOperation = "CreateTags"
+	deleteTagsOperation = "DeleteTags"
+)
+
+// DefaultEC2Batcher coalesces the tag updates of the EC2 resources of all the controllers.
+// Batching is disabled until SetEC2BatchWindow is called.
+var DefaultEC2Batcher = NewEC2Batcher(0)
+
+// SetEC2BatchWindow sets how long DefaultEC2Batcher waits for the tag updates of other resources
+// before sending a request. A window of zero disables batching.
+// It should be called before the controllers are started.
+func SetEC2BatchWindow(window time.Duration) {
+	DefaultEC2Batcher.mu.Lock()
+	defer DefaultEC2Batcher.mu.Unlock()
+
+	DefaultEC2Batcher.window = window
+}
+
+// EC2Batcher coalesces the tag updates of EC2 resources applying the same tags, such as the
+// instances of the machines of a cluster when its additional tags change, into CreateTags and
+// DeleteTags requests of up to MaxEC2BatchSize resources.
+// It is safe for concurrent use.
+type EC2Batcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[ec2BatchKey]*ec2Batch
+}
+
+// ec2BatchKey identifies the tag updates that can be sent in the same request.
+// Sessions are bound to a region and to credentials, so only the resources of
+// the same account are batched together.
+type ec2BatchKey struct {
+	session   interface{}
+	operation string
+	tags      string
+}
+
+type ec2Batch struct {
+	client      ec2iface.EC2API
+	tags        []*ec2.Tag
+	resourceIDs []*string
+	done        chan struct{}
+	err         error
+	// ctxs holds the context of the caller waiting for every resource.
+	ctxs []context.Context
+	// errs holds the error of every resource when they had to be sent one by one.
+	errs []error
+}
+
+// NewEC2Batcher returns a batcher waiting for the given window before sending a request.
+// A window of zero disables batching.
+func NewEC2Batcher(window time.Duration) *EC2Batcher {
+	return &EC2Batcher{
+		window:  window,
+		pending: map[ec2BatchKey]*ec2Batch{},
+	}
+}
+
+// CreateTags creates or updates the tags of a resource, in the same request as the
+// resources of the session getting the same tags within the batch window.
+func (b *EC2Batcher) CreateTags(ctx context.Context, session interface{}, client ec2iface.EC2API, resourceID *string, tags infrav1.Tags) error {
+	return b.do(ctx, session, client, createTagsOperation, resourceID, tags)
+}
+
+// DeleteTags deletes the tags of a resource, in the same request as the
+// resources of the session losing the same tags within the batch window.
+func (b *EC2Batcher) DeleteTags(ctx context.Context, session interface{}, client ec2iface.EC2API, resourceID *string, tags infrav1.Tags) error {
+	return b.do(ctx, session, client, deleteTagsOperation, resourceID, tags)
+}
+
+func (b *EC2Batcher) do(ctx context.Context, session interface{}, client ec2iface.EC2API, operation string, resourceID *string, tags infrav1.Tags) error {
+	b.mu.Lock()
+	if b.window == 0 {
+		b.mu.Unlock()
+		return send(ctx, client, operation, []*string{resourceID}, converters.MapToTags(tags))
+	}
+
+	key := ec2BatchKey{session: session, operation: operation, tags: tagsKey(tags)}
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &ec2Batch{
+			client: client,
+			tags:   converters.MapToTags(tags),
+			done:   make(chan struct{}),
+		}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key, batch) })
+	}
+	index := len(batch.resourceIDs)
+	batch.resourceIDs = append(batch.resourceIDs, resourceID)
+	batch.ctxs = append(batch.ctxs, ctx)
+	full := len(batch.resourceIDs) >= MaxEC2BatchSize
+	b.mu.Unlock()
+
+	if full {
+		b.flush(key, batch)
+	}
+
+	select {
+	case <-batch.done:
+		if batch.errs != nil {
+			return batch.errs[index]
+		}
+		return batch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush sends the request of a batch, unless it was already sent.
+func (b *EC2Batcher) flush(key ec2BatchKey, batch *ec2Batch) {
+	b.mu.Lock()
+	if b.pending[key] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	b.mu.Unlock()
+	defer close(batch.done)
+
+	// The callers whose context is done have stopped waiting, their resources are not sent.
+	var resourceIDs []*string
+	var ctxs []context.Context
+	for i, id := range batch.resourceIDs {
+		if batch.ctxs[i].Err() == nil {
+			resourceIDs = append(resourceIDs, id)
+			ctxs = append(ctxs, batch.ctxs[i])
+		}
+	}
+	if len(resourceIDs) == 0 {
+		return
+	}
+
+	ctx, cancel := waitersContext(ctxs)
+	defer cancel()
+
+	operation := key.operation
+	batch.err = send(ctx, batch.client, operation, resourceIDs, batch.tags)
+	if batch.err != nil && len(resourceIDs) > 1 {
+		// A single resource, for example an instance terminated in the meantime, fails the whole
+		// request. Retry the resources one by one so the others are still tagged.
+		batch.errs = make([]error, len(batch.resourceIDs))
+		for i, id := range batch.resourceIDs {
+			if err := batch.ctxs[i].Err(); err != nil {
+				batch.errs[i] = err
+				continue
+			}
+			batch.errs[i] = send(batch.ctxs[i], batch.client, operation, []*string{id}, batch.tags)
+		}
+	}
+}
+
+// waitersContext returns the context of a request sent for several callers. It carries the values of
+// the context of the first caller, and is cancelled once the contexts of all the callers are done.
+func waitersContext(ctxs []context.Context) (context.Context, context.CancelFunc) {
+	if len(ctxs) == 1 {
+		return context.WithCancel(ctxs[0])
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctxs[0]))
+	go func() {
+		for _, c := range ctxs {
+			select {
+			case <-c.Done():
+			case <-ctx.Done():
+				return
+			}
+		}
+		cancel()
+	}()
+	return ctx, cancel
+}
+
+func send(ctx context.Context, client ec2iface.EC2API, operation string, resourceIDs []*string, tags []*ec2.Tag) error {
+	var err error
+	switch operation {
+	case createTagsOperation:
+		_, err = client.CreateTagsWithContext(ctx, &ec2.CreateTagsInput{
+			Resources: resourceIDs,
+			Tags:      tags,
+		})
+	case deleteTagsOperation:
+		_, err = client.DeleteTagsWithContext(ctx, &ec2.DeleteTagsInput{
+			Resources: resourceIDs,
+			Tags:      tags,
+		})
+	}
+	return err
+}
+
+// tagsKey returns a canonical representation of tags.
+func tagsKey(tags infrav1.Tags) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte(0)
+		sb.WriteString(tags[k])
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestEC2BatcherCreateTags(t *testing.T) {
+	additionalTags := infrav1.Tags{"team": "infra"}
+
+	testCases := []struct {
+		name      string
+		window    time.Duration
+		resources int
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr func(resourceID string) bool
+	}{
+		{
+			name:      "sends every update on its own when batching is disabled",
+			window:    0,
+			resources: 3,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateTagsInput, _ ...request.Option) (*ec2.CreateTagsOutput, error) {
+						if len(input.Resources) != 1 {
+							return nil, errors.New("unexpected batch")
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					}).Times(3)
+			},
+			expectErr: func(string) bool { return false },
+		},
+		{
+			name:      "sends the updates of the window in one request",
+			window:    time.Second,
+			resources: 3,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateTagsInput, _ ...request.Option) (*ec2.CreateTagsOutput, error) {
+						if len(input.Resources) != 3 || len(input.Tags) != 1 || *input.Tags[0].Key != "team" {
+							return nil, errors.New("unexpected batch")
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					})
+			},
+			expectErr: func(string) bool { return false },
+		},
+		{
+			name:      "sends a full batch without waiting for the end of the window",
+			window:    time.Hour,
+			resources: MaxEC2BatchSize,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(gomock.Any(), gomock.Any()).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			expectErr: func(string) bool { return false },
+		},
+		{
+			name:      "retries the resources one by one when the batch fails",
+			window:    time.Second,
+			resources: 3,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateTagsInput, _ ...request.Option) (*ec2.CreateTagsOutput, error) {
+						for _, id := range input.Resources {
+							if *id == "i-1" {
+								return nil, errors.New("InvalidInstanceID.NotFound")
+							}
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					}).Times(4)
+			},
+			expectErr: func(resourceID string) bool { return resourceID == "i-1" },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			batcher := NewEC2Batcher(tc.window)
+			errs := make([]error, tc.resources)
+			var wg sync.WaitGroup
+			for i := 0; i < tc.resources; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					errs[i] = batcher.CreateTags(context.TODO(), "session", ec2Mock, aws.String(fmt.Sprintf("i-%d", i)), additionalTags)
+				}(i)
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if tc.expectErr(fmt.Sprintf("i-%d", i)) {
+					g.Expect(err).To(HaveOccurred())
+				} else {
+					g.Expect(err).NotTo(HaveOccurred())
+				}
+			}
+			g.Expect(batcher.pending).To(BeEmpty())
+		})
+	}
+}
+
+func TestEC2BatcherSeparatesSessionsAndTags(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().CreateTagsWithContext(gomock.Any(), gomock.Any()).Return(&ec2.CreateTagsOutput{}, nil).Times(3)
+
+	batcher := NewEC2Batcher(100 * time.Millisecond)
+	updates := []struct {
+		session string
+		tags    infrav1.Tags
+	}{
+		{session: "cluster-a", tags: infrav1.Tags{"team": "infra"}},
+		{session: "cluster-b", tags: infrav1.Tags{"team": "infra"}},
+		{session: "cluster-a", tags: infrav1.Tags{"team": "apps"}},
+	}
+
+	var wg sync.WaitGroup
+	for i, u := range updates {
+		wg.Add(1)
+		go func(i int, session string, tags infrav1.Tags) {
+			defer wg.Done()
+			g.Expect(batcher.CreateTags(context.TODO(), session, ec2Mock, aws.String(fmt.Sprintf("i-%d", i)), tags)).To(Succeed())
+		}(i, u.session, u.tags)
+	}
+	wg.Wait()
+}
+
+func TestEC2BatcherSendsWithTheContextOfTheCallers(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "reconcile")
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().CreateTagsWithContext(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(reqCtx context.Context, input *ec2.CreateTagsInput, _ ...request.Option) (*ec2.CreateTagsOutput, error) {
+			if reqCtx.Value(ctxKey{}) != "reconcile" || reqCtx.Err() != nil {
+				return nil, errors.New("unexpected context")
+			}
+			if len(input.Resources) != 1 || *input.Resources[0] != "i-0" {
+				return nil, errors.New("unexpected batch")
+			}
+			return &ec2.CreateTagsOutput{}, nil
+		})
+
+	batcher := NewEC2Batcher(100 * time.Millisecond)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		g.Expect(batcher.CreateTags(ctx, "session", ec2Mock, aws.String("i-0"), infrav1.Tags{"team": "infra"})).To(Succeed())
+	}()
+	go func() {
+		defer wg.Done()
+		g.Expect(batcher.CreateTags(cancelledCtx, "session", ec2Mock, aws.String("i-1"), infrav1.Tags{"team": "infra"})).To(MatchError(context.Canceled))
+	}()
+	wg.Wait()
+}
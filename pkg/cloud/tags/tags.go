@@ -46,7 +46,7 @@ type BuilderOption func(*Builder)
 // Builder is the interface for a tags builder.
 type Builder struct {
 	params    *infrav1.BuildParams
-	applyFunc func(params *infrav1.BuildParams) error
+	applyFunc func(ctx context.Context, params *infrav1.BuildParams) error
 }
 
 // New creates a new TagsBuilder with the specified build parameters
@@ -64,7 +64,7 @@ func New(params *infrav1.BuildParams, opts ...BuilderOption) *Builder {
 }
 
 // Apply tags a resource with tags including the cluster tag.
-func (b *Builder) Apply() error {
+func (b *Builder) Apply(ctx context.Context) error {
 	if b.params == nil {
 		return ErrBuildParamsRequired
 	}
@@ -72,19 +72,19 @@ func (b *Builder) Apply() error {
 		return ErrApplyFuncRequired
 	}
 
-	if err := b.applyFunc(b.params); err != nil {
+	if err := b.applyFunc(ctx, b.params); err != nil {
 		return fmt.Errorf("failed applying tags: %w", err)
 	}
 	return nil
 }
 
 // Ensure applies the tags if the current tags differ from the params.
-func (b *Builder) Ensure(current infrav1.Tags) error {
+func (b *Builder) Ensure(ctx context.Context, current infrav1.Tags) error {
 	if b.params == nil {
 		return ErrBuildParamsRequired
 	}
 	if diff := computeDiff(current, *b.params); len(diff) > 0 {
-		return b.Apply()
+		return b.Apply(ctx)
 	}
 	return nil
 }
@@ -92,7 +92,7 @@ func (b *Builder) Ensure(current infrav1.Tags) error {
 // WithEC2 is used to denote that the tags builder will be using EC2.
 func WithEC2(ec2client ec2iface.EC2API) BuilderOption {
 	return func(b *Builder) {
-		b.applyFunc = func(params *infrav1.BuildParams) error {
+		b.applyFunc = func(ctx context.Context, params *infrav1.BuildParams) error {
 			tags := infrav1.Build(*params)
 			awsTags := make([]*ec2.Tag, 0, len(tags))
 
@@ -116,7 +116,7 @@ func WithEC2(ec2client ec2iface.EC2API) BuilderOption {
 				Tags:      awsTags,
 			}
 
-			_, err := ec2client.CreateTagsWithContext(context.TODO(), createTagsInput)
+			_, err := ec2client.CreateTagsWithContext(ctx, createTagsInput)
 			return errors.Wrapf(err, "failed to tag resource %q in cluster %q", params.ResourceID, params.ClusterName)
 		}
 	}
@@ -125,7 +125,7 @@ func WithEC2(ec2client ec2iface.EC2API) BuilderOption {
 // WithEKS is used to specify that the tags builder will be targeting EKS.
 func WithEKS(eksclient eksiface.EKSAPI) BuilderOption {
 	return func(b *Builder) {
-		b.applyFunc = func(params *infrav1.BuildParams) error {
+		b.applyFunc = func(_ context.Context, params *infrav1.BuildParams) error {
 			tags := infrav1.Build(*params)
 
 			eksTags := make(map[string]*string, len(tags))
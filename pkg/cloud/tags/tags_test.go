@@ -181,7 +181,7 @@ func TestTagsEnsureWithEC2(t *testing.T) {
 			} else {
 				builder = New(tc.builder.params, func(builder *Builder) {})
 			}
-			err := builder.Ensure(nil)
+			err := builder.Ensure(context.TODO(), nil)
 			if err != nil {
 				g.Expect(err).To(Not(BeNil()))
 			} else {
@@ -231,7 +231,7 @@ func TestTagsEnsureWithEKS(t *testing.T) {
 			} else {
 				builder = New(tc.builder.params, func(builder *Builder) {})
 			}
-			err := builder.Ensure(nil)
+			err := builder.Ensure(context.TODO(), nil)
 			if err != nil {
 				g.Expect(err).To(Not(BeNil()))
 			} else {
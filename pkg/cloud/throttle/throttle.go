@@ -20,10 +20,13 @@ package throttle
 import (
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/request"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/metrics"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/rate"
 )
 
@@ -39,27 +42,36 @@ func NewMultiOperationMatch(strs ...string) string {
 }
 
 // OperationLimiter defines the specs of an operation limiter.
+// Limiters can be shared by the sessions of several clusters, so they are safe for concurrent use.
 type OperationLimiter struct {
+	// Key identifies the limiter in the metrics.
+	Key        string
 	Operation  string
 	RefillRate rate.Limit
 	Burst      int
-	regexp     *regexp.Regexp
-	limiter    *rate.Limiter
+
+	regexpOnce  sync.Once
+	regexp      *regexp.Regexp
+	regexpErr   error
+	limiterOnce sync.Once
+	limiter     *rate.Limiter
 }
 
 // Wait will wait on a request.
 func (o *OperationLimiter) Wait(r *request.Request) error {
-	return o.getLimiter().Wait(r.Context())
+	start := time.Now()
+	err := o.getLimiter().Wait(r.Context())
+	metrics.ObserveRateLimiterWait(o.Key, r.ClientInfo.ServiceID, r.Operation.Name, time.Since(start))
+	return err
 }
 
 // Match will match a request.
 func (o *OperationLimiter) Match(r *request.Request) (bool, error) {
-	if o.regexp == nil {
-		var err error
-		o.regexp, err = regexp.Compile("^" + o.Operation)
-		if err != nil {
-			return false, err
-		}
+	o.regexpOnce.Do(func() {
+		o.regexp, o.regexpErr = regexp.Compile("^" + o.Operation)
+	})
+	if o.regexpErr != nil {
+		return false, o.regexpErr
 	}
 	return o.regexp.MatchString(r.Operation.Name), nil
 }
@@ -72,9 +84,9 @@ func (s ServiceLimiter) LimitRequest(r *request.Request) {
 }
 
 func (o *OperationLimiter) getLimiter() *rate.Limiter {
-	if o.limiter == nil {
+	o.limiterOnce.Do(func() {
 		o.limiter = rate.NewLimiter(o.RefillRate, o.Burst)
-	}
+	})
 	return o.limiter
 }
 
@@ -85,7 +97,7 @@ func (s ServiceLimiter) ReviewResponse(r *request.Request) {
 			switch errorCode {
 			case "Throttling", "RequestLimitExceeded":
 				if ol, ok := s.matchRequest(r); ok {
-					ol.limiter.ResetTokens()
+					ol.getLimiter().ResetTokens()
 				}
 			}
 		}
@@ -239,6 +239,68 @@ func TestEKSAddonPlan(t *testing.T) {
 			expectCreateError: false,
 			expectDoError:     false,
 		},
+		{
+			name: "1 installed and 1 desired - configuration update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					UpdateAddon(gomock.Eq(&eks.UpdateAddonInput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ClusterName:         aws.String(clusterName),
+						ConfigurationValues: aws.String("replicaCount: 3"),
+						ResolveConflicts:    aws.String(eks.ResolveConflictsPreserve),
+					})).
+					Return(&eks.UpdateAddonOutput{
+						Update: &eks.Update{
+							CreatedAt: &created,
+							Id:        aws.String("someid"),
+							Status:    aws.String(addonStatusUpdating),
+							Type:      aws.String(eks.UpdateTypeAddonUpdate),
+						},
+					}, nil)
+
+				out := &eks.DescribeAddonOutput{
+					Addon: &eks.Addon{
+						Status: aws.String(eks.AddonStatusActive),
+					},
+				}
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(out, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				func() *EKSAddon {
+					desired := createDesiredAddon(addon1Name, addon1version)
+					desired.Configuration = aws.String("replicaCount: 3")
+					desired.ResolveConflict = aws.String(eks.ResolveConflictsPreserve)
+					return desired
+				}(),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - empty configuration is not an update",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// Do nothing
+			},
+			desiredAddons: []*EKSAddon{
+				func() *EKSAddon {
+					desired := createDesiredAddon(addon1Name, addon1version)
+					desired.Configuration = aws.String("")
+					return desired
+				}(),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, addon1version, addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
 		{
 			name: "1 installed and 1 desired - version upgrade in progress",
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
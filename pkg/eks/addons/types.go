@@ -17,6 +17,7 @@ limitations under the License.
 package addons
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/google/go-cmp/cmp"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -46,6 +47,10 @@ func (e *EKSAddon) IsEqual(other *EKSAddon, includeTags bool) bool {
 	if !cmp.Equal(e.ServiceAccountRoleARN, other.ServiceAccountRoleARN) {
 		return false
 	}
+	// An addon without configuration may be reported with either an empty or no value.
+	if aws.StringValue(e.Configuration) != aws.StringValue(other.Configuration) {
+		return false
+	}
 
 	if includeTags {
 		diffTags := e.Tags.Difference(other.Tags)
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keymutex
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKeyMutex(t *testing.T) {
+	g := NewWithT(t)
+
+	var m KeyMutex
+	counters := map[string]*int{"cluster-a": new(int), "cluster-b": new(int)}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		for _, key := range []string{"cluster-a", "cluster-b"} {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				unlock := m.Lock(key)
+				defer unlock()
+				// Only the goroutines holding different keys run concurrently, so the
+				// counter of a key is never modified concurrently.
+				*counters[key]++
+			}(key)
+		}
+	}
+	wg.Wait()
+
+	g.Expect(*counters["cluster-a"]).To(Equal(100))
+	g.Expect(*counters["cluster-b"]).To(Equal(100))
+	g.Expect(m.locks).To(BeEmpty())
+}
@@ -14,20 +14,29 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package mime provides a function to generate a multipart MIME document.
+// Package mime provides functions to generate and merge multipart MIME documents.
 package mime
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"html/template"
+	"io"
+	"mime"
 	"mime/multipart"
+	"net/mail"
 	"net/textproto"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 const (
 	includePart = "file:///etc/secret-userdata.txt\n"
+
+	boundaryLength = 32
 )
 
 var (
@@ -39,6 +48,14 @@ var (
 		"content-type": {"text/cloud-boothook"},
 	}
 
+	shellScriptType = textproto.MIMEHeader{
+		"content-type": {"text/x-shellscript"},
+	}
+
+	cloudConfigType = textproto.MIMEHeader{
+		"content-type": {"text/cloud-config"},
+	}
+
 	multipartHeader = strings.Join([]string{
 		"MIME-Version: 1.0",
 		"Content-Type: multipart/mixed; boundary=\"%s\"",
@@ -99,3 +116,76 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// MergeUserData merges the given user data documents into a single multipart MIME document, keeping their order.
+// Each document can either be a multipart MIME document, whose parts are copied as they are, or a single
+// cloud-config or shell script, which is added as a part of the matching content type.
+func MergeUserData(documents ...[]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	// The boundary is derived from the documents, so that merging the same documents always gives the same
+	// result and does not change the user data hash on every reconciliation.
+	hash := sha256.New()
+	for _, document := range documents {
+		hash.Write(document)
+	}
+	if err := mpWriter.SetBoundary(hex.EncodeToString(hash.Sum(nil))[:boundaryLength]); err != nil {
+		return []byte{}, err
+	}
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	for i, document := range documents {
+		if err := writeUserDataParts(mpWriter, document); err != nil {
+			return []byte{}, errors.Wrapf(err, "failed to merge user data document %d", i)
+		}
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeUserDataParts(mpWriter *multipart.Writer, document []byte) error {
+	msg, err := mail.ReadMessage(bytes.NewReader(document))
+	if err != nil {
+		// Not a MIME document, so add it as a single part.
+		return writeUserDataPart(mpWriter, partType(document), bytes.NewReader(document))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return writeUserDataPart(mpWriter, partType(document), bytes.NewReader(document))
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read MIME part")
+		}
+		if err := writeUserDataPart(mpWriter, part.Header, part); err != nil {
+			return err
+		}
+	}
+}
+
+func writeUserDataPart(mpWriter *multipart.Writer, header textproto.MIMEHeader, content io.Reader) error {
+	partWriter, err := mpWriter.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(partWriter, content)
+	return err
+}
+
+func partType(document []byte) textproto.MIMEHeader {
+	if bytes.HasPrefix(document, []byte("#cloud-config")) {
+		return cloudConfigType
+	}
+	return shellScriptType
+}
@@ -18,7 +18,11 @@ package mime
 
 import (
 	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/mail"
+	"reflect"
 	"testing"
 )
 
@@ -30,3 +34,57 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestMergeUserData(t *testing.T) {
+	multipartDoc, err := GenerateInitDocument("secretARN", 1, "eu-west-1", "localhost", "abc123")
+	if err != nil {
+		t.Fatalf("Cannot generate MIME doc: %+v", err)
+	}
+
+	doc, err := MergeUserData(multipartDoc, []byte("#cloud-config\nruncmd:\n  - /etc/eks/bootstrap.sh default_capi\n"))
+	if err != nil {
+		t.Fatalf("Cannot merge user data: %+v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewBuffer(doc))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME content type: %+v", err)
+	}
+
+	var contentTypes []string
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Cannot read MIME part: %+v\n%s", err, string(doc))
+		}
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+
+	expected := []string{"text/cloud-boothook", "text/x-include-url", "text/cloud-config"}
+	if !reflect.DeepEqual(contentTypes, expected) {
+		t.Fatalf("Expected parts %v, got %v", expected, contentTypes)
+	}
+}
+
+func TestMergeUserDataIsDeterministic(t *testing.T) {
+	first, err := MergeUserData([]byte("#!/bin/bash\necho hello\n"), []byte("#cloud-config\n"))
+	if err != nil {
+		t.Fatalf("Cannot merge user data: %+v", err)
+	}
+	second, err := MergeUserData([]byte("#!/bin/bash\necho hello\n"), []byte("#cloud-config\n"))
+	if err != nil {
+		t.Fatalf("Cannot merge user data: %+v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Fatalf("Expected merging the same documents to give the same result:\n%s\n%s", string(first), string(second))
+	}
+}
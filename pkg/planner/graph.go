@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Node is a procedure of a Graph, which is only done once the procedures it depends on are done.
+type Node struct {
+	Procedure
+
+	// DependsOn are the names of the procedures which must be done before this one.
+	DependsOn []string
+}
+
+// NodeState is the state of a node of a Graph once executed.
+type NodeState string
+
+const (
+	// NodeDone is the state of a node whose procedure was done.
+	NodeDone = NodeState("Done")
+	// NodeFailed is the state of a node whose procedure failed.
+	NodeFailed = NodeState("Failed")
+	// NodeBlocked is the state of a node whose procedure wasn't done because some of its dependencies weren't.
+	NodeBlocked = NodeState("Blocked")
+)
+
+// NodeResult is the result of the execution of a node of a Graph.
+type NodeResult struct {
+	// Name is the name of the procedure of the node.
+	Name string
+	// State is the state of the node.
+	State NodeState
+	// Err is the error of the last attempt of a failed node.
+	Err error
+	// BlockedBy are the names of the dependencies which weren't done of a blocked node.
+	BlockedBy []string
+}
+
+// Graph is a plan of procedures which depend on each other. A procedure which fails only stops the procedures
+// which depend on it: the independent branches of the graph are still done.
+//
+// The independent procedures are done concurrently, so the procedures mustn't update the same object: their
+// results are returned once the graph was executed, to be reported then.
+type Graph struct {
+	nodes       []Node
+	retryBudget int
+}
+
+// NewGraph creates a new Graph of nodes, whose failed procedures can be retried retryBudget times overall. It
+// returns an error when the names of the procedures aren't unique, when a node depends on a procedure which isn't
+// part of the graph, or when the dependencies form a cycle.
+func NewGraph(retryBudget int, nodes ...Node) (*Graph, error) {
+	indexes := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		if _, ok := indexes[node.Name()]; ok {
+			return nil, fmt.Errorf("duplicate procedure %q", node.Name())
+		}
+		indexes[node.Name()] = i
+	}
+	for _, node := range nodes {
+		for _, dependency := range node.DependsOn {
+			if _, ok := indexes[dependency]; !ok {
+				return nil, fmt.Errorf("procedure %q depends on unknown procedure %q", node.Name(), dependency)
+			}
+		}
+	}
+
+	// Visit the nodes depth first, a node still being visited being found again is a cycle.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make([]int, len(nodes))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch marks[i] {
+		case visiting:
+			return fmt.Errorf("procedure %q depends on itself", nodes[i].Name())
+		case visited:
+			return nil
+		}
+		marks[i] = visiting
+		for _, dependency := range nodes[i].DependsOn {
+			if err := visit(indexes[dependency]); err != nil {
+				return err
+			}
+		}
+		marks[i] = visited
+		return nil
+	}
+	for i := range nodes {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Graph{nodes: nodes, retryBudget: retryBudget}, nil
+}
+
+// Execute does the procedures of the graph once the procedures they depend on are done, concurrently for the
+// independent branches of the graph. The procedures are done in rounds: the failed procedures are retried in the
+// next round, while the retry budget of the graph isn't exhausted, when other procedures were done in the last
+// round, which can have removed the cause of their failure. It returns the results of the nodes, in their order,
+// and the errors of the failed procedures.
+func (g *Graph) Execute(ctx context.Context) ([]NodeResult, error) {
+	type attempt struct {
+		index int
+		err   error
+	}
+
+	results := make([]NodeResult, len(g.nodes))
+	states := make(map[string]NodeState, len(g.nodes))
+	budget := g.retryBudget
+	attempts := make(chan attempt)
+
+	for retry := false; ; retry = true {
+		attempted := make([]bool, len(g.nodes))
+		running := 0
+		progress := false
+		for {
+			for i, node := range g.nodes {
+				name := node.Name()
+				if attempted[i] || states[name] == NodeDone || !dependenciesDone(node, states) {
+					continue
+				}
+				if states[name] == NodeFailed {
+					if !retry || budget == 0 {
+						continue
+					}
+					budget--
+				}
+
+				attempted[i] = true
+				running++
+				go func(i int, node Node) {
+					// The attempt is reported even when the procedure exits its goroutine without returning.
+					err := fmt.Errorf("procedure %q didn't return", node.Name())
+					defer func() {
+						attempts <- attempt{index: i, err: err}
+					}()
+					// A panic of a procedure fails its node: it can't be recovered by the caller outside
+					// of this goroutine, and would crash the process.
+					defer func() {
+						if r := recover(); r != nil {
+							err = fmt.Errorf("procedure %q panicked: %v [recovered]\n%s", node.Name(), r, debug.Stack())
+						}
+					}()
+					err = node.Do(ctx)
+				}(i, node)
+			}
+			if running == 0 {
+				break
+			}
+
+			done := <-attempts
+			running--
+			results[done.index].Err = done.err
+			if done.err != nil {
+				states[g.nodes[done.index].Name()] = NodeFailed
+				continue
+			}
+			states[g.nodes[done.index].Name()] = NodeDone
+			progress = true
+		}
+
+		if !progress || budget == 0 {
+			break
+		}
+	}
+
+	errs := []error{}
+	for i, node := range g.nodes {
+		results[i].Name = node.Name()
+		switch states[node.Name()] {
+		case NodeDone:
+			results[i].State = NodeDone
+		case NodeFailed:
+			results[i].State = NodeFailed
+			errs = append(errs, results[i].Err)
+		default:
+			results[i].State = NodeBlocked
+			for _, dependency := range node.DependsOn {
+				if states[dependency] != NodeDone {
+					results[i].BlockedBy = append(results[i].BlockedBy, dependency)
+				}
+			}
+		}
+	}
+	return results, kerrors.NewAggregate(errs)
+}
+
+// dependenciesDone returns whether the procedures a node depends on are done.
+func dependenciesDone(node Node, states map[string]NodeState) bool {
+	for _, dependency := range node.DependsOn {
+		if states[dependency] != NodeDone {
+			return false
+		}
+	}
+	return true
+}
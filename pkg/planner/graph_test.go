@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeProcedure fails its first failures attempts, and records its attempts in calls.
+type fakeProcedure struct {
+	name     string
+	failures int
+	calls    *calls
+}
+
+func (p *fakeProcedure) Name() string {
+	return p.name
+}
+
+func (p *fakeProcedure) Do(_ context.Context) error {
+	p.calls.record(p.name)
+	if p.failures > 0 {
+		p.failures--
+		return errors.New(p.name + " failed")
+	}
+	return nil
+}
+
+// calls records the attempts of the procedures of a graph, which can be done concurrently.
+type calls struct {
+	mu       sync.Mutex
+	attempts map[string]int
+	order    []string
+}
+
+func (c *calls) record(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.attempts == nil {
+		c.attempts = map[string]int{}
+	}
+	c.attempts[name]++
+	c.order = append(c.order, name)
+}
+
+func TestNewGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   func(calls *calls) []Node
+		wantErr string
+	}{
+		{
+			name: "graph without cycle",
+			nodes: func(calls *calls) []Node {
+				return []Node{
+					{Procedure: &fakeProcedure{name: "a", calls: calls}},
+					{Procedure: &fakeProcedure{name: "b", calls: calls}, DependsOn: []string{"a"}},
+					{Procedure: &fakeProcedure{name: "c", calls: calls}, DependsOn: []string{"a", "b"}},
+				}
+			},
+		},
+		{
+			name: "duplicate procedure",
+			nodes: func(calls *calls) []Node {
+				return []Node{
+					{Procedure: &fakeProcedure{name: "a", calls: calls}},
+					{Procedure: &fakeProcedure{name: "a", calls: calls}},
+				}
+			},
+			wantErr: `duplicate procedure "a"`,
+		},
+		{
+			name: "unknown dependency",
+			nodes: func(calls *calls) []Node {
+				return []Node{
+					{Procedure: &fakeProcedure{name: "a", calls: calls}, DependsOn: []string{"b"}},
+				}
+			},
+			wantErr: `procedure "a" depends on unknown procedure "b"`,
+		},
+		{
+			name: "cycle",
+			nodes: func(calls *calls) []Node {
+				return []Node{
+					{Procedure: &fakeProcedure{name: "a", calls: calls}, DependsOn: []string{"c"}},
+					{Procedure: &fakeProcedure{name: "b", calls: calls}, DependsOn: []string{"a"}},
+					{Procedure: &fakeProcedure{name: "c", calls: calls}, DependsOn: []string{"b"}},
+				}
+			},
+			wantErr: "depends on itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := NewGraph(0, tt.nodes(&calls{})...)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGraphExecute(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryBudget  int
+		failures     map[string]int
+		wantAttempts map[string]int
+		wantStates   map[string]NodeState
+		wantBlocked  []string
+		wantErr      bool
+	}{
+		{
+			name:         "all procedures are done in dependency order",
+			wantAttempts: map[string]int{"lb": 1, "bastion": 1, "sg": 1, "network": 1},
+			wantStates:   map[string]NodeState{"lb": NodeDone, "bastion": NodeDone, "sg": NodeDone, "network": NodeDone},
+		},
+		{
+			name:         "a failure blocks the dependent procedures but not the independent ones",
+			failures:     map[string]int{"lb": 1},
+			wantAttempts: map[string]int{"lb": 1, "bastion": 1},
+			wantStates:   map[string]NodeState{"lb": NodeFailed, "bastion": NodeDone, "sg": NodeBlocked, "network": NodeBlocked},
+			wantBlocked:  []string{"lb"},
+			wantErr:      true,
+		},
+		{
+			name:         "a failed procedure is retried once another procedure is done",
+			retryBudget:  1,
+			failures:     map[string]int{"lb": 1},
+			wantAttempts: map[string]int{"lb": 2, "bastion": 1, "sg": 1, "network": 1},
+			wantStates:   map[string]NodeState{"lb": NodeDone, "bastion": NodeDone, "sg": NodeDone, "network": NodeDone},
+		},
+		{
+			name:         "a failed procedure isn't retried once the retry budget is exhausted",
+			retryBudget:  1,
+			failures:     map[string]int{"lb": 2},
+			wantAttempts: map[string]int{"lb": 2, "bastion": 1},
+			wantStates:   map[string]NodeState{"lb": NodeFailed, "bastion": NodeDone, "sg": NodeBlocked, "network": NodeBlocked},
+			wantBlocked:  []string{"lb"},
+			wantErr:      true,
+		},
+		{
+			name:         "a failed procedure isn't retried again when no other procedure was done in the last round",
+			retryBudget:  5,
+			failures:     map[string]int{"sg": 5},
+			wantAttempts: map[string]int{"lb": 1, "bastion": 1, "sg": 2},
+			wantStates:   map[string]NodeState{"lb": NodeDone, "bastion": NodeDone, "sg": NodeFailed, "network": NodeBlocked},
+			wantBlocked:  []string{"sg"},
+			wantErr:      true,
+		},
+		{
+			name:         "a failed procedure is only retried in the next round",
+			retryBudget:  5,
+			failures:     map[string]int{"bastion": 5},
+			wantAttempts: map[string]int{"lb": 1, "bastion": 2},
+			wantStates:   map[string]NodeState{"lb": NodeDone, "bastion": NodeFailed, "sg": NodeBlocked, "network": NodeBlocked},
+			wantBlocked:  []string{"bastion"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			calls := &calls{}
+			node := func(name string, dependsOn ...string) Node {
+				return Node{Procedure: &fakeProcedure{name: name, failures: tt.failures[name], calls: calls}, DependsOn: dependsOn}
+			}
+			graph, err := NewGraph(tt.retryBudget,
+				node("lb"),
+				node("bastion"),
+				node("sg", "lb", "bastion"),
+				node("network", "sg"),
+			)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			results, err := graph.Execute(context.TODO())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(calls.attempts).To(Equal(tt.wantAttempts))
+			if tt.wantStates["network"] == NodeDone {
+				g.Expect(calls.order[len(calls.order)-2:]).To(Equal([]string{"sg", "network"}))
+			}
+
+			states := map[string]NodeState{}
+			for _, result := range results {
+				states[result.Name] = result.State
+				if result.Name == "sg" && result.State == NodeBlocked {
+					g.Expect(result.BlockedBy).To(Equal(tt.wantBlocked))
+				}
+			}
+			g.Expect(states).To(Equal(tt.wantStates))
+		})
+	}
+}
+
+// waitingProcedure is only done once all the procedures sharing its barrier started.
+type waitingProcedure struct {
+	name    string
+	barrier *sync.WaitGroup
+}
+
+func (p *waitingProcedure) Name() string {
+	return p.name
+}
+
+func (p *waitingProcedure) Do(ctx context.Context) error {
+	p.barrier.Done()
+	waited := make(chan struct{})
+	go func() {
+		p.barrier.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestGraphExecuteDoesIndependentProceduresConcurrently(t *testing.T) {
+	g := NewWithT(t)
+
+	barrier := &sync.WaitGroup{}
+	barrier.Add(2)
+	graph, err := NewGraph(0,
+		Node{Procedure: &waitingProcedure{name: "lb", barrier: barrier}},
+		Node{Procedure: &waitingProcedure{name: "bastion", barrier: barrier}},
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	results, err := graph.Execute(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(results).To(HaveEach(HaveField("State", NodeDone)))
+}
+
+// panickingProcedure panics when it's done.
+type panickingProcedure struct {
+	name string
+}
+
+func (p *panickingProcedure) Name() string {
+	return p.name
+}
+
+func (p *panickingProcedure) Do(_ context.Context) error {
+	panic(p.name + " panicked")
+}
+
+func TestGraphExecuteFailsPanickingProcedures(t *testing.T) {
+	g := NewWithT(t)
+
+	calls := &calls{}
+	graph, err := NewGraph(0,
+		Node{Procedure: &panickingProcedure{name: "lb"}},
+		Node{Procedure: &fakeProcedure{name: "bastion", calls: calls}},
+		Node{Procedure: &fakeProcedure{name: "sg", calls: calls}, DependsOn: []string{"lb", "bastion"}},
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	results, err := graph.Execute(context.TODO())
+	g.Expect(err).To(MatchError(ContainSubstring("procedure \"lb\" panicked: lb panicked")))
+	g.Expect(results).To(ConsistOf(
+		HaveField("State", NodeFailed),
+		HaveField("State", NodeDone),
+		HaveField("State", NodeBlocked),
+	))
+	g.Expect(calls.attempts).To(Equal(map[string]int{"bastion": 1}))
+}
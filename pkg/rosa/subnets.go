@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+)
+
+const (
+	// InternalLoadBalancerRoleTag is the tag required on the private subnets of a ROSA cluster,
+	// so that the internal load balancers are created in them.
+	InternalLoadBalancerRoleTag = "kubernetes.io/role/internal-elb"
+	// LoadBalancerRoleTag is the tag required on the public subnets of a ROSA cluster,
+	// so that the internet-facing load balancers are created in them.
+	LoadBalancerRoleTag = "kubernetes.io/role/elb"
+
+	anyIPv4CidrBlock = "0.0.0.0/0"
+)
+
+// Subnet is a subnet provided for a ROSA cluster, with the properties ROSA has requirements on.
+type Subnet struct {
+	ID               string
+	VPCID            string
+	AvailabilityZone string
+	Tags             map[string]string
+
+	// Public is whether the subnet has a default route to an internet gateway.
+	Public bool
+	// Egress is whether the subnet has a default route to a NAT gateway, a NAT instance or a transit gateway.
+	Egress bool
+}
+
+// RoleTag returns the tag the subnet requires for the load balancers to be created in it.
+func (s *Subnet) RoleTag() string {
+	if s.Public {
+		return LoadBalancerRoleTag
+	}
+	return InternalLoadBalancerRoleTag
+}
+
+// DescribeSubnets describes the subnets with the specified IDs along with their routing.
+// A validation message is returned when some of the subnets don't exist.
+func DescribeSubnets(ctx context.Context, ec2Client ec2iface.EC2API, subnetIDs []string) ([]Subnet, string, error) {
+	out, err := ec2Client.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.SubnetNotFound {
+			return nil, awserrors.Message(err), nil
+		}
+		return nil, "", fmt.Errorf("failed to describe subnets: %w", err)
+	}
+
+	vpcIDs := []string{}
+	for _, sn := range out.Subnets {
+		if !slices.Contains(vpcIDs, aws.StringValue(sn.VpcId)) {
+			vpcIDs = append(vpcIDs, aws.StringValue(sn.VpcId))
+		}
+	}
+
+	routeTables, err := describeRouteTablesBySubnet(ctx, ec2Client, vpcIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	subnets := make([]Subnet, 0, len(out.Subnets))
+	for _, sn := range out.Subnets {
+		subnet := Subnet{
+			ID:               aws.StringValue(sn.SubnetId),
+			VPCID:            aws.StringValue(sn.VpcId),
+			AvailabilityZone: aws.StringValue(sn.AvailabilityZone),
+			Tags:             map[string]string{},
+		}
+		for _, tag := range sn.Tags {
+			subnet.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+
+		rt, ok := routeTables[subnet.ID]
+		if !ok {
+			// subnets without an explicit association use the main route table of their VPC.
+			rt = routeTables[subnet.VPCID]
+		}
+		if rt != nil {
+			for _, route := range rt.Routes {
+				if aws.StringValue(route.DestinationCidrBlock) != anyIPv4CidrBlock {
+					continue
+				}
+				switch {
+				case strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-"):
+					subnet.Public = true
+				case route.NatGatewayId != nil, route.TransitGatewayId != nil, route.InstanceId != nil, route.NetworkInterfaceId != nil:
+					subnet.Egress = true
+				}
+			}
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	return subnets, "", nil
+}
+
+// describeRouteTablesBySubnet returns the route tables of the VPCs indexed by the subnets they are associated
+// with. The main route tables are indexed by their VPC.
+func describeRouteTablesBySubnet(ctx context.Context, ec2Client ec2iface.EC2API, vpcIDs []string) (map[string]*ec2.RouteTable, error) {
+	routeTables := map[string]*ec2.RouteTable{}
+	if len(vpcIDs) == 0 {
+		return routeTables, nil
+	}
+
+	if err := ec2Client.DescribeRouteTablesPagesWithContext(ctx, &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: aws.StringSlice(vpcIDs),
+		}},
+	}, func(out *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+		for _, rt := range out.RouteTables {
+			for _, association := range rt.Associations {
+				if aws.BoolValue(association.Main) {
+					routeTables[aws.StringValue(rt.VpcId)] = rt
+				}
+				if association.SubnetId != nil {
+					routeTables[aws.StringValue(association.SubnetId)] = rt
+				}
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("failed to describe route tables: %w", err)
+	}
+
+	return routeTables, nil
+}
+
+// ValidateSubnets checks the subnets provided for a ROSA cluster against the requirements of ROSA, and returns a
+// message describing the requirements they don't meet.
+func ValidateSubnets(subnets []Subnet, controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec) string {
+	problems := []string{}
+
+	vpcIDs := []string{}
+	privateZones := []string{}
+	public := []string{}
+	for _, subnet := range subnets {
+		if !slices.Contains(vpcIDs, subnet.VPCID) {
+			vpcIDs = append(vpcIDs, subnet.VPCID)
+		}
+		if subnet.Public {
+			public = append(public, subnet.ID)
+			continue
+		}
+
+		if !slices.Contains(controlPlaneSpec.AvailabilityZones, subnet.AvailabilityZone) {
+			problems = append(problems, fmt.Sprintf("private subnet %s is in availability zone %s which is not one of the availabilityZones", subnet.ID, subnet.AvailabilityZone))
+		}
+		if !subnet.Egress {
+			problems = append(problems, fmt.Sprintf("private subnet %s has no default route to a NAT gateway or a transit gateway", subnet.ID))
+		}
+		privateZones = append(privateZones, subnet.AvailabilityZone)
+	}
+
+	if len(vpcIDs) > 1 {
+		sort.Strings(vpcIDs)
+		problems = append(problems, fmt.Sprintf("subnets must belong to a single VPC, found VPCs %s", strings.Join(vpcIDs, ", ")))
+	}
+	for _, zone := range controlPlaneSpec.AvailabilityZones {
+		if !slices.Contains(privateZones, zone) {
+			problems = append(problems, fmt.Sprintf("availability zone %s has no private subnet", zone))
+		}
+	}
+
+	switch {
+	case controlPlaneSpec.EndpointAccess == rosacontrolplanev1.Private && len(public) > 0:
+		problems = append(problems, fmt.Sprintf("clusters with private endpoint access only support private subnets, %s have a route to an internet gateway", strings.Join(public, ", ")))
+	case controlPlaneSpec.EndpointAccess != rosacontrolplanev1.Private && len(public) == 0:
+		problems = append(problems, "clusters with public endpoint access require a public subnet with a default route to an internet gateway")
+	}
+
+	return strings.Join(problems, "; ")
+}
+
+// TagSubnets applies the role tags ROSA requires to the subnets that don't have them.
+func TagSubnets(ctx context.Context, ec2Client ec2iface.EC2API, subnets []Subnet) error {
+	for _, subnet := range subnets {
+		if _, ok := subnet.Tags[subnet.RoleTag()]; ok {
+			continue
+		}
+
+		if _, err := ec2Client.CreateTagsWithContext(ctx, &ec2.CreateTagsInput{
+			Resources: aws.StringSlice([]string{subnet.ID}),
+			Tags: []*ec2.Tag{{
+				Key:   aws.String(subnet.RoleTag()),
+				Value: aws.String("1"),
+			}},
+		}); err != nil {
+			return fmt.Errorf("failed to tag subnet %s with %s: %w", subnet.ID, subnet.RoleTag(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestDescribeSubnets(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice([]string{"subnet-public", "subnet-private", "subnet-isolated"}),
+	}).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{
+				SubnetId:         aws.String("subnet-public"),
+				VpcId:            aws.String("vpc-1"),
+				AvailabilityZone: aws.String("us-east-1a"),
+				Tags:             []*ec2.Tag{{Key: aws.String(LoadBalancerRoleTag), Value: aws.String("1")}},
+			},
+			{
+				SubnetId:         aws.String("subnet-private"),
+				VpcId:            aws.String("vpc-1"),
+				AvailabilityZone: aws.String("us-east-1a"),
+			},
+			{
+				SubnetId:         aws.String("subnet-isolated"),
+				VpcId:            aws.String("vpc-1"),
+				AvailabilityZone: aws.String("us-east-1b"),
+			},
+		},
+	}, nil)
+	ec2Mock.EXPECT().DescribeRouteTablesPagesWithContext(context.TODO(), &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-1"})}},
+	}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeRouteTablesInput, fn func(*ec2.DescribeRouteTablesOutput, bool) bool, _ ...request.Option) error {
+		fn(&ec2.DescribeRouteTablesOutput{
+			RouteTables: []*ec2.RouteTable{
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+					Routes: []*ec2.Route{
+						{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+						{DestinationCidrBlock: aws.String(anyIPv4CidrBlock), GatewayId: aws.String("igw-1")},
+					},
+				},
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+					Routes: []*ec2.Route{
+						{DestinationCidrBlock: aws.String(anyIPv4CidrBlock), NatGatewayId: aws.String("nat-1")},
+					},
+				},
+				{
+					VpcId:        aws.String("vpc-1"),
+					Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+					Routes: []*ec2.Route{
+						{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+					},
+				},
+			},
+		}, true)
+		return nil
+	})
+
+	subnets, validationMessage, err := DescribeSubnets(context.TODO(), ec2Mock, []string{"subnet-public", "subnet-private", "subnet-isolated"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validationMessage).To(BeEmpty())
+	g.Expect(subnets).To(Equal([]Subnet{
+		{ID: "subnet-public", VPCID: "vpc-1", AvailabilityZone: "us-east-1a", Tags: map[string]string{LoadBalancerRoleTag: "1"}, Public: true},
+		{ID: "subnet-private", VPCID: "vpc-1", AvailabilityZone: "us-east-1a", Tags: map[string]string{}, Egress: true},
+		{ID: "subnet-isolated", VPCID: "vpc-1", AvailabilityZone: "us-east-1b", Tags: map[string]string{}},
+	}))
+}
+
+func TestDescribeSubnetsNotFound(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().DescribeSubnetsWithContext(context.TODO(), gomock.Any()).
+		Return(nil, awserr.New(awserrors.SubnetNotFound, "The subnet ID 'subnet-1' does not exist", nil))
+
+	subnets, validationMessage, err := DescribeSubnets(context.TODO(), ec2Mock, []string{"subnet-1"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(subnets).To(BeEmpty())
+	g.Expect(validationMessage).To(Equal("The subnet ID 'subnet-1' does not exist"))
+}
+
+func TestValidateSubnets(t *testing.T) {
+	publicSubnet := Subnet{ID: "subnet-public", VPCID: "vpc-1", AvailabilityZone: "us-east-1a", Public: true}
+	privateSubnet := Subnet{ID: "subnet-private", VPCID: "vpc-1", AvailabilityZone: "us-east-1a", Egress: true}
+
+	tests := []struct {
+		name           string
+		subnets        []Subnet
+		endpointAccess rosacontrolplanev1.RosaEndpointAccessType
+		want           string
+	}{
+		{
+			name:           "public cluster",
+			subnets:        []Subnet{publicSubnet, privateSubnet},
+			endpointAccess: rosacontrolplanev1.Public,
+		},
+		{
+			name:           "private cluster",
+			subnets:        []Subnet{privateSubnet},
+			endpointAccess: rosacontrolplanev1.Private,
+		},
+		{
+			name:           "public cluster without public subnet",
+			subnets:        []Subnet{privateSubnet},
+			endpointAccess: rosacontrolplanev1.Public,
+			want:           "clusters with public endpoint access require a public subnet with a default route to an internet gateway",
+		},
+		{
+			name:           "private cluster with public subnet",
+			subnets:        []Subnet{publicSubnet, privateSubnet},
+			endpointAccess: rosacontrolplanev1.Private,
+			want:           "clusters with private endpoint access only support private subnets, subnet-public have a route to an internet gateway",
+		},
+		{
+			name: "private subnets without egress in other availability zones and VPCs",
+			subnets: []Subnet{
+				publicSubnet,
+				{ID: "subnet-private", VPCID: "vpc-2", AvailabilityZone: "us-east-1b"},
+			},
+			endpointAccess: rosacontrolplanev1.Public,
+			want: "private subnet subnet-private is in availability zone us-east-1b which is not one of the availabilityZones; " +
+				"private subnet subnet-private has no default route to a NAT gateway or a transit gateway; " +
+				"subnets must belong to a single VPC, found VPCs vpc-1, vpc-2; " +
+				"availability zone us-east-1a has no private subnet",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ValidateSubnets(tc.subnets, rosacontrolplanev1.RosaControlPlaneSpec{
+				AvailabilityZones: []string{"us-east-1a"},
+				EndpointAccess:    tc.endpointAccess,
+			})).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestTagSubnets(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	ec2Mock.EXPECT().CreateTagsWithContext(context.TODO(), &ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{"subnet-private"}),
+		Tags:      []*ec2.Tag{{Key: aws.String(InternalLoadBalancerRoleTag), Value: aws.String("1")}},
+	}).Return(&ec2.CreateTagsOutput{}, nil)
+
+	g.Expect(TagSubnets(context.TODO(), ec2Mock, []Subnet{
+		{ID: "subnet-public", Public: true, Tags: map[string]string{LoadBalancerRoleTag: ""}},
+		{ID: "subnet-private", Tags: map[string]string{LoadBalancerRoleTag: "1"}},
+	})).To(Succeed())
+}
@@ -26,35 +26,67 @@ func CheckExistingScheduledUpgrade(client *ocm.Client, cluster *cmv1.Cluster) (*
 	return nil, nil
 }
 
-// ScheduleControlPlaneUpgrade schedules a new control plane upgrade to the specified version at the specified time.
-func ScheduleControlPlaneUpgrade(client *ocm.Client, cluster *cmv1.Cluster, version string, nextRun time.Time) (*cmv1.ControlPlaneUpgradePolicy, error) {
+// EarliestNextRun returns the earliest time an upgrade can be scheduled at.
+func EarliestNextRun(now time.Time) time.Time {
 	// earliestNextRun is set to at least 5 min from now by the OCM API.
 	// Set our next run request to something slightly longer than 5min to make sure we account for the latency between when we send this
 	// request and when the server processes it.
-	earliestNextRun := time.Now().Add(time.Minute * 6)
-	if nextRun.Before(earliestNextRun) {
+	return now.Add(time.Minute * 6)
+}
+
+// ScheduleControlPlaneUpgrade schedules a new control plane upgrade to the specified version at the specified time.
+func ScheduleControlPlaneUpgrade(client *ocm.Client, cluster *cmv1.Cluster, version string, nextRun time.Time) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	upgradePolicy, err := controlPlaneUpgradePolicy(version, nextRun)
+	if err != nil {
+		return nil, err
+	}
+	return client.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+}
+
+// CancelControlPlaneUpgrade cancels a scheduled control plane upgrade.
+func CancelControlPlaneUpgrade(client *ocm.Client, cluster *cmv1.Cluster, upgradePolicy *cmv1.ControlPlaneUpgradePolicy) error {
+	if _, err := client.CancelControlPlaneUpgrade(cluster.ID(), upgradePolicy.ID()); err != nil {
+		return fmt.Errorf("failed to cancel control plane upgrade to version %s: %w", upgradePolicy.Version(), err)
+	}
+	return nil
+}
+
+// MissingVersionGateAgreements returns the version gates that need to be acknowledged before upgrading the control plane
+// to the specified version.
+func MissingVersionGateAgreements(client *ocm.Client, cluster *cmv1.Cluster, version string) ([]*cmv1.VersionGate, error) {
+	upgradePolicy, err := controlPlaneUpgradePolicy(version, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return client.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+}
+
+// AcknowledgeVersionGates acknowledges the specified version gates of a cluster.
+func AcknowledgeVersionGates(client *ocm.Client, cluster *cmv1.Cluster, versionGates []*cmv1.VersionGate) error {
+	for _, versionGate := range versionGates {
+		if err := client.AckVersionGate(cluster.ID(), versionGate.ID()); err != nil {
+			return fmt.Errorf("failed to acknowledge version gate %s: %w", versionGate.ID(), err)
+		}
+	}
+	return nil
+}
+
+func controlPlaneUpgradePolicy(version string, nextRun time.Time) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	if earliestNextRun := EarliestNextRun(time.Now()); nextRun.Before(earliestNextRun) {
 		nextRun = earliestNextRun
 	}
 
-	upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().
+	return cmv1.NewControlPlaneUpgradePolicy().
 		UpgradeType(cmv1.UpgradeTypeControlPlane).
 		ScheduleType(cmv1.ScheduleTypeManual).
 		Version(version).
 		NextRun(nextRun).
 		Build()
-	if err != nil {
-		return nil, err
-	}
-	return client.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
 }
 
 // ScheduleNodePoolUpgrade schedules a new nodePool upgrade to the specified version at the specified time.
 func ScheduleNodePoolUpgrade(client *ocm.Client, clusterID string, nodePool *cmv1.NodePool, version string, nextRun time.Time) (*cmv1.NodePoolUpgradePolicy, error) {
-	// earliestNextRun is set to at least 5 min from now by the OCM API.
-	// Set our next run request to something slightly longer than 5min to make sure we account for the latency between when we send this
-	// request and when the server processes it.
-	earliestNextRun := time.Now().Add(time.Minute * 6)
-	if nextRun.Before(earliestNextRun) {
+	if earliestNextRun := EarliestNextRun(time.Now()); nextRun.Before(earliestNextRun) {
 		nextRun = earliestNextRun
 	}
 
@@ -0,0 +1,89 @@
+//go:build localstack
+// +build localstack
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+)
+
+func TestReconcileLoadbalancers(t *testing.T) {
+	tests := []struct {
+		name             string
+		loadBalancerType infrav1.LoadBalancerType
+	}{
+		{
+			name:             "classic load balancer",
+			loadBalancerType: infrav1.LoadBalancerTypeClassic,
+		},
+		{
+			name:             "network load balancer",
+			loadBalancerType: infrav1.LoadBalancerTypeNLB,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+
+			clusterScope := newLocalStackClusterScope(t, infrav1.AWSClusterSpec{
+				NetworkSpec: networkSpec(),
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: tc.loadBalancerType,
+					Scheme:           &infrav1.ELBSchemeInternetFacing,
+				},
+			})
+			networkSvc := network.NewService(clusterScope)
+			sgSvc := securitygroup.NewService(clusterScope, securityGroupRoles)
+			elbSvc := elb.NewService(clusterScope)
+
+			// The cleanups run in the reverse order, so that the load balancer is deleted before the
+			// security groups and the network.
+			g.Expect(networkSvc.ReconcileNetwork(ctx)).To(Succeed())
+			t.Cleanup(func() {
+				g.Expect(networkSvc.DeleteNetwork(ctx)).To(Succeed())
+			})
+			g.Expect(sgSvc.ReconcileSecurityGroups()).To(Succeed())
+			t.Cleanup(func() {
+				g.Expect(sgSvc.DeleteSecurityGroups()).To(Succeed())
+			})
+			g.Expect(elbSvc.ReconcileLoadbalancers(ctx)).To(Succeed())
+			t.Cleanup(func() {
+				g.Expect(elbSvc.DeleteLoadbalancers(ctx)).To(Succeed())
+			})
+
+			lb := clusterScope.Network().APIServerELB
+			g.Expect(lb.DNSName).NotTo(BeEmpty())
+			g.Expect(lb.LoadBalancerType).To(Equal(tc.loadBalancerType))
+			g.Expect(lb.SubnetIDs).To(ConsistOf(clusterScope.Subnets().FilterPublic()[0].GetResourceID()))
+
+			// A second reconcile finds the load balancer of the first one.
+			g.Expect(elbSvc.ReconcileLoadbalancers(ctx)).To(Succeed())
+			g.Expect(clusterScope.Network().APIServerELB.DNSName).To(Equal(lb.DNSName))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+//go:build localstack
+// +build localstack
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstack
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
+)
+
+// securityGroupRoles are the roles of the security groups of a cluster without a bastion.
+var securityGroupRoles = []infrav1.SecurityGroupRole{
+	infrav1.SecurityGroupAPIServerLB,
+	infrav1.SecurityGroupLB,
+	infrav1.SecurityGroupControlPlane,
+	infrav1.SecurityGroupNode,
+}
+
+func TestReconcileNetwork(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	clusterScope := newLocalStackClusterScope(t, infrav1.AWSClusterSpec{NetworkSpec: networkSpec()})
+	networkSvc := network.NewService(clusterScope)
+	sgSvc := securitygroup.NewService(clusterScope, securityGroupRoles)
+
+	g.Expect(networkSvc.ReconcileNetwork(ctx)).To(Succeed())
+	t.Cleanup(func() {
+		g.Expect(networkSvc.DeleteNetwork(ctx)).To(Succeed())
+	})
+
+	g.Expect(clusterScope.VPC().ID).To(HavePrefix("vpc-"))
+	g.Expect(clusterScope.VPC().InternetGatewayID).NotTo(BeNil())
+	g.Expect(clusterScope.Subnets()).To(HaveLen(2))
+	for _, subnet := range clusterScope.Subnets() {
+		g.Expect(subnet.GetResourceID()).To(HavePrefix("subnet-"))
+	}
+	g.Expect(clusterScope.Subnets().FilterPublic()[0].NatGatewayID).NotTo(BeNil())
+
+	// A second reconcile finds the resources of the first one.
+	vpcID := clusterScope.VPC().ID
+	g.Expect(networkSvc.ReconcileNetwork(ctx)).To(Succeed())
+	g.Expect(clusterScope.VPC().ID).To(Equal(vpcID))
+
+	g.Expect(sgSvc.ReconcileSecurityGroups()).To(Succeed())
+	t.Cleanup(func() {
+		g.Expect(sgSvc.DeleteSecurityGroups()).To(Succeed())
+	})
+
+	for _, role := range securityGroupRoles {
+		g.Expect(clusterScope.SecurityGroups()).To(HaveKey(role))
+		g.Expect(clusterScope.SecurityGroups()[role].ID).To(HavePrefix("sg-"))
+	}
+}
@@ -0,0 +1,129 @@
+//go:build localstack
+// +build localstack
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localstack contains integration tests of the AWS services run against the endpoints of
+// LocalStack, see hack/localstack-test.sh.
+package localstack
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const region = "us-east-1"
+
+// services are the IDs of the AWS services whose endpoints are overridden with the endpoint of LocalStack.
+var services = []string{"ec2", "elasticloadbalancing", "sts", "ssm", "tagging"}
+
+// serviceEndpoints are the service endpoints of LocalStack, in the format of the --service-endpoints flag of
+// the controllers.
+var serviceEndpoints string
+
+func TestMain(m *testing.M) {
+	endpoint := os.Getenv("LOCALSTACK_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://localhost:4566"
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(endpoint + "/_localstack/health")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "LocalStack is not reachable at %s, run the tests with make test-localstack: %v\n", endpoint, err)
+		os.Exit(1)
+	}
+	resp.Body.Close()
+
+	pairs := make([]string, 0, len(services))
+	for _, service := range services {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", service, endpoint))
+	}
+	serviceEndpoints = fmt.Sprintf("%s:%s", region, strings.Join(pairs, ","))
+
+	os.Exit(m.Run())
+}
+
+// newLocalStackClusterScope returns the scope of a cluster with a unique name and the given spec, whose AWS clients use the
+// endpoints of LocalStack.
+func newLocalStackClusterScope(t *testing.T, spec infrav1.AWSClusterSpec) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	awsEndpoints, err := endpoints.ParseFlag(serviceEndpoints)
+	if err != nil {
+		t.Fatalf("failed to parse the service endpoints %q: %v", serviceEndpoints, err)
+	}
+
+	name := "localstack-" + rand.String(5)
+	spec.Region = region
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       spec,
+		},
+		Endpoints: awsEndpoints,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the cluster scope: %v", err)
+	}
+	return clusterScope
+}
+
+// networkSpec returns the spec of a managed network with a public and a private subnet.
+func networkSpec() infrav1.NetworkSpec {
+	return infrav1.NetworkSpec{
+		VPC: infrav1.VPCSpec{
+			CidrBlock: "10.0.0.0/16",
+		},
+		Subnets: infrav1.Subnets{
+			{
+				ID:               "localstack-public",
+				CidrBlock:        "10.0.0.0/24",
+				AvailabilityZone: region + "a",
+				IsPublic:         true,
+			},
+			{
+				ID:               "localstack-private",
+				CidrBlock:        "10.0.1.0/24",
+				AvailabilityZone: region + "a",
+			},
+		},
+	}
+}
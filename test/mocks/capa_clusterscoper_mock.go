@@ -291,6 +291,20 @@ func (mr *MockClusterScoperMockRecorder) Namespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Namespace", reflect.TypeOf((*MockClusterScoper)(nil).Namespace))
 }
 
+// Partition mocks base method.
+func (m *MockClusterScoper) Partition() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Partition")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Partition indicates an expected call of Partition.
+func (mr *MockClusterScoperMockRecorder) Partition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Partition", reflect.TypeOf((*MockClusterScoper)(nil).Partition))
+}
+
 // PatchObject mocks base method.
 func (m *MockClusterScoper) PatchObject() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/planner"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// DeletionStep is a procedure of a planner.Graph deleting resources whose state is reported in a condition of an
+// object. As the steps of a graph are done concurrently, the condition isn't updated by the step: it is marked with
+// MarkDeletingSteps before the graph is executed, and with MarkDeletionSteps from the results of the graph.
+type DeletionStep struct {
+	// StepName is the name of the procedure.
+	StepName string
+	// Object is the object whose condition reports the state of the resources.
+	Object conditions.Setter
+	// Condition, if set, is the condition reporting the state of the resources.
+	Condition clusterv1.ConditionType
+	// Delete deletes the resources.
+	Delete func(ctx context.Context) error
+}
+
+// Name returns the name of the step.
+func (s *DeletionStep) Name() string {
+	return s.StepName
+}
+
+// Do deletes the resources of the step.
+func (s *DeletionStep) Do(ctx context.Context) error {
+	return s.Delete(ctx)
+}
+
+// MarkDeletingSteps marks the conditions of the deletion steps of a graph with the Deleting reason, before the
+// graph is executed.
+func MarkDeletingSteps(nodes []planner.Node) {
+	for _, node := range nodes {
+		step, ok := node.Procedure.(*DeletionStep)
+		if !ok || step.Condition == "" {
+			continue
+		}
+		conditions.MarkFalse(step.Object, step.Condition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	}
+}
+
+// MarkDeletionSteps marks the conditions of the deletion steps of an executed graph from their results: with the
+// Deleted reason once their resources were deleted, the DeletingFailed reason when they couldn't be, and the
+// DeletingBlocked reason when the steps they depend on failed.
+func MarkDeletionSteps(nodes []planner.Node, results []planner.NodeResult) {
+	for i, result := range results {
+		step, ok := nodes[i].Procedure.(*DeletionStep)
+		if !ok || step.Condition == "" {
+			continue
+		}
+		switch result.State {
+		case planner.NodeDone:
+			conditions.MarkFalse(step.Object, step.Condition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		case planner.NodeFailed:
+			conditions.MarkFalse(step.Object, step.Condition, infrav1.DeletingFailedReason, clusterv1.ConditionSeverityWarning, result.Err.Error())
+		case planner.NodeBlocked:
+			conditions.MarkFalse(step.Object, step.Condition, infrav1.DeletingBlockedReason, clusterv1.ConditionSeverityInfo,
+				"waiting for the deletion of %s", strings.Join(result.BlockedBy, ", "))
+		}
+	}
+}
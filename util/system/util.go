@@ -71,18 +71,13 @@ func GetNamespaceFromFile(nsFilePath string) (string, error) {
 	return string(namespace), nil
 }
 
-// GetPartitionFromRegion returns the cluster partition.
+// GetPartitionFromRegion returns the partition of a region, e.g. aws-us-gov for us-gov-west-1
+// or aws-cn for cn-north-1, matching the region against the regions and region patterns of the
+// partitions known by the SDK. It defaults to aws when the region is unknown.
 func GetPartitionFromRegion(region string) string {
-	switch region {
-	case endpoints.UsGovEast1RegionID, endpoints.UsGovWest1RegionID:
-		return endpoints.AwsUsGovPartitionID
-	case endpoints.CnNorth1RegionID, endpoints.CnNorthwest1RegionID:
-		return endpoints.AwsCnPartitionID
-	case endpoints.UsIsoEast1RegionID, endpoints.UsIsoWest1RegionID:
-		return endpoints.AwsIsoPartitionID
-	case endpoints.UsIsobEast1RegionID:
-		return endpoints.AwsIsoBPartitionID
-	default:
-		return endpoints.AwsPartitionID
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return partition.ID()
 	}
+
+	return endpoints.AwsPartitionID
 }
@@ -68,3 +68,30 @@ func TestGetNamespaceFromFile(t *testing.T) {
 	g.Expect(GetNamespaceFromFile(nsPath)).To(Equal("different-ns"))
 	g.Expect(os.Remove(nsPath)).NotTo(HaveOccurred())
 }
+
+func TestGetPartitionFromRegion(t *testing.T) {
+	cases := []struct {
+		region   string
+		expected string
+	}{
+		{region: "us-east-1", expected: "aws"},
+		{region: "eu-west-3", expected: "aws"},
+		{region: "us-gov-west-1", expected: "aws-us-gov"},
+		{region: "cn-north-1", expected: "aws-cn"},
+		{region: "cn-northwest-1", expected: "aws-cn"},
+		{region: "us-iso-east-1", expected: "aws-iso"},
+		{region: "us-isob-east-1", expected: "aws-iso-b"},
+		{region: "us-isof-south-1", expected: "aws-iso-f"},
+		{region: "eu-isoe-west-1", expected: "aws-iso-e"},
+		{region: "", expected: "aws"},
+		{region: "unknown", expected: "aws"},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.region, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(GetPartitionFromRegion(c.region)).To(Equal(c.expected))
+		})
+	}
+}